@@ -0,0 +1,312 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Milestones creatures can reach
+const (
+	MilestoneFirstFlight    = "first_flight"
+	MilestoneFirstSymbiosis = "first_symbiosis"
+	MilestoneAgriculture    = "agriculture"
+	MilestoneMeteorSurvivor = "meteor_survivor"
+
+	maxPendingAchievementEvents = 100
+	flightThreshold             = 0.5 // Flying ability the aerial biomes reward
+)
+
+// Milestone is an emergent feat a world, and each player, can earn once
+type Milestone struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Milestones are the feats achievements are awarded for, each detected from what really
+// happens in the world rather than scripted
+var Milestones = []Milestone{
+	{ID: MilestoneFirstFlight, Name: "Taking Wing", Description: "A creature born able to fly, bred from flightless ancestors"},
+	{ID: MilestoneFirstSymbiosis, Name: "Better Together", Description: "Two creatures form a partnership that helps both"},
+	{ID: MilestoneAgriculture, Name: "Green Thumb", Description: "A tribe builds its first farm"},
+	{ID: MilestoneMeteorSurvivor, Name: "Impact Survivor", Description: "A species lives through a meteor shower"},
+}
+
+// milestoneByID returns a milestone by ID
+func milestoneByID(id string) (Milestone, bool) {
+	for _, milestone := range Milestones {
+		if milestone.ID == id {
+			return milestone, true
+		}
+	}
+	return Milestone{}, false
+}
+
+// Achievement is a milestone earned by a species, for the world or for the player who owned it
+type Achievement struct {
+	Milestone string `json:"milestone"`
+	Name      string `json:"name"`
+	Tick      int    `json:"tick"`
+	Species   string `json:"species"`          // Species that reached the milestone
+	Player    string `json:"player,omitempty"` // Player who owned the species at the time
+	Detail    string `json:"detail"`           // What happened
+}
+
+// AchievementState is the achievements a save keeps
+type AchievementState struct {
+	World   []Achievement            `json:"world"`
+	Players map[string][]Achievement `json:"players,omitempty"`
+}
+
+// AchievementSystem awards achievements as creatures reach milestones: the first flight bred
+// in, the first mutualistic symbiosis, a tribe's first farm, a species that outlives a meteor
+// shower. The world earns each milestone once, from whichever species gets there first, and
+// each player earns it once more the first time a species they own gets there. It listens to
+// the central event bus and awards at the end of the tick.
+type AchievementSystem struct {
+	mu              sync.Mutex
+	world           []Achievement
+	players         map[string][]Achievement
+	meteorWitnesses [][]string // Species alive when each falling meteor shower began
+	eventBus        *CentralEventBus
+
+	// OwnerOf returns the player who owns a species; nil when the world has no players
+	OwnerOf func(species string) (string, bool)
+
+	// Events raised since the last update, kept apart so listeners never wait on an award
+	pendingMu sync.Mutex
+	pending   []CentralEvent
+}
+
+// NewAchievementSystem creates an achievement system listening to a world's event bus
+func NewAchievementSystem(eventBus *CentralEventBus) *AchievementSystem {
+	a := &AchievementSystem{players: make(map[string][]Achievement), eventBus: eventBus}
+	if eventBus != nil {
+		eventBus.AddListener(a.observe)
+	}
+	return a
+}
+
+// observe keeps the events a milestone could follow from
+func (a *AchievementSystem) observe(event CentralEvent) {
+	switch event.Type {
+	case "symbiosis_formed", "structure_built", "disaster", "disaster_ended":
+	default:
+		return
+	}
+	a.pendingMu.Lock()
+	defer a.pendingMu.Unlock()
+	if len(a.pending) < maxPendingAchievementEvents {
+		a.pending = append(a.pending, event)
+	}
+}
+
+// Restart forgets the old world's achievements, for a world that starts over. Players keep
+// theirs.
+func (a *AchievementSystem) Restart() {
+	a.pendingMu.Lock()
+	a.pending = nil
+	a.pendingMu.Unlock()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.world = nil
+	a.meteorWitnesses = nil
+}
+
+// Update awards the milestones the tick's events and creatures reached
+func (a *AchievementSystem) Update(w *World) {
+	a.pendingMu.Lock()
+	events := a.pending
+	a.pending = nil
+	a.pendingMu.Unlock()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, event := range events {
+		a.follow(w, event)
+	}
+
+	// Flight can arrive by birth or by mutation, so look at the creatures themselves
+	flown := make(map[string]bool)
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive || entity.Generation == 0 || flown[entity.Species] ||
+			entity.GetTrait("flying_ability") <= flightThreshold {
+			continue
+		}
+		flown[entity.Species] = true
+		a.award(w, MilestoneFirstFlight, entity.Species,
+			fmt.Sprintf("Creature %d of generation %d took to the air", entity.ID, entity.Generation))
+	}
+}
+
+// follow awards the milestone an event completes, if any
+func (a *AchievementSystem) follow(w *World, event CentralEvent) {
+	metadata := func(key string) string {
+		value, _ := event.Metadata[key].(string)
+		return value
+	}
+
+	switch event.Type {
+	case "symbiosis_formed":
+		if metadata("relationship_type") != "mutualistic" {
+			return
+		}
+		for _, species := range []string{metadata("host_species"), metadata("symbiont_species")} {
+			if species != "" {
+				a.award(w, MilestoneFirstSymbiosis, species, event.Description)
+			}
+		}
+
+	case "structure_built":
+		if metadata("structure_type") == "farm" && metadata("builder_species") != "" {
+			a.award(w, MilestoneAgriculture, metadata("builder_species"), event.Description)
+		}
+
+	case "disaster":
+		if metadata("name") == "Meteor Shower" {
+			a.meteorWitnesses = append(a.meteorWitnesses, livingSpecies(w))
+		}
+
+	case "disaster_ended":
+		if metadata("name") != "Meteor Shower" || len(a.meteorWitnesses) == 0 {
+			return
+		}
+		witnesses := a.meteorWitnesses[0]
+		a.meteorWitnesses = a.meteorWitnesses[1:]
+		survivors := make(map[string]bool)
+		for _, species := range livingSpecies(w) {
+			survivors[species] = true
+		}
+		for _, species := range witnesses {
+			if survivors[species] {
+				a.award(w, MilestoneMeteorSurvivor, species,
+					fmt.Sprintf("%s lived through the meteor shower that ended at tick %d", species, w.Tick))
+			}
+		}
+	}
+}
+
+// livingSpecies lists the species with a creature alive, sorted
+func livingSpecies(w *World) []string {
+	seen := make(map[string]bool)
+	var species []string
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive && !seen[entity.Species] {
+			seen[entity.Species] = true
+			species = append(species, entity.Species)
+		}
+	}
+	sort.Strings(species)
+	return species
+}
+
+// award gives a milestone to the world if it has not reached it yet, and to the species' owner
+// if they have not. Each new achievement is announced on the event bus and to the owner.
+func (a *AchievementSystem) award(w *World, milestoneID, species, detail string) {
+	milestone, _ := milestoneByID(milestoneID)
+	achievement := Achievement{
+		Milestone: milestone.ID,
+		Name:      milestone.Name,
+		Tick:      w.Tick,
+		Species:   species,
+		Detail:    detail,
+	}
+	player, owned := "", false
+	if a.OwnerOf != nil {
+		player, owned = a.OwnerOf(species)
+	}
+	if owned {
+		achievement.Player = player
+	}
+
+	if !hasAchievement(a.world, milestoneID) {
+		a.world = append(a.world, achievement)
+		if a.eventBus != nil {
+			a.eventBus.EmitSystemEvent(w.Tick, "achievement_unlocked", "achievement", "achievement_system",
+				fmt.Sprintf("Achievement unlocked: %s (%s)", milestone.Name, species), nil,
+				map[string]interface{}{"milestone": milestone.ID, "name": milestone.Name, "species": species, "player": player})
+		}
+	}
+
+	if owned && !hasAchievement(a.players[player], milestoneID) {
+		a.players[player] = append(a.players[player], achievement)
+		if w.PlayerEventsCallback != nil {
+			w.PlayerEventsCallback("achievement_unlocked", map[string]interface{}{
+				"species_name": species,
+				"milestone":    milestone.ID,
+				"name":         milestone.Name,
+				"detail":       detail,
+				"tick":         w.Tick,
+			})
+		}
+	}
+}
+
+// hasAchievement reports whether a list holds a milestone
+func hasAchievement(achievements []Achievement, milestoneID string) bool {
+	for _, achievement := range achievements {
+		if achievement.Milestone == milestoneID {
+			return true
+		}
+	}
+	return false
+}
+
+// AchievementsStatus reports the milestones and who has earned them
+type AchievementsStatus struct {
+	Milestones []Milestone              `json:"milestones"`
+	World      []Achievement            `json:"world"`   // The world's achievements, in the order earned
+	Players    map[string][]Achievement `json:"players"` // Each player's achievements, by player ID
+}
+
+// Status reports the world's achievements and every player's, or one player's when player is
+// not empty
+func (a *AchievementSystem) Status(player string) AchievementsStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	status := AchievementsStatus{
+		Milestones: Milestones,
+		World:      append([]Achievement{}, a.world...),
+		Players:    make(map[string][]Achievement),
+	}
+	for id, achievements := range a.players {
+		if player == "" || id == player {
+			status.Players[id] = append([]Achievement{}, achievements...)
+		}
+	}
+	if player != "" && status.Players[player] == nil {
+		status.Players[player] = []Achievement{}
+	}
+	return status
+}
+
+// State returns the achievements for a save
+func (a *AchievementSystem) State() *AchievementState {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.world) == 0 && len(a.players) == 0 {
+		return nil
+	}
+	state := &AchievementState{World: append([]Achievement{}, a.world...), Players: make(map[string][]Achievement)}
+	for player, achievements := range a.players {
+		state.Players[player] = append([]Achievement{}, achievements...)
+	}
+	return state
+}
+
+// Restore brings back the achievements of a save
+func (a *AchievementSystem) Restore(state *AchievementState) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.world = nil
+	a.meteorWitnesses = nil
+	if state == nil {
+		return
+	}
+	a.world = append(a.world, state.World...)
+	for player, achievements := range state.Players {
+		a.players[player] = append([]Achievement{}, achievements...)
+	}
+}
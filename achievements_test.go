@@ -0,0 +1,135 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+// earned returns the milestones in a list of achievements, in order
+func earned(achievements []Achievement) []string {
+	var milestones []string
+	for _, achievement := range achievements {
+		milestones = append(milestones, achievement.Milestone)
+	}
+	return milestones
+}
+
+func TestAchievementsFollowWhatHappens(t *testing.T) {
+	world := newSteppingTestWorld(81)
+	species := livingSpecies(world)
+	if len(species) < 2 {
+		t.Fatalf("Expected at least two species, got %v", species)
+	}
+	first, second := world.AllEntities[0], world.AllEntities[len(world.AllEntities)-1]
+	owners := map[string]string{first.Species: "alice"}
+	world.Achievements.OwnerOf = func(species string) (string, bool) {
+		player, owned := owners[species]
+		return player, owned
+	}
+	var notices []map[string]interface{}
+	world.PlayerEventsCallback = func(eventType string, data map[string]interface{}) {
+		if eventType == "achievement_unlocked" {
+			notices = append(notices, data)
+		}
+	}
+
+	// A parasite is no partnership, and creatures made able to fly did not evolve it
+	world.SymbioticRelationships.createRelationship(first, second, RelationshipParasitic, 0.8, world.Tick)
+	first.SetTrait("flying_ability", 0.9)
+	world.Achievements.Update(world)
+	if status := world.Achievements.Status(""); len(status.World) != 0 {
+		t.Fatalf("Expected nothing earned yet, got %+v", status.World)
+	}
+
+	world.SymbioticRelationships.createRelationship(first, second, RelationshipMutualistic, 0.8, world.Tick)
+	world.Tick = 5
+	first.Generation = 1
+	world.Achievements.Update(world)
+
+	tribe := NewTribe(1, "Growers", second)
+	tribe.TechLevel = 3
+	tribe.BuildStructure(StructureFarm, second.Position, second, 1, world.CentralEventBus, world.Tick)
+	tribe.BuildStructure(StructureFarm, second.Position, second, 2, world.CentralEventBus, world.Tick)
+	world.Achievements.Update(world)
+
+	// The species that lose their last creature under the meteors do not survive it
+	for _, event := range world.worldEventCatalogue() {
+		if event.Name == "Meteor Shower" {
+			event.Duration = 2
+			world.startWorldEvent(event)
+		}
+	}
+	world.Achievements.Update(world)
+	for _, entity := range world.AllEntities {
+		if entity.Species != first.Species {
+			entity.IsAlive = false
+		}
+	}
+	world.updateEvents()
+	world.Achievements.Update(world)
+	world.updateEvents()
+	world.Achievements.Update(world)
+
+	status := world.Achievements.Status("")
+	want := []string{MilestoneFirstSymbiosis, MilestoneFirstFlight, MilestoneAgriculture, MilestoneMeteorSurvivor}
+	if got := earned(status.World); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] || got[3] != want[3] {
+		t.Fatalf("Expected the world to earn each milestone once, in order, got %v", got)
+	}
+	if survivor := status.World[3]; survivor.Species != first.Species || survivor.Player != "alice" {
+		t.Errorf("Expected only the species still alive to survive the meteors, got %+v", survivor)
+	}
+	if farm := status.World[2]; farm.Species != second.Species || farm.Player != "" || farm.Tick != 5 {
+		t.Errorf("Expected the farm credited to its builder's species, got %+v", farm)
+	}
+
+	// Alice's species shared the partnership, flew and survived; it built no farm
+	if got := earned(status.Players["alice"]); len(got) != 3 || len(notices) != 3 || notices[0]["species_name"] != first.Species {
+		t.Errorf("Expected alice notified of her species' three milestones, got %v and %v", got, notices)
+	}
+
+	// The world's achievements travel with the save and start over with the world
+	state, err := NewStateManager(world).CurrentState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	world.Reset()
+	if status := world.Achievements.Status(""); len(status.World) != 0 || len(status.Players["alice"]) != 3 {
+		t.Errorf("Expected a reset to clear the world's achievements but not alice's, got %+v", status)
+	}
+	if err := NewStateManager(world).restoreState(state); err != nil {
+		t.Fatal(err)
+	}
+	if restored := world.Achievements.Status(""); len(restored.World) != 4 {
+		t.Errorf("Expected the saved achievements back, got %+v", restored.World)
+	}
+}
+
+func TestAchievementsAPI(t *testing.T) {
+	wi := NewWebInterface(newSteppingTestWorld(82))
+	entity := wi.world.AllEntities[0]
+	if _, err := wi.playerManager.AddPlayer("p1", "Player One"); err != nil {
+		t.Fatal(err)
+	}
+	if err := wi.playerManager.AddPlayerSpecies("p1", entity.Species); err != nil {
+		t.Fatal(err)
+	}
+	entity.Generation = 2
+	entity.SetTrait("flying_ability", 1)
+	wi.world.Achievements.Update(wi.world)
+
+	var status AchievementsStatus
+	if code := callControlAPI(t, wi.handleAchievements, http.MethodGet, "/api/achievements", "", &status); code != http.StatusOK {
+		t.Fatalf("Expected the achievements, got %d", code)
+	}
+	if len(status.Milestones) != len(Milestones) || len(status.World) != 1 || status.World[0].Player != "p1" {
+		t.Errorf("Expected the first flight credited to its owner, got %+v", status)
+	}
+	var other AchievementsStatus
+	callControlAPI(t, wi.handleAchievements, http.MethodGet, "/api/achievements?player=p2", "", &other)
+	if achievements, listed := other.Players["p2"]; !listed || len(achievements) != 0 || len(other.Players) != 1 {
+		t.Errorf("Expected only the asked-for player, with nothing earned, got %+v", other.Players)
+	}
+	if code := callControlAPI(t, wi.handleAchievements, http.MethodPost, "/api/achievements", "{}", nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected achievements read only, got %d", code)
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Adaptive radiation detection parameters
+const (
+	radiationWindow       = 500 // Ticks within which the daughter species must appear
+	radiationMinDaughters = 3   // Daughter species needed to call it a radiation
+	radiationMinNiches    = 2   // Distinct niche profiles the daughters must spread into
+)
+
+// RadiationEpisode is a rapid multiplication of species from one ancestor into distinct niches
+type RadiationEpisode struct {
+	Tick      int                `json:"tick"`       // When the radiation was last confirmed
+	StartTick int                `json:"start_tick"` // When its first daughter species appeared
+	Ancestor  string             `json:"ancestor"`
+	Daughters []string           `json:"daughters"`
+	Niches    []string           `json:"niches"`    // Niches the daughters occupy that the ancestor did not
+	Before    map[string]int     `json:"before"`    // Living species per niche just before the radiation began
+	After     map[string]int     `json:"after"`     // Living species per niche once it was confirmed
+	Disparity map[string]float64 `json:"disparity"` // Spread of each trait across the daughters
+}
+
+// nicheProfile names the combination of niches a lineage occupies
+func nicheProfile(lineage *SpeciesLineage) string {
+	niches := append([]string(nil), lineage.Niches...)
+	sort.Strings(niches)
+	return strings.Join(niches, "+")
+}
+
+// nicheOccupancy counts the species living at a tick in each niche
+func (mes *MacroEvolutionSystem) nicheOccupancy(tick int) map[string]int {
+	occupancy := make(map[string]int)
+	for _, lineage := range mes.SpeciesLineages {
+		if lineage.OriginTick > tick || (lineage.ExtinctionTick != 0 && lineage.ExtinctionTick <= tick) {
+			continue
+		}
+		for _, niche := range lineage.Niches {
+			occupancy[niche]++
+		}
+	}
+	return occupancy
+}
+
+// detectAdaptiveRadiation checks whether an ancestor's recent daughter species amount to an
+// adaptive radiation, logging a major event the first time and widening the episode as more
+// daughters join it
+func (mes *MacroEvolutionSystem) detectAdaptiveRadiation(ancestor string, world *World) {
+	parent := mes.SpeciesLineages[ancestor]
+	if parent == nil {
+		return
+	}
+
+	daughters := make([]*SpeciesLineage, 0)
+	for _, name := range parent.ChildSpecies {
+		if child := mes.SpeciesLineages[name]; child != nil && world.Tick-child.OriginTick <= radiationWindow {
+			daughters = append(daughters, child)
+		}
+	}
+	if len(daughters) < radiationMinDaughters {
+		return
+	}
+
+	profiles := make(map[string]bool)
+	occupied := make(map[string]bool)
+	for _, niche := range parent.Niches {
+		occupied[niche] = true
+	}
+	newNiches := make(map[string]bool)
+	start := world.Tick
+	for _, child := range daughters {
+		profiles[nicheProfile(child)] = true
+		for _, niche := range child.Niches {
+			if !occupied[niche] {
+				newNiches[niche] = true
+			}
+		}
+		if child.OriginTick < start {
+			start = child.OriginTick
+		}
+	}
+	if len(profiles) < radiationMinNiches {
+		return // Many daughters, but all doing the same thing
+	}
+
+	episode := RadiationEpisode{
+		Tick:      world.Tick,
+		StartTick: start,
+		Ancestor:  ancestor,
+		Daughters: make([]string, 0, len(daughters)),
+		Niches:    sortedKeys(newNiches),
+		Before:    mes.nicheOccupancy(start - 1),
+		After:     mes.nicheOccupancy(world.Tick),
+		Disparity: make(map[string]float64),
+	}
+	for _, child := range daughters {
+		episode.Daughters = append(episode.Daughters, child.SpeciesName)
+	}
+	sort.Strings(episode.Daughters)
+	for _, trait := range sortedKeys(daughters[0].DominantTraits) {
+		low, high := math.Inf(1), math.Inf(-1)
+		for _, child := range daughters {
+			value, ok := child.DominantTraits[trait]
+			if !ok {
+				low = math.Inf(1)
+				break
+			}
+			low, high = math.Min(low, value), math.Max(high, value)
+		}
+		if !math.IsInf(low, 1) {
+			episode.Disparity[trait] = high - low
+		}
+	}
+
+	// A radiation still underway grows rather than being logged again
+	for i := len(mes.Radiations) - 1; i >= 0; i-- {
+		if mes.Radiations[i].Ancestor == ancestor && world.Tick-mes.Radiations[i].Tick <= radiationWindow {
+			episode.StartTick = mes.Radiations[i].StartTick
+			episode.Before = mes.Radiations[i].Before
+			mes.Radiations[i] = episode
+			return
+		}
+	}
+	mes.Radiations = append(mes.Radiations, episode)
+
+	mes.Events = append(mes.Events, EvolutionaryEvent{
+		Tick:         world.Tick,
+		Type:         "adaptive_radiation",
+		Description:  fmt.Sprintf("'%s' radiated into %d species across %d niche profiles within %d ticks", ancestor, len(daughters), len(profiles), world.Tick-start),
+		Species:      ancestor,
+		AffectedIDs:  make([]int, 0),
+		TraitChanges: episode.Disparity,
+		Significance: math.Min(1.0, 0.5+0.1*float64(len(daughters))),
+		Environment:  mes.captureEnvironmentalState(world),
+	})
+}
+
+// GetRecentRadiations returns the most recent adaptive radiation episodes, newest first
+func (mes *MacroEvolutionSystem) GetRecentRadiations(count int) []RadiationEpisode {
+	recent := make([]RadiationEpisode, 0, count)
+	for i := len(mes.Radiations) - 1; i >= 0 && len(recent) < count; i-- {
+		recent = append(recent, mes.Radiations[i])
+	}
+	return recent
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// addDaughterSpecies records a species that split from a parent, as speciation detection does
+func addDaughterSpecies(world *World, parent, name string, tick int, speed float64, niches ...string) {
+	mes := world.MacroEvolutionSystem
+	world.Tick = tick
+	mes.SpeciesLineages[name] = &SpeciesLineage{SpeciesName: name, ParentSpecies: parent, OriginTick: tick,
+		DominantTraits: map[string]float64{"speed": speed}, Niches: niches}
+	mes.SpeciesLineages[parent].ChildSpecies = append(mes.SpeciesLineages[parent].ChildSpecies, name)
+	mes.detectAdaptiveRadiation(parent, world)
+}
+
+func newRadiationTestWorld() *World {
+	world := newSteppingTestWorld(95)
+	mes := NewMacroEvolutionSystem()
+	mes.SpeciesLineages["Ancestor"] = &SpeciesLineage{SpeciesName: "Ancestor", Niches: []string{"generalist"}}
+	mes.SpeciesLineages["Rival"] = &SpeciesLineage{SpeciesName: "Rival", Niches: []string{"predator"}}
+	world.MacroEvolutionSystem = mes
+	return world
+}
+
+func TestAdaptiveRadiationDetected(t *testing.T) {
+	world := newRadiationTestWorld()
+	mes := world.MacroEvolutionSystem
+
+	addDaughterSpecies(world, "Ancestor", "Hunter", 400, 0.8, "predator")
+	addDaughterSpecies(world, "Ancestor", "Swimmer", 450, 0.1, "aquatic")
+	if len(mes.Radiations) != 0 {
+		t.Fatalf("Expected no radiation from two daughters, got %+v", mes.Radiations)
+	}
+	addDaughterSpecies(world, "Ancestor", "Diver", 500, 0.3, "aquatic", "small")
+	if len(mes.Radiations) != 1 {
+		t.Fatalf("Expected a radiation once three daughters spread into new niches, got %d", len(mes.Radiations))
+	}
+
+	radiation := mes.Radiations[0]
+	if radiation.StartTick != 400 || !reflect.DeepEqual(radiation.Daughters, []string{"Diver", "Hunter", "Swimmer"}) {
+		t.Errorf("Expected the radiation to start at tick 400 with three daughters, got %+v", radiation)
+	}
+	if !reflect.DeepEqual(radiation.Niches, []string{"aquatic", "predator", "small"}) {
+		t.Errorf("Expected the niches new to the ancestor, got %v", radiation.Niches)
+	}
+	if !reflect.DeepEqual(radiation.Before, map[string]int{"generalist": 1, "predator": 1}) {
+		t.Errorf("Expected only the ancestor and its rival before, got %v", radiation.Before)
+	}
+	if radiation.After["aquatic"] != 2 || radiation.After["predator"] != 2 || radiation.After["small"] != 1 {
+		t.Errorf("Expected the daughters to fill the niches after, got %v", radiation.After)
+	}
+	if diff := radiation.Disparity["speed"] - 0.7; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("Expected a speed disparity of 0.7, got %.3f", radiation.Disparity["speed"])
+	}
+
+	events := 0
+	for _, event := range mes.Events {
+		if event.Type == "adaptive_radiation" && event.Species == "Ancestor" {
+			events++
+		}
+	}
+	if events != 1 {
+		t.Fatalf("Expected one radiation event, got %d", events)
+	}
+
+	// Another daughter widens the same episode rather than logging a new one
+	addDaughterSpecies(world, "Ancestor", "Climber", 550, 0.5, "mountain-dweller")
+	if len(mes.Radiations) != 1 || len(mes.Radiations[0].Daughters) != 4 || mes.Radiations[0].StartTick != 400 {
+		t.Errorf("Expected the episode to grow to four daughters, got %+v", mes.Radiations)
+	}
+	if len(mes.Events) != 1 {
+		t.Errorf("Expected the growing episode not to be logged again, got %d events", len(mes.Events))
+	}
+
+	mes.CurrentTick = 5000
+	mes.pruneEvents()
+	if len(mes.Events) != 1 {
+		t.Errorf("Expected radiation events to survive pruning")
+	}
+}
+
+func TestAdaptiveRadiationNeedsDistinctNichesQuickly(t *testing.T) {
+	world := newRadiationTestWorld()
+	mes := world.MacroEvolutionSystem
+
+	// Three daughters doing the same thing are not a radiation
+	for i, name := range []string{"A", "B", "C"} {
+		addDaughterSpecies(world, "Ancestor", name, 100+i*10, 0.5, "generalist")
+	}
+	if len(mes.Radiations) != 0 {
+		t.Errorf("Expected no radiation without niche divergence, got %+v", mes.Radiations)
+	}
+
+	// Nor are daughters spread over too long
+	addDaughterSpecies(world, "Rival", "Slow1", 1000, 0.1, "aquatic")
+	addDaughterSpecies(world, "Rival", "Slow2", 1000+radiationWindow, 0.2, "small")
+	addDaughterSpecies(world, "Rival", "Slow3", 1000+2*radiationWindow, 0.3, "large")
+	if len(mes.Radiations) != 0 {
+		t.Errorf("Expected no radiation from daughters spread over %d ticks, got %+v", 2*radiationWindow, mes.Radiations)
+	}
+}
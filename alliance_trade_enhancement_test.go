@@ -7,11 +7,11 @@ import (
 // TestResourceManagementInCasteColony tests the enhanced resource management in colonies
 func TestResourceManagementInCasteColony(t *testing.T) {
 	// Create test entities
-	queen := NewEntity(1, []string{"intelligence", "cooperation", "leadership"}, "testspecies", Position{X: 0, Y: 0})
+	queen := NewEntity(sharedRand, 1, []string{"intelligence", "cooperation", "leadership"}, "testspecies", Position{X: 0, Y: 0})
 	queen.IsAlive = true
 
 	// Create colony
-	colony := NewCasteColony(1, queen, Position{X: 0, Y: 0})
+	colony := NewCasteColony(sharedRand, 1, queen, Position{X: 0, Y: 0})
 
 	// Test initial resources
 	if len(colony.Resources) == 0 {
@@ -52,10 +52,10 @@ func TestTradeAgreementExecution(t *testing.T) {
 	system := NewColonyWarfareSystem()
 
 	// Create test colonies
-	queen1 := NewEntity(1, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 0, Y: 0})
-	queen2 := NewEntity(2, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 10, Y: 10})
-	colony1 := NewCasteColony(1, queen1, Position{X: 0, Y: 0})
-	colony2 := NewCasteColony(2, queen2, Position{X: 10, Y: 10})
+	queen1 := NewEntity(sharedRand, 1, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 0, Y: 0})
+	queen2 := NewEntity(sharedRand, 2, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 10, Y: 10})
+	colony1 := NewCasteColony(sharedRand, 1, queen1, Position{X: 0, Y: 0})
+	colony2 := NewCasteColony(sharedRand, 2, queen2, Position{X: 10, Y: 10})
 
 	// Register colonies
 	system.RegisterColony(colony1)
@@ -114,10 +114,10 @@ func TestAllianceFormationAndBenefits(t *testing.T) {
 	system := NewColonyWarfareSystem()
 
 	// Create test colonies
-	queen1 := NewEntity(1, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 0, Y: 0})
-	queen2 := NewEntity(2, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 10, Y: 10})
-	colony1 := NewCasteColony(1, queen1, Position{X: 0, Y: 0})
-	colony2 := NewCasteColony(2, queen2, Position{X: 10, Y: 10})
+	queen1 := NewEntity(sharedRand, 1, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 0, Y: 0})
+	queen2 := NewEntity(sharedRand, 2, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 10, Y: 10})
+	colony1 := NewCasteColony(sharedRand, 1, queen1, Position{X: 0, Y: 0})
+	colony2 := NewCasteColony(sharedRand, 2, queen2, Position{X: 10, Y: 10})
 
 	// Register colonies
 	system.RegisterColony(colony1)
@@ -180,10 +180,10 @@ func TestAutomaticTradeAndAllianceFormation(t *testing.T) {
 	system := NewColonyWarfareSystem()
 
 	// Create test colonies with complementary resources
-	queen1 := NewEntity(1, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 0, Y: 0})
-	queen2 := NewEntity(2, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 20, Y: 20})
-	colony1 := NewCasteColony(1, queen1, Position{X: 0, Y: 0})
-	colony2 := NewCasteColony(2, queen2, Position{X: 20, Y: 20})
+	queen1 := NewEntity(sharedRand, 1, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 0, Y: 0})
+	queen2 := NewEntity(sharedRand, 2, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 20, Y: 20})
+	colony1 := NewCasteColony(sharedRand, 1, queen1, Position{X: 0, Y: 0})
+	colony2 := NewCasteColony(sharedRand, 2, queen2, Position{X: 20, Y: 20})
 
 	// Set up resources to create trade incentives
 	colony1.Resources["food"] = 200.0      // Surplus food
@@ -220,8 +220,8 @@ func TestAutomaticTradeAndAllianceFormation(t *testing.T) {
 	system.ColonyDiplomacies[colony2.ID].TrustLevels[colony1.ID] = 0.8
 
 	// Add a common enemy to encourage alliance
-	queen3 := NewEntity(3, []string{"aggression", "strength"}, "predator", Position{X: 50, Y: 50})
-	colony3 := NewCasteColony(3, queen3, Position{X: 50, Y: 50})
+	queen3 := NewEntity(sharedRand, 3, []string{"aggression", "strength"}, "predator", Position{X: 50, Y: 50})
+	colony3 := NewCasteColony(sharedRand, 3, queen3, Position{X: 50, Y: 50})
 	system.RegisterColony(colony3)
 
 	// Set up enemy relations in both directions
@@ -250,13 +250,13 @@ func TestSharedDefenseMechanism(t *testing.T) {
 	system := NewColonyWarfareSystem()
 
 	// Create three colonies: two allies and one aggressor
-	queen1 := NewEntity(1, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 0, Y: 0})
-	queen2 := NewEntity(2, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 20, Y: 20})
-	queen3 := NewEntity(3, []string{"aggression", "strength"}, "predator", Position{X: 50, Y: 50})
+	queen1 := NewEntity(sharedRand, 1, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 0, Y: 0})
+	queen2 := NewEntity(sharedRand, 2, []string{"intelligence", "cooperation"}, "testspecies", Position{X: 20, Y: 20})
+	queen3 := NewEntity(sharedRand, 3, []string{"aggression", "strength"}, "predator", Position{X: 50, Y: 50})
 
-	colony1 := NewCasteColony(1, queen1, Position{X: 0, Y: 0})
-	colony2 := NewCasteColony(2, queen2, Position{X: 20, Y: 20})
-	colony3 := NewCasteColony(3, queen3, Position{X: 50, Y: 50})
+	colony1 := NewCasteColony(sharedRand, 1, queen1, Position{X: 0, Y: 0})
+	colony2 := NewCasteColony(sharedRand, 2, queen2, Position{X: 20, Y: 20})
+	colony3 := NewCasteColony(sharedRand, 3, queen3, Position{X: 50, Y: 50})
 
 	// Set reasonable colony sizes and initial fitness
 	colony1.ColonySize = 50
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Ancestral reconstruction defaults
+const (
+	defaultAncestryFrames = 60
+	maxAncestryFrames     = 500
+	ancestryKeyTraits     = 6 // Traits shown when none are asked for: those that changed most
+)
+
+// AncestralFrame is the reconstructed mean genotype of a lineage at one tick
+type AncestralFrame struct {
+	Tick          int                `json:"tick"`
+	Species       string             `json:"species"` // The ancestor the lineage ran through at this tick
+	Traits        map[string]float64 `json:"traits"`
+	Reconstructed bool               `json:"reconstructed"` // No mean recorded at this tick; interpolated between records
+}
+
+// LineageSplit marks where the lineage passed from an ancestor to its descendant species
+type LineageSplit struct {
+	Tick   int    `json:"tick"`
+	Parent string `json:"parent"`
+	Child  string `json:"child"`
+}
+
+// AncestralHistory is how a species' traits changed along its lineage back to the
+// founding population, as frames evenly spaced in time for animation
+type AncestralHistory struct {
+	Species string             `json:"species"`
+	Lineage []string           `json:"lineage"` // Founder first, the species itself last
+	Traits  []string           `json:"traits"`
+	Frames  []AncestralFrame   `json:"frames"`
+	Splits  []LineageSplit     `json:"splits"`
+	Change  map[string]float64 `json:"change"` // Net change of each trait from the founders to now
+}
+
+// ancestralRecord is a known mean genotype of one species of the lineage
+type ancestralRecord struct {
+	tick     int
+	species  string
+	traits   map[string]float64
+	recorded bool // From the trait history rather than a lineage record
+}
+
+// lineageOf follows parent species from a species back to its founders, founder first
+func (mes *MacroEvolutionSystem) lineageOf(species string) []string {
+	lineage := []string{species}
+	seen := map[string]bool{species: true}
+	for {
+		record := mes.SpeciesLineages[lineage[0]]
+		if record == nil || record.ParentSpecies == "" || seen[record.ParentSpecies] {
+			return lineage
+		}
+		seen[record.ParentSpecies] = true
+		lineage = append([]string{record.ParentSpecies}, lineage...)
+	}
+}
+
+// ancestralRecords gathers the known mean genotypes of each species while the lineage ran
+// through it. Ancestors without recorded history fall back to the traits noted when they
+// formed, so a lineage restored from a save still has its branch points.
+func (mes *MacroEvolutionSystem) ancestralRecords(w *World, lineage []string) ([]ancestralRecord, []LineageSplit) {
+	records := make([]ancestralRecord, 0)
+	splits := make([]LineageSplit, 0)
+	for i, species := range lineage {
+		from, to := 0, w.Tick+1
+		if record := mes.SpeciesLineages[species]; record != nil {
+			from = record.OriginTick
+		}
+		if i+1 < len(lineage) {
+			if child := mes.SpeciesLineages[lineage[i+1]]; child != nil {
+				to = child.OriginTick
+				splits = append(splits, LineageSplit{Tick: child.OriginTick, Parent: species, Child: lineage[i+1]})
+			}
+		}
+
+		byTick := make(map[int]map[string]float64)
+		prefix := species + "_"
+		for _, key := range sortedKeys(mes.TraitHistory) {
+			if len(key) <= len(prefix) || key[:len(prefix)] != prefix {
+				continue
+			}
+			trait := key[len(prefix):]
+			if _, ok := w.TraitRegistry.Lookup(TraitSubjectCreature, trait); !ok {
+				continue // Another species whose name starts with this one
+			}
+			for tick, value := range mes.TraitHistory[key] {
+				if tick < from || tick >= to {
+					continue
+				}
+				if byTick[tick] == nil {
+					byTick[tick] = make(map[string]float64)
+				}
+				byTick[tick][trait] = value
+			}
+		}
+
+		if len(byTick) == 0 {
+			if record := mes.SpeciesLineages[species]; record != nil && len(record.DominantTraits) > 0 {
+				records = append(records, ancestralRecord{tick: from, species: species, traits: record.DominantTraits})
+			}
+			continue
+		}
+		for _, tick := range sortedKeys(byTick) {
+			records = append(records, ancestralRecord{tick: tick, species: species, traits: byTick[tick], recorded: true})
+		}
+	}
+	return records, splits
+}
+
+// speciesGeneMeans averages the genes of a species' living creatures, as the trait
+// history records them, or returns nil when none are alive
+func speciesGeneMeans(w *World, species string) map[string]float64 {
+	sums := make(map[string]float64)
+	count := 0
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive || entity.Species != species {
+			continue
+		}
+		for name, trait := range entity.Traits {
+			sums[name] += trait.Value
+		}
+		count++
+	}
+	if count == 0 {
+		return nil
+	}
+	for name := range sums {
+		sums[name] /= float64(count)
+	}
+	return sums
+}
+
+// ReconstructAncestry reconstructs how a living species' traits changed along its lineage
+// back to the founding population. Frames are spaced evenly from the founding to now,
+// interpolating where no mean was recorded. Empty traits picks the ones that changed most.
+func ReconstructAncestry(w *World, species string, traits []string, frames int) (*AncestralHistory, error) {
+	if frames == 0 {
+		frames = defaultAncestryFrames
+	}
+	if frames < 2 || frames > maxAncestryFrames {
+		return nil, fmt.Errorf("frames must be between 2 and %d", maxAncestryFrames)
+	}
+	current := speciesGeneMeans(w, species)
+	if current == nil {
+		return nil, fmt.Errorf("no living creatures of species %q", species)
+	}
+	for _, trait := range traits {
+		if _, ok := w.TraitRegistry.Lookup(TraitSubjectCreature, trait); !ok {
+			return nil, fmt.Errorf("unknown trait %q", trait)
+		}
+	}
+
+	mes := w.MacroEvolutionSystem
+	lineage := mes.lineageOf(species)
+	records, splits := mes.ancestralRecords(w, lineage)
+	sort.SliceStable(records, func(i, j int) bool { return records[i].tick < records[j].tick })
+	if len(records) == 0 || records[len(records)-1].tick < w.Tick {
+		records = append(records, ancestralRecord{tick: w.Tick, species: species, traits: current, recorded: true})
+	}
+
+	first, last := records[0], records[len(records)-1]
+	history := &AncestralHistory{
+		Species: species,
+		Lineage: lineage,
+		Splits:  splits,
+		Change:  make(map[string]float64),
+	}
+	for _, trait := range sortedKeys(current) {
+		if start, ok := first.traits[trait]; ok {
+			history.Change[trait] = last.traits[trait] - start
+		}
+	}
+
+	history.Traits = traits
+	if len(history.Traits) == 0 {
+		history.Traits = sortedKeys(history.Change)
+		sort.SliceStable(history.Traits, func(i, j int) bool {
+			return math.Abs(history.Change[history.Traits[i]]) > math.Abs(history.Change[history.Traits[j]])
+		})
+		if len(history.Traits) > ancestryKeyTraits {
+			history.Traits = history.Traits[:ancestryKeyTraits]
+		}
+	}
+
+	span := float64(last.tick - first.tick)
+	history.Frames = make([]AncestralFrame, 0, frames)
+	next := 0 // First record after the frame's tick
+	for i := 0; i < frames; i++ {
+		tick := first.tick + int(math.Round(span*float64(i)/float64(frames-1)))
+		for next < len(records) && records[next].tick <= tick {
+			next++
+		}
+		before := records[maxInt(0, next-1)]
+		after := before
+		if next < len(records) {
+			after = records[next]
+		}
+
+		frame := AncestralFrame{
+			Tick:          tick,
+			Species:       before.species,
+			Traits:        make(map[string]float64, len(history.Traits)),
+			Reconstructed: before.tick != tick || !before.recorded,
+		}
+		weight := 0.0
+		if after.tick > before.tick {
+			weight = float64(tick-before.tick) / float64(after.tick-before.tick)
+		}
+		for _, trait := range history.Traits {
+			from, hasFrom := before.traits[trait]
+			to, hasTo := after.traits[trait]
+			switch {
+			case hasFrom && hasTo:
+				frame.Traits[trait] = from + (to-from)*weight
+			case hasFrom:
+				frame.Traits[trait] = from
+			case hasTo:
+				frame.Traits[trait] = to
+			}
+		}
+		history.Frames = append(history.Frames, frame)
+	}
+	return history, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReconstructAncestryFromHistory(t *testing.T) {
+	world := newSteppingTestWorld(91)
+	if _, err := world.Step(20); err != nil {
+		t.Fatal(err)
+	}
+	species := ""
+	for _, entity := range world.AllEntities {
+		if entity.IsAlive {
+			species = entity.Species
+			break
+		}
+	}
+	if species == "" {
+		t.Skip("No creature survived the run")
+	}
+
+	history, err := ReconstructAncestry(world, species, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Frames) != 10 || len(history.Traits) == 0 || len(history.Traits) > ancestryKeyTraits {
+		t.Fatalf("Expected 10 frames of at most %d key traits, got %d frames of %v", ancestryKeyTraits, len(history.Frames), history.Traits)
+	}
+	if history.Lineage[len(history.Lineage)-1] != species {
+		t.Errorf("Expected the lineage to end at %s, got %v", species, history.Lineage)
+	}
+	last := history.Frames[len(history.Frames)-1]
+	if last.Tick != world.Tick {
+		t.Errorf("Expected the animation to end now (tick %d), got %d", world.Tick, last.Tick)
+	}
+	current := speciesGeneMeans(world, species)
+	for _, trait := range history.Traits {
+		if math.Abs(last.Traits[trait]-current[trait]) > 1e-9 {
+			t.Errorf("Expected the last frame's %s to be the living mean %.3f, got %.3f", trait, current[trait], last.Traits[trait])
+		}
+	}
+	for i := 1; i < len(history.Traits); i++ {
+		if math.Abs(history.Change[history.Traits[i]]) > math.Abs(history.Change[history.Traits[i-1]]) {
+			t.Fatalf("Expected key traits ordered by how much they changed, got %v", history.Traits)
+		}
+	}
+}
+
+func TestReconstructAncestryAcrossSplits(t *testing.T) {
+	world := newSteppingTestWorld(92)
+	species := world.AllEntities[0].Species
+	mes := world.MacroEvolutionSystem
+	mes.TraitHistory = map[string]map[int]float64{
+		"Ancestor_speed": {0: 0, 8: 0.4, 15: 9}, // The tick 15 mean is after the split and belongs to the descendant
+	}
+	mes.SpeciesLineages["Ancestor"] = &SpeciesLineage{SpeciesName: "Ancestor", OriginTick: 0}
+	mes.SpeciesLineages[species] = &SpeciesLineage{SpeciesName: species, ParentSpecies: "Ancestor", OriginTick: 10,
+		DominantTraits: map[string]float64{"speed": 0.5}}
+	world.Tick = 20
+
+	history, err := ReconstructAncestry(world, species, []string{"speed"}, 21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Lineage) != 2 || history.Lineage[0] != "Ancestor" {
+		t.Fatalf("Expected the lineage to run through Ancestor, got %v", history.Lineage)
+	}
+	if len(history.Splits) != 1 || history.Splits[0].Tick != 10 || history.Splits[0].Child != species {
+		t.Errorf("Expected one split at tick 10, got %+v", history.Splits)
+	}
+
+	frame := history.Frames[4]
+	if frame.Tick != 4 || frame.Species != "Ancestor" || !frame.Reconstructed || math.Abs(frame.Traits["speed"]-0.2) > 1e-9 {
+		t.Errorf("Expected tick 4 interpolated halfway between Ancestor's records, got %+v", frame)
+	}
+	frame = history.Frames[10]
+	if frame.Species != species || !frame.Reconstructed || frame.Traits["speed"] != 0.5 {
+		t.Errorf("Expected the split to fall back to the traits noted at formation, got %+v", frame)
+	}
+	if frame = history.Frames[8]; frame.Reconstructed {
+		t.Errorf("Expected the recorded tick 8 mean to be marked as recorded, got %+v", frame)
+	}
+	if want := speciesGeneMeans(world, species)["speed"]; math.Abs(history.Change["speed"]-want) > 1e-9 {
+		t.Errorf("Expected a net speed change of %.3f from the founders, got %.3f", want, history.Change["speed"])
+	}
+}
+
+func TestAncestryAPI(t *testing.T) {
+	world := newSteppingTestWorld(93)
+	wi := NewWebInterface(world)
+	species := world.AllEntities[0].Species
+
+	rec := httptest.NewRecorder()
+	wi.handleAncestry(rec, httptest.NewRequest(http.MethodGet, "/api/ancestry?species="+species+"&traits=speed,size&frames=5", nil))
+	var history AncestralHistory
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &history) != nil {
+		t.Fatalf("Reconstructing the ancestry failed: %d %s", rec.Code, rec.Body.String())
+	}
+	if len(history.Frames) != 5 || len(history.Traits) != 2 {
+		t.Errorf("Expected 5 frames of speed and size, got %+v", history)
+	}
+
+	for _, query := range []string{"species=dragon", "species=" + species + "&traits=wings", "species=" + species + "&frames=1", "species=" + species + "&frames=abc"} {
+		rec = httptest.NewRecorder()
+		wi.handleAncestry(rec, httptest.NewRequest(http.MethodGet, "/api/ancestry?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected %s to be rejected, got %d", query, rec.Code)
+		}
+	}
+}
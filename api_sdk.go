@@ -0,0 +1,670 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sdkHeader marks the generated SDK files
+const sdkHeader = `// Code generated by "evosim export sdk"; DO NOT EDIT.`
+
+// sdkInitialisms are words written in capitals in Go names
+var sdkInitialisms = map[string]bool{"API": true, "CPU": true, "DNA": true, "ID": true, "IDS": true, "JSON": true, "RNA": true, "URL": true}
+
+// sdkIdentifier matches names TypeScript accepts as property names without quotes
+var sdkIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// sdkExportedName turns a JSON name such as entity_id, deltaX or join_as_player into an
+// exported Go name: EntityID, DeltaX, JoinAsPlayer
+func sdkExportedName(name string) string {
+	var out strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == ' ' || r == '.' }) {
+		switch upper := strings.ToUpper(word); {
+		case upper == "IDS":
+			out.WriteString("IDs")
+		case sdkInitialisms[upper]:
+			out.WriteString(upper)
+		default:
+			out.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	result := out.String()
+	if result == "" || result[0] < 'A' || result[0] > 'Z' {
+		result = "X" + result
+	}
+	return result
+}
+
+// sdkLowerFirst lowercases the start of a summary to follow a Go doc comment's name,
+// leaving acronyms such as AsyncAPI alone
+func sdkLowerFirst(text string) string {
+	if len(text) > 1 && text[1] >= 'A' && text[1] <= 'Z' {
+		return text
+	}
+	return strings.ToLower(text[:1]) + text[1:]
+}
+
+// sdkPathExpression renders an endpoint's path as a Go string expression, splicing in each
+// path parameter as the argument of its name formatted by splice
+func sdkPathExpression(path string, op apiOperation, splice string) string {
+	expression := strconv.Quote(path)
+	for _, param := range op.Params {
+		if param.InPath {
+			expression = strings.Replace(expression, "{"+param.Name+"}", fmt.Sprintf(splice, param.Name), 1)
+		}
+	}
+	return strings.TrimSuffix(expression, ` + ""`)
+}
+
+// GenerateSDK renders the API specs and the Go and TypeScript clients, keyed by their
+// slash-separated path under the SDK directory
+func GenerateSDK() (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for name, spec := range map[string]interface{}{"openapi.json": OpenAPISpec(), "asyncapi.json": AsyncAPISpec()} {
+		encoded, err := json.MarshalIndent(spec, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		files[name] = append(encoded, '\n')
+	}
+	goClient, err := generateGoClient()
+	if err != nil {
+		return nil, err
+	}
+	files["go/evosim/client.go"] = goClient
+	files["typescript/evosim.ts"] = generateTypeScriptClient()
+	return files, nil
+}
+
+// runSDKCommand implements "evosim export sdk": it writes the API specs and generated clients
+func runSDKCommand(args []string) error {
+	fs := newCommandFlags("sdk")
+	out := fs.String("out", "sdk", "Directory to write the specs and clients to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := applyEnvironment(fs, commandEnvPrefix(fs.Name())); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return commandUsage("sdk")
+	}
+
+	files, err := GenerateSDK()
+	if err != nil {
+		return err
+	}
+	for _, name := range sortedKeys(files) {
+		path := filepath.Join(*out, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, files[name], 0o644); err != nil {
+			return err
+		}
+		fmt.Println("Wrote", path)
+	}
+	return nil
+}
+
+// goSDKWriter renders schemas as Go types for the Go client
+type goSDKWriter struct {
+	schemas  *apiSchemaBuilder
+	usesTime bool
+}
+
+// typeName is the Go type of a value of a schema
+func (g *goSDKWriter) typeName(s *apiSchema) string {
+	switch {
+	case s.Ref != "":
+		return s.refName()
+	case s.Type == "boolean":
+		return "bool"
+	case s.Type == "integer":
+		return "int"
+	case s.Type == "number":
+		return "float64"
+	case s.Type == "string" && s.Format == "date-time":
+		g.usesTime = true
+		return "time.Time"
+	case s.Type == "string" && s.Format == "byte":
+		return "[]byte"
+	case s.Type == "string":
+		return "string"
+	case s.Type == "array":
+		return "[]" + g.typeName(s.Items)
+	case s.Type == "object" && s.Properties != nil:
+		return "struct {\n" + g.fields(s) + "}"
+	case s.Type == "object" && s.AdditionalProperties != nil:
+		return "map[string]" + g.typeName(s.AdditionalProperties)
+	}
+	return "interface{}"
+}
+
+// fieldType is the Go type of a struct field of a schema. References are pointers so
+// types can contain themselves, as are optional values so zero can still be sent.
+func (g *goSDKWriter) fieldType(s *apiSchema, optional bool) string {
+	if s.Ref != "" || ((s.pointer || optional) && s.Type != "array" && s.Type != "object" && s.Type != "") {
+		return "*" + g.typeName(s)
+	}
+	return g.typeName(s)
+}
+
+// fields renders the fields of an object schema in declaration order
+func (g *goSDKWriter) fields(s *apiSchema) string {
+	required := make(map[string]bool)
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	var out strings.Builder
+	used := make(map[string]bool)
+	for _, name := range s.order {
+		field := sdkExportedName(name)
+		for suffix := 2; used[field]; suffix++ {
+			field = fmt.Sprintf("%s%d", sdkExportedName(name), suffix)
+		}
+		used[field] = true
+		tag := name
+		if !required[name] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&out, "\t%s %s `json:\"%s\"`\n", field, g.fieldType(s.Properties[name], !required[name]), tag)
+	}
+	return out.String()
+}
+
+// declare renders a named Go type for a schema
+func (g *goSDKWriter) declare(out *strings.Builder, name, doc string, s *apiSchema) {
+	fmt.Fprintf(out, "// %s %s\n", name, doc)
+	if s.Ref != "" {
+		fmt.Fprintf(out, "type %s = %s\n\n", name, s.refName())
+		return
+	}
+	fmt.Fprintf(out, "type %s %s\n\n", name, g.typeName(s))
+}
+
+// resultType is the Go type an operation returns, declaring it if the response is an
+// inline object. It is empty for operations without a response body.
+func (g *goSDKWriter) resultType(out *strings.Builder, method string, op apiOperation) string {
+	if op.Response == nil {
+		return ""
+	}
+	schema := g.schemas.value(op.Response)
+	switch {
+	case op.textResponse():
+		return "string"
+	case schema.Ref != "":
+		return "*" + schema.refName()
+	case schema.Type == "object" && schema.Properties != nil:
+		g.declare(out, method+"Response", "is the response of "+method, schema)
+		return "*" + method + "Response"
+	}
+	return g.typeName(schema)
+}
+
+// generateGoClient renders the Go client package
+func generateGoClient() ([]byte, error) {
+	g := &goSDKWriter{schemas: newAPISchemaBuilder()}
+	var body strings.Builder
+
+	for _, endpoint := range apiEndpoints {
+		for _, op := range endpoint.Operations {
+			method := sdkExportedName(strings.ToUpper(op.ID[:1]) + op.ID[1:])
+			arguments := []string{"ctx context.Context"}
+
+			params := make([]apiParam, 0, len(op.Params))
+			for _, param := range op.Params {
+				if param.InPath {
+					arguments = append(arguments, param.Name+" string")
+				} else if param.Name != "format" || len(op.Alternates) == 0 {
+					params = append(params, param)
+				}
+			}
+			if len(params) > 0 {
+				fmt.Fprintf(&body, "// %sParams are the query parameters of %s. Optional parameters are left out when zero.\n", method, method)
+				fmt.Fprintf(&body, "type %sParams struct {\n", method)
+				for _, param := range params {
+					fmt.Fprintf(&body, "\t%s %s // %s\n", sdkExportedName(param.Name), g.typeName(&apiSchema{Type: param.Type}), param.Description)
+				}
+				body.WriteString("}\n\n")
+				arguments = append(arguments, "params "+method+"Params")
+			}
+			bodyArgument := "nil"
+			if op.Body != nil {
+				arguments = append(arguments, "body "+g.fieldType(g.schemas.value(op.Body), false))
+				bodyArgument = "body"
+			}
+			result := g.resultType(&body, method, op)
+
+			fmt.Fprintf(&body, "// %s calls %s %s: %s\n", method, op.Method, endpoint.Path, sdkLowerFirst(op.Summary))
+			if result == "" {
+				fmt.Fprintf(&body, "func (c *Client) %s(%s) error {\n", method, strings.Join(arguments, ", "))
+			} else {
+				fmt.Fprintf(&body, "func (c *Client) %s(%s) (%s, error) {\n", method, strings.Join(arguments, ", "), result)
+			}
+			query := "nil"
+			if len(params) > 0 || len(op.Alternates) > 0 {
+				query = "query"
+				body.WriteString("\tquery := url.Values{}\n")
+				if len(op.Alternates) > 0 {
+					body.WriteString("\tquery.Set(\"format\", \"json\")\n")
+				}
+			}
+			for _, param := range params {
+				field := "params." + sdkExportedName(param.Name)
+				value, zero := field, `""`
+				switch param.Type {
+				case "integer":
+					value, zero = "strconv.Itoa("+field+")", "0"
+				case "number":
+					value, zero = "strconv.FormatFloat("+field+", 'g', -1, 64)", "0"
+				case "boolean":
+					value, zero = "strconv.FormatBool("+field+")", "false"
+				}
+				if param.Required {
+					fmt.Fprintf(&body, "\tquery.Set(%q, %s)\n", param.Name, value)
+				} else {
+					fmt.Fprintf(&body, "\tif %s != %s {\n\t\tquery.Set(%q, %s)\n\t}\n", field, zero, param.Name, value)
+				}
+			}
+			call := fmt.Sprintf("c.do(ctx, %q, %s, %s, %s, ", op.Method, sdkPathExpression(endpoint.Path, op, `" + url.PathEscape(%s) + "`), query, bodyArgument)
+			switch {
+			case result == "":
+				fmt.Fprintf(&body, "\treturn %snil)\n", call)
+			case strings.HasPrefix(result, "*"):
+				fmt.Fprintf(&body, "\tvar result %s\n\tif err := %s&result); err != nil {\n\t\treturn nil, err\n\t}\n\treturn &result, nil\n", result[1:], call)
+			default:
+				fmt.Fprintf(&body, "\tvar result %s\n\terr := %s&result)\n\treturn result, err\n", result, call)
+			}
+			body.WriteString("}\n\n")
+		}
+	}
+
+	body.WriteString("// WebSocket messages. Clients send the Message types with an Action (or, to ask for\n")
+	body.WriteString("// isometric data, a Type); the server sends view updates and the Message types with a Type.\n\n")
+	constants := make([]string, 0)
+	for i, message := range append(append([]apiMessage{}, apiClientMessages...), apiServerMessages...) {
+		name := sdkExportedName(message.Name) + "Message"
+		doc := "is " + sdkLowerFirst(message.Summary)
+		if i < len(apiClientMessages) {
+			doc = "is sent to " + sdkLowerFirst(message.Summary)
+		}
+		schema := g.schemas.value(message.Payload)
+		if payload, ok := message.Payload.(apiObject); ok {
+			for _, property := range payload {
+				if value, ok := property.Value.(apiConst); ok {
+					constants = append(constants, fmt.Sprintf("%s%s = %q", sdkExportedName(property.Name), sdkExportedName(string(value)), value))
+				}
+				// Name the data of commands so clients can build it
+				if data, ok := property.Value.(apiObject); ok && property.Name == "data" {
+					dataName := sdkExportedName(message.Name) + "Data"
+					g.declare(&body, dataName, "is the data of a "+name, g.schemas.value(data))
+					schema.Properties["data"] = &apiSchema{Ref: apiComponentPrefix + dataName}
+				}
+			}
+		}
+		g.declare(&body, name, doc, schema)
+	}
+	body.WriteString("// Values of the action and type of WebSocket messages\nconst (\n")
+	for _, constant := range constants {
+		body.WriteString("\t" + constant + "\n")
+	}
+	body.WriteString(")\n\n")
+
+	for _, name := range sortedKeys(g.schemas.components) {
+		g.declare(&body, name, "is a type of the EvoSim API", g.schemas.components[name])
+	}
+
+	var out strings.Builder
+	out.WriteString(sdkHeader + "\n\n")
+	out.WriteString(`// Package evosim is a typed client for the EvoSim HTTP API, generated from the OpenAPI
+// description the server publishes at /api/spec. The WebSocket messages described at
+// /api/spec/asyncapi are typed too: dial WebSocketURL with any WebSocket library and
+// exchange the Message types as JSON.
+package evosim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+`)
+	if g.usesTime {
+		out.WriteString("\t\"time\"\n")
+	}
+	out.WriteString(`)
+
+// Client calls the EvoSim HTTP API
+type Client struct {
+	BaseURL    string // e.g. http://localhost:8080
+	HTTPClient *http.Client
+}
+
+// NewClient creates a client for the server at baseURL
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/"), HTTPClient: http.DefaultClient}
+}
+
+// Error is a request the server refused
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("evosim: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// WebSocketURL is the address of the live simulation WebSocket
+func (c *Client) WebSocketURL() string {
+	if strings.HasPrefix(c.BaseURL, "https://") {
+		return "wss://" + strings.TrimPrefix(c.BaseURL, "https://") + "/ws"
+	}
+	return "ws://" + strings.TrimPrefix(c.BaseURL, "http://") + "/ws"
+}
+
+// do sends a request and decodes the response into result: text into a *string, JSON
+// into anything else
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	target := c.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	request, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(response.Body)
+		return &Error{StatusCode: response.StatusCode, Message: strings.TrimSpace(string(message))}
+	}
+	switch result := result.(type) {
+	case nil:
+		return nil
+	case *string:
+		text, err := io.ReadAll(response.Body)
+		*result = string(text)
+		return err
+	default:
+		return json.NewDecoder(response.Body).Decode(result)
+	}
+}
+
+`)
+	out.WriteString(body.String())
+	return format.Source([]byte(out.String()))
+}
+
+// tsSDKWriter renders schemas as TypeScript types for the TypeScript client
+type tsSDKWriter struct {
+	schemas *apiSchemaBuilder
+}
+
+// typeName is the TypeScript type of a value of a schema
+func (t *tsSDKWriter) typeName(s *apiSchema, indent string) string {
+	var name string
+	switch {
+	case s.Ref != "":
+		name = s.refName()
+	case len(s.Enum) > 0:
+		quoted := make([]string, len(s.Enum))
+		for i, value := range s.Enum {
+			quoted[i] = fmt.Sprintf("%q", value)
+		}
+		name = strings.Join(quoted, " | ")
+	case s.Type == "boolean":
+		name = "boolean"
+	case s.Type == "integer" || s.Type == "number":
+		name = "number"
+	case s.Type == "string":
+		name = "string"
+	case s.Type == "array":
+		name = t.typeName(s.Items, indent)
+		if strings.Contains(name, "|") {
+			name = "(" + name + ")"
+		}
+		name += "[]"
+	case s.Type == "object" && s.Properties != nil:
+		name = "{\n" + t.fields(s, indent+"  ") + indent + "}"
+	case s.Type == "object" && s.AdditionalProperties != nil:
+		name = "{ [key: string]: " + t.typeName(s.AdditionalProperties, indent) + " }"
+	default:
+		name = "unknown"
+	}
+	if s.pointer && name != "unknown" {
+		name += " | null"
+	}
+	return name
+}
+
+// fields renders the properties of an object schema in declaration order
+func (t *tsSDKWriter) fields(s *apiSchema, indent string) string {
+	required := make(map[string]bool)
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	var out strings.Builder
+	for _, name := range s.order {
+		key := name
+		if !sdkIdentifier.MatchString(name) {
+			key = fmt.Sprintf("%q", name)
+		}
+		if !required[name] {
+			key += "?"
+		}
+		fmt.Fprintf(&out, "%s%s: %s;\n", indent, key, t.typeName(s.Properties[name], indent))
+	}
+	return out.String()
+}
+
+// declare renders a named TypeScript type for a schema
+func (t *tsSDKWriter) declare(out *strings.Builder, name, doc string, s *apiSchema) {
+	if doc != "" {
+		fmt.Fprintf(out, "/** %s */\n", doc)
+	}
+	if s.Type == "object" && s.Properties != nil {
+		fmt.Fprintf(out, "export interface %s {\n%s}\n\n", name, t.fields(s, "  "))
+		return
+	}
+	fmt.Fprintf(out, "export type %s = %s;\n\n", name, t.typeName(s, ""))
+}
+
+// generateTypeScriptClient renders the TypeScript client module
+func generateTypeScriptClient() []byte {
+	t := &tsSDKWriter{schemas: newAPISchemaBuilder()}
+	var types, methods strings.Builder
+
+	for _, endpoint := range apiEndpoints {
+		for _, op := range endpoint.Operations {
+			method := sdkExportedName(strings.ToUpper(op.ID[:1]) + op.ID[1:])
+			arguments := make([]string, 0, 2)
+			query := "{}"
+
+			params := &apiSchema{Type: "object", Properties: make(map[string]*apiSchema)}
+			for _, param := range op.Params {
+				if param.InPath {
+					arguments = append(arguments, param.Name+": string")
+					continue
+				}
+				if param.Name == "format" && len(op.Alternates) > 0 {
+					continue
+				}
+				params.Properties[param.Name] = &apiSchema{Type: param.Type}
+				params.order = append(params.order, param.Name)
+				if param.Required {
+					params.Required = append(params.Required, param.Name)
+				}
+			}
+			if len(params.order) > 0 {
+				t.declare(&types, method+"Params", "Query parameters of "+op.ID, params)
+				if len(params.Required) > 0 {
+					arguments = append(arguments, "params: "+method+"Params")
+				} else {
+					arguments = append(arguments, "params: "+method+"Params = {}")
+				}
+				query = "params"
+			}
+			if len(op.Alternates) > 0 {
+				query = `{ ...` + query + `, format: "json" }`
+				if query == `{ ...{}, format: "json" }` {
+					query = `{ format: "json" }`
+				}
+			}
+			bodyArgument := "undefined"
+			if op.Body != nil {
+				arguments = append(arguments, "body: "+t.typeName(t.schemas.value(op.Body), "  "))
+				bodyArgument = "body"
+			}
+
+			result, text := "void", "false"
+			if op.Response != nil {
+				schema := t.schemas.value(op.Response)
+				switch {
+				case op.textResponse():
+					result, text = "string", "true"
+				case schema.Ref == "" && schema.Type == "object" && schema.Properties != nil:
+					t.declare(&types, method+"Response", "Response of "+op.ID, schema)
+					result = method + "Response"
+				default:
+					schema.pointer = false
+					result = t.typeName(schema, "  ")
+				}
+			}
+
+			fmt.Fprintf(&methods, "  /** %s %s: %s */\n", op.Method, endpoint.Path, op.Summary)
+			fmt.Fprintf(&methods, "  %s(%s): Promise<%s> {\n", op.ID, strings.Join(arguments, ", "), result)
+			fmt.Fprintf(&methods, "    return this.request(%q, %s, %s, %s, %s);\n  }\n\n", op.Method,
+				sdkPathExpression(endpoint.Path, op, `" + encodeURIComponent(%s) + "`), query, bodyArgument, text)
+		}
+	}
+
+	var clientMessages, serverMessages []string
+	for _, list := range []struct {
+		messages []apiMessage
+		names    *[]string
+	}{{apiClientMessages, &clientMessages}, {apiServerMessages, &serverMessages}} {
+		for _, message := range list.messages {
+			name := sdkExportedName(message.Name) + "Message"
+			t.declare(&types, name, message.Summary, t.schemas.value(message.Payload))
+			*list.names = append(*list.names, name)
+		}
+	}
+	fmt.Fprintf(&types, "/** A message a client sends over the WebSocket */\nexport type ClientMessage =\n  | %s;\n\n", strings.Join(clientMessages, "\n  | "))
+	fmt.Fprintf(&types, "/** A message the server sends over the WebSocket */\nexport type ServerMessage =\n  | %s;\n\n", strings.Join(serverMessages, "\n  | "))
+
+	for _, name := range sortedKeys(t.schemas.components) {
+		t.declare(&types, name, "", t.schemas.components[name])
+	}
+
+	var out strings.Builder
+	out.WriteString(sdkHeader + "\n\n")
+	out.WriteString(`// A typed client for the EvoSim HTTP API and WebSocket, generated from the OpenAPI and
+// AsyncAPI descriptions the server publishes at /api/spec and /api/spec/asyncapi.
+
+`)
+	out.WriteString(types.String())
+	out.WriteString(`/** A request the server refused */
+export class EvoSimError extends Error {
+  constructor(public status: number, message: string) {
+    super(` + "`evosim: ${status} ${message}`" + `);
+  }
+}
+
+/** An open WebSocket to the live simulation */
+export interface EvoSimSocket {
+  socket: WebSocket;
+  send(message: ClientMessage): void;
+  close(): void;
+}
+
+/** Calls the EvoSim HTTP API */
+export class EvoSimClient {
+  /** baseURL is e.g. http://localhost:8080, or empty for the page's own server */
+  constructor(
+    private baseURL = "",
+    private fetchImpl: typeof fetch = globalThis.fetch.bind(globalThis),
+  ) {
+    this.baseURL = baseURL.replace(/\/+$/, "");
+  }
+
+  /** The address of the live simulation WebSocket */
+  webSocketURL(): string {
+    const base = this.baseURL || globalThis.location.origin;
+    return base.replace(/^http/, "ws") + "/ws";
+  }
+
+  /** Opens the WebSocket, handing every message the server sends to onMessage */
+  connect(onMessage: (message: ServerMessage) => void): EvoSimSocket {
+    const socket = new WebSocket(this.webSocketURL());
+    socket.onmessage = (event) => onMessage(JSON.parse(event.data) as ServerMessage);
+    return {
+      socket,
+      send: (message) => socket.send(JSON.stringify(message)),
+      close: () => socket.close(),
+    };
+  }
+
+`)
+	out.WriteString(methods.String())
+	out.WriteString(`  private async request<T>(
+    method: string,
+    path: string,
+    query: object,
+    body: unknown,
+    text: boolean,
+  ): Promise<T> {
+    const search = new URLSearchParams();
+    for (const [key, value] of Object.entries(query)) {
+      if (value !== undefined && value !== null) search.set(key, String(value));
+    }
+    const encoded = search.toString();
+    const url = this.baseURL + path + (encoded ? "?" + encoded : "");
+    const response = await this.fetchImpl(url, {
+      method,
+      headers: body === undefined ? undefined : { "Content-Type": "application/json" },
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    if (!response.ok) {
+      throw new EvoSimError(response.status, (await response.text()).trim());
+    }
+    if (response.status === ` + fmt.Sprint(http.StatusNoContent) + `) {
+      return undefined as T;
+    }
+    return (text ? await response.text() : await response.json()) as T;
+  }
+}
+`)
+	return []byte(out.String())
+}
@@ -0,0 +1,935 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:generate go run . export sdk --out sdk
+
+// apiVersion is the version of the HTTP and WebSocket API described by the specs
+const apiVersion = "2.0"
+
+// apiParam is a query parameter of an API operation
+type apiParam struct {
+	Name        string
+	Type        string // string, integer, number or boolean
+	Description string
+	Required    bool
+	InPath      bool // Fills the {name} segment of the path rather than the query; always required
+}
+
+// apiOperation is one method of an API endpoint. Bodies are given by an example value of
+// the Go type the handler decodes or encodes, so the spec follows the code.
+type apiOperation struct {
+	ID          string // operationId, also the name of the SDK method
+	Method      string
+	Summary     string
+	Params      []apiParam
+	Body        interface{} // Request body, nil for none
+	Response    interface{} // Response body, nil for none
+	ContentType string      // Response content type, JSON when empty
+	Alternates  []string    // Other content types the format parameter selects
+	Status      int         // Success status, 200 when zero
+}
+
+// apiEndpoint is an API path and the methods it answers
+type apiEndpoint struct {
+	Path       string
+	Operations []apiOperation
+}
+
+// apiProperty is a property of an apiObject, typed by an example value
+type apiProperty struct {
+	Name  string
+	Value interface{}
+}
+
+// apiObject describes a JSON object a handler builds from a map rather than a struct
+type apiObject []apiProperty
+
+// apiOptional marks a property of an apiObject that is not always present
+type apiOptional struct {
+	Value interface{}
+}
+
+// apiConst is a string property that always has the same value, such as a message type
+type apiConst string
+
+// Parameters shared by several operations
+var (
+	apiBranchParam = apiParam{Name: "branch", Type: "integer", Description: "Apply to this branch instead of the live world"}
+	apiXParam      = apiParam{Name: "x", Type: "number", Description: "World X coordinate to place the creatures at"}
+	apiYParam      = apiParam{Name: "y", Type: "number", Description: "World Y coordinate to place the creatures at"}
+)
+
+// apiDiffResponse is how a save diff is reported
+var apiDiffResponse = apiObject{
+	{"diff", (*SaveDiff)(nil)},
+	{"has_differences", false},
+	{"summary", ""},
+}
+
+// apiEndpoints lists every HTTP API endpoint the web interface registers
+var apiEndpoints = []apiEndpoint{
+	{"/api/status", []apiOperation{
+		{ID: "getStatus", Method: http.MethodGet, Summary: "Simulation status and speed", Response: apiObject{
+			{"tick", 0}, {"entities", 0}, {"plants", 0}, {"populations", 0}, {"status", ""}, {"seed", int64(0)},
+			{"ticks_per_second", 0.0}, {"unlimited_speed", false}, {"max_cpu", 0.0}, {"websocket", WebSocketStats{}},
+		}},
+	}},
+	{"/api/spec", []apiOperation{
+		{ID: "getOpenAPISpec", Method: http.MethodGet, Summary: "This OpenAPI description of the HTTP API", Response: map[string]interface{}{}},
+	}},
+	{"/api/spec/asyncapi", []apiOperation{
+		{ID: "getAsyncAPISpec", Method: http.MethodGet, Summary: "AsyncAPI description of the WebSocket protocol at /ws", Response: map[string]interface{}{}},
+	}},
+	{"/api/export/events", []apiOperation{
+		{ID: "exportEvents", Method: http.MethodGet, Summary: "Export events from the central event bus",
+			Params: []apiParam{
+				{Name: "type", Type: "string", Description: "Only events of this type"},
+				{Name: "category", Type: "string", Description: "Only events of this category"},
+				{Name: "format", Type: "string", Description: "json (default) or csv"},
+			},
+			Response: apiObject{
+				{"events", []CentralEvent(nil)}, {"total_count", 0}, {"export_time", time.Time{}},
+				{"filters", map[string]string(nil)}, {"certificate", (*RunCertificate)(nil)},
+			},
+			Alternates: []string{"text/csv"}},
+	}},
+	{"/api/export/analysis", []apiOperation{
+		{ID: "exportAnalysis", Method: http.MethodGet, Summary: "Export statistical analysis data",
+			Params: []apiParam{{Name: "format", Type: "string", Description: "json (default) or csv"}},
+			Response: apiObject{
+				{"summary_statistics", map[string]interface{}(nil)}, {"recent_events", []StatisticalEvent(nil)},
+				{"snapshots", []StatisticalSnapshot(nil)}, {"export_time", time.Time{}}, {"certificate", (*RunCertificate)(nil)},
+			},
+			Alternates: []string{"text/csv"}},
+	}},
+	{"/api/export/anomalies", []apiOperation{
+		{ID: "exportAnomalies", Method: http.MethodGet, Summary: "Export detected anomalies",
+			Params: []apiParam{{Name: "format", Type: "string", Description: "json (default) or csv"}},
+			Response: apiObject{
+				{"anomalies", []Anomaly(nil)}, {"total_count", 0}, {"anomaly_types", map[AnomalyType]int(nil)},
+				{"export_time", time.Time{}}, {"certificate", (*RunCertificate)(nil)},
+			},
+			Alternates: []string{"text/csv"}},
+	}},
+	{"/api/export/geojson", []apiOperation{
+		{ID: "exportGeoJSON", Method: http.MethodGet, Summary: "Export world geography and species ranges as GeoJSON",
+			Params:   []apiParam{{Name: "layer", Type: "string", Description: "Layer to export; all layers when empty"}},
+			Response: (*GeoJSONFeatureCollection)(nil), ContentType: "application/geo+json"},
+	}},
+	{"/api/export/darwincore", []apiOperation{
+		{ID: "exportDarwinCore", Method: http.MethodGet, Summary: "Export occurrence records in Darwin Core format",
+			Params: []apiParam{
+				{Name: "plants", Type: "boolean", Description: "Include plant occurrences"},
+				{Name: "format", Type: "string", Description: "csv (default) or json"},
+			},
+			Response: []DarwinCoreRecord(nil), Alternates: []string{"text/csv"}},
+	}},
+	{"/api/export/certificate", []apiOperation{
+		{ID: "exportCertificate", Method: http.MethodGet, Summary: "The run certificate: seed, config and state hash chain", Response: (*RunCertificate)(nil)},
+	}},
+	{"/api/diff", []apiOperation{
+		{ID: "diffSave", Method: http.MethodPost, Summary: "Compare a save file against the live simulation", Body: (*SimulationState)(nil), Response: apiDiffResponse},
+	}},
+	{"/api/validate", []apiOperation{
+		{ID: "validateLiveState", Method: http.MethodGet, Summary: "Check the live state for corruption", Response: apiObject{
+			{"valid", false}, {"report", (*SaveValidationReport)(nil)},
+		}},
+		{ID: "validateSave", Method: http.MethodPost, Summary: "Check a save file for corruption, optionally repairing it",
+			Params: []apiParam{{Name: "repair", Type: "boolean", Description: "Return the repaired state"}},
+			Body:   (*SimulationState)(nil),
+			Response: apiObject{
+				{"valid", false}, {"report", (*SaveValidationReport)(nil)}, {"repaired_state", apiOptional{(*SimulationState)(nil)}},
+			}},
+	}},
+	{"/api/creatures/export", []apiOperation{
+		{ID: "exportCreature", Method: http.MethodGet, Summary: "Download an entity or breeding pair as a creature file",
+			Params: []apiParam{
+				{Name: "id", Type: "integer", Description: "Entity to export", Required: true},
+				{Name: "mate", Type: "integer", Description: "Second entity of a breeding pair"},
+				{Name: "name", Type: "string", Description: "Name of the creature file"},
+				{Name: "description", Type: "string", Description: "Description of the creature file"},
+			},
+			Response: (*CreatureFile)(nil)},
+	}},
+	{"/api/creatures/import", []apiOperation{
+		{ID: "importCreature", Method: http.MethodPost, Summary: "Add the creatures in a creature file to the world",
+			Params: []apiParam{apiXParam, apiYParam}, Body: (*CreatureFile)(nil),
+			Response: apiObject{{"imported_ids", []int(nil)}, {"species", ""}}},
+	}},
+	{"/api/registry/list", []apiOperation{
+		{ID: "listRegistry", Method: http.MethodGet, Summary: "List files on the creature registry",
+			Params:   []apiParam{{Name: "kind", Type: "string", Description: "creature or scenario; both when empty"}},
+			Response: []RegistryEntry(nil)},
+	}},
+	{"/api/registry/import", []apiOperation{
+		{ID: "importRegistryFile", Method: http.MethodPost, Summary: "Download, verify and apply a registry file",
+			Params:   []apiParam{{Name: "id", Type: "string", Description: "Registry file to import", Required: true}, apiXParam, apiYParam},
+			Response: apiObject{{"entry", (*RegistryEntry)(nil)}, {"imported_ids", []int(nil)}}},
+	}},
+	{"/api/registry/upload", []apiOperation{
+		{ID: "uploadToRegistry", Method: http.MethodPost, Summary: "Publish a creature or the current world as a scenario",
+			Body: RegistryUploadRequest{}, Response: (*RegistryEntry)(nil)},
+	}},
+	{"/api/petri", []apiOperation{
+		{ID: "listPetriDishes", Method: http.MethodGet, Summary: "List petri dishes", Response: []map[string]interface{}(nil)},
+		{ID: "createPetriDish", Method: http.MethodPost, Summary: "Create a petri dish seeded with populations",
+			Body: PetriDishCreateRequest{}, Response: apiObject{{"id", 0}, {"config", PetriDishConfig{}}, {"stats", PetriDishStats{}}}},
+	}},
+	{"/api/petri/dish", []apiOperation{
+		{ID: "getPetriDish", Method: http.MethodGet, Summary: "Report a petri dish",
+			Params: []apiParam{{Name: "id", Type: "integer", Description: "Petri dish", Required: true}}, Response: PetriDishStats{}},
+		{ID: "updatePetriDish", Method: http.MethodPost, Summary: "Adjust a petri dish and optionally advance it",
+			Params: []apiParam{{Name: "id", Type: "integer", Description: "Petri dish", Required: true}},
+			Body:   PetriDishUpdateRequest{}, Response: PetriDishStats{}},
+		{ID: "deletePetriDish", Method: http.MethodDelete, Summary: "Remove a petri dish",
+			Params: []apiParam{{Name: "id", Type: "integer", Description: "Petri dish", Required: true}}, Status: http.StatusNoContent},
+	}},
+	{"/api/breakpoints", []apiOperation{
+		{ID: "listBreakpoints", Method: http.MethodGet, Summary: "List breakpoints and the one that last paused the simulation",
+			Response: apiObject{{"breakpoints", []Breakpoint(nil)}, {"last_hit", apiOptional{(*Breakpoint)(nil)}}}},
+		{ID: "addBreakpoint", Method: http.MethodPost, Summary: "Add a breakpoint",
+			Body: BreakpointRequest{}, Response: (*Breakpoint)(nil), Status: http.StatusCreated},
+		{ID: "removeBreakpoint", Method: http.MethodDelete, Summary: "Remove a breakpoint",
+			Params:   []apiParam{{Name: "id", Type: "integer", Description: "Breakpoint", Required: true}},
+			Response: apiObject{{"removed", 0}}},
+	}},
+	{"/api/timeline", []apiOperation{
+		{ID: "getTimeline", Method: http.MethodGet, Summary: "Event counts bucketed over a range of ticks",
+			Params: []apiParam{
+				{Name: "from", Type: "integer", Description: "First tick; the start of the run when empty"},
+				{Name: "to", Type: "integer", Description: "Last tick; the current tick when empty"},
+				{Name: "buckets", Type: "integer", Description: "Number of buckets (default 100, at most 1000)"},
+				{Name: "events", Type: "integer", Description: "How many individual events to include (default none)"},
+			},
+			Response: (*EventTimeline)(nil)},
+	}},
+	{"/api/entity/{id}", []apiOperation{
+		{ID: "inspectEntity", Method: http.MethodGet, Summary: "Everything about an entity: activity, DNA, neural network, memory and relationships",
+			Params:   []apiParam{{Name: "id", Type: "integer", Description: "Entity", InPath: true}},
+			Response: (*EntityInspection)(nil)},
+	}},
+	{"/api/cell/{x}/{y}", []apiOperation{
+		{ID: "inspectCell", Method: http.MethodGet, Summary: "A grid cell's ground and the entities and plants in it",
+			Params: []apiParam{
+				{Name: "x", Type: "integer", Description: "Grid column", InPath: true},
+				{Name: "y", Type: "integer", Description: "Grid row", InPath: true},
+			},
+			Response: (*CellInspection)(nil)},
+	}},
+	{"/api/follow", []apiOperation{
+		{ID: "getFollow", Method: http.MethodGet, Summary: "The creature the shared camera follows and what it did lately", Response: FollowState{}},
+		{ID: "followEntity", Method: http.MethodPost, Summary: "Lock the shared camera onto a creature, recording its decisions each tick",
+			Body: FollowRequest{}, Response: FollowState{}},
+		{ID: "unfollowEntity", Method: http.MethodDelete, Summary: "Let the shared camera move freely again", Response: FollowState{}},
+	}},
+	{"/api/entities", []apiOperation{
+		{ID: "listEntities", Method: http.MethodGet, Summary: "Page through entities in ID order",
+			Params: []apiParam{
+				{Name: "species", Type: "string", Description: "Only entities of this species"},
+				{Name: "alive", Type: "boolean", Description: "Only living entities"},
+				{Name: "offset", Type: "integer", Description: "Entities to skip"},
+				{Name: "limit", Type: "integer", Description: "Page size, 1-1000 (default 100)"},
+			},
+			Response: EntityListResponse{}},
+	}},
+	{"/api/populations", []apiOperation{
+		{ID: "listPopulations", Method: http.MethodGet, Summary: "Populations by species name", Response: []PopulationData(nil)},
+		{ID: "createPopulation", Method: http.MethodPost, Summary: "Add a species with adjusted starting traits",
+			Body: PopulationCreateRequest{}, Response: PopulationCreateResponse{}, Status: http.StatusCreated},
+	}},
+	{"/api/species", []apiOperation{
+		{ID: "listSpecies", Method: http.MethodGet, Summary: "Creature and plant species with their trait spread, relatives and members",
+			Response: []SpeciesProfile(nil)},
+	}},
+	{"/api/species/{name}", []apiOperation{
+		{ID: "getSpecies", Method: http.MethodGet, Summary: "A creature or plant species with its trait spread, relatives and members",
+			Params:   []apiParam{{Name: "name", Type: "string", Description: "Creature or plant species name", InPath: true}},
+			Response: SpeciesProfile{}},
+	}},
+	{"/api/species/{id}/sprite", []apiOperation{
+		{ID: "getSpeciesSprite", Method: http.MethodGet, Summary: "SVG sprite of a species drawn from its members' traits",
+			Params:   []apiParam{{Name: "id", Type: "string", Description: "Plant species number or creature species name", InPath: true}},
+			Response: "", ContentType: "image/svg+xml"},
+	}},
+	{"/api/control", []apiOperation{
+		{ID: "getControlState", Method: http.MethodGet, Summary: "Pause, speed and viewport state", Response: ControlState{}},
+	}},
+	{"/api/control/pause", []apiOperation{
+		{ID: "setPaused", Method: http.MethodPost, Summary: "Pause, resume or toggle the simulation",
+			Body: ControlPauseRequest{}, Response: ControlState{}},
+	}},
+	{"/api/control/speed", []apiOperation{
+		{ID: "setSpeed", Method: http.MethodPost, Summary: "Set the speed multiplier, unlimited mode and CPU cap",
+			Body: ControlSpeedRequest{}, Response: ControlState{}},
+	}},
+	{"/api/control/viewport", []apiOperation{
+		{ID: "setViewport", Method: http.MethodPost, Summary: "Pan and zoom the shared view",
+			Body: ControlViewportRequest{}, Response: ControlState{}},
+	}},
+	{"/api/control/reset", []apiOperation{
+		{ID: "resetWorld", Method: http.MethodPost, Summary: "Restart the world with the default populations", Response: ControlState{}},
+	}},
+	{"/api/performance", []apiOperation{
+		{ID: "getPerformance", Method: http.MethodGet, Summary: "Ticks per second and how often each scheduled subsystem runs", Response: PerformanceReport{}},
+		{ID: "updateSchedule", Method: http.MethodPost, Summary: "Change a scheduled subsystem's interval or run it on the next tick",
+			Body: ScheduleUpdateRequest{}, Response: PerformanceReport{}},
+	}},
+	{"/api/save", []apiOperation{
+		{ID: "downloadSave", Method: http.MethodGet, Summary: "The full simulation state", Response: (*SimulationState)(nil)},
+		{ID: "saveOnServer", Method: http.MethodPost, Summary: "Save the simulation state to a file on the server", Response: SaveResponse{}},
+	}},
+	{"/api/load", []apiOperation{
+		{ID: "loadSave", Method: http.MethodPost, Summary: "Replace the world with a save",
+			Body: (*SimulationState)(nil), Response: ControlState{}},
+	}},
+	{"/api/autosave", []apiOperation{
+		{ID: "getAutosave", Method: http.MethodGet, Summary: "Autosave settings, the autosaves kept and any autosave from a crashed or interrupted run",
+			Response: AutosaveStatus{}},
+	}},
+	{"/api/autosave/resume", []apiOperation{
+		{ID: "resumeAutosave", Method: http.MethodPost, Summary: "Replace the world with the autosave on offer", Response: AutosaveStatus{}},
+		{ID: "dismissAutosave", Method: http.MethodDelete, Summary: "Decline the autosave on offer, keeping its file", Response: AutosaveStatus{}},
+	}},
+	{"/api/operator/structures", []apiOperation{
+		{ID: "listOperatorStructures", Method: http.MethodGet, Summary: "List operator barriers and corridors with their experiments",
+			Response: apiObject{
+				{"structures", map[int]*OperatorStructure(nil)}, {"experiments", []*FragmentationExperiment(nil)},
+				{"stats", map[string]interface{}(nil)},
+			}},
+		{ID: "buildOperatorStructure", Method: http.MethodPost, Summary: "Build a barrier or corridor",
+			Body: OperatorStructureRequest{}, Response: (*OperatorStructure)(nil), Status: http.StatusCreated},
+		{ID: "removeOperatorStructure", Method: http.MethodDelete, Summary: "Remove a barrier or corridor",
+			Params: []apiParam{{Name: "id", Type: "integer", Description: "Structure", Required: true}}, Status: http.StatusNoContent},
+	}},
+	{"/api/operator/terraform", []apiOperation{
+		{ID: "terraform", Method: http.MethodPost, Summary: "Change the biome of grid cells",
+			Params: []apiParam{apiBranchParam}, Body: TerraformRequest{}, Response: (*Intervention)(nil), Status: http.StatusCreated},
+	}},
+	{"/api/operator/traits", []apiOperation{
+		{ID: "editTrait", Method: http.MethodPost, Summary: "Change a trait of a single entity",
+			Params: []apiParam{apiBranchParam}, Body: TraitEditRequest{}, Response: (*Intervention)(nil), Status: http.StatusCreated},
+	}},
+	{"/api/operator/interventions", []apiOperation{
+		{ID: "listInterventions", Method: http.MethodGet, Summary: "The intervention undo and redo history",
+			Params: []apiParam{apiBranchParam}, Response: apiObject{{"undo", []Intervention(nil)}, {"redo", []Intervention(nil)}}},
+		{ID: "undoRedoIntervention", Method: http.MethodPost, Summary: "Undo or redo the latest intervention",
+			Params: []apiParam{apiBranchParam}, Body: InterventionRequest{}, Response: (*Intervention)(nil)},
+	}},
+	{"/api/operator/events", []apiOperation{
+		{ID: "startEnvironmentalEvent", Method: http.MethodPost, Summary: "Start a wildfire, storm, flood or other environmental event on a grid cell",
+			Params: []apiParam{apiBranchParam}, Body: EnvironmentalEventRequest{}, Response: EnvironmentalEventResponse{}, Status: http.StatusCreated},
+	}},
+	{"/api/game", []apiOperation{
+		{ID: "getGame", Method: http.MethodGet, Summary: "The current or last competitive game",
+			Response: apiObject{{"game", (*CompetitiveGame)(nil)}}},
+		{ID: "startGame", Method: http.MethodPost, Summary: "Start a game between every player with a species",
+			Body: GameSettings{}, Response: CompetitiveGame{}, Status: http.StatusCreated},
+		{ID: "abortGame", Method: http.MethodDelete, Summary: "Abort the running game", Response: CompetitiveGame{}},
+	}},
+	{"/api/game/rematch", []apiOperation{
+		{ID: "rematchGame", Method: http.MethodPost, Summary: "Reset the world and replay the last finished game",
+			Response: CompetitiveGame{}, Status: http.StatusCreated},
+	}},
+	{"/api/tutorial", []apiOperation{
+		{ID: "getTutorial", Method: http.MethodGet, Summary: "The guided scenarios and the current or last tutorial",
+			Response: apiObject{{"scenarios", []TutorialScenario(nil)}, {"tutorial", (*Tutorial)(nil)}}},
+		{ID: "startTutorial", Method: http.MethodPost, Summary: "Reset the world into a guided scenario",
+			Body: TutorialRequest{}, Response: Tutorial{}, Status: http.StatusCreated},
+		{ID: "stopTutorial", Method: http.MethodDelete, Summary: "Stop the running tutorial", Response: Tutorial{}},
+	}},
+	{"/api/predictions", []apiOperation{
+		{ID: "listPredictions", Method: http.MethodGet, Summary: "Prediction rounds and the points leaderboard",
+			Response: apiObject{{"rounds", []PredictionRound(nil)}, {"leaderboard", []Spectator(nil)}}},
+		{ID: "openPredictionRound", Method: http.MethodPost, Summary: "Open a prediction round",
+			Body: PredictionRoundRequest{}, Response: PredictionRound{}, Status: http.StatusCreated},
+	}},
+	{"/api/predictions/predict", []apiOperation{
+		{ID: "predict", Method: http.MethodPost, Summary: "Stake a spectator's points on an option of an open round",
+			Body: PredictionRequest{}, Response: Spectator{}},
+	}},
+	{"/api/step", []apiOperation{
+		{ID: "getStateDigest", Method: http.MethodGet, Summary: "The current state digest", Response: StepResult{}},
+		{ID: "step", Method: http.MethodPost, Summary: "Pause and advance the simulation by a number of ticks",
+			Body: StepRequest{}, Response: StepResult{}},
+	}},
+	{"/api/fast-forward", []apiOperation{
+		{ID: "fastForward", Method: http.MethodPost, Summary: "Load a save and replay it to a tick",
+			Body: FastForwardRequest{}, Response: FastForwardResult{}},
+	}},
+	{"/api/population-caps", []apiOperation{
+		{ID: "getPopulationCaps", Method: http.MethodGet, Summary: "Soft population caps and the dispersal pool", Response: PopulationCapStatus{}},
+		{ID: "setPopulationCaps", Method: http.MethodPost, Summary: "Change the population caps",
+			Body: PopulationCapRequest{}, Response: PopulationCapStatus{}},
+	}},
+	{"/api/resource-budget", []apiOperation{
+		{ID: "getResourceBudget", Method: http.MethodGet, Summary: "Resources, plants and creatures each biome can support", Response: ResourceBudgetStatus{}},
+		{ID: "setResourceBudget", Method: http.MethodPost, Summary: "Change the resource budget settings; fields left out keep their values",
+			Body: ResourceBudgetConfig{}, Response: ResourceBudgetStatus{}},
+	}},
+	{"/api/gallery", []apiOperation{
+		{ID: "getGallery", Method: http.MethodGet, Summary: "Snapshots captured on notable events, newest first; images are served from /gallery/<image>",
+			Response: GalleryStatus{}},
+		{ID: "captureSnapshot", Method: http.MethodPost, Summary: "Capture a snapshot of the world now",
+			Body: GalleryCaptureRequest{}, Response: GallerySnapshot{}, Status: http.StatusCreated},
+	}},
+	{"/api/achievements", []apiOperation{
+		{ID: "getAchievements", Method: http.MethodGet, Summary: "Milestones with the achievements the world and each player have earned",
+			Params:   []apiParam{{Name: "player", Type: "string", Description: "Only this player's achievements"}},
+			Response: AchievementsStatus{}},
+	}},
+	{"/api/day-night", []apiOperation{
+		{ID: "getDayNight", Method: http.MethodGet, Summary: "The world clock and the daylight on each row of the grid", Response: DayNightStatus{}},
+	}},
+	{"/api/season", []apiOperation{
+		{ID: "getSeason", Method: http.MethodGet, Summary: "The current season, what it does to the world and the creatures away on migration", Response: SeasonStatus{}},
+	}},
+	{"/api/audio", []apiOperation{
+		{ID: "getAudio", Method: http.MethodGet, Summary: "The soundscape: rain, wind, bird calls and the fights just heard", Response: AmbientAudio{}},
+	}},
+	{"/api/migration", []apiOperation{
+		{ID: "getMigration", Method: http.MethodGet, Summary: "Herds on the move, the routes each species has learned and the journeys made", Response: MigrationStatus{}},
+	}},
+	{"/api/resources", []apiOperation{
+		{ID: "getResources", Method: http.MethodGet, Summary: "Memory footprint of each store", Response: ResourceReport{}},
+		{ID: "setResourcePolicy", Method: http.MethodPost, Summary: "Change a store's pruning policy or prune it now",
+			Body: ResourcePolicyRequest{}, Response: ResourceReport{}},
+	}},
+	{"/api/timescales", []apiOperation{
+		{ID: "getTimescales", Method: http.MethodGet, Summary: "Audit of every time constant", Response: TimescaleAudit{}},
+		{ID: "rescaleTimescales", Method: http.MethodPost, Summary: "Rescale a group of time constants together",
+			Body: TimescaleRequest{}, Response: TimescaleAudit{}},
+	}},
+	{"/api/traits", []apiOperation{
+		{ID: "listTraits", Method: http.MethodGet, Summary: "Trait definitions with their ranges",
+			Params:   []apiParam{{Name: "subject", Type: "string", Description: "creature or plant; both when empty"}},
+			Response: []TraitDefinition(nil)},
+	}},
+	{"/api/traits/legend", []apiOperation{
+		{ID: "getTraitLegend", Method: http.MethodGet, Summary: "How the views size and colour creatures by their traits", Response: TraitLegend{}},
+	}},
+	{"/api/legend", []apiOperation{
+		{ID: "getLegend", Method: http.MethodGet, Summary: "The symbols and colours each renderer draws biomes, creatures, plants, events and structures with",
+			Params:   []apiParam{{Name: "renderer", Type: "string", Description: "cli, web or iso; every renderer when empty"}},
+			Response: []RendererLegend(nil)},
+	}},
+	{"/api/mutations", []apiOperation{
+		{ID: "getMutations", Method: http.MethodGet, Summary: "Mutation operators and their recent changes", Response: MutationReport{}},
+		{ID: "setMutationRate", Method: http.MethodPost, Summary: "Change a mutation operator's rate",
+			Body: MutationRateRequest{}, Response: MutationReport{}},
+	}},
+	{"/api/selection", []apiOperation{
+		{ID: "getSelection", Method: http.MethodGet, Summary: "Selection strategies and the one each population uses", Response: SelectionReport{}},
+		{ID: "setSelectionStrategy", Method: http.MethodPost, Summary: "Change how a population picks its parents",
+			Body: SelectionStrategyRequest{}, Response: SelectionReport{}},
+	}},
+	{"/api/fitness-landscape", []apiOperation{
+		{ID: "getFitnessLandscape", Method: http.MethodGet, Summary: "Sample the fitness landscape over two traits",
+			Params: []apiParam{
+				{Name: "species", Type: "string", Description: "Species whose members are plotted on the landscape"},
+				{Name: "x", Type: "string", Description: "Trait on the X axis"},
+				{Name: "y", Type: "string", Description: "Trait on the Y axis"},
+				{Name: "resolution", Type: "integer", Description: "Samples along each axis"},
+				{Name: "radius", Type: "integer", Description: "Grid cells around the species sampled as habitat"},
+			},
+			Response: (*FitnessLandscape)(nil)},
+	}},
+	{"/api/ancestry", []apiOperation{
+		{ID: "getAncestry", Method: http.MethodGet, Summary: "How a living species' traits changed along its lineage",
+			Params: []apiParam{
+				{Name: "species", Type: "string", Description: "Living species", Required: true},
+				{Name: "traits", Type: "string", Description: "Comma-separated traits to follow"},
+				{Name: "frames", Type: "integer", Description: "Number of frames to reconstruct"},
+			},
+			Response: (*AncestralHistory)(nil)},
+	}},
+	{"/api/phylogeny", []apiOperation{
+		{ID: "getPhylogeny", Method: http.MethodGet, Summary: "Phylogenetic tree of plant species, living and extinct",
+			Params:   []apiParam{{Name: "format", Type: "string", Description: "json (default) or newick"}},
+			Response: (*Phylogeny)(nil), Alternates: []string{"text/x-nh"}},
+	}},
+	{"/api/history", []apiOperation{
+		{ID: "getMetricHistory", Method: http.MethodGet, Summary: "Population, fitness, energy and trait history for charts",
+			Params: []apiParam{
+				{Name: "metrics", Type: "string", Description: "Comma-separated metrics, e.g. population,trait:herbivores:speed; a trailing * matches every metric it starts", Required: true},
+				{Name: "from", Type: "integer", Description: "First tick; the oldest sample when empty"},
+				{Name: "to", Type: "integer", Description: "Last tick; the current tick when empty"},
+				{Name: "points", Type: "integer", Description: "Points each series is averaged down to (default 500, at most 5000)"},
+			},
+			Response: (*MetricHistoryChart)(nil)},
+	}},
+	{"/api/history/metrics", []apiOperation{
+		{ID: "getMetricHistoryIndex", Method: http.MethodGet, Summary: "Metrics held in the history, with their sample counts and ranges", Response: (*MetricHistoryIndex)(nil)},
+	}},
+	{"/api/red-queen", []apiOperation{
+		{ID: "getRedQueen", Method: http.MethodGet, Summary: "Arms races between interacting species", Response: []RedQueenReport(nil)},
+	}},
+	{"/api/niche-overlap", []apiOperation{
+		{ID: "getNicheOverlap", Method: http.MethodGet, Summary: "Diet, habitat and activity overlap of every pair of species", Response: NicheOverlapReport{}},
+	}},
+	{"/api/biome-transitions", []apiOperation{
+		{ID: "getBiomeTransitions", Method: http.MethodGet, Summary: "Biome state machine rules and recent transitions", Response: BiomeStateReport{}},
+	}},
+	{"/api/microclimates", []apiOperation{
+		{ID: "getMicroclimates", Method: http.MethodGet, Summary: "Cells whose tree cover and structures set their conditions", Response: MicroclimateReport{}},
+	}},
+	{"/api/pollution", []apiOperation{
+		{ID: "getPollution", Method: http.MethodGet, Summary: "Soil and water contamination and the tribes responsible", Response: PollutionReport{}},
+	}},
+	{"/api/light-pollution", []apiOperation{
+		{ID: "getLightPollution", Method: http.MethodGet, Summary: "Settlements lit at night and their effect on wildlife", Response: LightPollutionReport{}},
+	}},
+	{"/api/noise-pollution", []apiOperation{
+		{ID: "getNoisePollution", Method: http.MethodGet, Summary: "Disturbance zones around colonies and paths", Response: NoisePollutionReport{}},
+	}},
+	{"/api/zoonoses", []apiOperation{
+		{ID: "getZoonoses", Method: http.MethodGet, Summary: "Pathogen spillover between wildlife and colonies", Response: ZoonosisReport{}},
+	}},
+	{"/api/food-storage", []apiOperation{
+		{ID: "getFoodStorage", Method: http.MethodGet, Summary: "Tribes' food stores, spoilage and preservation", Response: FoodStorageReport{}},
+	}},
+	{"/api/demographics", []apiOperation{
+		{ID: "getDemographics", Method: http.MethodGet, Summary: "Population pyramids of every colony and species", Response: DemographicsReport{}},
+	}},
+	{"/api/census", []apiOperation{
+		{ID: "getCensus", Method: http.MethodGet, Summary: "Living creatures grouped by dimensions with mean metrics",
+			Params: []apiParam{
+				{Name: "by", Type: "string", Description: "Comma-separated dimensions: " + strings.Join(censusDimensions, ", ")},
+				{Name: "metrics", Type: "string", Description: "Comma-separated metrics: count, energy, age or creature traits"},
+			},
+			Response: CensusResult{}},
+	}},
+	{"/api/graphql", []apiOperation{
+		{ID: "getGraphQLSchema", Method: http.MethodGet, Summary: "The GraphQL schema; with ?query= and ?variables= runs a query instead",
+			Response: "", ContentType: "text/plain"},
+		{ID: "queryGraphQL", Method: http.MethodPost, Summary: "Run a GraphQL query over the world",
+			Body: GraphQLRequest{}, Response: GraphQLResponse{}},
+	}},
+	{"/api/branches", []apiOperation{
+		{ID: "listBranches", Method: http.MethodGet, Summary: "Compare every branch with the live world", Response: []BranchComparison(nil)},
+		{ID: "forkBranch", Method: http.MethodPost, Summary: "Fork the live world into a new branch",
+			Body: BranchForkRequest{}, Response: BranchComparison{}, Status: http.StatusCreated},
+	}},
+	{"/api/branches/branch", []apiOperation{
+		{ID: "diffBranch", Method: http.MethodGet, Summary: "Diff a branch against the live world",
+			Params: []apiParam{{Name: "id", Type: "integer", Description: "Branch", Required: true}}, Response: apiDiffResponse},
+		{ID: "updateBranch", Method: http.MethodPost, Summary: "Pause or resume a branch",
+			Params: []apiParam{{Name: "id", Type: "integer", Description: "Branch", Required: true}},
+			Body:   BranchUpdateRequest{}, Response: BranchComparison{}},
+		{ID: "deleteBranch", Method: http.MethodDelete, Summary: "Discard a branch",
+			Params: []apiParam{{Name: "id", Type: "integer", Description: "Branch", Required: true}}, Status: http.StatusNoContent},
+	}},
+}
+
+// apiMessage is a message sent over the WebSocket at /ws
+type apiMessage struct {
+	Name    string // The action or type the message is told apart by
+	Summary string
+	Payload interface{}
+}
+
+// apiClientAction describes a client command, sent as {action, data}. Commands read only
+// the data they need, so every property of the data is optional.
+func apiClientAction(action, summary string, data interface{}) apiMessage {
+	payload := apiObject{{"action", apiConst(action)}}
+	if properties, ok := data.(apiObject); ok {
+		optional := make(apiObject, len(properties))
+		for i, property := range properties {
+			optional[i] = apiProperty{property.Name, apiOptional{property.Value}}
+		}
+		data = optional
+	}
+	if data != nil {
+		payload = append(payload, apiProperty{"data", data})
+	}
+	return apiMessage{Name: action, Summary: summary, Payload: payload}
+}
+
+// apiServerMessage describes a typed message from the server, sent as {type, ...}
+func apiServerMessage(kind, summary string, properties ...apiProperty) apiMessage {
+	payload := append(apiObject{{"type", apiConst(kind)}}, properties...)
+	return apiMessage{Name: kind, Summary: summary, Payload: payload}
+}
+
+// apiDiplomacyData is the data of the diplomacy actions
+var apiDiplomacyData = apiObject{{"player_id", ""}, {"species", ""}, {"accept", false}}
+
+// apiClientMessages lists the messages a client can send over the WebSocket
+var apiClientMessages = []apiMessage{
+	apiClientAction("join_as_player", "Join the game as a player", apiObject{{"name", ""}}),
+	apiClientAction("create_species", "Create a species, optionally adjusting its traits", apiObject{{"name", ""}, {"traits", map[string]float64(nil)}}),
+	apiClientAction("control_species", "Order a species to move, gather or reproduce",
+		apiObject{{"species", ""}, {"command", ""}, {"x", 0.0}, {"y", 0.0}}),
+	apiClientAction("select_group", "Select a species' creatures as a group", apiObject{{"species", ""}, {"formation", ""}}),
+	apiClientAction("group_command", "Give a group a standing order: move, guard, gather, stop or disband",
+		apiObject{{"group_id", 0}, {"command", ""}, {"formation", ""}, {"x", 0.0}, {"y", 0.0}, {"radius", 0.0}}),
+	apiClientAction("set_species_policy", "Set a species' policy sliders",
+		apiObject{{"species", ""}, {"aggression", 0.0}, {"exploration", 0.0}, {"reproduction", 0.0}}),
+	apiClientAction("list_players", "Ask for the player list", nil),
+	apiClientAction("propose_alliance", "Propose an alliance to a player", apiDiplomacyData),
+	apiClientAction("respond_alliance", "Accept or refuse a player's alliance", apiDiplomacyData),
+	apiClientAction("leave_alliance", "Leave an alliance with a player", apiDiplomacyData),
+	apiClientAction("grant_co_control", "Let an ally control one of your species", apiDiplomacyData),
+	apiClientAction("revoke_co_control", "Take back an ally's control of a species", apiDiplomacyData),
+	apiClientAction("gift_species", "Give one of your species to an ally", apiDiplomacyData),
+	apiClientAction("toggle_pause", "Pause or resume the simulation", nil),
+	apiClientAction("reset", "Reset the world", nil),
+	apiClientAction("save_state", "Save the simulation on the server", nil),
+	apiClientAction("load_state", "Load a saved simulation", (*SimulationState)(nil)),
+	apiClientAction("increase_speed", "Speed the simulation up", nil),
+	apiClientAction("decrease_speed", "Slow the simulation down", nil),
+	apiClientAction("set_speed", "Set the speed multiplier", apiObject{{"speed", 0.0}}),
+	apiClientAction("set_unlimited_speed", "Run as fast as possible or follow the speed multiplier", apiObject{{"enabled", false}}),
+	apiClientAction("set_max_cpu", "Cap the share of CPU time spent simulating", apiObject{{"percent", 0.0}}),
+	apiClientAction("follow_entity", "Lock the viewport onto a creature, streaming what it does each tick", FollowRequest{}),
+	apiClientAction("unfollow_entity", "Let the viewport move freely again", nil),
+	apiClientAction("pan", "Move the viewport by a number of cells, letting go of a followed creature", apiObject{{"deltaX", 0}, {"deltaY", 0}}),
+	apiClientAction("zoom", "Set the zoom level", apiObject{{"zoom", 0.0}}),
+	apiClientAction("zoom_in", "Zoom in", nil),
+	apiClientAction("zoom_out", "Zoom out", nil),
+	apiClientAction("reset_viewport", "Reset the viewport", nil),
+	apiClientAction("subscribe_chunks", "Stream the grid chunks covering an area instead of the viewport's grid", ChunkSubscription{}),
+	apiClientAction("unsubscribe_chunks", "Go back to the viewport's grid", nil),
+	apiClientAction("build_structure", "Build a barrier or corridor", OperatorStructureRequest{}),
+	apiClientAction("undo_intervention", "Undo the latest intervention", nil),
+	apiClientAction("redo_intervention", "Redo the latest undone intervention", nil),
+	{Name: "get_isometric_data", Summary: "Ask for the isometric view of an area", Payload: apiObject{
+		{"type", apiConst("get_isometric_data")}, {"viewportX", apiOptional{0}}, {"viewportY", apiOptional{0}},
+		{"zoom", apiOptional{0.0}}, {"maxTiles", apiOptional{0}},
+	}},
+}
+
+// apiServerMessages lists the messages the server sends over the WebSocket
+var apiServerMessages = []apiMessage{
+	{Name: "view_update", Summary: "A frame of the simulation, sent about ten times a second", Payload: ViewData{}},
+	apiServerMessage("error", "An explanation of a refused request", apiProperty{"message", ""}),
+	apiServerMessage("isometric", "The isometric view asked for", apiProperty{"data", (*IsometricViewData)(nil)}),
+	apiServerMessage("player_joined", "Confirmation of joining as a player",
+		apiProperty{"player_id", ""}, apiProperty{"name", ""}, apiProperty{"message", ""}),
+	apiServerMessage("species_created", "Confirmation that the player's species was created",
+		apiProperty{"species_name", ""}, apiProperty{"message", ""}, apiProperty{"traits", map[string]float64(nil)}),
+	apiServerMessage("command_executed", "Confirmation of a species or group command",
+		apiProperty{"command", ""}, apiProperty{"message", ""}, apiProperty{"group_id", apiOptional{0}},
+		apiProperty{"entities_affected", apiOptional{0}}, apiProperty{"offspring", apiOptional{0}}),
+	apiServerMessage("group_selected", "Confirmation of a group selection",
+		apiProperty{"group_id", 0}, apiProperty{"size", 0}, apiProperty{"formation", ""}, apiProperty{"message", ""}),
+	apiServerMessage("species_policy_updated", "Confirmation of a species policy change",
+		apiProperty{"species", ""}, apiProperty{"policy", (*SpeciesPolicy)(nil)}, apiProperty{"message", ""}),
+	apiServerMessage("diplomacy", "The other players and the species the player controls",
+		apiProperty{"message", ""}, apiProperty{"species", []string(nil)}, apiProperty{"players", []apiObject{{
+			{"id", ""}, {"name", ""}, {"active", false}, {"allied", false}, {"requested", false}, {"pending", false},
+		}}}),
+	apiServerMessage("species_extinct", "Notice that one of the player's species died out",
+		apiProperty{"species_name", ""}, apiProperty{"message", ""}, apiProperty{"last_count", 0}, apiProperty{"tick", 0}),
+	apiServerMessage("subspecies_formed", "Notice that one of the player's species split",
+		apiProperty{"species_name", ""}, apiProperty{"parent_species", ""}, apiProperty{"message", ""},
+		apiProperty{"entity_count", 0}, apiProperty{"tick", 0}),
+	apiServerMessage("new_species_detected", "Notice of a new species in the simulation",
+		apiProperty{"species_name", ""}, apiProperty{"message", ""}, apiProperty{"entity_count", 0}, apiProperty{"tick", 0}),
+	apiServerMessage("achievement_unlocked", "Notice that one of the player's species reached a milestone",
+		apiProperty{"species_name", ""}, apiProperty{"milestone", ""}, apiProperty{"name", ""}, apiProperty{"message", ""},
+		apiProperty{"tick", 0}),
+	{Name: "audio", Summary: "The soundscape, sent about once a second", Payload: AmbientAudio{}},
+	apiServerMessage("announcement", "A message the teacher broadcast to a classroom world",
+		apiProperty{"message", ""}, apiProperty{"sent", time.Time{}}),
+}
+
+// apiSchema is a JSON schema, in the subset OpenAPI 3.0 and AsyncAPI 2 share
+type apiSchema struct {
+	Ref                  string                `json:"$ref,omitempty"`
+	Type                 string                `json:"type,omitempty"`
+	Format               string                `json:"format,omitempty"`
+	Enum                 []string              `json:"enum,omitempty"`
+	Items                *apiSchema            `json:"items,omitempty"`
+	Properties           map[string]*apiSchema `json:"properties,omitempty"`
+	Required             []string              `json:"required,omitempty"`
+	AdditionalProperties *apiSchema            `json:"additionalProperties,omitempty"`
+	Nullable             bool                  `json:"nullable,omitempty"`
+
+	order   []string // Property names in declaration order
+	pointer bool     // Whether the Go value is a pointer and so may be null
+}
+
+// refName is the component a schema refers to, or "" if it is not a reference
+func (s *apiSchema) refName() string {
+	return strings.TrimPrefix(s.Ref, apiComponentPrefix)
+}
+
+// apiComponentPrefix starts the reference to a component schema
+const apiComponentPrefix = "#/components/schemas/"
+
+var (
+	apiTimeType      = reflect.TypeOf(time.Time{})
+	apiRawJSONType   = reflect.TypeOf(json.RawMessage{})
+	apiMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// apiSchemaBuilder derives schemas from Go types the way encoding/json encodes them.
+// Named structs become components referred to by name, which also breaks cycles.
+type apiSchemaBuilder struct {
+	components map[string]*apiSchema
+	names      map[reflect.Type]string
+}
+
+// newAPISchemaBuilder creates a builder with no components
+func newAPISchemaBuilder() *apiSchemaBuilder {
+	return &apiSchemaBuilder{components: make(map[string]*apiSchema), names: make(map[reflect.Type]string)}
+}
+
+// value builds the schema of an example value: an apiObject, an apiConst or any Go value
+func (b *apiSchemaBuilder) value(v interface{}) *apiSchema {
+	switch v := v.(type) {
+	case apiObject:
+		schema := &apiSchema{Type: "object", Properties: make(map[string]*apiSchema)}
+		for _, property := range v {
+			value, optional := property.Value.(apiOptional)
+			if optional {
+				schema.Properties[property.Name] = b.value(value.Value)
+			} else {
+				schema.Properties[property.Name] = b.value(property.Value)
+				schema.Required = append(schema.Required, property.Name)
+			}
+			schema.order = append(schema.order, property.Name)
+		}
+		return schema
+	case []apiObject:
+		return &apiSchema{Type: "array", Items: b.value(v[0])}
+	case apiConst:
+		return &apiSchema{Type: "string", Enum: []string{string(v)}}
+	case nil:
+		return &apiSchema{}
+	}
+	return b.schema(reflect.TypeOf(v))
+}
+
+// schema builds the schema of a Go type
+func (b *apiSchemaBuilder) schema(t reflect.Type) *apiSchema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t, nullable = t.Elem(), true
+	}
+	var schema *apiSchema
+	switch {
+	case t == apiTimeType:
+		schema = &apiSchema{Type: "string", Format: "date-time"}
+	case t == apiRawJSONType || t.Implements(apiMarshalerType) || reflect.PointerTo(t).Implements(apiMarshalerType):
+		schema = &apiSchema{}
+	default:
+		switch t.Kind() {
+		case reflect.Bool:
+			schema = &apiSchema{Type: "boolean"}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			schema = &apiSchema{Type: "integer"}
+		case reflect.Float32, reflect.Float64:
+			schema = &apiSchema{Type: "number"}
+		case reflect.String:
+			schema = &apiSchema{Type: "string"}
+		case reflect.Slice, reflect.Array:
+			if t.Elem().Kind() == reflect.Uint8 {
+				schema = &apiSchema{Type: "string", Format: "byte"}
+			} else {
+				schema = &apiSchema{Type: "array", Items: b.schema(t.Elem())}
+			}
+		case reflect.Map:
+			schema = &apiSchema{Type: "object", AdditionalProperties: b.schema(t.Elem())}
+		case reflect.Struct:
+			if t.Name() == "" {
+				schema = &apiSchema{Type: "object", Properties: make(map[string]*apiSchema)}
+				b.fields(schema, t)
+			} else {
+				schema = &apiSchema{Ref: apiComponentPrefix + b.component(t)}
+			}
+		default:
+			schema = &apiSchema{}
+		}
+	}
+	schema.Nullable = nullable && schema.Ref == "" && schema.Type != ""
+	schema.pointer = nullable
+	return schema
+}
+
+// component registers a named struct as a component, filling it in the first time it is
+// seen, and returns its name
+func (b *apiSchemaBuilder) component(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i] // Generic instantiations are named after the generic type
+	}
+	if _, taken := b.components[name]; taken {
+		// A type of the same name from another package, told apart by its package
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	schema := &apiSchema{Type: "object", Properties: make(map[string]*apiSchema)}
+	b.names[t] = name
+	b.components[name] = schema
+	b.fields(schema, t)
+	return name
+}
+
+// fields adds the properties encoding/json writes for a struct's fields, inlining
+// embedded structs
+func (b *apiSchemaBuilder) fields(schema *apiSchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			b.fields(schema, fieldType)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		switch fieldType.Kind() {
+		case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, exists := schema.Properties[name]; !exists {
+			schema.order = append(schema.order, name)
+		}
+		schema.Properties[name] = b.schema(field.Type)
+		if !strings.Contains(","+options+",", ",omitempty,") && field.Type.Kind() != reflect.Ptr {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// OpenAPISpec describes the HTTP API as an OpenAPI 3.0 document
+func OpenAPISpec() map[string]interface{} {
+	b := newAPISchemaBuilder()
+	errorResponse := map[string]interface{}{
+		"description": "The request was refused; the body says why",
+		"content":     map[string]interface{}{"text/plain": map[string]interface{}{"schema": &apiSchema{Type: "string"}}},
+	}
+
+	paths := make(map[string]interface{})
+	for _, endpoint := range apiEndpoints {
+		operations := make(map[string]interface{})
+		for _, op := range endpoint.Operations {
+			operation := map[string]interface{}{"operationId": op.ID, "summary": op.Summary}
+			if len(op.Params) > 0 {
+				params := make([]map[string]interface{}, 0, len(op.Params))
+				for _, param := range op.Params {
+					in := "query"
+					if param.InPath {
+						in = "path"
+					}
+					params = append(params, map[string]interface{}{
+						"name": param.Name, "in": in, "required": param.Required || param.InPath,
+						"description": param.Description, "schema": &apiSchema{Type: param.Type},
+					})
+				}
+				operation["parameters"] = params
+			}
+			if op.Body != nil {
+				operation["requestBody"] = map[string]interface{}{
+					"required": true,
+					"content":  map[string]interface{}{"application/json": map[string]interface{}{"schema": b.value(op.Body)}},
+				}
+			}
+			response := map[string]interface{}{"description": http.StatusText(op.status())}
+			if op.Response != nil {
+				content := map[string]interface{}{op.contentType(): map[string]interface{}{"schema": b.value(op.Response)}}
+				for _, alternate := range op.Alternates {
+					content[alternate] = map[string]interface{}{"schema": &apiSchema{Type: "string"}}
+				}
+				response["content"] = content
+			}
+			operation["responses"] = map[string]interface{}{
+				strconv.Itoa(op.status()): response,
+				"default":                 errorResponse,
+			}
+			operations[strings.ToLower(op.Method)] = operation
+		}
+		paths[endpoint.Path] = operations
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "EvoSim API",
+			"version": apiVersion,
+			"description": "HTTP API of the EvoSim web interface. Live updates and player commands " +
+				"travel over the WebSocket at /ws, described by the AsyncAPI document at /api/spec/asyncapi.",
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": b.components},
+	}
+}
+
+// AsyncAPISpec describes the WebSocket protocol at /ws as an AsyncAPI 2.6 document
+func AsyncAPISpec() map[string]interface{} {
+	b := newAPISchemaBuilder()
+	messages := make(map[string]interface{})
+	refs := func(list []apiMessage) []map[string]string {
+		oneOf := make([]map[string]string, 0, len(list))
+		for _, message := range list {
+			messages[message.Name] = map[string]interface{}{
+				"name": message.Name, "summary": message.Summary, "payload": b.value(message.Payload),
+			}
+			oneOf = append(oneOf, map[string]string{"$ref": "#/components/messages/" + message.Name})
+		}
+		return oneOf
+	}
+
+	return map[string]interface{}{
+		"asyncapi": "2.6.0",
+		"info": map[string]interface{}{
+			"title":   "EvoSim WebSocket API",
+			"version": apiVersion,
+			"description": "Live simulation frames and player commands. Clients send {action, data} " +
+				"commands; the server sends view updates and messages told apart by their type.",
+		},
+		"defaultContentType": "application/json",
+		"channels": map[string]interface{}{
+			"/ws": map[string]interface{}{
+				"publish":   map[string]interface{}{"operationId": "sendCommand", "message": map[string]interface{}{"oneOf": refs(apiClientMessages)}},
+				"subscribe": map[string]interface{}{"operationId": "receiveUpdate", "message": map[string]interface{}{"oneOf": refs(apiServerMessages)}},
+			},
+		},
+		"components": map[string]interface{}{"schemas": b.components, "messages": messages},
+	}
+}
+
+// status is the status an operation answers with on success
+func (op apiOperation) status() int {
+	if op.Status == 0 {
+		return http.StatusOK
+	}
+	return op.Status
+}
+
+// contentType is the content type of an operation's response
+func (op apiOperation) contentType() string {
+	if op.ContentType == "" {
+		return "application/json"
+	}
+	return op.ContentType
+}
+
+// textResponse reports whether clients read the response as text rather than decoding JSON
+func (op apiOperation) textResponse() bool {
+	return strings.HasPrefix(op.contentType(), "text/") || strings.HasSuffix(op.contentType(), "+xml")
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/GoCodeAlone/evosim/sdk/go/evosim"
+)
+
+func TestAPISpecCoversEveryRoute(t *testing.T) {
+	source, err := os.ReadFile("web_interface.go")
+	if err != nil {
+		t.Fatalf("Failed to read the web interface: %v", err)
+	}
+	registered := make(map[string]bool)
+	for _, match := range regexp.MustCompile(`mux\.HandleFunc\("(/api/[^"]*)"`).FindAllStringSubmatch(string(source), -1) {
+		registered[match[1]] = true
+	}
+
+	ids := make(map[string]bool)
+	for _, endpoint := range apiEndpoints {
+		if !registered[endpoint.Path] {
+			t.Errorf("Expected %s to be a registered route", endpoint.Path)
+		}
+		delete(registered, endpoint.Path)
+		for _, op := range endpoint.Operations {
+			if ids[op.ID] {
+				t.Errorf("Expected operation IDs to be unique, %s is repeated", op.ID)
+			}
+			ids[op.ID] = true
+		}
+	}
+	for path := range registered {
+		t.Errorf("Expected the route %s to be described in the API spec", path)
+	}
+}
+
+func TestAPISpecEndpoints(t *testing.T) {
+	wi := NewWebInterface(newGraphQLTestWorld())
+
+	rec := httptest.NewRecorder()
+	wi.handleAPISpec(rec, httptest.NewRequest(http.MethodGet, "/api/spec", nil))
+	var spec struct {
+		OpenAPI    string                                `json:"openapi"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]apiSchema `json:"schemas"`
+		} `json:"components"`
+	}
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &spec) != nil || spec.OpenAPI != "3.0.3" {
+		t.Fatalf("Expected an OpenAPI document, got %d %s", rec.Code, rec.Body.String())
+	}
+	if _, ok := spec.Paths["/api/branches/branch"]["delete"]; !ok {
+		t.Errorf("Expected every method of an endpoint described, got %v", spec.Paths["/api/branches/branch"])
+	}
+	census := spec.Components.Schemas["CensusResult"]
+	if census.Properties["groups"] == nil || census.Properties["groups"].Items.Ref != apiComponentPrefix+"CensusGroup" {
+		t.Errorf("Expected the census result schema to refer to its groups, got %+v", census)
+	}
+	if pending := spec.Components.Schemas["PetriDishUpdateRequest"]; pending.Properties["temperature"] == nil ||
+		!pending.Properties["temperature"].Nullable || len(pending.Required) != 1 {
+		t.Errorf("Expected optional and pointer fields left out of the required list, got %+v", pending)
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleAsyncAPISpec(rec, httptest.NewRequest(http.MethodGet, "/api/spec/asyncapi", nil))
+	var async struct {
+		Components struct {
+			Messages map[string]json.RawMessage `json:"messages"`
+		} `json:"components"`
+	}
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &async) != nil {
+		t.Fatalf("Expected an AsyncAPI document, got %d %s", rec.Code, rec.Body.String())
+	}
+	for _, name := range []string{"pan", "get_isometric_data", "view_update", "species_extinct"} {
+		if async.Components.Messages[name] == nil {
+			t.Errorf("Expected the %s message described", name)
+		}
+	}
+}
+
+func TestGeneratedSDKIsUpToDate(t *testing.T) {
+	files, err := GenerateSDK()
+	if err != nil {
+		t.Fatalf("Failed to generate the SDK: %v", err)
+	}
+	for name, generated := range files {
+		committed, err := os.ReadFile(filepath.Join("sdk", filepath.FromSlash(name)))
+		if err != nil || !bytes.Equal(committed, generated) {
+			t.Errorf("Expected sdk/%s to match the API; regenerate it with go generate", name)
+		}
+	}
+}
+
+func TestGoSDKAgainstServer(t *testing.T) {
+	wi := NewWebInterface(newGraphQLTestWorld())
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/census", wi.handleCensus)
+	mux.HandleFunc("/api/graphql", wi.handleGraphQL)
+	mux.HandleFunc("/api/entity/{id}", wi.handleEntityInspect)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := evosim.NewClient(server.URL)
+	ctx := context.Background()
+
+	census, err := client.GetCensus(ctx, evosim.GetCensusParams{By: "species", Metrics: "speed"})
+	if err != nil || census.Total != 3 || len(census.Groups) != 2 || census.Groups[0].Key["species"] != "Wolf" {
+		t.Fatalf("Expected a typed census grouped by species, got %+v (%v)", census, err)
+	}
+
+	schema, err := client.GetGraphQLSchema(ctx)
+	if err != nil || !regexp.MustCompile(`type Species \{`).MatchString(schema) {
+		t.Errorf("Expected the GraphQL schema as text, got %q (%v)", schema, err)
+	}
+	response, err := client.QueryGraphQL(ctx, &evosim.GraphQLRequest{Query: "{ tick }"})
+	if err != nil || len(response.Errors) != 0 {
+		t.Errorf("Expected a GraphQL query answered, got %+v (%v)", response, err)
+	}
+
+	var refused *evosim.Error
+	if _, err := client.InspectEntity(ctx, "99"); !errors.As(err, &refused) || refused.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected a missing entity reported as a 404, got %v", err)
+	}
+}
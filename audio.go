@@ -0,0 +1,110 @@
+package main
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	audioPeriod           = time.Second // How often the mix is sent to web clients
+	audioFightWindow      = 20          // Ticks a fight is heard for
+	maxAudioCues          = 16
+	audioCallsPerCreature = 0.02 // Bird calls a second for each living creature
+	maxAudioCalls         = 8.0
+)
+
+// AudioCue is a sound heard once, where it happened
+type AudioCue struct {
+	Sound string  `json:"sound"` // "battle"
+	Tick  int     `json:"tick"`
+	X     float64 `json:"x"` // Across the map, from 0 at the left edge to 1 at the right, for panning
+	Y     float64 `json:"y"` // Down the map, from 0 at the top to 1 at the bottom
+}
+
+// AmbientAudio is the world's soundscape: levels for the continuous sounds and cues for the
+// ones heard once. Web clients receive it as an "audio" message about once a second.
+type AmbientAudio struct {
+	Type       string     `json:"type"` // Always "audio"
+	Tick       int        `json:"tick"`
+	Rain       float64    `json:"rain"`       // 0 to 1
+	Wind       float64    `json:"wind"`       // 0 to 1
+	BirdCalls  float64    `json:"bird_calls"` // Calls a second, in proportion to the living creatures
+	Population int        `json:"population"`
+	Wars       int        `json:"wars"` // Colony wars being fought
+	Cues       []AudioCue `json:"cues"` // Fights of the last few ticks, oldest first
+}
+
+// AmbientAudioSystem hears the fights of the world and mixes its soundscape
+type AmbientAudioSystem struct {
+	fights []AudioCue
+}
+
+// NewAmbientAudioSystem creates a soundscape with nothing heard yet
+func NewAmbientAudioSystem() *AmbientAudioSystem {
+	return &AmbientAudioSystem{}
+}
+
+// Restart forgets the fights heard, for a world that starts over
+func (a *AmbientAudioSystem) Restart() {
+	a.fights = nil
+}
+
+// RecordFight hears a fight at a place on the map
+func (a *AmbientAudioSystem) RecordFight(w *World, position Position) {
+	a.fights = append(a.fights, AudioCue{
+		Sound: "battle",
+		Tick:  w.Tick,
+		X:     math.Max(0, math.Min(1, position.X/w.Config.Width)),
+		Y:     math.Max(0, math.Min(1, position.Y/w.Config.Height)),
+	})
+	for len(a.fights) > 0 && w.Tick-a.fights[0].Tick >= audioFightWindow {
+		a.fights = a.fights[1:]
+	}
+	if len(a.fights) > maxAudioCues {
+		a.fights = a.fights[len(a.fights)-maxAudioCues:]
+	}
+}
+
+// Mix works out the soundscape of the world now: rain from the season and the storms, wind
+// from the wind system, bird calls from the population and the fights of the last few ticks
+func (a *AmbientAudioSystem) Mix(w *World) AmbientAudio {
+	mix := AmbientAudio{Type: "audio", Tick: w.Tick, Cues: []AudioCue{}}
+
+	// The season's showers patter lightly; storms pour
+	mix.Rain = seasonalRainfall(w.AdvancedTimeSystem.Season) * 10
+	for _, event := range w.EnvironmentalEvents {
+		if event.Type == "storm" || event.Type == "hurricane" {
+			mix.Rain += event.Intensity
+		}
+	}
+	if w.WindSystem != nil {
+		wind := w.WindSystem
+		mix.Wind = wind.BaseWindStrength*wind.SeasonalMultiplier + 0.15*float64(wind.WeatherPattern)
+		if wind.WeatherPattern >= 2 {
+			mix.Rain += 0.2
+		}
+	}
+	mix.Rain = math.Min(1, mix.Rain)
+	mix.Wind = math.Min(1, mix.Wind)
+
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive {
+			mix.Population++
+		}
+	}
+	mix.BirdCalls = math.Min(maxAudioCalls, float64(mix.Population)*audioCallsPerCreature)
+
+	if w.ColonyWarfareSystem != nil {
+		for _, conflict := range w.ColonyWarfareSystem.ActiveConflicts {
+			if conflict.IsActive {
+				mix.Wars++
+			}
+		}
+	}
+	for _, fight := range a.fights {
+		if w.Tick-fight.Tick < audioFightWindow {
+			mix.Cues = append(mix.Cues, fight)
+		}
+	}
+	return mix
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSoundscapeFollowsTheWorld(t *testing.T) {
+	world := newSteppingTestWorld(111)
+	world.EnvironmentalEvents = nil
+	world.AdvancedTimeSystem.Season = Summer
+	calm := world.Audio.Mix(world)
+	if calm.Type != "audio" || calm.Population != len(world.AllEntities) || len(calm.Cues) != 0 {
+		t.Fatalf("Expected every creature heard and no fights, got %+v", calm)
+	}
+	if want := float64(calm.Population) * audioCallsPerCreature; calm.BirdCalls != want {
+		t.Errorf("Expected %.2f bird calls a second for %d creatures, got %.2f", want, calm.Population, calm.BirdCalls)
+	}
+
+	// Half the creatures make half the calls
+	for _, entity := range world.AllEntities[:calm.Population/2] {
+		entity.IsAlive = false
+	}
+	if fewer := world.Audio.Mix(world); fewer.BirdCalls >= calm.BirdCalls || fewer.Population != calm.Population-calm.Population/2 {
+		t.Errorf("Expected fewer calls from fewer creatures, got %.2f", fewer.BirdCalls)
+	}
+
+	world.EnvironmentalEvents = append(world.EnvironmentalEvents, &EnhancedEnvironmentalEvent{Type: "storm", Intensity: 0.9})
+	if stormy := world.Audio.Mix(world); stormy.Rain <= calm.Rain || stormy.Rain > 1 {
+		t.Errorf("Expected a storm to bring heavier rain, got %.2f after %.2f", stormy.Rain, calm.Rain)
+	}
+
+	// A kill is heard where it happened, and only for a while
+	predator, prey := world.AllEntities[len(world.AllEntities)-1], world.AllEntities[len(world.AllEntities)-2]
+	prey.Position = Position{X: world.Config.Width * 3 / 4, Y: 0}
+	world.recordPredation(predator, prey)
+	cues := world.Audio.Mix(world).Cues
+	if len(cues) != 1 || cues[0].Sound != "battle" || cues[0].X != 0.75 || cues[0].Tick != world.Tick {
+		t.Fatalf("Expected the fight heard three quarters across the map, got %+v", cues)
+	}
+	world.Tick += audioFightWindow
+	if cues := world.Audio.Mix(world).Cues; len(cues) != 0 {
+		t.Errorf("Expected an old fight no longer heard, got %+v", cues)
+	}
+}
+
+func TestSoundscapeReachesWebClients(t *testing.T) {
+	wi := NewWebInterface(newSteppingTestWorld(112))
+	conn, closeConn := dialTestWebSocket(t, wi.handleWebSocketUpgrade)
+	defer closeConn()
+
+	// The client may join after the first message, so keep sending until it hears the soundscape
+	heard := make(chan AmbientAudio, 1)
+	go func() {
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var mix AmbientAudio
+			if json.Unmarshal(message, &mix) == nil && mix.Type == "audio" {
+				heard <- mix
+				return
+			}
+		}
+	}()
+	timeout := time.After(5 * time.Second)
+	for {
+		wi.lastAudio = time.Time{}
+		wi.sendAudio()
+		select {
+		case mix := <-heard:
+			if mix.Population == 0 {
+				t.Errorf("Expected the creatures heard, got %+v", mix)
+			}
+			var status AmbientAudio
+			if code := callControlAPI(t, wi.handleAudio, http.MethodGet, "/api/audio", "", &status); code != http.StatusOK || status.Population != mix.Population {
+				t.Errorf("Expected the same soundscape from the API, got %d %+v", code, status)
+			}
+			return
+		case <-timeout:
+			t.Fatal("Expected the soundscape sent over the WebSocket")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
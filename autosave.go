@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Files kept in the autosave directory. Autosave names start with the time they were written,
+// so they sort oldest first.
+const (
+	autosaveSessionFile = "session.json"
+	autosavePrefix      = "autosave-"
+	autosaveTimeLayout  = "20060102-150405.000"
+)
+
+// defaultAutosaveKeep is how many autosaves a run keeps before removing the oldest
+const defaultAutosaveKeep = 5
+
+// How an autosaved session ended. A session still marked running when the next run starts
+// crashed or was killed.
+const (
+	AutosaveRunning     = "running"
+	AutosaveInterrupted = "interrupted" // Stopped by Ctrl+C or SIGTERM
+	AutosaveFinished    = "finished"    // Quit, or ran to its end
+	AutosaveDismissed   = "dismissed"   // Its recovery was declined or taken up
+)
+
+// How a run treats an autosave it could resume
+const (
+	ResumeAsk    = "ask"    // Prompt in the terminal, or offer it in the web page
+	ResumeAlways = "always" // Resume without asking
+	ResumeNever  = "never"  // Start a new world
+)
+
+// resumeModes lists the --resume choices
+var resumeModes = []string{ResumeAsk, ResumeAlways, ResumeNever}
+
+// AutosaveSession records a run's autosaves and how it ended, so the next run can tell a
+// crash or Ctrl+C from a finished run
+type AutosaveSession struct {
+	Status        string     `json:"status"`
+	Started       time.Time  `json:"started"`
+	Ended         *time.Time `json:"ended,omitempty"`
+	LastSave      string     `json:"last_save,omitempty"` // File in the autosave directory
+	LastSavedTick int        `json:"last_saved_tick"`     // -1 before the first save
+	LastSaved     *time.Time `json:"last_saved,omitempty"`
+}
+
+// AutosaveRecovery is an autosave left by a run that crashed or was interrupted
+type AutosaveRecovery struct {
+	File    string    `json:"file"`
+	Tick    int       `json:"tick"`
+	SavedAt time.Time `json:"saved_at"`
+	Status  string    `json:"status"` // How the run ended: running if it crashed
+}
+
+// Describe says what the autosave is, for the offer to resume it
+func (r *AutosaveRecovery) Describe() string {
+	ended := "was interrupted"
+	if r.Status == AutosaveRunning {
+		ended = "did not shut down cleanly"
+	}
+	return fmt.Sprintf("The last run %s; its autosave %s is from tick %d (%s)",
+		ended, r.File, r.Tick, r.SavedAt.Format(time.RFC3339))
+}
+
+// Autosaver writes a world to a rotating set of files in a directory every Interval ticks,
+// keeping the newest Keep. The world runs it at the end of each tick.
+type Autosaver struct {
+	Dir      string
+	Interval int    // Ticks between autosaves, 0 to only save when interrupted
+	Keep     int    // Autosaves kept
+	Format   string // Save format, JSON when empty
+	// Autosave kept however old, such as one from the last run still offered to resume
+	Protected string
+	session   AutosaveSession
+	lastTick  int
+}
+
+// NewAutosaver creates an autosaver writing to a directory
+func NewAutosaver(dir string, interval, keep int, format string) (*Autosaver, error) {
+	if interval < 0 {
+		return nil, fmt.Errorf("autosave interval must not be negative")
+	}
+	if keep < 1 {
+		return nil, fmt.Errorf("at least one autosave must be kept")
+	}
+	if _, err := ResolveSaveFormat("", format); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create the autosave directory: %v", err)
+	}
+	return &Autosaver{Dir: dir, Interval: interval, Keep: keep, Format: format}, nil
+}
+
+// Begin records a new session for the world, marked running until End
+func (a *Autosaver) Begin(w *World) error {
+	a.lastTick = w.Tick
+	a.session = AutosaveSession{Status: AutosaveRunning, Started: time.Now(), LastSavedTick: -1}
+	return writeAutosaveSession(a.Dir, a.session)
+}
+
+// Update autosaves the world once it has run the interval since the last autosave. A failed
+// autosave is logged rather than stopping the simulation.
+func (a *Autosaver) Update(w *World) {
+	if w.Tick < a.lastTick {
+		// A save was loaded from before the last autosave
+		a.lastTick = w.Tick
+	}
+	if a.Interval <= 0 || w.Tick-a.lastTick < a.Interval {
+		return
+	}
+	if _, err := a.Save(w); err != nil {
+		log.Printf("Autosave failed: %v", err)
+	}
+}
+
+// Save autosaves the world now and removes the oldest autosaves beyond Keep. The file is
+// written beside its name and swapped in, so a crash mid-write never leaves a torn autosave.
+func (a *Autosaver) Save(w *World) (string, error) {
+	a.lastTick = w.Tick
+	now := time.Now()
+	name := fmt.Sprintf("%s%s-tick%08d%s", autosavePrefix, now.Format(autosaveTimeLayout), w.Tick, SaveFileExtension(a.Format))
+	path := filepath.Join(a.Dir, name)
+
+	manager := NewStateManager(w)
+	manager.Format = a.Format
+	if err := manager.WriteStateFile(path + ".tmp"); err != nil {
+		return "", err
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return "", fmt.Errorf("failed to write autosave: %v", err)
+	}
+
+	saves, err := listAutosaves(a.Dir)
+	if err != nil {
+		return "", err
+	}
+	for excess := len(saves) - a.Keep; excess > 0 && len(saves) > 0; saves = saves[1:] {
+		if filepath.Join(a.Dir, saves[0]) == a.Protected {
+			continue
+		}
+		if err := os.Remove(filepath.Join(a.Dir, saves[0])); err != nil {
+			return "", fmt.Errorf("failed to remove an old autosave: %v", err)
+		}
+		excess--
+	}
+
+	a.session.LastSave = name
+	a.session.LastSavedTick = w.Tick
+	a.session.LastSaved = &now
+	return path, writeAutosaveSession(a.Dir, a.session)
+}
+
+// End records how the session ended. An interrupted session is autosaved first, so resuming
+// it loses nothing.
+func (a *Autosaver) End(w *World, status string) error {
+	if status == AutosaveInterrupted && w.Tick != a.session.LastSavedTick {
+		if _, err := a.Save(w); err != nil {
+			return err
+		}
+	}
+	now := time.Now()
+	a.session.Status = status
+	a.session.Ended = &now
+	return writeAutosaveSession(a.Dir, a.session)
+}
+
+// Session returns the current session's record
+func (a *Autosaver) Session() AutosaveSession {
+	return a.session
+}
+
+// FindAutosaveRecovery returns the latest autosave in a directory if the run that wrote it
+// crashed or was interrupted, or nil when there is nothing to resume
+func FindAutosaveRecovery(dir string) (*AutosaveRecovery, error) {
+	session, err := readAutosaveSession(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if (session.Status != AutosaveRunning && session.Status != AutosaveInterrupted) || session.LastSave == "" || session.LastSaved == nil {
+		return nil, nil
+	}
+	file := filepath.Join(dir, session.LastSave)
+	if _, err := os.Stat(file); err != nil {
+		return nil, nil
+	}
+	return &AutosaveRecovery{File: file, Tick: session.LastSavedTick, SavedAt: *session.LastSaved, Status: session.Status}, nil
+}
+
+// DismissAutosaveRecovery stops offering a directory's autosave, once it has been resumed or
+// declined. Its files are kept.
+func DismissAutosaveRecovery(dir string) error {
+	session, err := readAutosaveSession(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read the autosave session: %v", err)
+	}
+	session.Status = AutosaveDismissed
+	return writeAutosaveSession(dir, session)
+}
+
+// ResolveResumeMode checks a --resume choice
+func ResolveResumeMode(mode string) (string, error) {
+	for _, known := range resumeModes {
+		if mode == known {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("unknown resume mode %q (expected %s)", mode, strings.Join(resumeModes, ", "))
+}
+
+// askToResume offers a recovery in the terminal, resuming unless the answer is no
+func askToResume(recovery *AutosaveRecovery, in io.Reader, out io.Writer) bool {
+	fmt.Fprintf(out, "%s.\nResume it? [Y/n] ", recovery.Describe())
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "n", "no":
+		return false
+	}
+	return true
+}
+
+// stdinIsTerminal reports whether someone can answer a prompt
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// listAutosaves lists the autosave files in a directory, oldest first
+func listAutosaves(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the autosave directory: %v", err)
+	}
+	var saves []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.Type().IsRegular() && strings.HasPrefix(name, autosavePrefix) && !strings.HasSuffix(name, ".tmp") {
+			saves = append(saves, name)
+		}
+	}
+	sort.Strings(saves)
+	return saves, nil
+}
+
+// readAutosaveSession reads a directory's session record
+func readAutosaveSession(dir string) (AutosaveSession, error) {
+	var session AutosaveSession
+	data, err := os.ReadFile(filepath.Join(dir, autosaveSessionFile))
+	if err != nil {
+		return session, err
+	}
+	if err := json.Unmarshal(data, &session); err != nil {
+		return session, fmt.Errorf("invalid autosave session: %v", err)
+	}
+	return session, nil
+}
+
+// writeAutosaveSession writes a directory's session record, swapped in like the autosaves
+func writeAutosaveSession(dir string, session AutosaveSession) error {
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the autosave session: %v", err)
+	}
+	path := filepath.Join(dir, autosaveSessionFile)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return fmt.Errorf("failed to write the autosave session: %v", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write the autosave session: %v", err)
+	}
+	return nil
+}
+
+// offerAutosaveRecovery resumes the world from the autosave a crashed or interrupted run left
+// in a directory, as the resume mode says. Asking prompts in the terminal when interactive and
+// otherwise returns the recovery still on offer, for the web page to offer. It reports whether
+// the world was resumed.
+func offerAutosaveRecovery(world *World, dir, mode string, interactive bool) (bool, *AutosaveRecovery, error) {
+	if mode == ResumeNever {
+		return false, nil, nil
+	}
+	recovery, err := FindAutosaveRecovery(dir)
+	if err != nil || recovery == nil {
+		return false, nil, err
+	}
+	if mode == ResumeAsk && !interactive {
+		log.Printf("%s. Pass --resume always to resume it", recovery.Describe())
+		return false, recovery, nil
+	}
+
+	resume := mode == ResumeAlways || askToResume(recovery, os.Stdin, os.Stderr)
+	if resume {
+		if err := NewStateManager(world).LoadFromFile(recovery.File); err != nil {
+			return false, nil, fmt.Errorf("resuming autosave: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "Resumed from %s at tick %d\n", recovery.File, world.Tick)
+	}
+	return resume, nil, DismissAutosaveRecovery(dir)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAutosaveRotatesAndRecovers(t *testing.T) {
+	dir := t.TempDir()
+	world := newSteppingTestWorld(139)
+	autosaver, err := NewAutosaver(dir, 5, 2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := autosaver.Begin(world); err != nil {
+		t.Fatal(err)
+	}
+	world.Autosaver = autosaver
+	if _, err := world.Step(17); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every 5 ticks, keeping the newest two
+	saves, _ := listAutosaves(dir)
+	if len(saves) != 2 || !strings.HasSuffix(saves[1], "tick00000015.json") {
+		t.Fatalf("Expected the autosaves of ticks 10 and 15 kept, got %v", saves)
+	}
+
+	// A run still marked running crashed, and its latest autosave is offered
+	recovery, err := FindAutosaveRecovery(dir)
+	if err != nil || recovery == nil || recovery.Tick != 15 || recovery.Status != AutosaveRunning {
+		t.Fatalf("Expected the crashed run's autosave at tick 15 offered, got %+v (%v)", recovery, err)
+	}
+
+	// A finished run has nothing to resume; an interrupted one saves on the way out
+	if err := autosaver.End(world, AutosaveFinished); err != nil {
+		t.Fatal(err)
+	}
+	if recovery, _ := FindAutosaveRecovery(dir); recovery != nil {
+		t.Errorf("Expected nothing offered after a finished run, got %+v", recovery)
+	}
+	autosaver.Protected = filepath.Join(dir, saves[0])
+	if err := autosaver.Begin(world); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := world.Step(8); err != nil {
+		t.Fatal(err)
+	}
+	if err := autosaver.End(world, AutosaveInterrupted); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(autosaver.Protected); err != nil {
+		t.Errorf("Expected the protected autosave kept, got %v", err)
+	}
+	recovery, _ = FindAutosaveRecovery(dir)
+	if recovery == nil || recovery.Tick != world.Tick || recovery.Status != AutosaveInterrupted {
+		t.Fatalf("Expected the interrupted run saved at tick %d, got %+v", world.Tick, recovery)
+	}
+
+	// Asking without a terminal leaves the offer for the web page
+	fresh := newSteppingTestWorld(140)
+	if resumed, offer, err := offerAutosaveRecovery(fresh, dir, ResumeAsk, false); err != nil || resumed || offer == nil {
+		t.Fatalf("Expected the autosave left on offer, got %v %+v %v", resumed, offer, err)
+	}
+	if resumed, _, err := offerAutosaveRecovery(fresh, dir, ResumeAlways, false); err != nil || !resumed || fresh.Tick != world.Tick {
+		t.Fatalf("Expected the world resumed at tick %d, got tick %d (%v)", world.Tick, fresh.Tick, err)
+	}
+	if recovery, _ := FindAutosaveRecovery(dir); recovery != nil {
+		t.Errorf("Expected a resumed autosave no longer offered, got %+v", recovery)
+	}
+
+	if _, err := NewAutosaver(dir, 5, 0, ""); err == nil {
+		t.Error("Expected keeping no autosaves refused")
+	}
+	if _, err := ResolveResumeMode("maybe"); err == nil {
+		t.Error("Expected an unknown resume mode refused")
+	}
+}
+
+func TestAskToResume(t *testing.T) {
+	recovery := &AutosaveRecovery{File: "autosaves/autosave.json", Tick: 40, Status: AutosaveRunning}
+	var prompt strings.Builder
+	if !askToResume(recovery, strings.NewReader("\n"), &prompt) {
+		t.Error("Expected resuming by default")
+	}
+	if !strings.Contains(prompt.String(), "did not shut down cleanly") || !strings.Contains(prompt.String(), "tick 40") {
+		t.Errorf("Expected the prompt to describe the autosave, got %q", prompt.String())
+	}
+	if askToResume(recovery, strings.NewReader("no\n"), &prompt) {
+		t.Error("Expected no declining the autosave")
+	}
+}
+
+func TestWebResumesAutosave(t *testing.T) {
+	dir := t.TempDir()
+	crashed := newSteppingTestWorld(141)
+	if _, err := crashed.Step(6); err != nil {
+		t.Fatal(err)
+	}
+	autosaver, err := NewAutosaver(dir, 5, 3, SaveFormatBinary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := autosaver.Begin(crashed); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := autosaver.Save(crashed); err != nil {
+		t.Fatal(err)
+	}
+	recovery, err := FindAutosaveRecovery(dir)
+	if err != nil || recovery == nil {
+		t.Fatalf("Expected an autosave to resume, got %v", err)
+	}
+
+	world := newSteppingTestWorld(142)
+	wi := NewWebInterface(world)
+	wi.autosaveOffer = recovery
+	routes := wi.Routes()
+	request := func(method string) (int, AutosaveStatus) {
+		path := "/api/autosave"
+		if method != http.MethodGet {
+			path += "/resume"
+		}
+		rec := httptest.NewRecorder()
+		routes.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		var status AutosaveStatus
+		_ = json.Unmarshal(rec.Body.Bytes(), &status)
+		return rec.Code, status
+	}
+
+	if code, status := request(http.MethodGet); code != http.StatusOK || status.Enabled || status.Recovery == nil || status.Recovery.Tick != 6 {
+		t.Fatalf("Expected the autosave offered, got %d %+v", code, status)
+	}
+	if code, status := request(http.MethodPost); code != http.StatusOK || status.Recovery != nil || world.Tick != 6 {
+		t.Fatalf("Expected the world resumed at tick 6, got %d at tick %d", code, world.Tick)
+	}
+	if code, _ := request(http.MethodPost); code != http.StatusNotFound {
+		t.Errorf("Expected nothing left to resume, got %d", code)
+	}
+	if recovery, _ := FindAutosaveRecovery(dir); recovery != nil {
+		t.Errorf("Expected the resumed autosave dismissed, got %+v", recovery)
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// BatchResultRow is a single observation in long format: one metric value for one run at one tick
+type BatchResultRow struct {
+	RunID      int                `json:"run_id"`
+	Parameters map[string]float64 `json:"parameters"`
+	Tick       int                `json:"tick"`
+	Metric     string             `json:"metric"`
+	Value      float64            `json:"value"`
+}
+
+// BatchResultRecorder accumulates long-format results across one or more runs
+type BatchResultRecorder struct {
+	Rows           []BatchResultRow `json:"rows"`
+	ParameterNames []string         `json:"parameter_names"` // Union of parameter names across runs, in first-seen order
+	knownParams    map[string]bool
+}
+
+// NewBatchResultRecorder creates an empty batch result recorder
+func NewBatchResultRecorder() *BatchResultRecorder {
+	return &BatchResultRecorder{
+		Rows:           make([]BatchResultRow, 0),
+		ParameterNames: make([]string, 0),
+		knownParams:    make(map[string]bool),
+	}
+}
+
+// CollectBatchMetrics returns the metrics reported for every sample of a batch run
+func CollectBatchMetrics(world *World) map[string]float64 {
+	metrics := make(map[string]float64)
+
+	alive := 0
+	totalEnergy := 0.0
+	bySpecies := make(map[string]int)
+	for _, entity := range world.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		alive++
+		totalEnergy += entity.Energy
+		bySpecies[entity.Species]++
+	}
+
+	plants := 0
+	for _, plant := range world.AllPlants {
+		if plant.IsAlive {
+			plants++
+		}
+	}
+
+	metrics["total_population"] = float64(alive)
+	metrics["plant_count"] = float64(plants)
+	metrics["species_richness"] = float64(len(bySpecies))
+	if alive > 0 {
+		metrics["mean_energy"] = totalEnergy / float64(alive)
+	} else {
+		metrics["mean_energy"] = 0
+	}
+	for species, count := range bySpecies {
+		metrics["population:"+species] = float64(count)
+	}
+
+	if world.EcosystemMonitor != nil {
+		current := world.EcosystemMonitor.CurrentMetrics
+		metrics["shannon_diversity"] = current.ShannonDiversity
+		metrics["ecosystem_stability"] = current.EcosystemStability
+		metrics["connectivity_index"] = current.ConnectivityIndex
+	}
+
+	return metrics
+}
+
+// Record samples the world's metrics for the given run and parameter set
+func (br *BatchResultRecorder) Record(runID int, parameters map[string]float64, world *World) {
+	br.registerParameters(parameters)
+
+	metrics := CollectBatchMetrics(world)
+	names := make([]string, 0, len(metrics))
+	for name := range metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		br.Rows = append(br.Rows, BatchResultRow{
+			RunID:      runID,
+			Parameters: parameters,
+			Tick:       world.Tick,
+			Metric:     name,
+			Value:      metrics[name],
+		})
+	}
+}
+
+// registerParameters adds any new parameter names to the CSV column set
+func (br *BatchResultRecorder) registerParameters(parameters map[string]float64) {
+	if br.knownParams == nil {
+		br.knownParams = make(map[string]bool)
+	}
+	names := make([]string, 0, len(parameters))
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !br.knownParams[name] {
+			br.knownParams[name] = true
+			br.ParameterNames = append(br.ParameterNames, name)
+		}
+	}
+}
+
+// WriteCSV writes results as long-format CSV: run_id, one column per parameter, tick, metric, value
+func (br *BatchResultRecorder) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	header := append([]string{"run_id"}, br.ParameterNames...)
+	header = append(header, "tick", "metric", "value")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range br.Rows {
+		record := make([]string, 0, len(header))
+		record = append(record, strconv.Itoa(row.RunID))
+		for _, name := range br.ParameterNames {
+			value, exists := row.Parameters[name]
+			if !exists {
+				record = append(record, "")
+				continue
+			}
+			record = append(record, strconv.FormatFloat(value, 'g', -1, 64))
+		}
+		record = append(record, strconv.Itoa(row.Tick), row.Metric, strconv.FormatFloat(row.Value, 'g', -1, 64))
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// BatchExperiment describes a parameter sweep run headlessly, in the spirit of NetLogo's BehaviorSpace
+type BatchExperiment struct {
+	Name           string               `json:"name"`
+	Base           WorldConfig          `json:"base"`
+	Sweep          map[string][]float64 `json:"sweep"` // Parameter name -> values to try
+	Repetitions    int                  `json:"repetitions"`
+	Ticks          int                  `json:"ticks"`
+	SampleInterval int                  `json:"sample_interval"`
+
+	// Setup is called after each world is created, e.g. to add populations
+	Setup func(world *World, parameters map[string]float64) `json:"-"`
+}
+
+// ParameterCombinations expands the sweep into the full factorial set of parameter maps
+func (be *BatchExperiment) ParameterCombinations() []map[string]float64 {
+	names := make([]string, 0, len(be.Sweep))
+	for name := range be.Sweep {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	combinations := []map[string]float64{{}}
+	for _, name := range names {
+		expanded := make([]map[string]float64, 0, len(combinations)*len(be.Sweep[name]))
+		for _, combination := range combinations {
+			for _, value := range be.Sweep[name] {
+				next := make(map[string]float64, len(combination)+1)
+				for k, v := range combination {
+					next[k] = v
+				}
+				next[name] = value
+				expanded = append(expanded, next)
+			}
+		}
+		combinations = expanded
+	}
+
+	return combinations
+}
+
+// Run executes every parameter combination for the configured number of repetitions
+func (be *BatchExperiment) Run() *BatchResultRecorder {
+	recorder := NewBatchResultRecorder()
+
+	repetitions := be.Repetitions
+	if repetitions <= 0 {
+		repetitions = 1
+	}
+	interval := be.SampleInterval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	runID := 1
+	for _, parameters := range be.ParameterCombinations() {
+		for rep := 0; rep < repetitions; rep++ {
+			world := NewWorld(applyBatchParameters(be.Base, parameters))
+			if be.Setup != nil {
+				be.Setup(world, parameters)
+			}
+
+			recorder.Record(runID, parameters, world)
+			for tick := 1; tick <= be.Ticks; tick++ {
+				world.Update()
+				if tick%interval == 0 || tick == be.Ticks {
+					recorder.Record(runID, parameters, world)
+				}
+			}
+			runID++
+		}
+	}
+
+	return recorder
+}
+
+// applyBatchParameters overrides world configuration fields named in the parameter set
+func applyBatchParameters(config WorldConfig, parameters map[string]float64) WorldConfig {
+	for name, value := range parameters {
+		switch name {
+		case "width":
+			config.Width = value
+		case "height":
+			config.Height = value
+		case "population_size":
+			config.PopulationSize = int(value)
+		case "grid_width":
+			config.GridWidth = int(value)
+		case "grid_height":
+			config.GridHeight = int(value)
+		}
+	}
+	return config
+}
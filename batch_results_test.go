@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestBatchParameterCombinations(t *testing.T) {
+	experiment := &BatchExperiment{
+		Sweep: map[string][]float64{
+			"population_size": {5, 10, 20},
+			"width":           {30, 60},
+		},
+	}
+
+	combinations := experiment.ParameterCombinations()
+	if len(combinations) != 6 {
+		t.Fatalf("Expected 6 combinations, got %d", len(combinations))
+	}
+	for _, combination := range combinations {
+		if len(combination) != 2 {
+			t.Errorf("Expected both parameters in combination, got %v", combination)
+		}
+	}
+}
+
+func TestBatchResultsLongFormatCSV(t *testing.T) {
+	recorder := NewBatchResultRecorder()
+	world := NewWorld(WorldConfig{Width: 20, Height: 20, GridWidth: 10, GridHeight: 10})
+	world.AllEntities = []*Entity{NewEntity(sharedRand, 1, []string{}, "herbivore", Position{X: 5, Y: 5})}
+
+	recorder.Record(1, map[string]float64{"population_size": 5}, world)
+	recorder.Record(2, map[string]float64{"population_size": 10, "width": 20}, world)
+
+	var buf bytes.Buffer
+	if err := recorder.WriteCSV(&buf); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+
+	expectedHeader := []string{"run_id", "population_size", "width", "tick", "metric", "value"}
+	if len(rows[0]) != len(expectedHeader) {
+		t.Fatalf("Expected header %v, got %v", expectedHeader, rows[0])
+	}
+	for i, column := range expectedHeader {
+		if rows[0][i] != column {
+			t.Errorf("Expected column %d to be %s, got %s", i, column, rows[0][i])
+		}
+	}
+
+	found := false
+	for _, row := range rows[1:] {
+		if row[0] == "1" && row[2] != "" {
+			t.Errorf("Expected empty width for run 1, got %q", row[2])
+		}
+		if row[0] == "1" && row[4] == "population:herbivore" && row[5] == "1" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected per-species population metric in long-format output")
+	}
+}
+
+func TestBatchExperimentRun(t *testing.T) {
+	setupCalls := 0
+	experiment := &BatchExperiment{
+		Base:           WorldConfig{Width: 20, Height: 20, GridWidth: 10, GridHeight: 10, PopulationSize: 3},
+		Sweep:          map[string][]float64{"width": {20, 30}},
+		Repetitions:    2,
+		Ticks:          4,
+		SampleInterval: 2,
+		Setup: func(world *World, parameters map[string]float64) {
+			setupCalls++
+			if world.Config.Width != parameters["width"] {
+				t.Errorf("Expected width %.0f applied to world, got %.0f", parameters["width"], world.Config.Width)
+			}
+		},
+	}
+
+	recorder := experiment.Run()
+	if setupCalls != 4 {
+		t.Errorf("Expected 4 runs, got %d", setupCalls)
+	}
+
+	ticksByRun := make(map[int]map[int]bool)
+	for _, row := range recorder.Rows {
+		if ticksByRun[row.RunID] == nil {
+			ticksByRun[row.RunID] = make(map[int]bool)
+		}
+		ticksByRun[row.RunID][row.Tick] = true
+	}
+	if len(ticksByRun) != 4 {
+		t.Fatalf("Expected results for 4 runs, got %d", len(ticksByRun))
+	}
+	for runID, ticks := range ticksByRun {
+		if len(ticks) != 3 {
+			t.Errorf("Expected samples at ticks 0, 2, 4 for run %d, got %v", runID, ticks)
+		}
+	}
+}
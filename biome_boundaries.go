@@ -67,6 +67,8 @@ type BiomeBoundarySystem struct {
 	EcotoneArea         float64 `json:"ecotone_area"`
 	MigrationEvents     int     `json:"migration_events"`
 	EvolutionEvents     int     `json:"evolution_events"`
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewBiomeBoundarySystem creates a new biome boundary system
@@ -79,6 +81,7 @@ func NewBiomeBoundarySystem() *BiomeBoundarySystem {
 		UpdateFrequency:   25, // Update every 25 ticks
 		EvolutionPressure: 0.15,
 		MigrationBonus:    0.3,
+		rng:               sharedRand,
 	}
 }
 
@@ -256,7 +259,7 @@ func (bbs *BiomeBoundarySystem) calculateBoundaryWidth(biomeA, biomeB BiomeType)
 	}
 
 	// Ecotones are wider
-	return baseWidth + rand.Float64()*1.5
+	return baseWidth + bbs.rng.Float64()*1.5
 }
 
 // calculatePermeability determines how easily entities can cross boundaries
@@ -286,11 +289,10 @@ func (bbs *BiomeBoundarySystem) processEntityBoundaryEffects(world *World, tick
 		}
 
 		// Find nearby boundaries
-		nearbyBoundaries := bbs.findNearbyBoundaries(entity.Position)
+		nearbyBoundaries := bbs.findNearbyBoundaries(entity.Position, world.Geometry())
 
 		for _, boundary := range nearbyBoundaries {
-			distance := math.Sqrt(math.Pow(entity.Position.X-boundary.Position.X, 2) +
-				math.Pow(entity.Position.Y-boundary.Position.Y, 2))
+			distance := world.Distance(entity.Position, boundary.Position)
 
 			if distance <= boundary.Width {
 				// Apply boundary effects
@@ -315,13 +317,14 @@ func (bbs *BiomeBoundarySystem) applyBoundaryEffectsToEntity(entity *Entity, bou
 	// Ecotone effects
 	if boundary.BoundaryType == EcotoneZone {
 		// Enhanced mutation rate in ecotones (evolutionary pressure)
-		if rand.Float64() < bbs.EvolutionPressure*intensity*0.01 {
-			entity.Mutate(0.05, 0.05)
+		if bbs.rng.Float64() < bbs.EvolutionPressure*intensity*0.01 {
+			entity.MutateWith(world.Rand, world.Mutations, 0.05, 0.05)
 			bbs.EvolutionEvents++
 		}
 
 		// Resource bonus in ecotones
 		entity.Energy += boundary.ResourceDensity * intensity * 0.5
+		world.capEnergy(entity)
 
 		// Migration bonus (increased speed)
 		if entity.GetTrait("speed") > 0 {
@@ -364,7 +367,7 @@ func (bbs *BiomeBoundarySystem) calculateStability(biomeA, biomeB BiomeType) flo
 	if bbs.isBarrierCombination(biomeA, biomeB) {
 		return 0.9 // Barriers are very stable
 	}
-	return 0.6 + rand.Float64()*0.3 // Base stability with variation
+	return 0.6 + bbs.rng.Float64()*0.3 // Base stability with variation
 }
 
 func (bbs *BiomeBoundarySystem) calculateChangeRate(biomeA, biomeB BiomeType) float64 {
@@ -372,22 +375,22 @@ func (bbs *BiomeBoundarySystem) calculateChangeRate(biomeA, biomeB BiomeType) fl
 	if bbs.isBarrierCombination(biomeA, biomeB) {
 		return 0.01 // Very slow change for barriers
 	}
-	return 0.05 + rand.Float64()*0.05 // Moderate change rate
+	return 0.05 + bbs.rng.Float64()*0.05 // Moderate change rate
 }
 
 func (bbs *BiomeBoundarySystem) calculateResourceDensity(biomeA, biomeB BiomeType) float64 {
 	// Ecotones typically have higher resource density
-	return 0.8 + rand.Float64()*0.4 // 0.8 to 1.2
+	return 0.8 + bbs.rng.Float64()*0.4 // 0.8 to 1.2
 }
 
 func (bbs *BiomeBoundarySystem) calculateSpeciesDiversity(biomeA, biomeB BiomeType) float64 {
 	// Edge effect - higher diversity at boundaries
-	return 1.2 + rand.Float64()*0.3 // 1.2 to 1.5
+	return 1.2 + bbs.rng.Float64()*0.3 // 1.2 to 1.5
 }
 
 func (bbs *BiomeBoundarySystem) calculateCompetitionLevel(biomeA, biomeB BiomeType) float64 {
 	// Higher competition in resource-rich ecotones
-	return 0.7 + rand.Float64()*0.6 // 0.7 to 1.3
+	return 0.7 + bbs.rng.Float64()*0.6 // 0.7 to 1.3
 }
 
 // setBoundaryTraitModifiers sets trait modifiers for boundary zones
@@ -418,12 +421,11 @@ func (bbs *BiomeBoundarySystem) setBoundaryTraitModifiers(boundary *BiomeBoundar
 }
 
 // findNearbyBoundaries finds boundaries near a given position
-func (bbs *BiomeBoundarySystem) findNearbyBoundaries(pos Position) []*BiomeBoundary {
+func (bbs *BiomeBoundarySystem) findNearbyBoundaries(pos Position, geometry WorldGeometry) []*BiomeBoundary {
 	nearby := make([]*BiomeBoundary, 0)
 
 	for _, boundary := range bbs.Boundaries {
-		distance := math.Sqrt(math.Pow(pos.X-boundary.Position.X, 2) +
-			math.Pow(pos.Y-boundary.Position.Y, 2))
+		distance := geometry.Distance(pos, boundary.Position)
 
 		if distance <= boundary.Width+bbs.DetectionRadius {
 			nearby = append(nearby, boundary)
@@ -440,11 +442,10 @@ func (bbs *BiomeBoundarySystem) processBoundaryEffectsOnPlants(world *World, tic
 			continue
 		}
 
-		nearbyBoundaries := bbs.findNearbyBoundaries(plant.Position)
+		nearbyBoundaries := bbs.findNearbyBoundaries(plant.Position, world.Geometry())
 
 		for _, boundary := range nearbyBoundaries {
-			distance := math.Sqrt(math.Pow(plant.Position.X-boundary.Position.X, 2) +
-				math.Pow(plant.Position.Y-boundary.Position.Y, 2))
+			distance := world.Distance(plant.Position, boundary.Position)
 
 			if distance <= boundary.Width {
 				// Plants in ecotones get resource bonus
@@ -461,10 +462,10 @@ func (bbs *BiomeBoundarySystem) processBoundaryEffectsOnPlants(world *World, tic
 func (bbs *BiomeBoundarySystem) updateBoundaryDynamics(world *World, tick int) {
 	for _, boundary := range bbs.Boundaries {
 		// Boundaries can shift over time based on environmental pressures
-		if rand.Float64() < boundary.ChangeRate {
+		if bbs.rng.Float64() < boundary.ChangeRate {
 			// Small boundary adjustments
-			boundary.Position.X += (rand.Float64() - 0.5) * 0.1
-			boundary.Position.Y += (rand.Float64() - 0.5) * 0.1
+			boundary.Position.X += (bbs.rng.Float64() - 0.5) * 0.1
+			boundary.Position.Y += (bbs.rng.Float64() - 0.5) * 0.1
 
 			// Keep boundaries within world bounds
 			boundary.Position.X = math.Max(0, math.Min(float64(world.Config.GridWidth), boundary.Position.X))
@@ -124,7 +124,7 @@ func TestBoundaryEffectsOnEntities(t *testing.T) {
 	system := NewBiomeBoundarySystem()
 
 	// Create an entity
-	entity := NewEntity(1, []string{"speed", "endurance", "adaptability"}, "testspecies", Position{X: 5, Y: 5})
+	entity := NewEntity(sharedRand, 1, []string{"speed", "endurance", "adaptability"}, "testspecies", Position{X: 5, Y: 5})
 	entity.SetTrait("speed", 1.0)
 	entity.SetTrait("endurance", 1.0)
 	entity.SetTrait("adaptability", 1.0)
@@ -197,7 +197,7 @@ func TestEcotoneEffects(t *testing.T) {
 	system.Boundaries = append(system.Boundaries, boundary)
 
 	// Create entity near boundary
-	entity := NewEntity(1, []string{"adaptability", "intelligence"}, "testspecies", Position{X: 5.5, Y: 5.5})
+	entity := NewEntity(sharedRand, 1, []string{"adaptability", "intelligence"}, "testspecies", Position{X: 5.5, Y: 5.5})
 	entity.SetTrait("adaptability", 1.0)
 	entity.SetTrait("intelligence", 1.0)
 	entity.IsAlive = true
@@ -259,7 +259,7 @@ func TestBarrierEffects(t *testing.T) {
 	system.Boundaries = append(system.Boundaries, boundary)
 
 	// Create entity at barrier
-	entity := NewEntity(1, []string{"speed", "endurance"}, "testspecies", Position{X: 5.1, Y: 5.1})
+	entity := NewEntity(sharedRand, 1, []string{"speed", "endurance"}, "testspecies", Position{X: 5.1, Y: 5.1})
 	entity.SetTrait("speed", 1.5)
 	entity.SetTrait("endurance", 1.0)
 	entity.IsAlive = true
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Biome state machine parameters
+const (
+	biomePressureDecay    = 0.75  // Share of a rule's pressure a cell keeps through a check without its trigger
+	biomePressureFloor    = 0.001 // Pressure below which a rule is forgotten
+	biomeHysteresis       = 2.0   // How much more pressure it takes to return to the biome a cell just left
+	biomeMinDwell         = 200   // Ticks a cell holds a new biome before it can change again
+	biomeDroughtLevel     = 0.15  // Water level below which a cell away from water is in drought
+	biomeFireStartChance  = 0.001 // Chance per check of a fire starting in a flammable cell
+	maxBiomeTransitions   = 200   // Recent transitions kept for inspection
+	maxReportedPressures  = 50    // Cells under pressure listed in a report
+	defaultBiomeThreshold = 1.0
+)
+
+// BiomeTransitionRule is an edge of the biome state machine. While its trigger is present a
+// cell of the From biome gathers Rate x intensity pressure per check, and turns To once the
+// pressure reaches Threshold. Without the trigger the pressure decays, so only a sustained
+// or repeated driver changes terrain.
+type BiomeTransitionRule struct {
+	From      BiomeType `json:"-"`
+	To        BiomeType `json:"-"`
+	FromName  string    `json:"from"`
+	ToName    string    `json:"to"`
+	Trigger   string    `json:"trigger"` // "heat", "cold", "fire", "flood", "drought", etc.
+	Rate      float64   `json:"rate"`
+	Threshold float64   `json:"threshold"`
+}
+
+// biomeTransitionRules are the state machine's edges. Rates match the per-check odds the
+// transitions used to have, so a driver takes as long on average to change a cell as before.
+var biomeTransitionRules = []BiomeTransitionRule{
+	// Water and ice
+	{From: BiomeWater, To: BiomeIce, Trigger: "cold", Rate: 0.03},
+	{From: BiomeSwamp, To: BiomeIce, Trigger: "cold", Rate: 0.04},
+	{From: BiomeIce, To: BiomeWater, Trigger: "heat", Rate: 0.02},
+	{From: BiomeIce, To: BiomePlains, Trigger: "heat", Rate: 0.01},
+	{From: BiomeIce, To: BiomeWater, Trigger: "hotspring", Rate: 0.15},
+	{From: BiomeWater, To: BiomeRainforest, Trigger: "hotspring", Rate: 0.05},
+	// Grassland and desert
+	{From: BiomePlains, To: BiomeDesert, Trigger: "fire", Rate: 1.25},
+	{From: BiomePlains, To: BiomeDesert, Trigger: "drought", Rate: 0.05},
+	{From: BiomeDesert, To: BiomePlains, Trigger: "water", Rate: 0.03},
+	{From: BiomeDesert, To: BiomePlains, Trigger: "flood", Rate: 0.1},
+	// Forest and swamp
+	{From: BiomeForest, To: BiomeDesert, Trigger: "fire", Rate: 1.25},
+	{From: BiomeRainforest, To: BiomeDesert, Trigger: "fire", Rate: 1.0}, // Wet enough to need more than one fire
+	{From: BiomeForest, To: BiomeSwamp, Trigger: "flood", Rate: 0.05},
+	{From: BiomeSwamp, To: BiomeForest, Trigger: "drought", Rate: 0.05},
+	{From: BiomePlains, To: BiomeSwamp, Trigger: "flood", Rate: 0.08},
+	// Volcanism
+	{From: BiomePlains, To: BiomeMountain, Trigger: "volcanic", Rate: 0.12},
+	{From: BiomeForest, To: BiomeRadiation, Trigger: "volcanic", Rate: 0.08}, // Lava burns forest to radiation zones
+}
+
+func init() {
+	for i := range biomeTransitionRules {
+		rule := &biomeTransitionRules[i]
+		rule.FromName, rule.ToName = biomeName(rule.From), biomeName(rule.To)
+		if rule.Threshold == 0 {
+			rule.Threshold = defaultBiomeThreshold
+		}
+	}
+}
+
+// BiomeTransitionEvent records a cell changing biome
+type BiomeTransitionEvent struct {
+	Tick      int     `json:"tick"`
+	X         int     `json:"x"`
+	Y         int     `json:"y"`
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	Trigger   string  `json:"trigger"`
+	Pressure  float64 `json:"pressure"`
+	Threshold float64 `json:"threshold"`
+	Reverting bool    `json:"reverting"` // Back to the biome it last left, past the hysteresis threshold
+}
+
+// BiomeCellPressure is a cell building toward a transition
+type BiomeCellPressure struct {
+	X         int     `json:"x"`
+	Y         int     `json:"y"`
+	Biome     string  `json:"biome"`
+	To        string  `json:"to"`
+	Trigger   string  `json:"trigger"`
+	Pressure  float64 `json:"pressure"`
+	Threshold float64 `json:"threshold"`
+	Dwelling  bool    `json:"dwelling"` // Changed too recently to change again yet
+}
+
+// BiomeStateReport is the state machine's rules, recent transitions and cells under pressure
+type BiomeStateReport struct {
+	Tick        int                    `json:"tick"`
+	Rules       []BiomeTransitionRule  `json:"rules"`
+	Transitions []BiomeTransitionEvent `json:"transitions"` // Newest first
+	Pressures   []BiomeCellPressure    `json:"pressures"`   // Closest to changing first
+}
+
+// biomeCellState is what the state machine remembers about one cell
+type biomeCellState struct {
+	pressure    map[int]float64 // Rule index -> accumulated pressure
+	changed     bool
+	previous    BiomeType // The biome the cell last left
+	changedTick int
+}
+
+// BiomeStateMachine drives biome transitions from accumulated environmental pressure, with
+// hysteresis so a cell that has just changed does not flicker back
+type BiomeStateMachine struct {
+	cells       map[int]*biomeCellState // y*GridWidth+x
+	Transitions []BiomeTransitionEvent
+}
+
+// NewBiomeStateMachine creates a state machine with no pressure anywhere
+func NewBiomeStateMachine() *BiomeStateMachine {
+	return &BiomeStateMachine{cells: make(map[int]*biomeCellState), Transitions: make([]BiomeTransitionEvent, 0)}
+}
+
+// threshold is the pressure a rule needs in a cell: more when it would undo the cell's last change
+func (state *biomeCellState) threshold(rule BiomeTransitionRule) (float64, bool) {
+	if state != nil && state.changed && rule.To == state.previous {
+		return rule.Threshold * biomeHysteresis, true
+	}
+	return rule.Threshold, false
+}
+
+// dwelling reports whether a cell changed too recently to change again
+func (state *biomeCellState) dwelling(tick int) bool {
+	return state != nil && state.changed && tick-state.changedTick < biomeMinDwell
+}
+
+// Process runs one check of every cell: pressure builds where triggers are present and
+// decays elsewhere, and cells whose pressure crosses a threshold change biome. Changes are
+// applied together after the check so neighbours see the same terrain.
+func (bsm *BiomeStateMachine) Process(w *World) {
+	type change struct {
+		x, y, rule int
+		event      BiomeTransitionEvent
+	}
+	changes := make([]change, 0)
+
+	for y := 0; y < w.Config.GridHeight; y++ {
+		for x := 0; x < w.Config.GridWidth; x++ {
+			key := y*w.Config.GridWidth + x
+			current := w.Grid[y][x].Biome
+			triggers := w.detectTransitionTriggers(x, y)
+			state := bsm.cells[key]
+			if state != nil {
+				// Pressure toward leaving a biome the cell has since lost some other way no longer applies
+				for i := range state.pressure {
+					if biomeTransitionRules[i].From != current {
+						delete(state.pressure, i)
+					}
+				}
+			}
+
+			best, bestRatio := -1, 0.0
+			for i, rule := range biomeTransitionRules {
+				if rule.From != current {
+					continue
+				}
+				if intensity := triggers[rule.Trigger]; intensity > 0 {
+					if state == nil {
+						state = &biomeCellState{pressure: make(map[int]float64)}
+						bsm.cells[key] = state
+					}
+					state.pressure[i] += rule.Rate * intensity
+				} else if state != nil && state.pressure[i] > 0 {
+					state.pressure[i] *= biomePressureDecay
+					if state.pressure[i] < biomePressureFloor {
+						delete(state.pressure, i)
+					}
+				}
+				if state == nil {
+					continue
+				}
+				threshold, _ := state.threshold(rule)
+				if ratio := state.pressure[i] / threshold; ratio >= 1 && ratio > bestRatio {
+					best, bestRatio = i, ratio
+				}
+			}
+
+			if state != nil && len(state.pressure) == 0 && !state.changed {
+				delete(bsm.cells, key)
+			}
+			if best < 0 || state.dwelling(w.Tick) {
+				continue
+			}
+			rule := biomeTransitionRules[best]
+			threshold, reverting := state.threshold(rule)
+			changes = append(changes, change{x: x, y: y, rule: best, event: BiomeTransitionEvent{
+				Tick: w.Tick, X: x, Y: y, From: rule.FromName, To: rule.ToName, Trigger: rule.Trigger,
+				Pressure: state.pressure[best], Threshold: threshold, Reverting: reverting,
+			}})
+		}
+	}
+
+	for _, c := range changes {
+		rule := biomeTransitionRules[c.rule]
+		w.Grid[c.y][c.x].Biome = rule.To
+		bsm.cells[c.y*w.Config.GridWidth+c.x] = &biomeCellState{
+			pressure: make(map[int]float64), changed: true, previous: rule.From, changedTick: w.Tick,
+		}
+		bsm.Transitions = append(bsm.Transitions, c.event)
+		w.logBiomeTransition(c.event)
+	}
+	if len(bsm.Transitions) > maxBiomeTransitions {
+		bsm.Transitions = bsm.Transitions[len(bsm.Transitions)-maxBiomeTransitions:]
+	}
+}
+
+// logBiomeTransition records a transition in the event log
+func (w *World) logBiomeTransition(event BiomeTransitionEvent) {
+	if w.EventLogger == nil {
+		return
+	}
+	w.EventLogger.addEvent(LogEvent{
+		Timestamp:   time.Now(),
+		Tick:        event.Tick,
+		Type:        fmt.Sprintf("biome_transition_%s_to_%s", event.From, event.To),
+		Description: fmt.Sprintf("Biome transition from %s to %s triggered by %s", event.From, event.To, event.Trigger),
+		Data: map[string]interface{}{
+			"trigger":    event.Trigger,
+			"pressure":   event.Pressure,
+			"threshold":  event.Threshold,
+			"reverting":  event.Reverting,
+			"from_biome": event.From,
+			"to_biome":   event.To,
+			"position_x": float64(event.X),
+			"position_y": float64(event.Y),
+		},
+	})
+}
+
+// Report lists the rules, the most recent transitions and the cells closest to changing
+func (bsm *BiomeStateMachine) Report(w *World) BiomeStateReport {
+	report := BiomeStateReport{
+		Tick:        w.Tick,
+		Rules:       biomeTransitionRules,
+		Transitions: make([]BiomeTransitionEvent, 0, len(bsm.Transitions)),
+		Pressures:   make([]BiomeCellPressure, 0),
+	}
+	for i := len(bsm.Transitions) - 1; i >= 0; i-- {
+		report.Transitions = append(report.Transitions, bsm.Transitions[i])
+	}
+	for _, key := range sortedKeys(bsm.cells) {
+		state := bsm.cells[key]
+		x, y := key%w.Config.GridWidth, key/w.Config.GridWidth
+		for _, i := range sortedKeys(state.pressure) {
+			rule := biomeTransitionRules[i]
+			threshold, _ := state.threshold(rule)
+			report.Pressures = append(report.Pressures, BiomeCellPressure{
+				X: x, Y: y, Biome: rule.FromName, To: rule.ToName, Trigger: rule.Trigger,
+				Pressure: state.pressure[i], Threshold: threshold, Dwelling: state.dwelling(w.Tick),
+			})
+		}
+	}
+	sort.SliceStable(report.Pressures, func(i, j int) bool {
+		return report.Pressures[i].Pressure/report.Pressures[i].Threshold > report.Pressures[j].Pressure/report.Pressures[j].Threshold
+	})
+	if len(report.Pressures) > maxReportedPressures {
+		report.Pressures = report.Pressures[:maxReportedPressures]
+	}
+	return report
+}
+
+// Clear forgets all pressure and transition history, as when a new world is loaded
+func (bsm *BiomeStateMachine) Clear() {
+	bsm.cells = make(map[int]*biomeCellState)
+	bsm.Transitions = make([]BiomeTransitionEvent, 0)
+}
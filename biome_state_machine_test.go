@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newBiomeTestWorld makes a small world of bare mountain, which no transition rule touches
+func newBiomeTestWorld() *World {
+	world := newEmptyTestWorld(100)
+	fillTestBiome(world, BiomeMountain)
+	for y := range world.Grid {
+		for x := range world.Grid[y] {
+			world.Grid[y][x].WaterLevel = 0.5
+		}
+	}
+	return world
+}
+
+func TestBiomePressureBuildsToThreshold(t *testing.T) {
+	world := newBiomeTestWorld()
+	world.Grid[5][5].Biome = BiomeHotSpring
+	world.Grid[5][6].Biome = BiomeIce
+
+	// A hot spring next door adds 0.15 x 0.5 a check, so the ice holds for 13 checks
+	for i := 0; i < 13; i++ {
+		world.processBiomeTransitions()
+	}
+	if world.Grid[5][6].Biome != BiomeIce || len(world.BiomeStates.Transitions) != 0 {
+		t.Fatalf("Expected ice to hold below its threshold, got %s", biomeName(world.Grid[5][6].Biome))
+	}
+	report := world.BiomeStates.Report(world)
+	if len(report.Pressures) == 0 || report.Pressures[0].X != 6 || report.Pressures[0].Y != 5 || report.Pressures[0].To != "water" {
+		t.Errorf("Expected the ice to be reported building toward water, got %+v", report.Pressures)
+	}
+
+	world.processBiomeTransitions()
+	if world.Grid[5][6].Biome != BiomeWater {
+		t.Fatalf("Expected ice to melt once pressure reached its threshold, got %s", biomeName(world.Grid[5][6].Biome))
+	}
+	transition := world.BiomeStates.Transitions[0]
+	if transition.From != "ice" || transition.To != "water" || transition.Trigger != "hotspring" || transition.Reverting {
+		t.Errorf("Expected a hotspring melt, got %+v", transition)
+	}
+	if events := world.EventLogger.GetEventsByType("biome_transition_ice_to_water"); len(events) != 1 {
+		t.Errorf("Expected the transition logged once, got %d events", len(events))
+	}
+}
+
+func TestBiomeHysteresis(t *testing.T) {
+	world := newBiomeTestWorld()
+	world.Grid[5][5].Biome = BiomeHotSpring
+	world.Grid[5][6].Biome = BiomeIce
+	for world.Grid[5][6].Biome == BiomeIce {
+		world.processBiomeTransitions()
+	}
+
+	// The hot spring keeps pushing the new water toward rainforest, but not before it has dwelt
+	for i := 0; i < 60; i++ {
+		world.processBiomeTransitions()
+	}
+	if world.Grid[5][6].Biome != BiomeWater {
+		t.Fatalf("Expected a fresh biome to hold through its dwell time, got %s", biomeName(world.Grid[5][6].Biome))
+	}
+
+	// Cold from tundra would refreeze plain water in about 167 checks; going back needs twice that
+	world.Grid[5][5].Biome = BiomeTundra
+	checks := 0
+	for world.Grid[5][6].Biome == BiomeWater && checks < 1000 {
+		world.Tick += 20
+		world.processBiomeTransitions()
+		checks++
+	}
+	if world.Grid[5][6].Biome != BiomeIce || checks < 300 {
+		t.Fatalf("Expected refreezing to take twice as long as a first freeze, took %d checks to %s", checks, biomeName(world.Grid[5][6].Biome))
+	}
+	last := world.BiomeStates.Transitions[len(world.BiomeStates.Transitions)-1]
+	if !last.Reverting || last.Threshold != biomeHysteresis*defaultBiomeThreshold {
+		t.Errorf("Expected the refreeze marked as a reversion past the raised threshold, got %+v", last)
+	}
+}
+
+func TestDroughtTrigger(t *testing.T) {
+	world := newBiomeTestWorld()
+	world.Grid[2][2].Biome = BiomeSwamp
+	world.Grid[2][2].WaterLevel = 0.03
+	if drought := world.detectTransitionTriggers(2, 2)["drought"]; drought < 0.79 || drought > 0.81 {
+		t.Errorf("Expected a dried-out swamp alone to be in drought, got %.3f", drought)
+	}
+	world.Grid[2][3].Biome = BiomeWater
+	if drought := world.detectTransitionTriggers(2, 2)["drought"]; drought != 0 {
+		t.Errorf("Expected open water beside a cell to relieve drought, got %.3f", drought)
+	}
+}
+
+func TestBiomeTransitionsAPI(t *testing.T) {
+	world := newBiomeTestWorld()
+	world.Grid[5][5].Biome = BiomeHotSpring
+	world.Grid[5][6].Biome = BiomeIce
+	for i := 0; i < 14; i++ {
+		world.processBiomeTransitions()
+	}
+	wi := NewWebInterface(world)
+	rec := httptest.NewRecorder()
+	wi.handleBiomeTransitions(rec, httptest.NewRequest(http.MethodGet, "/api/biome-transitions", nil))
+	var report BiomeStateReport
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &report) != nil {
+		t.Fatalf("Expected a biome state report, got %d %s", rec.Code, rec.Body.String())
+	}
+	if len(report.Rules) != len(biomeTransitionRules) || len(report.Transitions) != 1 || report.Transitions[0].To != "water" {
+		t.Errorf("Expected the rules and the ice melt, got %+v", report)
+	}
+
+	world.BiomeStates.Clear()
+	if report := world.BiomeStates.Report(world); len(report.Transitions) != 0 || len(report.Pressures) != 0 {
+		t.Errorf("Expected clearing to forget transitions and pressure, got %+v", report)
+	}
+}
@@ -40,12 +40,12 @@ type BioRhythm struct {
 }
 
 // NewBioRhythm creates a new biorhythm system for an entity
-func NewBioRhythm(entityID int, entity *Entity) *BioRhythm {
+func NewBioRhythm(rng *rand.Rand, entityID int, entity *Entity) *BioRhythm {
 	br := &BioRhythm{
 		EntityID:          entityID,
 		Activities:        make(map[ActivityType]*ActivityState),
 		ActivitySchedule:  make(map[TimeOfDay][]ActivityType),
-		CircadianClock:    rand.Float64(), // Random starting point in circadian cycle
+		CircadianClock:    rng.Float64(), // Random starting point in circadian cycle
 		EnergyAtLastSleep: 100.0,
 	}
 
@@ -58,7 +58,7 @@ func NewBioRhythm(entityID int, entity *Entity) *BioRhythm {
 	for _, activity := range activities {
 		br.Activities[activity] = &ActivityState{
 			LastPerformed: 0,
-			NeedLevel:     rand.Float64() * 0.5, // Start with some random need
+			NeedLevel:     rng.Float64() * 0.5, // Start with some random need
 			IsActive:      false,
 			Duration:      0,
 		}
@@ -139,7 +139,7 @@ func (br *BioRhythm) setupActivitySchedule(entity *Entity) {
 }
 
 // Update updates the biorhythm system and calculates current needs
-func (br *BioRhythm) Update(tick int, entity *Entity, timeState TimeState) {
+func (br *BioRhythm) Update(rng *rand.Rand, tick int, entity *Entity, timeState TimeState) {
 	// Update circadian clock
 	br.updateCircadianClock(timeState)
 
@@ -150,7 +150,7 @@ func (br *BioRhythm) Update(tick int, entity *Entity, timeState TimeState) {
 	br.updateActivityDurations()
 
 	// Determine current activity based on needs and schedule
-	br.determineCurrentActivity(tick, entity, timeState)
+	br.determineCurrentActivity(rng, tick, entity, timeState)
 }
 
 // updateCircadianClock updates the internal biological clock
@@ -255,7 +255,7 @@ func (br *BioRhythm) updateActivityDurations() {
 }
 
 // determineCurrentActivity determines what the entity should be doing right now
-func (br *BioRhythm) determineCurrentActivity(tick int, entity *Entity, timeState TimeState) {
+func (br *BioRhythm) determineCurrentActivity(rng *rand.Rand, tick int, entity *Entity, timeState TimeState) {
 	// First, stop all current activities
 	for _, activity := range br.Activities {
 		activity.IsActive = false
@@ -307,12 +307,12 @@ func (br *BioRhythm) determineCurrentActivity(tick int, entity *Entity, timeStat
 		activity.Duration = 1
 
 		// Perform activity effects
-		br.performActivity(bestActivity, tick, entity, timeState)
+		br.performActivity(rng, bestActivity, tick, entity, timeState)
 	}
 }
 
 // performActivity executes the effects of performing an activity
-func (br *BioRhythm) performActivity(activity ActivityType, tick int, entity *Entity, timeState TimeState) {
+func (br *BioRhythm) performActivity(rng *rand.Rand, activity ActivityType, tick int, entity *Entity, timeState TimeState) {
 	activityState := br.Activities[activity]
 
 	switch activity {
@@ -351,7 +351,7 @@ func (br *BioRhythm) performActivity(activity ActivityType, tick int, entity *En
 		activityState.LastPerformed = tick
 		entity.Energy -= 0.1 // Playing costs energy
 		// Play can improve social traits over time (small chance)
-		if rand.Float64() < 0.001 && entity.GetTrait("cooperation") < 1.0 {
+		if rng.Float64() < 0.001 && entity.GetTrait("cooperation") < 1.0 {
 			currentCoop := entity.GetTrait("cooperation")
 			entity.SetTrait("cooperation", currentCoop+0.01)
 		}
@@ -362,7 +362,7 @@ func (br *BioRhythm) performActivity(activity ActivityType, tick int, entity *En
 		activityState.LastPerformed = tick
 		entity.Energy -= 0.15 // Exploring costs energy
 		// Small chance to "discover" food (increase food-finding ability)
-		if rand.Float64() < 0.002 {
+		if rng.Float64() < 0.002 {
 			entity.Energy += 5.0 // Found something useful
 		}
 
@@ -385,7 +385,7 @@ func (br *BioRhythm) performActivity(activity ActivityType, tick int, entity *En
 		activityState.LastPerformed = tick
 		entity.Energy -= 0.05
 		// Socializing might improve cooperation
-		if rand.Float64() < 0.001 && entity.GetTrait("cooperation") < 1.0 {
+		if rng.Float64() < 0.001 && entity.GetTrait("cooperation") < 1.0 {
 			currentCoop := entity.GetTrait("cooperation")
 			entity.SetTrait("cooperation", currentCoop+0.005)
 		}
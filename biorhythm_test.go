@@ -6,7 +6,7 @@ import (
 
 func TestBioRhythmBasics(t *testing.T) {
 	// Create a test entity
-	entity := NewEntity(1, []string{"circadian_preference", "sleep_need", "hunger_need", "thirst_need"}, "test", Position{X: 50, Y: 50})
+	entity := NewEntity(sharedRand, 1, []string{"circadian_preference", "sleep_need", "hunger_need", "thirst_need"}, "test", Position{X: 50, Y: 50})
 
 	// Set some biorhythm traits
 	entity.SetTrait("circadian_preference", -0.6) // Nocturnal
@@ -35,15 +35,15 @@ func TestBioRhythmBasics(t *testing.T) {
 
 func TestBioRhythmTimeEffects(t *testing.T) {
 	// Create entities with different circadian preferences
-	nocturnalEntity := NewEntity(1, []string{"circadian_preference", "sleep_need", "hunger_need", "thirst_need"}, "predator", Position{X: 50, Y: 50})
+	nocturnalEntity := NewEntity(sharedRand, 1, []string{"circadian_preference", "sleep_need", "hunger_need", "thirst_need"}, "predator", Position{X: 50, Y: 50})
 	nocturnalEntity.SetTrait("circadian_preference", -0.8) // Strongly nocturnal
 	// Reinitialize biorhythm with correct traits
-	nocturnalEntity.BioRhythm = NewBioRhythm(nocturnalEntity.ID, nocturnalEntity)
+	nocturnalEntity.BioRhythm = NewBioRhythm(sharedRand, nocturnalEntity.ID, nocturnalEntity)
 
-	diurnalEntity := NewEntity(2, []string{"circadian_preference", "sleep_need", "hunger_need", "thirst_need"}, "herbivore", Position{X: 50, Y: 50})
+	diurnalEntity := NewEntity(sharedRand, 2, []string{"circadian_preference", "sleep_need", "hunger_need", "thirst_need"}, "herbivore", Position{X: 50, Y: 50})
 	diurnalEntity.SetTrait("circadian_preference", 0.7) // Strongly diurnal
 	// Reinitialize biorhythm with correct traits
-	diurnalEntity.BioRhythm = NewBioRhythm(diurnalEntity.ID, diurnalEntity)
+	diurnalEntity.BioRhythm = NewBioRhythm(sharedRand, diurnalEntity.ID, diurnalEntity)
 
 	// Test night time effects
 	nightTimeState := TimeState{
@@ -64,8 +64,8 @@ func TestBioRhythmTimeEffects(t *testing.T) {
 	diurnalEntity.BioRhythm.Activities[ActivityExplore].NeedLevel = 0.1
 
 	// Update biorhythms
-	nocturnalEntity.BioRhythm.Update(100, nocturnalEntity, nightTimeState)
-	diurnalEntity.BioRhythm.Update(100, diurnalEntity, nightTimeState)
+	nocturnalEntity.BioRhythm.Update(sharedRand, 100, nocturnalEntity, nightTimeState)
+	diurnalEntity.BioRhythm.Update(sharedRand, 100, diurnalEntity, nightTimeState)
 
 	// Check activity modifiers
 	nocturnalModifier := nocturnalEntity.BioRhythm.GetActivityModifier(nocturnalEntity, nightTimeState)
@@ -100,8 +100,8 @@ func TestBioRhythmTimeEffects(t *testing.T) {
 	nocturnalEntity.BioRhythm.Activities[ActivityEat].NeedLevel = 0.1
 
 	// Update biorhythms so entities choose appropriate activities for midday
-	nocturnalEntity.BioRhythm.Update(200, nocturnalEntity, dayTimeState)
-	diurnalEntity.BioRhythm.Update(200, diurnalEntity, dayTimeState)
+	nocturnalEntity.BioRhythm.Update(sharedRand, 200, nocturnalEntity, dayTimeState)
+	diurnalEntity.BioRhythm.Update(sharedRand, 200, diurnalEntity, dayTimeState)
 
 	// Check activity modifiers
 	nocturnalModifierDay := nocturnalEntity.BioRhythm.GetActivityModifier(nocturnalEntity, dayTimeState)
@@ -117,7 +117,7 @@ func TestBioRhythmTimeEffects(t *testing.T) {
 }
 
 func TestBioRhythmNeeds(t *testing.T) {
-	entity := NewEntity(1, []string{"circadian_preference", "sleep_need", "hunger_need", "thirst_need"}, "test", Position{X: 50, Y: 50})
+	entity := NewEntity(sharedRand, 1, []string{"circadian_preference", "sleep_need", "hunger_need", "thirst_need"}, "test", Position{X: 50, Y: 50})
 	entity.SetTrait("sleep_need", 0.5)
 	entity.SetTrait("hunger_need", 0.7)
 	entity.SetTrait("thirst_need", 0.4)
@@ -142,7 +142,7 @@ func TestBioRhythmNeeds(t *testing.T) {
 
 	// Update biorhythm many times to simulate time passing
 	for i := 0; i < 200; i++ {
-		entity.BioRhythm.Update(i, entity, timeState)
+		entity.BioRhythm.Update(sharedRand, i, entity, timeState)
 	}
 
 	// Need levels should have increased over time
@@ -174,10 +174,10 @@ func TestBioRhythmNeeds(t *testing.T) {
 
 func TestBioRhythmActivitySchedule(t *testing.T) {
 	// Test nocturnal entity schedule
-	nocturnalEntity := NewEntity(1, []string{"circadian_preference"}, "predator", Position{X: 50, Y: 50})
+	nocturnalEntity := NewEntity(sharedRand, 1, []string{"circadian_preference"}, "predator", Position{X: 50, Y: 50})
 	nocturnalEntity.SetTrait("circadian_preference", -0.7) // Nocturnal
 	// Reinitialize biorhythm after setting traits
-	nocturnalEntity.BioRhythm = NewBioRhythm(nocturnalEntity.ID, nocturnalEntity)
+	nocturnalEntity.BioRhythm = NewBioRhythm(sharedRand, nocturnalEntity.ID, nocturnalEntity)
 
 	// Check that night activities include active behaviors
 	nightActivities := nocturnalEntity.BioRhythm.ActivitySchedule[Night]
@@ -206,12 +206,12 @@ func TestBioRhythmActivitySchedule(t *testing.T) {
 	}
 
 	// Test diurnal entity schedule
-	diurnalEntity := NewEntity(2, []string{"circadian_preference", "cooperation", "intelligence"}, "herbivore", Position{X: 50, Y: 50})
+	diurnalEntity := NewEntity(sharedRand, 2, []string{"circadian_preference", "cooperation", "intelligence"}, "herbivore", Position{X: 50, Y: 50})
 	diurnalEntity.SetTrait("circadian_preference", 0.8) // Diurnal
 	diurnalEntity.SetTrait("cooperation", 0.7)
 	diurnalEntity.SetTrait("intelligence", 0.6)
 	// Reinitialize biorhythm after setting traits
-	diurnalEntity.BioRhythm = NewBioRhythm(diurnalEntity.ID, diurnalEntity)
+	diurnalEntity.BioRhythm = NewBioRhythm(sharedRand, diurnalEntity.ID, diurnalEntity)
 
 	// Check that day activities include active behaviors
 	dayActivitiesDiurnal := diurnalEntity.BioRhythm.ActivitySchedule[Morning]
@@ -228,11 +228,11 @@ func TestBioRhythmActivitySchedule(t *testing.T) {
 }
 
 func TestBioRhythmEatingBehavior(t *testing.T) {
-	entity := NewEntity(1, []string{"circadian_preference", "hunger_need", "species"}, "herbivore", Position{X: 50, Y: 50})
+	entity := NewEntity(sharedRand, 1, []string{"circadian_preference", "hunger_need", "species"}, "herbivore", Position{X: 50, Y: 50})
 	entity.SetTrait("hunger_need", 0.8) // High hunger need
 
 	// Create a plant to eat
-	plant := NewPlant(0, PlantGrass, Position{X: 50, Y: 50})
+	plant := NewPlant(sharedRand, 0, PlantGrass, Position{X: 50, Y: 50})
 	plant.Energy = 50.0
 
 	// Set high hunger need
@@ -258,7 +258,7 @@ func TestBioRhythmEatingBehavior(t *testing.T) {
 	entity.Energy = 80 // High energy
 
 	// Entity should not eat when not hungry and energy is high
-	plant2 := NewPlant(1, PlantGrass, Position{X: 50, Y: 50})
+	plant2 := NewPlant(sharedRand, 1, PlantGrass, Position{X: 50, Y: 50})
 	plant2.Energy = 50.0
 
 	// With low hunger and high energy, entity should not eat
@@ -283,7 +283,7 @@ func TestBioRhythmDrinkingBehavior(t *testing.T) {
 	gridY := 5
 	world.Grid[gridY][gridX].Biome = BiomeWater
 
-	entity := NewEntity(1, []string{"thirst_need"}, "test", Position{X: 50, Y: 50})
+	entity := NewEntity(sharedRand, 1, []string{"thirst_need"}, "test", Position{X: 50, Y: 50})
 	entity.SetTrait("thirst_need", 0.6)
 	entity.Energy = 80.0 // Start with less than full energy to see the effect
 
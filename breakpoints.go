@@ -0,0 +1,262 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Breakpoint kinds
+const (
+	BreakpointTick            = "tick"             // Pause when the world reaches a tick
+	BreakpointPopulationBelow = "population_below" // Pause when a species' living count drops below a threshold
+	BreakpointSpeciation      = "speciation"       // Pause when a species appears that did not exist when armed
+)
+
+// Breakpoint pauses the simulation when its condition is met. Each breakpoint fires once;
+// re-add it to catch the next occurrence.
+type Breakpoint struct {
+	ID        int    `json:"id"`
+	Spec      string `json:"spec"` // The text the breakpoint was created from
+	Kind      string `json:"kind"`
+	Tick      int    `json:"tick,omitempty"`      // BreakpointTick: tick to stop at
+	Species   string `json:"species,omitempty"`   // BreakpointPopulationBelow: species to watch (empty = any)
+	Threshold int    `json:"threshold,omitempty"` // BreakpointPopulationBelow: pause when the count drops below this
+	Hit       bool   `json:"hit"`
+	HitTick   int    `json:"hit_tick,omitempty"`
+	HitReason string `json:"hit_reason,omitempty"`
+
+	// Species seen alive since the breakpoint was armed, filled in on the first check
+	seenSpecies map[string]bool
+}
+
+// ParseBreakpoint parses a breakpoint spec:
+//
+//	tick=N                  pause at tick N
+//	population<N            pause when any species drops below N living members
+//	population:SPECIES<N    pause when SPECIES drops below N living members
+//	speciation              pause on the first new species
+func ParseBreakpoint(spec string) (*Breakpoint, error) {
+	spec = strings.TrimSpace(spec)
+	bp := &Breakpoint{Spec: spec}
+
+	switch {
+	case spec == "speciation":
+		bp.Kind = BreakpointSpeciation
+
+	case strings.HasPrefix(spec, "tick="):
+		tick, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(spec, "tick=")))
+		if err != nil || tick <= 0 {
+			return nil, fmt.Errorf("invalid breakpoint %q: tick must be a positive integer", spec)
+		}
+		bp.Kind = BreakpointTick
+		bp.Tick = tick
+
+	case strings.HasPrefix(spec, "population"):
+		condition := strings.TrimPrefix(spec, "population")
+		lt := strings.Index(condition, "<")
+		if lt < 0 {
+			return nil, fmt.Errorf("invalid breakpoint %q: expected population<N or population:SPECIES<N", spec)
+		}
+		if species := condition[:lt]; species != "" {
+			if !strings.HasPrefix(species, ":") || len(species) == 1 {
+				return nil, fmt.Errorf("invalid breakpoint %q: expected population:SPECIES<N", spec)
+			}
+			bp.Species = strings.TrimSpace(species[1:])
+		}
+		threshold, err := strconv.Atoi(strings.TrimSpace(condition[lt+1:]))
+		if err != nil || threshold <= 0 {
+			return nil, fmt.Errorf("invalid breakpoint %q: threshold must be a positive integer", spec)
+		}
+		bp.Kind = BreakpointPopulationBelow
+		bp.Threshold = threshold
+
+	default:
+		return nil, fmt.Errorf("unknown breakpoint %q (use tick=N, population<N, population:SPECIES<N or speciation)", spec)
+	}
+	return bp, nil
+}
+
+// BreakpointSystem holds the breakpoints armed on a world
+type BreakpointSystem struct {
+	mu          sync.Mutex
+	breakpoints []*Breakpoint
+	nextID      int
+	lastHit     *Breakpoint // Most recent breakpoint to fire, cleared when the world resumes
+}
+
+// NewBreakpointSystem creates an empty breakpoint system
+func NewBreakpointSystem() *BreakpointSystem {
+	return &BreakpointSystem{nextID: 1}
+}
+
+// Add parses and arms a breakpoint. Speciation breakpoints compare against the species
+// alive in w, or against those alive at the first check if w is nil or still empty.
+func (bs *BreakpointSystem) Add(spec string, w *World) (*Breakpoint, error) {
+	bp, err := ParseBreakpoint(spec)
+	if err != nil {
+		return nil, err
+	}
+	if bp.Kind == BreakpointSpeciation && w != nil {
+		if counts := livingSpeciesCounts(w); len(counts) > 0 {
+			bp.seenSpecies = make(map[string]bool)
+			for species := range counts {
+				bp.seenSpecies[species] = true
+			}
+		}
+	}
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bp.ID = bs.nextID
+	bs.nextID++
+	bs.breakpoints = append(bs.breakpoints, bp)
+	return bp, nil
+}
+
+// Remove deletes a breakpoint by ID
+func (bs *BreakpointSystem) Remove(id int) bool {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	for i, bp := range bs.breakpoints {
+		if bp.ID == id {
+			bs.breakpoints = append(bs.breakpoints[:i], bs.breakpoints[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// List returns copies of all breakpoints, including ones that have already fired
+func (bs *BreakpointSystem) List() []Breakpoint {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	list := make([]Breakpoint, 0, len(bs.breakpoints))
+	for _, bp := range bs.breakpoints {
+		copied := *bp
+		copied.seenSpecies = nil
+		list = append(list, copied)
+	}
+	return list
+}
+
+// LastHit returns the breakpoint that most recently paused the world, or nil
+func (bs *BreakpointSystem) LastHit() *Breakpoint {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	if bs.lastHit == nil {
+		return nil
+	}
+	copied := *bs.lastHit
+	copied.seenSpecies = nil
+	return &copied
+}
+
+// Check evaluates armed breakpoints against the world after a tick and returns the
+// first one that fired, or nil
+func (bs *BreakpointSystem) Check(w *World) *Breakpoint {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bs.lastHit = nil
+
+	var counts map[string]int
+	for _, bp := range bs.breakpoints {
+		if bp.Hit {
+			continue
+		}
+		if counts == nil {
+			counts = livingSpeciesCounts(w)
+		}
+		if reason := bp.evaluate(w.Tick, counts); reason != "" {
+			bp.Hit = true
+			bp.HitTick = w.Tick
+			bp.HitReason = reason
+			bs.lastHit = bp
+			return bp
+		}
+	}
+	return nil
+}
+
+// evaluate returns why the breakpoint fires, or an empty string if it doesn't
+func (bp *Breakpoint) evaluate(tick int, counts map[string]int) string {
+	switch bp.Kind {
+	case BreakpointTick:
+		if tick >= bp.Tick {
+			return fmt.Sprintf("reached tick %d", tick)
+		}
+
+	case BreakpointPopulationBelow:
+		if bp.Species != "" {
+			if counts[bp.Species] < bp.Threshold {
+				return fmt.Sprintf("%s dropped to %d (below %d)", bp.Species, counts[bp.Species], bp.Threshold)
+			}
+			return ""
+		}
+		// Species that died out since being armed count as zero
+		if bp.seenSpecies == nil {
+			bp.seenSpecies = make(map[string]bool)
+		}
+		for species := range counts {
+			bp.seenSpecies[species] = true
+		}
+		for _, species := range sortedKeys(bp.seenSpecies) {
+			if counts[species] < bp.Threshold {
+				return fmt.Sprintf("%s dropped to %d (below %d)", species, counts[species], bp.Threshold)
+			}
+		}
+
+	case BreakpointSpeciation:
+		if bp.seenSpecies == nil {
+			// Arm against the species alive on the first check that finds any
+			if len(counts) > 0 {
+				bp.seenSpecies = make(map[string]bool)
+				for species := range counts {
+					bp.seenSpecies[species] = true
+				}
+			}
+			return ""
+		}
+		newSpecies := make([]string, 0)
+		for species := range counts {
+			if !bp.seenSpecies[species] {
+				newSpecies = append(newSpecies, species)
+			}
+		}
+		if len(newSpecies) > 0 {
+			sort.Strings(newSpecies)
+			return fmt.Sprintf("new species %s appeared", strings.Join(newSpecies, ", "))
+		}
+	}
+	return ""
+}
+
+// livingSpeciesCounts counts living entities by species
+func livingSpeciesCounts(w *World) map[string]int {
+	counts := make(map[string]int)
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive {
+			counts[entity.Species]++
+		}
+	}
+	return counts
+}
+
+// checkBreakpoints pauses the world when a breakpoint fires
+func (w *World) checkBreakpoints() {
+	if w.Breakpoints == nil {
+		return
+	}
+	hit := w.Breakpoints.Check(w)
+	if hit == nil {
+		return
+	}
+
+	w.Paused = true
+	if w.CentralEventBus != nil {
+		w.CentralEventBus.EmitSystemEvent(w.Tick, "breakpoint", "hit", "breakpoints",
+			fmt.Sprintf("Paused at breakpoint %q: %s", hit.Spec, hit.HitReason), nil,
+			map[string]interface{}{"breakpoint_id": hit.ID, "spec": hit.Spec, "reason": hit.HitReason})
+	}
+}
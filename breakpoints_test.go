@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseBreakpoint(t *testing.T) {
+	valid := map[string]Breakpoint{
+		"tick=500":                 {Kind: BreakpointTick, Tick: 500},
+		"population<5":             {Kind: BreakpointPopulationBelow, Threshold: 5},
+		"population:herbivore < 3": {Kind: BreakpointPopulationBelow, Species: "herbivore", Threshold: 3},
+		"speciation":               {Kind: BreakpointSpeciation},
+	}
+	for spec, expected := range valid {
+		bp, err := ParseBreakpoint(spec)
+		if err != nil {
+			t.Errorf("ParseBreakpoint(%q) failed: %v", spec, err)
+			continue
+		}
+		if bp.Kind != expected.Kind || bp.Tick != expected.Tick || bp.Species != expected.Species || bp.Threshold != expected.Threshold {
+			t.Errorf("ParseBreakpoint(%q) = %+v, expected %+v", spec, *bp, expected)
+		}
+	}
+
+	for _, spec := range []string{"", "tick=", "tick=-1", "population", "population<0", "population:<5", "populationherbivore<5", "extinction"} {
+		if _, err := ParseBreakpoint(spec); err == nil {
+			t.Errorf("Expected ParseBreakpoint(%q) to fail", spec)
+		}
+	}
+}
+
+func TestTickBreakpointPausesWorld(t *testing.T) {
+	world := NewWorld(WorldConfig{Width: 30, Height: 30, GridWidth: 6, GridHeight: 6, Breakpoints: []string{"tick=3"}})
+
+	for i := 0; i < 10; i++ {
+		world.Update()
+	}
+	if world.Tick != 3 || !world.IsPaused() {
+		t.Fatalf("Expected world paused at tick 3, got tick %d (paused=%v)", world.Tick, world.IsPaused())
+	}
+	hit := world.Breakpoints.LastHit()
+	if hit == nil || hit.Spec != "tick=3" {
+		t.Fatalf("Expected tick=3 to be reported as the last hit, got %+v", hit)
+	}
+
+	// Breakpoints fire once, so resuming runs on
+	world.SetPaused(false)
+	world.Update()
+	if world.Tick != 4 || world.IsPaused() {
+		t.Errorf("Expected world to resume after the breakpoint, got tick %d (paused=%v)", world.Tick, world.IsPaused())
+	}
+	if world.Breakpoints.LastHit() != nil {
+		t.Error("Expected last hit to clear once the world resumes")
+	}
+}
+
+func TestPopulationAndSpeciationBreakpoints(t *testing.T) {
+	world := NewWorld(WorldConfig{Width: 30, Height: 30, GridWidth: 6, GridHeight: 6})
+	for i := 0; i < 4; i++ {
+		world.AllEntities = append(world.AllEntities, &Entity{ID: i, Species: "herbivore", IsAlive: true})
+	}
+
+	if _, err := world.Breakpoints.Add("population:herbivore<3", world); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := world.Breakpoints.Add("speciation", world); err != nil {
+		t.Fatal(err)
+	}
+	if hit := world.Breakpoints.Check(world); hit != nil {
+		t.Fatalf("Expected no breakpoint with 4 herbivores, got %s", hit.Spec)
+	}
+
+	world.AllEntities[0].Species = "herbivore_2"
+	hit := world.Breakpoints.Check(world)
+	if hit == nil || hit.Kind != BreakpointSpeciation {
+		t.Fatalf("Expected speciation breakpoint to fire, got %+v", hit)
+	}
+
+	world.AllEntities[1].IsAlive = false
+	hit = world.Breakpoints.Check(world)
+	if hit == nil || hit.Kind != BreakpointPopulationBelow {
+		t.Fatalf("Expected population breakpoint to fire with 2 herbivores, got %+v", hit)
+	}
+	if hit.HitReason != "herbivore dropped to 2 (below 3)" {
+		t.Errorf("Unexpected hit reason %q", hit.HitReason)
+	}
+}
+
+func TestBreakpointAPI(t *testing.T) {
+	wi := NewWebInterface(NewWorld(WorldConfig{Width: 30, Height: 30, GridWidth: 6, GridHeight: 6}))
+
+	rec := httptest.NewRecorder()
+	wi.handleBreakpoints(rec, httptest.NewRequest(http.MethodPost, "/api/breakpoints", bytes.NewReader([]byte(`{"spec": "bogus"}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid spec, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleBreakpoints(rec, httptest.NewRequest(http.MethodPost, "/api/breakpoints", bytes.NewReader([]byte(`{"spec": "tick=100"}`))))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Add failed: %d %s", rec.Code, rec.Body.String())
+	}
+	var created Breakpoint
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Invalid breakpoint: %v", err)
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleBreakpoints(rec, httptest.NewRequest(http.MethodGet, "/api/breakpoints", nil))
+	var listed struct {
+		Breakpoints []Breakpoint `json:"breakpoints"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &listed); err != nil || len(listed.Breakpoints) != 1 {
+		t.Fatalf("Expected one breakpoint listed, got %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleBreakpoints(rec, httptest.NewRequest(http.MethodDelete, "/api/breakpoints?id=1", nil))
+	if rec.Code != http.StatusOK || len(wi.world.Breakpoints.List()) != 0 {
+		t.Errorf("Expected breakpoint %d to be removed, got %d", created.ID, rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	wi.handleBreakpoints(rec, httptest.NewRequest(http.MethodDelete, "/api/breakpoints?id=1", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 removing a missing breakpoint, got %d", rec.Code)
+	}
+}
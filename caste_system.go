@@ -60,7 +60,7 @@ type CasteStatus struct {
 }
 
 // NewCasteStatus creates a new caste status for an entity
-func NewCasteStatus(role CasteRole) *CasteStatus {
+func NewCasteStatus(rng *rand.Rand, role CasteRole) *CasteStatus {
 	reproductiveCapability := 1.0
 	canChangeRole := true
 
@@ -94,9 +94,9 @@ func NewCasteStatus(role CasteRole) *CasteStatus {
 
 	return &CasteStatus{
 		Role:                   role,
-		RoleSpecialization:     0.5 + rand.Float64()*0.3, // 0.5-0.8
-		CasteLoyalty:           0.7 + rand.Float64()*0.3, // 0.7-1.0
-		RoleEfficiency:         0.4 + rand.Float64()*0.4, // 0.4-0.8
+		RoleSpecialization:     0.5 + rng.Float64()*0.3, // 0.5-0.8
+		CasteLoyalty:           0.7 + rng.Float64()*0.3, // 0.7-1.0
+		RoleEfficiency:         0.4 + rng.Float64()*0.4, // 0.4-0.8
 		CanChangeRole:          canChangeRole,
 		ReproductiveCapability: reproductiveCapability,
 	}
@@ -138,13 +138,15 @@ type CasteColony struct {
 	ResourceGeneration  map[string]float64 `json:"resource_generation"`  // Resource production per tick
 	ResourceConsumption map[string]float64 `json:"resource_consumption"` // Resource consumption per tick
 	TradeRoutes         []*TradeRoute      `json:"trade_routes"`         // Active trade routes
+
+	rng *rand.Rand // The world's random source
 }
 
 // NewCasteColony creates a new caste-based colony
-func NewCasteColony(id int, queen *Entity, nestLocation Position) *CasteColony {
+func NewCasteColony(rng *rand.Rand, id int, queen *Entity, nestLocation Position) *CasteColony {
 	// Ensure queen has proper caste status
 	if queen.CasteStatus == nil {
-		queen.CasteStatus = NewCasteStatus(Queen)
+		queen.CasteStatus = NewCasteStatus(rng, Queen)
 	} else {
 		queen.CasteStatus.Role = Queen
 		queen.CasteStatus.ReproductiveCapability = 3.0
@@ -168,13 +170,13 @@ func NewCasteColony(id int, queen *Entity, nestLocation Position) *CasteColony {
 		NestLocation:      nestLocation,
 		ColonyAge:         0,
 		ColonyFitness:     queen.Fitness,
-		MaxColonySize:     100 + rand.Intn(400), // 100-500 members
+		MaxColonySize:     100 + rng.Intn(400), // 100-500 members
 		ReproductionRate:  0.1,
 		Resources: map[string]float64{
-			"food":      25.0 + rand.Float64()*25.0, // 25-50 starting food
-			"biomass":   10.0 + rand.Float64()*15.0, // 10-25 starting biomass
-			"energy":    15.0 + rand.Float64()*20.0, // 15-35 starting energy
-			"materials": 5.0 + rand.Float64()*10.0,  // 5-15 starting materials
+			"food":      25.0 + rng.Float64()*25.0, // 25-50 starting food
+			"biomass":   10.0 + rng.Float64()*15.0, // 10-25 starting biomass
+			"energy":    15.0 + rng.Float64()*20.0, // 15-35 starting energy
+			"materials": 5.0 + rng.Float64()*10.0,  // 5-15 starting materials
 		},
 		ResourceGeneration: map[string]float64{
 			"food":      0.5, // Base food generation per tick
@@ -187,6 +189,7 @@ func NewCasteColony(id int, queen *Entity, nestLocation Position) *CasteColony {
 			"energy": 0.2, // Base energy consumption per tick
 		},
 		TradeRoutes: make([]*TradeRoute, 0),
+		rng:         rng,
 	}
 
 	colony.CasteDistribution[Queen] = 1
@@ -236,7 +239,7 @@ func (cc *CasteColony) AddMember(entity *Entity, assignedRole CasteRole) bool {
 
 	// Assign caste status if not already present
 	if entity.CasteStatus == nil {
-		entity.CasteStatus = NewCasteStatus(assignedRole)
+		entity.CasteStatus = NewCasteStatus(cc.rng, assignedRole)
 	} else {
 		// Update role if changeable
 		if entity.CasteStatus.CanChangeRole {
@@ -327,8 +330,7 @@ func (cc *CasteColony) modifyTraitsForRole(entity *Entity, role CasteRole) {
 
 	// Ensure traits stay within bounds
 	for name, trait := range entity.Traits {
-		value := math.Max(-2.0, math.Min(2.0, trait.Value))
-		entity.SetTrait(name, value)
+		entity.Traits[name] = Trait{Name: name, Value: math.Max(-2.0, math.Min(2.0, trait.Value))}
 	}
 }
 
@@ -467,22 +469,23 @@ func (cc *CasteColony) performWorkerActions(worker *Entity, world *World, tick i
 	// Workers focus on foraging and basic labor
 	if worker.Energy < 50 {
 		// Seek food sources
-		if targetX, targetY, found := worker.SeekNutrition(world.AllPlants, world.AllEntities, 20.0); found {
+		index := world.spatialIndex()
+		plants, entities := index.QueryPlantRadius(worker.Position, 20.0), index.QueryRadius(worker.Position, 20.0)
+		if targetX, targetY, found := worker.SeekNutrition(plants, entities, 20.0, world.Geometry()); found {
 			speed := worker.GetTrait("speed") * worker.CasteStatus.RoleEfficiency
-			worker.MoveTo(targetX, targetY, speed)
+			worker.MoveTo(targetX, targetY, speed, world.Geometry())
 		}
 	} else {
 		// Return to nest area when well-fed
-		distance := math.Sqrt(math.Pow(worker.Position.X-cc.NestLocation.X, 2) +
-			math.Pow(worker.Position.Y-cc.NestLocation.Y, 2))
+		distance := world.Distance(worker.Position, cc.NestLocation)
 		if distance > 15.0 {
 			speed := worker.GetTrait("speed") * 0.8
-			worker.MoveTo(cc.NestLocation.X, cc.NestLocation.Y, speed)
+			worker.MoveTo(cc.NestLocation.X, cc.NestLocation.Y, speed, world.Geometry())
 		}
 	}
 
 	// Increase role efficiency through practice
-	if rand.Float64() < 0.01 {
+	if cc.rng.Float64() < 0.01 {
 		worker.CasteStatus.RoleEfficiency = math.Min(1.0, worker.CasteStatus.RoleEfficiency+0.01)
 	}
 }
@@ -494,15 +497,15 @@ func (cc *CasteColony) performSoldierActions(soldier *Entity, world *World, tick
 	}
 
 	// Soldiers defend territory and hunt threats
-	nearbyThreats := cc.findNearbyThreats(soldier, world.AllEntities, 25.0)
+	nearbyThreats := cc.findNearbyThreats(soldier, world.AllEntities, 25.0, world.Geometry())
 
 	if len(nearbyThreats) > 0 {
 		// Engage closest threat
 		closest := nearbyThreats[0]
-		minDistance := soldier.DistanceTo(closest)
+		minDistance := world.Distance(soldier.Position, closest.Position)
 
 		for _, threat := range nearbyThreats[1:] {
-			distance := soldier.DistanceTo(threat)
+			distance := world.Distance(soldier.Position, threat.Position)
 			if distance < minDistance {
 				minDistance = distance
 				closest = threat
@@ -510,16 +513,16 @@ func (cc *CasteColony) performSoldierActions(soldier *Entity, world *World, tick
 		}
 
 		// Attack if close enough
-		if minDistance < 3.0 && soldier.CanKill(closest) {
-			soldier.Kill(closest)
+		if minDistance < 3.0 && soldier.CanKill(world.Rand, closest) {
+			soldier.Kill(world.Rand, closest)
 		} else {
 			// Move toward threat
 			speed := soldier.GetTrait("speed") * 1.2 // Soldiers move faster when hunting
-			soldier.MoveTo(closest.Position.X, closest.Position.Y, speed)
+			soldier.MoveTo(closest.Position.X, closest.Position.Y, speed, world.Geometry())
 		}
 	} else {
 		// Patrol territory
-		cc.patrolTerritory(soldier)
+		cc.patrolTerritory(soldier, world.Geometry())
 	}
 }
 
@@ -533,18 +536,18 @@ func (cc *CasteColony) performQueenActions(queen *Entity, world *World, tick int
 	if queen.Energy > 60 && cc.ColonySize < cc.MaxColonySize {
 		// Look for reproduction opportunities
 		if queen.ReproductionStatus != nil && queen.ReproductionStatus.ReadyToMate {
-			nearbyDrones := cc.findNearbyDrones(queen, 30.0)
+			nearbyDrones := cc.findNearbyDrones(queen, 30.0, world.Geometry())
 			if len(nearbyDrones) > 0 {
 				// Attempt reproduction with best drone
 				bestDrone := cc.selectBestMate(queen, nearbyDrones)
 				if bestDrone != nil {
-					distance := queen.DistanceTo(bestDrone)
+					distance := world.Distance(queen.Position, bestDrone.Position)
 					if distance < 5.0 {
 						// Reproduce (handled by reproduction system)
 						world.ReproductionSystem.StartMating(queen, bestDrone, tick)
 					} else {
 						// Move toward mate
-						queen.MoveTo(bestDrone.Position.X, bestDrone.Position.Y, queen.GetTrait("speed")*0.5)
+						queen.MoveTo(bestDrone.Position.X, bestDrone.Position.Y, queen.GetTrait("speed")*0.5, world.Geometry())
 					}
 				}
 			}
@@ -552,10 +555,9 @@ func (cc *CasteColony) performQueenActions(queen *Entity, world *World, tick int
 	}
 
 	// Stay near nest
-	distance := math.Sqrt(math.Pow(queen.Position.X-cc.NestLocation.X, 2) +
-		math.Pow(queen.Position.Y-cc.NestLocation.Y, 2))
+	distance := world.Distance(queen.Position, cc.NestLocation)
 	if distance > 10.0 {
-		queen.MoveTo(cc.NestLocation.X, cc.NestLocation.Y, queen.GetTrait("speed")*0.3)
+		queen.MoveTo(cc.NestLocation.X, cc.NestLocation.Y, queen.GetTrait("speed")*0.3, world.Geometry())
 	}
 }
 
@@ -569,17 +571,19 @@ func (cc *CasteColony) performScoutActions(scout *Entity, world *World, tick int
 	explorationRadius := 50.0 + scout.GetTrait("exploration_drive")*30.0
 
 	// Move to unexplored areas
-	angle := rand.Float64() * 2 * math.Pi
-	distance := 20.0 + rand.Float64()*explorationRadius
+	angle := cc.rng.Float64() * 2 * math.Pi
+	distance := 20.0 + cc.rng.Float64()*explorationRadius
 
 	targetX := scout.Position.X + math.Cos(angle)*distance
 	targetY := scout.Position.Y + math.Sin(angle)*distance
 
 	speed := scout.GetTrait("speed") * 1.3 // Scouts are fast
-	scout.MoveTo(targetX, targetY, speed)
+	scout.MoveTo(targetX, targetY, speed, world.Geometry())
 
 	// Look for food sources and report back
-	if targetX, targetY, found := scout.SeekNutrition(world.AllPlants, world.AllEntities, 30.0); found {
+	index := world.spatialIndex()
+	plants, entities := index.QueryPlantRadius(scout.Position, 30.0), index.QueryRadius(scout.Position, 30.0)
+	if targetX, targetY, found := scout.SeekNutrition(plants, entities, 30.0, world.Geometry()); found {
 		// Send food signal to colony
 		world.CommunicationSystem.SendSignal(scout, SignalFood, map[string]interface{}{
 			"x":       targetX,
@@ -596,15 +600,15 @@ func (cc *CasteColony) performNurseActions(nurse *Entity, world *World, tick int
 	}
 
 	// Nurses care for young and weak colony members
-	weakMembers := cc.findWeakMembers(nurse, 15.0)
+	weakMembers := cc.findWeakMembers(nurse, 15.0, world.Geometry())
 
 	if len(weakMembers) > 0 {
 		// Help closest weak member
 		closest := weakMembers[0]
-		minDistance := nurse.DistanceTo(closest)
+		minDistance := world.Distance(nurse.Position, closest.Position)
 
 		for _, weak := range weakMembers[1:] {
-			distance := nurse.DistanceTo(weak)
+			distance := world.Distance(nurse.Position, weak.Position)
 			if distance < minDistance {
 				minDistance = distance
 				closest = weak
@@ -620,14 +624,13 @@ func (cc *CasteColony) performNurseActions(nurse *Entity, world *World, tick int
 			}
 		} else {
 			// Move toward weak member
-			nurse.MoveTo(closest.Position.X, closest.Position.Y, nurse.GetTrait("speed"))
+			nurse.MoveTo(closest.Position.X, closest.Position.Y, nurse.GetTrait("speed"), world.Geometry())
 		}
 	} else {
 		// Stay near nest when no one needs care
-		distance := math.Sqrt(math.Pow(nurse.Position.X-cc.NestLocation.X, 2) +
-			math.Pow(nurse.Position.Y-cc.NestLocation.Y, 2))
+		distance := world.Distance(nurse.Position, cc.NestLocation)
 		if distance > 12.0 {
-			nurse.MoveTo(cc.NestLocation.X, cc.NestLocation.Y, nurse.GetTrait("speed")*0.6)
+			nurse.MoveTo(cc.NestLocation.X, cc.NestLocation.Y, nurse.GetTrait("speed")*0.6, world.Geometry())
 		}
 	}
 }
@@ -645,14 +648,13 @@ func (cc *CasteColony) performBuilderActions(builder *Entity, world *World, tick
 			if structure.Tribe != nil && structure.Tribe.ID == cc.ID {
 				if structure.Health < structure.MaxHealth*0.7 {
 					// Repair structure
-					distance := math.Sqrt(math.Pow(builder.Position.X-structure.Position.X, 2) +
-						math.Pow(builder.Position.Y-structure.Position.Y, 2))
+					distance := world.Distance(builder.Position, structure.Position)
 
 					if distance < 5.0 {
 						structure.Repair(builder, 10.0)
 					} else {
 						// Move toward structure
-						builder.MoveTo(structure.Position.X, structure.Position.Y, builder.GetTrait("speed"))
+						builder.MoveTo(structure.Position.X, structure.Position.Y, builder.GetTrait("speed"), world.Geometry())
 					}
 					return
 				}
@@ -663,12 +665,11 @@ func (cc *CasteColony) performBuilderActions(builder *Entity, world *World, tick
 		if cc.ColonySize > 20 && len(world.CivilizationSystem.Structures) < cc.ColonySize/10 {
 			// Try to build a cache near nest
 			buildPos := Position{
-				X: cc.NestLocation.X + (rand.Float64()-0.5)*20.0,
-				Y: cc.NestLocation.Y + (rand.Float64()-0.5)*20.0,
+				X: cc.NestLocation.X + (cc.rng.Float64()-0.5)*20.0,
+				Y: cc.NestLocation.Y + (cc.rng.Float64()-0.5)*20.0,
 			}
 
-			distance := math.Sqrt(math.Pow(builder.Position.X-buildPos.X, 2) +
-				math.Pow(builder.Position.Y-buildPos.Y, 2))
+			distance := world.Distance(builder.Position, buildPos)
 
 			if distance < 3.0 {
 				// Attempt to build
@@ -680,7 +681,7 @@ func (cc *CasteColony) performBuilderActions(builder *Entity, world *World, tick
 				}
 			} else {
 				// Move toward build location
-				builder.MoveTo(buildPos.X, buildPos.Y, builder.GetTrait("speed"))
+				builder.MoveTo(buildPos.X, buildPos.Y, builder.GetTrait("speed"), world.Geometry())
 			}
 		}
 	}
@@ -709,7 +710,7 @@ func (cc *CasteColony) performSpecialistActions(specialist *Entity, world *World
 
 // Helper methods for caste actions
 
-func (cc *CasteColony) findNearbyThreats(soldier *Entity, allEntities []*Entity, radius float64) []*Entity {
+func (cc *CasteColony) findNearbyThreats(soldier *Entity, allEntities []*Entity, radius float64, geometry WorldGeometry) []*Entity {
 	threats := make([]*Entity, 0)
 
 	for _, entity := range allEntities {
@@ -717,7 +718,7 @@ func (cc *CasteColony) findNearbyThreats(soldier *Entity, allEntities []*Entity,
 			continue
 		}
 
-		distance := soldier.DistanceTo(entity)
+		distance := geometry.Distance(soldier.Position, entity.Position)
 		if distance <= radius {
 			// Consider aggressive entities as threats
 			if entity.GetTrait("aggression") > 0.3 || entity.Species == "predator" {
@@ -729,7 +730,7 @@ func (cc *CasteColony) findNearbyThreats(soldier *Entity, allEntities []*Entity,
 	return threats
 }
 
-func (cc *CasteColony) findNearbyDrones(queen *Entity, radius float64) []*Entity {
+func (cc *CasteColony) findNearbyDrones(queen *Entity, radius float64, geometry WorldGeometry) []*Entity {
 	drones := make([]*Entity, 0)
 
 	for _, member := range cc.Members {
@@ -738,7 +739,7 @@ func (cc *CasteColony) findNearbyDrones(queen *Entity, radius float64) []*Entity
 		}
 
 		if member.CasteStatus.Role == Drone {
-			distance := queen.DistanceTo(member)
+			distance := geometry.Distance(queen.Position, member.Position)
 			if distance <= radius {
 				drones = append(drones, member)
 			}
@@ -776,7 +777,7 @@ func (cc *CasteColony) calculateMateScore(queen *Entity, drone *Entity) float64
 	return intelligenceScore*0.4 + fitnessScore*0.4 + reproductiveScore*0.2
 }
 
-func (cc *CasteColony) findWeakMembers(nurse *Entity, radius float64) []*Entity {
+func (cc *CasteColony) findWeakMembers(nurse *Entity, radius float64, geometry WorldGeometry) []*Entity {
 	weakMembers := make([]*Entity, 0)
 
 	for _, member := range cc.Members {
@@ -784,7 +785,7 @@ func (cc *CasteColony) findWeakMembers(nurse *Entity, radius float64) []*Entity
 			continue
 		}
 
-		distance := nurse.DistanceTo(member)
+		distance := geometry.Distance(nurse.Position, member.Position)
 		if distance <= radius && member.Energy < 25.0 {
 			weakMembers = append(weakMembers, member)
 		}
@@ -793,15 +794,15 @@ func (cc *CasteColony) findWeakMembers(nurse *Entity, radius float64) []*Entity
 	return weakMembers
 }
 
-func (cc *CasteColony) patrolTerritory(soldier *Entity) {
+func (cc *CasteColony) patrolTerritory(soldier *Entity, geometry WorldGeometry) {
 	// Simple patrol pattern around nest
-	angle := rand.Float64() * 2 * math.Pi
-	patrolRadius := 20.0 + rand.Float64()*15.0
+	angle := cc.rng.Float64() * 2 * math.Pi
+	patrolRadius := 20.0 + cc.rng.Float64()*15.0
 
 	targetX := cc.NestLocation.X + math.Cos(angle)*patrolRadius
 	targetY := cc.NestLocation.Y + math.Sin(angle)*patrolRadius
 
-	soldier.MoveTo(targetX, targetY, soldier.GetTrait("speed")*0.8)
+	soldier.MoveTo(targetX, targetY, soldier.GetTrait("speed")*0.8, geometry)
 }
 
 func (cc *CasteColony) findColonyTribe(world *World) *Tribe {
@@ -820,7 +821,7 @@ func (cc *CasteColony) findColonyTribe(world *World) *Tribe {
 
 func (cc *CasteColony) coordinateColonyActions(specialist *Entity, world *World, tick int) {
 	// Advanced coordination - send strategic signals
-	if rand.Float64() < 0.1 { // 10% chance per tick
+	if cc.rng.Float64() < 0.1 { // 10% chance per tick
 		// Analyze colony needs and send appropriate signals
 		workerCount := cc.CasteDistribution[Worker]
 		if float64(workerCount)/float64(cc.ColonySize) < 0.4 {
@@ -835,7 +836,7 @@ func (cc *CasteColony) coordinateColonyActions(specialist *Entity, world *World,
 
 func (cc *CasteColony) assistComplexTasks(specialist *Entity, world *World, tick int) {
 	// Help with various colony tasks
-	if rand.Float64() < 0.5 {
+	if cc.rng.Float64() < 0.5 {
 		// Act as advanced worker
 		cc.performWorkerActions(specialist, world, tick)
 	} else {
@@ -941,7 +942,8 @@ func (cc *CasteColony) calculateDistributionBonus() float64 {
 		Queen:      0.01, // 1% queens
 	}
 
-	for role, optimalRatio := range optimalRatios {
+	for _, role := range sortedKeys(optimalRatios) {
+		optimalRatio := optimalRatios[role]
 		currentRatio := float64(cc.CasteDistribution[role]) / float64(cc.ColonySize)
 		diff := math.Abs(currentRatio - optimalRatio)
 
@@ -966,7 +968,7 @@ func (cc *CasteColony) reassignRoles() {
 			currentEfficiency := member.CasteStatus.RoleEfficiency
 
 			// Assume new role starts with lower efficiency
-			if currentEfficiency < 0.6 || rand.Float64() < 0.1 { // 10% chance to change
+			if currentEfficiency < 0.6 || cc.rng.Float64() < 0.1 { // 10% chance to change
 				// Change role
 				cc.CasteDistribution[member.CasteStatus.Role]--
 				member.CasteStatus.Role = optimalRole
@@ -983,6 +985,8 @@ func (cc *CasteColony) reassignRoles() {
 type CasteSystem struct {
 	Colonies     []*CasteColony `json:"colonies"`
 	NextColonyID int            `json:"next_colony_id"`
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewCasteSystem creates a new caste management system
@@ -990,6 +994,7 @@ func NewCasteSystem() *CasteSystem {
 	return &CasteSystem{
 		Colonies:     make([]*CasteColony, 0),
 		NextColonyID: 1,
+		rng:          sharedRand,
 	}
 }
 
@@ -1025,7 +1030,7 @@ func (cs *CasteSystem) TryFormCasteColony(entities []*Entity, nestLocation Posit
 	}
 
 	// Create new colony
-	colony := NewCasteColony(cs.NextColonyID, queenCandidate, nestLocation)
+	colony := NewCasteColony(cs.rng, cs.NextColonyID, queenCandidate, nestLocation)
 	cs.NextColonyID++
 
 	// Add other entities with appropriate roles
@@ -1074,7 +1079,7 @@ func (cs *CasteSystem) GetColonyByMember(entity *Entity) *CasteColony {
 }
 
 // AddCasteStatusToEntity adds caste status to an entity if it doesn't have one
-func AddCasteStatusToEntity(entity *Entity) {
+func AddCasteStatusToEntity(rng *rand.Rand, entity *Entity) {
 	if entity.CasteStatus == nil {
 		// Determine role based on traits
 		role := Worker // Default role
@@ -1087,7 +1092,7 @@ func AddCasteStatusToEntity(entity *Entity) {
 			role = Scout
 		}
 
-		entity.CasteStatus = NewCasteStatus(role)
+		entity.CasteStatus = NewCasteStatus(rng, role)
 	}
 }
 
@@ -1105,7 +1110,8 @@ func (cc *CasteColony) UpdateResources(tick int) {
 	specialistEfficiency := float64(cc.CasteDistribution[Specialist]) * 1.2
 
 	// Generate resources
-	for resourceType, baseGeneration := range cc.ResourceGeneration {
+	for _, resourceType := range sortedKeys(cc.ResourceGeneration) {
+		baseGeneration := cc.ResourceGeneration[resourceType]
 		production := baseGeneration
 
 		switch resourceType {
@@ -1126,7 +1132,8 @@ func (cc *CasteColony) UpdateResources(tick int) {
 	}
 
 	// Consume resources
-	for resourceType, baseConsumption := range cc.ResourceConsumption {
+	for _, resourceType := range sortedKeys(cc.ResourceConsumption) {
+		baseConsumption := cc.ResourceConsumption[resourceType]
 		consumption := baseConsumption * float64(cc.ColonySize) * 0.1
 
 		if cc.Resources[resourceType] >= consumption {
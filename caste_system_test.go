@@ -10,7 +10,7 @@ func TestCasteSystem(t *testing.T) {
 	traitNames := []string{"intelligence", "cooperation", "strength", "aggression", "leadership", "reproductive_capability"}
 
 	// Create a queen candidate
-	queen := NewEntity(1, traitNames, "test_species", Position{X: 0, Y: 0})
+	queen := NewEntity(sharedRand, 1, traitNames, "test_species", Position{X: 0, Y: 0})
 	queen.SetTrait("intelligence", 0.8)
 	queen.SetTrait("leadership", 0.7)
 	queen.SetTrait("reproductive_capability", 0.9)
@@ -19,7 +19,7 @@ func TestCasteSystem(t *testing.T) {
 
 	// Create worker candidates
 	for i := 2; i <= 4; i++ {
-		worker := NewEntity(i, traitNames, "test_species", Position{X: float64(i * 2), Y: 0})
+		worker := NewEntity(sharedRand, i, traitNames, "test_species", Position{X: float64(i * 2), Y: 0})
 		worker.SetTrait("intelligence", 0.4)
 		worker.SetTrait("cooperation", 0.7)
 		worker.SetTrait("endurance", 0.6)
@@ -28,7 +28,7 @@ func TestCasteSystem(t *testing.T) {
 
 	// Create soldier candidates
 	for i := 5; i <= 6; i++ {
-		soldier := NewEntity(i, traitNames, "test_species", Position{X: float64(i * 2), Y: 0})
+		soldier := NewEntity(sharedRand, i, traitNames, "test_species", Position{X: float64(i * 2), Y: 0})
 		soldier.SetTrait("aggression", 0.8)
 		soldier.SetTrait("strength", 0.7)
 		soldier.SetTrait("cooperation", 0.5)
@@ -82,12 +82,12 @@ func TestCasteSystem(t *testing.T) {
 
 func TestCasteRoleAssignment(t *testing.T) {
 	// Create colony
-	queen := NewEntity(1, []string{"intelligence", "leadership", "reproductive_capability"}, "test", Position{})
+	queen := NewEntity(sharedRand, 1, []string{"intelligence", "leadership", "reproductive_capability"}, "test", Position{})
 	queen.SetTrait("intelligence", 0.8)
 	queen.SetTrait("leadership", 0.7)
 	queen.SetTrait("reproductive_capability", 0.9)
 
-	colony := NewCasteColony(1, queen, Position{X: 0, Y: 0})
+	colony := NewCasteColony(sharedRand, 1, queen, Position{X: 0, Y: 0})
 
 	// Test role assignment for different entity types
 	testCases := []struct {
@@ -128,7 +128,7 @@ func TestCasteRoleAssignment(t *testing.T) {
 	}
 
 	for i, tc := range testCases {
-		entity := NewEntity(i+10, []string{}, "test", Position{})
+		entity := NewEntity(sharedRand, i+10, []string{}, "test", Position{})
 		for trait, value := range tc.traits {
 			entity.SetTrait(trait, value)
 		}
@@ -146,7 +146,7 @@ func TestCasteStatusCreation(t *testing.T) {
 	roles := []CasteRole{Queen, Worker, Soldier, Drone, Scout, Nurse, Builder, Specialist}
 
 	for _, role := range roles {
-		status := NewCasteStatus(role)
+		status := NewCasteStatus(sharedRand, role)
 
 		if status.Role != role {
 			t.Errorf("Expected role %s, got %s", role.String(), status.Role.String())
@@ -186,14 +186,14 @@ func TestCasteStatusCreation(t *testing.T) {
 
 func TestCasteTraitModification(t *testing.T) {
 	// Create test colony
-	queen := NewEntity(1, []string{"intelligence", "leadership"}, "test", Position{})
+	queen := NewEntity(sharedRand, 1, []string{"intelligence", "leadership"}, "test", Position{})
 	queen.SetTrait("intelligence", 0.5)
 	queen.SetTrait("leadership", 0.5)
 
-	colony := NewCasteColony(1, queen, Position{})
+	colony := NewCasteColony(sharedRand, 1, queen, Position{})
 
 	// Test trait modification for worker
-	worker := NewEntity(2, []string{"foraging_efficiency", "endurance", "cooperation"}, "test", Position{})
+	worker := NewEntity(sharedRand, 2, []string{"foraging_efficiency", "endurance", "cooperation"}, "test", Position{})
 	worker.SetTrait("foraging_efficiency", 0.3)
 	worker.SetTrait("endurance", 0.3)
 	worker.SetTrait("cooperation", 0.3)
@@ -216,7 +216,7 @@ func TestCasteTraitModification(t *testing.T) {
 	}
 
 	// Test trait modification for soldier
-	soldier := NewEntity(3, []string{"aggression", "strength", "defense"}, "test", Position{})
+	soldier := NewEntity(sharedRand, 3, []string{"aggression", "strength", "defense"}, "test", Position{})
 	soldier.SetTrait("aggression", 0.3)
 	soldier.SetTrait("strength", 0.3)
 	soldier.SetTrait("defense", 0.3)
@@ -241,15 +241,15 @@ func TestCasteTraitModification(t *testing.T) {
 
 func TestColonyMemberManagement(t *testing.T) {
 	// Create test colony
-	queen := NewEntity(1, []string{"intelligence"}, "test", Position{})
-	colony := NewCasteColony(1, queen, Position{})
+	queen := NewEntity(sharedRand, 1, []string{"intelligence"}, "test", Position{})
+	colony := NewCasteColony(sharedRand, 1, queen, Position{})
 
 	// Test adding members
-	worker1 := NewEntity(2, []string{"cooperation", "intelligence"}, "test", Position{})
+	worker1 := NewEntity(sharedRand, 2, []string{"cooperation", "intelligence"}, "test", Position{})
 	worker1.SetTrait("cooperation", 0.6)
 	worker1.SetTrait("intelligence", 0.4)
 
-	worker2 := NewEntity(3, []string{"cooperation", "intelligence"}, "test", Position{})
+	worker2 := NewEntity(sharedRand, 3, []string{"cooperation", "intelligence"}, "test", Position{})
 	worker2.SetTrait("cooperation", 0.7)
 	worker2.SetTrait("intelligence", 0.5)
 
@@ -284,13 +284,13 @@ func TestColonyMemberManagement(t *testing.T) {
 
 func TestColonyCanJoinChecks(t *testing.T) {
 	// Create test colony
-	queen := NewEntity(1, []string{"intelligence", "cooperation"}, "test_species", Position{})
+	queen := NewEntity(sharedRand, 1, []string{"intelligence", "cooperation"}, "test_species", Position{})
 	queen.SetTrait("intelligence", 0.8)
 	queen.SetTrait("cooperation", 0.7)
-	colony := NewCasteColony(1, queen, Position{})
+	colony := NewCasteColony(sharedRand, 1, queen, Position{})
 
 	// Test suitable candidate
-	suitable := NewEntity(2, []string{"cooperation", "intelligence"}, "test_species", Position{})
+	suitable := NewEntity(sharedRand, 2, []string{"cooperation", "intelligence"}, "test_species", Position{})
 	suitable.SetTrait("cooperation", 0.6)
 	suitable.SetTrait("intelligence", 0.4)
 
@@ -299,7 +299,7 @@ func TestColonyCanJoinChecks(t *testing.T) {
 	}
 
 	// Test entity with low cooperation
-	lowCooperation := NewEntity(3, []string{"cooperation", "intelligence"}, "test_species", Position{})
+	lowCooperation := NewEntity(sharedRand, 3, []string{"cooperation", "intelligence"}, "test_species", Position{})
 	lowCooperation.SetTrait("cooperation", 0.2) // Too low
 	lowCooperation.SetTrait("intelligence", 0.5)
 
@@ -308,7 +308,7 @@ func TestColonyCanJoinChecks(t *testing.T) {
 	}
 
 	// Test entity with low intelligence
-	lowIntelligence := NewEntity(4, []string{"cooperation", "intelligence"}, "test_species", Position{})
+	lowIntelligence := NewEntity(sharedRand, 4, []string{"cooperation", "intelligence"}, "test_species", Position{})
 	lowIntelligence.SetTrait("cooperation", 0.6)
 	lowIntelligence.SetTrait("intelligence", 0.1) // Too low
 
@@ -317,7 +317,7 @@ func TestColonyCanJoinChecks(t *testing.T) {
 	}
 
 	// Test different species (should require higher traits)
-	differentSpecies := NewEntity(5, []string{"cooperation", "intelligence"}, "other_species", Position{})
+	differentSpecies := NewEntity(sharedRand, 5, []string{"cooperation", "intelligence"}, "other_species", Position{})
 	differentSpecies.SetTrait("cooperation", 0.6) // Not high enough for cross-species
 	differentSpecies.SetTrait("intelligence", 0.6)
 
@@ -326,7 +326,7 @@ func TestColonyCanJoinChecks(t *testing.T) {
 	}
 
 	// Test different species with high traits
-	differentSpeciesHigh := NewEntity(6, []string{"cooperation", "intelligence"}, "other_species", Position{})
+	differentSpeciesHigh := NewEntity(sharedRand, 6, []string{"cooperation", "intelligence"}, "other_species", Position{})
 	differentSpeciesHigh.SetTrait("cooperation", 0.9) // High enough for cross-species
 	differentSpeciesHigh.SetTrait("intelligence", 0.8)
 
@@ -335,7 +335,7 @@ func TestColonyCanJoinChecks(t *testing.T) {
 	}
 
 	// Test dead entity
-	deadEntity := NewEntity(7, []string{"cooperation", "intelligence"}, "test_species", Position{})
+	deadEntity := NewEntity(sharedRand, 7, []string{"cooperation", "intelligence"}, "test_species", Position{})
 	deadEntity.SetTrait("cooperation", 0.8)
 	deadEntity.SetTrait("intelligence", 0.7)
 	deadEntity.IsAlive = false
@@ -347,15 +347,15 @@ func TestColonyCanJoinChecks(t *testing.T) {
 
 func TestColonyFitnessCalculation(t *testing.T) {
 	// Create test colony with known fitness values
-	queen := NewEntity(1, []string{}, "test", Position{})
+	queen := NewEntity(sharedRand, 1, []string{}, "test", Position{})
 	queen.Fitness = 0.8
-	colony := NewCasteColony(1, queen, Position{})
+	colony := NewCasteColony(sharedRand, 1, queen, Position{})
 
-	worker1 := NewEntity(2, []string{}, "test", Position{})
+	worker1 := NewEntity(sharedRand, 2, []string{}, "test", Position{})
 	worker1.Fitness = 0.6
 	colony.AddMember(worker1, Worker)
 
-	worker2 := NewEntity(3, []string{}, "test", Position{})
+	worker2 := NewEntity(sharedRand, 3, []string{}, "test", Position{})
 	worker2.Fitness = 0.7
 	colony.AddMember(worker2, Worker)
 
@@ -373,11 +373,11 @@ func TestColonyFitnessCalculation(t *testing.T) {
 
 func TestCasteRoleReassignment(t *testing.T) {
 	// Create colony with suboptimal role assignments
-	queen := NewEntity(1, []string{}, "test", Position{})
-	colony := NewCasteColony(1, queen, Position{})
+	queen := NewEntity(sharedRand, 1, []string{}, "test", Position{})
+	colony := NewCasteColony(sharedRand, 1, queen, Position{})
 
 	// Add entity as worker but with soldier traits
-	entity := NewEntity(2, []string{"aggression", "strength", "cooperation", "intelligence"}, "test", Position{})
+	entity := NewEntity(sharedRand, 2, []string{"aggression", "strength", "cooperation", "intelligence"}, "test", Position{})
 	entity.SetTrait("aggression", 0.9) // High aggression
 	entity.SetTrait("strength", 0.8)   // High strength
 	entity.SetTrait("cooperation", 0.5)
@@ -406,11 +406,11 @@ func TestAddCasteStatusToEntity(t *testing.T) {
 	// Test automatic caste assignment based on traits
 
 	// Queen candidate
-	queenCandidate := NewEntity(1, []string{"intelligence", "leadership"}, "test", Position{})
+	queenCandidate := NewEntity(sharedRand, 1, []string{"intelligence", "leadership"}, "test", Position{})
 	queenCandidate.SetTrait("intelligence", 0.8)
 	queenCandidate.SetTrait("leadership", 0.6)
 
-	AddCasteStatusToEntity(queenCandidate)
+	AddCasteStatusToEntity(sharedRand, queenCandidate)
 
 	if queenCandidate.CasteStatus == nil {
 		t.Error("Caste status should be added to entity")
@@ -421,11 +421,11 @@ func TestAddCasteStatusToEntity(t *testing.T) {
 	}
 
 	// Soldier candidate
-	soldierCandidate := NewEntity(2, []string{"aggression", "strength"}, "test", Position{})
+	soldierCandidate := NewEntity(sharedRand, 2, []string{"aggression", "strength"}, "test", Position{})
 	soldierCandidate.SetTrait("aggression", 0.7)
 	soldierCandidate.SetTrait("strength", 0.6)
 
-	AddCasteStatusToEntity(soldierCandidate)
+	AddCasteStatusToEntity(sharedRand, soldierCandidate)
 
 	if soldierCandidate.CasteStatus.Role != Soldier {
 		t.Errorf("Entity with high aggression and strength should be assigned Soldier role, got %s",
@@ -433,11 +433,11 @@ func TestAddCasteStatusToEntity(t *testing.T) {
 	}
 
 	// Scout candidate
-	scoutCandidate := NewEntity(3, []string{"speed", "intelligence"}, "test", Position{})
+	scoutCandidate := NewEntity(sharedRand, 3, []string{"speed", "intelligence"}, "test", Position{})
 	scoutCandidate.SetTrait("speed", 0.7)
 	scoutCandidate.SetTrait("intelligence", 0.5)
 
-	AddCasteStatusToEntity(scoutCandidate)
+	AddCasteStatusToEntity(sharedRand, scoutCandidate)
 
 	if scoutCandidate.CasteStatus.Role != Scout {
 		t.Errorf("Entity with high speed and intelligence should be assigned Scout role, got %s",
@@ -445,10 +445,10 @@ func TestAddCasteStatusToEntity(t *testing.T) {
 	}
 
 	// Worker (default)
-	workerCandidate := NewEntity(4, []string{"cooperation"}, "test", Position{})
+	workerCandidate := NewEntity(sharedRand, 4, []string{"cooperation"}, "test", Position{})
 	workerCandidate.SetTrait("cooperation", 0.5)
 
-	AddCasteStatusToEntity(workerCandidate)
+	AddCasteStatusToEntity(sharedRand, workerCandidate)
 
 	if workerCandidate.CasteStatus.Role != Worker {
 		t.Errorf("Entity with average traits should be assigned Worker role, got %s",
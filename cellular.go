@@ -78,6 +78,8 @@ type CellularSystem struct {
 	ComplexityThresholds map[int]int               `json:"complexity_thresholds"` // Level -> min cells
 	DNASystem            *DNASystem                `json:"-"`
 	eventBus             *CentralEventBus          `json:"-"` // Event tracking
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewCellularSystem creates a new cellular management system
@@ -114,6 +116,7 @@ func NewCellularSystem(dnaSystem *DNASystem, eventBus *CentralEventBus) *Cellula
 		},
 		DNASystem: dnaSystem,
 		eventBus:  eventBus,
+		rng:       sharedRand,
 	}
 }
 
@@ -257,7 +260,8 @@ func (cs *CellularSystem) calculateCellSize(dna *DNAStrand) float64 {
 
 // UpdateCellularOrganisms updates all cellular organisms
 func (cs *CellularSystem) UpdateCellularOrganisms() {
-	for _, organism := range cs.OrganismMap {
+	for _, entityID := range sortedKeys(cs.OrganismMap) {
+		organism := cs.OrganismMap[entityID]
 		cs.updateOrganism(organism)
 	}
 }
@@ -299,7 +303,8 @@ func (cs *CellularSystem) updateCell(cell *Cell) {
 	energyProduced := 0.0
 	energyConsumed := 0.0
 
-	for _, organelle := range cell.Organelles {
+	for _, organelleType := range sortedKeys(cell.Organelles) {
+		organelle := cell.Organelles[organelleType]
 		switch organelle.Type {
 		case OrganelleMitochondria:
 			energyProduced += float64(organelle.Count) * organelle.Efficiency * 2.0
@@ -342,7 +347,7 @@ func (cs *CellularSystem) shouldCellDivide(cell *Cell, organism *CellularOrganis
 		cell.Health > healthThreshold &&
 		cell.Age > ageMinimum &&
 		len(organism.Cells) < maxCells &&
-		rand.Float64() < 0.01 // 1% chance per tick
+		cs.rng.Float64() < 0.01 // 1% chance per tick
 }
 
 // performCellDivision creates a new cell through division
@@ -357,8 +362,8 @@ func (cs *CellularSystem) performCellDivision(parentCell *Cell, organism *Cellul
 	daughterCell.Energy = parentCell.Energy * 0.4
 
 	// Slight positioning offset
-	daughterCell.Position.X += (rand.Float64() - 0.5) * 2.0
-	daughterCell.Position.Y += (rand.Float64() - 0.5) * 2.0
+	daughterCell.Position.X += (cs.rng.Float64() - 0.5) * 2.0
+	daughterCell.Position.Y += (cs.rng.Float64() - 0.5) * 2.0
 
 	// Connect cells
 	parentCell.Connections = append(parentCell.Connections, daughterCell.ID)
@@ -397,7 +402,7 @@ func (cs *CellularSystem) performCellDivision(parentCell *Cell, organism *Cellul
 	}
 
 	// Potential for specialization in multicellular organisms
-	if organism.ComplexityLevel >= 2 && rand.Float64() < 0.3 {
+	if organism.ComplexityLevel >= 2 && cs.rng.Float64() < 0.3 {
 		cs.specializeDaughterCell(daughterCell, organism)
 	}
 }
@@ -411,7 +416,7 @@ func (cs *CellularSystem) specializeDaughterCell(cell *Cell, organism *CellularO
 	}
 
 	oldType := cell.Type
-	newType := cellTypes[rand.Intn(len(cellTypes))]
+	newType := cellTypes[cs.rng.Intn(len(cellTypes))]
 	cell.Type = newType
 	cell.Specialized = true
 
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Census dimensions a population can be stratified by
+const (
+	CensusBySpecies  = "species"
+	CensusByBiome    = "biome"
+	CensusByRegion   = "region"
+	CensusByAgeClass = "age_class"
+	CensusBySex      = "sex"
+	CensusByColony   = "colony"
+)
+
+// Census metrics other than mean trait values
+const (
+	CensusCount  = "count"
+	CensusEnergy = "energy"
+	CensusAge    = "age"
+)
+
+// censusDimensions lists the dimensions in the order they are documented
+var censusDimensions = []string{CensusBySpecies, CensusByBiome, CensusByRegion, CensusByAgeClass, CensusBySex, CensusByColony}
+
+// censusRegions is how many bands the world is cut into each way to name regions
+const censusRegions = 3
+
+// censusRegion names the ninth of the world a position lies in by compass point, north at
+// the top of the map
+func censusRegion(w *World, pos Position) string {
+	band := func(value, size float64) int {
+		if size <= 0 {
+			return 1
+		}
+		return max(0, min(censusRegions-1, int(value*censusRegions/size)))
+	}
+	rows := []string{"north", "", "south"}
+	columns := []string{"west", "", "east"}
+	row, column := rows[band(pos.Y, w.Config.Height)], columns[band(pos.X, w.Config.Width)]
+	switch {
+	case row == "" && column == "":
+		return "central"
+	case row == "" || column == "":
+		return row + column
+	}
+	return row + "-" + column
+}
+
+// ageClass is whether a creature is young, adult or old, by the share of its lifespan lived
+func ageClass(entity *Entity) string {
+	switch cohort := ageCohort(entity); {
+	case cohort < ageCohorts/5:
+		return "young"
+	case cohort >= ageCohorts*4/5:
+		return "old"
+	}
+	return "adult"
+}
+
+// CensusQuery is how to stratify a census and what to measure in each group
+type CensusQuery struct {
+	By      []string `json:"by"`
+	Metrics []string `json:"metrics"` // count, energy, age or creature trait names
+}
+
+// ParseCensusQuery reads a census query from comma-separated by and metrics parameters,
+// rejecting unknown dimensions and traits. With no metrics only the count is taken.
+func (w *World) ParseCensusQuery(values url.Values) (CensusQuery, error) {
+	query := CensusQuery{By: make([]string, 0), Metrics: make([]string, 0)}
+	split := func(value string) []string {
+		parts := make([]string, 0)
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		return parts
+	}
+
+	seen := make(map[string]bool)
+	for _, dimension := range split(values.Get("by")) {
+		known := false
+		for _, candidate := range censusDimensions {
+			known = known || candidate == dimension
+		}
+		if !known {
+			return query, fmt.Errorf("unknown census dimension %q, expected one of %s", dimension, strings.Join(censusDimensions, ", "))
+		}
+		if !seen[dimension] {
+			seen[dimension] = true
+			query.By = append(query.By, dimension)
+		}
+	}
+	for _, metric := range split(values.Get("metrics")) {
+		switch metric {
+		case CensusCount, CensusEnergy, CensusAge:
+		default:
+			if _, ok := w.TraitRegistry.Lookup(TraitSubjectCreature, metric); !ok {
+				return query, fmt.Errorf("unknown census metric %q, expected count, energy, age or a creature trait", metric)
+			}
+		}
+		if !seen["metric:"+metric] {
+			seen["metric:"+metric] = true
+			query.Metrics = append(query.Metrics, metric)
+		}
+	}
+	if len(query.Metrics) == 0 {
+		query.Metrics = append(query.Metrics, CensusCount)
+	}
+	return query, nil
+}
+
+// CensusGroup is the living creatures sharing one value of every dimension
+type CensusGroup struct {
+	Key   map[string]string  `json:"key"` // Dimension to value
+	Count int                `json:"count"`
+	Means map[string]float64 `json:"means,omitempty"` // Metric to mean over the group
+}
+
+// CensusResult is a census of the living creatures, largest group first
+type CensusResult struct {
+	Tick    int           `json:"tick"`
+	By      []string      `json:"by"`
+	Metrics []string      `json:"metrics"`
+	Total   int           `json:"total"`
+	Groups  []CensusGroup `json:"groups"`
+}
+
+// Census counts the living creatures in each group of a query's dimensions and averages its
+// metrics over each. With no dimensions the whole population is one group.
+func (w *World) Census(query CensusQuery) CensusResult {
+	result := CensusResult{Tick: w.Tick, By: query.By, Metrics: query.Metrics, Groups: make([]CensusGroup, 0)}
+	colonies := make(map[int]string)
+	if w.CivilizationSystem != nil {
+		for _, tribe := range w.CivilizationSystem.Tribes {
+			colonies[tribe.ID] = tribe.Name
+		}
+	}
+	value := func(entity *Entity, dimension string) string {
+		switch dimension {
+		case CensusBySpecies:
+			return entity.Species
+		case CensusByBiome:
+			x, y := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
+			return biomeName(w.Grid[y][x].Biome)
+		case CensusByRegion:
+			return censusRegion(w, entity.Position)
+		case CensusByAgeClass:
+			return ageClass(entity)
+		case CensusBySex:
+			return entitySex(entity)
+		case CensusByColony:
+			if name, ok := colonies[entity.TribeID]; ok {
+				return name
+			}
+			return "wild"
+		}
+		return ""
+	}
+
+	groups := make(map[string]*CensusGroup)
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		key := make(map[string]string, len(query.By))
+		parts := make([]string, len(query.By))
+		for i, dimension := range query.By {
+			key[dimension] = value(entity, dimension)
+			parts[i] = key[dimension]
+		}
+		id := strings.Join(parts, "\x00")
+		group := groups[id]
+		if group == nil {
+			group = &CensusGroup{Key: key, Means: make(map[string]float64)}
+			groups[id] = group
+		}
+		group.Count++
+		result.Total++
+		for _, metric := range query.Metrics {
+			switch metric {
+			case CensusCount:
+			case CensusEnergy:
+				group.Means[metric] += entity.Energy
+			case CensusAge:
+				group.Means[metric] += float64(entity.Age)
+			default:
+				group.Means[metric] += entity.GetTrait(metric)
+			}
+		}
+	}
+
+	for _, id := range sortedKeys(groups) {
+		group := groups[id]
+		for metric := range group.Means {
+			group.Means[metric] /= float64(group.Count)
+		}
+		result.Groups = append(result.Groups, *group)
+	}
+	sort.SliceStable(result.Groups, func(i, j int) bool { return result.Groups[i].Count > result.Groups[j].Count })
+	return result
+}
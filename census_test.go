@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// newCensusTestWorld makes an empty 90x90 plains world with wolves in the north-west and a
+// tribe of folk in the south-east
+func newCensusTestWorld() (*World, *Tribe) {
+	world := newEmptyTestWorld(90)
+	fillTestBiome(world, BiomePlains)
+
+	for i := 0; i < 4; i++ {
+		wolf := NewEntity(world.Rand, i+1, []string{"speed"}, "Wolf", Position{X: 10, Y: 10})
+		wolf.ReproductionStatus.Mode = LiveBirth
+		wolf.Energy = 40
+		wolf.SetTrait("speed", 0.8)
+		world.AllEntities = append(world.AllEntities, wolf)
+	}
+	tribe := addTestTribe(world, "Cinderholt", 2, Position{X: 80, Y: 80})
+	for _, member := range tribe.Members {
+		member.ReproductionStatus.Mode = LiveBirth
+		member.Energy = 100
+		member.Age = member.MaxLifespan / 2
+	}
+	return world, tribe
+}
+
+func TestCensusStratifiesByDimensions(t *testing.T) {
+	world, _ := newCensusTestWorld()
+	query, err := world.ParseCensusQuery(url.Values{"by": {"colony,region, age_class"}, "metrics": {"energy,speed"}})
+	if err != nil {
+		t.Fatalf("Expected a valid census query, got %v", err)
+	}
+	result := world.Census(query)
+	if result.Total != 6 || len(result.Groups) != 2 {
+		t.Fatalf("Expected two groups of the six creatures, got %+v", result)
+	}
+	wolves, folk := result.Groups[0], result.Groups[1]
+	if wolves.Key[CensusByColony] != "wild" || wolves.Key[CensusByRegion] != "north-west" || wolves.Key[CensusByAgeClass] != "young" || wolves.Count != 4 {
+		t.Errorf("Expected the young wild wolves in the north-west first, got %+v", wolves)
+	}
+	if wolves.Means[CensusEnergy] != 40 || wolves.Means["speed"] != 0.8 {
+		t.Errorf("Expected the wolves' mean energy and speed, got %+v", wolves.Means)
+	}
+	if folk.Key[CensusByColony] != "Cinderholt" || folk.Key[CensusByRegion] != "south-east" || folk.Key[CensusByAgeClass] != "adult" {
+		t.Errorf("Expected the adult tribe in the south-east, got %+v", folk)
+	}
+
+	whole := world.Census(CensusQuery{Metrics: []string{CensusCount}})
+	if len(whole.Groups) != 1 || whole.Groups[0].Count != 6 || len(whole.Groups[0].Means) != 0 {
+		t.Errorf("Expected the whole population as one counted group, got %+v", whole)
+	}
+	bySex := world.Census(CensusQuery{By: []string{CensusBySex, CensusByBiome}})
+	if len(bySex.Groups) != 2 || bySex.Groups[0].Key[CensusByBiome] != "plains" {
+		t.Errorf("Expected the plains population split into females and males, got %+v", bySex)
+	}
+}
+
+func TestCensusAPI(t *testing.T) {
+	world, _ := newCensusTestWorld()
+	wi := NewWebInterface(world)
+
+	rec := httptest.NewRecorder()
+	wi.handleCensus(rec, httptest.NewRequest(http.MethodGet, "/api/census?by=species&metrics=count,age", nil))
+	var result CensusResult
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &result) != nil {
+		t.Fatalf("Expected a census, got %d %s", rec.Code, rec.Body.String())
+	}
+	if len(result.Groups) != 2 || result.Groups[0].Key[CensusBySpecies] != "Wolf" || result.Groups[1].Means[CensusAge] <= 0 {
+		t.Errorf("Expected the census grouped by species with mean ages, got %+v", result)
+	}
+
+	for _, query := range []string{"by=diet", "metrics=wingspan_of_doom"} {
+		rec := httptest.NewRecorder()
+		wi.handleCensus(rec, httptest.NewRequest(http.MethodGet, "/api/census?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected %s to be rejected, got %d", query, rec.Code)
+		}
+	}
+}
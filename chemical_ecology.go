@@ -2,7 +2,6 @@ package main
 
 import (
 	"math"
-	"math/rand"
 )
 
 // AirborneChemicalSignal represents airborne chemical signals (distinct from underground network signals)
@@ -234,8 +233,7 @@ func (ces *ChemicalEcologySystem) processPlantChemicalResponses(world *World) {
 
 		// Check for nearby chemical signals
 		for _, signal := range ces.AirborneSignals {
-			distance := math.Sqrt(math.Pow(plant.Position.X-signal.Position.X, 2) +
-				math.Pow(plant.Position.Y-signal.Position.Y, 2))
+			distance := world.Distance(plant.Position, signal.Position)
 
 			if distance <= signal.MaxRange {
 				ces.applyChemicalEffectToPlant(plant, signal, distance, world)
@@ -258,8 +256,7 @@ func (ces *ChemicalEcologySystem) processEntityChemicalResponses(world *World) {
 
 		// Check for nearby chemical signals
 		for _, signal := range ces.AirborneSignals {
-			distance := math.Sqrt(math.Pow(entity.Position.X-signal.Position.X, 2) +
-				math.Pow(entity.Position.Y-signal.Position.Y, 2))
+			distance := world.Distance(entity.Position, signal.Position)
 
 			if distance <= signal.MaxRange {
 				ces.applyChemicalEffectToEntity(entity, signal, distance, world)
@@ -275,7 +272,7 @@ func (ces *ChemicalEcologySystem) applyChemicalEffectToPlant(plant *Plant, signa
 	switch signal.Type {
 	case "warning":
 		// Plants respond to warning signals by increasing defenses
-		if rand.Float64() < effectStrength*0.3 {
+		if world.Rand.Float64() < effectStrength*0.3 {
 			ces.EmitPlantAirborneSignal(plant, "toxin", world)
 		}
 	}
@@ -314,8 +311,7 @@ func (ces *ChemicalEcologySystem) applyChemicalEffectToEntity(entity *Entity, si
 		// Attractant signals draw entities toward plants
 		if effectStrength > 0.3 {
 			// Move entity toward signal source
-			dx := signal.Position.X - entity.Position.X
-			dy := signal.Position.Y - entity.Position.Y
+			dx, dy := world.Geometry().Direction(entity.Position, signal.Position)
 			moveDistance := effectStrength * 0.5
 
 			if distance > 0.1 {
@@ -330,8 +326,7 @@ func (ces *ChemicalEcologySystem) applyChemicalEffectToEntity(entity *Entity, si
 		defense := entity.GetTrait("defense")
 		if defense < 0.5 && effectStrength > 0.4 {
 			// Move away from warning source
-			dx := entity.Position.X - signal.Position.X
-			dy := entity.Position.Y - signal.Position.Y
+			dx, dy := world.Geometry().Direction(signal.Position, entity.Position)
 			moveDistance := effectStrength * 0.3
 
 			if distance > 0.1 {
@@ -349,8 +344,7 @@ func (ces *ChemicalEcologySystem) applyChemicalEffectToEntity(entity *Entity, si
 		if signal.ProducerID != entity.ID {
 			if aggression > 0.6 && cooperation < 0.4 {
 				// Aggressive entities might be attracted to challenge
-				dx := signal.Position.X - entity.Position.X
-				dy := signal.Position.Y - entity.Position.Y
+				dx, dy := world.Geometry().Direction(entity.Position, signal.Position)
 				moveDistance := effectStrength * 0.2
 
 				if distance > 0.1 {
@@ -359,8 +353,7 @@ func (ces *ChemicalEcologySystem) applyChemicalEffectToEntity(entity *Entity, si
 				}
 			} else if cooperation > 0.6 {
 				// Cooperative entities might investigate
-				dx := signal.Position.X - entity.Position.X
-				dy := signal.Position.Y - entity.Position.Y
+				dx, dy := world.Geometry().Direction(entity.Position, signal.Position)
 				moveDistance := effectStrength * 0.1
 
 				if distance > 0.1 {
@@ -375,14 +368,13 @@ func (ces *ChemicalEcologySystem) applyChemicalEffectToEntity(entity *Entity, si
 // TriggerPlantDefenses triggers defensive chemical releases based on threats
 func (ces *ChemicalEcologySystem) TriggerPlantDefenses(plant *Plant, threat *Entity, world *World) {
 	// Plants might release toxins when being consumed
-	distance := math.Sqrt(math.Pow(threat.Position.X-plant.Position.X, 2) +
-		math.Pow(threat.Position.Y-plant.Position.Y, 2))
+	distance := world.Distance(threat.Position, plant.Position)
 
 	if distance < 2.0 { // Close enough to be threatening
 		ces.EmitPlantAirborneSignal(plant, "toxin", world)
 
 		// Also emit warning signal to nearby plants
-		if rand.Float64() < 0.6 {
+		if world.Rand.Float64() < 0.6 {
 			ces.EmitPlantAirborneSignal(plant, "warning", world)
 		}
 	}
@@ -81,7 +81,7 @@ func NewStructure(id int, structureType StructureType, position Position, builde
 }
 
 // Update maintains the structure
-func (s *Structure) Update() {
+func (s *Structure) Update(rng *rand.Rand) {
 	if !s.IsActive {
 		return
 	}
@@ -92,11 +92,11 @@ func (s *Structure) Update() {
 	// Structure-specific updates
 	switch s.Type {
 	case StructureFarm:
-		s.updateFarm()
+		s.updateFarm(rng)
 	case StructureCache:
 		s.updateCache()
 	case StructureTrap:
-		s.updateTrap()
+		s.updateTrap(rng)
 	}
 
 	// Deactivate if health is too low
@@ -106,10 +106,10 @@ func (s *Structure) Update() {
 }
 
 // updateFarm handles farm production
-func (s *Structure) updateFarm() {
+func (s *Structure) updateFarm(rng *rand.Rand) {
 	// Farms produce food over time if maintained
 	if s.Health > s.MaxHealth*0.5 {
-		production := 2.0 + rand.Float64()*3.0
+		production := 2.0 + rng.Float64()*3.0
 		s.Resources["food"] += production
 
 		// Limit storage to capacity
@@ -128,10 +128,10 @@ func (s *Structure) updateCache() {
 }
 
 // updateTrap handles trap functionality
-func (s *Structure) updateTrap() {
+func (s *Structure) updateTrap(rng *rand.Rand) {
 	// Traps have a chance to catch prey
-	if rand.Float64() < 0.05 { // 5% chance per tick
-		s.Resources["food"] += 10.0 + rand.Float64()*15.0
+	if rng.Float64() < 0.05 { // 5% chance per tick
+		s.Resources["food"] += 10.0 + rng.Float64()*15.0
 	}
 }
 
@@ -287,15 +287,16 @@ func (t *Tribe) BuildStructure(structureType StructureType, position Position, b
 		}
 
 		metadata := map[string]interface{}{
-			"structure_id":   structure.ID,
-			"structure_type": structureTypeName,
-			"tribe_id":       t.ID,
-			"tribe_name":     t.Name,
-			"builder_id":     builder.ID,
-			"tech_level":     t.TechLevel,
-			"cost":           cost,
-			"max_health":     structure.MaxHealth,
-			"capacity":       structure.Capacity,
+			"structure_id":    structure.ID,
+			"structure_type":  structureTypeName,
+			"tribe_id":        t.ID,
+			"tribe_name":      t.Name,
+			"builder_id":      builder.ID,
+			"builder_species": builder.Species,
+			"tech_level":      t.TechLevel,
+			"cost":            cost,
+			"max_health":      structure.MaxHealth,
+			"capacity":        structure.Capacity,
 		}
 
 		eventBus.EmitSystemEvent(tick, "structure_built", "civilization", "civilization_system",
@@ -307,7 +308,7 @@ func (t *Tribe) BuildStructure(structureType StructureType, position Position, b
 }
 
 // Update maintains the tribe
-func (t *Tribe) Update(eventBus *CentralEventBus, tick int) {
+func (t *Tribe) Update(rng *rand.Rand, eventBus *CentralEventBus, tick int) {
 	originalMemberCount := len(t.Members)
 
 	// Remove dead members
@@ -367,7 +368,7 @@ func (t *Tribe) Update(eventBus *CentralEventBus, tick int) {
 
 	// Research and development
 	oldTechLevel := t.TechLevel
-	t.advanceTechnology()
+	t.advanceTechnology(rng)
 
 	if eventBus != nil && t.TechLevel > oldTechLevel {
 		metadata := map[string]interface{}{
@@ -425,7 +426,7 @@ func (t *Tribe) collectResources() {
 }
 
 // advanceTechnology improves tribe's technological level
-func (t *Tribe) advanceTechnology() {
+func (t *Tribe) advanceTechnology(rng *rand.Rand) {
 	if len(t.Members) == 0 {
 		return
 	}
@@ -439,7 +440,7 @@ func (t *Tribe) advanceTechnology() {
 
 	researchRate := avgIntelligence * t.Culture["innovation"] * 0.001
 
-	if rand.Float64() < researchRate {
+	if rng.Float64() < researchRate {
 		t.TechLevel++
 	}
 }
@@ -562,11 +563,13 @@ func (ts *TradeSystem) shouldAcceptTrade(trade *Trade) bool {
 	offerValue := 0.0
 	requestValue := 0.0
 
-	for resource, amount := range trade.Offering {
+	for _, resource := range sortedKeys(trade.Offering) {
+		amount := trade.Offering[resource]
 		offerValue += amount * ts.getResourceValue(trade.ToTribe, resource)
 	}
 
-	for resource, amount := range trade.Requesting {
+	for _, resource := range sortedKeys(trade.Requesting) {
+		amount := trade.Requesting[resource]
 		requestValue += amount * ts.getResourceValue(trade.ToTribe, resource)
 	}
 
@@ -661,6 +664,8 @@ type CivilizationSystem struct {
 	NextTribeID     int
 	NextStructureID int
 	EventBus        *CentralEventBus // For event tracking
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewCivilizationSystem creates a new civilization system
@@ -672,6 +677,7 @@ func NewCivilizationSystem(eventBus *CentralEventBus) *CivilizationSystem {
 		NextTribeID:     1,
 		NextStructureID: 1,
 		EventBus:        eventBus,
+		rng:             sharedRand,
 	}
 }
 
@@ -680,7 +686,7 @@ func (cs *CivilizationSystem) Update(tick int) {
 	// Update all tribes
 	activeTrbs := make([]*Tribe, 0)
 	for _, tribe := range cs.Tribes {
-		tribe.Update(cs.EventBus, tick)
+		tribe.Update(cs.rng, cs.EventBus, tick)
 		if len(tribe.Members) > 0 {
 			activeTrbs = append(activeTrbs, tribe)
 		}
@@ -691,7 +697,7 @@ func (cs *CivilizationSystem) Update(tick int) {
 	activeStructures := make([]*Structure, 0)
 	for _, structure := range cs.Structures {
 		wasActive := structure.IsActive
-		structure.Update()
+		structure.Update(cs.rng)
 
 		// Emit event for structure destruction
 		if wasActive && !structure.IsActive && cs.EventBus != nil {
@@ -732,13 +738,13 @@ func (cs *CivilizationSystem) Update(tick int) {
 
 // generateRandomTrades creates occasional trade proposals between tribes
 func (cs *CivilizationSystem) generateRandomTrades() {
-	if len(cs.Tribes) < 2 || rand.Float64() > 0.05 {
+	if len(cs.Tribes) < 2 || cs.rng.Float64() > 0.05 {
 		return // 5% chance per tick
 	}
 
 	// Pick two random tribes
-	tribe1 := cs.Tribes[rand.Intn(len(cs.Tribes))]
-	tribe2 := cs.Tribes[rand.Intn(len(cs.Tribes))]
+	tribe1 := cs.Tribes[cs.rng.Intn(len(cs.Tribes))]
+	tribe2 := cs.Tribes[cs.rng.Intn(len(cs.Tribes))]
 
 	if tribe1 == tribe2 {
 		return
@@ -0,0 +1,666 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Files kept for the classroom below the server's data directory. The directory name can't
+// be a world name, so it never collides with a student's world.
+const (
+	classroomDir        = "_classroom"
+	classroomFile       = "classroom.json"
+	classroomResultsDir = "results"
+)
+
+// Limits on what a teacher sends to the class
+const (
+	maxClassroomBroadcasts    = 50
+	maxClassroomMessageLength = 500
+)
+
+// Classroom is a class of students, each with a sandbox world built from the same template.
+// It is kept in the server's data directory so the class survives a restart.
+type Classroom struct {
+	Template         RunConfig                      `json:"template"`
+	AutosaveInterval int                            `json:"autosave_interval"` // For every student's world
+	Students         []string                       `json:"students"`          // World names, sorted
+	Broadcasts       []ClassroomBroadcast           `json:"broadcasts"`        // Latest last
+	Collections      map[string]ClassroomCollection `json:"collections"`       // Student -> latest results collected
+	Created          time.Time                      `json:"created"`
+}
+
+// ClassroomBroadcast is a message the teacher sent to every student
+type ClassroomBroadcast struct {
+	Message string    `json:"message"`
+	Sent    time.Time `json:"sent"`
+}
+
+// ClassroomCollection records when a student's results were last collected
+type ClassroomCollection struct {
+	Tick      int       `json:"tick"`
+	Collected time.Time `json:"collected"`
+}
+
+// ClassroomRequest is the body of POST /api/classroom
+type ClassroomRequest struct {
+	Template         *RunConfig `json:"template,omitempty"`          // A run config, as given to --config; empty for the defaults
+	Students         []string   `json:"students"`                    // Names of the students, which name their worlds
+	AutosaveInterval *int       `json:"autosave_interval,omitempty"` // Ticks between autosaves, 0 to only save on shutdown
+}
+
+// ClassroomStudentRequest is the body of POST /api/classroom/students
+type ClassroomStudentRequest struct {
+	Name string `json:"name"`
+}
+
+// ClassroomPauseRequest is the body of POST /api/classroom/pause
+type ClassroomPauseRequest struct {
+	Paused bool `json:"paused"`
+}
+
+// ClassroomBroadcastRequest is the body of POST /api/classroom/broadcast
+type ClassroomBroadcastRequest struct {
+	Message string `json:"message"`
+}
+
+// ClassroomStudent is a student's row on the teacher's dashboard
+type ClassroomStudent struct {
+	Name      string               `json:"name"`
+	World     *HostedWorldSummary  `json:"world,omitempty"` // Missing if the world was deleted from the lobby
+	Metrics   map[string]float64   `json:"metrics,omitempty"`
+	Collected *ClassroomCollection `json:"collected,omitempty"`
+}
+
+// ClassroomStatus is the teacher's dashboard
+type ClassroomStatus struct {
+	Template         RunConfig            `json:"template"`
+	AutosaveInterval int                  `json:"autosave_interval"`
+	Students         []ClassroomStudent   `json:"students"`
+	Broadcasts       []ClassroomBroadcast `json:"broadcasts"`
+	Created          time.Time            `json:"created"`
+}
+
+// ClassroomResults is what a student hands in for grading: their world's headline metrics
+// and its analysis export, whose run certificate shows the world wasn't edited afterwards
+type ClassroomResults struct {
+	Student   string                 `json:"student"`
+	Tick      int                    `json:"tick"`
+	Collected time.Time              `json:"collected"`
+	Metrics   map[string]float64     `json:"metrics"`
+	Analysis  map[string]interface{} `json:"analysis"`
+}
+
+// ClassroomAnnouncement is the message students' pages receive when the teacher broadcasts
+type ClassroomAnnouncement struct {
+	Type    string    `json:"type"` // Always "announcement"
+	Message string    `json:"message"`
+	Sent    time.Time `json:"sent"`
+}
+
+// loadClassroom reads the class kept in a data directory, if there is one
+func loadClassroom(dataDir string) (*Classroom, error) {
+	data, err := os.ReadFile(filepath.Join(dataDir, classroomDir, classroomFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("classroom: %v", err)
+	}
+	var classroom Classroom
+	if err := json.Unmarshal(data, &classroom); err != nil {
+		return nil, fmt.Errorf("classroom: invalid settings: %v", err)
+	}
+	if classroom.Collections == nil {
+		classroom.Collections = make(map[string]ClassroomCollection)
+	}
+	return &classroom, nil
+}
+
+// saveClassroom writes the class to the data directory; the caller holds classroomMutex
+func (s *WorldServer) saveClassroom() error {
+	dir := filepath.Join(s.dataDir, classroomDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create the classroom directory: %v", err)
+	}
+	data, err := json.MarshalIndent(s.classroom, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the classroom: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, classroomFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write the classroom: %v", err)
+	}
+	return nil
+}
+
+// addStudentWorld creates a student's world from the class template; the caller holds
+// classroomMutex
+func (s *WorldServer) addStudentWorld(name string) error {
+	if slices.Contains(s.classroom.Students, name) {
+		return fmt.Errorf("student %q is already in the class", name)
+	}
+	template := s.classroom.Template
+	autosave := s.classroom.AutosaveInterval
+	if _, err := s.CreateWorld(CreateWorldRequest{Name: name, Config: &template, AutosaveInterval: &autosave}); err != nil {
+		return fmt.Errorf("student %s: %v", name, err)
+	}
+	s.classroom.Students = append(s.classroom.Students, name)
+	slices.Sort(s.classroom.Students)
+	return nil
+}
+
+// StartClassroom creates a class with a sandbox world for every student, all built from the
+// template. If any world can't be created, none are.
+func (s *WorldServer) StartClassroom(request ClassroomRequest) (ClassroomStatus, error) {
+	classroom := &Classroom{
+		AutosaveInterval: defaultAutosaveInterval,
+		Broadcasts:       make([]ClassroomBroadcast, 0),
+		Collections:      make(map[string]ClassroomCollection),
+		Created:          time.Now(),
+	}
+	if request.Template != nil {
+		classroom.Template = *request.Template
+	}
+	if err := classroom.Template.Validate(); err != nil {
+		return ClassroomStatus{}, fmt.Errorf("invalid template: %v", err)
+	}
+	if request.AutosaveInterval != nil {
+		if *request.AutosaveInterval < 0 {
+			return ClassroomStatus{}, fmt.Errorf("autosave interval must not be negative")
+		}
+		classroom.AutosaveInterval = *request.AutosaveInterval
+	}
+	if len(request.Students) == 0 {
+		return ClassroomStatus{}, fmt.Errorf("a class needs at least one student")
+	}
+
+	s.classroomMutex.Lock()
+	if s.classroom != nil {
+		s.classroomMutex.Unlock()
+		return ClassroomStatus{}, fmt.Errorf("a class is already running")
+	}
+	s.classroom = classroom
+	for _, name := range request.Students {
+		if err := s.addStudentWorld(name); err != nil {
+			for _, created := range classroom.Students {
+				_ = s.DeleteWorld(created)
+			}
+			s.classroom = nil
+			s.classroomMutex.Unlock()
+			return ClassroomStatus{}, err
+		}
+	}
+	err := s.saveClassroom()
+	s.classroomMutex.Unlock()
+	if err != nil {
+		return ClassroomStatus{}, err
+	}
+
+	log.Printf("Started a class of %d student(s)", len(request.Students))
+	status, _ := s.ClassroomStatus()
+	return status, nil
+}
+
+// AddStudent gives a student who joins late a world built from the class template
+func (s *WorldServer) AddStudent(name string) error {
+	s.classroomMutex.Lock()
+	defer s.classroomMutex.Unlock()
+	if s.classroom == nil {
+		return fmt.Errorf("no class is running")
+	}
+	if err := s.addStudentWorld(name); err != nil {
+		return err
+	}
+	return s.saveClassroom()
+}
+
+// RemoveStudent takes a student out of the class and deletes their world
+func (s *WorldServer) RemoveStudent(name string) error {
+	s.classroomMutex.Lock()
+	defer s.classroomMutex.Unlock()
+	if s.classroom == nil {
+		return fmt.Errorf("no class is running")
+	}
+	index := slices.Index(s.classroom.Students, name)
+	if index < 0 {
+		return fmt.Errorf("no student named %q", name)
+	}
+	if _, exists := s.World(name); exists {
+		if err := s.DeleteWorld(name); err != nil {
+			return err
+		}
+	}
+	s.classroom.Students = slices.Delete(s.classroom.Students, index, index+1)
+	delete(s.classroom.Collections, name)
+	return s.saveClassroom()
+}
+
+// EndClassroom ends the class. The students' worlds stay in the lobby and their collected
+// results stay on disk.
+func (s *WorldServer) EndClassroom() error {
+	s.classroomMutex.Lock()
+	defer s.classroomMutex.Unlock()
+	if s.classroom == nil {
+		return fmt.Errorf("no class is running")
+	}
+	if err := os.Remove(filepath.Join(s.dataDir, classroomDir, classroomFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to end the class: %v", err)
+	}
+	s.classroom = nil
+	log.Printf("Ended the class")
+	return nil
+}
+
+// classroomWorlds lists the students and their worlds, nil for worlds deleted from the lobby
+func (s *WorldServer) classroomWorlds() ([]string, []*HostedWorld, bool) {
+	s.classroomMutex.Lock()
+	defer s.classroomMutex.Unlock()
+	if s.classroom == nil {
+		return nil, nil, false
+	}
+	students := slices.Clone(s.classroom.Students)
+	worlds := make([]*HostedWorld, len(students))
+	for i, name := range students {
+		worlds[i], _ = s.World(name)
+	}
+	return students, worlds, true
+}
+
+// ClassroomStatus describes the class and how each student's world is doing
+func (s *WorldServer) ClassroomStatus() (ClassroomStatus, bool) {
+	students, worlds, exists := s.classroomWorlds()
+	if !exists {
+		return ClassroomStatus{}, false
+	}
+
+	rows := make([]ClassroomStudent, len(students))
+	for i, name := range students {
+		rows[i] = ClassroomStudent{Name: name}
+		if hosted := worlds[i]; hosted != nil {
+			summary := hosted.Summary()
+			rows[i].World = &summary
+			hosted.wi.tickMutex.Lock()
+			rows[i].Metrics = CollectBatchMetrics(hosted.wi.world)
+			hosted.wi.tickMutex.Unlock()
+		}
+	}
+
+	s.classroomMutex.Lock()
+	defer s.classroomMutex.Unlock()
+	if s.classroom == nil {
+		return ClassroomStatus{}, false
+	}
+	for i := range rows {
+		if collection, collected := s.classroom.Collections[rows[i].Name]; collected {
+			rows[i].Collected = &collection
+		}
+	}
+	return ClassroomStatus{
+		Template:         s.classroom.Template,
+		AutosaveInterval: s.classroom.AutosaveInterval,
+		Students:         rows,
+		Broadcasts:       slices.Clone(s.classroom.Broadcasts),
+		Created:          s.classroom.Created,
+	}, true
+}
+
+// PauseClassroom pauses or resumes every student's world at once
+func (s *WorldServer) PauseClassroom(paused bool) error {
+	_, worlds, exists := s.classroomWorlds()
+	if !exists {
+		return fmt.Errorf("no class is running")
+	}
+	for _, hosted := range worlds {
+		if hosted == nil {
+			continue
+		}
+		hosted.wi.tickMutex.Lock()
+		hosted.wi.world.SetPaused(paused)
+		hosted.wi.tickMutex.Unlock()
+		hosted.wi.sendFrame()
+	}
+	log.Printf("Set every student's world paused to %v", paused)
+	return nil
+}
+
+// BroadcastToClassroom shows a message on every student's page and records it in each
+// world's event log
+func (s *WorldServer) BroadcastToClassroom(message string) (ClassroomBroadcast, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return ClassroomBroadcast{}, fmt.Errorf("the message is empty")
+	}
+	if len(message) > maxClassroomMessageLength {
+		return ClassroomBroadcast{}, fmt.Errorf("messages are at most %d characters", maxClassroomMessageLength)
+	}
+	_, worlds, exists := s.classroomWorlds()
+	if !exists {
+		return ClassroomBroadcast{}, fmt.Errorf("no class is running")
+	}
+
+	broadcast := ClassroomBroadcast{Message: message, Sent: time.Now()}
+	for _, hosted := range worlds {
+		if hosted == nil {
+			continue
+		}
+		hosted.wi.tickMutex.Lock()
+		world := hosted.wi.world
+		world.CentralEventBus.EmitSystemEvent(world.Tick, "teacher_message", "classroom", "teacher", message, nil, nil)
+		hosted.wi.tickMutex.Unlock()
+		hosted.wi.notifyClients(ClassroomAnnouncement{Type: "announcement", Message: message, Sent: broadcast.Sent})
+	}
+
+	s.classroomMutex.Lock()
+	defer s.classroomMutex.Unlock()
+	if s.classroom == nil {
+		return ClassroomBroadcast{}, fmt.Errorf("no class is running")
+	}
+	s.classroom.Broadcasts = append(s.classroom.Broadcasts, broadcast)
+	if excess := len(s.classroom.Broadcasts) - maxClassroomBroadcasts; excess > 0 {
+		s.classroom.Broadcasts = s.classroom.Broadcasts[excess:]
+	}
+	return broadcast, s.saveClassroom()
+}
+
+// CollectClassroomResults exports the results of every student's world, or of one student's
+// when a name is given, and keeps a copy of each below the data directory
+func (s *WorldServer) CollectClassroomResults(student string) ([]ClassroomResults, error) {
+	students, worlds, exists := s.classroomWorlds()
+	if !exists {
+		return nil, fmt.Errorf("no class is running")
+	}
+	if student != "" && !slices.Contains(students, student) {
+		return nil, fmt.Errorf("no student named %q", student)
+	}
+	dir := filepath.Join(s.dataDir, classroomDir, classroomResultsDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create the results directory: %v", err)
+	}
+
+	results := make([]ClassroomResults, 0, len(students))
+	for i, name := range students {
+		hosted := worlds[i]
+		if hosted == nil || student != "" && name != student {
+			continue
+		}
+		hosted.wi.tickMutex.Lock()
+		result := ClassroomResults{
+			Student:   name,
+			Tick:      hosted.wi.world.Tick,
+			Collected: time.Now(),
+			Metrics:   CollectBatchMetrics(hosted.wi.world),
+			Analysis:  hosted.wi.analysisExport(),
+		}
+		hosted.wi.tickMutex.Unlock()
+
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s's results: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name+".json"), data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s's results: %v", name, err)
+		}
+		results = append(results, result)
+	}
+
+	s.classroomMutex.Lock()
+	defer s.classroomMutex.Unlock()
+	if s.classroom != nil {
+		for _, result := range results {
+			s.classroom.Collections[result.Student] = ClassroomCollection{Tick: result.Tick, Collected: result.Collected}
+		}
+		if err := s.saveClassroom(); err != nil {
+			return nil, err
+		}
+	}
+	log.Printf("Collected results from %d student(s)", len(results))
+	return results, nil
+}
+
+// writeClassroomGradebook writes one CSV row per student with their tick and every metric
+func writeClassroomGradebook(w io.Writer, results []ClassroomResults) error {
+	metricSet := make(map[string]bool)
+	for _, result := range results {
+		for metric := range result.Metrics {
+			metricSet[metric] = true
+		}
+	}
+	metrics := sortedKeys(metricSet)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(append([]string{"student", "tick", "collected"}, metrics...)); err != nil {
+		return err
+	}
+	for _, result := range results {
+		row := []string{result.Student, strconv.Itoa(result.Tick), result.Collected.Format(time.RFC3339)}
+		for _, metric := range metrics {
+			value, exists := result.Metrics[metric]
+			if !exists {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, strconv.FormatFloat(value, 'g', -1, 64))
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// serveClassroom serves the teacher's dashboard
+func (s *WorldServer) serveClassroom(w http.ResponseWriter, r *http.Request) {
+	webAssets.ServePage(w, "classroom")
+}
+
+// decodeClassroomRequest decodes a classroom request body, refusing unknown fields
+func decodeClassroomRequest(w http.ResponseWriter, r *http.Request, request interface{}) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(request); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// writeClassroomStatus answers with the dashboard, or 404 when no class is running
+func (s *WorldServer) writeClassroomStatus(w http.ResponseWriter, code int) {
+	status, exists := s.ClassroomStatus()
+	if !exists {
+		http.Error(w, "No class is running", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(status)
+}
+
+// handleClassroom shows (GET), starts (POST) and ends (DELETE) the class
+func (s *WorldServer) handleClassroom(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+		s.writeClassroomStatus(w, http.StatusOK)
+	case http.MethodPost:
+		var request ClassroomRequest
+		if !decodeClassroomRequest(w, r, &request) {
+			return
+		}
+		if _, err := s.StartClassroom(request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.writeClassroomStatus(w, http.StatusCreated)
+	case http.MethodDelete:
+		if err := s.EndClassroom(); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleClassroomStudents adds (POST) and removes (DELETE ?name=) students
+func (s *WorldServer) handleClassroomStudents(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var request ClassroomStudentRequest
+		if !decodeClassroomRequest(w, r, &request) {
+			return
+		}
+		if err := s.AddStudent(request.Name); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.writeClassroomStatus(w, http.StatusCreated)
+	case http.MethodDelete:
+		if err := s.RemoveStudent(r.URL.Query().Get("name")); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		s.writeClassroomStatus(w, http.StatusOK)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleClassroomPause pauses or resumes every student's world (POST)
+func (s *WorldServer) handleClassroomPause(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var request ClassroomPauseRequest
+	if !decodeClassroomRequest(w, r, &request) {
+		return
+	}
+	if err := s.PauseClassroom(request.Paused); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	s.writeClassroomStatus(w, http.StatusOK)
+}
+
+// handleClassroomBroadcast sends a message to every student (POST)
+func (s *WorldServer) handleClassroomBroadcast(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var request ClassroomBroadcastRequest
+	if !decodeClassroomRequest(w, r, &request) {
+		return
+	}
+	broadcast, err := s.BroadcastToClassroom(request.Message)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(broadcast)
+}
+
+// handleClassroomResults collects every student's results, or one student's with
+// ?student=, as JSON or as a CSV gradebook with ?format=csv (GET)
+func (s *WorldServer) handleClassroomResults(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		http.Error(w, "format must be json or csv", http.StatusBadRequest)
+		return
+	}
+	results, err := s.CollectClassroomResults(r.URL.Query().Get("student"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", "attachment; filename=gradebook.csv")
+		if err := writeClassroomGradebook(w, results); err != nil {
+			log.Printf("Failed to write the gradebook: %v", err)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", "attachment; filename=classroom_results.json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// runClassroomCommand runs a world server for a class: it starts the class with a world for
+// each listed student, or brings back the class kept in the data directory and adds any
+// listed students who are new, then serves the teacher's dashboard beside the lobby
+func runClassroomCommand(args []string) error {
+	fs := newCommandFlags("classroom")
+	port := fs.Int("port", 8080, "Port for the dashboard, the lobby and the students' worlds")
+	dataDir := fs.String("data", "classroom", "Directory keeping the class, each world's autosaves and the collected results")
+	students := fs.String("students", "", "Comma-separated student names, each given a world of that name")
+	templateFile := fs.String("template", "", "JSON run config every student's world is built from")
+	autosave := fs.Int("autosave", defaultAutosaveInterval, "Ticks between autosaves of each student's world, 0 to only save on shutdown")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := applyEnvironment(fs, commandEnvPrefix(fs.Name())); err != nil {
+		return err
+	}
+
+	var names []string
+	for _, name := range strings.Split(*students, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	server, err := NewWorldServer(*dataDir)
+	if err != nil {
+		return err
+	}
+
+	if server.classroom == nil {
+		if len(names) == 0 {
+			return fmt.Errorf("list the class with --students, e.g. --students alice,bob")
+		}
+		request := ClassroomRequest{Students: names, AutosaveInterval: autosave}
+		if *templateFile != "" {
+			if request.Template, err = LoadRunConfig(*templateFile); err != nil {
+				return err
+			}
+		}
+		if _, err := server.StartClassroom(request); err != nil {
+			return err
+		}
+	} else {
+		if *templateFile != "" {
+			log.Printf("Keeping the template of the class in %s; --template only applies to a new class", *dataDir)
+		}
+		for _, name := range names {
+			if _, exists := server.World(name); exists {
+				continue
+			}
+			if err := server.AddStudent(name); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Printf("Teacher dashboard on http://localhost:%d/classroom", *port)
+	return serveWorlds(server, *port, *dataDir)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testClassroom = `{"students": ["bob", "alice"], "autosave_interval": 5, "template": {"world": {"width": 60, "height": 60, "grid_width": 20, "grid_height": 20, "population_size": 5}, "speed": {"multiplier": 0.1}}}`
+
+func TestClassroomGivesEachStudentAWorld(t *testing.T) {
+	server, err := NewWorldServer(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create the server: %v", err)
+	}
+	defer server.Close()
+	handler := server.Handler().ServeHTTP
+
+	if code := callControlAPI(t, handler, http.MethodGet, "/api/classroom", "", nil); code != http.StatusNotFound {
+		t.Errorf("Expected no class before one starts, got %d", code)
+	}
+	for _, body := range []string{
+		`{"students": []}`,
+		`{"students": ["alice", "alice"]}`,
+		`{"students": ["alice", "Bad Name"]}`,
+		`{"students": ["alice"], "seating": "rows"}`,
+	} {
+		if code := callControlAPI(t, handler, http.MethodPost, "/api/classroom", body, nil); code != http.StatusBadRequest {
+			t.Errorf("Expected %s refused, got %d", body, code)
+		}
+	}
+	if worlds := server.Worlds(); len(worlds) != 0 {
+		t.Fatalf("Expected refused classes to leave no worlds behind, got %+v", worlds)
+	}
+
+	var status ClassroomStatus
+	if code := callControlAPI(t, handler, http.MethodPost, "/api/classroom", testClassroom, &status); code != http.StatusCreated {
+		t.Fatalf("Expected the class started, got %d", code)
+	}
+	if len(status.Students) != 2 || status.Students[0].Name != "alice" || status.Students[0].World == nil {
+		t.Fatalf("Expected a world for each student by name, got %+v", status.Students)
+	}
+	if status.Students[1].World.AutosaveInterval != 5 || status.Students[1].Metrics["total_population"] == 0 {
+		t.Errorf("Expected the class's autosave interval and each world's metrics, got %+v", status.Students[1])
+	}
+	if code := callControlAPI(t, handler, http.MethodPost, "/api/classroom", testClassroom, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected a second class refused, got %d", code)
+	}
+	var world struct {
+		Entities int `json:"entities"`
+	}
+	if callControlAPI(t, handler, http.MethodGet, "/worlds/alice/api/status", "", &world); world.Entities != 15 {
+		t.Errorf("Expected alice's world built from the template, got %d creatures", world.Entities)
+	}
+
+	// Students join and leave during the class
+	callControlAPI(t, handler, http.MethodPost, "/api/classroom/students", `{"name": "carol"}`, &status)
+	if len(status.Students) != 3 || status.Students[2].Name != "carol" {
+		t.Errorf("Expected carol added, got %+v", status.Students)
+	}
+	if code := callControlAPI(t, handler, http.MethodPost, "/api/classroom/students", `{"name": "carol"}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected a student added twice refused, got %d", code)
+	}
+	callControlAPI(t, handler, http.MethodDelete, "/api/classroom/students?name=bob", "", &status)
+	if len(status.Students) != 2 {
+		t.Errorf("Expected bob removed, got %+v", status.Students)
+	}
+	if _, err := os.Stat(filepath.Join(server.dataDir, "bob")); !os.IsNotExist(err) {
+		t.Errorf("Expected bob's world removed")
+	}
+	if code := callControlAPI(t, handler, http.MethodDelete, "/api/classroom/students?name=bob", "", nil); code != http.StatusNotFound {
+		t.Errorf("Expected removing an unknown student not found, got %d", code)
+	}
+
+	callControlAPI(t, handler, http.MethodPost, "/api/classroom/pause", `{"paused": true}`, &status)
+	for _, student := range status.Students {
+		if !student.World.Paused {
+			t.Errorf("Expected %s's world paused", student.Name)
+		}
+	}
+}
+
+func TestClassroomBroadcastsAndCollectsResults(t *testing.T) {
+	dataDir := t.TempDir()
+	server, err := NewWorldServer(dataDir)
+	if err != nil {
+		t.Fatalf("Failed to create the server: %v", err)
+	}
+	handler := server.Handler().ServeHTTP
+	callControlAPI(t, handler, http.MethodPost, "/api/classroom", testClassroom, nil)
+
+	if code := callControlAPI(t, handler, http.MethodPost, "/api/classroom/broadcast", `{"message": "  "}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected an empty message refused, got %d", code)
+	}
+	var broadcast ClassroomBroadcast
+	if code := callControlAPI(t, handler, http.MethodPost, "/api/classroom/broadcast", `{"message": " Look for predators "}`, &broadcast); code != http.StatusCreated {
+		t.Fatalf("Expected the message sent, got %d", code)
+	}
+	alice, _ := server.World("alice")
+	if events := alice.wi.world.CentralEventBus.GetEventsByType("teacher_message"); len(events) != 1 || events[0].Description != "Look for predators" {
+		t.Errorf("Expected the message in alice's event log, got %+v", events)
+	}
+
+	var results []ClassroomResults
+	if code := callControlAPI(t, handler, http.MethodGet, "/api/classroom/results?student=alice", "", &results); code != http.StatusOK {
+		t.Fatalf("Expected alice's results collected, got %d", code)
+	}
+	if len(results) != 1 || results[0].Student != "alice" || results[0].Analysis["certificate"] == nil {
+		t.Fatalf("Expected alice's results with a run certificate, got %+v", results)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, classroomDir, classroomResultsDir, "alice.json")); err != nil {
+		t.Errorf("Expected alice's results kept: %v", err)
+	}
+	if code := callControlAPI(t, handler, http.MethodGet, "/api/classroom/results?student=zed", "", nil); code != http.StatusNotFound {
+		t.Errorf("Expected an unknown student not found, got %d", code)
+	}
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/api/classroom/results?format=csv", nil))
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "student,tick,collected,") || !strings.HasPrefix(lines[1], "alice,") {
+		t.Errorf("Expected a gradebook row for each student, got %q", rec.Body.String())
+	}
+	if err := server.Close(); err != nil {
+		t.Fatalf("Failed to close the server: %v", err)
+	}
+
+	// The class, its messages and what was collected survive a restart
+	server, err = NewWorldServer(dataDir)
+	if err != nil {
+		t.Fatalf("Failed to restore the server: %v", err)
+	}
+	defer server.Close()
+	handler = server.Handler().ServeHTTP
+	var status ClassroomStatus
+	if code := callControlAPI(t, handler, http.MethodGet, "/api/classroom", "", &status); code != http.StatusOK {
+		t.Fatalf("Expected the class restored, got %d", code)
+	}
+	if len(status.Students) != 2 || len(status.Broadcasts) != 1 || status.Students[0].Collected == nil || status.Students[0].World == nil {
+		t.Errorf("Expected the students, message and collections restored, got %+v", status)
+	}
+
+	if code := callControlAPI(t, handler, http.MethodDelete, "/api/classroom", "", nil); code != http.StatusNoContent {
+		t.Fatalf("Expected the class ended, got %d", code)
+	}
+	if code := callControlAPI(t, handler, http.MethodGet, "/api/classroom", "", nil); code != http.StatusNotFound {
+		t.Errorf("Expected no class once it ended, got %d", code)
+	}
+	if worlds := server.Worlds(); len(worlds) != 2 {
+		t.Errorf("Expected the students' worlds kept after the class, got %+v", worlds)
+	}
+}
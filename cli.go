@@ -55,7 +55,12 @@ type CLIModel struct {
 	showSignals    bool
 	showStructures bool
 	showPhysics    bool
+	showDaylight   bool
 	showTime       bool
+	// Measured simulation rate for the status bar
+	governor *SpeedGovernor
+	// Quit with Ctrl+C rather than q, so the next run offers to resume the autosave
+	interrupted bool
 }
 
 // tickMsg represents an auto-advance tick
@@ -78,9 +83,11 @@ var keys = struct {
 	signals    key.Binding
 	structures key.Binding
 	physics    key.Binding
+	daylight   key.Binding
 	export     key.Binding
 	speedUp    key.Binding
 	speedDown  key.Binding
+	follow     key.Binding
 }{
 	up: key.NewBinding(
 		key.WithKeys("up", "k"),
@@ -142,6 +149,10 @@ var keys = struct {
 		key.WithKeys("p"),
 		key.WithHelp("p", "toggle physics"),
 	),
+	daylight: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "toggle night shading"),
+	),
 	export: key.NewBinding(
 		key.WithKeys("e"),
 		key.WithHelp("e", "export data"),
@@ -154,6 +165,10 @@ var keys = struct {
 		key.WithKeys("-", "_"),
 		key.WithHelp("-", "slow down"),
 	),
+	follow: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "follow creature"),
+	),
 }
 
 // Styles
@@ -180,22 +195,76 @@ var (
 			Padding(0, 1).
 			Bold(true)
 
-	biomeColors = map[BiomeType]lipgloss.Style{
-		BiomePlains:    lipgloss.NewStyle().Foreground(lipgloss.Color("34")),  // Green
-		BiomeForest:    lipgloss.NewStyle().Foreground(lipgloss.Color("28")),  // Dark Green
-		BiomeDesert:    lipgloss.NewStyle().Foreground(lipgloss.Color("220")), // Yellow
-		BiomeMountain:  lipgloss.NewStyle().Foreground(lipgloss.Color("244")), // Gray
-		BiomeWater:     lipgloss.NewStyle().Foreground(lipgloss.Color("39")),  // Blue
-		BiomeRadiation: lipgloss.NewStyle().Foreground(lipgloss.Color("196")), // Red
+	// cliBiomeColors are the ANSI colours the terminal grid draws biomes in, unstyled when missing
+	cliBiomeColors = map[BiomeType]string{
+		BiomePlains:    "34",  // Green
+		BiomeForest:    "28",  // Dark Green
+		BiomeDesert:    "220", // Yellow
+		BiomeMountain:  "244", // Gray
+		BiomeWater:     "39",  // Blue
+		BiomeRadiation: "196", // Red
+	}
+	biomeColors = ansiStyles(cliBiomeColors)
+
+	// cliSpeciesColors are the ANSI colours of each base species' creatures
+	cliSpeciesColors = map[string]string{
+		"herbivore": "46",  // Bright Green
+		"predator":  "196", // Red
+		"omnivore":  "208", // Orange
 	}
+	speciesStyles = ansiStyles(cliSpeciesColors)
 
-	speciesStyles = map[string]lipgloss.Style{
-		"herbivore": lipgloss.NewStyle().Foreground(lipgloss.Color("46")),  // Bright Green
-		"predator":  lipgloss.NewStyle().Foreground(lipgloss.Color("196")), // Red
-		"omnivore":  lipgloss.NewStyle().Foreground(lipgloss.Color("208")), // Orange
+	baseSpeciesSymbols = map[string]rune{
+		"herbivore": '●',
+		"predator":  '▲',
+		"omnivore":  '◆',
 	}
+
+	// cliStructureSymbols mark civilization structures in the terminal grid
+	cliStructureSymbols = map[StructureType]cliMarker{
+		StructureNest:    {'N', "Nest"},
+		StructureCache:   {'C', "Cache"},
+		StructureBarrier: {'B', "Barrier"},
+		StructureTrap:    {'P', "Trap"},
+		StructureFarm:    {'F', "Farm"},
+		StructureWell:    {'W', "Well"},
+		StructureTower:   {'O', "Tower"},
+		StructureMarket:  {'M', "Market"},
+	}
+
+	// cliSignalSymbols mark cells in range of a signal in the terminal grid
+	cliSignalSymbols = map[SignalType]cliMarker{
+		SignalDanger:    {'!', "Danger"},
+		SignalFood:      {'*', "Food"},
+		SignalMating:    {'M', "Mating"},
+		SignalTerritory: {'T', "Territory"},
+		SignalHelp:      {'?', "Help"},
+		SignalMigration: {'→', "Migration"},
+	}
+)
+
+// cliMarker is a symbol the terminal grid marks something with, and what it stands for
+type cliMarker struct {
+	Symbol rune
+	Label  string
+}
+
+// ANSI colours of the terminal grid's plants, structures and signals
+const (
+	cliPlantColor     = "240"
+	cliStructureColor = "214"
+	cliSignalColor    = "196"
 )
 
+// ansiStyles makes a lipgloss style for each ANSI colour
+func ansiStyles[K comparable](colors map[K]string) map[K]lipgloss.Style {
+	styles := make(map[K]lipgloss.Style, len(colors))
+	for key, color := range colors {
+		styles[key] = lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+	}
+	return styles
+}
+
 // NewCLIModel creates a new CLI model
 func NewCLIModel(world *World) CLIModel {
 	speciesColors := map[string]string{
@@ -203,27 +272,38 @@ func NewCLIModel(world *World) CLIModel {
 		"predator":  "red",
 		"omnivore":  "orange",
 	}
-
-	speciesSymbols := map[string]rune{
-		"herbivore": '●',
-		"predator":  '▲',
-		"omnivore":  '◆',
-	}
 	return CLIModel{world: world,
 		viewModes:      []string{"grid", "stats", "events", "populations", "communication", "civilization", "physics", "wind", "species", "network", "dna", "cellular", "evolution", "topology", "tools", "environment", "behavior", "reproduction", "statistical", "ecosystem", "anomalies", "warfare", "fungal", "cultural", "symbiotic", "biorhythm"},
 		selectedView:   "grid",
 		autoAdvance:    true,
 		lastUpdateTime: time.Now(),
 		speciesColors:  speciesColors,
-		speciesSymbols: speciesSymbols,
+		speciesSymbols: baseSpeciesSymbols,
 		viewportX:      0,
 		viewportY:      0,
 		zoomLevel:      1,
 		showSignals:    true,
 		showStructures: true,
 		showPhysics:    false,
+		showDaylight:   true,
 		showTime:       true,
+		governor:       NewSpeedGovernor(),
+	}
+}
+
+// recordTicks feeds the status bar's ticks-per-second measurement
+func (m CLIModel) recordTicks(ticks int, now time.Time) {
+	if m.governor != nil {
+		m.governor.RecordTicks(ticks, now)
+	}
+}
+
+// ticksPerSecond returns the measured simulation rate, or 0 before any measurement
+func (m CLIModel) ticksPerSecond() float64 {
+	if m.governor == nil {
+		return 0
 	}
+	return m.governor.TicksPerSecond()
 }
 
 // doTick schedules the next automatic update
@@ -249,6 +329,7 @@ func (m CLIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, keys.quit):
+			m.interrupted = msg.String() == "ctrl+c"
 			return m, tea.Quit
 
 		case key.Matches(msg, keys.help):
@@ -256,6 +337,8 @@ func (m CLIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, keys.space):
 			m.paused = !m.paused
+			// Resuming also clears a pause triggered by a breakpoint
+			m.world.SetPaused(m.paused)
 
 		case key.Matches(msg, keys.auto):
 			m.autoAdvance = !m.autoAdvance
@@ -270,26 +353,36 @@ func (m CLIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case key.Matches(msg, keys.enter):
-			// Manual step forward
+			// Manual step forward, even when a breakpoint has paused the world
+			worldPaused := m.world.IsPaused()
+			m.world.SetPaused(false)
 			m.world.Update()
+			if worldPaused {
+				m.world.SetPaused(true)
+			}
 			m.tick++
+			m.centerOnFollowed()
 
 		case key.Matches(msg, keys.left):
+			m.stopFollowing() // Panning away lets the camera go
 			if m.viewportX > 0 {
 				m.viewportX--
 			}
 
 		case key.Matches(msg, keys.right):
+			m.stopFollowing()
 			if m.viewportX < m.world.Config.GridWidth-20 {
 				m.viewportX++
 			}
 
 		case key.Matches(msg, keys.up):
+			m.stopFollowing()
 			if m.viewportY > 0 {
 				m.viewportY--
 			}
 
 		case key.Matches(msg, keys.down):
+			m.stopFollowing()
 			if m.viewportY < m.world.Config.GridHeight-15 {
 				m.viewportY++
 			}
@@ -298,11 +391,17 @@ func (m CLIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.zoomLevel = (m.zoomLevel % 3) + 1
 
 		case key.Matches(msg, keys.reset):
+			m.stopFollowing()
 			m.viewportX = 0
 			m.viewportY = 0
 			m.zoomLevel = 1
-			m.selectedEntity = nil
-			m.followEntity = false
+
+		case key.Matches(msg, keys.follow):
+			if m.followEntity {
+				m.stopFollowing()
+			} else {
+				m.startFollowing()
+			}
 
 		case key.Matches(msg, keys.signals):
 			m.showSignals = !m.showSignals
@@ -313,6 +412,9 @@ func (m CLIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, keys.physics):
 			m.showPhysics = !m.showPhysics
 
+		case key.Matches(msg, keys.daylight):
+			m.showDaylight = !m.showDaylight
+
 		case key.Matches(msg, keys.export):
 			// Export statistical data
 			m.exportStatisticalData()
@@ -325,6 +427,10 @@ func (m CLIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tickMsg:
+		// A breakpoint pauses the world itself; reflect that in the CLI
+		if m.world.IsPaused() && !m.paused {
+			m.paused = true
+		}
 		if m.autoAdvance && !m.paused {
 			// Run multiple simulation updates based on speed multiplier
 			speedMultiplier := m.world.GetSpeedMultiplier()
@@ -345,6 +451,10 @@ func (m CLIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.world.Update()
 			}
 			m.tick++
+			m.recordTicks(updatesToRun, time.Time(msg))
+			m.centerOnFollowed()
+		} else {
+			m.recordTicks(0, time.Time(msg))
 		}
 		cmd = doTick()
 	}
@@ -352,6 +462,57 @@ func (m CLIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// gridViewWidth and gridViewHeight are the most cells the grid view shows
+const (
+	gridViewWidth  = 60
+	gridViewHeight = 25
+)
+
+// startFollowing locks the viewport onto the living creature nearest the middle of the view
+func (m *CLIModel) startFollowing() {
+	width, height := float64(m.world.Config.Width), float64(m.world.Config.Height)
+	cellW, cellH := width/float64(m.world.Config.GridWidth), height/float64(m.world.Config.GridHeight)
+	middle := Position{
+		X: (float64(m.viewportX) + float64(min(m.world.Config.GridWidth-m.viewportX, gridViewWidth))/2) * cellW,
+		Y: (float64(m.viewportY) + float64(min(m.world.Config.GridHeight-m.viewportY, gridViewHeight))/2) * cellH,
+	}
+	var nearest *Entity
+	nearestDistance := math.Inf(1)
+	for _, entity := range m.world.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		if distance := m.world.Distance(middle, entity.Position); distance < nearestDistance {
+			nearest, nearestDistance = entity, distance
+		}
+	}
+	if nearest == nil || m.world.Follow.Follow(m.world, nearest.ID) != nil {
+		return
+	}
+	m.selectedEntity = nearest
+	m.followEntity = true
+	m.centerOnFollowed()
+}
+
+// stopFollowing lets the viewport move freely again
+func (m *CLIModel) stopFollowing() {
+	if m.followEntity && m.selectedEntity != nil {
+		m.world.Follow.Unfollow(m.selectedEntity.ID)
+	}
+	m.selectedEntity = nil
+	m.followEntity = false
+}
+
+// centerOnFollowed keeps the followed creature in the middle of the grid view while it lives
+func (m *CLIModel) centerOnFollowed() {
+	if !m.followEntity || m.selectedEntity == nil || !m.selectedEntity.IsAlive {
+		return
+	}
+	x, y := m.world.worldToGridCoords(m.selectedEntity.Position.X, m.selectedEntity.Position.Y)
+	m.viewportX = max(0, min(x-gridViewWidth/2, m.world.Config.GridWidth-gridViewWidth))
+	m.viewportY = max(0, min(y-gridViewHeight/2, m.world.Config.GridHeight-gridViewHeight))
+}
+
 // View renders the interface
 func (m CLIModel) View() string {
 	if m.showHelp {
@@ -433,6 +594,9 @@ func (m CLIModel) headerView() string {
 	status := "▶ RUNNING"
 	if m.paused {
 		status = "⏸ PAUSED"
+		if hit := m.world.Breakpoints.LastHit(); hit != nil && m.world.IsPaused() {
+			status = fmt.Sprintf("⏸ BREAKPOINT %s: %s", hit.Spec, hit.HitReason)
+		}
 	}
 
 	entities := len(m.world.AllEntities)
@@ -472,8 +636,8 @@ func (m CLIModel) headerView() string {
 
 	title := titleStyle.Render(fmt.Sprintf("🌍 Genetic Ecosystem - Tick %d", m.world.Tick))
 	speedText := fmt.Sprintf("%.2fx", m.world.GetSpeedMultiplier())
-	infoText := fmt.Sprintf("%s | %s %s | Speed: %s | Entities: %d | Pops: %d | Events: %d | View: %s",
-		status, timeIcon, worldTime, speedText, entities, populations, activeEvents, strings.ToUpper(m.selectedView))
+	infoText := fmt.Sprintf("%s | %s %s | Speed: %s (%.1f TPS) | Entities: %d | Pops: %d | Events: %d | View: %s",
+		status, timeIcon, worldTime, speedText, m.ticksPerSecond(), entities, populations, activeEvents, strings.ToUpper(m.selectedView))
 
 	if len(indicators) > 0 {
 		infoText += " | " + strings.Join(indicators, " ")
@@ -494,8 +658,19 @@ func (m CLIModel) gridView() string {
 	// Build grid representation with viewport support
 	startX := m.viewportX
 	startY := m.viewportY
-	displayWidth := min(m.world.Config.GridWidth-startX, 60)
-	displayHeight := min(m.world.Config.GridHeight-startY, 25)
+	displayWidth := min(m.world.Config.GridWidth-startX, gridViewWidth)
+	displayHeight := min(m.world.Config.GridHeight-startY, gridViewHeight)
+
+	// Breadcrumbs of the followed creature's recent path, true for the cell it stands in
+	var track *FollowTrack
+	followTrail := make(map[GridPoint]bool)
+	if m.followEntity && m.selectedEntity != nil {
+		if track = m.world.Follow.Track(m.selectedEntity.ID); track != nil {
+			for i, point := range track.Trail {
+				followTrail[point] = track.Alive && i == len(track.Trail)-1
+			}
+		}
+	}
 
 	for y := startY; y < startY+displayHeight; y++ {
 		for x := startX; x < startX+displayWidth; x++ {
@@ -508,19 +683,9 @@ func (m CLIModel) gridView() string {
 			if m.showStructures && m.world.CivilizationSystem != nil {
 				for _, structure := range m.world.CivilizationSystem.Structures {
 					if int(structure.Position.X) == x && int(structure.Position.Y) == y && structure.IsActive {
-						structureSymbols := map[StructureType]rune{
-							StructureNest:    'N',
-							StructureCache:   'C',
-							StructureBarrier: 'B',
-							StructureTrap:    'P',
-							StructureFarm:    'F',
-							StructureWell:    'W',
-							StructureTower:   'O',
-							StructureMarket:  'M',
-						}
-						if structSymbol, exists := structureSymbols[structure.Type]; exists {
-							symbol = structSymbol
-							style = lipgloss.NewStyle().Foreground(lipgloss.Color("214")) // Orange for structures
+						if marker, exists := cliStructureSymbols[structure.Type]; exists {
+							symbol = marker.Symbol
+							style = lipgloss.NewStyle().Foreground(lipgloss.Color(cliStructureColor))
 						}
 						break
 					}
@@ -534,17 +699,9 @@ func (m CLIModel) gridView() string {
 						(signal.Position.Y-float64(y))*(signal.Position.Y-float64(y)))
 					if distance <= signal.Range {
 						// Show signal effect with different symbols
-						signalSymbols := map[SignalType]rune{
-							SignalDanger:    '!',
-							SignalFood:      '*',
-							SignalMating:    'M',
-							SignalTerritory: 'T',
-							SignalHelp:      '?',
-							SignalMigration: '→',
-						}
-						if signalSymbol, exists := signalSymbols[signal.Type]; exists && distance < 2.0 {
-							symbol = signalSymbol
-							style = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Blink(true) // Red blinking
+						if marker, exists := cliSignalSymbols[signal.Type]; exists && distance < 2.0 {
+							symbol = marker.Symbol
+							style = lipgloss.NewStyle().Foreground(lipgloss.Color(cliSignalColor)).Blink(true)
 							break
 						}
 					}
@@ -633,7 +790,7 @@ func (m CLIModel) gridView() string {
 						config := GetPlantConfigs()[dominantPlant.Type]
 						symbol = config.Symbol
 						// Use a dimmer style for plants
-						style = biomeColors[cell.Biome].Foreground(lipgloss.Color("240"))
+						style = biomeColors[cell.Biome].Foreground(lipgloss.Color(cliPlantColor))
 					}
 
 					// Show multiple plants with small numbers
@@ -647,6 +804,14 @@ func (m CLIModel) gridView() string {
 				}
 			}
 
+			if m.showDaylight {
+				style = daylightShade(style, m.world.DayNight.LightAt(y))
+			}
+			if current, onTrail := followTrail[GridPoint{X: x, Y: y}]; current {
+				style = style.Background(lipgloss.Color("220")).Foreground(lipgloss.Color("16")).Bold(true)
+			} else if onTrail {
+				style = style.Background(lipgloss.Color("58"))
+			}
 			gridBuilder.WriteString(style.Render(string(symbol)))
 		}
 		if y < startY+displayHeight-1 {
@@ -659,60 +824,108 @@ func (m CLIModel) gridView() string {
 	// Add legend
 	legend := m.legendView()
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, grid, "  ", legend)
+	view := lipgloss.JoinHorizontal(lipgloss.Top, grid, "  ", legend)
+	if track != nil {
+		view = lipgloss.JoinVertical(lipgloss.Left, view, followView(track))
+	}
+	return view
+}
+
+// followView streams what the followed creature did over its latest ticks
+func followView(track *FollowTrack) string {
+	var view strings.Builder
+	status := "🎥 Following"
+	if !track.Alive {
+		status = "💀 Followed"
+	}
+	view.WriteString(titleStyle.Render(fmt.Sprintf("%s #%d %s since tick %d (f: stop)", status, track.EntityID, track.Species, track.StartTick)) + "\n")
+	if len(track.Frames) == 0 {
+		return view.String()
+	}
+	latest := track.Frames[len(track.Frames)-1]
+	view.WriteString(fmt.Sprintf("%s at (%.1f, %.1f) | Energy %.1f (%+.1f)\n", latest.Activity, latest.Position.X, latest.Position.Y, latest.Energy, latest.EnergyChange))
+	for i := len(track.Frames) - 1; i >= 0; i-- {
+		if decision := track.Frames[i].Decision; decision != nil {
+			view.WriteString(fmt.Sprintf("Decision at tick %d: move (%+.2f, %+.2f) at %.0f%% | senses", track.Frames[i].Tick, decision.Move.X, decision.Move.Y, decision.Intensity*100))
+			for _, name := range neuralInputNames {
+				view.WriteString(fmt.Sprintf(" %s %.2f", name, decision.Inputs[name]))
+			}
+			view.WriteString("\n")
+			for layer, values := range decision.Activations {
+				view.WriteString(fmt.Sprintf("  L%d ", layer))
+				for _, value := range values {
+					view.WriteString(activationShade(value))
+				}
+				view.WriteString("\n")
+			}
+			break
+		}
+	}
+	for i := len(track.Frames) - 1; i >= max(0, len(track.Frames)-5); i-- {
+		for _, interaction := range track.Frames[i].Interactions {
+			view.WriteString(fmt.Sprintf("  Tick %d %s: %s\n", track.Frames[i].Tick, interaction.Kind, interaction.Description))
+		}
+	}
+	return strings.TrimRight(view.String(), "\n")
+}
+
+// activationShade draws a neuron's activation as a block, green when positive and red when
+// negative, denser the stronger it fires
+func activationShade(value float64) string {
+	shades := []string{"░", "▒", "▓", "█"}
+	shade := shades[min(len(shades)-1, int(math.Abs(value)*float64(len(shades))))]
+	color := "46"
+	if value < 0 {
+		color = "196"
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(shade)
+}
+
+// daylightShade darkens a cell's background by the daylight on it, navy by night and grey at dusk
+func daylightShade(style lipgloss.Style, light float64) lipgloss.Style {
+	switch {
+	case light < 0.25:
+		return style.Background(lipgloss.Color("17"))
+	case light < 0.6:
+		return style.Background(lipgloss.Color("237"))
+	}
+	return style
 }
 
 // legendView renders the legend for symbols and colors
 func (m CLIModel) legendView() string {
 	var legend strings.Builder
 
-	legend.WriteString(titleStyle.Render("Legend") + "\n\n")
+	legend.WriteString(titleStyle.Render("Legend") + "\n")
 
-	legend.WriteString("🌱 Biomes:\n")
-	// Get sorted biome types for consistent ordering
-	var biomeTypes []BiomeType
-	for biomeType := range m.world.Biomes {
-		biomeTypes = append(biomeTypes, biomeType)
+	if m.showDaylight && m.world.AdvancedTimeSystem != nil {
+		legend.WriteString(fmt.Sprintf("\n🌗 %s\n", m.world.AdvancedTimeSystem.GetTimeDescription()))
+		legend.WriteString(daylightShade(lipgloss.NewStyle(), 0).Render("  ") + " night  " +
+			daylightShade(lipgloss.NewStyle(), 0.5).Render("  ") + " dusk\n")
 	}
-	sort.Slice(biomeTypes, func(i, j int) bool {
-		return m.world.Biomes[biomeTypes[i]].Name < m.world.Biomes[biomeTypes[j]].Name
-	})
 
-	for _, biomeType := range biomeTypes {
-		biome := m.world.Biomes[biomeType]
-		style := biomeColors[biomeType]
-		legend.WriteString(fmt.Sprintf("%s %s\n",
-			style.Render(string(biome.Symbol)), biome.Name))
-	}
-
-	legend.WriteString("\n👥 Species:\n")
-	// Show actual species names from populations
-	if m.world.SpeciesNaming != nil {
-		for _, pop := range m.world.Populations {
-			if info := m.world.SpeciesNaming.GetSpeciesInfo(pop.Species); info != nil {
-				baseSpecies := info.Species
-				if symbol, exists := m.speciesSymbols[baseSpecies]; exists {
-					if style, exists := speciesStyles[baseSpecies]; exists {
-						legend.WriteString(fmt.Sprintf("%s %s\n",
-							style.Render(string(symbol)), pop.Species))
-					}
-				}
-			}
+	// The same tables the grid draws from, so the legend can't drift from it
+	icons := map[string]string{"biomes": "🌱", "structures": "🏗", "signals": "📡", "entities": "👥", "plants": "🌿"}
+	for _, section := range m.world.rendererLegend(LegendRendererCLI).Sections {
+		if (section.Name == "structures" && !m.showStructures) || (section.Name == "signals" && !m.showSignals) {
+			continue
 		}
-	} else {
-		// Fallback to old behavior
-		for species, symbol := range m.speciesSymbols {
-			style := speciesStyles[species]
-			caser := cases.Title(language.English)
-			legend.WriteString(fmt.Sprintf("%s %s\n",
-				style.Render(string(symbol)), caser.String(species)))
+		legend.WriteString(fmt.Sprintf("\n%s %s:\n", icons[section.Name], section.Label))
+		for j, entry := range section.Entries {
+			style := lipgloss.NewStyle()
+			if entry.Color != "" {
+				style = style.Foreground(lipgloss.Color(entry.Color))
+			}
+			legend.WriteString(style.Render(entry.Symbol) + " " + entry.Label)
+			if j%2 == 1 || j == len(section.Entries)-1 {
+				legend.WriteString("\n")
+			} else {
+				legend.WriteString(strings.Repeat(" ", max(1, 18-lipgloss.Width(entry.Symbol+" "+entry.Label))))
+			}
 		}
 	}
 
-	legend.WriteString("\n📊 Numbers = Multiple entities\n")
-	legend.WriteString("+ = 10+ entities")
-
-	return legend.String()
+	return strings.TrimRight(legend.String(), "\n")
 }
 
 // statsView renders detailed statistics
@@ -1360,7 +1573,8 @@ func (m CLIModel) footerView() string {
 		"v: cycle view",
 		"arrows: navigate",
 		"enter: step",
-		"s/t/p: toggles",
+		"s/t/p/n: toggles",
+		"f: follow",
 		"r: reset",
 		"?: help",
 		"q: quit",
@@ -1382,9 +1596,11 @@ CONTROLS:
   ←→↑↓/hjkl  Navigate viewport (pan around world)
   z          Cycle zoom level
   r          Reset viewport to origin
+  f          Follow the creature nearest the middle of the grid, or stop following
   s          Toggle signal visualization
   t          Toggle structure visualization
   p          Toggle physics visualization
+  n          Toggle night shading of the grid
   ?          Toggle this help screen
   q          Quit
 
@@ -1584,7 +1800,7 @@ func (m CLIModel) windView() string {
 		if plant.IsAlive {
 			plantCounts[plant.Type]++
 			totalPlants++
-			if plant.CanReproduce() {
+			if plant.CanReproduce(sharedRand) {
 				reproducingPlants[plant.Type]++
 				totalReproducing++
 			}
@@ -3218,9 +3434,7 @@ func (m *CLIModel) reproductionView() string {
 		}
 
 		if rs.RequiresMigration {
-			dx := entity.Position.X - rs.PreferredMatingLocation.X
-			dy := entity.Position.Y - rs.PreferredMatingLocation.Y
-			distance := math.Sqrt(dx*dx + dy*dy)
+			distance := m.world.Distance(entity.Position, rs.PreferredMatingLocation)
 			content.WriteString(fmt.Sprintf("  Migration distance: %.1f units\n", distance))
 		}
 
@@ -3406,6 +3620,24 @@ func (m *CLIModel) ecosystemView() string {
 	}
 	content.WriteString("\n")
 
+	// Seed caching mutualism
+	content.WriteString("SEED CACHING MUTUALISM:\n")
+	content.WriteString(fmt.Sprintf("  Cached Seeds: %d\n", metrics.CachedSeeds))
+	content.WriteString(fmt.Sprintf("  Forgotten-Cache Recruits: %d\n", metrics.CacheRecruits))
+	content.WriteString(fmt.Sprintf("  Trees Planted by Cachers: %.1f%%\n", metrics.CacherDependence*100))
+	for _, species := range sortedKeys(metrics.RecruitsByCacher) {
+		content.WriteString(fmt.Sprintf("    %s: %d\n", species, metrics.RecruitsByCacher[species]))
+	}
+	content.WriteString("\n")
+
+	// Habitat connectivity
+	content.WriteString("HABITAT CONNECTIVITY:\n")
+	content.WriteString(fmt.Sprintf("  Connectivity Index: %.4f\n", metrics.ConnectivityIndex))
+	content.WriteString(fmt.Sprintf("  Habitat Patches: %d\n", metrics.HabitatPatches))
+	content.WriteString(fmt.Sprintf("  Movement Corridors: %d\n", metrics.CorridorCount))
+	content.WriteString(fmt.Sprintf("  Choke Points: %d\n", metrics.ChokePointCount))
+	content.WriteString("\n")
+
 	// Ecosystem health
 	content.WriteString("ECOSYSTEM HEALTH:\n")
 	healthScore := m.world.EcosystemMonitor.GetHealthScore()
@@ -4940,6 +5172,15 @@ func getTimeOfDayName(timeOfDay TimeOfDay) string {
 func RunCLI(world *World) error {
 	model := NewCLIModel(world)
 	p := tea.NewProgram(model, tea.WithAltScreen())
-	_, err := p.Run()
-	return err
+	final, err := p.Run()
+	if err != nil || world.Autosaver == nil {
+		return err
+	}
+
+	// Quitting with q finishes the session; Ctrl+C leaves it to resume
+	status := AutosaveFinished
+	if model, ok := final.(CLIModel); ok && model.interrupted {
+		status = AutosaveInterrupted
+	}
+	return world.Autosaver.End(world, status)
 }
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newFuzzWorld creates a small populated world that is cheap to load into repeatedly
+func newFuzzWorld() *World {
+	world := NewWorld(WorldConfig{Width: 30, Height: 30, PopulationSize: 3, GridWidth: 6, GridHeight: 6})
+	world.AddPopulation(startingPopulations(false)[0])
+	return world
+}
+
+// FuzzClientMessage checks that no client message can panic the server.
+// Run with: go test -run '^$' -fuzz FuzzClientMessage
+func FuzzClientMessage(f *testing.F) {
+	seeds := []string{
+		`{"action": "toggle_pause"}`,
+		`{"action": "set_speed", "data": {"speed": 2}}`,
+		`{"action": "set_speed", "data": {"speed": "fast"}}`,
+		`{"action": "pan", "data": {"deltaX": 1e300, "deltaY": -1e300}}`,
+		`{"action": "zoom", "data": {"zoom": -5}}`,
+		`{"action": "join_as_player", "data": {"player_name": 7}}`,
+		`{"action": "create_species", "data": {"traits": {"speed": "x"}, "population_size": -1}}`,
+		`{"action": "control_species", "data": null}`,
+		`{"action": "build_structure", "data": {"cells": [[1e9, -1e9]]}}`,
+		`{"action": "load_state", "data": {"entities": [null], "biomes": [[99]]}}`,
+		`{"type": "get_isometric_data", "viewportX": -1e12, "zoom": 0, "maxTiles": -3}`,
+		`[1, 2, 3]`,
+		`null`,
+		`not json`,
+	}
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+
+	wi := NewWebInterface(newFuzzWorld())
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		var msg map[string]interface{}
+		if json.Unmarshal(raw, &msg) == nil {
+			// Saving writes files into the working directory and resetting is slow
+			if action, _ := msg["action"].(string); action == "save_state" || action == "reset" {
+				return
+			}
+		}
+		wi.dispatchClientMessage(nil, raw)
+	})
+}
+
+// FuzzLoadFromData checks that hand-edited or corrupt saves return errors instead of panicking.
+// Run with: go test -run '^$' -fuzz FuzzLoadFromData -fuzzminimizetime 2s
+// (the valid save seed is large, so default minimization of new inputs is slow)
+func FuzzLoadFromData(f *testing.F) {
+	world := newFuzzWorld()
+	state, err := NewStateManager(world).CurrentState()
+	if err != nil {
+		f.Fatalf("Failed to capture state: %v", err)
+	}
+	valid, err := json.Marshal(state)
+	if err != nil {
+		f.Fatalf("Failed to marshal state: %v", err)
+	}
+	f.Add(valid)
+	f.Add([]byte(`{"config": {"Width": 0, "Height": -1, "GridWidth": 1000, "GridHeight": 1000}}`))
+	f.Add([]byte(`{"entities": [null, {"id": 1, "position": {"X": 1e308, "Y": -1e308}}], "plants": [null]}`))
+	f.Add([]byte(`{"biomes": [[1, 2], [3]], "events": [{"biome_changes": {"nan,inf": 4}}]}`))
+	f.Add([]byte(`{"entities": [{"id": 1, "species": "x", "dna": {"chromosomes": [{"genes": [{"sequence": ["Q"]}]}]}, "cellular": {"cells": [{"type": -4}]}}]}`))
+	f.Add([]byte(`{"network": {"connections": [null], "signals": [null]}, "species": {"active_species": {"a": null}}}`))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		var data map[string]interface{}
+		if json.Unmarshal(raw, &data) != nil {
+			return
+		}
+		_ = NewStateManager(newFuzzWorld()).LoadFromData(data)
+	})
+}
+
+func TestClientMessageErrorsAreReportedWithoutDisconnecting(t *testing.T) {
+	wi := NewWebInterface(newFuzzWorld())
+	server := httptest.NewServer(http.HandlerFunc(wi.handleWebSocketUpgrade))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	// Discard the initial view update
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, _, err := conn.ReadMessage(); err != nil {
+		t.Fatalf("Failed to read initial view: %v", err)
+	}
+
+	for _, message := range []string{`not json`, `{"action": "load_state", "data": "oops"}`} {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+			t.Fatalf("Failed to send %q: %v", message, err)
+		}
+		var response map[string]interface{}
+		if err := conn.ReadJSON(&response); err != nil {
+			t.Fatalf("Expected an error response to %q, connection failed: %v", message, err)
+		}
+		if response["type"] != "error" {
+			t.Errorf("Expected an error response to %q, got %v", message, response["type"])
+		}
+	}
+}
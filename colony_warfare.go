@@ -188,6 +188,10 @@ type ColonyWarfareSystem struct {
 	DiplomacyUpdateRate  int     `json:"diplomacy_update_rate"`  // Ticks between diplomacy updates
 	ResourceCompetition  float64 `json:"resource_competition"`   // How much colonies compete for resources
 	MaxActiveConflicts   int     `json:"max_active_conflicts"`
+
+	EventBus *CentralEventBus `json:"-"` // Optional; receives conflict events
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewColonyWarfareSystem creates a new inter-colony warfare and diplomacy system
@@ -205,6 +209,7 @@ func NewColonyWarfareSystem() *ColonyWarfareSystem {
 		DiplomacyUpdateRate:  50,   // Update every 50 ticks
 		ResourceCompetition:  0.7,  // Moderate competition
 		MaxActiveConflicts:   10,   // Maximum simultaneous conflicts
+		rng:                  sharedRand,
 	}
 }
 
@@ -321,7 +326,7 @@ func (cws *ColonyWarfareSystem) CheckForConflicts(colonies []*CasteColony, tick
 	}
 
 	for _, border := range cws.TerritoryBorders {
-		if rand.Float64() < cws.BorderConflictChance {
+		if cws.rng.Float64() < cws.BorderConflictChance {
 			colony1 := cws.findColonyByID(colonies, border.Colony1ID)
 			colony2 := cws.findColonyByID(colonies, border.Colony2ID)
 
@@ -347,7 +352,7 @@ func (cws *ColonyWarfareSystem) shouldStartConflict(colony1, colony2 *CasteColon
 
 	// More likely if already enemies
 	if diplomacy1.Relations[colony2.ID] == Enemy {
-		return rand.Float64() < 0.3 // 30% chance
+		return cws.rng.Float64() < 0.3 // 30% chance
 	}
 
 	// Resource competition increases conflict chance
@@ -364,7 +369,7 @@ func (cws *ColonyWarfareSystem) shouldStartConflict(colony1, colony2 *CasteColon
 		conflictChance *= 0.5
 	}
 
-	return rand.Float64() < conflictChance
+	return cws.rng.Float64() < conflictChance
 }
 
 // calculateResourcePressure determines how much colonies compete for resources
@@ -428,6 +433,18 @@ func (cws *ColonyWarfareSystem) StartConflict(attacker, defender *CasteColony, c
 	}
 	attackerDiplomacy.RelationHistory[defender.ID] = append(attackerDiplomacy.RelationHistory[defender.ID], event)
 
+	if cws.EventBus != nil {
+		// Skirmishes and raids are routine; resource and total wars are worth an alert
+		eventType := "conflict_started"
+		if conflictType == ResourceWar || conflictType == TotalWar {
+			eventType = "war_declared"
+		}
+		position := attacker.NestLocation
+		cws.EventBus.EmitSystemEvent(tick, eventType, "warfare", "colony_warfare",
+			fmt.Sprintf("Colony %d started a %s against colony %d", attacker.ID, conflictType, defender.ID), &position,
+			map[string]interface{}{"conflict_id": conflict.ID, "attacker": attacker.ID, "defender": defender.ID, "conflict_type": conflictType.String()})
+	}
+
 	return conflict
 }
 
@@ -435,13 +452,13 @@ func (cws *ColonyWarfareSystem) StartConflict(attacker, defender *CasteColony, c
 func (cws *ColonyWarfareSystem) getInitialConflictIntensity(conflictType ConflictType) float64 {
 	switch conflictType {
 	case BorderSkirmish:
-		return 0.2 + rand.Float64()*0.3 // 0.2-0.5
+		return 0.2 + cws.rng.Float64()*0.3 // 0.2-0.5
 	case ResourceWar:
-		return 0.4 + rand.Float64()*0.4 // 0.4-0.8
+		return 0.4 + cws.rng.Float64()*0.4 // 0.4-0.8
 	case TotalWar:
-		return 0.7 + rand.Float64()*0.3 // 0.7-1.0
+		return 0.7 + cws.rng.Float64()*0.3 // 0.7-1.0
 	case Raid:
-		return 0.1 + rand.Float64()*0.2 // 0.1-0.3
+		return 0.1 + cws.rng.Float64()*0.2 // 0.1-0.3
 	default:
 		return 0.3
 	}
@@ -531,7 +548,7 @@ func (cws *ColonyWarfareSystem) resolveBattle(attacker, defender *CasteColony, a
 	strengthRatio := attackerStrength / (attackerStrength + defenderStrength)
 
 	// Add some randomness
-	battleRoll := rand.Float64()
+	battleRoll := cws.rng.Float64()
 
 	casualties := 0
 	resourcesLost := 0.0
@@ -544,7 +561,7 @@ func (cws *ColonyWarfareSystem) resolveBattle(attacker, defender *CasteColony, a
 
 		// Attacker gains territory
 		if len(defender.Territory) > 1 && conflict.WarGoal == "territory" {
-			claimedTerritory := defender.Territory[rand.Intn(len(defender.Territory))]
+			claimedTerritory := defender.Territory[cws.rng.Intn(len(defender.Territory))]
 			conflict.TerritoryClaimed = append(conflict.TerritoryClaimed, claimedTerritory)
 
 			// Remove from defender territory
@@ -604,7 +621,7 @@ func (cws *ColonyWarfareSystem) shouldEndConflict(conflict *Conflict, attacker,
 	}
 
 	// Random chance to end (war weariness)
-	if conflict.TurnsActive > 30 && rand.Float64() < 0.05 {
+	if conflict.TurnsActive > 30 && cws.rng.Float64() < 0.05 {
 		return true
 	}
 
@@ -684,7 +701,7 @@ func (cws *ColonyWarfareSystem) evaluateWarOutcome(conflict *Conflict, attacker,
 	case "dominance":
 		return attacker.ColonySize > int(float64(defender.ColonySize)*1.2)
 	default:
-		return rand.Float64() < 0.5 // Random if unclear
+		return cws.rng.Float64() < 0.5 // Random if unclear
 	}
 }
 
@@ -745,7 +762,7 @@ func (cws *ColonyWarfareSystem) processDiplomaticInteraction(colony1, colony2 *C
 
 	successChance := (trust + reputation + proximity) / 3.0
 
-	if rand.Float64() < successChance {
+	if cws.rng.Float64() < successChance {
 		// Successful diplomacy - improve relations
 		cws.improveRelations(colony1, colony2, tick)
 	}
@@ -1164,7 +1181,7 @@ func (cws *ColonyWarfareSystem) evaluateAndCreateTrade(colony1, colony2 *CasteCo
 
 	// Only create trade if both sides have something to offer and want
 	if len(offeredResources) > 0 && len(wantedResources) > 0 {
-		duration := 500 + rand.Intn(1000) // 500-1500 tick duration
+		duration := 500 + cws.rng.Intn(1000) // 500-1500 tick duration
 		agreement := cws.CreateTradeAgreement(colony1, colony2, offeredResources, wantedResources, duration, tick)
 
 		if agreement != nil {
@@ -1390,7 +1407,8 @@ func (cws *ColonyWarfareSystem) coordinateJointOperations(alliance *Alliance, co
 	}
 
 	// Find enemies that are hostile to multiple alliance members
-	for enemyID, hostileCount := range enemyCounts {
+	for _, enemyID := range sortedKeys(enemyCounts) {
+		hostileCount := enemyCounts[enemyID]
 		if hostileCount >= 2 { // At least 2 alliance members consider this an enemy
 			enemy := cws.findColonyByID(colonies, enemyID)
 			if enemy != nil {
@@ -1598,7 +1616,7 @@ func (cws *ColonyWarfareSystem) AttemptAllianceFormation(colonies []*CasteColony
 
 				if !alreadyAllied {
 					members := []int{colony1.ID, colony2.ID}
-					resourceShare := 0.1 + rand.Float64()*0.1 // 10-20% resource sharing
+					resourceShare := 0.1 + cws.rng.Float64()*0.1 // 10-20% resource sharing
 					alliance := cws.CreateAlliance(members, "defensive", resourceShare, tick)
 
 					if alliance != nil {
@@ -1638,7 +1656,7 @@ func (cws *ColonyWarfareSystem) shouldFormAlliance(colony1, colony2 *CasteColony
 	}
 
 	// More likely to ally if they have common enemies
-	return commonThreats > 0 && rand.Float64() < 0.3
+	return commonThreats > 0 && cws.rng.Float64() < 0.3
 }
 
 // calculateTrustBonus returns a multiplier based on trust level between colonies
@@ -1762,7 +1780,7 @@ func (cws *ColonyWarfareSystem) addProximityThreats(agreement *TradeAgreement, t
 				ThreatType:  "nearby_conflict",
 				Severity:    conflict.Intensity * 0.3, // Conflicts reduce trade efficiency
 				Position:    Position{X: 0, Y: 0},     // Could be more specific with actual positions
-				Duration:    20 + rand.Intn(30),       // 20-50 tick threat duration
+				Duration:    20 + cws.rng.Intn(30),    // 20-50 tick threat duration
 				Description: fmt.Sprintf("Trade route threatened by %s conflict", conflict.ConflictType.String()),
 			}
 
@@ -1782,12 +1800,12 @@ func (cws *ColonyWarfareSystem) addProximityThreats(agreement *TradeAgreement, t
 	}
 
 	// Add random raider threats occasionally
-	if rand.Float64() < 0.05 { // 5% chance per trade cycle
+	if cws.rng.Float64() < 0.05 { // 5% chance per trade cycle
 		threat := TradeThreat{
 			ThreatType:  "raiders",
-			Severity:    0.1 + rand.Float64()*0.3, // 10-40% trade loss
-			Position:    Position{X: float64(rand.Intn(100)), Y: float64(rand.Intn(100))},
-			Duration:    10 + rand.Intn(20), // 10-30 tick duration
+			Severity:    0.1 + cws.rng.Float64()*0.3, // 10-40% trade loss
+			Position:    Position{X: float64(cws.rng.Intn(100)), Y: float64(cws.rng.Intn(100))},
+			Duration:    10 + cws.rng.Intn(20), // 10-30 tick duration
 			Description: "Raider activity reported along trade route",
 		}
 		agreement.RouteThreats = append(agreement.RouteThreats, threat)
@@ -0,0 +1,391 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// cliCommand is an evosim subcommand, or a group of them
+type cliCommand struct {
+	Name        string
+	Usage       string // Options and arguments after the command's path
+	Summary     string
+	Run         func(args []string) error
+	Help        func(w io.Writer) // Longer help printed after the options, if any
+	Subcommands []*cliCommand
+}
+
+// evosimCommands is the command tree. Each command parses its own flags, so modes with
+// options of the same name no longer share a namespace.
+func evosimCommands() []*cliCommand {
+	return []*cliCommand{
+		{Name: "run", Usage: "[options]", Summary: "Simulate a world in the terminal, the browser (--web, --iso) or headlessly (--headless)",
+			Run: runRunCommand, Help: printRunHelp},
+		{Name: "serve", Usage: "[--port N] [--data dir]", Summary: "Host many named worlds in one process with a lobby and autosaves",
+			Run: runServeCommand},
+		{Name: "classroom", Usage: "--students a,b,... [--template config.json] [--port N] [--data dir]", Summary: "Give each student a sandbox world from a template, with a teacher dashboard to watch, pause, message and grade them",
+			Run: runClassroomCommand},
+		{Name: "lint", Usage: "[--kind kind] [--strict] [--format text|json] <file> ...", Summary: "Check run configs, custom traits, creature files and scenario saves for mistakes before a run starts", Run: runLintCommand},
+		{Name: "analyze", Summary: "Inspect saves, run certificates and tunings", Subcommands: []*cliCommand{
+			{Name: "diff", Usage: "<save-a.json> <save-b.json>", Summary: "Compare two saves: populations, traits, geography and tech", Run: runDiffCommand},
+			{Name: "validate", Usage: "[--repair] [--out file] <save.json>", Summary: "Check a save for corruption and optionally repair it", Run: runValidateCommand},
+			{Name: "migrate", Usage: "[--format json|binary] [--out file] <save>", Summary: "Upgrade a save written by an older version to the current save format, or convert it between JSON and binary", Run: runMigrateCommand},
+			{Name: "certificate", Usage: "[--no-replay] <certificate or export .json>", Summary: "Check a run certificate's hash chain and re-simulate its run", Run: runCertificateCommand},
+			{Name: "timescales", Usage: "[--profile name] [--scale group=factor ...]", Summary: "Audit every time constant of a tuning profile and try rescaling groups", Run: runTimescalesCommand},
+		}},
+		{Name: "export", Summary: "Write data from a save, or the API specs and clients", Subcommands: []*cliCommand{
+			{Name: "geojson", Usage: "[--layer name] [--out file] <save.json>", Summary: "Geography and species ranges as GeoJSON", Run: runGeoJSONExportCommand},
+			{Name: "occurrences", Usage: "[--plants] [--format csv|json] [--out file] <save.json>", Summary: "Occurrence records in Darwin Core", Run: runOccurrencesExportCommand},
+			{Name: "sdk", Usage: "[--out dir]", Summary: "The OpenAPI and AsyncAPI specs with generated Go and TypeScript clients", Run: runSDKCommand},
+		}},
+		{Name: "experiment", Summary: "Run controlled experiments on the simulation", Subcommands: []*cliCommand{
+			{Name: "tournament", Usage: "[--bouts N] [--ticks N] [--founders N] [--seed N] [--out file] <a.creature.json> <b.creature.json> ...", Summary: "Pit exported species against each other and rank them", Run: runTournamentCommand},
+			{Name: "determinism", Usage: "[world options] [--seed N] [--ticks N] [--parallel]", Summary: "Simulate a seed twice and report where the runs diverge", Run: runDeterminismCommand},
+		}},
+	}
+}
+
+// cliAliases keeps the commands that used to sit at the top level working
+var cliAliases = map[string][]string{
+	"diff":        {"analyze", "diff"},
+	"validate":    {"analyze", "validate"},
+	"certificate": {"analyze", "certificate"},
+	"timescales":  {"analyze", "timescales"},
+	"tournament":  {"experiment", "tournament"},
+	"sdk":         {"export", "sdk"},
+}
+
+// resolveCommand finds the command the arguments name, returning it with its path and the
+// arguments left for it. Arguments starting with a flag run a simulation, as before there
+// were subcommands. A group comes back without a subcommand when none is named.
+func resolveCommand(args []string) (*cliCommand, []string, []string, error) {
+	commands := evosimCommands()
+	if len(args) == 0 || (strings.HasPrefix(args[0], "-") && !isHelpFlag(args[0])) {
+		if rest, legacy := legacyDeterminismArgs(args); legacy {
+			command, path := commandPath(commands, "determinism")
+			return command, path, rest, nil
+		}
+		return commands[0], []string{"run"}, args, nil
+	}
+	if alias, exists := cliAliases[args[0]]; exists {
+		args = append(append([]string{}, alias...), args[1:]...)
+	}
+
+	var command *cliCommand
+	path := make([]string, 0, 2)
+	for len(args) > 0 {
+		next := findCommand(commands, args[0])
+		if next == nil {
+			if command == nil {
+				return nil, nil, nil, fmt.Errorf("unknown command %q; run evosim help for the commands", args[0])
+			}
+			if command.Run == nil && !strings.HasPrefix(args[0], "-") {
+				return nil, nil, nil, fmt.Errorf("unknown command %q; run evosim help %s for its commands", args[0], strings.Join(path, " "))
+			}
+			break
+		}
+		command, commands = next, next.Subcommands
+		path = append(path, next.Name)
+		args = args[1:]
+	}
+	return command, path, args, nil
+}
+
+// legacyDeterminismArgs turns the --verify-determinism run options of old into those of
+// experiment determinism, reporting whether the arguments asked for the check
+func legacyDeterminismArgs(args []string) ([]string, bool) {
+	rest := make([]string, 0, len(args))
+	verify := false
+	for _, arg := range args {
+		name, value, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		switch {
+		case name == "verify-determinism" && value != "false":
+			verify = true
+		case name == "verify-ticks":
+			rest = append(rest, strings.Replace(arg, "verify-ticks", "ticks", 1))
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest, verify
+}
+
+func findCommand(commands []*cliCommand, name string) *cliCommand {
+	for _, command := range commands {
+		if command.Name == name {
+			return command
+		}
+	}
+	return nil
+}
+
+// commandPath finds a command anywhere in the tree by name, which is unique
+func commandPath(commands []*cliCommand, name string) (*cliCommand, []string) {
+	for _, command := range commands {
+		if command.Name == name {
+			return command, []string{command.Name}
+		}
+		if found, path := commandPath(command.Subcommands, name); found != nil {
+			return found, append([]string{command.Name}, path...)
+		}
+	}
+	return nil, nil
+}
+
+func isHelpFlag(arg string) bool {
+	return arg == "-h" || arg == "--h" || arg == "-help" || arg == "--help"
+}
+
+// runCommandLine runs the command named by the arguments
+func runCommandLine(args []string) error {
+	if len(args) > 0 && (args[0] == "version" || args[0] == "--version" || args[0] == "-version") {
+		printVersion(os.Stdout)
+		return nil
+	}
+	if len(args) > 0 && (args[0] == "help" || isHelpFlag(args[0])) {
+		if len(args) == 1 {
+			printOverview(os.Stdout)
+			return nil
+		}
+		args = append(args[1:], "--help")
+	}
+
+	command, path, rest, err := resolveCommand(args)
+	if err != nil {
+		return err
+	}
+	if command.Run == nil {
+		printCommandHelp(os.Stdout, command, path, nil)
+		return nil
+	}
+	if err := command.Run(rest); !errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return nil
+}
+
+// newCommandFlags creates a command's flag set, whose --help prints the command's usage,
+// summary and options
+func newCommandFlags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	command, path := commandPath(evosimCommands(), name)
+	fs.Usage = func() { printCommandHelp(fs.Output(), command, path, fs) }
+	return fs
+}
+
+// commandUsage is the usage line of a command, reported when its arguments are wrong
+func commandUsage(name string) error {
+	command, path := commandPath(evosimCommands(), name)
+	return fmt.Errorf("usage: evosim %s %s", strings.Join(path, " "), command.Usage)
+}
+
+// printCommandHelp prints a command's help: its usage and options for a command, its
+// subcommands for a group and every command for none
+func printCommandHelp(w io.Writer, command *cliCommand, path []string, fs *flag.FlagSet) {
+	if command == nil {
+		printOverview(w)
+		return
+	}
+	name := "evosim " + strings.Join(path, " ")
+	fmt.Fprintln(w, command.Summary)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Usage:")
+	if command.Run == nil {
+		fmt.Fprintf(w, "  %s <command> [options]\n\n", name)
+		fmt.Fprintln(w, "Commands:")
+		printCommandList(w, command.Subcommands, "")
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Run \"%s <command> --help\" for a command's options.\n", name)
+		return
+	}
+	fmt.Fprintf(w, "  %s %s\n", name, command.Usage)
+	if fs != nil {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Options:")
+		fs.PrintDefaults()
+	}
+	if command.Help != nil {
+		fmt.Fprintln(w)
+		command.Help(w)
+	}
+}
+
+func printCommandList(w io.Writer, commands []*cliCommand, prefix string) {
+	for _, command := range commands {
+		fmt.Fprintf(w, "  %-24s %s\n", prefix+command.Name, command.Summary)
+		printCommandList(w, command.Subcommands, prefix+command.Name+" ")
+	}
+}
+
+// printOverview prints what evosim does and its commands
+func printOverview(w io.Writer) {
+	fmt.Fprintln(w, "Genetic Ecosystem Simulation")
+	fmt.Fprintln(w, "============================")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "A genetic algorithm simulation featuring a complete ecosystem with:")
+	fmt.Fprintln(w, "• Multiple species (herbivores, predators, omnivores)")
+	fmt.Fprintln(w, "• Primitive life form evolution from simple organisms")
+	fmt.Fprintln(w, "• Environment-specific adaptations (aquatic, soil, aerial)")
+	fmt.Fprintln(w, "• Plant life system with 6 plant types")
+	fmt.Fprintln(w, "• Dynamic biomes and world events")
+	fmt.Fprintln(w, "• Evolutionary pressure and species adaptation")
+	fmt.Fprintln(w, "• Event logging system")
+	fmt.Fprintln(w, "• Tool creation and environmental modification")
+	fmt.Fprintln(w, "• Emergent behavior discovery and social learning")
+	fmt.Fprintln(w, "• Web interface with real-time visualization")
+	fmt.Fprintln(w, "• 2.5D isometric game view with interactive features")
+	fmt.Fprintln(w, "• State persistence for save/load functionality")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  evosim <command> [options]")
+	fmt.Fprintln(w, "  evosim [options]           Same as evosim run [options]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	printCommandList(w, evosimCommands(), "")
+	fmt.Fprintf(w, "  %-24s %s\n", "help [command]", "Show a command's options")
+	fmt.Fprintf(w, "  %-24s %s\n", "version", "Show version information")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run \"evosim <command> --help\" for a command's options. The older top-level")
+	fmt.Fprintln(w, "commands diff, validate, certificate, timescales, tournament and sdk still work.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Environment:")
+	fmt.Fprintln(w, "  Every option can also be set from an environment variable named EVOSIM_ and the")
+	fmt.Fprintln(w, "  flag in capitals with dashes as underscores, e.g. EVOSIM_WEB=true EVOSIM_WEB_PORT=9000")
+	fmt.Fprintln(w, "  for run. Other commands read EVOSIM_<COMMAND>_<FLAG>, e.g. EVOSIM_SERVE_DATA=/data.")
+	fmt.Fprintln(w, "  Repeatable flags such as --break take a comma-separated list. Flags on the command")
+	fmt.Fprintln(w, "  line win over the environment, which wins over --config")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Containers:")
+	fmt.Fprintln(w, "  The web interface and the world server answer liveness probes at /healthz and")
+	fmt.Fprintln(w, "  readiness probes at /readyz (503 while starting or shutting down), stop cleanly on")
+	fmt.Fprintln(w, "  SIGTERM and log to stderr, keeping stdout for reports and the headless summary")
+}
+
+// printVersion prints the version and what it brings
+func printVersion(w io.Writer) {
+	fmt.Fprintln(w, "Genetic Ecosystem Simulation v2.0")
+	fmt.Fprintln(w, "Enhanced with:")
+	fmt.Fprintln(w, "• Plant life, event logging, and evolutionary pressure")
+	fmt.Fprintln(w, "• Tool creation and environmental modification systems")
+	fmt.Fprintln(w, "• Emergent behavior discovery and social learning")
+	fmt.Fprintln(w, "• Web interface with real-time visualization")
+	fmt.Fprintln(w, "• Complete state persistence functionality")
+	fmt.Fprintln(w, "• Underground plant networks and wind dispersal")
+	fmt.Fprintln(w, "• DNA/RNA genetic systems and cellular evolution")
+	fmt.Fprintln(w, "• Species formation and macro evolution tracking")
+}
+
+// worldFlags are the options describing the world to build, shared by the commands that
+// simulate one
+type worldFlags struct {
+	width, height                   *float64
+	gridWidth, gridHeight, popSize  *int
+	profile, traitsFile, configFile *string
+	geometry, gridShape             *string
+	fogOfWar, primitive             *bool
+}
+
+func addWorldFlags(fs *flag.FlagSet) *worldFlags {
+	return &worldFlags{
+		width:      fs.Float64("width", 100.0, "World width"),
+		height:     fs.Float64("height", 100.0, "World height"),
+		gridWidth:  fs.Int("grid-width", 40, "Grid cells width for visualization"),
+		gridHeight: fs.Int("grid-height", 25, "Grid cells height for visualization"),
+		popSize:    fs.Int("pop-size", 20, "Population size per species"),
+		profile:    fs.String("profile", "standard", "Tuning profile for lifespans, event durations, mutation, gestation, decay and seasons: "+strings.Join(TuningProfileNames(), ", ")),
+		traitsFile: fs.String("traits", "", "JSON file of custom trait definitions to evolve alongside the built-in traits"),
+		geometry:   fs.String("geometry", GeometryBox, "Which edges of the map join: "+strings.Join(WorldGeometryNames(), ", ")),
+		gridShape:  fs.String("grid-shape", GridShapeSquare, "Shape of the grid cells: "+strings.Join(GridShapeNames(), ", ")),
+		configFile: fs.String("config", "", "JSON run config with populations, world size, simulation settings, an event timetable and speed (flags override it)"),
+		fogOfWar:   fs.Bool("fog-of-war", false, "Only show players the parts of the map their species can perceive or have explored"),
+		primitive:  fs.Bool("primitive", false, "Start with primitive life forms that can evolve into complex species"),
+	}
+}
+
+// parseCommandFlags parses a command's arguments, then fills the flags not given from the
+// environment and, when the command has world flags and --config names one, the run config.
+// It returns the run config, or nil without one.
+func parseCommandFlags(fs *flag.FlagSet, args []string, envPrefix string, world *worldFlags) (*RunConfig, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if err := applyEnvironment(fs, envPrefix); err != nil {
+		return nil, err
+	}
+	if world == nil || *world.configFile == "" {
+		return nil, nil
+	}
+	runConfig, err := LoadRunConfig(*world.configFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := runConfig.ApplyToFlags(fs); err != nil {
+		return nil, err
+	}
+	return runConfig, nil
+}
+
+// WorldConfig builds the configuration of the world the flags describe, with the run
+// config's simulation settings and event timetable
+func (wf *worldFlags) WorldConfig(runConfig *RunConfig) (WorldConfig, error) {
+	if _, err := FindTuningProfile(*wf.profile); err != nil {
+		return WorldConfig{}, err
+	}
+	if err := ValidateGeometry(*wf.geometry); err != nil {
+		return WorldConfig{}, err
+	}
+	if err := ValidateGridShape(*wf.gridShape); err != nil {
+		return WorldConfig{}, err
+	}
+	var customTraits []CustomTraitDefinition
+	if *wf.traitsFile != "" {
+		var err error
+		if customTraits, err = LoadCustomTraits(*wf.traitsFile); err != nil {
+			return WorldConfig{}, err
+		}
+	}
+
+	config := WorldConfig{
+		Width:          *wf.width,
+		Height:         *wf.height,
+		NumPopulations: 3,
+		PopulationSize: *wf.popSize,
+		GridWidth:      *wf.gridWidth,
+		GridHeight:     *wf.gridHeight,
+		FogOfWar:       *wf.fogOfWar,
+		Profile:        *wf.profile,
+		CustomTraits:   customTraits,
+		Geometry:       *wf.geometry,
+		GridShape:      *wf.gridShape,
+	}
+	if runConfig != nil {
+		if err := runConfig.ApplyToWorld(&config); err != nil {
+			return WorldConfig{}, err
+		}
+	}
+	return config, nil
+}
+
+// writeCommandOutput writes a command's output to a file, or to stdout when none is given
+func writeCommandOutput(filename string, write func(w io.Writer) error) error {
+	if filename == "" {
+		return write(os.Stdout)
+	}
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", filename)
+	return nil
+}
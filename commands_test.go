@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResolveCommand(t *testing.T) {
+	cases := []struct {
+		args []string
+		path string
+		rest []string
+	}{
+		{nil, "run", nil},
+		{[]string{"--web", "--web-port", "9000"}, "run", []string{"--web", "--web-port", "9000"}},
+		{[]string{"run", "--headless"}, "run", []string{"--headless"}},
+		{[]string{"analyze", "diff", "a.json", "b.json"}, "analyze diff", []string{"a.json", "b.json"}},
+		{[]string{"validate", "--repair", "save.json"}, "analyze validate", []string{"--repair", "save.json"}},
+		{[]string{"sdk"}, "export sdk", []string{}},
+		{[]string{"export"}, "export", []string{}},
+		{[]string{"--verify-determinism", "--verify-ticks=10", "--seed", "3"}, "experiment determinism", []string{"--ticks=10", "--seed", "3"}},
+	}
+	for _, c := range cases {
+		command, path, rest, err := resolveCommand(c.args)
+		if err != nil || command == nil {
+			t.Errorf("%v: expected %s, got %v", c.args, c.path, err)
+			continue
+		}
+		if strings.Join(path, " ") != c.path || (len(rest) > 0 || len(c.rest) > 0) && !slices.Equal(rest, c.rest) {
+			t.Errorf("%v: expected %s %v, got %v %v", c.args, c.path, c.rest, path, rest)
+		}
+	}
+
+	for _, args := range [][]string{{"bogus"}, {"analyze", "nope"}} {
+		if _, _, _, err := resolveCommand(args); err == nil {
+			t.Errorf("%v: expected an unknown command refused", args)
+		}
+	}
+	if err := runDiffCommand([]string{"one.json"}); err == nil || !strings.Contains(err.Error(), "evosim analyze diff") {
+		t.Errorf("Expected the usage of the command's new path, got %v", err)
+	}
+	if err := runDeterminismCommand([]string{"--help"}); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("Expected each command to have its own help, got %v", err)
+	}
+}
+
+func TestExportCommandsReadSaves(t *testing.T) {
+	dir := t.TempDir()
+	save := filepath.Join(dir, "save.json")
+	if err := NewStateManager(newGraphQLTestWorld()).SaveToFile(save); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+
+	geojson := filepath.Join(dir, "biomes.geojson")
+	if err := runGeoJSONExportCommand([]string{"--layer", "biomes", "--out", geojson, save}); err != nil {
+		t.Fatalf("Failed to export the biomes: %v", err)
+	}
+	if data, _ := os.ReadFile(geojson); !strings.Contains(string(data), "FeatureCollection") {
+		t.Errorf("Expected a feature collection, got %.100s", data)
+	}
+
+	occurrences := filepath.Join(dir, "occurrences.csv")
+	if err := runOccurrencesExportCommand([]string{"--out", occurrences, save}); err != nil {
+		t.Fatalf("Failed to export occurrences: %v", err)
+	}
+	if data, _ := os.ReadFile(occurrences); strings.Count(string(data), "\n") < 2 {
+		t.Errorf("Expected a record per creature, got %s", data)
+	}
+	if err := runGeoJSONExportCommand([]string{"--layer", "oceans", save}); err == nil {
+		t.Errorf("Expected an unknown layer refused")
+	}
+}
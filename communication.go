@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"math"
 	"math/rand"
 )
 
@@ -34,6 +33,8 @@ type CommunicationSystem struct {
 	Signals    []Signal
 	MaxSignals int
 	EventBus   *CentralEventBus // For event tracking
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewCommunicationSystem creates a new communication system
@@ -42,6 +43,7 @@ func NewCommunicationSystem(eventBus *CentralEventBus) *CommunicationSystem {
 		Signals:    make([]Signal, 0),
 		MaxSignals: 100, // Limit active signals
 		EventBus:   eventBus,
+		rng:        sharedRand,
 	}
 }
 
@@ -93,7 +95,7 @@ func (cs *CommunicationSystem) SendSignal(entity *Entity, signalType SignalType,
 }
 
 // ReceiveSignals allows an entity to detect and respond to nearby signals
-func (cs *CommunicationSystem) ReceiveSignals(entity *Entity, tick int) []Signal {
+func (cs *CommunicationSystem) ReceiveSignals(entity *Entity, tick int, geometry WorldGeometry) []Signal {
 	var receivedSignals []Signal
 	intelligence := entity.GetTrait("intelligence")
 	cooperation := entity.GetTrait("cooperation")
@@ -104,15 +106,13 @@ func (cs *CommunicationSystem) ReceiveSignals(entity *Entity, tick int) []Signal
 	}
 
 	for _, signal := range cs.Signals {
-		dx := entity.Position.X - signal.Position.X
-		dy := entity.Position.Y - signal.Position.Y
-		distance := math.Sqrt(dx*dx + dy*dy)
+		distance := geometry.Distance(entity.Position, signal.Position)
 
 		// Check if entity is in range and can understand the signal
 		if distance <= signal.Range && signal.Strength > 0.1 {
 			// Cooperative entities are better at understanding signals
 			comprehension := intelligence*0.7 + cooperation*0.3
-			if rand.Float64() < comprehension {
+			if cs.rng.Float64() < comprehension {
 				receivedSignals = append(receivedSignals, signal)
 
 				// Emit event for signal reception
@@ -251,7 +251,7 @@ func (gbs *GroupBehaviorSystem) FormGroup(entities []*Entity, purpose string, ti
 }
 
 // UpdateGroups maintains group integrity and behavior
-func (gbs *GroupBehaviorSystem) UpdateGroups(tick int) {
+func (gbs *GroupBehaviorSystem) UpdateGroups(tick int, geometry WorldGeometry) {
 	activeGroups := make([]*Group, 0)
 
 	for _, group := range gbs.Groups {
@@ -335,7 +335,7 @@ func (gbs *GroupBehaviorSystem) UpdateGroups(tick int) {
 		case "hunting":
 			gbs.coordinateHunting(group, tick)
 		case "migration":
-			gbs.coordinateMigration(group, tick)
+			gbs.coordinateMigration(group, tick, geometry)
 		case "territory":
 			gbs.defendTerritory(group, tick)
 		}
@@ -378,7 +378,7 @@ func (gbs *GroupBehaviorSystem) coordinateHunting(group *Group, tick int) {
 }
 
 // coordinateMigration makes group move together toward better biomes
-func (gbs *GroupBehaviorSystem) coordinateMigration(group *Group, tick int) {
+func (gbs *GroupBehaviorSystem) coordinateMigration(group *Group, tick int, geometry WorldGeometry) {
 	if group.Leader == nil || !group.Leader.IsAlive {
 		return
 	}
@@ -394,7 +394,7 @@ func (gbs *GroupBehaviorSystem) coordinateMigration(group *Group, tick int) {
 
 		// Move toward leader position
 		speed := member.GetTrait("speed") * 0.5 // Slower when in group
-		member.MoveTo(leaderPos.X, leaderPos.Y, speed)
+		member.MoveTo(leaderPos.X, leaderPos.Y, speed, geometry)
 		membersFollowing++
 	}
 
@@ -0,0 +1,503 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Victory conditions of a competitive game
+const (
+	VictoryPopulation = "population" // A player's species make up a share of all living creatures
+	VictoryTech       = "tech"       // A tribe led by a player's species reaches a tech level
+	VictoryTerritory  = "territory"  // A player's creatures occupy a share of the grid cells
+	VictorySurvival   = "survival"   // Most creatures left after a cataclysm wins
+)
+
+// Competitive game statuses
+const (
+	GameRunning  = "running"
+	GameFinished = "finished"
+	GameAborted  = "aborted"
+)
+
+// Competitive game defaults and tuning
+const (
+	defaultGameTimeLimit      = 3000 // Ticks before the best score wins
+	defaultCataclysmDelay     = 200  // Ticks from the start of a survival game to its cataclysm
+	cataclysmDuration         = 60   // Ticks the cataclysm lasts
+	cataclysmDamage           = 1.5  // Energy every creature loses per cataclysm tick
+	survivalGraceTicks        = 100  // Ticks after the cataclysm before survivors are counted
+	rematchPairsPerSpecies    = 3    // Breeding pairs of each species kept for a rematch
+	minCompetitivePlayerCount = 2
+)
+
+// defaultVictoryTargets are the scores that win each condition
+var defaultVictoryTargets = map[string]float64{
+	VictoryPopulation: 0.6,
+	VictoryTech:       5,
+	VictoryTerritory:  0.3,
+	VictorySurvival:   0,
+}
+
+// GameSettings choose how a competitive game is won
+type GameSettings struct {
+	Condition      string  `json:"condition"`
+	Target         float64 `json:"target"`          // Score that wins; 0 uses the condition's default
+	TimeLimit      int     `json:"time_limit"`      // Ticks before the best score wins; 0 uses the default
+	CataclysmDelay int     `json:"cataclysm_delay"` // Survival only: ticks until the cataclysm; 0 uses the default
+}
+
+// GamePlayer is a player taking part in a competitive game
+type GamePlayer struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Species []string `json:"species"`
+}
+
+// PlayerStanding is a player's progress toward the victory condition
+type PlayerStanding struct {
+	PlayerID       string   `json:"player_id"`
+	Name           string   `json:"name"`
+	Species        []string `json:"species"`
+	Score          float64  `json:"score"`
+	Population     int      `json:"population"`
+	PeakPopulation int      `json:"peak_population"`
+	Territory      float64  `json:"territory"` // Share of grid cells holding the player's creatures
+	TechLevel      int      `json:"tech_level"`
+	Eliminated     bool     `json:"eliminated"`
+	EliminatedTick int      `json:"eliminated_tick,omitempty"`
+}
+
+// CompetitiveGame is one match between players' species. Once finished it doubles as the
+// end-of-game summary.
+type CompetitiveGame struct {
+	ID            int              `json:"id"`
+	Settings      GameSettings     `json:"settings"`
+	Status        string           `json:"status"`
+	StartTick     int              `json:"start_tick"`
+	EndTick       int              `json:"end_tick,omitempty"`
+	CataclysmTick int              `json:"cataclysm_tick,omitempty"`
+	Winner        string           `json:"winner,omitempty"` // Player ID; empty on a draw
+	Reason        string           `json:"reason,omitempty"`
+	Standings     []PlayerStanding `json:"standings"` // Best first once the game is over
+
+	players []GamePlayer
+	evolved map[string][]*CreatureFile // Player ID -> evolved breeding pairs kept for a rematch
+}
+
+// CompetitiveGameSystem runs at most one competitive game at a time and remembers the last
+// one so it can be reviewed and rematched
+type CompetitiveGameSystem struct {
+	mu       sync.Mutex
+	game     *CompetitiveGame
+	nextID   int
+	eventBus *CentralEventBus
+}
+
+// NewCompetitiveGameSystem creates a game system with no game played yet
+func NewCompetitiveGameSystem(eventBus *CentralEventBus) *CompetitiveGameSystem {
+	return &CompetitiveGameSystem{nextID: 1, eventBus: eventBus}
+}
+
+// Start begins a game between players, filling in defaults for unset settings
+func (gs *CompetitiveGameSystem) Start(w *World, settings GameSettings, players []GamePlayer) (CompetitiveGame, error) {
+	target, known := defaultVictoryTargets[settings.Condition]
+	if !known {
+		return CompetitiveGame{}, fmt.Errorf("unknown victory condition %q (use population, tech, territory or survival)", settings.Condition)
+	}
+	if settings.Target < 0 || settings.TimeLimit < 0 || settings.CataclysmDelay < 0 || isInvalidNumber(settings.Target) {
+		return CompetitiveGame{}, fmt.Errorf("game settings cannot be negative")
+	}
+	if settings.Condition == VictoryPopulation || settings.Condition == VictoryTerritory {
+		if settings.Target > 1 {
+			return CompetitiveGame{}, fmt.Errorf("%s target is a share between 0 and 1", settings.Condition)
+		}
+	}
+	if settings.Target == 0 {
+		settings.Target = target
+	}
+	if settings.TimeLimit == 0 {
+		settings.TimeLimit = defaultGameTimeLimit
+	}
+	if settings.Condition == VictorySurvival {
+		if settings.CataclysmDelay == 0 {
+			settings.CataclysmDelay = defaultCataclysmDelay
+		}
+	} else {
+		settings.CataclysmDelay = 0
+	}
+
+	competing := make([]GamePlayer, 0, len(players))
+	for _, player := range players {
+		if len(player.Species) > 0 {
+			competing = append(competing, GamePlayer{ID: player.ID, Name: player.Name, Species: append([]string(nil), player.Species...)})
+		}
+	}
+	if len(competing) < minCompetitivePlayerCount {
+		return CompetitiveGame{}, fmt.Errorf("a competitive game needs at least %d players with species, got %d", minCompetitivePlayerCount, len(competing))
+	}
+	sort.Slice(competing, func(i, j int) bool {
+		return competing[i].ID < competing[j].ID
+	})
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	if gs.game != nil && gs.game.Status == GameRunning {
+		return CompetitiveGame{}, fmt.Errorf("game %d is still running", gs.game.ID)
+	}
+	game := &CompetitiveGame{
+		ID:        gs.nextID,
+		Settings:  settings,
+		Status:    GameRunning,
+		StartTick: w.Tick,
+		players:   competing,
+	}
+	if settings.Condition == VictorySurvival {
+		game.CataclysmTick = w.Tick + settings.CataclysmDelay
+	}
+	gs.nextID++
+	gs.game = game
+	game.Standings = gs.standings(w, game)
+
+	names := make([]string, 0, len(competing))
+	for _, player := range competing {
+		names = append(names, player.Name)
+	}
+	gs.emit(w, "game_started", fmt.Sprintf("Competitive game %d started: %s victory between %s",
+		game.ID, settings.Condition, strings.Join(names, ", ")), game)
+	return game.snapshot(), nil
+}
+
+// Rematch starts a new game with the settings and players of the last finished one,
+// bringing back each player's evolved breeding pairs. The caller is expected to have
+// reset the world first.
+func (gs *CompetitiveGameSystem) Rematch(w *World) (CompetitiveGame, error) {
+	gs.mu.Lock()
+	last := gs.game
+	gs.mu.Unlock()
+	if last == nil || last.Status == GameRunning {
+		return CompetitiveGame{}, fmt.Errorf("there is no finished game to rematch")
+	}
+
+	// Spread the players evenly around the middle of the world
+	for i, player := range last.players {
+		angle := 2 * math.Pi * float64(i) / float64(len(last.players))
+		radius := math.Min(w.Config.Width, w.Config.Height) * 0.3
+		home := Position{
+			X: w.Config.Width/2 + math.Cos(angle)*radius,
+			Y: w.Config.Height/2 + math.Sin(angle)*radius,
+		}
+		for j, file := range last.evolved[player.ID] {
+			pos := Position{X: home.X, Y: home.Y + float64(j)*2}
+			if _, err := w.ImportCreatureFile(file, pos); err != nil {
+				return CompetitiveGame{}, fmt.Errorf("failed to bring back %s's species: %v", player.Name, err)
+			}
+		}
+	}
+	return gs.Start(w, last.Settings, last.players)
+}
+
+// Abort ends a running game without a winner
+func (gs *CompetitiveGameSystem) Abort(w *World) bool {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	if gs.game == nil || gs.game.Status != GameRunning {
+		return false
+	}
+	gs.game.Status = GameAborted
+	gs.game.EndTick = w.Tick
+	gs.game.Reason = "aborted"
+	gs.emit(w, "game_aborted", fmt.Sprintf("Competitive game %d was aborted", gs.game.ID), gs.game)
+	return true
+}
+
+// Current returns the running game, or the last one played
+func (gs *CompetitiveGameSystem) Current() (CompetitiveGame, bool) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	if gs.game == nil {
+		return CompetitiveGame{}, false
+	}
+	return gs.game.snapshot(), true
+}
+
+// AddSpecies counts a new species (e.g. a subspecies) toward a player in the running game
+func (gs *CompetitiveGameSystem) AddSpecies(playerID, species string) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	if gs.game == nil || gs.game.Status != GameRunning {
+		return
+	}
+	for i := range gs.game.players {
+		if gs.game.players[i].ID == playerID {
+			gs.game.players[i].Species = append(gs.game.players[i].Species, species)
+		}
+	}
+}
+
+// Update scores the running game, runs its cataclysm and ends it once someone has won
+func (gs *CompetitiveGameSystem) Update(w *World) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	game := gs.game
+	if game == nil || game.Status != GameRunning {
+		return
+	}
+
+	if game.CataclysmTick > 0 {
+		if w.Tick == game.CataclysmTick {
+			w.Events = append(w.Events, &WorldEvent{
+				Name:           "Cataclysm",
+				Description:    "A cataclysm tests which species can survive",
+				Duration:       cataclysmDuration,
+				GlobalMutation: 0.2,
+				GlobalDamage:   cataclysmDamage,
+			})
+			gs.emit(w, "cataclysm", "A cataclysm strikes the competing species", game)
+		}
+		if w.Tick >= game.CataclysmTick && w.Tick < game.CataclysmTick+cataclysmDuration {
+			for _, entity := range w.AllEntities {
+				if entity.IsAlive {
+					entity.Energy -= cataclysmDamage
+				}
+			}
+		}
+	}
+
+	game.Standings = gs.standings(w, game)
+	winner, reason, over := gs.checkVictory(w, game)
+	if !over {
+		return
+	}
+
+	game.Status = GameFinished
+	game.EndTick = w.Tick
+	game.Winner = winner
+	game.Reason = reason
+	sort.SliceStable(game.Standings, func(i, j int) bool {
+		a, b := game.Standings[i], game.Standings[j]
+		if a.PlayerID == winner || b.PlayerID == winner {
+			return a.PlayerID == winner
+		}
+		if a.Eliminated != b.Eliminated {
+			return !a.Eliminated
+		}
+		return a.Score > b.Score
+	})
+	game.evolved = gs.keepEvolvedSpecies(w, game)
+
+	description := fmt.Sprintf("Competitive game %d ended in a draw: %s", game.ID, reason)
+	if winner != "" {
+		description = fmt.Sprintf("Competitive game %d won by %s: %s", game.ID, game.Standings[0].Name, reason)
+	}
+	gs.emit(w, "game_over", description, game)
+}
+
+// standings measures every player's population, territory and tech level and scores them
+// by the game's victory condition
+func (gs *CompetitiveGameSystem) standings(w *World, game *CompetitiveGame) []PlayerStanding {
+	owners := make(map[string]int)
+	for i, player := range game.players {
+		for _, species := range player.Species {
+			owners[species] = i
+		}
+	}
+	previous := make(map[string]PlayerStanding, len(game.Standings))
+	for _, standing := range game.Standings {
+		previous[standing.PlayerID] = standing
+	}
+
+	populations := make([]int, len(game.players))
+	cells := make([]map[GridPoint]bool, len(game.players))
+	for i := range cells {
+		cells[i] = make(map[GridPoint]bool)
+	}
+	totalAlive := 0
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		totalAlive++
+		owner, owned := owners[entity.Species]
+		if !owned {
+			continue
+		}
+		populations[owner]++
+		x, y := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
+		cells[owner][GridPoint{X: x, Y: y}] = true
+	}
+
+	techLevels := make([]int, len(game.players))
+	if w.CivilizationSystem != nil {
+		for _, tribe := range w.CivilizationSystem.Tribes {
+			if tribe.Leader == nil {
+				continue
+			}
+			if owner, owned := owners[tribe.Leader.Species]; owned && tribe.TechLevel > techLevels[owner] {
+				techLevels[owner] = tribe.TechLevel
+			}
+		}
+	}
+
+	totalCells := float64(w.Config.GridWidth * w.Config.GridHeight)
+	standings := make([]PlayerStanding, 0, len(game.players))
+	for i, player := range game.players {
+		standing := PlayerStanding{
+			PlayerID:       player.ID,
+			Name:           player.Name,
+			Species:        player.Species,
+			Population:     populations[i],
+			PeakPopulation: populations[i],
+			TechLevel:      techLevels[i],
+		}
+		if totalCells > 0 {
+			standing.Territory = float64(len(cells[i])) / totalCells
+		}
+		if last, exists := previous[player.ID]; exists {
+			standing.PeakPopulation = max(standing.PeakPopulation, last.PeakPopulation)
+			standing.Eliminated = last.Eliminated
+			standing.EliminatedTick = last.EliminatedTick
+		}
+		if !standing.Eliminated && standing.Population == 0 && w.Tick > game.StartTick {
+			standing.Eliminated = true
+			standing.EliminatedTick = w.Tick
+		}
+
+		switch game.Settings.Condition {
+		case VictoryPopulation:
+			if totalAlive > 0 {
+				standing.Score = float64(standing.Population) / float64(totalAlive)
+			}
+		case VictoryTech:
+			standing.Score = float64(standing.TechLevel)
+		case VictoryTerritory:
+			standing.Score = standing.Territory
+		case VictorySurvival:
+			standing.Score = float64(standing.Population)
+		}
+		standings = append(standings, standing)
+	}
+	return standings
+}
+
+// checkVictory decides whether the game is over, returning the winner ("" on a draw)
+func (gs *CompetitiveGameSystem) checkVictory(w *World, game *CompetitiveGame) (string, string, bool) {
+	var alive []PlayerStanding
+	for _, standing := range game.Standings {
+		if !standing.Eliminated {
+			alive = append(alive, standing)
+		}
+	}
+	switch len(alive) {
+	case 0:
+		return "", "every competing species died out", true
+	case 1:
+		return alive[0].PlayerID, "last species standing", true
+	}
+
+	if game.Settings.Condition == VictorySurvival {
+		if w.Tick >= game.CataclysmTick+cataclysmDuration+survivalGraceTicks {
+			winner, tied := bestStanding(alive)
+			if tied {
+				return "", "survivors tied after the cataclysm", true
+			}
+			return winner.PlayerID, fmt.Sprintf("most survivors after the cataclysm (%d)", winner.Population), true
+		}
+	} else if winner, tied := bestStanding(alive); !tied && winner.Score >= game.Settings.Target {
+		return winner.PlayerID, fmt.Sprintf("reached the %s target (%s)", game.Settings.Condition, formatGameScore(game.Settings.Condition, winner.Score)), true
+	}
+
+	if w.Tick-game.StartTick >= game.Settings.TimeLimit {
+		winner, tied := bestStanding(alive)
+		if tied {
+			return "", "time ran out with scores tied", true
+		}
+		return winner.PlayerID, fmt.Sprintf("best %s score when time ran out (%s)", game.Settings.Condition, formatGameScore(game.Settings.Condition, winner.Score)), true
+	}
+	return "", "", false
+}
+
+// keepEvolvedSpecies exports the best breeding pairs of each player's species for a rematch
+func (gs *CompetitiveGameSystem) keepEvolvedSpecies(w *World, game *CompetitiveGame) map[string][]*CreatureFile {
+	evolved := make(map[string][]*CreatureFile)
+	for _, player := range game.players {
+		for _, species := range player.Species {
+			members := make([]*Entity, 0)
+			for _, entity := range w.AllEntities {
+				if entity.IsAlive && entity.Species == species {
+					members = append(members, entity)
+				}
+			}
+			sort.SliceStable(members, func(i, j int) bool {
+				if members[i].Generation != members[j].Generation {
+					return members[i].Generation > members[j].Generation
+				}
+				return members[i].Fitness > members[j].Fitness
+			})
+			for i := 0; i < rematchPairsPerSpecies && i*2 < len(members); i++ {
+				ids := []int{members[i*2].ID}
+				if i*2+1 < len(members) {
+					ids = append(ids, members[i*2+1].ID)
+				}
+				file, err := ExportCreatureFile(w, ids, species, fmt.Sprintf("Evolved in competitive game %d", game.ID))
+				if err == nil {
+					evolved[player.ID] = append(evolved[player.ID], file)
+				}
+			}
+		}
+	}
+	return evolved
+}
+
+// bestStanding returns the highest scoring standing and whether another one ties it
+func bestStanding(standings []PlayerStanding) (PlayerStanding, bool) {
+	best := standings[0]
+	tied := false
+	for _, standing := range standings[1:] {
+		switch {
+		case standing.Score > best.Score:
+			best = standing
+			tied = false
+		case standing.Score == best.Score:
+			tied = true
+		}
+	}
+	return best, tied
+}
+
+// formatGameScore renders a score in the unit of its victory condition
+func formatGameScore(condition string, score float64) string {
+	switch condition {
+	case VictoryPopulation, VictoryTerritory:
+		return fmt.Sprintf("%.0f%%", score*100)
+	case VictoryTech:
+		return fmt.Sprintf("tech level %.0f", score)
+	}
+	return fmt.Sprintf("%.0f", score)
+}
+
+// snapshot copies the game so it can be read without holding the system's lock
+func (game *CompetitiveGame) snapshot() CompetitiveGame {
+	copied := *game
+	copied.Standings = append([]PlayerStanding(nil), game.Standings...)
+	copied.players = append([]GamePlayer(nil), game.players...)
+	return copied
+}
+
+// emit records a game step on the central event bus
+func (gs *CompetitiveGameSystem) emit(w *World, eventType, description string, game *CompetitiveGame) {
+	if gs.eventBus == nil {
+		return
+	}
+	metadata := map[string]interface{}{
+		"game_id":   game.ID,
+		"condition": game.Settings.Condition,
+	}
+	if game.Winner != "" {
+		metadata["winner"] = game.Winner
+	}
+	gs.eventBus.EmitSystemEvent(w.Tick, eventType, "competitive_game", "competitive_game", description, nil, metadata)
+}
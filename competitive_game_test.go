@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newGameTestWorld returns a world holding count living creatures of each species
+func newGameTestWorld(counts map[string]int) *World {
+	world := newTestWorld(50, 10)
+	for _, species := range sortedKeys(counts) {
+		for i := 0; i < counts[species]; i++ {
+			world.NextID++
+			entity := NewEntity(sharedRand, world.NextID, []string{"speed"}, species, Position{X: float64(i * 5), Y: float64(len(world.AllEntities))})
+			world.AllEntities = append(world.AllEntities, entity)
+		}
+	}
+	return world
+}
+
+var testGamePlayers = []GamePlayer{
+	{ID: "p2", Name: "Bea", Species: []string{"beetle"}},
+	{ID: "p1", Name: "Ann", Species: []string{"ant"}},
+	{ID: "p3", Name: "Spectator"},
+}
+
+func TestCompetitiveGamePopulationVictoryAndRematch(t *testing.T) {
+	world := newGameTestWorld(map[string]int{"ant": 7, "beetle": 3})
+	games := world.CompetitiveGame
+
+	if _, err := games.Start(world, GameSettings{Condition: "chess"}, testGamePlayers); err == nil {
+		t.Error("Expected an unknown victory condition to be rejected")
+	}
+	if _, err := games.Start(world, GameSettings{Condition: VictoryPopulation}, testGamePlayers[:1]); err == nil {
+		t.Error("Expected a game with one player to be rejected")
+	}
+	if _, err := games.Start(world, GameSettings{Condition: VictoryTerritory, Target: 2}, testGamePlayers); err == nil {
+		t.Error("Expected a territory share above 1 to be rejected")
+	}
+
+	game, err := games.Start(world, GameSettings{Condition: VictoryPopulation, Target: 0.72}, testGamePlayers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(game.Standings) != 2 || game.Settings.TimeLimit != defaultGameTimeLimit {
+		t.Fatalf("Expected two competing players and the default time limit, got %+v", game)
+	}
+	if _, err := games.Start(world, GameSettings{Condition: VictoryPopulation}, testGamePlayers); err == nil {
+		t.Error("Expected a second game to be rejected while one is running")
+	}
+
+	world.Tick++
+	games.Update(world)
+	if current, _ := games.Current(); current.Status != GameRunning {
+		t.Fatalf("Expected 70%% of creatures to fall short of a 72%% target, got %+v", current)
+	}
+
+	// A subspecies counts toward its player
+	world.NextID++
+	world.AllEntities = append(world.AllEntities, NewEntity(sharedRand, world.NextID, []string{"speed"}, "ant_split", Position{X: 40, Y: 40}))
+	games.AddSpecies("p1", "ant_split")
+	world.Tick++
+	games.Update(world)
+	finished, _ := games.Current()
+	if finished.Status != GameFinished || finished.Winner != "p1" || finished.Standings[0].Population != 8 {
+		t.Fatalf("Expected Ann to win with 8 of 11 creatures, got %+v", finished)
+	}
+	if events := world.CentralEventBus.GetEventsByType("game_over"); len(events) != 1 || events[0].Severity != SeverityHigh {
+		t.Errorf("Expected one high severity game over event, got %+v", events)
+	}
+
+	// The rematch starts over with each player's evolved breeding pairs
+	world.Reset()
+	rematch, err := games.Rematch(world)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rematch.ID != finished.ID+1 || rematch.Status != GameRunning || rematch.Settings != finished.Settings {
+		t.Errorf("Expected a new running game with the same settings, got %+v", rematch)
+	}
+	species := make(map[string]int)
+	for _, entity := range world.AllEntities {
+		species[entity.Species]++
+	}
+	if species["ant"] != 6 || species["ant_split"] != 1 || species["beetle"] != 3 {
+		t.Errorf("Expected up to %d pairs of each evolved species, got %v", rematchPairsPerSpecies, species)
+	}
+}
+
+func TestCompetitiveGameEliminationAndSurvival(t *testing.T) {
+	world := newGameTestWorld(map[string]int{"ant": 2, "beetle": 2})
+	if _, err := world.CompetitiveGame.Start(world, GameSettings{Condition: VictoryTech}, testGamePlayers); err != nil {
+		t.Fatal(err)
+	}
+	for _, entity := range world.AllEntities {
+		if entity.Species == "beetle" {
+			entity.IsAlive = false
+		}
+	}
+	world.Tick++
+	world.CompetitiveGame.Update(world)
+	game, _ := world.CompetitiveGame.Current()
+	if game.Winner != "p1" || game.Reason != "last species standing" || !game.Standings[1].Eliminated {
+		t.Fatalf("Expected Ann to win once the beetles died out, got %+v", game)
+	}
+
+	// Survival games strike with a cataclysm and count survivors afterwards
+	world = newGameTestWorld(map[string]int{"ant": 3, "beetle": 2})
+	game, err := world.CompetitiveGame.Start(world, GameSettings{Condition: VictorySurvival, CataclysmDelay: 5}, testGamePlayers)
+	if err != nil || game.CataclysmTick != 5 {
+		t.Fatalf("Expected a cataclysm at tick 5, got %+v (%v)", game, err)
+	}
+	energy := world.AllEntities[0].Energy
+	for world.Tick = 1; world.Tick <= 5+cataclysmDuration+survivalGraceTicks; world.Tick++ {
+		world.CompetitiveGame.Update(world)
+	}
+	if lost := energy - world.AllEntities[0].Energy; lost != cataclysmDamage*cataclysmDuration {
+		t.Errorf("Expected the cataclysm to drain %.0f energy, drained %.1f", cataclysmDamage*cataclysmDuration, lost)
+	}
+	game, _ = world.CompetitiveGame.Current()
+	if game.Status != GameFinished || game.Winner != "p1" || game.EndTick != 5+cataclysmDuration+survivalGraceTicks {
+		t.Errorf("Expected Ann's larger surviving population to win after the grace period, got %+v", game)
+	}
+	if events := world.CentralEventBus.GetEventsByType("cataclysm"); len(events) != 1 {
+		t.Errorf("Expected one cataclysm event, got %d", len(events))
+	}
+}
+
+func TestCompetitiveGameAPI(t *testing.T) {
+	wi := NewWebInterface(newGameTestWorld(map[string]int{"ant": 2, "beetle": 2}))
+
+	rec := httptest.NewRecorder()
+	wi.handleGame(rec, httptest.NewRequest(http.MethodPost, "/api/game", bytes.NewReader([]byte(`{"condition": "population"}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without players, got %d", rec.Code)
+	}
+
+	for _, player := range testGamePlayers[:2] {
+		wi.playerManager.Players[player.ID] = &Player{ID: player.ID, Name: player.Name, Species: player.Species}
+	}
+	rec = httptest.NewRecorder()
+	wi.handleGame(rec, httptest.NewRequest(http.MethodPost, "/api/game", bytes.NewReader([]byte(`{"condition": "territory", "time_limit": 50}`))))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Starting a game failed: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleGameRematch(rec, httptest.NewRequest(http.MethodPost, "/api/game/rematch", nil))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for a rematch while the game runs, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleGame(rec, httptest.NewRequest(http.MethodDelete, "/api/game", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Aborting the game failed: %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	wi.handleGame(rec, httptest.NewRequest(http.MethodGet, "/api/game", nil))
+	var response struct {
+		Game CompetitiveGame `json:"game"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil || response.Game.Status != GameAborted || response.Game.Settings.TimeLimit != 50 {
+		t.Errorf("Expected the aborted game, got %s", rec.Body.String())
+	}
+}
@@ -8,6 +8,8 @@ import (
 // SimulationConfig holds all configuration for the simulation
 type SimulationConfig struct {
 	Time       TimeConfig               `json:"time"`
+	DayNight   DayNightConfig           `json:"day_night"`
+	Seasons    SeasonsConfig            `json:"seasons"`
 	Energy     EnergyConfig             `json:"energy"`
 	Population PopulationConfigSettings `json:"population"`
 	Physics    PhysicsConfig            `json:"physics"`
@@ -15,7 +17,11 @@ type SimulationConfig struct {
 	Evolution  EvolutionConfig          `json:"evolution"`
 	Biomes     BiomesConfig             `json:"biomes"`
 	Plants     PlantsConfig             `json:"plants"`
+	Budget     ResourceBudgetConfig     `json:"resource_budget"`
+	Timescales TimescaleConfig          `json:"timescales"`
 	Web        WebConfig                `json:"web"`
+	Gallery    GalleryConfig            `json:"gallery"`
+	Schedule   map[string]int           `json:"schedule,omitempty"` // Ticks between runs of scheduled systems (see ScheduledSystemNames)
 }
 
 // TimeConfig holds all time-related configuration
@@ -27,6 +33,75 @@ type TimeConfig struct {
 	SeasonalVariation float64 `json:"seasonal_variation"` // How much seasons affect environment
 }
 
+// DayNightConfig sets the length of the day-night cycle, how the sun's light falls across the
+// map's latitudes through the year, and how strongly light sways plants and hunters
+type DayNightConfig struct {
+	DayLength           int     `json:"day_length"`           // Ticks from one dawn to the next
+	MaxLatitude         float64 `json:"max_latitude"`         // Latitude of the map's top and bottom edges, in degrees north and south
+	AxialTilt           float64 `json:"axial_tilt"`           // Degrees the sun moves north and south of the equator through the year
+	NightLight          float64 `json:"night_light"`          // Moonlight and starlight, the least light a cell gets, 0-1
+	PhotosynthesisLight float64 `json:"photosynthesis_light"` // Share of photosynthesis full sun adds and darkness takes away, 0-1
+	NightHunting        float64 `json:"night_hunting"`        // Share of kills darkness adds for night hunters and takes from day hunters, 0-1
+}
+
+// Validate checks that the day has ticks in it and the angles and shares are in range
+func (dayNight DayNightConfig) Validate() error {
+	if dayNight.DayLength <= 0 {
+		return fmt.Errorf("day length must be positive")
+	}
+	if dayNight.MaxLatitude < 0 || dayNight.MaxLatitude > 90 || dayNight.AxialTilt < 0 || dayNight.AxialTilt > 90 {
+		return fmt.Errorf("latitude and axial tilt must be between 0 and 90 degrees")
+	}
+	for _, share := range []float64{dayNight.NightLight, dayNight.PhotosynthesisLight, dayNight.NightHunting} {
+		if share < 0 || share > 1 {
+			return fmt.Errorf("night light, photosynthesis light and night hunting must be between 0 and 1")
+		}
+	}
+	return nil
+}
+
+// SeasonsConfig sets what each season of the year does to the world
+type SeasonsConfig struct {
+	Spring SeasonEffects `json:"spring"`
+	Summer SeasonEffects `json:"summer"`
+	Autumn SeasonEffects `json:"autumn"`
+	Winter SeasonEffects `json:"winter"`
+}
+
+// SeasonEffects is how one season sways the biomes, plants and creatures
+type SeasonEffects struct {
+	Temperature float64 `json:"temperature"`  // Multiplies biome temperatures
+	PlantGrowth float64 `json:"plant_growth"` // Multiplies how well plants thrive on their soil
+	Food        float64 `json:"food"`         // Multiplies the chance a creature finds a plant to eat
+	Mating      bool    `json:"mating"`       // Every creature mates; otherwise only those with the season's mating gene
+	Migration   bool    `json:"migration"`    // Migratory creatures set off when the season begins
+}
+
+// For returns the effects of a season
+func (seasons SeasonsConfig) For(season Season) SeasonEffects {
+	switch season {
+	case Summer:
+		return seasons.Summer
+	case Autumn:
+		return seasons.Autumn
+	case Winter:
+		return seasons.Winter
+	default:
+		return seasons.Spring
+	}
+}
+
+// Validate checks that no season multiplies by less than nothing
+func (seasons SeasonsConfig) Validate() error {
+	for season := Spring; season <= Winter; season++ {
+		effects := seasons.For(season)
+		if effects.Temperature < 0 || effects.PlantGrowth < 0 || effects.Food < 0 {
+			return fmt.Errorf("%s temperature, plant growth and food cannot be negative", seasonToString(season))
+		}
+	}
+	return nil
+}
+
 // EnergyConfig holds all energy-related configuration
 type EnergyConfig struct {
 	BaseEnergyDrain        float64            `json:"base_energy_drain"`        // Base energy cost per tick
@@ -41,7 +116,9 @@ type EnergyConfig struct {
 // PopulationConfigSettings holds population-related configuration
 type PopulationConfigSettings struct {
 	DefaultPopSize    int     `json:"default_pop_size"`    // Default population size per species
-	MaxPopulation     int     `json:"max_population"`      // Maximum total population
+	MaxPopulation     int     `json:"max_population"`      // Soft cap on the total population; the surplus disperses offstage (0 disables)
+	RegionSize        int     `json:"region_size"`         // Side of a population cap region in grid cells
+	RegionSoftCap     int     `json:"region_soft_cap"`     // Creatures per region before the surplus emigrates (0 disables)
 	MutationRateBase  float64 `json:"mutation_rate_base"`  // Base mutation rate
 	MutationRateRange float64 `json:"mutation_rate_range"` // Range of mutation rate variation
 	SelectionPressure float64 `json:"selection_pressure"`  // How strong natural selection is
@@ -92,6 +169,46 @@ type PlantsConfig struct {
 	NutrientRequirement map[string]float64 `json:"nutrient_requirement"` // Nutrient needs by plant type
 }
 
+// ResourceBudgetConfig sets the sunlight, water and nutrients each grid cell of plains
+// supplies, which other biomes scale, and what plants and creatures draw on them
+type ResourceBudgetConfig struct {
+	Enabled              bool    `json:"enabled"`               // Cap plant growth and reproduction by the budget
+	SunlightPerCell      float64 `json:"sunlight_per_cell"`     // Sunlight reaching a cell each tick
+	WaterPerCell         float64 `json:"water_per_cell"`        // Water a cell holds
+	NutrientsPerCell     float64 `json:"nutrients_per_cell"`    // Soil nutrients a cell holds
+	WaterRegeneration    float64 `json:"water_regeneration"`    // Share of a cell's missing water restored each tick, 0-1
+	NutrientRegeneration float64 `json:"nutrient_regeneration"` // Share of a cell's missing nutrients restored each tick, 0-1
+	PlantUptake          float64 `json:"plant_uptake"`          // Of each resource a plant takes per tick, per unit of size plus one
+	Photosynthesis       float64 `json:"photosynthesis"`        // Energy a plant makes per tick from a full share, per unit of size plus one
+	SoilBonus            float64 `json:"soil_bonus"`            // Most that rich soil adds to a plant's growth, as a share of its normal growth
+	CreaturesPerCell     float64 `json:"creatures_per_cell"`    // Creatures a cell feeds on food other than plants
+	BiomassPerCreature   float64 `json:"biomass_per_creature"`  // Plant biomass that feeds one more creature
+}
+
+// Validate checks that the budget's amounts are not negative and its regeneration rates are shares
+func (budget ResourceBudgetConfig) Validate() error {
+	if budget.SunlightPerCell < 0 || budget.WaterPerCell < 0 || budget.NutrientsPerCell < 0 || budget.PlantUptake < 0 ||
+		budget.Photosynthesis < 0 || budget.SoilBonus < 0 || budget.CreaturesPerCell < 0 || budget.BiomassPerCreature < 0 {
+		return fmt.Errorf("resource budget amounts cannot be negative")
+	}
+	if budget.WaterRegeneration < 0 || budget.WaterRegeneration > 1 || budget.NutrientRegeneration < 0 || budget.NutrientRegeneration > 1 {
+		return fmt.Errorf("resource regeneration rates must be between 0 and 1")
+	}
+	return nil
+}
+
+// TimescaleConfig scales how long things take relative to the built-in values. Tuning
+// profiles set these together so lifespans, events and gestation stay in proportion.
+type TimescaleConfig struct {
+	Profile            string  `json:"profile"`              // Tuning profile the scales came from
+	LifespanScale      float64 `json:"lifespan_scale"`       // Multiplies lifespans and the ages of maturity and senescence
+	EventDurationScale float64 `json:"event_duration_scale"` // Multiplies how long world and environmental events last
+	MutationRateScale  float64 `json:"mutation_rate_scale"`  // Multiplies mutation rates at reproduction
+	GestationScale     float64 `json:"gestation_scale"`      // Multiplies gestation and egg incubation periods
+	DecayScale         float64 `json:"decay_scale"`          // Multiplies how long remains take to decay
+	SeasonScale        float64 `json:"season_scale"`         // Multiplies the length of a season
+}
+
 // WebConfig holds web interface configuration
 type WebConfig struct {
 	UpdateInterval time.Duration `json:"update_interval"` // How often to update web interface
@@ -99,6 +216,28 @@ type WebConfig struct {
 	MaxClients     int           `json:"max_clients"`     // Maximum concurrent clients
 }
 
+// GalleryConfig sets when the snapshot gallery captures the world and how much of it it keeps
+type GalleryConfig struct {
+	Enabled      bool `json:"enabled"`       // Capture a snapshot on notable events
+	MaxSnapshots int  `json:"max_snapshots"` // Snapshots kept before the oldest are dropped
+	Cooldown     int  `json:"cooldown"`      // Fewest ticks between two snapshots of the same kind of event
+	CellPixels   int  `json:"cell_pixels"`   // Side of a grid cell in a snapshot image, in pixels
+}
+
+// Validate checks that the gallery keeps snapshots and draws cells a sensible size
+func (gallery GalleryConfig) Validate() error {
+	if gallery.MaxSnapshots <= 0 {
+		return fmt.Errorf("gallery must keep at least one snapshot")
+	}
+	if gallery.Cooldown < 0 {
+		return fmt.Errorf("gallery cooldown cannot be negative")
+	}
+	if gallery.CellPixels < 1 || gallery.CellPixels > 32 {
+		return fmt.Errorf("gallery cell pixels must be between 1 and 32")
+	}
+	return nil
+}
+
 // DefaultSimulationConfig returns a default configuration
 func DefaultSimulationConfig() *SimulationConfig {
 	return &SimulationConfig{
@@ -109,6 +248,20 @@ func DefaultSimulationConfig() *SimulationConfig {
 			NightPenalty:      0.01, // Additional energy cost at night
 			SeasonalVariation: 0.3,  // 30% variation between seasons
 		},
+		DayNight: DayNightConfig{
+			DayLength:           dayPeriods, // One tick for each time of day
+			MaxLatitude:         60,
+			AxialTilt:           23.5,
+			NightLight:          0.05,
+			PhotosynthesisLight: 0.5,
+			NightHunting:        0.5,
+		},
+		Seasons: SeasonsConfig{
+			Spring: SeasonEffects{Temperature: 0.8, PlantGrowth: 1.2, Food: 1.1, Mating: true, Migration: true},
+			Summer: SeasonEffects{Temperature: 1.2, PlantGrowth: 1.1, Food: 1.0},
+			Autumn: SeasonEffects{Temperature: 0.9, PlantGrowth: 0.9, Food: 0.9, Migration: true},
+			Winter: SeasonEffects{Temperature: 0.6, PlantGrowth: 0.6, Food: 0.7},
+		},
 		Energy: EnergyConfig{
 			BaseEnergyDrain:        0.01,  // Base energy cost per tick
 			MovementEnergyCost:     0.005, // Energy cost for movement
@@ -138,6 +291,8 @@ func DefaultSimulationConfig() *SimulationConfig {
 		Population: PopulationConfigSettings{
 			DefaultPopSize:    20,   // Default 20 entities per species
 			MaxPopulation:     1000, // Maximum 1000 entities total
+			RegionSize:        10,   // 10x10 grid cell regions
+			RegionSoftCap:     0,    // No per-region cap
 			MutationRateBase:  0.1,  // 10% base mutation rate
 			MutationRateRange: 0.15, // ±15% variation in mutation rate
 			SelectionPressure: 0.3,  // Moderate selection pressure
@@ -258,11 +413,39 @@ func DefaultSimulationConfig() *SimulationConfig {
 				"aquatic":   1.2,
 			},
 		},
+		Budget: ResourceBudgetConfig{
+			Enabled:              true,
+			SunlightPerCell:      0.5,
+			WaterPerCell:         3.0,
+			NutrientsPerCell:     6.0,
+			WaterRegeneration:    0.1,  // Rain refills a dry cell in about ten ticks
+			NutrientRegeneration: 0.05, // Decay and weathering are slower
+			PlantUptake:          0.1,  // A full-grown plant of size 2 takes what a cell of plains renews
+			Photosynthesis:       0.8,
+			SoilBonus:            1.0, // Rich soil at most doubles growth
+			CreaturesPerCell:     0.05,
+			BiomassPerCreature:   200,
+		},
+		Timescales: TimescaleConfig{
+			Profile:            "standard",
+			LifespanScale:      1.0,
+			EventDurationScale: 1.0,
+			MutationRateScale:  1.0,
+			GestationScale:     1.0,
+			DecayScale:         1.0,
+			SeasonScale:        1.0,
+		},
 		Web: WebConfig{
 			UpdateInterval: 100 * time.Millisecond,
 			Port:           8080,
 			MaxClients:     100,
 		},
+		Gallery: GalleryConfig{
+			Enabled:      true,
+			MaxSnapshots: 100,
+			Cooldown:     50, // A burst of speciations or wars fills one snapshot, not dozens
+			CellPixels:   8,
+		},
 	}
 }
 
@@ -305,12 +488,35 @@ func (config *SimulationConfig) Validate() error {
 	if config.Population.DefaultPopSize <= 0 {
 		return fmt.Errorf("default population size must be positive")
 	}
+	if config.Population.MaxPopulation < 0 || config.Population.RegionSoftCap < 0 || config.Population.RegionSize < 0 {
+		return fmt.Errorf("population caps and region size cannot be negative")
+	}
+	if err := config.DayNight.Validate(); err != nil {
+		return err
+	}
+	if err := config.Seasons.Validate(); err != nil {
+		return err
+	}
+	if err := config.Budget.Validate(); err != nil {
+		return err
+	}
+	if err := config.Gallery.Validate(); err != nil {
+		return err
+	}
+	scales := config.Timescales
+	if scales.LifespanScale <= 0 || scales.EventDurationScale <= 0 || scales.MutationRateScale <= 0 ||
+		scales.GestationScale <= 0 || scales.DecayScale <= 0 || scales.SeasonScale <= 0 {
+		return fmt.Errorf("timescale multipliers must be positive")
+	}
 	if config.World.Width <= 0 || config.World.Height <= 0 {
 		return fmt.Errorf("world dimensions must be positive")
 	}
 	if config.World.GridWidth <= 0 || config.World.GridHeight <= 0 {
 		return fmt.Errorf("grid dimensions must be positive")
 	}
+	if err := ValidateSchedule(config.Schedule); err != nil {
+		return err
+	}
 	return nil
 }
 
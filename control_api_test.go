@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHealthProbes(t *testing.T) {
+	wi := NewWebInterface(newGraphQLTestWorld())
+	var health HealthStatus
+	if code := callControlAPI(t, wi.handleReadyz, http.MethodGet, "/readyz", "", nil); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected not ready before the simulation starts, got %d", code)
+	}
+	wi.Start()
+	if code := callControlAPI(t, wi.handleReadyz, http.MethodGet, "/readyz", "", &health); code != http.StatusOK || health.Status != "ready" {
+		t.Errorf("Expected ready once running, got %d %+v", code, health)
+	}
+	wi.Stop()
+	rec := httptest.NewRecorder()
+	wi.handleReadyz(rec, httptest.NewRequest(http.MethodHead, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable || rec.Body.Len() != 0 {
+		t.Errorf("Expected a bodiless 503 while stopping, got %d", rec.Code)
+	}
+	if code := callControlAPI(t, wi.handleHealthz, http.MethodGet, "/healthz", "", &health); code != http.StatusOK || health.Status != "ok" {
+		t.Errorf("Expected the process still live, got %d", code)
+	}
+}
+
+// callControlAPI sends a request to a handler and decodes its JSON response into out
+func callControlAPI(t *testing.T, handler http.HandlerFunc, method, target, body string, out interface{}) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	if out != nil && rec.Code < 300 {
+		if err := json.NewDecoder(rec.Body).Decode(out); err != nil {
+			t.Fatalf("Failed to decode the response to %s %s: %v", method, target, err)
+		}
+	}
+	return rec.Code
+}
+
+func TestControlAPIDrivesTheSimulation(t *testing.T) {
+	wi := NewWebInterface(newGraphQLTestWorld())
+
+	var state ControlState
+	callControlAPI(t, wi.handleControlPause, http.MethodPost, "/api/control/pause", `{"paused": true}`, &state)
+	if !state.Paused || !wi.world.IsPaused() {
+		t.Errorf("Expected the simulation paused")
+	}
+	callControlAPI(t, wi.handleControlPause, http.MethodPost, "/api/control/pause", "", &state)
+	if state.Paused {
+		t.Errorf("Expected an empty pause request to toggle")
+	}
+
+	callControlAPI(t, wi.handleControlSpeed, http.MethodPost, "/api/control/speed", `{"speed": 4, "max_cpu": 50}`, &state)
+	if state.Speed != 4 || state.MaxCPU != 50 || state.UnlimitedSpeed {
+		t.Errorf("Expected speed 4x at half the CPU, got %+v", state)
+	}
+	if code := callControlAPI(t, wi.handleControlSpeed, http.MethodPost, "/api/control/speed", `{"speed": 100}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected an out of range speed refused, got %d", code)
+	}
+
+	callControlAPI(t, wi.handleControlViewport, http.MethodPost, "/api/control/viewport", `{"x": 3, "y": 2, "zoom": 2}`, &state)
+	if state.ViewportX != 3 || state.ViewportY != 2 || state.Zoom != 2 {
+		t.Errorf("Expected the view moved to (3, 2) at 2x, got %+v", state)
+	}
+
+	var current ControlState
+	callControlAPI(t, wi.handleControl, http.MethodGet, "/api/control", "", &current)
+	if current != state {
+		t.Errorf("Expected the control state to report the changes, got %+v want %+v", current, state)
+	}
+	if code := callControlAPI(t, wi.handleControl, http.MethodPost, "/api/control", "", nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected the control state read only, got %d", code)
+	}
+}
+
+func TestPopulationAndEntityAPI(t *testing.T) {
+	wi := NewWebInterface(newGraphQLTestWorld())
+	wi.world.Config.PopulationSize = 5
+
+	var created PopulationCreateResponse
+	body := `{"name": "Scouts", "traits": {"speed": 0.3}}`
+	if code := callControlAPI(t, wi.handlePopulations, http.MethodPost, "/api/populations", body, &created); code != http.StatusCreated {
+		t.Fatalf("Expected the species created, got %d", code)
+	}
+	if created.Traits["speed"] != 0.4 {
+		t.Errorf("Expected the speed adjustment applied, got %v", created.Traits)
+	}
+	if code := callControlAPI(t, wi.handlePopulations, http.MethodPost, "/api/populations", `{"name": ""}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected a species without a name refused, got %d", code)
+	}
+
+	var populations []PopulationData
+	callControlAPI(t, wi.handlePopulations, http.MethodGet, "/api/populations", "", &populations)
+	found := false
+	for _, population := range populations {
+		found = found || population.Name == created.Name
+	}
+	if !found {
+		t.Errorf("Expected the new species listed among %d populations", len(populations))
+	}
+
+	var page EntityListResponse
+	callControlAPI(t, wi.handleEntities, http.MethodGet, "/api/entities?species="+url.QueryEscape(created.Name)+"&alive=true&limit=2", "", &page)
+	if page.Total == 0 || len(page.Entities) > 2 {
+		t.Fatalf("Expected a page of at most 2 of the new species, got %d of %d", len(page.Entities), page.Total)
+	}
+	for i, entity := range page.Entities {
+		if entity.Species != created.Name || (i > 0 && entity.ID <= page.Entities[i-1].ID) {
+			t.Errorf("Expected the new species in ID order, got %+v", entity)
+		}
+	}
+	if code := callControlAPI(t, wi.handleEntities, http.MethodGet, "/api/entities?limit=5000", "", nil); code != http.StatusBadRequest {
+		t.Errorf("Expected an oversized page refused, got %d", code)
+	}
+}
+
+func TestSaveAndLoadAPI(t *testing.T) {
+	wi := NewWebInterface(newGraphQLTestWorld())
+	for i := 0; i < 5; i++ {
+		wi.world.Update()
+	}
+	tick := wi.world.Tick
+
+	rec := httptest.NewRecorder()
+	wi.handleSave(rec, httptest.NewRequest(http.MethodGet, "/api/save", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the save downloaded, got %d", rec.Code)
+	}
+	save := rec.Body.Bytes()
+
+	for i := 0; i < 5; i++ {
+		wi.world.Update()
+	}
+	var state ControlState
+	callControlAPI(t, wi.handleLoad, http.MethodPost, "/api/load", string(save), &state)
+	if state.Tick != tick || wi.world.Tick != tick {
+		t.Errorf("Expected the world back at tick %d, got %d", tick, wi.world.Tick)
+	}
+	if code := callControlAPI(t, wi.handleLoad, http.MethodPost, "/api/load", "[]", nil); code != http.StatusBadRequest {
+		t.Errorf("Expected a save that is not an object refused, got %d", code)
+	}
+
+	dir := t.TempDir()
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	var saved SaveResponse
+	callControlAPI(t, wi.handleSave, http.MethodPost, "/api/save", "", &saved)
+	if written, err := os.ReadFile(saved.Filename); err != nil || !bytes.Contains(written, []byte(`"tick"`)) {
+		t.Errorf("Expected the save written to %q on the server: %v", saved.Filename, err)
+	}
+}
+
+func TestHandlersWaitForTicks(t *testing.T) {
+	world := NewWorld(WorldConfig{Width: 40, Height: 40, PopulationSize: 4, GridWidth: 8, GridHeight: 8})
+	world.AddPopulation(startingPopulations(false)[0])
+	wi := NewWebInterface(world)
+	wi.governor.SetUnlimited(true)
+	routes := wi.Routes()
+	creature := `{"format": "evosim-creature", "version": 1, "creatures": [{"species": "visitor", "traits": {"speed": 0.2}}]}`
+
+	// Run under -race, these would report the handlers touching the world mid-tick
+	wi.startLoop(wi.simulationLoop)
+	defer wi.Stop()
+	for round := 0; round < 5; round++ {
+		for _, target := range []string{"/api/status", "/api/export/geojson", "/api/export/darwincore", "/api/export/events",
+			"/api/timeline", "/api/breakpoints", "/api/achievements", "/api/predictions", "/api/operator/structures", "/api/validate"} {
+			rec := httptest.NewRecorder()
+			routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+			if rec.Code >= 500 {
+				t.Errorf("Expected GET %s to succeed while ticking, got %d", target, rec.Code)
+			}
+		}
+		rec := httptest.NewRecorder()
+		routes.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/creatures/import", strings.NewReader(creature)))
+		if rec.Code != http.StatusOK {
+			t.Errorf("Expected the creature imported while ticking, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Convergent evolution detection parameters
+const (
+	convergenceInterval    = 100  // Ticks between checks, as for adaptations
+	convergenceGap         = 0.15 // Traits this close are converged
+	convergenceMinApproach = 0.3  // How much closer than at their origins the lineages must have come
+)
+
+// ConvergedTrait is a trait two unrelated lineages arrived at independently
+type ConvergedTrait struct {
+	Trait     string  `json:"trait"`
+	OriginGap float64 `json:"origin_gap"` // How far apart the lineages started
+	Gap       float64 `json:"gap"`        // How far apart they are now
+	ValueA    float64 `json:"value_a"`
+	ValueB    float64 `json:"value_b"`
+}
+
+// ConvergenceEvent records two independent lineages evolving similar traits in similar biomes
+type ConvergenceEvent struct {
+	Tick     int              `json:"tick"`
+	SpeciesA string           `json:"species_a"`
+	SpeciesB string           `json:"species_b"`
+	RootA    string           `json:"root_a"` // Founders of each lineage, which differ
+	RootB    string           `json:"root_b"`
+	Habitats []string         `json:"habitats"` // Biome preferences the two share
+	Traits   []ConvergedTrait `json:"traits"`
+}
+
+// originTraits returns the earliest recorded mean traits of a species, falling back to those
+// noted when it formed
+func (mes *MacroEvolutionSystem) originTraits(species string) map[string]float64 {
+	traits := make(map[string]float64)
+	earliest := make(map[string]int)
+	prefix := species + "_"
+	for key, history := range mes.TraitHistory {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		trait := key[len(prefix):]
+		for tick, value := range history {
+			if first, ok := earliest[trait]; !ok || tick < first {
+				earliest[trait] = tick
+				traits[trait] = value
+			}
+		}
+	}
+	if lineage := mes.SpeciesLineages[species]; lineage != nil {
+		for trait, value := range lineage.DominantTraits {
+			if _, ok := traits[trait]; !ok {
+				traits[trait] = value
+			}
+		}
+	}
+	return traits
+}
+
+// detectConvergence compares living species from independent lineages that share a habitat,
+// reporting traits they have converged on since they arose. Each pair is reported once.
+func (mes *MacroEvolutionSystem) detectConvergence(world *World) {
+	if world.Tick%convergenceInterval != 0 {
+		return
+	}
+
+	members := make(map[string][]*Entity)
+	for _, entity := range world.AllEntities {
+		if entity.IsAlive {
+			members[entity.Species] = append(members[entity.Species], entity)
+		}
+	}
+	reported := make(map[string]bool)
+	for _, convergence := range mes.Convergences {
+		reported[convergence.SpeciesA+"|"+convergence.SpeciesB] = true
+	}
+
+	species := sortedKeys(members)
+	origins := make(map[string]map[string]float64)
+	current := make(map[string]map[string]float64)
+	for _, name := range species {
+		origins[name] = mes.originTraits(name)
+		current[name] = mes.calculateCurrentSpeciesTraits(name, world)
+	}
+	for i, a := range species {
+		for _, b := range species[i+1:] {
+			if reported[a+"|"+b] {
+				continue
+			}
+			rootA, rootB := mes.lineageOf(a)[0], mes.lineageOf(b)[0]
+			if rootA == rootB {
+				continue // Shared ancestry, not convergence
+			}
+			habitats := sharedStrings(mes.analyzeBiomePreference(members[a], world), mes.analyzeBiomePreference(members[b], world))
+			if len(habitats) == 0 {
+				continue
+			}
+			traits := convergedTraits(origins[a], origins[b], current[a], current[b])
+			if len(traits) == 0 {
+				continue
+			}
+			mes.recordConvergence(world, ConvergenceEvent{
+				Tick: world.Tick, SpeciesA: a, SpeciesB: b, RootA: rootA, RootB: rootB, Habitats: habitats, Traits: traits,
+			})
+		}
+	}
+}
+
+// convergedTraits finds the traits two lineages started apart on but now share
+func convergedTraits(originA, originB, currentA, currentB map[string]float64) []ConvergedTrait {
+	traits := make([]ConvergedTrait, 0)
+	for _, trait := range sortedKeys(currentA) {
+		valueB, ok := currentB[trait]
+		startA, okA := originA[trait]
+		startB, okB := originB[trait]
+		if !ok || !okA || !okB {
+			continue
+		}
+		gap := math.Abs(currentA[trait] - valueB)
+		originGap := math.Abs(startA - startB)
+		if gap <= convergenceGap && originGap-gap >= convergenceMinApproach {
+			traits = append(traits, ConvergedTrait{Trait: trait, OriginGap: originGap, Gap: gap, ValueA: currentA[trait], ValueB: valueB})
+		}
+	}
+	sort.SliceStable(traits, func(i, j int) bool {
+		return traits[i].OriginGap-traits[i].Gap > traits[j].OriginGap-traits[j].Gap
+	})
+	return traits
+}
+
+// sharedStrings returns the strings in both lists, sorted
+func sharedStrings(a, b []string) []string {
+	inA := make(map[string]bool)
+	for _, value := range a {
+		inA[value] = true
+	}
+	shared := make(map[string]bool)
+	for _, value := range b {
+		if inA[value] {
+			shared[value] = true
+		}
+	}
+	return sortedKeys(shared)
+}
+
+// recordConvergence stores a convergence and logs it as an evolutionary event
+func (mes *MacroEvolutionSystem) recordConvergence(world *World, convergence ConvergenceEvent) {
+	mes.Convergences = append(mes.Convergences, convergence)
+
+	names := make([]string, len(convergence.Traits))
+	changes := make(map[string]float64)
+	for i, trait := range convergence.Traits {
+		names[i] = trait.Trait
+		changes[trait.Trait] = trait.OriginGap - trait.Gap
+	}
+	mes.Events = append(mes.Events, EvolutionaryEvent{
+		Tick: world.Tick,
+		Type: "convergence",
+		Description: fmt.Sprintf("Unrelated species '%s' and '%s' converged on %s in %s habitats",
+			convergence.SpeciesA, convergence.SpeciesB, strings.Join(names, ", "), strings.Join(convergence.Habitats, "/")),
+		Species:      convergence.SpeciesA,
+		AffectedIDs:  make([]int, 0),
+		TraitChanges: changes,
+		Significance: math.Min(1.0, 0.3+0.15*float64(len(convergence.Traits))),
+		Environment:  mes.captureEnvironmentalState(world),
+	})
+}
+
+// GetRecentConvergences returns the most recent convergence events, newest first
+func (mes *MacroEvolutionSystem) GetRecentConvergences(count int) []ConvergenceEvent {
+	recent := make([]ConvergenceEvent, 0, count)
+	for i := len(mes.Convergences) - 1; i >= 0 && len(recent) < count; i-- {
+		recent = append(recent, mes.Convergences[i])
+	}
+	return recent
+}
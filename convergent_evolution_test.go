@@ -0,0 +1,79 @@
+package main
+
+import "testing"
+
+// newConvergenceTestWorld floods the world and gives two species the same aquatic adaptation,
+// which they started far apart on
+func newConvergenceTestWorld(t *testing.T) (*World, string, string) {
+	world := newSteppingTestWorld(96)
+	fillTestBiome(world, BiomeWater)
+	species := sortedKeys(world.Populations)
+	if len(species) < 2 {
+		t.Fatalf("Expected at least two species, got %v", species)
+	}
+	a, b := world.Populations[species[0]].Species, world.Populations[species[1]].Species
+	for _, entity := range world.AllEntities {
+		if entity.Species == a || entity.Species == b {
+			entity.Traits["aquatic_adaptation"] = Trait{Name: "aquatic_adaptation", Value: 0.8}
+		}
+	}
+
+	mes := NewMacroEvolutionSystem()
+	mes.TraitHistory[a+"_aquatic_adaptation"] = map[int]float64{0: -0.6, 50: 0.2}
+	mes.TraitHistory[b+"_aquatic_adaptation"] = map[int]float64{0: 0.1}
+	world.MacroEvolutionSystem = mes
+	world.Tick = convergenceInterval
+	return world, a, b
+}
+
+func TestConvergenceDetected(t *testing.T) {
+	world, a, b := newConvergenceTestWorld(t)
+	mes := world.MacroEvolutionSystem
+	mes.detectConvergence(world)
+
+	if len(mes.Convergences) != 1 {
+		t.Fatalf("Expected one convergence, got %+v", mes.Convergences)
+	}
+	convergence := mes.Convergences[0]
+	if convergence.SpeciesA != a && convergence.SpeciesA != b || convergence.RootA == convergence.RootB {
+		t.Errorf("Expected %s and %s from separate roots, got %+v", a, b, convergence)
+	}
+	if len(convergence.Habitats) != 1 || convergence.Habitats[0] != "aquatic" {
+		t.Errorf("Expected the shared aquatic habitat, got %v", convergence.Habitats)
+	}
+	if len(convergence.Traits) != 1 || convergence.Traits[0].Trait != "aquatic_adaptation" || convergence.Traits[0].Gap != 0 {
+		t.Fatalf("Expected convergence on aquatic adaptation, got %+v", convergence.Traits)
+	}
+	if gap := convergence.Traits[0].OriginGap; gap < 0.7-1e-9 || gap > 0.7+1e-9 {
+		t.Errorf("Expected the earliest means 0.7 apart, got %.3f", gap)
+	}
+	if len(mes.Events) != 1 || mes.Events[0].Type != "convergence" {
+		t.Errorf("Expected a convergence event, got %+v", mes.Events)
+	}
+
+	// The pair is reported once
+	world.Tick += convergenceInterval
+	mes.detectConvergence(world)
+	if len(mes.Convergences) != 1 {
+		t.Errorf("Expected the pair not to be reported again, got %d", len(mes.Convergences))
+	}
+}
+
+func TestConvergenceIgnoresSharedAncestry(t *testing.T) {
+	world, a, b := newConvergenceTestWorld(t)
+	mes := world.MacroEvolutionSystem
+	mes.SpeciesLineages[b] = &SpeciesLineage{SpeciesName: b, ParentSpecies: a}
+	mes.detectConvergence(world)
+	if len(mes.Convergences) != 0 {
+		t.Errorf("Expected related species not to count as convergent, got %+v", mes.Convergences)
+	}
+
+	// Nor does similarity that was there from the start
+	world, a, _ = newConvergenceTestWorld(t)
+	mes = world.MacroEvolutionSystem
+	mes.TraitHistory[a+"_aquatic_adaptation"] = map[int]float64{0: 0.2}
+	mes.detectConvergence(world)
+	if len(mes.Convergences) != 0 {
+		t.Errorf("Expected no convergence for species that started alike, got %+v", mes.Convergences)
+	}
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"time"
+)
+
+// Creature file format identifiers
+const (
+	CreatureFileFormat  = "evosim-creature"
+	CreatureFileVersion = 1
+)
+
+// CreatureRecord holds everything needed to recreate one entity in another world
+type CreatureRecord struct {
+	OriginalID     int                    `json:"original_id"`
+	Species        string                 `json:"species"`
+	Generation     int                    `json:"generation"`
+	Traits         map[string]float64     `json:"traits"`
+	Classification OrganismClassification `json:"classification"`
+	MaxLifespan    int                    `json:"max_lifespan"`
+	DNA            *DNAState              `json:"dna,omitempty"`
+	Cellular       *CellularState         `json:"cellular,omitempty"`
+	NeuralNetwork  *EntityNeuralNetwork   `json:"neural_network,omitempty"`
+	Culture        *CulturalMemory        `json:"culture,omitempty"`
+}
+
+// CreatureFile is a standalone, shareable file containing a single creature or a breeding pair
+type CreatureFile struct {
+	Format      string           `json:"format"`
+	Version     int              `json:"version"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	ExportedAt  time.Time        `json:"exported_at"`
+	SourceTick  int              `json:"source_tick"`
+	Creatures   []CreatureRecord `json:"creatures"`
+}
+
+// cloneViaJSON deep-copies a value by round-tripping it through JSON
+func cloneViaJSON(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// ExportCreatureFile packages one entity, or a breeding pair of the same species, into a creature file
+func ExportCreatureFile(world *World, entityIDs []int, name, description string) (*CreatureFile, error) {
+	if len(entityIDs) == 0 || len(entityIDs) > 2 {
+		return nil, fmt.Errorf("a creature file holds one entity or a breeding pair, got %d", len(entityIDs))
+	}
+
+	entities := make([]*Entity, 0, len(entityIDs))
+	for _, id := range entityIDs {
+		entity := world.findEntityByID(id)
+		if entity == nil || !entity.IsAlive {
+			return nil, fmt.Errorf("entity %d not found or not alive", id)
+		}
+		entities = append(entities, entity)
+	}
+	if len(entities) == 2 {
+		if entities[0].ID == entities[1].ID {
+			return nil, fmt.Errorf("a breeding pair needs two different entities")
+		}
+		if entities[0].Species != entities[1].Species {
+			return nil, fmt.Errorf("a breeding pair must share a species (%s vs %s)", entities[0].Species, entities[1].Species)
+		}
+	}
+
+	if name == "" {
+		name = entities[0].Species
+	}
+	file := &CreatureFile{
+		Format:      CreatureFileFormat,
+		Version:     CreatureFileVersion,
+		Name:        name,
+		Description: description,
+		ExportedAt:  time.Now(),
+		SourceTick:  world.Tick,
+		Creatures:   make([]CreatureRecord, 0, len(entities)),
+	}
+
+	sm := NewStateManager(world)
+	for _, entity := range entities {
+		record := CreatureRecord{
+			OriginalID:     entity.ID,
+			Species:        entity.Species,
+			Generation:     entity.Generation,
+			Traits:         make(map[string]float64, len(entity.Traits)),
+			Classification: entity.Classification,
+			MaxLifespan:    entity.MaxLifespan,
+		}
+		for traitName, trait := range entity.Traits {
+			record.Traits[traitName] = trait.Value
+		}
+
+		if world.CellularSystem != nil {
+			if organism, exists := world.CellularSystem.OrganismMap[entity.ID]; exists {
+				record.Cellular = sm.convertCellularToState(organism)
+				if len(organism.Cells) > 0 && organism.Cells[0].DNA != nil {
+					record.DNA = sm.convertDNAToState(organism.Cells[0].DNA)
+				}
+			}
+		}
+
+		if world.NeuralAISystem != nil {
+			if network, exists := world.NeuralAISystem.EntityNetworks[entity.ID]; exists {
+				record.NeuralNetwork = &EntityNeuralNetwork{}
+				if err := cloneViaJSON(network, record.NeuralNetwork); err != nil {
+					return nil, fmt.Errorf("failed to copy neural network: %v", err)
+				}
+			}
+		}
+
+		if world.CulturalKnowledgeSystem != nil {
+			if memory, exists := world.CulturalKnowledgeSystem.EntityMemories[entity.ID]; exists {
+				record.Culture = &CulturalMemory{}
+				if err := cloneViaJSON(memory, record.Culture); err != nil {
+					return nil, fmt.Errorf("failed to copy cultural memory: %v", err)
+				}
+			}
+		}
+
+		file.Creatures = append(file.Creatures, record)
+	}
+
+	return file, nil
+}
+
+// Validate checks that a creature file can be imported
+func (cf *CreatureFile) Validate() error {
+	if cf.Format != CreatureFileFormat {
+		return fmt.Errorf("not a creature file (format %q)", cf.Format)
+	}
+	if cf.Version > CreatureFileVersion {
+		return fmt.Errorf("creature file version %d is newer than supported version %d", cf.Version, CreatureFileVersion)
+	}
+	if len(cf.Creatures) == 0 || len(cf.Creatures) > 2 {
+		return fmt.Errorf("creature file must hold one or two creatures, got %d", len(cf.Creatures))
+	}
+	for i, record := range cf.Creatures {
+		if record.Species == "" {
+			return fmt.Errorf("creature %d has no species", i)
+		}
+		for traitName, value := range record.Traits {
+			if isInvalidNumber(value) {
+				return fmt.Errorf("creature %d trait %s is not a number", i, traitName)
+			}
+		}
+	}
+	return nil
+}
+
+// ImportCreatureFile adds the creatures from a file to the world near the given position.
+// Imported creatures arrive as fresh individuals: age is reset and energy is full.
+func (w *World) ImportCreatureFile(file *CreatureFile, pos Position) ([]*Entity, error) {
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+
+	sm := NewStateManager(w)
+	imported := make([]*Entity, 0, len(file.Creatures))
+	for i, record := range file.Creatures {
+		w.NextID++
+		entityPos := Position{
+			X: math.Max(0, math.Min(w.Config.Width-1, pos.X+float64(i)*1.5)),
+			Y: math.Max(0, math.Min(w.Config.Height-1, pos.Y)),
+		}
+
+		entity := NewEntity(w.Rand, w.NextID, []string{}, record.Species, entityPos)
+		for traitName, value := range record.Traits {
+			// Creature files travel between worlds, so skip traits this one doesn't know
+			// and keep the rest within range
+			definition, ok := w.TraitRegistry.Lookup(TraitSubjectCreature, traitName)
+			if !ok {
+				log.Printf("Skipping unknown trait %s on imported %s", traitName, record.Species)
+				continue
+			}
+			entity.SetTrait(traitName, definition.Clamp(value))
+		}
+		entity.Generation = record.Generation
+		entity.Classification = record.Classification
+		if record.MaxLifespan > 0 {
+			entity.MaxLifespan = record.MaxLifespan
+		}
+
+		// Molecular and rhythm systems depend on traits, so rebuild them now that traits are set
+		entity.MolecularNeeds = NewMolecularNeeds(entity)
+		entity.MolecularMetabolism = NewMolecularMetabolism(w.Rand, entity)
+		entity.MolecularProfile = CreateEntityMolecularProfile(entity)
+		entity.BioRhythm = NewBioRhythm(w.Rand, entity.ID, entity)
+		AddCasteStatusToEntity(w.Rand, entity)
+		if IsEntityInsectLike(entity) {
+			AddInsectTraitsToEntity(w.Rand, entity)
+			AddPollinatorTraitsToEntity(w.Rand, entity)
+		}
+
+		if w.CellularSystem != nil && (record.DNA != nil || record.Cellular != nil) {
+			dna := sm.restoreDNA(record.DNA)
+			if dna != nil {
+				dna.EntityID = entity.ID
+			}
+			if organism := sm.restoreCellular(record.Cellular, dna); organism != nil {
+				organism.EntityID = entity.ID
+				w.CellularSystem.OrganismMap[entity.ID] = organism
+			}
+		}
+
+		if w.NeuralAISystem != nil && record.NeuralNetwork != nil {
+			network := &EntityNeuralNetwork{}
+			if err := cloneViaJSON(record.NeuralNetwork, network); err != nil {
+				return imported, fmt.Errorf("failed to import neural network: %v", err)
+			}
+			network.ID = w.NeuralAISystem.NextNetworkID
+			w.NeuralAISystem.NextNetworkID++
+			network.EntityID = entity.ID
+			network.CreatedTick = w.Tick
+			network.LastUpdateTick = w.Tick
+			w.NeuralAISystem.EntityNetworks[entity.ID] = network
+			w.NeuralAISystem.TotalNetworks++
+		}
+
+		if w.CulturalKnowledgeSystem != nil && record.Culture != nil {
+			w.CulturalKnowledgeSystem.importMemory(entity.ID, record.Culture)
+		}
+
+		w.AllEntities = append(w.AllEntities, entity)
+		imported = append(imported, entity)
+	}
+
+	if w.CentralEventBus != nil {
+		w.CentralEventBus.EmitSystemEvent(w.Tick, "creature_imported", "creature_file", "creature_files",
+			fmt.Sprintf("Imported %d creature(s) of %s from \"%s\"", len(imported), file.Creatures[0].Species, file.Name),
+			&pos, map[string]interface{}{
+				"name":        file.Name,
+				"count":       len(imported),
+				"source_tick": file.SourceTick,
+			})
+	}
+
+	return imported, nil
+}
+
+// importMemory registers an imported cultural memory, giving its knowledge IDs in this world
+func (cks *CulturalKnowledgeSystem) importMemory(entityID int, source *CulturalMemory) {
+	memory := &CulturalMemory{}
+	if err := cloneViaJSON(source, memory); err != nil {
+		return
+	}
+
+	remapped := make(map[int]*CulturalKnowledge, len(memory.KnownKnowledge))
+	for _, knowledge := range memory.KnownKnowledge {
+		knowledge.ID = cks.NextKnowledgeID
+		cks.NextKnowledgeID++
+		knowledge.LearnerCount = 1
+		knowledge.TeacherCount = 1
+		cks.AllKnowledge[knowledge.ID] = knowledge
+		remapped[knowledge.ID] = knowledge
+	}
+
+	memory.EntityID = entityID
+	memory.KnownKnowledge = remapped
+	memory.RecentlyTaught = make([]int, 0)
+	memory.RecentlyLearned = make([]int, 0)
+	memory.MentorEntityID = -1
+	memory.StudentEntityIDs = make([]int, 0)
+	cks.EntityMemories[entityID] = memory
+}
+
+// SaveCreatureFile writes a creature file to disk
+func SaveCreatureFile(filename string, file *CreatureFile) error {
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal creature file: %v", err)
+	}
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write creature file: %v", err)
+	}
+	return nil
+}
+
+// LoadCreatureFile reads and validates a creature file from disk
+func LoadCreatureFile(filename string) (*CreatureFile, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read creature file: %v", err)
+	}
+	var file CreatureFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal creature file: %v", err)
+	}
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
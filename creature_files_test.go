@@ -0,0 +1,124 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func addCreatureTestEntity(world *World, species string) *Entity {
+	world.NextID++
+	entity := NewEntity(world.Rand, world.NextID, []string{"speed", "intelligence", "cooperation"}, species, Position{X: 10, Y: 10})
+	world.AllEntities = append(world.AllEntities, entity)
+	return entity
+}
+
+func TestExportCreatureFileIncludesGenomeBrainAndCulture(t *testing.T) {
+	world := newTestWorld(50, 10)
+	entity := addCreatureTestEntity(world, "herbivore")
+	entity.SetTrait("intelligence", 0.9)
+	world.NeuralAISystem.CreateNeuralNetwork(entity, world.Tick)
+	world.CulturalKnowledgeSystem.RegisterEntity(entity)
+
+	file, err := ExportCreatureFile(world, []int{entity.ID}, "Clever grazer", "")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if len(file.Creatures) != 1 {
+		t.Fatalf("Expected 1 creature, got %d", len(file.Creatures))
+	}
+
+	record := file.Creatures[0]
+	if record.Traits["intelligence"] != 0.9 {
+		t.Errorf("Expected exported intelligence 0.9, got %.2f", record.Traits["intelligence"])
+	}
+	if record.NeuralNetwork == nil || len(record.NeuralNetwork.Neurons) == 0 {
+		t.Error("Expected neural network to be exported")
+	}
+	if record.Culture == nil {
+		t.Error("Expected cultural memory to be exported")
+	}
+	if record.NeuralNetwork == world.NeuralAISystem.EntityNetworks[entity.ID] {
+		t.Error("Expected exported neural network to be a copy")
+	}
+}
+
+func TestExportBreedingPairValidation(t *testing.T) {
+	world := newTestWorld(50, 10)
+	a := addCreatureTestEntity(world, "herbivore")
+	b := addCreatureTestEntity(world, "herbivore")
+	c := addCreatureTestEntity(world, "predator")
+
+	if _, err := ExportCreatureFile(world, []int{a.ID, b.ID}, "", ""); err != nil {
+		t.Errorf("Expected same-species pair to export: %v", err)
+	}
+	if _, err := ExportCreatureFile(world, []int{a.ID, c.ID}, "", ""); err == nil {
+		t.Error("Expected mixed-species pair to be rejected")
+	}
+	if _, err := ExportCreatureFile(world, []int{a.ID, b.ID, c.ID}, "", ""); err == nil {
+		t.Error("Expected more than two creatures to be rejected")
+	}
+	if _, err := ExportCreatureFile(world, []int{999}, "", ""); err == nil {
+		t.Error("Expected missing entity to be rejected")
+	}
+}
+
+func TestCreatureFileRoundTripIntoNewWorld(t *testing.T) {
+	source := newTestWorld(50, 10)
+	a := addCreatureTestEntity(source, "omnivore")
+	b := addCreatureTestEntity(source, "omnivore")
+	source.NeuralAISystem.CreateNeuralNetwork(a, 0)
+	source.CulturalKnowledgeSystem.RegisterEntity(a)
+
+	file, err := ExportCreatureFile(source, []int{a.ID, b.ID}, "Pair", "A breeding pair")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "pair.creature.json")
+	if err := SaveCreatureFile(path, file); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := LoadCreatureFile(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	target := newTestWorld(50, 10)
+	addCreatureTestEntity(target, "herbivore")
+	imported, err := target.ImportCreatureFile(loaded, Position{X: 25, Y: 25})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(imported) != 2 || len(target.AllEntities) != 3 {
+		t.Fatalf("Expected 2 imported creatures alongside 1 native, got %d imported and %d total", len(imported), len(target.AllEntities))
+	}
+
+	first := imported[0]
+	if first.ID == 1 {
+		t.Error("Expected imported creature to get a fresh ID in the target world")
+	}
+	if first.GetTrait("speed") != a.GetTrait("speed") {
+		t.Errorf("Expected speed %.3f to survive import, got %.3f", a.GetTrait("speed"), first.GetTrait("speed"))
+	}
+	network := target.NeuralAISystem.EntityNetworks[first.ID]
+	if network == nil || network.EntityID != first.ID {
+		t.Error("Expected neural network to be attached to the imported creature")
+	}
+	memory := target.CulturalKnowledgeSystem.EntityMemories[first.ID]
+	if memory == nil || memory.EntityID != first.ID {
+		t.Fatal("Expected cultural memory to be attached to the imported creature")
+	}
+	for id := range memory.KnownKnowledge {
+		if target.CulturalKnowledgeSystem.AllKnowledge[id] == nil {
+			t.Errorf("Imported knowledge %d not registered in target world", id)
+		}
+	}
+}
+
+func TestCreatureFileRejectsForeignFormat(t *testing.T) {
+	world := newTestWorld(50, 10)
+	file := &CreatureFile{Format: "something-else", Version: 1, Creatures: []CreatureRecord{{Species: "x"}}}
+	if _, err := world.ImportCreatureFile(file, Position{}); err == nil {
+		t.Error("Expected foreign format to be rejected")
+	}
+}
@@ -89,6 +89,8 @@ type CulturalKnowledgeSystem struct {
 	BaseLearningSuccess float64 `json:"base_learning_success"` // Base success rate for learning
 	InnovationRate      float64 `json:"innovation_rate"`       // Rate of new knowledge creation
 	KnowledgeDecayRate  float64 `json:"knowledge_decay_rate"`  // Rate at which unused knowledge fades
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewCulturalKnowledgeSystem creates a new cultural knowledge system
@@ -102,6 +104,7 @@ func NewCulturalKnowledgeSystem() *CulturalKnowledgeSystem {
 		BaseLearningSuccess: 0.7,    // 70% success rate for learning
 		InnovationRate:      0.001,  // 0.1% chance per tick for innovation
 		KnowledgeDecayRate:  0.0001, // Very slow decay
+		rng:                 sharedRand,
 	}
 }
 
@@ -137,7 +140,8 @@ func (cks *CulturalKnowledgeSystem) initializeBasicKnowledge(entity *Entity, mem
 	cks.ensureBasicKnowledgeExists()
 
 	// Give entities knowledge based on their traits
-	for _, knowledge := range cks.AllKnowledge {
+	for _, knowledgeID := range sortedKeys(cks.AllKnowledge) {
+		knowledge := cks.AllKnowledge[knowledgeID]
 		learnChance := 0.0
 
 		switch knowledge.Type {
@@ -159,7 +163,7 @@ func (cks *CulturalKnowledgeSystem) initializeBasicKnowledge(entity *Entity, mem
 			learnChance = entity.GetTrait("aggression")*0.2 + entity.GetTrait("cooperation")*0.2
 		}
 
-		if rand.Float64() < learnChance {
+		if cks.rng.Float64() < learnChance {
 			cks.learnKnowledge(memory, knowledge)
 		}
 	}
@@ -215,7 +219,7 @@ func (cks *CulturalKnowledgeSystem) ensureBasicKnowledgeExists() {
 }
 
 // Update processes cultural knowledge for one tick
-func (cks *CulturalKnowledgeSystem) Update(entities []*Entity, tick int) {
+func (cks *CulturalKnowledgeSystem) Update(entities []*Entity, tick int, geometry WorldGeometry) {
 	// Register new entities
 	for _, entity := range entities {
 		if entity.IsAlive {
@@ -224,7 +228,7 @@ func (cks *CulturalKnowledgeSystem) Update(entities []*Entity, tick int) {
 	}
 
 	// Process knowledge teaching and learning
-	cks.processTeachingAndLearning(entities, tick)
+	cks.processTeachingAndLearning(entities, tick, geometry)
 
 	// Process knowledge innovation
 	cks.processInnovation(entities, tick)
@@ -240,8 +244,8 @@ func (cks *CulturalKnowledgeSystem) Update(entities []*Entity, tick int) {
 }
 
 // processTeachingAndLearning handles knowledge transfer between entities
-func (cks *CulturalKnowledgeSystem) processTeachingAndLearning(entities []*Entity, tick int) {
-	nearbyPairs := cks.findNearbyEntityPairs(entities)
+func (cks *CulturalKnowledgeSystem) processTeachingAndLearning(entities []*Entity, tick int, geometry WorldGeometry) {
+	nearbyPairs := cks.findNearbyEntityPairs(entities, geometry)
 
 	for _, pair := range nearbyPairs {
 		teacher := pair[0]
@@ -262,30 +266,31 @@ func (cks *CulturalKnowledgeSystem) processTeachingAndLearning(entities []*Entit
 			teachingChance *= 2.0
 		}
 
-		if rand.Float64() < teachingChance {
+		if cks.rng.Float64() < teachingChance {
 			cks.attemptKnowledgeTransfer(teacherMemory, studentMemory, tick)
 		}
 	}
 }
 
 // findNearbyEntityPairs finds entities close enough for knowledge transfer
-func (cks *CulturalKnowledgeSystem) findNearbyEntityPairs(entities []*Entity) [][2]*Entity {
+func (cks *CulturalKnowledgeSystem) findNearbyEntityPairs(entities []*Entity, geometry WorldGeometry) [][2]*Entity {
 	pairs := make([][2]*Entity, 0)
+	var index spatialLayer[*Entity]
+	index.sync(entities, entityPosition, true)
 
 	for i, entity1 := range entities {
 		if !entity1.IsAlive {
 			continue
 		}
 
-		for j := i + 1; j < len(entities); j++ {
+		for _, j := range index.query(entity1.Position, 3.0, entityPosition, geometry) {
 			entity2 := entities[j]
-			if !entity2.IsAlive {
+			if j <= i || !entity2.IsAlive {
 				continue
 			}
 
 			// Check if entities are close enough (within 3 units)
-			distance := math.Sqrt(math.Pow(entity1.Position.X-entity2.Position.X, 2) +
-				math.Pow(entity1.Position.Y-entity2.Position.Y, 2))
+			distance := geometry.Distance(entity1.Position, entity2.Position)
 
 			if distance <= 3.0 {
 				pairs = append(pairs, [2]*Entity{entity1, entity2})
@@ -315,7 +320,7 @@ func (cks *CulturalKnowledgeSystem) attemptKnowledgeTransfer(teacher, student *C
 	}
 
 	// Select random knowledge to teach
-	knowledge := teachableKnowledge[rand.Intn(len(teachableKnowledge))]
+	knowledge := teachableKnowledge[cks.rng.Intn(len(teachableKnowledge))]
 
 	// Calculate learning success chance
 	successChance := cks.BaseLearningSuccess * student.LearningAbility
@@ -327,7 +332,7 @@ func (cks *CulturalKnowledgeSystem) attemptKnowledgeTransfer(teacher, student *C
 	// Teacher's ability affects success
 	successChance *= (0.5 + teacher.TeachingAbility*0.5)
 
-	if rand.Float64() < successChance {
+	if cks.rng.Float64() < successChance {
 		// Learning successful
 		cks.learnKnowledge(student, knowledge)
 		teacher.RecentlyTaught = append(teacher.RecentlyTaught, knowledge.ID)
@@ -383,7 +388,7 @@ func (cks *CulturalKnowledgeSystem) processInnovation(entities []*Entity, tick i
 		knowledgeBonus := float64(len(memory.KnownKnowledge)) * 0.001
 		innovationChance += knowledgeBonus
 
-		if rand.Float64() < innovationChance {
+		if cks.rng.Float64() < innovationChance {
 			cks.createInnovation(entity, memory, tick)
 		}
 	}
@@ -395,8 +400,8 @@ func (cks *CulturalKnowledgeSystem) createInnovation(entity *Entity, memory *Cul
 	innovationType := cks.selectInnovationType(entity)
 
 	// Create new knowledge
-	effectiveness := 0.3 + rand.Float64()*0.4 // 0.3-0.7 initial effectiveness
-	complexity := 0.5 + rand.Float64()*0.3    // 0.5-0.8 complexity for innovations
+	effectiveness := 0.3 + cks.rng.Float64()*0.4 // 0.3-0.7 initial effectiveness
+	complexity := 0.5 + cks.rng.Float64()*0.3    // 0.5-0.8 complexity for innovations
 
 	innovation := &CulturalKnowledge{
 		ID:              cks.NextKnowledgeID,
@@ -437,19 +442,21 @@ func (cks *CulturalKnowledgeSystem) selectInnovationType(entity *Entity) Knowled
 
 	// Convert to cumulative weights
 	totalWeight := 0.0
-	for _, weight := range weights {
+	for _, knowledgeType := range sortedKeys(weights) {
+		weight := weights[knowledgeType]
 		totalWeight += weight
 	}
 
 	if totalWeight == 0 {
 		// Fallback to random selection
-		return KnowledgeType(rand.Intn(8))
+		return KnowledgeType(cks.rng.Intn(8))
 	}
 
-	randValue := rand.Float64() * totalWeight
+	randValue := cks.rng.Float64() * totalWeight
 	cumulative := 0.0
 
-	for knowledgeType, weight := range weights {
+	for _, knowledgeType := range sortedKeys(weights) {
+		weight := weights[knowledgeType]
 		cumulative += weight
 		if randValue <= cumulative {
 			return knowledgeType
@@ -461,15 +468,17 @@ func (cks *CulturalKnowledgeSystem) selectInnovationType(entity *Entity) Knowled
 
 // processKnowledgeDecay handles the gradual loss of unused knowledge
 func (cks *CulturalKnowledgeSystem) processKnowledgeDecay(tick int) {
-	for _, memory := range cks.EntityMemories {
-		for knowledgeID, knowledge := range memory.KnownKnowledge {
+	for _, entityID := range sortedKeys(cks.EntityMemories) {
+		memory := cks.EntityMemories[entityID]
+		for _, knowledgeID := range sortedKeys(memory.KnownKnowledge) {
+			knowledge := memory.KnownKnowledge[knowledgeID]
 			timeSinceLastUse := tick - knowledge.LastUsed
 
 			// Knowledge decays if not used recently
 			if timeSinceLastUse > 1000 { // After 1000 ticks without use
 				decayChance := knowledge.DecayRate * float64(timeSinceLastUse-1000) * 0.001
 
-				if rand.Float64() < decayChance {
+				if cks.rng.Float64() < decayChance {
 					delete(memory.KnownKnowledge, knowledgeID)
 					cks.KnowledgeLossEvents++
 				}
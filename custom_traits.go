@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+)
+
+// Custom trait cost functions, applied to the trait's position within its range
+const (
+	TraitCostNone        = "none"        // Free to carry
+	TraitCostLinear      = "linear"      // Cost rises evenly across the range
+	TraitCostQuadratic   = "quadratic"   // Cheap low in the range, steep at the top
+	TraitCostExponential = "exponential" // Steeper still at the top
+)
+
+// CustomTraitCost is the energy a creature pays each tick to carry a custom trait
+type CustomTraitCost struct {
+	Function    string  `json:"function"`    // One of the TraitCost constants; empty means none
+	Coefficient float64 `json:"coefficient"` // Energy per tick at the top of the range
+}
+
+// CustomTraitDefinition is a trait defined in configuration rather than code. Creatures
+// inherit and mutate it like any gene, pay its cost in energy, and its hooks shift
+// built-in traits so existing systems respond to it.
+type CustomTraitDefinition struct {
+	Name         string             `json:"name"`
+	Description  string             `json:"description"`
+	Min          float64            `json:"min"`
+	Max          float64            `json:"max"`
+	Initial      float64            `json:"initial"`      // Mean value in founding creatures
+	Variation    float64            `json:"variation"`    // Founders vary by up to this much either side of Initial
+	Heritability float64            `json:"heritability"` // Share of the inherited value a newborn keeps (0-1); the rest is drawn as for a founder
+	Cost         CustomTraitCost    `json:"cost"`
+	Hooks        map[string]float64 `json:"hooks"` // Built-in trait -> change per unit of this trait, e.g. {"speed": 0.3}
+}
+
+// Validate checks a definition against the built-in traits it would join
+func (d CustomTraitDefinition) Validate(registry *TraitRegistry) error {
+	if d.Name == "" {
+		return fmt.Errorf("custom trait name is required")
+	}
+	if existing, ok := registry.Lookup(TraitSubjectCreature, d.Name); ok && !existing.Custom {
+		return fmt.Errorf("custom trait %s would replace a built-in trait", d.Name)
+	}
+	for _, v := range []float64{d.Min, d.Max, d.Initial, d.Variation, d.Heritability, d.Cost.Coefficient} {
+		if isInvalidNumber(v) {
+			return fmt.Errorf("custom trait %s has a value that is not a number", d.Name)
+		}
+	}
+	if d.Min >= d.Max {
+		return fmt.Errorf("custom trait %s needs min below max", d.Name)
+	}
+	if d.Initial < d.Min || d.Initial > d.Max {
+		return fmt.Errorf("custom trait %s initial value %v is outside [%v, %v]", d.Name, d.Initial, d.Min, d.Max)
+	}
+	if d.Variation < 0 {
+		return fmt.Errorf("custom trait %s variation cannot be negative", d.Name)
+	}
+	if d.Heritability < 0 || d.Heritability > 1 {
+		return fmt.Errorf("custom trait %s heritability must be between 0 and 1", d.Name)
+	}
+	switch d.Cost.Function {
+	case "", TraitCostNone, TraitCostLinear, TraitCostQuadratic, TraitCostExponential:
+	default:
+		return fmt.Errorf("custom trait %s has unknown cost function %q", d.Name, d.Cost.Function)
+	}
+	if d.Cost.Coefficient < 0 {
+		return fmt.Errorf("custom trait %s cost cannot be negative", d.Name)
+	}
+	for _, target := range sortedKeys(d.Hooks) {
+		definition, ok := registry.Lookup(TraitSubjectCreature, target)
+		if !ok || definition.Custom || definition.Kind != TraitKindGene {
+			return fmt.Errorf("custom trait %s hooks into %s, which is not a built-in gene", d.Name, target)
+		}
+		if isInvalidNumber(d.Hooks[target]) {
+			return fmt.Errorf("custom trait %s hook into %s is not a number", d.Name, target)
+		}
+	}
+	return nil
+}
+
+// clamp limits a value to the trait's range
+func (d CustomTraitDefinition) clamp(value float64) float64 {
+	return math.Max(d.Min, math.Min(d.Max, value))
+}
+
+// founderValue draws a value for a creature that did not inherit the trait
+func (d CustomTraitDefinition) founderValue(rng *rand.Rand) float64 {
+	return d.clamp(d.Initial + (rng.Float64()*2-1)*d.Variation)
+}
+
+// energyCost returns the energy per tick a creature pays to carry the trait at a value
+func (d CustomTraitDefinition) energyCost(value float64) float64 {
+	position := (d.clamp(value) - d.Min) / (d.Max - d.Min)
+	switch d.Cost.Function {
+	case TraitCostLinear:
+		return d.Cost.Coefficient * position
+	case TraitCostQuadratic:
+		return d.Cost.Coefficient * position * position
+	case TraitCostExponential:
+		return d.Cost.Coefficient * (math.Exp(position) - 1) / (math.E - 1)
+	}
+	return 0
+}
+
+// registryDefinition describes the trait for the trait registry
+func (d CustomTraitDefinition) registryDefinition() TraitDefinition {
+	return TraitDefinition{
+		Name:        d.Name,
+		Subject:     TraitSubjectCreature,
+		Kind:        TraitKindGene,
+		Min:         d.Min,
+		Max:         d.Max,
+		Description: d.Description,
+		Systems:     append([]string{"custom"}, sortedKeys(d.Hooks)...),
+		Custom:      true,
+	}
+}
+
+// RegisterCustomTraits validates custom trait definitions and adds them to the registry
+func (r *TraitRegistry) RegisterCustomTraits(definitions []CustomTraitDefinition) error {
+	seen := make(map[string]bool, len(definitions))
+	for _, definition := range definitions {
+		if seen[definition.Name] {
+			return fmt.Errorf("custom trait %s is defined more than once", definition.Name)
+		}
+		seen[definition.Name] = true
+		if err := definition.Validate(r); err != nil {
+			return err
+		}
+		if err := r.Register(definition.registryDefinition()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadCustomTraits reads custom trait definitions from a JSON file holding an array of them
+func LoadCustomTraits(filename string) ([]CustomTraitDefinition, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom traits: %v", err)
+	}
+	var definitions []CustomTraitDefinition
+	if err := json.Unmarshal(data, &definitions); err != nil {
+		return nil, fmt.Errorf("failed to parse custom traits: %v", err)
+	}
+	if err := NewTraitRegistry().RegisterCustomTraits(definitions); err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
+
+// CustomTraitSystem gives creatures the custom traits in the world configuration,
+// applies heritability at birth, charges each trait's energy cost and expresses hooks
+type CustomTraitSystem struct {
+	Definitions []CustomTraitDefinition
+	known       map[int]bool // Creatures seen on the previous tick
+
+	rng *rand.Rand // Its RNG stream, or the world's source
+}
+
+// NewCustomTraitSystem creates a system for the given definitions
+func NewCustomTraitSystem(definitions []CustomTraitDefinition) *CustomTraitSystem {
+	return &CustomTraitSystem{Definitions: definitions, known: make(map[int]bool), rng: sharedRand}
+}
+
+// Update runs the custom traits for every living creature
+func (cts *CustomTraitSystem) Update(w *World) {
+	if len(cts.Definitions) == 0 {
+		return
+	}
+
+	known := make(map[int]bool, len(w.AllEntities))
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		known[entity.ID] = true
+		if !cts.known[entity.ID] {
+			cts.express(entity)
+		}
+		cts.applyHooks(entity)
+
+		for _, definition := range cts.Definitions {
+			entity.Energy -= definition.energyCost(entity.Traits[definition.Name].Value)
+		}
+	}
+	cts.known = known
+}
+
+// express gives a newly seen creature its custom traits. Founders draw them afresh;
+// newborns keep the heritable share of what they inherited.
+func (cts *CustomTraitSystem) express(entity *Entity) {
+	newborn := entity.Generation > 0 && entity.Age <= 1
+	for _, definition := range cts.Definitions {
+		trait, inherited := entity.Traits[definition.Name]
+		value := trait.Value
+		switch {
+		case !inherited:
+			value = definition.founderValue(cts.rng)
+		case newborn:
+			value = definition.Heritability*value + (1-definition.Heritability)*definition.founderValue(cts.rng)
+		}
+		entity.Traits[definition.Name] = Trait{Name: definition.Name, Value: definition.clamp(value)}
+	}
+}
+
+// applyHooks recomputes how far the creature's custom traits shift its built-in traits
+func (cts *CustomTraitSystem) applyHooks(entity *Entity) {
+	var offsets map[string]float64
+	for _, definition := range cts.Definitions {
+		value := entity.Traits[definition.Name].Value
+		for _, target := range sortedKeys(definition.Hooks) {
+			if offsets == nil {
+				offsets = make(map[string]float64)
+			}
+			offsets[target] += definition.Hooks[target] * value
+		}
+	}
+	entity.traitOffsets = offsets
+}
+
+// setCustomTraits registers the custom traits in the world's trait registry and starts
+// running them; invalid definitions leave the world with the built-in traits only
+func (w *World) setCustomTraits(definitions []CustomTraitDefinition) error {
+	w.TraitRegistry = NewTraitRegistry()
+	w.CustomTraits = NewCustomTraitSystem(nil)
+	if err := w.TraitRegistry.RegisterCustomTraits(definitions); err != nil {
+		w.TraitRegistry = NewTraitRegistry()
+		return err
+	}
+	w.CustomTraits = NewCustomTraitSystem(definitions)
+	w.CustomTraits.rng = w.Rand
+	return nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// glowTrait is a costly custom trait that makes creatures faster
+var glowTrait = CustomTraitDefinition{
+	Name:         "bioluminescence",
+	Description:  "Light produced to signal mates",
+	Min:          0,
+	Max:          1,
+	Initial:      0.5,
+	Variation:    0.1,
+	Heritability: 1,
+	Cost:         CustomTraitCost{Function: TraitCostQuadratic, Coefficient: 0.4},
+	Hooks:        map[string]float64{"speed": 0.6},
+}
+
+// newCustomTraitTestWorld creates a populated world evolving the given custom traits
+func newCustomTraitTestWorld(definitions ...CustomTraitDefinition) *World {
+	config := steppingTestConfig(151)
+	config.CustomTraits = definitions
+	return newPopulatedTestWorld(config, false)
+}
+
+func TestCustomTraitValidation(t *testing.T) {
+	registry := NewTraitRegistry()
+	if err := registry.RegisterCustomTraits([]CustomTraitDefinition{glowTrait}); err != nil {
+		t.Fatalf("Expected the glow trait to be valid: %v", err)
+	}
+	if definition, ok := registry.Lookup(TraitSubjectCreature, glowTrait.Name); !ok || !definition.Custom || definition.Max != 1 {
+		t.Errorf("Expected the glow trait registered as custom, got %+v", definition)
+	}
+
+	broken := map[string]func(d *CustomTraitDefinition){
+		"built-in name":     func(d *CustomTraitDefinition) { d.Name = "speed" },
+		"empty range":       func(d *CustomTraitDefinition) { d.Max = d.Min },
+		"initial outside":   func(d *CustomTraitDefinition) { d.Initial = 2 },
+		"heritability > 1":  func(d *CustomTraitDefinition) { d.Heritability = 1.5 },
+		"unknown cost":      func(d *CustomTraitDefinition) { d.Cost.Function = "cubic" },
+		"hook into nothing": func(d *CustomTraitDefinition) { d.Hooks = map[string]float64{"telepathy": 1} },
+		"hook into state":   func(d *CustomTraitDefinition) { d.Hooks = map[string]float64{"hive_id": 1} },
+	}
+	for name, breakIt := range broken {
+		definition := glowTrait
+		breakIt(&definition)
+		if err := NewTraitRegistry().RegisterCustomTraits([]CustomTraitDefinition{definition}); err == nil {
+			t.Errorf("Expected a definition with %s to be rejected", name)
+		}
+	}
+	if err := NewTraitRegistry().RegisterCustomTraits([]CustomTraitDefinition{glowTrait, glowTrait}); err == nil {
+		t.Error("Expected a duplicate definition to be rejected")
+	}
+}
+
+func TestCustomTraitCostsAndHooks(t *testing.T) {
+	world := newCustomTraitTestWorld(glowTrait)
+	creature := world.AllEntities[0]
+	speedGene := creature.Traits["speed"].Value
+	energy := creature.Energy
+
+	world.CustomTraits.Update(world)
+	glow := creature.Traits[glowTrait.Name].Value
+	if glow < 0.4 || glow > 0.6 {
+		t.Fatalf("Expected founders to glow around 0.5, got %.2f", glow)
+	}
+	if cost := energy - creature.Energy; math.Abs(cost-0.4*glow*glow) > 1e-9 {
+		t.Errorf("Expected a quadratic cost of %.3f, got %.3f", 0.4*glow*glow, cost)
+	}
+	if math.Abs(creature.GetTrait("speed")-(speedGene+0.6*glow)) > 1e-9 || creature.Traits["speed"].Value != speedGene {
+		t.Errorf("Expected glowing to express as speed without changing the gene, got %.3f from %.3f", creature.GetTrait("speed"), speedGene)
+	}
+
+	// Systems that nudge a trait keep the hook out of the gene
+	creature.SetTrait("speed", creature.GetTrait("speed")+0.1)
+	if math.Abs(creature.Traits["speed"].Value-(speedGene+0.1)) > 1e-9 {
+		t.Errorf("Expected the nudge alone stored, got gene %.3f from %.3f", creature.Traits["speed"].Value, speedGene)
+	}
+
+	// Offspring inherit the genes, not the hooks
+	child := Crossover(sharedRand, creature, creature, world.NextID, creature.Species)
+	if child.Traits["speed"].Value != creature.Traits["speed"].Value {
+		t.Errorf("Expected the child to inherit the speed gene, got %.3f", child.Traits["speed"].Value)
+	}
+}
+
+func TestCustomTraitHeritability(t *testing.T) {
+	world := newCustomTraitTestWorld(glowTrait, CustomTraitDefinition{
+		Name: "crest", Min: -1, Max: 1, Initial: -0.5, Heritability: 0,
+	})
+	world.CustomTraits.Update(world)
+
+	parent := world.AllEntities[0]
+	parent.Traits[glowTrait.Name] = Trait{Name: glowTrait.Name, Value: 0.9}
+	parent.Traits["crest"] = Trait{Name: "crest", Value: 0.9}
+	child := Crossover(sharedRand, parent, parent, world.NextID, parent.Species)
+	world.NextID++
+	world.AllEntities = append(world.AllEntities, child)
+
+	world.CustomTraits.Update(world)
+	if child.Traits[glowTrait.Name].Value != 0.9 {
+		t.Errorf("Expected a fully heritable trait passed on, got %.2f", child.Traits[glowTrait.Name].Value)
+	}
+	if child.Traits["crest"].Value != -0.5 {
+		t.Errorf("Expected a non-heritable trait drawn afresh, got %.2f", child.Traits["crest"].Value)
+	}
+}
+
+func TestCustomTraitsTravelWithSaves(t *testing.T) {
+	dir := t.TempDir()
+	traitsFile := filepath.Join(dir, "traits.json")
+	if err := os.WriteFile(traitsFile, []byte(`[{"name": "bioluminescence", "min": 0, "max": 1, "initial": 0.5,
+		"heritability": 0.8, "cost": {"function": "linear", "coefficient": 0.1}, "hooks": {"metabolism": 0.5}}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	definitions, err := LoadCustomTraits(traitsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	world := newCustomTraitTestWorld(definitions...)
+	if _, err := world.Step(5); err != nil {
+		t.Fatal(err)
+	}
+	saveFile := filepath.Join(dir, "save.json")
+	if err := NewStateManager(world).SaveToFile(saveFile); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewWorld(WorldConfig{Width: 100, Height: 100, GridWidth: 10, GridHeight: 10})
+	if err := NewStateManager(loaded).LoadFromFile(saveFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loaded.TraitRegistry.Lookup(TraitSubjectCreature, "bioluminescence"); !ok || len(loaded.CustomTraits.Definitions) != 1 {
+		t.Fatal("Expected the custom trait restored with the save")
+	}
+	for _, entity := range loaded.AllEntities {
+		if _, ok := entity.Traits["bioluminescence"]; entity.IsAlive && !ok {
+			t.Errorf("Expected entity %d to keep its custom trait", entity.ID)
+		}
+	}
+
+	if err := os.WriteFile(traitsFile, []byte(`[{"name": "bioluminescence", "min": 1, "max": 0}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCustomTraits(traitsFile); err == nil {
+		t.Error("Expected an invalid traits file to be rejected")
+	}
+}
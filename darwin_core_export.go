@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// darwinCoreEpoch anchors simulated days to a calendar date for eventDate
+var darwinCoreEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// DarwinCoreColumns are the Darwin Core terms written to the occurrence CSV, in order
+var DarwinCoreColumns = []string{
+	"occurrenceID",
+	"basisOfRecord",
+	"datasetName",
+	"kingdom",
+	"scientificName",
+	"organismID",
+	"individualCount",
+	"occurrenceStatus",
+	"eventDate",
+	"verbatimEventDate",
+	"decimalLatitude",
+	"decimalLongitude",
+	"geodeticDatum",
+	"verbatimCoordinates",
+	"habitat",
+}
+
+// DarwinCoreRecord is a single simulated occurrence using Darwin Core terms
+type DarwinCoreRecord struct {
+	OccurrenceID        string  `json:"occurrenceID"`
+	BasisOfRecord       string  `json:"basisOfRecord"`
+	DatasetName         string  `json:"datasetName"`
+	Kingdom             string  `json:"kingdom"`
+	ScientificName      string  `json:"scientificName"`
+	OrganismID          int     `json:"organismID"`
+	IndividualCount     int     `json:"individualCount"`
+	OccurrenceStatus    string  `json:"occurrenceStatus"`
+	EventDate           string  `json:"eventDate"`
+	VerbatimEventDate   string  `json:"verbatimEventDate"`
+	DecimalLatitude     float64 `json:"decimalLatitude"`
+	DecimalLongitude    float64 `json:"decimalLongitude"`
+	GeodeticDatum       string  `json:"geodeticDatum"`
+	VerbatimCoordinates string  `json:"verbatimCoordinates"`
+	Habitat             string  `json:"habitat"`
+}
+
+// BuildDarwinCoreRecords creates one occurrence record per living organism at the current tick.
+// World coordinates are projected onto the full latitude/longitude range so that
+// standard species distribution modeling tools accept them.
+func BuildDarwinCoreRecords(world *World, includePlants bool) []DarwinCoreRecord {
+	eventDate := darwinCoreEventDate(world)
+	verbatimDate := fmt.Sprintf("tick %d", world.Tick)
+	records := make([]DarwinCoreRecord, 0, len(world.AllEntities))
+
+	newRecord := func(kind string, id int, kingdom, name string, pos Position) DarwinCoreRecord {
+		lat, lon := darwinCoreCoordinates(world, pos)
+		gx, gy := world.worldToGridCoords(pos.X, pos.Y)
+		return DarwinCoreRecord{
+			OccurrenceID:        fmt.Sprintf("evosim:%s:%d:%d", kind, id, world.Tick),
+			BasisOfRecord:       "MachineObservation",
+			DatasetName:         "EvoSim simulated occurrences",
+			Kingdom:             kingdom,
+			ScientificName:      name,
+			OrganismID:          id,
+			IndividualCount:     1,
+			OccurrenceStatus:    "present",
+			EventDate:           eventDate,
+			VerbatimEventDate:   verbatimDate,
+			DecimalLatitude:     lat,
+			DecimalLongitude:    lon,
+			GeodeticDatum:       "WGS84",
+			VerbatimCoordinates: fmt.Sprintf("%.2f,%.2f", pos.X, pos.Y),
+			Habitat:             world.getBiomeName(world.Grid[gy][gx].Biome),
+		}
+	}
+
+	for _, entity := range world.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		records = append(records, newRecord("entity", entity.ID, "Animalia", entity.Species, entity.Position))
+	}
+
+	if includePlants {
+		configs := GetPlantConfigs()
+		for _, plant := range world.AllPlants {
+			if !plant.IsAlive {
+				continue
+			}
+			records = append(records, newRecord("plant", plant.ID, "Plantae", configs[plant.Type].Name, plant.Position))
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].OccurrenceID < records[j].OccurrenceID
+	})
+
+	return records
+}
+
+// darwinCoreEventDate converts the current tick into an ISO 8601 date counted from the epoch
+func darwinCoreEventDate(world *World) string {
+	ticksPerDay := 1
+	if world.AdvancedTimeSystem != nil && world.AdvancedTimeSystem.DayLength > 0 {
+		ticksPerDay = world.AdvancedTimeSystem.DayLength
+	}
+	return darwinCoreEpoch.AddDate(0, 0, world.Tick/ticksPerDay).Format("2006-01-02")
+}
+
+// darwinCoreCoordinates projects a world position onto latitude and longitude
+func darwinCoreCoordinates(world *World, pos Position) (float64, float64) {
+	lon := -180.0 + (pos.X/world.Config.Width)*360.0
+	lat := 90.0 - (pos.Y/world.Config.Height)*180.0
+	return roundCoordinate(lat), roundCoordinate(lon)
+}
+
+// WriteDarwinCoreCSV writes occurrence records as a Darwin Core CSV with a header row
+func WriteDarwinCoreCSV(w io.Writer, records []DarwinCoreRecord) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(DarwinCoreColumns); err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		row := []string{
+			record.OccurrenceID,
+			record.BasisOfRecord,
+			record.DatasetName,
+			record.Kingdom,
+			record.ScientificName,
+			strconv.Itoa(record.OrganismID),
+			strconv.Itoa(record.IndividualCount),
+			record.OccurrenceStatus,
+			record.EventDate,
+			record.VerbatimEventDate,
+			strconv.FormatFloat(record.DecimalLatitude, 'f', -1, 64),
+			strconv.FormatFloat(record.DecimalLongitude, 'f', -1, 64),
+			record.GeodeticDatum,
+			record.VerbatimCoordinates,
+			record.Habitat,
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// runOccurrencesExportCommand writes a save's occurrence records in Darwin Core
+func runOccurrencesExportCommand(args []string) error {
+	fs := newCommandFlags("occurrences")
+	plants := fs.Bool("plants", false, "Include plants as well as creatures")
+	format := fs.String("format", "csv", "csv or json")
+	out := fs.String("out", "", "File to write (stdout when empty)")
+	if _, err := parseCommandFlags(fs, args, commandEnvPrefix(fs.Name()), nil); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return commandUsage("occurrences")
+	}
+	if *format != "csv" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	world, err := LoadWorldFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	records := BuildDarwinCoreRecords(world, *plants)
+	return writeCommandOutput(*out, func(w io.Writer) error {
+		if *format == "json" {
+			return json.NewEncoder(w).Encode(records)
+		}
+		return WriteDarwinCoreCSV(w, records)
+	})
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestDarwinCoreRecordsForLivingOrganisms(t *testing.T) {
+	world := newEmptyTestWorld(100)
+
+	alive := NewEntity(sharedRand, 1, []string{}, "herbivore", Position{X: 50, Y: 50})
+	dead := NewEntity(sharedRand, 2, []string{}, "predator", Position{X: 10, Y: 10})
+	dead.IsAlive = false
+	world.AllEntities = []*Entity{alive, dead}
+	world.AllPlants = []*Plant{NewPlant(sharedRand, 1, PlantTree, Position{X: 0, Y: 0})}
+
+	records := BuildDarwinCoreRecords(world, false)
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 occurrence without plants, got %d", len(records))
+	}
+
+	record := records[0]
+	if record.ScientificName != "herbivore" || record.Kingdom != "Animalia" {
+		t.Errorf("Unexpected taxon %s/%s", record.Kingdom, record.ScientificName)
+	}
+	if record.DecimalLatitude != 0 || record.DecimalLongitude != 0 {
+		t.Errorf("Expected world center to map to 0,0, got %.3f,%.3f", record.DecimalLatitude, record.DecimalLongitude)
+	}
+	if record.BasisOfRecord != "MachineObservation" {
+		t.Errorf("Expected MachineObservation, got %s", record.BasisOfRecord)
+	}
+
+	withPlants := BuildDarwinCoreRecords(world, true)
+	if len(withPlants) != 2 {
+		t.Fatalf("Expected 2 occurrences with plants, got %d", len(withPlants))
+	}
+	for _, r := range withPlants {
+		if r.Kingdom == "Plantae" && (r.DecimalLatitude != 90 || r.DecimalLongitude != -180) {
+			t.Errorf("Expected plant at world origin to map to 90,-180, got %.3f,%.3f", r.DecimalLatitude, r.DecimalLongitude)
+		}
+	}
+}
+
+func TestDarwinCoreEventDateFollowsTicks(t *testing.T) {
+	world := newEmptyTestWorld(100)
+	world.AdvancedTimeSystem.DayLength = 10
+	world.Tick = 325
+
+	if date := darwinCoreEventDate(world); date != "2000-02-02" {
+		t.Errorf("Expected tick 325 at 10 ticks/day to be 2000-02-02, got %s", date)
+	}
+}
+
+func TestWriteDarwinCoreCSV(t *testing.T) {
+	world := newEmptyTestWorld(100)
+	world.AllEntities = []*Entity{
+		NewEntity(sharedRand, 1, []string{}, "herbivore", Position{X: 20, Y: 30}),
+		NewEntity(sharedRand, 2, []string{}, "omnivore", Position{X: 70, Y: 80}),
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDarwinCoreCSV(&buf, BuildDarwinCoreRecords(world, false)); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d rows", len(rows))
+	}
+	if rows[0][0] != "occurrenceID" || len(rows[0]) != len(DarwinCoreColumns) {
+		t.Errorf("Unexpected header %v", rows[0])
+	}
+	for _, row := range rows[1:] {
+		if len(row) != len(DarwinCoreColumns) {
+			t.Errorf("Expected %d columns, got %d", len(DarwinCoreColumns), len(row))
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import "math"
+
+// dayPeriods is the number of times of day the day-night cycle passes through, Dawn to LateNight
+const dayPeriods = 8
+
+// twilight is how far below the horizon the sun still lights the sky, as the sine of its elevation
+const twilight = 0.25
+
+// DayNightSystem is the world clock's day-night cycle. It follows the advanced time system's
+// tick and season, works out how high the sun stands over each row of the grid, and from that
+// the daylight on each cell. The top row lies MaxLatitude north and the bottom row as far
+// south (a torus repeats the bands, see rowLatitude), so summer days are long and bright in the north and short in the south, and the
+// equator sees even days all year. Dawn opens each day and noon falls a quarter of the way
+// through it, matching the times of day the rest of the simulation runs on.
+type DayNightSystem struct {
+	tick        int
+	phase       float64   // Share of the day gone since dawn, 0-1
+	declination float64   // Latitude the sun stands overhead at, in degrees
+	latitudes   []float64 // Latitude of each grid row, in degrees north
+	light       []float64 // Daylight on each grid row, 0-1
+}
+
+// NewDayNightSystem creates a day-night cycle that works out the light on its first use
+func NewDayNightSystem() *DayNightSystem {
+	return &DayNightSystem{tick: -1}
+}
+
+// Update works out the sun's position and the light on every row for the current tick
+func (dn *DayNightSystem) Update(w *World) {
+	settings := w.SimConfig.DayNight
+	clock := w.AdvancedTimeSystem
+	length := max(1, settings.DayLength)
+	dn.tick = clock.WorldTick
+	dn.phase = float64(clock.WorldTick%length) / float64(length)
+
+	seasonLength := max(1, clock.SeasonLength)
+	year := float64(int(clock.Season)*seasonLength+clock.SeasonDay) / float64(4*seasonLength)
+	dn.declination = settings.AxialTilt * math.Sin(2*math.Pi*year)
+	hourAngle := 2 * math.Pi * (dn.phase - 0.25)
+
+	rows := w.Config.GridHeight
+	dn.latitudes = resizeFloats(dn.latitudes, rows)
+	dn.light = resizeFloats(dn.light, rows)
+	for y := range rows {
+		dn.latitudes[y] = rowLatitude(w.Config.Geometry, settings.MaxLatitude, y, rows)
+		dn.light[y] = daylight(dn.latitudes[y], dn.declination, hourAngle, settings.NightLight)
+	}
+}
+
+// resizeFloats returns a slice of n values, reusing values when it is long enough
+func resizeFloats(values []float64, n int) []float64 {
+	if cap(values) < n {
+		return make([]float64, n)
+	}
+	return values[:n]
+}
+
+// daylight is the light at a latitude with the sun overhead at declination, hourAngle
+// radians from noon, never darker than the night sky
+func daylight(latitude, declination, hourAngle, nightLight float64) float64 {
+	lat, dec := latitude*math.Pi/180, declination*math.Pi/180
+	elevation := math.Sin(lat)*math.Sin(dec) + math.Cos(lat)*math.Cos(dec)*math.Cos(hourAngle)
+	return math.Max(nightLight, math.Min(1, (elevation+twilight)/(1+twilight)))
+}
+
+// current reports whether the light was worked out for a tick and grid
+func (dn *DayNightSystem) current(tick, rows int) bool {
+	return dn.tick == tick && len(dn.light) == rows
+}
+
+// LightAt returns the daylight on a grid row, 0-1
+func (dn *DayNightSystem) LightAt(gridY int) float64 {
+	if dn == nil || gridY < 0 || gridY >= len(dn.light) {
+		return 1
+	}
+	return dn.light[gridY]
+}
+
+// dayPhaseTime is the time of day a phase of the cycle falls in
+func dayPhaseTime(phase float64) TimeOfDay {
+	return TimeOfDay(min(dayPeriods-1, int(phase*dayPeriods)))
+}
+
+// DayNightRow is the daylight on one row of the grid
+type DayNightRow struct {
+	Row      int     `json:"row"`
+	Latitude float64 `json:"latitude"` // Degrees north, negative south of the equator
+	Light    float64 `json:"light"`
+}
+
+// DayNightStatus reports the world clock and the daylight across the grid
+type DayNightStatus struct {
+	Settings    DayNightConfig `json:"settings"`
+	Tick        int            `json:"tick"`
+	Day         int            `json:"day"`   // Day-night cycles since the world began
+	Phase       float64        `json:"phase"` // Share of the day gone since dawn, 0-1
+	TimeOfDay   string         `json:"time_of_day"`
+	IsNight     bool           `json:"is_night"`
+	Season      string         `json:"season"`
+	Declination float64        `json:"declination"` // Latitude the sun stands overhead at
+	Rows        []DayNightRow  `json:"rows"`        // Daylight on each row, north to south
+}
+
+// Status reports the clock and the light on each row
+func (dn *DayNightSystem) Status(w *World) DayNightStatus {
+	settings := w.SimConfig.DayNight
+	status := DayNightStatus{
+		Settings:    settings,
+		Tick:        dn.tick,
+		Day:         dn.tick / max(1, settings.DayLength),
+		Phase:       dn.phase,
+		TimeOfDay:   timeOfDayName(dayPhaseTime(dn.phase)),
+		IsNight:     TimeState{TimeOfDay: dayPhaseTime(dn.phase)}.IsNight(),
+		Season:      seasonToString(w.AdvancedTimeSystem.Season),
+		Declination: dn.declination,
+		Rows:        make([]DayNightRow, len(dn.light)),
+	}
+	for y, light := range dn.light {
+		status.Rows[y] = DayNightRow{Row: y, Latitude: dn.latitudes[y], Light: light}
+	}
+	return status
+}
+
+// dayNight returns the world's day-night cycle brought up to date with its clock, creating it
+// for worlds built without one
+func (w *World) dayNight() *DayNightSystem {
+	if w.DayNight == nil {
+		w.DayNight = NewDayNightSystem()
+	}
+	if !w.DayNight.current(w.AdvancedTimeSystem.WorldTick, w.Config.GridHeight) {
+		w.DayNight.Update(w)
+	}
+	return w.DayNight
+}
+
+// daylightAt returns the daylight on a grid row
+func (w *World) daylightAt(gridY int) float64 {
+	return w.dayNight().LightAt(gridY)
+}
+
+// photosynthesisLight scales photosynthesis by daylight, raising it in full sun and lowering
+// it in the dark by the configured share
+func (w *World) photosynthesisLight(gridY int) float64 {
+	return math.Max(0, 1+w.SimConfig.DayNight.PhotosynthesisLight*(2*w.daylightAt(gridY)-1))
+}
+
+// darkHuntModifier scales a hunter's kill chance by the dark around it: night hunters strike
+// more often in the dark and day hunters less, in proportion to their circadian preference
+func (w *World) darkHuntModifier(hunter *Entity) float64 {
+	_, gridY := w.gridCell(hunter.Position)
+	darkness := 1 - w.daylightAt(gridY)
+	preference := hunter.GetTrait("circadian_preference")
+	return math.Max(0, 1-w.SimConfig.DayNight.NightHunting*preference*darkness)
+}
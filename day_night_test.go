@@ -0,0 +1,120 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+// setClock moves a world's clock to a tick and season
+func setClock(world *World, tick int, season Season) *DayNightSystem {
+	world.AdvancedTimeSystem.WorldTick = tick
+	world.AdvancedTimeSystem.Season = season
+	world.AdvancedTimeSystem.SeasonDay = 0
+	return world.dayNight()
+}
+
+func TestDaylightFollowsTheClockSeasonAndLatitude(t *testing.T) {
+	world := newSteppingTestWorld(61)
+	rows := world.Config.GridHeight
+	nightLight := world.SimConfig.DayNight.NightLight
+
+	// At an equinox noon the equator is brightest and the hemispheres mirror each other
+	noon := setClock(world, 2, Spring).Status(world)
+	if noon.TimeOfDay != "Midday" || noon.IsNight || noon.Declination != 0 {
+		t.Fatalf("Expected an equinox noon, got %+v", noon)
+	}
+	north, middle, south := noon.Rows[0], noon.Rows[rows/2], noon.Rows[rows-1]
+	if north.Latitude <= 0 || south.Latitude != -north.Latitude || north.Light != south.Light || middle.Light <= north.Light {
+		t.Errorf("Expected the light to fall off evenly toward both poles, got %+v %+v %+v", north, middle, south)
+	}
+
+	// At midnight only the night sky lights the map
+	midnight := setClock(world, 6, Spring).Status(world)
+	if !midnight.IsNight || midnight.Rows[rows/2].Light != nightLight || midnight.Day != 0 {
+		t.Errorf("Expected a dark midnight, got %+v", midnight)
+	}
+
+	// In northern summer the north sees the longer, brighter day
+	summer := setClock(world, 2, Summer)
+	if summer.declination != world.SimConfig.DayNight.AxialTilt || summer.LightAt(0) <= summer.LightAt(rows-1) {
+		t.Errorf("Expected the north brighter in summer, got %v north and %v south", summer.LightAt(0), summer.LightAt(rows-1))
+	}
+	if dusk := setClock(world, 4, Summer); dusk.LightAt(0) <= nightLight || dusk.LightAt(rows-1) != nightLight {
+		t.Errorf("Expected the north still lit while the south is dark on a summer evening, got %v north and %v south", dusk.LightAt(0), dusk.LightAt(rows-1))
+	}
+}
+
+func TestTimeOfDayFollowsTheDayLength(t *testing.T) {
+	config := DefaultSimulationConfig()
+	config.DayNight.DayLength = 24
+	world := NewWorldWithConfig(DefaultDeterminismConfig().World, config)
+	world.AdvancedTimeSystem.SeasonLength = 1000
+
+	seen := make(map[TimeOfDay]int)
+	for i := 0; i < 24; i++ {
+		world.AdvancedTimeSystem.Update()
+		status := world.dayNight().Status(world)
+		if status.TimeOfDay != timeOfDayName(world.AdvancedTimeSystem.TimeOfDay) {
+			t.Fatalf("Tick %d: expected the clock and the time system to agree, got %s and %s",
+				status.Tick, status.TimeOfDay, timeOfDayName(world.AdvancedTimeSystem.TimeOfDay))
+		}
+		seen[world.AdvancedTimeSystem.TimeOfDay]++
+	}
+	for timeOfDay := Dawn; timeOfDay <= LateNight; timeOfDay++ {
+		if seen[timeOfDay] != 3 {
+			t.Errorf("Expected %s to last 3 of the day's 24 ticks, got %d", timeOfDayName(timeOfDay), seen[timeOfDay])
+		}
+	}
+
+	config.DayNight.DayLength = 0
+	if err := config.Validate(); err == nil {
+		t.Error("Expected a day without ticks to be rejected")
+	}
+}
+
+func TestDaylightSwaysPlantsAndHunters(t *testing.T) {
+	world := newSteppingTestWorld(62)
+	row := world.Config.GridHeight / 2
+	hunterAt := Position{X: world.Config.Width / 2, Y: world.Config.Height / 2}
+	nocturnal := NewEntity(sharedRand, 1, []string{"circadian_preference"}, "predator", hunterAt)
+	nocturnal.SetTrait("circadian_preference", -1)
+	diurnal := NewEntity(sharedRand, 2, []string{"circadian_preference"}, "predator", hunterAt)
+	diurnal.SetTrait("circadian_preference", 1)
+
+	setClock(world, 2, Spring)
+	noonPhotosynthesis := world.photosynthesisLight(row)
+	noonDiurnal := world.darkHuntModifier(diurnal)
+
+	setClock(world, 6, Spring)
+	if night := world.photosynthesisLight(row); noonPhotosynthesis <= 1 || night >= 1 {
+		t.Errorf("Expected photosynthesis raised at noon and lowered at night, got %v and %v", noonPhotosynthesis, night)
+	}
+	if world.darkHuntModifier(nocturnal) <= 1 || world.darkHuntModifier(diurnal) >= noonDiurnal {
+		t.Errorf("Expected the dark to favour night hunters over day hunters, got %v and %v",
+			world.darkHuntModifier(nocturnal), world.darkHuntModifier(diurnal))
+	}
+	if world.getSunlightAt(hunterAt) > world.SimConfig.DayNight.NightLight {
+		t.Errorf("Expected no sunlight for seeds at midnight, got %v", world.getSunlightAt(hunterAt))
+	}
+
+	world.SimConfig.DayNight.NightHunting = 0
+	if modifier := world.darkHuntModifier(nocturnal); modifier != 1 {
+		t.Errorf("Expected no effect with night hunting off, got %v", modifier)
+	}
+}
+
+func TestDayNightAPI(t *testing.T) {
+	wi := NewWebInterface(newSteppingTestWorld(63))
+	wi.world.Update()
+
+	var status DayNightStatus
+	if code := callControlAPI(t, wi.handleDayNight, http.MethodGet, "/api/day-night", "", &status); code != http.StatusOK {
+		t.Fatalf("Expected the clock, got %d", code)
+	}
+	if status.Tick != 1 || status.TimeOfDay != "Morning" || len(status.Rows) != wi.world.Config.GridHeight {
+		t.Errorf("Expected the first tick's morning light on every row, got %+v", status)
+	}
+	if code := callControlAPI(t, wi.handleDayNight, http.MethodPost, "/api/day-night", "{}", nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected the clock read only, got %d", code)
+	}
+}
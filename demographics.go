@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Demographics parameters
+const (
+	demographicsInterval = 50  // Ticks between censuses of the colonies
+	ageCohorts           = 10  // Cohorts in a pyramid, each a tenth of a lifespan
+	birthRateHistory     = 8   // Past census birth rates kept per colony
+	advancedTechLevel    = 3   // Tech level from which a colony counts as advanced
+	transitionDrop       = 0.5 // Share of its peak a colony's birth rate must fall to
+	transitionCensuses   = 4   // Censuses of birth rates needed before a fall counts
+)
+
+// Sexes in a population pyramid
+const (
+	SexFemale  = "female"
+	SexMale    = "male"
+	SexAsexual = "asexual"
+)
+
+// entitySex is a creature's sex. Creatures carry no sex of their own, so those that bud or
+// split are asexual and the rest are split evenly into females and males by ID.
+func entitySex(entity *Entity) string {
+	if status := entity.ReproductionStatus; status != nil && (status.Mode == Budding || status.Mode == Fission) {
+		return SexAsexual
+	}
+	if entity.ID%2 == 0 {
+		return SexFemale
+	}
+	return SexMale
+}
+
+// ageCohort is the pyramid cohort a creature's age falls in, by the share of its lifespan lived
+func ageCohort(entity *Entity) int {
+	if entity.MaxLifespan <= 0 {
+		return 0
+	}
+	cohort := entity.Age * ageCohorts / entity.MaxLifespan
+	if cohort >= ageCohorts {
+		return ageCohorts - 1
+	}
+	return cohort
+}
+
+// PyramidCohort is the number of each sex in one age cohort
+type PyramidCohort struct {
+	Label   string `json:"label"` // Share of the lifespan lived, such as "10-20%"
+	Female  int    `json:"female"`
+	Male    int    `json:"male"`
+	Asexual int    `json:"asexual"`
+}
+
+// PopulationPyramid is the age and sex structure of a species or colony, youngest cohort first
+type PopulationPyramid struct {
+	Group      string          `json:"group"` // Species or colony name
+	Population int             `json:"population"`
+	YouthShare float64         `json:"youth_share"` // Share in the first fifth of their lifespan
+	Cohorts    []PyramidCohort `json:"cohorts"`
+}
+
+// newPopulationPyramid builds the pyramid of a group of living creatures
+func newPopulationPyramid(group string, entities []*Entity) PopulationPyramid {
+	pyramid := PopulationPyramid{Group: group, Cohorts: make([]PyramidCohort, ageCohorts)}
+	for i := range pyramid.Cohorts {
+		pyramid.Cohorts[i].Label = fmt.Sprintf("%d-%d%%", i*100/ageCohorts, (i+1)*100/ageCohorts)
+	}
+	young := 0
+	for _, entity := range entities {
+		if !entity.IsAlive {
+			continue
+		}
+		cohort := ageCohort(entity)
+		switch entitySex(entity) {
+		case SexFemale:
+			pyramid.Cohorts[cohort].Female++
+		case SexMale:
+			pyramid.Cohorts[cohort].Male++
+		default:
+			pyramid.Cohorts[cohort].Asexual++
+		}
+		pyramid.Population++
+		if cohort < ageCohorts/5 {
+			young++
+		}
+	}
+	if pyramid.Population > 0 {
+		pyramid.YouthShare = float64(young) / float64(pyramid.Population)
+	}
+	return pyramid
+}
+
+// ColonyDemography is one colony's age and sex structure and the course of its birth rate
+type ColonyDemography struct {
+	TribeID        int               `json:"tribe_id"`
+	Name           string            `json:"name"`
+	TechLevel      int               `json:"tech_level"`
+	FoodSecure     bool              `json:"food_secure"`
+	Births         int               `json:"births"`      // Members born into the colony since it was first counted
+	BirthRate      float64           `json:"birth_rate"`  // Births per member at the last census
+	BirthRates     []float64         `json:"birth_rates"` // Recent census birth rates, oldest first
+	Transitioned   bool              `json:"transitioned"`
+	TransitionTick int               `json:"transition_tick,omitempty"`
+	Pyramid        PopulationPyramid `json:"pyramid"`
+}
+
+// DemographicsReport holds the pyramids of every colony and species
+type DemographicsReport struct {
+	Tick        int                 `json:"tick"`
+	Colonies    []ColonyDemography  `json:"colonies"`
+	Species     []PopulationPyramid `json:"species"`
+	Transitions int                 `json:"transitions"` // Colonies that have been through the demographic transition
+}
+
+// DemographicsSystem takes a census of the colonies, counting the members born into each,
+// and notices when an advanced colony with secure food starts having fewer children: the
+// demographic transition
+type DemographicsSystem struct {
+	colonies map[int]*ColonyDemography
+	members  map[int]map[int]bool // Members counted at each colony's last census
+}
+
+// NewDemographicsSystem creates a system with no censuses taken yet
+func NewDemographicsSystem() *DemographicsSystem {
+	return &DemographicsSystem{
+		colonies: make(map[int]*ColonyDemography),
+		members:  make(map[int]map[int]bool),
+	}
+}
+
+// foodSecure reports whether a tribe's stores keep it out of famine
+func (w *World) foodSecure(tribe *Tribe) bool {
+	if w.FoodStorage != nil {
+		if record := w.FoodStorage.tribes[tribe.ID]; record != nil {
+			return record.Status != FoodStatusFamine
+		}
+	}
+	return tribe.Resources["food"] >= famineCover*memberRation*float64(len(tribe.Members))
+}
+
+// Update takes a census of every colony every demographicsInterval ticks
+func (ds *DemographicsSystem) Update(w *World) {
+	if w.Tick%demographicsInterval != 0 || w.CivilizationSystem == nil {
+		return
+	}
+	for _, tribe := range w.CivilizationSystem.Tribes {
+		if len(tribe.Members) > 0 {
+			ds.census(w, tribe)
+		}
+	}
+}
+
+// census counts a colony's births since the last census and checks whether its birth rate
+// has fallen far from its peak while the colony is advanced and well fed. A member first
+// counted while still in the youngest cohort was born into the colony; older newcomers were
+// recruited.
+func (ds *DemographicsSystem) census(w *World, tribe *Tribe) {
+	record := ds.colonies[tribe.ID]
+	previous := ds.members[tribe.ID]
+	current := make(map[int]bool)
+	births := 0
+	for _, member := range tribe.Members {
+		if !member.IsAlive {
+			continue
+		}
+		current[member.ID] = true
+		if previous != nil && !previous[member.ID] && ageCohort(member) == 0 {
+			births++
+		}
+	}
+	ds.members[tribe.ID] = current
+	if record == nil {
+		// The first census only finds out who is there
+		ds.colonies[tribe.ID] = &ColonyDemography{TribeID: tribe.ID, BirthRates: make([]float64, 0)}
+		return
+	}
+
+	record.Name, record.TechLevel = tribe.Name, tribe.TechLevel
+	record.FoodSecure = w.foodSecure(tribe)
+	record.Births += births
+	record.BirthRate = 0
+	if len(current) > 0 {
+		record.BirthRate = float64(births) / float64(len(current))
+	}
+	record.BirthRates = append(record.BirthRates, record.BirthRate)
+	if len(record.BirthRates) > birthRateHistory {
+		record.BirthRates = record.BirthRates[1:]
+	}
+
+	if record.Transitioned || tribe.TechLevel < advancedTechLevel || !record.FoodSecure || len(record.BirthRates) < transitionCensuses {
+		return
+	}
+	peak := 0.0
+	for _, rate := range record.BirthRates {
+		if rate > peak {
+			peak = rate
+		}
+	}
+	latest := len(record.BirthRates) - 1
+	if peak > 0 && record.BirthRate <= transitionDrop*peak && record.BirthRate <= record.BirthRates[latest-1] {
+		record.Transitioned, record.TransitionTick = true, w.Tick
+		if w.EventLogger != nil {
+			w.EventLogger.LogWorldEvent(w.Tick, "demographic_transition",
+				fmt.Sprintf("Tribe %s has gone through a demographic transition, its birth rate down to %.2f from a peak of %.2f", tribe.Name, record.BirthRate, peak))
+		}
+	}
+}
+
+// Report builds the pyramid of every counted colony and every living species
+func (ds *DemographicsSystem) Report(w *World) DemographicsReport {
+	report := DemographicsReport{
+		Tick:     w.Tick,
+		Colonies: make([]ColonyDemography, 0),
+		Species:  make([]PopulationPyramid, 0),
+	}
+	tribes := make(map[int]*Tribe)
+	if w.CivilizationSystem != nil {
+		for _, tribe := range w.CivilizationSystem.Tribes {
+			tribes[tribe.ID] = tribe
+		}
+	}
+	for _, id := range sortedKeys(ds.colonies) {
+		tribe := tribes[id]
+		if tribe == nil {
+			continue
+		}
+		record := *ds.colonies[id]
+		record.Name, record.TechLevel = tribe.Name, tribe.TechLevel
+		record.BirthRates = append([]float64{}, record.BirthRates...)
+		record.Pyramid = newPopulationPyramid(tribe.Name, tribe.Members)
+		report.Colonies = append(report.Colonies, record)
+		if record.Transitioned {
+			report.Transitions++
+		}
+	}
+
+	bySpecies := make(map[string][]*Entity)
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive {
+			bySpecies[entity.Species] = append(bySpecies[entity.Species], entity)
+		}
+	}
+	for _, species := range sortedKeys(bySpecies) {
+		report.Species = append(report.Species, newPopulationPyramid(species, bySpecies[species]))
+	}
+	sort.SliceStable(report.Species, func(i, j int) bool { return report.Species[i].Population > report.Species[j].Population })
+	return report
+}
+
+// Clear forgets every census taken, as when a new world is loaded
+func (ds *DemographicsSystem) Clear() {
+	ds.colonies = make(map[int]*ColonyDemography)
+	ds.members = make(map[int]map[int]bool)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newDemographicsTestWorld makes an empty 100x100 world with a ten-member tribe of live-bearing
+// adults, of a tech level and holding the given food
+func newDemographicsTestWorld(techLevel int, food float64) (*World, *Tribe) {
+	world := newEmptyTestWorld(100)
+	tribe := addTestTribe(world, "Hollowmere", 10, Position{X: 50, Y: 50})
+	for _, member := range tribe.Members {
+		member.Age = member.MaxLifespan / 2
+		member.ReproductionStatus.Mode = LiveBirth
+	}
+	tribe.TechLevel = techLevel
+	tribe.Resources["food"] = food
+	return world, tribe
+}
+
+// runCensuses takes a number of censuses, with the given number of children born into the
+// tribe before each
+func runCensuses(world *World, tribe *Tribe, births ...int) {
+	for _, born := range births {
+		for i := 0; i < born; i++ {
+			child := NewEntity(world.Rand, len(world.AllEntities)+1, []string{"intelligence"}, "Folk", Position{X: 50, Y: 50})
+			child.TribeID = tribe.ID
+			child.ReproductionStatus.Mode = LiveBirth
+			world.AllEntities = append(world.AllEntities, child)
+			tribe.AddMember(child)
+		}
+		runSystem(world, demographicsInterval, 1, world.Demographics.Update)
+	}
+}
+
+// transitionLogged reports whether a demographic transition has been logged
+func transitionLogged(world *World) bool {
+	for _, event := range world.EventLogger.GetEventsByType(EventWorldEvent) {
+		if event.Data["event_name"] == "demographic_transition" {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPopulationPyramidsByAgeAndSex(t *testing.T) {
+	world, tribe := newDemographicsTestWorld(0, 100)
+	for i := 0; i < 4; i++ {
+		spore := NewEntity(sharedRand, 100+i, []string{"speed"}, "Spore", Position{X: 10, Y: 10})
+		spore.ReproductionStatus.Mode = Budding
+		world.AllEntities = append(world.AllEntities, spore)
+	}
+	runCensuses(world, tribe, 0, 2)
+
+	report := world.Demographics.Report(world)
+	if len(report.Colonies) != 1 || report.Colonies[0].Births != 2 {
+		t.Fatalf("Expected the two children counted as births into the colony, got %+v", report.Colonies)
+	}
+	colony := report.Colonies[0].Pyramid
+	if colony.Population != 12 || len(colony.Cohorts) != ageCohorts {
+		t.Fatalf("Expected a pyramid of the twelve members in %d cohorts, got %+v", ageCohorts, colony)
+	}
+	middle := colony.Cohorts[ageCohorts/2]
+	if middle.Female != 5 || middle.Male != 5 || colony.Cohorts[0].Female+colony.Cohorts[0].Male != 2 {
+		t.Errorf("Expected the adults split by sex in the middle cohort and the children in the youngest, got %+v", colony.Cohorts)
+	}
+
+	for _, pyramid := range report.Species {
+		if pyramid.Group != "Spore" {
+			continue
+		}
+		if pyramid.Cohorts[0].Asexual != 4 || pyramid.YouthShare != 1 {
+			t.Errorf("Expected budding spores counted as young and asexual, got %+v", pyramid)
+		}
+		return
+	}
+	t.Errorf("Expected a pyramid for the spores")
+}
+
+func TestDemographicTransitionInSecureAdvancedColonies(t *testing.T) {
+	births := []int{0, 4, 4, 3, 1, 0}
+
+	world, tribe := newDemographicsTestWorld(advancedTechLevel, 500)
+	runCensuses(world, tribe, births...)
+	colony := world.Demographics.Report(world).Colonies[0]
+	if !colony.Transitioned || !colony.FoodSecure || !transitionLogged(world) {
+		t.Fatalf("Expected a well-fed advanced colony with falling births to go through the transition, got %+v", colony)
+	}
+
+	primitive, primitiveTribe := newDemographicsTestWorld(advancedTechLevel-1, 500)
+	runCensuses(primitive, primitiveTribe, births...)
+	hungry, hungryTribe := newDemographicsTestWorld(advancedTechLevel, 0)
+	runCensuses(hungry, hungryTribe, births...)
+	if transitionLogged(primitive) || transitionLogged(hungry) {
+		t.Errorf("Expected no transition in a primitive or hungry colony")
+	}
+
+	growing, growingTribe := newDemographicsTestWorld(advancedTechLevel, 500)
+	runCensuses(growing, growingTribe, 0, 1, 2, 3, 4, 4)
+	if transitionLogged(growing) {
+		t.Errorf("Expected no transition while births are rising")
+	}
+}
+
+func TestDemographicsAPI(t *testing.T) {
+	world, tribe := newDemographicsTestWorld(0, 100)
+	runCensuses(world, tribe, 0, 1)
+
+	wi := NewWebInterface(world)
+	rec := httptest.NewRecorder()
+	wi.handleDemographics(rec, httptest.NewRequest(http.MethodGet, "/api/demographics", nil))
+	var report DemographicsReport
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &report) != nil {
+		t.Fatalf("Expected a demographics report, got %d %s", rec.Code, rec.Body.String())
+	}
+	if len(report.Colonies) != 1 || report.Colonies[0].BirthRate <= 0 || len(report.Species) != 1 {
+		t.Errorf("Expected the colony's birth and the species' pyramid reported, got %+v", report)
+	}
+}
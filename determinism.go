@@ -0,0 +1,358 @@
+package main
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DeterminismConfig describes a determinism check run
+type DeterminismConfig struct {
+	World     WorldConfig
+	Primitive bool  // Use the primitive starting populations
+	Seed      int64 // Seed for the world's random source
+	Ticks     int   // Ticks to simulate in each run
+	Streams   bool  // Draw every subsystem from its own RNG stream
+	Parallel  bool  // Use RNG streams and update their subsystems concurrently in the second run
+}
+
+// DefaultDeterminismConfig returns the standard world setup checked for 500 ticks
+func DefaultDeterminismConfig() DeterminismConfig {
+	return DeterminismConfig{
+		World: WorldConfig{
+			Width:          100,
+			Height:         100,
+			NumPopulations: 3,
+			PopulationSize: 20,
+			GridWidth:      40,
+			GridHeight:     25,
+		},
+		Seed:  1,
+		Ticks: 500,
+	}
+}
+
+// determinismSubsystems lists the fingerprinted parts of the world in report order
+var determinismSubsystems = []string{"terrain", "wind", "topology", "plants", "entities", "populations", "speciation", "reproduction", "events"}
+
+// worldFingerprint maps each subsystem to a hash of its state
+type worldFingerprint map[string]uint64
+
+// DeterminismReport is the outcome of a determinism check
+type DeterminismReport struct {
+	Seed          int64    `json:"seed"`
+	Ticks         int      `json:"ticks"`
+	TicksCompared int      `json:"ticks_compared"`
+	Diverged      bool     `json:"diverged"`
+	DivergedTick  int      `json:"diverged_tick"` // 0 means the freshly created world already differed
+	Subsystems    []string `json:"subsystems"`    // Subsystems whose state differed at the divergent tick
+	Parallel      bool     `json:"parallel"`      // A serial run was compared with a parallel one
+}
+
+// VerifyDeterminism runs the same seeded simulation twice and reports the first tick and
+// subsystems where the two runs differ. The first run records a fingerprint per tick and
+// the second run compares against it in lockstep, stopping at the first difference. With
+// Parallel set the first run updates the stream subsystems serially and the second
+// concurrently, which must make no difference.
+func VerifyDeterminism(config DeterminismConfig) *DeterminismReport {
+	serial := config
+	serial.Streams = config.Streams || config.Parallel
+	serial.Parallel = false
+	reference := make([]worldFingerprint, 0, config.Ticks+1)
+	runSeededSimulation(serial, func(tick int, fingerprint worldFingerprint) bool {
+		reference = append(reference, fingerprint)
+		return true
+	})
+
+	report := &DeterminismReport{Seed: config.Seed, Ticks: config.Ticks, Parallel: config.Parallel}
+	runSeededSimulation(config, func(tick int, fingerprint worldFingerprint) bool {
+		report.TicksCompared = tick
+		for _, subsystem := range determinismSubsystems {
+			if fingerprint[subsystem] != reference[tick][subsystem] {
+				report.Subsystems = append(report.Subsystems, subsystem)
+			}
+		}
+		if len(report.Subsystems) > 0 {
+			report.Diverged = true
+			report.DivergedTick = tick
+			return false
+		}
+		return true
+	})
+	return report
+}
+
+// Summary returns a human-readable description of the report
+func (r *DeterminismReport) Summary() string {
+	if !r.Diverged {
+		runs := "both runs"
+		if r.Parallel {
+			runs = "serial and parallel runs"
+		}
+		return fmt.Sprintf("Deterministic: seed %d produced identical state in %s for %d ticks", r.Seed, runs, r.Ticks)
+	}
+	when := fmt.Sprintf("tick %d", r.DivergedTick)
+	if r.DivergedTick == 0 {
+		when = "world creation"
+	}
+	return fmt.Sprintf("Nondeterministic: seed %d diverged at %s in %s", r.Seed, when, strings.Join(r.Subsystems, ", "))
+}
+
+// seedForRun returns the seed a run uses: the one asked for or, for 0, one taken from the
+// clock, so that every run has a seed it can be reproduced from
+func seedForRun(seed int64) int64 {
+	if seed != 0 {
+		return seed
+	}
+	if seed = time.Now().UnixNano(); seed == 0 {
+		seed = 1
+	}
+	return seed
+}
+
+// runSeededSimulation builds a world from the seed and calls visit with the fingerprint
+// after creation (tick 0) and after every tick until visit returns false
+func runSeededSimulation(config DeterminismConfig, visit func(tick int, fingerprint worldFingerprint) bool) {
+	worldConfig := config.World
+	worldConfig.Seed = config.Seed
+	world := NewWorld(worldConfig)
+	world.Deterministic = true
+	if config.Streams || config.Parallel {
+		world.RNG = NewRNGStreams(config.Seed, config.Parallel)
+	}
+	for _, population := range startingPopulations(config.Primitive) {
+		world.AddPopulation(population)
+	}
+
+	if !visit(0, fingerprintWorld(world)) {
+		return
+	}
+	for tick := 1; tick <= config.Ticks; tick++ {
+		world.Update()
+		if !visit(tick, fingerprintWorld(world)) {
+			return
+		}
+	}
+}
+
+// fingerprintWorld hashes the simulation-relevant state of each subsystem. Wall-clock
+// timestamps are left out since they legitimately differ between runs.
+func fingerprintWorld(w *World) worldFingerprint {
+	fingerprint := make(worldFingerprint, len(determinismSubsystems))
+
+	h := newStateHasher()
+	for y := range w.Grid {
+		for x := range w.Grid[y] {
+			cell := &w.Grid[y][x]
+			h.int(int(cell.Biome))
+			h.float(cell.WaterLevel)
+			h.float(cell.SoilPH)
+			h.float(cell.OrganicMatter)
+			h.floats(cell.SoilNutrients)
+		}
+	}
+	fingerprint["terrain"] = h.sum()
+
+	h = newStateHasher()
+	if ws := w.WindSystem; ws != nil {
+		h.float(ws.BaseWindDirection)
+		h.float(ws.BaseWindStrength)
+		h.int(ws.WeatherPattern)
+		h.int(ws.WeatherDuration)
+		h.int(len(ws.RegionalStorms))
+		for _, grain := range ws.AllPollenGrains {
+			h.int(grain.ID)
+			h.position(grain.Position)
+		}
+	}
+	fingerprint["wind"] = h.sum()
+
+	h = newStateHasher()
+	if ts := w.TopologySystem; ts != nil {
+		for x := range ts.TopologyGrid {
+			for y := range ts.TopologyGrid[x] {
+				h.float(ts.TopologyGrid[x][y].Elevation)
+			}
+		}
+		h.int(len(ts.GeologicalEvents))
+	}
+	fingerprint["topology"] = h.sum()
+
+	h = newStateHasher()
+	for _, plant := range w.AllPlants {
+		h.int(plant.ID)
+		h.int(int(plant.Type))
+		h.bool(plant.IsAlive)
+		h.position(plant.Position)
+		h.float(plant.Energy)
+		h.float(plant.Size)
+		h.int(plant.Age)
+		h.traits(plant.Traits)
+	}
+	fingerprint["plants"] = h.sum()
+
+	h = newStateHasher()
+	for _, entity := range w.AllEntities {
+		h.int(entity.ID)
+		h.str(entity.Species)
+		h.bool(entity.IsAlive)
+		h.position(entity.Position)
+		h.float(entity.Energy)
+		h.int(entity.Age)
+		h.int(entity.Generation)
+		h.traits(entity.Traits)
+	}
+	fingerprint["entities"] = h.sum()
+
+	h = newStateHasher()
+	for _, name := range sortedKeys(w.Populations) {
+		population := w.Populations[name]
+		h.str(name)
+		h.int(population.Generation)
+		h.int(len(population.Entities))
+	}
+	fingerprint["populations"] = h.sum()
+
+	h = newStateHasher()
+	if w.SpeciationSystem != nil {
+		for _, id := range sortedKeys(w.SpeciationSystem.AllSpecies) {
+			species := w.SpeciationSystem.AllSpecies[id]
+			h.int(id)
+			h.str(species.Name)
+			h.int(len(species.Members))
+			h.int(species.ExtinctionTick)
+		}
+	}
+	fingerprint["speciation"] = h.sum()
+
+	h = newStateHasher()
+	if w.ReproductionSystem != nil {
+		for _, egg := range w.ReproductionSystem.Eggs {
+			h.int(egg.ID)
+			h.position(egg.Position)
+		}
+		h.int(len(w.ReproductionSystem.DecayingItems))
+	}
+	fingerprint["reproduction"] = h.sum()
+
+	h = newStateHasher()
+	for _, event := range w.Events {
+		h.str(event.Name)
+		h.int(event.Duration)
+		h.position(event.Position)
+	}
+	for _, event := range w.EnvironmentalEvents {
+		h.str(event.Name)
+		h.int(event.Duration)
+	}
+	fingerprint["events"] = h.sum()
+
+	return fingerprint
+}
+
+// stateHasher accumulates values into a 64-bit FNV hash
+type stateHasher struct {
+	h   hash.Hash64
+	buf [8]byte
+}
+
+func newStateHasher() *stateHasher {
+	return &stateHasher{h: fnv.New64a()}
+}
+
+func (sh *stateHasher) sum() uint64 {
+	return sh.h.Sum64()
+}
+
+func (sh *stateHasher) uint(v uint64) {
+	binary.LittleEndian.PutUint64(sh.buf[:], v)
+	sh.h.Write(sh.buf[:])
+}
+
+func (sh *stateHasher) int(v int) {
+	sh.uint(uint64(v))
+}
+
+func (sh *stateHasher) float(v float64) {
+	sh.uint(math.Float64bits(v))
+}
+
+func (sh *stateHasher) bool(v bool) {
+	if v {
+		sh.uint(1)
+	} else {
+		sh.uint(0)
+	}
+}
+
+func (sh *stateHasher) str(s string) {
+	sh.int(len(s))
+	sh.h.Write([]byte(s))
+}
+
+func (sh *stateHasher) position(p Position) {
+	sh.float(p.X)
+	sh.float(p.Y)
+}
+
+func (sh *stateHasher) traits(traits map[string]Trait) {
+	for _, name := range sortedKeys(traits) {
+		sh.str(name)
+		sh.float(traits[name].Value)
+	}
+}
+
+func (sh *stateHasher) floats(values map[string]float64) {
+	for _, name := range sortedKeys(values) {
+		sh.str(name)
+		sh.float(values[name])
+	}
+}
+
+// sortedKeys returns the keys of a map in sorted order. Loops that draw random numbers
+// while iterating a map must use it, otherwise seeded runs are not reproducible.
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// runDeterminismCommand simulates a seed twice and reports where the runs diverge, failing
+// when they do
+func runDeterminismCommand(args []string) error {
+	fs := newCommandFlags("determinism")
+	options := addWorldFlags(fs)
+	seed := fs.Int64("seed", 0, "Random seed (0 picks one from the clock and prints it)")
+	ticks := fs.Int("ticks", 500, "Ticks to simulate per run")
+	parallel := fs.Bool("parallel", false, "Compare a run updating world chunks one after another against one updating them concurrently, both from per-subsystem RNG streams")
+	runConfig, err := parseCommandFlags(fs, args, commandEnvPrefix(fs.Name()), options)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return commandUsage("determinism")
+	}
+	worldConfig, err := options.WorldConfig(runConfig)
+	if err != nil {
+		return err
+	}
+
+	config := DeterminismConfig{World: worldConfig, Primitive: *options.primitive, Seed: seedForRun(*seed), Ticks: *ticks, Parallel: *parallel}
+	fmt.Fprintf(os.Stderr, "Verifying determinism: seed %d, %d ticks per run...\n", config.Seed, config.Ticks)
+	report := VerifyDeterminism(config)
+	fmt.Println(report.Summary())
+	if report.Diverged {
+		return fmt.Errorf("the runs diverged")
+	}
+	return nil
+}
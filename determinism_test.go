@@ -0,0 +1,47 @@
+package main
+
+import "testing"
+
+func TestVerifyDeterminismReproducesSeededRun(t *testing.T) {
+	config := DefaultDeterminismConfig()
+	config.Ticks = 30
+
+	report := VerifyDeterminism(config)
+	if report.Diverged {
+		t.Fatalf("Expected identical runs, got: %s", report.Summary())
+	}
+	if report.TicksCompared != config.Ticks {
+		t.Errorf("Expected %d ticks compared, got %d", config.Ticks, report.TicksCompared)
+	}
+}
+
+func TestUnseededRunsPickAReproducibleSeed(t *testing.T) {
+	if seedForRun(7) != 7 {
+		t.Errorf("Expected a given seed kept")
+	}
+	config := DefaultDeterminismConfig()
+	config.Seed = seedForRun(0)
+	config.Ticks = 20
+	if config.Seed == 0 {
+		t.Fatalf("Expected a seed picked for an unseeded run")
+	}
+	if report := VerifyDeterminism(config); report.Diverged {
+		t.Errorf("Expected the picked seed to reproduce the run, got: %s", report.Summary())
+	}
+}
+
+func TestFingerprintIdentifiesChangedSubsystem(t *testing.T) {
+	world := NewWorld(DefaultDeterminismConfig().World)
+	world.AddPopulation(startingPopulations(false)[0])
+
+	before := fingerprintWorld(world)
+	world.AllEntities[0].Position.X += 0.5
+	after := fingerprintWorld(world)
+
+	for _, subsystem := range determinismSubsystems {
+		changed := before[subsystem] != after[subsystem]
+		if changed != (subsystem == "entities") {
+			t.Errorf("Subsystem %s: changed=%v after moving an entity", subsystem, changed)
+		}
+	}
+}
@@ -45,6 +45,8 @@ type DNASystem struct {
 	MutationRates  map[string]float64 // Mutation rates for different gene types
 	DominanceRules map[string]bool    // Default dominance for genes
 	eventBus       *CentralEventBus   `json:"-"` // Event tracking
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewDNASystem creates a new DNA management system
@@ -87,6 +89,7 @@ func NewDNASystem(eventBus *CentralEventBus) *DNASystem {
 			"LON": false, "ADA": false, "MET": false,
 		},
 		eventBus: eventBus,
+		rng:      sharedRand,
 	}
 }
 
@@ -106,9 +109,9 @@ func (ds *DNASystem) GenerateRandomDNA(entityID int, generation int) *DNAStrand
 			Genes: make([]Gene, 0),
 		}
 
-		// Add genes for each trait
-		for trait, geneName := range ds.TraitToGene {
-			gene := ds.generateRandomGene(geneName, trait)
+		// Add genes for each trait, in sorted order so seeded runs produce the same genome
+		for _, trait := range sortedKeys(ds.TraitToGene) {
+			gene := ds.generateRandomGene(ds.TraitToGene[trait], trait)
 			chromosome.Genes = append(chromosome.Genes, gene)
 		}
 
@@ -129,11 +132,11 @@ func (ds *DNASystem) generateRandomGene(geneName, traitName string) Gene {
 	nucleotides := []Nucleotide{Adenine, Thymine, Guanine, Cytosine}
 
 	for i := 0; i < length; i++ {
-		sequence[i] = nucleotides[rand.Intn(4)]
+		sequence[i] = nucleotides[ds.rng.Intn(4)]
 	}
 
 	dominant := ds.DominanceRules[geneName]
-	if rand.Float64() < 0.3 { // 30% chance to flip dominance
+	if ds.rng.Float64() < 0.3 { // 30% chance to flip dominance
 		dominant = !dominant
 	}
 
@@ -141,7 +144,7 @@ func (ds *DNASystem) generateRandomGene(geneName, traitName string) Gene {
 		Name:       geneName,
 		Sequence:   sequence,
 		Dominant:   dominant,
-		Expression: rand.Float64()*0.8 + 0.2, // 0.2 to 1.0
+		Expression: ds.rng.Float64()*0.8 + 0.2, // 0.2 to 1.0
 	}
 }
 
@@ -238,11 +241,11 @@ func (ds *DNASystem) MutateDNA(dna *DNAStrand, mutationPressure float64) {
 			copy(oldSequence, gene.Sequence)
 
 			for seqIdx := range gene.Sequence {
-				if rand.Float64() < effectiveMutationRate {
+				if ds.rng.Float64() < effectiveMutationRate {
 					// Point mutation - change nucleotide
 					nucleotides := []Nucleotide{Adenine, Thymine, Guanine, Cytosine}
 					oldNucleotide := gene.Sequence[seqIdx]
-					gene.Sequence[seqIdx] = nucleotides[rand.Intn(4)]
+					gene.Sequence[seqIdx] = nucleotides[ds.rng.Intn(4)]
 					dna.Mutations++
 					geneMutations++
 
@@ -276,7 +279,7 @@ func (ds *DNASystem) MutateDNA(dna *DNAStrand, mutationPressure float64) {
 			}
 
 			// Occasional dominance shifts
-			if rand.Float64() < effectiveMutationRate*0.1 {
+			if ds.rng.Float64() < effectiveMutationRate*0.1 {
 				oldDominant := gene.Dominant
 				gene.Dominant = !gene.Dominant
 				dna.Mutations++
@@ -307,9 +310,9 @@ func (ds *DNASystem) MutateDNA(dna *DNAStrand, mutationPressure float64) {
 			}
 
 			// Expression level mutations
-			if rand.Float64() < effectiveMutationRate*0.5 {
+			if ds.rng.Float64() < effectiveMutationRate*0.5 {
 				oldExpression := gene.Expression
-				change := (rand.Float64() - 0.5) * 0.2
+				change := (ds.rng.Float64() - 0.5) * 0.2
 				gene.Expression = math.Max(0.1, math.Min(1.0, gene.Expression+change))
 
 				// Emit expression change event
@@ -391,14 +394,14 @@ func (ds *DNASystem) CrossoverDNA(parent1, parent2 *DNAStrand, childID int) *DNA
 
 			if geneIdx < len(parent1Genes) && geneIdx < len(parent2Genes) {
 				// Both parents have this gene - choose randomly or recombine
-				if rand.Float64() < 0.5 {
+				if ds.rng.Float64() < 0.5 {
 					selectedGene = parent1Genes[geneIdx]
 				} else {
 					selectedGene = parent2Genes[geneIdx]
 				}
 
 				// Possibility of recombination within gene
-				if rand.Float64() < 0.1 {
+				if ds.rng.Float64() < 0.1 {
 					selectedGene = ds.recombineGenes(parent1Genes[geneIdx], parent2Genes[geneIdx])
 				}
 			} else if geneIdx < len(parent1Genes) {
@@ -451,7 +454,7 @@ func (ds *DNASystem) recombineGenes(gene1, gene2 Gene) Gene {
 	}
 
 	// Random crossover point
-	crossoverPoint := rand.Intn(minLength)
+	crossoverPoint := ds.rng.Intn(minLength)
 
 	newSequence := make([]Nucleotide, minLength)
 
@@ -0,0 +1,157 @@
+//go:build longrun
+
+package main
+
+// Long-running ecological invariant checks. These run full simulations for
+// thousands of ticks and are excluded from the default test run:
+//
+//	go test -tags=longrun -run Invariant -timeout 30m ./...
+
+import (
+	"math"
+	"testing"
+)
+
+// invariantTrials is how many independent runs each invariant is checked across
+const invariantTrials = 3
+
+// newInvariantWorld creates a world with the standard starting populations
+func newInvariantWorld(primitive bool) *World {
+	world := NewWorld(WorldConfig{
+		Width:          100,
+		Height:         100,
+		NumPopulations: 3,
+		PopulationSize: 20,
+		GridWidth:      40,
+		GridHeight:     40,
+	})
+	for _, population := range startingPopulations(primitive) {
+		world.AddPopulation(population)
+	}
+	return world
+}
+
+// livingEnergy returns the total energy and count of living entities
+func livingEnergy(world *World) (float64, int) {
+	total := 0.0
+	alive := 0
+	for _, entity := range world.AllEntities {
+		if entity.IsAlive {
+			total += entity.Energy
+			alive++
+		}
+	}
+	return total, alive
+}
+
+func TestInvariantEnergyStaysFiniteAndCapped(t *testing.T) {
+	for trial := 0; trial < invariantTrials; trial++ {
+		world := newInvariantWorld(false)
+		maxEnergy := world.SimConfig.Energy.MaxEnergyLevel
+		for tick := 0; tick < 2000; tick++ {
+			world.Update()
+			for _, entity := range world.AllEntities {
+				if !entity.IsAlive {
+					continue
+				}
+				if math.IsNaN(entity.Energy) || math.IsInf(entity.Energy, 0) {
+					t.Fatalf("Trial %d tick %d: entity %d has non-finite energy", trial, world.Tick, entity.ID)
+				}
+				if entity.Energy > maxEnergy+1e-9 {
+					t.Fatalf("Trial %d tick %d: entity %d has energy %.2f above the %.0f cap", trial, world.Tick, entity.ID, entity.Energy, maxEnergy)
+				}
+			}
+		}
+	}
+}
+
+// Resting lets individuals recover energy, but a closed system without food must run down
+func TestInvariantNoEnergyCreationWithoutFood(t *testing.T) {
+	const starvationDeadline = 5000
+
+	for trial := 0; trial < invariantTrials; trial++ {
+		config := DefaultPetriDishConfig()
+		config.FoodDensity = 0
+		dish := NewPetriDish(config)
+		dish.AddPopulation("herbivore", startingPopulations(false)[0].BaseTraits)
+
+		initial, _ := livingEnergy(dish.World)
+		for tick := 0; tick < starvationDeadline; tick++ {
+			dish.Step(1)
+			dish.World.AllPlants = dish.World.AllPlants[:0] // Keep the dish closed: no food may appear
+			current, alive := livingEnergy(dish.World)
+			if current > initial+1e-6 {
+				t.Fatalf("Trial %d tick %d: living energy %.2f exceeds the initial %.2f with no food", trial, dish.World.Tick, current, initial)
+			}
+			if alive == 0 {
+				break
+			}
+		}
+		if _, alive := livingEnergy(dish.World); alive > 0 {
+			t.Errorf("Trial %d: %d herbivores survived %d ticks without food", trial, alive, dish.World.Tick)
+		}
+	}
+}
+
+func TestInvariantPredatorsNeedPrey(t *testing.T) {
+	for trial := 0; trial < invariantTrials; trial++ {
+		config := DefaultPetriDishConfig()
+		config.FoodDensity = 0
+		dish := NewPetriDish(config)
+		dish.AddPopulation("predator", startingPopulations(false)[1].BaseTraits)
+
+		for tick := 0; tick < 3000; tick++ {
+			dish.Step(1)
+			dish.World.AllPlants = dish.World.AllPlants[:0]
+			if _, alive := livingEnergy(dish.World); alive == 0 {
+				break
+			}
+		}
+		if _, alive := livingEnergy(dish.World); alive > 0 {
+			t.Errorf("Trial %d: %d predators persisted for %d ticks without prey", trial, alive, dish.World.Tick)
+		}
+	}
+}
+
+func TestInvariantPopulationsStayBounded(t *testing.T) {
+	for trial := 0; trial < invariantTrials; trial++ {
+		world := newInvariantWorld(false)
+		limit := world.SimConfig.Population.MaxPopulation
+		peak := 0
+		for tick := 0; tick < 3000; tick++ {
+			world.Update()
+			if _, alive := livingEnergy(world); alive > peak {
+				peak = alive
+			}
+		}
+		if peak > limit {
+			t.Errorf("Trial %d: population peaked at %d, above the %d limit", trial, peak, limit)
+		}
+	}
+}
+
+func TestInvariantPrimitiveModeSpeciates(t *testing.T) {
+	const speciationDeadline = 3000
+
+	for trial := 0; trial < invariantTrials; trial++ {
+		world := newInvariantWorld(true)
+		founders := make(map[string]bool)
+		for _, entity := range world.AllEntities {
+			founders[entity.Species] = true
+		}
+
+		speciated := false
+		for tick := 0; tick < speciationDeadline && !speciated; tick++ {
+			world.Update()
+			for _, entity := range world.AllEntities {
+				if entity.IsAlive && !founders[entity.Species] {
+					speciated = true
+					break
+				}
+			}
+		}
+		if !speciated {
+			t.Errorf("Trial %d: no new species appeared within %d ticks in primitive mode", trial, speciationDeadline)
+		}
+	}
+}
@@ -40,6 +40,22 @@ type EcosystemMetrics struct {
 	SeedBankSize             int            `json:"seed_bank_size"`
 	GerminationRate          float64        `json:"germination_rate"`
 
+	// Seed caching mutualism metrics
+	CachedSeeds      int            `json:"cached_seeds"`
+	CacheRecruits    int            `json:"cache_recruits"`
+	CacherDependence float64        `json:"cacher_dependence"` // Fraction of living trees planted by cachers
+	RecruitsByCacher map[string]int `json:"recruits_by_cacher"`
+
+	// Habitat connectivity metrics
+	ConnectivityIndex float64 `json:"connectivity_index"` // Probability two random habitat cells are connected
+	HabitatPatches    int     `json:"habitat_patches"`
+	CorridorCount     int     `json:"corridor_count"`
+	ChokePointCount   int     `json:"choke_point_count"`
+
+	// Niche overlap and competition between coexisting species
+	NicheOverlaps    []NicheOverlap `json:"niche_overlaps"`    // Most overlapping pairs first
+	LikelyExclusions int            `json:"likely_exclusions"` // Pairs where one species looks set to lose out
+
 	// Ecosystem health
 	EcosystemStability  float64 `json:"ecosystem_stability"`
 	BiodiversityIndex   float64 `json:"biodiversity_index"`
@@ -81,6 +97,15 @@ func (em *EcosystemMonitor) UpdateMetrics(world *World) {
 	// Calculate dispersal metrics
 	em.calculateDispersalMetrics(world, &metrics)
 
+	// Calculate seed caching mutualism metrics
+	em.calculateSeedCachingMetrics(world, &metrics)
+
+	// Calculate habitat connectivity metrics
+	em.calculateConnectivityMetrics(world, &metrics)
+
+	// Calculate niche overlap and competition
+	em.calculateNicheOverlapMetrics(world, &metrics)
+
 	// Calculate ecosystem health
 	em.calculateEcosystemHealth(world, &metrics)
 
@@ -152,7 +177,8 @@ func (em *EcosystemMonitor) calculateDiversityMetrics(world *World, metrics *Eco
 	shannonSum := 0.0
 	simpsonSum := 0.0
 
-	for _, count := range allSpeciesCounts {
+	for _, species := range sortedKeys(allSpeciesCounts) {
+		count := allSpeciesCounts[species]
 		if count > 0 {
 			proportion := float64(count) / float64(totalOrganisms)
 			shannonSum += proportion * math.Log(proportion)
@@ -339,6 +365,46 @@ func (em *EcosystemMonitor) calculateDispersalMetrics(world *World, metrics *Eco
 	}
 }
 
+// calculateSeedCachingMetrics measures the cacher-tree dispersal mutualism
+func (em *EcosystemMonitor) calculateSeedCachingMetrics(world *World, metrics *EcosystemMetrics) {
+	metrics.RecruitsByCacher = make(map[string]int)
+	if world.SeedCachingSystem == nil {
+		return
+	}
+
+	scs := world.SeedCachingSystem
+	metrics.CachedSeeds = scs.CachedSeedCount()
+	metrics.CacheRecruits = scs.CacheRecruits
+	metrics.CacherDependence = scs.CacherDependence(world.AllPlants)
+	for species, count := range scs.RecruitsBySpecies {
+		metrics.RecruitsByCacher[species] = count
+	}
+}
+
+// calculateConnectivityMetrics reports habitat fragmentation from the corridor analysis
+func (em *EcosystemMonitor) calculateConnectivityMetrics(world *World, metrics *EcosystemMetrics) {
+	if world.MovementCorridorSystem == nil {
+		return
+	}
+
+	mcs := world.MovementCorridorSystem
+	metrics.ConnectivityIndex = mcs.ConnectivityIndex
+	metrics.HabitatPatches = mcs.HabitatPatches
+	metrics.CorridorCount = len(mcs.Corridors)
+	metrics.ChokePointCount = len(mcs.ChokePoints)
+}
+
+// calculateNicheOverlapMetrics keeps the most overlapping species pairs and counts the
+// competitive exclusions they point to
+func (em *EcosystemMonitor) calculateNicheOverlapMetrics(world *World, metrics *EcosystemMetrics) {
+	report := AnalyzeNicheOverlap(world)
+	metrics.LikelyExclusions = report.Exclusions
+	metrics.NicheOverlaps = report.Pairs
+	if len(metrics.NicheOverlaps) > maxReportedOverlaps {
+		metrics.NicheOverlaps = metrics.NicheOverlaps[:maxReportedOverlaps]
+	}
+}
+
 // calculateEcosystemHealth computes overall ecosystem health metrics
 func (em *EcosystemMonitor) calculateEcosystemHealth(world *World, metrics *EcosystemMetrics) {
 	// Biodiversity index combines Shannon diversity and species richness
@@ -28,7 +28,7 @@ func TestEcosystemMonitor(t *testing.T) {
 
 	// Add some plants
 	for i := 0; i < 5; i++ {
-		plant := NewPlant(i+1, PlantGrass, Position{X: float64(i * 2), Y: float64(i * 2)})
+		plant := NewPlant(sharedRand, i+1, PlantGrass, Position{X: float64(i * 2), Y: float64(i * 2)})
 		world.AllPlants = append(world.AllPlants, plant)
 	}
 
@@ -217,7 +217,7 @@ func TestNetworkConnectivity(t *testing.T) {
 
 	// Add plants close together to encourage network formation
 	for i := 0; i < 5; i++ {
-		plant := NewPlant(i+1, PlantGrass, Position{X: float64(i), Y: float64(i)})
+		plant := NewPlant(sharedRand, i+1, PlantGrass, Position{X: float64(i), Y: float64(i)})
 		world.AllPlants = append(world.AllPlants, plant)
 	}
 
@@ -0,0 +1,182 @@
+package main
+
+import "slices"
+
+// HealthComponent is an entity's vitals
+type HealthComponent struct {
+	Energy float64
+	Age    int
+	Alive  bool
+}
+
+// InventoryComponent lists what an entity carries
+type InventoryComponent struct {
+	Tools []int // IDs of the tools it owns, in ID order
+}
+
+// EntityComponents stores the components of the world's entities in packed arrays, one
+// slot per entity in AllEntities order, so systems loop over contiguous memory instead of
+// following pointers and looking up maps for each entity:
+//
+//   - position and health (energy, age, whether it lives)
+//   - traits, one column per trait name, zero where an entity lacks the trait
+//   - neural network, physics and inventory, nil or empty where an entity has none
+//
+// Entities holds the handle to the rest of each entity's record. Sync packs the components
+// from the entities and the systems that own the neural networks, physics and tools, and a
+// system that changes a component writes it through to the entity, so the subsystems not
+// yet moved onto the store see the change.
+//
+// Saves keep their entity-shaped format: entityState builds each record from a slot's
+// components and the store is packed again from the restored entities, so saves written
+// before the store existed load unchanged.
+type EntityComponents struct {
+	IDs       []int
+	Entities  []*Entity
+	Positions []Position
+	Health    []HealthComponent
+	Neural    []*EntityNeuralNetwork
+	Physics   []*PhysicsComponent
+	Inventory []InventoryComponent
+
+	TraitNames []string    // Names of the trait columns, sorted
+	traits     [][]float64 // One column per trait name
+	traitIndex map[string]int
+	slots      map[int]int // Entity ID -> slot
+}
+
+// NewEntityComponents creates an empty component store
+func NewEntityComponents() *EntityComponents {
+	return &EntityComponents{traitIndex: make(map[string]int), slots: make(map[int]int)}
+}
+
+// Sync packs the components of the entities, in the order given, reusing the arrays' memory.
+// Physics, neural networks and tools are looked up by owner; any of them may be nil.
+func (c *EntityComponents) Sync(entities []*Entity, physics map[int]*PhysicsComponent, networks map[int]*EntityNeuralNetwork, tools map[int]*Tool) {
+	c.IDs = c.IDs[:0]
+	c.Entities = c.Entities[:0]
+	c.Positions = c.Positions[:0]
+	c.Health = c.Health[:0]
+	c.Neural = c.Neural[:0]
+	c.Physics = c.Physics[:0]
+	c.Inventory = c.Inventory[:0]
+	clear(c.slots)
+
+	carried := make(map[*Entity][]int)
+	for _, id := range sortedKeys(tools) {
+		if owner := tools[id].Owner; owner != nil {
+			carried[owner] = append(carried[owner], id)
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, entity := range entities {
+		c.slots[entity.ID] = len(c.Entities)
+		c.IDs = append(c.IDs, entity.ID)
+		c.Entities = append(c.Entities, entity)
+		c.Positions = append(c.Positions, entity.Position)
+		c.Health = append(c.Health, HealthComponent{Energy: entity.Energy, Age: entity.Age, Alive: entity.IsAlive})
+		c.Neural = append(c.Neural, networks[entity.ID])
+		c.Physics = append(c.Physics, physics[entity.ID])
+		c.Inventory = append(c.Inventory, InventoryComponent{Tools: carried[entity]})
+		for name := range entity.Traits {
+			names[name] = true
+		}
+	}
+
+	c.TraitNames = append(c.TraitNames[:0], sortedKeys(names)...)
+	clear(c.traitIndex)
+	c.traits = slices.Grow(c.traits[:0], len(c.TraitNames))[:len(c.TraitNames)]
+	for column, name := range c.TraitNames {
+		c.traitIndex[name] = column
+		values := slices.Grow(c.traits[column][:0], len(entities))[:len(entities)]
+		for slot, entity := range entities {
+			values[slot] = entity.GetTrait(name)
+		}
+		c.traits[column] = values
+	}
+}
+
+// Len is the number of entities in the store
+func (c *EntityComponents) Len() int {
+	return len(c.Entities)
+}
+
+// Slot finds an entity's slot
+func (c *EntityComponents) Slot(id int) (int, bool) {
+	slot, exists := c.slots[id]
+	return slot, exists
+}
+
+// Trait returns the column of a trait, one value per slot, or nil when no entity has it
+func (c *EntityComponents) Trait(name string) []float64 {
+	column, exists := c.traitIndex[name]
+	if !exists {
+		return nil
+	}
+	return c.traits[column]
+}
+
+// RefreshPosition copies an entity's position into its slot after something moved it
+func (c *EntityComponents) RefreshPosition(slot int) {
+	c.Positions[slot] = c.Entities[slot].Position
+}
+
+// entityState builds the save record of the entity in a slot from its components. Traits
+// are saved as the entity's genes, without the shifts custom trait hooks add to the columns.
+func (c *EntityComponents) entityState(slot int) *EntityState {
+	entity := c.Entities[slot]
+	state := &EntityState{
+		ID:       c.IDs[slot],
+		Species:  entity.Species,
+		Position: c.Positions[slot],
+		Traits:   make(map[string]float64, len(entity.Traits)),
+		Fitness:  entity.Fitness,
+		Energy:   c.Health[slot].Energy,
+		Age:      c.Health[slot].Age,
+	}
+	for name, trait := range entity.Traits {
+		state.Traits[name] = trait.Value
+	}
+	return state
+}
+
+// entityComponents returns the world's component store packed from its entities as they
+// stand, creating the store for worlds built without one
+func (w *World) entityComponents() *EntityComponents {
+	if w.Components == nil {
+		w.Components = NewEntityComponents()
+	}
+	var tools map[int]*Tool
+	if w.ToolSystem != nil {
+		tools = w.ToolSystem.Tools
+	}
+	var networks map[int]*EntityNeuralNetwork
+	if w.NeuralAISystem != nil {
+		networks = w.NeuralAISystem.EntityNetworks
+	}
+	w.Components.Sync(w.AllEntities, w.PhysicsComponents, networks, tools)
+	return w.Components
+}
+
+// carryTools moves the tools in each living entity's inventory along with it, and drops
+// the tools of entities no longer in the world where their owner last stood
+func (w *World) carryTools(components *EntityComponents) {
+	for slot := 0; slot < components.Len(); slot++ {
+		if !components.Health[slot].Alive {
+			continue
+		}
+		for _, id := range components.Inventory[slot].Tools {
+			w.ToolSystem.Tools[id].Position = components.Positions[slot]
+		}
+	}
+	for _, id := range sortedKeys(w.ToolSystem.Tools) {
+		tool := w.ToolSystem.Tools[id]
+		if tool.Owner == nil {
+			continue
+		}
+		if slot, exists := components.Slot(tool.Owner.ID); !exists || !components.Health[slot].Alive {
+			w.ToolSystem.DropTool(tool, tool.Owner.Position)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEntityComponentsPackEveryComponent(t *testing.T) {
+	entities := []*Entity{
+		NewEntity(sharedRand, 1, []string{"size"}, "A", Position{X: 1, Y: 1}),
+		NewEntity(sharedRand, 2, []string{"size", "intelligence"}, "A", Position{X: 2, Y: 2}),
+		NewEntity(sharedRand, 3, []string{"intelligence"}, "A", Position{X: 3, Y: 3}),
+	}
+	entities[1].IsAlive = false
+	physics := map[int]*PhysicsComponent{1: NewPhysicsComponent(entities[0])}
+	network := &EntityNeuralNetwork{EntityID: 3}
+	tools := map[int]*Tool{7: {ID: 7, Owner: entities[2]}, 5: {ID: 5, Owner: entities[2]}, 6: {ID: 6}}
+
+	components := NewEntityComponents()
+	components.Sync(entities, physics, map[int]*EntityNeuralNetwork{3: network}, tools)
+	if components.Len() != 3 || !reflect.DeepEqual(components.IDs, []int{1, 2, 3}) {
+		t.Fatalf("Expected every entity packed in order, got %v", components.IDs)
+	}
+	slot, exists := components.Slot(3)
+	if !exists || components.Positions[slot] != entities[2].Position || components.Health[slot].Energy != entities[2].Energy {
+		t.Errorf("Expected entity 3's position and health in its slot")
+	}
+	if components.Health[1].Alive || !components.Health[0].Alive {
+		t.Errorf("Expected the dead entity's health to say so, got %+v", components.Health)
+	}
+	if sizes := components.Trait("size"); sizes[0] != entities[0].GetTrait("size") || sizes[2] != 0 {
+		t.Errorf("Expected the size column to hold each entity's size and zero where it has none, got %v", sizes)
+	}
+	if components.Trait("wings") != nil || !reflect.DeepEqual(components.TraitNames, []string{"intelligence", "size"}) {
+		t.Errorf("Expected columns for the traits the entities have, got %v", components.TraitNames)
+	}
+	if components.Physics[0] != physics[1] || components.Physics[2] != nil || components.Neural[2] != network || components.Neural[0] != nil {
+		t.Errorf("Expected physics and networks packed with their owners")
+	}
+	if !reflect.DeepEqual(components.Inventory[2].Tools, []int{5, 7}) || len(components.Inventory[0].Tools) != 0 {
+		t.Errorf("Expected entity 3 to carry tools 5 and 7, got %+v", components.Inventory)
+	}
+
+	// A second sync starts afresh
+	components.Sync(entities[2:], nil, nil, nil)
+	if _, exists := components.Slot(1); exists || components.Len() != 1 || components.Trait("size") != nil {
+		t.Errorf("Expected the store rebuilt from the new entities")
+	}
+}
+
+func TestComponentCollisionsMoveEntitiesApart(t *testing.T) {
+	first := NewEntity(sharedRand, 1, []string{"size", "endurance"}, "A", Position{X: 10, Y: 10})
+	second := NewEntity(sharedRand, 2, []string{"size", "endurance"}, "A", Position{X: 10.2, Y: 10})
+	ghost := NewEntity(sharedRand, 3, []string{"size", "endurance"}, "A", Position{X: 10.1, Y: 10})
+	far := NewEntity(sharedRand, 4, []string{"size", "endurance"}, "A", Position{X: 50, Y: 50})
+	entities := []*Entity{first, ghost, second, far}
+	ghost.IsAlive = false
+	physics := map[int]*PhysicsComponent{}
+	for _, entity := range entities {
+		entity.SetTrait("size", 0)
+		physics[entity.ID] = NewPhysicsComponent(entity)
+	}
+	physics[1].Velocity = Vector2D{X: 1}
+
+	components := NewEntityComponents()
+	components.Sync(entities, physics, nil, nil)
+	system := NewPhysicsSystem()
+	NewCollisionSystem().CheckComponentCollisions(components, system, nil)
+
+	if system.CollisionsThisTick != 1 || ghost.Position.X != 10.1 {
+		t.Fatalf("Expected one collision between the living entities, got %d", system.CollisionsThisTick)
+	}
+	if first.Position.X >= 10 || second.Position.X <= 10.2 || components.Positions[0] != first.Position {
+		t.Errorf("Expected the colliding entities pushed apart and their slots updated, got %v and %v", first.Position, second.Position)
+	}
+	if physics[1].Velocity.X >= 1 || physics[2].Velocity.X <= 0 {
+		t.Errorf("Expected momentum passed from the first entity to the second")
+	}
+}
+
+func TestToolsTravelInTheirOwnersInventory(t *testing.T) {
+	world := newEmptyTestWorld(100)
+	owner := NewEntity(world.Rand, 1, []string{"intelligence"}, "Folk", Position{X: 10, Y: 10})
+	fallen := NewEntity(world.Rand, 2, []string{"intelligence"}, "Folk", Position{X: 30, Y: 30})
+	world.AllEntities = []*Entity{owner, fallen}
+	carried := &Tool{ID: 1, Type: ToolStone, Owner: owner, Position: owner.Position}
+	dropped := &Tool{ID: 2, Type: ToolStone, Owner: fallen, Position: fallen.Position}
+	world.ToolSystem.Tools = map[int]*Tool{1: carried, 2: dropped}
+
+	owner.Position = Position{X: 60, Y: 40}
+	fallen.IsAlive = false
+	world.carryTools(world.entityComponents())
+	if carried.Owner != owner || carried.Position != owner.Position {
+		t.Errorf("Expected the tool carried to its owner at %v, got %v", owner.Position, carried.Position)
+	}
+	if dropped.Owner != nil || dropped.Position != fallen.Position {
+		t.Errorf("Expected a dead owner's tool dropped where it fell, got %+v", dropped)
+	}
+}
+
+func TestNeuralDecisionsSteerOnlyThinkingSlots(t *testing.T) {
+	world := newEmptyTestWorld(100)
+	thinker := NewEntity(world.Rand, 1, []string{"intelligence"}, "Folk", Position{X: 10, Y: 10})
+	simple := NewEntity(world.Rand, 2, []string{"intelligence"}, "Folk", Position{X: 20, Y: 20})
+	unbuilt := NewEntity(world.Rand, 3, []string{"intelligence"}, "Folk", Position{X: 30, Y: 30})
+	thinker.SetTrait("intelligence", 0.8)
+	simple.SetTrait("intelligence", 0.1)
+	unbuilt.SetTrait("intelligence", 0.8)
+	world.AllEntities = []*Entity{thinker, simple, unbuilt}
+	world.NeuralAISystem.CreateNeuralNetwork(thinker, world.Tick)
+
+	if slots := thinkingSlots(world.entityComponents()); !reflect.DeepEqual(slots, []int{0}) {
+		t.Errorf("Expected only the intelligent entity with a network to think, got slots %v", slots)
+	}
+}
+
+func TestSavesAreWrittenFromComponentsInTheEntityFormat(t *testing.T) {
+	// An entity record as saves held it before the component store
+	record := &EntityState{ID: 9, Species: "Folk", Position: Position{X: 12, Y: 34}, Traits: map[string]float64{"speed": 0.4, "size": -0.2}, Fitness: 1.5, Energy: 61, Age: 17}
+	world := newEmptyTestWorld(100)
+	state, err := NewStateManager(world).CurrentState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Entities = []*EntityState{record}
+
+	loaded := newEmptyTestWorld(100)
+	if err := NewStateManager(loaded).restoreState(state); err != nil {
+		t.Fatal(err)
+	}
+	components := loaded.entityComponents()
+	slot, exists := components.Slot(9)
+	if !exists || components.Positions[slot] != record.Position || components.Health[slot].Energy != 61 || components.Trait("speed")[slot] != 0.4 {
+		t.Fatalf("Expected the saved entity packed into the store, got %+v", components.Health)
+	}
+
+	resaved, err := NewStateManager(loaded).CurrentState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resaved.Entities[0]; got.ID != record.ID || got.Position != record.Position || got.Energy != record.Energy ||
+		got.Age != record.Age || got.Fitness != record.Fitness || !reflect.DeepEqual(got.Traits, record.Traits) {
+		t.Errorf("Expected the record written back unchanged, got %+v", got)
+	}
+}
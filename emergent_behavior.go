@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	"math"
-	"math/rand"
 )
 
 // EmergentBehaviorSystem manages emergent behaviors that can develop naturally
@@ -157,13 +156,13 @@ func (ebs *EmergentBehaviorSystem) UpdateEntityBehaviors(world *World) {
 
 		// Try to discover new behaviors - enhanced discovery rate
 		discoveryRate := pattern.Curiosity * entity.GetTrait("intelligence") * 0.03 // Increased rate
-		if rand.Float64() < discoveryRate {
+		if world.Rand.Float64() < discoveryRate {
 			ebs.attemptBehaviorDiscovery(entity, pattern, world)
 		}
 
 		// Try to learn from nearby entities - enhanced social learning
 		socialRate := pattern.Curiosity * entity.GetTrait("cooperation") * 0.12 // Increased rate
-		if pattern.SocialLearning && rand.Float64() < socialRate {
+		if pattern.SocialLearning && world.Rand.Float64() < socialRate {
 			ebs.attemptSocialLearning(entity, pattern, world)
 		}
 
@@ -174,7 +173,8 @@ func (ebs *EmergentBehaviorSystem) UpdateEntityBehaviors(world *World) {
 
 // attemptBehaviorDiscovery tries to discover new behaviors based on circumstances
 func (ebs *EmergentBehaviorSystem) attemptBehaviorDiscovery(entity *Entity, pattern *BehaviorPattern, world *World) {
-	for behaviorName, behavior := range ebs.LearnedBehaviors {
+	for _, behaviorName := range sortedKeys(ebs.LearnedBehaviors) {
+		behavior := ebs.LearnedBehaviors[behaviorName]
 		// Skip if already known
 		if pattern.KnownBehaviors[behaviorName] > 0.0 {
 			continue
@@ -198,7 +198,7 @@ func (ebs *EmergentBehaviorSystem) attemptBehaviorDiscovery(entity *Entity, patt
 		curiosityBonus := pattern.Curiosity * 0.6                                       // Increased curiosity bonus
 		discoveryChance := (intelligence - behavior.Complexity + curiosityBonus) * 0.18 // Increased discovery multiplier
 
-		if discoveryChance > 0 && rand.Float64() < discoveryChance {
+		if discoveryChance > 0 && world.Rand.Float64() < discoveryChance {
 			// Successfully discovered behavior!
 			pattern.KnownBehaviors[behaviorName] = 0.15 // Start with higher proficiency
 			behavior.Spread++
@@ -219,8 +219,7 @@ func (ebs *EmergentBehaviorSystem) attemptSocialLearning(entity *Entity, pattern
 		}
 
 		// Check if nearby
-		distance := math.Sqrt(math.Pow(entity.Position.X-other.Position.X, 2) +
-			math.Pow(entity.Position.Y-other.Position.Y, 2))
+		distance := world.Distance(entity.Position, other.Position)
 
 		if distance > 5.0 {
 			continue
@@ -233,7 +232,8 @@ func (ebs *EmergentBehaviorSystem) attemptSocialLearning(entity *Entity, pattern
 		}
 
 		// Try to learn behaviors the other entity knows
-		for behaviorName, otherProficiency := range otherPattern.KnownBehaviors {
+		for _, behaviorName := range sortedKeys(otherPattern.KnownBehaviors) {
+			otherProficiency := otherPattern.KnownBehaviors[behaviorName]
 			if otherProficiency <= 0.03 { // Further lowered threshold for learning
 				continue
 			}
@@ -248,7 +248,7 @@ func (ebs *EmergentBehaviorSystem) attemptSocialLearning(entity *Entity, pattern
 			proximityBonus := math.Max(0, (5.0-distance)/5.0) * 0.05                              // Increased proximity bonus
 			learningChance := baseChance + proximityBonus
 
-			if rand.Float64() < learningChance {
+			if world.Rand.Float64() < learningChance {
 				// Learn a bit of the behavior - increased learning rate
 				improvement := pattern.LearningRate * 0.2 // Increased improvement rate
 				pattern.KnownBehaviors[behaviorName] = math.Min(otherProficiency, myProficiency+improvement)
@@ -372,7 +372,7 @@ func (ebs *EmergentBehaviorSystem) executeBehavior(entity *Entity, behaviorName
 
 	case "tunnel_digging":
 		// Create a tunnel for protection
-		direction := rand.Float64() * 2 * math.Pi
+		direction := world.Rand.Float64() * 2 * math.Pi
 		length := 3.0 + proficiency*5.0
 		tunnel := world.EnvironmentalModSystem.CreateTunnel(entity, entity.Position, direction, length)
 		if tunnel != nil {
@@ -398,8 +398,8 @@ func (ebs *EmergentBehaviorSystem) executeBehavior(entity *Entity, behaviorName
 		// Try to improve an existing tool
 		tools := world.ToolSystem.GetEntityTools(entity)
 		if len(tools) > 0 {
-			tool := tools[rand.Intn(len(tools))]
-			modificationType := ModificationType(rand.Intn(6)) // Random modification type
+			tool := tools[world.Rand.Intn(len(tools))]
+			modificationType := ModificationType(world.Rand.Intn(6)) // Random modification type
 			success := world.ToolSystem.ModifyTool(tool, entity, modificationType)
 			if success {
 				pattern.KnownBehaviors[behaviorName] = math.Min(1.0, proficiency+0.03)
@@ -417,8 +417,7 @@ func (ebs *EmergentBehaviorSystem) assessNearbyDanger(entity *Entity, world *Wor
 			continue
 		}
 
-		distance := math.Sqrt(math.Pow(entity.Position.X-other.Position.X, 2) +
-			math.Pow(entity.Position.Y-other.Position.Y, 2))
+		distance := world.Distance(entity.Position, other.Position)
 
 		if distance < 10.0 {
 			otherAggression := other.GetTrait("aggression")
@@ -435,8 +434,7 @@ func (ebs *EmergentBehaviorSystem) countNearbyResources(entity *Entity, world *W
 			continue
 		}
 
-		distance := math.Sqrt(math.Pow(entity.Position.X-plant.Position.X, 2) +
-			math.Pow(entity.Position.Y-plant.Position.Y, 2))
+		distance := world.Distance(entity.Position, plant.Position)
 
 		if distance < 8.0 {
 			count++
@@ -454,8 +452,7 @@ func (ebs *EmergentBehaviorSystem) countNearbyAllies(entity *Entity, world *Worl
 			continue
 		}
 
-		distance := math.Sqrt(math.Pow(entity.Position.X-other.Position.X, 2) +
-			math.Pow(entity.Position.Y-other.Position.Y, 2))
+		distance := world.Distance(entity.Position, other.Position)
 
 		if distance < 6.0 {
 			otherCooperation := other.GetTrait("cooperation")
@@ -476,8 +473,7 @@ func (ebs *EmergentBehaviorSystem) countNeedyAllies(entity *Entity, world *World
 			continue
 		}
 
-		distance := math.Sqrt(math.Pow(entity.Position.X-other.Position.X, 2) +
-			math.Pow(entity.Position.Y-other.Position.Y, 2))
+		distance := world.Distance(entity.Position, other.Position)
 
 		if distance < 6.0 && other.Energy < 40.0 {
 			otherCooperation := other.GetTrait("cooperation")
@@ -493,7 +489,8 @@ func (ebs *EmergentBehaviorSystem) chooseBestToolType(entity *Entity, pattern *B
 	bestScore := 0.0
 	bestType := -1
 
-	for toolType, preference := range pattern.ToolPreferences {
+	for _, toolType := range sortedKeys(pattern.ToolPreferences) {
+		preference := pattern.ToolPreferences[toolType]
 		// Check if entity can create this tool type
 		recipe, exists := world.ToolSystem.ToolRecipes[toolType]
 		if !exists {
@@ -534,8 +531,8 @@ func (ebs *EmergentBehaviorSystem) findGoodTrapLocation(entity *Entity, world *W
 	bestScore := 0.0
 
 	for i := 0; i < 10; i++ {
-		angle := rand.Float64() * 2 * math.Pi
-		distance := 3.0 + rand.Float64()*5.0
+		angle := world.Rand.Float64() * 2 * math.Pi
+		distance := 3.0 + world.Rand.Float64()*5.0
 
 		testPos := Position{
 			X: entity.Position.X + math.Cos(angle)*distance,
@@ -549,8 +546,7 @@ func (ebs *EmergentBehaviorSystem) findGoodTrapLocation(entity *Entity, world *W
 				continue
 			}
 
-			plantDistance := math.Sqrt(math.Pow(testPos.X-plant.Position.X, 2) +
-				math.Pow(testPos.Y-plant.Position.Y, 2))
+			plantDistance := world.Distance(testPos, plant.Position)
 
 			if plantDistance < 4.0 {
 				resourceCount++
@@ -192,7 +192,7 @@ func TestEventEffectsOnEntities(t *testing.T) {
 	world := NewWorld(config)
 
 	// Create an entity at a known position
-	entity := NewEntity(1, []string{"energy", "speed", "vision"}, "test_species", Position{X: 10, Y: 10})
+	entity := NewEntity(sharedRand, 1, []string{"energy", "speed", "vision"}, "test_species", Position{X: 10, Y: 10})
 	entity.Energy = 100.0
 	world.AllEntities = append(world.AllEntities, entity)
 
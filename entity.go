@@ -90,10 +90,13 @@ type Entity struct {
 
 	// Biorhythm system
 	BioRhythm *BioRhythm `json:"biorhythm"` // Tracks biological rhythms and activity needs
+
+	// Shifts custom trait hooks add to built-in traits; recomputed each tick, never inherited
+	traitOffsets map[string]float64
 }
 
 // NewEntity creates a new entity with random traits
-func NewEntity(id int, traitNames []string, species string, position Position) *Entity {
+func NewEntity(rng *rand.Rand, id int, traitNames []string, species string, position Position) *Entity {
 	entity := &Entity{
 		ID:         id,
 		Traits:     make(map[string]Trait),
@@ -110,13 +113,13 @@ func NewEntity(id int, traitNames []string, species string, position Position) *
 	for _, name := range traitNames {
 		entity.Traits[name] = Trait{
 			Name:  name,
-			Value: rand.Float64()*2 - 1, // Random value between -1 and 1
+			Value: rng.Float64()*2 - 1, // Random value between -1 and 1
 		}
 	}
 
 	// Initialize molecular systems
 	entity.MolecularNeeds = NewMolecularNeeds(entity)
-	entity.MolecularMetabolism = NewMolecularMetabolism(entity)
+	entity.MolecularMetabolism = NewMolecularMetabolism(rng, entity)
 	entity.MolecularProfile = CreateEntityMolecularProfile(entity)
 
 	// Initialize feedback loop systems
@@ -124,7 +127,7 @@ func NewEntity(id int, traitNames []string, species string, position Position) *
 	entity.EnvironmentalMemory = NewEnvironmentalMemory()
 
 	// Initialize reproduction system
-	entity.ReproductionStatus = NewReproductionStatus()
+	entity.ReproductionStatus = NewReproductionStatus(rng)
 
 	// Initialize organism classification (will be updated by world systems)
 	entity.Classification = ClassificationEukaryotic // Default classification
@@ -136,26 +139,35 @@ func NewEntity(id int, traitNames []string, species string, position Position) *
 	entity.OriginalTraits = nil
 
 	// Initialize biorhythm system
-	entity.BioRhythm = NewBioRhythm(id, entity)
+	entity.BioRhythm = NewBioRhythm(rng, id, entity)
 
 	return entity
 }
 
-// GetTrait safely gets a trait value, returning 0 if not found
+// GetTrait safely gets a trait value, returning 0 if not found. The value includes any
+// shift from custom trait hooks.
 func (e *Entity) GetTrait(name string) float64 {
 	if trait, exists := e.Traits[name]; exists {
-		return trait.Value
+		return trait.Value + e.traitOffsets[name]
 	}
-	return 0.0
+	return e.traitOffsets[name]
 }
 
-// SetTrait sets or updates a trait
+// SetTrait sets or updates a trait so that GetTrait returns value, keeping any shift from
+// custom trait hooks out of the stored gene
 func (e *Entity) SetTrait(name string, value float64) {
-	e.Traits[name] = Trait{Name: name, Value: value}
+	e.Traits[name] = Trait{Name: name, Value: value - e.traitOffsets[name]}
+}
+
+// Mutate applies random mutations to the entity's traits with feedback loop influence,
+// using the default mutation operators
+func (e *Entity) Mutate(rng *rand.Rand, mutationRate float64, mutationStrength float64) {
+	e.MutateWith(rng, nil, mutationRate, mutationStrength)
 }
 
-// Mutate applies random mutations to the entity's traits with feedback loop influence
-func (e *Entity) Mutate(mutationRate float64, mutationStrength float64) {
+// MutateWith applies random mutations using the operators and rates of a mutation system,
+// which logs the operator behind each change. A nil system uses the default operators.
+func (e *Entity) MutateWith(rng *rand.Rand, mutations *MutationSystem, mutationRate float64, mutationStrength float64) {
 	// Calculate mutation pressure from feedback loops
 
 	// Environmental pressure increases mutation rate and strength
@@ -189,15 +201,16 @@ func (e *Entity) Mutate(mutationRate float64, mutationStrength float64) {
 	mutationRate = math.Min(mutationRate, 0.5)         // Max 50% mutation chance
 	mutationStrength = math.Min(mutationStrength, 0.8) // Max strength
 
-	for name, trait := range e.Traits {
-		if rand.Float64() < mutationRate {
-			// Apply Gaussian noise for mutation
-			mutation := rand.NormFloat64() * mutationStrength
-
-			// Bias mutations toward beneficial directions based on feedback
-			mutation = e.biasedMutation(name, mutation)
-
-			newValue := trait.Value + mutation
+	for _, name := range sortedKeys(e.Traits) {
+		trait := e.Traits[name]
+		// The roll that decides whether the trait mutates also picks the operator, so
+		// choosing one draws nothing extra from the random stream
+		if roll := rng.Float64(); roll < mutationRate {
+			operator := mutations.choose(roll / mutationRate)
+			if operator == nil {
+				continue // Every operator is switched off
+			}
+			newValue := operator.apply(rng, e, name, trait.Value, mutationStrength)
 
 			// Clamp values to reasonable bounds
 			newValue = math.Max(-2.0, math.Min(2.0, newValue))
@@ -206,12 +219,13 @@ func (e *Entity) Mutate(mutationRate float64, mutationStrength float64) {
 				Name:  name,
 				Value: newValue,
 			}
+			mutations.record(e, name, operator.Name, trait.Value, newValue)
 		}
 	}
 }
 
 // Clone creates a deep copy of the entity
-func (e *Entity) Clone() *Entity {
+func (e *Entity) Clone(rng *rand.Rand) *Entity {
 	clone := &Entity{
 		ID:         e.ID,
 		Traits:     make(map[string]Trait),
@@ -241,14 +255,14 @@ func (e *Entity) Clone() *Entity {
 
 	// Clone reproduction status
 	if e.ReproductionStatus != nil {
-		clone.ReproductionStatus = NewReproductionStatus()
+		clone.ReproductionStatus = NewReproductionStatus(rng)
 		// Copy some heritable traits
 		clone.ReproductionStatus.Mode = e.ReproductionStatus.Mode
 		clone.ReproductionStatus.Strategy = e.ReproductionStatus.Strategy
 	}
 
 	// Initialize new biorhythm (don't copy - each entity gets a fresh rhythm)
-	clone.BioRhythm = NewBioRhythm(clone.ID, clone)
+	clone.BioRhythm = NewBioRhythm(rng, clone.ID, clone)
 
 	return clone
 }
@@ -259,21 +273,21 @@ func (e *Entity) String() string {
 		e.ID, e.Species, e.Fitness, e.Energy, e.Position.X, e.Position.Y, e.IsAlive)
 }
 
-// DistanceTo calculates the distance to another entity
+// DistanceTo calculates the straight-line distance to another entity, ignoring any edges
+// the world joins; World.Distance measures the short way round
 func (e *Entity) DistanceTo(other *Entity) float64 {
 	dx := e.Position.X - other.Position.X
 	dy := e.Position.Y - other.Position.Y
 	return math.Sqrt(dx*dx + dy*dy)
 }
 
-// MoveTo moves the entity towards a target position with environment-specific adaptations
-func (e *Entity) MoveTo(targetX, targetY float64, speed float64) {
+// MoveTo moves the entity towards a target position, the short way round a map that wraps
+func (e *Entity) MoveTo(targetX, targetY float64, speed float64, geometry WorldGeometry) {
 	if !e.IsAlive {
 		return
 	}
 
-	dx := targetX - e.Position.X
-	dy := targetY - e.Position.Y
+	dx, dy := geometry.Direction(e.Position, Position{X: targetX, Y: targetY})
 	distance := math.Sqrt(dx*dx + dy*dy)
 
 	if distance > 0 {
@@ -291,13 +305,12 @@ func (e *Entity) MoveTo(targetX, targetY float64, speed float64) {
 }
 
 // MoveToWithConfig moves the entity to a target position using configuration for energy costs
-func (e *Entity) MoveToWithConfig(targetX, targetY float64, speed float64, config *SimulationConfig) {
+func (e *Entity) MoveToWithConfig(targetX, targetY float64, speed float64, config *SimulationConfig, geometry WorldGeometry) {
 	if !e.IsAlive {
 		return
 	}
 
-	dx := targetX - e.Position.X
-	dy := targetY - e.Position.Y
+	dx, dy := geometry.Direction(e.Position, Position{X: targetX, Y: targetY})
 	distance := math.Sqrt(dx*dx + dy*dy)
 
 	if distance > 0 {
@@ -314,8 +327,9 @@ func (e *Entity) MoveToWithConfig(targetX, targetY float64, speed float64, confi
 	}
 }
 
-// MoveToWithEnvironment moves the entity with environment-specific adaptations
-func (e *Entity) MoveToWithEnvironment(targetX, targetY float64, speed float64, biome BiomeType) {
+// MoveToWithEnvironment moves the entity with environment-specific adaptations, the short way
+// round a map that wraps
+func (e *Entity) MoveToWithEnvironment(targetX, targetY float64, speed float64, biome BiomeType, geometry WorldGeometry) {
 	if !e.IsAlive {
 		return
 	}
@@ -376,8 +390,7 @@ func (e *Entity) MoveToWithEnvironment(targetX, targetY float64, speed float64,
 	}
 
 	// Apply movement
-	dx := targetX - e.Position.X
-	dy := targetY - e.Position.Y
+	dx, dy := geometry.Direction(e.Position, Position{X: targetX, Y: targetY})
 	distance := math.Sqrt(dx*dx + dy*dy)
 
 	if distance > 0 {
@@ -388,20 +401,21 @@ func (e *Entity) MoveToWithEnvironment(targetX, targetY float64, speed float64,
 		e.Position.X += dx
 		e.Position.Y += dy
 
-		// Apply environment-specific energy cost
-		energyCost := effectiveSpeed * 0.1 * energyMultiplier
+		// Apply environment-specific energy cost; a negative speed trait moves the
+		// creature backwards at the same cost
+		energyCost := math.Abs(effectiveSpeed) * 0.1 * energyMultiplier
 		e.Energy -= energyCost
 	}
 }
 
 // MoveRandomly moves the entity in a random direction with environment considerations
-func (e *Entity) MoveRandomly(maxDistance float64) {
+func (e *Entity) MoveRandomly(rng *rand.Rand, maxDistance float64) {
 	if !e.IsAlive {
 		return
 	}
 
-	angle := rand.Float64() * 2 * math.Pi
-	distance := rand.Float64() * maxDistance
+	angle := rng.Float64() * 2 * math.Pi
+	distance := rng.Float64() * maxDistance
 
 	e.Position.X += math.Cos(angle) * distance
 	e.Position.Y += math.Sin(angle) * distance
@@ -411,13 +425,13 @@ func (e *Entity) MoveRandomly(maxDistance float64) {
 }
 
 // MoveRandomlyWithEnvironment moves the entity randomly with environment-specific adaptations
-func (e *Entity) MoveRandomlyWithEnvironment(maxDistance float64, biome BiomeType) {
+func (e *Entity) MoveRandomlyWithEnvironment(rng *rand.Rand, maxDistance float64, biome BiomeType) {
 	if !e.IsAlive {
 		return
 	}
 
-	angle := rand.Float64() * 2 * math.Pi
-	distance := rand.Float64() * maxDistance
+	angle := rng.Float64() * 2 * math.Pi
+	distance := rng.Float64() * maxDistance
 
 	// Apply environment-specific movement constraints
 	effectiveDistance := distance
@@ -469,7 +483,7 @@ func (e *Entity) MoveRandomlyWithEnvironment(maxDistance float64, biome BiomeTyp
 }
 
 // CanKill determines if this entity can kill another based on traits
-func (e *Entity) CanKill(other *Entity) bool {
+func (e *Entity) CanKill(rng *rand.Rand, other *Entity) bool {
 	if !e.IsAlive || !other.IsAlive || e.Species == other.Species {
 		return false
 	}
@@ -479,14 +493,14 @@ func (e *Entity) CanKill(other *Entity) bool {
 	theirPower := other.GetTrait("defense") + other.GetTrait("strength") + other.GetTrait("size")
 
 	// Add some randomness to combat
-	myPower += (rand.Float64() - 0.5) * 0.5
+	myPower += (rng.Float64() - 0.5) * 0.5
 
 	return myPower > theirPower && e.Energy > 20
 }
 
 // Kill attempts to kill another entity
-func (e *Entity) Kill(other *Entity) bool {
-	if !e.CanKill(other) {
+func (e *Entity) Kill(rng *rand.Rand, other *Entity) bool {
+	if !e.CanKill(rng, other) {
 		return false
 	}
 
@@ -597,7 +611,7 @@ func (e *Entity) CanMerge(other *Entity) bool {
 }
 
 // Merge combines this entity with another, creating a new entity
-func (e *Entity) Merge(other *Entity, newID int) *Entity {
+func (e *Entity) Merge(rng *rand.Rand, other *Entity, newID int) *Entity {
 	if !e.CanMerge(other) {
 		return nil
 	}
@@ -624,13 +638,13 @@ func (e *Entity) Merge(other *Entity, newID int) *Entity {
 		allTraits[name] = true
 	}
 
-	for name := range allTraits {
-		val1 := e.GetTrait(name)
-		val2 := other.GetTrait(name)
+	for _, name := range sortedKeys(allTraits) {
+		val1 := e.Traits[name].Value
+		val2 := other.Traits[name].Value
 		avgValue := (val1 + val2) / 2.0
 
 		// Add small random variation
-		avgValue += (rand.Float64() - 0.5) * 0.1
+		avgValue += (rng.Float64() - 0.5) * 0.1
 		avgValue = math.Max(-2.0, math.Min(2.0, avgValue))
 
 		merged.SetTrait(name, avgValue)
@@ -644,7 +658,7 @@ func (e *Entity) Merge(other *Entity, newID int) *Entity {
 }
 
 // Crossover performs recombination between two entities
-func Crossover(parent1, parent2 *Entity, childID int, species string) *Entity {
+func Crossover(rng *rand.Rand, parent1, parent2 *Entity, childID int, species string) *Entity {
 	// Calculate position between parents
 	childPos := Position{
 		X: (parent1.Position.X + parent2.Position.X) / 2.0,
@@ -673,12 +687,13 @@ func Crossover(parent1, parent2 *Entity, childID int, species string) *Entity {
 	}
 
 	// For each trait, randomly choose from one parent or take average
-	for name := range traitNames {
-		val1 := parent1.GetTrait(name)
-		val2 := parent2.GetTrait(name)
+	for _, name := range sortedKeys(traitNames) {
+		// Inherit the genes, not what custom trait hooks add to them
+		val1 := parent1.Traits[name].Value
+		val2 := parent2.Traits[name].Value
 
 		var childValue float64
-		if rand.Float64() < 0.5 {
+		if rng.Float64() < 0.5 {
 			// Take from parent1
 			childValue = val1
 		} else {
@@ -687,7 +702,7 @@ func Crossover(parent1, parent2 *Entity, childID int, species string) *Entity {
 		}
 
 		// Sometimes blend the values (25% chance)
-		if rand.Float64() < 0.25 {
+		if rng.Float64() < 0.25 {
 			childValue = (val1 + val2) / 2.0
 		}
 
@@ -700,7 +715,7 @@ func Crossover(parent1, parent2 *Entity, childID int, species string) *Entity {
 
 	// Inherit dietary preferences (averaged from parents)
 	if parent1.DietaryMemory != nil && parent2.DietaryMemory != nil {
-		child.inheritDietaryPreferences(parent1, parent2)
+		child.inheritDietaryPreferences(rng, parent1, parent2)
 	}
 
 	// Inherit environmental adaptations (averaged from parents)
@@ -711,11 +726,6 @@ func Crossover(parent1, parent2 *Entity, childID int, species string) *Entity {
 	return child
 }
 
-func init() {
-	// Note: As of Go 1.20, rand.Seed is deprecated and not needed
-	// Global random generator is automatically seeded
-}
-
 // Update handles entity aging, energy decay, and natural death
 // Deprecated: Use UpdateWithConfig instead for configuration-aware updates
 func (e *Entity) Update() {
@@ -923,7 +933,7 @@ func (e *Entity) UpdateWithClassificationAndConfig(classifier *OrganismClassifie
 }
 
 // SeekNutrition makes the entity move toward the most nutritionally desirable nearby food sources
-func (e *Entity) SeekNutrition(plants []*Plant, entities []*Entity, maxDistance float64) (targetX, targetY float64, found bool) {
+func (e *Entity) SeekNutrition(plants []*Plant, entities []*Entity, maxDistance float64, geometry WorldGeometry) (targetX, targetY float64, found bool) {
 	if !e.IsAlive || e.MolecularNeeds == nil {
 		return 0, 0, false
 	}
@@ -938,10 +948,7 @@ func (e *Entity) SeekNutrition(plants []*Plant, entities []*Entity, maxDistance
 			continue
 		}
 
-		// Calculate distance
-		dx := plant.Position.X - e.Position.X
-		dy := plant.Position.Y - e.Position.Y
-		distance := math.Sqrt(dx*dx + dy*dy)
+		distance := geometry.Distance(e.Position, plant.Position)
 
 		if distance > maxDistance {
 			continue
@@ -967,10 +974,7 @@ func (e *Entity) SeekNutrition(plants []*Plant, entities []*Entity, maxDistance
 				continue
 			}
 
-			// Calculate distance
-			dx := other.Position.X - e.Position.X
-			dy := other.Position.Y - e.Position.Y
-			distance := math.Sqrt(dx*dx + dy*dy)
+			distance := geometry.Distance(e.Position, other.Position)
 
 			if distance > maxDistance {
 				continue
@@ -1149,7 +1153,7 @@ func (e *Entity) DrinkWater(world *World, tick int) bool {
 		hydrationGain = 4.0 // Ice/snow as water source
 	default:
 		// Small chance of finding water in other biomes
-		if rand.Float64() < 0.1 {
+		if world.Rand.Float64() < 0.1 {
 			canDrink = true
 			hydrationGain = 3.0 // Found small water source
 		}
@@ -1191,6 +1195,32 @@ func (e *Entity) CheckStarvation(world *World) {
 	e.checkEvolutionaryPressure(world)
 }
 
+// CheckComplexity lets well-fed microbes and simple organisms evolve into more complex
+// forms; unlike the other species they change when they have energy to spare
+func (e *Entity) CheckComplexity(world *World) {
+	if !e.IsAlive {
+		return
+	}
+
+	biome := world.getBiomeAtPosition(e.Position.X, e.Position.Y)
+	switch e.speciesType(world) {
+	case SpeciesMicrobe:
+		e.handleMicrobeEvolution(world, biome)
+	case "simple":
+		e.handleSimpleOrganismEvolution(world, biome)
+	}
+}
+
+// speciesType resolves the underlying species type of a population with a generated name
+func (e *Entity) speciesType(world *World) string {
+	if world.SpeciesNaming != nil {
+		if info := world.SpeciesNaming.GetSpeciesInfo(e.Species); info != nil {
+			return info.Species
+		}
+	}
+	return e.Species
+}
+
 // checkEvolutionaryPressure applies environmental and survival pressure to drive evolution
 func (e *Entity) checkEvolutionaryPressure(world *World) {
 	if !e.IsAlive {
@@ -1201,11 +1231,7 @@ func (e *Entity) checkEvolutionaryPressure(world *World) {
 	biome := world.getBiomeAtPosition(e.Position.X, e.Position.Y)
 
 	// Different evolutionary pressures based on current species and conditions
-	switch e.Species {
-	case SpeciesMicrobe:
-		e.handleMicrobeEvolution(world, biome)
-	case "simple":
-		e.handleSimpleOrganismEvolution(world, biome)
+	switch e.speciesType(world) {
 	case SpeciesPredator:
 		e.handlePredatorEvolution(world, biome)
 	case "herbivore":
@@ -1232,7 +1258,7 @@ func (e *Entity) handleMicrobeEvolution(world *World, biome BiomeType) {
 			evolutionChance *= 2.0 // Good for basic evolution
 		}
 
-		if rand.Float64() < evolutionChance {
+		if world.Rand.Float64() < evolutionChance {
 			e.evolveSpecies("simple", world)
 		}
 	}
@@ -1271,7 +1297,7 @@ func (e *Entity) handleSimpleOrganismEvolution(world *World, biome BiomeType) {
 			}
 		}
 
-		if rand.Float64() < evolutionChance {
+		if world.Rand.Float64() < evolutionChance {
 			e.evolveSpecies(targetSpecies, world)
 		}
 	}
@@ -1282,15 +1308,15 @@ func (e *Entity) handlePredatorEvolution(world *World, biome BiomeType) {
 	if e.Energy < 5 && e.Age > 50 {
 		// Check if there are any herbivores or omnivores nearby
 		hasPreyNearby := false
-		for _, other := range world.AllEntities {
-			if other.IsAlive && other.Species != "predator" && e.DistanceTo(other) < 20 {
+		for _, other := range world.spatialIndex().QueryRadius(e.Position, 20) {
+			if other.IsAlive && other.Species != "predator" && world.Distance(e.Position, other.Position) < 20 {
 				hasPreyNearby = true
 				break
 			}
 		}
 
 		// If no prey nearby, consider evolutionary adaptation
-		if !hasPreyNearby && rand.Float64() < 0.001 {
+		if !hasPreyNearby && world.Rand.Float64() < 0.001 {
 			// Environment influences evolution direction
 			switch biome {
 			case BiomeWater:
@@ -1317,13 +1343,13 @@ func (e *Entity) handleHerbivoreEvolution(world *World, biome BiomeType) {
 	// Herbivores might evolve under predation pressure
 	if e.Energy < 10 && e.Age > 40 {
 		nearbyPredators := 0
-		for _, other := range world.AllEntities {
-			if other.IsAlive && other.Species == "predator" && e.DistanceTo(other) < 15 {
+		for _, other := range world.spatialIndex().QueryRadius(e.Position, 15) {
+			if other.IsAlive && other.Species == "predator" && world.Distance(e.Position, other.Position) < 15 {
 				nearbyPredators++
 			}
 		}
 
-		if nearbyPredators > 2 && rand.Float64() < 0.0005 {
+		if nearbyPredators > 2 && world.Rand.Float64() < 0.0005 {
 			// High predation pressure - might evolve defenses or become omnivore
 			if biome == BiomeWater && e.GetTrait("aquatic_adaptation") > 0 {
 				e.evolveSpecies("aquatic_herbivore", world)
@@ -1346,21 +1372,21 @@ func (e *Entity) handleOmnivoreEvolution(world *World, biome BiomeType) {
 		case BiomeWater:
 			if e.GetTrait("aquatic_adaptation") > 0.5 {
 				evolutionChance *= 2.0
-				if rand.Float64() < evolutionChance {
+				if world.Rand.Float64() < evolutionChance {
 					e.evolveSpecies("aquatic_omnivore", world)
 				}
 			}
 		case BiomeSoil:
 			if e.GetTrait("digging_ability") > 0.5 {
 				evolutionChance *= 1.5
-				if rand.Float64() < evolutionChance {
+				if world.Rand.Float64() < evolutionChance {
 					e.evolveSpecies("underground_omnivore", world)
 				}
 			}
 		case BiomeAir:
 			if e.GetTrait("flying_ability") > 0.3 {
 				evolutionChance *= 1.2
-				if rand.Float64() < evolutionChance {
+				if world.Rand.Float64() < evolutionChance {
 					e.evolveSpecies("aerial_omnivore", world)
 				}
 			}
@@ -1464,8 +1490,7 @@ func (e *Entity) evolveSpecies(newSpecies string, world *World) {
 
 	// Ensure traits stay within bounds
 	for name, trait := range e.Traits {
-		value := math.Max(-2.0, math.Min(2.0, trait.Value))
-		e.SetTrait(name, value)
+		e.Traits[name] = Trait{Name: name, Value: math.Max(-2.0, math.Min(2.0, trait.Value))}
 	}
 
 	// Log the evolution
@@ -1496,7 +1521,7 @@ func NewEnvironmentalMemory() *EnvironmentalMemory {
 }
 
 // inheritDietaryPreferences inherits dietary preferences from parents with some variation
-func (e *Entity) inheritDietaryPreferences(parent1, parent2 *Entity) {
+func (e *Entity) inheritDietaryPreferences(rng *rand.Rand, parent1, parent2 *Entity) {
 	// Inherit plant preferences
 	allPlantTypes := make(map[int]bool)
 	for plantType := range parent1.DietaryMemory.PlantTypePreferences {
@@ -1506,13 +1531,13 @@ func (e *Entity) inheritDietaryPreferences(parent1, parent2 *Entity) {
 		allPlantTypes[plantType] = true
 	}
 
-	for plantType := range allPlantTypes {
+	for _, plantType := range sortedKeys(allPlantTypes) {
 		pref1 := parent1.DietaryMemory.PlantTypePreferences[plantType]
 		pref2 := parent2.DietaryMemory.PlantTypePreferences[plantType]
 		avgPref := (pref1 + pref2) / 2.0
 
 		// Add some variation (mutation in dietary preference)
-		variation := (rand.Float64() - 0.5) * 0.2
+		variation := (rng.Float64() - 0.5) * 0.2
 		inheritedPref := math.Max(0.0, math.Min(2.0, avgPref+variation))
 
 		if inheritedPref > 0.1 { // Only inherit significant preferences
@@ -1529,13 +1554,13 @@ func (e *Entity) inheritDietaryPreferences(parent1, parent2 *Entity) {
 		allPreySpecies[species] = true
 	}
 
-	for species := range allPreySpecies {
+	for _, species := range sortedKeys(allPreySpecies) {
 		pref1 := parent1.DietaryMemory.PreySpeciesPreferences[species]
 		pref2 := parent2.DietaryMemory.PreySpeciesPreferences[species]
 		avgPref := (pref1 + pref2) / 2.0
 
 		// Add some variation
-		variation := (rand.Float64() - 0.5) * 0.2
+		variation := (rng.Float64() - 0.5) * 0.2
 		inheritedPref := math.Max(0.0, math.Min(2.0, avgPref+variation))
 
 		if inheritedPref > 0.1 {
@@ -1722,18 +1747,8 @@ func (e *Entity) trackEnvironmentalExposure(biome BiomeType, season string, even
 	e.EnvironmentalMemory.BiomeExposure[biome] += 0.01 // Small increment per tick
 
 	// Track seasonal pressure
-	seasonalStress := 0.0
-	switch season {
-	case "Winter":
-		seasonalStress = 0.2 // Winter is generally stressful
-	case "Summer":
-		seasonalStress = 0.1 // Mild stress
-	case "Spring", "Autumn":
-		seasonalStress = 0.05 // Low stress
-	}
-
 	current := e.EnvironmentalMemory.SeasonalPressure[season]
-	e.EnvironmentalMemory.SeasonalPressure[season] = current + seasonalStress*0.1
+	e.EnvironmentalMemory.SeasonalPressure[season] = current + seasonalStress(season)*0.1
 
 	// Track environmental events
 	if event != nil {
@@ -1751,6 +1766,19 @@ func (e *Entity) trackEnvironmentalExposure(biome BiomeType, season string, even
 	e.updateEnvironmentalFitness(biome)
 }
 
+// seasonalStress returns how stressful a season is for creatures
+func seasonalStress(season string) float64 {
+	switch season {
+	case "Winter":
+		return 0.2 // Winter is generally stressful
+	case "Summer":
+		return 0.1 // Mild stress
+	case "Spring", "Autumn":
+		return 0.05 // Low stress
+	}
+	return 0
+}
+
 // updateEnvironmentalFitness calculates how well-adapted the entity is to current environment
 func (e *Entity) updateEnvironmentalFitness(currentBiome BiomeType) {
 	if e.EnvironmentalMemory == nil {
@@ -1759,33 +1787,37 @@ func (e *Entity) updateEnvironmentalFitness(currentBiome BiomeType) {
 	}
 
 	// Check how well entity's traits match the current environment
-	fitness := 1.0
+	fitness := 1.0 + e.habitatAdaptation(currentBiome)
 
-	switch currentBiome {
+	// Factor in accumulated environmental pressure
+	totalPressure := e.EnvironmentalMemory.TemperaturePressure + e.EnvironmentalMemory.RadiationPressure
+	fitness -= totalPressure * 0.1 // High pressure reduces fitness
+
+	e.EnvironmentalMemory.AdaptationFitness = math.Max(0.0, math.Min(2.0, fitness))
+}
+
+// habitatAdaptation returns the bonus (or penalty) the entity's traits earn in a biome
+func (e *Entity) habitatAdaptation(biome BiomeType) float64 {
+	switch biome {
 	case BiomeWater:
 		aquaticAdaptation := e.GetTrait("aquatic_adaptation")
-		fitness += aquaticAdaptation * 0.5 // Well-adapted entities get bonus
+		return aquaticAdaptation * 0.5 // Well-adapted entities get bonus
 	case BiomeSoil:
 		diggingAbility := e.GetTrait("digging_ability")
 		undergroundNav := e.GetTrait("underground_nav")
-		fitness += (diggingAbility + undergroundNav) * 0.25
+		return (diggingAbility + undergroundNav) * 0.25
 	case BiomeAir:
 		flyingAbility := e.GetTrait("flying_ability")
 		altitudeTolerance := e.GetTrait("altitude_tolerance")
-		fitness += (flyingAbility + altitudeTolerance) * 0.25
+		return (flyingAbility + altitudeTolerance) * 0.25
 	case BiomeRadiation:
 		endurance := e.GetTrait("endurance")
-		fitness += endurance * 0.3 // Endurance helps with radiation
+		return endurance * 0.3 // Endurance helps with radiation
 	case BiomeDesert:
 		endurance := e.GetTrait("endurance")
-		fitness += endurance * 0.4 // Endurance crucial in desert
+		return endurance * 0.4 // Endurance crucial in desert
 	}
-
-	// Factor in accumulated environmental pressure
-	totalPressure := e.EnvironmentalMemory.TemperaturePressure + e.EnvironmentalMemory.RadiationPressure
-	fitness -= totalPressure * 0.1 // High pressure reduces fitness
-
-	e.EnvironmentalMemory.AdaptationFitness = math.Max(0.0, math.Min(2.0, fitness))
+	return 0
 }
 
 // biasedMutation applies directional bias to mutations based on feedback loops
@@ -1796,7 +1828,8 @@ func (e *Entity) biasedMutation(traitName string, mutation float64) float64 {
 
 	// Environmental bias - encourage traits that help with current environment exposure
 	if e.EnvironmentalMemory != nil {
-		for biome, exposure := range e.EnvironmentalMemory.BiomeExposure {
+		for _, biome := range sortedKeys(e.EnvironmentalMemory.BiomeExposure) {
+			exposure := e.EnvironmentalMemory.BiomeExposure[biome]
 			if exposure > 0.2 { // Significant exposure to this biome
 				currentValue := e.GetTrait(traitName)
 				switch biome {
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Colour schemes the grid and isometric views can draw creatures in
+const (
+	AppearanceColorSpecies    = "species"    // Each species in its own colour
+	AppearanceColorDiet       = "diet"       // Green for plant eaters through red for meat eaters
+	AppearanceColorAggression = "aggression" // Green for docile creatures through red for aggressive ones
+)
+
+// appearanceScaleTrait is the trait creatures are drawn larger or smaller by
+const appearanceScaleTrait = "size"
+
+// The smallest and largest a creature is drawn, relative to its usual size
+const (
+	appearanceMinScale = 0.6
+	appearanceMaxScale = 1.4
+)
+
+// appearanceMeals is how many of a creature's latest meals decide the colour of its diet
+const appearanceMeals = 10
+
+// TraitColors is the colour of some creatures in each colour scheme that follows a value,
+// keyed in JSON by the scheme's name
+type TraitColors struct {
+	Diet       string `json:"diet"`
+	Aggression string `json:"aggression"`
+}
+
+// TraitLegend explains how the views draw creatures: what their size follows and what
+// each colour scheme means, with the trait ranges taken from the live trait registry
+type TraitLegend struct {
+	Scale  LegendScale         `json:"scale"`
+	Colors []LegendColorScheme `json:"colors"`
+}
+
+// LegendScale is the trait creatures are drawn larger or smaller by
+type LegendScale struct {
+	Trait TraitDefinition `json:"trait"`
+	Stops []LegendStop    `json:"stops"`
+}
+
+// LegendColorScheme is one way of colouring creatures by what they are like
+type LegendColorScheme struct {
+	Name        string           `json:"name"`
+	Label       string           `json:"label"`
+	Description string           `json:"description"`
+	Trait       *TraitDefinition `json:"trait,omitempty"` // Trait the colour follows, nil for the diet
+	Stops       []LegendStop     `json:"stops"`           // Empty for colours that don't follow a value
+}
+
+// LegendStop is a value along a legend with how it is drawn
+type LegendStop struct {
+	Value float64 `json:"value"`
+	Label string  `json:"label"`
+	Scale float64 `json:"scale,omitempty"` // How large creatures of this value are drawn
+	Color string  `json:"color,omitempty"` // The colour creatures of this value are drawn in
+}
+
+// appearanceTrait is the registry's definition of a creature trait, or a gene's usual
+// range for a trait it doesn't know
+func (w *World) appearanceTrait(name string) TraitDefinition {
+	if w.TraitRegistry != nil {
+		if definition, ok := w.TraitRegistry.Lookup(TraitSubjectCreature, name); ok {
+			return definition
+		}
+	}
+	return creatureGene(name, "")
+}
+
+// traitShare is where a value lies along a trait's range, from 0 at its minimum to 1 at
+// its maximum
+func traitShare(definition TraitDefinition, value float64) float64 {
+	return math.Max(0, math.Min(1, (value-definition.Min)/(definition.Max-definition.Min)))
+}
+
+// appearanceScale is how large creatures are drawn for a share of the size range
+func appearanceScale(share float64) float64 {
+	return appearanceMinScale + (appearanceMaxScale-appearanceMinScale)*share
+}
+
+// appearanceColor is the colour for a share along a scheme, green through yellow to red
+func appearanceColor(share float64) string {
+	return hslColorHex(120*(1-share), 0.85, 0.55)
+}
+
+// hslColorHex converts a hue in degrees, saturation and lightness to a hex colour
+func hslColorHex(hue, saturation, lightness float64) string {
+	chroma := (1 - math.Abs(2*lightness-1)) * saturation
+	sector := math.Mod(hue/60, 6)
+	x := chroma * (1 - math.Abs(math.Mod(sector, 2)-1))
+	var r, g, b float64
+	switch {
+	case sector < 1:
+		r, g = chroma, x
+	case sector < 2:
+		r, g = x, chroma
+	case sector < 3:
+		g, b = chroma, x
+	case sector < 4:
+		g, b = x, chroma
+	case sector < 5:
+		r, b = x, chroma
+	default:
+		r, b = chroma, x
+	}
+	m := lightness - chroma/2
+	channel := func(v float64) int { return int(math.Round((v + m) * 255)) }
+	return fmt.Sprintf("#%02X%02X%02X", channel(r), channel(g), channel(b))
+}
+
+// entityMeatShare is how much of a creature's diet is meat: the share of its latest meals
+// that were other creatures, or what its species eats when it hasn't eaten yet
+func entityMeatShare(entity *Entity) float64 {
+	if entity.DietaryMemory != nil && len(entity.DietaryMemory.ConsumptionHistory) > 0 {
+		meals := entity.DietaryMemory.ConsumptionHistory
+		meals = meals[max(0, len(meals)-appearanceMeals):]
+		meat := 0
+		for _, meal := range meals {
+			if meal.FoodType == "entity" {
+				meat++
+			}
+		}
+		return float64(meat) / float64(len(meals))
+	}
+	switch entity.Species {
+	case "herbivore":
+		return 0
+	case SpeciesPredator:
+		return 1
+	}
+	return 0.5
+}
+
+// EntityAppearance is how a group of creatures sharing a cell is drawn: the scale of their
+// mean size and their mean colour in each scheme that follows a value
+func (w *World) EntityAppearance(entities []*Entity) (float64, TraitColors) {
+	size := w.appearanceTrait(appearanceScaleTrait)
+	aggression := w.appearanceTrait("aggression")
+	var sizeShare, aggressionShare, meatShare float64
+	living := 0
+	for _, entity := range entities {
+		if !entity.IsAlive {
+			continue
+		}
+		living++
+		sizeShare += traitShare(size, entity.GetTrait(appearanceScaleTrait))
+		aggressionShare += traitShare(aggression, entity.GetTrait("aggression"))
+		meatShare += entityMeatShare(entity)
+	}
+	if living == 0 {
+		return 0, TraitColors{}
+	}
+	n := float64(living)
+	return appearanceScale(sizeShare / n), TraitColors{Diet: appearanceColor(meatShare / n), Aggression: appearanceColor(aggressionShare / n)}
+}
+
+// TraitLegend describes how creatures are drawn, in the trait ranges the registry holds now
+func (w *World) TraitLegend() TraitLegend {
+	size := w.appearanceTrait(appearanceScaleTrait)
+	aggression := w.appearanceTrait("aggression")
+	legend := TraitLegend{Scale: LegendScale{Trait: size}}
+	for _, share := range []float64{0, 0.5, 1} {
+		value := size.Min + share*(size.Max-size.Min)
+		legend.Scale.Stops = append(legend.Scale.Stops, LegendStop{Value: value, Label: fmt.Sprintf("%+.1f", value), Scale: appearanceScale(share)})
+	}
+
+	diet := LegendColorScheme{Name: AppearanceColorDiet, Label: "Diet",
+		Description: fmt.Sprintf("Share of meat in the last %d meals, or what the species eats before its first", appearanceMeals)}
+	for _, stop := range []LegendStop{{Value: 0, Label: "Plants"}, {Value: 0.5, Label: "Mixed"}, {Value: 1, Label: "Meat"}} {
+		stop.Color = appearanceColor(stop.Value)
+		diet.Stops = append(diet.Stops, stop)
+	}
+	aggressive := LegendColorScheme{Name: AppearanceColorAggression, Label: "Aggression", Description: aggression.Description, Trait: &aggression}
+	for _, share := range []float64{0, 0.5, 1} {
+		value := aggression.Min + share*(aggression.Max-aggression.Min)
+		aggressive.Stops = append(aggressive.Stops, LegendStop{Value: value, Label: fmt.Sprintf("%+.1f", value), Color: appearanceColor(share)})
+	}
+	legend.Colors = []LegendColorScheme{
+		{Name: AppearanceColorSpecies, Label: "Species", Description: "Each species in its own colour", Stops: make([]LegendStop, 0)},
+		diet,
+		aggressive,
+	}
+	return legend
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEntityAppearance(t *testing.T) {
+	world := newSteppingTestWorld(125)
+	small, large := world.AllEntities[0], world.AllEntities[1]
+	small.SetTrait("size", -1.5)
+	large.SetTrait("size", 1.5)
+	smallScale, _ := world.EntityAppearance([]*Entity{small})
+	largeScale, _ := world.EntityAppearance([]*Entity{large})
+	if smallScale >= 1 || largeScale <= 1 || smallScale < appearanceMinScale || largeScale > appearanceMaxScale {
+		t.Errorf("Expected creatures drawn by their size, got %.2f and %.2f", smallScale, largeScale)
+	}
+	if scale, colors := world.EntityAppearance([]*Entity{small, large}); scale != 1 || colors.Diet == "" || colors.Aggression == "" {
+		t.Errorf("Expected a cell drawn at its creatures' mean size, got %.2f %v", scale, colors)
+	}
+
+	small.SetTrait("aggression", -2)
+	large.SetTrait("aggression", 2)
+	_, docile := world.EntityAppearance([]*Entity{small})
+	_, aggressive := world.EntityAppearance([]*Entity{large})
+	if docile.Aggression != appearanceColor(0) || aggressive.Aggression != appearanceColor(1) {
+		t.Errorf("Expected aggression to run green to red, got %s and %s", docile.Aggression, aggressive.Aggression)
+	}
+
+	// A creature's diet follows what it has eaten lately
+	small.DietaryMemory = &DietaryMemory{ConsumptionHistory: []ConsumptionRecord{{FoodType: "plant"}, {FoodType: "plant"}}}
+	large.DietaryMemory = &DietaryMemory{ConsumptionHistory: []ConsumptionRecord{{FoodType: "plant"}, {FoodType: "entity"}, {FoodType: "entity"}, {FoodType: "entity"}}}
+	if share := entityMeatShare(small); share != 0 {
+		t.Errorf("Expected a plant eater's diet all plants, got %.2f", share)
+	}
+	if share := entityMeatShare(large); share != 0.75 {
+		t.Errorf("Expected three meat meals in four, got %.2f", share)
+	}
+	if red, green := hslColorHex(0, 1, 0.5), hslColorHex(120, 1, 0.5); red != "#FF0000" || green != "#00FF00" {
+		t.Errorf("Expected pure hues converted, got %s and %s", red, green)
+	}
+}
+
+func TestTraitLegend(t *testing.T) {
+	world := newSteppingTestWorld(126)
+	legend := world.TraitLegend()
+	if legend.Scale.Trait.Name != "size" || len(legend.Scale.Stops) != 3 || legend.Scale.Stops[0].Scale != appearanceMinScale {
+		t.Errorf("Expected the legend to size creatures by their size, got %+v", legend.Scale)
+	}
+	names := make(map[string]bool)
+	for _, scheme := range legend.Colors {
+		names[scheme.Name] = true
+	}
+	if !names[AppearanceColorSpecies] || !names[AppearanceColorDiet] || !names[AppearanceColorAggression] {
+		t.Errorf("Expected species, diet and aggression colour schemes, got %v", names)
+	}
+
+	// The legend follows the live registry's ranges
+	size, _ := world.TraitRegistry.Lookup(TraitSubjectCreature, "size")
+	size.Min, size.Max = -4, 4
+	if err := world.TraitRegistry.Register(size); err != nil {
+		t.Fatal(err)
+	}
+	if stops := world.TraitLegend().Scale.Stops; stops[0].Value != -4 || stops[2].Value != 4 {
+		t.Errorf("Expected the legend to span the registered size range, got %+v", stops)
+	}
+
+	rec := httptest.NewRecorder()
+	NewWebInterface(world).Routes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/traits/legend", nil))
+	var served TraitLegend
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &served) != nil || len(served.Colors) != len(legend.Colors) {
+		t.Fatalf("Expected the legend served, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	for _, row := range NewViewManager(world).GetCurrentViewData().Grid {
+		for _, cell := range row {
+			if cell.EntityCount > 0 && (cell.EntityScale == 0 || cell.EntityColors.Diet == "") {
+				t.Fatalf("Expected occupied cells sized and coloured by their creatures, got %+v", cell)
+			}
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"sort"
+)
+
+// inspectorDNALength is how many nucleotides of an entity's DNA the inspector shows
+const inspectorDNALength = 120
+
+// inspectorMeals is how many of an entity's latest meals the inspector shows
+const inspectorMeals = 5
+
+// EntityInspection is everything the web inspector shows of one entity: its details, what
+// it is doing, its genes and brain, what it remembers and who it is bound up with
+type EntityInspection struct {
+	EntityDetailData
+	GridX          int                  `json:"grid_x"` // Grid cell the entity is in
+	GridY          int                  `json:"grid_y"`
+	Activity       string               `json:"activity"` // What its biorhythm has it doing
+	Classification string               `json:"classification"`
+	Caste          string               `json:"caste,omitempty"`
+	TribeID        int                  `json:"tribe_id,omitempty"`
+	Pregnant       bool                 `json:"pregnant"`
+	Offspring      int                  `json:"offspring"`
+	DNA            *DNASummary          `json:"dna,omitempty"`
+	Brain          *BrainSummary        `json:"brain,omitempty"`
+	Memory         EntityMemory         `json:"memory"`
+	Relationships  []EntityRelationship `json:"relationships"`
+}
+
+// DNASummary is the shape of an entity's DNA and the start of its sequence
+type DNASummary struct {
+	Chromosomes int           `json:"chromosomes"`
+	Mutations   int           `json:"mutations"`
+	Sequence    string        `json:"sequence"` // Cut short after inspectorDNALength nucleotides
+	Genes       []GeneSummary `json:"genes"`
+}
+
+// GeneSummary is one gene and how strongly it is expressed
+type GeneSummary struct {
+	Name       string  `json:"name"`
+	Dominant   bool    `json:"dominant"`
+	Expression float64 `json:"expression"`
+}
+
+// BrainSummary is the size of an entity's neural network and how well it has learned
+type BrainSummary struct {
+	Type              string             `json:"type"`
+	Architecture      string             `json:"architecture"`
+	Neurons           int                `json:"neurons"`
+	Layers            int                `json:"layers"` // Input, hidden and output
+	Experience        float64            `json:"experience"`
+	Decisions         int                `json:"decisions"`
+	Accuracy          float64            `json:"accuracy"` // Share of decisions that went well
+	SuccessfulActions map[string]float64 `json:"successful_actions"`
+}
+
+// EntityMemory is what an entity has learned from its surroundings and its elders
+type EntityMemory struct {
+	RecentMeals       []ConsumptionRecord `json:"recent_meals"` // Newest last
+	DietaryFitness    float64             `json:"dietary_fitness"`
+	AdaptationFitness float64             `json:"adaptation_fitness"`
+	Knowledge         []string            `json:"knowledge"` // Cultural knowledge it holds
+}
+
+// EntityRelationship is another entity this one is bound up with
+type EntityRelationship struct {
+	Kind     string `json:"kind"` // mate, mentor, student, host or symbiont
+	EntityID int    `json:"entity_id"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// CellInspection is one grid cell: its ground and what lives in it
+type CellInspection struct {
+	X              int                 `json:"x"`
+	Y              int                 `json:"y"`
+	Biome          string              `json:"biome"`
+	WaterLevel     float64             `json:"water_level"`
+	SoilPH         float64             `json:"soil_ph"`
+	SoilCompaction float64             `json:"soil_compaction"`
+	OrganicMatter  float64             `json:"organic_matter"`
+	SoilNutrients  map[string]float64  `json:"soil_nutrients"`
+	Event          string              `json:"event,omitempty"`
+	Entities       []*EntityDetailData `json:"entities"`
+	Plants         []CellPlant         `json:"plants"`
+}
+
+// CellPlant is a plant as the cell inspector lists it
+type CellPlant struct {
+	ID     int     `json:"id"`
+	Type   string  `json:"type"`
+	Energy float64 `json:"energy"`
+	Size   float64 `json:"size"`
+	Age    int     `json:"age"`
+}
+
+// InspectEntity gathers everything the inspector shows of an entity, or nil if no entity
+// has the ID
+func (vm *ViewManager) InspectEntity(id int) *EntityInspection {
+	entity := vm.world.findEntityByID(id)
+	if entity == nil {
+		return nil
+	}
+	w := vm.world
+	inspection := &EntityInspection{
+		EntityDetailData: *vm.entityDetail(entity),
+		Activity:         "Unknown",
+		Classification:   "Unknown",
+		TribeID:          entity.TribeID,
+		Relationships:    make([]EntityRelationship, 0),
+	}
+	inspection.GridX, inspection.GridY = w.worldToGridCoords(entity.Position.X, entity.Position.Y)
+	if entity.BioRhythm != nil {
+		inspection.Activity = entity.BioRhythm.String()
+	}
+	if w.OrganismClassifier != nil {
+		inspection.Classification = w.OrganismClassifier.GetClassificationName(entity.Classification)
+	}
+	if entity.CasteStatus != nil {
+		inspection.Caste = entity.CasteStatus.Role.String()
+	}
+	if status := entity.ReproductionStatus; status != nil {
+		inspection.Pregnant = status.IsPregnant
+		inspection.Offspring = status.OffspringCount
+		if status.MateID != 0 {
+			inspection.Relationships = append(inspection.Relationships, EntityRelationship{Kind: "mate", EntityID: status.MateID})
+		}
+	}
+
+	if w.CellularSystem != nil {
+		if organism := w.CellularSystem.OrganismMap[id]; organism != nil && len(organism.Cells) > 0 && organism.Cells[0].DNA != nil {
+			inspection.DNA = summarizeDNA(w.DNASystem, organism.Cells[0].DNA)
+		}
+	}
+	if w.NeuralAISystem != nil {
+		if network := w.NeuralAISystem.EntityNetworks[id]; network != nil {
+			inspection.Brain = summarizeBrain(network)
+		}
+	}
+
+	inspection.Memory.RecentMeals = make([]ConsumptionRecord, 0)
+	inspection.Memory.Knowledge = make([]string, 0)
+	if memory := entity.DietaryMemory; memory != nil {
+		meals := memory.ConsumptionHistory
+		inspection.Memory.RecentMeals = append(inspection.Memory.RecentMeals, meals[max(0, len(meals)-inspectorMeals):]...)
+		inspection.Memory.DietaryFitness = memory.DietaryFitness
+	}
+	if entity.EnvironmentalMemory != nil {
+		inspection.Memory.AdaptationFitness = entity.EnvironmentalMemory.AdaptationFitness
+	}
+	if w.CulturalKnowledgeSystem != nil {
+		if memory := w.CulturalKnowledgeSystem.EntityMemories[id]; memory != nil {
+			for _, knowledgeID := range sortedKeys(memory.KnownKnowledge) {
+				inspection.Memory.Knowledge = append(inspection.Memory.Knowledge, memory.KnownKnowledge[knowledgeID].Type.String())
+			}
+			if memory.MentorEntityID != 0 {
+				inspection.Relationships = append(inspection.Relationships, EntityRelationship{Kind: "mentor", EntityID: memory.MentorEntityID})
+			}
+			for _, student := range memory.StudentEntityIDs {
+				inspection.Relationships = append(inspection.Relationships, EntityRelationship{Kind: "student", EntityID: student})
+			}
+		}
+	}
+	if w.SymbioticRelationships != nil {
+		for _, relationship := range w.SymbioticRelationships.Relationships {
+			if !relationship.IsActive {
+				continue
+			}
+			// Named for the partner's side: the host of a symbiont, or the symbiont on a host
+			switch id {
+			case relationship.SymbiontID:
+				inspection.Relationships = append(inspection.Relationships, EntityRelationship{
+					Kind: "host", EntityID: relationship.HostID, Detail: relationshipTypeName(relationship.Type)})
+			case relationship.HostID:
+				inspection.Relationships = append(inspection.Relationships, EntityRelationship{
+					Kind: "symbiont", EntityID: relationship.SymbiontID, Detail: relationshipTypeName(relationship.Type)})
+			}
+		}
+	}
+	return inspection
+}
+
+// summarizeDNA describes a DNA strand gene by gene
+func summarizeDNA(dnaSystem *DNASystem, dna *DNAStrand) *DNASummary {
+	summary := &DNASummary{
+		Chromosomes: len(dna.Chromosomes),
+		Mutations:   dna.Mutations,
+		Genes:       make([]GeneSummary, 0),
+	}
+	if dnaSystem != nil {
+		summary.Sequence = dnaSystem.GetDNAString(dna, inspectorDNALength)
+	}
+	for _, chromosome := range dna.Chromosomes {
+		for _, gene := range chromosome.Genes {
+			summary.Genes = append(summary.Genes, GeneSummary{Name: gene.Name, Dominant: gene.Dominant, Expression: gene.Expression})
+		}
+	}
+	sort.SliceStable(summary.Genes, func(i, j int) bool { return summary.Genes[i].Name < summary.Genes[j].Name })
+	return summary
+}
+
+// summarizeBrain describes a neural network by its size and record
+func summarizeBrain(network *EntityNeuralNetwork) *BrainSummary {
+	summary := &BrainSummary{
+		Type:              neuralNetworkTypeName(network.Type),
+		Architecture:      network.Architecture,
+		Neurons:           len(network.Neurons),
+		Layers:            len(network.HiddenLayers) + 2,
+		Experience:        network.Experience,
+		Decisions:         network.TotalDecisions,
+		SuccessfulActions: network.SuccessfulActions,
+	}
+	if network.TotalDecisions > 0 {
+		summary.Accuracy = float64(network.CorrectDecisions) / float64(network.TotalDecisions)
+	}
+	return summary
+}
+
+// neuralNetworkTypeName is the name a network type goes by in reports
+func neuralNetworkTypeName(networkType NeuralNetworkType) string {
+	switch networkType {
+	case FeedForward:
+		return "FeedForward"
+	case Recurrent:
+		return "Recurrent"
+	case Convolutional:
+		return "Convolutional"
+	case Reinforcement:
+		return "Reinforcement"
+	}
+	return "Unknown"
+}
+
+// InspectCell describes a grid cell and the entities and plants in it, or nil if the cell
+// is off the grid
+func (vm *ViewManager) InspectCell(x, y int) *CellInspection {
+	w := vm.world
+	if x < 0 || x >= w.Config.GridWidth || y < 0 || y >= w.Config.GridHeight {
+		return nil
+	}
+	cell := w.Grid[y][x]
+	inspection := &CellInspection{
+		X:              x,
+		Y:              y,
+		WaterLevel:     cell.WaterLevel,
+		SoilPH:         cell.SoilPH,
+		SoilCompaction: cell.SoilCompaction,
+		OrganicMatter:  cell.OrganicMatter,
+		SoilNutrients:  cell.SoilNutrients,
+		Entities:       make([]*EntityDetailData, 0, len(cell.Entities)),
+		Plants:         make([]CellPlant, 0, len(cell.Plants)),
+	}
+	inspection.Biome, _, _ = vm.getBiomeInfo(cell.Biome)
+	if cell.Event != nil {
+		inspection.Event = cell.Event.Name
+	}
+	for _, entity := range cell.Entities {
+		if entity.IsAlive {
+			inspection.Entities = append(inspection.Entities, vm.entityDetail(entity))
+		}
+	}
+	plantConfigs := GetPlantConfigs()
+	for _, plant := range cell.Plants {
+		if plant.IsAlive {
+			inspection.Plants = append(inspection.Plants, CellPlant{
+				ID: plant.ID, Type: plantConfigs[plant.Type].Name, Energy: plant.Energy, Size: plant.Size, Age: plant.Age})
+		}
+	}
+	return inspection
+}
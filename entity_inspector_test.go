@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEntityInspection(t *testing.T) {
+	world := newSteppingTestWorld(120)
+	if _, err := world.Step(10); err != nil {
+		t.Fatal(err)
+	}
+	vm := NewViewManager(world)
+
+	var occupied *CellInspection
+	for y := 0; y < world.Config.GridHeight && occupied == nil; y++ {
+		for x := 0; x < world.Config.GridWidth; x++ {
+			if cell := vm.InspectCell(x, y); cell != nil && len(cell.Entities) > 0 {
+				occupied = cell
+				break
+			}
+		}
+	}
+	if occupied == nil {
+		t.Fatal("Expected a grid cell with creatures in it")
+	}
+	if occupied.Biome == "" {
+		t.Error("Expected the cell's biome named")
+	}
+	if vm.InspectCell(-1, 0) != nil || vm.InspectCell(0, world.Config.GridHeight) != nil {
+		t.Error("Expected cells off the grid refused")
+	}
+
+	inspection := vm.InspectEntity(occupied.Entities[0].ID)
+	if inspection == nil || inspection.ID != occupied.Entities[0].ID || inspection.Species == "" || len(inspection.Traits) == 0 {
+		t.Fatalf("Expected the creature in the cell inspected, got %+v", inspection)
+	}
+	if inspection.Activity == "" || inspection.Classification == "" {
+		t.Errorf("Expected the creature's activity and classification, got %q %q", inspection.Activity, inspection.Classification)
+	}
+	if inspection.DNA == nil || len(inspection.DNA.Genes) == 0 || inspection.DNA.Sequence == "" {
+		t.Errorf("Expected the creature's DNA, got %+v", inspection.DNA)
+	}
+	for id, network := range world.NeuralAISystem.EntityNetworks {
+		if brain := vm.InspectEntity(id); brain != nil && (brain.Brain == nil || brain.Brain.Neurons != len(network.Neurons)) {
+			t.Errorf("Expected entity %d's neural network summarized, got %+v", id, brain.Brain)
+		}
+	}
+	if len(inspection.Memory.RecentMeals) > inspectorMeals {
+		t.Errorf("Expected at most %d meals remembered, got %d", inspectorMeals, len(inspection.Memory.RecentMeals))
+	}
+	if vm.InspectEntity(-1) != nil {
+		t.Error("Expected an unknown entity refused")
+	}
+}
+
+func TestEntityInspectorAPI(t *testing.T) {
+	world := newSteppingTestWorld(121)
+	routes := NewWebInterface(world).Routes()
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	entity := world.AllEntities[0]
+	rec := get(fmt.Sprintf("/api/entity/%d", entity.ID))
+	var inspection EntityInspection
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &inspection) != nil || inspection.ID != entity.ID {
+		t.Fatalf("Expected entity %d inspected, got %d %s", entity.ID, rec.Code, rec.Body.String())
+	}
+	if rec := get("/api/entity/999999"); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected an unknown entity not found, got %d", rec.Code)
+	}
+	if rec := get("/api/entity/abc"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected a malformed id refused, got %d", rec.Code)
+	}
+	if rec := get(fmt.Sprintf("/api/entity?id=%d", entity.ID)); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected entities inspected only by path, got %d", rec.Code)
+	}
+
+	rec = get(fmt.Sprintf("/api/cell/%d/%d", inspection.GridX, inspection.GridY))
+	var cell CellInspection
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &cell) != nil || cell.X != inspection.GridX || cell.Y != inspection.GridY {
+		t.Fatalf("Expected the entity's cell inspected, got %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := get("/api/cell/-1/0"); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected a cell off the grid not found, got %d", rec.Code)
+	}
+	if rec := get("/api/cell/a/b"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected a malformed cell refused, got %d", rec.Code)
+	}
+}
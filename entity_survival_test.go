@@ -180,7 +180,7 @@ func TestEntitySurvivalInDifferentBiomes(t *testing.T) {
 
 				// Initialize molecular systems to prevent nil pointer issues
 				entity.MolecularNeeds = NewMolecularNeeds(entity)
-				entity.MolecularMetabolism = NewMolecularMetabolism(entity)
+				entity.MolecularMetabolism = NewMolecularMetabolism(sharedRand, entity)
 				entity.MolecularProfile = NewMolecularProfile()
 
 				testEntities[i] = entity
@@ -252,7 +252,7 @@ func TestEnergyDecayRates(t *testing.T) {
 
 	// Initialize molecular systems
 	entity.MolecularNeeds = NewMolecularNeeds(entity)
-	entity.MolecularMetabolism = NewMolecularMetabolism(entity)
+	entity.MolecularMetabolism = NewMolecularMetabolism(sharedRand, entity)
 	entity.MolecularProfile = NewMolecularProfile()
 
 	initialEnergy := entity.Energy
@@ -318,7 +318,7 @@ func TestPoorlyAdaptedEntitySurvival(t *testing.T) {
 
 	// Initialize molecular systems
 	poorEntity.MolecularNeeds = NewMolecularNeeds(poorEntity)
-	poorEntity.MolecularMetabolism = NewMolecularMetabolism(poorEntity)
+	poorEntity.MolecularMetabolism = NewMolecularMetabolism(sharedRand, poorEntity)
 	poorEntity.MolecularProfile = NewMolecularProfile()
 
 	world.AllEntities = []*Entity{poorEntity}
@@ -326,7 +326,7 @@ func TestPoorlyAdaptedEntitySurvival(t *testing.T) {
 	// Run simulation and track survival
 	survivalTime := 0
 	for tick := 1; tick <= 20; tick++ {
-		world.updateEntityWithBiome(poorEntity)
+		world.updateEntityWithBiome(world.Rand, world.Mutations, poorEntity)
 		poorEntity.UpdateWithClassification(world.OrganismClassifier, world.CellularSystem)
 
 		if poorEntity.IsAlive {
@@ -383,7 +383,7 @@ func TestWellAdaptedEntitySurvival(t *testing.T) {
 
 	// Initialize molecular systems
 	adaptedEntity.MolecularNeeds = NewMolecularNeeds(adaptedEntity)
-	adaptedEntity.MolecularMetabolism = NewMolecularMetabolism(adaptedEntity)
+	adaptedEntity.MolecularMetabolism = NewMolecularMetabolism(sharedRand, adaptedEntity)
 	adaptedEntity.MolecularProfile = NewMolecularProfile()
 
 	world.AllEntities = []*Entity{adaptedEntity}
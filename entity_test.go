@@ -7,7 +7,7 @@ import (
 func TestNewEntity(t *testing.T) {
 	traitNames := []string{"strength", "agility", "intelligence"}
 	pos := Position{X: 10, Y: 20}
-	entity := NewEntity(1, traitNames, "test", pos)
+	entity := NewEntity(sharedRand, 1, traitNames, "test", pos)
 
 	if entity.ID != 1 {
 		t.Errorf("Expected entity ID to be 1, got %d", entity.ID)
@@ -38,7 +38,7 @@ func TestNewEntity(t *testing.T) {
 
 func TestEntityGetTrait(t *testing.T) {
 	pos := Position{X: 0, Y: 0}
-	entity := NewEntity(1, []string{"strength"}, "test", pos)
+	entity := NewEntity(sharedRand, 1, []string{"strength"}, "test", pos)
 	entity.SetTrait("strength", 0.5)
 
 	value := entity.GetTrait("strength")
@@ -55,7 +55,7 @@ func TestEntityGetTrait(t *testing.T) {
 
 func TestEntitySetTrait(t *testing.T) {
 	pos := Position{X: 0, Y: 0}
-	entity := NewEntity(1, []string{}, "test", pos)
+	entity := NewEntity(sharedRand, 1, []string{}, "test", pos)
 	entity.SetTrait("newTrait", 0.75)
 
 	if len(entity.Traits) != 1 {
@@ -70,11 +70,11 @@ func TestEntitySetTrait(t *testing.T) {
 
 func TestEntityMutate(t *testing.T) {
 	pos := Position{X: 0, Y: 0}
-	entity := NewEntity(1, []string{"strength"}, "test", pos)
+	entity := NewEntity(sharedRand, 1, []string{"strength"}, "test", pos)
 	originalValue := entity.GetTrait("strength")
 
 	// Test with 100% mutation rate
-	entity.Mutate(1.0, 0.1)
+	entity.Mutate(sharedRand, 1.0, 0.1)
 
 	newValue := entity.GetTrait("strength")
 	// Value should have changed (with very high probability)
@@ -85,7 +85,7 @@ func TestEntityMutate(t *testing.T) {
 
 	// Test with 0% mutation rate
 	entity.SetTrait("strength", 0.5)
-	entity.Mutate(0.0, 0.1)
+	entity.Mutate(sharedRand, 0.0, 0.1)
 
 	finalValue := entity.GetTrait("strength")
 	if finalValue != 0.5 {
@@ -95,12 +95,12 @@ func TestEntityMutate(t *testing.T) {
 
 func TestEntityClone(t *testing.T) {
 	pos := Position{X: 5, Y: 10}
-	original := NewEntity(1, []string{"strength", "agility"}, "test", pos)
+	original := NewEntity(sharedRand, 1, []string{"strength", "agility"}, "test", pos)
 	original.SetTrait("strength", 0.5)
 	original.SetTrait("agility", 0.3)
 	original.Fitness = 1.5
 
-	clone := original.Clone()
+	clone := original.Clone(sharedRand)
 
 	if clone.ID != original.ID {
 		t.Errorf("Expected clone ID %d, got %d", original.ID, clone.ID)
@@ -125,15 +125,15 @@ func TestCrossover(t *testing.T) {
 	pos1 := Position{X: 0, Y: 0}
 	pos2 := Position{X: 10, Y: 10}
 
-	parent1 := NewEntity(1, []string{"strength", "agility"}, "test", pos1)
+	parent1 := NewEntity(sharedRand, 1, []string{"strength", "agility"}, "test", pos1)
 	parent1.SetTrait("strength", 0.8)
 	parent1.SetTrait("agility", 0.2)
 
-	parent2 := NewEntity(2, []string{"strength", "intelligence"}, "test", pos2)
+	parent2 := NewEntity(sharedRand, 2, []string{"strength", "intelligence"}, "test", pos2)
 	parent2.SetTrait("strength", 0.3)
 	parent2.SetTrait("intelligence", 0.7)
 
-	child := Crossover(parent1, parent2, 3, "test")
+	child := Crossover(sharedRand, parent1, parent2, 3, "test")
 
 	if child.ID != 3 {
 		t.Errorf("Expected child ID 3, got %d", child.ID)
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// envPrefix starts the environment variables that mirror the command-line flags
+const envPrefix = "EVOSIM_"
+
+// listFlag is a flag that can be given several times; its environment variable takes a
+// comma-separated list
+type listFlag interface {
+	flag.Value
+	isList()
+}
+
+// envName is the environment variable mirroring a flag: the prefix, then the flag in capitals
+// with dashes as underscores, e.g. EVOSIM_WEB_PORT for --web-port
+func envName(prefix, flagName string) string {
+	return prefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// commandEnvPrefix is the prefix of a subcommand's environment variables, e.g. EVOSIM_SERVE_
+// for serve, so subcommands sharing a flag name can be configured apart
+func commandEnvPrefix(command string) string {
+	return envName(envPrefix, command) + "_"
+}
+
+// applyEnvironment sets each flag not given on the command line from its environment variable,
+// so a container can be configured without arguments. Flags on the command line win over the
+// environment, which in turn wins over a --config file.
+func applyEnvironment(flags *flag.FlagSet, prefix string) error {
+	explicit := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	var err error
+	flags.VisitAll(func(f *flag.Flag) {
+		if err != nil || explicit[f.Name] {
+			return
+		}
+		name := envName(prefix, f.Name)
+		value, set := os.LookupEnv(name)
+		if !set {
+			return
+		}
+		values := []string{value}
+		if _, list := f.Value.(listFlag); list {
+			values = strings.Split(value, ",")
+		}
+		for _, value := range values {
+			if setErr := flags.Set(f.Name, strings.TrimSpace(value)); setErr != nil {
+				err = fmt.Errorf("invalid %s: %v", name, setErr)
+				return
+			}
+		}
+	})
+	return err
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestApplyEnvironment(t *testing.T) {
+	flags := flag.NewFlagSet("evosim", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	port := flags.Int("web-port", 8080, "")
+	web := flags.Bool("web", false, "")
+	seed := flags.Int64("seed", 0, "")
+	var breakpoints breakpointFlags
+	flags.Var(&breakpoints, "break", "")
+
+	t.Setenv("EVOSIM_WEB_PORT", "9000")
+	t.Setenv("EVOSIM_WEB", "true")
+	t.Setenv("EVOSIM_SEED", "7")
+	t.Setenv("EVOSIM_BREAK", "tick=100, speciation")
+	if err := flags.Parse([]string{"--seed", "3"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyEnvironment(flags, envPrefix); err != nil {
+		t.Fatalf("Failed to read the environment: %v", err)
+	}
+	if *port != 9000 || !*web || len(breakpoints) != 2 || breakpoints[1] != "speciation" {
+		t.Errorf("Expected the flags set from the environment, got port %d, web %v, breakpoints %v", *port, *web, breakpoints)
+	}
+	if *seed != 3 {
+		t.Errorf("Expected the command line to win over the environment, got seed %d", *seed)
+	}
+
+	// The environment counts as given, so a run config does not override it
+	config := &RunConfig{World: RunWorldConfig{Width: 50, GridWidth: 10}}
+	flags.Float64("width", 100, "")
+	flags.Int("grid-width", 40, "")
+	t.Setenv("EVOSIM_GRID_WIDTH", "64")
+	if err := applyEnvironment(flags, envPrefix); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.ApplyToFlags(flags); err != nil {
+		t.Fatal(err)
+	}
+	if flags.Lookup("grid-width").Value.String() != "64" || flags.Lookup("width").Value.String() != "50" {
+		t.Errorf("Expected the environment over the config and the config over the defaults")
+	}
+
+	t.Setenv("EVOSIM_SERVE_PORT", "soon")
+	serve := flag.NewFlagSet("serve", flag.ContinueOnError)
+	serve.Int("port", 8080, "")
+	if err := applyEnvironment(serve, commandEnvPrefix(serve.Name())); err == nil {
+		t.Errorf("Expected an invalid EVOSIM_SERVE_PORT refused")
+	}
+}
@@ -46,6 +46,8 @@ type EnvironmentalModificationSystem struct {
 	NextModID     int                                `json:"next_mod_id"`
 	TunnelNetwork map[int][]int                      `json:"tunnel_network"` // Tunnel ID -> Connected tunnel IDs
 	eventBus      *CentralEventBus                   `json:"-"`              // Event tracking
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewEnvironmentalModificationSystem creates a new environmental modification system
@@ -55,6 +57,7 @@ func NewEnvironmentalModificationSystem(eventBus *CentralEventBus) *Environmenta
 		NextModID:     1,
 		TunnelNetwork: make(map[int][]int),
 		eventBus:      eventBus,
+		rng:           sharedRand,
 	}
 }
 
@@ -308,7 +311,7 @@ func (ems *EnvironmentalModificationSystem) UseModification(mod *EnvironmentalMo
 
 	case EnvModTrap:
 		// Traps are usually triggered accidentally
-		if user != mod.Creator && rand.Float64() < mod.Properties["trigger_sensitivity"] {
+		if user != mod.Creator && ems.rng.Float64() < mod.Properties["trigger_sensitivity"] {
 			benefit = ems.triggerTrap(mod, user)
 		}
 	}
@@ -459,7 +462,8 @@ func (ems *EnvironmentalModificationSystem) GetNearbyModifications(position Posi
 
 // UpdateModifications maintains environmental modifications
 func (ems *EnvironmentalModificationSystem) UpdateModifications(tick int) {
-	for id, mod := range ems.Modifications {
+	for _, id := range sortedKeys(ems.Modifications) {
+		mod := ems.Modifications[id]
 		if !mod.IsActive {
 			continue
 		}
@@ -506,7 +510,8 @@ func (ems *EnvironmentalModificationSystem) GetModificationStats() map[string]in
 	typeCounts := make(map[EnvironmentalModType]int)
 	avgDurability := 0.0
 
-	for _, mod := range ems.Modifications {
+	for _, modID := range sortedKeys(ems.Modifications) {
+		mod := ems.Modifications[modID]
 		totalMods++
 		if mod.IsActive {
 			activeMods++
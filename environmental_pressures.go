@@ -32,6 +32,8 @@ type EnvironmentalPressureSystem struct {
 	ClimateChangeRate      float64 `json:"climate_change_rate"`     // How quickly climate changes
 	PollutionAccumulation  float64 `json:"pollution_accumulation"`  // Rate of pollution buildup
 	FragmentationThreshold float64 `json:"fragmentation_threshold"` // Population density threshold for fragmentation
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // PressureType constants
@@ -54,6 +56,7 @@ func NewEnvironmentalPressureSystem() *EnvironmentalPressureSystem {
 		ClimateChangeRate:      0.001,  // 0.1% change per tick
 		PollutionAccumulation:  0.0005, // 0.05% pollution increase per tick
 		FragmentationThreshold: 0.1,    // 10% population density threshold
+		rng:                    sharedRand,
 	}
 }
 
@@ -104,7 +107,7 @@ func (eps *EnvironmentalPressureSystem) evaluateNewPressures(world *World, tick
 	}
 
 	// Climate change trigger - based on population density and energy usage
-	if rand.Float64() < 0.001 && !eps.hasPressureType(PressureClimateChange) {
+	if eps.rng.Float64() < 0.001 && !eps.hasPressureType(PressureClimateChange) {
 		totalPopulation := len(world.AllEntities) + len(world.AllPlants)
 		if totalPopulation > 100 { // High population threshold
 			eps.triggerClimateChange(world, tick)
@@ -112,21 +115,21 @@ func (eps *EnvironmentalPressureSystem) evaluateNewPressures(world *World, tick
 	}
 
 	// Pollution trigger - based on civilization development
-	if rand.Float64() < 0.002 && !eps.hasPressureType(PressurePollution) {
+	if eps.rng.Float64() < 0.002 && !eps.hasPressureType(PressurePollution) {
 		if world.CivilizationSystem != nil && len(world.CivilizationSystem.Structures) > 20 {
 			eps.triggerPollution(world, tick)
 		}
 	}
 
 	// Habitat fragmentation - based on entity distribution
-	if rand.Float64() < 0.003 && !eps.hasPressureType(PressureHabitatFragmentation) {
+	if eps.rng.Float64() < 0.003 && !eps.hasPressureType(PressureHabitatFragmentation) {
 		if eps.isPopulationFragmented(world) {
 			eps.triggerHabitatFragmentation(world, tick)
 		}
 	}
 
 	// Resource depletion - based on ecosystem health
-	if rand.Float64() < 0.001 && !eps.hasPressureType(PressureResourceDepletion) {
+	if eps.rng.Float64() < 0.001 && !eps.hasPressureType(PressureResourceDepletion) {
 		if world.EcosystemMonitor != nil {
 			healthScore := world.EcosystemMonitor.GetHealthScore()
 			if healthScore < 30 { // Low ecosystem health
@@ -153,16 +156,16 @@ func (eps *EnvironmentalPressureSystem) triggerClimateChange(world *World, tick
 		Type:         PressureClimateChange,
 		Name:         "Global Climate Change",
 		Description:  "Rising temperatures and changing weather patterns affecting all biomes",
-		Severity:     0.3 + rand.Float64()*0.4, // 30-70% severity
+		Severity:     0.3 + eps.rng.Float64()*0.4, // 30-70% severity
 		StartTick:    tick,
 		Duration:     -1,                                                              // Permanent
 		AffectedArea: Position{X: world.Config.Width / 2, Y: world.Config.Height / 2}, // Global center
 		Radius:       math.Max(world.Config.Width, world.Config.Height),               // Global effect
 		Effects: map[string]interface{}{
-			"temperature_change":   (rand.Float64() - 0.5) * 4.0, // ±2°C change
-			"precipitation_change": (rand.Float64() - 0.5) * 0.6, // ±30% precipitation change
-			"biome_shift_rate":     0.02,                         // 2% chance of biome changes per tick
-			"extreme_weather_rate": 0.005,                        // Increased extreme weather
+			"temperature_change":   (eps.rng.Float64() - 0.5) * 4.0, // ±2°C change
+			"precipitation_change": (eps.rng.Float64() - 0.5) * 0.6, // ±30% precipitation change
+			"biome_shift_rate":     0.02,                            // 2% chance of biome changes per tick
+			"extreme_weather_rate": 0.005,                           // Increased extreme weather
 		},
 		IsActive: true,
 	}
@@ -181,11 +184,11 @@ func (eps *EnvironmentalPressureSystem) triggerPollution(world *World, tick int)
 		Type:         PressurePollution,
 		Name:         "Environmental Pollution",
 		Description:  "Toxic contamination spreading from industrial activities",
-		Severity:     0.2 + rand.Float64()*0.5, // 20-70% severity
+		Severity:     0.2 + eps.rng.Float64()*0.5, // 20-70% severity
 		StartTick:    tick,
-		Duration:     500 + rand.Intn(1000), // 500-1500 ticks
+		Duration:     500 + eps.rng.Intn(1000), // 500-1500 ticks
 		AffectedArea: Position{X: centerX, Y: centerY},
-		Radius:       10.0 + rand.Float64()*15.0, // 10-25 unit radius
+		Radius:       10.0 + eps.rng.Float64()*15.0, // 10-25 unit radius
 		Effects: map[string]interface{}{
 			"toxicity_increase":    0.1,  // 10% toxicity increase in affected area
 			"reproduction_penalty": 0.3,  // 30% reproduction penalty
@@ -206,7 +209,7 @@ func (eps *EnvironmentalPressureSystem) triggerHabitatFragmentation(world *World
 		Type:         PressureHabitatFragmentation,
 		Name:         "Habitat Fragmentation",
 		Description:  "Landscape divided into isolated patches, disrupting natural movement",
-		Severity:     0.4 + rand.Float64()*0.4, // 40-80% severity
+		Severity:     0.4 + eps.rng.Float64()*0.4, // 40-80% severity
 		StartTick:    tick,
 		Duration:     -1, // Permanent structural change
 		AffectedArea: Position{X: world.Config.Width / 2, Y: world.Config.Height / 2},
@@ -231,9 +234,9 @@ func (eps *EnvironmentalPressureSystem) triggerResourceDepletion(world *World, t
 		Type:         PressureResourceDepletion,
 		Name:         "Resource Depletion Crisis",
 		Description:  "Overharvesting has depleted critical ecosystem resources",
-		Severity:     0.5 + rand.Float64()*0.3, // 50-80% severity
+		Severity:     0.5 + eps.rng.Float64()*0.3, // 50-80% severity
 		StartTick:    tick,
-		Duration:     200 + rand.Intn(500), // 200-700 ticks recovery time
+		Duration:     200 + eps.rng.Intn(500), // 200-700 ticks recovery time
 		AffectedArea: Position{X: world.Config.Width / 2, Y: world.Config.Height / 2},
 		Radius:       math.Max(world.Config.Width, world.Config.Height) * 0.7, // Most of world
 		Effects: map[string]interface{}{
@@ -318,7 +321,7 @@ func (eps *EnvironmentalPressureSystem) applyPressureEffects(world *World, tick
 // updateClimateChangePressure updates climate change pressure over time
 func (eps *EnvironmentalPressureSystem) updateClimateChangePressure(pressure *EnvironmentalPressure, world *World, tick int) {
 	// Climate change gradually intensifies
-	if rand.Float64() < eps.ClimateChangeRate {
+	if eps.rng.Float64() < eps.ClimateChangeRate {
 		pressure.Severity = math.Min(pressure.Severity+0.01, 1.0)
 	}
 }
@@ -326,7 +329,7 @@ func (eps *EnvironmentalPressureSystem) updateClimateChangePressure(pressure *En
 // updatePollutionPressure updates pollution pressure over time
 func (eps *EnvironmentalPressureSystem) updatePollutionPressure(pressure *EnvironmentalPressure, world *World, tick int) {
 	// Pollution can spread slowly
-	if rand.Float64() < eps.PollutionAccumulation {
+	if eps.rng.Float64() < eps.PollutionAccumulation {
 		pressure.Radius = math.Min(pressure.Radius+0.5, 50.0)
 	}
 }
@@ -344,9 +347,9 @@ func (eps *EnvironmentalPressureSystem) applyClimateChangeEffects(pressure *Envi
 	biomeShiftRate := pressure.Effects["biome_shift_rate"].(float64) * pressure.Severity
 
 	// Random biome changes due to climate shift
-	if rand.Float64() < biomeShiftRate {
-		x := rand.Intn(world.Config.GridWidth)
-		y := rand.Intn(world.Config.GridHeight)
+	if eps.rng.Float64() < biomeShiftRate {
+		x := eps.rng.Intn(world.Config.GridWidth)
+		y := eps.rng.Intn(world.Config.GridHeight)
 
 		// Shift towards warmer/dryer biomes
 		currentBiome := world.Grid[y][x].Biome
@@ -358,7 +361,7 @@ func (eps *EnvironmentalPressureSystem) applyClimateChangeEffects(pressure *Envi
 
 	// Increase extreme weather events
 	extremeWeatherRate := pressure.Effects["extreme_weather_rate"].(float64) * pressure.Severity
-	if rand.Float64() < extremeWeatherRate {
+	if eps.rng.Float64() < extremeWeatherRate {
 		world.triggerEnhancedEnvironmentalEvent()
 	}
 }
@@ -371,7 +374,7 @@ func (eps *EnvironmentalPressureSystem) applyPollutionEffects(pressure *Environm
 
 	// Apply effects to entities in affected area
 	for _, entity := range world.AllEntities {
-		if entity.IsAlive && eps.isInAffectedArea(entity.Position, pressure) {
+		if entity.IsAlive && eps.isInAffectedArea(entity.Position, pressure, world.Geometry()) {
 			// Health decay - check if trait exists first
 			if healthTrait, exists := entity.Traits["health"]; exists {
 				currentHealth := healthTrait.Value
@@ -393,7 +396,7 @@ func (eps *EnvironmentalPressureSystem) applyPollutionEffects(pressure *Environm
 
 	// Apply effects to plants in affected area
 	for _, plant := range world.AllPlants {
-		if plant.IsAlive && eps.isInAffectedArea(plant.Position, pressure) {
+		if plant.IsAlive && eps.isInAffectedArea(plant.Position, pressure, world.Geometry()) {
 			// Reduce plant health and growth
 			plant.Energy *= (1.0 - healthDecay)
 			plant.GrowthRate *= (1.0 - healthDecay/2)
@@ -426,7 +429,7 @@ func (eps *EnvironmentalPressureSystem) applyResourceDepletionEffects(pressure *
 	for y := 0; y < world.Config.GridHeight; y++ {
 		for x := 0; x < world.Config.GridWidth; x++ {
 			cellPos := Position{X: float64(x), Y: float64(y)}
-			if eps.isInAffectedArea(cellPos, pressure) {
+			if eps.isInAffectedArea(cellPos, pressure, world.Geometry()) {
 				cell := &world.Grid[y][x]
 				cell.WaterLevel *= (1.0 - waterReduction*pressure.Severity)
 
@@ -442,9 +445,8 @@ func (eps *EnvironmentalPressureSystem) applyResourceDepletionEffects(pressure *
 // Helper functions
 
 // isInAffectedArea checks if a position is within the pressure's affected area
-func (eps *EnvironmentalPressureSystem) isInAffectedArea(pos Position, pressure *EnvironmentalPressure) bool {
-	distance := math.Sqrt(math.Pow(pos.X-pressure.AffectedArea.X, 2) + math.Pow(pos.Y-pressure.AffectedArea.Y, 2))
-	return distance <= pressure.Radius
+func (eps *EnvironmentalPressureSystem) isInAffectedArea(pos Position, pressure *EnvironmentalPressure, geometry WorldGeometry) bool {
+	return geometry.Distance(pos, pressure.AffectedArea) <= pressure.Radius
 }
 
 // getClimateShiftedBiome returns a biome that represents climate change effects
@@ -458,7 +460,7 @@ func (eps *EnvironmentalPressureSystem) getClimateShiftedBiome(currentBiome Biom
 	case BiomeRainforest:
 		return BiomeForest
 	case BiomeForest:
-		if rand.Float64() < 0.5 {
+		if eps.rng.Float64() < 0.5 {
 			return BiomePlains
 		}
 		return BiomeDesert
@@ -94,7 +94,8 @@ func (e *EvaluationEngine) calculateMolecularFitness(entity *Entity) float64 {
 	if entity.MolecularMetabolism != nil {
 		totalEfficiency := 0.0
 		count := 0
-		for _, efficiency := range entity.MolecularMetabolism.Efficiency {
+		for _, molType := range sortedKeys(entity.MolecularMetabolism.Efficiency) {
+			efficiency := entity.MolecularMetabolism.Efficiency[molType]
 			totalEfficiency += efficiency
 			count++
 		}
@@ -124,7 +125,8 @@ func (e *EvaluationEngine) calculateMolecularFitness(entity *Entity) float64 {
 
 	// Bonus for toxin tolerance (survival advantage)
 	toxinToleranceBonus := 0.0
-	for _, tolerance := range entity.MolecularNeeds.Tolerances {
+	for _, molType := range sortedKeys(entity.MolecularNeeds.Tolerances) {
+		tolerance := entity.MolecularNeeds.Tolerances[molType]
 		toxinToleranceBonus += tolerance * 0.05
 	}
 
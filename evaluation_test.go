@@ -108,7 +108,7 @@ func TestEvaluationEngineEvaluateMinimize(t *testing.T) {
 func TestEvaluationEngineEvaluateExpression(t *testing.T) {
 	engine := NewEvaluationEngine()
 	pos := Position{X: 0, Y: 0}
-	entity := NewEntity(1, []string{"strength", "agility"}, "test", pos)
+	entity := NewEntity(sharedRand, 1, []string{"strength", "agility"}, "test", pos)
 	entity.SetTrait("strength", 2.0)
 	entity.SetTrait("agility", 3.0)
 
@@ -191,7 +191,7 @@ func TestEvaluationEngineCreateFitnessFunction(t *testing.T) {
 func TestEvaluationEngineComplexExpression(t *testing.T) {
 	engine := NewEvaluationEngine()
 	pos := Position{X: 0, Y: 0}
-	entity := NewEntity(1, []string{"strength", "agility", "intelligence"}, "test", pos)
+	entity := NewEntity(sharedRand, 1, []string{"strength", "agility", "intelligence"}, "test", pos)
 	entity.SetTrait("strength", 1.0)
 	entity.SetTrait("agility", 2.0)
 	entity.SetTrait("intelligence", 3.0)
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"sort"
 	"sync"
 	"time"
 )
@@ -14,6 +15,59 @@ const (
 	EventTypeSpeciation = "speciation"
 )
 
+// Event severity levels, from routine to alert-worthy
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// severityRanks orders severity levels so they can be compared
+var severityRanks = map[string]int{SeverityLow: 0, SeverityMedium: 1, SeverityHigh: 2, SeverityCritical: 3}
+
+// SeverityRank returns the rank of a severity level (low=0 ... critical=3); unknown levels rank as low
+func SeverityRank(severity string) int {
+	return severityRanks[severity]
+}
+
+// criticalEventTypes change the course of the simulation
+var criticalEventTypes = map[string]bool{
+	"extinction":           true,
+	"catastrophic_die_off": true,
+	"system_failure":       true,
+	"critical_error":       true,
+}
+
+// highEventTypes are notable enough to alert an observer
+var highEventTypes = map[string]bool{
+	EventTypeSpeciation:         true,
+	"war_declared":              true,
+	"disaster":                  true,
+	"environmental_event_start": true,
+	"mass_die_off":              true,
+	"breakpoint":                true,
+	"tribe_disbanded":           true,
+	"structure_destroyed":       true,
+	"game_over":                 true,
+	"cataclysm":                 true,
+	"resource_leak_suspected":   true,
+	"zoonotic_spillover":        true,
+	"achievement_unlocked":      true,
+}
+
+// routineEventTypes happen so often that they stay low severity regardless of magnitude
+var routineEventTypes = map[string]bool{
+	EventTypeBirth:    true,
+	EventTypeDeath:    true,
+	"movement":        true,
+	"collision":       true,
+	"pollen_released": true,
+}
+
+// maxAlerts is how many high and critical severity events the bus keeps for alerting
+const maxAlerts = 50
+
 // CentralEvent represents a unified event in the simulation
 type CentralEvent struct {
 	ID          int                    `json:"id"`
@@ -46,10 +100,16 @@ type CentralEventBus struct {
 	nextID    int
 	mutex     sync.RWMutex
 
-	// Event filtering and querying
+	// Event filtering and querying. Indices count from the oldest event when the indices
+	// were last built; dropped events before the first in events have gone since.
 	eventsByType     map[string][]int // Maps event type to event indices
 	eventsByCategory map[string][]int // Maps category to event indices
 	eventsByTick     map[int][]int    // Maps tick to event indices
+	dropped          int
+
+	// Recent high and critical severity events, kept separately so they are not
+	// crowded out of the main list by routine events
+	alerts []CentralEvent
 }
 
 // NewCentralEventBus creates a new central event bus
@@ -84,7 +144,7 @@ func (eb *CentralEventBus) EmitEvent(tick int, eventType, category, subCategory,
 		Source:      source,
 		Description: description,
 		Metadata:    metadata,
-		Severity:    "medium", // Default severity
+		Severity:    eb.determineSeverity(eventType, category, 0),
 	}
 
 	eb.addEvent(event)
@@ -200,7 +260,7 @@ func (eb *CentralEventBus) addEvent(event CentralEvent) {
 	eb.nextID++
 
 	// Update indices
-	eventIndex := len(eb.events) - 1
+	eventIndex := eb.dropped + len(eb.events) - 1
 
 	// Index by type
 	eb.eventsByType[event.Type] = append(eb.eventsByType[event.Type], eventIndex)
@@ -216,89 +276,112 @@ func (eb *CentralEventBus) addEvent(event CentralEvent) {
 		eb.removeOldestEvent()
 	}
 
+	if SeverityRank(event.Severity) >= SeverityRank(SeverityHigh) {
+		eb.alerts = append(eb.alerts, event)
+		if len(eb.alerts) > maxAlerts {
+			eb.alerts = eb.alerts[1:]
+		}
+	}
+
 	// Notify listeners
 	for _, listener := range eb.listeners {
 		listener(event)
 	}
 }
 
-// removeOldestEvent removes the oldest event and updates indices
+// removeOldestEvent removes the oldest event. Its indices go stale and are skipped by
+// readers until enough have gone to make rebuilding the indices worthwhile.
 func (eb *CentralEventBus) removeOldestEvent() {
 	if len(eb.events) == 0 {
 		return
 	}
 
-	// Remove from main list
 	eb.events = eb.events[1:]
+	eb.dropped++
+	if eb.dropped >= max(eb.maxEvents, 1) {
+		eb.events = append([]CentralEvent(nil), eb.events...)
+		eb.reindex()
+	}
+}
 
-	// Update all indices by decrementing them
-	for eventType, indices := range eb.eventsByType {
-		newIndices := make([]int, 0)
-		for _, index := range indices {
-			if index > 0 {
-				newIndices = append(newIndices, index-1)
-			}
-		}
-		if len(newIndices) == 0 {
-			delete(eb.eventsByType, eventType)
-		} else {
-			eb.eventsByType[eventType] = newIndices
-		}
+// reindex rebuilds the indices from the events the bus holds
+func (eb *CentralEventBus) reindex() {
+	eb.eventsByType = make(map[string][]int)
+	eb.eventsByCategory = make(map[string][]int)
+	eb.eventsByTick = make(map[int][]int)
+	eb.dropped = 0
+	for i, event := range eb.events {
+		eb.eventsByType[event.Type] = append(eb.eventsByType[event.Type], i)
+		eb.eventsByCategory[event.Category] = append(eb.eventsByCategory[event.Category], i)
+		eb.eventsByTick[event.Tick] = append(eb.eventsByTick[event.Tick], i)
 	}
+}
 
-	for category, indices := range eb.eventsByCategory {
-		newIndices := make([]int, 0)
-		for _, index := range indices {
-			if index > 0 {
-				newIndices = append(newIndices, index-1)
-			}
-		}
-		if len(newIndices) == 0 {
-			delete(eb.eventsByCategory, category)
-		} else {
-			eb.eventsByCategory[category] = newIndices
-		}
+// live skips the indices of events removed since the indices were built
+func (eb *CentralEventBus) live(indices []int) []int {
+	return indices[sort.SearchInts(indices, eb.dropped):]
+}
+
+// indexed returns the events an index points at that the bus still holds
+func (eb *CentralEventBus) indexed(indices []int) []CentralEvent {
+	indices = eb.live(indices)
+	events := make([]CentralEvent, len(indices))
+	for i, index := range indices {
+		events[i] = eb.events[index-eb.dropped]
 	}
+	return events
+}
 
-	for tick, indices := range eb.eventsByTick {
-		newIndices := make([]int, 0)
-		for _, index := range indices {
-			if index > 0 {
-				newIndices = append(newIndices, index-1)
-			}
-		}
-		if len(newIndices) == 0 {
-			delete(eb.eventsByTick, tick)
-		} else {
-			eb.eventsByTick[tick] = newIndices
-		}
+// Len returns how many events the bus holds
+func (eb *CentralEventBus) Len() int {
+	eb.mutex.RLock()
+	defer eb.mutex.RUnlock()
+	return len(eb.events)
+}
+
+// Trim drops the oldest events until at most keep remain and returns how many went.
+// Alerts are kept apart and survive the trim.
+func (eb *CentralEventBus) Trim(keep int) int {
+	eb.mutex.Lock()
+	defer eb.mutex.Unlock()
+
+	drop := len(eb.events) - keep
+	if drop <= 0 {
+		return 0
 	}
+	eb.events = append([]CentralEvent(nil), eb.events[drop:]...)
+
+	// Rebuilding the indices once is cheaper than shifting them per event
+	eb.reindex()
+	return drop
 }
 
 // determineSeverity determines event severity based on type and context
 func (eb *CentralEventBus) determineSeverity(eventType, category string, change float64) string {
-	// Critical events
-	if eventType == "extinction" || eventType == "system_failure" || eventType == "critical_error" {
-		return "critical"
+	if criticalEventTypes[eventType] {
+		return SeverityCritical
+	}
+	if highEventTypes[eventType] {
+		return SeverityHigh
 	}
 
-	// High severity events
-	if eventType == EventTypeDeath || eventType == EventTypeBirth || eventType == EventTypeEvolution || eventType == EventTypeSpeciation {
-		return "high"
+	// Births and deaths happen every tick; alerting on them would drown out everything else
+	if routineEventTypes[eventType] {
+		return SeverityLow
 	}
 
 	// Check for large changes
-	if change != 0 && (change > 100 || change < -100) {
-		return "high"
+	if change > 100 || change < -100 {
+		return SeverityHigh
 	}
 
-	// Medium severity for most other events
-	if eventType == "reproduction" || eventType == "communication" || eventType == "movement" {
-		return "medium"
+	// Medium severity for noteworthy but common events
+	if eventType == EventTypeEvolution || eventType == "reproduction" || eventType == "communication" || eventType == "conflict_started" {
+		return SeverityMedium
 	}
 
 	// Low severity for routine events
-	return "low"
+	return SeverityLow
 }
 
 // GetAllEvents returns all events in chronological order
@@ -316,16 +399,7 @@ func (eb *CentralEventBus) GetEventsByType(eventType string) []CentralEvent {
 	eb.mutex.RLock()
 	defer eb.mutex.RUnlock()
 
-	indices, exists := eb.eventsByType[eventType]
-	if !exists {
-		return []CentralEvent{}
-	}
-
-	events := make([]CentralEvent, len(indices))
-	for i, index := range indices {
-		events[i] = eb.events[index]
-	}
-	return events
+	return eb.indexed(eb.eventsByType[eventType])
 }
 
 // GetEventsByCategory returns events of a specific category
@@ -333,16 +407,7 @@ func (eb *CentralEventBus) GetEventsByCategory(category string) []CentralEvent {
 	eb.mutex.RLock()
 	defer eb.mutex.RUnlock()
 
-	indices, exists := eb.eventsByCategory[category]
-	if !exists {
-		return []CentralEvent{}
-	}
-
-	events := make([]CentralEvent, len(indices))
-	for i, index := range indices {
-		events[i] = eb.events[index]
-	}
-	return events
+	return eb.indexed(eb.eventsByCategory[category])
 }
 
 // GetEventsByTick returns events from a specific tick
@@ -350,16 +415,7 @@ func (eb *CentralEventBus) GetEventsByTick(tick int) []CentralEvent {
 	eb.mutex.RLock()
 	defer eb.mutex.RUnlock()
 
-	indices, exists := eb.eventsByTick[tick]
-	if !exists {
-		return []CentralEvent{}
-	}
-
-	events := make([]CentralEvent, len(indices))
-	for i, index := range indices {
-		events[i] = eb.events[index]
-	}
-	return events
+	return eb.indexed(eb.eventsByTick[tick])
 }
 
 // GetEventsSince returns events since a specific tick
@@ -376,6 +432,20 @@ func (eb *CentralEventBus) GetEventsSince(tick int) []CentralEvent {
 	return events
 }
 
+// GetEventsInRange returns events from fromTick to toTick inclusive
+func (eb *CentralEventBus) GetEventsInRange(fromTick, toTick int) []CentralEvent {
+	eb.mutex.RLock()
+	defer eb.mutex.RUnlock()
+
+	var events []CentralEvent
+	for _, event := range eb.events {
+		if event.Tick >= fromTick && event.Tick <= toTick {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
 // GetRecentEvents returns the most recent N events
 func (eb *CentralEventBus) GetRecentEvents(count int) []CentralEvent {
 	eb.mutex.RLock()
@@ -406,6 +476,20 @@ func (eb *CentralEventBus) GetEventsBySeverity(severity string) []CentralEvent {
 	return events
 }
 
+// GetRecentAlerts returns up to count of the most recent high and critical severity events
+func (eb *CentralEventBus) GetRecentAlerts(count int) []CentralEvent {
+	eb.mutex.RLock()
+	defer eb.mutex.RUnlock()
+
+	start := 0
+	if count < len(eb.alerts) {
+		start = len(eb.alerts) - count
+	}
+	alerts := make([]CentralEvent, len(eb.alerts)-start)
+	copy(alerts, eb.alerts[start:])
+	return alerts
+}
+
 // GetEventStats returns statistics about the events in the bus
 func (eb *CentralEventBus) GetEventStats() map[string]interface{} {
 	eb.mutex.RLock()
@@ -420,14 +504,18 @@ func (eb *CentralEventBus) GetEventStats() map[string]interface{} {
 	// Count by type
 	typeStats := make(map[string]int)
 	for eventType, indices := range eb.eventsByType {
-		typeStats[eventType] = len(indices)
+		if count := len(eb.live(indices)); count > 0 {
+			typeStats[eventType] = count
+		}
 	}
 	stats["events_by_type"] = typeStats
 
 	// Count by category
 	categoryStats := make(map[string]int)
 	for category, indices := range eb.eventsByCategory {
-		categoryStats[category] = len(indices)
+		if count := len(eb.live(indices)); count > 0 {
+			categoryStats[category] = count
+		}
 	}
 	stats["events_by_category"] = categoryStats
 
@@ -464,5 +552,7 @@ func (eb *CentralEventBus) ClearEvents() {
 	eb.eventsByType = make(map[string][]int)
 	eb.eventsByCategory = make(map[string][]int)
 	eb.eventsByTick = make(map[int][]int)
+	eb.dropped = 0
+	eb.alerts = nil
 	eb.nextID = 1
 }
@@ -0,0 +1,72 @@
+package main
+
+import "testing"
+
+func TestEventSeverityClassification(t *testing.T) {
+	eb := NewCentralEventBus(100)
+	expected := map[string]string{
+		"extinction":           SeverityCritical,
+		"catastrophic_die_off": SeverityCritical,
+		"war_declared":         SeverityHigh,
+		"disaster":             SeverityHigh,
+		EventTypeSpeciation:    SeverityHigh,
+		"conflict_started":     SeverityMedium,
+		EventTypeEvolution:     SeverityMedium,
+		EventTypeBirth:         SeverityLow,
+		EventTypeDeath:         SeverityLow,
+		"group_formed":         SeverityLow,
+	}
+	for eventType, severity := range expected {
+		if got := eb.determineSeverity(eventType, "system", 0); got != severity {
+			t.Errorf("Expected %s events to be %s, got %s", eventType, severity, got)
+		}
+	}
+
+	// Routine events stay low however large the change
+	if got := eb.determineSeverity(EventTypeDeath, "entity", -150); got != SeverityLow {
+		t.Errorf("Expected a death with a large energy change to stay low, got %s", got)
+	}
+	if SeverityRank(SeverityCritical) <= SeverityRank(SeverityHigh) || SeverityRank("unknown") != SeverityRank(SeverityLow) {
+		t.Error("Expected severity ranks to order low < high < critical with unknown levels ranked low")
+	}
+}
+
+func TestRecentAlertsSurviveRoutineEvents(t *testing.T) {
+	eb := NewCentralEventBus(10)
+	eb.EmitSystemEvent(1, "war_declared", "warfare", "test", "War", nil, nil)
+	for i := 0; i < 50; i++ {
+		eb.EmitSystemEvent(2, "group_formed", "communication", "test", "Group", nil, nil)
+	}
+	eb.EmitSystemEvent(3, "extinction", "species", "test", "Gone", nil, nil)
+
+	alerts := eb.GetRecentAlerts(10)
+	if len(alerts) != 2 {
+		t.Fatalf("Expected 2 alerts after the war left the event list, got %d", len(alerts))
+	}
+	if alerts[0].Type != "war_declared" || alerts[1].Type != "extinction" || alerts[1].ID <= alerts[0].ID {
+		t.Errorf("Expected alerts in emission order, got %s then %s", alerts[0].Type, alerts[1].Type)
+	}
+	if len(eb.GetRecentAlerts(1)) != 1 {
+		t.Error("Expected alert count to be limited")
+	}
+}
+
+func TestExtinctionReachesEventBusOnce(t *testing.T) {
+	world := NewWorld(WorldConfig{Width: 30, Height: 30, PopulationSize: 3, GridWidth: 6, GridHeight: 6})
+	world.AddPopulation(startingPopulations(false)[0])
+	world.EventLogger.UpdatePopulationCounts(world.Tick, world.Populations)
+
+	for _, entity := range world.AllEntities {
+		entity.IsAlive = false
+	}
+	world.Tick++
+	world.EventLogger.UpdatePopulationCounts(world.Tick, world.Populations)
+	world.emitExtinctions()
+
+	if extinctions := world.CentralEventBus.GetEventsByType("extinction"); len(extinctions) != 1 || extinctions[0].Severity != SeverityCritical {
+		t.Fatalf("Expected one critical extinction event on the bus, got %+v", extinctions)
+	}
+	if logged := world.EventLogger.GetEventsByType("extinction"); len(logged) != 0 {
+		t.Errorf("Expected mirrored extinction not to be logged again, got %d", len(logged))
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+// maxTimelineMarkers caps how many notable events a timeline draws individually
+const maxTimelineMarkers = 200
+
+// TimelineBucket counts the events in a span of ticks by category
+type TimelineBucket struct {
+	StartTick int            `json:"start_tick"`
+	EndTick   int            `json:"end_tick"` // Inclusive
+	Counts    map[string]int `json:"counts"`   // Category -> events
+}
+
+// TimelineMarker is a high or critical severity event drawn on its own
+type TimelineMarker struct {
+	ID          int    `json:"id"`
+	Tick        int    `json:"tick"`
+	Type        string `json:"type"`
+	Category    string `json:"category"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+}
+
+// EventTimeline summarizes central events between two ticks for the TIMELINE view
+type EventTimeline struct {
+	FromTick   int              `json:"from_tick"`
+	ToTick     int              `json:"to_tick"`
+	Categories []string         `json:"categories"` // Sorted; one timeline lane each
+	Buckets    []TimelineBucket `json:"buckets"`
+	MaxCount   int              `json:"max_count"` // Largest single category count in any bucket
+	Markers    []TimelineMarker `json:"markers"`
+	Events     []TimelineMarker `json:"events,omitempty"` // Most recent events in range, when requested
+}
+
+// BuildEventTimeline groups events with fromTick <= tick <= toTick into at most bucketCount
+// equal spans per category. The most recent eventLimit events are listed individually.
+func BuildEventTimeline(events []CentralEvent, fromTick, toTick, bucketCount, eventLimit int) *EventTimeline {
+	if toTick < fromTick {
+		toTick = fromTick
+	}
+	if bucketCount < 1 {
+		bucketCount = 1
+	}
+	span := toTick - fromTick + 1
+	bucketSize := (span + bucketCount - 1) / bucketCount
+	bucketCount = (span + bucketSize - 1) / bucketSize
+
+	timeline := &EventTimeline{
+		FromTick: fromTick,
+		ToTick:   toTick,
+		Buckets:  make([]TimelineBucket, bucketCount),
+		Markers:  make([]TimelineMarker, 0),
+	}
+	for i := range timeline.Buckets {
+		start := fromTick + i*bucketSize
+		timeline.Buckets[i] = TimelineBucket{
+			StartTick: start,
+			EndTick:   min(start+bucketSize-1, toTick),
+			Counts:    make(map[string]int),
+		}
+	}
+
+	categories := make(map[string]bool)
+	inRange := make([]CentralEvent, 0)
+	for _, event := range events {
+		if event.Tick < fromTick || event.Tick > toTick {
+			continue
+		}
+		inRange = append(inRange, event)
+		categories[event.Category] = true
+
+		bucket := &timeline.Buckets[(event.Tick-fromTick)/bucketSize]
+		bucket.Counts[event.Category]++
+		timeline.MaxCount = max(timeline.MaxCount, bucket.Counts[event.Category])
+
+		if SeverityRank(event.Severity) >= SeverityRank(SeverityHigh) {
+			timeline.Markers = append(timeline.Markers, newTimelineMarker(event))
+		}
+	}
+	timeline.Categories = sortedKeys(categories)
+	if len(timeline.Markers) > maxTimelineMarkers {
+		timeline.Markers = timeline.Markers[len(timeline.Markers)-maxTimelineMarkers:]
+	}
+
+	if eventLimit > 0 {
+		start := max(0, len(inRange)-eventLimit)
+		timeline.Events = make([]TimelineMarker, 0, len(inRange)-start)
+		for _, event := range inRange[start:] {
+			timeline.Events = append(timeline.Events, newTimelineMarker(event))
+		}
+	}
+	return timeline
+}
+
+func newTimelineMarker(event CentralEvent) TimelineMarker {
+	return TimelineMarker{
+		ID:          event.ID,
+		Tick:        event.Tick,
+		Type:        event.Type,
+		Category:    event.Category,
+		Severity:    event.Severity,
+		Description: event.Description,
+	}
+}
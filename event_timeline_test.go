@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBuildEventTimelineBucketsByCategory(t *testing.T) {
+	events := []CentralEvent{
+		{ID: 1, Tick: -1, Category: "system", Type: "dna", Severity: SeverityLow},
+		{ID: 2, Tick: 0, Category: "entity", Type: EventTypeBirth, Severity: SeverityLow},
+		{ID: 3, Tick: 9, Category: "entity", Type: EventTypeDeath, Severity: SeverityLow},
+		{ID: 4, Tick: 9, Category: "entity", Type: EventTypeDeath, Severity: SeverityLow},
+		{ID: 5, Tick: 10, Category: "system", Type: "extinction", Severity: SeverityCritical},
+		{ID: 6, Tick: 25, Category: "plant", Type: "growth", Severity: SeverityLow},
+	}
+
+	timeline := BuildEventTimeline(events, 0, 24, 3, 2)
+	if len(timeline.Buckets) != 3 || timeline.Buckets[2].StartTick != 18 || timeline.Buckets[2].EndTick != 24 {
+		t.Fatalf("Expected 3 buckets of 9 ticks ending at tick 24, got %+v", timeline.Buckets)
+	}
+	if timeline.Buckets[0].Counts["entity"] != 1 || timeline.Buckets[1].Counts["entity"] != 2 || timeline.Buckets[1].Counts["system"] != 1 {
+		t.Errorf("Unexpected bucket counts: %+v", timeline.Buckets)
+	}
+	if timeline.MaxCount != 2 {
+		t.Errorf("Expected max count 2, got %d", timeline.MaxCount)
+	}
+	if len(timeline.Categories) != 2 || timeline.Categories[0] != "entity" || timeline.Categories[1] != "system" {
+		t.Errorf("Expected only in-range categories in sorted order, got %v", timeline.Categories)
+	}
+	if len(timeline.Markers) != 1 || timeline.Markers[0].Type != "extinction" {
+		t.Errorf("Expected the extinction as the only marker, got %+v", timeline.Markers)
+	}
+	if len(timeline.Events) != 2 || timeline.Events[0].ID != 4 || timeline.Events[1].ID != 5 {
+		t.Errorf("Expected the two most recent in-range events, got %+v", timeline.Events)
+	}
+}
+
+func TestTimelineAPI(t *testing.T) {
+	world := NewWorld(WorldConfig{Width: 30, Height: 30, GridWidth: 6, GridHeight: 6})
+	world.Tick = 50
+	world.CentralEventBus.EmitSystemEvent(20, "war_declared", "warfare", "test", "War", nil, nil)
+	world.CentralEventBus.EmitSystemEvent(40, "group_formed", "communication", "test", "Group", nil, nil)
+	wi := NewWebInterface(world)
+
+	rec := httptest.NewRecorder()
+	wi.handleTimeline(rec, httptest.NewRequest(http.MethodGet, "/api/timeline?from=10&to=500&buckets=4&events=10", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Timeline failed: %d %s", rec.Code, rec.Body.String())
+	}
+	var timeline EventTimeline
+	if err := json.Unmarshal(rec.Body.Bytes(), &timeline); err != nil {
+		t.Fatalf("Invalid timeline: %v", err)
+	}
+	if timeline.FromTick != 10 || timeline.ToTick != 50 {
+		t.Errorf("Expected range clamped to ticks 10-50, got %d-%d", timeline.FromTick, timeline.ToTick)
+	}
+	if len(timeline.Markers) != 1 || len(timeline.Events) != 2 {
+		t.Errorf("Expected 1 marker and 2 events, got %d and %d", len(timeline.Markers), len(timeline.Events))
+	}
+
+	for _, query := range []string{"from=abc", "from=30&to=20", "buckets=-1"} {
+		rec = httptest.NewRecorder()
+		wi.handleTimeline(rec, httptest.NewRequest(http.MethodGet, "/api/timeline?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %q, got %d", query, rec.Code)
+		}
+	}
+}
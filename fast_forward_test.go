@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFastForwardReplaysSaveDeterministically(t *testing.T) {
+	source := newSteppingTestWorld(17)
+	for i := 0; i < 5; i++ {
+		source.Update()
+	}
+	state, err := NewStateManager(source).CurrentState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	world := newSteppingTestWorld(1)
+	if _, err := FastForward(world, state, 3, 0); err == nil {
+		t.Error("Expected a target before the save's tick to be rejected")
+	}
+	if _, err := FastForward(world, state, state.Tick+maxFastForwardTicks+1, 0); err == nil {
+		t.Error("Expected an oversized fast-forward to be rejected")
+	}
+
+	world.Paused = true
+	first, err := FastForward(world, state, 25, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.FromTick != 5 || first.ToTick != 25 || first.Ticks != 20 || world.Tick != 25 || !world.IsPaused() {
+		t.Fatalf("Expected 20 ticks from 5 to 25 with the world still paused, got %+v", first)
+	}
+	if len(livingSpeciesCounts(world)) == 0 {
+		t.Error("Expected the save's creatures to survive the replay")
+	}
+
+	// The same save replayed elsewhere, even into a world that has moved on, ends identically
+	other := newSteppingTestWorld(99)
+	for i := 0; i < 8; i++ {
+		other.Update()
+	}
+	second, err := FastForward(other, state, 25, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Digest != first.Digest || second.Seed != first.Seed || other.IsPaused() {
+		t.Errorf("Expected a running world with digest %s, got %+v", first.Digest, second)
+	}
+
+	// A breakpoint ends the replay early and leaves the world paused there
+	stopped := newSteppingTestWorld(2)
+	if _, err := stopped.Breakpoints.Add("tick=12", nil); err != nil {
+		t.Fatal(err)
+	}
+	result, err := FastForward(stopped, state, 25, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ToTick != 12 || result.StoppedBy != "breakpoint" || !stopped.IsPaused() {
+		t.Errorf("Expected the breakpoint to stop the replay at tick 12, got %+v", result)
+	}
+}
+
+func TestFastForwardAPI(t *testing.T) {
+	source := newSteppingTestWorld(21)
+	source.Update()
+	state, err := NewStateManager(source).CurrentState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wi := NewWebInterface(newSteppingTestWorld(22))
+
+	rec := httptest.NewRecorder()
+	wi.handleFastForward(rec, httptest.NewRequest(http.MethodPost, "/api/fast-forward", bytes.NewReader([]byte(`{"target_tick": 10}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without a save, got %d", rec.Code)
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{"state": state, "target_tick": 10, "seed": 4})
+	rec = httptest.NewRecorder()
+	wi.handleFastForward(rec, httptest.NewRequest(http.MethodPost, "/api/fast-forward", bytes.NewReader(body)))
+	var result FastForwardResult
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &result) != nil {
+		t.Fatalf("Fast-forward failed: %d %s", rec.Code, rec.Body.String())
+	}
+	if result.ToTick != 10 || result.Seed != 4 || wi.world.Tick != 10 || wi.world.IsPaused() {
+		t.Errorf("Expected a running world at tick 10, got %+v", result)
+	}
+}
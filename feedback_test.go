@@ -8,7 +8,7 @@ import (
 
 func TestDietaryMemoryInitialization(t *testing.T) {
 	pos := Position{X: 0, Y: 0}
-	entity := NewEntity(1, []string{"strength"}, "test", pos)
+	entity := NewEntity(sharedRand, 1, []string{"strength"}, "test", pos)
 
 	if entity.DietaryMemory == nil {
 		t.Error("Expected DietaryMemory to be initialized")
@@ -29,7 +29,7 @@ func TestDietaryMemoryInitialization(t *testing.T) {
 
 func TestEnvironmentalMemoryInitialization(t *testing.T) {
 	pos := Position{X: 0, Y: 0}
-	entity := NewEntity(1, []string{"strength"}, "test", pos)
+	entity := NewEntity(sharedRand, 1, []string{"strength"}, "test", pos)
 
 	if entity.EnvironmentalMemory == nil {
 		t.Error("Expected EnvironmentalMemory to be initialized")
@@ -46,8 +46,8 @@ func TestEnvironmentalMemoryInitialization(t *testing.T) {
 
 func TestDietaryPreferenceInheritance(t *testing.T) {
 	pos := Position{X: 0, Y: 0}
-	parent1 := NewEntity(1, []string{"strength"}, "test", pos)
-	parent2 := NewEntity(2, []string{"strength"}, "test", pos)
+	parent1 := NewEntity(sharedRand, 1, []string{"strength"}, "test", pos)
+	parent2 := NewEntity(sharedRand, 2, []string{"strength"}, "test", pos)
 
 	// Set up some dietary preferences for parents
 	parent1.DietaryMemory.PlantTypePreferences[0] = 1.5 // Strong preference for plant type 0
@@ -57,7 +57,7 @@ func TestDietaryPreferenceInheritance(t *testing.T) {
 	parent2.DietaryMemory.PreySpeciesPreferences["herbivore"] = 1.0
 
 	// Create child through crossover
-	child := Crossover(parent1, parent2, 3, "test")
+	child := Crossover(sharedRand, parent1, parent2, 3, "test")
 
 	// Check that child inherited averaged preferences with some variation
 	plantPref := child.DietaryMemory.PlantTypePreferences[0]
@@ -76,7 +76,7 @@ func TestFeedbackLoopFitnessContribution(t *testing.T) {
 	engine.AddRule("simple", "strength", 1.0, 1.0, false)
 
 	pos := Position{X: 0, Y: 0}
-	entity := NewEntity(1, []string{"strength"}, "test", pos)
+	entity := NewEntity(sharedRand, 1, []string{"strength"}, "test", pos)
 	entity.SetTrait("strength", 0.5)
 
 	// Modify dietary fitness to test contribution
@@ -94,13 +94,13 @@ func TestFeedbackLoopFitnessContribution(t *testing.T) {
 
 func TestDietaryMemoryUpdatesFromConsumption(t *testing.T) {
 	pos := Position{X: 0, Y: 0}
-	entity := NewEntity(1, []string{"strength"}, "herbivore", pos)
+	entity := NewEntity(sharedRand, 1, []string{"strength"}, "herbivore", pos)
 
 	// Make entity hungry so it will want to eat
 	entity.Energy = 20 // Low energy to trigger eating
 
 	// Create a test plant
-	plant := NewPlant(1, PlantGrass, Position{X: 1, Y: 1})
+	plant := NewPlant(sharedRand, 1, PlantGrass, Position{X: 1, Y: 1})
 
 	// Initial state - no preferences
 	initialPref := entity.DietaryMemory.PlantTypePreferences[int(PlantGrass)]
@@ -131,7 +131,7 @@ func TestDietaryMemoryUpdatesFromConsumption(t *testing.T) {
 
 func TestEnvironmentalAdaptationTracking(t *testing.T) {
 	pos := Position{X: 0, Y: 0}
-	entity := NewEntity(1, []string{"strength", "aquatic_adaptation"}, "test", pos)
+	entity := NewEntity(sharedRand, 1, []string{"strength", "aquatic_adaptation"}, "test", pos)
 	entity.SetTrait("aquatic_adaptation", 0.8) // Well adapted to water
 
 	// Initial exposure should be empty
@@ -156,7 +156,7 @@ func TestEnvironmentalAdaptationTracking(t *testing.T) {
 
 func TestBiasedMutationFromEnvironmentalPressure(t *testing.T) {
 	pos := Position{X: 0, Y: 0}
-	entity := NewEntity(1, []string{"aquatic_adaptation"}, "test", pos)
+	entity := NewEntity(sharedRand, 1, []string{"aquatic_adaptation"}, "test", pos)
 
 	// Set poor aquatic adaptation
 	entity.SetTrait("aquatic_adaptation", -0.8)
@@ -184,7 +184,7 @@ func TestBiasedMutationFromEnvironmentalPressure(t *testing.T) {
 	totalMutations := 200
 
 	for i := 0; i < totalMutations; i++ {
-		testEntity := entity.Clone()
+		testEntity := entity.Clone(sharedRand)
 		testEntity.EnvironmentalMemory.BiomeExposure[BiomeWater] = 0.9
 		testEntity.EnvironmentalMemory.AdaptationFitness = 0.2
 
@@ -209,7 +209,7 @@ func TestBiasedMutationFromEnvironmentalPressure(t *testing.T) {
 
 func TestMutationRateIncreasesWithEnvironmentalPressure(t *testing.T) {
 	pos := Position{X: 0, Y: 0}
-	entity := NewEntity(1, []string{"strength"}, "test", pos)
+	entity := NewEntity(sharedRand, 1, []string{"strength"}, "test", pos)
 
 	// Add environmental pressure
 	entity.EnvironmentalMemory.RadiationPressure = 2.0   // High radiation
@@ -221,12 +221,12 @@ func TestMutationRateIncreasesWithEnvironmentalPressure(t *testing.T) {
 	testRuns := 1000
 
 	for i := 0; i < testRuns; i++ {
-		testEntity := entity.Clone()
+		testEntity := entity.Clone(sharedRand)
 		testEntity.DietaryMemory = NewDietaryMemory()
 		testEntity.EnvironmentalMemory = entity.EnvironmentalMemory
 
 		initialValue := testEntity.GetTrait("strength")
-		testEntity.Mutate(0.01, 0.1) // Very low base mutation rate
+		testEntity.Mutate(sharedRand, 0.01, 0.1) // Very low base mutation rate
 		newValue := testEntity.GetTrait("strength")
 
 		if newValue != initialValue {
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Fitness landscape sampling limits
+const (
+	defaultLandscapeResolution = 21
+	minLandscapeResolution     = 3
+	maxLandscapeResolution     = 41
+	defaultLandscapeRadius     = 0.5  // How far past the population the axes extend
+	landscapeContextSize       = 20   // Creatures whose other traits and surroundings each point is averaged over
+	landscapeEnergyWeight      = 10.0 // Fitness lost per unit of energy a genotype burns each tick
+	maxLandscapeExtrema        = 5    // Peaks and valleys reported
+)
+
+// FitnessLandscapeRequest chooses the two traits to project genotype space onto
+type FitnessLandscapeRequest struct {
+	Species    string  `json:"species"` // Empty samples every living creature
+	XTrait     string  `json:"x_trait"`
+	YTrait     string  `json:"y_trait"`
+	Resolution int     `json:"resolution"` // Points along each axis
+	Radius     float64 `json:"radius"`     // Margin around the population's spread on each axis
+}
+
+// LandscapePoint is a place on the landscape and the expected fitness there
+type LandscapePoint struct {
+	X       float64 `json:"x"`
+	Y       float64 `json:"y"`
+	Fitness float64 `json:"fitness"`
+}
+
+// LandscapeIndividual places a living creature on the landscape
+type LandscapeIndividual struct {
+	EntityID int     `json:"entity_id"`
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Fitness  float64 `json:"fitness"` // Expected fitness of the creature's own genotype where it stands
+}
+
+// FitnessLandscape is a 2D projection of the expected fitness around a population
+type FitnessLandscape struct {
+	Tick       int                   `json:"tick"`
+	Species    string                `json:"species"`
+	XTrait     string                `json:"x_trait"`
+	YTrait     string                `json:"y_trait"`
+	XValues    []float64             `json:"x_values"`
+	YValues    []float64             `json:"y_values"`
+	Fitness    [][]float64           `json:"fitness"` // Indexed [y][x]
+	Min        float64               `json:"min"`
+	Max        float64               `json:"max"`
+	Peaks      []LandscapePoint      `json:"peaks"`   // Local maxima, highest first
+	Valleys    []LandscapePoint      `json:"valleys"` // Local minima, lowest first
+	Population []LandscapeIndividual `json:"population"`
+	Centroid   LandscapePoint        `json:"centroid"` // The population's mean genotype
+	UphillX    float64               `json:"uphill_x"` // Direction selection pushes the centroid, as a unit vector
+	UphillY    float64               `json:"uphill_y"`
+}
+
+// landscapeContext is a sampled creature whose other genes and surroundings hold while
+// the two projected traits vary
+type landscapeContext struct {
+	probe  *Entity
+	biome  BiomeType
+	season string
+}
+
+// landscapeFitness estimates how well a genotype would fare where a creature stands:
+// how its traits suit the biome and season, what they earn it in foraging and fights,
+// and the energy they cost to carry
+func landscapeFitness(w *World, c landscapeContext) float64 {
+	e := c.probe
+	fitness := 1.0 + e.habitatAdaptation(c.biome)
+
+	speed := e.GetTrait("speed")
+	size := e.GetTrait("size")
+	strength := e.GetTrait("strength")
+	intelligence := e.GetTrait("intelligence")
+	endurance := e.GetTrait("endurance")
+
+	// Each trait helps less the further it is pushed
+	fitness += 0.3*speed + 0.2*strength + 0.2*intelligence + 0.1*size
+	fitness -= 0.1 * (speed*speed + strength*strength + intelligence*intelligence + size*size)
+
+	// Endurance halves the season's stress at its best
+	fitness -= seasonalStress(c.season) * (1 - 0.25*endurance)
+
+	// Moving fast, being large and carrying custom traits burn energy every tick
+	energy := (math.Max(0, speed) + math.Max(0, size)) * w.SimConfig.Energy.MovementEnergyCost
+	if w.CustomTraits != nil {
+		for _, definition := range w.CustomTraits.Definitions {
+			energy += definition.energyCost(e.Traits[definition.Name].Value)
+		}
+	}
+	return fitness - energy*landscapeEnergyWeight
+}
+
+// expectedFitness averages the fitness of a point in trait space over the sampled contexts
+func expectedFitness(w *World, contexts []landscapeContext, xTrait, yTrait string, x, y float64) float64 {
+	total := 0.0
+	for _, c := range contexts {
+		c.probe.Traits[xTrait] = Trait{Name: xTrait, Value: x}
+		c.probe.Traits[yTrait] = Trait{Name: yTrait, Value: y}
+		total += landscapeFitness(w, c)
+	}
+	return total / float64(len(contexts))
+}
+
+// newLandscapeContext copies a creature's genes into a probe that can be rewritten freely.
+// Custom trait hooks carry over so the probe expresses its traits as the creature would.
+func newLandscapeContext(w *World, entity *Entity) landscapeContext {
+	probe := &Entity{ID: entity.ID, Species: entity.Species, Traits: make(map[string]Trait, len(entity.Traits)), traitOffsets: entity.traitOffsets}
+	for name, trait := range entity.Traits {
+		probe.Traits[name] = trait
+	}
+	return landscapeContext{probe: probe, biome: w.getBiomeAt(entity.Position), season: w.getCurrentSeason()}
+}
+
+// landscapeAxis spreads resolution values over the population's range of a trait,
+// widened by the radius and kept within the trait's valid range
+func landscapeAxis(definition TraitDefinition, values []float64, radius float64, resolution int) []float64 {
+	low, high := values[0], values[0]
+	for _, v := range values {
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+	low = math.Max(definition.Min, low-radius)
+	high = math.Min(definition.Max, high+radius)
+	if high <= low {
+		low, high = definition.Min, definition.Max
+	}
+
+	axis := make([]float64, resolution)
+	for i := range axis {
+		axis[i] = low + (high-low)*float64(i)/float64(resolution-1)
+	}
+	return axis
+}
+
+// landscapeTrait checks a projected trait is a creature gene and returns its definition
+func landscapeTrait(registry *TraitRegistry, name string) (TraitDefinition, error) {
+	definition, ok := registry.Lookup(TraitSubjectCreature, name)
+	if !ok || definition.Kind != TraitKindGene || definition.Prefix {
+		return TraitDefinition{}, fmt.Errorf("%q is not a creature gene", name)
+	}
+	return definition, nil
+}
+
+// SampleFitnessLandscape samples genotype space around the living members of a species
+// (or every living creature) and projects the expected fitness onto two traits
+func SampleFitnessLandscape(w *World, request FitnessLandscapeRequest) (*FitnessLandscape, error) {
+	if request.Resolution == 0 {
+		request.Resolution = defaultLandscapeResolution
+	}
+	if request.Resolution < minLandscapeResolution || request.Resolution > maxLandscapeResolution {
+		return nil, fmt.Errorf("resolution must be between %d and %d", minLandscapeResolution, maxLandscapeResolution)
+	}
+	if request.Radius == 0 {
+		request.Radius = defaultLandscapeRadius
+	}
+	if request.Radius < 0 || isInvalidNumber(request.Radius) {
+		return nil, fmt.Errorf("radius must be a positive number")
+	}
+	if request.XTrait == request.YTrait {
+		return nil, fmt.Errorf("the landscape needs two different traits")
+	}
+	xDefinition, err := landscapeTrait(w.TraitRegistry, request.XTrait)
+	if err != nil {
+		return nil, err
+	}
+	yDefinition, err := landscapeTrait(w.TraitRegistry, request.YTrait)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*Entity, 0)
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive && (request.Species == "" || entity.Species == request.Species) {
+			members = append(members, entity)
+		}
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("no living creatures to sample")
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+
+	// Spread the sampled contexts evenly over the population
+	contexts := make([]landscapeContext, 0, landscapeContextSize)
+	stride := math.Max(1, float64(len(members))/landscapeContextSize)
+	for i := 0.0; int(i) < len(members) && len(contexts) < landscapeContextSize; i += stride {
+		contexts = append(contexts, newLandscapeContext(w, members[int(i)]))
+	}
+
+	landscape := &FitnessLandscape{
+		Tick:       w.Tick,
+		Species:    request.Species,
+		XTrait:     request.XTrait,
+		YTrait:     request.YTrait,
+		Population: make([]LandscapeIndividual, 0, len(members)),
+		Peaks:      make([]LandscapePoint, 0),
+		Valleys:    make([]LandscapePoint, 0),
+	}
+
+	xs := make([]float64, len(members))
+	ys := make([]float64, len(members))
+	for i, entity := range members {
+		xs[i] = entity.Traits[request.XTrait].Value
+		ys[i] = entity.Traits[request.YTrait].Value
+		own := newLandscapeContext(w, entity)
+		landscape.Population = append(landscape.Population, LandscapeIndividual{
+			EntityID: entity.ID, X: xs[i], Y: ys[i], Fitness: landscapeFitness(w, own),
+		})
+		landscape.Centroid.X += xs[i] / float64(len(members))
+		landscape.Centroid.Y += ys[i] / float64(len(members))
+	}
+	landscape.XValues = landscapeAxis(xDefinition, xs, request.Radius, request.Resolution)
+	landscape.YValues = landscapeAxis(yDefinition, ys, request.Radius, request.Resolution)
+
+	landscape.Fitness = make([][]float64, request.Resolution)
+	landscape.Min, landscape.Max = math.Inf(1), math.Inf(-1)
+	for yi, y := range landscape.YValues {
+		landscape.Fitness[yi] = make([]float64, request.Resolution)
+		for xi, x := range landscape.XValues {
+			fitness := expectedFitness(w, contexts, request.XTrait, request.YTrait, x, y)
+			landscape.Fitness[yi][xi] = fitness
+			landscape.Min = math.Min(landscape.Min, fitness)
+			landscape.Max = math.Max(landscape.Max, fitness)
+		}
+	}
+	landscape.findExtrema()
+
+	// Selection pushes the centroid up the local gradient
+	centroid := landscape.Centroid
+	landscape.Centroid.Fitness = expectedFitness(w, contexts, request.XTrait, request.YTrait, centroid.X, centroid.Y)
+	const step = 0.01
+	dx := expectedFitness(w, contexts, request.XTrait, request.YTrait, centroid.X+step, centroid.Y) -
+		expectedFitness(w, contexts, request.XTrait, request.YTrait, centroid.X-step, centroid.Y)
+	dy := expectedFitness(w, contexts, request.XTrait, request.YTrait, centroid.X, centroid.Y+step) -
+		expectedFitness(w, contexts, request.XTrait, request.YTrait, centroid.X, centroid.Y-step)
+	if length := math.Hypot(dx, dy); length > 1e-12 {
+		landscape.UphillX, landscape.UphillY = dx/length, dy/length
+	}
+	return landscape, nil
+}
+
+// findExtrema marks the grid points higher (peaks) or lower (valleys) than all their neighbours
+func (fl *FitnessLandscape) findExtrema() {
+	rows, cols := len(fl.Fitness), len(fl.XValues)
+	for yi := 0; yi < rows; yi++ {
+		for xi := 0; xi < cols; xi++ {
+			value := fl.Fitness[yi][xi]
+			peak, valley := true, true
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					ny, nx := yi+dy, xi+dx
+					if (dx == 0 && dy == 0) || ny < 0 || ny >= rows || nx < 0 || nx >= cols {
+						continue
+					}
+					peak = peak && value > fl.Fitness[ny][nx]
+					valley = valley && value < fl.Fitness[ny][nx]
+				}
+			}
+			point := LandscapePoint{X: fl.XValues[xi], Y: fl.YValues[yi], Fitness: value}
+			if peak {
+				fl.Peaks = append(fl.Peaks, point)
+			}
+			if valley {
+				fl.Valleys = append(fl.Valleys, point)
+			}
+		}
+	}
+
+	sort.Slice(fl.Peaks, func(i, j int) bool { return fl.Peaks[i].Fitness > fl.Peaks[j].Fitness })
+	sort.Slice(fl.Valleys, func(i, j int) bool { return fl.Valleys[i].Fitness < fl.Valleys[j].Fitness })
+	if len(fl.Peaks) > maxLandscapeExtrema {
+		fl.Peaks = fl.Peaks[:maxLandscapeExtrema]
+	}
+	if len(fl.Valleys) > maxLandscapeExtrema {
+		fl.Valleys = fl.Valleys[:maxLandscapeExtrema]
+	}
+}
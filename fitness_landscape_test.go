@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFitnessLandscapeShape(t *testing.T) {
+	world := newSteppingTestWorld(85)
+	species := world.AllEntities[0].Species
+	landscape, err := SampleFitnessLandscape(world, FitnessLandscapeRequest{Species: species, XTrait: "speed", YTrait: "size", Resolution: 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(landscape.XValues) != 11 || len(landscape.YValues) != 11 || len(landscape.Fitness) != 11 || len(landscape.Fitness[0]) != 11 {
+		t.Fatalf("Expected an 11x11 landscape, got %dx%d", len(landscape.XValues), len(landscape.YValues))
+	}
+	for _, individual := range landscape.Population {
+		entity := world.findEntityByID(individual.EntityID)
+		if entity == nil || entity.Species != species {
+			t.Fatalf("Expected only %s on the landscape, got %d", species, individual.EntityID)
+		}
+		if individual.X < landscape.XValues[0] || individual.X > landscape.XValues[10] || individual.Y < landscape.YValues[0] || individual.Y > landscape.YValues[10] {
+			t.Errorf("Expected creature %d inside the sampled range, got (%.2f, %.2f)", individual.EntityID, individual.X, individual.Y)
+		}
+	}
+
+	// Speed pays off less the further it goes, so the landscape peaks within the gene range
+	if len(landscape.Peaks) == 0 || landscape.Peaks[0].Fitness != landscape.Max {
+		t.Fatalf("Expected the highest peak to be the maximum, got %+v (max %.3f)", landscape.Peaks, landscape.Max)
+	}
+	if landscape.Centroid.Fitness > landscape.Max+1e-9 || landscape.Centroid.Fitness < landscape.Min-1e-9 {
+		t.Errorf("Expected the centroid within the landscape's range, got %.3f", landscape.Centroid.Fitness)
+	}
+	if math.Abs(math.Hypot(landscape.UphillX, landscape.UphillY)-1) > 1e-9 {
+		t.Errorf("Expected a unit uphill direction, got (%.3f, %.3f)", landscape.UphillX, landscape.UphillY)
+	}
+
+	// Sampling leaves the creatures' genes alone
+	for _, individual := range landscape.Population {
+		entity := world.findEntityByID(individual.EntityID)
+		if entity.Traits["speed"].Value != individual.X || entity.Traits["size"].Value != individual.Y {
+			t.Fatalf("Expected creature %d's genes unchanged by sampling", individual.EntityID)
+		}
+	}
+}
+
+func TestFitnessLandscapeRespondsToEnvironment(t *testing.T) {
+	world := newSteppingTestWorld(86)
+	fillTestBiome(world, BiomeWater)
+	landscape, err := SampleFitnessLandscape(world, FitnessLandscapeRequest{XTrait: "aquatic_adaptation", YTrait: "digging_ability", Resolution: 5, Radius: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range landscape.Fitness {
+		if row[len(row)-1] <= row[0] {
+			t.Fatalf("Expected aquatic adaptation to pay off in a flooded world, got %v", row)
+		}
+		if row[0] != landscape.Fitness[0][0] {
+			t.Fatalf("Expected digging to make no difference underwater")
+		}
+	}
+}
+
+func TestFitnessLandscapeAPI(t *testing.T) {
+	wi := NewWebInterface(newSteppingTestWorld(87))
+
+	rec := httptest.NewRecorder()
+	wi.handleFitnessLandscape(rec, httptest.NewRequest(http.MethodGet, "/api/fitness-landscape?x=strength&y=intelligence&resolution=7", nil))
+	var landscape FitnessLandscape
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &landscape) != nil {
+		t.Fatalf("Sampling the landscape failed: %d %s", rec.Code, rec.Body.String())
+	}
+	if landscape.XTrait != "strength" || len(landscape.Fitness) != 7 || len(landscape.Population) == 0 {
+		t.Errorf("Expected a 7x7 strength landscape with the population on it, got %+v", landscape)
+	}
+
+	for _, query := range []string{"x=hive_id", "x=speed&y=speed", "resolution=500", "species=dragon", "radius=abc"} {
+		rec = httptest.NewRecorder()
+		wi.handleFitnessLandscape(rec, httptest.NewRequest(http.MethodGet, "/api/fitness-landscape?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected %s to be rejected, got %d", query, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"math"
+	"sync"
+)
+
+// Creature perception ranges for fog of war, in world units
+const (
+	fogBaseSightRange   = 4.0  // Every creature sees this far
+	fogVisionSightRange = 10.0 // Added on top at a vision trait of 1
+)
+
+// Fog states of a grid cell as seen by a player
+const (
+	FogVisible    = ""           // Currently perceived by one of the player's creatures
+	FogRemembered = "remembered" // Seen before; terrain is known but not what is there now
+	FogHidden     = "hidden"     // Never seen
+)
+
+// FogOfWarSystem tracks which grid cells each player's species have ever perceived.
+// It only has an effect when WorldConfig.FogOfWar is set.
+type FogOfWarSystem struct {
+	mu       sync.Mutex
+	explored map[string]map[GridPoint]bool // Player ID -> cells their creatures have seen
+}
+
+// NewFogOfWarSystem creates a fog of war system with nothing explored
+func NewFogOfWarSystem() *FogOfWarSystem {
+	return &FogOfWarSystem{explored: make(map[string]map[GridPoint]bool)}
+}
+
+// Visibility returns the fog state of every cell the player has seen: cells perceived by a
+// living creature of one of their species right now are FogVisible, previously seen cells
+// are FogRemembered. Cells missing from the result are FogHidden.
+func (fs *FogOfWarSystem) Visibility(w *World, playerID string, species []string) map[GridPoint]string {
+	owned := make(map[string]bool, len(species))
+	for _, name := range species {
+		owned[name] = true
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	explored := fs.explored[playerID]
+	if explored == nil {
+		explored = make(map[GridPoint]bool)
+		fs.explored[playerID] = explored
+	}
+
+	visibility := make(map[GridPoint]string, len(explored))
+	for cell := range explored {
+		visibility[cell] = FogRemembered
+	}
+	layout := w.GridLayout()
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive || !owned[entity.Species] {
+			continue
+		}
+		// Sight reaches across the joined edges of a map that wraps
+		sight := perceptionRange(entity)
+		low := layout.CellAt(Position{X: entity.Position.X - sight, Y: entity.Position.Y - sight})
+		high := layout.CellAt(Position{X: entity.Position.X + sight, Y: entity.Position.Y + sight})
+		ownX, ownY := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
+		for y := low.Y; y <= high.Y; y++ {
+			for x := low.X; x <= high.X; x++ {
+				cellX, cellY, onMap := w.wrapGridCell(x, y)
+				if !onMap || (w.Distance(entity.Position, w.CellCenter(cellX, cellY)) > sight && (cellX != ownX || cellY != ownY)) {
+					continue
+				}
+				cell := GridPoint{X: cellX, Y: cellY}
+				visibility[cell] = FogVisible
+				explored[cell] = true
+			}
+		}
+	}
+	return visibility
+}
+
+// Forget drops everything a player has explored
+func (fs *FogOfWarSystem) Forget(playerID string) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	delete(fs.explored, playerID)
+}
+
+// Clear drops what every player has explored, used when the world is reset
+func (fs *FogOfWarSystem) Clear() {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.explored = make(map[string]map[GridPoint]bool)
+}
+
+// perceptionRange is how far a creature can see, in world units
+func perceptionRange(entity *Entity) float64 {
+	return fogBaseSightRange + math.Max(0, math.Min(1, entity.GetTrait("vision")))*fogVisionSightRange
+}
+
+// applyFogOfWar returns a copy of the view data with the grid limited to what a player can
+// see. Remembered cells keep their terrain; hidden cells reveal nothing.
+func applyFogOfWar(data *ViewData, visibility map[GridPoint]string) *ViewData {
+	fogged := *data
+	fogged.Grid = make([][]CellData, len(data.Grid))
+	for y, row := range data.Grid {
+		fogged.Grid[y] = make([]CellData, len(row))
+		for x, cell := range row {
+			fogged.Grid[y][x] = fogCell(cell, visibility)
+		}
+	}
+	return &fogged
+}
+
+// fogCell hides what a player cannot see of a cell
+func fogCell(cell CellData, visibility map[GridPoint]string) CellData {
+	state, seen := visibility[GridPoint{X: cell.GridX, Y: cell.GridY}]
+	switch {
+	case !seen:
+		return CellData{X: cell.X, Y: cell.Y, GridX: cell.GridX, GridY: cell.GridY, Fog: FogHidden}
+	case state == FogRemembered:
+		return CellData{
+			X: cell.X, Y: cell.Y, GridX: cell.GridX, GridY: cell.GridY,
+			Biome: cell.Biome, BiomeSymbol: cell.BiomeSymbol, BiomeColor: cell.BiomeColor,
+			Fog: FogRemembered,
+		}
+	}
+	return cell
+}
@@ -0,0 +1,69 @@
+package main
+
+import "testing"
+
+func TestFogOfWarVisibilityAndMemory(t *testing.T) {
+	// 10x10 grid over a 50x50 world: cells are 5 units wide
+	scout := &Entity{ID: 1, Species: "mine", Position: Position{X: 7.5, Y: 7.5}}
+	rival := &Entity{ID: 2, Species: "theirs", Position: Position{X: 42.5, Y: 42.5}}
+	world := newGroupTestWorld(scout, rival)
+
+	visibility := world.FogOfWar.Visibility(world, "p1", []string{"mine"})
+	if state, seen := visibility[GridPoint{X: 1, Y: 1}]; !seen || state != FogVisible {
+		t.Fatalf("Expected the scout's own cell to be visible, got %q", state)
+	}
+	if _, seen := visibility[GridPoint{X: 8, Y: 8}]; seen {
+		t.Error("Expected the rival's distant cell to stay hidden")
+	}
+	if _, seen := visibility[GridPoint{X: 3, Y: 1}]; seen {
+		t.Error("Expected cells beyond the scout's sight to stay hidden")
+	}
+
+	// Better eyes see further
+	scout.Traits["vision"] = Trait{Name: "vision", Value: 1}
+	if visibility = world.FogOfWar.Visibility(world, "p1", []string{"mine"}); visibility[GridPoint{X: 3, Y: 1}] != FogVisible {
+		t.Error("Expected a high-vision scout to see two cells away")
+	}
+
+	// Leaving an area leaves it remembered
+	scout.Position = Position{X: 37.5, Y: 37.5}
+	visibility = world.FogOfWar.Visibility(world, "p1", []string{"mine"})
+	if state, seen := visibility[GridPoint{X: 1, Y: 1}]; !seen || state != FogRemembered {
+		t.Errorf("Expected the scout's old cell to be remembered, got %q", state)
+	}
+	if visibility[GridPoint{X: 8, Y: 8}] != FogVisible {
+		t.Error("Expected the scout to see the rival's cell now")
+	}
+
+	// Other players have explored nothing
+	if len(world.FogOfWar.Visibility(world, "p2", nil)) != 0 {
+		t.Error("Expected a player without species to see nothing")
+	}
+	world.FogOfWar.Forget("p1")
+	scout.IsAlive = false
+	if len(world.FogOfWar.Visibility(world, "p1", []string{"mine"})) != 0 {
+		t.Error("Expected forgetting to drop remembered cells")
+	}
+}
+
+func TestApplyFogOfWarHidesCells(t *testing.T) {
+	cell := func(x int) CellData {
+		return CellData{X: x, GridX: x, Biome: "Forest", BiomeSymbol: "♣", EntityCount: 2, EntitySymbol: "H", PlantCount: 1, HasEvent: true}
+	}
+	data := &ViewData{Tick: 5, Grid: [][]CellData{{cell(0), cell(1), cell(2)}}}
+
+	fogged := applyFogOfWar(data, map[GridPoint]string{{X: 0, Y: 0}: FogVisible, {X: 1, Y: 0}: FogRemembered})
+	row := fogged.Grid[0]
+	if row[0] != data.Grid[0][0] {
+		t.Errorf("Expected visible cells unchanged, got %+v", row[0])
+	}
+	if row[1].Fog != FogRemembered || row[1].Biome != "Forest" || row[1].EntityCount != 0 || row[1].PlantCount != 0 || row[1].HasEvent {
+		t.Errorf("Expected remembered cells to keep only terrain, got %+v", row[1])
+	}
+	if row[2].Fog != FogHidden || row[2].Biome != "" || row[2].EntityCount != 0 || row[2].GridX != 2 {
+		t.Errorf("Expected hidden cells to reveal nothing but their position, got %+v", row[2])
+	}
+	if data.Grid[0][2].Fog != "" || data.Grid[0][2].EntityCount != 2 || fogged.Tick != 5 {
+		t.Error("Expected the shared view data to be left untouched")
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// followFrames is how many ticks of a followed creature's life are kept
+const followFrames = 50
+
+// followTrail is how many of the cells a followed creature passed through lately are kept
+// as breadcrumbs
+const followTrail = 40
+
+// neuralInputNames name the inputs createEnvironmentalInputs senses, in order
+var neuralInputNames = []string{"vision", "energy", "threat", "food", "social"}
+
+// FollowTrack is what a followed creature has done lately, tick by tick
+type FollowTrack struct {
+	EntityID  int           `json:"entity_id"`
+	Species   string        `json:"species"`
+	Alive     bool          `json:"alive"`
+	StartTick int           `json:"start_tick"` // When the first camera locked onto it
+	Frames    []FollowFrame `json:"frames"`     // Newest last
+	Trail     []GridPoint   `json:"trail"`      // Cells it passed through lately, oldest first
+}
+
+// FollowFrame is one tick of a followed creature's life
+type FollowFrame struct {
+	Tick         int                 `json:"tick"`
+	Position     Position            `json:"position"`
+	Energy       float64             `json:"energy"`
+	EnergyChange float64             `json:"energy_change"` // Since the frame before
+	Activity     string              `json:"activity"`      // What its biorhythm has it doing
+	Decision     *FollowDecision     `json:"decision,omitempty"`
+	Interactions []FollowInteraction `json:"interactions"`
+}
+
+// FollowDecision is a decision the creature's neural network made and how it got there
+type FollowDecision struct {
+	Inputs      map[string]float64 `json:"inputs"`      // What it sensed, by name
+	Move        Position           `json:"move"`        // Direction it chose, each -1 to 1
+	Intensity   float64            `json:"intensity"`   // How hard it acted on it, 0-1
+	Activations [][]float64        `json:"activations"` // Neuron values layer by layer, input to output
+}
+
+// FollowInteraction is something that happened between the creature and the world
+type FollowInteraction struct {
+	Kind        string `json:"kind"` // ate, or the type of the event it took part in
+	Description string `json:"description"`
+}
+
+// FollowSystem records what followed creatures do each tick, for cameras locked onto them.
+// Only creatures some camera follows are recorded.
+type FollowSystem struct {
+	watchers map[int]int // Entity ID -> cameras following it
+	tracks   map[int]*FollowTrack
+	pending  map[int]*FollowDecision // Decisions made this tick, before its frame is recorded
+}
+
+// NewFollowSystem creates a follow system with nobody followed
+func NewFollowSystem() *FollowSystem {
+	return &FollowSystem{
+		watchers: make(map[int]int),
+		tracks:   make(map[int]*FollowTrack),
+		pending:  make(map[int]*FollowDecision),
+	}
+}
+
+// Follow starts a camera following a living creature
+func (fs *FollowSystem) Follow(w *World, id int) error {
+	entity := w.findEntityByID(id)
+	if entity == nil || !entity.IsAlive {
+		return fmt.Errorf("no living entity %d", id)
+	}
+	fs.watchers[id]++
+	if fs.tracks[id] == nil {
+		fs.tracks[id] = &FollowTrack{EntityID: id, Species: entity.Species, Alive: true, StartTick: w.Tick,
+			Frames: make([]FollowFrame, 0), Trail: make([]GridPoint, 0)}
+		fs.recordFrame(w, entity, fs.tracks[id])
+	}
+	return nil
+}
+
+// Unfollow stops a camera following a creature, forgetting its track once no camera follows it
+func (fs *FollowSystem) Unfollow(id int) {
+	if fs.watchers[id] == 0 {
+		return
+	}
+	fs.watchers[id]--
+	if fs.watchers[id] == 0 {
+		delete(fs.watchers, id)
+		delete(fs.tracks, id)
+		delete(fs.pending, id)
+	}
+}
+
+// Clear stops following every creature
+func (fs *FollowSystem) Clear() {
+	clear(fs.watchers)
+	clear(fs.tracks)
+	clear(fs.pending)
+}
+
+// Followed reports whether any camera follows a creature
+func (fs *FollowSystem) Followed(id int) bool {
+	return fs.watchers[id] > 0
+}
+
+// Track is a copy of a followed creature's track, or nil if nobody follows it
+func (fs *FollowSystem) Track(id int) *FollowTrack {
+	track := fs.tracks[id]
+	if track == nil {
+		return nil
+	}
+	copied := *track
+	copied.Frames = slices.Clone(track.Frames)
+	copied.Trail = slices.Clone(track.Trail)
+	return &copied
+}
+
+// recordDecision keeps a followed creature's neural decision for this tick's frame
+func (fs *FollowSystem) recordDecision(entityID int, network *EntityNeuralNetwork, inputs, outputs []float64) {
+	if !fs.Followed(entityID) || len(outputs) < 3 {
+		return
+	}
+	decision := &FollowDecision{
+		Inputs:    make(map[string]float64, len(inputs)),
+		Move:      Position{X: outputs[0], Y: outputs[1]},
+		Intensity: math.Max(0, math.Min(1, outputs[2])),
+	}
+	for i, value := range inputs {
+		if i < len(neuralInputNames) {
+			decision.Inputs[neuralInputNames[i]] = value
+		}
+	}
+	layers := append([][]int{network.InputNeurons}, network.HiddenLayers...)
+	for _, layer := range append(layers, network.OutputNeurons) {
+		values := make([]float64, 0, len(layer))
+		for _, id := range layer {
+			if neuron := network.Neurons[id]; neuron != nil {
+				values = append(values, neuron.Value)
+			}
+		}
+		decision.Activations = append(decision.Activations, values)
+	}
+	fs.pending[entityID] = decision
+}
+
+// Update records a frame for each followed creature at the end of a tick
+func (fs *FollowSystem) Update(w *World) {
+	for _, id := range sortedKeys(fs.tracks) {
+		track := fs.tracks[id]
+		if !track.Alive {
+			continue
+		}
+		entity := w.findEntityByID(id)
+		if entity == nil || !entity.IsAlive {
+			track.Alive = false
+			continue
+		}
+		fs.recordFrame(w, entity, track)
+	}
+	clear(fs.pending)
+}
+
+// recordFrame adds the creature's state this tick to its track
+func (fs *FollowSystem) recordFrame(w *World, entity *Entity, track *FollowTrack) {
+	frame := FollowFrame{
+		Tick:         w.Tick,
+		Position:     entity.Position,
+		Energy:       entity.Energy,
+		Activity:     "Unknown",
+		Decision:     fs.pending[entity.ID],
+		Interactions: make([]FollowInteraction, 0),
+	}
+	if n := len(track.Frames); n > 0 {
+		frame.EnergyChange = entity.Energy - track.Frames[n-1].Energy
+	}
+	if entity.BioRhythm != nil {
+		frame.Activity = entity.BioRhythm.String()
+	}
+	if memory := entity.DietaryMemory; memory != nil {
+		for _, meal := range memory.ConsumptionHistory {
+			if meal.Tick == w.Tick {
+				frame.Interactions = append(frame.Interactions, FollowInteraction{
+					Kind: "ate", Description: fmt.Sprintf("Ate %s %s for %.1f energy", meal.FoodType, meal.FoodID, meal.Nutrition)})
+			}
+		}
+	}
+	if w.CentralEventBus != nil {
+		for _, event := range w.CentralEventBus.GetEventsByTick(w.Tick) {
+			if event.EntityID == entity.ID || slices.Contains(event.ImpactedIDs, entity.ID) {
+				frame.Interactions = append(frame.Interactions, FollowInteraction{Kind: event.Type, Description: event.Description})
+			}
+		}
+	}
+	track.Frames = append(track.Frames, frame)
+	if len(track.Frames) > followFrames {
+		track.Frames = track.Frames[len(track.Frames)-followFrames:]
+	}
+
+	x, y := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
+	if cell := (GridPoint{X: x, Y: y}); len(track.Trail) == 0 || track.Trail[len(track.Trail)-1] != cell {
+		track.Trail = append(track.Trail, cell)
+		if len(track.Trail) > followTrail {
+			track.Trail = track.Trail[len(track.Trail)-followTrail:]
+		}
+	}
+}
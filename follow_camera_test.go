@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// thinkingEntity is a living creature clever enough for its neural network to steer it
+func thinkingEntity(t *testing.T, world *World) *Entity {
+	for _, entity := range world.AllEntities {
+		if entity.IsAlive && entity.GetTrait("intelligence") > 0.3 {
+			return entity
+		}
+	}
+	t.Fatal("Expected a creature with a neural network")
+	return nil
+}
+
+func TestFollowSystem(t *testing.T) {
+	world := newSteppingTestWorld(127)
+	if err := world.Follow.Follow(world, -1); err == nil {
+		t.Error("Expected following a creature that doesn't exist refused")
+	}
+
+	entity := thinkingEntity(t, world)
+	if err := world.Follow.Follow(world, entity.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := world.Step(10); err != nil {
+		t.Fatal(err)
+	}
+	track := world.Follow.Track(entity.ID)
+	if track == nil || len(track.Frames) == 0 || len(track.Trail) == 0 {
+		t.Fatalf("Expected the followed creature's ticks recorded, got %+v", track)
+	}
+	if !entity.IsAlive {
+		t.Skip("The followed creature died")
+	}
+	latest := track.Frames[len(track.Frames)-1]
+	if latest.Tick != world.Tick || latest.Energy != entity.Energy {
+		t.Errorf("Expected the latest frame at tick %d with %.1f energy, got %+v", world.Tick, entity.Energy, latest)
+	}
+	x, y := world.worldToGridCoords(entity.Position.X, entity.Position.Y)
+	if track.Trail[len(track.Trail)-1] != (GridPoint{X: x, Y: y}) {
+		t.Errorf("Expected the breadcrumbs to end where the creature stands, got %v", track.Trail)
+	}
+
+	var decision *FollowDecision
+	for _, frame := range track.Frames {
+		if frame.Decision != nil {
+			decision = frame.Decision
+		}
+	}
+	if decision == nil {
+		t.Fatal("Expected the creature's neural decisions recorded")
+	}
+	if len(decision.Inputs) != len(neuralInputNames) || len(decision.Activations) < 2 ||
+		len(decision.Activations[0]) != len(neuralInputNames) || len(decision.Activations[len(decision.Activations)-1]) != 3 {
+		t.Errorf("Expected the network's senses and each layer's activations, got %+v", decision)
+	}
+
+	// A track is kept while any camera follows the creature
+	if err := world.Follow.Follow(world, entity.ID); err != nil {
+		t.Fatal(err)
+	}
+	world.Follow.Unfollow(entity.ID)
+	if !world.Follow.Followed(entity.ID) {
+		t.Error("Expected the creature still followed by the other camera")
+	}
+	world.Follow.Unfollow(entity.ID)
+	if world.Follow.Followed(entity.ID) || world.Follow.Track(entity.ID) != nil {
+		t.Error("Expected the track forgotten once no camera follows the creature")
+	}
+
+	// A creature that dies keeps its track, which stops growing
+	if err := world.Follow.Follow(world, entity.ID); err != nil {
+		t.Fatal(err)
+	}
+	entity.IsAlive = false
+	world.Follow.Update(world)
+	world.Follow.Update(world)
+	if track := world.Follow.Track(entity.ID); track.Alive || len(track.Frames) != 1 {
+		t.Errorf("Expected the dead creature's track closed, got %+v", track)
+	}
+}
+
+func TestWebFollowCamera(t *testing.T) {
+	world := newSteppingTestWorld(128)
+	wi := NewWebInterface(world)
+	wi.setZoomLevel(4)
+	entity := thinkingEntity(t, world)
+
+	follow := func(method, body string) FollowState {
+		rec := httptest.NewRecorder()
+		wi.Routes().ServeHTTP(rec, httptest.NewRequest(method, "/api/follow", strings.NewReader(body)))
+		var state FollowState
+		if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &state) != nil {
+			t.Fatalf("Expected %s /api/follow to succeed, got %d %s", method, rec.Code, rec.Body.String())
+		}
+		return state
+	}
+	body, _ := json.Marshal(FollowRequest{EntityID: entity.ID})
+	if state := follow(http.MethodPost, string(body)); state.EntityID != entity.ID || state.Track == nil {
+		t.Fatalf("Expected the camera locked onto entity %d, got %+v", entity.ID, state)
+	}
+	rec := httptest.NewRecorder()
+	wi.Routes().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/follow", bytes.NewReader([]byte(`{"entity_id": -1}`))))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected following a missing creature refused, got %d", rec.Code)
+	}
+
+	// Each frame centres the viewport on the creature and carries its track
+	<-wi.broadcastChan
+	if _, err := world.Step(5); err != nil {
+		t.Fatal(err)
+	}
+	wi.sendFrame()
+	frame := <-wi.broadcastChan
+	if frame.Follow == nil || frame.Follow.EntityID != entity.ID {
+		t.Fatalf("Expected the frame to carry the followed creature's track, got %+v", frame.Follow)
+	}
+	if entity.IsAlive {
+		x, y := world.worldToGridCoords(entity.Position.X, entity.Position.Y)
+		width, height := world.Config.GridWidth/4, world.Config.GridHeight/4
+		if x < frame.ViewportX || x >= frame.ViewportX+width || y < frame.ViewportY || y >= frame.ViewportY+height {
+			t.Errorf("Expected the followed creature at %d,%d in the viewport at %d,%d", x, y, frame.ViewportX, frame.ViewportY)
+		}
+	}
+
+	if state := follow(http.MethodGet, ""); state.EntityID != entity.ID {
+		t.Errorf("Expected the camera to report what it follows, got %+v", state)
+	}
+	if state := follow(http.MethodDelete, ""); state.EntityID != 0 || world.Follow.Followed(entity.ID) {
+		t.Errorf("Expected the camera to move freely again, got %+v", state)
+	}
+}
+
+func TestCLIFollowCamera(t *testing.T) {
+	world := newSteppingTestWorld(129)
+	model := NewCLIModel(world)
+	pressF := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")}
+
+	updated, _ := model.Update(pressF)
+	model = updated.(CLIModel)
+	if !model.followEntity || model.selectedEntity == nil || !world.Follow.Followed(model.selectedEntity.ID) {
+		t.Fatal("Expected f to follow the creature nearest the middle of the grid")
+	}
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(CLIModel)
+	if view := model.gridView(); !strings.Contains(view, "Following") && !strings.Contains(view, "Followed") {
+		t.Error("Expected the grid view to stream the followed creature")
+	}
+
+	followed := model.selectedEntity.ID
+	updated, _ = model.Update(pressF)
+	model = updated.(CLIModel)
+	if model.followEntity || world.Follow.Followed(followed) {
+		t.Error("Expected f again to stop following")
+	}
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Food storage parameters
+const (
+	foodStorageInterval = 10   // Ticks between spoilage and ration checks
+	baseSpoilage        = 0.02 // Share of stored food lost per update at mild, moderately humid conditions
+	maxSpoilage         = 0.5  // Most of a store lost in one update
+	mildAmbient         = 0.5  // Ambient temperature at which the season neither speeds nor slows spoilage
+	memberRation        = 5.0  // Food a colonist eats per update
+	famineCover         = 1.0  // Stores covering fewer rations than this mean famine
+	surplusCover        = 4.0  // Stores covering this many rations mean surplus
+	famineHunger        = 3.0  // Energy a colonist loses per update in the depths of famine
+)
+
+// Food store statuses
+const (
+	FoodStatusFamine  = "famine"
+	FoodStatusStable  = "stable"
+	FoodStatusSurplus = "surplus"
+)
+
+// PreservationMethod is a way of keeping stored food that a tribe learns at a tech level
+type PreservationMethod struct {
+	Name      string
+	TechLevel int
+	Dryness   float64 // Share of humidity's effect on spoilage taken away
+	Slowing   float64 // Share of all spoilage taken away
+	ColdStore float64 // Highest temperature factor stores are kept at, or 0 for none
+}
+
+// preservationMethods are listed in the order tribes discover them
+var preservationMethods = []PreservationMethod{
+	{Name: "drying", TechLevel: 2, Dryness: 0.8},
+	{Name: "smoking", TechLevel: 3, Slowing: 0.4},
+	{Name: "cold_storage", TechLevel: 5, ColdStore: 0.5},
+}
+
+// spoilageRate is the share of a store lost per update at a storage temperature (-1 cold to
+// 1 hot, and beyond in a heat wave) and humidity (0-1), kept with the given methods
+func spoilageRate(temperature, humidity float64, methods []PreservationMethod) float64 {
+	warmth := math.Max(0.2, math.Min(3, 1+temperature))
+	damp := humidity
+	slowing := 0.0
+	for _, method := range methods {
+		damp *= 1 - method.Dryness
+		slowing = math.Max(slowing, method.Slowing)
+		if method.ColdStore > 0 {
+			warmth = math.Min(warmth, method.ColdStore)
+		}
+	}
+	return math.Min(maxSpoilage, baseSpoilage*warmth*(0.5+damp)*(1-slowing))
+}
+
+// knownPreservation lists the methods a tribe of a tech level has discovered
+func knownPreservation(techLevel int) []PreservationMethod {
+	known := make([]PreservationMethod, 0)
+	for _, method := range preservationMethods {
+		if techLevel >= method.TechLevel {
+			known = append(known, method)
+		}
+	}
+	return known
+}
+
+// TribeFoodStore is the state of one tribe's food stores
+type TribeFoodStore struct {
+	TribeID      int      `json:"tribe_id"`
+	Name         string   `json:"name"`
+	TechLevel    int      `json:"tech_level"`
+	Preservation []string `json:"preservation"`
+	Stored       float64  `json:"stored"` // Colony stores and cache contents together
+	Cover        float64  `json:"cover"`  // Updates of rations the stores would last
+	Spoilage     float64  `json:"spoilage"`
+	Spoiled      float64  `json:"spoiled"` // Total food lost to spoilage
+	Status       string   `json:"status"`
+	Famines      int      `json:"famines"`
+	FamineTicks  int      `json:"famine_ticks"`
+}
+
+// FoodStorageReport lists every tribe's stores, hungriest first, and the food spoiling in
+// creatures' own caches
+type FoodStorageReport struct {
+	Tick        int              `json:"tick"`
+	Tribes      []TribeFoodStore `json:"tribes"`
+	CachedWild  float64          `json:"cached_wild"` // Food held in creatures' dug caches
+	SpoiledWild float64          `json:"spoiled_wild"`
+	InFamine    int              `json:"in_famine"`
+	WithSurplus int              `json:"with_surplus"`
+	Discoveries int              `json:"discoveries"` // Preservation methods discovered across tribes
+}
+
+// FoodStorageSystem spoils stored food according to where and how it is kept, and lets
+// famines and surpluses follow from what is left
+type FoodStorageSystem struct {
+	tribes      map[int]*TribeFoodStore
+	spoiledWild float64
+	discoveries int
+}
+
+// NewFoodStorageSystem creates a system with no stores tracked yet
+func NewFoodStorageSystem() *FoodStorageSystem {
+	return &FoodStorageSystem{tribes: make(map[int]*TribeFoodStore)}
+}
+
+// storageConditions are the temperature and humidity food is kept at in a position: its
+// biome's, shifted by the season and tempered by the microclimate
+func (w *World) storageConditions(pos Position) (temperature, humidity float64) {
+	x, y := w.worldToGridCoords(pos.X, pos.Y)
+	biome := w.Biomes[w.Grid[y][x].Biome]
+	temperature = w.localTemperature(pos, biome.Temperature)
+	if w.AdvancedTimeSystem != nil {
+		temperature += w.AdvancedTimeSystem.Temperature - mildAmbient
+	}
+	return temperature, w.Microclimates.At(x, y).humidity(biome.Humidity)
+}
+
+// Update spoils every store and settles each tribe's famine or surplus every
+// foodStorageInterval ticks
+func (fss *FoodStorageSystem) Update(w *World) {
+	if w.Tick%foodStorageInterval != 0 {
+		return
+	}
+	if w.CivilizationSystem != nil {
+		for _, tribe := range w.CivilizationSystem.Tribes {
+			if len(tribe.Members) > 0 {
+				fss.updateTribe(w, tribe)
+			}
+		}
+	}
+
+	// Creatures' caches spoil with no preservation at all
+	if w.EnvironmentalModSystem != nil {
+		for _, id := range sortedKeys(w.EnvironmentalModSystem.Modifications) {
+			cache := w.EnvironmentalModSystem.Modifications[id]
+			if !cache.IsActive || cache.Type != EnvModCache || cache.Properties["stored_resources"] <= 0 {
+				continue
+			}
+			temperature, humidity := w.storageConditions(cache.Position)
+			spoiled := cache.Properties["stored_resources"] * spoilageRate(temperature, humidity, nil)
+			cache.Properties["stored_resources"] -= spoiled
+			fss.spoiledWild += spoiled
+		}
+	}
+}
+
+// updateTribe spoils a tribe's colony stores and caches, then lets its members go hungry if
+// what is left would not last a round of rations
+func (fss *FoodStorageSystem) updateTribe(w *World, tribe *Tribe) {
+	record := fss.tribes[tribe.ID]
+	if record == nil {
+		record = &TribeFoodStore{TribeID: tribe.ID, Status: FoodStatusStable, Preservation: make([]string, 0)}
+		fss.tribes[tribe.ID] = record
+	}
+	methods := knownPreservation(tribe.TechLevel)
+	for len(record.Preservation) < len(methods) {
+		method := methods[len(record.Preservation)]
+		record.Preservation = append(record.Preservation, method.Name)
+		fss.discoveries++
+		w.logFoodEvent("food_preservation", fmt.Sprintf("Tribe %s has learned to preserve food by %s", tribe.Name, method.Name))
+	}
+	record.Name, record.TechLevel = tribe.Name, tribe.TechLevel
+
+	centerX, centerY := 0.0, 0.0
+	for _, member := range tribe.Members {
+		centerX += member.Position.X
+		centerY += member.Position.Y
+	}
+	temperature, humidity := w.storageConditions(Position{X: centerX / float64(len(tribe.Members)), Y: centerY / float64(len(tribe.Members))})
+	record.Spoilage = spoilageRate(temperature, humidity, methods)
+
+	spoiled := tribe.Resources["food"] * record.Spoilage
+	tribe.Resources["food"] -= spoiled
+	stored := tribe.Resources["food"]
+	for _, structure := range tribe.Structures {
+		if structure.Type != StructureCache || structure.Resources["food"] <= 0 {
+			continue
+		}
+		temperature, humidity := w.storageConditions(structure.Position)
+		lost := structure.Resources["food"] * spoilageRate(temperature, humidity, methods)
+		structure.Resources["food"] -= lost
+		spoiled += lost
+		stored += structure.Resources["food"]
+	}
+	record.Spoiled += spoiled
+	record.Stored = stored
+	record.Cover = stored / (memberRation * float64(len(tribe.Members)))
+
+	status := FoodStatusStable
+	if record.Cover < famineCover {
+		status = FoodStatusFamine
+	} else if record.Cover >= surplusCover {
+		status = FoodStatusSurplus
+	}
+	if status != record.Status {
+		if record.Status == FoodStatusFamine {
+			w.logFoodEvent("famine_ended", fmt.Sprintf("The famine in tribe %s has ended", tribe.Name))
+		}
+		switch status {
+		case FoodStatusFamine:
+			record.Famines++
+			w.logFoodEvent("famine", fmt.Sprintf("Famine has struck tribe %s, its stores down to %.1f", tribe.Name, stored))
+		case FoodStatusSurplus:
+			w.logFoodEvent("food_surplus", fmt.Sprintf("Tribe %s has built up a food surplus of %.1f", tribe.Name, stored))
+		}
+		record.Status = status
+	}
+
+	// Hunger deepens the further stores fall short of a round of rations
+	if status == FoodStatusFamine {
+		record.FamineTicks += foodStorageInterval
+		hunger := famineHunger * (1 - record.Cover/famineCover)
+		for _, member := range tribe.Members {
+			if member.IsAlive {
+				member.Energy -= hunger
+			}
+		}
+	}
+}
+
+// logFoodEvent records a food storage milestone in the event log
+func (w *World) logFoodEvent(name, description string) {
+	if w.EventLogger != nil {
+		w.EventLogger.LogWorldEvent(w.Tick, name, description)
+	}
+}
+
+// Report lists every tribe's food stores, hungriest first, with the state of creatures' caches
+func (fss *FoodStorageSystem) Report(w *World) FoodStorageReport {
+	report := FoodStorageReport{
+		Tick:        w.Tick,
+		Tribes:      make([]TribeFoodStore, 0),
+		SpoiledWild: fss.spoiledWild,
+		Discoveries: fss.discoveries,
+	}
+	for _, id := range sortedKeys(fss.tribes) {
+		record := *fss.tribes[id]
+		report.Tribes = append(report.Tribes, record)
+		switch record.Status {
+		case FoodStatusFamine:
+			report.InFamine++
+		case FoodStatusSurplus:
+			report.WithSurplus++
+		}
+	}
+	sort.SliceStable(report.Tribes, func(i, j int) bool { return report.Tribes[i].Cover < report.Tribes[j].Cover })
+	if w.EnvironmentalModSystem != nil {
+		for _, id := range sortedKeys(w.EnvironmentalModSystem.Modifications) {
+			if cache := w.EnvironmentalModSystem.Modifications[id]; cache.IsActive && cache.Type == EnvModCache {
+				report.CachedWild += cache.Properties["stored_resources"]
+			}
+		}
+	}
+	return report
+}
+
+// Clear forgets every tribe's stores and past spoilage, as when a new world is loaded
+func (fss *FoodStorageSystem) Clear() {
+	fss.tribes = make(map[int]*TribeFoodStore)
+	fss.spoiledWild = 0
+	fss.discoveries = 0
+}
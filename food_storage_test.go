@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newFoodStorageTestWorld makes a 100x100 world of one biome at mild ambient temperature,
+// with a five-member tribe holding the given food at (50, 50)
+func newFoodStorageTestWorld(biome BiomeType, food float64) (*World, *Tribe) {
+	world := newEmptyTestWorld(100)
+	world.AdvancedTimeSystem.Temperature = mildAmbient
+	fillTestBiome(world, biome)
+
+	tribe := addTestTribe(world, "Saltmarrow", 5, Position{X: 50, Y: 50})
+	for _, member := range tribe.Members {
+		member.Energy = 100
+	}
+	tribe.Resources["food"] = food
+	return world, tribe
+}
+
+// runFoodStorage advances the food storage system by a number of updates
+func runFoodStorage(world *World, updates int) {
+	runSystem(world, foodStorageInterval, updates, world.FoodStorage.Update)
+}
+
+// foodEventLogged reports whether a food storage event of a name has been logged
+func foodEventLogged(world *World, name string) bool {
+	for _, event := range world.EventLogger.GetEventsByType(EventWorldEvent) {
+		if event.Data["event_name"] == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSpoilageFollowsTemperatureAndHumidity(t *testing.T) {
+	if spoilageRate(0.6, 1.0, nil) <= spoilageRate(-0.7, 0.6, nil) {
+		t.Errorf("Expected food to rot faster in hot, wet air than in the cold")
+	}
+	raw := spoilageRate(0.6, 1.0, nil)
+	for _, method := range preservationMethods {
+		if spoilageRate(0.6, 1.0, []PreservationMethod{method}) >= raw {
+			t.Errorf("Expected %s to slow spoilage", method.Name)
+		}
+	}
+
+	jungle, jungleTribe := newFoodStorageTestWorld(BiomeRainforest, 100)
+	tundra, tundraTribe := newFoodStorageTestWorld(BiomeTundra, 100)
+	cache := NewStructure(1, StructureCache, Position{X: 55, Y: 55}, jungleTribe.Leader)
+	cache.Resources["food"] = 50
+	jungleTribe.Structures = append(jungleTribe.Structures, cache)
+	runFoodStorage(jungle, 10)
+	runFoodStorage(tundra, 10)
+
+	if jungleTribe.Resources["food"] >= tundraTribe.Resources["food"] {
+		t.Errorf("Expected rainforest stores to spoil faster than tundra stores, got %.1f and %.1f",
+			jungleTribe.Resources["food"], tundraTribe.Resources["food"])
+	}
+	if cache.Resources["food"] >= 50 {
+		t.Errorf("Expected food in the tribe's cache to spoil as well")
+	}
+}
+
+func TestPreservationTechnologySavesStores(t *testing.T) {
+	primitive, primitiveTribe := newFoodStorageTestWorld(BiomeRainforest, 100)
+	advanced, advancedTribe := newFoodStorageTestWorld(BiomeRainforest, 100)
+	advancedTribe.TechLevel = 5
+	runFoodStorage(primitive, 10)
+	runFoodStorage(advanced, 10)
+
+	if advancedTribe.Resources["food"] <= primitiveTribe.Resources["food"] {
+		t.Errorf("Expected preservation to keep more food, got %.1f against %.1f",
+			advancedTribe.Resources["food"], primitiveTribe.Resources["food"])
+	}
+	report := advanced.FoodStorage.Report(advanced)
+	if len(report.Tribes[0].Preservation) != len(preservationMethods) || report.Discoveries != len(preservationMethods) {
+		t.Errorf("Expected every preservation method discovered at tech level 5, got %+v", report.Tribes[0])
+	}
+	if !foodEventLogged(advanced, "food_preservation") || foodEventLogged(primitive, "food_preservation") {
+		t.Errorf("Expected only the advanced tribe's discoveries logged")
+	}
+}
+
+func TestFaminesAndSurplusesEmerge(t *testing.T) {
+	world, tribe := newFoodStorageTestWorld(BiomePlains, 10)
+	runFoodStorage(world, 1)
+
+	record := world.FoodStorage.Report(world).Tribes[0]
+	if record.Status != FoodStatusFamine || record.Famines != 1 || !foodEventLogged(world, "famine") {
+		t.Fatalf("Expected scant stores to bring famine, got %+v", record)
+	}
+	if tribe.Leader.Energy >= 100 {
+		t.Errorf("Expected famine to leave the tribe hungry")
+	}
+
+	tribe.Resources["food"] = 500
+	runFoodStorage(world, 1)
+	record = world.FoodStorage.Report(world).Tribes[0]
+	if record.Status != FoodStatusSurplus || !foodEventLogged(world, "famine_ended") || !foodEventLogged(world, "food_surplus") {
+		t.Errorf("Expected full stores to end the famine in surplus, got %+v", record)
+	}
+}
+
+func TestFoodStorageAPI(t *testing.T) {
+	world, tribe := newFoodStorageTestWorld(BiomeSwamp, 100)
+	tribe.Leader.SetTrait("intelligence", 0.8)
+	cache := world.EnvironmentalModSystem.CreateCache(tribe.Leader, Position{X: 20, Y: 20})
+	cache.Properties["stored_resources"] = 10
+	runFoodStorage(world, 3)
+
+	wi := NewWebInterface(world)
+	rec := httptest.NewRecorder()
+	wi.handleFoodStorage(rec, httptest.NewRequest(http.MethodGet, "/api/food-storage", nil))
+	var report FoodStorageReport
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &report) != nil || len(report.Tribes) != 1 {
+		t.Fatalf("Expected a food storage report with the tribe, got %d %s", rec.Code, rec.Body.String())
+	}
+	if report.SpoiledWild <= 0 || report.CachedWild >= 10 || report.Tribes[0].Spoiled <= 0 {
+		t.Errorf("Expected spoilage in both the creature's cache and the colony stores, got %+v", report)
+	}
+}
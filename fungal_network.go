@@ -39,6 +39,8 @@ type FungalNetwork struct {
 	TotalBiomass         float64           `json:"total_biomass"`
 	TotalNutrientCycling float64           `json:"total_nutrient_cycling"` // Nutrients processed per tick
 	DecompositionEvents  int               `json:"decomposition_events"`   // Total decomposition events
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewFungalNetwork creates a new fungal network system
@@ -50,6 +52,7 @@ func NewFungalNetwork() *FungalNetwork {
 		TotalBiomass:         0.0,
 		TotalNutrientCycling: 0.0,
 		DecompositionEvents:  0,
+		rng:                  sharedRand,
 	}
 }
 
@@ -80,7 +83,7 @@ func (fn *FungalNetwork) updateOrganisms(world *World, tick int) {
 		organism.Age++
 
 		// Fungi have natural lifespan
-		maxAge := 500 + rand.Intn(1000) // 500-1500 tick lifespan
+		maxAge := 500 + fn.rng.Intn(1000) // 500-1500 tick lifespan
 		if organism.Age > maxAge {
 			organism.IsAlive = false
 			continue
@@ -156,7 +159,7 @@ func (fn *FungalNetwork) processDecomposition(world *World, tick int) {
 		}
 
 		// Find nearby fungal decomposers
-		nearbyFungi := fn.findNearbyDecomposers(item.Position, 5.0)
+		nearbyFungi := fn.findNearbyDecomposers(item.Position, 5.0, world.Geometry())
 
 		if len(nearbyFungi) > 0 {
 			// Accelerate decomposition with fungi present
@@ -195,7 +198,7 @@ func (fn *FungalNetwork) processDecomposition(world *World, tick int) {
 }
 
 // findNearbyDecomposers finds decomposer fungi within range
-func (fn *FungalNetwork) findNearbyDecomposers(position Position, maxDistance float64) []*FungalOrganism {
+func (fn *FungalNetwork) findNearbyDecomposers(position Position, maxDistance float64, geometry WorldGeometry) []*FungalOrganism {
 	nearby := make([]*FungalOrganism, 0)
 
 	for _, organism := range fn.Organisms {
@@ -203,8 +206,7 @@ func (fn *FungalNetwork) findNearbyDecomposers(position Position, maxDistance fl
 			continue
 		}
 
-		distance := math.Sqrt(math.Pow(organism.Position.X-position.X, 2) +
-			math.Pow(organism.Position.Y-position.Y, 2))
+		distance := geometry.Distance(organism.Position, position)
 
 		if distance <= maxDistance {
 			nearby = append(nearby, organism)
@@ -216,12 +218,12 @@ func (fn *FungalNetwork) findNearbyDecomposers(position Position, maxDistance fl
 
 // createSpores generates fungal spores for reproduction
 func (fn *FungalNetwork) createSpores(parent *FungalOrganism, tick int) {
-	sporeCount := 1 + rand.Intn(3) // 1-3 spores
+	sporeCount := 1 + fn.rng.Intn(3) // 1-3 spores
 
 	for i := 0; i < sporeCount; i++ {
 		// Spores disperse randomly around parent
-		angle := rand.Float64() * 2 * math.Pi
-		distance := 1.0 + rand.Float64()*10.0 // 1-11 unit dispersal
+		angle := fn.rng.Float64() * 2 * math.Pi
+		distance := 1.0 + fn.rng.Float64()*10.0 // 1-11 unit dispersal
 
 		sporePos := Position{
 			X: parent.Position.X + math.Cos(angle)*distance,
@@ -233,9 +235,9 @@ func (fn *FungalNetwork) createSpores(parent *FungalOrganism, tick int) {
 			Position:        sporePos,
 			ParentID:        parent.ID,
 			Species:         parent.Species,
-			GerminationTime: 50 + rand.Intn(100),      // 50-150 ticks to germinate
-			Viability:       0.3 + rand.Float64()*0.5, // 30-80% viability
-			NutrientReq:     1.0 + rand.Float64()*2.0, // 1-3 nutrient requirement
+			GerminationTime: 50 + fn.rng.Intn(100),      // 50-150 ticks to germinate
+			Viability:       0.3 + fn.rng.Float64()*0.5, // 30-80% viability
+			NutrientReq:     1.0 + fn.rng.Float64()*2.0, // 1-3 nutrient requirement
 		}
 
 		fn.Spores = append(fn.Spores, spore)
@@ -249,7 +251,7 @@ func (fn *FungalNetwork) createSpores(parent *FungalOrganism, tick int) {
 // attemptGermination tries to germinate a spore
 func (fn *FungalNetwork) attemptGermination(spore *FungalSpore, world *World, tick int) bool {
 	// Check viability
-	if rand.Float64() > spore.Viability {
+	if fn.rng.Float64() > spore.Viability {
 		return false
 	}
 
@@ -260,7 +262,7 @@ func (fn *FungalNetwork) attemptGermination(spore *FungalSpore, world *World, ti
 	}
 
 	// Check for competition (too many fungi nearby)
-	nearbyFungi := fn.findNearbyFungi(spore.Position, 3.0)
+	nearbyFungi := fn.findNearbyFungi(spore.Position, 3.0, world.Geometry())
 	if len(nearbyFungi) > 2 {
 		return false // Too crowded
 	}
@@ -274,10 +276,10 @@ func (fn *FungalNetwork) createOrganismFromSpore(spore *FungalSpore, tick int) {
 		ID:                 fn.NextID,
 		Position:           spore.Position,
 		Species:            spore.Species,
-		Biomass:            0.5 + rand.Float64()*0.5, // Small initial biomass
-		DecompositionRate:  0.1 + rand.Float64()*0.1,
+		Biomass:            0.5 + fn.rng.Float64()*0.5, // Small initial biomass
+		DecompositionRate:  0.1 + fn.rng.Float64()*0.1,
 		NutrientStorage:    spore.NutrientReq, // Start with required nutrients
-		SporeProduction:    0.05 + rand.Float64()*0.05,
+		SporeProduction:    0.05 + fn.rng.Float64()*0.05,
 		NetworkConnections: make([]int, 0),
 		LastReproduced:     tick,
 		IsAlive:            true,
@@ -296,7 +298,7 @@ func (fn *FungalNetwork) formNetworkConnections(organism *FungalOrganism, world
 	}
 
 	// Find nearby fungi to connect with
-	nearbyFungi := fn.findNearbyFungi(organism.Position, 8.0)
+	nearbyFungi := fn.findNearbyFungi(organism.Position, 8.0, world.Geometry())
 
 	for _, nearby := range nearbyFungi {
 		if nearby.ID == organism.ID {
@@ -323,7 +325,7 @@ func (fn *FungalNetwork) formNetworkConnections(organism *FungalOrganism, world
 }
 
 // findNearbyFungi finds all fungi within range
-func (fn *FungalNetwork) findNearbyFungi(position Position, maxDistance float64) []*FungalOrganism {
+func (fn *FungalNetwork) findNearbyFungi(position Position, maxDistance float64, geometry WorldGeometry) []*FungalOrganism {
 	nearby := make([]*FungalOrganism, 0)
 
 	for _, organism := range fn.Organisms {
@@ -331,8 +333,7 @@ func (fn *FungalNetwork) findNearbyFungi(position Position, maxDistance float64)
 			continue
 		}
 
-		distance := math.Sqrt(math.Pow(organism.Position.X-position.X, 2) +
-			math.Pow(organism.Position.Y-position.Y, 2))
+		distance := geometry.Distance(organism.Position, position)
 
 		if distance <= maxDistance {
 			nearby = append(nearby, organism)
@@ -353,8 +354,7 @@ func (fn *FungalNetwork) getNutrientsAtPosition(position Position, world *World)
 				continue
 			}
 
-			distance := math.Sqrt(math.Pow(item.Position.X-position.X, 2) +
-				math.Pow(item.Position.Y-position.Y, 2))
+			distance := world.Distance(item.Position, position)
 
 			if distance <= 2.0 {
 				nutrients += item.NutrientValue * 0.1 // 10% of nearby organic matter
@@ -380,13 +380,13 @@ func (fn *FungalNetwork) updateStatistics() {
 func (fn *FungalNetwork) SeedInitialFungi(world *World, count int) {
 	for i := 0; i < count; i++ {
 		position := Position{
-			X: rand.Float64() * float64(world.Config.GridWidth),
-			Y: rand.Float64() * float64(world.Config.GridHeight),
+			X: fn.rng.Float64() * float64(world.Config.GridWidth),
+			Y: fn.rng.Float64() * float64(world.Config.GridHeight),
 		}
 
 		// Create mostly decomposer fungi initially
 		species := "decomposer"
-		if rand.Float64() < 0.2 {
+		if fn.rng.Float64() < 0.2 {
 			species = "mycorrhizal" // 20% chance of beneficial fungi
 		}
 
@@ -394,14 +394,14 @@ func (fn *FungalNetwork) SeedInitialFungi(world *World, count int) {
 			ID:                 fn.NextID,
 			Position:           position,
 			Species:            species,
-			Biomass:            1.0 + rand.Float64()*2.0,
-			DecompositionRate:  0.1 + rand.Float64()*0.1,
-			NutrientStorage:    2.0 + rand.Float64()*3.0,
-			SporeProduction:    0.05 + rand.Float64()*0.05,
+			Biomass:            1.0 + fn.rng.Float64()*2.0,
+			DecompositionRate:  0.1 + fn.rng.Float64()*0.1,
+			NutrientStorage:    2.0 + fn.rng.Float64()*3.0,
+			SporeProduction:    0.05 + fn.rng.Float64()*0.05,
 			NetworkConnections: make([]int, 0),
 			LastReproduced:     0,
 			IsAlive:            true,
-			Age:                rand.Intn(100), // Varied starting ages
+			Age:                fn.rng.Intn(100), // Varied starting ages
 		}
 
 		fn.Organisms = append(fn.Organisms, organism)
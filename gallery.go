@@ -0,0 +1,443 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Gallery files and limits
+const (
+	galleryIndexFile        = "gallery.json"
+	galleryManualTrigger    = "manual"
+	maxPendingGalleryEvents = 100
+)
+
+// galleryTrigger is a kind of notable event the gallery captures the world on
+type galleryTrigger struct {
+	Name   string   // Trigger snapshots are filed under
+	Title  string   // Title of its snapshots
+	Events []string // Central event types that set it off
+	Once   bool     // Only the world's first such event
+}
+
+// galleryTriggers are the notable events worth a snapshot. Creatures only make tools in this
+// world, so the first one made counts as the first tool use.
+var galleryTriggers = []galleryTrigger{
+	{Name: "first_tool_use", Title: "First tool use", Events: []string{"tool_created", "tool_used"}, Once: true},
+	{Name: "speciation", Title: "Speciation", Events: []string{EventTypeSpeciation}},
+	{Name: "war_declaration", Title: "War declared", Events: []string{"war_declared"}},
+}
+
+// galleryTriggerFor returns the trigger an event type sets off, nil for none
+func galleryTriggerFor(eventType string) *galleryTrigger {
+	for i := range galleryTriggers {
+		for _, event := range galleryTriggers[i].Events {
+			if event == eventType {
+				return &galleryTriggers[i]
+			}
+		}
+	}
+	return nil
+}
+
+// GalleryStats summarises the world when a snapshot was taken
+type GalleryStats struct {
+	Season        string         `json:"season"`
+	TimeOfDay     string         `json:"time_of_day"`
+	Creatures     int            `json:"creatures"`
+	Plants        int            `json:"plants"`
+	Species       int            `json:"species"`
+	Populations   map[string]int `json:"populations"` // Living creatures per species
+	AverageEnergy float64        `json:"average_energy"`
+	Tribes        int            `json:"tribes"`
+	Tools         int            `json:"tools"`
+}
+
+// GallerySnapshot is a rendered picture of the grid with a summary of the world at the tick
+// something notable happened
+type GallerySnapshot struct {
+	ID          int          `json:"id"`
+	Trigger     string       `json:"trigger"` // first_tool_use, speciation, war_declaration or manual
+	Title       string       `json:"title"`
+	Description string       `json:"description"` // The event that set it off
+	Tick        int          `json:"tick"`
+	Taken       time.Time    `json:"taken"`
+	Image       string       `json:"image"` // PNG file name, served below gallery/
+	Width       int          `json:"width"`
+	Height      int          `json:"height"`
+	Stats       GalleryStats `json:"stats"`
+
+	png []byte // Encoded image, kept in memory when the gallery has no directory
+}
+
+// galleryIndex is what the gallery keeps beside its images
+type galleryIndex struct {
+	NextID    int                `json:"next_id"`
+	Firsts    []string           `json:"firsts"` // Once-only triggers already captured
+	Snapshots []*GallerySnapshot `json:"snapshots"`
+}
+
+// SnapshotGallery captures a rendered snapshot of the world each time something notable
+// happens: the first tool use, a speciation, a declaration of war. It listens to the central
+// event bus and captures at the end of the tick, keeping the images and an index in a
+// directory beside the world's save when it has one, or in memory otherwise.
+type SnapshotGallery struct {
+	mu        sync.Mutex
+	dir       string
+	snapshots []*GallerySnapshot // Oldest first
+	nextID    int
+	firsts    map[string]bool
+	lastTick  map[string]int // Tick of each trigger's last automatic snapshot
+	eventBus  *CentralEventBus
+
+	// Events raised since the last update, kept apart so listeners never wait on a capture
+	pendingMu sync.Mutex
+	pending   []CentralEvent
+}
+
+// NewSnapshotGallery creates an empty gallery listening to a world's event bus
+func NewSnapshotGallery(eventBus *CentralEventBus) *SnapshotGallery {
+	g := &SnapshotGallery{nextID: 1, firsts: make(map[string]bool), lastTick: make(map[string]int), eventBus: eventBus}
+	if eventBus != nil {
+		eventBus.AddListener(g.observe)
+	}
+	return g
+}
+
+// observe keeps the events that call for a snapshot
+func (g *SnapshotGallery) observe(event CentralEvent) {
+	if galleryTriggerFor(event.Type) == nil {
+		return
+	}
+	g.pendingMu.Lock()
+	defer g.pendingMu.Unlock()
+	if len(g.pending) < maxPendingGalleryEvents {
+		g.pending = append(g.pending, event)
+	}
+}
+
+// SetDir keeps the gallery in a directory, bringing back the snapshots already there
+func (g *SnapshotGallery) SetDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create the gallery directory: %v", err)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.dir = dir
+
+	data, err := os.ReadFile(filepath.Join(dir, galleryIndexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return g.writeIndex()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the gallery: %v", err)
+	}
+	var index galleryIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return fmt.Errorf("invalid gallery index: %v", err)
+	}
+	g.snapshots = index.Snapshots
+	g.nextID = max(1, index.NextID)
+	g.firsts = make(map[string]bool)
+	for _, trigger := range index.Firsts {
+		g.firsts[trigger] = true
+	}
+	return nil
+}
+
+// Dir returns the directory the gallery is kept in, empty when it is kept in memory
+func (g *SnapshotGallery) Dir() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.dir
+}
+
+// Restart forgets which once-only events the old world saw, for a world that starts over.
+// The snapshots already taken stay in the gallery.
+func (g *SnapshotGallery) Restart() {
+	g.pendingMu.Lock()
+	g.pending = nil
+	g.pendingMu.Unlock()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.firsts = make(map[string]bool)
+	g.lastTick = make(map[string]int)
+	if g.dir != "" {
+		if err := g.writeIndex(); err != nil {
+			log.Printf("Gallery: %v", err)
+		}
+	}
+}
+
+// Update captures a snapshot for each trigger the tick's events set off, at most one per
+// trigger, skipping once-only triggers already captured and triggers still cooling down
+func (g *SnapshotGallery) Update(w *World) {
+	g.pendingMu.Lock()
+	events := g.pending
+	g.pending = nil
+	g.pendingMu.Unlock()
+
+	settings := w.SimConfig.Gallery
+	if len(events) == 0 || !settings.Enabled {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	captured := make(map[string]bool)
+	for _, event := range events {
+		trigger := galleryTriggerFor(event.Type)
+		if captured[trigger.Name] || (trigger.Once && g.firsts[trigger.Name]) {
+			continue
+		}
+		if last, seen := g.lastTick[trigger.Name]; seen && w.Tick-last < settings.Cooldown {
+			continue
+		}
+		captured[trigger.Name] = true
+		g.lastTick[trigger.Name] = w.Tick
+		if trigger.Once {
+			g.firsts[trigger.Name] = true
+		}
+		if _, err := g.capture(w, trigger.Name, trigger.Title, event.Description); err != nil {
+			log.Printf("Gallery: %v", err)
+		}
+	}
+}
+
+// Capture takes a snapshot of the world now, whatever has happened
+func (g *SnapshotGallery) Capture(w *World, title string) (GallerySnapshot, error) {
+	if title == "" {
+		title = fmt.Sprintf("Tick %d", w.Tick)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.capture(w, galleryManualTrigger, title, "Captured on request")
+}
+
+// capture renders the grid and summarises the world into a new snapshot, saving it to the
+// gallery's directory and dropping the oldest snapshots beyond the limit; the caller holds
+// the lock
+func (g *SnapshotGallery) capture(w *World, trigger, title, description string) (GallerySnapshot, error) {
+	picture := renderGalleryImage(w, w.SimConfig.Gallery.CellPixels)
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, picture); err != nil {
+		return GallerySnapshot{}, fmt.Errorf("failed to encode snapshot: %v", err)
+	}
+
+	snapshot := &GallerySnapshot{
+		ID:          g.nextID,
+		Trigger:     trigger,
+		Title:       title,
+		Description: description,
+		Tick:        w.Tick,
+		Taken:       time.Now(),
+		Image:       fmt.Sprintf("snapshot_%04d_tick_%d.png", g.nextID, w.Tick),
+		Width:       picture.Bounds().Dx(),
+		Height:      picture.Bounds().Dy(),
+		Stats:       galleryStats(w),
+		png:         encoded.Bytes(),
+	}
+	g.nextID++
+	g.snapshots = append(g.snapshots, snapshot)
+
+	var dropped []*GallerySnapshot
+	if excess := len(g.snapshots) - max(1, w.SimConfig.Gallery.MaxSnapshots); excess > 0 {
+		dropped = append(dropped, g.snapshots[:excess]...)
+		g.snapshots = append([]*GallerySnapshot(nil), g.snapshots[excess:]...)
+	}
+
+	if g.dir != "" {
+		if err := os.WriteFile(filepath.Join(g.dir, snapshot.Image), snapshot.png, 0644); err != nil {
+			return *snapshot, fmt.Errorf("failed to save snapshot: %v", err)
+		}
+		snapshot.png = nil
+		for _, old := range dropped {
+			if err := os.Remove(filepath.Join(g.dir, old.Image)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				log.Printf("Gallery: failed to remove %s: %v", old.Image, err)
+			}
+		}
+		if err := g.writeIndex(); err != nil {
+			return *snapshot, err
+		}
+	}
+
+	if g.eventBus != nil {
+		g.eventBus.EmitSystemEvent(w.Tick, "snapshot_captured", "gallery", "snapshot_gallery",
+			fmt.Sprintf("Snapshot %d captured: %s", snapshot.ID, title), nil,
+			map[string]interface{}{"snapshot": snapshot.ID, "trigger": trigger, "image": snapshot.Image})
+	}
+	return *snapshot, nil
+}
+
+// writeIndex saves the gallery's index beside its images; the caller holds the lock
+func (g *SnapshotGallery) writeIndex() error {
+	index := galleryIndex{NextID: g.nextID, Firsts: sortedKeys(g.firsts), Snapshots: g.snapshots}
+	if index.Snapshots == nil {
+		index.Snapshots = []*GallerySnapshot{}
+	}
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the gallery index: %v", err)
+	}
+	path := filepath.Join(g.dir, galleryIndexFile)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return fmt.Errorf("failed to save the gallery index: %v", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to save the gallery index: %v", err)
+	}
+	return nil
+}
+
+// Snapshots returns the snapshots in the gallery, newest first
+func (g *SnapshotGallery) Snapshots() []GallerySnapshot {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	snapshots := make([]GallerySnapshot, len(g.snapshots))
+	for i, snapshot := range g.snapshots {
+		snapshots[len(snapshots)-1-i] = *snapshot
+	}
+	return snapshots
+}
+
+// Latest returns the ID of the newest snapshot, 0 when the gallery is empty
+func (g *SnapshotGallery) Latest() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.snapshots) == 0 {
+		return 0
+	}
+	return g.snapshots[len(g.snapshots)-1].ID
+}
+
+// Image returns the PNG of the snapshot saved under a file name
+func (g *SnapshotGallery) Image(name string) ([]byte, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, snapshot := range g.snapshots {
+		if snapshot.Image != name {
+			continue
+		}
+		if snapshot.png != nil || g.dir == "" {
+			return snapshot.png, snapshot.png != nil
+		}
+		data, err := os.ReadFile(filepath.Join(g.dir, name))
+		return data, err == nil
+	}
+	return nil, false
+}
+
+// GalleryStatus is the gallery's settings and snapshots
+type GalleryStatus struct {
+	Settings  GalleryConfig     `json:"settings"`
+	Directory string            `json:"directory,omitempty"` // Where the images are kept, empty when in memory
+	Snapshots []GallerySnapshot `json:"snapshots"`           // Newest first
+}
+
+// Status reports the gallery
+func (g *SnapshotGallery) Status(w *World) GalleryStatus {
+	return GalleryStatus{Settings: w.SimConfig.Gallery, Directory: g.Dir(), Snapshots: g.Snapshots()}
+}
+
+// galleryDirBeside is the gallery directory kept beside a save file, named after it
+func galleryDirBeside(save string) string {
+	return strings.TrimSuffix(save, filepath.Ext(save)) + "_gallery"
+}
+
+// galleryStats summarises the world for a snapshot
+func galleryStats(w *World) GalleryStats {
+	stats := GalleryStats{
+		Season:      seasonToString(w.AdvancedTimeSystem.Season),
+		TimeOfDay:   timeOfDayName(w.AdvancedTimeSystem.TimeOfDay),
+		Populations: livingSpeciesCounts(w),
+	}
+	energy := 0.0
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive {
+			stats.Creatures++
+			energy += entity.Energy
+		}
+	}
+	if stats.Creatures > 0 {
+		stats.AverageEnergy = energy / float64(stats.Creatures)
+	}
+	for _, plant := range w.AllPlants {
+		if plant.IsAlive {
+			stats.Plants++
+		}
+	}
+	stats.Species = len(stats.Populations)
+	if w.CivilizationSystem != nil {
+		stats.Tribes = len(w.CivilizationSystem.Tribes)
+	}
+	if w.ToolSystem != nil {
+		stats.Tools = len(w.ToolSystem.Tools)
+	}
+	return stats
+}
+
+// renderGalleryImage draws the grid as an image, each cell a square of its biome's colour with
+// its first living plant in the middle and every living creature a dot of its species' colour
+func renderGalleryImage(w *World, cellPixels int) *image.RGBA {
+	cellPixels = max(1, cellPixels)
+	width, height := w.Config.GridWidth*cellPixels, w.Config.GridHeight*cellPixels
+	picture := image.NewRGBA(image.Rect(0, 0, width, height))
+	colors := &IsometricViewManager{world: w}
+
+	inset := cellPixels / 4
+	for y := 0; y < w.Config.GridHeight; y++ {
+		for x := 0; x < w.Config.GridWidth; x++ {
+			cell := w.Grid[y][x]
+			left, top := x*cellPixels, y*cellPixels
+			fillRect(picture, left, top, cellPixels, cellPixels, hexColor(colors.getBiomeColorHex(cell.Biome)))
+			for _, plant := range cell.Plants {
+				if plant.IsAlive {
+					fillRect(picture, left+inset, top+inset, cellPixels-2*inset, cellPixels-2*inset, hexColor(colors.getPlantColorHex(plant.Type)))
+					break
+				}
+			}
+		}
+	}
+
+	dot := max(1, cellPixels/3)
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		x := int(entity.Position.X / w.Config.Width * float64(width))
+		y := int(entity.Position.Y / w.Config.Height * float64(height))
+		fillRect(picture, x-dot/2, y-dot/2, dot, dot, hexColor(colors.getEntityColorHex(entity.Species)))
+	}
+	return picture
+}
+
+// fillRect paints a rectangle, clipped to the image
+func fillRect(picture *image.RGBA, left, top, width, height int, fill color.RGBA) {
+	area := image.Rect(left, top, left+width, top+height).Intersect(picture.Bounds())
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			picture.SetRGBA(x, y, fill)
+		}
+	}
+}
+
+// hexColor parses a #RRGGBB colour, opaque black when it is not one
+func hexColor(hex string) color.RGBA {
+	var r, g, b uint8
+	if _, err := fmt.Sscanf(hex, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return color.RGBA{A: 255}
+	}
+	return color.RGBA{R: r, G: g, B: b, A: 255}
+}
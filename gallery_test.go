@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// raiseNotable emits a notable event and lets the gallery look at the tick
+func raiseNotable(world *World, tick int, eventType string) {
+	world.Tick = tick
+	world.CentralEventBus.EmitSystemEvent(tick, eventType, "test", "test", eventType+" happened", nil, nil)
+	world.Gallery.Update(world)
+}
+
+func TestGalleryCapturesNotableEvents(t *testing.T) {
+	world := newSteppingTestWorld(71)
+	cooldown := world.SimConfig.Gallery.Cooldown
+
+	raiseNotable(world, 10, "tool_created")
+	raiseNotable(world, 11, "tool_used")
+	raiseNotable(world, 20, EventTypeSpeciation)
+	raiseNotable(world, 30, "war_declared")
+	raiseNotable(world, 20+cooldown/2, EventTypeSpeciation)
+	raiseNotable(world, 20+cooldown, EventTypeSpeciation)
+	raiseNotable(world, 20+cooldown, "birth")
+
+	snapshots := world.Gallery.Snapshots()
+	var triggers []string
+	for _, snapshot := range snapshots {
+		triggers = append(triggers, snapshot.Trigger)
+	}
+	want := []string{"speciation", "war_declaration", "speciation", "first_tool_use"}
+	if len(triggers) != len(want) {
+		t.Fatalf("Expected the first tool use, speciations a cooldown apart and the war, newest first, got %v", triggers)
+	}
+	for i := range want {
+		if triggers[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, triggers)
+		}
+	}
+
+	first := snapshots[len(snapshots)-1]
+	if first.Tick != 10 || first.Title != "First tool use" || first.Description != "tool_created happened" {
+		t.Errorf("Expected the first tool use captured when it happened, got %+v", first)
+	}
+	if first.Stats.Creatures != len(world.AllEntities) || first.Stats.Species == 0 || first.Stats.Season == "" {
+		t.Errorf("Expected the world summarised, got %+v", first.Stats)
+	}
+
+	data, exists := world.Gallery.Image(first.Image)
+	if !exists {
+		t.Fatalf("Expected the image of %s kept in memory", first.Image)
+	}
+	picture, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Expected a PNG, got %v", err)
+	}
+	cellPixels := world.SimConfig.Gallery.CellPixels
+	if bounds := picture.Bounds(); bounds.Dx() != world.Config.GridWidth*cellPixels || bounds.Dy() != first.Height {
+		t.Errorf("Expected a %d pixel square for each cell, got %v", cellPixels, bounds)
+	}
+
+	// A world that starts over gets its own first tool use
+	world.Reset()
+	raiseNotable(world, 5, "tool_created")
+	if latest := world.Gallery.Snapshots()[0]; latest.Trigger != "first_tool_use" || latest.Tick != 5 {
+		t.Errorf("Expected the new world's first tool use captured, got %+v", latest)
+	}
+
+	world.SimConfig.Gallery.Enabled = false
+	latest := world.Gallery.Latest()
+	raiseNotable(world, 500, "war_declared")
+	if world.Gallery.Latest() != latest {
+		t.Errorf("Expected nothing captured with the gallery off, got snapshot %d", world.Gallery.Latest())
+	}
+}
+
+func TestGalleryIsKeptBesideTheSave(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "run_gallery")
+	world := newSteppingTestWorld(72)
+	world.SimConfig.Gallery.MaxSnapshots = 2
+	if err := world.Gallery.SetDir(dir); err != nil {
+		t.Fatalf("Failed to open the gallery: %v", err)
+	}
+	raiseNotable(world, 1, "tool_created")
+	world.Gallery.Capture(world, "")
+	newest, err := world.Gallery.Capture(world, "Before the flood")
+	if err != nil {
+		t.Fatalf("Failed to capture: %v", err)
+	}
+	if newest.Title != "Before the flood" || newest.Trigger != galleryManualTrigger {
+		t.Errorf("Expected a titled snapshot on request, got %+v", newest)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.png"))
+	if len(files) != 2 {
+		t.Errorf("Expected the oldest image removed beyond the limit, got %v", files)
+	}
+
+	// A world loaded from the save finds its gallery again and its first tool use already taken
+	reloaded := newSteppingTestWorld(72)
+	if err := reloaded.Gallery.SetDir(dir); err != nil {
+		t.Fatalf("Failed to reopen the gallery: %v", err)
+	}
+	if snapshots := reloaded.Gallery.Snapshots(); len(snapshots) != 2 || snapshots[0].ID != newest.ID {
+		t.Fatalf("Expected the two kept snapshots back, got %+v", snapshots)
+	}
+	if data, exists := reloaded.Gallery.Image(newest.Image); !exists || !bytes.HasPrefix(data, []byte("\x89PNG")) {
+		t.Errorf("Expected the image read back from the gallery directory")
+	}
+	raiseNotable(reloaded, 2, "tool_created")
+	if latest := reloaded.Gallery.Latest(); latest != newest.ID {
+		t.Errorf("Expected no second first tool use, got snapshot %d", latest)
+	}
+
+	if dir := galleryDirBeside(filepath.Join("saves", "run.json")); dir != filepath.Join("saves", "run_gallery") {
+		t.Errorf("Expected the gallery named after the save, got %s", dir)
+	}
+	if _, err := os.Stat(filepath.Join(dir, galleryIndexFile)); err != nil {
+		t.Errorf("Expected the gallery index beside the images: %v", err)
+	}
+}
+
+func TestGalleryAPI(t *testing.T) {
+	wi := NewWebInterface(newSteppingTestWorld(73))
+
+	var status GalleryStatus
+	if code := callControlAPI(t, wi.handleGallery, http.MethodGet, "/api/gallery", "", &status); code != http.StatusOK || len(status.Snapshots) != 0 {
+		t.Fatalf("Expected an empty gallery, got %d %+v", code, status)
+	}
+	var snapshot GallerySnapshot
+	if code := callControlAPI(t, wi.handleGallery, http.MethodPost, "/api/gallery", `{"title": "Day one"}`, &snapshot); code != http.StatusCreated {
+		t.Fatalf("Expected a snapshot captured, got %d", code)
+	}
+	if code := callControlAPI(t, wi.handleGallery, http.MethodPost, "/api/gallery", `{"title": 1}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected a bad title refused, got %d", code)
+	}
+	callControlAPI(t, wi.handleGallery, http.MethodGet, "/api/gallery", "", &status)
+	if len(status.Snapshots) != 1 || status.Snapshots[0].Title != "Day one" || status.Directory != "" {
+		t.Errorf("Expected the snapshot listed from memory, got %+v", status)
+	}
+
+	routes := wi.Routes()
+	rec := httptest.NewRecorder()
+	routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gallery/"+snapshot.Image, nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/png" {
+		t.Errorf("Expected the snapshot's PNG, got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	rec = httptest.NewRecorder()
+	routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gallery/missing.png", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected an unknown image not found, got %d", rec.Code)
+	}
+}
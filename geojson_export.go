@@ -0,0 +1,396 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// GeoJSON layer names accepted by the export endpoint
+const (
+	GeoJSONLayerBiomes      = "biomes"
+	GeoJSONLayerRivers      = "rivers"
+	GeoJSONLayerTerritories = "territories"
+	GeoJSONLayerRanges      = "ranges"
+	GeoJSONLayerAll         = "all"
+)
+
+// GeoJSONGeometry is a GeoJSON geometry object
+type GeoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// GeoJSONFeature is a single GeoJSON feature
+type GeoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   GeoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// GeoJSONFeatureCollection is the top-level GeoJSON document
+type GeoJSONFeatureCollection struct {
+	Type     string                 `json:"type"`
+	Features []GeoJSONFeature       `json:"features"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// IsValidGeoJSONLayer reports whether a layer name can be exported
+func IsValidGeoJSONLayer(layer string) bool {
+	switch layer {
+	case GeoJSONLayerBiomes, GeoJSONLayerRivers, GeoJSONLayerTerritories, GeoJSONLayerRanges, GeoJSONLayerAll:
+		return true
+	}
+	return false
+}
+
+// ExportGeoJSON builds a feature collection for the requested layer.
+// Coordinates are in world units with the Y axis flipped so that north is up in GIS tools.
+func ExportGeoJSON(world *World, layer string) *GeoJSONFeatureCollection {
+	collection := &GeoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: make([]GeoJSONFeature, 0),
+		Metadata: map[string]interface{}{
+			"layer":        layer,
+			"tick":         world.Tick,
+			"world_width":  world.Config.Width,
+			"world_height": world.Config.Height,
+			"crs":          "planar world units, y-up",
+		},
+	}
+
+	if layer == GeoJSONLayerBiomes || layer == GeoJSONLayerAll {
+		collection.Features = append(collection.Features, geoJSONBiomeFeatures(world)...)
+	}
+	if layer == GeoJSONLayerRivers || layer == GeoJSONLayerAll {
+		collection.Features = append(collection.Features, geoJSONRiverFeatures(world)...)
+	}
+	if layer == GeoJSONLayerTerritories || layer == GeoJSONLayerAll {
+		collection.Features = append(collection.Features, geoJSONTerritoryFeatures(world)...)
+	}
+	if layer == GeoJSONLayerRanges || layer == GeoJSONLayerAll {
+		collection.Features = append(collection.Features, geoJSONRangeFeatures(world)...)
+	}
+
+	return collection
+}
+
+// geoJSONCellSize returns the world size of a single grid cell
+func geoJSONCellSize(world *World) (float64, float64) {
+	return world.Config.Width / float64(world.Config.GridWidth), world.Config.Height / float64(world.Config.GridHeight)
+}
+
+// geoJSONPoint converts a world position into a GeoJSON coordinate pair
+func geoJSONPoint(world *World, x, y float64) []float64 {
+	return []float64{roundCoordinate(x), roundCoordinate(world.Config.Height - y)}
+}
+
+// roundCoordinate keeps exported coordinates compact
+func roundCoordinate(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
+
+// cellsToMultiPolygon merges grid cells into row-run rectangles forming a MultiPolygon
+func cellsToMultiPolygon(world *World, cells map[GridPoint]bool) [][][][]float64 {
+	cellW, cellH := geoJSONCellSize(world)
+	polygons := make([][][][]float64, 0)
+
+	for y := 0; y < world.Config.GridHeight; y++ {
+		x := 0
+		for x < world.Config.GridWidth {
+			if !cells[GridPoint{X: x, Y: y}] {
+				x++
+				continue
+			}
+			start := x
+			for x < world.Config.GridWidth && cells[GridPoint{X: x, Y: y}] {
+				x++
+			}
+
+			minX, maxX := float64(start)*cellW, float64(x)*cellW
+			minY, maxY := float64(y)*cellH, float64(y+1)*cellH
+			ring := [][]float64{
+				geoJSONPoint(world, minX, minY),
+				geoJSONPoint(world, minX, maxY),
+				geoJSONPoint(world, maxX, maxY),
+				geoJSONPoint(world, maxX, minY),
+				geoJSONPoint(world, minX, minY),
+			}
+			polygons = append(polygons, [][][]float64{ring})
+		}
+	}
+
+	return polygons
+}
+
+// geoJSONBiomeFeatures produces one MultiPolygon feature per biome present in the world
+func geoJSONBiomeFeatures(world *World) []GeoJSONFeature {
+	cellsByBiome := make(map[BiomeType]map[GridPoint]bool)
+	for y := 0; y < world.Config.GridHeight; y++ {
+		for x := 0; x < world.Config.GridWidth; x++ {
+			biome := world.Grid[y][x].Biome
+			if cellsByBiome[biome] == nil {
+				cellsByBiome[biome] = make(map[GridPoint]bool)
+			}
+			cellsByBiome[biome][GridPoint{X: x, Y: y}] = true
+		}
+	}
+
+	biomes := make([]BiomeType, 0, len(cellsByBiome))
+	for biome := range cellsByBiome {
+		biomes = append(biomes, biome)
+	}
+	sort.Slice(biomes, func(i, j int) bool { return biomes[i] < biomes[j] })
+
+	cellW, cellH := geoJSONCellSize(world)
+	features := make([]GeoJSONFeature, 0, len(biomes))
+	for _, biome := range biomes {
+		cells := cellsByBiome[biome]
+		properties := map[string]interface{}{
+			"layer":      GeoJSONLayerBiomes,
+			"biome":      world.getBiomeName(biome),
+			"biome_type": int(biome),
+			"cell_count": len(cells),
+			"area":       float64(len(cells)) * cellW * cellH,
+		}
+		if info, exists := world.Biomes[biome]; exists {
+			properties["name"] = info.Name
+			properties["color"] = info.Color
+		}
+		features = append(features, GeoJSONFeature{
+			Type:       "Feature",
+			Geometry:   GeoJSONGeometry{Type: "MultiPolygon", Coordinates: cellsToMultiPolygon(world, cells)},
+			Properties: properties,
+		})
+	}
+
+	return features
+}
+
+// geoJSONRiverFeatures produces LineString features for rivers and streams
+func geoJSONRiverFeatures(world *World) []GeoJSONFeature {
+	features := make([]GeoJSONFeature, 0)
+	if world.TopologySystem == nil {
+		return features
+	}
+
+	ids := make([]int, 0, len(world.TopologySystem.WaterBodies))
+	for id, body := range world.TopologySystem.WaterBodies {
+		if (body.Type == "river" || body.Type == "stream") && len(body.Points) >= 2 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	// Water body points are stored in topology grid coordinates
+	cellW, cellH := geoJSONCellSize(world)
+	for _, id := range ids {
+		body := world.TopologySystem.WaterBodies[id]
+		line := make([][]float64, 0, len(body.Points))
+		for _, point := range body.Points {
+			line = append(line, geoJSONPoint(world, (point.X+0.5)*cellW, (point.Y+0.5)*cellH))
+		}
+		features = append(features, GeoJSONFeature{
+			Type:     "Feature",
+			Geometry: GeoJSONGeometry{Type: "LineString", Coordinates: line},
+			Properties: map[string]interface{}{
+				"layer":     GeoJSONLayerRivers,
+				"id":        body.ID,
+				"type":      body.Type,
+				"flow":      body.Flow,
+				"depth":     body.Depth,
+				"salinity":  body.Salinity,
+				"is_active": body.IsActive,
+			},
+		})
+	}
+
+	return features
+}
+
+// geoJSONTerritoryFeatures produces polygons for tribal territories and caste colony territories
+func geoJSONTerritoryFeatures(world *World) []GeoJSONFeature {
+	features := make([]GeoJSONFeature, 0)
+
+	territories := world.generateTerritories()
+	ids := make([]int, 0, len(territories))
+	for id := range territories {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	for _, id := range ids {
+		territory := territories[id]
+		features = append(features, GeoJSONFeature{
+			Type:     "Feature",
+			Geometry: GeoJSONGeometry{Type: "Polygon", Coordinates: [][][]float64{circleRing(world, territory.Center, territory.Radius, 24)}},
+			Properties: map[string]interface{}{
+				"layer":    GeoJSONLayerTerritories,
+				"kind":     "tribe",
+				"id":       territory.ID,
+				"owner_id": territory.OwnerID,
+				"radius":   territory.Radius,
+				"quality":  territory.Quality,
+			},
+		})
+	}
+
+	if world.CasteSystem != nil {
+		for _, colony := range world.CasteSystem.Colonies {
+			var ring [][]float64
+			hull := convexHull(colony.Territory)
+			if len(hull) >= 3 {
+				ring = make([][]float64, 0, len(hull)+1)
+				for _, point := range hull {
+					ring = append(ring, geoJSONPoint(world, point.X, point.Y))
+				}
+				ring = append(ring, ring[0])
+			} else {
+				// Small colonies only claim the area around their nest
+				ring = circleRing(world, colony.NestLocation, 3.0, 12)
+			}
+			features = append(features, GeoJSONFeature{
+				Type:     "Feature",
+				Geometry: GeoJSONGeometry{Type: "Polygon", Coordinates: [][][]float64{ring}},
+				Properties: map[string]interface{}{
+					"layer":       GeoJSONLayerTerritories,
+					"kind":        "colony",
+					"id":          colony.ID,
+					"colony_size": colony.ColonySize,
+					"fitness":     colony.ColonyFitness,
+				},
+			})
+		}
+	}
+
+	return features
+}
+
+// geoJSONRangeFeatures produces one MultiPolygon per species covering every occupied grid cell
+func geoJSONRangeFeatures(world *World) []GeoJSONFeature {
+	cellsBySpecies := make(map[string]map[GridPoint]bool)
+	disturbedBySpecies := make(map[string]map[GridPoint]bool)
+	population := make(map[string]int)
+	for _, entity := range world.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		gx, gy := world.worldToGridCoords(entity.Position.X, entity.Position.Y)
+		if cellsBySpecies[entity.Species] == nil {
+			cellsBySpecies[entity.Species] = make(map[GridPoint]bool)
+		}
+		cellsBySpecies[entity.Species][GridPoint{X: gx, Y: gy}] = true
+		population[entity.Species]++
+		if noise, _ := world.NoisePollution.NoiseAt(entity.Position); noise > 0 {
+			if disturbedBySpecies[entity.Species] == nil {
+				disturbedBySpecies[entity.Species] = make(map[GridPoint]bool)
+			}
+			disturbedBySpecies[entity.Species][GridPoint{X: gx, Y: gy}] = true
+		}
+	}
+
+	species := make([]string, 0, len(cellsBySpecies))
+	for name := range cellsBySpecies {
+		species = append(species, name)
+	}
+	sort.Strings(species)
+
+	cellW, cellH := geoJSONCellSize(world)
+	features := make([]GeoJSONFeature, 0, len(species))
+	for _, name := range species {
+		cells := cellsBySpecies[name]
+		features = append(features, GeoJSONFeature{
+			Type:     "Feature",
+			Geometry: GeoJSONGeometry{Type: "MultiPolygon", Coordinates: cellsToMultiPolygon(world, cells)},
+			Properties: map[string]interface{}{
+				"layer":           GeoJSONLayerRanges,
+				"species":         name,
+				"population":      population[name],
+				"cell_count":      len(cells),
+				"range_area":      float64(len(cells)) * cellW * cellH,
+				"disturbed_cells": len(disturbedBySpecies[name]), // Cells where members live amid settlement noise
+			},
+		})
+	}
+
+	return features
+}
+
+// circleRing approximates a circle as a closed polygon ring
+func circleRing(world *World, center Position, radius float64, segments int) [][]float64 {
+	ring := make([][]float64, 0, segments+1)
+	for i := 0; i < segments; i++ {
+		angle := 2 * math.Pi * float64(i) / float64(segments)
+		ring = append(ring, geoJSONPoint(world, center.X+math.Cos(angle)*radius, center.Y+math.Sin(angle)*radius))
+	}
+	return append(ring, ring[0])
+}
+
+// convexHull returns the convex hull of a set of positions using the monotone chain algorithm
+func convexHull(points []Position) []Position {
+	if len(points) < 3 {
+		return points
+	}
+
+	sorted := make([]Position, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+
+	cross := func(o, a, b Position) float64 {
+		return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+	}
+
+	hull := make([]Position, 0, 2*len(sorted))
+	for _, p := range sorted {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(sorted) - 2; i >= 0; i-- {
+		p := sorted[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+
+	return hull[:len(hull)-1]
+}
+
+// GeoJSONFilename returns the download filename for a layer export
+func GeoJSONFilename(layer string) string {
+	return fmt.Sprintf("evosim_%s.geojson", layer)
+}
+
+// runGeoJSONExportCommand writes a save's geography and species ranges as GeoJSON
+func runGeoJSONExportCommand(args []string) error {
+	fs := newCommandFlags("geojson")
+	layer := fs.String("layer", GeoJSONLayerAll, "Layer to export: biomes, rivers, territories, ranges or all")
+	out := fs.String("out", "", "File to write (stdout when empty)")
+	if _, err := parseCommandFlags(fs, args, commandEnvPrefix(fs.Name()), nil); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return commandUsage("geojson")
+	}
+	if !IsValidGeoJSONLayer(*layer) {
+		return fmt.Errorf("unknown layer %q", *layer)
+	}
+
+	world, err := LoadWorldFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return writeCommandOutput(*out, func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(ExportGeoJSON(world, *layer))
+	})
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGeoJSONBiomeLayerCoversGrid(t *testing.T) {
+	world := newTestWorld(40, 10)
+	collection := ExportGeoJSON(world, GeoJSONLayerBiomes)
+
+	if collection.Type != "FeatureCollection" {
+		t.Fatalf("Expected FeatureCollection, got %s", collection.Type)
+	}
+	if len(collection.Features) == 0 {
+		t.Fatal("Expected at least one biome feature")
+	}
+
+	totalCells := 0
+	for _, feature := range collection.Features {
+		if feature.Geometry.Type != "MultiPolygon" {
+			t.Errorf("Expected MultiPolygon geometry, got %s", feature.Geometry.Type)
+		}
+		totalCells += feature.Properties["cell_count"].(int)
+
+		polygons := feature.Geometry.Coordinates.([][][][]float64)
+		for _, polygon := range polygons {
+			ring := polygon[0]
+			if len(ring) != 5 || ring[0][0] != ring[4][0] || ring[0][1] != ring[4][1] {
+				t.Fatalf("Expected closed rectangular ring, got %v", ring)
+			}
+		}
+	}
+
+	if totalCells != world.Config.GridWidth*world.Config.GridHeight {
+		t.Errorf("Expected biome features to cover %d cells, got %d",
+			world.Config.GridWidth*world.Config.GridHeight, totalCells)
+	}
+}
+
+func TestGeoJSONSpeciesRanges(t *testing.T) {
+	world := newTestWorld(40, 10)
+
+	for i, pos := range []Position{{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 30, Y: 30}} {
+		entity := NewEntity(sharedRand, 100+i, []string{}, "herbivore", pos)
+		world.AllEntities = append(world.AllEntities, entity)
+	}
+	dead := NewEntity(sharedRand, 200, []string{}, "predator", Position{X: 10, Y: 10})
+	dead.IsAlive = false
+	world.AllEntities = append(world.AllEntities, dead)
+
+	collection := ExportGeoJSON(world, GeoJSONLayerRanges)
+	if len(collection.Features) != 1 {
+		t.Fatalf("Expected 1 range feature for living species, got %d", len(collection.Features))
+	}
+
+	feature := collection.Features[0]
+	if feature.Properties["species"] != "herbivore" {
+		t.Errorf("Expected herbivore range, got %v", feature.Properties["species"])
+	}
+	if feature.Properties["population"] != 3 {
+		t.Errorf("Expected population 3, got %v", feature.Properties["population"])
+	}
+	// (1,1) and (2,2) share a 4x4 grid cell
+	if feature.Properties["cell_count"] != 2 {
+		t.Errorf("Expected range of 2 cells, got %v", feature.Properties["cell_count"])
+	}
+}
+
+func TestGeoJSONRiverCoordinatesFlipped(t *testing.T) {
+	world := newTestWorld(40, 10)
+	world.TopologySystem.WaterBodies = map[int]*WaterBody{
+		1: {ID: 1, Type: "river", Points: []Position{{X: 0, Y: 0}, {X: 1, Y: 0}}, IsActive: true},
+		2: {ID: 2, Type: "lake", Points: []Position{{X: 5, Y: 5}, {X: 6, Y: 6}}},
+	}
+
+	collection := ExportGeoJSON(world, GeoJSONLayerRivers)
+	if len(collection.Features) != 1 {
+		t.Fatalf("Expected only the river to be exported, got %d features", len(collection.Features))
+	}
+
+	line := collection.Features[0].Geometry.Coordinates.([][]float64)
+	// Grid cell (0,0) is centered at world (2,2), which is y=38 once flipped
+	if line[0][0] != 2 || line[0][1] != 38 {
+		t.Errorf("Expected first river point at [2 38], got %v", line[0])
+	}
+}
+
+func TestGeoJSONAllLayersEncode(t *testing.T) {
+	world := newTestWorld(40, 10)
+	collection := ExportGeoJSON(world, GeoJSONLayerAll)
+
+	data, err := json.Marshal(collection)
+	if err != nil {
+		t.Fatalf("Failed to encode GeoJSON: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode GeoJSON: %v", err)
+	}
+	if decoded["type"] != "FeatureCollection" {
+		t.Errorf("Expected FeatureCollection, got %v", decoded["type"])
+	}
+
+	if IsValidGeoJSONLayer("elevation") {
+		t.Error("Expected unknown layer to be rejected")
+	}
+}
+
+func TestConvexHull(t *testing.T) {
+	points := []Position{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 2, Y: 2}}
+	hull := convexHull(points)
+	if len(hull) != 4 {
+		t.Errorf("Expected square hull of 4 points, got %v", hull)
+	}
+}
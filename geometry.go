@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// World geometries, saying which edges of the map join up
+const (
+	GeometryBox      = "box"      // Bounded on every side, with polar caps all round the edge
+	GeometryTorus    = "torus"    // Wraps round east to west and north to south, with no edges and no poles
+	GeometryCylinder = "cylinder" // Wraps round east to west, with polar caps along the top and bottom
+)
+
+// polarCapRows is how many grid rows in from a pole the ice and tundra reach
+const polarCapRows = 3
+
+// WorldGeometry is the shape of the map: how far apart two places are, which way one lies
+// from another, and where a position that runs off an edge ends up. Subsystems measure
+// proximity through the world's geometry rather than with their own arithmetic, so a new
+// shape of world needs only a new geometry.
+type WorldGeometry interface {
+	Name() string
+	// Distance is how far apart two positions are, the shortest way
+	Distance(a, b Position) float64
+	// Direction is the shortest step from one position to another
+	Direction(from, to Position) (dx, dy float64)
+	// Wrap brings a position that has crossed a joined edge round to the other side,
+	// leaving it alone past an edge that doesn't join
+	Wrap(pos Position) Position
+	// Contain wraps a position and holds it inside the edges that don't join, no nearer
+	// their far side than margin
+	Contain(pos Position, margin float64) Position
+	// Copies lists the places a position appears at when seen across each joined edge,
+	// itself first, for searches that must look past the edge of the map
+	Copies(pos Position) []Position
+}
+
+// WorldGeometryInfo describes a geometry
+type WorldGeometryInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	create      func(width, height float64) WorldGeometry
+}
+
+// worldGeometryLibrary lists the geometries a world can have
+var worldGeometryLibrary = []WorldGeometryInfo{
+	{GeometryBox, "Bounded on every side, with polar caps all round the edge", func(width, height float64) WorldGeometry {
+		return BoxGeometry{rectangle{width, height, false, false}}
+	}},
+	{GeometryTorus, "Wraps round east to west and north to south, with repeating climate bands and no poles", func(width, height float64) WorldGeometry {
+		return TorusGeometry{rectangle{width, height, true, true}}
+	}},
+	{GeometryCylinder, "Wraps round east to west, with polar caps along the top and bottom", func(width, height float64) WorldGeometry {
+		return CylinderGeometry{rectangle{width, height, true, false}}
+	}},
+}
+
+// WorldGeometryNames lists the names of the geometries
+func WorldGeometryNames() []string {
+	names := make([]string, len(worldGeometryLibrary))
+	for i, info := range worldGeometryLibrary {
+		names[i] = info.Name
+	}
+	return names
+}
+
+// NewWorldGeometry creates a geometry by name for a map of the given size. An empty name
+// is a box.
+func NewWorldGeometry(name string, width, height float64) (WorldGeometry, error) {
+	if name == "" {
+		name = GeometryBox
+	}
+	for _, info := range worldGeometryLibrary {
+		if info.Name == name {
+			return info.create(width, height), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown world geometry %q (known: %s)", name, strings.Join(WorldGeometryNames(), ", "))
+}
+
+// ValidateGeometry checks a world geometry name. An empty name is a box.
+func ValidateGeometry(name string) error {
+	_, err := NewWorldGeometry(name, 1, 1)
+	return err
+}
+
+// rectangle measures a rectangular map whose opposite edges may join
+type rectangle struct {
+	width, height float64
+	wrapX, wrapY  bool
+}
+
+func (r rectangle) Distance(a, b Position) float64 {
+	dx, dy := r.Direction(b, a)
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+func (r rectangle) Direction(from, to Position) (dx, dy float64) {
+	dx, dy = to.X-from.X, to.Y-from.Y
+	if r.wrapX {
+		dx -= r.width * math.Round(dx/r.width)
+	}
+	if r.wrapY {
+		dy -= r.height * math.Round(dy/r.height)
+	}
+	return dx, dy
+}
+
+func (r rectangle) Wrap(pos Position) Position {
+	if r.wrapX {
+		pos.X = wrapCoordinate(pos.X, r.width)
+	}
+	if r.wrapY {
+		pos.Y = wrapCoordinate(pos.Y, r.height)
+	}
+	return pos
+}
+
+func (r rectangle) Contain(pos Position, margin float64) Position {
+	pos = r.Wrap(pos)
+	if !r.wrapX {
+		pos.X = math.Max(0, math.Min(r.width-margin, pos.X))
+	}
+	if !r.wrapY {
+		pos.Y = math.Max(0, math.Min(r.height-margin, pos.Y))
+	}
+	return pos
+}
+
+func (r rectangle) Copies(pos Position) []Position {
+	copies := []Position{pos}
+	if r.wrapX {
+		copies = append(copies, Position{X: pos.X - r.width, Y: pos.Y}, Position{X: pos.X + r.width, Y: pos.Y})
+	}
+	if r.wrapY {
+		for _, copy := range copies {
+			copies = append(copies, Position{X: copy.X, Y: copy.Y - r.height}, Position{X: copy.X, Y: copy.Y + r.height})
+		}
+	}
+	return copies
+}
+
+// wrapCoordinate brings a coordinate into [0, period)
+func wrapCoordinate(value, period float64) float64 {
+	value = math.Mod(value, period)
+	if value < 0 {
+		value += period
+	}
+	if value >= period {
+		value = 0 // A tiny negative value rounds up to the period
+	}
+	return value
+}
+
+// BoxGeometry is a map bounded on every side
+type BoxGeometry struct{ rectangle }
+
+func (BoxGeometry) Name() string { return GeometryBox }
+
+// TorusGeometry is a map whose east edge joins its west and north edge its south
+type TorusGeometry struct{ rectangle }
+
+func (TorusGeometry) Name() string { return GeometryTorus }
+
+// CylinderGeometry is a map whose east edge joins its west, bounded at the poles
+type CylinderGeometry struct{ rectangle }
+
+func (CylinderGeometry) Name() string { return GeometryCylinder }
+
+// Geometry returns the world's geometry. Worlds keep the one built from their configuration
+// when they are created or restored; others build it as asked.
+func (w *World) Geometry() WorldGeometry {
+	if w.geometry != nil {
+		return w.geometry
+	}
+	geometry, err := NewWorldGeometry(w.Config.Geometry, w.Config.Width, w.Config.Height)
+	if err != nil {
+		geometry, _ = NewWorldGeometry(GeometryBox, w.Config.Width, w.Config.Height)
+	}
+	return geometry
+}
+
+// setGeometry builds the world's geometry and grid layout from its configuration
+func (w *World) setGeometry() {
+	w.geometry, w.gridLayout = nil, nil
+	w.geometry, w.gridLayout = w.Geometry(), w.GridLayout()
+}
+
+// Distance is how far apart two positions are, the short way round a map that wraps
+func (w *World) Distance(a, b Position) float64 {
+	return w.Geometry().Distance(a, b)
+}
+
+// Nearest returns the copy of target nearest to from, so a creature heads for it the short
+// way round a map that wraps. The copy may lie off the map until the creature gets there
+// and its position is wrapped.
+func (w *World) Nearest(from, target Position) Position {
+	dx, dy := w.Geometry().Direction(from, target)
+	return Position{X: from.X + dx, Y: from.Y + dy}
+}
+
+// KeepOnMap wraps a position across the joined edges and holds it inside the others, no
+// nearer the far edge than margin
+func (w *World) KeepOnMap(pos Position, margin float64) Position {
+	return w.Geometry().Contain(pos, margin)
+}
+
+// wrapGridCell finds the grid cell at x, y, across a joined edge if need be. It reports
+// false for a cell off an edge that doesn't join.
+func (w *World) wrapGridCell(x, y int) (int, int, bool) {
+	// Cells are numbered by column and row whatever their shape, so the cell moves by as many
+	// columns and rows as wrapping moves the middle of its column and row. A hex cell's own
+	// middle can lie right on the edge.
+	cellWidth, cellHeight := w.Config.Width/float64(w.Config.GridWidth), w.Config.Height/float64(w.Config.GridHeight)
+	center := Position{X: (float64(x) + 0.5) * cellWidth, Y: (float64(y) + 0.5) * cellHeight}
+	wrapped := w.Geometry().Wrap(center)
+	x += int(math.Round((wrapped.X-center.X)/w.Config.Width)) * w.Config.GridWidth
+	y += int(math.Round((wrapped.Y-center.Y)/w.Config.Height)) * w.Config.GridHeight
+	return x, y, x >= 0 && x < w.Config.GridWidth && y >= 0 && y < w.Config.GridHeight
+}
+
+// wrapMovers brings the creatures and seeds that crossed a joined edge during the tick back
+// onto the map
+func (w *World) wrapMovers() {
+	geometry := w.Geometry()
+	for _, entity := range w.AllEntities {
+		entity.Position = geometry.Wrap(entity.Position)
+	}
+	if w.SeedDispersalSystem != nil {
+		for _, seed := range w.SeedDispersalSystem.AllSeeds {
+			seed.Position = geometry.Wrap(seed.Position)
+		}
+	}
+}
+
+// distanceFromPole is how many grid rows or columns a cell lies from the nearest polar
+// edge. A box has ice all round its edge, a cylinder only along the top and bottom, and a
+// torus none at all.
+func (w *World) distanceFromPole(x, y int) float64 {
+	fromTopOrBottom := math.Min(float64(y), float64(w.Config.GridHeight-y))
+	switch w.Config.Geometry {
+	case GeometryTorus:
+		return math.Inf(1)
+	case GeometryCylinder:
+		return fromTopOrBottom
+	}
+	return math.Min(math.Min(float64(x), float64(w.Config.GridWidth-x)), fromTopOrBottom)
+}
+
+// rowLatitude is the latitude of a grid row in degrees north. A box or cylinder runs from
+// maxLatitude north along the top to as far south along the bottom. A torus has nowhere for
+// the top and bottom to differ, so its climate bands repeat: north where they join, south
+// across the middle, and an equator a quarter of the way in from each.
+func rowLatitude(geometry string, maxLatitude float64, y, rows int) float64 {
+	share := (float64(y) + 0.5) / float64(rows)
+	if geometry == GeometryTorus {
+		return maxLatitude * math.Cos(2*math.Pi*share)
+	}
+	return maxLatitude * (1 - 2*share)
+}
+
+// nearestEquator is the height of the equator nearest to y, of the two a torus has a
+// quarter of the way in from its joined edges
+func (w *World) nearestEquator(y float64) float64 {
+	switch {
+	case w.Config.Geometry != GeometryTorus:
+		return w.Config.Height / 2
+	case y < w.Config.Height/2:
+		return w.Config.Height / 4
+	}
+	return w.Config.Height * 3 / 4
+}
+
+// geometry names the configured geometry, a box when none is given
+func (c WorldConfig) geometry() string {
+	if c.Geometry == "" {
+		return GeometryBox
+	}
+	return c.Geometry
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// distanceBetween returns the Euclidean distance between two positions, straight across the
+// map; World.Distance measures the short way round one that wraps
+func distanceBetween(a, b Position) float64 {
+	dx := a.X - b.X
+	dy := a.Y - b.Y
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+// newGeometryTestWorld makes a small seeded world of the given geometry
+func newGeometryTestWorld(seed int64, geometry string) *World {
+	config := steppingTestConfig(seed)
+	config.Geometry = geometry
+	return newPopulatedTestWorld(config, false)
+}
+
+func TestGeometryJoinsEdges(t *testing.T) {
+	west, east := Position{X: 1, Y: 50}, Position{X: 99, Y: 50}
+	north, south := Position{X: 50, Y: 1}, Position{X: 50, Y: 99}
+
+	box := newGeometryTestWorld(116, GeometryBox)
+	cylinder := newGeometryTestWorld(116, GeometryCylinder)
+	torus := newGeometryTestWorld(116, GeometryTorus)
+
+	if box.Distance(west, east) != 98 || cylinder.Distance(west, east) != 2 || torus.Distance(west, east) != 2 {
+		t.Errorf("Expected only wrapping maps to join east and west, got %.1f %.1f %.1f",
+			box.Distance(west, east), cylinder.Distance(west, east), torus.Distance(west, east))
+	}
+	if cylinder.Distance(north, south) != 98 || torus.Distance(north, south) != 2 {
+		t.Errorf("Expected only a torus to join north and south, got %.1f %.1f", cylinder.Distance(north, south), torus.Distance(north, south))
+	}
+
+	// Crossing a joined edge comes round the other side; crossing a closed one stops at it
+	if pos := cylinder.KeepOnMap(Position{X: -3, Y: 104}, 0); pos.X != 97 || pos.Y != 100 {
+		t.Errorf("Expected a cylinder to wrap east to west and stop at the poles, got %+v", pos)
+	}
+	if pos := torus.KeepOnMap(Position{X: 102, Y: -1}, 0); pos.X != 2 || pos.Y != 99 {
+		t.Errorf("Expected a torus to wrap both ways, got %+v", pos)
+	}
+	if pos := box.KeepOnMap(Position{X: -3, Y: 104}, 1); pos.X != 0 || pos.Y != 99 {
+		t.Errorf("Expected a box to hold positions inside, got %+v", pos)
+	}
+	if target := torus.Nearest(west, east); target.X != -1 {
+		t.Errorf("Expected the way east to lie west across the edge, got %+v", target)
+	}
+
+	// Neighbours are found across the joined edge
+	torus.AllEntities[0].Position, torus.AllEntities[1].Position = west, east
+	torus.Spatial = nil
+	found := torus.spatialIndex().QueryRadius(east, 3)
+	if len(found) < 2 || found[0] != torus.AllEntities[0] {
+		t.Errorf("Expected the creature across the edge found, got %d", len(found))
+	}
+}
+
+func TestGeometryShapesClimate(t *testing.T) {
+	box := newGeometryTestWorld(117, GeometryBox)
+	cylinder := newGeometryTestWorld(117, GeometryCylinder)
+	torus := newGeometryTestWorld(117, GeometryTorus)
+
+	// A box has ice round every edge, a cylinder only at the top and bottom, a torus nowhere
+	if box.distanceFromPole(0, 12) != 0 || cylinder.distanceFromPole(0, 12) != 12 || !math.IsInf(torus.distanceFromPole(0, 0), 1) {
+		t.Error("Expected the polar caps to follow the geometry")
+	}
+	if rowLatitude(GeometryCylinder, 60, 0, 24) <= 55 || rowLatitude(GeometryCylinder, 60, 23, 24) >= -55 {
+		t.Error("Expected a cylinder to run from north to south")
+	}
+	if top, bottom := rowLatitude(GeometryTorus, 60, 0, 24), rowLatitude(GeometryTorus, 60, 23, 24); math.Abs(top-bottom) > 1e-9 || top < 55 {
+		t.Errorf("Expected a torus's top and bottom rows to share a climate, got %.1f and %.1f", top, bottom)
+	}
+	if torus.nearestEquator(95) != 75 || torus.nearestEquator(5) != 25 || cylinder.nearestEquator(5) != 50 {
+		t.Error("Expected a torus to have two equators")
+	}
+
+	// Creatures wandering a torus for a while stay on the map
+	for i := 0; i < 30; i++ {
+		torus.Update()
+	}
+	for _, entity := range torus.AllEntities {
+		if entity.Position.X < 0 || entity.Position.X >= 100 || entity.Position.Y < 0 || entity.Position.Y >= 100 {
+			t.Fatalf("Expected creatures kept on the torus, got %+v", entity.Position)
+		}
+	}
+}
+
+func TestGeometryConfig(t *testing.T) {
+	config := RunConfig{World: RunWorldConfig{Geometry: GeometryTorus}}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if world, err := config.WorldConfig(); err != nil || world.Geometry != GeometryTorus {
+		t.Errorf("Expected a torus world, got %q %v", world.Geometry, err)
+	}
+	config.World.Geometry = "sphere"
+	if err := config.Validate(); err == nil {
+		t.Error("Expected an unknown geometry refused")
+	}
+}
+
+func TestWorldGeometryInterface(t *testing.T) {
+	for _, name := range WorldGeometryNames() {
+		geometry, err := NewWorldGeometry(name, 100, 100)
+		if err != nil || geometry.Name() != name {
+			t.Fatalf("Expected a %s geometry, got %v %v", name, geometry, err)
+		}
+		a, b := Position{X: 10, Y: 20}, Position{X: 13, Y: 24}
+		if dx, dy := geometry.Direction(a, b); geometry.Distance(a, b) != 5 || dx != 3 || dy != 4 {
+			t.Errorf("Expected %s to measure across the middle of the map as usual", name)
+		}
+		if copies := geometry.Copies(a); copies[0] != a {
+			t.Errorf("Expected %s to list a position itself first", name)
+		}
+	}
+	if geometry, _ := NewWorldGeometry("", 100, 100); geometry.Name() != GeometryBox {
+		t.Error("Expected an unnamed geometry to be a box")
+	}
+	if _, err := NewWorldGeometry("hex", 100, 100); err == nil {
+		t.Error("Expected an unknown geometry refused")
+	}
+
+	torus, _ := NewWorldGeometry(GeometryTorus, 100, 100)
+	if dx, dy := torus.Direction(Position{X: 99, Y: 1}, Position{X: 1, Y: 99}); dx != 2 || dy != -2 {
+		t.Errorf("Expected the short way across both joined edges, got %.1f, %.1f", dx, dy)
+	}
+	if copies := torus.Copies(Position{X: 1, Y: 1}); len(copies) != 9 {
+		t.Errorf("Expected a torus position seen across both edges and the corners, got %d", len(copies))
+	}
+
+	// Subsystems measure through the geometry they are given
+	sender := NewEntity(sharedRand, 1, []string{"intelligence", "cooperation"}, "herbivore", Position{X: 1, Y: 50})
+	receiver := NewEntity(sharedRand, 2, []string{"intelligence", "cooperation"}, "herbivore", Position{X: 99, Y: 50})
+	for _, entity := range []*Entity{sender, receiver} {
+		entity.SetTrait("intelligence", 1)
+		entity.SetTrait("cooperation", 1)
+	}
+	communication := NewCommunicationSystem(nil)
+	communication.SendSignal(sender, SignalFood, nil, 0)
+	box, _ := NewWorldGeometry(GeometryBox, 100, 100)
+	if len(communication.ReceiveSignals(receiver, 0, box)) != 0 || len(communication.ReceiveSignals(receiver, 0, torus)) != 1 {
+		t.Error("Expected a signal heard across the joined edge of a torus only")
+	}
+}
+
+func TestPreyAcrossTheWrapEdgeIsClose(t *testing.T) {
+	world := newGeometryTestWorld(116, GeometryTorus)
+	world.AllEntities = nil
+	hunter := NewEntity(world.Rand, 1, []string{"speed", "aggression", "vision"}, "predator", Position{X: 1, Y: 50})
+	hunter.SetTrait("aggression", 0.5)
+	prey := NewEntity(world.Rand, 2, []string{"speed"}, "herbivore", Position{X: 98, Y: 50})
+	world.AllEntities = []*Entity{hunter, prey}
+
+	if nearby := world.ViewportSystem.findNearbyEntities(hunter, world.AllEntities, 5, world.Geometry()); len(nearby) != 1 {
+		t.Errorf("Expected prey 3 units away across the edge found nearby, got %d", len(nearby))
+	}
+	hunter.MoveTo(prey.Position.X, prey.Position.Y, 1, world.Geometry())
+	if hunter.Position.X != 0 || world.Distance(hunter.Position, prey.Position) != 2 {
+		t.Errorf("Expected the hunter to close in westward across the edge, got %+v", hunter.Position)
+	}
+
+	// A carcass across the edge is food within reach
+	prey.IsAlive = false
+	if x, _, found := hunter.SeekNutrition(nil, world.AllEntities, 5, world.Geometry()); !found || x != prey.Position.X {
+		t.Errorf("Expected the carcass across the edge sought, got %v at %.1f", found, x)
+	}
+
+	// The hunter's player sees the cells across the edge
+	preyX, preyY := world.worldToGridCoords(prey.Position.X, prey.Position.Y)
+	if visibility := world.FogOfWar.Visibility(world, "hunter", []string{"predator"}); visibility[GridPoint{X: preyX, Y: preyY}] != FogVisible {
+		t.Errorf("Expected the prey's cell across the edge in sight")
+	}
+}
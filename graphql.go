@@ -0,0 +1,807 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// graphQLMaxDepth is the deepest nesting of selections a query may ask for
+const graphQLMaxDepth = 12
+
+// GraphQLError is an error met parsing or running a query
+type GraphQLError struct {
+	Message string `json:"message"`
+}
+
+// GraphQLResponse is the result of a query: the data asked for, or null and the errors
+// that stopped it
+type GraphQLResponse struct {
+	Data   interface{}    `json:"data"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
+
+// graphQLArg is an argument a field accepts; a type ending in ! is required
+type graphQLArg struct {
+	Name string
+	Type string
+}
+
+// graphQLField is one field of a type: its result type, such as Int or [Entity], its
+// arguments and how it is resolved from the parent value
+type graphQLField struct {
+	Type        string
+	Args        []graphQLArg
+	Description string
+	Resolve     func(q *graphQLQuery, parent interface{}, args map[string]interface{}) interface{}
+}
+
+// graphQLScalars are the leaf types of the schema
+var graphQLScalars = map[string]bool{"Int": true, "Float": true, "String": true, "Boolean": true}
+
+// graphQLTrait is one trait of an entity
+type graphQLTrait struct {
+	Name  string
+	Value float64
+}
+
+// graphQLResource is one stock a tribe holds
+type graphQLResource struct {
+	Name   string
+	Amount float64
+}
+
+// graphQLRelationship is a symbiotic relationship seen from one of its partners
+type graphQLRelationship struct {
+	*SymbioticRelationship
+	entityID int
+}
+
+// relationshipTypeName is the name a relationship type goes by in reports
+func relationshipTypeName(relType RelationshipType) string {
+	switch relType {
+	case RelationshipParasitic:
+		return "parasitic"
+	case RelationshipMutualistic:
+		return "mutualistic"
+	}
+	return "commensal"
+}
+
+// graphQLLimitArg lets a list of entities be cut short
+var graphQLLimitArg = graphQLArg{Name: "limit", Type: "Int"}
+
+// graphQLGetter is a field without arguments read straight off its parent value
+func graphQLGetter[T any](fieldType, description string, get func(T) interface{}) graphQLField {
+	return graphQLField{Type: fieldType, Description: description,
+		Resolve: func(_ *graphQLQuery, parent interface{}, _ map[string]interface{}) interface{} {
+			return get(parent.(T))
+		}}
+}
+
+// graphQLTypes is the schema over the world model: species, their populations and entities,
+// and each entity's traits, relationships and tribe
+var graphQLTypes = map[string]map[string]graphQLField{
+	"Query": {
+		"tick": {Type: "Int", Description: "Current simulation tick",
+			Resolve: func(q *graphQLQuery, _ interface{}, _ map[string]interface{}) interface{} { return q.world.Tick }},
+		"species": {Type: "[Species]", Args: []graphQLArg{{"name", "String"}}, Description: "Species with living members, or the one named",
+			Resolve: func(q *graphQLQuery, _ interface{}, args map[string]interface{}) interface{} { return q.species(args) }},
+		"entity": {Type: "Entity", Args: []graphQLArg{{"id", "Int!"}}, Description: "An entity by ID, living or dead",
+			Resolve: func(q *graphQLQuery, _ interface{}, args map[string]interface{}) interface{} {
+				return q.entityByID(args["id"].(int))
+			}},
+		"entities": {Type: "[Entity]", Args: []graphQLArg{{"species", "String"}, graphQLLimitArg}, Description: "Living entities, optionally of one species",
+			Resolve: func(q *graphQLQuery, _ interface{}, args map[string]interface{}) interface{} {
+				species, _ := args["species"].(string)
+				return limitEntities(livingEntities(q.world.AllEntities, species), args)
+			}},
+		"tribes": {Type: "[Tribe]", Description: "Every tribe",
+			Resolve: func(q *graphQLQuery, _ interface{}, _ map[string]interface{}) interface{} {
+				if q.world.CivilizationSystem == nil {
+					return []*Tribe{}
+				}
+				return q.world.CivilizationSystem.Tribes
+			}},
+		"tribe": {Type: "Tribe", Args: []graphQLArg{{"id", "Int!"}}, Description: "A tribe by ID",
+			Resolve: func(q *graphQLQuery, _ interface{}, args map[string]interface{}) interface{} {
+				return q.tribeByID(args["id"].(int))
+			}},
+	},
+	"Species": {
+		"name": graphQLGetter("String", "", func(name string) interface{} { return name }),
+		"count": {Type: "Int", Description: "Living members",
+			Resolve: func(q *graphQLQuery, parent interface{}, _ map[string]interface{}) interface{} {
+				return len(livingEntities(q.world.AllEntities, parent.(string)))
+			}},
+		"population": {Type: "Population", Description: "The species' evolving population",
+			Resolve: func(q *graphQLQuery, parent interface{}, _ map[string]interface{}) interface{} {
+				return q.world.Populations[parent.(string)]
+			}},
+		"entities": {Type: "[Entity]", Args: []graphQLArg{graphQLLimitArg}, Description: "Living members",
+			Resolve: func(q *graphQLQuery, parent interface{}, args map[string]interface{}) interface{} {
+				return limitEntities(livingEntities(q.world.AllEntities, parent.(string)), args)
+			}},
+		"mean_trait": {Type: "Float", Args: []graphQLArg{{"name", "String!"}}, Description: "Mean of a trait over the living members",
+			Resolve: func(q *graphQLQuery, parent interface{}, args map[string]interface{}) interface{} {
+				members := livingEntities(q.world.AllEntities, parent.(string))
+				if len(members) == 0 {
+					return nil
+				}
+				total := 0.0
+				for _, member := range members {
+					total += member.GetTrait(args["name"].(string))
+				}
+				return total / float64(len(members))
+			}},
+	},
+	"Population": {
+		"species":           graphQLGetter("String", "", func(p *Population) interface{} { return p.Species }),
+		"generation":        graphQLGetter("Int", "", func(p *Population) interface{} { return p.Generation }),
+		"mutation_rate":     graphQLGetter("Float", "", func(p *Population) interface{} { return p.MutationRate }),
+		"mutation_strength": graphQLGetter("Float", "", func(p *Population) interface{} { return p.MutationStrength }),
+		"trait_names":       graphQLGetter("[String]", "Traits the population evolves", func(p *Population) interface{} { return p.TraitNames }),
+		"size": graphQLGetter("Int", "Living members", func(p *Population) interface{} {
+			return len(livingEntities(p.Entities, ""))
+		}),
+		"entities": {Type: "[Entity]", Args: []graphQLArg{graphQLLimitArg}, Description: "Living members",
+			Resolve: func(_ *graphQLQuery, parent interface{}, args map[string]interface{}) interface{} {
+				return limitEntities(livingEntities(parent.(*Population).Entities, ""), args)
+			}},
+	},
+	"Entity": {
+		"id":           graphQLGetter("Int", "", func(e *Entity) interface{} { return e.ID }),
+		"species":      graphQLGetter("String", "", func(e *Entity) interface{} { return e.Species }),
+		"generation":   graphQLGetter("Int", "", func(e *Entity) interface{} { return e.Generation }),
+		"alive":        graphQLGetter("Boolean", "", func(e *Entity) interface{} { return e.IsAlive }),
+		"energy":       graphQLGetter("Float", "", func(e *Entity) interface{} { return e.Energy }),
+		"fitness":      graphQLGetter("Float", "", func(e *Entity) interface{} { return e.Fitness }),
+		"age":          graphQLGetter("Int", "", func(e *Entity) interface{} { return e.Age }),
+		"max_lifespan": graphQLGetter("Int", "", func(e *Entity) interface{} { return e.MaxLifespan }),
+		"sex":          graphQLGetter("String", "female, male or asexual", func(e *Entity) interface{} { return entitySex(e) }),
+		"x":            graphQLGetter("Float", "", func(e *Entity) interface{} { return e.Position.X }),
+		"y":            graphQLGetter("Float", "", func(e *Entity) interface{} { return e.Position.Y }),
+		"traits": graphQLGetter("[Trait]", "Every trait, by name", func(e *Entity) interface{} {
+			traits := make([]graphQLTrait, 0, len(e.Traits))
+			for _, name := range sortedKeys(e.Traits) {
+				traits = append(traits, graphQLTrait{Name: name, Value: e.GetTrait(name)})
+			}
+			return traits
+		}),
+		"trait": {Type: "Float", Args: []graphQLArg{{"name", "String!"}}, Description: "The value of one trait",
+			Resolve: func(_ *graphQLQuery, parent interface{}, args map[string]interface{}) interface{} {
+				return parent.(*Entity).GetTrait(args["name"].(string))
+			}},
+		"tribe": {Type: "Tribe",
+			Resolve: func(q *graphQLQuery, parent interface{}, _ map[string]interface{}) interface{} {
+				return q.tribeByID(parent.(*Entity).TribeID)
+			}},
+		"relationships": {Type: "[Relationship]", Description: "Active symbiotic relationships as host or symbiont",
+			Resolve: func(q *graphQLQuery, parent interface{}, _ map[string]interface{}) interface{} {
+				return q.relationshipsOf(parent.(*Entity).ID)
+			}},
+	},
+	"Trait": {
+		"name":  graphQLGetter("String", "", func(t graphQLTrait) interface{} { return t.Name }),
+		"value": graphQLGetter("Float", "", func(t graphQLTrait) interface{} { return t.Value }),
+	},
+	"Relationship": {
+		"id":   graphQLGetter("Int", "", func(r graphQLRelationship) interface{} { return r.ID }),
+		"type": graphQLGetter("String", "parasitic, mutualistic or commensal", func(r graphQLRelationship) interface{} { return relationshipTypeName(r.Type) }),
+		"role": graphQLGetter("String", "Whether the entity is the host or the symbiont", func(r graphQLRelationship) interface{} {
+			if r.HostID == r.entityID {
+				return "host"
+			}
+			return "symbiont"
+		}),
+		"strength":     graphQLGetter("Float", "", func(r graphQLRelationship) interface{} { return r.Strength }),
+		"duration":     graphQLGetter("Int", "", func(r graphQLRelationship) interface{} { return r.Duration }),
+		"virulence":    graphQLGetter("Float", "", func(r graphQLRelationship) interface{} { return r.Virulence }),
+		"transmission": graphQLGetter("Float", "", func(r graphQLRelationship) interface{} { return r.Transmission }),
+		"partner": {Type: "Entity", Description: "The other side of the relationship, if it is an entity",
+			Resolve: func(q *graphQLQuery, parent interface{}, _ map[string]interface{}) interface{} {
+				relationship := parent.(graphQLRelationship)
+				if relationship.HostID == relationship.entityID {
+					return q.entityByID(relationship.SymbiontID)
+				}
+				return q.entityByID(relationship.HostID)
+			}},
+	},
+	"Tribe": {
+		"id":         graphQLGetter("Int", "", func(t *Tribe) interface{} { return t.ID }),
+		"name":       graphQLGetter("String", "", func(t *Tribe) interface{} { return t.Name }),
+		"tech_level": graphQLGetter("Int", "", func(t *Tribe) interface{} { return t.TechLevel }),
+		"size":       graphQLGetter("Int", "", func(t *Tribe) interface{} { return len(t.Members) }),
+		"leader":     graphQLGetter("Entity", "", func(t *Tribe) interface{} { return t.Leader }),
+		"members": {Type: "[Entity]", Args: []graphQLArg{graphQLLimitArg},
+			Resolve: func(_ *graphQLQuery, parent interface{}, args map[string]interface{}) interface{} {
+				return limitEntities(parent.(*Tribe).Members, args)
+			}},
+		"resources": graphQLGetter("[Resource]", "Stocks held, by name", func(t *Tribe) interface{} {
+			resources := make([]graphQLResource, 0, len(t.Resources))
+			for _, name := range sortedKeys(t.Resources) {
+				resources = append(resources, graphQLResource{Name: name, Amount: t.Resources[name]})
+			}
+			return resources
+		}),
+	},
+	"Resource": {
+		"name":   graphQLGetter("String", "", func(r graphQLResource) interface{} { return r.Name }),
+		"amount": graphQLGetter("Float", "", func(r graphQLResource) interface{} { return r.Amount }),
+	},
+}
+
+// GraphQLSchema describes the schema in the GraphQL schema language
+func GraphQLSchema() string {
+	var schema strings.Builder
+	for i, typeName := range sortedKeys(graphQLTypes) {
+		if i > 0 {
+			schema.WriteString("\n")
+		}
+		fmt.Fprintf(&schema, "type %s {\n", typeName)
+		fields := graphQLTypes[typeName]
+		for _, name := range sortedKeys(fields) {
+			field := fields[name]
+			if field.Description != "" {
+				fmt.Fprintf(&schema, "  # %s\n", field.Description)
+			}
+			args := make([]string, len(field.Args))
+			for j, arg := range field.Args {
+				args[j] = arg.Name + ": " + arg.Type
+			}
+			if len(args) > 0 {
+				fmt.Fprintf(&schema, "  %s(%s): %s\n", name, strings.Join(args, ", "), field.Type)
+			} else {
+				fmt.Fprintf(&schema, "  %s: %s\n", name, field.Type)
+			}
+		}
+		schema.WriteString("}\n")
+	}
+	return schema.String()
+}
+
+// livingEntities lists the living entities of a species, or of every species if none is given
+func livingEntities(entities []*Entity, species string) []*Entity {
+	living := make([]*Entity, 0)
+	for _, entity := range entities {
+		if entity != nil && entity.IsAlive && (species == "" || entity.Species == species) {
+			living = append(living, entity)
+		}
+	}
+	return living
+}
+
+// limitEntities cuts a list of entities to the limit argument, if one was given
+func limitEntities(entities []*Entity, args map[string]interface{}) []*Entity {
+	if limit, ok := args["limit"].(int); ok && limit >= 0 && limit < len(entities) {
+		return entities[:limit]
+	}
+	return entities
+}
+
+// graphQLQuery is one query being run against the world, with the lookups its resolvers
+// share built on first use
+type graphQLQuery struct {
+	world         *World
+	variables     map[string]interface{}
+	entities      map[int]*Entity
+	relationships map[int][]graphQLRelationship
+}
+
+// species lists the species with living members, or just the one named if it exists
+func (q *graphQLQuery) species(args map[string]interface{}) []string {
+	names := make(map[string]bool)
+	for _, entity := range livingEntities(q.world.AllEntities, "") {
+		names[entity.Species] = true
+	}
+	if name, ok := args["name"].(string); ok {
+		if names[name] || q.world.Populations[name] != nil {
+			return []string{name}
+		}
+		return []string{}
+	}
+	return sortedKeys(names)
+}
+
+// entityByID finds an entity, living or dead
+func (q *graphQLQuery) entityByID(id int) *Entity {
+	if q.entities == nil {
+		q.entities = make(map[int]*Entity, len(q.world.AllEntities))
+		for _, entity := range q.world.AllEntities {
+			q.entities[entity.ID] = entity
+		}
+	}
+	return q.entities[id]
+}
+
+// tribeByID finds a tribe, or nil for the wild
+func (q *graphQLQuery) tribeByID(id int) *Tribe {
+	if id == 0 || q.world.CivilizationSystem == nil {
+		return nil
+	}
+	for _, tribe := range q.world.CivilizationSystem.Tribes {
+		if tribe.ID == id {
+			return tribe
+		}
+	}
+	return nil
+}
+
+// relationshipsOf lists an entity's active symbiotic relationships
+func (q *graphQLQuery) relationshipsOf(id int) []graphQLRelationship {
+	if q.relationships == nil {
+		q.relationships = make(map[int][]graphQLRelationship)
+		if q.world.SymbioticRelationships != nil {
+			for _, relationship := range q.world.SymbioticRelationships.Relationships {
+				if !relationship.IsActive {
+					continue
+				}
+				q.relationships[relationship.HostID] = append(q.relationships[relationship.HostID], graphQLRelationship{relationship, relationship.HostID})
+				q.relationships[relationship.SymbiontID] = append(q.relationships[relationship.SymbiontID], graphQLRelationship{relationship, relationship.SymbiontID})
+			}
+		}
+	}
+	if relationships := q.relationships[id]; relationships != nil {
+		return relationships
+	}
+	return []graphQLRelationship{}
+}
+
+// ExecuteGraphQL runs a query over the world with the given variables. Only queries are
+// supported; fragments, directives and mutations are not.
+func (w *World) ExecuteGraphQL(query string, variables map[string]interface{}) GraphQLResponse {
+	document, err := parseGraphQL(query)
+	if err != nil {
+		return GraphQLResponse{Errors: []GraphQLError{{Message: err.Error()}}}
+	}
+	q := &graphQLQuery{world: w, variables: make(map[string]interface{})}
+	for name, value := range document.defaults {
+		q.variables[name] = value
+	}
+	for name, value := range variables {
+		q.variables[name] = value
+	}
+	data, err := q.resolveObject("Query", nil, document.selections, 1)
+	if err != nil {
+		return GraphQLResponse{Errors: []GraphQLError{{Message: err.Error()}}}
+	}
+	return GraphQLResponse{Data: data}
+}
+
+// graphQLEntry is one field of a result object
+type graphQLEntry struct {
+	Key   string
+	Value interface{}
+}
+
+// graphQLObject is a result object, whose fields keep the order they were selected in
+type graphQLObject []graphQLEntry
+
+// MarshalJSON writes the object's fields in selection order
+func (o graphQLObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, entry := range o {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		key, _ := json.Marshal(entry.Key)
+		value, err := json.Marshal(entry.Value)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// resolveObject resolves the selected fields of a value of an object type
+func (q *graphQLQuery) resolveObject(typeName string, parent interface{}, selections []graphQLSelection, depth int) (graphQLObject, error) {
+	if depth > graphQLMaxDepth {
+		return nil, fmt.Errorf("query is nested more than %d levels deep", graphQLMaxDepth)
+	}
+	object := make(graphQLObject, 0, len(selections))
+	for _, selection := range selections {
+		key := selection.Name
+		if selection.Alias != "" {
+			key = selection.Alias
+		}
+		if selection.Name == "__typename" {
+			object = append(object, graphQLEntry{key, typeName})
+			continue
+		}
+		field, ok := graphQLTypes[typeName][selection.Name]
+		if !ok {
+			return nil, fmt.Errorf("cannot query field %q on type %s", selection.Name, typeName)
+		}
+		args, err := q.arguments(typeName, selection, field)
+		if err != nil {
+			return nil, err
+		}
+		value, err := q.complete(field.Type, field.Resolve(q, parent, args), selection, depth)
+		if err != nil {
+			return nil, err
+		}
+		object = append(object, graphQLEntry{key, value})
+	}
+	return object, nil
+}
+
+// complete turns a resolved value into its result: a scalar, an object of its selected
+// fields, a list of either, or null
+func (q *graphQLQuery) complete(fieldType string, value interface{}, selection graphQLSelection, depth int) (interface{}, error) {
+	fieldType = strings.TrimSuffix(fieldType, "!")
+	reflected := reflect.ValueOf(value)
+	if value == nil || ((reflected.Kind() == reflect.Ptr || reflected.Kind() == reflect.Map) && reflected.IsNil()) {
+		return nil, nil
+	}
+	if strings.HasPrefix(fieldType, "[") {
+		itemType := strings.TrimSuffix(fieldType[1:], "]")
+		items := make([]interface{}, 0, reflected.Len())
+		for i := 0; i < reflected.Len(); i++ {
+			item, err := q.complete(itemType, reflected.Index(i).Interface(), selection, depth)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+	if graphQLScalars[fieldType] {
+		if len(selection.Selections) > 0 {
+			return nil, fmt.Errorf("field %q is a %s and has no subfields", selection.Name, fieldType)
+		}
+		if number, ok := value.(float64); ok && (math.IsNaN(number) || math.IsInf(number, 0)) {
+			return nil, nil
+		}
+		return value, nil
+	}
+	if len(selection.Selections) == 0 {
+		return nil, fmt.Errorf("field %q of type %s needs a selection of subfields", selection.Name, fieldType)
+	}
+	return q.resolveObject(fieldType, value, selection.Selections, depth+1)
+}
+
+// arguments checks a selection's arguments against its field and fills in variables
+func (q *graphQLQuery) arguments(typeName string, selection graphQLSelection, field graphQLField) (map[string]interface{}, error) {
+	args := make(map[string]interface{}, len(selection.Args))
+	for name := range selection.Args {
+		known := false
+		for _, arg := range field.Args {
+			known = known || arg.Name == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown argument %q on field %s.%s", name, typeName, selection.Name)
+		}
+	}
+	for _, arg := range field.Args {
+		value := selection.Args[arg.Name]
+		if variable, ok := value.(graphQLVariable); ok {
+			value = q.variables[string(variable)]
+		}
+		if value == nil {
+			if strings.HasSuffix(arg.Type, "!") {
+				return nil, fmt.Errorf("argument %q of field %s.%s is required", arg.Name, typeName, selection.Name)
+			}
+			continue
+		}
+		coerced, ok := coerceGraphQLValue(strings.TrimSuffix(arg.Type, "!"), value)
+		if !ok {
+			return nil, fmt.Errorf("argument %q of field %s.%s must be of type %s", arg.Name, typeName, selection.Name, strings.TrimSuffix(arg.Type, "!"))
+		}
+		args[arg.Name] = coerced
+	}
+	return args, nil
+}
+
+// coerceGraphQLValue converts a literal or JSON variable value to a scalar type
+func coerceGraphQLValue(scalar string, value interface{}) (interface{}, bool) {
+	switch scalar {
+	case "Int":
+		switch number := value.(type) {
+		case int:
+			return number, true
+		case float64:
+			if number == math.Trunc(number) {
+				return int(number), true
+			}
+		}
+	case "Float":
+		switch number := value.(type) {
+		case int:
+			return float64(number), true
+		case float64:
+			return number, true
+		}
+	case "String":
+		text, ok := value.(string)
+		return text, ok
+	case "Boolean":
+		flag, ok := value.(bool)
+		return flag, ok
+	}
+	return nil, false
+}
+
+// graphQLVariable is a reference to a query variable in an argument
+type graphQLVariable string
+
+// graphQLSelection is one selected field with its alias, arguments and subfields
+type graphQLSelection struct {
+	Alias      string
+	Name       string
+	Args       map[string]interface{}
+	Selections []graphQLSelection
+}
+
+// graphQLDocument is a parsed query: its selections and the defaults of its variables
+type graphQLDocument struct {
+	selections []graphQLSelection
+	defaults   map[string]interface{}
+}
+
+// graphQLParser reads a query one token at a time
+type graphQLParser struct {
+	source []rune
+	pos    int
+	token  string // Current token; punctuators are themselves, strings keep their quote
+}
+
+// parseGraphQL parses a query document holding a single query
+func parseGraphQL(source string) (*graphQLDocument, error) {
+	p := &graphQLParser{source: []rune(source)}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	document := &graphQLDocument{defaults: make(map[string]interface{})}
+	switch p.token {
+	case "{":
+	case "query":
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.isName() {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		if p.token == "(" {
+			if err := p.variableDefinitions(document); err != nil {
+				return nil, err
+			}
+		}
+	case "mutation", "subscription":
+		return nil, fmt.Errorf("only queries are supported, not %ss", p.token)
+	default:
+		return nil, fmt.Errorf("expected a query, found %q", p.token)
+	}
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	if p.token != "" {
+		return nil, fmt.Errorf("expected the end of the query, found %q; only one operation is supported", p.token)
+	}
+	document.selections = selections
+	return document, nil
+}
+
+// next moves to the next token, skipping whitespace, commas and comments
+func (p *graphQLParser) next() error {
+	for p.pos < len(p.source) {
+		r := p.source[p.pos]
+		if r == '#' {
+			for p.pos < len(p.source) && p.source[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		if !unicode.IsSpace(r) && r != ',' {
+			break
+		}
+		p.pos++
+	}
+	if p.pos >= len(p.source) {
+		p.token = ""
+		return nil
+	}
+
+	start := p.pos
+	r := p.source[p.pos]
+	switch {
+	case strings.ContainsRune("{}():!$=[]@", r):
+		p.pos++
+	case r == '.':
+		if p.pos+2 < len(p.source) && string(p.source[p.pos:p.pos+3]) == "..." {
+			return fmt.Errorf("fragments are not supported")
+		}
+		return fmt.Errorf("unexpected %q", r)
+	case r == '"':
+		p.pos++
+		for p.pos < len(p.source) && p.source[p.pos] != '"' {
+			if p.source[p.pos] == '\\' {
+				p.pos++
+			}
+			p.pos++
+		}
+		if p.pos >= len(p.source) {
+			return fmt.Errorf("unterminated string")
+		}
+		p.pos++
+	case r == '_' || unicode.IsLetter(r):
+		for p.pos < len(p.source) && (p.source[p.pos] == '_' || unicode.IsLetter(p.source[p.pos]) || unicode.IsDigit(p.source[p.pos])) {
+			p.pos++
+		}
+	case r == '-' || unicode.IsDigit(r):
+		p.pos++
+		for p.pos < len(p.source) && strings.ContainsRune("0123456789.eE+-", p.source[p.pos]) {
+			p.pos++
+		}
+	default:
+		return fmt.Errorf("unexpected %q", r)
+	}
+	p.token = string(p.source[start:p.pos])
+	return nil
+}
+
+// isName reports whether the current token is a name
+func (p *graphQLParser) isName() bool {
+	return p.token != "" && (p.token[0] == '_' || unicode.IsLetter(rune(p.token[0])))
+}
+
+// expect checks the current token and moves past it
+func (p *graphQLParser) expect(token string) error {
+	if p.token != token {
+		return fmt.Errorf("expected %q, found %q", token, p.token)
+	}
+	return p.next()
+}
+
+// name reads a name
+func (p *graphQLParser) name() (string, error) {
+	if !p.isName() {
+		return "", fmt.Errorf("expected a name, found %q", p.token)
+	}
+	name := p.token
+	return name, p.next()
+}
+
+// variableDefinitions reads the query's variables, keeping their defaults; their types are
+// checked when the arguments they fill are
+func (p *graphQLParser) variableDefinitions(document *graphQLDocument) error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for p.token != ")" {
+		if err := p.expect("$"); err != nil {
+			return err
+		}
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		for p.token == "[" || p.token == "]" || p.token == "!" || p.isName() {
+			if err := p.next(); err != nil {
+				return err
+			}
+		}
+		if p.token == "=" {
+			if err := p.next(); err != nil {
+				return err
+			}
+			value, err := p.value()
+			if err != nil {
+				return err
+			}
+			document.defaults[name] = value
+		}
+	}
+	return p.next()
+}
+
+// selectionSet reads the fields between braces
+func (p *graphQLParser) selectionSet() ([]graphQLSelection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	selections := make([]graphQLSelection, 0)
+	for p.token != "}" {
+		if p.token == "" {
+			return nil, fmt.Errorf("unterminated selection set")
+		}
+		if p.token == "@" {
+			return nil, fmt.Errorf("directives are not supported")
+		}
+		selection := graphQLSelection{Args: make(map[string]interface{})}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		selection.Name = name
+		if p.token == ":" {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if selection.Name, err = p.name(); err != nil {
+				return nil, err
+			}
+			selection.Alias = name
+		}
+		if p.token == "(" {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			for p.token != ")" {
+				arg, err := p.name()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+				if selection.Args[arg], err = p.value(); err != nil {
+					return nil, err
+				}
+			}
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		if p.token == "{" {
+			if selection.Selections, err = p.selectionSet(); err != nil {
+				return nil, err
+			}
+		}
+		selections = append(selections, selection)
+	}
+	if len(selections) == 0 {
+		return nil, fmt.Errorf("empty selection set")
+	}
+	return selections, p.next()
+}
+
+// value reads an argument value: a variable or a scalar literal. Enum values are read as
+// strings.
+func (p *graphQLParser) value() (interface{}, error) {
+	token := p.token
+	switch {
+	case token == "$":
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		return graphQLVariable(name), err
+	case token == "[" || token == "{":
+		return nil, fmt.Errorf("list and object arguments are not supported")
+	case strings.HasPrefix(token, `"`):
+		text, err := strconv.Unquote(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", token)
+		}
+		return text, p.next()
+	case token == "true" || token == "false":
+		return token == "true", p.next()
+	case token == "null":
+		return nil, p.next()
+	case p.isName():
+		return token, p.next()
+	}
+	if number, err := strconv.Atoi(token); err == nil {
+		return number, p.next()
+	}
+	if number, err := strconv.ParseFloat(token, 64); err == nil {
+		return number, p.next()
+	}
+	return nil, fmt.Errorf("expected a value, found %q", token)
+}
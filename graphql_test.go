@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// newGraphQLTestWorld makes an empty world with two wolves, one carrying a parasite, and a
+// tribe of one
+func newGraphQLTestWorld() *World {
+	world := newEmptyTestWorld(100)
+	world.Populations = map[string]*Population{"Wolf": {Species: "Wolf", Generation: 3, TraitNames: []string{"speed"}}}
+
+	for i := 1; i <= 2; i++ {
+		wolf := NewEntity(sharedRand, i, []string{"speed"}, "Wolf", Position{X: 10, Y: 10})
+		wolf.SetTrait("speed", 0.2*float64(i))
+		world.AllEntities = append(world.AllEntities, wolf)
+		world.Populations["Wolf"].Entities = append(world.Populations["Wolf"].Entities, wolf)
+	}
+	chief := NewEntity(sharedRand, 3, []string{"intelligence"}, "Folk", Position{X: 50, Y: 50})
+	chief.TribeID = 1
+	world.AllEntities = append(world.AllEntities, chief)
+	world.CivilizationSystem.Tribes = []*Tribe{NewTribe(1, "Ashgrove", chief)}
+
+	world.SymbioticRelationships.Relationships = append(world.SymbioticRelationships.Relationships, &SymbioticRelationship{
+		ID: 1, HostID: 1, SymbiontID: 2, Type: RelationshipParasitic, Strength: 0.5, Transmission: 0.3, IsActive: true,
+	})
+	return world
+}
+
+// graphQLJSON runs a query and renders its response as JSON
+func graphQLJSON(t *testing.T, world *World, query string, variables map[string]interface{}) string {
+	t.Helper()
+	encoded, err := json.Marshal(world.ExecuteGraphQL(query, variables))
+	if err != nil {
+		t.Fatalf("Failed to encode the response: %v", err)
+	}
+	return string(encoded)
+}
+
+func TestGraphQLNestedQueries(t *testing.T) {
+	world := newGraphQLTestWorld()
+
+	got := graphQLJSON(t, world, `query Pack($limit: Int = 1) {
+		species(name: "Wolf") {
+			name
+			population { generation trait_names size entities(limit: $limit) { id } }
+			fast: mean_trait(name: "speed")
+		}
+	}`, nil)
+	want := `{"data":{"species":[{"name":"Wolf","population":{"generation":3,"trait_names":["speed"],"size":2,"entities":[{"id":1}]},"fast":0.30000000000000004}]}}`
+	if got != want {
+		t.Errorf("Expected the species with its population and entities in selection order,\n got %s\nwant %s", got, want)
+	}
+
+	got = graphQLJSON(t, world, `{ entity(id: $id) { __typename species trait(name: "speed")
+		relationships { type role partner { id relationships { role } } } tribe { name } } }`, map[string]interface{}{"id": 1.0})
+	want = `{"data":{"entity":{"__typename":"Entity","species":"Wolf","trait":0.2,` +
+		`"relationships":[{"type":"parasitic","role":"host","partner":{"id":2,"relationships":[{"role":"symbiont"}]}}],"tribe":null}}}`
+	if got != want {
+		t.Errorf("Expected the entity's relationships followed to its partner,\n got %s\nwant %s", got, want)
+	}
+
+	got = graphQLJSON(t, world, `{ tribes { name leader { species tribe { size } } } }`, nil)
+	if want := `{"data":{"tribes":[{"name":"Ashgrove","leader":{"species":"Folk","tribe":{"size":1}}}]}}`; got != want {
+		t.Errorf("Expected tribes with their leaders, got %s", got)
+	}
+}
+
+func TestGraphQLRejectsInvalidQueries(t *testing.T) {
+	world := newGraphQLTestWorld()
+	for query, message := range map[string]string{
+		`{ species { diet } }`:                    `cannot query field "diet" on type Species`,
+		`{ entity { id } }`:                       `argument "id" of field Query.entity is required`,
+		`{ entity(id: "one") { id } }`:            `must be of type Int`,
+		`{ species }`:                             `needs a selection of subfields`,
+		`{ tick { value } }`:                      `has no subfields`,
+		`{ species { ...wolfFields } }`:           `fragments are not supported`,
+		`mutation { tick }`:                       `only queries are supported`,
+		`{ entities(colour: "grey") { id } }`:     `unknown argument "colour"`,
+		`{ species { name } } { tick }`:           `only one operation is supported`,
+		`{ species { population { species } }`:    `unterminated selection set`,
+		`{ entities(limit: 1) { id tribe { } } }`: `empty selection set`,
+	} {
+		response := world.ExecuteGraphQL(query, nil)
+		if response.Data != nil || len(response.Errors) != 1 || !strings.Contains(response.Errors[0].Message, message) {
+			t.Errorf("Expected %s to fail with %q, got %+v", query, message, response)
+		}
+	}
+
+	deep := "{ entity(id: 1) { " + strings.Repeat("relationships { partner { ", graphQLMaxDepth) + "id" +
+		strings.Repeat(" } }", graphQLMaxDepth) + " } }"
+	if response := world.ExecuteGraphQL(deep, nil); len(response.Errors) == 0 {
+		t.Errorf("Expected a query nested too deeply to be refused")
+	}
+}
+
+func TestGraphQLAPI(t *testing.T) {
+	wi := NewWebInterface(newGraphQLTestWorld())
+
+	rec := httptest.NewRecorder()
+	wi.handleGraphQL(rec, httptest.NewRequest(http.MethodGet, "/api/graphql", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "type Species {") ||
+		!strings.Contains(rec.Body.String(), "entities(limit: Int): [Entity]") {
+		t.Errorf("Expected the schema from a GET without a query, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	body := `{"query": "query($name: String!) { species(name: $name) { count } }", "variables": {"name": "Folk"}}`
+	rec = httptest.NewRecorder()
+	wi.handleGraphQL(rec, httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(body)))
+	if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != `{"data":{"species":[{"count":1}]}}` {
+		t.Errorf("Expected a posted query answered, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleGraphQL(rec, httptest.NewRequest(http.MethodGet, "/api/graphql?query="+url.QueryEscape("{ tick }"), nil))
+	if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != `{"data":{"tick":0}}` {
+		t.Errorf("Expected a query in the URL answered, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleGraphQL(rec, httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader("{")))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected a malformed request refused, got %d", rec.Code)
+	}
+}
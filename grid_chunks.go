@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log"
+
+	"github.com/gorilla/websocket"
+)
+
+// gridChunkSize is the number of cells along each side of a chunk
+const gridChunkSize = 32
+
+// maxChunkSubscription caps the chunks one client may subscribe to, about a million cells
+const maxChunkSubscription = 1024
+
+// ChunkKey identifies a chunk by its column and row among the chunks
+type ChunkKey struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// GridChunk is a square of world grid cells, streamed to clients that subscribe to the part
+// of a large world they are looking at instead of taking the viewport's grid every frame
+type GridChunk struct {
+	X      int        `json:"x"`      // Chunk column
+	Y      int        `json:"y"`      // Chunk row
+	GridX  int        `json:"grid_x"` // World grid column of the first cell
+	GridY  int        `json:"grid_y"` // World grid row of the first cell
+	Width  int        `json:"width"`  // Cells across; chunks at the world's edge may be narrower
+	Height int        `json:"height"`
+	Cells  []CellData `json:"cells"` // Row by row; X and Y are positions within the chunk
+}
+
+// ChunkSubscription is the area of the world grid a client is looking at, sent as the data of
+// a subscribe_chunks action. The margin of extra cells around it lets the client pan a little
+// before it needs to subscribe again.
+type ChunkSubscription struct {
+	X      int `json:"x"` // Grid column of the left edge
+	Y      int `json:"y"` // Grid row of the top edge
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	Margin int `json:"margin"`
+}
+
+// builtChunk is a chunk built once per frame, with a hash of its cells so unchanged chunks
+// are not sent again
+type builtChunk struct {
+	chunk GridChunk
+	hash  uint64
+}
+
+// Keys lists the chunks covering the area and its margin in a grid of the given size, row by
+// row
+func (s ChunkSubscription) Keys(gridWidth, gridHeight int) ([]ChunkKey, error) {
+	if s.Width <= 0 || s.Height <= 0 || s.Margin < 0 {
+		return nil, fmt.Errorf("chunk subscription needs a positive width and height")
+	}
+	left := max(0, s.X-s.Margin) / gridChunkSize
+	top := max(0, s.Y-s.Margin) / gridChunkSize
+	right := min(gridWidth, s.X+s.Width+s.Margin) - 1
+	bottom := min(gridHeight, s.Y+s.Height+s.Margin) - 1
+	if right < 0 || bottom < 0 || left*gridChunkSize >= gridWidth || top*gridChunkSize >= gridHeight {
+		return []ChunkKey{}, nil
+	}
+
+	right /= gridChunkSize
+	bottom /= gridChunkSize
+	if (right-left+1)*(bottom-top+1) > maxChunkSubscription {
+		return nil, fmt.Errorf("chunk subscription covers more than %d chunks", maxChunkSubscription)
+	}
+	keys := make([]ChunkKey, 0, (right-left+1)*(bottom-top+1))
+	for y := top; y <= bottom; y++ {
+		for x := left; x <= right; x++ {
+			keys = append(keys, ChunkKey{X: x, Y: y})
+		}
+	}
+	return keys, nil
+}
+
+// BuildGridChunk describes the cells of a chunk; like the rest of the view it must not run
+// while the world is updating
+func (vm *ViewManager) BuildGridChunk(key ChunkKey) GridChunk {
+	chunk := GridChunk{X: key.X, Y: key.Y, GridX: key.X * gridChunkSize, GridY: key.Y * gridChunkSize}
+	chunk.Width = max(0, min(gridChunkSize, vm.world.Config.GridWidth-chunk.GridX))
+	chunk.Height = max(0, min(gridChunkSize, vm.world.Config.GridHeight-chunk.GridY))
+	chunk.Cells = make([]CellData, 0, chunk.Width*chunk.Height)
+	for y := 0; y < chunk.Height; y++ {
+		for x := 0; x < chunk.Width; x++ {
+			chunk.Cells = append(chunk.Cells, vm.buildCellData(chunk.GridX+x, chunk.GridY+y, x, y))
+		}
+	}
+	return chunk
+}
+
+// hashChunkCells hashes a chunk's cells to tell whether it changed
+func hashChunkCells(cells []CellData) uint64 {
+	hash := fnv.New64a()
+	_ = json.NewEncoder(hash).Encode(cells)
+	return hash.Sum64()
+}
+
+// subscribeChunks sets the chunks a client streams, forgetting what it was sent of chunks
+// it no longer subscribes to. A nil list returns the client to the viewport's grid.
+func (c *wsClient) subscribeChunks(keys []ChunkKey) {
+	c.chunkMutex.Lock()
+	defer c.chunkMutex.Unlock()
+	c.chunkKeys = keys
+	if keys == nil {
+		c.chunkHashes = nil
+		return
+	}
+
+	hashes := make(map[ChunkKey]uint64, len(keys))
+	for _, key := range keys {
+		if hash, sent := c.chunkHashes[key]; sent {
+			hashes[key] = hash
+		}
+	}
+	c.chunkHashes = hashes
+}
+
+// subscribedChunks returns the chunks a client streams and whether it streams chunks at all
+func (c *wsClient) subscribedChunks() ([]ChunkKey, bool) {
+	c.chunkMutex.Lock()
+	defer c.chunkMutex.Unlock()
+	return c.chunkKeys, c.chunkKeys != nil
+}
+
+// changedChunks picks the subscribed chunks that differ from what the client was sent last,
+// limited to what a fogged player can see, with their hashes
+func (c *wsClient) changedChunks(built map[ChunkKey]*builtChunk, visibility map[GridPoint]string, fogged bool) ([]GridChunk, map[ChunkKey]uint64) {
+	c.chunkMutex.Lock()
+	defer c.chunkMutex.Unlock()
+
+	changed := make([]GridChunk, 0)
+	hashes := make(map[ChunkKey]uint64)
+	for _, key := range c.chunkKeys {
+		entry, exists := built[key]
+		if !exists {
+			continue
+		}
+		chunk, hash := entry.chunk, entry.hash
+		if fogged {
+			cells := make([]CellData, len(chunk.Cells))
+			for i, cell := range chunk.Cells {
+				cells[i] = fogCell(cell, visibility)
+			}
+			chunk.Cells = cells
+			hash = hashChunkCells(cells)
+		}
+		if sent, exists := c.chunkHashes[key]; exists && sent == hash {
+			continue
+		}
+		changed = append(changed, chunk)
+		hashes[key] = hash
+	}
+	return changed, hashes
+}
+
+// recordChunks notes the chunks a client was sent
+func (c *wsClient) recordChunks(hashes map[ChunkKey]uint64) {
+	c.chunkMutex.Lock()
+	defer c.chunkMutex.Unlock()
+	if c.chunkHashes == nil {
+		return // Unsubscribed meanwhile
+	}
+	for key, hash := range hashes {
+		c.chunkHashes[key] = hash
+	}
+}
+
+// handleSubscribeChunks subscribes a client to the chunks covering an area of the world grid
+func (wi *WebInterface) handleSubscribeChunks(conn *websocket.Conn, data interface{}) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		wi.sendErrorToClient(conn, "Invalid chunk subscription")
+		return
+	}
+	var subscription ChunkSubscription
+	if err := json.Unmarshal(raw, &subscription); err != nil {
+		wi.sendErrorToClient(conn, fmt.Sprintf("Invalid chunk subscription: %v", err))
+		return
+	}
+	keys, err := subscription.Keys(wi.world.Config.GridWidth, wi.world.Config.GridHeight)
+	if err != nil {
+		wi.sendErrorToClient(conn, err.Error())
+		return
+	}
+	client, exists := wi.clientForConn(conn)
+	if !exists {
+		return
+	}
+	client.subscribeChunks(keys)
+}
+
+// handleUnsubscribeChunks returns a client to receiving the viewport's grid
+func (wi *WebInterface) handleUnsubscribeChunks(conn *websocket.Conn) {
+	if client, exists := wi.clientForConn(conn); exists {
+		client.subscribeChunks(nil)
+	}
+}
+
+// chunkedClients reports which connected clients stream chunks, and whether all of them do
+func (wi *WebInterface) chunkedClients() (subscribed map[ChunkKey]bool, allChunked bool) {
+	wi.clientsMutex.RLock()
+	defer wi.clientsMutex.RUnlock()
+	subscribed = make(map[ChunkKey]bool)
+	allChunked = len(wi.clients) > 0
+	for _, client := range wi.clients {
+		keys, chunked := client.subscribedChunks()
+		if !chunked {
+			allChunked = false
+		}
+		for _, key := range keys {
+			subscribed[key] = true
+		}
+	}
+	return subscribed, allChunked
+}
+
+// buildSubscribedChunks builds every chunk some client subscribes to, once for all of them
+func (wi *WebInterface) buildSubscribedChunks(subscribed map[ChunkKey]bool) map[ChunkKey]*builtChunk {
+	built := make(map[ChunkKey]*builtChunk, len(subscribed))
+	for key := range subscribed {
+		chunk := wi.viewManager.BuildGridChunk(key)
+		built[key] = &builtChunk{chunk: chunk, hash: hashChunkCells(chunk.Cells)}
+	}
+	return built
+}
+
+// queueChunkFrame sends a chunk-streaming client the frame without the viewport's grid but
+// with the chunks that changed since it was last sent them
+func (wi *WebInterface) queueChunkFrame(client *wsClient, data *ViewData) {
+	clientData := wi.viewDataForClient(client.conn, data)
+	if clientData == data {
+		copied := *data
+		clientData = &copied
+	}
+	clientData.Grid = nil
+	clientData.ChunkSize = gridChunkSize
+
+	var visibility map[GridPoint]string
+	fogged := false
+	if data.fogOfWar != nil {
+		if playerID, isPlayer := wi.playerForConn(client.conn); isPlayer {
+			visibility, fogged = data.fogOfWar[playerID], true
+		}
+	}
+	chunks, hashes := client.changedChunks(data.chunks, visibility, fogged)
+	clientData.Chunks = chunks
+
+	message, err := json.Marshal(clientData)
+	if err != nil {
+		log.Printf("Error encoding view update: %v", err)
+		return
+	}
+	if wi.queueFrame(client, message) {
+		client.recordChunks(hashes)
+	}
+}
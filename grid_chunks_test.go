@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChunkSubscriptionKeys(t *testing.T) {
+	keys, err := ChunkSubscription{X: 30, Y: 10, Width: 40, Height: 10, Margin: 4}.Keys(100, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ChunkKey{{0, 0}, {1, 0}, {2, 0}}
+	if len(keys) != len(want) || keys[0] != want[0] || keys[2] != want[2] {
+		t.Errorf("Expected columns 0-2 of the first row, got %v", keys)
+	}
+
+	// The margin stops at the world's edge
+	keys, _ = ChunkSubscription{X: 90, Y: 30, Width: 10, Height: 10, Margin: 100}.Keys(100, 40)
+	if len(keys) != 4*2 {
+		t.Errorf("Expected the whole 4x2 chunk world, got %v", keys)
+	}
+	if keys, _ := (ChunkSubscription{X: 500, Y: 0, Width: 10, Height: 10}).Keys(100, 40); len(keys) != 0 {
+		t.Errorf("Expected no chunks outside the world, got %v", keys)
+	}
+	for _, subscription := range []ChunkSubscription{{Width: 0, Height: 5}, {Width: 5000, Height: 5000}} {
+		if _, err := subscription.Keys(5000, 5000); err == nil {
+			t.Errorf("Expected %+v refused", subscription)
+		}
+	}
+}
+
+// nextChunkFrame sends a frame and decodes what a chunk-streaming client receives
+func nextChunkFrame(t *testing.T, wi *WebInterface, client *wsClient) ViewData {
+	t.Helper()
+	wi.sendFrame()
+	data := <-wi.broadcastChan
+	if data.Grid != nil {
+		t.Errorf("Expected the viewport's grid left out when every client streams chunks")
+	}
+	wi.broadcastToClients(data)
+	var frame ViewData
+	if err := json.Unmarshal(<-client.send, &frame); err != nil {
+		t.Fatalf("Failed to decode the frame: %v", err)
+	}
+	return frame
+}
+
+func TestChunkStreamingSendsOnlyChangedChunks(t *testing.T) {
+	world := NewWorld(WorldConfig{Width: 140, Height: 80, GridWidth: 70, GridHeight: 40, PopulationSize: 5})
+	wi := NewWebInterface(world)
+	client := newWSClient(nil, 8)
+	wi.clients[nil] = client
+	keys, err := ChunkSubscription{X: 0, Y: 0, Width: 40, Height: 20}.Keys(70, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.subscribeChunks(keys)
+
+	frame := nextChunkFrame(t, wi, client)
+	if frame.ChunkSize != gridChunkSize || frame.Grid != nil || frame.GridWidth != 70 || len(frame.Chunks) != 2 {
+		t.Fatalf("Expected the two subscribed chunks instead of the grid, got %d chunks", len(frame.Chunks))
+	}
+	second := frame.Chunks[1]
+	if second.X != 1 || second.GridX != 32 || second.Width != 32 || second.Height != 32 || len(second.Cells) != 32*32 {
+		t.Errorf("Expected the second chunk to hold cells 32-63 of rows 0-31, got %+v", second)
+	}
+	if cell := second.Cells[33]; cell.GridX != 33 || cell.GridY != 1 || cell.Biome == "" {
+		t.Errorf("Expected the cells row by row, got %+v", cell)
+	}
+
+	// Nothing changed, so nothing is sent again
+	if frame := nextChunkFrame(t, wi, client); len(frame.Chunks) != 0 {
+		t.Errorf("Expected unchanged chunks left out, got %d", len(frame.Chunks))
+	}
+
+	// A change to one cell resends its chunk only
+	cell := &world.Grid[5][40]
+	if cell.Biome == BiomeRadiation {
+		cell.Biome = BiomeWater
+	} else {
+		cell.Biome = BiomeRadiation
+	}
+	frame = nextChunkFrame(t, wi, client)
+	if len(frame.Chunks) != 1 || frame.Chunks[0].X != 1 {
+		t.Errorf("Expected only the changed chunk resent, got %d chunks", len(frame.Chunks))
+	}
+
+	// Widening the subscription sends the new chunks alone
+	keys, _ = ChunkSubscription{X: 0, Y: 0, Width: 70, Height: 40}.Keys(70, 40)
+	client.subscribeChunks(keys)
+	if frame := nextChunkFrame(t, wi, client); len(frame.Chunks) != 4 {
+		t.Errorf("Expected the four newly subscribed chunks, got %d", len(frame.Chunks))
+	}
+
+	// A client taking the grid brings it back
+	client.subscribeChunks(nil)
+	wi.sendFrame()
+	if data := <-wi.broadcastChan; data.Grid == nil {
+		t.Errorf("Expected the grid built once a client takes it")
+	}
+}
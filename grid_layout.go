@@ -0,0 +1,377 @@
+package main
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Grid shapes, saying how the map is cut into cells
+const (
+	GridShapeSquare = "square" // Rows and columns of squares, each sharing an edge with four others
+	GridShapeHex    = "hex"    // Rows of hexagons, every other row shifted half a cell east, each sharing an edge with six others
+)
+
+// GridLayout is how the map is cut into grid cells: which cell a position lies in, where a
+// cell's middle is and which cells lie around it. Cells are numbered by column and row
+// whatever their shape, so the grid is stored the same way for every layout. Cells a
+// layout returns may lie off the grid; the world wraps or drops them.
+type GridLayout interface {
+	Name() string
+	// CellAt is the cell a position lies in
+	CellAt(pos Position) GridPoint
+	// CellCenter is the middle of a cell
+	CellCenter(cell GridPoint) Position
+	// Neighbors lists the cells sharing an edge with a cell, the steps a path can take
+	Neighbors(cell GridPoint) []GridPoint
+	// Adjacent lists the cells touching a cell at an edge or a corner, row by row
+	Adjacent(cell GridPoint) []GridPoint
+	// Within lists the cells no more than radius cells from a cell, itself included, row by row
+	Within(cell GridPoint, radius int) []GridPoint
+	// Steps is how many steps between neighbours it takes to get from one cell to another
+	Steps(a, b GridPoint) int
+	// CenterDistance is how far apart two cells' middles are, in cells
+	CenterDistance(a, b GridPoint) float64
+}
+
+// GridShapeInfo describes a grid shape
+type GridShapeInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	create      func(cells gridCells) GridLayout
+}
+
+// gridShapeLibrary lists the shapes a world's grid can have
+var gridShapeLibrary = []GridShapeInfo{
+	{GridShapeSquare, "Rows and columns of squares; paths step north, south, east and west", func(cells gridCells) GridLayout {
+		return SquareGridLayout{cells}
+	}},
+	{GridShapeHex, "Rows of hexagons, every other row shifted half a cell east; paths step to any of six neighbours", func(cells gridCells) GridLayout {
+		return HexGridLayout{cells}
+	}},
+}
+
+// GridShapeNames lists the names of the grid shapes
+func GridShapeNames() []string {
+	names := make([]string, len(gridShapeLibrary))
+	for i, info := range gridShapeLibrary {
+		names[i] = info.Name
+	}
+	return names
+}
+
+// NewGridLayout creates a grid layout by shape name for a map of the given size cut into
+// the given number of columns and rows. An empty name is a square grid.
+func NewGridLayout(name string, width, height float64, columns, rows int) (GridLayout, error) {
+	if name == "" {
+		name = GridShapeSquare
+	}
+	for _, info := range gridShapeLibrary {
+		if info.Name == name {
+			return info.create(gridCells{width, height, columns, rows}), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown grid shape %q (known: %s)", name, strings.Join(GridShapeNames(), ", "))
+}
+
+// ValidateGridShape checks a grid shape name. An empty name is a square grid.
+func ValidateGridShape(name string) error {
+	_, err := NewGridLayout(name, 1, 1, 1, 1)
+	return err
+}
+
+// gridCells is a map of the given size cut into columns and rows
+type gridCells struct {
+	width, height float64
+	columns, rows int
+}
+
+// cellSize is the width of a column and the height of a row in world units
+func (g gridCells) cellSize() (float64, float64) {
+	return g.width / float64(g.columns), g.height / float64(g.rows)
+}
+
+// SquareGridLayout cuts the map into rows and columns of squares
+type SquareGridLayout struct{ gridCells }
+
+func (SquareGridLayout) Name() string { return GridShapeSquare }
+
+func (l SquareGridLayout) CellAt(pos Position) GridPoint {
+	return GridPoint{X: int(math.Floor((pos.X / l.width) * float64(l.columns))), Y: int(math.Floor((pos.Y / l.height) * float64(l.rows)))}
+}
+
+func (l SquareGridLayout) CellCenter(cell GridPoint) Position {
+	cellWidth, cellHeight := l.cellSize()
+	return Position{X: (float64(cell.X) + 0.5) * cellWidth, Y: (float64(cell.Y) + 0.5) * cellHeight}
+}
+
+func (SquareGridLayout) Neighbors(cell GridPoint) []GridPoint {
+	return []GridPoint{{X: cell.X - 1, Y: cell.Y}, {X: cell.X + 1, Y: cell.Y}, {X: cell.X, Y: cell.Y - 1}, {X: cell.X, Y: cell.Y + 1}}
+}
+
+func (l SquareGridLayout) Adjacent(cell GridPoint) []GridPoint {
+	adjacent := l.Within(cell, 1)
+	return append(adjacent[:4], adjacent[5:]...) // All but the cell itself, in the middle
+}
+
+func (SquareGridLayout) Within(cell GridPoint, radius int) []GridPoint {
+	cells := make([]GridPoint, 0, (2*radius+1)*(2*radius+1))
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			cells = append(cells, GridPoint{X: cell.X + dx, Y: cell.Y + dy})
+		}
+	}
+	return cells
+}
+
+func (SquareGridLayout) Steps(a, b GridPoint) int {
+	return absInt(a.X-b.X) + absInt(a.Y-b.Y)
+}
+
+func (SquareGridLayout) CenterDistance(a, b GridPoint) float64 {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	return math.Sqrt(float64(dx*dx + dy*dy))
+}
+
+// HexGridLayout cuts the map into pointy-topped hexagons, every odd row shifted half a cell
+// east of the even rows, stretched to fill the same columns and rows a square grid would.
+// A hex grid on a map that joins north to south needs an even number of rows for the
+// shifted rows to line up across the seam.
+type HexGridLayout struct{ gridCells }
+
+func (HexGridLayout) Name() string { return GridShapeHex }
+
+// hexAxial converts a cell's column and row to axial hex coordinates
+func hexAxial(cell GridPoint) (q, r int) {
+	return cell.X - (cell.Y-rowParity(cell.Y))/2, cell.Y
+}
+
+// rowParity is 1 for odd rows, shifted half a cell east, and 0 for even ones
+func rowParity(row int) int {
+	return row & 1
+}
+
+func (l HexGridLayout) CellAt(pos Position) GridPoint {
+	// Measured in cells from the middle of the first, the fractional axial coordinates are
+	// rounded to the nearest hexagon through cube coordinates
+	cellWidth, cellHeight := l.cellSize()
+	u, v := pos.X/cellWidth-0.5, pos.Y/cellHeight-0.5
+	q, r := u-v/2, v
+	s := -q - r
+	rq, rr, rs := math.Round(q), math.Round(r), math.Round(s)
+	dq, dr, ds := math.Abs(rq-q), math.Abs(rr-r), math.Abs(rs-s)
+	if dq > dr && dq > ds {
+		rq = -rr - rs
+	} else if dr > ds {
+		rr = -rq - rs
+	}
+	row := int(rr)
+	return GridPoint{X: int(rq) + (row-rowParity(row))/2, Y: row}
+}
+
+func (l HexGridLayout) CellCenter(cell GridPoint) Position {
+	cellWidth, cellHeight := l.cellSize()
+	return Position{
+		X: (float64(cell.X) + 0.5 + 0.5*float64(rowParity(cell.Y))) * cellWidth,
+		Y: (float64(cell.Y) + 0.5) * cellHeight,
+	}
+}
+
+// hexDirections are the steps to a cell's six neighbours, east and then round by north,
+// for even and odd rows
+var hexDirections = [2][6]GridPoint{
+	{{X: 1, Y: 0}, {X: 0, Y: -1}, {X: -1, Y: -1}, {X: -1, Y: 0}, {X: -1, Y: 1}, {X: 0, Y: 1}},
+	{{X: 1, Y: 0}, {X: 1, Y: -1}, {X: 0, Y: -1}, {X: -1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}},
+}
+
+func (HexGridLayout) Neighbors(cell GridPoint) []GridPoint {
+	neighbors := make([]GridPoint, 0, 6)
+	for _, step := range hexDirections[rowParity(cell.Y)] {
+		neighbors = append(neighbors, GridPoint{X: cell.X + step.X, Y: cell.Y + step.Y})
+	}
+	return neighbors
+}
+
+func (l HexGridLayout) Adjacent(cell GridPoint) []GridPoint {
+	adjacent := l.Within(cell, 1)
+	for i, other := range adjacent {
+		if other == cell {
+			return append(adjacent[:i], adjacent[i+1:]...)
+		}
+	}
+	return adjacent
+}
+
+func (l HexGridLayout) Within(cell GridPoint, radius int) []GridPoint {
+	cells := make([]GridPoint, 0, 3*radius*(radius+1)+1)
+	for y := cell.Y - radius; y <= cell.Y+radius; y++ {
+		for x := cell.X - radius - 1; x <= cell.X+radius+1; x++ {
+			if other := (GridPoint{X: x, Y: y}); l.Steps(cell, other) <= radius {
+				cells = append(cells, other)
+			}
+		}
+	}
+	return cells
+}
+
+func (HexGridLayout) Steps(a, b GridPoint) int {
+	aq, ar := hexAxial(a)
+	bq, br := hexAxial(b)
+	dq, dr := aq-bq, ar-br
+	return (absInt(dq) + absInt(dr) + absInt(dq+dr)) / 2
+}
+
+func (HexGridLayout) CenterDistance(a, b GridPoint) float64 {
+	aq, ar := hexAxial(a)
+	bq, br := hexAxial(b)
+	dq, dr := float64(aq-bq), float64(ar-br)
+	return math.Sqrt(dq*dq + dq*dr + dr*dr)
+}
+
+// absInt is the absolute value of an integer
+func absInt(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// GridLayout returns the world's grid layout. Worlds keep the one built from their
+// configuration when they are created or restored; others build it as asked.
+func (w *World) GridLayout() GridLayout {
+	if w.gridLayout != nil {
+		return w.gridLayout
+	}
+	layout, err := NewGridLayout(w.Config.GridShape, w.Config.Width, w.Config.Height, w.Config.GridWidth, w.Config.GridHeight)
+	if err != nil {
+		layout, _ = NewGridLayout(GridShapeSquare, w.Config.Width, w.Config.Height, w.Config.GridWidth, w.Config.GridHeight)
+	}
+	return layout
+}
+
+// CellCenter is the middle of a grid cell in world units
+func (w *World) CellCenter(x, y int) Position {
+	return w.GridLayout().CellCenter(GridPoint{X: x, Y: y})
+}
+
+// CellNeighbors lists the grid cells sharing an edge with a cell, across the joined edges
+// of a map that wraps, leaving out those off an edge that doesn't join
+func (w *World) CellNeighbors(cell GridPoint) []GridPoint {
+	neighbors := make([]GridPoint, 0, 6)
+	for _, neighbor := range w.GridLayout().Neighbors(cell) {
+		if x, y, onMap := w.wrapGridCell(neighbor.X, neighbor.Y); onMap {
+			neighbors = append(neighbors, GridPoint{X: x, Y: y})
+		}
+	}
+	return neighbors
+}
+
+// gridNeighbors lists the cells sharing an edge with a cell that lie on a grid of the given
+// size, without wrapping
+func gridNeighbors(layout GridLayout, p GridPoint, width, height int) []GridPoint {
+	neighbors := make([]GridPoint, 0, 6)
+	for _, n := range layout.Neighbors(p) {
+		if n.X >= 0 && n.X < width && n.Y >= 0 && n.Y < height {
+			neighbors = append(neighbors, n)
+		}
+	}
+	return neighbors
+}
+
+// adjacentCells lists the cells on the grid touching a cell at an edge or a corner,
+// without wrapping
+func (w *World) adjacentCells(x, y int) []GridPoint {
+	adjacent := make([]GridPoint, 0, 8)
+	for _, n := range w.GridLayout().Adjacent(GridPoint{X: x, Y: y}) {
+		if n.X >= 0 && n.X < w.Config.GridWidth && n.Y >= 0 && n.Y < w.Config.GridHeight {
+			adjacent = append(adjacent, n)
+		}
+	}
+	return adjacent
+}
+
+// FindPath finds the shortest path of grid cells from one cell to another, stepping between
+// neighbours across the joined edges of a map that wraps and only through cells passable
+// allows. The path starts with from and ends with to; it is nil when there is no way
+// through.
+func (w *World) FindPath(from, to GridPoint, passable func(GridPoint) bool) []GridPoint {
+	layout := w.GridLayout()
+	geometry := w.Geometry()
+	// The fewest steps to the target, the short way round across any joined edge
+	targets := make([]GridPoint, 0, 9)
+	for _, copy := range geometry.Copies(layout.CellCenter(to)) {
+		targets = append(targets, layout.CellAt(copy))
+	}
+	estimate := func(cell GridPoint) int {
+		fewest := math.MaxInt
+		for _, target := range targets {
+			fewest = min(fewest, layout.Steps(cell, target))
+		}
+		return fewest
+	}
+
+	cameFrom := map[GridPoint]GridPoint{from: from}
+	cost := map[GridPoint]int{from: 0}
+	open := &pathQueue{}
+	heap.Push(open, pathNode{cell: from, estimate: estimate(from)})
+	for open.Len() > 0 {
+		node := heap.Pop(open).(pathNode)
+		if node.cell == to {
+			path := []GridPoint{to}
+			for cell := to; cell != from; {
+				cell = cameFrom[cell]
+				path = append(path, cell)
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path
+		}
+		if node.cost > cost[node.cell] {
+			continue // Reached more cheaply since it was queued
+		}
+		for _, next := range w.CellNeighbors(node.cell) {
+			if next != to && !passable(next) {
+				continue
+			}
+			if known, seen := cost[next]; seen && known <= node.cost+1 {
+				continue
+			}
+			cost[next] = node.cost + 1
+			cameFrom[next] = node.cell
+			open.order++
+			heap.Push(open, pathNode{cell: next, cost: node.cost + 1, estimate: node.cost + 1 + estimate(next), order: open.order})
+		}
+	}
+	return nil
+}
+
+// pathNode is a cell waiting to be explored by FindPath
+type pathNode struct {
+	cell     GridPoint
+	cost     int // Steps from the start
+	estimate int // Steps from the start plus the fewest still to go
+	order    int // When it was queued, so equal estimates are explored first come first served
+}
+
+// pathQueue is FindPath's open set, the lowest estimate first
+type pathQueue struct {
+	nodes []pathNode
+	order int
+}
+
+func (q *pathQueue) Len() int { return len(q.nodes) }
+func (q *pathQueue) Less(i, j int) bool {
+	if q.nodes[i].estimate != q.nodes[j].estimate {
+		return q.nodes[i].estimate < q.nodes[j].estimate
+	}
+	return q.nodes[i].order < q.nodes[j].order
+}
+func (q *pathQueue) Swap(i, j int) { q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i] }
+func (q *pathQueue) Push(node any) { q.nodes = append(q.nodes, node.(pathNode)) }
+func (q *pathQueue) Pop() any {
+	node := q.nodes[len(q.nodes)-1]
+	q.nodes = q.nodes[:len(q.nodes)-1]
+	return node
+}
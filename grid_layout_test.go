@@ -0,0 +1,156 @@
+package main
+
+import (
+	"testing"
+)
+
+// newGridShapeTestWorld makes a small seeded world of the given grid shape and geometry
+func newGridShapeTestWorld(seed int64, shape, geometry string) *World {
+	config := steppingTestConfig(seed)
+	config.GridShape = shape
+	config.Geometry = geometry
+	config.GridHeight = 24 // Hex rows only meet across the poles when there are an even number of them
+	return newPopulatedTestWorld(config, false)
+}
+
+func TestGridLayoutsFindCells(t *testing.T) {
+	for _, shape := range GridShapeNames() {
+		layout, err := NewGridLayout(shape, 100, 100, 20, 20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for y := 0; y < 20; y++ {
+			for x := 0; x < 20; x++ {
+				cell := GridPoint{X: x, Y: y}
+				if found := layout.CellAt(layout.CellCenter(cell)); found != cell {
+					t.Fatalf("Expected the middle of %s cell %+v to lie in it, got %+v", shape, cell, found)
+				}
+				for _, neighbor := range layout.Neighbors(cell) {
+					if layout.Steps(cell, neighbor) != 1 || layout.CenterDistance(cell, neighbor) != 1 {
+						t.Fatalf("Expected %s neighbours one step and one cell apart, got %+v and %+v", shape, cell, neighbor)
+					}
+				}
+			}
+		}
+	}
+
+	square, _ := NewGridLayout(GridShapeSquare, 100, 100, 20, 20)
+	hex, _ := NewGridLayout(GridShapeHex, 100, 100, 20, 20)
+	cell := GridPoint{X: 5, Y: 5}
+	if len(square.Neighbors(cell)) != 4 || len(square.Adjacent(cell)) != 8 {
+		t.Error("Expected square cells to share edges with four cells and touch eight")
+	}
+	if len(hex.Neighbors(cell)) != 6 || len(hex.Adjacent(cell)) != 6 {
+		t.Error("Expected hex cells to share edges with six cells and touch no others")
+	}
+	if len(hex.Within(cell, 1)) != 7 || len(hex.Within(cell, 2)) != 19 || len(square.Within(cell, 2)) != 25 {
+		t.Errorf("Expected hex rings of 6 and 12 cells, got %d and %d", len(hex.Within(cell, 1)), len(hex.Within(cell, 2)))
+	}
+	// Odd rows sit half a cell east, so a step down and right from an odd row is a neighbour
+	if hex.Steps(GridPoint{X: 5, Y: 5}, GridPoint{X: 6, Y: 6}) != 1 || hex.Steps(GridPoint{X: 5, Y: 4}, GridPoint{X: 6, Y: 5}) != 2 {
+		t.Error("Expected odd hex rows shifted east")
+	}
+	if square.Steps(GridPoint{X: 0, Y: 0}, GridPoint{X: 3, Y: 4}) != 7 || hex.Steps(GridPoint{X: 0, Y: 0}, GridPoint{X: 0, Y: 4}) != 4 {
+		t.Error("Expected steps counted between neighbours")
+	}
+
+	if _, err := NewGridLayout("triangle", 100, 100, 20, 20); err == nil {
+		t.Error("Expected an unknown grid shape refused")
+	}
+	if _, err := ParseRunConfig([]byte(`{"world": {"grid_shape": "triangle"}}`)); err == nil {
+		t.Error("Expected a run config with an unknown grid shape refused")
+	}
+	config, err := ParseRunConfig([]byte(`{"world": {"grid_shape": "hex"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if worldConfig, err := config.WorldConfig(); err != nil || worldConfig.GridShape != GridShapeHex {
+		t.Errorf("Expected a run config to cut the world into hexagons, got %q", worldConfig.GridShape)
+	}
+}
+
+func TestFindPath(t *testing.T) {
+	for _, shape := range GridShapeNames() {
+		world := newGridShapeTestWorld(122, shape, GeometryBox)
+		// A wall down column 10 with a gap at the bottom
+		wall := make(map[GridPoint]bool)
+		for y := 0; y < world.Config.GridHeight-1; y++ {
+			wall[GridPoint{X: 10, Y: y}] = true
+		}
+		passable := func(cell GridPoint) bool { return !wall[cell] }
+
+		from, to := GridPoint{X: 5, Y: 2}, GridPoint{X: 15, Y: 2}
+		path := world.FindPath(from, to, passable)
+		if len(path) == 0 || path[0] != from || path[len(path)-1] != to {
+			t.Fatalf("Expected a %s path from %+v to %+v, got %v", shape, from, to, path)
+		}
+		layout := world.GridLayout()
+		for i, cell := range path {
+			if wall[cell] {
+				t.Fatalf("Expected the %s path round the wall, got %v", shape, path)
+			}
+			if i > 0 && layout.Steps(path[i-1], cell) != 1 {
+				t.Fatalf("Expected the %s path to step between neighbours, got %v", shape, path)
+			}
+		}
+		if len(path)-1 <= layout.Steps(from, to) {
+			t.Errorf("Expected the %s path to go the long way round the wall, got %d steps", shape, len(path)-1)
+		}
+
+		wall[GridPoint{X: 10, Y: world.Config.GridHeight - 1}] = true
+		if path := world.FindPath(from, to, passable); path != nil {
+			t.Errorf("Expected no %s path through a closed wall, got %v", shape, path)
+		}
+	}
+
+	// On a torus the closed wall can be walked round across the joined edge
+	torus := newGridShapeTestWorld(122, GridShapeHex, GeometryTorus)
+	from, to := GridPoint{X: 1, Y: 3}, GridPoint{X: torus.Config.GridWidth - 2, Y: 3}
+	if path := torus.FindPath(from, to, func(GridPoint) bool { return true }); len(path) != 4 {
+		t.Errorf("Expected a short hop across the joined edge, got %v", path)
+	}
+}
+
+func TestHexWorld(t *testing.T) {
+	world := newGridShapeTestWorld(123, GridShapeHex, GeometryTorus)
+	if world.GridLayout().Name() != GridShapeHex || NewViewManager(world).GetCurrentViewData().GridShape != GridShapeHex {
+		t.Fatal("Expected the world and its view cut into hexagons")
+	}
+	// The west end of an odd row belongs to the hexagon across the joined edge
+	rowHeight := world.Config.Height / float64(world.Config.GridHeight)
+	if x, y := world.worldToGridCoords(0.1, 3.5*rowHeight); x != world.Config.GridWidth-1 || y != 3 {
+		t.Errorf("Expected the west end of row 3 to lie in the last hexagon of the row, got %d,%d", x, y)
+	}
+	if _, err := world.Step(10); err != nil {
+		t.Fatal(err)
+	}
+	filed := 0
+	for _, row := range world.Grid {
+		for _, cell := range row {
+			filed += len(cell.Entities)
+		}
+	}
+	if filed == 0 {
+		t.Error("Expected creatures filed in the hex cells they stand in")
+	}
+	if len(world.CellNeighbors(GridPoint{X: 0, Y: 0})) != 6 {
+		t.Error("Expected a hex cell at the corner of a torus to have six neighbours")
+	}
+}
+
+func TestGroupsRouteRoundBarriers(t *testing.T) {
+	world := newGridShapeTestWorld(124, GridShapeHex, GeometryBox)
+	from, target := world.CellCenter(5, 10), world.CellCenter(15, 10)
+	if waypoint := groupWaypoint(world, from, target); waypoint != target {
+		t.Errorf("Expected a group to head straight for its target over open ground, got %+v", waypoint)
+	}
+
+	// A wall down column 10 with a gap at the top
+	for y := 1; y < world.Config.GridHeight; y++ {
+		world.OperatorStructures.BarrierCells[GridPoint{X: 10, Y: y}] = true
+	}
+	waypoint := groupWaypoint(world, from, target)
+	if x, y := world.worldToGridCoords(waypoint.X, waypoint.Y); y >= 10 || world.GridLayout().Steps(GridPoint{X: 5, Y: 10}, GridPoint{X: x, Y: y}) != 1 {
+		t.Errorf("Expected a group to head for the next cell north toward the gap, got %d,%d", x, y)
+	}
+}
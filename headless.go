@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HeadlessConfig describes an unattended run without the CLI or web interface
+type HeadlessConfig struct {
+	MaxTicks         int    // Ticks to simulate, 0 to run until extinction or interruption
+	SnapshotInterval int    // Save the state every this many ticks, 0 for no snapshots
+	SnapshotDir      string // Directory the snapshots are written to
+	SaveFormat       string // Format of the snapshots (see SaveFormatJSON), JSON when empty
+}
+
+// HeadlessSummary reports how an unattended run went
+type HeadlessSummary struct {
+	Seed           int64          `json:"seed"`
+	StartTick      int            `json:"start_tick"`
+	Tick           int            `json:"tick"`
+	Ticks          int            `json:"ticks"` // Ticks simulated
+	StoppedBy      string         `json:"stopped_by"`
+	ElapsedMS      int64          `json:"elapsed_ms"`
+	TicksPerSecond float64        `json:"ticks_per_second"`
+	Digest         string         `json:"digest"` // Hash of the final state
+	EntityCount    int            `json:"entity_count"`
+	PlantCount     int            `json:"plant_count"`
+	Populations    map[string]int `json:"populations"` // Living creatures per species
+	Snapshots      []string       `json:"snapshots"`   // Snapshot files in the order written
+}
+
+// Reasons a headless run stops
+const (
+	headlessStopMaxTicks    = "max_ticks"
+	headlessStopExtinction  = "extinction"
+	headlessStopBreakpoint  = "breakpoint"
+	headlessStopInterrupted = "interrupted"
+)
+
+// RunHeadless simulates the world as fast as possible until it reaches the tick limit, every
+// creature has died, a breakpoint fires or ctx is cancelled, saving a snapshot at every
+// multiple of the snapshot interval
+func RunHeadless(ctx context.Context, world *World, config HeadlessConfig) (*HeadlessSummary, error) {
+	if config.MaxTicks < 0 || config.SnapshotInterval < 0 {
+		return nil, fmt.Errorf("tick limit and snapshot interval must not be negative")
+	}
+	if _, err := ResolveSaveFormat("", config.SaveFormat); err != nil {
+		return nil, err
+	}
+	if config.SnapshotInterval > 0 {
+		if err := os.MkdirAll(config.SnapshotDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create the snapshot directory: %v", err)
+		}
+	}
+
+	summary := &HeadlessSummary{Seed: world.Seed, StartTick: world.Tick, Snapshots: []string{}}
+	start := time.Now()
+	world.Paused = false
+	for summary.StoppedBy == "" {
+		if config.MaxTicks > 0 && summary.Ticks >= config.MaxTicks {
+			summary.StoppedBy = headlessStopMaxTicks
+			break
+		}
+		if ctx.Err() != nil {
+			summary.StoppedBy = headlessStopInterrupted
+			break
+		}
+
+		world.Update()
+		summary.Ticks++
+
+		if config.SnapshotInterval > 0 && world.Tick%config.SnapshotInterval == 0 {
+			filename := filepath.Join(config.SnapshotDir, fmt.Sprintf("snapshot_%08d%s", world.Tick, SaveFileExtension(config.SaveFormat)))
+			snapshots := NewStateManager(world)
+			snapshots.Format = config.SaveFormat
+			if err := snapshots.WriteStateFile(filename); err != nil {
+				return nil, fmt.Errorf("failed to save the snapshot at tick %d: %v", world.Tick, err)
+			}
+			summary.Snapshots = append(summary.Snapshots, filename)
+			log.Printf("Tick %d: %d creatures, snapshot %s", world.Tick, len(world.AllEntities), filename)
+		}
+
+		switch {
+		case world.Paused:
+			summary.StoppedBy = headlessStopBreakpoint
+		case !anyAlive(world.AllEntities):
+			summary.StoppedBy = headlessStopExtinction
+		}
+	}
+
+	// Only an interrupted run is left to resume
+	if world.Autosaver != nil {
+		status := AutosaveFinished
+		if summary.StoppedBy == headlessStopInterrupted {
+			status = AutosaveInterrupted
+		}
+		if err := world.Autosaver.End(world, status); err != nil {
+			return nil, err
+		}
+	}
+
+	elapsed := time.Since(start)
+	summary.ElapsedMS = elapsed.Milliseconds()
+	if elapsed > 0 {
+		summary.TicksPerSecond = float64(summary.Ticks) / elapsed.Seconds()
+	}
+	state := world.StateDigest()
+	summary.Tick = state.Tick
+	summary.Digest = state.Digest
+	summary.EntityCount = state.EntityCount
+	summary.PlantCount = state.PlantCount
+	summary.Populations = state.Populations
+	return summary, nil
+}
+
+// anyAlive reports whether any of the entities is alive
+func anyAlive(entities []*Entity) bool {
+	for _, entity := range entities {
+		if entity.IsAlive {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteHeadlessSummary writes the summary as indented JSON to a file, or to stdout when the
+// filename is empty
+func WriteHeadlessSummary(summary *HeadlessSummary, filename string) error {
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if filename == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
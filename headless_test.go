@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunHeadlessSnapshotsAndSummary(t *testing.T) {
+	world := NewWorld(DefaultDeterminismConfig().World)
+	for _, population := range startingPopulations(false) {
+		world.AddPopulation(population)
+	}
+	world.Seed = 9
+	dir := t.TempDir()
+
+	summary, err := RunHeadless(context.Background(), world, HeadlessConfig{MaxTicks: 12, SnapshotInterval: 5, SnapshotDir: dir})
+	if err != nil {
+		t.Fatalf("Headless run failed: %v", err)
+	}
+	if summary.StoppedBy != headlessStopMaxTicks || summary.Ticks != 12 || summary.Tick != 12 || summary.Seed != 9 {
+		t.Errorf("Expected 12 ticks run to the limit, got %+v", summary)
+	}
+	want := []string{filepath.Join(dir, "snapshot_00000005.json"), filepath.Join(dir, "snapshot_00000010.json")}
+	if len(summary.Snapshots) != len(want) || summary.Snapshots[0] != want[0] || summary.Snapshots[1] != want[1] {
+		t.Fatalf("Expected snapshots at ticks 5 and 10, got %v", summary.Snapshots)
+	}
+	state, err := LoadStateFile(summary.Snapshots[1])
+	if err != nil || state.Tick != 10 {
+		t.Errorf("Expected the snapshot to load at tick 10, got %v", err)
+	}
+	if summary.Digest != world.StateDigest().Digest || summary.EntityCount == 0 {
+		t.Errorf("Expected the summary to describe the final state")
+	}
+}
+
+func TestRunHeadlessStops(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	summary, err := RunHeadless(ctx, newGraphQLTestWorld(), HeadlessConfig{})
+	if err != nil || summary.StoppedBy != headlessStopInterrupted || summary.Ticks != 0 {
+		t.Errorf("Expected an interrupted run to stop at once, got %+v (%v)", summary, err)
+	}
+
+	empty := newGraphQLTestWorld()
+	empty.AllEntities = nil
+	summary, err = RunHeadless(context.Background(), empty, HeadlessConfig{})
+	if err != nil || summary.StoppedBy != headlessStopExtinction || summary.Ticks != 1 {
+		t.Errorf("Expected a lifeless world to stop after a tick, got %+v (%v)", summary, err)
+	}
+
+	if _, err := RunHeadless(context.Background(), empty, HeadlessConfig{MaxTicks: -1}); err == nil {
+		t.Errorf("Expected a negative tick limit refused")
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+// History retention tiers. Memory stays bounded however long the run: older snapshots are
+// merged into coarser ones instead of piling up or being thrown away.
+const (
+	historyRecentLength      = 50 // Snapshots kept at full resolution
+	historyDownsampleFactor  = 10 // Snapshots merged into one at each coarser tier
+	historyDownsampledLength = 25 // Downsampled snapshots kept before they fold into epochs
+	historyEpochLength       = 25 // Epoch summaries kept; when full, neighbours merge pairwise
+)
+
+// TieredHistory keeps a series of snapshots in three tiers: the most recent at full
+// resolution, older ones downsampled, and the oldest as epoch summaries that keep
+// halving in resolution so the whole run always fits
+type TieredHistory[T any] struct {
+	merge func(snapshots []T) T // Combines consecutive snapshots into one
+
+	recent      []T
+	downsampled []T
+	epochs      []T
+
+	// Snapshots aged out of a tier, waiting for enough company to be merged
+	pendingDownsample []T
+	pendingEpoch      []T
+}
+
+// NewTieredHistory creates an empty history that merges snapshots with merge
+func NewTieredHistory[T any](merge func(snapshots []T) T) *TieredHistory[T] {
+	return &TieredHistory[T]{merge: merge}
+}
+
+// Add appends the newest snapshot, cascading the oldest down the tiers
+func (h *TieredHistory[T]) Add(snapshot T) {
+	h.recent = append(h.recent, snapshot)
+	if len(h.recent) <= historyRecentLength {
+		return
+	}
+	h.pendingDownsample = append(h.pendingDownsample, h.recent[0])
+	h.recent = append(h.recent[:0:0], h.recent[1:]...)
+	if len(h.pendingDownsample) < historyDownsampleFactor {
+		return
+	}
+
+	h.downsampled = append(h.downsampled, h.merge(h.pendingDownsample))
+	h.pendingDownsample = nil
+	if len(h.downsampled) <= historyDownsampledLength {
+		return
+	}
+	h.pendingEpoch = append(h.pendingEpoch, h.downsampled[0])
+	h.downsampled = append(h.downsampled[:0:0], h.downsampled[1:]...)
+	if len(h.pendingEpoch) < historyDownsampleFactor {
+		return
+	}
+
+	h.epochs = append(h.epochs, h.merge(h.pendingEpoch))
+	h.pendingEpoch = nil
+	if len(h.epochs) > historyEpochLength {
+		merged := make([]T, 0, historyEpochLength)
+		for i := 0; i < len(h.epochs); i += 2 {
+			merged = append(merged, h.merge(h.epochs[i:min(i+2, len(h.epochs))]))
+		}
+		h.epochs = merged
+	}
+}
+
+// Snapshots returns the whole history, oldest first
+func (h *TieredHistory[T]) Snapshots() []T {
+	snapshots := make([]T, 0, h.Len())
+	snapshots = append(snapshots, h.epochs...)
+	snapshots = append(snapshots, h.pendingEpoch...)
+	snapshots = append(snapshots, h.downsampled...)
+	snapshots = append(snapshots, h.pendingDownsample...)
+	return append(snapshots, h.recent...)
+}
+
+// Len counts the snapshots held across all tiers
+func (h *TieredHistory[T]) Len() int {
+	return len(h.epochs) + len(h.pendingEpoch) + len(h.downsampled) + len(h.pendingDownsample) + len(h.recent)
+}
+
+// mergePopulationSnapshots averages each species over the merged snapshots. The merged
+// snapshot carries the last tick and the number of raw snapshots it stands for.
+func mergePopulationSnapshots(snapshots []PopulationHistorySnapshot) PopulationHistorySnapshot {
+	last := snapshots[len(snapshots)-1]
+	merged := PopulationHistorySnapshot{Tick: last.Tick, Timestamp: last.Timestamp, FirstTick: snapshots[0].firstTick()}
+	weights := make(map[string]float64)
+	totals := make(map[string]*PopulationData)
+	order := make([]string, 0)
+	for _, snapshot := range snapshots {
+		weight := float64(snapshot.samples())
+		merged.Samples += snapshot.samples()
+		for _, pop := range snapshot.Populations {
+			total, exists := totals[pop.Name]
+			if !exists {
+				total = &PopulationData{Name: pop.Name, Species: pop.Species, TraitAverages: make(map[string]float64)}
+				totals[pop.Name] = total
+				order = append(order, pop.Name)
+			}
+			weights[pop.Name] += weight
+			total.Count += int(float64(pop.Count) * weight)
+			total.AvgFitness += pop.AvgFitness * weight
+			total.AvgEnergy += pop.AvgEnergy * weight
+			total.AvgAge += pop.AvgAge * weight
+			total.Generation = max(total.Generation, pop.Generation)
+			for trait, value := range pop.TraitAverages {
+				total.TraitAverages[trait] += value * weight
+			}
+		}
+	}
+
+	// Species absent from some snapshots are averaged over those they appear in
+	for _, name := range order {
+		total, weight := totals[name], weights[name]
+		total.Count = int(float64(total.Count)/weight + 0.5)
+		total.AvgFitness /= weight
+		total.AvgEnergy /= weight
+		total.AvgAge /= weight
+		for trait := range total.TraitAverages {
+			total.TraitAverages[trait] /= weight
+		}
+		merged.Populations = append(merged.Populations, *total)
+	}
+	return merged
+}
+
+// mergeCommunicationSnapshots averages signal activity over the merged snapshots
+func mergeCommunicationSnapshots(snapshots []CommunicationHistorySnapshot) CommunicationHistorySnapshot {
+	last := snapshots[len(snapshots)-1]
+	merged := CommunicationHistorySnapshot{Tick: last.Tick, Timestamp: last.Timestamp, FirstTick: snapshots[0].firstTick(), SignalTypes: make(map[string]int)}
+	signals := 0.0
+	types := make(map[string]float64)
+	for _, snapshot := range snapshots {
+		weight := snapshot.samples()
+		merged.Samples += weight
+		signals += float64(snapshot.ActiveSignals * weight)
+		for signal, count := range snapshot.SignalTypes {
+			types[signal] += float64(count * weight)
+		}
+	}
+	merged.ActiveSignals = int(signals/float64(merged.Samples) + 0.5)
+	for signal, count := range types {
+		merged.SignalTypes[signal] = int(count/float64(merged.Samples) + 0.5)
+	}
+	return merged
+}
+
+// mergePhysicsSnapshots averages motion and collisions over the merged snapshots
+func mergePhysicsSnapshots(snapshots []PhysicsHistorySnapshot) PhysicsHistorySnapshot {
+	last := snapshots[len(snapshots)-1]
+	merged := PhysicsHistorySnapshot{Tick: last.Tick, Timestamp: last.Timestamp, FirstTick: snapshots[0].firstTick()}
+	collisions := 0.0
+	for _, snapshot := range snapshots {
+		weight := snapshot.samples()
+		merged.Samples += weight
+		collisions += float64(snapshot.Collisions * weight)
+		merged.AverageVelocity += snapshot.AverageVelocity * float64(weight)
+		merged.TotalMomentum += snapshot.TotalMomentum * float64(weight)
+	}
+	merged.Collisions = int(collisions/float64(merged.Samples) + 0.5)
+	merged.AverageVelocity /= float64(merged.Samples)
+	merged.TotalMomentum /= float64(merged.Samples)
+	return merged
+}
+
+// samples is how many raw snapshots a snapshot stands for, and firstTick the earliest
+// tick it covers
+func (s PopulationHistorySnapshot) samples() int { return max(s.Samples, 1) }
+
+func (s PopulationHistorySnapshot) firstTick() int {
+	return firstSnapshotTick(s.Tick, s.FirstTick, s.Samples)
+}
+
+func (s CommunicationHistorySnapshot) samples() int { return max(s.Samples, 1) }
+
+func (s CommunicationHistorySnapshot) firstTick() int {
+	return firstSnapshotTick(s.Tick, s.FirstTick, s.Samples)
+}
+
+func (s PhysicsHistorySnapshot) samples() int { return max(s.Samples, 1) }
+
+func (s PhysicsHistorySnapshot) firstTick() int {
+	return firstSnapshotTick(s.Tick, s.FirstTick, s.Samples)
+}
+
+// firstSnapshotTick is the first tick of a merged snapshot or the tick of a raw one
+func firstSnapshotTick(tick, firstTick, samples int) int {
+	if samples > 1 {
+		return firstTick
+	}
+	return tick
+}
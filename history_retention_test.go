@@ -0,0 +1,67 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTieredHistoryStaysBoundedOverLongRuns(t *testing.T) {
+	history := NewTieredHistory(mergePhysicsSnapshots)
+	const snapshots = 200000
+	for i := 0; i < snapshots; i++ {
+		history.Add(PhysicsHistorySnapshot{Tick: i * 5, Collisions: 4, AverageVelocity: 2.5})
+	}
+
+	bound := historyRecentLength + historyDownsampledLength + historyEpochLength + 2*historyDownsampleFactor
+	if history.Len() > bound {
+		t.Fatalf("Expected at most %d snapshots held, got %d", bound, history.Len())
+	}
+
+	all := history.Snapshots()
+	samples := 0
+	for i, snapshot := range all {
+		samples += snapshot.samples()
+		if i > 0 && snapshot.firstTick() <= all[i-1].Tick {
+			t.Fatalf("Expected snapshots in order without overlap, got %+v after %+v", snapshot, all[i-1])
+		}
+		if snapshot.Collisions != 4 || math.Abs(snapshot.AverageVelocity-2.5) > 1e-9 {
+			t.Fatalf("Expected merging to preserve averages, got %+v", snapshot)
+		}
+	}
+	if samples != snapshots || all[0].firstTick() != 0 || all[len(all)-1].Tick != (snapshots-1)*5 {
+		t.Errorf("Expected the history to cover the whole run, got %d samples from tick %d", samples, all[0].firstTick())
+	}
+	if recent := all[len(all)-historyRecentLength:]; recent[0].Samples != 0 {
+		t.Error("Expected the most recent snapshots at full resolution")
+	}
+}
+
+func TestMergePopulationSnapshotsWeighsSamples(t *testing.T) {
+	merged := mergePopulationSnapshots([]PopulationHistorySnapshot{
+		{Tick: 10, FirstTick: 0, Samples: 3, Populations: []PopulationData{{Name: "A", Count: 10, AvgEnergy: 40, TraitAverages: map[string]float64{"speed": 0.1}}}},
+		{Tick: 15, Populations: []PopulationData{
+			{Name: "A", Count: 30, AvgEnergy: 80, TraitAverages: map[string]float64{"speed": 0.5}},
+			{Name: "B", Count: 7, Generation: 2},
+		}},
+	})
+	if merged.Samples != 4 || merged.FirstTick != 0 || merged.Tick != 15 || len(merged.Populations) != 2 {
+		t.Fatalf("Expected one snapshot of 4 samples over ticks 0-15, got %+v", merged)
+	}
+	a, b := merged.Populations[0], merged.Populations[1]
+	if a.Count != 15 || a.AvgEnergy != 50 || math.Abs(a.TraitAverages["speed"]-0.2) > 1e-9 {
+		t.Errorf("Expected a sample-weighted average for A, got %+v", a)
+	}
+	if b.Count != 7 || b.Generation != 2 {
+		t.Errorf("Expected B averaged over the snapshots it appears in, got %+v", b)
+	}
+}
+
+func TestViewManagerCapturesEachTickOnce(t *testing.T) {
+	world := newSteppingTestWorld(51)
+	vm := NewViewManager(world)
+	vm.GetCurrentViewData()
+	vm.GetCurrentViewData()
+	if got := vm.populationHistory.Len(); got != 1 {
+		t.Errorf("Expected repeated frames of a paused tick to capture once, got %d snapshots", got)
+	}
+}
@@ -192,7 +192,7 @@ func (hm *HiveMind) ShareKnowledge(knowledgeType string, position Position, valu
 }
 
 // GetCollectiveDecision makes a group decision based on member input
-func (hm *HiveMind) GetCollectiveDecision(decisionType string, options []string) string {
+func (hm *HiveMind) GetCollectiveDecision(rng *rand.Rand, decisionType string, options []string) string {
 	if len(hm.Members) == 0 {
 		return ""
 	}
@@ -232,7 +232,7 @@ func (hm *HiveMind) GetCollectiveDecision(decisionType string, options []string)
 		default:
 			// Random choice for unknown decision types
 			if len(options) > 0 {
-				preferredOption = options[rand.Intn(len(options))]
+				preferredOption = options[rng.Intn(len(options))]
 			}
 		}
 
@@ -244,7 +244,8 @@ func (hm *HiveMind) GetCollectiveDecision(decisionType string, options []string)
 	bestScore := 0.0
 	totalVotes := 0.0
 
-	for option, score := range votes {
+	for _, option := range sortedKeys(votes) {
+		score := votes[option]
 		totalVotes += score
 		if score > bestScore {
 			bestScore = score
@@ -262,13 +263,13 @@ func (hm *HiveMind) GetCollectiveDecision(decisionType string, options []string)
 }
 
 // CoordinateMovement coordinates movement of hive members
-func (hm *HiveMind) CoordinateMovement(targetX, targetY float64) {
+func (hm *HiveMind) CoordinateMovement(rng *rand.Rand, targetX, targetY float64, geometry WorldGeometry) {
 	if len(hm.Members) == 0 {
 		return
 	}
 
 	// Calculate formation based on hive type
-	formations := hm.calculateFormation(targetX, targetY)
+	formations := hm.calculateFormation(rng, targetX, targetY)
 
 	for i, member := range hm.Members {
 		if !member.IsAlive || i >= len(formations) {
@@ -279,12 +280,12 @@ func (hm *HiveMind) CoordinateMovement(targetX, targetY float64) {
 		speed := member.GetTrait("speed") * 0.8 // Slightly slower when coordinated
 
 		// Move toward formation position
-		member.MoveTo(formation.X, formation.Y, speed)
+		member.MoveTo(formation.X, formation.Y, speed, geometry)
 	}
 }
 
 // calculateFormation determines optimal positions for coordinated movement
-func (hm *HiveMind) calculateFormation(targetX, targetY float64) []Position {
+func (hm *HiveMind) calculateFormation(rng *rand.Rand, targetX, targetY float64) []Position {
 	formations := make([]Position, len(hm.Members))
 
 	switch hm.Type {
@@ -347,8 +348,8 @@ func (hm *HiveMind) calculateFormation(targetX, targetY float64) []Position {
 		// Default to random positions around target
 		for i := range hm.Members {
 			formations[i] = Position{
-				X: targetX + (rand.Float64()-0.5)*10.0,
-				Y: targetY + (rand.Float64()-0.5)*10.0,
+				X: targetX + (rng.Float64()-0.5)*10.0,
+				Y: targetY + (rng.Float64()-0.5)*10.0,
 			}
 		}
 	}
@@ -11,7 +11,7 @@ func TestHiveMindSystem(t *testing.T) {
 	traitNames := []string{"intelligence", "cooperation", "strength", "speed"}
 
 	for i := 0; i < 5; i++ {
-		entity := NewEntity(i, traitNames, "test_species", Position{X: float64(i * 2), Y: 0})
+		entity := NewEntity(sharedRand, i, traitNames, "test_species", Position{X: float64(i * 2), Y: 0})
 		entity.SetTrait("intelligence", 0.5+float64(i)*0.1)
 		entity.SetTrait("cooperation", 0.6+float64(i)*0.05)
 		entities = append(entities, entity)
@@ -36,7 +36,7 @@ func TestHiveMindSystem(t *testing.T) {
 	}
 
 	// Test collective decision making
-	decision := hiveMind.GetCollectiveDecision("food_search", []string{"aggressive_search", "conservative_search"})
+	decision := hiveMind.GetCollectiveDecision(sharedRand, "food_search", []string{"aggressive_search", "conservative_search"})
 	if decision == "" {
 		t.Error("Expected decision but got empty string")
 	}
@@ -50,7 +50,7 @@ func TestHiveMindSystem(t *testing.T) {
 	}
 
 	// Test coordinated movement
-	hiveMind.CoordinateMovement(20, 10)
+	hiveMind.CoordinateMovement(sharedRand, 20, 10, BoxGeometry{})
 
 	// Verify members moved toward target formation
 	moved := false
@@ -75,7 +75,7 @@ func TestHiveMindSystem(t *testing.T) {
 }
 
 func TestHiveMindMemoryDecay(t *testing.T) {
-	entity := NewEntity(1, []string{"intelligence", "cooperation"}, "test", Position{})
+	entity := NewEntity(sharedRand, 1, []string{"intelligence", "cooperation"}, "test", Position{})
 	entity.SetTrait("intelligence", 0.8)
 	entity.SetTrait("cooperation", 0.7)
 
@@ -116,14 +116,14 @@ func TestHiveMindMemoryDecay(t *testing.T) {
 
 func TestHiveMindCompatibility(t *testing.T) {
 	// Create hive mind with one member
-	founder := NewEntity(1, []string{"intelligence", "cooperation"}, "test", Position{})
+	founder := NewEntity(sharedRand, 1, []string{"intelligence", "cooperation"}, "test", Position{})
 	founder.SetTrait("intelligence", 0.8)
 	founder.SetTrait("cooperation", 0.7)
 
 	hiveMind := NewHiveMind(1, founder, SimpleCollective)
 
 	// Test compatible entity
-	compatible := NewEntity(2, []string{"intelligence", "cooperation"}, "test", Position{})
+	compatible := NewEntity(sharedRand, 2, []string{"intelligence", "cooperation"}, "test", Position{})
 	compatible.SetTrait("intelligence", 0.7) // Close to founder
 	compatible.SetTrait("cooperation", 0.6)  // Close to founder
 
@@ -132,7 +132,7 @@ func TestHiveMindCompatibility(t *testing.T) {
 	}
 
 	// Test incompatible entity (low intelligence)
-	incompatible1 := NewEntity(3, []string{"intelligence", "cooperation"}, "test", Position{})
+	incompatible1 := NewEntity(sharedRand, 3, []string{"intelligence", "cooperation"}, "test", Position{})
 	incompatible1.SetTrait("intelligence", 0.1) // Too low
 	incompatible1.SetTrait("cooperation", 0.7)
 
@@ -141,7 +141,7 @@ func TestHiveMindCompatibility(t *testing.T) {
 	}
 
 	// Test incompatible entity (low cooperation)
-	incompatible2 := NewEntity(4, []string{"intelligence", "cooperation"}, "test", Position{})
+	incompatible2 := NewEntity(sharedRand, 4, []string{"intelligence", "cooperation"}, "test", Position{})
 	incompatible2.SetTrait("intelligence", 0.8)
 	incompatible2.SetTrait("cooperation", 0.2) // Too low
 
@@ -150,7 +150,7 @@ func TestHiveMindCompatibility(t *testing.T) {
 	}
 
 	// Test incompatible entity (too different traits)
-	incompatible3 := NewEntity(5, []string{"intelligence", "cooperation"}, "test", Position{})
+	incompatible3 := NewEntity(sharedRand, 5, []string{"intelligence", "cooperation"}, "test", Position{})
 	incompatible3.SetTrait("intelligence", 0.2) // Very different from founder
 	incompatible3.SetTrait("cooperation", 0.9)
 
@@ -165,7 +165,7 @@ func TestHiveMindTypes(t *testing.T) {
 	expectedMaxMembers := []int{10, 50, 25, 100}
 
 	for i, hiveType := range types {
-		entity := NewEntity(1, []string{"intelligence", "cooperation"}, "test", Position{})
+		entity := NewEntity(sharedRand, 1, []string{"intelligence", "cooperation"}, "test", Position{})
 		entity.SetTrait("intelligence", 0.8)
 		entity.SetTrait("cooperation", 0.7)
 
@@ -183,15 +183,15 @@ func TestCollectiveIntelligence(t *testing.T) {
 	entities := make([]*Entity, 3)
 	traitNames := []string{"intelligence", "cooperation"}
 
-	entities[0] = NewEntity(1, traitNames, "test", Position{})
+	entities[0] = NewEntity(sharedRand, 1, traitNames, "test", Position{})
 	entities[0].SetTrait("intelligence", 0.5)
 	entities[0].SetTrait("cooperation", 0.8)
 
-	entities[1] = NewEntity(2, traitNames, "test", Position{})
+	entities[1] = NewEntity(sharedRand, 2, traitNames, "test", Position{})
 	entities[1].SetTrait("intelligence", 0.7)
 	entities[1].SetTrait("cooperation", 0.9)
 
-	entities[2] = NewEntity(3, traitNames, "test", Position{})
+	entities[2] = NewEntity(sharedRand, 3, traitNames, "test", Position{})
 	entities[2].SetTrait("intelligence", 0.6)
 	entities[2].SetTrait("cooperation", 0.7)
 
@@ -209,7 +209,7 @@ func TestCollectiveIntelligence(t *testing.T) {
 }
 
 func TestHiveMindSafetyCheck(t *testing.T) {
-	entity := NewEntity(1, []string{"intelligence", "cooperation"}, "test", Position{})
+	entity := NewEntity(sharedRand, 1, []string{"intelligence", "cooperation"}, "test", Position{})
 	entity.SetTrait("intelligence", 0.8)
 	entity.SetTrait("cooperation", 0.7)
 
@@ -73,6 +73,8 @@ type InsectSystem struct {
 	SwarmUnits      []*SwarmUnit      `json:"swarm_units"`
 	NextTrailID     int               `json:"next_trail_id"`
 	NextSwarmID     int               `json:"next_swarm_id"`
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewInsectSystem creates a new insect management system
@@ -82,11 +84,12 @@ func NewInsectSystem() *InsectSystem {
 		SwarmUnits:      make([]*SwarmUnit, 0),
 		NextTrailID:     1,
 		NextSwarmID:     1,
+		rng:             sharedRand,
 	}
 }
 
 // AddInsectTraitsToEntity adds insect traits to an entity if appropriate
-func AddInsectTraitsToEntity(entity *Entity) {
+func AddInsectTraitsToEntity(rng *rand.Rand, entity *Entity) {
 	// Check if entity has insect-like characteristics
 	size := entity.GetTrait("size")
 	cooperation := entity.GetTrait("cooperation")
@@ -95,17 +98,17 @@ func AddInsectTraitsToEntity(entity *Entity) {
 	// Small, cooperative, intelligent entities are good insect candidates
 	if size < -0.3 && cooperation > 0.4 && intelligence > 0.3 {
 		// Add insect-specific traits
-		entity.SetTrait("swarm_capability", 0.4+rand.Float64()*0.6)
-		entity.SetTrait("pheromone_sensitivity", 0.5+rand.Float64()*0.5)
-		entity.SetTrait("pheromone_production", 0.3+rand.Float64()*0.7)
+		entity.SetTrait("swarm_capability", 0.4+rng.Float64()*0.6)
+		entity.SetTrait("pheromone_sensitivity", 0.5+rng.Float64()*0.5)
+		entity.SetTrait("pheromone_production", 0.3+rng.Float64()*0.7)
 		entity.SetTrait("colony_loyalty", cooperation+0.2)
-		entity.SetTrait("metamorphosis", rand.Float64()*0.5)
-		entity.SetTrait("exoskeleton_strength", 0.2+rand.Float64()*0.4)
+		entity.SetTrait("metamorphosis", rng.Float64()*0.5)
+		entity.SetTrait("exoskeleton_strength", 0.2+rng.Float64()*0.4)
 		entity.SetTrait("eusociality", cooperation*0.8)
 
 		// Flying capability for some insects
-		if rand.Float64() < 0.4 { // 40% chance of flight
-			entity.SetTrait("flight_capability", 0.3+rand.Float64()*0.7)
+		if rng.Float64() < 0.4 { // 40% chance of flight
+			entity.SetTrait("flight_capability", 0.3+rng.Float64()*0.7)
 			entity.SetTrait("flying_ability", entity.GetTrait("flight_capability"))
 		}
 
@@ -131,8 +134,8 @@ func (is *InsectSystem) CreatePheromoneTrail(producer *Entity, trailType Pheromo
 		Positions:    positions,
 		Strength:     strengths,
 		ProducerID:   producer.ID,
-		CreationTick: 0,                          // Will be set by world
-		DecayRate:    0.02 + rand.Float64()*0.03, // 2-5% decay per tick
+		CreationTick: 0,                            // Will be set by world
+		DecayRate:    0.02 + is.rng.Float64()*0.03, // 2-5% decay per tick
 		MaxStrength:  production,
 		// Enhanced persistence features
 		ReinforcementCount:  0,
@@ -140,7 +143,7 @@ func (is *InsectSystem) CreatePheromoneTrail(producer *Entity, trailType Pheromo
 		UsageCount:          0,
 		EnvironmentalFactor: 1.0, // Start with neutral factor
 		PersistenceBonus:    0.0,
-		WeatherResistance:   0.3 + rand.Float64()*0.4, // 30-70% weather resistance
+		WeatherResistance:   0.3 + is.rng.Float64()*0.4, // 30-70% weather resistance
 	}
 
 	is.NextTrailID++
@@ -422,7 +425,7 @@ func (is *InsectSystem) calculateSwarmSpeed(entities []*Entity) float64 {
 }
 
 // UpdateSwarmMovement coordinates swarm member movements
-func (is *InsectSystem) UpdateSwarmMovement(swarm *SwarmUnit) {
+func (is *InsectSystem) UpdateSwarmMovement(swarm *SwarmUnit, geometry WorldGeometry) {
 	if len(swarm.Members) == 0 || swarm.LeaderEntity == nil {
 		return
 	}
@@ -442,8 +445,8 @@ func (is *InsectSystem) UpdateSwarmMovement(swarm *SwarmUnit) {
 		is.updateExplorationSwarmTarget(swarm)
 	default:
 		// Random movement
-		angle := rand.Float64() * 2 * math.Pi
-		distance := 20.0 + rand.Float64()*30.0
+		angle := is.rng.Float64() * 2 * math.Pi
+		distance := 20.0 + is.rng.Float64()*30.0
 		swarm.TargetPosition.X = swarm.CenterPosition.X + math.Cos(angle)*distance
 		swarm.TargetPosition.Y = swarm.CenterPosition.Y + math.Sin(angle)*distance
 	}
@@ -458,9 +461,8 @@ func (is *InsectSystem) UpdateSwarmMovement(swarm *SwarmUnit) {
 
 		formation := formations[i]
 
-		// Calculate movement toward formation position
-		dx := formation.X - member.Position.X
-		dy := formation.Y - member.Position.Y
+		// Calculate movement toward formation position, the short way round a map that wraps
+		dx, dy := geometry.Direction(member.Position, formation)
 		distance := math.Sqrt(dx*dx + dy*dy)
 
 		if distance > 0.5 { // Only move if not already in position
@@ -617,8 +619,8 @@ func (is *InsectSystem) updateForagingSwarmTarget(swarm *SwarmUnit) {
 		swarm.TargetPosition.Y = targetY
 	} else {
 		// Random foraging movement
-		angle := rand.Float64() * 2 * math.Pi
-		distance := 15.0 + rand.Float64()*25.0
+		angle := is.rng.Float64() * 2 * math.Pi
+		distance := 15.0 + is.rng.Float64()*25.0
 		swarm.TargetPosition.X = swarm.CenterPosition.X + math.Cos(angle)*distance
 		swarm.TargetPosition.Y = swarm.CenterPosition.Y + math.Sin(angle)*distance
 	}
@@ -633,7 +635,7 @@ func (is *InsectSystem) updateMigrationSwarmTarget(swarm *SwarmUnit) {
 	} else {
 		// Head toward better environment (simplified)
 		angle := float64(swarm.ID) * 0.5 // Consistent direction based on swarm ID
-		distance := 40.0 + rand.Float64()*60.0
+		distance := 40.0 + is.rng.Float64()*60.0
 		swarm.TargetPosition.X = swarm.CenterPosition.X + math.Cos(angle)*distance
 		swarm.TargetPosition.Y = swarm.CenterPosition.Y + math.Sin(angle)*distance
 	}
@@ -654,19 +656,19 @@ func (is *InsectSystem) updateDefenseSwarmTarget(swarm *SwarmUnit) {
 // Update target for exploration swarms
 func (is *InsectSystem) updateExplorationSwarmTarget(swarm *SwarmUnit) {
 	// Explore new areas
-	angle := rand.Float64() * 2 * math.Pi
-	distance := 30.0 + rand.Float64()*50.0
+	angle := is.rng.Float64() * 2 * math.Pi
+	distance := 30.0 + is.rng.Float64()*50.0
 	swarm.TargetPosition.X = swarm.CenterPosition.X + math.Cos(angle)*distance
 	swarm.TargetPosition.Y = swarm.CenterPosition.Y + math.Sin(angle)*distance
 }
 
 // Update maintains the insect system
-func (is *InsectSystem) Update(tick int) {
+func (is *InsectSystem) Update(tick int, geometry WorldGeometry) {
 	// Update pheromone trails
 	is.updatePheromoneTrails()
 
 	// Update swarm units
-	is.updateSwarmUnits()
+	is.updateSwarmUnits(geometry)
 }
 
 // updatePheromoneTrails handles enhanced trail decay, environmental factors, and persistence
@@ -712,7 +714,7 @@ func (is *InsectSystem) calculateEnvironmentalFactor(trail *PheromoneTrail) floa
 
 	// Check weather conditions (simplified)
 	// In a full implementation, this would check actual weather patterns
-	weatherSeverity := rand.Float64() // 0-1, where 1 is severe weather
+	weatherSeverity := is.rng.Float64() // 0-1, where 1 is severe weather
 
 	if weatherSeverity > 0.7 {
 		// Severe weather reduces persistence
@@ -721,11 +723,11 @@ func (is *InsectSystem) calculateEnvironmentalFactor(trail *PheromoneTrail) floa
 	}
 
 	// Temperature effects (simplified)
-	temperatureFactor := 0.8 + rand.Float64()*0.4 // 0.8-1.2
+	temperatureFactor := 0.8 + is.rng.Float64()*0.4 // 0.8-1.2
 	factor *= temperatureFactor
 
 	// Humidity helps preserve pheromones
-	humidityFactor := 0.9 + rand.Float64()*0.2 // 0.9-1.1
+	humidityFactor := 0.9 + is.rng.Float64()*0.2 // 0.9-1.1
 	factor *= humidityFactor
 
 	return math.Max(0.2, math.Min(2.0, factor)) // Clamp between 0.2 and 2.0
@@ -757,7 +759,7 @@ func (is *InsectSystem) calculateEffectiveDecayRate(trail *PheromoneTrail) float
 }
 
 // updateSwarmUnits maintains swarm integrity and behavior
-func (is *InsectSystem) updateSwarmUnits() {
+func (is *InsectSystem) updateSwarmUnits(geometry WorldGeometry) {
 	activeSwarms := make([]*SwarmUnit, 0)
 
 	for _, swarm := range is.SwarmUnits {
@@ -790,7 +792,7 @@ func (is *InsectSystem) updateSwarmUnits() {
 		// Keep swarms with enough members and a leader
 		if len(swarm.Members) >= 3 && swarm.LeaderEntity != nil {
 			// Update swarm movement
-			is.UpdateSwarmMovement(swarm)
+			is.UpdateSwarmMovement(swarm, geometry)
 			activeSwarms = append(activeSwarms, swarm)
 		} else {
 			// Disband swarm - remove markings from remaining members
@@ -25,12 +25,12 @@ func TestInsectSystem(t *testing.T) {
 
 func TestAddInsectTraitsToEntity(t *testing.T) {
 	// Test entity that should get insect traits (small, cooperative, intelligent)
-	insectLike := NewEntity(1, []string{"size", "cooperation", "intelligence"}, "test", Position{})
+	insectLike := NewEntity(sharedRand, 1, []string{"size", "cooperation", "intelligence"}, "test", Position{})
 	insectLike.SetTrait("size", -0.5)        // Small
 	insectLike.SetTrait("cooperation", 0.6)  // Cooperative
 	insectLike.SetTrait("intelligence", 0.5) // Intelligent
 
-	AddInsectTraitsToEntity(insectLike)
+	AddInsectTraitsToEntity(sharedRand, insectLike)
 
 	// Check that insect traits were added
 	if insectLike.GetTrait("swarm_capability") == 0.0 {
@@ -47,12 +47,12 @@ func TestAddInsectTraitsToEntity(t *testing.T) {
 	}
 
 	// Test entity that should NOT get insect traits (large, non-cooperative)
-	nonInsectLike := NewEntity(2, []string{"size", "cooperation", "intelligence"}, "test", Position{})
+	nonInsectLike := NewEntity(sharedRand, 2, []string{"size", "cooperation", "intelligence"}, "test", Position{})
 	nonInsectLike.SetTrait("size", 0.5)         // Large
 	nonInsectLike.SetTrait("cooperation", 0.2)  // Non-cooperative
 	nonInsectLike.SetTrait("intelligence", 0.2) // Low intelligence
 
-	AddInsectTraitsToEntity(nonInsectLike)
+	AddInsectTraitsToEntity(sharedRand, nonInsectLike)
 
 	// Check that insect traits were NOT added
 	if nonInsectLike.GetTrait("swarm_capability") != 0.0 {
@@ -64,7 +64,7 @@ func TestPheromoneTrailCreation(t *testing.T) {
 	is := NewInsectSystem()
 
 	// Create entity with pheromone production ability
-	entity := NewEntity(1, []string{"pheromone_production"}, "test", Position{X: 0, Y: 0})
+	entity := NewEntity(sharedRand, 1, []string{"pheromone_production"}, "test", Position{X: 0, Y: 0})
 	entity.SetTrait("pheromone_production", 0.8)
 
 	startPos := Position{X: 0, Y: 0}
@@ -96,7 +96,7 @@ func TestPheromoneTrailCreation(t *testing.T) {
 	}
 
 	// Test entity with low pheromone production
-	weakEntity := NewEntity(2, []string{"pheromone_production"}, "test", Position{})
+	weakEntity := NewEntity(sharedRand, 2, []string{"pheromone_production"}, "test", Position{})
 	weakEntity.SetTrait("pheromone_production", 0.1) // Too low
 
 	weakTrail := is.CreatePheromoneTrail(weakEntity, TrailPheromone, startPos, endPos)
@@ -109,10 +109,10 @@ func TestPheromoneTrailFollowing(t *testing.T) {
 	is := NewInsectSystem()
 
 	// Create entity with pheromone sensitivity
-	follower := NewEntity(1, []string{"pheromone_sensitivity"}, "test", Position{X: 5, Y: 5})
+	follower := NewEntity(sharedRand, 1, []string{"pheromone_sensitivity"}, "test", Position{X: 5, Y: 5})
 	follower.SetTrait("pheromone_sensitivity", 0.8)
 
-	producer := NewEntity(2, []string{"pheromone_production"}, "test", Position{})
+	producer := NewEntity(sharedRand, 2, []string{"pheromone_production"}, "test", Position{})
 	producer.SetTrait("pheromone_production", 0.8)
 
 	// Create trail near follower
@@ -138,7 +138,7 @@ func TestPheromoneTrailFollowing(t *testing.T) {
 	}
 
 	// Test entity with low sensitivity
-	insensitive := NewEntity(3, []string{"pheromone_sensitivity"}, "test", Position{X: 5, Y: 5})
+	insensitive := NewEntity(sharedRand, 3, []string{"pheromone_sensitivity"}, "test", Position{X: 5, Y: 5})
 	insensitive.SetTrait("pheromone_sensitivity", 0.1) // Too low
 
 	_, _, foundByInsensitive := is.FollowPheromoneTrail(insensitive, FoodPheromone)
@@ -157,7 +157,7 @@ func TestPheromoneTrailDecay(t *testing.T) {
 	is := NewInsectSystem()
 
 	// Create entity and trail
-	entity := NewEntity(1, []string{"pheromone_production"}, "test", Position{})
+	entity := NewEntity(sharedRand, 1, []string{"pheromone_production"}, "test", Position{})
 	entity.SetTrait("pheromone_production", 0.8)
 
 	startPos := Position{X: 0, Y: 0}
@@ -203,7 +203,7 @@ func TestSwarmUnitCreation(t *testing.T) {
 	traitNames := []string{"swarm_capability", "cooperation", "intelligence"}
 
 	for i := 0; i < 6; i++ {
-		entities[i] = NewEntity(i+1, traitNames, "test_species", Position{X: float64(i * 2), Y: 0})
+		entities[i] = NewEntity(sharedRand, i+1, traitNames, "test_species", Position{X: float64(i * 2), Y: 0})
 		entities[i].SetTrait("swarm_capability", 0.5+float64(i)*0.1)
 		entities[i].SetTrait("cooperation", 0.6+float64(i)*0.05)
 		entities[i].SetTrait("intelligence", 0.4+float64(i)*0.1)
@@ -253,7 +253,7 @@ func TestSwarmUnitCreation(t *testing.T) {
 	// Test entities unsuitable for swarming
 	unsuitableEntities := make([]*Entity, 5)
 	for i := 0; i < 5; i++ {
-		unsuitableEntities[i] = NewEntity(i+10, traitNames, "test", Position{})
+		unsuitableEntities[i] = NewEntity(sharedRand, i+10, traitNames, "test", Position{})
 		unsuitableEntities[i].SetTrait("swarm_capability", 0.1) // Too low
 		unsuitableEntities[i].SetTrait("cooperation", 0.2)      // Too low
 	}
@@ -272,7 +272,7 @@ func TestSwarmMovement(t *testing.T) {
 	traitNames := []string{"swarm_capability", "cooperation", "speed"}
 
 	for i := 0; i < 5; i++ {
-		entities[i] = NewEntity(i+1, traitNames, "test", Position{X: float64(i * 2), Y: 0})
+		entities[i] = NewEntity(sharedRand, i+1, traitNames, "test", Position{X: float64(i * 2), Y: 0})
 		entities[i].SetTrait("swarm_capability", 0.7)
 		entities[i].SetTrait("cooperation", 0.8)
 		entities[i].SetTrait("speed", 0.6)
@@ -293,7 +293,7 @@ func TestSwarmMovement(t *testing.T) {
 	}
 
 	// Update swarm movement
-	is.UpdateSwarmMovement(swarm)
+	is.UpdateSwarmMovement(swarm, BoxGeometry{})
 
 	// Check that members moved
 	moved := false
@@ -330,7 +330,7 @@ func TestSwarmFormations(t *testing.T) {
 	// Create test swarm
 	entities := make([]*Entity, 6) // Changed from 4 to 6 to meet minimum swarm size
 	for i := 0; i < 6; i++ {
-		entities[i] = NewEntity(i+1, []string{"swarm_capability", "cooperation"}, "test", Position{})
+		entities[i] = NewEntity(sharedRand, i+1, []string{"swarm_capability", "cooperation"}, "test", Position{})
 		entities[i].SetTrait("swarm_capability", 0.7)
 		entities[i].SetTrait("cooperation", 0.8)
 	}
@@ -366,7 +366,7 @@ func TestSwarmUpdate(t *testing.T) {
 	// Create swarm with some entities
 	entities := make([]*Entity, 5)
 	for i := 0; i < 5; i++ {
-		entities[i] = NewEntity(i+1, []string{"swarm_capability", "cooperation"}, "test", Position{})
+		entities[i] = NewEntity(sharedRand, i+1, []string{"swarm_capability", "cooperation"}, "test", Position{})
 		entities[i].SetTrait("swarm_capability", 0.7)
 		entities[i].SetTrait("cooperation", 0.8)
 	}
@@ -381,7 +381,7 @@ func TestSwarmUpdate(t *testing.T) {
 	entities[1].IsAlive = false
 
 	// Update system
-	is.updateSwarmUnits()
+	is.updateSwarmUnits(BoxGeometry{})
 
 	// Check that dead members were removed
 	if len(swarm.Members) != 3 {
@@ -400,7 +400,7 @@ func TestSwarmUpdate(t *testing.T) {
 	entities[2].IsAlive = false
 	entities[3].IsAlive = false
 
-	is.updateSwarmUnits()
+	is.updateSwarmUnits(BoxGeometry{})
 
 	// Swarm should be disbanded (less than 3 members)
 	if len(is.SwarmUnits) != 0 {
@@ -415,7 +415,7 @@ func TestSwarmUpdate(t *testing.T) {
 
 func TestIsEntityInsectLike(t *testing.T) {
 	// Test insect-like entity
-	insectLike := NewEntity(1, []string{"size", "cooperation", "swarm_capability"}, "test", Position{})
+	insectLike := NewEntity(sharedRand, 1, []string{"size", "cooperation", "swarm_capability"}, "test", Position{})
 	insectLike.SetTrait("size", -0.3)            // Small
 	insectLike.SetTrait("cooperation", 0.6)      // Cooperative
 	insectLike.SetTrait("swarm_capability", 0.5) // Swarm capable
@@ -425,7 +425,7 @@ func TestIsEntityInsectLike(t *testing.T) {
 	}
 
 	// Test non-insect-like entity (large)
-	large := NewEntity(2, []string{"size", "cooperation", "swarm_capability"}, "test", Position{})
+	large := NewEntity(sharedRand, 2, []string{"size", "cooperation", "swarm_capability"}, "test", Position{})
 	large.SetTrait("size", 0.5)             // Large
 	large.SetTrait("cooperation", 0.6)      // Cooperative
 	large.SetTrait("swarm_capability", 0.5) // Swarm capable
@@ -435,7 +435,7 @@ func TestIsEntityInsectLike(t *testing.T) {
 	}
 
 	// Test non-insect-like entity (non-cooperative)
-	nonCooperative := NewEntity(3, []string{"size", "cooperation", "swarm_capability"}, "test", Position{})
+	nonCooperative := NewEntity(sharedRand, 3, []string{"size", "cooperation", "swarm_capability"}, "test", Position{})
 	nonCooperative.SetTrait("size", -0.3)            // Small
 	nonCooperative.SetTrait("cooperation", 0.2)      // Non-cooperative
 	nonCooperative.SetTrait("swarm_capability", 0.5) // Swarm capable
@@ -445,7 +445,7 @@ func TestIsEntityInsectLike(t *testing.T) {
 	}
 
 	// Test non-insect-like entity (low swarm capability)
-	lowSwarm := NewEntity(4, []string{"size", "cooperation", "swarm_capability"}, "test", Position{})
+	lowSwarm := NewEntity(sharedRand, 4, []string{"size", "cooperation", "swarm_capability"}, "test", Position{})
 	lowSwarm.SetTrait("size", -0.3)            // Small
 	lowSwarm.SetTrait("cooperation", 0.6)      // Cooperative
 	lowSwarm.SetTrait("swarm_capability", 0.2) // Low swarm capability
@@ -459,7 +459,7 @@ func TestPheromoneStrengthAtPosition(t *testing.T) {
 	is := NewInsectSystem()
 
 	// Create entity and trail
-	entity := NewEntity(1, []string{"pheromone_production"}, "test", Position{})
+	entity := NewEntity(sharedRand, 1, []string{"pheromone_production"}, "test", Position{})
 	entity.SetTrait("pheromone_production", 0.8)
 
 	startPos := Position{X: 0, Y: 0}
@@ -498,7 +498,7 @@ func TestPheromoneTrailReinforcement(t *testing.T) {
 	is := NewInsectSystem()
 
 	// Create initial trail
-	producer := NewEntity(1, []string{"pheromone_production"}, "test", Position{})
+	producer := NewEntity(sharedRand, 1, []string{"pheromone_production"}, "test", Position{})
 	producer.SetTrait("pheromone_production", 0.5)
 
 	startPos := Position{X: 0, Y: 0}
@@ -512,7 +512,7 @@ func TestPheromoneTrailReinforcement(t *testing.T) {
 	originalStrength := trail.Strength[2] // Middle of trail
 
 	// Create reinforcing entity near trail
-	reinforcer := NewEntity(2, []string{"pheromone_production"}, "test", Position{X: 5, Y: 5})
+	reinforcer := NewEntity(sharedRand, 2, []string{"pheromone_production"}, "test", Position{X: 5, Y: 5})
 	reinforcer.SetTrait("pheromone_production", 0.6)
 
 	// Reinforce trail
@@ -524,7 +524,7 @@ func TestPheromoneTrailReinforcement(t *testing.T) {
 	}
 
 	// Test entity with no pheromone production
-	nonProducer := NewEntity(3, []string{"pheromone_production"}, "test", Position{X: 5, Y: 5})
+	nonProducer := NewEntity(sharedRand, 3, []string{"pheromone_production"}, "test", Position{X: 5, Y: 5})
 	nonProducer.SetTrait("pheromone_production", 0.05) // Too low
 
 	originalStrengthBeforeNonReinforcement := trail.Strength[2]
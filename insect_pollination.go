@@ -79,6 +79,8 @@ type InsectPollinationSystem struct {
 	NectarConsumed           float64                     `json:"nectar_consumed"`
 	SeasonalModifier         float64                     `json:"seasonal_modifier"`
 	NextEventID              int                         `json:"next_event_id"`
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewInsectPollinationSystem creates a new pollination management system
@@ -89,11 +91,12 @@ func NewInsectPollinationSystem() *InsectPollinationSystem {
 		PollinatorMemories: make(map[int][]*PollinatorMemory),
 		SeasonalModifier:   1.0,
 		NextEventID:        1,
+		rng:                sharedRand,
 	}
 }
 
 // AddPollinatorTraitsToEntity adds pollination traits to insect-like entities
-func AddPollinatorTraitsToEntity(entity *Entity) {
+func AddPollinatorTraitsToEntity(rng *rand.Rand, entity *Entity) {
 	// Check if entity is suitable for pollination (insect-like + flying capability)
 	size := entity.GetTrait("size")
 	flyingAbility := entity.GetTrait("flying_ability")
@@ -111,32 +114,32 @@ func AddPollinatorTraitsToEntity(entity *Entity) {
 		}
 
 		// Add pollination-specific traits
-		entity.SetTrait("pollination_efficiency", 0.3+rand.Float64()*0.6)
-		entity.SetTrait("nectar_detection", 0.4+rand.Float64()*0.5)
+		entity.SetTrait("pollination_efficiency", 0.3+rng.Float64()*0.6)
+		entity.SetTrait("nectar_detection", 0.4+rng.Float64()*0.5)
 		entity.SetTrait("flower_memory", intelligence*0.8+0.2)
-		entity.SetTrait("pollen_capacity", 0.2+rand.Float64()*0.4)
+		entity.SetTrait("pollen_capacity", 0.2+rng.Float64()*0.4)
 		entity.SetTrait("flight_range", flyingAbility*30+10) // 10-40 unit range
-		entity.SetTrait("nectar_needs", 0.1+rand.Float64()*0.2)
-		entity.SetTrait("seasonal_activity", 0.6+rand.Float64()*0.4)
+		entity.SetTrait("nectar_needs", 0.1+rng.Float64()*0.2)
+		entity.SetTrait("seasonal_activity", 0.6+rng.Float64()*0.4)
 		entity.SetTrait("pollinator_type", float64(pollinatorType))
 
 		// Set plant preferences based on pollinator type
 		switch pollinatorType {
 		case GeneralistPollinator:
 			// Equal preference for all flowering plants
-			entity.SetTrait("grass_preference", 0.6+rand.Float64()*0.3)
-			entity.SetTrait("bush_preference", 0.7+rand.Float64()*0.3)
-			entity.SetTrait("tree_preference", 0.5+rand.Float64()*0.4)
-			entity.SetTrait("cactus_preference", 0.4+rand.Float64()*0.3)
+			entity.SetTrait("grass_preference", 0.6+rng.Float64()*0.3)
+			entity.SetTrait("bush_preference", 0.7+rng.Float64()*0.3)
+			entity.SetTrait("tree_preference", 0.5+rng.Float64()*0.4)
+			entity.SetTrait("cactus_preference", 0.4+rng.Float64()*0.3)
 		case SpecialistPollinator:
 			// Strong preference for 1-2 plant types
-			preferredType := rand.Intn(4) // Choose primary preference
+			preferredType := rng.Intn(4) // Choose primary preference
 			for i := 0; i < 4; i++ {
-				preference := 0.1 + rand.Float64()*0.2 // Low base preference
+				preference := 0.1 + rng.Float64()*0.2 // Low base preference
 				if i == preferredType {
-					preference = 0.8 + rand.Float64()*0.2 // High preference for chosen type
-				} else if rand.Float64() < 0.3 { // 30% chance of secondary preference
-					preference = 0.4 + rand.Float64()*0.3
+					preference = 0.8 + rng.Float64()*0.2 // High preference for chosen type
+				} else if rng.Float64() < 0.3 { // 30% chance of secondary preference
+					preference = 0.4 + rng.Float64()*0.3
 				}
 
 				switch i {
@@ -152,10 +155,10 @@ func AddPollinatorTraitsToEntity(entity *Entity) {
 			}
 		case HybridPollinator:
 			// Moderate preferences with some specialization
-			entity.SetTrait("grass_preference", 0.3+rand.Float64()*0.5)
-			entity.SetTrait("bush_preference", 0.4+rand.Float64()*0.5)
-			entity.SetTrait("tree_preference", 0.3+rand.Float64()*0.6)
-			entity.SetTrait("cactus_preference", 0.2+rand.Float64()*0.4)
+			entity.SetTrait("grass_preference", 0.3+rng.Float64()*0.5)
+			entity.SetTrait("bush_preference", 0.4+rng.Float64()*0.5)
+			entity.SetTrait("tree_preference", 0.3+rng.Float64()*0.6)
+			entity.SetTrait("cactus_preference", 0.2+rng.Float64()*0.4)
 		}
 
 		// Enhance energy and endurance for pollinators
@@ -246,7 +249,7 @@ func (ips *InsectPollinationSystem) determinePollinatorAttraction(plantType Plan
 		}
 	case PlantGrass:
 		// Simple plants mainly attract generalists
-		if rand.Float64() < 0.3 {
+		if ips.rng.Float64() < 0.3 {
 			attraction = append(attraction, HybridPollinator)
 		}
 	}
@@ -255,14 +258,11 @@ func (ips *InsectPollinationSystem) determinePollinatorAttraction(plantType Plan
 }
 
 // FindNearbyFlowers finds flower patches within a pollinator's range
-func (ips *InsectPollinationSystem) FindNearbyFlowers(pollinator *Entity, maxDistance float64) []*FlowerPatch {
+func (ips *InsectPollinationSystem) FindNearbyFlowers(pollinator *Entity, maxDistance float64, geometry WorldGeometry) []*FlowerPatch {
 	nearbyFlowers := make([]*FlowerPatch, 0)
 
 	for _, patch := range ips.FlowerPatches {
-		distance := math.Sqrt(
-			math.Pow(patch.Position.X-pollinator.Position.X, 2) +
-				math.Pow(patch.Position.Y-pollinator.Position.Y, 2),
-		)
+		distance := geometry.Distance(pollinator.Position, patch.Position)
 
 		if distance <= maxDistance && patch.BloomingLevel > 0.1 {
 			nearbyFlowers = append(nearbyFlowers, patch)
@@ -273,7 +273,7 @@ func (ips *InsectPollinationSystem) FindNearbyFlowers(pollinator *Entity, maxDis
 }
 
 // SelectBestFlower chooses the most attractive flower for a pollinator
-func (ips *InsectPollinationSystem) SelectBestFlower(pollinator *Entity, flowers []*FlowerPatch) *FlowerPatch {
+func (ips *InsectPollinationSystem) SelectBestFlower(pollinator *Entity, flowers []*FlowerPatch, geometry WorldGeometry) *FlowerPatch {
 	if len(flowers) == 0 {
 		return nil
 	}
@@ -286,7 +286,7 @@ func (ips *InsectPollinationSystem) SelectBestFlower(pollinator *Entity, flowers
 	bestScore := 0.0
 
 	for _, flower := range flowers {
-		score := ips.calculateFlowerAttractiveness(pollinator, flower, pollinatorType, nectarDetection, memories)
+		score := ips.calculateFlowerAttractiveness(pollinator, flower, pollinatorType, nectarDetection, memories, geometry)
 		if score > bestScore {
 			bestScore = score
 			bestFlower = flower
@@ -298,7 +298,7 @@ func (ips *InsectPollinationSystem) SelectBestFlower(pollinator *Entity, flowers
 
 // calculateFlowerAttractiveness calculates how attractive a flower is to a pollinator
 func (ips *InsectPollinationSystem) calculateFlowerAttractiveness(pollinator *Entity, flower *FlowerPatch,
-	pollinatorType PollinatorType, nectarDetection float64, memories []*PollinatorMemory) float64 {
+	pollinatorType PollinatorType, nectarDetection float64, memories []*PollinatorMemory, geometry WorldGeometry) float64 {
 
 	score := 0.0
 
@@ -310,19 +310,13 @@ func (ips *InsectPollinationSystem) calculateFlowerAttractiveness(pollinator *En
 	score *= preference
 
 	// Distance penalty
-	distance := math.Sqrt(
-		math.Pow(flower.Position.X-pollinator.Position.X, 2) +
-			math.Pow(flower.Position.Y-pollinator.Position.Y, 2),
-	)
+	distance := geometry.Distance(pollinator.Position, flower.Position)
 	score *= (1.0 / (1.0 + distance*0.1))
 
 	// Memory bonus
 	for _, memory := range memories {
 		if memory.PlantType == flower.PlantType {
-			memoryDistance := math.Sqrt(
-				math.Pow(memory.Location.X-flower.Position.X, 2) +
-					math.Pow(memory.Location.Y-flower.Position.Y, 2),
-			)
+			memoryDistance := geometry.Distance(memory.Location, flower.Position)
 			if memoryDistance < 5.0 { // Close to remembered location
 				score *= (1.0 + memory.SuccessRate*memory.MemoryStrength)
 			}
@@ -362,14 +356,14 @@ func (ips *InsectPollinationSystem) getPlantPreference(pollinator *Entity, plant
 }
 
 // AttemptPollination processes a pollination attempt between pollinator and flower
-func (ips *InsectPollinationSystem) AttemptPollination(pollinator *Entity, flower *FlowerPatch, tick int) bool {
+func (ips *InsectPollinationSystem) AttemptPollination(pollinator *Entity, flower *FlowerPatch, tick int, geometry WorldGeometry) bool {
 	efficiency := pollinator.GetTrait("pollination_efficiency")
 	pollenCapacity := pollinator.GetTrait("pollen_capacity")
 
 	// Check if pollination is successful
 	successChance := efficiency * flower.BloomingLevel * ips.SeasonalModifier
 
-	if rand.Float64() > successChance {
+	if ips.rng.Float64() > successChance {
 		return false // Pollination failed
 	}
 
@@ -392,7 +386,7 @@ func (ips *InsectPollinationSystem) AttemptPollination(pollinator *Entity, flowe
 	ips.NectarConsumed += nectarGained
 
 	// Update pollinator memory
-	ips.updatePollinatorMemory(pollinator, flower, nectarGained, tick)
+	ips.updatePollinatorMemory(pollinator, flower, nectarGained, tick, geometry)
 
 	// Check for cross-pollination with carried pollen
 	ips.tryTransferPollen(pollinator, flower, pollenTransfer, tick)
@@ -401,7 +395,7 @@ func (ips *InsectPollinationSystem) AttemptPollination(pollinator *Entity, flowe
 }
 
 // updatePollinatorMemory updates a pollinator's memory of flower locations
-func (ips *InsectPollinationSystem) updatePollinatorMemory(pollinator *Entity, flower *FlowerPatch, nectarGained float64, tick int) {
+func (ips *InsectPollinationSystem) updatePollinatorMemory(pollinator *Entity, flower *FlowerPatch, nectarGained float64, tick int, geometry WorldGeometry) {
 	memories := ips.PollinatorMemories[pollinator.ID]
 	if memories == nil {
 		memories = make([]*PollinatorMemory, 0)
@@ -410,10 +404,7 @@ func (ips *InsectPollinationSystem) updatePollinatorMemory(pollinator *Entity, f
 	// Look for existing memory of this location
 	var existingMemory *PollinatorMemory
 	for _, memory := range memories {
-		distance := math.Sqrt(
-			math.Pow(memory.Location.X-flower.Position.X, 2) +
-				math.Pow(memory.Location.Y-flower.Position.Y, 2),
-		)
+		distance := geometry.Distance(memory.Location, flower.Position)
 		if distance < 3.0 && memory.PlantType == flower.PlantType {
 			existingMemory = memory
 			break
@@ -483,7 +474,7 @@ func (ips *InsectPollinationSystem) tryTransferPollen(pollinator *Entity, flower
 		// Attempt cross-pollination
 		crossPollinationSuccess := pollinator.GetTrait("pollination_efficiency") * 0.7
 
-		if rand.Float64() < crossPollinationSuccess {
+		if ips.rng.Float64() < crossPollinationSuccess {
 			// Record pollination event
 			event := &PollinationEvent{
 				PollinatorID:   pollinator.ID,
@@ -579,7 +570,8 @@ func (ips *InsectPollinationSystem) UpdateFlowerPatches(plants []*Plant, season
 
 // UpdatePollinatorMemories decays old memories
 func (ips *InsectPollinationSystem) UpdatePollinatorMemories(tick int) {
-	for entityID, memories := range ips.PollinatorMemories {
+	for _, entityID := range sortedKeys(ips.PollinatorMemories) {
+		memories := ips.PollinatorMemories[entityID]
 		activeMemories := make([]*PollinatorMemory, 0)
 
 		for _, memory := range memories {
@@ -636,7 +628,7 @@ func (ips *InsectPollinationSystem) GetPollinationStats() map[string]interface{}
 }
 
 // Update processes the pollination system for one tick
-func (ips *InsectPollinationSystem) Update(entities []*Entity, plants []*Plant, season Season, tick int) {
+func (ips *InsectPollinationSystem) Update(entities []*Entity, plants []*Plant, season Season, tick int, geometry WorldGeometry) {
 	// Update seasonal modifier
 	ips.updateSeasonalModifier(season)
 
@@ -649,7 +641,7 @@ func (ips *InsectPollinationSystem) Update(entities []*Entity, plants []*Plant,
 	// Process pollinator behaviors
 	for _, entity := range entities {
 		if entity.IsAlive && ips.isEntityPollinator(entity) {
-			ips.processPollinatorBehavior(entity, tick)
+			ips.processPollinatorBehavior(entity, tick, geometry)
 		}
 	}
 
@@ -683,13 +675,13 @@ func (ips *InsectPollinationSystem) isEntityPollinator(entity *Entity) bool {
 }
 
 // processPollinatorBehavior handles pollinator decision-making and actions
-func (ips *InsectPollinationSystem) processPollinatorBehavior(pollinator *Entity, tick int) {
+func (ips *InsectPollinationSystem) processPollinatorBehavior(pollinator *Entity, tick int, geometry WorldGeometry) {
 	flightRange := pollinator.GetTrait("flight_range")
 	nectarNeeds := pollinator.GetTrait("nectar_needs")
 	seasonalActivity := pollinator.GetTrait("seasonal_activity")
 
 	// Adjust activity based on seasonal modifier
-	if rand.Float64() > seasonalActivity*ips.SeasonalModifier {
+	if ips.rng.Float64() > seasonalActivity*ips.SeasonalModifier {
 		return // Not active this tick
 	}
 
@@ -697,39 +689,35 @@ func (ips *InsectPollinationSystem) processPollinatorBehavior(pollinator *Entity
 	energyRatio := pollinator.Energy / pollinator.GetTrait("endurance")
 	needsNectar := energyRatio < nectarNeeds*2.0
 
-	if needsNectar || rand.Float64() < 0.3 { // 30% chance of foraging even when not needy
+	if needsNectar || ips.rng.Float64() < 0.3 { // 30% chance of foraging even when not needy
 		// Look for nearby flowers
-		nearbyFlowers := ips.FindNearbyFlowers(pollinator, flightRange)
+		nearbyFlowers := ips.FindNearbyFlowers(pollinator, flightRange, geometry)
 
 		if len(nearbyFlowers) > 0 {
 			// Select best flower based on preferences and memory
-			targetFlower := ips.SelectBestFlower(pollinator, nearbyFlowers)
+			targetFlower := ips.SelectBestFlower(pollinator, nearbyFlowers, geometry)
 
 			if targetFlower != nil {
 				// Move toward flower
-				ips.moveTowardFlower(pollinator, targetFlower)
+				ips.moveTowardFlower(pollinator, targetFlower, geometry)
 
 				// Check if close enough to pollinate
-				distance := math.Sqrt(
-					math.Pow(targetFlower.Position.X-pollinator.Position.X, 2) +
-						math.Pow(targetFlower.Position.Y-pollinator.Position.Y, 2),
-				)
+				distance := geometry.Distance(pollinator.Position, targetFlower.Position)
 
 				if distance < 2.0 { // Close enough to interact
-					ips.AttemptPollination(pollinator, targetFlower, tick)
+					ips.AttemptPollination(pollinator, targetFlower, tick, geometry)
 				}
 			}
 		} else {
 			// No flowers nearby, search for new areas
-			ips.searchForFlowers(pollinator)
+			ips.searchForFlowers(pollinator, geometry)
 		}
 	}
 }
 
 // moveTowardFlower moves a pollinator toward a target flower
-func (ips *InsectPollinationSystem) moveTowardFlower(pollinator *Entity, flower *FlowerPatch) {
-	dx := flower.Position.X - pollinator.Position.X
-	dy := flower.Position.Y - pollinator.Position.Y
+func (ips *InsectPollinationSystem) moveTowardFlower(pollinator *Entity, flower *FlowerPatch, geometry WorldGeometry) {
+	dx, dy := geometry.Direction(pollinator.Position, flower.Position)
 	distance := math.Sqrt(dx*dx + dy*dy)
 
 	if distance > 0.5 {
@@ -742,11 +730,11 @@ func (ips *InsectPollinationSystem) moveTowardFlower(pollinator *Entity, flower
 }
 
 // searchForFlowers makes a pollinator explore for new flower patches
-func (ips *InsectPollinationSystem) searchForFlowers(pollinator *Entity) {
+func (ips *InsectPollinationSystem) searchForFlowers(pollinator *Entity, geometry WorldGeometry) {
 	// Use memory to guide search
 	memories := ips.PollinatorMemories[pollinator.ID]
 
-	if len(memories) > 0 && rand.Float64() < 0.7 { // 70% chance to use memory
+	if len(memories) > 0 && ips.rng.Float64() < 0.7 { // 70% chance to use memory
 		// Move toward remembered location
 		bestMemory := memories[0]
 		for _, memory := range memories {
@@ -755,8 +743,7 @@ func (ips *InsectPollinationSystem) searchForFlowers(pollinator *Entity) {
 			}
 		}
 
-		dx := bestMemory.Location.X - pollinator.Position.X
-		dy := bestMemory.Location.Y - pollinator.Position.Y
+		dx, dy := geometry.Direction(pollinator.Position, bestMemory.Location)
 		distance := math.Sqrt(dx*dx + dy*dy)
 
 		if distance > 1.0 {
@@ -766,7 +753,7 @@ func (ips *InsectPollinationSystem) searchForFlowers(pollinator *Entity) {
 		}
 	} else {
 		// Random exploration
-		angle := rand.Float64() * 2 * math.Pi
+		angle := ips.rng.Float64() * 2 * math.Pi
 		speed := pollinator.GetTrait("speed") * 0.3
 
 		pollinator.Position.X += math.Cos(angle) * speed
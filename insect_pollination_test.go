@@ -41,7 +41,7 @@ func TestPollinatorTraitsAddition(t *testing.T) {
 	entity.SetTrait("intelligence", 0.6)
 
 	// Add pollinator traits
-	AddPollinatorTraitsToEntity(entity)
+	AddPollinatorTraitsToEntity(sharedRand, entity)
 
 	// Check that pollinator traits were added
 	if entity.GetTrait("pollination_efficiency") < 0.3 {
@@ -65,7 +65,7 @@ func TestFlowerPatchCreation(t *testing.T) {
 	system := NewInsectPollinationSystem()
 
 	// Create a healthy mature plant
-	plant := NewPlant(1, PlantTree, Position{X: 10, Y: 10})
+	plant := NewPlant(sharedRand, 1, PlantTree, Position{X: 10, Y: 10})
 	plant.Age = 20 // Mature enough to flower
 	plant.Energy = 100
 
@@ -110,10 +110,10 @@ func TestPollinatorBehaviorSystem(t *testing.T) {
 	pollinator.SetTrait("flying_ability", 0.6)
 	pollinator.SetTrait("swarm_capability", 0.4)
 	pollinator.SetTrait("intelligence", 0.5)
-	AddPollinatorTraitsToEntity(pollinator)
+	AddPollinatorTraitsToEntity(sharedRand, pollinator)
 
 	// Create a flowering plant
-	plant := NewPlant(1, PlantBush, Position{X: 5, Y: 5})
+	plant := NewPlant(sharedRand, 1, PlantBush, Position{X: 5, Y: 5})
 	plant.Age = 15
 	plant.Energy = 80
 
@@ -122,7 +122,7 @@ func TestPollinatorBehaviorSystem(t *testing.T) {
 
 	// Update system for a few ticks
 	for i := 0; i < 5; i++ {
-		system.Update(entities, plants, Summer, i)
+		system.Update(entities, plants, Summer, i, BoxGeometry{})
 	}
 
 	// Check that flower patches were created
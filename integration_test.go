@@ -14,7 +14,7 @@ func TestIntegration(t *testing.T) {
 	generations := 10
 
 	// Create population
-	population := NewPopulation(populationSize, traitNames, mutationRate, mutationStrength)
+	population := NewPopulation(sharedRand, populationSize, traitNames, mutationRate, mutationStrength)
 	if population == nil {
 		t.Fatal("Failed to create population")
 	}
@@ -81,7 +81,7 @@ func TestIntegration(t *testing.T) {
 // TestDynamicEvaluation tests the dynamic evaluation system
 func TestDynamicEvaluation(t *testing.T) {
 	pos := Position{X: 0, Y: 0}
-	entity := NewEntity(1, []string{"strength", "agility", "magic"}, "test", pos)
+	entity := NewEntity(sharedRand, 1, []string{"strength", "agility", "magic"}, "test", pos)
 	entity.SetTrait("strength", 1.0)
 	entity.SetTrait("agility", 0.5)
 	entity.SetTrait("magic", 2.0)
@@ -137,7 +137,7 @@ func TestDynamicEvaluation(t *testing.T) {
 // TestEvolutionConvergence tests that evolution tends to improve fitness over time
 func TestEvolutionConvergence(t *testing.T) {
 	traitNames := []string{"x", "y"}
-	population := NewPopulation(50, traitNames, 0.2, 0.3)
+	population := NewPopulation(sharedRand, 50, traitNames, 0.2, 0.3)
 
 	// Simple optimization problem: minimize distance from origin
 	engine := NewEvaluationEngine()
@@ -52,6 +52,8 @@ type IsometricEntity struct {
 	Energy   float64                `json:"energy"`
 	Age      int                    `json:"age"`
 	Color    string                 `json:"color"`
+	Scale    float64                `json:"scale"`  // How large to draw the creature, by its size (see TraitLegend)
+	Colors   TraitColors            `json:"colors"` // Its colour in each trait colour scheme (see AppearanceColorDiet)
 	Traits   map[string]float64     `json:"traits"`
 	DNA      IsometricDNA           `json:"dna"`
 }
@@ -100,6 +102,10 @@ type WorldInfo struct {
 	Tick      int `json:"tick"`
 	TotalEntities int `json:"totalEntities"`
 	TotalPlants   int `json:"totalPlants"`
+	MapWidth      float64 `json:"mapWidth"`  // Width of the world in the units creatures and plants are placed in
+	MapHeight     float64 `json:"mapHeight"` // Height of the world in the same units
+	SeaLevel      float64 `json:"seaLevel"`  // Elevation of the sea
+	Geometry      string  `json:"geometry"`  // Which edges of the map join (see GeometryBox)
 }
 
 // IsometricViewManager manages the isometric view data generation
@@ -137,8 +143,14 @@ func (ivm *IsometricViewManager) GenerateIsometricData(viewportX, viewportY int,
 			Height:        ivm.world.Config.GridHeight,
 			Tick:          ivm.world.Tick,
 			TotalEntities: len(ivm.world.AllEntities),
+			MapWidth:      ivm.world.Config.Width,
+			MapHeight:     ivm.world.Config.Height,
+			Geometry:      ivm.world.Config.geometry(),
 		},
 	}
+	if ivm.world.TopologySystem != nil {
+		data.WorldInfo.SeaLevel = ivm.world.TopologySystem.SeaLevel
+	}
 	
 	// Generate tiles
 	for y := int(startY); y < int(endY); y++ {
@@ -279,6 +291,7 @@ func (ivm *IsometricViewManager) GenerateIsometricData(viewportX, viewportY int,
 						}
 					}
 					
+					scale, colors := ivm.world.EntityAppearance([]*Entity{entity})
 					isometricEntity := IsometricEntity{
 						ID:      entity.ID,
 						X:       entity.Position.X,
@@ -288,6 +301,8 @@ func (ivm *IsometricViewManager) GenerateIsometricData(viewportX, viewportY int,
 						Energy:  entity.Energy,
 						Age:     entity.Age,
 						Color:   ivm.getEntityColorHex(entity.Species),
+						Scale:   scale,
+						Colors:  colors,
 						Traits:  traits,
 						DNA:     dna,
 					}
@@ -303,28 +318,39 @@ func (ivm *IsometricViewManager) GenerateIsometricData(viewportX, viewportY int,
 	return data
 }
 
+// isoBiomeColors are the colours the isometric view paints each biome's tiles
+var isoBiomeColors = map[BiomeType]string{
+	BiomePlains:       "#64C864", // Green
+	BiomeForest:       "#329632", // Dark green
+	BiomeWater:        "#3264C8", // Blue
+	BiomeMountain:     "#969696", // Gray
+	BiomeDesert:       "#C8B464", // Sandy
+	BiomeTundra:       "#C8DCFF", // Light blue
+	BiomeSwamp:        "#649664", // Murky green
+	BiomeIce:          "#F0F0FF", // White
+	BiomeRainforest:   "#147814", // Very dark green
+	BiomeSoil:         "#8B4513", // Brown
+	BiomeAir:          "#C8DCFF", // Transparent blue
+	BiomeDeepWater:    "#143C96", // Dark blue
+	BiomeHighAltitude: "#B4B4C8", // Light gray
+	BiomeCanyon:       "#B47850", // Orange-brown
+	BiomeRadiation:    "#FF6464", // Red
+	BiomeHotSpring:    "#FF9664", // Orange
+}
+
+// isoPlantColors are the colours the isometric view paints each plant type
+var isoPlantColors = map[PlantType]string{
+	PlantGrass:    "#64FF64", // Bright green
+	PlantBush:     "#32C832", // Medium green
+	PlantTree:     "#8B4513", // Brown trunk
+	PlantMushroom: "#C89664", // Brown
+	PlantAlgae:    "#32FF96", // Cyan-green
+	PlantCactus:   "#329632", // Dark green
+}
+
 // getBiomeColorHex returns hex color for biomes
 func (ivm *IsometricViewManager) getBiomeColorHex(biomeType BiomeType) string {
-	colors := map[BiomeType]string{
-		BiomePlains:       "#64C864", // Green
-		BiomeForest:       "#329632", // Dark green
-		BiomeWater:        "#3264C8", // Blue
-		BiomeMountain:     "#969696", // Gray
-		BiomeDesert:       "#C8B464", // Sandy
-		BiomeTundra:       "#C8DCFF", // Light blue
-		BiomeSwamp:        "#649664", // Murky green
-		BiomeIce:          "#F0F0FF", // White
-		BiomeRainforest:   "#147814", // Very dark green
-		BiomeSoil:         "#8B4513", // Brown
-		BiomeAir:          "#C8DCFF", // Transparent blue
-		BiomeDeepWater:    "#143C96", // Dark blue
-		BiomeHighAltitude: "#B4B4C8", // Light gray
-		BiomeCanyon:       "#B47850", // Orange-brown
-		BiomeRadiation:    "#FF6464", // Red
-		BiomeHotSpring:    "#FF9664", // Orange
-	}
-	
-	if color, exists := colors[biomeType]; exists {
+	if color, exists := isoBiomeColors[biomeType]; exists {
 		return color
 	}
 	return "#808080" // Default gray
@@ -332,16 +358,7 @@ func (ivm *IsometricViewManager) getBiomeColorHex(biomeType BiomeType) string {
 
 // getPlantColorHex returns hex color for plant types
 func (ivm *IsometricViewManager) getPlantColorHex(plantType PlantType) string {
-	colors := map[PlantType]string{
-		PlantGrass:    "#64FF64", // Bright green
-		PlantBush:     "#32C832", // Medium green
-		PlantTree:     "#8B4513", // Brown trunk
-		PlantMushroom: "#C89664", // Brown
-		PlantAlgae:    "#32FF96", // Cyan-green
-		PlantCactus:   "#329632", // Dark green
-	}
-	
-	if color, exists := colors[plantType]; exists {
+	if color, exists := isoPlantColors[plantType]; exists {
 		return color
 	}
 	return "#64C864" // Default green
@@ -484,24 +501,44 @@ func (ivm *IsometricViewManager) addRecentEvents(data *IsometricViewData, viewpo
 	}
 }
 
+// isoGeologicalEventColors are the colours the isometric view marks geological events in
+var isoGeologicalEventColors = map[string]string{
+	"earthquake":          "#8B4513", // Brown
+	"volcanic_eruption":   "#FF4500", // Orange-red
+	"landslide":           "#A0522D", // Sienna
+	"flood":               "#1E90FF", // Dodger blue
+	"continental_drift":   "#696969", // Dim gray
+	"seafloor_spreading":  "#20B2AA", // Light sea green
+	"mountain_uplift":     "#708090", // Slate gray
+	"rift_valley":         "#8B0000", // Dark red
+	"geyser_formation":    "#00FFFF", // Cyan
+	"hot_spring_creation": "#FFB347", // Peach
+	"ice_sheet_advance":   "#F0F8FF", // Alice blue
+	"glacial_retreat":     "#B0E0E6", // Powder blue
+}
+
+// isoEventColors are the colours the isometric view draws world events' effects in
+var isoEventColors = map[string]string{
+	"birth":           "#00FF00", // Green
+	"death":           "#FF0000", // Red
+	"reproduction":    "#FF69B4", // Pink
+	"evolution":       "#FFD700", // Gold
+	"mutation":        "#9932CC", // Purple
+	"migration":       "#00BFFF", // Blue
+	"combat":          "#FF4500", // Orange-red
+	"cooperation":     "#32CD32", // Lime green
+	"tool_creation":   "#8B4513", // Brown
+	"structure_built": "#FFB347", // Orange
+	"extinction":      "#8B0000", // Dark red
+	"speciation":      "#FF1493", // Deep pink
+	"environmental":   "#FFFF00", // Yellow
+	"disaster":        "#DC143C", // Crimson
+	"discovery":       "#00CED1", // Dark turquoise
+}
+
 // getGeologicalEventColor returns color for geological events
 func (ivm *IsometricViewManager) getGeologicalEventColor(eventType string) string {
-	colors := map[string]string{
-		"earthquake":           "#8B4513", // Brown
-		"volcanic_eruption":    "#FF4500", // Orange-red
-		"landslide":            "#A0522D", // Sienna
-		"flood":                "#1E90FF", // Dodger blue
-		"continental_drift":    "#696969", // Dim gray
-		"seafloor_spreading":   "#20B2AA", // Light sea green
-		"mountain_uplift":      "#708090", // Slate gray
-		"rift_valley":          "#8B0000", // Dark red
-		"geyser_formation":     "#00FFFF", // Cyan
-		"hot_spring_creation":  "#FFB347", // Peach
-		"ice_sheet_advance":    "#F0F8FF", // Alice blue
-		"glacial_retreat":      "#B0E0E6", // Powder blue
-	}
-	
-	if color, exists := colors[eventType]; exists {
+	if color, exists := isoGeologicalEventColors[eventType]; exists {
 		return color
 	}
 	return "#808080" // Default gray
@@ -509,26 +546,8 @@ func (ivm *IsometricViewManager) getGeologicalEventColor(eventType string) strin
 
 // getEventColor returns color for different event types
 func (ivm *IsometricViewManager) getEventColor(eventType string) string {
-	colors := map[string]string{
-		"birth":           "#00FF00", // Green
-		"death":           "#FF0000", // Red
-		"reproduction":    "#FF69B4", // Pink
-		"evolution":       "#FFD700", // Gold
-		"mutation":        "#9932CC", // Purple
-		"migration":       "#00BFFF", // Blue
-		"combat":          "#FF4500", // Orange-red
-		"cooperation":     "#32CD32", // Lime green
-		"tool_creation":   "#8B4513", // Brown
-		"structure_built": "#FFB347", // Orange
-		"extinction":      "#8B0000", // Dark red
-		"speciation":      "#FF1493", // Deep pink
-		"environmental":   "#FFFF00", // Yellow
-		"disaster":        "#DC143C", // Crimson
-		"discovery":       "#00CED1", // Dark turquoise
-	}
-	
-	if color, exists := colors[eventType]; exists {
+	if color, exists := isoEventColors[eventType]; exists {
 		return color
 	}
 	return "#FFFFFF" // Default white
-}
\ No newline at end of file
+}
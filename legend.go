@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Renderers a legend can describe
+const (
+	LegendRendererCLI       = "cli" // The terminal grid
+	LegendRendererWeb       = "web" // The web grid
+	LegendRendererIsometric = "iso" // The 2.5D isometric view
+)
+
+// legendRenderers lists the renderers in the order legends are served
+var legendRenderers = []string{LegendRendererCLI, LegendRendererWeb, LegendRendererIsometric}
+
+// LegendEntry is one thing a renderer draws and how it draws it. The renderers' symbol
+// tables hold these, so a legend always shows what the views actually draw.
+type LegendEntry struct {
+	Key    string `json:"key"`              // What it stands for, as the view's data names it
+	Label  string `json:"label"`            // Human-readable name
+	Symbol string `json:"symbol,omitempty"` // Character the view draws, or that its data carries; empty for painted colours
+	Glyph  string `json:"glyph,omitempty"`  // What the browser shows in place of the symbol, when it differs
+	Color  string `json:"color,omitempty"`  // CSS colour, or ANSI 256-colour number for the terminal
+	Class  string `json:"class,omitempty"`  // CSS class of the web grid cell
+}
+
+// LegendSection groups a legend's entries by what they mark: biomes, entities, plants,
+// events, structures or signals
+type LegendSection struct {
+	Name    string        `json:"name"`
+	Label   string        `json:"label"`
+	Entries []LegendEntry `json:"entries"`
+}
+
+// RendererLegend is everything one renderer draws, built from its symbol tables
+type RendererLegend struct {
+	Renderer string          `json:"renderer"`
+	Sections []LegendSection `json:"sections"`
+}
+
+// Section returns the legend's section of the name, or nil
+func (legend RendererLegend) Section(name string) *LegendSection {
+	for i := range legend.Sections {
+		if legend.Sections[i].Name == name {
+			return &legend.Sections[i]
+		}
+	}
+	return nil
+}
+
+// RendererLegends builds the legend of a renderer, or of every renderer when it is empty
+func (w *World) RendererLegends(renderer string) ([]RendererLegend, error) {
+	if renderer == "" {
+		legends := make([]RendererLegend, 0, len(legendRenderers))
+		for _, name := range legendRenderers {
+			legends = append(legends, w.rendererLegend(name))
+		}
+		return legends, nil
+	}
+	for _, name := range legendRenderers {
+		if name == renderer {
+			return []RendererLegend{w.rendererLegend(name)}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown renderer %q (expected %s)", renderer, strings.Join(legendRenderers, ", "))
+}
+
+// rendererLegend builds the legend of a known renderer
+func (w *World) rendererLegend(renderer string) RendererLegend {
+	legend := RendererLegend{Renderer: renderer}
+	switch renderer {
+	case LegendRendererCLI:
+		legend.Sections = w.cliLegend()
+	case LegendRendererWeb:
+		legend.Sections = gridLegend()
+	case LegendRendererIsometric:
+		legend.Sections = w.isometricLegend()
+	}
+	return legend
+}
+
+// cliLegend describes the terminal grid: world biomes in their ANSI colours, overdrawn by
+// structures, then signals, then creatures, then plants
+func (w *World) cliLegend() []LegendSection {
+	biomes := LegendSection{Name: "biomes", Label: "Biomes"}
+	for _, biomeType := range w.biomeTypesByName() {
+		biome := w.Biomes[biomeType]
+		biomes.Entries = append(biomes.Entries, LegendEntry{Key: biome.Name, Label: biome.Name, Symbol: string(biome.Symbol), Color: cliBiomeColors[biomeType]})
+	}
+
+	structures := LegendSection{Name: "structures", Label: "Structures"}
+	for _, structureType := range sortedKeys(cliStructureSymbols) {
+		marker := cliStructureSymbols[structureType]
+		structures.Entries = append(structures.Entries, LegendEntry{Key: legendKey(marker.Label), Label: marker.Label, Symbol: string(marker.Symbol), Color: cliStructureColor})
+	}
+
+	signals := LegendSection{Name: "signals", Label: "Signals"}
+	for _, signalType := range sortedKeys(cliSignalSymbols) {
+		marker := cliSignalSymbols[signalType]
+		signals.Entries = append(signals.Entries, LegendEntry{Key: legendKey(marker.Label), Label: marker.Label, Symbol: string(marker.Symbol), Color: cliSignalColor})
+	}
+
+	entities := LegendSection{Name: "entities", Label: "Creatures"}
+	for _, species := range sortedKeys(baseSpeciesSymbols) {
+		entities.Entries = append(entities.Entries, LegendEntry{Key: species, Label: legendLabel(species), Symbol: string(baseSpeciesSymbols[species]), Color: cliSpeciesColors[species]})
+	}
+	entities.Entries = append(entities.Entries,
+		LegendEntry{Key: "several", Label: "2 to 9 creatures", Symbol: "2-9"},
+		LegendEntry{Key: "many", Label: "10 or more creatures", Symbol: "+"})
+
+	plants := LegendSection{Name: "plants", Label: "Plants"}
+	configs := GetPlantConfigs()
+	for _, plantType := range sortedKeys(configs) {
+		config := configs[plantType]
+		plants.Entries = append(plants.Entries, LegendEntry{Key: legendKey(config.Name), Label: config.Name, Symbol: string(config.Symbol), Color: cliPlantColor})
+	}
+	plants.Entries = append(plants.Entries,
+		LegendEntry{Key: "several", Label: "2 to 4 plants", Symbol: "2-4", Color: cliPlantColor},
+		LegendEntry{Key: "many", Label: "5 or more plants", Symbol: "■", Color: cliPlantColor})
+
+	return []LegendSection{biomes, structures, signals, entities, plants}
+}
+
+// gridLegend describes the web grid: the symbols its cells carry, the glyphs the browser
+// draws for them and the CSS classes that colour them
+func gridLegend() []LegendSection {
+	biomes := LegendSection{Name: "biomes", Label: "Biomes"}
+	for _, biomeType := range sortedKeys(gridBiomeSymbols) {
+		entry := gridBiomeSymbols[biomeType]
+		entry.Key = entry.Label
+		biomes.Entries = append(biomes.Entries, entry)
+	}
+
+	entities := LegendSection{Name: "entities", Label: "Creatures"}
+	for _, species := range sortedKeys(gridSpeciesSymbols) {
+		entry := gridSpeciesSymbols[species]
+		entry.Key = species
+		entities.Entries = append(entities.Entries, entry)
+	}
+	other := gridOtherSpecies
+	other.Key = "other"
+	entities.Entries = append(entities.Entries, other,
+		LegendEntry{Key: "several", Label: "2 to 9 creatures", Symbol: "2-9"},
+		LegendEntry{Key: "many", Label: "10 or more creatures", Symbol: "+"})
+
+	plants := LegendSection{Name: "plants", Label: "Plants"}
+	for _, plantType := range sortedKeys(gridPlantSymbols) {
+		entry := gridPlantSymbols[plantType]
+		entry.Key = legendKey(entry.Label)
+		plants.Entries = append(plants.Entries, entry)
+	}
+
+	events := LegendSection{Name: "events", Label: "Events", Entries: []LegendEntry{
+		{Key: "event", Label: "Active world event", Symbol: gridEventSymbol, Class: "has-event"},
+	}}
+	return []LegendSection{biomes, entities, plants, events}
+}
+
+// isometricLegend describes the colours the isometric view paints tiles, plants and event
+// effects in. Its creatures are drawn as their species' sprites, sized and tinted as the
+// trait legend explains.
+func (w *World) isometricLegend() []LegendSection {
+	biomes := LegendSection{Name: "biomes", Label: "Biomes"}
+	for _, biomeType := range sortedKeys(isoBiomeColors) {
+		name := gridBiomeSymbols[biomeType].Label
+		if biome, exists := w.Biomes[biomeType]; exists {
+			name = biome.Name
+		}
+		biomes.Entries = append(biomes.Entries, LegendEntry{Key: name, Label: name, Color: isoBiomeColors[biomeType]})
+	}
+
+	plants := LegendSection{Name: "plants", Label: "Plants"}
+	configs := GetPlantConfigs()
+	for _, plantType := range sortedKeys(isoPlantColors) {
+		name := configs[plantType].Name
+		plants.Entries = append(plants.Entries, LegendEntry{Key: legendKey(name), Label: name, Color: isoPlantColors[plantType]})
+	}
+
+	events := LegendSection{Name: "events", Label: "Events"}
+	for _, eventType := range sortedKeys(isoEventColors) {
+		events.Entries = append(events.Entries, LegendEntry{Key: eventType, Label: legendLabel(eventType), Color: isoEventColors[eventType]})
+	}
+
+	geology := LegendSection{Name: "geology", Label: "Geological events"}
+	for _, eventType := range sortedKeys(isoGeologicalEventColors) {
+		geology.Entries = append(geology.Entries, LegendEntry{Key: eventType, Label: legendLabel(eventType), Color: isoGeologicalEventColors[eventType]})
+	}
+	return []LegendSection{biomes, plants, events, geology}
+}
+
+// biomeTypesByName lists the world's biomes alphabetically
+func (w *World) biomeTypesByName() []BiomeType {
+	biomeTypes := make([]BiomeType, 0, len(w.Biomes))
+	for biomeType := range w.Biomes {
+		biomeTypes = append(biomeTypes, biomeType)
+	}
+	sort.Slice(biomeTypes, func(i, j int) bool {
+		return w.Biomes[biomeTypes[i]].Name < w.Biomes[biomeTypes[j]].Name
+	})
+	return biomeTypes
+}
+
+// legendKey names a label the way view data does, as in "Danger signal" to danger_signal
+func legendKey(label string) string {
+	return strings.ReplaceAll(strings.ToLower(label), " ", "_")
+}
+
+// legendLabel turns a key such as volcanic_eruption into "Volcanic eruption"
+func legendLabel(key string) string {
+	label := strings.ReplaceAll(key, "_", " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
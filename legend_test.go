@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLegendMatchesGridSymbols(t *testing.T) {
+	world := newSteppingTestWorld(133)
+	if _, err := world.Step(3); err != nil {
+		t.Fatal(err)
+	}
+	legends, err := world.RendererLegends(LegendRendererWeb)
+	if err != nil || len(legends) != 1 {
+		t.Fatalf("Expected the web legend, got %v", err)
+	}
+	legend := legends[0]
+	known := func(section, symbol string) bool {
+		for _, entry := range legend.Section(section).Entries {
+			if entry.Symbol == symbol || entry.Key == symbol {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Every symbol the grid data carries is in the legend
+	for _, row := range NewViewManager(world).GetCurrentViewData().Grid {
+		for _, cell := range row {
+			if !known("biomes", cell.Biome) || !known("biomes", cell.BiomeSymbol) {
+				t.Errorf("Expected biome %s (%s) in the legend", cell.Biome, cell.BiomeSymbol)
+			}
+			if cell.EntityCount == 1 && !known("entities", cell.EntitySymbol) {
+				t.Errorf("Expected creature symbol %q in the legend", cell.EntitySymbol)
+			}
+			if cell.HasEvent && !known("events", cell.EventSymbol) {
+				t.Errorf("Expected event symbol %q in the legend", cell.EventSymbol)
+			}
+		}
+	}
+	for plantType := range gridPlantSymbols {
+		if symbol := NewViewManager(world).getPlantTypeSymbol(plantType); !known("plants", symbol) {
+			t.Errorf("Expected plant symbol %q in the legend", symbol)
+		}
+	}
+}
+
+func TestLegendPerRenderer(t *testing.T) {
+	world := newSteppingTestWorld(134)
+	legends, err := world.RendererLegends("")
+	if err != nil || len(legends) != len(legendRenderers) {
+		t.Fatalf("Expected a legend per renderer, got %d: %v", len(legends), err)
+	}
+	if _, err := world.RendererLegends("teletext"); err == nil {
+		t.Error("Expected an unknown renderer refused")
+	}
+
+	// The terminal legend lists the world's own biome symbols and every structure it marks
+	cli := legends[0]
+	if biomes := cli.Section("biomes"); len(biomes.Entries) != len(world.Biomes) {
+		t.Errorf("Expected every biome in the terminal legend, got %d of %d", len(biomes.Entries), len(world.Biomes))
+	}
+	plains := world.Biomes[BiomePlains]
+	if entry := cli.Section("biomes").Entries[0]; entry.Symbol == "" {
+		t.Errorf("Expected terminal biomes drawn by symbol, got %+v", entry)
+	}
+	if structures := cli.Section("structures"); structures == nil || len(structures.Entries) != len(cliStructureSymbols) {
+		t.Error("Expected every structure symbol in the terminal legend")
+	}
+	if view := (CLIModel{world: world, showStructures: true}).legendView(); !strings.Contains(view, plains.Name) || !strings.Contains(view, "Barrier") {
+		t.Errorf("Expected the terminal legend drawn from its tables, got\n%s", view)
+	}
+
+	// The isometric legend carries the colours its tiles are painted in
+	tiles := NewIsometricViewManager(world).GenerateIsometricData(0, 0, 1, 50).Tiles
+	iso := legends[2].Section("biomes")
+	for _, tile := range tiles {
+		found := false
+		for _, entry := range iso.Entries {
+			found = found || (entry.Key == tile.BiomeName && entry.Color == tile.Color)
+		}
+		if !found {
+			t.Fatalf("Expected %s painted %s in the isometric legend", tile.BiomeName, tile.Color)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	NewWebInterface(world).Routes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/legend?renderer=iso", nil))
+	var served []RendererLegend
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &served) != nil || len(served) != 1 || served[0].Renderer != LegendRendererIsometric {
+		t.Fatalf("Expected the isometric legend served, got %d %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	NewWebInterface(world).Routes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/legend?renderer=teletext", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected an unknown renderer refused, got %d", rec.Code)
+	}
+}
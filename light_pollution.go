@@ -0,0 +1,190 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// Light pollution parameters
+const (
+	lightInterval          = 10   // Ticks between updates of where settlements are lit
+	fireTechLevel          = 2    // Tribes this advanced keep fires burning through the night
+	lightBaseRadius        = 15.0 // Reach of a fire-lit camp's glow
+	lightRadiusPerTech     = 5.0  // Extra reach per tech level beyond fire
+	lightBaseBrightness    = 0.4  // Brightness of a fire-lit camp at its centre
+	lightBrightnessPerTech = 0.15
+	lightStructureGlow     = 0.05 // Extra brightness per lit structure
+	lightHuntPenalty       = 0.6  // Share of a nocturnal hunter's kills lost in full light
+	lightAttraction        = 0.5  // How far full light bends a night migrant's heading toward it
+	nocturnalPreference    = -0.3 // Circadian preference below which a creature is nocturnal
+)
+
+// LightSource is a settlement lit at night
+type LightSource struct {
+	TribeID    int      `json:"tribe_id"`
+	Name       string   `json:"name"`
+	TechLevel  int      `json:"tech_level"`
+	Position   Position `json:"position"`
+	Radius     float64  `json:"radius"`
+	Brightness float64  `json:"brightness"`
+}
+
+// LightPollutionReport lists the lit settlements and what their light has disrupted
+type LightPollutionReport struct {
+	Tick                int           `json:"tick"`
+	IsNight             bool          `json:"is_night"`
+	Sources             []LightSource `json:"sources"`
+	LitNocturnal        int           `json:"lit_nocturnal"`        // Nocturnal creatures under artificial light now
+	HuntsDisrupted      int           `json:"hunts_disrupted"`      // Nocturnal attacks made under artificial light
+	MigrantsMisdirected int           `json:"migrants_misdirected"` // Night migration steps drawn off course
+}
+
+// LightPollutionSystem tracks the night glow of fire-using and advanced tribes and the
+// nocturnal hunting and migration it disturbs
+type LightPollutionSystem struct {
+	Sources             []LightSource
+	HuntsDisrupted      int
+	MigrantsMisdirected int
+}
+
+// NewLightPollutionSystem creates a system with the world still dark
+func NewLightPollutionSystem() *LightPollutionSystem {
+	return &LightPollutionSystem{Sources: make([]LightSource, 0)}
+}
+
+// Update relocates each lit settlement to its tribe's centre every lightInterval ticks
+func (lps *LightPollutionSystem) Update(w *World) {
+	if w.Tick%lightInterval != 0 || w.CivilizationSystem == nil {
+		return
+	}
+	sources := make([]LightSource, 0)
+	for _, tribe := range w.CivilizationSystem.Tribes {
+		if len(tribe.Members) == 0 || tribe.TechLevel < fireTechLevel {
+			continue
+		}
+		centerX, centerY := 0.0, 0.0
+		for _, member := range tribe.Members {
+			centerX += member.Position.X
+			centerY += member.Position.Y
+		}
+		advance := float64(tribe.TechLevel - fireTechLevel)
+		lit := 0
+		for _, structure := range tribe.Structures {
+			if structure.Health > 0 {
+				lit++
+			}
+		}
+		sources = append(sources, LightSource{
+			TribeID:    tribe.ID,
+			Name:       tribe.Name,
+			TechLevel:  tribe.TechLevel,
+			Position:   Position{X: centerX / float64(len(tribe.Members)), Y: centerY / float64(len(tribe.Members))},
+			Radius:     lightBaseRadius + lightRadiusPerTech*advance,
+			Brightness: math.Min(1, lightBaseBrightness+lightBrightnessPerTech*advance+lightStructureGlow*float64(lit)),
+		})
+	}
+	lps.Sources = sources
+}
+
+// LightAt is the artificial light falling on a position and the source casting most of it
+func (lps *LightPollutionSystem) LightAt(pos Position) (float64, *LightSource) {
+	if lps == nil {
+		return 0, nil
+	}
+	brightest, brightestSource := 0.0, (*LightSource)(nil)
+	for i := range lps.Sources {
+		source := &lps.Sources[i]
+		distance := math.Hypot(pos.X-source.Position.X, pos.Y-source.Position.Y)
+		if distance >= source.Radius {
+			continue
+		}
+		if light := source.Brightness * (1 - distance/source.Radius); light > brightest {
+			brightest, brightestSource = light, source
+		}
+	}
+	return brightest, brightestSource
+}
+
+// nightLightAt is the artificial light at a position, which only matters in the dark
+func (w *World) nightLightAt(pos Position) (float64, *LightSource) {
+	if w.LightPollution == nil || w.AdvancedTimeSystem == nil || !w.AdvancedTimeSystem.GetTimeState().IsNight() {
+		return 0, nil
+	}
+	return w.LightPollution.LightAt(pos)
+}
+
+// isNocturnal reports whether a creature keeps night hours
+func isNocturnal(entity *Entity) bool {
+	return entity.GetTrait("circadian_preference") < nocturnalPreference
+}
+
+// litHuntModifier scales the kill chance of a nocturnal hunter caught in settlement light,
+// which gives it away to its prey
+func (w *World) litHuntModifier(hunter *Entity) float64 {
+	if !isNocturnal(hunter) {
+		return 1
+	}
+	light, _ := w.nightLightAt(hunter.Position)
+	if light <= 0 {
+		return 1
+	}
+	w.LightPollution.HuntsDisrupted++
+	return 1 - light*lightHuntPenalty
+}
+
+// misdirectMigrant bends a night migrant's unit heading toward the lights it passes
+func (w *World) misdirectMigrant(entity *Entity, directionX, directionY float64) (float64, float64) {
+	light, source := w.nightLightAt(entity.Position)
+	if light <= 0 {
+		return directionX, directionY
+	}
+	towardX, towardY := w.Geometry().Direction(entity.Position, source.Position)
+	distance := math.Hypot(towardX, towardY)
+	if distance == 0 {
+		return directionX, directionY
+	}
+	pull := light * lightAttraction
+	x := directionX*(1-pull) + towardX/distance*pull
+	y := directionY*(1-pull) + towardY/distance*pull
+	if length := math.Hypot(x, y); length > 0 {
+		w.LightPollution.MigrantsMisdirected++
+		return x / length, y / length
+	}
+	return directionX, directionY
+}
+
+// litNocturnalCount counts the nocturnal creatures under artificial light right now
+func (w *World) litNocturnalCount() int {
+	count := 0
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive && isNocturnal(entity) {
+			if light, _ := w.nightLightAt(entity.Position); light > 0 {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Report lists the lit settlements, brightest first, and the disruption they have caused
+func (lps *LightPollutionSystem) Report(w *World) LightPollutionReport {
+	report := LightPollutionReport{
+		Tick:                w.Tick,
+		Sources:             append([]LightSource{}, lps.Sources...),
+		LitNocturnal:        w.litNocturnalCount(),
+		HuntsDisrupted:      lps.HuntsDisrupted,
+		MigrantsMisdirected: lps.MigrantsMisdirected,
+	}
+	if w.AdvancedTimeSystem != nil {
+		report.IsNight = w.AdvancedTimeSystem.GetTimeState().IsNight()
+	}
+	sort.SliceStable(report.Sources, func(i, j int) bool { return report.Sources[i].Brightness > report.Sources[j].Brightness })
+	return report
+}
+
+// Clear puts out every light and forgets past disruption, as when a new world is loaded
+func (lps *LightPollutionSystem) Clear() {
+	lps.Sources = make([]LightSource, 0)
+	lps.HuntsDisrupted = 0
+	lps.MigrantsMisdirected = 0
+}
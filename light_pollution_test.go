@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newLightPollutionTestWorld makes an empty 100x100 world at midnight with a five-member
+// tribe camped at (50, 50)
+func newLightPollutionTestWorld(techLevel int) (*World, *Tribe) {
+	world := newEmptyTestWorld(100)
+	world.AdvancedTimeSystem.TimeOfDay = Midnight
+
+	tribe := addTestTribe(world, "Emberfall", 5, Position{X: 50, Y: 50})
+	tribe.TechLevel = techLevel
+	world.LightPollution.Update(world)
+	return world, tribe
+}
+
+// newNocturnal makes a creature that keeps night hours
+func newNocturnal(id int, pos Position) *Entity {
+	entity := NewEntity(sharedRand, id, []string{"speed"}, "Owl", pos)
+	entity.SetTrait("circadian_preference", -0.9)
+	return entity
+}
+
+func TestOnlyFireUsingTribesLightTheNight(t *testing.T) {
+	world, _ := newLightPollutionTestWorld(fireTechLevel - 1)
+	if light, _ := world.nightLightAt(Position{X: 50, Y: 50}); light != 0 || len(world.LightPollution.Sources) != 0 {
+		t.Errorf("Expected a tribe without fire to leave the night dark, got %.2f", light)
+	}
+
+	world, tribe := newLightPollutionTestWorld(fireTechLevel)
+	camp, _ := world.nightLightAt(Position{X: 50, Y: 50})
+	edge, _ := world.nightLightAt(Position{X: 60, Y: 50})
+	far, _ := world.nightLightAt(Position{X: 90, Y: 90})
+	if camp <= edge || edge <= 0 || far != 0 {
+		t.Errorf("Expected light fading from the camp, got %.2f at camp, %.2f nearby and %.2f far away", camp, edge, far)
+	}
+	world.AdvancedTimeSystem.TimeOfDay = Midday
+	if light, _ := world.nightLightAt(Position{X: 50, Y: 50}); light != 0 {
+		t.Errorf("Expected settlement light not to matter by day, got %.2f", light)
+	}
+
+	tribe.TechLevel = fireTechLevel + 3
+	world.LightPollution.Update(world)
+	source := world.LightPollution.Sources[0]
+	if source.Radius <= lightBaseRadius || source.Brightness <= lightBaseBrightness {
+		t.Errorf("Expected an advanced tribe to shine brighter and further, got %+v", source)
+	}
+}
+
+func TestLightSpoilsNocturnalHunts(t *testing.T) {
+	world, _ := newLightPollutionTestWorld(fireTechLevel + 2)
+	world.SimConfig.DayNight.NightHunting = 0 // Weigh settlement light alone, not the dark of night
+	lit := newNocturnal(10, Position{X: 52, Y: 50})
+	dark := newNocturnal(11, Position{X: 10, Y: 90})
+	diurnal := NewEntity(sharedRand, 12, []string{"speed"}, "Hawk", Position{X: 52, Y: 50})
+	diurnal.SetTrait("circadian_preference", 0.9)
+
+	if world.killChance(lit) >= world.killChance(dark) {
+		t.Errorf("Expected a nocturnal hunter to kill less under settlement light, got %.3f lit and %.3f dark",
+			world.killChance(lit), world.killChance(dark))
+	}
+	if world.killChance(diurnal) != world.killChance(dark) {
+		t.Errorf("Expected a day hunter unaffected by night light, got %.3f", world.killChance(diurnal))
+	}
+	if world.LightPollution.HuntsDisrupted == 0 {
+		t.Errorf("Expected lit hunts counted")
+	}
+}
+
+func TestLightMisdirectsNightMigrants(t *testing.T) {
+	world, _ := newLightPollutionTestWorld(fireTechLevel + 2)
+	migrant := newNocturnal(10, Position{X: 50, Y: 60})
+	x, y := world.misdirectMigrant(migrant, 1, 0)
+	if y >= 0 || x <= 0 {
+		t.Errorf("Expected a migrant heading east to be drawn north toward the camp, got (%.2f, %.2f)", x, y)
+	}
+	if world.LightPollution.MigrantsMisdirected != 1 {
+		t.Errorf("Expected the misdirected migrant counted, got %d", world.LightPollution.MigrantsMisdirected)
+	}
+
+	stray := newNocturnal(11, Position{X: 10, Y: 90})
+	if x, y := world.misdirectMigrant(stray, 1, 0); x != 1 || y != 0 {
+		t.Errorf("Expected a migrant far from any light to keep its heading, got (%.2f, %.2f)", x, y)
+	}
+}
+
+func TestLightDisruptionShowsInBiorhythms(t *testing.T) {
+	world, _ := newLightPollutionTestWorld(fireTechLevel + 2)
+	world.AllEntities = append(world.AllEntities, newNocturnal(10, Position{X: 52, Y: 50}), newNocturnal(11, Position{X: 10, Y: 90}))
+	data := NewViewManager(world).getBioRhythmData()
+	if data.LightDisrupted != 1 {
+		t.Errorf("Expected one nocturnal creature disrupted by light, got %d", data.LightDisrupted)
+	}
+
+	wi := NewWebInterface(world)
+	rec := httptest.NewRecorder()
+	wi.handleLightPollution(rec, httptest.NewRequest(http.MethodGet, "/api/light-pollution", nil))
+	var report LightPollutionReport
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &report) != nil || len(report.Sources) != 1 {
+		t.Fatalf("Expected a light pollution report with the camp, got %d %s", rec.Code, rec.Body.String())
+	}
+	if !report.IsNight || report.LitNocturnal != 1 || report.Sources[0].TribeID != 1 {
+		t.Errorf("Expected one lit nocturnal creature at night, got %+v", report)
+	}
+}
@@ -0,0 +1,732 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kinds of content file the linter checks
+const (
+	LintKindConfig   = "config"   // Run config, as given to run --config or classroom --template
+	LintKindTraits   = "traits"   // Custom trait definitions, as given to --traits
+	LintKindCreature = "creature" // Exported creature or breeding pair
+	LintKindScenario = "scenario" // Save shared as a scenario, with its biome map
+)
+
+// lintKinds lists the kinds in the order files are linted, so the traits a config or creature
+// refers to are known before it is checked
+var lintKinds = []string{LintKindTraits, LintKindConfig, LintKindCreature, LintKindScenario}
+
+// Lint issue severities. Errors stop a run or an import; warnings are likely mistakes the
+// simulation works around.
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// LintIssue is a problem found in a content file
+type LintIssue struct {
+	File     string `json:"file"`
+	Path     string `json:"path,omitempty"` // Where in the file, e.g. populations[1].traits.speed
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Hint     string `json:"hint,omitempty"` // How to fix it
+}
+
+// LintedFile is a file the linter checked, and what it took it for
+type LintedFile struct {
+	File string `json:"file"`
+	Kind string `json:"kind"`
+}
+
+// LintReport collects the issues found in a set of files
+type LintReport struct {
+	Files  []LintedFile `json:"files"`
+	Issues []LintIssue  `json:"issues"`
+}
+
+// Count returns the number of issues of a severity
+func (r *LintReport) Count(severity string) int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// Summary renders the report as human-readable text, file by file
+func (r *LintReport) Summary() string {
+	var b strings.Builder
+	for _, file := range r.Files {
+		issues := 0
+		for _, issue := range r.Issues {
+			if issue.File == file.File {
+				issues++
+			}
+		}
+		if issues == 0 {
+			fmt.Fprintf(&b, "%s (%s): ok\n", file.File, file.Kind)
+			continue
+		}
+		fmt.Fprintf(&b, "%s (%s):\n", file.File, file.Kind)
+		for _, issue := range r.Issues {
+			if issue.File != file.File {
+				continue
+			}
+			location := ""
+			if issue.Path != "" {
+				location = issue.Path + ": "
+			}
+			fmt.Fprintf(&b, "  %-7s %s%s\n", issue.Severity, location, issue.Message)
+			if issue.Hint != "" {
+				fmt.Fprintf(&b, "          %s\n", issue.Hint)
+			}
+		}
+	}
+	fmt.Fprintf(&b, "%d file(s) checked: %d error(s), %d warning(s)\n", len(r.Files), r.Count(LintError), r.Count(LintWarning))
+	return b.String()
+}
+
+// Linter checks content files before a run starts. Files linted together are checked against
+// each other: creatures and populations against the custom traits among them, and creatures
+// against the populations of the configs.
+type Linter struct {
+	Traits  *TraitRegistry
+	species map[string]string // Species of the configs' populations, to the config naming it
+	report  *LintReport
+	file    string
+}
+
+// NewLinter creates a linter knowing the built-in traits
+func NewLinter() *Linter {
+	return &Linter{Traits: NewTraitRegistry(), species: make(map[string]string), report: &LintReport{Issues: make([]LintIssue, 0)}}
+}
+
+// add records an issue in the file being linted
+func (l *Linter) add(severity, path, hint, format string, args ...interface{}) {
+	l.report.Issues = append(l.report.Issues, LintIssue{
+		File:     l.file,
+		Path:     path,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+		Hint:     hint,
+	})
+}
+
+// LintFiles lints files, taking each for the given kind or, when kind is empty, the kind its
+// contents suggest. Issues are reported in the order the files were given.
+func (l *Linter) LintFiles(filenames []string, kind string) (*LintReport, error) {
+	if kind != "" && !containsString(lintKinds, kind) {
+		return nil, fmt.Errorf("unknown file kind %q (expected %s)", kind, strings.Join(lintKinds, ", "))
+	}
+	contents := make(map[string][]byte, len(filenames))
+	kinds := make(map[string]string, len(filenames))
+	for _, filename := range filenames {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", filename, err)
+		}
+		contents[filename] = data
+		kinds[filename] = kind
+		if kind == "" {
+			kinds[filename] = detectLintKind(data)
+		}
+		l.report.Files = append(l.report.Files, LintedFile{File: filename, Kind: kinds[filename]})
+	}
+
+	for _, next := range lintKinds {
+		for _, filename := range filenames {
+			if kinds[filename] == next {
+				l.Lint(filename, next, contents[filename])
+			}
+		}
+	}
+
+	order := make(map[string]int, len(filenames))
+	for i, filename := range filenames {
+		order[filename] = i
+	}
+	sort.SliceStable(l.report.Issues, func(i, j int) bool {
+		return order[l.report.Issues[i].File] < order[l.report.Issues[j].File]
+	})
+	return l.report, nil
+}
+
+// Lint checks one file's contents as the given kind
+func (l *Linter) Lint(filename, kind string, data []byte) {
+	l.file = filename
+	switch kind {
+	case LintKindConfig:
+		l.lintConfig(data)
+	case LintKindTraits:
+		l.lintTraits(data)
+	case LintKindCreature:
+		l.lintCreature(data)
+	case LintKindScenario:
+		l.lintScenario(data)
+	}
+}
+
+// detectLintKind guesses what a file is from its contents: saves by their format or save
+// fields, creature files by their format marker, custom traits as a list, and anything else
+// as a run config
+func detectLintKind(data []byte) string {
+	if saveFormatOf(data) == SaveFormatBinary {
+		return LintKindScenario
+	}
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		return LintKindTraits
+	}
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(trimmed, &fields) != nil {
+		return LintKindConfig
+	}
+	var format string
+	_ = json.Unmarshal(fields["format"], &format)
+	if format == CreatureFileFormat || fields["creatures"] != nil {
+		return LintKindCreature
+	}
+	for _, key := range []string{"schema_version", "entities", "biomes", "plants"} {
+		if fields[key] != nil {
+			return LintKindScenario
+		}
+	}
+	return LintKindConfig
+}
+
+// decode reads a file's JSON into a value, reporting syntax and type errors where they are in
+// the file. It reports whether decoding succeeded.
+func (l *Linter) decode(data []byte, doc, value interface{}) bool {
+	if err := json.Unmarshal(data, doc); err != nil {
+		l.add(LintError, "", "", "%s", describeJSONError(data, err))
+		return false
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			l.add(LintError, jsonErrorPath(typeErr.Field), "", "expected %s, got %s", jsonTypeName(typeErr.Type), typeErr.Value)
+			return false
+		}
+		l.add(LintError, "", "", "%v", err)
+		return false
+	}
+	return true
+}
+
+// jsonErrorPath writes the field path of a decoding error, e.g. creatures.1.species, with
+// list indexes in brackets as the linter's paths have them
+func jsonErrorPath(field string) string {
+	var b strings.Builder
+	for i, part := range strings.Split(field, ".") {
+		if _, err := strconv.Atoi(part); err == nil {
+			fmt.Fprintf(&b, "[%s]", part)
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// jsonTypeName describes the JSON a Go type decodes from
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "a whole number"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "a list"
+	}
+	return "an object"
+}
+
+// describeJSONError names the line and column of a syntax error
+func describeJSONError(data []byte, err error) string {
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return fmt.Sprintf("invalid JSON: %v", err)
+	}
+	offset := int(syntaxErr.Offset)
+	if offset > len(data) {
+		offset = len(data)
+	}
+	line := bytes.Count(data[:offset], []byte("\n")) + 1
+	column := offset - bytes.LastIndexByte(data[:offset], '\n') - 1
+	return fmt.Sprintf("invalid JSON at line %d, column %d: %v", line, column, err)
+}
+
+// lintFields reports the fields of a decoded document its type has no place for, suggesting
+// the field that was probably meant
+func (l *Linter) lintFields(doc interface{}, t reflect.Type, prefix string) {
+	found := make(map[string]bool)
+	collectUnsupportedFields(doc, t, "", found)
+	for _, path := range sortedKeys(found) {
+		parent, field := splitFieldPath(path)
+		hint := ""
+		if known := fieldNamesAt(t, parent); len(known) > 0 {
+			hint = suggestName(field, known)
+		}
+		l.add(LintError, joinFieldPath(prefix, path), hint, "unknown field %q", field)
+	}
+}
+
+// splitFieldPath splits a field path from collectUnsupportedFields into the path of the value
+// holding the field and the field's name
+func splitFieldPath(path string) (string, string) {
+	depth := 0
+	for i := len(path) - 1; i >= 0; i-- {
+		switch path[i] {
+		case ']':
+			depth++
+		case '[':
+			depth--
+		case '.':
+			if depth == 0 {
+				return path[:i], path[i+1:]
+			}
+		}
+	}
+	return "", path
+}
+
+// fieldNamesAt lists the JSON field names of the struct found by following a field path from
+// a type, or nil when the path leads elsewhere
+func fieldNamesAt(t reflect.Type, path string) []string {
+	for path != "" {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if strings.HasPrefix(path, "[") {
+			end := strings.IndexByte(path, ']')
+			if end < 0 || (t.Kind() != reflect.Slice && t.Kind() != reflect.Array && t.Kind() != reflect.Map) {
+				return nil
+			}
+			t, path = t.Elem(), strings.TrimPrefix(path[end+1:], ".")
+			continue
+		}
+		end := strings.IndexAny(path, ".[")
+		name := path
+		if end >= 0 {
+			name, path = path[:end], strings.TrimPrefix(path[end:], ".")
+		} else {
+			path = ""
+		}
+		if t.Kind() != reflect.Struct {
+			return nil
+		}
+		next, ok := jsonFields(t)[name]
+		if !ok {
+			return nil
+		}
+		t = next
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return sortedKeys(jsonFields(t))
+}
+
+// lintConfig checks a run config: its fields, the values a run would refuse, and the trait,
+// biome and event names it refers to
+func (l *Linter) lintConfig(data []byte) {
+	var doc interface{}
+	var config RunConfig
+	if !l.decode(data, &doc, &config) {
+		return
+	}
+	l.lintFields(doc, reflect.TypeOf(RunConfig{}), "")
+	errorsBefore := l.report.Count(LintError)
+
+	world := config.World
+	if world.Width < 0 || world.Height < 0 || world.GridWidth < 0 || world.GridHeight < 0 {
+		l.add(LintError, "world", "", "world dimensions must not be negative")
+	}
+	if world.PopulationSize < 0 || world.PopulationCap < 0 || world.RegionCap < 0 {
+		l.add(LintError, "world", "", "population size and caps must not be negative")
+	}
+	if world.Profile != "" {
+		if _, err := FindTuningProfile(world.Profile); err != nil {
+			l.add(LintError, "world.profile", suggestName(world.Profile, TuningProfileNames()), "%v", err)
+		}
+	}
+	if err := ValidateGeometry(world.Geometry); err != nil {
+		l.add(LintError, "world.geometry", "", "%v", err)
+	}
+	if err := ValidateGridShape(world.GridShape); err != nil {
+		l.add(LintError, "world.grid_shape", "", "%v", err)
+	}
+	worldConfig, _ := (&RunConfig{World: world}).WorldConfig()
+
+	populations := make(map[string]int)
+	for i, population := range config.Populations {
+		path := fmt.Sprintf("populations[%d]", i)
+		if population.Name == "" {
+			l.add(LintError, path+".name", "", "population has no name")
+		} else if first, listed := populations[population.Name]; listed {
+			l.add(LintError, path+".name", "", "population %q is already listed as populations[%d]", population.Name, first)
+		} else {
+			populations[population.Name] = i
+		}
+		species := population.Species
+		if species == "" {
+			species = population.Name
+		}
+		if species != "" {
+			l.species[species] = l.file
+		}
+
+		for _, trait := range sortedKeys(population.Traits) {
+			value := population.Traits[trait]
+			traitPath := path + ".traits." + trait
+			if _, known := l.Traits.Lookup(TraitSubjectCreature, trait); !known {
+				l.add(LintError, traitPath, l.traitHint(trait), "unknown creature trait %q", trait)
+				continue
+			}
+			if math.IsNaN(value) || value < -1 || value > 1 {
+				l.add(LintError, traitPath, "", "founding trait %v must be between -1 and 1", value)
+			}
+		}
+		if population.X != nil && (*population.X < 0 || *population.X > worldConfig.Width) {
+			l.add(LintWarning, path+".x", "", "x %v is outside the world, which is %v wide", *population.X, worldConfig.Width)
+		}
+		if population.Y != nil && (*population.Y < 0 || *population.Y > worldConfig.Height) {
+			l.add(LintWarning, path+".y", "", "y %v is outside the world, which is %v high", *population.Y, worldConfig.Height)
+		}
+		if population.Spread < 0 {
+			l.add(LintError, path+".spread", "", "spread must not be negative")
+		}
+		if population.MutationRate < 0 || population.MutationRate > 1 {
+			l.add(LintError, path+".mutation_rate", "", "mutation rate must be between 0 and 1")
+		}
+		if population.Selection != "" {
+			if _, err := NewSelectionStrategy(population.Selection); err != nil {
+				l.add(LintError, path+".selection", suggestName(population.Selection, SelectionStrategyNames()), "%v", err)
+			}
+		}
+	}
+
+	for i, scheduled := range config.Events.Timetable {
+		path := fmt.Sprintf("events.timetable[%d]", i)
+		if _, exists := worldEventDurations[scheduled.Name]; !exists {
+			l.add(LintError, path+".name", suggestName(scheduled.Name, sortedKeys(worldEventDurations)), "unknown world event %q", scheduled.Name)
+		}
+		if scheduled.Tick <= 0 {
+			l.add(LintError, path+".tick", "", "tick must be positive")
+		}
+		if scheduled.Duration < 0 {
+			l.add(LintError, path+".duration", "", "duration must not be negative")
+		}
+	}
+
+	if config.Speed.Multiplier != 0 && (config.Speed.Multiplier < 0.1 || config.Speed.Multiplier > 16) {
+		l.add(LintError, "speed.multiplier", "", "speed multiplier must be between 0.1 and 16")
+	}
+	if config.Speed.MaxCPU != 0 && (config.Speed.MaxCPU < 5 || config.Speed.MaxCPU > 100) {
+		l.add(LintError, "speed.max_cpu", "", "max CPU must be between 5 and 100 percent")
+	}
+
+	if len(config.Simulation) > 0 {
+		l.lintSimulation(config.Simulation)
+	}
+
+	// Anything else the run would refuse, so a config the linter passes always starts
+	if l.report.Count(LintError) == errorsBefore {
+		if err := config.Validate(); err != nil {
+			l.add(LintError, "", "", "%v", err)
+		}
+	}
+}
+
+// lintSimulation checks a config's simulation overrides, whose biome and trait maps are keyed
+// by name and would otherwise ignore a misspelt one
+func (l *Linter) lintSimulation(data json.RawMessage) {
+	var doc interface{}
+	var simulation SimulationConfig
+	if err := json.Unmarshal(data, &doc); err != nil {
+		l.add(LintError, "simulation", "", "%v", err)
+		return
+	}
+	if err := json.Unmarshal(data, &simulation); err != nil {
+		l.add(LintError, "simulation", "", "%v", err)
+		return
+	}
+	l.lintFields(doc, reflect.TypeOf(SimulationConfig{}), "simulation")
+
+	biomeMaps := map[string][]string{
+		"simulation.energy.biome_energy_modifiers":   sortedKeys(simulation.Energy.BiomeEnergyModifiers),
+		"simulation.biomes.energy_drain_multipliers": sortedKeys(simulation.Biomes.EnergyDrainMultipliers),
+		"simulation.biomes.mutation_rate_modifiers":  sortedKeys(simulation.Biomes.MutationRateModifiers),
+		"simulation.biomes.temperature_ranges":       sortedKeys(simulation.Biomes.TemperatureRanges),
+		"simulation.biomes.carrying_capacities":      sortedKeys(simulation.Biomes.CarryingCapacities),
+	}
+	for _, path := range sortedKeys(biomeMaps) {
+		for _, name := range biomeMaps[path] {
+			if _, known := parseBiomeName(name); !known {
+				l.add(LintError, path+"."+name, suggestName(name, biomeNames()), "unknown biome %q", name)
+			}
+		}
+	}
+	for _, trait := range sortedKeys(simulation.Evolution.TraitBounds) {
+		if _, known := l.Traits.Lookup(TraitSubjectCreature, trait); !known {
+			l.add(LintError, "simulation.evolution.trait_bounds."+trait, l.traitHint(trait), "unknown creature trait %q", trait)
+		}
+	}
+}
+
+// lintTraits checks custom trait definitions and adds the valid ones to the traits the other
+// files are checked against
+func (l *Linter) lintTraits(data []byte) {
+	var doc interface{}
+	var definitions []CustomTraitDefinition
+	if !l.decode(data, &doc, &definitions) {
+		return
+	}
+	l.lintFields(doc, reflect.TypeOf(definitions), "")
+
+	seen := make(map[string]int, len(definitions))
+	for i, definition := range definitions {
+		path := fmt.Sprintf("[%d]", i)
+		if first, defined := seen[definition.Name]; defined && definition.Name != "" {
+			l.add(LintError, path+".name", "", "custom trait %s is already defined at [%d]", definition.Name, first)
+			continue
+		}
+		seen[definition.Name] = i
+		if err := definition.Validate(l.Traits); err != nil {
+			hint := ""
+			for _, target := range sortedKeys(definition.Hooks) {
+				if gene, ok := l.Traits.Lookup(TraitSubjectCreature, target); !ok || gene.Custom || gene.Kind != TraitKindGene {
+					hint = l.traitHint(target)
+					break
+				}
+			}
+			l.add(LintError, path, hint, "%v", err)
+			continue
+		}
+		if err := l.Traits.Register(definition.registryDefinition()); err != nil {
+			l.add(LintError, path, "", "%v", err)
+		}
+	}
+}
+
+// lintCreature checks an exported creature file as an import would, and warns about traits
+// the import would drop or clamp
+func (l *Linter) lintCreature(data []byte) {
+	var doc interface{}
+	var file CreatureFile
+	if !l.decode(data, &doc, &file) {
+		return
+	}
+	l.lintFields(doc, reflect.TypeOf(file), "")
+
+	if file.Format != CreatureFileFormat {
+		l.add(LintError, "format", fmt.Sprintf("Set it to %q", CreatureFileFormat), "not a creature file (format %q)", file.Format)
+	}
+	if file.Version > CreatureFileVersion {
+		l.add(LintError, "version", "", "creature file version %d is newer than supported version %d", file.Version, CreatureFileVersion)
+	}
+	if len(file.Creatures) == 0 || len(file.Creatures) > 2 {
+		l.add(LintError, "creatures", "", "creature file must hold one or two creatures, got %d", len(file.Creatures))
+	}
+	for i, record := range file.Creatures {
+		path := fmt.Sprintf("creatures[%d]", i)
+		if record.Species == "" {
+			l.add(LintError, path+".species", "", "creature has no species")
+		} else if i == 1 && record.Species != file.Creatures[0].Species {
+			l.add(LintWarning, path+".species", "", "breeding pair mixes species %q and %q", file.Creatures[0].Species, record.Species)
+		} else if len(l.species) > 0 && i == 0 {
+			if _, configured := l.species[record.Species]; !configured {
+				l.add(LintWarning, path+".species", suggestName(record.Species, sortedKeys(l.species)),
+					"species %q is not one of the configured populations; it will arrive as a species of its own", record.Species)
+			}
+		}
+		for _, trait := range sortedKeys(record.Traits) {
+			value := record.Traits[trait]
+			traitPath := path + ".traits." + trait
+			if isInvalidNumber(value) {
+				l.add(LintError, traitPath, "", "trait %s is not a number", trait)
+				continue
+			}
+			definition, known := l.Traits.Lookup(TraitSubjectCreature, trait)
+			if !known {
+				l.add(LintWarning, traitPath, l.traitHint(trait), "unknown creature trait %q will be dropped on import", trait)
+				continue
+			}
+			if value < definition.Min || value > definition.Max {
+				l.add(LintWarning, traitPath, "", "trait %s is %v, outside [%v, %v]; it will be clamped on import", trait, value, definition.Min, definition.Max)
+			}
+		}
+	}
+}
+
+// lintScenario checks a save shared as a scenario, as loading it would, including its biome
+// map
+func (l *Linter) lintScenario(data []byte) {
+	state, migrations, err := DecodeState(data)
+	if err != nil {
+		l.add(LintError, "", "", "%v", err)
+		return
+	}
+	if len(migrations) > 0 {
+		l.add(LintWarning, "schema_version", "Run evosim analyze migrate to rewrite it in the current format",
+			"written in save schema version %d, upgraded to %d on load", migrations[0].From, StateSchemaVersion)
+	}
+
+	unknownBiomes := 0
+	for y, row := range state.Biomes {
+		for x, biome := range row {
+			if biomeName(biome) != "unknown" {
+				continue
+			}
+			if unknownBiomes == 0 {
+				l.add(LintError, fmt.Sprintf("biomes[%d][%d]", y, x), fmt.Sprintf("Biomes are numbered %d to %d", BiomePlains, BiomeCanyon),
+					"unknown biome %d on the map", biome)
+			}
+			unknownBiomes++
+		}
+	}
+	if unknownBiomes > 1 {
+		l.add(LintError, "biomes", "", "%d cells of the map have an unknown biome", unknownBiomes)
+	}
+
+	for _, issue := range ValidateState(state, false).Issues {
+		severity := LintError
+		if issue.Severity == SaveIssueWarning {
+			severity = LintWarning
+		}
+		l.add(severity, issue.Kind, "Run evosim analyze validate --repair to fix it", "%s", issue.Message)
+	}
+}
+
+// traitHint suggests the creature trait probably meant by an unknown name
+func (l *Linter) traitHint(name string) string {
+	definitions := l.Traits.Definitions(TraitSubjectCreature)
+	names := make([]string, 0, len(definitions))
+	for _, definition := range definitions {
+		if !definition.Prefix {
+			names = append(names, definition.Name)
+		}
+	}
+	if hint := suggestName(name, names); hint != "" {
+		return hint
+	}
+	return "Custom traits are known when their definitions are linted alongside"
+}
+
+// biomeNames lists the names of every biome
+func biomeNames() []string {
+	names := make([]string, 0, int(BiomeCanyon)+1)
+	for biome := BiomePlains; biome <= BiomeCanyon; biome++ {
+		names = append(names, biomeName(biome))
+	}
+	return names
+}
+
+// suggestName suggests the known name closest to a misspelt one, or lists the known names
+// when none is close
+func suggestName(name string, known []string) string {
+	best, bestDistance := "", math.MaxInt
+	for _, candidate := range known {
+		if distance := editDistance(strings.ToLower(name), strings.ToLower(candidate)); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if best != "" && bestDistance <= max(1, min(2, len(name)/4)) {
+		return fmt.Sprintf("Did you mean %q?", best)
+	}
+	if len(known) > 0 && len(known) <= 20 {
+		return "Expected one of: " + strings.Join(known, ", ")
+	}
+	return ""
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(min(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// runLintCommand implements the "evosim lint [--kind k] [--strict] [--format text|json] <file> ..."
+// command
+func runLintCommand(args []string) error {
+	fs := newCommandFlags("lint")
+	kind := fs.String("kind", "", "Lint every file as one kind: "+strings.Join(lintKinds, ", ")+" (default: by its contents)")
+	strict := fs.Bool("strict", false, "Fail on warnings as well as errors")
+	format := fs.String("format", "text", "Report as text or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := applyEnvironment(fs, commandEnvPrefix(fs.Name())); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return commandUsage("lint")
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown report format %q (expected text or json)", *format)
+	}
+
+	report, err := NewLinter().LintFiles(fs.Args(), *kind)
+	if err != nil {
+		return err
+	}
+	if err := writeLintReport(os.Stdout, report, *format); err != nil {
+		return err
+	}
+	failures := report.Count(LintError)
+	if *strict {
+		failures += report.Count(LintWarning)
+	}
+	if failures > 0 {
+		return fmt.Errorf("lint found %d problem(s)", failures)
+	}
+	return nil
+}
+
+// writeLintReport writes a report as text or JSON
+func writeLintReport(w io.Writer, report *LintReport, format string) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	_, err := io.WriteString(w, report.Summary())
+	return err
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeLintFile writes a file for the linter into a test directory
+func writeLintFile(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// findLintIssue returns the issue at a path, or nil
+func findLintIssue(report *LintReport, path string) *LintIssue {
+	for i := range report.Issues {
+		if report.Issues[i].Path == path {
+			return &report.Issues[i]
+		}
+	}
+	return nil
+}
+
+func TestLintReportsEveryIssue(t *testing.T) {
+	dir := t.TempDir()
+	clean := writeLintFile(t, dir, "clean.json", testRunConfig)
+	config := writeLintFile(t, dir, "run.json", `{
+		"world": {"width": 80, "profile": "fast-evolutoin"},
+		"popuations": [],
+		"populations": [
+			{"name": "Grazers", "species": "herbivore", "traits": {"sped": 0.4, "glow": 0.3, "size": 1.5}, "x": 400},
+			{"name": "Grazers"}
+		],
+		"simulation": {"biomes": {"energy_drain_multipliers": {"dessert": 2.5}}, "evolution": {"trait_bounds": {"agression": [-1, 1]}}},
+		"events": {"timetable": [{"tick": 500, "name": "Ice age"}]}
+	}`)
+	traits := writeLintFile(t, dir, "traits.json", `[{"name": "glow", "min": 0, "max": 1, "initial": 0.5, "heritability": 0.5}]`)
+	creature := writeLintFile(t, dir, "pair.creature.json", `{"format": "evosim-creature", "version": 1, "creatures": [
+		{"species": "herbivore", "traits": {"speed": 3, "glow": 0.2}}, {"species": "predator", "traits": {"size": "big"}}]}`)
+
+	// Traits are linted first whatever the order, so the config and creature know glow
+	report, err := NewLinter().LintFiles([]string{clean, config, creature, traits}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kinds := make([]string, 0, len(report.Files))
+	for _, file := range report.Files {
+		kinds = append(kinds, file.Kind)
+	}
+	if strings.Join(kinds, ",") != "config,config,creature,traits" {
+		t.Errorf("Expected each file's kind told from its contents, got %v", kinds)
+	}
+	for _, issue := range report.Issues {
+		if issue.File == clean || issue.File == traits {
+			t.Errorf("Expected %s to lint clean, got %+v", filepath.Base(issue.File), issue)
+		}
+	}
+
+	expected := map[string]string{
+		"popuations":                 `Did you mean "populations"?`,
+		"world.profile":              `Did you mean "fast-evolution"?`,
+		"populations[0].traits.sped": `Did you mean "speed"?`,
+		"populations[0].traits.size": "",
+		"populations[0].x":           "",
+		"populations[1].name":        "",
+		"events.timetable[0].name":   `Did you mean "Ice Age"?`,
+		"simulation.biomes.energy_drain_multipliers.dessert": `Did you mean "desert"?`,
+		"simulation.evolution.trait_bounds.agression":        `Did you mean "aggression"?`,
+		"creatures[1].traits.size":                           "",
+	}
+	for path, hint := range expected {
+		issue := findLintIssue(report, path)
+		if issue == nil {
+			t.Errorf("Expected an issue at %s", path)
+			continue
+		}
+		if issue.Hint != hint {
+			t.Errorf("Expected the issue at %s to hint %q, got %q", path, hint, issue.Hint)
+		}
+	}
+	if issue := findLintIssue(report, "populations[0].traits.glow"); issue != nil {
+		t.Errorf("Expected the custom trait known to the config, got %+v", issue)
+	}
+	if issue := findLintIssue(report, "populations[0].x"); issue != nil && issue.Severity != LintWarning {
+		t.Errorf("Expected a population outside the world only warned about, got %+v", issue)
+	}
+
+	// Without its definitions the custom trait is unknown
+	alone, err := NewLinter().LintFiles([]string{config}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if findLintIssue(alone, "populations[0].traits.glow") == nil {
+		t.Error("Expected the custom trait unknown without its definitions")
+	}
+}
+
+func TestLintCreatureFile(t *testing.T) {
+	dir := t.TempDir()
+	config := writeLintFile(t, dir, "run.json", `{"populations": [{"name": "Grazers", "species": "herbivore"}]}`)
+	creature := writeLintFile(t, dir, "pair.creature.json", `{"format": "evosim-creature", "version": 1, "creatures": [
+		{"species": "herbivore", "traits": {"speed": 3, "wingspan": 0.2}}, {"species": "predator"}]}`)
+	stray := writeLintFile(t, dir, "stray.json", `{"format": "evosim-creature", "version": 1, "creatures": [{"species": "herbivores"}]}`)
+
+	report, err := NewLinter().LintFiles([]string{creature, stray, config}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, severity := range map[string]string{
+		"creatures[0].traits.speed":    LintWarning, // Clamped on import
+		"creatures[0].traits.wingspan": LintWarning, // Dropped on import
+		"creatures[1].species":         LintWarning, // A pair of two species
+	} {
+		if issue := findLintIssue(report, path); issue == nil || issue.Severity != severity {
+			t.Errorf("Expected a %s at %s, got %+v", severity, path, issue)
+		}
+	}
+	var strayIssue *LintIssue
+	for i, issue := range report.Issues {
+		if issue.File == stray {
+			strayIssue = &report.Issues[i]
+		}
+	}
+	if strayIssue == nil || strayIssue.Hint != `Did you mean "herbivore"?` {
+		t.Errorf("Expected a species missing from the config cross-referenced, got %+v", strayIssue)
+	}
+
+	forced, err := NewLinter().LintFiles([]string{config}, LintKindCreature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue := findLintIssue(forced, "format"); issue == nil || issue.Severity != LintError {
+		t.Errorf("Expected a config linted as a creature file refused, got %+v", forced.Issues)
+	}
+	if _, err := NewLinter().LintFiles([]string{config}, "map"); err == nil {
+		t.Error("Expected an unknown kind refused")
+	}
+}
+
+func TestLintScenario(t *testing.T) {
+	dir := t.TempDir()
+	world := newSteppingTestWorld(143)
+	if _, err := world.Step(3); err != nil {
+		t.Fatal(err)
+	}
+	binary := filepath.Join(dir, "scenario"+BinarySaveExtension)
+	if err := NewStateManager(world).SaveToFile(binary); err != nil {
+		t.Fatal(err)
+	}
+	state, err := NewStateManager(world).CurrentState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Biomes[1][2] = BiomeType(99)
+	data, _ := json.Marshal(state)
+	broken := writeLintFile(t, dir, "broken.json", string(data))
+
+	report, err := NewLinter().LintFiles([]string{binary, broken}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Files[0].Kind != LintKindScenario || report.Files[1].Kind != LintKindScenario {
+		t.Errorf("Expected saves taken for scenarios, got %+v", report.Files)
+	}
+	for _, issue := range report.Issues {
+		if issue.File == binary && issue.Severity == LintError {
+			t.Errorf("Expected the saved world to lint without errors, got %+v", issue)
+		}
+	}
+	if issue := findLintIssue(report, "biomes[1][2]"); issue == nil || issue.File != broken || issue.Severity != LintError {
+		t.Errorf("Expected the unknown biome on the map reported, got %+v", report.Issues)
+	}
+}
+
+func TestLintCommand(t *testing.T) {
+	dir := t.TempDir()
+	clean := writeLintFile(t, dir, "clean.json", testRunConfig)
+	warned := writeLintFile(t, dir, "warned.json", `{"populations": [{"name": "A", "x": -5}]}`)
+	invalid := writeLintFile(t, dir, "invalid.json", "{\n  \"world\": {\"width\": 10,}\n}")
+
+	if err := runLintCommand([]string{clean, warned}); err != nil {
+		t.Errorf("Expected warnings alone to pass, got %v", err)
+	}
+	if err := runLintCommand([]string{"--strict", clean, warned}); err == nil {
+		t.Error("Expected --strict to fail on warnings")
+	}
+	if err := runLintCommand([]string{"--format", "json", invalid}); err == nil {
+		t.Error("Expected invalid JSON to fail")
+	}
+	report, _ := NewLinter().LintFiles([]string{invalid}, "")
+	if len(report.Issues) != 1 || !strings.Contains(report.Issues[0].Message, "line 2, column 25") {
+		t.Errorf("Expected the syntax error located, got %+v", report.Issues)
+	}
+	if err := runLintCommand(nil); err == nil {
+		t.Error("Expected lint without files refused")
+	}
+}
@@ -9,7 +9,7 @@ import (
 // EvolutionaryEvent represents a significant evolutionary milestone
 type EvolutionaryEvent struct {
 	Tick         int                    `json:"tick"`
-	Type         string                 `json:"type"` // "speciation", "extinction", "adaptation", "mutation_burst"
+	Type         string                 `json:"type"` // "speciation", "extinction", "adaptation", "mutation_burst", "adaptive_radiation", "convergence"
 	Description  string                 `json:"description"`
 	Species      string                 `json:"species"`
 	AffectedIDs  []int                  `json:"affected_ids"`  // Entity IDs involved
@@ -61,6 +61,8 @@ type MacroEvolutionSystem struct {
 	ExtinctionEvents []EvolutionaryEvent        `json:"extinction_events"`
 	CurrentTick      int                        `json:"current_tick"`
 	TraitHistory     map[string]map[int]float64 `json:"trait_history"` // Trait -> Tick -> Average value
+	Radiations       []RadiationEpisode         `json:"radiations"`    // Adaptive radiations, oldest first
+	Convergences     []ConvergenceEvent         `json:"convergences"`  // Independent lineages converging, oldest first
 
 	// Analysis parameters
 	SignificanceThreshold float64 `json:"significance_threshold"`
@@ -78,6 +80,8 @@ func NewMacroEvolutionSystem() *MacroEvolutionSystem {
 		EvolutionRates:        make(map[string]float64),
 		ExtinctionEvents:      make([]EvolutionaryEvent, 0),
 		TraitHistory:          make(map[string]map[int]float64),
+		Radiations:            make([]RadiationEpisode, 0),
+		Convergences:          make([]ConvergenceEvent, 0),
 		SignificanceThreshold: 0.3,
 		AdaptationThreshold:   0.2,
 		SpeciationThreshold:   0.5,
@@ -101,6 +105,9 @@ func (mes *MacroEvolutionSystem) UpdateMacroEvolution(world *World) {
 	// Identify significant adaptations
 	mes.detectAdaptationEvents(world)
 
+	// Look for unrelated lineages arriving at the same solutions
+	mes.detectConvergence(world)
+
 	// Update phylogenetic tree
 	mes.updatePhylogeneticTree(world)
 
@@ -201,7 +208,7 @@ func (mes *MacroEvolutionSystem) recordSpeciationEvent(speciesName string, world
 
 	// Calculate dominant traits
 	dominantTraits := make(map[string]float64)
-	for traitName := range speciesEntities[0].Traits {
+	for _, traitName := range sortedKeys(speciesEntities[0].Traits) {
 		sum := 0.0
 		for _, entity := range speciesEntities {
 			sum += entity.GetTrait(traitName)
@@ -249,6 +256,10 @@ func (mes *MacroEvolutionSystem) recordSpeciationEvent(speciesName string, world
 	}
 
 	mes.Events = append(mes.Events, event)
+
+	if parentSpecies != "" {
+		mes.detectAdaptiveRadiation(parentSpecies, world)
+	}
 }
 
 // findParentSpecies attempts to identify the most likely parent species
@@ -285,7 +296,8 @@ func (mes *MacroEvolutionSystem) calculateTraitDistance(traits1, traits2 map[str
 	distance := 0.0
 	count := 0
 
-	for traitName, value1 := range traits1 {
+	for _, traitName := range sortedKeys(traits1) {
+		value1 := traits1[traitName]
 		if value2, exists := traits2[traitName]; exists {
 			diff := value1 - value2
 			distance += diff * diff
@@ -310,7 +322,7 @@ func (mes *MacroEvolutionSystem) identifyNiches(entities []*Entity, world *World
 
 	// Analyze traits to determine niches
 	avgTraits := make(map[string]float64)
-	for traitName := range entities[0].Traits {
+	for _, traitName := range sortedKeys(entities[0].Traits) {
 		sum := 0.0
 		for _, entity := range entities {
 			sum += entity.GetTrait(traitName)
@@ -353,13 +365,7 @@ func (mes *MacroEvolutionSystem) analyzeBiomePreference(entities []*Entity, worl
 	biomeCounts := make(map[BiomeType]int)
 
 	for _, entity := range entities {
-		x := int(entity.Position.X)
-		y := int(entity.Position.Y)
-
-		if x >= 0 && x < len(world.Grid) && y >= 0 && y < len(world.Grid[0]) {
-			biome := world.Grid[x][y].Biome
-			biomeCounts[biome]++
-		}
+		biomeCounts[world.getBiomeAt(entity.Position)]++
 	}
 
 	preferences := make([]string, 0)
@@ -492,7 +498,7 @@ func (mes *MacroEvolutionSystem) calculateCurrentSpeciesTraits(speciesName strin
 	}
 
 	traits := make(map[string]float64)
-	for traitName := range speciesEntities[0].Traits {
+	for _, traitName := range sortedKeys(speciesEntities[0].Traits) {
 		sum := 0.0
 		for _, entity := range speciesEntities {
 			sum += entity.GetTrait(traitName)
@@ -651,8 +657,8 @@ func (mes *MacroEvolutionSystem) pruneEvents() {
 			keep = true
 		}
 
-		// Always keep speciation and extinction events
-		if event.Type == "speciation" || event.Type == "extinction" {
+		// Always keep speciation, extinction and radiation events
+		if event.Type == "speciation" || event.Type == "extinction" || event.Type == "adaptive_radiation" {
 			keep = true
 		}
 
@@ -1,12 +1,34 @@
 package main
 
 import (
-	"flag"
+	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
 )
 
+// breakpointFlags collects repeated --break flags
+type breakpointFlags []string
+
+func (b *breakpointFlags) String() string {
+	return strings.Join(*b, ", ")
+}
+
+func (b *breakpointFlags) Set(spec string) error {
+	if _, err := ParseBreakpoint(spec); err != nil {
+		return err
+	}
+	*b = append(*b, spec)
+	return nil
+}
+
+func (b *breakpointFlags) isList() {}
+
 // createPrimitiveTraits creates a base trait map for primitive organisms with variations
 func createPrimitiveTraits(sizeModifier, intelligenceModifier, cooperationModifier float64) map[string]float64 {
 	traits := map[string]float64{
@@ -56,257 +78,286 @@ func createPrimitiveTraits(sizeModifier, intelligenceModifier, cooperationModifi
 	return traits
 }
 
+// startingPopulations returns the populations a new world starts with
+func startingPopulations(primitive bool) []PopulationConfig {
+	if primitive {
+		// Start with primitive life forms that can evolve into complex species
+		return []PopulationConfig{
+			{
+				Name:             "Primitive Microbes",
+				Species:          "microbe",
+				BaseTraits:       createPrimitiveTraits(0.0, 0.0, 0.0),
+				StartPos:         Position{X: 30, Y: 30},
+				Spread:           25.0, // Widely spread
+				Color:            "gray",
+				BaseMutationRate: 0.25, // Very high mutation rate for rapid evolution
+			},
+			{
+				Name:             "Simple Organisms",
+				Species:          "simple",
+				BaseTraits:       createPrimitiveTraits(0.5, 0.3, 0.1), // Slightly larger, smarter, more cooperative
+				StartPos:         Position{X: 70, Y: 40},
+				Spread:           20.0,
+				Color:            "yellow",
+				BaseMutationRate: 0.20, // High mutation rate for evolution
+			},
+		}
+	}
+
+	// Predator-prey ecosystem populations
+	return []PopulationConfig{
+		{
+			Name:    "Herbivores",
+			Species: "herbivore",
+			BaseTraits: map[string]float64{
+				"size":               -0.5, // Smaller
+				"speed":              0.3,  // Moderate speed
+				"aggression":         -0.8, // Very peaceful
+				"defense":            0.2,  // Some defense
+				"cooperation":        0.6,  // Cooperative
+				"intelligence":       0.1,  // Basic intelligence
+				"endurance":          0.4,  // Good endurance
+				"strength":           -0.2, // Weaker
+				"aquatic_adaptation": -0.5, // Poor in water initially
+				"digging_ability":    0.1,  // Basic digging
+				"underground_nav":    -0.3, // Poor underground navigation
+				"flying_ability":     -0.8, // Cannot fly
+				"altitude_tolerance": -0.6, // Poor at altitude
+				// Biorhythm traits
+				"circadian_preference": 0.7, // Strongly diurnal (active during day)
+				"sleep_need":           0.2, // Lower sleep requirement (grazing animals)
+				"hunger_need":          0.8, // High hunger needs (constant grazing)
+				"thirst_need":          0.6, // High water needs
+				"play_drive":           0.3, // Some play behavior (social animals)
+				"exploration_drive":    0.5, // Moderate exploration for food
+				"scavenging_behavior":  0.1, // Minimal scavenging (prefer fresh plants)
+			},
+			StartPos:         Position{X: 20, Y: 20},
+			Spread:           15.0,
+			Color:            "green",
+			BaseMutationRate: 0.08, // Low mutation rate - stable species
+		},
+		{
+			Name:    "Predators",
+			Species: "predator",
+			BaseTraits: map[string]float64{
+				"size":               0.8,  // Larger
+				"speed":              0.6,  // Fast
+				"aggression":         0.9,  // Very aggressive
+				"defense":            0.4,  // Good defense
+				"cooperation":        -0.2, // Less cooperative
+				"intelligence":       0.7,  // Smart hunters
+				"endurance":          0.3,  // Lower endurance
+				"strength":           0.8,  // Strong
+				"aquatic_adaptation": -0.2, // Somewhat poor in water
+				"digging_ability":    0.0,  // Average digging
+				"underground_nav":    0.2,  // Decent underground navigation
+				"flying_ability":     -0.5, // Poor flying ability
+				"altitude_tolerance": 0.1,  // Slightly better at altitude
+				// Biorhythm traits
+				"circadian_preference": -0.6, // Nocturnal (hunt at night)
+				"sleep_need":           0.4,  // Moderate sleep needs (conserve energy)
+				"hunger_need":          0.3,  // Lower hunger frequency (large meals)
+				"thirst_need":          0.2,  // Lower water needs
+				"play_drive":           -0.3, // Limited play (focus on survival)
+				"exploration_drive":    0.8,  // High exploration (hunting territory)
+				"scavenging_behavior":  0.7,  // High scavenging behavior
+			},
+			StartPos:         Position{X: 80, Y: 80},
+			Spread:           10.0,
+			Color:            "red",
+			BaseMutationRate: 0.12, // Higher mutation rate - adaptive hunters
+		},
+		{
+			Name:    "Omnivores",
+			Species: "omnivore",
+			BaseTraits: map[string]float64{
+				"size":               0.0,  // Medium size
+				"speed":              0.4,  // Decent speed
+				"aggression":         0.2,  // Moderately aggressive
+				"defense":            0.5,  // Good defense
+				"cooperation":        0.3,  // Somewhat cooperative
+				"intelligence":       0.5,  // Intelligent
+				"endurance":          0.6,  // Good endurance
+				"strength":           0.3,  // Moderate strength
+				"aquatic_adaptation": 0.1,  // Slightly adapted to water
+				"digging_ability":    0.2,  // Good digging ability
+				"underground_nav":    0.1,  // Basic underground navigation
+				"flying_ability":     -0.3, // Limited flying ability
+				"altitude_tolerance": 0.0,  // Average altitude tolerance
+				// Biorhythm traits
+				"circadian_preference": 0.3, // Slightly diurnal but adaptable
+				"sleep_need":           0.3, // Moderate sleep needs
+				"hunger_need":          0.6, // High hunger (active foragers)
+				"thirst_need":          0.5, // Moderate water needs
+				"play_drive":           0.6, // High play behavior (intelligent species)
+				"exploration_drive":    0.7, // High exploration (opportunistic)
+				"scavenging_behavior":  0.8, // Very high scavenging (opportunistic feeders)
+			},
+			StartPos:         Position{X: 50, Y: 20},
+			Spread:           12.0,
+			Color:            "blue",
+			BaseMutationRate: 0.10, // Moderate mutation rate - adaptable
+		},
+	}
+}
+
 func main() {
-	// Define command-line flags
+	if err := runCommandLine(os.Args[1:]); err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+}
+
+// runRunCommand simulates a world in the terminal, the browser or headlessly, or plays back
+// a replay. It is also what evosim runs when given options without a command.
+func runRunCommand(args []string) error {
+	fs := newCommandFlags("run")
+	options := addWorldFlags(fs)
 	var (
-		help       = flag.Bool("help", false, "Show help message")
-		h          = flag.Bool("h", false, "Show help message (short)")
-		width      = flag.Float64("width", 100.0, "World width")
-		height     = flag.Float64("height", 100.0, "World height")
-		gridWidth  = flag.Int("grid-width", 40, "Grid cells width for visualization")
-		gridHeight = flag.Int("grid-height", 25, "Grid cells height for visualization")
-		popSize    = flag.Int("pop-size", 20, "Population size per species")
-		seed       = flag.Int64("seed", 0, "Random seed (0 for current time)")
-		version    = flag.Bool("version", false, "Show version information")
-		loadState  = flag.String("load", "", "Load simulation state from file")
-		saveState  = flag.String("save", "", "Save simulation state to file and exit")
-		webMode    = flag.Bool("web", false, "Enable web interface mode")
-		webPort    = flag.Int("web-port", 8080, "Port for web interface")
-		isoMode    = flag.Bool("iso", false, "Enable 2.5D isometric game view")
-		primitive  = flag.Bool("primitive", false, "Start with primitive life forms that can evolve into complex species")
+		seed       = fs.Int64("seed", 0, "Random seed (0 picks one from the clock and prints it)")
+		loadState  = fs.String("load", "", "Load simulation state from file")
+		saveState  = fs.String("save", "", "Save simulation state to file and exit")
+		saveFormat = fs.String("save-format", "", "Format of --save and --headless snapshots: json or binary (by the --save file extension when empty)")
+		fastTo     = fs.Int("fast-forward", 0, "Replay the --load save to this tick before starting")
+		webMode    = fs.Bool("web", false, "Enable web interface mode")
+		webPort    = fs.Int("web-port", 8080, "Port for web interface")
+		isoMode    = fs.Bool("iso", false, "Enable 2.5D isometric game view")
+
+		headless         = fs.Bool("headless", false, "Run without the CLI or web interface and write a JSON summary when done")
+		maxTicks         = fs.Int("max-ticks", 0, "Ticks to simulate with --headless (0 runs until extinction or interruption)")
+		snapshotInterval = fs.Int("snapshot-interval", 0, "Save the state every N ticks with --headless (0 disables snapshots)")
+		snapshotDir      = fs.String("snapshot-dir", "snapshots", "Directory for --headless snapshots")
+		summaryFile      = fs.String("summary", "", "File for the --headless summary JSON (stdout when empty)")
+		galleryDir       = fs.String("gallery", "", "Directory for the snapshot gallery (beside the --load save or in the --headless snapshot directory when empty)")
+
+		autosaveInterval = fs.Int("autosave-interval", 0, "Autosave every N ticks, and on Ctrl+C (0 disables autosaves)")
+		autosaveKeep     = fs.Int("autosave-keep", defaultAutosaveKeep, "Autosaves to keep before removing the oldest")
+		autosaveDir      = fs.String("autosave-dir", "autosaves", "Directory for autosaves")
+		resumeMode       = fs.String("resume", ResumeAsk, "Resume the autosave a crashed or interrupted run left: ask, always or never")
+
+		unlimitedSpeed = fs.Bool("unlimited-speed", false, "Run the web simulation as fast as possible instead of following the speed multiplier")
+		maxCPU         = fs.Float64("max-cpu", 100, "Percentage of CPU time the web simulation may use (5-100)")
+		populationCap  = fs.Int("population-cap", 0, "Soft cap on the total population; the surplus disperses offstage (0 keeps the default)")
+		regionCap      = fs.Int("region-cap", 0, "Creatures per map region before the surplus emigrates to neighbouring regions (0 disables)")
+		recordFile     = fs.String("record", "", "Record the run's history to a replay file")
+		replayFile     = fs.String("replay", "", "Play back a replay file in the terminal, or in the browser with --web")
+		parallel       = fs.Bool("parallel", false, "Update creatures, plants and physics in world chunks on every CPU core, drawing from per-subsystem RNG streams")
+
+		registryURL        = fs.String("registry-url", "", "Creature sharing registry URL (empty disables the registry)")
+		registryKey        = fs.String("registry-key", "", "Trusted registry public key (base64 ed25519) for verifying downloads")
+		registrySigningKey = fs.String("registry-signing-key", "", "Private key seed (base64 ed25519) for signing uploads")
+		registryCache      = fs.String("registry-cache", "", "Directory for cached registry downloads")
 	)
 
-	flag.Parse()
-
-	// Show help
-	if *help || *h {
-		fmt.Println("Genetic Ecosystem Simulation")
-		fmt.Println("============================")
-		fmt.Println()
-		fmt.Println("A genetic algorithm simulation featuring a complete ecosystem with:")
-		fmt.Println("• Multiple species (herbivores, predators, omnivores)")
-		fmt.Println("• Primitive life form evolution from simple organisms")
-		fmt.Println("• Environment-specific adaptations (aquatic, soil, aerial)")
-		fmt.Println("• Plant life system with 6 plant types")
-		fmt.Println("• Dynamic biomes and world events")
-		fmt.Println("• Evolutionary pressure and species adaptation")
-		fmt.Println("• Event logging system")
-		fmt.Println("• Tool creation and environmental modification")
-		fmt.Println("• Emergent behavior discovery and social learning")
-		fmt.Println("• Web interface with real-time visualization")
-		fmt.Println("• 2.5D isometric game view with interactive features")
-		fmt.Println("• State persistence for save/load functionality")
-		fmt.Println()
-		fmt.Println("Usage:")
-		fmt.Printf("  %s [options]\n", os.Args[0])
-		fmt.Println()
-		fmt.Println("Options:")
-		flag.PrintDefaults()
-		fmt.Println()
-		fmt.Println("Primitive Evolution Mode:")
-		fmt.Println("  Use --primitive flag to start with basic microbes and simple organisms")
-		fmt.Println("  that can evolve into complex species through environmental pressure.")
-		fmt.Println("  This mode demonstrates evolution from the ground up.")
-		fmt.Println()
-		fmt.Println("Controls (in simulation):")
-		fmt.Println("  space      Pause/Resume simulation")
-		fmt.Println("  enter      Manual step (when paused)")
-		fmt.Println("  v          Cycle through views (grid/stats/events/populations)")
-		fmt.Println("  ?          Toggle help screen")
-		fmt.Println("  q          Quit")
-		fmt.Println()
-		fmt.Println("Web Interface:")
-		fmt.Println("  Use --web flag to enable web interface mode")
-		fmt.Println("  Access via browser at http://localhost:<port> (default: 8080)")
-		fmt.Println("  All 14 view modes available with real-time updates")
-		fmt.Println("  WebSocket-based live simulation streaming")
-		fmt.Println()
-		fmt.Println("2.5D Isometric View:")
-		fmt.Println("  Use --iso flag to enable 2.5D isometric game interface")
-		fmt.Println("  Launches web server with isometric view at http://localhost:<port>/iso")
-		fmt.Println("  Interactive world with clickable entities and plants")
-		fmt.Println("  Real-time DNA and species details on selection")
-		fmt.Println("  WASD/Arrow Keys - Camera movement, Mouse Wheel - Zoom")
-		fmt.Println("  Left Click - Select entity/plant, Space - Toggle details")
-		fmt.Println()
-		fmt.Println("State Management:")
-		fmt.Println("  --save <file>   Save simulation state to JSON file")
-		fmt.Println("  --load <file>   Load simulation state from JSON file")
-		fmt.Println("  State includes all entities, tools, behaviors, and environment")
-		fmt.Println()
-		fmt.Println("The simulation will display a real-time grid showing entities, plants,")
-		fmt.Println("biomes, tools, and environmental modifications. Different symbols represent")
-		fmt.Println("different species and plant types. Check the in-simulation help (?) for")
-		fmt.Println("detailed symbol meanings.")
-		return
+	var breakpoints breakpointFlags
+	fs.Var(&breakpoints, "break", "Pause when a condition is met: tick=N, population<N, population:SPECIES<N or speciation (repeatable)")
+
+	// Run reads EVOSIM_<FLAG> rather than EVOSIM_RUN_<FLAG>, as it did before there were commands
+	runConfig, err := parseCommandFlags(fs, args, envPrefix, options)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return commandUsage("run")
+	}
+	if _, err := ResolveSaveFormat(*saveState, *saveFormat); err != nil {
+		return err
+	}
+	if _, err := ResolveResumeMode(*resumeMode); err != nil {
+		return err
+	}
+	var autosaver *Autosaver
+	if *autosaveInterval > 0 {
+		if autosaver, err = NewAutosaver(*autosaveDir, *autosaveInterval, *autosaveKeep, *saveFormat); err != nil {
+			return err
+		}
+	}
+
+	// Play back a recorded run instead of simulating
+	if *replayFile != "" {
+		replay, err := LoadReplay(*replayFile)
+		if err != nil {
+			return fmt.Errorf("loading replay: %v", err)
+		}
+		if replay.Truncated {
+			log.Printf("Replay ends early at tick %d; the recording was not finished", replay.LastTick())
+		}
+		if *webMode || *isoMode {
+			err = RunReplayServer(replay, *webPort)
+		} else {
+			err = RunReplayCLI(replay)
+		}
+		if err != nil {
+			return fmt.Errorf("playing replay: %v", err)
+		}
+		return nil
 	}
 
-	// Show version
-	if *version {
-		fmt.Println("Genetic Ecosystem Simulation v2.0")
-		fmt.Println("Enhanced with:")
-		fmt.Println("• Plant life, event logging, and evolutionary pressure")
-		fmt.Println("• Tool creation and environmental modification systems")
-		fmt.Println("• Emergent behavior discovery and social learning")
-		fmt.Println("• Web interface with real-time visualization")
-		fmt.Println("• Complete state persistence functionality")
-		fmt.Println("• Underground plant networks and wind dispersal")
-		fmt.Println("• DNA/RNA genetic systems and cellular evolution")
-		fmt.Println("• Species formation and macro evolution tracking")
-		return
+	worldConfig, err := options.WorldConfig(runConfig)
+	if err != nil {
+		return err
 	}
-	// Initialize random seed (deprecated but functional)
-	if *seed == 0 {
-		// Use current time for randomness
-	} else {
-		// For deterministic behavior, we'd need to modify NewWorld to accept a rand source
-		fmt.Printf("Using random seed: %d\n", *seed)
+	worldConfig.Breakpoints = breakpoints
+	if err := worldConfig.SetPopulationCaps(*populationCap, *regionCap); err != nil {
+		return err
 	}
 
-	// Create world configuration
-	worldConfig := WorldConfig{
-		Width:          *width,
-		Height:         *height,
-		NumPopulations: 3,
-		PopulationSize: *popSize,
-		GridWidth:      *gridWidth,
-		GridHeight:     *gridHeight,
+	// Every run prints the seed of its world's random source. A run given --seed updates its
+	// creatures one after another and a --parallel run chunk by chunk, so both can be
+	// reproduced; others share the source between concurrent workers and cannot.
+	// Progress goes to stderr, keeping stdout for what was asked for, such as the headless summary.
+	worldSeed := seedForRun(*seed)
+	worldConfig.Seed = worldSeed
+	switch {
+	case *seed != 0:
+		fmt.Fprintf(os.Stderr, "Using random seed: %d\n", worldSeed)
+	case *parallel:
+		fmt.Fprintf(os.Stderr, "Using random seed: %d (pass --seed %d --parallel to reproduce this run)\n", worldSeed, worldSeed)
+	default:
+		fmt.Fprintf(os.Stderr, "Using random seed: %d (creatures update concurrently, so this run cannot be reproduced; pass --seed for one that can)\n", worldSeed)
 	}
 
 	// Create the world
 	world := NewWorld(worldConfig)
+	world.Deterministic = *seed != 0
+	if *parallel {
+		world.RNG = NewRNGStreams(worldSeed, true)
+	}
+	world.HashChain.Primitive = *options.primitive
+	if runConfig != nil && runConfig.Speed.Multiplier > 0 {
+		world.SetSpeedMultiplier(runConfig.Speed.Multiplier)
+	}
 
 	// Create state manager
 	stateManager := NewStateManager(world)
+	stateManager.Format = *saveFormat
 
-	// Load state if specified
-	if *loadState != "" {
-		err := stateManager.LoadFromFile(*loadState)
+	// Load state if specified, or resume the last run's autosave, asking first only in the
+	// terminal view; the web page offers it in a banner
+	var recovery *AutosaveRecovery
+	interactive := !*webMode && !*isoMode && !*headless && *saveState == "" && stdinIsTerminal()
+	if *loadState != "" && *fastTo > 0 {
+		state, err := LoadStateFile(*loadState)
 		if err != nil {
-			log.Fatalf("Error loading state: %v", err)
+			return fmt.Errorf("loading state: %v", err)
 		}
-	} else {
-		var populations []PopulationConfig
-
-		if *primitive {
-			// Start with primitive life forms that can evolve into complex species
-			populations = []PopulationConfig{
-				{
-					Name:             "Primitive Microbes",
-					Species:          "microbe",
-					BaseTraits:       createPrimitiveTraits(0.0, 0.0, 0.0),
-					StartPos:         Position{X: 30, Y: 30},
-					Spread:           25.0, // Widely spread
-					Color:            "gray",
-					BaseMutationRate: 0.25, // Very high mutation rate for rapid evolution
-				},
-				{
-					Name:             "Simple Organisms",
-					Species:          "simple",
-					BaseTraits:       createPrimitiveTraits(0.5, 0.3, 0.1), // Slightly larger, smarter, more cooperative
-					StartPos:         Position{X: 70, Y: 40},
-					Spread:           20.0,
-					Color:            "yellow",
-					BaseMutationRate: 0.20, // High mutation rate for evolution
-				},
-			}
-		} else {
-			// Define predator-prey ecosystem populations only if not loading state
-			populations = []PopulationConfig{
-				{
-					Name:    "Herbivores",
-					Species: "herbivore",
-					BaseTraits: map[string]float64{
-						"size":               -0.5, // Smaller
-						"speed":              0.3,  // Moderate speed
-						"aggression":         -0.8, // Very peaceful
-						"defense":            0.2,  // Some defense
-						"cooperation":        0.6,  // Cooperative
-						"intelligence":       0.1,  // Basic intelligence
-						"endurance":          0.4,  // Good endurance
-						"strength":           -0.2, // Weaker
-						"aquatic_adaptation": -0.5, // Poor in water initially
-						"digging_ability":    0.1,  // Basic digging
-						"underground_nav":    -0.3, // Poor underground navigation
-						"flying_ability":     -0.8, // Cannot fly
-						"altitude_tolerance": -0.6, // Poor at altitude
-						// Biorhythm traits
-						"circadian_preference": 0.7, // Strongly diurnal (active during day)
-						"sleep_need":           0.2, // Lower sleep requirement (grazing animals)
-						"hunger_need":          0.8, // High hunger needs (constant grazing)
-						"thirst_need":          0.6, // High water needs
-						"play_drive":           0.3, // Some play behavior (social animals)
-						"exploration_drive":    0.5, // Moderate exploration for food
-						"scavenging_behavior":  0.1, // Minimal scavenging (prefer fresh plants)
-					},
-					StartPos:         Position{X: 20, Y: 20},
-					Spread:           15.0,
-					Color:            "green",
-					BaseMutationRate: 0.08, // Low mutation rate - stable species
-				},
-				{
-					Name:    "Predators",
-					Species: "predator",
-					BaseTraits: map[string]float64{
-						"size":               0.8,  // Larger
-						"speed":              0.6,  // Fast
-						"aggression":         0.9,  // Very aggressive
-						"defense":            0.4,  // Good defense
-						"cooperation":        -0.2, // Less cooperative
-						"intelligence":       0.7,  // Smart hunters
-						"endurance":          0.3,  // Lower endurance
-						"strength":           0.8,  // Strong
-						"aquatic_adaptation": -0.2, // Somewhat poor in water
-						"digging_ability":    0.0,  // Average digging
-						"underground_nav":    0.2,  // Decent underground navigation
-						"flying_ability":     -0.5, // Poor flying ability
-						"altitude_tolerance": 0.1,  // Slightly better at altitude
-						// Biorhythm traits
-						"circadian_preference": -0.6, // Nocturnal (hunt at night)
-						"sleep_need":           0.4,  // Moderate sleep needs (conserve energy)
-						"hunger_need":          0.3,  // Lower hunger frequency (large meals)
-						"thirst_need":          0.2,  // Lower water needs
-						"play_drive":           -0.3, // Limited play (focus on survival)
-						"exploration_drive":    0.8,  // High exploration (hunting territory)
-						"scavenging_behavior":  0.7,  // High scavenging behavior
-					},
-					StartPos:         Position{X: 80, Y: 80},
-					Spread:           10.0,
-					Color:            "red",
-					BaseMutationRate: 0.12, // Higher mutation rate - adaptive hunters
-				},
-				{
-					Name:    "Omnivores",
-					Species: "omnivore",
-					BaseTraits: map[string]float64{
-						"size":               0.0,  // Medium size
-						"speed":              0.4,  // Decent speed
-						"aggression":         0.2,  // Moderately aggressive
-						"defense":            0.5,  // Good defense
-						"cooperation":        0.3,  // Somewhat cooperative
-						"intelligence":       0.5,  // Intelligent
-						"endurance":          0.6,  // Good endurance
-						"strength":           0.3,  // Moderate strength
-						"aquatic_adaptation": 0.1,  // Slightly adapted to water
-						"digging_ability":    0.2,  // Good digging ability
-						"underground_nav":    0.1,  // Basic underground navigation
-						"flying_ability":     -0.3, // Limited flying ability
-						"altitude_tolerance": 0.0,  // Average altitude tolerance
-						// Biorhythm traits
-						"circadian_preference": 0.3, // Slightly diurnal but adaptable
-						"sleep_need":           0.3, // Moderate sleep needs
-						"hunger_need":          0.6, // High hunger (active foragers)
-						"thirst_need":          0.5, // Moderate water needs
-						"play_drive":           0.6, // High play behavior (intelligent species)
-						"exploration_drive":    0.7, // High exploration (opportunistic)
-						"scavenging_behavior":  0.8, // Very high scavenging (opportunistic feeders)
-					},
-					StartPos:         Position{X: 50, Y: 20},
-					Spread:           12.0,
-					Color:            "blue",
-					BaseMutationRate: 0.10, // Moderate mutation rate - adaptable
-				},
-			}
+		result, err := FastForward(world, state, *fastTo, *seed)
+		if err != nil {
+			return fmt.Errorf("fast-forwarding: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "Fast-forwarded from tick %d to %d in %d ms (seed %d, digest %s)\n",
+			result.FromTick, result.ToTick, result.ElapsedMS, result.Seed, result.Digest)
+	} else if *loadState != "" {
+		if err := stateManager.LoadFromFile(*loadState); err != nil {
+			return fmt.Errorf("loading state: %v", err)
+		}
+	} else if resumed, offer, err := offerAutosaveRecovery(world, *autosaveDir, *resumeMode, interactive); err != nil {
+		return err
+	} else if !resumed {
+		recovery = offer
+		populations := startingPopulations(*options.primitive)
+		if runConfig != nil && len(runConfig.Populations) > 0 {
+			populations = runConfig.StartingPopulations(*options.width, *options.height)
 		}
 
 		// Add populations to the world
@@ -317,28 +368,245 @@ func main() {
 
 	// Save state if specified and exit
 	if *saveState != "" {
-		err := stateManager.SaveToFile(*saveState)
+		if err := stateManager.SaveToFile(*saveState); err != nil {
+			return fmt.Errorf("saving state: %v", err)
+		}
+		return nil
+	}
+
+	// Keep the snapshot gallery beside the run's saves; without any it stays in memory
+	gallery := *galleryDir
+	if gallery == "" && *loadState != "" {
+		gallery = galleryDirBeside(*loadState)
+	} else if gallery == "" && *headless && *snapshotInterval > 0 {
+		gallery = filepath.Join(*snapshotDir, hostedWorldGalleryDir)
+	}
+	if gallery != "" {
+		if err := world.Gallery.SetDir(gallery); err != nil {
+			return fmt.Errorf("opening gallery: %v", err)
+		}
+	}
+
+	// Record the run's history for playback
+	if *recordFile != "" {
+		recorder, err := CreateReplayRecorder(*recordFile, world, defaultReplayKeyframeInterval)
 		if err != nil {
-			log.Fatalf("Error saving state: %v", err)
+			return fmt.Errorf("recording replay: %v", err)
 		}
-		return
+		world.Recorder = recorder
+		defer func() {
+			if err := recorder.Close(); err != nil {
+				log.Printf("Error recording replay: %v", err)
+			}
+		}()
 	}
+
+	// Configure the optional creature sharing registry
+	var registry *RegistryClient
+	if *registryURL != "" {
+		registry = NewRegistryClient(*registryURL, *registryCache)
+		if *registryKey != "" {
+			if err := registry.AddTrustedKey(*registryKey); err != nil {
+				return fmt.Errorf("configuring registry: %v", err)
+			}
+		}
+		if *registrySigningKey != "" {
+			if err := registry.SetSigningKey(*registrySigningKey); err != nil {
+				return fmt.Errorf("configuring registry: %v", err)
+			}
+		}
+	}
+
+	// Configure the simulation rate for the web interface
+	governor := NewSpeedGovernor()
+	governor.SetUnlimited(*unlimitedSpeed)
+	governor.SetMaxCPU(*maxCPU / 100)
+
+	// Autosave from here on, keeping the last run's autosave while it is still on offer
+	if autosaver != nil {
+		if recovery != nil {
+			autosaver.Protected = recovery.File
+		}
+		if err := autosaver.Begin(world); err != nil {
+			return err
+		}
+		world.Autosaver = autosaver
+	}
+
 	// Run the interface
-	if *webMode {
+	if *headless {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		summary, err := RunHeadless(ctx, world, HeadlessConfig{MaxTicks: *maxTicks, SnapshotInterval: *snapshotInterval, SnapshotDir: *snapshotDir, SaveFormat: *saveFormat})
+		if err != nil {
+			return fmt.Errorf("running headless: %v", err)
+		}
+		if err := WriteHeadlessSummary(summary, *summaryFile); err != nil {
+			return fmt.Errorf("writing summary: %v", err)
+		}
+	} else if *webMode {
 		// Create and run the web interface
-		if err := RunWebInterface(world, *webPort); err != nil {
-			log.Fatalf("Error running web interface: %v", err)
+		if err := RunWebInterface(world, *webPort, registry, governor, recovery); err != nil {
+			return fmt.Errorf("running web interface: %v", err)
 		}
 	} else if *isoMode {
 		// Create and run the web interface with isometric view
-		fmt.Printf("Starting isometric 2.5D interface on http://localhost:%d/iso\n", *webPort)
-		if err := RunWebInterface(world, *webPort); err != nil {
-			log.Fatalf("Error running isometric interface: %v", err)
+		log.Printf("Starting isometric 2.5D interface on http://localhost:%d/iso", *webPort)
+		if err := RunWebInterface(world, *webPort, registry, governor, recovery); err != nil {
+			return fmt.Errorf("running isometric interface: %v", err)
 		}
 	} else {
 		// Create and run the CLI
 		if err := RunCLI(world); err != nil {
-			log.Fatalf("Error running CLI: %v", err)
+			return fmt.Errorf("running CLI: %v", err)
 		}
 	}
+	return nil
+}
+
+// printRunHelp explains run's modes and options beyond the flag list
+func printRunHelp(w io.Writer) {
+	fmt.Fprintln(w, "Primitive Evolution Mode:")
+	fmt.Fprintln(w, "  Use --primitive flag to start with basic microbes and simple organisms")
+	fmt.Fprintln(w, "  that can evolve into complex species through environmental pressure.")
+	fmt.Fprintln(w, "  This mode demonstrates evolution from the ground up.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Controls (in simulation):")
+	fmt.Fprintln(w, "  space      Pause/Resume simulation")
+	fmt.Fprintln(w, "  enter      Manual step (when paused)")
+	fmt.Fprintln(w, "  v          Cycle through views (grid/stats/events/populations)")
+	fmt.Fprintln(w, "  ?          Toggle help screen")
+	fmt.Fprintln(w, "  q          Quit")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Headless Runs:")
+	fmt.Fprintln(w, "  --headless --max-ticks <n> [--snapshot-interval <n>] [--snapshot-dir dir] [--summary file]")
+	fmt.Fprintln(w, "                  Simulate without an interface until the tick limit, extinction, a")
+	fmt.Fprintln(w, "                  breakpoint or Ctrl+C, saving snapshot_<tick>.json every interval")
+	fmt.Fprintln(w, "                  and writing a JSON summary of the run to stdout or the file")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Autosaves:")
+	fmt.Fprintln(w, "  --autosave-interval <n> [--autosave-keep <n>] [--autosave-dir dir]")
+	fmt.Fprintln(w, "                  Save the world every n ticks and on Ctrl+C, keeping the newest")
+	fmt.Fprintln(w, "                  few. After a crash or Ctrl+C the next run offers to resume the")
+	fmt.Fprintln(w, "                  latest: the terminal asks, and the web page shows a banner.")
+	fmt.Fprintln(w, "                  --resume always|never answers without asking; --load skips it")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Web Interface:")
+	fmt.Fprintln(w, "  Use --web flag to enable web interface mode")
+	fmt.Fprintln(w, "  Access via browser at http://localhost:<port> (default: 8080)")
+	fmt.Fprintln(w, "  All 14 view modes available with real-time updates")
+	fmt.Fprintln(w, "  WebSocket-based live simulation streaming")
+	fmt.Fprintln(w, "  --unlimited-speed  Tick as fast as possible; views still update at 10 FPS")
+	fmt.Fprintln(w, "  --max-cpu <pct>    Cap the share of CPU time spent simulating")
+	fmt.Fprintln(w, "  --fog-of-war       Players only see what their species perceive, plus explored terrain")
+	fmt.Fprintln(w, "  The HTTP API is described at /api/spec (OpenAPI) and the WebSocket protocol at")
+	fmt.Fprintln(w, "  /api/spec/asyncapi (AsyncAPI); evosim export sdk writes both with generated clients")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "World Geometry:")
+	fmt.Fprintln(w, "  --geometry <name>  box (default) is bounded, with ice all round the edge; torus wraps")
+	fmt.Fprintln(w, "                     east to west and north to south, with repeating climate bands and")
+	fmt.Fprintln(w, "                     no poles; cylinder wraps east to west, with polar caps top and bottom.")
+	fmt.Fprintln(w, "                     Movement, seed dispersal and distances cross the joined edges")
+	fmt.Fprintln(w, "  --grid-shape <name>  square (default) cells touch four others edge to edge; hex cells")
+	fmt.Fprintln(w, "                     touch six, so neighbourhoods, biome spread and group paths are")
+	fmt.Fprintln(w, "                     rounder, and the web grid draws them as hexagons")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Population Caps:")
+	fmt.Fprintln(w, "  --population-cap <n>  Soft cap on the whole population (default 1000); the surplus")
+	fmt.Fprintln(w, "                        waits offstage in a dispersal pool until there is room")
+	fmt.Fprintln(w, "  --region-cap <n>      Creatures per 10x10 cell region before the youngest emigrate")
+	fmt.Fprintln(w, "                        to a neighbouring region")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run Config Files:")
+	fmt.Fprintln(w, "  --config <file>   Read a run from JSON: world (width, height, grid_width, grid_height,")
+	fmt.Fprintln(w, "                    population_size, profile, fog_of_war, geometry, grid_shape,")
+	fmt.Fprintln(w, "                    population_cap, region_cap),")
+	fmt.Fprintln(w, "                    primitive, populations [{name, species, traits, x, y, spread, color,")
+	fmt.Fprintln(w, "                    mutation_rate}], simulation (overrides on the simulation settings,")
+	fmt.Fprintln(w, "                    e.g. {\"biomes\": {\"energy_drain_multipliers\": {\"desert\": 2}}}),")
+	fmt.Fprintln(w, "                    events {random_events, timetable [{tick, name, duration}]} and")
+	fmt.Fprintln(w, "                    speed {multiplier, unlimited, max_cpu}. Flags override the file")
+	fmt.Fprintln(w, "                    simulation.schedule sets the ticks between runs of the scheduled")
+	fmt.Fprintln(w, "                    subsystems: "+strings.Join(ScheduledSystemNames(), ", "))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Replays:")
+	fmt.Fprintln(w, "  --record <file>   Record births, deaths, movements and events every tick to a")
+	fmt.Fprintln(w, "                    gzipped replay, with a full keyframe every 100 ticks")
+	fmt.Fprintln(w, "  --replay <file>   Play a replay back: space play/pause, arrows step, [ ] jump 100")
+	fmt.Fprintln(w, "                    ticks, home/end, +/- speed. With --web the browser page at")
+	fmt.Fprintln(w, "                    http://localhost:<port> has a scrubbing slider")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Tuning Profiles:")
+	fmt.Fprintln(w, "  --profile <name>  Scale lifespans, event durations, mutation rates, gestation,")
+	fmt.Fprintln(w, "                    decay and seasons together so their timescales stay in proportion")
+	for _, tuning := range tuningProfiles {
+		fmt.Fprintf(w, "    %-15s %s\n", tuning.Name, tuning.Description)
+	}
+	fmt.Fprintln(w, "                    evosim analyze timescales audits a profile's time constants")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Custom Traits:")
+	fmt.Fprintln(w, "  --traits <file>   Evolve extra traits defined in a JSON array. Each gives a name,")
+	fmt.Fprintln(w, "                    min/max range, initial value and variation, heritability (0-1),")
+	fmt.Fprintln(w, "                    a cost {function: linear|quadratic|exponential, coefficient} in")
+	fmt.Fprintln(w, "                    energy per tick, and hooks shifting built-in traits, e.g.")
+	fmt.Fprintln(w, "                    {\"speed\": 0.3, \"metabolism\": 0.2}")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "2.5D Isometric View:")
+	fmt.Fprintln(w, "  Use --iso flag to enable 2.5D isometric game interface")
+	fmt.Fprintln(w, "  Launches web server with isometric view at http://localhost:<port>/iso")
+	fmt.Fprintln(w, "  Interactive world with clickable entities and plants")
+	fmt.Fprintln(w, "  Real-time DNA and species details on selection")
+	fmt.Fprintln(w, "  WASD/Arrow Keys - Camera movement, Mouse Wheel - Zoom")
+	fmt.Fprintln(w, "  Left Click - Select entity/plant, Space - Toggle details")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "3D Terrain View:")
+	fmt.Fprintln(w, "  With --web or --iso, http://localhost:<port>/3d shows the topology in WebGL")
+	fmt.Fprintln(w, "  as a lit terrain mesh, or wrapped round a globe, with creatures and plants")
+	fmt.Fprintln(w, "  Drag - Orbit, Mouse Wheel - Zoom, WASD/Arrow Keys - Move, G - Globe, R - Reset")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "State Management:")
+	fmt.Fprintln(w, "  --save <file>   Save simulation state to JSON file")
+	fmt.Fprintln(w, "  --load <file>   Load simulation state from JSON file")
+	fmt.Fprintln(w, "  State includes all entities, tools, behaviors, and environment")
+	fmt.Fprintln(w, "  --load <file> --fast-forward <tick> [--seed N]")
+	fmt.Fprintln(w, "                  Replay the save to the given tick at full speed, then carry on")
+	fmt.Fprintln(w, "                  as usual; the same save, tick and seed always give the same state")
+	fmt.Fprintln(w, "  evosim analyze diff and validate compare and repair saves")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Breakpoints:")
+	fmt.Fprintln(w, "  --break tick=N             Pause at tick N")
+	fmt.Fprintln(w, "  --break population<N       Pause when any species drops below N members")
+	fmt.Fprintln(w, "  --break population:NAME<N  Pause when species NAME drops below N members")
+	fmt.Fprintln(w, "  --break speciation         Pause when the first new species appears")
+	fmt.Fprintln(w, "                  Repeat --break to arm several; each fires once. Breakpoints can")
+	fmt.Fprintln(w, "                  also be managed from the web interface")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Snapshot Gallery:")
+	fmt.Fprintln(w, "  Pictures the grid with a summary of the world on the first tool use, each")
+	fmt.Fprintln(w, "  speciation and each declaration of war; browse them from the web page's Gallery")
+	fmt.Fprintln(w, "  --gallery <dir>  Keep the images and their index there; by default they go beside")
+	fmt.Fprintln(w, "                   the --load save (<save>_gallery), or stay in memory without one")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Parallel Updates:")
+	fmt.Fprintln(w, "  --parallel      Split creature updates, plant growth, creature physics and")
+	fmt.Fprintln(w, "                  neural sensing into chunks of the world updated on every CPU")
+	fmt.Fprintln(w, "                  core, with every subsystem drawing from its own RNG stream")
+	fmt.Fprintln(w, "                  derived from the seed. A --parallel run reproduces only other")
+	fmt.Fprintln(w, "                  --parallel runs of the same seed; evosim experiment determinism")
+	fmt.Fprintln(w, "                  --parallel checks that concurrent chunks match chunks in turn")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run Certificates:")
+	fmt.Fprintln(w, "  JSON exports carry a certificate: a hash chain over the state every 100 ticks,")
+	fmt.Fprintln(w, "  checked by evosim analyze certificate")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Creature Registry:")
+	fmt.Fprintln(w, "  --registry-url <url>          Share creatures and scenarios via a remote registry")
+	fmt.Fprintln(w, "  --registry-key <base64>       Trusted ed25519 public key for verifying downloads")
+	fmt.Fprintln(w, "  --registry-signing-key <b64>  ed25519 seed used to sign uploads")
+	fmt.Fprintln(w, "  --registry-cache <dir>        Local cache for verified downloads")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "The simulation will display a real-time grid showing entities, plants,")
+	fmt.Fprintln(w, "biomes, tools, and environmental modifications. Different symbols represent")
+	fmt.Fprintln(w, "different species and plant types. Check the in-simulation help (?) for")
+	fmt.Fprintln(w, "detailed symbol meanings.")
 }
@@ -82,6 +82,8 @@ type MetamorphosisSystem struct {
 	StageTraitModifiers  map[LifeStage]map[string]float64 `json:"stage_trait_modifiers"` // How traits change per stage
 	EnvironmentModifiers map[string]float64               `json:"environment_modifiers"` // Environmental effects on development
 	SeasonalModifiers    map[string]float64               `json:"seasonal_modifiers"`    // Seasonal effects on metamorphosis
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewMetamorphosisSystem creates a new metamorphosis management system
@@ -92,6 +94,7 @@ func NewMetamorphosisSystem() *MetamorphosisSystem {
 		StageTraitModifiers:  make(map[LifeStage]map[string]float64),
 		EnvironmentModifiers: make(map[string]float64),
 		SeasonalModifiers:    make(map[string]float64),
+		rng:                  sharedRand,
 	}
 
 	ms.initializeStageRequirements()
@@ -403,8 +406,8 @@ func (ms *MetamorphosisSystem) updateStageCapabilities(entity *Entity, stage Lif
 
 	case StagePupa:
 		status.CanMove = false
-		status.VulnerabilityModifier = 2.0         // Very vulnerable during transformation
-		status.PupalShelter = rand.Float64() < 0.7 // 70% chance of creating protective shelter
+		status.VulnerabilityModifier = 2.0           // Very vulnerable during transformation
+		status.PupalShelter = ms.rng.Float64() < 0.7 // 70% chance of creating protective shelter
 
 	case StageAdult:
 		status.CanMove = true
@@ -29,7 +29,7 @@ func TestMetamorphosisStatusCreation(t *testing.T) {
 	system := NewMetamorphosisSystem()
 
 	// Create a basic entity
-	entity := NewEntity(1, []string{"size", "intelligence", "swarm_capability", "pollination_efficiency"}, "test_species", Position{X: 50, Y: 50})
+	entity := NewEntity(sharedRand, 1, []string{"size", "intelligence", "swarm_capability", "pollination_efficiency"}, "test_species", Position{X: 50, Y: 50})
 
 	// Set traits that would trigger complete metamorphosis
 	entity.SetTrait("size", -0.2)
@@ -60,7 +60,7 @@ func TestNoMetamorphosisEntities(t *testing.T) {
 	system := NewMetamorphosisSystem()
 
 	// Create an entity that doesn't undergo metamorphosis
-	entity := NewEntity(1, []string{"size", "intelligence", "swarm_capability"}, "test_species", Position{X: 50, Y: 50})
+	entity := NewEntity(sharedRand, 1, []string{"size", "intelligence", "swarm_capability"}, "test_species", Position{X: 50, Y: 50})
 
 	// Set traits that would not trigger metamorphosis
 	entity.SetTrait("size", 0.5)             // Large size
@@ -86,7 +86,7 @@ func TestStageAdvancement(t *testing.T) {
 	system := NewMetamorphosisSystem()
 
 	// Create entity with complete metamorphosis
-	entity := NewEntity(1, []string{"size", "intelligence", "swarm_capability", "pollination_efficiency"}, "test_species", Position{X: 50, Y: 50})
+	entity := NewEntity(sharedRand, 1, []string{"size", "intelligence", "swarm_capability", "pollination_efficiency"}, "test_species", Position{X: 50, Y: 50})
 	entity.SetTrait("size", -0.2)
 	entity.SetTrait("swarm_capability", 0.5)
 	entity.SetTrait("pollination_efficiency", 0.6)
@@ -130,7 +130,7 @@ func TestPupaStageImmobility(t *testing.T) {
 	system := NewMetamorphosisSystem()
 
 	// Create entity and advance to pupa stage
-	entity := NewEntity(1, []string{"size", "intelligence", "swarm_capability", "pollination_efficiency"}, "test_species", Position{X: 50, Y: 50})
+	entity := NewEntity(sharedRand, 1, []string{"size", "intelligence", "swarm_capability", "pollination_efficiency"}, "test_species", Position{X: 50, Y: 50})
 	entity.SetTrait("size", -0.2)
 	entity.SetTrait("swarm_capability", 0.5)
 	entity.SetTrait("pollination_efficiency", 0.6)
@@ -161,7 +161,7 @@ func TestTraitModification(t *testing.T) {
 	system := NewMetamorphosisSystem()
 
 	// Create entity
-	entity := NewEntity(1, []string{"size", "speed", "reproduction_rate", "intelligence"}, "test_species", Position{X: 50, Y: 50})
+	entity := NewEntity(sharedRand, 1, []string{"size", "speed", "reproduction_rate", "intelligence"}, "test_species", Position{X: 50, Y: 50})
 	entity.SetTrait("size", 0.5)
 	entity.SetTrait("speed", 0.8)
 	entity.SetTrait("reproduction_rate", 0.6)
@@ -213,7 +213,7 @@ func TestTraitModification(t *testing.T) {
 func TestEnvironmentalRequirements(t *testing.T) {
 	system := NewMetamorphosisSystem()
 
-	entity := NewEntity(1, []string{"size", "swarm_capability", "pollination_efficiency"}, "test_species", Position{X: 50, Y: 50})
+	entity := NewEntity(sharedRand, 1, []string{"size", "swarm_capability", "pollination_efficiency"}, "test_species", Position{X: 50, Y: 50})
 	entity.SetTrait("size", -0.2)
 	entity.SetTrait("swarm_capability", 0.5)
 	entity.SetTrait("pollination_efficiency", 0.6)
@@ -248,7 +248,7 @@ func TestMetamorphosisStats(t *testing.T) {
 	entities := make([]*Entity, 5)
 
 	for i := 0; i < 5; i++ {
-		entity := NewEntity(i+1, []string{"size", "swarm_capability", "pollination_efficiency"}, "test_species", Position{X: 50, Y: 50})
+		entity := NewEntity(sharedRand, i+1, []string{"size", "swarm_capability", "pollination_efficiency"}, "test_species", Position{X: 50, Y: 50})
 
 		if i < 3 {
 			// Complete metamorphosis entities
@@ -326,7 +326,7 @@ func TestMetamorphosisTypeString(t *testing.T) {
 func TestStageDescription(t *testing.T) {
 	system := NewMetamorphosisSystem()
 
-	entity := NewEntity(1, []string{"size", "swarm_capability", "pollination_efficiency"}, "test_species", Position{X: 50, Y: 50})
+	entity := NewEntity(sharedRand, 1, []string{"size", "swarm_capability", "pollination_efficiency"}, "test_species", Position{X: 50, Y: 50})
 	entity.SetTrait("size", -0.2)
 	entity.SetTrait("swarm_capability", 0.5)
 	entity.SetTrait("pollination_efficiency", 0.6)
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// Metric history sizes
+const (
+	defaultMetricHistoryCapacity = 2000 // Samples kept per metric: 10,000 ticks at the default interval
+	defaultMetricChartPoints     = 500  // Points a series is averaged down to unless asked otherwise
+	maxMetricChartPoints         = 5000
+)
+
+// metricRing holds one metric's latest samples, overwriting the oldest once it is full
+type metricRing struct {
+	ticks  []int
+	values []float64
+	next   int // Slot the next sample goes in
+	size   int
+}
+
+func newMetricRing(capacity int) *metricRing {
+	return &metricRing{ticks: make([]int, capacity), values: make([]float64, capacity)}
+}
+
+func (r *metricRing) add(tick int, value float64) {
+	r.ticks[r.next] = tick
+	r.values[r.next] = value
+	r.next = (r.next + 1) % len(r.ticks)
+	if r.size < len(r.ticks) {
+		r.size++
+	}
+}
+
+// at returns the i-th oldest sample
+func (r *metricRing) at(i int) (int, float64) {
+	slot := (r.next - r.size + i + len(r.ticks)) % len(r.ticks)
+	return r.ticks[slot], r.values[slot]
+}
+
+func (r *metricRing) firstTick() int {
+	tick, _ := r.at(0)
+	return tick
+}
+
+func (r *metricRing) lastTick() int {
+	tick, _ := r.at(r.size - 1)
+	return tick
+}
+
+// MetricInfo describes a metric the history holds
+type MetricInfo struct {
+	Metric     string `json:"metric"`
+	Kind       string `json:"kind"`                 // population, plants, fitness, energy or trait
+	Population string `json:"population,omitempty"` // Empty for whole-world totals
+	Trait      string `json:"trait,omitempty"`
+	Samples    int    `json:"samples"`
+	FirstTick  int    `json:"first_tick"`
+	LastTick   int    `json:"last_tick"`
+}
+
+// MetricSeries is one metric's samples between two ticks, averaged down to at most the points
+// asked for
+type MetricSeries struct {
+	Metric string    `json:"metric"`
+	Ticks  []int     `json:"ticks"` // Last tick each point covers
+	Values []float64 `json:"values"`
+}
+
+// MetricHistory keeps the recent history of the world's population metrics for charting: the
+// whole world's creature and plant counts, fitness and energy, and each population's size,
+// fitness, energy and average traits. Every metric has its own ring buffer, so a long run
+// charts its latest stretch in constant memory.
+type MetricHistory struct {
+	Capacity int
+	metrics  map[string]*metricRing
+}
+
+// NewMetricHistory creates a history keeping the given number of samples per metric
+func NewMetricHistory(capacity int) *MetricHistory {
+	if capacity <= 0 {
+		capacity = defaultMetricHistoryCapacity
+	}
+	return &MetricHistory{Capacity: capacity, metrics: make(map[string]*metricRing)}
+}
+
+// add records a sample of a metric, starting its ring on the first one
+func (h *MetricHistory) add(metric string, tick int, value float64) {
+	ring, exists := h.metrics[metric]
+	if !exists {
+		ring = newMetricRing(h.Capacity)
+		h.metrics[metric] = ring
+	}
+	ring.add(tick, value)
+}
+
+// Record samples every metric at the world's current tick. A population that has died out
+// keeps charting zero members, and its other metrics are dropped once their last sample has
+// scrolled out of the window.
+func (h *MetricHistory) Record(w *World) {
+	tick := w.Tick
+
+	var total, fitness, energy float64
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive {
+			total++
+			fitness += entity.Fitness
+			energy += entity.Energy
+		}
+	}
+	h.add("population", tick, total)
+	if total > 0 {
+		h.add("fitness", tick, fitness/total)
+		h.add("energy", tick, energy/total)
+	}
+	plants := 0
+	for _, plant := range w.AllPlants {
+		if plant.IsAlive {
+			plants++
+		}
+	}
+	h.add("plants", tick, float64(plants))
+
+	for _, name := range sortedKeys(w.Populations) {
+		pop := w.Populations[name]
+		var members, fitness, energy float64
+		traits := make(map[string]float64, len(pop.TraitNames))
+		for _, entity := range pop.Entities {
+			if !entity.IsAlive {
+				continue
+			}
+			members++
+			fitness += entity.Fitness
+			energy += entity.Energy
+			for _, trait := range pop.TraitNames {
+				traits[trait] += entity.GetTrait(trait)
+			}
+		}
+		if members == 0 {
+			// Only chart the drop to zero for a population already in the history
+			if _, charted := h.metrics["population:"+name]; charted {
+				h.add("population:"+name, tick, 0)
+			}
+			continue
+		}
+		h.add("population:"+name, tick, members)
+		h.add("fitness:"+name, tick, fitness/members)
+		h.add("energy:"+name, tick, energy/members)
+		for trait, sum := range traits {
+			h.add("trait:"+name+":"+trait, tick, sum/members)
+		}
+	}
+
+	// Drop metrics that stopped before everything still in the window
+	oldest := h.metrics["population"].firstTick()
+	for metric, ring := range h.metrics {
+		if ring.lastTick() < oldest {
+			delete(h.metrics, metric)
+		}
+	}
+}
+
+// Metrics lists the metrics the history holds, sorted by name
+func (h *MetricHistory) Metrics() []MetricInfo {
+	infos := make([]MetricInfo, 0, len(h.metrics))
+	for _, metric := range sortedKeys(h.metrics) {
+		ring := h.metrics[metric]
+		info := MetricInfo{Metric: metric, Samples: ring.size, FirstTick: ring.firstTick(), LastTick: ring.lastTick()}
+		parts := strings.SplitN(metric, ":", 3)
+		info.Kind = parts[0]
+		if len(parts) > 1 {
+			info.Population = parts[1]
+		}
+		if len(parts) > 2 {
+			info.Trait = parts[2]
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// Match returns the metrics named by a pattern, sorted: the metric itself, or every metric
+// starting with the pattern when it ends in *, e.g. population:* for each population's size
+func (h *MetricHistory) Match(pattern string) []string {
+	if prefix, wildcard := strings.CutSuffix(pattern, "*"); wildcard {
+		matches := make([]string, 0)
+		for metric := range h.metrics {
+			if strings.HasPrefix(metric, prefix) {
+				matches = append(matches, metric)
+			}
+		}
+		sort.Strings(matches)
+		return matches
+	}
+	if _, exists := h.metrics[pattern]; exists {
+		return []string{pattern}
+	}
+	return nil
+}
+
+// Series returns a metric's samples from one tick to another, averaging runs of neighbouring
+// samples into at most the given number of points
+func (h *MetricHistory) Series(metric string, from, to, points int) (*MetricSeries, bool) {
+	ring, exists := h.metrics[metric]
+	if !exists {
+		return nil, false
+	}
+	ticks := make([]int, 0, ring.size)
+	values := make([]float64, 0, ring.size)
+	for i := 0; i < ring.size; i++ {
+		if tick, value := ring.at(i); tick >= from && tick <= to {
+			ticks = append(ticks, tick)
+			values = append(values, value)
+		}
+	}
+
+	series := &MetricSeries{Metric: metric, Ticks: ticks, Values: values}
+	if points <= 0 || len(ticks) <= points {
+		return series, true
+	}
+	series.Ticks = make([]int, points)
+	series.Values = make([]float64, points)
+	for point := 0; point < points; point++ {
+		start, end := point*len(ticks)/points, (point+1)*len(ticks)/points
+		sum := 0.0
+		for _, value := range values[start:end] {
+			sum += value
+		}
+		series.Ticks[point] = ticks[end-1]
+		series.Values[point] = sum / float64(end-start)
+	}
+	return series, true
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"testing"
+)
+
+func TestMetricHistoryRing(t *testing.T) {
+	history := NewMetricHistory(4)
+	for tick := 0; tick < 10; tick++ {
+		history.add("population", tick, float64(tick))
+	}
+	series, exists := history.Series("population", 0, 100, 0)
+	if !exists || len(series.Ticks) != 4 || series.Ticks[0] != 6 || series.Values[3] != 9 {
+		t.Fatalf("Expected the latest 4 samples oldest first, got %+v", series)
+	}
+	series, _ = history.Series("population", 7, 8, 0)
+	if len(series.Ticks) != 2 || series.Ticks[0] != 7 {
+		t.Errorf("Expected only the samples in range, got %+v", series)
+	}
+	series, _ = history.Series("population", 0, 100, 2)
+	if len(series.Values) != 2 || series.Values[0] != 6.5 || series.Ticks[1] != 9 {
+		t.Errorf("Expected neighbouring samples averaged, got %+v", series)
+	}
+	if _, exists := history.Series("plants", 0, 100, 0); exists {
+		t.Errorf("Expected no series for an unrecorded metric")
+	}
+}
+
+func TestMetricHistoryRecordsPopulations(t *testing.T) {
+	world := newGraphQLTestWorld()
+	history := NewMetricHistory(3)
+	history.Record(world)
+
+	infos := history.Metrics()
+	kinds := make(map[string]int)
+	for _, info := range infos {
+		kinds[info.Kind]++
+	}
+	if kinds["population"] != len(world.Populations)+1 || kinds["plants"] != 1 || kinds["trait"] == 0 {
+		t.Fatalf("Expected world totals and each population's metrics, got %+v", infos)
+	}
+	sizes := history.Match("population:*")
+	if len(sizes) != len(world.Populations) {
+		t.Errorf("Expected a size per population, got %v", sizes)
+	}
+
+	// A population that dies out charts zero members and its other metrics age out
+	name := sizes[0][len("population:"):]
+	for _, entity := range world.Populations[name].Entities {
+		entity.IsAlive = false
+	}
+	for i := 0; i < 4; i++ {
+		world.Tick++
+		history.Record(world)
+	}
+	if series, _ := history.Series("population:"+name, 0, world.Tick, 0); series == nil || series.Values[len(series.Values)-1] != 0 {
+		t.Errorf("Expected the extinct population's size to drop to zero, got %+v", series)
+	}
+	if matches := history.Match("fitness:" + name); len(matches) != 0 {
+		t.Errorf("Expected the extinct population's fitness dropped, got %v", matches)
+	}
+}
+
+func TestMetricHistoryAPI(t *testing.T) {
+	wi := &WebInterface{world: newGraphQLTestWorld()}
+	for i := 0; i < 3; i++ {
+		wi.world.Tick += 5
+		wi.world.MetricHistory.Record(wi.world)
+	}
+
+	var index MetricHistoryIndex
+	if code := callControlAPI(t, wi.handleMetricHistoryIndex, http.MethodGet, "/api/history/metrics", "", &index); code != http.StatusOK || len(index.Metrics) == 0 || index.Interval != 5 {
+		t.Fatalf("Expected the charted metrics, got %d %+v", code, index)
+	}
+
+	var chart MetricHistoryChart
+	if code := callControlAPI(t, wi.handleMetricHistory, http.MethodGet, "/api/history?metrics=population,energy:*,missing&from=6", "", &chart); code != http.StatusOK {
+		t.Fatalf("Expected the chart, got %d", code)
+	}
+	if len(chart.Series) != 1+len(wi.world.Populations) || chart.Series[0].Metric != "population" || len(chart.Series[0].Ticks) != 2 {
+		t.Errorf("Expected the world population and each population's energy from tick 6, got %+v", chart)
+	}
+	if math.IsNaN(chart.Series[1].Values[0]) {
+		t.Errorf("Expected a population's average energy, got %v", chart.Series[1].Values)
+	}
+
+	for _, target := range []string{"/api/history", "/api/history?metrics=population&points=x", "/api/history?metrics=population&from=9&to=2"} {
+		if code := callControlAPI(t, wi.handleMetricHistory, http.MethodGet, target, "", nil); code != http.StatusBadRequest {
+			t.Errorf("Expected %s refused, got %d", target, code)
+		}
+	}
+}
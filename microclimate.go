@@ -0,0 +1,254 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// Microclimate parameters
+const (
+	microclimateInterval = 10   // Ticks between recalculations of the microclimate map
+	treeCanopy           = 0.12 // Canopy per unit of tree size in a cell
+	bushCanopy           = 0.04 // Canopy per unit of bush size
+	canopyCooling        = 0.5  // Share of warmth a full canopy takes off
+	shadeCooling         = 0.35 // Share of warmth full shade takes off
+	maxCooling           = 0.6  // Most warmth any cover can take off
+	windbreakWarming     = 0.3  // Share of cold a full windbreak keeps off
+	canopyRetention      = 0.4  // Share of the missing humidity a full canopy holds in
+	windbreakRetention   = 0.1  // Share of the missing humidity a full windbreak holds in
+	refugeCooling        = 0.3  // Cooling that makes a cell a refuge from heat
+	heatWaveTemperature  = 1.1  // Ambient temperature above which the day is a heat wave
+	heatWaveWarming      = 2.0  // How much a heat wave's excess ambient heat warms biomes
+	maxReportedCells     = 100  // Microclimate cells listed in a report
+)
+
+// structureCover is the shade and windbreak a healthy structure of each type gives its cell.
+// Large structures do most; farms, wells and traps barely stand above the ground.
+var structureCover = map[StructureType][2]float64{
+	StructureNest:    {0.2, 0.2},
+	StructureCache:   {0.1, 0.1},
+	StructureBarrier: {0.4, 0.7},
+	StructureTower:   {0.5, 0.3},
+	StructureMarket:  {0.4, 0.3},
+}
+
+// Microclimate is how vegetation and structures change the conditions of one cell relative
+// to its parent biome
+type Microclimate struct {
+	Canopy    float64 `json:"canopy"`    // Tree and bush cover (0-1)
+	Shade     float64 `json:"shade"`     // Shade cast by structures (0-1)
+	Windbreak float64 `json:"windbreak"` // Shelter from wind by structures and canopy (0-1)
+	Cooling   float64 `json:"cooling"`   // Share of warmth taken off
+	Retention float64 `json:"retention"` // Share of the missing humidity held in
+}
+
+// temperature is a parent temperature (-1 cold to 1 hot) as felt under this cover: shade
+// and canopy take the edge off heat, windbreaks off cold
+func (m Microclimate) temperature(parent float64) float64 {
+	if parent > 0 {
+		return parent * (1 - m.Cooling)
+	}
+	return parent * (1 - m.Windbreak*windbreakWarming)
+}
+
+// humidity is a parent humidity (0-1) as held in under this cover
+func (m Microclimate) humidity(parent float64) float64 {
+	return parent + (1-parent)*m.Retention
+}
+
+// MicroclimateCell is a cell whose conditions differ from its parent biome
+type MicroclimateCell struct {
+	X                 int     `json:"x"`
+	Y                 int     `json:"y"`
+	Biome             string  `json:"biome"`
+	Microclimate              // Cover and its effects
+	ParentTemperature float64 `json:"parent_temperature"`
+	Temperature       float64 `json:"temperature"`
+	ParentHumidity    float64 `json:"parent_humidity"`
+	Humidity          float64 `json:"humidity"`
+	Refuge            bool    `json:"refuge"` // Cool enough to shelter from heat
+	Sheltering        int     `json:"sheltering"`
+}
+
+// MicroclimateReport lists the cells whose microclimates differ from their biome, coolest first
+type MicroclimateReport struct {
+	Tick       int                `json:"tick"`
+	Ambient    float64            `json:"ambient"`
+	HeatWave   bool               `json:"heat_wave"`
+	Refugia    int                `json:"refugia"`
+	Sheltering int                `json:"sheltering"` // Living creatures in refugia
+	Cells      []MicroclimateCell `json:"cells"`
+}
+
+// MicroclimateSystem maps the microclimates that tree cover and structures create
+type MicroclimateSystem struct {
+	cells [][]Microclimate // [y][x], matching the world grid
+}
+
+// NewMicroclimateSystem creates a system with every cell at its biome's conditions
+func NewMicroclimateSystem() *MicroclimateSystem {
+	return &MicroclimateSystem{}
+}
+
+// At returns the microclimate of a grid cell, or none outside the mapped grid
+func (ms *MicroclimateSystem) At(x, y int) Microclimate {
+	if ms == nil || y < 0 || y >= len(ms.cells) || x < 0 || x >= len(ms.cells[y]) {
+		return Microclimate{}
+	}
+	return ms.cells[y][x]
+}
+
+// Update remaps canopy from living trees and bushes, and shade and windbreaks from standing
+// structures, every microclimateInterval ticks
+func (ms *MicroclimateSystem) Update(w *World) {
+	if w.Tick%microclimateInterval != 0 && len(ms.cells) == w.Config.GridHeight {
+		return
+	}
+	cells := make([][]Microclimate, w.Config.GridHeight)
+	for y := range cells {
+		cells[y] = make([]Microclimate, w.Config.GridWidth)
+	}
+
+	for _, plant := range w.AllPlants {
+		if !plant.IsAlive || (plant.Type != PlantTree && plant.Type != PlantBush) {
+			continue
+		}
+		x, y := w.worldToGridCoords(plant.Position.X, plant.Position.Y)
+		if plant.Type == PlantTree {
+			cells[y][x].Canopy += plant.Size * treeCanopy
+		} else {
+			cells[y][x].Canopy += plant.Size * bushCanopy
+		}
+	}
+
+	// A windbreak shelters its own cell and, at half strength, the cell downwind of it
+	downwindX, downwindY := 0, 0
+	if w.WindSystem != nil {
+		downwindX = int(math.Round(math.Cos(w.WindSystem.BaseWindDirection)))
+		downwindY = int(math.Round(math.Sin(w.WindSystem.BaseWindDirection)))
+	}
+	for _, structure := range w.standingStructures() {
+		cover, ok := structureCover[structure.Type]
+		if !ok {
+			continue
+		}
+		integrity := structure.integrity()
+		x, y := w.worldToGridCoords(structure.Position.X, structure.Position.Y)
+		cells[y][x].Shade += cover[0] * integrity
+		cells[y][x].Windbreak += cover[1] * integrity
+		if lx, ly := x+downwindX, y+downwindY; (lx != x || ly != y) && lx >= 0 && lx < w.Config.GridWidth && ly >= 0 && ly < w.Config.GridHeight {
+			cells[ly][lx].Windbreak += cover[1] * integrity * 0.5
+		}
+	}
+
+	for y := range cells {
+		for x := range cells[y] {
+			cell := &cells[y][x]
+			cell.Canopy = math.Min(1, cell.Canopy)
+			cell.Shade = math.Min(1, cell.Shade)
+			cell.Windbreak = math.Min(1, cell.Windbreak+cell.Canopy*0.5)
+			cell.Cooling = math.Min(maxCooling, cell.Canopy*canopyCooling+cell.Shade*shadeCooling)
+			cell.Retention = math.Min(1, cell.Canopy*canopyRetention+cell.Windbreak*windbreakRetention)
+		}
+	}
+	ms.cells = cells
+}
+
+// standingStructures lists every structure still standing, whether the civilization system
+// or a tribe holds it
+func (w *World) standingStructures() []*Structure {
+	if w.CivilizationSystem == nil {
+		return nil
+	}
+	seen := make(map[*Structure]bool)
+	standing := make([]*Structure, 0)
+	add := func(structures []*Structure) {
+		for _, structure := range structures {
+			if structure != nil && structure.Health > 0 && !seen[structure] {
+				seen[structure] = true
+				standing = append(standing, structure)
+			}
+		}
+	}
+	add(w.CivilizationSystem.Structures)
+	for _, tribe := range w.CivilizationSystem.Tribes {
+		add(tribe.Structures)
+	}
+	return standing
+}
+
+// integrity is the share of a structure still standing. Structures raised without a rated
+// maximum count against the default of 100.
+func (s *Structure) integrity() float64 {
+	maxHealth := s.MaxHealth
+	if maxHealth <= 0 {
+		maxHealth = 100
+	}
+	return math.Min(1, math.Max(0, s.Health/maxHealth))
+}
+
+// heatWaveExcess is how far the day's ambient temperature is above heat wave level
+func (w *World) heatWaveExcess() float64 {
+	if w.AdvancedTimeSystem == nil {
+		return 0
+	}
+	return math.Max(0, w.AdvancedTimeSystem.Temperature-heatWaveTemperature)
+}
+
+// localTemperature is a biome temperature (-1 cold to 1 hot) as felt at a position, warmed
+// by any heat wave and tempered by the position's microclimate
+func (w *World) localTemperature(pos Position, parent float64) float64 {
+	x, y := w.worldToGridCoords(pos.X, pos.Y)
+	return w.Microclimates.At(x, y).temperature(parent + w.heatWaveExcess()*heatWaveWarming)
+}
+
+// Report lists the cells whose cover sets them apart from their biome, with the refugia
+// they offer and how many creatures are sheltering in them
+func (ms *MicroclimateSystem) Report(w *World) MicroclimateReport {
+	report := MicroclimateReport{Tick: w.Tick, Cells: make([]MicroclimateCell, 0)}
+	if w.AdvancedTimeSystem != nil {
+		report.Ambient = w.AdvancedTimeSystem.Temperature
+	}
+	report.HeatWave = w.heatWaveExcess() > 0
+
+	sheltering := make(map[[2]int]int)
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive {
+			x, y := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
+			sheltering[[2]int{x, y}]++
+		}
+	}
+
+	for y := 0; y < w.Config.GridHeight; y++ {
+		for x := 0; x < w.Config.GridWidth; x++ {
+			micro := ms.At(x, y)
+			if micro.Canopy < 0.05 && micro.Shade < 0.05 && micro.Windbreak < 0.05 {
+				continue
+			}
+			biome := w.Biomes[w.Grid[y][x].Biome]
+			parent := biome.Temperature + w.heatWaveExcess()*heatWaveWarming
+			cell := MicroclimateCell{
+				X: x, Y: y, Biome: biomeName(biome.Type), Microclimate: micro,
+				ParentTemperature: parent, Temperature: micro.temperature(parent),
+				ParentHumidity: biome.Humidity, Humidity: micro.humidity(biome.Humidity),
+				Refuge: micro.Cooling >= refugeCooling,
+			}
+			if cell.Refuge {
+				cell.Sheltering = sheltering[[2]int{x, y}]
+				report.Refugia++
+				report.Sheltering += cell.Sheltering
+			}
+			report.Cells = append(report.Cells, cell)
+		}
+	}
+	sort.SliceStable(report.Cells, func(i, j int) bool { return report.Cells[i].Cooling > report.Cells[j].Cooling })
+	if len(report.Cells) > maxReportedCells {
+		report.Cells = report.Cells[:maxReportedCells]
+	}
+	return report
+}
+
+// Clear forgets the microclimate map, as when a new world is loaded
+func (ms *MicroclimateSystem) Clear() {
+	ms.cells = nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTreeCanopyCoolsAndHoldsHumidity(t *testing.T) {
+	world := newEmptyTestWorld(100)
+	fillTestBiome(world, BiomeDesert)
+	for i := 0; i < 3; i++ {
+		world.AllPlants = append(world.AllPlants, NewPlant(sharedRand, i, PlantTree, Position{X: 35, Y: 35}))
+	}
+	world.Microclimates.Update(world)
+
+	grove, open := world.Microclimates.At(3, 3), world.Microclimates.At(6, 6)
+	if grove.Canopy <= 0.5 || grove.Cooling <= 0 || grove.Retention <= 0 {
+		t.Fatalf("Expected a grove to give canopy, cooling and retained humidity, got %+v", grove)
+	}
+	if open != (Microclimate{}) {
+		t.Errorf("Expected open desert to keep its biome's conditions, got %+v", open)
+	}
+	if grove.temperature(0.7) >= 0.7 || grove.humidity(0.1) <= 0.1 {
+		t.Errorf("Expected the grove cooler and more humid than desert, got %.2f and %.2f", grove.temperature(0.7), grove.humidity(0.1))
+	}
+	if grove.temperature(-0.8) < -0.8 {
+		t.Errorf("Expected cover not to make cold worse, got %.2f", grove.temperature(-0.8))
+	}
+}
+
+func TestStructuresShadeAndBreakWind(t *testing.T) {
+	world := newEmptyTestWorld(100)
+	fillTestBiome(world, BiomeDesert)
+	world.WindSystem.BaseWindDirection = 0 // Blowing toward +x
+	world.CivilizationSystem.Structures = append(world.CivilizationSystem.Structures,
+		NewStructure(1, StructureBarrier, Position{X: 45, Y: 45}, nil),
+		NewStructure(2, StructureFarm, Position{X: 75, Y: 75}, nil))
+	world.Microclimates.Update(world)
+
+	wall, lee, farm := world.Microclimates.At(4, 4), world.Microclimates.At(5, 4), world.Microclimates.At(7, 7)
+	if wall.Shade <= 0 || wall.Windbreak <= 0 {
+		t.Errorf("Expected a barrier to shade and shelter its cell, got %+v", wall)
+	}
+	if lee.Windbreak <= 0 || lee.Windbreak >= wall.Windbreak || lee.Shade != 0 {
+		t.Errorf("Expected weaker shelter without shade downwind of the barrier, got %+v", lee)
+	}
+	if farm != (Microclimate{}) {
+		t.Errorf("Expected a farm to cast no shade, got %+v", farm)
+	}
+}
+
+func TestHeatWaveRefugia(t *testing.T) {
+	world := newEmptyTestWorld(100)
+	fillTestBiome(world, BiomeDesert)
+	for i := 0; i < 3; i++ {
+		world.AllPlants = append(world.AllPlants, NewPlant(sharedRand, i, PlantTree, Position{X: 35, Y: 35}))
+	}
+	world.Microclimates.Update(world)
+	world.AdvancedTimeSystem.Temperature = 1.3
+
+	sheltered := NewEntity(sharedRand, 1, []string{"endurance"}, "Lizard", Position{X: 35, Y: 35})
+	exposed := NewEntity(sharedRand, 2, []string{"endurance"}, "Lizard", Position{X: 65, Y: 65})
+	world.AllEntities = []*Entity{sheltered, exposed}
+	sheltered.Energy, exposed.Energy = 100, 100
+	world.applyEnvironmentalPressure(sheltered, world.Biomes[BiomeDesert])
+	world.applyEnvironmentalPressure(exposed, world.Biomes[BiomeDesert])
+	if 100-sheltered.Energy >= 100-exposed.Energy {
+		t.Errorf("Expected shade to spare a creature heat stress, lost %.3f sheltered and %.3f exposed",
+			100-sheltered.Energy, 100-exposed.Energy)
+	}
+
+	report := world.Microclimates.Report(world)
+	if !report.HeatWave || report.Refugia != 1 || report.Sheltering != 1 || len(report.Cells) != 1 {
+		t.Fatalf("Expected one refuge with one creature in a heat wave, got %+v", report)
+	}
+	cell := report.Cells[0]
+	if !cell.Refuge || cell.Temperature >= cell.ParentTemperature || cell.Humidity <= cell.ParentHumidity {
+		t.Errorf("Expected the refuge cooler and more humid than its biome, got %+v", cell)
+	}
+}
+
+func TestMicroclimatesAPI(t *testing.T) {
+	world := newEmptyTestWorld(100)
+	fillTestBiome(world, BiomeDesert)
+	world.AllPlants = append(world.AllPlants, NewPlant(sharedRand, 1, PlantTree, Position{X: 15, Y: 15}))
+	world.Microclimates.Update(world)
+	wi := NewWebInterface(world)
+	rec := httptest.NewRecorder()
+	wi.handleMicroclimates(rec, httptest.NewRequest(http.MethodGet, "/api/microclimates", nil))
+	var report MicroclimateReport
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &report) != nil || len(report.Cells) != 1 {
+		t.Fatalf("Expected the grove's microclimate, got %d %s", rec.Code, rec.Body.String())
+	}
+	if report.Cells[0].X != 1 || report.Cells[0].Canopy <= 0 {
+		t.Errorf("Expected canopy reported at the grove, got %+v", report.Cells[0])
+	}
+}
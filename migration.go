@@ -0,0 +1,621 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Migration reasons
+const (
+	MigrationSeasonal = "seasonal" // Driven off by a colder season
+	MigrationScarcity = "scarcity" // Driven off by hunger
+
+	migrationArrival          = 5.0  // How close a herd must come to a waypoint, and a migrant to its place, to stop
+	herdRadius                = 15.0 // How close migratory creatures of a species must be to move as one herd
+	routeReach                = 15.0 // How close a herd must be to the start of a route to follow it
+	routeTeachRange           = 5.0  // How close a creature must come to one who knows a route to learn it
+	migrationTrailSpacing     = 5.0  // Distance a herd travels between the points of its trail
+	scarcityEnergy            = 30.0 // Mean energy below which a herd goes looking for food
+	migrationPressureInterval = 25   // Ticks between checks for hungry herds
+	migrationTeachInterval    = 10   // Ticks between passing routes on
+	maxRoutesPerSpecies       = 4
+	maxMigrationHistory       = 30
+)
+
+// MigrationRoute is a way a species has learned to travel, from where a herd set off to where
+// it arrived. Creatures that travel it know it and teach it to their kin nearby; a route no
+// living creature knows is forgotten.
+type MigrationRoute struct {
+	ID        int        `json:"id"`
+	Species   string     `json:"species"`
+	Reason    string     `json:"reason"`    // What drove the herd that found it
+	Waypoints []Position `json:"waypoints"` // From where it starts to where it ends
+	LearnedAt int        `json:"learned_at"`
+	Uses      int        `json:"uses"`    // Journeys made along it, the first included
+	Knowers   int        `json:"knowers"` // Living creatures who know it
+
+	knowers map[int]bool
+}
+
+// MigrationPath is the trail of a journey a herd finished or abandoned
+type MigrationPath struct {
+	Species   string     `json:"species"`
+	Reason    string     `json:"reason"`
+	Returning bool       `json:"returning"` // Whether it was the way home
+	Path      []Position `json:"path"`
+	Size      int        `json:"size"` // Creatures that set off
+	Started   int        `json:"started"`
+	Ended     int        `json:"ended"`
+}
+
+// migrationHerd is the migratory creatures of a species that set off together. The herd moves
+// at the pace of its slowest member and each member keeps its place in the herd.
+type migrationHerd struct {
+	species   string
+	reason    string
+	members   []*Entity
+	offsets   []Position // Each member's place relative to the herd
+	position  Position
+	waypoints []Position
+	next      int // Waypoint the herd is heading for
+	trail     []Position
+	route     *MigrationRoute // Route being followed; nil while finding a new way
+	size      int
+	returning bool
+	arrived   bool
+	learned   bool // Whether its members have learned the way there
+	started   int
+}
+
+// MigrationSystem moves herds of migratory creatures under seasonal or resource pressure. When
+// a season turns colder the herds head for the equator and when it turns warmer they come home;
+// a hungry herd sets off for the richest ground within reach and stays there. A herd with a
+// member who knows a route from where it stands follows it; otherwise it makes its own way and
+// learns the route on arrival.
+type MigrationSystem struct {
+	herds   []*migrationHerd
+	routes  map[string][]*MigrationRoute // Routes by species
+	history []MigrationPath
+	nextID  int
+
+	eventBus *CentralEventBus
+}
+
+// NewMigrationSystem creates a migration system with no routes learned
+func NewMigrationSystem(eventBus *CentralEventBus) *MigrationSystem {
+	return &MigrationSystem{routes: make(map[string][]*MigrationRoute), nextID: 1, eventBus: eventBus}
+}
+
+// Restart calls off every migration and forgets every route, for a world that starts over
+func (m *MigrationSystem) Restart() {
+	m.herds = nil
+	m.routes = make(map[string][]*MigrationRoute)
+	m.history = nil
+	m.nextID = 1
+}
+
+// SeasonTurned sends the herds toward the equator for a colder season, or home for a warmer one
+func (m *MigrationSystem) SeasonTurned(w *World, colder bool) {
+	if !colder {
+		for _, herd := range m.herds {
+			if herd.reason == MigrationSeasonal && !herd.returning {
+				m.turnBack(w, herd)
+			}
+		}
+		return
+	}
+
+	for _, herd := range m.gatherHerds(w) {
+		toEquator := w.nearestEquator(herd.position.Y) - herd.position.Y
+		if math.Abs(toEquator) <= migrationArrival {
+			continue
+		}
+		reach := math.Inf(1)
+		for _, member := range herd.members {
+			reach = math.Min(reach, NewMigrationBehavior(member).MigrationRange)
+		}
+		target := herd.position
+		target.Y += math.Copysign(math.Min(math.Abs(toEquator), reach), toEquator)
+		m.setOff(w, herd, MigrationSeasonal, target)
+	}
+}
+
+// gatherHerds groups the migratory creatures not already away into herds of the same species
+// standing close together
+func (m *MigrationSystem) gatherHerds(w *World) []*migrationHerd {
+	away := make(map[*Entity]bool)
+	for _, herd := range m.herds {
+		for _, member := range herd.members {
+			away[member] = true
+		}
+	}
+
+	var herds []*migrationHerd
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive || away[entity] || !NewMigrationBehavior(entity).IsMigratory {
+			continue
+		}
+		var joined *migrationHerd
+		for _, herd := range herds {
+			if herd.species == entity.Species && w.Distance(herd.members[0].Position, entity.Position) <= herdRadius {
+				joined = herd
+				break
+			}
+		}
+		if joined == nil {
+			joined = &migrationHerd{species: entity.Species}
+			herds = append(herds, joined)
+		}
+		joined.members = append(joined.members, entity)
+	}
+
+	for _, herd := range herds {
+		for _, member := range herd.members {
+			herd.position.X += member.Position.X / float64(len(herd.members))
+			herd.position.Y += member.Position.Y / float64(len(herd.members))
+		}
+		for _, member := range herd.members {
+			herd.offsets = append(herd.offsets, Position{X: member.Position.X - herd.position.X, Y: member.Position.Y - herd.position.Y})
+		}
+		herd.size = len(herd.members)
+	}
+	return herds
+}
+
+// setOff starts a herd on its way to a destination, along a route one of its members knows
+// when there is one
+func (m *MigrationSystem) setOff(w *World, herd *migrationHerd, reason string, destination Position) {
+	herd.reason, herd.started = reason, w.Tick
+	herd.trail = []Position{herd.position}
+	herd.waypoints = []Position{destination}
+	if route := m.knownRoute(w, herd); route != nil {
+		herd.route = route
+		herd.waypoints = append([]Position{}, route.Waypoints[1:]...)
+		route.Uses++
+	}
+	m.herds = append(m.herds, herd)
+
+	if m.eventBus != nil {
+		way := "making its own way"
+		if herd.route != nil {
+			way = fmt.Sprintf("along route %d", herd.route.ID)
+		}
+		m.eventBus.EmitSystemEvent(w.Tick, "migration_started", "migration", "migration_system",
+			fmt.Sprintf("A herd of %d %s sets off on a %s migration, %s", herd.size, herd.species, reason, way), &herd.position,
+			map[string]interface{}{"species": herd.species, "reason": reason, "size": herd.size, "known_route": herd.route != nil})
+	}
+}
+
+// knownRoute returns the most travelled route of the herd's reason starting near it that one of
+// its members knows
+func (m *MigrationSystem) knownRoute(w *World, herd *migrationHerd) *MigrationRoute {
+	var best *MigrationRoute
+	for _, route := range m.routes[herd.species] {
+		if route.Reason != herd.reason || w.Distance(route.Waypoints[0], herd.position) > routeReach ||
+			(best != nil && route.Uses <= best.Uses) {
+			continue
+		}
+		for _, member := range herd.members {
+			if member.IsAlive && route.knowers[member.ID] {
+				best = route
+				break
+			}
+		}
+	}
+	return best
+}
+
+// turnBack sends a herd home the way it came
+func (m *MigrationSystem) turnBack(w *World, herd *migrationHerd) {
+	m.record(w, herd)
+	path := append([]Position{}, herd.trail...)
+	if last := path[len(path)-1]; w.Distance(last, herd.position) > 0 {
+		path = append(path, herd.position)
+	}
+	herd.waypoints = herd.waypoints[:0]
+	for i := len(path) - 2; i >= 0; i-- {
+		herd.waypoints = append(herd.waypoints, path[i])
+	}
+	herd.next, herd.returning, herd.arrived = 0, true, false
+	herd.trail = []Position{herd.position}
+	herd.started = w.Tick
+	if len(herd.waypoints) == 0 {
+		herd.waypoints = []Position{herd.position}
+	}
+}
+
+// Update sends hungry herds off, moves the herds along and passes the routes on
+func (m *MigrationSystem) Update(w *World) {
+	if w.Tick%migrationPressureInterval == 0 {
+		m.relieveHunger(w)
+	}
+
+	kept := m.herds[:0]
+	for _, herd := range m.herds {
+		if m.move(w, herd) {
+			kept = append(kept, herd)
+		}
+	}
+	for i := len(kept); i < len(m.herds); i++ {
+		m.herds[i] = nil
+	}
+	m.herds = kept
+
+	if w.Tick%migrationTeachInterval == 0 {
+		m.teach(w)
+	}
+}
+
+// relieveHunger sends each herd whose members are starving toward the cell within reach with
+// the most plants, when it has more than where the herd stands and is not close by
+func (m *MigrationSystem) relieveHunger(w *World) {
+	for _, herd := range m.gatherHerds(w) {
+		energy, reach := 0.0, math.Inf(1)
+		for _, member := range herd.members {
+			energy += member.Energy / float64(herd.size)
+			reach = math.Min(reach, NewMigrationBehavior(member).MigrationRange)
+		}
+		if energy >= scarcityEnergy {
+			continue
+		}
+
+		gridX, gridY := w.gridCell(herd.position)
+		richest, best := herd.position, livingPlants(&w.Grid[gridY][gridX])
+		for y := range w.Grid {
+			for x := range w.Grid[y] {
+				center := w.CellCenter(x, y)
+				if plants := livingPlants(&w.Grid[y][x]); plants > best && w.Distance(center, herd.position) <= reach {
+					richest, best = center, plants
+				}
+			}
+		}
+		if w.Distance(richest, herd.position) > migrationArrival {
+			m.setOff(w, herd, MigrationScarcity, richest)
+		}
+	}
+}
+
+// livingPlants counts the living plants in a cell
+func livingPlants(cell *GridCell) int {
+	count := 0
+	for _, plant := range cell.Plants {
+		if plant.IsAlive {
+			count++
+		}
+	}
+	return count
+}
+
+// move takes a herd a step along its way and its members with it, reporting whether the
+// migration goes on
+func (m *MigrationSystem) move(w *World, herd *migrationHerd) bool {
+	living := 0
+	pace := math.Inf(1)
+	for _, member := range herd.members {
+		if member.IsAlive {
+			living++
+			pace = math.Min(pace, migrantSpeed(member))
+		}
+	}
+	if living == 0 {
+		m.record(w, herd)
+		return false
+	}
+
+	for !herd.arrived && pace > 0 {
+		waypoint := herd.waypoints[herd.next]
+		remaining := w.Distance(herd.position, waypoint)
+		step := math.Min(pace, remaining)
+		if remaining > 0 {
+			dx, dy := w.Geometry().Direction(herd.position, waypoint)
+			herd.position = w.Geometry().Wrap(Position{X: herd.position.X + dx*step/remaining, Y: herd.position.Y + dy*step/remaining})
+		}
+		pace -= step
+		if step == remaining {
+			herd.next++
+			herd.arrived = herd.next == len(herd.waypoints)
+		}
+	}
+	if last := herd.trail[len(herd.trail)-1]; herd.arrived || w.Distance(last, herd.position) >= migrationTrailSpacing {
+		if w.Distance(last, herd.position) > 0 {
+			herd.trail = append(herd.trail, herd.position)
+		}
+	}
+
+	settled := true
+	for i, member := range herd.members {
+		if !member.IsAlive {
+			continue
+		}
+		place := Position{X: herd.position.X + herd.offsets[i].X, Y: herd.position.Y + herd.offsets[i].Y}
+		dx, dy := w.Geometry().Direction(member.Position, place)
+		gap := math.Hypot(dx, dy)
+		if gap <= migrationArrival && (herd.arrived || gap == 0) {
+			continue
+		}
+		if gap > migrationArrival {
+			settled = false
+		}
+		speed := math.Min(migrantSpeed(member), gap)
+		directionX, directionY := w.misdirectMigrant(member, dx/gap, dy/gap)
+		member.Position.X += directionX * speed
+		member.Position.Y += directionY * speed
+		member.Energy -= speed * 0.02
+	}
+	if !herd.arrived || !settled {
+		return true
+	}
+
+	// The way there is learned on arrival; a herd that went for the season waits to go home
+	if !herd.returning && !herd.learned {
+		m.learn(w, herd)
+		herd.learned = true
+	}
+	if herd.reason == MigrationSeasonal && !herd.returning {
+		return true
+	}
+	m.record(w, herd)
+	return false
+}
+
+// migrantSpeed is how far a creature travels in a tick of migration
+func migrantSpeed(entity *Entity) float64 {
+	speed := entity.GetTrait("speed") * 0.5
+	if speed <= 0 {
+		speed = 1.0
+	}
+	return speed
+}
+
+// learn teaches a herd that arrived the route it took, a new one if it made its own way
+func (m *MigrationSystem) learn(w *World, herd *migrationHerd) {
+	route := herd.route
+	if route == nil {
+		if len(herd.trail) < 2 {
+			return
+		}
+		route = &MigrationRoute{
+			ID:        m.nextID,
+			Species:   herd.species,
+			Reason:    herd.reason,
+			Waypoints: append([]Position{}, herd.trail...),
+			LearnedAt: w.Tick,
+			Uses:      1,
+			knowers:   make(map[int]bool),
+		}
+		m.nextID++
+		m.keep(route)
+		herd.route = route
+		if m.eventBus != nil {
+			end := route.Waypoints[len(route.Waypoints)-1]
+			m.eventBus.EmitSystemEvent(w.Tick, "migration_route_learned", "migration", "migration_system",
+				fmt.Sprintf("The %s learned a %s migration route to (%.0f, %.0f)", herd.species, herd.reason, end.X, end.Y), &end,
+				map[string]interface{}{"species": herd.species, "reason": herd.reason, "route": route.ID})
+		}
+	}
+	for _, member := range herd.members {
+		if member.IsAlive {
+			route.knowers[member.ID] = true
+		}
+	}
+	route.Knowers = len(route.knowers)
+}
+
+// keep adds a route to its species', dropping the least travelled beyond the limit
+func (m *MigrationSystem) keep(route *MigrationRoute) {
+	routes := append(m.routes[route.Species], route)
+	if len(routes) > maxRoutesPerSpecies {
+		sort.SliceStable(routes, func(i, j int) bool { return routes[i].Uses > routes[j].Uses })
+		routes = routes[:maxRoutesPerSpecies]
+	}
+	m.routes[route.Species] = routes
+}
+
+// record keeps the trail of a journey for the history
+func (m *MigrationSystem) record(w *World, herd *migrationHerd) {
+	if len(herd.trail) < 2 {
+		return
+	}
+	m.history = append(m.history, MigrationPath{
+		Species:   herd.species,
+		Reason:    herd.reason,
+		Returning: herd.returning,
+		Path:      append([]Position{}, herd.trail...),
+		Size:      herd.size,
+		Started:   herd.started,
+		Ended:     w.Tick,
+	})
+	if len(m.history) > maxMigrationHistory {
+		m.history = m.history[len(m.history)-maxMigrationHistory:]
+	}
+}
+
+// teach passes each route from the creatures who know it to their kin close by, and forgets
+// the routes no living creature knows
+func (m *MigrationSystem) teach(w *World) {
+	byID := make(map[int]*Entity, len(w.AllEntities))
+	kin := make(map[string][]*Entity)
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive {
+			byID[entity.ID] = entity
+			kin[entity.Species] = append(kin[entity.Species], entity)
+		}
+	}
+
+	for species, routes := range m.routes {
+		kept := routes[:0]
+		for _, route := range routes {
+			var teachers []*Entity
+			for id := range route.knowers {
+				if teacher, alive := byID[id]; alive {
+					teachers = append(teachers, teacher)
+				} else {
+					delete(route.knowers, id)
+				}
+			}
+			for _, student := range kin[species] {
+				if route.knowers[student.ID] {
+					continue
+				}
+				for _, teacher := range teachers {
+					if w.Distance(teacher.Position, student.Position) <= routeTeachRange {
+						route.knowers[student.ID] = true
+						break
+					}
+				}
+			}
+			route.Knowers = len(route.knowers)
+			if route.Knowers > 0 {
+				kept = append(kept, route)
+			}
+		}
+		if len(kept) == 0 {
+			delete(m.routes, species)
+		} else {
+			m.routes[species] = kept
+		}
+	}
+}
+
+// Migrants counts the creatures away on a migration
+func (m *MigrationSystem) Migrants() int {
+	count := 0
+	for _, herd := range m.herds {
+		for _, member := range herd.members {
+			if member.IsAlive {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Routes returns a species' routes, most travelled first
+func (m *MigrationSystem) Routes(species string) []MigrationRoute {
+	var routes []MigrationRoute
+	for _, route := range m.routes[species] {
+		routes = append(routes, *route)
+	}
+	sort.SliceStable(routes, func(i, j int) bool { return routes[i].Uses > routes[j].Uses })
+	return routes
+}
+
+// HerdStatus reports a herd on the move
+type HerdStatus struct {
+	Species   string     `json:"species"`
+	Reason    string     `json:"reason"`
+	Position  Position   `json:"position"`
+	Members   []Position `json:"members"`   // Where each living member stands
+	Waypoints []Position `json:"waypoints"` // Where the herd is still heading
+	Trail     []Position `json:"trail"`     // Where it has been since it set off
+	Route     int        `json:"route"`     // Route it follows, 0 while it makes its own way
+	Returning bool       `json:"returning"`
+	Arrived   bool       `json:"arrived"`
+	Started   int        `json:"started"`
+}
+
+// MigrationStatus reports the herds on the move, the routes each species knows and the
+// journeys made
+type MigrationStatus struct {
+	Width    float64          `json:"width"`
+	Height   float64          `json:"height"`
+	Herds    []HerdStatus     `json:"herds"`
+	Routes   []MigrationRoute `json:"routes"`
+	History  []MigrationPath  `json:"history"` // Oldest first
+	Migrants int              `json:"migrants"`
+}
+
+// Status reports the migrations of a world
+func (m *MigrationSystem) Status(w *World) MigrationStatus {
+	status := MigrationStatus{
+		Width:    w.Config.Width,
+		Height:   w.Config.Height,
+		Herds:    []HerdStatus{},
+		Routes:   []MigrationRoute{},
+		History:  append([]MigrationPath{}, m.history...),
+		Migrants: m.Migrants(),
+	}
+	for _, herd := range m.herds {
+		report := HerdStatus{
+			Species:   herd.species,
+			Reason:    herd.reason,
+			Position:  herd.position,
+			Waypoints: append([]Position{}, herd.waypoints[herd.next:]...),
+			Trail:     append([]Position{}, herd.trail...),
+			Returning: herd.returning,
+			Arrived:   herd.arrived,
+			Started:   herd.started,
+		}
+		if herd.route != nil {
+			report.Route = herd.route.ID
+		}
+		for _, member := range herd.members {
+			if member.IsAlive {
+				report.Members = append(report.Members, member.Position)
+			}
+		}
+		status.Herds = append(status.Herds, report)
+	}
+	species := make([]string, 0, len(m.routes))
+	for name := range m.routes {
+		species = append(species, name)
+	}
+	sort.Strings(species)
+	for _, name := range species {
+		status.Routes = append(status.Routes, m.Routes(name)...)
+	}
+	return status
+}
+
+// MigrationState is the routes and history a save keeps
+type MigrationState struct {
+	Routes  []SavedMigrationRoute `json:"routes"`
+	History []MigrationPath       `json:"history,omitempty"`
+	NextID  int                   `json:"next_id"`
+}
+
+// SavedMigrationRoute is a route with the creatures who know it
+type SavedMigrationRoute struct {
+	MigrationRoute
+	KnowerIDs []int `json:"knower_ids"`
+}
+
+// State returns the routes and history for a save
+func (m *MigrationSystem) State() *MigrationState {
+	if len(m.routes) == 0 && len(m.history) == 0 {
+		return nil
+	}
+	state := &MigrationState{History: append([]MigrationPath{}, m.history...), NextID: m.nextID}
+	for _, routes := range m.routes {
+		for _, route := range routes {
+			saved := SavedMigrationRoute{MigrationRoute: *route}
+			for id := range route.knowers {
+				saved.KnowerIDs = append(saved.KnowerIDs, id)
+			}
+			sort.Ints(saved.KnowerIDs)
+			state.Routes = append(state.Routes, saved)
+		}
+	}
+	sort.Slice(state.Routes, func(i, j int) bool { return state.Routes[i].ID < state.Routes[j].ID })
+	return state
+}
+
+// Restore brings back the routes and history of a save, calling off every migration
+func (m *MigrationSystem) Restore(state *MigrationState) {
+	m.Restart()
+	if state == nil {
+		return
+	}
+	m.history = append(m.history, state.History...)
+	m.nextID = max(1, state.NextID)
+	for _, saved := range state.Routes {
+		route := saved.MigrationRoute
+		route.knowers = make(map[int]bool, len(saved.KnowerIDs))
+		for _, id := range saved.KnowerIDs {
+			route.knowers[id] = true
+		}
+		route.Knowers = len(route.knowers)
+		m.routes[route.Species] = append(m.routes[route.Species], &route)
+	}
+}
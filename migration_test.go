@@ -0,0 +1,183 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"testing"
+)
+
+// newMigrant adds a creature to the world, migratory when clever and hardy enough
+func newMigrant(world *World, species string, position Position, intelligence, speed float64) *Entity {
+	entity := NewEntity(world.Rand, world.NextID, []string{"intelligence", "endurance", "speed"}, species, position)
+	world.NextID++
+	entity.SetTrait("intelligence", intelligence)
+	entity.SetTrait("endurance", 0.9)
+	entity.SetTrait("speed", speed)
+	world.AllEntities = append(world.AllEntities, entity)
+	return entity
+}
+
+func TestHerdsLearnFollowAndForgetRoutes(t *testing.T) {
+	world := newSteppingTestWorld(101)
+	world.AllEntities = nil
+	herd := []*Entity{
+		newMigrant(world, "grazer", Position{X: 50, Y: 2}, 0.9, 4),
+		newMigrant(world, "grazer", Position{X: 54, Y: 4}, 0.9, 2),
+		newMigrant(world, "grazer", Position{X: 46, Y: 3}, 0.9, 4),
+	}
+	loner := newMigrant(world, "grazer", Position{X: 90, Y: 90}, 0.9, 4)
+	var learned []string
+	world.CentralEventBus.AddListener(func(event CentralEvent) {
+		if event.Type == "migration_route_learned" {
+			learned = append(learned, event.Metadata["reason"].(string))
+		}
+	})
+
+	turnSeason(world, Summer)
+	turnSeason(world, Autumn)
+	status := world.Migrations.Status(world)
+	if len(status.Herds) != 2 || len(status.Herds[0].Members) != 3 || status.Herds[0].Route != 0 {
+		t.Fatalf("Expected the three grazers close together to set off as one herd of their own making, got %+v", status.Herds)
+	}
+	for i := 0; i < 60; i++ {
+		turnSeason(world, Autumn)
+	}
+
+	// The herd kept its shape on the way, and moved at the pace of its slowest member
+	if gap := distanceBetween(herd[0].Position, herd[1].Position); math.Abs(gap-math.Hypot(4, 2)) > migrationArrival {
+		t.Errorf("Expected the herd to keep together, got members %.1f apart", gap)
+	}
+	routes := world.Migrations.Routes("grazer")
+	if len(routes) != 2 || len(learned) != 2 || learned[0] != MigrationSeasonal {
+		t.Fatalf("Expected each herd to learn its way south, got %+v and %v", routes, learned)
+	}
+	route := routes[0]
+	if route.Waypoints[0].Y > 5 || route.Knowers != 3 {
+		route = routes[1]
+	}
+	if start, end := route.Waypoints[0], route.Waypoints[len(route.Waypoints)-1]; start.Y > 5 || end.Y < 40 || route.Knowers != 3 {
+		t.Errorf("Expected a route from the north toward the equator known to the herd, got %+v", route)
+	}
+
+	// A newcomer beside the herd learns the route from it; one far off does not
+	pupil := newMigrant(world, "grazer", herd[0].Position, 0.1, 1)
+	stranger := newMigrant(world, "grazer", Position{X: 5, Y: 95}, 0.1, 1)
+	world.Tick = 10*migrationTeachInterval - 1
+	turnSeason(world, Autumn)
+	for _, known := range world.Migrations.Routes("grazer") {
+		if known.ID == route.ID && known.Knowers != 4 {
+			t.Errorf("Expected only the newcomer beside the herd taught, got %d knowers", known.Knowers)
+		}
+	}
+
+	// Spring is warmer than winter, so the herd comes home the way it went and the journeys go
+	// into the history
+	turnSeason(world, Winter)
+	for i := 0; i < 60; i++ {
+		turnSeason(world, Spring)
+	}
+	if migrants := world.Migrations.Migrants(); migrants != 0 || herd[0].Position.Y > 2+migrationArrival {
+		t.Fatalf("Expected every herd home, got %d away and %v", migrants, herd[0].Position)
+	}
+	if history := world.Migrations.Status(world).History; len(history) != 4 || !history[3].Returning {
+		t.Errorf("Expected both herds' journeys out and back recorded, got %+v", history)
+	}
+
+	// Next autumn the herd follows what it learned
+	turnSeason(world, Summer)
+	turnSeason(world, Autumn)
+	status = world.Migrations.Status(world)
+	followed := false
+	for _, moving := range status.Herds {
+		followed = followed || moving.Route == route.ID
+	}
+	if !followed {
+		t.Errorf("Expected the herd to follow route %d, got %+v", route.ID, status.Herds)
+	}
+
+	// A route nobody alive knows is forgotten
+	for _, entity := range append(herd, pupil, loner, stranger) {
+		entity.IsAlive = false
+	}
+	world.Tick = 20*migrationTeachInterval - 1
+	turnSeason(world, Autumn)
+	if routes := world.Migrations.Routes("grazer"); len(routes) != 0 {
+		t.Errorf("Expected the routes forgotten with the last who knew them, got %+v", routes)
+	}
+}
+
+func TestHungryHerdsGoWhereTheFoodIs(t *testing.T) {
+	world := newSteppingTestWorld(102)
+	world.AllEntities = nil
+	for y := range world.Grid {
+		for x := range world.Grid[y] {
+			world.Grid[y][x].Plants = nil
+		}
+	}
+	herd := []*Entity{
+		newMigrant(world, "grazer", Position{X: 20, Y: 20}, 0.9, 4),
+		newMigrant(world, "grazer", Position{X: 22, Y: 20}, 0.9, 4),
+	}
+	meadow := Position{X: 50, Y: 30}
+	gridX, gridY := world.gridCell(meadow)
+	for i := 0; i < 5; i++ {
+		world.Grid[gridY][gridX].Plants = append(world.Grid[gridY][gridX].Plants, NewPlant(sharedRand, i, PlantGrass, meadow))
+	}
+
+	// Well fed, they stay put
+	world.Tick = migrationPressureInterval
+	world.Migrations.Update(world)
+	if world.Migrations.Migrants() != 0 {
+		t.Fatal("Expected a well fed herd to stay")
+	}
+	for _, member := range herd {
+		member.Energy = scarcityEnergy / 2
+	}
+	world.Tick = 2 * migrationPressureInterval
+	world.Migrations.Update(world)
+	if status := world.Migrations.Status(world); len(status.Herds) != 1 || status.Herds[0].Reason != MigrationScarcity {
+		t.Fatalf("Expected the hungry herd to set off for food, got %+v", status.Herds)
+	}
+	for i := 0; i < 40; i++ {
+		world.Tick++
+		world.Migrations.Update(world)
+	}
+	if world.Migrations.Migrants() != 0 || distanceBetween(herd[0].Position, meadow) > 2*migrationArrival {
+		t.Fatalf("Expected the herd to settle by the plants, got %v", herd[0].Position)
+	}
+	routes := world.Migrations.Routes("grazer")
+	if len(routes) != 1 || routes[0].Reason != MigrationScarcity || routes[0].Knowers != 2 {
+		t.Fatalf("Expected the way to food learned, got %+v", routes)
+	}
+
+	// The routes and who knows them travel with the save
+	state, err := NewStateManager(world).CurrentState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	world.Reset()
+	if routes := world.Migrations.Routes("grazer"); len(routes) != 0 {
+		t.Errorf("Expected a reset to forget the routes, got %+v", routes)
+	}
+	if err := NewStateManager(world).restoreState(state); err != nil {
+		t.Fatal(err)
+	}
+	if restored := world.Migrations.Routes("grazer"); len(restored) != 1 || restored[0].Knowers != 2 || len(world.Migrations.Status(world).History) != 1 {
+		t.Errorf("Expected the saved route and journey back, got %+v", restored)
+	}
+}
+
+func TestMigrationAPI(t *testing.T) {
+	wi := NewWebInterface(newSteppingTestWorld(103))
+
+	var status MigrationStatus
+	if code := callControlAPI(t, wi.handleMigration, http.MethodGet, "/api/migration", "", &status); code != http.StatusOK {
+		t.Fatalf("Expected the migrations, got %d", code)
+	}
+	if status.Width != wi.world.Config.Width || status.Herds == nil || status.Routes == nil {
+		t.Errorf("Expected the map size and empty lists, got %+v", status)
+	}
+	if code := callControlAPI(t, wi.handleMigration, http.MethodPost, "/api/migration", "{}", nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected the migrations read only, got %d", code)
+	}
+}
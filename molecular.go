@@ -164,7 +164,7 @@ func NewMolecularNeeds(entity *Entity) *MolecularNeeds {
 }
 
 // NewMolecularMetabolism creates new molecular metabolism based on entity traits
-func NewMolecularMetabolism(entity *Entity) *MolecularMetabolism {
+func NewMolecularMetabolism(rng *rand.Rand, entity *Entity) *MolecularMetabolism {
 	metabolism := &MolecularMetabolism{
 		Efficiency:         make(map[MolecularType]float64),
 		StorageCapacity:    make(map[MolecularType]float64),
@@ -188,7 +188,7 @@ func NewMolecularMetabolism(entity *Entity) *MolecularMetabolism {
 
 	// Set efficiencies and storage for all molecule types
 	for molType := ProteinStructural; molType <= ToxinTannin; molType++ {
-		efficiency := baseEfficiency + rand.Float64()*0.2 - 0.1 // Some variation
+		efficiency := baseEfficiency + rng.Float64()*0.2 - 0.1 // Some variation
 
 		// Cooperative entities are better at processing social molecules
 		if molType == ProteinTransport || molType == VitaminWater {
@@ -196,7 +196,7 @@ func NewMolecularMetabolism(entity *Entity) *MolecularMetabolism {
 		}
 
 		metabolism.Efficiency[molType] = math.Min(efficiency, 1.0)
-		metabolism.StorageCapacity[molType] = (0.3 + rand.Float64()*0.4) * storageMultiplier
+		metabolism.StorageCapacity[molType] = (0.3 + rng.Float64()*0.4) * storageMultiplier
 		metabolism.CurrentStorage[molType] = 0.0
 	}
 
@@ -221,7 +221,8 @@ func (mp *MolecularProfile) updateDerivedMetrics() {
 	totalToxicity := 0.0
 	diversityCount := 0
 
-	for molType, component := range mp.Components {
+	for _, molType := range sortedKeys(mp.Components) {
+		component := mp.Components[molType]
 		weightedConcentration := component.Concentration * component.Quality * component.Freshness
 		totalMolecules += weightedConcentration
 		totalBiomass += weightedConcentration
@@ -246,7 +247,8 @@ func (mp *MolecularProfile) GetNutritionalValue(needs *MolecularNeeds) float64 {
 	totalValue := 0.0
 	totalWeight := 0.0
 
-	for molType, requirement := range needs.Requirements {
+	for _, molType := range sortedKeys(needs.Requirements) {
+		requirement := needs.Requirements[molType]
 		if component, exists := mp.Components[molType]; exists {
 			priority := needs.Priorities[molType]
 			componentValue := component.Concentration * component.Quality * component.Freshness
@@ -271,7 +273,8 @@ func (mp *MolecularProfile) ConsumeNutrients(needs *MolecularNeeds, metabolism *
 	energyGained = 0.0
 	toxinDamage = 0.0
 
-	for molType, component := range mp.Components {
+	for _, molType := range sortedKeys(mp.Components) {
+		component := mp.Components[molType]
 		if component.Concentration <= 0 {
 			continue
 		}
@@ -345,7 +348,8 @@ func getMolecularEnergyMultiplier(molType MolecularType) float64 {
 
 // UpdateDeficiencies updates molecular deficiencies based on time and consumption
 func (needs *MolecularNeeds) UpdateDeficiencies(timeStep float64) {
-	for molType, requirement := range needs.Requirements {
+	for _, molType := range sortedKeys(needs.Requirements) {
+		requirement := needs.Requirements[molType]
 		// Deficiencies increase over time as molecules are used up
 		metabolicRate := 0.05 * timeStep // Base metabolic consumption rate
 
@@ -370,7 +374,8 @@ func (needs *MolecularNeeds) GetOverallNutritionalStatus() float64 {
 	totalDeficiency := 0.0
 	totalRequirement := 0.0
 
-	for molType, requirement := range needs.Requirements {
+	for _, molType := range sortedKeys(needs.Requirements) {
+		requirement := needs.Requirements[molType]
 		deficiency := needs.Deficiencies[molType]
 		priority := needs.Priorities[molType]
 
@@ -446,7 +451,8 @@ func CreatePlantMolecularProfile(plant *Plant) *MolecularProfile {
 	// Modify based on plant traits
 	if plantTraits, ok := plant.Traits["nutrition_value"]; ok {
 		nutritionMultiplier := 1.0 + plantTraits.Value*0.5
-		for molType, component := range profile.Components {
+		for _, molType := range sortedKeys(profile.Components) {
+			component := profile.Components[molType]
 			if molType < ToxinAlkaloid { // Only boost non-toxins
 				component.Quality *= nutritionMultiplier
 				profile.Components[molType] = component
@@ -456,7 +462,8 @@ func CreatePlantMolecularProfile(plant *Plant) *MolecularProfile {
 
 	if plantTraits, ok := plant.Traits["toxicity"]; ok {
 		toxicityMultiplier := 1.0 + plantTraits.Value*0.8
-		for molType, component := range profile.Components {
+		for _, molType := range sortedKeys(profile.Components) {
+			component := profile.Components[molType]
 			if molType >= ToxinAlkaloid { // Only boost toxins
 				component.Concentration *= toxicityMultiplier
 				profile.Components[molType] = component
@@ -466,7 +473,8 @@ func CreatePlantMolecularProfile(plant *Plant) *MolecularProfile {
 
 	// Age affects freshness
 	ageFactor := math.Max(0.1, 1.0-float64(plant.Age)*0.01)
-	for molType, component := range profile.Components {
+	for _, molType := range sortedKeys(profile.Components) {
+		component := profile.Components[molType]
 		component.Freshness *= ageFactor
 		profile.Components[molType] = component
 	}
@@ -517,7 +525,8 @@ func CreateEntityMolecularProfile(entity *Entity) *MolecularProfile {
 	switch entity.Species {
 	case "herbivore", "aquatic_herbivore", "aerial_herbivore":
 		// Herbivores have less protein, more carbohydrates
-		for molType, component := range profile.Components {
+		for _, molType := range sortedKeys(profile.Components) {
+			component := profile.Components[molType]
 			if molType >= ProteinStructural && molType <= ProteinDefensive {
 				component.Concentration *= 0.8
 				profile.Components[molType] = component
@@ -527,7 +536,8 @@ func CreateEntityMolecularProfile(entity *Entity) *MolecularProfile {
 
 	case "carnivore", "predator":
 		// Carnivores have more protein, defensive compounds
-		for molType, component := range profile.Components {
+		for _, molType := range sortedKeys(profile.Components) {
+			component := profile.Components[molType]
 			if molType >= ProteinStructural && molType <= ProteinDefensive {
 				component.Concentration *= 1.3
 				component.Quality *= 1.2
@@ -558,7 +568,8 @@ func CreateEntityMolecularProfile(entity *Entity) *MolecularProfile {
 
 	if flyingAbility := entity.GetTrait("flying_ability"); flyingAbility > 0 {
 		// Flying creatures have lighter, more efficient proteins
-		for molType, component := range profile.Components {
+		for _, molType := range sortedKeys(profile.Components) {
+			component := profile.Components[molType]
 			if molType >= ProteinStructural && molType <= ProteinDefensive {
 				component.Quality *= 1.0 + flyingAbility*0.3
 				profile.Components[molType] = component
@@ -569,7 +580,8 @@ func CreateEntityMolecularProfile(entity *Entity) *MolecularProfile {
 
 	// Age affects freshness and some nutritional quality
 	ageFactor := math.Max(0.3, 1.0-float64(entity.Age)*0.005)
-	for molType, component := range profile.Components {
+	for _, molType := range sortedKeys(profile.Components) {
+		component := profile.Components[molType]
 		component.Freshness *= ageFactor
 		// Older entities may have more complex, tougher proteins
 		if molType == ProteinStructural {
@@ -593,7 +605,8 @@ func GetMolecularDesirability(foodProfile *MolecularProfile, entityNeeds *Molecu
 
 	// Penalty for toxicity
 	toxinPenalty := 0.0
-	for molType, component := range foodProfile.Components {
+	for _, molType := range sortedKeys(foodProfile.Components) {
+		component := foodProfile.Components[molType]
 		if molType >= ToxinAlkaloid {
 			tolerance := entityNeeds.Tolerances[molType]
 			if tolerance == 0 {
@@ -67,7 +67,7 @@ func TestMolecularMetabolism(t *testing.T) {
 	entity.SetTrait("size", 0.6)
 	entity.SetTrait("cooperation", 0.4)
 
-	metabolism := NewMolecularMetabolism(entity)
+	metabolism := NewMolecularMetabolism(sharedRand, entity)
 
 	// Test that efficiencies are set
 	if len(metabolism.Efficiency) == 0 {
@@ -225,7 +225,7 @@ func TestNutrientConsumption(t *testing.T) {
 	entity.SetTrait("size", 0.5)
 
 	needs := NewMolecularNeeds(entity)
-	metabolism := NewMolecularMetabolism(entity)
+	metabolism := NewMolecularMetabolism(sharedRand, entity)
 
 	// Test consumption
 	energyGained, toxinDamage := foodProfile.ConsumeNutrients(needs, metabolism, 0.5)
@@ -305,7 +305,7 @@ func TestMolecularFitnessIntegration(t *testing.T) {
 	entity1.SetTrait("strength", 0.5)
 	entity1.SetTrait("intelligence", 0.5)
 	entity1.MolecularNeeds = NewMolecularNeeds(entity1)
-	entity1.MolecularMetabolism = NewMolecularMetabolism(entity1)
+	entity1.MolecularMetabolism = NewMolecularMetabolism(sharedRand, entity1)
 
 	entity2 := &Entity{
 		ID:      2,
@@ -315,7 +315,7 @@ func TestMolecularFitnessIntegration(t *testing.T) {
 	entity2.SetTrait("strength", 0.5)
 	entity2.SetTrait("intelligence", 0.5)
 	entity2.MolecularNeeds = NewMolecularNeeds(entity2)
-	entity2.MolecularMetabolism = NewMolecularMetabolism(entity2)
+	entity2.MolecularMetabolism = NewMolecularMetabolism(sharedRand, entity2)
 
 	// Give entity2 better nutritional status
 	for molType := range entity2.MolecularNeeds.Deficiencies {
@@ -0,0 +1,374 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// GridPoint identifies a single cell in the world grid
+type GridPoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// CorridorCell describes a heavily travelled or structurally critical grid cell
+type CorridorCell struct {
+	X       int  `json:"x"`
+	Y       int  `json:"y"`
+	Traffic int  `json:"traffic"` // Number of recorded entity crossings into the cell
+	IsChoke bool `json:"is_choke"`
+}
+
+// MovementCorridorSystem analyzes realized movement tracks and habitat connectivity
+type MovementCorridorSystem struct {
+	Traffic           [][]int           `json:"traffic"` // Crossings per grid cell [y][x]
+	LastCells         map[int]GridPoint `json:"-"`       // Entity ID -> last observed grid cell
+	TotalCrossings    int               `json:"total_crossings"`
+	Corridors         []CorridorCell    `json:"corridors"`
+	ChokePoints       []CorridorCell    `json:"choke_points"`
+	HabitatPatches    int               `json:"habitat_patches"`
+	LargestPatch      int               `json:"largest_patch"`
+	HabitatCells      int               `json:"habitat_cells"`
+	ConnectivityIndex float64           `json:"connectivity_index"` // Probability two random habitat cells are connected
+	PreviousIndex     float64           `json:"previous_index"`
+	AnalysisInterval  int               `json:"analysis_interval"`
+	LastAnalysisTick  int               `json:"last_analysis_tick"`
+	eventBus          *CentralEventBus
+}
+
+// NewMovementCorridorSystem creates a corridor analysis system sized to the world grid
+func NewMovementCorridorSystem(gridWidth, gridHeight int, eventBus *CentralEventBus) *MovementCorridorSystem {
+	traffic := make([][]int, gridHeight)
+	for y := range traffic {
+		traffic[y] = make([]int, gridWidth)
+	}
+	return &MovementCorridorSystem{
+		Traffic:           traffic,
+		LastCells:         make(map[int]GridPoint),
+		Corridors:         make([]CorridorCell, 0),
+		ChokePoints:       make([]CorridorCell, 0),
+		ConnectivityIndex: 1.0,
+		PreviousIndex:     1.0,
+		AnalysisInterval:  50,
+		eventBus:          eventBus,
+	}
+}
+
+// Update records entity cell transitions and periodically re-runs the connectivity analysis
+func (mcs *MovementCorridorSystem) Update(world *World) {
+	mcs.recordMovement(world)
+
+	if world.Tick%mcs.AnalysisInterval == 0 {
+		mcs.Analyze(world)
+	}
+}
+
+// recordMovement counts each entity crossing into a new grid cell
+func (mcs *MovementCorridorSystem) recordMovement(world *World) {
+	seen := make(map[int]bool, len(world.AllEntities))
+	for _, entity := range world.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		seen[entity.ID] = true
+		gx, gy := world.worldToGridCoords(entity.Position.X, entity.Position.Y)
+		current := GridPoint{X: gx, Y: gy}
+
+		if last, exists := mcs.LastCells[entity.ID]; exists && last != current {
+			if gy < len(mcs.Traffic) && gx < len(mcs.Traffic[gy]) {
+				mcs.Traffic[gy][gx]++
+				mcs.TotalCrossings++
+			}
+		}
+		mcs.LastCells[entity.ID] = current
+	}
+
+	// Forget tracks of entities that are gone
+	for id := range mcs.LastCells {
+		if !seen[id] {
+			delete(mcs.LastCells, id)
+		}
+	}
+}
+
+// isHabitatCell returns whether a cell is usable habitat for terrestrial movement
+func (mcs *MovementCorridorSystem) isHabitatCell(world *World, blocked, roads map[GridPoint]bool, x, y int) bool {
+	point := GridPoint{X: x, Y: y}
+	if blocked[point] || roads[point] {
+		return false
+	}
+	if world.OperatorStructures != nil && world.OperatorStructures.CorridorCells[point] {
+		return true
+	}
+	return world.Grid[y][x].Biome != BiomeDeepWater
+}
+
+// collectModificationCells gathers cells fragmented by walls and roads
+func (mcs *MovementCorridorSystem) collectModificationCells(world *World) (map[GridPoint]bool, map[GridPoint]bool) {
+	blocked := make(map[GridPoint]bool)
+	roads := make(map[GridPoint]bool)
+	bridges := make(map[GridPoint]bool)
+
+	// Operator-drawn fences block movement while canals and corridors connect habitat
+	if world.OperatorStructures != nil {
+		for point := range world.OperatorStructures.BarrierCells {
+			blocked[point] = true
+		}
+		for point := range world.OperatorStructures.CorridorCells {
+			bridges[point] = true
+		}
+	}
+
+	if world.EnvironmentalModSystem != nil {
+		for _, mod := range world.EnvironmentalModSystem.Modifications {
+			if !mod.IsActive {
+				continue
+			}
+			gx, gy := world.worldToGridCoords(mod.Position.X, mod.Position.Y)
+			point := GridPoint{X: gx, Y: gy}
+			switch mod.Type {
+			case EnvModBarrier, EnvModDam:
+				blocked[point] = true
+			case EnvModPath:
+				roads[point] = true
+			case EnvModBridge:
+				bridges[point] = true
+			}
+		}
+	}
+
+	// Bridges restore connectivity across barriers
+	for point := range bridges {
+		delete(blocked, point)
+	}
+	return blocked, roads
+}
+
+// Analyze computes corridors, choke points, habitat patches, and the connectivity index
+func (mcs *MovementCorridorSystem) Analyze(world *World) {
+	height := len(world.Grid)
+	if height == 0 {
+		return
+	}
+	width := len(world.Grid[0])
+	blocked, roads := mcs.collectModificationCells(world)
+
+	habitat := make([][]bool, height)
+	mcs.HabitatCells = 0
+	for y := 0; y < height; y++ {
+		habitat[y] = make([]bool, width)
+		for x := 0; x < width; x++ {
+			habitat[y][x] = mcs.isHabitatCell(world, blocked, roads, x, y)
+			if habitat[y][x] {
+				mcs.HabitatCells++
+			}
+		}
+	}
+
+	patchSizes := mcs.findPatches(habitat, world.GridLayout())
+	mcs.HabitatPatches = len(patchSizes)
+	mcs.LargestPatch = 0
+	sumSquares := 0.0
+	for _, size := range patchSizes {
+		sumSquares += float64(size) * float64(size)
+		if size > mcs.LargestPatch {
+			mcs.LargestPatch = size
+		}
+	}
+
+	mcs.PreviousIndex = mcs.ConnectivityIndex
+	if mcs.HabitatCells > 0 {
+		total := float64(mcs.HabitatCells)
+		mcs.ConnectivityIndex = sumSquares / (total * total)
+	} else {
+		mcs.ConnectivityIndex = 0
+	}
+
+	mcs.Corridors = mcs.detectCorridors()
+	mcs.ChokePoints = mcs.detectChokePoints(habitat, world.GridLayout())
+	mcs.LastAnalysisTick = world.Tick
+
+	// Report significant fragmentation caused by terrain change or construction
+	if mcs.eventBus != nil && mcs.PreviousIndex-mcs.ConnectivityIndex > 0.1 {
+		mcs.eventBus.EmitSystemEvent(world.Tick, "habitat_fragmentation", "connectivity", "movement_corridors",
+			fmt.Sprintf("Habitat connectivity fell from %.2f to %.2f (%d patches)",
+				mcs.PreviousIndex, mcs.ConnectivityIndex, mcs.HabitatPatches),
+			nil, map[string]interface{}{
+				"previous_index": mcs.PreviousIndex,
+				"current_index":  mcs.ConnectivityIndex,
+				"patches":        mcs.HabitatPatches,
+			})
+	}
+}
+
+// findPatches returns the size of every habitat patch, joined cell to cell across the edges
+// the layout's cells share
+func (mcs *MovementCorridorSystem) findPatches(habitat [][]bool, layout GridLayout) []int {
+	height := len(habitat)
+	width := len(habitat[0])
+	visited := make([][]bool, height)
+	for y := range visited {
+		visited[y] = make([]bool, width)
+	}
+
+	sizes := make([]int, 0)
+	stack := make([]GridPoint, 0)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if !habitat[y][x] || visited[y][x] {
+				continue
+			}
+			size := 0
+			visited[y][x] = true
+			stack = append(stack[:0], GridPoint{X: x, Y: y})
+			for len(stack) > 0 {
+				p := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				size++
+				for _, n := range gridNeighbors(layout, p, width, height) {
+					if habitat[n.Y][n.X] && !visited[n.Y][n.X] {
+						visited[n.Y][n.X] = true
+						stack = append(stack, n)
+					}
+				}
+			}
+			sizes = append(sizes, size)
+		}
+	}
+	return sizes
+}
+
+// detectCorridors selects cells whose traffic is well above the mean of travelled cells
+func (mcs *MovementCorridorSystem) detectCorridors() []CorridorCell {
+	values := make([]float64, 0)
+	for _, row := range mcs.Traffic {
+		for _, count := range row {
+			if count > 0 {
+				values = append(values, float64(count))
+			}
+		}
+	}
+	if len(values) == 0 {
+		return make([]CorridorCell, 0)
+	}
+
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	threshold := mean + math.Sqrt(variance/float64(len(values)))
+
+	corridors := make([]CorridorCell, 0)
+	for y, row := range mcs.Traffic {
+		for x, count := range row {
+			if float64(count) > threshold {
+				corridors = append(corridors, CorridorCell{X: x, Y: y, Traffic: count})
+			}
+		}
+	}
+	sort.Slice(corridors, func(i, j int) bool { return corridors[i].Traffic > corridors[j].Traffic })
+	if len(corridors) > 20 {
+		corridors = corridors[:20]
+	}
+	return corridors
+}
+
+// detectChokePoints finds travelled habitat cells whose loss would split a patch (articulation points)
+func (mcs *MovementCorridorSystem) detectChokePoints(habitat [][]bool, layout GridLayout) []CorridorCell {
+	height := len(habitat)
+	width := len(habitat[0])
+	index := func(p GridPoint) int { return p.Y*width + p.X }
+
+	disc := make([]int, width*height)
+	low := make([]int, width*height)
+	parent := make([]int, width*height)
+	isArticulation := make([]bool, width*height)
+	for i := range parent {
+		parent[i] = -1
+	}
+
+	// Iterative Tarjan to avoid deep recursion on large grids
+	type frame struct {
+		point    GridPoint
+		next     int
+		children int
+	}
+	timer := 0
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			root := GridPoint{X: x, Y: y}
+			if !habitat[y][x] || disc[index(root)] != 0 {
+				continue
+			}
+			timer++
+			disc[index(root)], low[index(root)] = timer, timer
+			stack := []*frame{{point: root}}
+			for len(stack) > 0 {
+				top := stack[len(stack)-1]
+				neighbors := gridNeighbors(layout, top.point, width, height)
+				if top.next < len(neighbors) {
+					n := neighbors[top.next]
+					top.next++
+					if !habitat[n.Y][n.X] {
+						continue
+					}
+					ni, ti := index(n), index(top.point)
+					if disc[ni] == 0 {
+						parent[ni] = ti
+						top.children++
+						timer++
+						disc[ni], low[ni] = timer, timer
+						stack = append(stack, &frame{point: n})
+					} else if ni != parent[ti] {
+						low[ti] = int(math.Min(float64(low[ti]), float64(disc[ni])))
+					}
+					continue
+				}
+
+				stack = stack[:len(stack)-1]
+				ti := index(top.point)
+				if len(stack) == 0 {
+					if top.children > 1 {
+						isArticulation[ti] = true
+					}
+					continue
+				}
+				pi := index(stack[len(stack)-1].point)
+				low[pi] = int(math.Min(float64(low[pi]), float64(low[ti])))
+				if len(stack) > 1 && low[ti] >= disc[pi] {
+					isArticulation[pi] = true
+				}
+			}
+		}
+	}
+
+	chokes := make([]CorridorCell, 0)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if isArticulation[y*width+x] && y < len(mcs.Traffic) && x < len(mcs.Traffic[y]) && mcs.Traffic[y][x] > 0 {
+				chokes = append(chokes, CorridorCell{X: x, Y: y, Traffic: mcs.Traffic[y][x], IsChoke: true})
+			}
+		}
+	}
+	sort.Slice(chokes, func(i, j int) bool { return chokes[i].Traffic > chokes[j].Traffic })
+	return chokes
+}
+
+// GetStats returns corridor and fragmentation statistics
+func (mcs *MovementCorridorSystem) GetStats() map[string]interface{} {
+	return map[string]interface{}{
+		"total_crossings":    mcs.TotalCrossings,
+		"corridors":          len(mcs.Corridors),
+		"choke_points":       len(mcs.ChokePoints),
+		"habitat_patches":    mcs.HabitatPatches,
+		"largest_patch":      mcs.LargestPatch,
+		"habitat_cells":      mcs.HabitatCells,
+		"connectivity_index": mcs.ConnectivityIndex,
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestConnectivityIndexFullyConnected(t *testing.T) {
+	world := newTestWorld(20, 10)
+	fillTestBiome(world, BiomePlains)
+	mcs := world.MovementCorridorSystem
+
+	mcs.Analyze(world)
+
+	if mcs.HabitatPatches != 1 {
+		t.Errorf("Expected a single habitat patch, got %d", mcs.HabitatPatches)
+	}
+	if mcs.ConnectivityIndex != 1.0 {
+		t.Errorf("Expected connectivity index 1.0, got %.3f", mcs.ConnectivityIndex)
+	}
+}
+
+func TestWallFragmentsHabitat(t *testing.T) {
+	world := newTestWorld(20, 10)
+	fillTestBiome(world, BiomePlains)
+	mcs := world.MovementCorridorSystem
+
+	// Build a wall of barriers down the middle column of the grid
+	for y := 0; y < world.Config.GridHeight; y++ {
+		id := world.EnvironmentalModSystem.NextModID
+		world.EnvironmentalModSystem.Modifications[id] = &EnvironmentalModification{
+			ID:       id,
+			Type:     EnvModBarrier,
+			Position: Position{X: 10.5, Y: float64(y)*2 + 1},
+			IsActive: true,
+		}
+		world.EnvironmentalModSystem.NextModID++
+	}
+
+	mcs.Analyze(world)
+
+	if mcs.HabitatPatches != 2 {
+		t.Fatalf("Expected wall to split habitat into 2 patches, got %d", mcs.HabitatPatches)
+	}
+	if mcs.ConnectivityIndex >= 0.6 {
+		t.Errorf("Expected connectivity index near 0.5 after fragmentation, got %.3f", mcs.ConnectivityIndex)
+	}
+}
+
+func TestChokePointDetection(t *testing.T) {
+	world := newTestWorld(20, 10)
+	fillTestBiome(world, BiomePlains)
+	mcs := world.MovementCorridorSystem
+
+	// Deep water everywhere except a single column connecting two land rows
+	for y := range world.Grid {
+		for x := range world.Grid[y] {
+			if y != 0 && y != 9 && x != 5 {
+				world.Grid[y][x].Biome = BiomeDeepWater
+			}
+		}
+	}
+	mcs.Traffic[5][5] = 10
+
+	mcs.Analyze(world)
+
+	if mcs.HabitatPatches != 1 {
+		t.Fatalf("Expected one connected patch, got %d", mcs.HabitatPatches)
+	}
+	found := false
+	for _, choke := range mcs.ChokePoints {
+		if choke.X == 5 && choke.Y == 5 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected travelled bridge cell (5,5) to be a choke point, got %v", mcs.ChokePoints)
+	}
+}
+
+func TestMovementRecording(t *testing.T) {
+	world := newTestWorld(20, 10)
+	fillTestBiome(world, BiomePlains)
+	mcs := world.MovementCorridorSystem
+
+	entity := NewEntity(sharedRand, 1, []string{}, "herbivore", Position{X: 1, Y: 1})
+	world.AllEntities = append(world.AllEntities, entity)
+
+	mcs.recordMovement(world)
+	entity.Position = Position{X: 5, Y: 1}
+	mcs.recordMovement(world)
+
+	if mcs.TotalCrossings != 1 {
+		t.Errorf("Expected 1 recorded crossing, got %d", mcs.TotalCrossings)
+	}
+	gx, gy := world.worldToGridCoords(entity.Position.X, entity.Position.Y)
+	if mcs.Traffic[gy][gx] != 1 {
+		t.Errorf("Expected traffic 1 in destination cell, got %d", mcs.Traffic[gy][gx])
+	}
+}
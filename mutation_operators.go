@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Mutation operator names
+const (
+	MutationPoint       = "point"
+	MutationDuplication = "duplication"
+	MutationDeletion    = "deletion"
+	MutationRegulatory  = "regulatory"
+)
+
+// maxMutationRecords bounds the log of recent mutations
+const maxMutationRecords = 500
+
+// MutationOperator is one kind of genetic change. Rates are relative: an operator with
+// twice the rate of another produces twice as many of the mutations that occur.
+// Only point mutation is on by default, so seeded runs (and their run certificates)
+// replay exactly as they did before the other operators existed.
+type MutationOperator struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Rate        float64 `json:"rate"`
+	apply       func(rng *rand.Rand, e *Entity, trait string, value, strength float64) float64
+}
+
+// mutationOperatorLibrary lists the operators with their default rates
+func mutationOperatorLibrary() []*MutationOperator {
+	return []*MutationOperator{
+		{
+			Name:        MutationPoint,
+			Description: "A small change in either direction, biased by feeding and environmental pressure",
+			Rate:        1.0,
+			apply: func(rng *rand.Rand, e *Entity, trait string, value, strength float64) float64 {
+				return value + e.biasedMutation(trait, rng.NormFloat64()*strength)
+			},
+		},
+		{
+			Name:        MutationDuplication,
+			Description: "An extra copy of the gene strengthens the trait in its current direction",
+			Rate:        0,
+			apply: func(rng *rand.Rand, e *Entity, trait string, value, strength float64) float64 {
+				return value * (1 + math.Min(1, 2*strength)*rng.Float64())
+			},
+		},
+		{
+			Name:        MutationDeletion,
+			Description: "Part of the gene is lost, weakening the trait toward zero",
+			Rate:        0,
+			apply: func(rng *rand.Rand, e *Entity, trait string, value, strength float64) float64 {
+				return value * (1 - math.Min(1, 2*strength)*rng.Float64())
+			},
+		},
+		{
+			Name:        MutationRegulatory,
+			Description: "A change in how strongly the gene is expressed scales the trait up or down",
+			Rate:        0,
+			apply: func(rng *rand.Rand, e *Entity, trait string, value, strength float64) float64 {
+				return value * (1 + rng.NormFloat64()*2*strength)
+			},
+		},
+	}
+}
+
+// defaultMutationOperators serve creatures mutated outside a world
+var defaultMutationOperators = mutationOperatorLibrary()
+
+// MutationRecord attributes one trait change to the operator that made it
+type MutationRecord struct {
+	Tick     int     `json:"tick"`
+	EntityID int     `json:"entity_id"`
+	Species  string  `json:"species"`
+	Trait    string  `json:"trait"`
+	Operator string  `json:"operator"`
+	OldValue float64 `json:"old_value"`
+	NewValue float64 `json:"new_value"`
+}
+
+// mutationTally totals the changes one operator has made
+type mutationTally struct {
+	count        int
+	traitChanges map[string]float64
+}
+
+// MutationSystem holds a world's mutation operators and logs which operator produced
+// each change, so adaptation can be attributed to kinds of genetic change
+type MutationSystem struct {
+	Operators []*MutationOperator
+	Recent    []MutationRecord // Latest mutations, oldest first
+	tallies   map[string]*mutationTally
+	tick      int // Tick the current mutations happen in
+}
+
+// NewMutationSystem creates a mutation system with the default operators and rates
+func NewMutationSystem() *MutationSystem {
+	return &MutationSystem{
+		Operators: mutationOperatorLibrary(),
+		Recent:    make([]MutationRecord, 0),
+		tallies:   make(map[string]*mutationTally),
+	}
+}
+
+// SetRate changes an operator's relative rate; zero switches the operator off
+func (ms *MutationSystem) SetRate(name string, rate float64) error {
+	if rate < 0 || isInvalidNumber(rate) {
+		return fmt.Errorf("mutation rate must be a non-negative number")
+	}
+	for _, operator := range ms.Operators {
+		if operator.Name == name {
+			operator.Rate = rate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown mutation operator %q", name)
+}
+
+// choose picks the operator for a mutation in proportion to the rates from a uniform
+// roll in [0, 1), or nil when every operator is switched off. A nil system uses the
+// default operators.
+func (ms *MutationSystem) choose(roll float64) *MutationOperator {
+	operators := defaultMutationOperators
+	if ms != nil {
+		operators = ms.Operators
+	}
+
+	total := 0.0
+	for _, operator := range operators {
+		total += operator.Rate
+	}
+	if total <= 0 {
+		return nil
+	}
+	pick := roll * total
+	for _, operator := range operators {
+		if pick < operator.Rate {
+			return operator
+		}
+		pick -= operator.Rate
+	}
+	return operators[len(operators)-1]
+}
+
+// record logs a mutation; a nil system keeps no log
+func (ms *MutationSystem) record(e *Entity, trait, operator string, oldValue, newValue float64) {
+	if ms == nil {
+		return
+	}
+	tally := ms.tallies[operator]
+	if tally == nil {
+		tally = &mutationTally{traitChanges: make(map[string]float64)}
+		ms.tallies[operator] = tally
+	}
+	tally.count++
+	tally.traitChanges[trait] += newValue - oldValue
+
+	ms.Recent = append(ms.Recent, MutationRecord{
+		Tick: ms.tick, EntityID: e.ID, Species: e.Species, Trait: trait,
+		Operator: operator, OldValue: oldValue, NewValue: newValue,
+	})
+	if len(ms.Recent) > maxMutationRecords {
+		ms.Recent = ms.Recent[len(ms.Recent)-maxMutationRecords:]
+	}
+}
+
+// fork returns an empty log sharing the system's operators, for a worker to record into
+// and merge back afterwards; a nil system forks nil
+func (ms *MutationSystem) fork() *MutationSystem {
+	if ms == nil {
+		return nil
+	}
+	return &MutationSystem{Operators: ms.Operators, tallies: make(map[string]*mutationTally), tick: ms.tick}
+}
+
+// merge adds the mutations a fork logged, after those already logged
+func (ms *MutationSystem) merge(fork *MutationSystem) {
+	if ms == nil || fork == nil {
+		return
+	}
+	for operator, forked := range fork.tallies {
+		tally := ms.tallies[operator]
+		if tally == nil {
+			tally = &mutationTally{traitChanges: make(map[string]float64)}
+			ms.tallies[operator] = tally
+		}
+		tally.count += forked.count
+		for trait, change := range forked.traitChanges {
+			tally.traitChanges[trait] += change
+		}
+	}
+	ms.Recent = append(ms.Recent, fork.Recent...)
+	if len(ms.Recent) > maxMutationRecords {
+		ms.Recent = ms.Recent[len(ms.Recent)-maxMutationRecords:]
+	}
+}
+
+// Clear forgets logged mutations, keeping the rates
+func (ms *MutationSystem) Clear() {
+	ms.Recent = make([]MutationRecord, 0)
+	ms.tallies = make(map[string]*mutationTally)
+}
+
+// MutationOperatorStatus reports an operator's rate and the changes it has made
+type MutationOperatorStatus struct {
+	Name         string             `json:"name"`
+	Description  string             `json:"description"`
+	Rate         float64            `json:"rate"`
+	Mutations    int                `json:"mutations"`
+	Share        float64            `json:"share"`         // Fraction of all logged mutations
+	TraitChanges map[string]float64 `json:"trait_changes"` // Net change the operator made to each trait
+}
+
+// MutationReport summarises the mutation operators for the API and the evolution view
+type MutationReport struct {
+	Operators []MutationOperatorStatus `json:"operators"`
+	Total     int                      `json:"total"`
+	Recent    []MutationRecord         `json:"recent"`
+}
+
+// Report summarises each operator's rate and logged changes, with the latest mutations
+func (ms *MutationSystem) Report(recent int) MutationReport {
+	report := MutationReport{Operators: make([]MutationOperatorStatus, 0, len(ms.Operators))}
+	for _, tally := range ms.tallies {
+		report.Total += tally.count
+	}
+	for _, operator := range ms.Operators {
+		status := MutationOperatorStatus{
+			Name:         operator.Name,
+			Description:  operator.Description,
+			Rate:         operator.Rate,
+			TraitChanges: make(map[string]float64),
+		}
+		if tally := ms.tallies[operator.Name]; tally != nil {
+			status.Mutations = tally.count
+			for trait, change := range tally.traitChanges {
+				status.TraitChanges[trait] = change
+			}
+		}
+		if report.Total > 0 {
+			status.Share = float64(status.Mutations) / float64(report.Total)
+		}
+		report.Operators = append(report.Operators, status)
+	}
+
+	start := maxInt(0, len(ms.Recent)-recent)
+	report.Recent = append([]MutationRecord(nil), ms.Recent[start:]...)
+	return report
+}
+
+// Attribution splits the net change each operator made to a trait into shares of the
+// total movement, so an adaptation can be credited to the kinds of change behind it
+func (ms *MutationSystem) Attribution(trait string) map[string]float64 {
+	shares := make(map[string]float64)
+	total := 0.0
+	for _, tally := range ms.tallies {
+		total += math.Abs(tally.traitChanges[trait])
+	}
+	if total == 0 {
+		return shares
+	}
+	for name, tally := range ms.tallies {
+		if change := tally.traitChanges[trait]; change != 0 {
+			shares[name] = math.Abs(change) / total
+		}
+	}
+	return shares
+}
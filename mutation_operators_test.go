@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newMutationTestEntity creates a creature with a few genes to mutate
+func newMutationTestEntity() *Entity {
+	return NewEntity(sharedRand, 1, []string{"speed", "strength", "intelligence", "size"}, "herbivore", Position{X: 10, Y: 10})
+}
+
+// newMutationTestSystem creates a mutation system with the given operator rates
+func newMutationTestSystem(t *testing.T, rates map[string]float64) *MutationSystem {
+	mutations := NewMutationSystem()
+	for name, rate := range rates {
+		if err := mutations.SetRate(name, rate); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return mutations
+}
+
+func TestMutationOperatorRates(t *testing.T) {
+	if operator := NewMutationSystem().choose(0.999); operator.Name != MutationPoint {
+		t.Errorf("Expected only point mutation on by default, got %s", operator.Name)
+	}
+
+	mutations := newMutationTestSystem(t, map[string]float64{MutationPoint: 0, MutationDuplication: 1, MutationDeletion: 1})
+
+	entity := newMutationTestEntity()
+	for i := 0; i < 50; i++ {
+		entity.MutateWith(sharedRand, mutations, 1, 0.2)
+	}
+	for _, record := range mutations.Recent {
+		if record.Operator != MutationDuplication && record.Operator != MutationDeletion {
+			t.Fatalf("Expected only duplication and deletion with the others switched off, got %s", record.Operator)
+		}
+	}
+	if len(mutations.Recent) == 0 {
+		t.Fatal("Expected mutations to be logged")
+	}
+
+	for _, operator := range mutations.Operators {
+		operator.Rate = 0
+	}
+	before := make(map[string]float64)
+	for name, trait := range entity.Traits {
+		before[name] = trait.Value
+	}
+	entity.MutateWith(sharedRand, mutations, 1, 0.2)
+	for name, trait := range entity.Traits {
+		if trait.Value != before[name] {
+			t.Errorf("Expected no change with every operator off, %s went from %.3f to %.3f", name, before[name], trait.Value)
+		}
+	}
+
+	if err := mutations.SetRate("transposition", 1); err == nil {
+		t.Error("Expected an unknown operator to be rejected")
+	}
+	if err := mutations.SetRate(MutationPoint, -1); err == nil {
+		t.Error("Expected a negative rate to be rejected")
+	}
+}
+
+func TestMutationAttribution(t *testing.T) {
+	mutations := newMutationTestSystem(t, map[string]float64{MutationDuplication: 0.3, MutationDeletion: 0.3, MutationRegulatory: 0.3})
+	entity := newMutationTestEntity()
+	for i := 0; i < 200; i++ {
+		entity.MutateWith(sharedRand, mutations, 1, 0.2)
+	}
+
+	report := mutations.Report(10)
+	if len(report.Recent) != 10 {
+		t.Errorf("Expected the 10 latest mutations, got %d", len(report.Recent))
+	}
+	counted, shares := 0, 0.0
+	for _, status := range report.Operators {
+		counted += status.Mutations
+		shares += status.Share
+	}
+	if counted != report.Total || math.Abs(shares-1) > 1e-9 {
+		t.Errorf("Expected operator counts to add up to the total, got %d of %d and shares %.3f", counted, report.Total, shares)
+	}
+	for _, record := range mutations.Recent {
+		if record.EntityID != entity.ID || record.Operator == "" || entity.Traits[record.Trait].Name == "" {
+			t.Errorf("Expected each record to attribute a change to entity %d, got %+v", entity.ID, record)
+		}
+	}
+
+	total := 0.0
+	for _, share := range mutations.Attribution("speed") {
+		total += share
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("Expected the speed attribution to sum to 1, got %.3f", total)
+	}
+}
+
+func TestMutationsAPI(t *testing.T) {
+	world := newSteppingTestWorld(84)
+	wi := NewWebInterface(world)
+
+	rec := httptest.NewRecorder()
+	wi.handleMutations(rec, httptest.NewRequest(http.MethodPost, "/api/mutations", strings.NewReader(`{"operator": "duplication", "rate": 0.5}`)))
+	var report MutationReport
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &report) != nil {
+		t.Fatalf("Setting a rate failed: %d %s", rec.Code, rec.Body.String())
+	}
+	for _, status := range report.Operators {
+		if status.Name == MutationDuplication && status.Rate != 0.5 {
+			t.Errorf("Expected the duplication rate set to 0.5, got %.2f", status.Rate)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleMutations(rec, httptest.NewRequest(http.MethodPost, "/api/mutations", strings.NewReader(`{"operator": "duplication", "rate": -1}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected a negative rate to be rejected, got %d", rec.Code)
+	}
+
+	if _, err := world.Step(30); err != nil {
+		t.Fatal(err)
+	}
+	if operators := NewViewManager(world).getEvolutionData().MutationOperators; len(operators) != len(world.Mutations.Operators) {
+		t.Errorf("Expected the evolution view to list every operator, got %d", len(operators))
+	}
+}
@@ -101,6 +101,8 @@ type NeuralAISystem struct {
 	TotalLearningEvents  int     `json:"total_learning_events"`
 	AvgNetworkComplexity float64 `json:"avg_network_complexity"`
 	EmergentBehaviors    int     `json:"emergent_behaviors"` // Unprogrammed behaviors discovered
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewNeuralAISystem creates a new neural AI system
@@ -117,6 +119,7 @@ func NewNeuralAISystem() *NeuralAISystem {
 		NetworkComplexity:    10, // Default 10 neurons per network
 		AdaptationRate:       0.05,
 		ExperienceDecay:      0.001,
+		rng:                  sharedRand,
 	}
 }
 
@@ -172,7 +175,7 @@ func (nai *NeuralAISystem) buildNetworkArchitecture(network *EntityNeuralNetwork
 		neuron := &Neuron{
 			ID:          nai.NextNeuronID,
 			Value:       0.0,
-			Bias:        rand.Float64()*0.2 - 0.1, // Small random bias
+			Bias:        nai.rng.Float64()*0.2 - 0.1, // Small random bias
 			Layer:       0,
 			Activation:  "relu",
 			Connections: make([]*Synapse, 0),
@@ -190,7 +193,7 @@ func (nai *NeuralAISystem) buildNetworkArchitecture(network *EntityNeuralNetwork
 			neuron := &Neuron{
 				ID:          nai.NextNeuronID,
 				Value:       0.0,
-				Bias:        rand.Float64()*0.2 - 0.1,
+				Bias:        nai.rng.Float64()*0.2 - 0.1,
 				Layer:       1,
 				Activation:  "sigmoid",
 				Connections: make([]*Synapse, 0),
@@ -208,7 +211,7 @@ func (nai *NeuralAISystem) buildNetworkArchitecture(network *EntityNeuralNetwork
 		neuron := &Neuron{
 			ID:          nai.NextNeuronID,
 			Value:       0.0,
-			Bias:        rand.Float64()*0.2 - 0.1,
+			Bias:        nai.rng.Float64()*0.2 - 0.1,
 			Layer:       2,
 			Activation:  "tanh",
 			Connections: make([]*Synapse, 0),
@@ -232,7 +235,7 @@ func (nai *NeuralAISystem) connectLayers(network *EntityNeuralNetwork) {
 				synapse := &Synapse{
 					FromNeuronID: inputID,
 					ToNeuronID:   hiddenID,
-					Weight:       rand.Float64()*2 - 1, // Random weight between -1 and 1
+					Weight:       nai.rng.Float64()*2 - 1, // Random weight between -1 and 1
 					Strength:     1.0,
 					LastActive:   0,
 				}
@@ -246,7 +249,7 @@ func (nai *NeuralAISystem) connectLayers(network *EntityNeuralNetwork) {
 				synapse := &Synapse{
 					FromNeuronID: hiddenID,
 					ToNeuronID:   outputID,
-					Weight:       rand.Float64()*2 - 1,
+					Weight:       nai.rng.Float64()*2 - 1,
 					Strength:     1.0,
 					LastActive:   0,
 				}
@@ -260,7 +263,7 @@ func (nai *NeuralAISystem) connectLayers(network *EntityNeuralNetwork) {
 				synapse := &Synapse{
 					FromNeuronID: inputID,
 					ToNeuronID:   outputID,
-					Weight:       rand.Float64()*2 - 1,
+					Weight:       nai.rng.Float64()*2 - 1,
 					Strength:     1.0,
 					LastActive:   0,
 				}
@@ -318,7 +321,8 @@ func (nai *NeuralAISystem) forwardPass(network *EntityNeuralNetwork, inputs []fl
 			neuron := network.Neurons[neuronID]
 			// Sum weighted inputs from connected neurons
 			sum := neuron.Bias
-			for _, otherNeuron := range network.Neurons {
+			for _, otherID := range sortedKeys(network.Neurons) {
+				otherNeuron := network.Neurons[otherID]
 				for _, synapse := range otherNeuron.Connections {
 					if synapse.ToNeuronID == neuronID {
 						sum += otherNeuron.Value * synapse.Weight * synapse.Strength
@@ -335,7 +339,8 @@ func (nai *NeuralAISystem) forwardPass(network *EntityNeuralNetwork, inputs []fl
 	for i, outputID := range network.OutputNeurons {
 		neuron := network.Neurons[outputID]
 		sum := neuron.Bias
-		for _, otherNeuron := range network.Neurons {
+		for _, otherID := range sortedKeys(network.Neurons) {
+			otherNeuron := network.Neurons[otherID]
 			for _, synapse := range otherNeuron.Connections {
 				if synapse.ToNeuronID == outputID {
 					sum += otherNeuron.Value * synapse.Weight * synapse.Strength
@@ -384,10 +389,11 @@ func (nai *NeuralAISystem) LearnFromOutcome(entityID int, success bool, reward f
 	}
 
 	// Update connection weights for recently active synapses
-	for _, neuron := range network.Neurons {
+	for _, neuronID := range sortedKeys(network.Neurons) {
+		neuron := network.Neurons[neuronID]
 		for _, synapse := range neuron.Connections {
 			if tick-synapse.LastActive <= 5 { // Only recent connections
-				synapse.Weight += learningFactor * rand.Float64() * 0.1
+				synapse.Weight += learningFactor * nai.rng.Float64() * 0.1
 				// Keep weights in reasonable range
 				synapse.Weight = math.Max(-2.0, math.Min(2.0, synapse.Weight))
 
@@ -431,11 +437,13 @@ func (nai *NeuralAISystem) Update(entities []*Entity, tick int) {
 
 // decayExperience reduces the experience of all networks over time
 func (nai *NeuralAISystem) decayExperience() {
-	for _, network := range nai.EntityNetworks {
+	for _, entityID := range sortedKeys(nai.EntityNetworks) {
+		network := nai.EntityNetworks[entityID]
 		network.Experience *= (1.0 - nai.ExperienceDecay)
 
 		// Decay connection strengths slightly
-		for _, neuron := range network.Neurons {
+		for _, neuronID := range sortedKeys(network.Neurons) {
+			neuron := network.Neurons[neuronID]
 			for _, synapse := range neuron.Connections {
 				synapse.Strength *= 0.999 // Very slight decay
 			}
@@ -450,7 +458,8 @@ func (nai *NeuralAISystem) updateStatistics() {
 
 	// Calculate average network complexity
 	totalComplexity := 0.0
-	for _, network := range nai.EntityNetworks {
+	for _, entityID := range sortedKeys(nai.EntityNetworks) {
+		network := nai.EntityNetworks[entityID]
 		totalComplexity += network.ComplexityScore
 	}
 	if nai.TotalNetworks > 0 {
@@ -491,7 +500,8 @@ func (nai *NeuralAISystem) GetNeuralStats() map[string]interface{} {
 	correctDecisions := 0
 	totalExperience := 0.0
 
-	for _, network := range nai.EntityNetworks {
+	for _, entityID := range sortedKeys(nai.EntityNetworks) {
+		network := nai.EntityNetworks[entityID]
 		totalDecisions += network.TotalDecisions
 		correctDecisions += network.CorrectDecisions
 		totalExperience += network.Experience
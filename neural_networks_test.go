@@ -32,7 +32,7 @@ func TestNeuralNetworkCreation(t *testing.T) {
 	system := NewNeuralAISystem()
 
 	// Create test entity with high intelligence
-	entity := NewEntity(1, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 0, Y: 0})
+	entity := NewEntity(sharedRand, 1, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 0, Y: 0})
 	entity.SetTrait("intelligence", 0.8)
 	entity.SetTrait("curiosity", 0.6)
 	entity.IsAlive = true
@@ -77,7 +77,7 @@ func TestNeuralDecisionProcessing(t *testing.T) {
 	system := NewNeuralAISystem()
 
 	// Create test entity
-	entity := NewEntity(1, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 0, Y: 0})
+	entity := NewEntity(sharedRand, 1, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 0, Y: 0})
 	entity.SetTrait("intelligence", 0.7)
 	entity.SetTrait("curiosity", 0.5)
 	entity.IsAlive = true
@@ -118,7 +118,7 @@ func TestNeuralLearning(t *testing.T) {
 	system := NewNeuralAISystem()
 
 	// Create test entity
-	entity := NewEntity(1, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 0, Y: 0})
+	entity := NewEntity(sharedRand, 1, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 0, Y: 0})
 	entity.SetTrait("intelligence", 0.8)
 	entity.SetTrait("curiosity", 0.6)
 	entity.IsAlive = true
@@ -202,17 +202,17 @@ func TestNeuralSystemUpdate(t *testing.T) {
 	system := NewNeuralAISystem()
 
 	// Create entities with varying intelligence
-	entity1 := NewEntity(1, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 0, Y: 0})
+	entity1 := NewEntity(sharedRand, 1, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 0, Y: 0})
 	entity1.SetTrait("intelligence", 0.8) // High intelligence - should get network
 	entity1.SetTrait("curiosity", 0.6)
 	entity1.IsAlive = true
 
-	entity2 := NewEntity(2, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 1, Y: 1})
+	entity2 := NewEntity(sharedRand, 2, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 1, Y: 1})
 	entity2.SetTrait("intelligence", 0.2) // Low intelligence - should not get network
 	entity2.SetTrait("curiosity", 0.3)
 	entity2.IsAlive = true
 
-	entity3 := NewEntity(3, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 2, Y: 2})
+	entity3 := NewEntity(sharedRand, 3, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 2, Y: 2})
 	entity3.SetTrait("intelligence", 0.5) // Medium intelligence - should get network
 	entity3.SetTrait("curiosity", 0.4)
 	entity3.IsAlive = true
@@ -245,7 +245,7 @@ func TestNeuralStatsGeneration(t *testing.T) {
 	system := NewNeuralAISystem()
 
 	// Create test entity and network
-	entity := NewEntity(1, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 0, Y: 0})
+	entity := NewEntity(sharedRand, 1, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 0, Y: 0})
 	entity.SetTrait("intelligence", 0.8)
 	entity.SetTrait("curiosity", 0.6)
 	entity.IsAlive = true
@@ -292,7 +292,7 @@ func TestEntityNeuralData(t *testing.T) {
 	system := NewNeuralAISystem()
 
 	// Create test entity
-	entity := NewEntity(1, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 0, Y: 0})
+	entity := NewEntity(sharedRand, 1, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 0, Y: 0})
 	entity.SetTrait("intelligence", 0.8)
 	entity.SetTrait("curiosity", 0.6)
 	entity.IsAlive = true
@@ -460,12 +460,12 @@ func TestNeuralNetworkCleanup(t *testing.T) {
 	system := NewNeuralAISystem()
 
 	// Create entities
-	entity1 := NewEntity(1, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 0, Y: 0})
+	entity1 := NewEntity(sharedRand, 1, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 0, Y: 0})
 	entity1.SetTrait("intelligence", 0.8)
 	entity1.SetTrait("curiosity", 0.6)
 	entity1.IsAlive = true
 
-	entity2 := NewEntity(2, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 1, Y: 1})
+	entity2 := NewEntity(sharedRand, 2, []string{"intelligence", "curiosity"}, "testspecies", Position{X: 1, Y: 1})
 	entity2.SetTrait("intelligence", 0.7)
 	entity2.SetTrait("curiosity", 0.5)
 	entity2.IsAlive = true
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// exclusionOverlap is the combined niche overlap above which a lopsided pair is flagged as
+// heading for competitive exclusion
+const exclusionOverlap = 0.5
+
+// maxReportedOverlaps bounds the pairs kept with each ecosystem metrics snapshot
+const maxReportedOverlaps = 10
+
+// NicheProfile is how a species uses food, habitat and the hours of the day, each as
+// proportions summing to one
+type NicheProfile struct {
+	Species    string             `json:"species"`
+	Population int                `json:"population"`
+	Diet       map[string]float64 `json:"diet"`     // Plant name or "prey:<species>"
+	Habitat    map[string]float64 `json:"habitat"`  // Biome
+	Activity   map[string]float64 `json:"activity"` // Time of day spent awake
+}
+
+// NicheOverlap compares the niches of two coexisting species. Overlaps are Pianka's index
+// (0 = nothing shared, 1 = identical), and the overall overlap is their product. Alpha is
+// the MacArthur-Levins competition coefficient: AlphaAB is how much one B weighs on A's
+// resources relative to one more A.
+type NicheOverlap struct {
+	SpeciesA        string  `json:"species_a"`
+	SpeciesB        string  `json:"species_b"`
+	Diet            float64 `json:"diet"`
+	Habitat         float64 `json:"habitat"`
+	Activity        float64 `json:"activity"`
+	Overall         float64 `json:"overall"`
+	AlphaAB         float64 `json:"alpha_ab"`
+	AlphaBA         float64 `json:"alpha_ba"`
+	LikelyExclusion bool    `json:"likely_exclusion"`
+	Excluded        string  `json:"excluded,omitempty"` // The species expected to lose out
+	Reason          string  `json:"reason,omitempty"`
+}
+
+// NicheOverlapReport is every coexisting pair's niche overlap, most overlapping first
+type NicheOverlapReport struct {
+	Tick       int            `json:"tick"`
+	Profiles   []NicheProfile `json:"profiles"`
+	Pairs      []NicheOverlap `json:"pairs"`
+	Exclusions int            `json:"exclusions"`
+}
+
+// addShare adds weight to a key of a niche dimension
+func addShare(shares map[string]float64, key string, weight float64) {
+	if weight > 0 {
+		shares[key] += weight
+	}
+}
+
+// normalizeShares scales a niche dimension to proportions
+func normalizeShares(shares map[string]float64) {
+	total := 0.0
+	for _, weight := range shares {
+		total += weight
+	}
+	if total == 0 {
+		return
+	}
+	for key := range shares {
+		shares[key] /= total
+	}
+}
+
+// dietKey names a food from a consumption record's identifier
+func dietKey(foodID string, plantNames map[PlantType]string) string {
+	if number, ok := strings.CutPrefix(foodID, "plant_"); ok {
+		if plantType, err := strconv.Atoi(number); err == nil {
+			if name, exists := plantNames[PlantType(plantType)]; exists {
+				return name
+			}
+		}
+		return foodID
+	}
+	return "prey:" + foodID
+}
+
+// speciesNicheProfiles builds the niche profile of each living species. Diet comes from
+// what members have been seen eating, or their learned preferences before they have eaten;
+// activity from the times of day their schedules keep them awake.
+func speciesNicheProfiles(w *World) map[string]*NicheProfile {
+	plantNames := make(map[PlantType]string)
+	for plantType, config := range GetPlantConfigs() {
+		plantNames[plantType] = config.Name
+	}
+
+	profiles := make(map[string]*NicheProfile)
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		profile := profiles[entity.Species]
+		if profile == nil {
+			profile = &NicheProfile{Species: entity.Species, Diet: make(map[string]float64),
+				Habitat: make(map[string]float64), Activity: make(map[string]float64)}
+			profiles[entity.Species] = profile
+		}
+		profile.Population++
+		addShare(profile.Habitat, biomeName(w.getBiomeAt(entity.Position)), 1)
+
+		if memory := entity.DietaryMemory; memory != nil {
+			if len(memory.ConsumptionHistory) > 0 {
+				for _, record := range memory.ConsumptionHistory {
+					addShare(profile.Diet, dietKey(record.FoodID, plantNames), 1)
+				}
+			} else {
+				for plantType, preference := range memory.PlantTypePreferences {
+					addShare(profile.Diet, dietKey(fmt.Sprintf("plant_%d", plantType), plantNames), preference)
+				}
+				for prey, preference := range memory.PreySpeciesPreferences {
+					addShare(profile.Diet, "prey:"+prey, preference)
+				}
+			}
+		}
+
+		if entity.BioRhythm != nil {
+			for timeOfDay, activities := range entity.BioRhythm.ActivitySchedule {
+				for _, activity := range activities {
+					if activity != ActivitySleep && activity != ActivityRest {
+						addShare(profile.Activity, getTimeOfDayNameWeb(timeOfDay), 1)
+						break
+					}
+				}
+			}
+		}
+	}
+	for _, profile := range profiles {
+		normalizeShares(profile.Diet)
+		normalizeShares(profile.Habitat)
+		normalizeShares(profile.Activity)
+	}
+	return profiles
+}
+
+// nicheDimensionOverlap returns Pianka's overlap of two resource-use distributions and the
+// MacArthur-Levins coefficients of each on the other. A dimension with nothing observed for
+// either species cannot tell them apart, so it counts as complete overlap.
+func nicheDimensionOverlap(a, b map[string]float64) (overlap, alphaAB, alphaBA float64) {
+	if len(a) == 0 || len(b) == 0 {
+		return 1, 1, 1
+	}
+	var shared, squaresA, squaresB float64
+	for key, share := range a {
+		shared += share * b[key]
+		squaresA += share * share
+	}
+	for _, share := range b {
+		squaresB += share * share
+	}
+	return shared / math.Sqrt(squaresA*squaresB), shared / squaresA, shared / squaresB
+}
+
+// compareNiches measures the overlap of two species' niches and, treating their present
+// populations as carrying capacities, whether Lotka-Volterra competition lets them coexist
+func compareNiches(a, b *NicheProfile) NicheOverlap {
+	overlap := NicheOverlap{SpeciesA: a.Species, SpeciesB: b.Species}
+	var dietAB, dietBA, habitatAB, habitatBA, activityAB, activityBA float64
+	overlap.Diet, dietAB, dietBA = nicheDimensionOverlap(a.Diet, b.Diet)
+	overlap.Habitat, habitatAB, habitatBA = nicheDimensionOverlap(a.Habitat, b.Habitat)
+	overlap.Activity, activityAB, activityBA = nicheDimensionOverlap(a.Activity, b.Activity)
+	overlap.Overall = overlap.Diet * overlap.Habitat * overlap.Activity
+	overlap.AlphaAB = dietAB * habitatAB * activityAB
+	overlap.AlphaBA = dietBA * habitatBA * activityBA
+	if overlap.Overall < exclusionOverlap {
+		return overlap
+	}
+
+	// Coexistence needs each species to limit itself more than it limits the other:
+	// alphaAB < K_A/K_B and alphaBA < K_B/K_A
+	ratio := float64(a.Population) / float64(b.Population)
+	aLoses := overlap.AlphaAB >= ratio
+	bLoses := overlap.AlphaBA >= 1/ratio
+	switch {
+	case aLoses && bLoses:
+		overlap.Excluded = a.Species
+		if b.Population < a.Population {
+			overlap.Excluded = b.Species
+		}
+		overlap.Reason = "each competes harder with the other than with itself; the rarer is likely to go"
+	case aLoses:
+		overlap.Excluded = a.Species
+		overlap.Reason = fmt.Sprintf("%s presses on %s's niche (alpha %.2f) more than %s's numbers can absorb", b.Species, a.Species, overlap.AlphaAB, a.Species)
+	case bLoses:
+		overlap.Excluded = b.Species
+		overlap.Reason = fmt.Sprintf("%s presses on %s's niche (alpha %.2f) more than %s's numbers can absorb", a.Species, b.Species, overlap.AlphaBA, b.Species)
+	}
+	overlap.LikelyExclusion = overlap.Excluded != ""
+	return overlap
+}
+
+// AnalyzeNicheOverlap compares the niches of every pair of living species
+func AnalyzeNicheOverlap(w *World) NicheOverlapReport {
+	profiles := speciesNicheProfiles(w)
+	report := NicheOverlapReport{Tick: w.Tick, Profiles: make([]NicheProfile, 0, len(profiles)), Pairs: make([]NicheOverlap, 0)}
+	species := sortedKeys(profiles)
+	for i, a := range species {
+		report.Profiles = append(report.Profiles, *profiles[a])
+		for _, b := range species[i+1:] {
+			overlap := compareNiches(profiles[a], profiles[b])
+			if overlap.LikelyExclusion {
+				report.Exclusions++
+			}
+			report.Pairs = append(report.Pairs, overlap)
+		}
+	}
+	sort.SliceStable(report.Pairs, func(i, j int) bool { return report.Pairs[i].Overall > report.Pairs[j].Overall })
+	return report
+}
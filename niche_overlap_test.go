@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNicheDimensionOverlap(t *testing.T) {
+	// A specialist inside a generalist's niche feels the generalist more than the reverse
+	overlap, alphaAB, alphaBA := nicheDimensionOverlap(map[string]float64{"Grass": 1}, map[string]float64{"Grass": 0.5, "Bush": 0.5})
+	if math.Abs(overlap-math.Sqrt(0.5)) > 1e-9 || math.Abs(alphaAB-0.5) > 1e-9 || math.Abs(alphaBA-1) > 1e-9 {
+		t.Errorf("Expected overlap 0.707 with alphas 0.5 and 1, got %.3f, %.3f, %.3f", overlap, alphaAB, alphaBA)
+	}
+	if overlap, _, _ := nicheDimensionOverlap(map[string]float64{"Grass": 1}, map[string]float64{"Kelp": 1}); overlap != 0 {
+		t.Errorf("Expected no overlap between separate diets, got %.3f", overlap)
+	}
+	if overlap, alphaAB, _ := nicheDimensionOverlap(nil, map[string]float64{"Kelp": 1}); overlap != 1 || alphaAB != 1 {
+		t.Errorf("Expected an unobserved dimension not to separate species, got %.3f, %.3f", overlap, alphaAB)
+	}
+}
+
+func TestCompetitiveExclusionFlagged(t *testing.T) {
+	niche := func(species string, population int, diet string) *NicheProfile {
+		return &NicheProfile{Species: species, Population: population, Diet: map[string]float64{diet: 1},
+			Habitat: map[string]float64{"plains": 1}, Activity: map[string]float64{"Dawn": 0.5, "Evening": 0.5}}
+	}
+
+	overlap := compareNiches(niche("Rare", 5, "Grass"), niche("Common", 40, "Grass"))
+	if overlap.Overall != 1 || !overlap.LikelyExclusion || overlap.Excluded != "Rare" || overlap.Reason == "" {
+		t.Errorf("Expected the rarer of two identical niches to be flagged, got %+v", overlap)
+	}
+
+	overlap = compareNiches(niche("Grazer", 5, "Grass"), niche("Browser", 40, "Bush"))
+	if overlap.Overall != 0 || overlap.LikelyExclusion {
+		t.Errorf("Expected species eating different plants to coexist, got %+v", overlap)
+	}
+
+	// Partial overlap with balanced numbers lets both persist
+	a, b := niche("Left", 20, "Grass"), niche("Right", 20, "Grass")
+	a.Habitat = map[string]float64{"plains": 0.8, "forest": 0.2}
+	b.Habitat = map[string]float64{"plains": 0.2, "forest": 0.8}
+	if overlap = compareNiches(a, b); overlap.LikelyExclusion || overlap.AlphaAB >= 1 {
+		t.Errorf("Expected partly separated species of equal numbers to coexist, got %+v", overlap)
+	}
+}
+
+func TestNicheProfilesFromWorld(t *testing.T) {
+	world := newSteppingTestWorld(99)
+	fillTestBiome(world, BiomeWater)
+	species := world.AllEntities[0].Species
+	for _, entity := range world.AllEntities {
+		if entity.Species == species {
+			entity.DietaryMemory.ConsumptionHistory = []ConsumptionRecord{
+				{FoodType: "plant", FoodID: "plant_0"}, {FoodType: "entity", FoodID: "Hare"}, {FoodType: "plant", FoodID: "plant_0"},
+			}
+		}
+	}
+
+	report := AnalyzeNicheOverlap(world)
+	var profile *NicheProfile
+	for i := range report.Profiles {
+		if report.Profiles[i].Species == species {
+			profile = &report.Profiles[i]
+		}
+	}
+	if profile == nil {
+		t.Fatalf("Expected a profile of %s, got %+v", species, report.Profiles)
+	}
+	grass := GetPlantConfigs()[PlantType(0)].Name
+	if math.Abs(profile.Diet[grass]-2.0/3) > 1e-9 || math.Abs(profile.Diet["prey:Hare"]-1.0/3) > 1e-9 {
+		t.Errorf("Expected two thirds %s and a third Hare, got %v", grass, profile.Diet)
+	}
+	if profile.Habitat["water"] != 1 {
+		t.Errorf("Expected a flooded world to leave only water habitat, got %v", profile.Habitat)
+	}
+	total := 0.0
+	for _, share := range profile.Activity {
+		total += share
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("Expected activity shares to sum to one, got %v", profile.Activity)
+	}
+	if len(report.Pairs) != len(report.Profiles)*(len(report.Profiles)-1)/2 {
+		t.Errorf("Expected every pair of %d species compared, got %d", len(report.Profiles), len(report.Pairs))
+	}
+	for i := 1; i < len(report.Pairs); i++ {
+		if report.Pairs[i].Overall > report.Pairs[i-1].Overall {
+			t.Fatalf("Expected pairs ordered by overlap")
+		}
+	}
+}
+
+func TestNicheOverlapAPI(t *testing.T) {
+	wi := NewWebInterface(newSteppingTestWorld(100))
+	rec := httptest.NewRecorder()
+	wi.handleNicheOverlap(rec, httptest.NewRequest(http.MethodGet, "/api/niche-overlap", nil))
+	var report NicheOverlapReport
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &report) != nil || len(report.Profiles) == 0 {
+		t.Fatalf("Expected niche profiles, got %d %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// Noise pollution parameters
+const (
+	noiseInterval        = 5    // Ticks between disturbances of wildlife
+	busyColonySize       = 3    // Members a tribe needs before its camp disturbs wildlife
+	colonyNoiseRadius    = 12.0 // Reach of a busy camp's disturbance
+	colonyNoisePerMember = 1.0  // Extra reach per member
+	maxColonyNoiseRadius = 30.0
+	colonyBaseLoudness   = 0.2
+	memberLoudness       = 0.04 // Extra loudness per member
+	structureLoudness    = 0.05 // Extra loudness per standing structure
+	roadNoiseRadius      = 6.0  // Reach of the traffic on a worn path
+	roadLoudness         = 0.4  // Loudness of a fully worn path
+	noiseStress          = 1.0  // Energy a fully sensitive creature loses per update in full noise
+	noiseFlight          = 4.0  // Distance a fully sensitive creature flees per update within a disturbance zone
+	sensitiveThreshold   = 0.5  // Sensitivity at which a creature flees rather than putting up with noise
+)
+
+// NoiseSource is a busy camp or a worn path whose traffic disturbs wildlife around it. A
+// camp is a point, a path the segment from Start to End.
+type NoiseSource struct {
+	Kind     string   `json:"kind"` // "colony" or "road"
+	ID       int      `json:"id"`   // Tribe or path ID
+	Name     string   `json:"name,omitempty"`
+	Start    Position `json:"start"`
+	End      Position `json:"end"`
+	Radius   float64  `json:"radius"`
+	Loudness float64  `json:"loudness"`
+}
+
+// distance is how far a position lies from the nearest point of the source, and that point
+func (ns NoiseSource) distance(pos Position) (float64, Position) {
+	nearest := ns.Start
+	dx, dy := ns.End.X-ns.Start.X, ns.End.Y-ns.Start.Y
+	if length := dx*dx + dy*dy; length > 0 {
+		along := math.Max(0, math.Min(1, ((pos.X-ns.Start.X)*dx+(pos.Y-ns.Start.Y)*dy)/length))
+		nearest = Position{X: ns.Start.X + along*dx, Y: ns.Start.Y + along*dy}
+	}
+	return math.Hypot(pos.X-nearest.X, pos.Y-nearest.Y), nearest
+}
+
+// noiseSensitivity is how badly a creature takes disturbance, 0-1: timid creatures flee it
+// while bold ones learn to live with it
+func noiseSensitivity(entity *Entity) float64 {
+	return math.Max(0, math.Min(1, 0.5-entity.GetTrait("aggression")*0.5))
+}
+
+// SpeciesDisturbance is how one wild species is living with settlement noise
+type SpeciesDisturbance struct {
+	Species     string  `json:"species"`
+	Population  int     `json:"population"`
+	InZone      int     `json:"in_zone"`    // Living within a disturbance zone now
+	ZoneShare   float64 `json:"zone_share"` // Share of the population in disturbance zones
+	Sensitivity float64 `json:"sensitivity"`
+	Response    string  `json:"response"`  // "avoider" or "tolerant"
+	Displaced   int     `json:"displaced"` // Times members have fled noise
+	EnergyLost  float64 `json:"energy_lost"`
+}
+
+// NoisePollutionReport lists the noise sources and how each wild species responds to them.
+// Avoiders fall below the disturbed area share in the zones and tolerant species do not,
+// which is the urban-edge pattern.
+type NoisePollutionReport struct {
+	Tick          int                  `json:"tick"`
+	Sources       []NoiseSource        `json:"sources"`
+	DisturbedArea float64              `json:"disturbed_area"` // Share of grid cells within a disturbance zone
+	Species       []SpeciesDisturbance `json:"species"`
+}
+
+// NoisePollutionSystem tracks the disturbance around busy colonies and worn paths, stressing
+// wildlife within it and driving sensitive species away
+type NoisePollutionSystem struct {
+	Sources    []NoiseSource
+	displaced  map[string]int
+	energyLost map[string]float64
+}
+
+// NewNoisePollutionSystem creates a system with a quiet world
+func NewNoisePollutionSystem() *NoisePollutionSystem {
+	return &NoisePollutionSystem{
+		Sources:    make([]NoiseSource, 0),
+		displaced:  make(map[string]int),
+		energyLost: make(map[string]float64),
+	}
+}
+
+// Update relocates the noise sources and disturbs the wildlife near them every noiseInterval ticks
+func (nps *NoisePollutionSystem) Update(w *World) {
+	if w.Tick%noiseInterval != 0 {
+		return
+	}
+	nps.Sources = w.noiseSources()
+	if len(nps.Sources) == 0 {
+		return
+	}
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive || entity.TribeID != 0 {
+			continue
+		}
+		noise, source := nps.NoiseAt(entity.Position)
+		if noise <= 0 {
+			continue
+		}
+		sensitivity := noiseSensitivity(entity)
+		stress := noise * sensitivity * noiseStress
+		entity.Energy -= stress
+		nps.energyLost[entity.Species] += stress
+		if sensitivity < sensitiveThreshold {
+			continue
+		}
+
+		// Flee straight away from the nearest point of the source, at a pace set by temperament
+		// rather than loudness so that sensitive creatures clear the zone instead of lingering
+		// at its edge
+		distance, nearest := source.distance(entity.Position)
+		if distance == 0 {
+			continue
+		}
+		flight := sensitivity * noiseFlight
+		entity.Position.X += (entity.Position.X - nearest.X) / distance * flight
+		entity.Position.Y += (entity.Position.Y - nearest.Y) / distance * flight
+		entity.Position, _ = clampPosition(entity.Position, w.Config)
+		nps.displaced[entity.Species]++
+	}
+}
+
+// noiseSources gathers the busy camps of tribes and the worn paths between places
+func (w *World) noiseSources() []NoiseSource {
+	sources := make([]NoiseSource, 0)
+	if w.CivilizationSystem != nil {
+		for _, tribe := range w.CivilizationSystem.Tribes {
+			if len(tribe.Members) < busyColonySize {
+				continue
+			}
+			centerX, centerY := 0.0, 0.0
+			for _, member := range tribe.Members {
+				centerX += member.Position.X
+				centerY += member.Position.Y
+			}
+			center := Position{X: centerX / float64(len(tribe.Members)), Y: centerY / float64(len(tribe.Members))}
+			standing := 0
+			for _, structure := range tribe.Structures {
+				if structure.Health > 0 {
+					standing++
+				}
+			}
+			sources = append(sources, NoiseSource{
+				Kind: "colony", ID: tribe.ID, Name: tribe.Name, Start: center, End: center,
+				Radius:   math.Min(maxColonyNoiseRadius, colonyNoiseRadius+colonyNoisePerMember*float64(len(tribe.Members))),
+				Loudness: math.Min(1, colonyBaseLoudness+memberLoudness*float64(len(tribe.Members))+structureLoudness*float64(standing)),
+			})
+		}
+	}
+	if w.EnvironmentalModSystem != nil {
+		for _, id := range sortedKeys(w.EnvironmentalModSystem.Modifications) {
+			path := w.EnvironmentalModSystem.Modifications[id]
+			if !path.IsActive || path.Type != EnvModPath {
+				continue
+			}
+			end := Position{X: path.Properties["end_x"], Y: path.Properties["end_y"]}
+			sources = append(sources, NoiseSource{
+				Kind: "road", ID: path.ID, Start: path.Position, End: end,
+				Radius: roadNoiseRadius, Loudness: roadLoudness * path.Durability,
+			})
+		}
+	}
+	return sources
+}
+
+// NoiseAt is the disturbance at a position and the source making most of it
+func (nps *NoisePollutionSystem) NoiseAt(pos Position) (float64, *NoiseSource) {
+	if nps == nil {
+		return 0, nil
+	}
+	loudest, loudestSource := 0.0, (*NoiseSource)(nil)
+	for i := range nps.Sources {
+		source := &nps.Sources[i]
+		distance, _ := source.distance(pos)
+		if distance >= source.Radius {
+			continue
+		}
+		if noise := source.Loudness * (1 - distance/source.Radius); noise > loudest {
+			loudest, loudestSource = noise, source
+		}
+	}
+	return loudest, loudestSource
+}
+
+// cellDisturbed reports whether the centre of a grid cell lies within a disturbance zone
+func (w *World) cellDisturbed(x, y int) bool {
+	if w.NoisePollution == nil {
+		return false
+	}
+	cellWidth, cellHeight := geoJSONCellSize(w)
+	noise, _ := w.NoisePollution.NoiseAt(Position{X: (float64(x) + 0.5) * cellWidth, Y: (float64(y) + 0.5) * cellHeight})
+	return noise > 0
+}
+
+// Report lists the noise sources, the share of the world they disturb and how every wild
+// species is distributed around them
+func (nps *NoisePollutionSystem) Report(w *World) NoisePollutionReport {
+	report := NoisePollutionReport{
+		Tick:    w.Tick,
+		Sources: append([]NoiseSource{}, nps.Sources...),
+		Species: make([]SpeciesDisturbance, 0),
+	}
+	disturbed := 0
+	for y := 0; y < w.Config.GridHeight; y++ {
+		for x := 0; x < w.Config.GridWidth; x++ {
+			if w.cellDisturbed(x, y) {
+				disturbed++
+			}
+		}
+	}
+	if cells := w.Config.GridWidth * w.Config.GridHeight; cells > 0 {
+		report.DisturbedArea = float64(disturbed) / float64(cells)
+	}
+
+	records := make(map[string]*SpeciesDisturbance)
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive || entity.TribeID != 0 {
+			continue
+		}
+		record := records[entity.Species]
+		if record == nil {
+			record = &SpeciesDisturbance{Species: entity.Species}
+			records[entity.Species] = record
+		}
+		record.Population++
+		record.Sensitivity += noiseSensitivity(entity)
+		if noise, _ := nps.NoiseAt(entity.Position); noise > 0 {
+			record.InZone++
+		}
+	}
+	for _, species := range sortedKeys(records) {
+		record := records[species]
+		record.ZoneShare = float64(record.InZone) / float64(record.Population)
+		record.Sensitivity /= float64(record.Population)
+		record.Response = "tolerant"
+		if record.Sensitivity >= sensitiveThreshold {
+			record.Response = "avoider"
+		}
+		record.Displaced = nps.displaced[species]
+		record.EnergyLost = nps.energyLost[species]
+		report.Species = append(report.Species, *record)
+	}
+	sort.SliceStable(report.Species, func(i, j int) bool { return report.Species[i].ZoneShare < report.Species[j].ZoneShare })
+	return report
+}
+
+// Clear silences the world and forgets past disturbance, as when a new world is loaded
+func (nps *NoisePollutionSystem) Clear() {
+	nps.Sources = make([]NoiseSource, 0)
+	nps.displaced = make(map[string]int)
+	nps.energyLost = make(map[string]float64)
+}
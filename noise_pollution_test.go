@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newNoiseTestWorld makes an empty 100x100 world with a six-member tribe camped at (50, 50)
+func newNoiseTestWorld() *World {
+	world := newEmptyTestWorld(100)
+	for _, member := range addTestTribe(world, "Stonecall", 6, Position{X: 50, Y: 50}).Members {
+		member.Energy = 100
+	}
+	return world
+}
+
+// newWildlife makes a wild creature with the given boldness
+func newWildlife(id int, species string, aggression float64, pos Position) *Entity {
+	entity := NewEntity(sharedRand, id, []string{"speed"}, species, pos)
+	entity.SetTrait("aggression", aggression)
+	entity.Energy = 100
+	return entity
+}
+
+// runNoise advances the noise system by a number of updates
+func runNoise(world *World, updates int) {
+	runSystem(world, noiseInterval, updates, world.NoisePollution.Update)
+}
+
+func TestNoiseDrivesSensitiveSpeciesAway(t *testing.T) {
+	world := newNoiseTestWorld()
+	timid := newWildlife(10, "Deer", -0.8, Position{X: 58, Y: 50})
+	bold := newWildlife(11, "Crow", 0.8, Position{X: 42, Y: 50})
+	distant := newWildlife(12, "Deer", -0.8, Position{X: 5, Y: 95})
+	world.AllEntities = append(world.AllEntities, timid, bold, distant)
+	runNoise(world, 1)
+
+	if timid.Position.X <= 58 || timid.Position.Y != 50 {
+		t.Errorf("Expected a timid creature to flee straight away from the camp, got %+v", timid.Position)
+	}
+	if timid.Energy >= 100 || bold.Energy >= 100 || bold.Energy <= timid.Energy {
+		t.Errorf("Expected noise to stress the timid more than the bold, got %.2f and %.2f", timid.Energy, bold.Energy)
+	}
+	if bold.Position.X != 42 {
+		t.Errorf("Expected a bold creature to stay put, got %+v", bold.Position)
+	}
+	if distant.Energy != 100 || distant.Position.X != 5 {
+		t.Errorf("Expected wildlife far from the camp undisturbed")
+	}
+	if world.AllEntities[0].Energy != 100 {
+		t.Errorf("Expected the tribe not to be disturbed by its own noise")
+	}
+
+	runNoise(world, 20)
+	if noise, _ := world.NoisePollution.NoiseAt(timid.Position); noise > 0 {
+		t.Errorf("Expected the timid creature driven out of the disturbance zone, still at %+v", timid.Position)
+	}
+}
+
+func TestWornPathsDisturbWildlife(t *testing.T) {
+	world := newNoiseTestWorld()
+	world.CivilizationSystem.Tribes = nil
+	walker := NewEntity(sharedRand, 20, []string{"speed"}, "Folk", Position{X: 10, Y: 10})
+	walker.Energy = 1000
+	path := world.EnvironmentalModSystem.CreatePath(walker, Position{X: 10, Y: 10}, Position{X: 90, Y: 10})
+	path.Durability = 1
+	beside := newWildlife(10, "Deer", -0.8, Position{X: 50, Y: 13})
+	world.AllEntities = []*Entity{beside}
+	runNoise(world, 1)
+
+	if len(world.NoisePollution.Sources) != 1 || world.NoisePollution.Sources[0].Kind != "road" {
+		t.Fatalf("Expected the path as the only noise source, got %+v", world.NoisePollution.Sources)
+	}
+	if beside.Position.Y <= 13 || beside.Position.X != 50 {
+		t.Errorf("Expected a creature beside the path to flee away from it, got %+v", beside.Position)
+	}
+}
+
+func TestNoiseShapesSpeciesRanges(t *testing.T) {
+	world := newNoiseTestWorld()
+	for i := 0; i < 5; i++ {
+		offset := float64(i) * 2
+		world.AllEntities = append(world.AllEntities,
+			newWildlife(10+i, "Deer", -0.8, Position{X: 54 + offset, Y: 52}),
+			newWildlife(20+i, "Crow", 0.8, Position{X: 54 + offset, Y: 48}))
+	}
+	runNoise(world, 20)
+
+	report := world.NoisePollution.Report(world)
+	if len(report.Species) != 2 || report.DisturbedArea <= 0 {
+		t.Fatalf("Expected both wild species and a disturbed area reported, got %+v", report)
+	}
+	deer, crow := report.Species[0], report.Species[1]
+	if deer.Species != "Deer" || deer.Response != "avoider" || deer.ZoneShare != 0 || deer.Displaced == 0 {
+		t.Errorf("Expected the deer to have avoided the camp, got %+v", deer)
+	}
+	if crow.Species != "Crow" || crow.Response != "tolerant" || crow.ZoneShare != 1 {
+		t.Errorf("Expected the crows to live on at the camp, got %+v", crow)
+	}
+
+	disturbed := make(map[string]int)
+	for _, feature := range geoJSONRangeFeatures(world) {
+		disturbed[feature.Properties["species"].(string)] = feature.Properties["disturbed_cells"].(int)
+	}
+	if disturbed["Deer"] != 0 || disturbed["Crow"] == 0 {
+		t.Errorf("Expected range maps to show only the crows within the disturbance, got %v", disturbed)
+	}
+
+	wi := NewWebInterface(world)
+	rec := httptest.NewRecorder()
+	wi.handleNoisePollution(rec, httptest.NewRequest(http.MethodGet, "/api/noise-pollution", nil))
+	var decoded NoisePollutionReport
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &decoded) != nil || len(decoded.Sources) != 1 || len(decoded.Species) != 2 {
+		t.Fatalf("Expected a noise report with the camp and both species, got %d %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Intervention kinds
+const (
+	InterventionTerraform       = "terraform"
+	InterventionSpawn           = "spawn"
+	InterventionTraitEdit       = "trait_edit"
+	InterventionBuildStructure  = "build_structure"
+	InterventionRemoveStructure = "remove_structure"
+)
+
+// maxInterventionHistory caps how many interventions can be undone
+const maxInterventionHistory = 100
+
+// Intervention is an operator action recorded together with its inverse so it can be
+// undone and redone
+type Intervention struct {
+	ID          int    `json:"id"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	Tick        int    `json:"tick"` // Tick the action was first applied
+
+	apply  func(w *World) error // Performs the action again on redo
+	revert func(w *World) error // Inverse of apply, run on undo
+}
+
+// InterventionSystem keeps the undo and redo stacks of operator interventions.
+// Every action, undo, and redo is also emitted on the central event bus.
+type InterventionSystem struct {
+	mu       sync.Mutex
+	undo     []*Intervention
+	redo     []*Intervention
+	nextID   int
+	eventBus *CentralEventBus
+}
+
+// NewInterventionSystem creates an empty intervention history
+func NewInterventionSystem(eventBus *CentralEventBus) *InterventionSystem {
+	return &InterventionSystem{nextID: 1, eventBus: eventBus}
+}
+
+// Record runs an action and pushes it onto the undo stack. apply is only kept for redo;
+// the first run is done by first, which may differ (e.g. it allocates new IDs).
+// Recording a new intervention discards anything that could have been redone.
+func (is *InterventionSystem) Record(w *World, kind, description string, first, apply, revert func(w *World) error) (*Intervention, error) {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+
+	if err := first(w); err != nil {
+		return nil, err
+	}
+	intervention := &Intervention{
+		ID:          is.nextID,
+		Kind:        kind,
+		Description: description,
+		Tick:        w.Tick,
+		apply:       apply,
+		revert:      revert,
+	}
+	is.nextID++
+	is.undo = append(is.undo, intervention)
+	if len(is.undo) > maxInterventionHistory {
+		is.undo = is.undo[len(is.undo)-maxInterventionHistory:]
+	}
+	is.redo = nil
+
+	is.emit(w, "operator_intervention", "apply", intervention.Description, intervention)
+	return intervention, nil
+}
+
+// Undo reverts the most recent intervention
+func (is *InterventionSystem) Undo(w *World) (*Intervention, error) {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+
+	if len(is.undo) == 0 {
+		return nil, fmt.Errorf("nothing to undo")
+	}
+	intervention := is.undo[len(is.undo)-1]
+	if err := intervention.revert(w); err != nil {
+		return nil, fmt.Errorf("failed to undo %s: %v", intervention.Description, err)
+	}
+	is.undo = is.undo[:len(is.undo)-1]
+	is.redo = append(is.redo, intervention)
+
+	is.emit(w, "intervention_undone", "undo", "Undid: "+intervention.Description, intervention)
+	return intervention, nil
+}
+
+// Redo re-applies the most recently undone intervention
+func (is *InterventionSystem) Redo(w *World) (*Intervention, error) {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+
+	if len(is.redo) == 0 {
+		return nil, fmt.Errorf("nothing to redo")
+	}
+	intervention := is.redo[len(is.redo)-1]
+	if err := intervention.apply(w); err != nil {
+		return nil, fmt.Errorf("failed to redo %s: %v", intervention.Description, err)
+	}
+	is.redo = is.redo[:len(is.redo)-1]
+	is.undo = append(is.undo, intervention)
+
+	is.emit(w, "intervention_redone", "redo", "Redid: "+intervention.Description, intervention)
+	return intervention, nil
+}
+
+// History returns copies of the undo and redo stacks, most recent last
+func (is *InterventionSystem) History() (undo, redo []Intervention) {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+	undo = make([]Intervention, 0, len(is.undo))
+	for _, intervention := range is.undo {
+		undo = append(undo, *intervention)
+	}
+	redo = make([]Intervention, 0, len(is.redo))
+	for _, intervention := range is.redo {
+		redo = append(redo, *intervention)
+	}
+	return undo, redo
+}
+
+// Clear forgets all interventions, used when the world they applied to is reset
+func (is *InterventionSystem) Clear() {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+	is.undo = nil
+	is.redo = nil
+}
+
+// emit records an intervention step on the central event bus
+func (is *InterventionSystem) emit(w *World, eventType, step, description string, intervention *Intervention) {
+	if is.eventBus == nil {
+		return
+	}
+	is.eventBus.EmitSystemEvent(w.Tick, eventType, intervention.Kind, "operator_interventions", description, nil,
+		map[string]interface{}{
+			"intervention_id": intervention.ID,
+			"kind":            intervention.Kind,
+			"step":            step,
+		})
+}
+
+// parseBiomeName returns the biome type with the given name (see biomeName)
+func parseBiomeName(name string) (BiomeType, bool) {
+	for biome := BiomePlains; biome <= BiomeCanyon; biome++ {
+		if biomeName(biome) == name {
+			return biome, true
+		}
+	}
+	return BiomePlains, false
+}
+
+// TerraformCells changes the biome of grid cells as an undoable intervention
+func (w *World) TerraformCells(cells []GridPoint, biome BiomeType) (*Intervention, error) {
+	if biomeName(biome) == "unknown" {
+		return nil, fmt.Errorf("unknown biome %d", biome)
+	}
+
+	previous := make(map[GridPoint]BiomeType)
+	targets := make([]GridPoint, 0, len(cells))
+	for _, cell := range cells {
+		if cell.X < 0 || cell.X >= w.Config.GridWidth || cell.Y < 0 || cell.Y >= w.Config.GridHeight {
+			continue
+		}
+		if _, seen := previous[cell]; seen {
+			continue
+		}
+		previous[cell] = w.Grid[cell.Y][cell.X].Biome
+		targets = append(targets, cell)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("terraform has no cells inside the world grid")
+	}
+
+	apply := func(w *World) error {
+		for _, cell := range targets {
+			w.Grid[cell.Y][cell.X].Biome = biome
+		}
+		return nil
+	}
+	revert := func(w *World) error {
+		for _, cell := range targets {
+			w.Grid[cell.Y][cell.X].Biome = previous[cell]
+		}
+		return nil
+	}
+	return w.Interventions.Record(w, InterventionTerraform,
+		fmt.Sprintf("Terraformed %d cell(s) to %s", len(targets), biomeName(biome)), apply, apply, revert)
+}
+
+// SpawnCreatures imports a creature file as an undoable intervention. Undoing removes the
+// spawned creatures; redoing brings the same individuals back.
+func (w *World) SpawnCreatures(file *CreatureFile, pos Position) ([]*Entity, error) {
+	var spawned []*Entity
+	networks := make(map[int]*EntityNeuralNetwork)
+	organisms := make(map[int]*CellularOrganism)
+
+	first := func(w *World) error {
+		entities, err := w.ImportCreatureFile(file, pos)
+		if err != nil {
+			w.removeEntities(entities)
+			return err
+		}
+		spawned = entities
+		for _, entity := range spawned {
+			if w.NeuralAISystem != nil && w.NeuralAISystem.EntityNetworks[entity.ID] != nil {
+				networks[entity.ID] = w.NeuralAISystem.EntityNetworks[entity.ID]
+			}
+			if w.CellularSystem != nil && w.CellularSystem.OrganismMap[entity.ID] != nil {
+				organisms[entity.ID] = w.CellularSystem.OrganismMap[entity.ID]
+			}
+		}
+		return nil
+	}
+	apply := func(w *World) error {
+		for _, entity := range spawned {
+			w.AllEntities = append(w.AllEntities, entity)
+			if network, exists := networks[entity.ID]; exists && w.NeuralAISystem != nil {
+				w.NeuralAISystem.EntityNetworks[entity.ID] = network
+			}
+			if organism, exists := organisms[entity.ID]; exists && w.CellularSystem != nil {
+				w.CellularSystem.OrganismMap[entity.ID] = organism
+			}
+		}
+		return nil
+	}
+	revert := func(w *World) error {
+		w.removeEntities(spawned)
+		return nil
+	}
+
+	species := ""
+	if len(file.Creatures) > 0 {
+		species = file.Creatures[0].Species
+	}
+	if _, err := w.Interventions.Record(w, InterventionSpawn,
+		fmt.Sprintf("Spawned %d %s from \"%s\"", len(file.Creatures), species, file.Name), first, apply, revert); err != nil {
+		return nil, err
+	}
+	return spawned, nil
+}
+
+// removeEntities takes entities out of the world along with their neural networks and organisms
+func (w *World) removeEntities(entities []*Entity) {
+	if len(entities) == 0 {
+		return
+	}
+	removed := make(map[*Entity]bool, len(entities))
+	for _, entity := range entities {
+		removed[entity] = true
+		if w.NeuralAISystem != nil {
+			delete(w.NeuralAISystem.EntityNetworks, entity.ID)
+		}
+		if w.CellularSystem != nil {
+			delete(w.CellularSystem.OrganismMap, entity.ID)
+		}
+	}
+	remaining := make([]*Entity, 0, len(w.AllEntities))
+	for _, entity := range w.AllEntities {
+		if !removed[entity] {
+			remaining = append(remaining, entity)
+		}
+	}
+	w.AllEntities = remaining
+}
+
+// EditEntityTrait sets one of an entity's traits as an undoable intervention
+func (w *World) EditEntityTrait(entityID int, trait string, value float64) (*Intervention, error) {
+	entity := w.findEntityByID(entityID)
+	if entity == nil {
+		return nil, fmt.Errorf("entity %d not found", entityID)
+	}
+	if trait == "" {
+		return nil, fmt.Errorf("trait name is required")
+	}
+	if isInvalidNumber(value) {
+		return nil, fmt.Errorf("trait %s value is not a number", trait)
+	}
+	if err := w.TraitRegistry.Check(TraitSubjectCreature, trait, value); err != nil {
+		return nil, err
+	}
+
+	previous, existed := entity.Traits[trait]
+	apply := func(w *World) error {
+		entity.SetTrait(trait, value)
+		return nil
+	}
+	revert := func(w *World) error {
+		if existed {
+			entity.Traits[trait] = previous
+		} else {
+			delete(entity.Traits, trait)
+		}
+		return nil
+	}
+	return w.Interventions.Record(w, InterventionTraitEdit,
+		fmt.Sprintf("Set entity %d %s to %.3f (was %.3f)", entityID, trait, value, previous.Value), apply, apply, revert)
+}
+
+// BuildOperatorStructure builds a barrier or corridor as an undoable intervention
+func (w *World) BuildOperatorStructure(structureType, kind string, cells []GridPoint, baselineTicks int) (*OperatorStructure, error) {
+	var structure *OperatorStructure
+	first := func(w *World) error {
+		built, err := w.OperatorStructures.BuildStructure(w, structureType, kind, cells, baselineTicks)
+		structure = built
+		return err
+	}
+	apply := func(w *World) error {
+		w.OperatorStructures.restoreStructure(structure)
+		return nil
+	}
+	revert := func(w *World) error {
+		w.OperatorStructures.RemoveStructure(structure.ID)
+		return nil
+	}
+	if _, err := w.Interventions.Record(w, InterventionBuildStructure,
+		fmt.Sprintf("Built %s %s", kind, structureType), first, apply, revert); err != nil {
+		return nil, err
+	}
+	return structure, nil
+}
+
+// RemoveOperatorStructure removes a barrier or corridor as an undoable intervention
+func (w *World) RemoveOperatorStructure(id int) (*Intervention, error) {
+	structure, exists := w.OperatorStructures.Structures[id]
+	if !exists {
+		return nil, fmt.Errorf("structure %d not found", id)
+	}
+	apply := func(w *World) error {
+		w.OperatorStructures.RemoveStructure(id)
+		return nil
+	}
+	revert := func(w *World) error {
+		w.OperatorStructures.restoreStructure(structure)
+		return nil
+	}
+	return w.Interventions.Record(w, InterventionRemoveStructure,
+		fmt.Sprintf("Removed %s %s %d", structure.Kind, structure.Type, id), apply, apply, revert)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInterventionUndoRedo(t *testing.T) {
+	world := NewWorld(WorldConfig{Width: 30, Height: 30, PopulationSize: 3, GridWidth: 6, GridHeight: 6})
+	world.AddPopulation(startingPopulations(false)[0])
+	entity := world.AllEntities[0]
+	originalSpeed := entity.GetTrait("speed")
+	originalBiome := world.Grid[1][2].Biome
+	target := BiomeDeepWater
+	if originalBiome == target {
+		target = BiomeDesert
+	}
+
+	if _, err := world.TerraformCells([]GridPoint{{X: 2, Y: 1}, {X: 2, Y: 1}, {X: 99, Y: 99}}, target); err != nil {
+		t.Fatalf("Terraform failed: %v", err)
+	}
+	if _, err := world.EditEntityTrait(entity.ID, "speed", 0.9); err != nil {
+		t.Fatalf("Trait edit failed: %v", err)
+	}
+	if world.Grid[1][2].Biome != target || entity.GetTrait("speed") != 0.9 {
+		t.Fatal("Expected terraform and trait edit to apply")
+	}
+
+	// Undo runs in reverse order
+	if undone, err := world.Interventions.Undo(world); err != nil || undone.Kind != InterventionTraitEdit {
+		t.Fatalf("Expected the trait edit to be undone first, got %+v (%v)", undone, err)
+	}
+	if entity.GetTrait("speed") != originalSpeed || world.Grid[1][2].Biome != target {
+		t.Errorf("Expected only the trait edit to be reverted")
+	}
+	if _, err := world.Interventions.Undo(world); err != nil || world.Grid[1][2].Biome != originalBiome {
+		t.Errorf("Expected terraform to be reverted, got %v (%v)", world.Grid[1][2].Biome, err)
+	}
+	if _, err := world.Interventions.Undo(world); err == nil {
+		t.Error("Expected undo with an empty history to fail")
+	}
+
+	if redone, err := world.Interventions.Redo(world); err != nil || redone.Kind != InterventionTerraform || world.Grid[1][2].Biome != target {
+		t.Errorf("Expected terraform to be redone, got %+v (%v)", redone, err)
+	}
+
+	// A new intervention drops whatever could still be redone
+	if _, err := world.EditEntityTrait(entity.ID, "size", 0.5); err != nil {
+		t.Fatal(err)
+	}
+	undo, redo := world.Interventions.History()
+	if len(undo) != 2 || len(redo) != 0 {
+		t.Errorf("Expected 2 undoable and 0 redoable interventions, got %d and %d", len(undo), len(redo))
+	}
+
+	if events := world.CentralEventBus.GetEventsByType("intervention_undone"); len(events) != 2 {
+		t.Errorf("Expected both undos on the event bus, got %d", len(events))
+	}
+	if _, err := world.EditEntityTrait(-1, "speed", 1); err == nil {
+		t.Error("Expected editing a missing entity to fail")
+	}
+}
+
+func TestSpawnAndStructureInterventions(t *testing.T) {
+	world := NewWorld(WorldConfig{Width: 30, Height: 30, GridWidth: 6, GridHeight: 6})
+	file := &CreatureFile{
+		Format:    CreatureFileFormat,
+		Version:   CreatureFileVersion,
+		Name:      "pair",
+		Creatures: []CreatureRecord{{Species: "herbivore", Traits: map[string]float64{"speed": 0.5}}, {Species: "herbivore"}},
+	}
+
+	spawned, err := world.SpawnCreatures(file, Position{X: 10, Y: 10})
+	if err != nil || len(spawned) != 2 || len(world.AllEntities) != 2 {
+		t.Fatalf("Expected 2 spawned creatures, got %d (%v)", len(world.AllEntities), err)
+	}
+	structure, err := world.BuildOperatorStructure(OperatorStructureCorridor, "", []GridPoint{{X: 0, Y: 0}, {X: 1, Y: 0}}, 0)
+	if err != nil || !world.OperatorStructures.CorridorCells[GridPoint{X: 1, Y: 0}] {
+		t.Fatalf("Expected corridor to be built, got %v", err)
+	}
+
+	if _, err := world.RemoveOperatorStructure(structure.ID); err != nil || len(world.OperatorStructures.CorridorCells) != 0 {
+		t.Fatalf("Expected corridor to be removed, got %v", err)
+	}
+	if _, err := world.Interventions.Undo(world); err != nil || world.OperatorStructures.Structures[structure.ID] != structure {
+		t.Fatalf("Expected undoing the removal to restore the corridor, got %v", err)
+	}
+	_, _ = world.Interventions.Undo(world)
+	if len(world.OperatorStructures.Structures) != 0 {
+		t.Error("Expected undoing the build to remove the corridor")
+	}
+
+	if _, err := world.Interventions.Undo(world); err != nil || len(world.AllEntities) != 0 {
+		t.Fatalf("Expected undoing the spawn to remove both creatures, %d left (%v)", len(world.AllEntities), err)
+	}
+	if _, err := world.Interventions.Redo(world); err != nil || len(world.AllEntities) != 2 || world.AllEntities[0] != spawned[0] {
+		t.Errorf("Expected redo to bring back the same creatures, got %d (%v)", len(world.AllEntities), err)
+	}
+}
+
+func TestInterventionAPI(t *testing.T) {
+	wi := NewWebInterface(NewWorld(WorldConfig{Width: 30, Height: 30, GridWidth: 6, GridHeight: 6}))
+
+	rec := httptest.NewRecorder()
+	wi.handleTerraform(rec, httptest.NewRequest(http.MethodPost, "/api/operator/terraform", bytes.NewReader([]byte(`{"biome": "lava", "cells": [{"x": 1, "y": 1}]}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown biome, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleTerraform(rec, httptest.NewRequest(http.MethodPost, "/api/operator/terraform", bytes.NewReader([]byte(`{"biome": "swamp", "from": {"x": 0, "y": 0}, "to": {"x": 2, "y": 0}}`))))
+	if rec.Code != http.StatusCreated || wi.world.Grid[0][2].Biome != BiomeSwamp {
+		t.Fatalf("Terraform failed: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleInterventions(rec, httptest.NewRequest(http.MethodPost, "/api/operator/interventions", bytes.NewReader([]byte(`{"action": "undo"}`))))
+	var undone Intervention
+	if err := json.Unmarshal(rec.Body.Bytes(), &undone); err != nil || undone.Kind != InterventionTerraform {
+		t.Fatalf("Expected terraform to be undone, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleInterventions(rec, httptest.NewRequest(http.MethodPost, "/api/operator/interventions", bytes.NewReader([]byte(`{"action": "undo"}`))))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 with nothing to undo, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleInterventions(rec, httptest.NewRequest(http.MethodGet, "/api/operator/interventions", nil))
+	var history struct {
+		Undo []Intervention `json:"undo"`
+		Redo []Intervention `json:"redo"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &history); err != nil || len(history.Undo) != 0 || len(history.Redo) != 1 {
+		t.Errorf("Expected one redoable intervention, got %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,468 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Operator structure types
+const (
+	OperatorStructureBarrier  = "barrier"
+	OperatorStructureCorridor = "corridor"
+)
+
+// Experiment phases for fragmentation experiments
+const (
+	ExperimentPhaseBaseline  = "baseline"
+	ExperimentPhaseTreatment = "treatment"
+	ExperimentPhaseComplete  = "complete"
+)
+
+// OperatorStructure is a barrier or corridor drawn on the map by an operator
+type OperatorStructure struct {
+	ID          int         `json:"id"`
+	Type        string      `json:"type"` // "barrier" or "corridor"
+	Kind        string      `json:"kind"` // Free-form label such as "fence" or "canal"
+	Cells       []GridPoint `json:"cells"`
+	CreatedTick int         `json:"created_tick"`
+	Active      bool        `json:"active"`
+}
+
+// GeneFlowMeasurement summarizes gene flow between two regions over a period
+type GeneFlowMeasurement struct {
+	StartTick       int     `json:"start_tick"`
+	EndTick         int     `json:"end_tick"`
+	Crossings       int     `json:"crossings"`        // Entities moving between regions
+	CrossingRate    float64 `json:"crossing_rate"`    // Crossings per 100 ticks
+	TraitDivergence float64 `json:"trait_divergence"` // Distance between region mean trait vectors
+	PopulationA     int     `json:"population_a"`
+	PopulationB     int     `json:"population_b"`
+}
+
+// FragmentationExperiment compares gene flow across a barrier before and after it is built
+type FragmentationExperiment struct {
+	ID             int                 `json:"id"`
+	StructureID    int                 `json:"structure_id"`
+	Phase          string              `json:"phase"`
+	BaselineTicks  int                 `json:"baseline_ticks"`
+	TreatmentTicks int                 `json:"treatment_ticks"`
+	PhaseStartTick int                 `json:"phase_start_tick"`
+	RegionA        map[GridPoint]bool  `json:"-"`
+	RegionB        map[GridPoint]bool  `json:"-"`
+	RegionASize    int                 `json:"region_a_size"`
+	RegionBSize    int                 `json:"region_b_size"`
+	Before         GeneFlowMeasurement `json:"before"`
+	After          GeneFlowMeasurement `json:"after"`
+	Summary        string              `json:"summary"`
+	entityRegions  map[int]int         // Entity ID -> last region (1 = A, 2 = B)
+	crossings      int
+}
+
+// OperatorStructureSystem manages operator-built barriers, corridors, and fragmentation experiments
+type OperatorStructureSystem struct {
+	Structures       map[int]*OperatorStructure `json:"structures"`
+	Experiments      []*FragmentationExperiment `json:"experiments"`
+	BarrierCells     map[GridPoint]bool         `json:"-"`
+	CorridorCells    map[GridPoint]bool         `json:"-"`
+	NextStructureID  int                        `json:"next_structure_id"`
+	NextExperimentID int                        `json:"next_experiment_id"`
+	BlockedMoves     int                        `json:"blocked_moves"`
+	lastPositions    map[int]Position
+	eventBus         *CentralEventBus
+}
+
+// NewOperatorStructureSystem creates a new operator structure system
+func NewOperatorStructureSystem(eventBus *CentralEventBus) *OperatorStructureSystem {
+	return &OperatorStructureSystem{
+		Structures:       make(map[int]*OperatorStructure),
+		Experiments:      make([]*FragmentationExperiment, 0),
+		BarrierCells:     make(map[GridPoint]bool),
+		CorridorCells:    make(map[GridPoint]bool),
+		NextStructureID:  1,
+		NextExperimentID: 1,
+		lastPositions:    make(map[int]Position),
+		eventBus:         eventBus,
+	}
+}
+
+// RasterizeLine returns the grid cells on a straight line between two cells (Bresenham)
+func RasterizeLine(x0, y0, x1, y1 int) []GridPoint {
+	cells := make([]GridPoint, 0)
+	dx := int(math.Abs(float64(x1 - x0)))
+	dy := -int(math.Abs(float64(y1 - y0)))
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		cells = append(cells, GridPoint{X: x0, Y: y0})
+		if x0 == x1 && y0 == y1 {
+			break
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+	return cells
+}
+
+// BuildStructure places a barrier or corridor on the given cells. Barriers built with a
+// positive baselineTicks start as a planned structure so gene flow can be measured first.
+func (oss *OperatorStructureSystem) BuildStructure(world *World, structureType, kind string, cells []GridPoint, baselineTicks int) (*OperatorStructure, error) {
+	if structureType != OperatorStructureBarrier && structureType != OperatorStructureCorridor {
+		return nil, fmt.Errorf("unknown structure type %q", structureType)
+	}
+
+	valid := make([]GridPoint, 0, len(cells))
+	for _, cell := range cells {
+		if cell.X >= 0 && cell.X < world.Config.GridWidth && cell.Y >= 0 && cell.Y < world.Config.GridHeight {
+			valid = append(valid, cell)
+		}
+	}
+	if len(valid) == 0 {
+		return nil, fmt.Errorf("structure has no cells inside the world grid")
+	}
+
+	structure := &OperatorStructure{
+		ID:          oss.NextStructureID,
+		Type:        structureType,
+		Kind:        kind,
+		Cells:       valid,
+		CreatedTick: world.Tick,
+	}
+	oss.NextStructureID++
+	oss.Structures[structure.ID] = structure
+
+	if structureType == OperatorStructureBarrier {
+		oss.startExperiment(world, structure, baselineTicks)
+	}
+	if structureType == OperatorStructureCorridor || baselineTicks <= 0 {
+		oss.activate(world, structure)
+	}
+
+	return structure, nil
+}
+
+// RemoveStructure deletes an operator structure
+func (oss *OperatorStructureSystem) RemoveStructure(id int) bool {
+	if _, exists := oss.Structures[id]; !exists {
+		return false
+	}
+	delete(oss.Structures, id)
+	oss.rebuildCellIndex()
+	return true
+}
+
+// restoreStructure puts a removed structure back as it was
+func (oss *OperatorStructureSystem) restoreStructure(structure *OperatorStructure) {
+	oss.Structures[structure.ID] = structure
+	oss.rebuildCellIndex()
+}
+
+// activate makes a structure affect movement
+func (oss *OperatorStructureSystem) activate(world *World, structure *OperatorStructure) {
+	structure.Active = true
+	oss.rebuildCellIndex()
+
+	if oss.eventBus != nil {
+		oss.eventBus.EmitSystemEvent(world.Tick, "operator_structure", structure.Type, "operator_structures",
+			fmt.Sprintf("Operator built %s %s (%d cells)", structure.Kind, structure.Type, len(structure.Cells)),
+			nil, map[string]interface{}{
+				"structure_id": structure.ID,
+				"type":         structure.Type,
+				"kind":         structure.Kind,
+				"cells":        len(structure.Cells),
+			})
+	}
+}
+
+// rebuildCellIndex recomputes the barrier and corridor cell lookups
+func (oss *OperatorStructureSystem) rebuildCellIndex() {
+	oss.BarrierCells = make(map[GridPoint]bool)
+	oss.CorridorCells = make(map[GridPoint]bool)
+	for _, structure := range oss.Structures {
+		if !structure.Active {
+			continue
+		}
+		for _, cell := range structure.Cells {
+			if structure.Type == OperatorStructureBarrier {
+				oss.BarrierCells[cell] = true
+			} else {
+				oss.CorridorCells[cell] = true
+			}
+		}
+	}
+}
+
+// Update enforces barriers, assists corridor travel, and advances experiments
+func (oss *OperatorStructureSystem) Update(world *World) {
+	seen := make(map[int]bool, len(world.AllEntities))
+	for _, entity := range world.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		seen[entity.ID] = true
+		gx, gy := world.worldToGridCoords(entity.Position.X, entity.Position.Y)
+		cell := GridPoint{X: gx, Y: gy}
+
+		if last, exists := oss.lastPositions[entity.ID]; exists && oss.crossesBarrier(world, last, entity.Position) {
+			entity.Position = last
+			oss.BlockedMoves++
+		} else if oss.CorridorCells[cell] {
+			// Corridors make travel cheaper, encouraging their use
+			entity.Energy = math.Min(100, entity.Energy+0.2)
+		}
+		oss.lastPositions[entity.ID] = entity.Position
+	}
+	for id := range oss.lastPositions {
+		if !seen[id] {
+			delete(oss.lastPositions, id)
+		}
+	}
+
+	for _, experiment := range oss.Experiments {
+		oss.updateExperiment(world, experiment)
+	}
+}
+
+// crossesBarrier reports whether a move between two positions touches any barrier cell
+func (oss *OperatorStructureSystem) crossesBarrier(world *World, from, to Position) bool {
+	if len(oss.BarrierCells) == 0 {
+		return false
+	}
+	fx, fy := world.worldToGridCoords(from.X, from.Y)
+	tx, ty := world.worldToGridCoords(to.X, to.Y)
+	for _, cell := range RasterizeLine(fx, fy, tx, ty) {
+		if cell.X == fx && cell.Y == fy {
+			continue // Entities already standing on a barrier may walk off it
+		}
+		if oss.BarrierCells[cell] {
+			return true
+		}
+	}
+	return false
+}
+
+// startExperiment splits the world along a barrier and begins the baseline measurement
+func (oss *OperatorStructureSystem) startExperiment(world *World, structure *OperatorStructure, baselineTicks int) {
+	regionA, regionB := splitRegions(world.GridLayout(), world.Config.GridWidth, world.Config.GridHeight, structure.Cells)
+	if len(regionB) == 0 {
+		return // Barrier does not separate the map into two regions
+	}
+
+	if baselineTicks < 0 {
+		baselineTicks = 0
+	}
+	experiment := &FragmentationExperiment{
+		ID:             oss.NextExperimentID,
+		StructureID:    structure.ID,
+		Phase:          ExperimentPhaseBaseline,
+		BaselineTicks:  baselineTicks,
+		TreatmentTicks: int(math.Max(100, float64(baselineTicks))),
+		PhaseStartTick: world.Tick,
+		RegionA:        regionA,
+		RegionB:        regionB,
+		RegionASize:    len(regionA),
+		RegionBSize:    len(regionB),
+		entityRegions:  make(map[int]int),
+	}
+	oss.NextExperimentID++
+	if baselineTicks == 0 {
+		experiment.Phase = ExperimentPhaseTreatment
+	}
+	oss.Experiments = append(oss.Experiments, experiment)
+}
+
+// updateExperiment counts crossings and moves the experiment through its phases
+func (oss *OperatorStructureSystem) updateExperiment(world *World, experiment *FragmentationExperiment) {
+	if experiment.Phase == ExperimentPhaseComplete {
+		return
+	}
+
+	for _, entity := range world.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		region := experiment.regionOf(world, entity.Position)
+		if region == 0 {
+			continue
+		}
+		if previous, exists := experiment.entityRegions[entity.ID]; exists && previous != region {
+			experiment.crossings++
+		}
+		experiment.entityRegions[entity.ID] = region
+	}
+
+	elapsed := world.Tick - experiment.PhaseStartTick
+	switch experiment.Phase {
+	case ExperimentPhaseBaseline:
+		if elapsed >= experiment.BaselineTicks {
+			experiment.Before = experiment.measure(world, experiment.PhaseStartTick)
+			experiment.Phase = ExperimentPhaseTreatment
+			experiment.PhaseStartTick = world.Tick
+			experiment.crossings = 0
+			if structure, exists := oss.Structures[experiment.StructureID]; exists && !structure.Active {
+				oss.activate(world, structure)
+			}
+		}
+	case ExperimentPhaseTreatment:
+		if elapsed >= experiment.TreatmentTicks {
+			experiment.After = experiment.measure(world, experiment.PhaseStartTick)
+			experiment.Phase = ExperimentPhaseComplete
+			experiment.Summary = experiment.summarize()
+			if oss.eventBus != nil {
+				oss.eventBus.EmitSystemEvent(world.Tick, "fragmentation_experiment", "gene_flow", "operator_structures",
+					experiment.Summary, nil, map[string]interface{}{
+						"experiment_id":        experiment.ID,
+						"before_crossing_rate": experiment.Before.CrossingRate,
+						"after_crossing_rate":  experiment.After.CrossingRate,
+						"before_divergence":    experiment.Before.TraitDivergence,
+						"after_divergence":     experiment.After.TraitDivergence,
+					})
+			}
+		}
+	}
+}
+
+// regionOf returns 1 or 2 for positions in region A or B, 0 otherwise
+func (fe *FragmentationExperiment) regionOf(world *World, pos Position) int {
+	gx, gy := world.worldToGridCoords(pos.X, pos.Y)
+	cell := GridPoint{X: gx, Y: gy}
+	if fe.RegionA[cell] {
+		return 1
+	}
+	if fe.RegionB[cell] {
+		return 2
+	}
+	return 0
+}
+
+// measure captures gene flow statistics for the period starting at startTick
+func (fe *FragmentationExperiment) measure(world *World, startTick int) GeneFlowMeasurement {
+	measurement := GeneFlowMeasurement{
+		StartTick: startTick,
+		EndTick:   world.Tick,
+		Crossings: fe.crossings,
+	}
+	if duration := world.Tick - startTick; duration > 0 {
+		measurement.CrossingRate = float64(fe.crossings) * 100 / float64(duration)
+	}
+
+	sumsA := make(map[string]float64)
+	sumsB := make(map[string]float64)
+	for _, entity := range world.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		switch fe.regionOf(world, entity.Position) {
+		case 1:
+			measurement.PopulationA++
+			for name, trait := range entity.Traits {
+				sumsA[name] += trait.Value
+			}
+		case 2:
+			measurement.PopulationB++
+			for name, trait := range entity.Traits {
+				sumsB[name] += trait.Value
+			}
+		}
+	}
+
+	if measurement.PopulationA > 0 && measurement.PopulationB > 0 {
+		sumSquares := 0.0
+		for _, name := range sortedKeys(sumsA) {
+			sumA := sumsA[name]
+			diff := sumA/float64(measurement.PopulationA) - sumsB[name]/float64(measurement.PopulationB)
+			sumSquares += diff * diff
+		}
+		measurement.TraitDivergence = math.Sqrt(sumSquares)
+	}
+	return measurement
+}
+
+// summarize describes the before/after change in gene flow
+func (fe *FragmentationExperiment) summarize() string {
+	change := "unchanged"
+	if fe.Before.CrossingRate > 0 {
+		reduction := (fe.Before.CrossingRate - fe.After.CrossingRate) / fe.Before.CrossingRate * 100
+		change = fmt.Sprintf("%.0f%% lower", reduction)
+		if reduction < 0 {
+			change = fmt.Sprintf("%.0f%% higher", -reduction)
+		}
+	}
+	return fmt.Sprintf("Fragmentation experiment %d: crossing rate %.2f -> %.2f per 100 ticks (%s), trait divergence %.3f -> %.3f",
+		fe.ID, fe.Before.CrossingRate, fe.After.CrossingRate, change, fe.Before.TraitDivergence, fe.After.TraitDivergence)
+}
+
+// splitRegions labels the grid into connected regions separated by the given wall cells
+// and returns the two largest regions.
+func splitRegions(layout GridLayout, width, height int, wall []GridPoint) (map[GridPoint]bool, map[GridPoint]bool) {
+	blocked := make(map[GridPoint]bool, len(wall))
+	for _, cell := range wall {
+		blocked[cell] = true
+	}
+
+	visited := make(map[GridPoint]bool)
+	regions := make([]map[GridPoint]bool, 0)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			start := GridPoint{X: x, Y: y}
+			if blocked[start] || visited[start] {
+				continue
+			}
+			region := make(map[GridPoint]bool)
+			stack := []GridPoint{start}
+			visited[start] = true
+			for len(stack) > 0 {
+				p := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				region[p] = true
+				for _, n := range gridNeighbors(layout, p, width, height) {
+					if !blocked[n] && !visited[n] {
+						visited[n] = true
+						stack = append(stack, n)
+					}
+				}
+			}
+			regions = append(regions, region)
+		}
+	}
+
+	sort.Slice(regions, func(i, j int) bool { return len(regions[i]) > len(regions[j]) })
+	if len(regions) < 2 {
+		if len(regions) == 1 {
+			return regions[0], map[GridPoint]bool{}
+		}
+		return map[GridPoint]bool{}, map[GridPoint]bool{}
+	}
+	return regions[0], regions[1]
+}
+
+// GetStats returns operator structure statistics
+func (oss *OperatorStructureSystem) GetStats() map[string]interface{} {
+	barriers, corridors := 0, 0
+	for _, structure := range oss.Structures {
+		if structure.Type == OperatorStructureBarrier {
+			barriers++
+		} else {
+			corridors++
+		}
+	}
+	return map[string]interface{}{
+		"barriers":      barriers,
+		"corridors":     corridors,
+		"blocked_moves": oss.BlockedMoves,
+		"experiments":   len(oss.Experiments),
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestRasterizeLine(t *testing.T) {
+	cells := RasterizeLine(0, 0, 3, 3)
+	if len(cells) != 4 {
+		t.Fatalf("Expected 4 cells on diagonal line, got %d", len(cells))
+	}
+	if cells[0] != (GridPoint{X: 0, Y: 0}) || cells[3] != (GridPoint{X: 3, Y: 3}) {
+		t.Errorf("Unexpected line endpoints: %v", cells)
+	}
+
+	vertical := RasterizeLine(5, 0, 5, 9)
+	if len(vertical) != 10 {
+		t.Errorf("Expected 10 cells on vertical line, got %d", len(vertical))
+	}
+}
+
+func TestBarrierBlocksMovement(t *testing.T) {
+	world := newTestWorld(20, 10)
+	fillTestBiome(world, BiomePlains)
+	oss := world.OperatorStructures
+
+	_, err := oss.BuildStructure(world, OperatorStructureBarrier, "fence", RasterizeLine(5, 0, 5, 9), 0)
+	if err != nil {
+		t.Fatalf("Failed to build barrier: %v", err)
+	}
+
+	entity := NewEntity(sharedRand, 1, []string{}, "herbivore", Position{X: 8, Y: 5})
+	world.AllEntities = append(world.AllEntities, entity)
+	oss.Update(world)
+
+	// Try to walk across the fence
+	entity.Position = Position{X: 13, Y: 5}
+	oss.Update(world)
+
+	if entity.Position.X != 8 {
+		t.Errorf("Expected barrier to push entity back to x=8, got x=%.1f", entity.Position.X)
+	}
+	if oss.BlockedMoves != 1 {
+		t.Errorf("Expected 1 blocked move, got %d", oss.BlockedMoves)
+	}
+}
+
+func TestCorridorRestoresConnectivity(t *testing.T) {
+	world := newTestWorld(20, 10)
+	fillTestBiome(world, BiomePlains)
+	for y := range world.Grid {
+		world.Grid[y][5].Biome = BiomeDeepWater
+	}
+	world.MovementCorridorSystem.Analyze(world)
+	if world.MovementCorridorSystem.HabitatPatches != 2 {
+		t.Fatalf("Expected river to split habitat into 2 patches, got %d", world.MovementCorridorSystem.HabitatPatches)
+	}
+
+	_, err := world.OperatorStructures.BuildStructure(world, OperatorStructureCorridor, "canal bridge", []GridPoint{{X: 5, Y: 4}}, 0)
+	if err != nil {
+		t.Fatalf("Failed to build corridor: %v", err)
+	}
+	world.MovementCorridorSystem.Analyze(world)
+	if world.MovementCorridorSystem.HabitatPatches != 1 {
+		t.Errorf("Expected corridor to reconnect habitat, got %d patches", world.MovementCorridorSystem.HabitatPatches)
+	}
+}
+
+func TestFragmentationExperimentPhases(t *testing.T) {
+	world := newTestWorld(20, 10)
+	fillTestBiome(world, BiomePlains)
+	oss := world.OperatorStructures
+
+	structure, err := oss.BuildStructure(world, OperatorStructureBarrier, "fence", RasterizeLine(5, 0, 5, 9), 10)
+	if err != nil {
+		t.Fatalf("Failed to build barrier: %v", err)
+	}
+	if structure.Active {
+		t.Fatal("Expected barrier with baseline period to start inactive")
+	}
+	if len(oss.Experiments) != 1 {
+		t.Fatalf("Expected an experiment to start, got %d", len(oss.Experiments))
+	}
+
+	experiment := oss.Experiments[0]
+	entity := NewEntity(sharedRand, 1, []string{"size"}, "herbivore", Position{X: 8, Y: 5})
+	world.AllEntities = append(world.AllEntities, entity)
+
+	// Baseline: the entity wanders freely between regions
+	for i := 0; i < 10; i++ {
+		world.Tick++
+		if i%2 == 0 {
+			entity.Position.X = 13
+		} else {
+			entity.Position.X = 8
+		}
+		oss.Update(world)
+	}
+
+	if experiment.Phase != ExperimentPhaseTreatment {
+		t.Fatalf("Expected experiment in treatment phase, got %s", experiment.Phase)
+	}
+	if !structure.Active {
+		t.Error("Expected barrier to activate after baseline")
+	}
+	if experiment.Before.Crossings == 0 {
+		t.Error("Expected crossings during baseline")
+	}
+
+	for i := 0; i < experiment.TreatmentTicks; i++ {
+		world.Tick++
+		entity.Position.X = 13
+		oss.Update(world)
+	}
+
+	if experiment.Phase != ExperimentPhaseComplete {
+		t.Fatalf("Expected experiment to complete, got %s", experiment.Phase)
+	}
+	if experiment.After.CrossingRate >= experiment.Before.CrossingRate {
+		t.Errorf("Expected barrier to reduce crossing rate: before %.2f, after %.2f",
+			experiment.Before.CrossingRate, experiment.After.CrossingRate)
+	}
+	if experiment.Summary == "" {
+		t.Error("Expected experiment summary")
+	}
+}
@@ -34,6 +34,8 @@ type OrganismLifespanData struct {
 type OrganismClassifier struct {
 	LifespanData map[OrganismClassification]*OrganismLifespanData
 	TimeSystem   *AdvancedTimeSystem
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewOrganismClassifier creates a new organism classification system
@@ -41,6 +43,7 @@ func NewOrganismClassifier(timeSystem *AdvancedTimeSystem) *OrganismClassifier {
 	classifier := &OrganismClassifier{
 		LifespanData: make(map[OrganismClassification]*OrganismLifespanData),
 		TimeSystem:   timeSystem,
+		rng:          sharedRand,
 	}
 
 	// Initialize realistic lifespan data based on biological principles
@@ -193,6 +196,14 @@ func (oc *OrganismClassifier) classifyByTraits(entity *Entity) OrganismClassific
 	}
 }
 
+// withRand returns a copy of the classifier drawing from another source, for a worker
+// that must not share the world's
+func (oc *OrganismClassifier) withRand(rng *rand.Rand) *OrganismClassifier {
+	copy := *oc
+	copy.rng = rng
+	return &copy
+}
+
 // CalculateLifespan determines the actual lifespan for a specific entity
 func (oc *OrganismClassifier) CalculateLifespan(entity *Entity, classification OrganismClassification) int {
 	data := oc.LifespanData[classification]
@@ -202,7 +213,7 @@ func (oc *OrganismClassifier) CalculateLifespan(entity *Entity, classification O
 	size := entity.GetTrait("size")
 
 	// Base lifespan with variance
-	variance := (rand.Float64() - 0.5) * 2 * data.LifespanVariance
+	variance := (oc.rng.Float64() - 0.5) * 2 * data.LifespanVariance
 	baseLifespan := float64(data.BaseLifespanTicks) * (1.0 + variance)
 
 	// Trait modifiers
@@ -265,7 +276,7 @@ func (oc *OrganismClassifier) ShouldAge(entity *Entity, classification OrganismC
 		return true
 	} else {
 		// Slow aging - probabilistic
-		return rand.Float64() < agingRate
+		return oc.rng.Float64() < agingRate
 	}
 }
 
@@ -282,12 +293,26 @@ func (oc *OrganismClassifier) IsDeathByOldAge(entity *Entity, classification Org
 	if entity.Age >= data.SenescenceAge {
 		senescenceProgress := float64(entity.Age-data.SenescenceAge) / float64(maxLifespan-data.SenescenceAge)
 		deathProbability := senescenceProgress * 0.01 // Up to 1% chance per tick in late senescence
-		return rand.Float64() < deathProbability
+		return oc.rng.Float64() < deathProbability
 	}
 
 	return false
 }
 
+// ScaleLifespans stretches every classification's lifespan along with its ages of
+// maturity, peak fitness and senescence, so life stages keep their proportions
+func (oc *OrganismClassifier) ScaleLifespans(scale float64) {
+	scaleTicks := func(ticks int) int {
+		return max(1, int(math.Round(float64(ticks)*scale)))
+	}
+	for _, data := range oc.LifespanData {
+		data.BaseLifespanTicks = scaleTicks(data.BaseLifespanTicks)
+		data.MaturationAge = scaleTicks(data.MaturationAge)
+		data.PeakAge = scaleTicks(data.PeakAge)
+		data.SenescenceAge = scaleTicks(data.SenescenceAge)
+	}
+}
+
 // GetLifespanData returns the lifespan data for a classification
 func (oc *OrganismClassifier) GetLifespanData(classification OrganismClassification) *OrganismLifespanData {
 	return oc.LifespanData[classification]
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"math/rand"
 	"testing"
 )
 
@@ -44,7 +45,7 @@ func TestEntityClassificationByTraits(t *testing.T) {
 	classifier := NewOrganismClassifier(timeSystem)
 
 	// Test prokaryotic classification (low intelligence, size, cooperation)
-	prokaryoticEntity := NewEntity(1, []string{"intelligence", "size", "cooperation"}, "test", Position{})
+	prokaryoticEntity := NewEntity(sharedRand, 1, []string{"intelligence", "size", "cooperation"}, "test", Position{})
 	prokaryoticEntity.SetTrait("intelligence", -0.8)
 	prokaryoticEntity.SetTrait("size", -0.6)
 	prokaryoticEntity.SetTrait("cooperation", -0.7)
@@ -55,7 +56,7 @@ func TestEntityClassificationByTraits(t *testing.T) {
 	}
 
 	// Test advanced multicellular classification (high intelligence, size, cooperation)
-	advancedEntity := NewEntity(2, []string{"intelligence", "size", "cooperation"}, "test", Position{})
+	advancedEntity := NewEntity(sharedRand, 2, []string{"intelligence", "size", "cooperation"}, "test", Position{})
 	advancedEntity.SetTrait("intelligence", 0.8)
 	advancedEntity.SetTrait("size", 0.7)
 	advancedEntity.SetTrait("cooperation", 0.9)
@@ -70,7 +71,7 @@ func TestLifespanCalculation(t *testing.T) {
 	timeSystem := NewAdvancedTimeSystemLegacy(480, 120)
 	classifier := NewOrganismClassifier(timeSystem)
 
-	entity := NewEntity(1, []string{"endurance", "size"}, "test", Position{})
+	entity := NewEntity(sharedRand, 1, []string{"endurance", "size"}, "test", Position{})
 	entity.SetTrait("endurance", 0.5)
 	entity.SetTrait("size", 0.3)
 
@@ -106,7 +107,7 @@ func TestAgingRateCalculation(t *testing.T) {
 	timeSystem := NewAdvancedTimeSystemLegacy(480, 120)
 	classifier := NewOrganismClassifier(timeSystem)
 
-	entity := NewEntity(1, []string{"metabolism", "size"}, "test", Position{})
+	entity := NewEntity(sharedRand, 1, []string{"metabolism", "size"}, "test", Position{})
 	entity.SetTrait("metabolism", 0.5)
 	entity.SetTrait("size", 0.2)
 
@@ -129,9 +130,11 @@ func TestAgingRateCalculation(t *testing.T) {
 func TestLifespanProgression(t *testing.T) {
 	timeSystem := NewAdvancedTimeSystemLegacy(480, 120)
 	classifier := NewOrganismClassifier(timeSystem)
+	// Lifespans carry random variance, so seed the classifier to keep the progression checked stable
+	classifier.rng = rand.New(rand.NewSource(1))
 
 	// Test that more complex organisms have longer lifespans
-	entity := NewEntity(1, []string{"endurance", "size"}, "test", Position{})
+	entity := NewEntity(sharedRand, 1, []string{"endurance", "size"}, "test", Position{})
 	entity.SetTrait("endurance", 0.0)
 	entity.SetTrait("size", 0.0)
 
@@ -152,7 +155,7 @@ func TestDeathByOldAge(t *testing.T) {
 	timeSystem := NewAdvancedTimeSystemLegacy(480, 120)
 	classifier := NewOrganismClassifier(timeSystem)
 
-	entity := NewEntity(1, []string{"endurance"}, "test", Position{})
+	entity := NewEntity(sharedRand, 1, []string{"endurance"}, "test", Position{})
 	entity.SetTrait("endurance", 0.0)
 
 	classification := ClassificationEukaryotic
@@ -183,7 +186,7 @@ func TestEntityUpdateWithClassification(t *testing.T) {
 	dnaSystem := NewDNASystem(NewCentralEventBus(1000))
 	cellularSystem := NewCellularSystem(dnaSystem, NewCentralEventBus(1000))
 
-	entity := NewEntity(1, []string{"intelligence", "size", "cooperation", "endurance"}, "test", Position{})
+	entity := NewEntity(sharedRand, 1, []string{"intelligence", "size", "cooperation", "endurance"}, "test", Position{})
 	entity.SetTrait("intelligence", 0.0)
 	entity.SetTrait("size", 0.0)
 	entity.SetTrait("cooperation", 0.0)
@@ -215,6 +218,8 @@ func TestEntityUpdateWithClassification(t *testing.T) {
 func TestRealisticLifespanRanges(t *testing.T) {
 	timeSystem := NewAdvancedTimeSystemLegacy(480, 120)
 	classifier := NewOrganismClassifier(timeSystem)
+	// Lifespans carry random variance, so seed the classifier to keep the ranges checked stable
+	classifier.rng = rand.New(rand.NewSource(1))
 
 	ticksPerDay := float64(timeSystem.DayLength)
 
@@ -232,7 +237,7 @@ func TestRealisticLifespanRanges(t *testing.T) {
 		{ClassificationAdvancedMulticellular, 500, 2500}, // Year+ (scaled with variance)
 	}
 
-	entity := NewEntity(1, []string{"endurance", "size"}, "test", Position{})
+	entity := NewEntity(sharedRand, 1, []string{"endurance", "size"}, "test", Position{})
 	entity.SetTrait("endurance", 0.0)
 	entity.SetTrait("size", 0.0)
 
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// PetriDishConfig describes a small homogeneous world for controlled experiments
+type PetriDishConfig struct {
+	Size           float64   `json:"size"`            // World width and height
+	GridSize       int       `json:"grid_size"`       // Grid cells per side
+	Biome          BiomeType `json:"biome"`           // Single biome covering the whole dish
+	Temperature    float64   `json:"temperature"`     // Biome temperature modifier (-1 to 1, 0 = normal)
+	FoodDensity    float64   `json:"food_density"`    // Target plants per grid cell
+	FoodType       PlantType `json:"food_type"`       // Plant type used as food
+	ReplenishFood  bool      `json:"replenish_food"`  // Top food back up to the target density every tick
+	PopulationSize int       `json:"population_size"` // Entities per population added with AddPopulation
+	Seed           int64     `json:"seed,omitempty"`  // Seed of the dish's random source; 0 picks one from the clock
+}
+
+// DefaultPetriDishConfig returns a small plains dish with normal temperature and moderate food
+func DefaultPetriDishConfig() PetriDishConfig {
+	return PetriDishConfig{
+		Size:           40,
+		GridSize:       10,
+		Biome:          BiomePlains,
+		Temperature:    0,
+		FoodDensity:    0.5,
+		FoodType:       PlantGrass,
+		ReplenishFood:  false,
+		PopulationSize: 10,
+	}
+}
+
+// PetriDish is a fast, homogeneous micro-world where single variables can be adjusted
+// while everything else is held constant. Random world events and population respawning
+// are disabled, so populations only change through births and deaths.
+type PetriDish struct {
+	Config PetriDishConfig
+	World  *World
+}
+
+// PetriDishStats is a snapshot of a petri dish
+type PetriDishStats struct {
+	Tick        int            `json:"tick"`
+	Temperature float64        `json:"temperature"`
+	FoodDensity float64        `json:"food_density"`
+	Plants      int            `json:"plants"`
+	Population  int            `json:"population"`
+	BySpecies   map[string]int `json:"by_species"`
+	MeanEnergy  float64        `json:"mean_energy"`
+}
+
+// NewPetriDish creates a petri dish, filling missing config values with defaults
+func NewPetriDish(config PetriDishConfig) *PetriDish {
+	defaults := DefaultPetriDishConfig()
+	if config.Size <= 0 {
+		config.Size = defaults.Size
+	}
+	if config.GridSize <= 0 {
+		config.GridSize = defaults.GridSize
+	}
+	if config.PopulationSize <= 0 {
+		config.PopulationSize = defaults.PopulationSize
+	}
+	if config.FoodDensity < 0 {
+		config.FoodDensity = 0
+	}
+
+	world := NewWorld(WorldConfig{
+		Width:          config.Size,
+		Height:         config.Size,
+		PopulationSize: config.PopulationSize,
+		GridWidth:      config.GridSize,
+		GridHeight:     config.GridSize,
+		Seed:           config.Seed,
+	})
+	world.RandomEventsOff = true
+	world.RespawnOff = true
+
+	// Homogeneous environment: one biome everywhere and only the configured food
+	for y := range world.Grid {
+		for x := range world.Grid[y] {
+			world.Grid[y][x].Biome = config.Biome
+			world.Grid[y][x].Plants = world.Grid[y][x].Plants[:0]
+		}
+	}
+	world.AllPlants = make([]*Plant, 0)
+	world.AllEntities = make([]*Entity, 0)
+
+	dish := &PetriDish{Config: config, World: world}
+	dish.SetTemperature(config.Temperature)
+	dish.topUpFood()
+	return dish
+}
+
+// SetTemperature changes the temperature modifier of the dish's biome
+func (pd *PetriDish) SetTemperature(temperature float64) {
+	temperature = math.Max(-1, math.Min(1, temperature))
+	pd.Config.Temperature = temperature
+
+	// World biome definitions are per world, so this never affects other worlds
+	biome := pd.World.Biomes[pd.Config.Biome]
+	biome.Temperature = temperature
+	pd.World.Biomes[pd.Config.Biome] = biome
+}
+
+// SetFoodDensity changes the target plants per grid cell, adding food immediately if below target
+func (pd *PetriDish) SetFoodDensity(density float64) {
+	pd.Config.FoodDensity = math.Max(0, density)
+	pd.topUpFood()
+}
+
+// topUpFood plants food uniformly at random until the target density is reached
+func (pd *PetriDish) topUpFood() {
+	w := pd.World
+	target := int(pd.Config.FoodDensity * float64(w.Config.GridWidth*w.Config.GridHeight))
+
+	alive := 0
+	for _, plant := range w.AllPlants {
+		if plant.IsAlive {
+			alive++
+		}
+	}
+
+	for ; alive < target; alive++ {
+		pos := Position{X: w.Rand.Float64() * w.Config.Width, Y: w.Rand.Float64() * w.Config.Height}
+		plant := NewPlant(w.Rand, w.NextPlantID, pd.Config.FoodType, pos)
+		w.NextPlantID++
+		w.AllPlants = append(w.AllPlants, plant)
+		gridX, gridY := w.worldToGridCoords(pos.X, pos.Y)
+		w.Grid[gridY][gridX].Plants = append(w.Grid[gridY][gridX].Plants, plant)
+	}
+}
+
+// AddPopulation adds a population centred in the dish
+func (pd *PetriDish) AddPopulation(species string, baseTraits map[string]float64) {
+	pd.World.AddPopulation(PopulationConfig{
+		Name:             species,
+		Species:          species,
+		BaseTraits:       baseTraits,
+		StartPos:         Position{X: pd.Config.Size / 2, Y: pd.Config.Size / 2},
+		Spread:           pd.Config.Size / 3,
+		BaseMutationRate: 0.1,
+	})
+}
+
+// Step advances the dish by the given number of ticks
+func (pd *PetriDish) Step(ticks int) {
+	for i := 0; i < ticks; i++ {
+		pd.World.Update()
+		if pd.Config.ReplenishFood {
+			pd.topUpFood()
+		}
+	}
+}
+
+// Stats returns a snapshot of the dish
+func (pd *PetriDish) Stats() PetriDishStats {
+	stats := PetriDishStats{
+		Tick:        pd.World.Tick,
+		Temperature: pd.Config.Temperature,
+		FoodDensity: pd.Config.FoodDensity,
+		BySpecies:   make(map[string]int),
+	}
+	for _, plant := range pd.World.AllPlants {
+		if plant.IsAlive {
+			stats.Plants++
+		}
+	}
+	totalEnergy := 0.0
+	for _, entity := range pd.World.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		stats.Population++
+		stats.BySpecies[entity.Species]++
+		totalEnergy += entity.Energy
+	}
+	if stats.Population > 0 {
+		stats.MeanEnergy = totalEnergy / float64(stats.Population)
+	}
+	return stats
+}
+
+// PetriDishLab manages petri dishes running alongside the main world
+type PetriDishLab struct {
+	mu     sync.Mutex
+	dishes map[int]*PetriDish
+	nextID int
+}
+
+// NewPetriDishLab creates an empty lab
+func NewPetriDishLab() *PetriDishLab {
+	return &PetriDishLab{dishes: make(map[int]*PetriDish), nextID: 1}
+}
+
+// Create adds a new dish and returns its ID
+func (lab *PetriDishLab) Create(config PetriDishConfig) (int, *PetriDish) {
+	lab.mu.Lock()
+	defer lab.mu.Unlock()
+	id := lab.nextID
+	lab.nextID++
+	dish := NewPetriDish(config)
+	lab.dishes[id] = dish
+	return id, dish
+}
+
+// With runs fn on a dish while holding the lab lock
+func (lab *PetriDishLab) With(id int, fn func(dish *PetriDish)) error {
+	lab.mu.Lock()
+	defer lab.mu.Unlock()
+	dish, exists := lab.dishes[id]
+	if !exists {
+		return fmt.Errorf("petri dish %d not found", id)
+	}
+	fn(dish)
+	return nil
+}
+
+// Remove discards a dish
+func (lab *PetriDishLab) Remove(id int) bool {
+	lab.mu.Lock()
+	defer lab.mu.Unlock()
+	_, exists := lab.dishes[id]
+	delete(lab.dishes, id)
+	return exists
+}
+
+// List returns the stats of every dish keyed by ID, in ID order
+func (lab *PetriDishLab) List() []map[string]interface{} {
+	lab.mu.Lock()
+	defer lab.mu.Unlock()
+	ids := make([]int, 0, len(lab.dishes))
+	for id := range lab.dishes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	list := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		list = append(list, map[string]interface{}{
+			"id":     id,
+			"config": lab.dishes[id].Config,
+			"stats":  lab.dishes[id].Stats(),
+		})
+	}
+	return list
+}
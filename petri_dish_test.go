@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPetriDishIsHomogeneous(t *testing.T) {
+	config := DefaultPetriDishConfig()
+	config.Biome = BiomeDesert
+	config.FoodDensity = 2
+	dish := NewPetriDish(config)
+
+	for y := range dish.World.Grid {
+		for x := range dish.World.Grid[y] {
+			if dish.World.Grid[y][x].Biome != BiomeDesert {
+				t.Fatalf("Expected uniform desert, found %v at %d,%d", dish.World.Grid[y][x].Biome, x, y)
+			}
+		}
+	}
+	if len(dish.World.AllEntities) != 0 {
+		t.Errorf("Expected an empty dish, got %d entities", len(dish.World.AllEntities))
+	}
+	expectedPlants := int(config.FoodDensity * float64(config.GridSize*config.GridSize))
+	if len(dish.World.AllPlants) != expectedPlants {
+		t.Errorf("Expected %d plants, got %d", expectedPlants, len(dish.World.AllPlants))
+	}
+	for _, plant := range dish.World.AllPlants {
+		if plant.Type != config.FoodType {
+			t.Errorf("Expected only %v plants, found %v", config.FoodType, plant.Type)
+			break
+		}
+	}
+	if !dish.World.RandomEventsOff || !dish.World.RespawnOff {
+		t.Error("Expected random events and respawning to be disabled in a petri dish")
+	}
+}
+
+func TestPetriDishVariablesAreIsolated(t *testing.T) {
+	dish := NewPetriDish(DefaultPetriDishConfig())
+	other := NewPetriDish(DefaultPetriDishConfig())
+
+	dish.SetTemperature(0.8)
+	if dish.World.Biomes[BiomePlains].Temperature != 0.8 {
+		t.Errorf("Expected dish temperature 0.8, got %.2f", dish.World.Biomes[BiomePlains].Temperature)
+	}
+	if other.World.Biomes[BiomePlains].Temperature != 0 {
+		t.Errorf("Expected other dish to keep temperature 0, got %.2f", other.World.Biomes[BiomePlains].Temperature)
+	}
+
+	dish.SetTemperature(5)
+	if dish.Config.Temperature != 1 {
+		t.Errorf("Expected temperature clamped to 1, got %.2f", dish.Config.Temperature)
+	}
+
+	before := dish.Stats().Plants
+	dish.SetFoodDensity(3)
+	if dish.Stats().Plants <= before {
+		t.Errorf("Expected raising food density to add plants (had %d, now %d)", before, dish.Stats().Plants)
+	}
+}
+
+func TestPetriDishStepsPopulation(t *testing.T) {
+	config := DefaultPetriDishConfig()
+	config.ReplenishFood = true
+	dish := NewPetriDish(config)
+	dish.AddPopulation("herbivore", map[string]float64{"speed": 0.5, "size": 0.2})
+	if dish.Stats().Population != config.PopulationSize {
+		t.Fatalf("Expected %d entities, got %d", config.PopulationSize, dish.Stats().Population)
+	}
+
+	dish.Step(10)
+	stats := dish.Stats()
+	if stats.Tick != 10 {
+		t.Errorf("Expected tick 10, got %d", stats.Tick)
+	}
+	target := int(config.FoodDensity * float64(config.GridSize*config.GridSize))
+	if stats.Plants < target {
+		t.Errorf("Expected food replenished to at least %d, got %d", target, stats.Plants)
+	}
+}
+
+func TestPetriDishAPI(t *testing.T) {
+	wi := NewWebInterface(NewWorld(WorldConfig{Width: 50, Height: 50, GridWidth: 10, GridHeight: 10}))
+
+	body := []byte(`{"size": 30, "food_density": 1, "populations": [{"species": "herbivore", "traits": {"speed": 0.4}}]}`)
+	rec := httptest.NewRecorder()
+	wi.handlePetriDishes(rec, httptest.NewRequest(http.MethodPost, "/api/petri", bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Create failed: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handlePetriDish(rec, httptest.NewRequest(http.MethodPost, "/api/petri/dish?id=1", bytes.NewReader([]byte(`{"ticks": 3, "temperature": -0.5}`))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Update failed: %d %s", rec.Code, rec.Body.String())
+	}
+	var stats PetriDishStats
+	if err := json.Unmarshal(rec.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("Invalid stats: %v", err)
+	}
+	if stats.Tick != 3 || stats.Temperature != -0.5 {
+		t.Errorf("Expected tick 3 at temperature -0.5, got tick %d at %.2f", stats.Tick, stats.Temperature)
+	}
+	if wi.world.Tick != 0 {
+		t.Errorf("Expected main world to be unaffected, got tick %d", wi.world.Tick)
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handlePetriDish(rec, httptest.NewRequest(http.MethodDelete, "/api/petri/dish?id=1", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Expected delete to succeed, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	wi.handlePetriDish(rec, httptest.NewRequest(http.MethodGet, "/api/petri/dish?id=1", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected deleted dish to be gone, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PhylogenyNode is one plant species in the phylogenetic tree, with the species that split from it
+type PhylogenyNode struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	PlantType      string             `json:"plant_type"`
+	ParentID       int                `json:"parent_id"`                 // 0 for founding species
+	FormationTick  int                `json:"formation_tick"`            // When it split from its parent, or formed
+	ExtinctionTick int                `json:"extinction_tick,omitempty"` // When its last member died; 0 while living
+	Extinct        bool               `json:"extinct"`
+	Age            int                `json:"age"` // Ticks from formation to extinction or now
+	Population     int                `json:"population"`
+	PeakPopulation int                `json:"peak_population"`
+	SplitDistance  float64            `json:"split_distance"`       // Genetic distance from the parent at the split
+	Divergence     map[string]float64 `json:"divergence,omitempty"` // Founding traits minus the parent's at the split
+	TraitDrift     map[string]float64 `json:"trait_drift"`          // Current (or final) traits minus the founding ones
+	Descendants    int                `json:"descendants"`          // Species descended from it, extinct or not
+	Children       []*PhylogenyNode   `json:"children"`             // Oldest split first
+}
+
+// Phylogeny is the tree of plant species built from the speciation system's parent-child links
+type Phylogeny struct {
+	Tick    int              `json:"tick"`
+	Roots   []*PhylogenyNode `json:"roots"` // Founding species, oldest first
+	Species int              `json:"species"`
+	Living  int              `json:"living"`
+	Extinct int              `json:"extinct"`
+	Depth   int              `json:"depth"` // Most splits between a founder and a descendant
+}
+
+// BuildPhylogeny builds the phylogenetic tree of every species the speciation system has
+// tracked, living and extinct, as of the given tick
+func BuildPhylogeny(ss *SpeciationSystem, tick int) *Phylogeny {
+	phylogeny := &Phylogeny{Tick: tick, Roots: make([]*PhylogenyNode, 0)}
+	if ss == nil {
+		return phylogeny
+	}
+
+	nodes := make(map[int]*PhylogenyNode, len(ss.AllSpecies))
+	for _, id := range sortedKeys(ss.AllSpecies) {
+		species := ss.AllSpecies[id]
+		node := &PhylogenyNode{
+			ID:             species.ID,
+			Name:           species.Name,
+			PlantType:      GetPlantConfigs()[species.OriginPlantType].Name,
+			ParentID:       species.ParentSpeciesID,
+			FormationTick:  species.FormationTick,
+			Extinct:        species.IsExtinct,
+			Age:            tick - species.FormationTick,
+			Population:     len(species.Members),
+			PeakPopulation: species.PeakPopulation,
+			SplitDistance:  species.SplitDistance,
+			TraitDrift:     traitDifference(species.CurrentTraits, species.FoundingTraits),
+			Children:       make([]*PhylogenyNode, 0),
+		}
+		if species.IsExtinct {
+			node.ExtinctionTick = species.ExtinctionTick
+			node.Age = species.ExtinctionTick - species.FormationTick
+			node.Population = 0
+			phylogeny.Extinct++
+		} else {
+			phylogeny.Living++
+		}
+		if len(species.AncestorTraits) > 0 {
+			node.Divergence = traitDifference(species.FoundingTraits, species.AncestorTraits)
+		}
+		nodes[id] = node
+	}
+	phylogeny.Species = len(nodes)
+
+	for _, id := range sortedKeys(nodes) {
+		node := nodes[id]
+		if parent, exists := nodes[node.ParentID]; exists && node.ParentID != node.ID {
+			parent.Children = append(parent.Children, node)
+		} else {
+			node.ParentID = 0
+			phylogeny.Roots = append(phylogeny.Roots, node)
+		}
+	}
+	for _, root := range phylogeny.Roots {
+		if depth := sortPhylogeny(root); depth > phylogeny.Depth {
+			phylogeny.Depth = depth
+		}
+	}
+	sort.SliceStable(phylogeny.Roots, func(i, j int) bool {
+		return phylogeny.Roots[i].FormationTick < phylogeny.Roots[j].FormationTick
+	})
+	return phylogeny
+}
+
+// sortPhylogeny orders each node's children by when they split off and counts its descendants,
+// returning the depth of the subtree below it
+func sortPhylogeny(node *PhylogenyNode) int {
+	sort.SliceStable(node.Children, func(i, j int) bool {
+		return node.Children[i].FormationTick < node.Children[j].FormationTick
+	})
+	depth := 0
+	for _, child := range node.Children {
+		if childDepth := sortPhylogeny(child) + 1; childDepth > depth {
+			depth = childDepth
+		}
+		node.Descendants += child.Descendants + 1
+	}
+	return depth
+}
+
+// traitDifference is each trait of a minus the same trait of b, over the traits both have
+func traitDifference(a, b map[string]float64) map[string]float64 {
+	difference := make(map[string]float64)
+	for trait, value := range a {
+		if base, exists := b[trait]; exists {
+			difference[trait] = value - base
+		}
+	}
+	return difference
+}
+
+// Newick writes the tree in Newick format with branch lengths in ticks. A species carries on
+// after each split, so every split becomes an internal node whose branches are the parent
+// continuing and the daughter species. Founders hang from the start of the run, so the depth
+// of every node is the tick it happened at.
+func (p *Phylogeny) Newick() string {
+	var b strings.Builder
+	b.WriteString("(")
+	for i, root := range p.Roots {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		writeNewickLineage(&b, root, 0, 0)
+	}
+	b.WriteString(");")
+	return b.String()
+}
+
+// writeNewickLineage writes a species from the given tick, splitting off its children from the
+// given index on
+func writeNewickLineage(b *strings.Builder, node *PhylogenyNode, from, child int) {
+	if child == len(node.Children) {
+		b.WriteString(newickLabel(node.Name))
+		writeNewickLength(b, node.FormationTick+node.Age-from)
+		return
+	}
+	split := node.Children[child].FormationTick
+	b.WriteString("(")
+	writeNewickLineage(b, node, split, child+1)
+	b.WriteString(",")
+	writeNewickLineage(b, node.Children[child], split, 0)
+	b.WriteString(")")
+	writeNewickLength(b, split-from)
+}
+
+func writeNewickLength(b *strings.Builder, ticks int) {
+	if ticks < 0 {
+		ticks = 0
+	}
+	b.WriteString(":")
+	b.WriteString(strconv.Itoa(ticks))
+}
+
+// newickLabel quotes a species name when it holds characters Newick reserves
+func newickLabel(name string) string {
+	if !strings.ContainsAny(name, " ()[]':;,\t\n") {
+		return name
+	}
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testPhylogenySpecies tracks a founder that split twice, one daughter splitting again and the
+// other dying out
+func testPhylogenySpecies() *SpeciationSystem {
+	ss := NewSpeciationSystem()
+	add := func(id, parent, formed int, name string) *Species {
+		species := &Species{ID: id, Name: name, ParentSpeciesID: parent, FormationTick: formed,
+			FoundingTraits: map[string]float64{"height": 0.5}, CurrentTraits: map[string]float64{"height": 0.5}}
+		ss.AllSpecies[id] = species
+		if parent != 0 {
+			ss.AllSpecies[parent].ChildSpeciesIDs = append(ss.AllSpecies[parent].ChildSpeciesIDs, id)
+			species.AncestorTraits = map[string]float64{"height": 0.2}
+		}
+		return species
+	}
+	add(1, 0, 0, "A")
+	add(3, 1, 30, "C")
+	add(2, 1, 10, "B").CurrentTraits["height"] = 0.9
+	add(4, 2, 20, "Old oak")
+	ss.AllSpecies[3].IsExtinct = true
+	ss.AllSpecies[3].ExtinctionTick = 50
+	return ss
+}
+
+func TestPhylogenyTree(t *testing.T) {
+	phylogeny := BuildPhylogeny(testPhylogenySpecies(), 100)
+	if phylogeny.Species != 4 || phylogeny.Living != 3 || phylogeny.Extinct != 1 || phylogeny.Depth != 2 || len(phylogeny.Roots) != 1 {
+		t.Fatalf("Expected one founder two splits deep with one extinct daughter, got %+v", phylogeny)
+	}
+	root := phylogeny.Roots[0]
+	if root.Descendants != 3 || len(root.Children) != 2 || root.Children[0].Name != "B" {
+		t.Fatalf("Expected the founder's daughters oldest first, got %+v", root)
+	}
+	daughter, extinct := root.Children[0], root.Children[1]
+	if math.Abs(daughter.TraitDrift["height"]-0.4) > 1e-9 || math.Abs(daughter.Divergence["height"]-0.3) > 1e-9 {
+		t.Errorf("Expected the drift since forming and the divergence from the parent, got %v and %v", daughter.TraitDrift, daughter.Divergence)
+	}
+	if !extinct.Extinct || extinct.Age != 20 || extinct.Population != 0 {
+		t.Errorf("Expected the extinct branch to end when it died out, got %+v", extinct)
+	}
+
+	// Every split is an internal node, so each node's depth is the tick it happened at
+	if newick := phylogeny.Newick(); newick != "(((A:70,C:20):20,(B:80,'Old oak':80):10):10);" {
+		t.Errorf("Unexpected Newick tree %s", newick)
+	}
+	if newick := BuildPhylogeny(nil, 5).Newick(); newick != "();" {
+		t.Errorf("Expected an empty tree, got %s", newick)
+	}
+}
+
+func TestSpeciationRecordsDivergence(t *testing.T) {
+	plants := make([]*Plant, 0, 6)
+	for i := 0; i < 6; i++ {
+		plant := NewPlant(sharedRand, i, PlantGrass, Position{})
+		for name, trait := range plant.Traits {
+			trait.Value = float64(i / 3)
+			plant.Traits[name] = trait
+		}
+		plants = append(plants, plant)
+	}
+
+	ss := NewSpeciationSystem()
+	ss.Update(plants[:3], 7)
+	if len(ss.AllSpecies) != 1 || ss.AllSpecies[1].FormationTick != 7 {
+		t.Fatalf("Expected the founding species to form at tick 7, got %+v", ss.AllSpecies)
+	}
+	founder := ss.AllSpecies[1]
+	founder.Members = plants
+	ss.checkSpeciesForSplit(founder, 12)
+	daughter := ss.AllSpecies[2]
+	if daughter == nil || daughter.ParentSpeciesID != 1 || daughter.FormationTick != 12 {
+		t.Fatalf("Expected a daughter split from the founder at tick 12, got %+v", daughter)
+	}
+	if daughter.SplitDistance <= ss.GeneticDistanceThreshold || math.Abs(daughter.AncestorTraits["hardiness"]-0.5) > 1e-9 {
+		t.Errorf("Expected the split distance and the parent's traits at the split, got %v and %v", daughter.SplitDistance, daughter.AncestorTraits)
+	}
+}
+
+func TestPhylogenyAPI(t *testing.T) {
+	wi := &WebInterface{world: newGraphQLTestWorld()}
+	wi.world.SpeciationSystem = testPhylogenySpecies()
+
+	var phylogeny Phylogeny
+	if code := callControlAPI(t, wi.handlePhylogeny, http.MethodGet, "/api/phylogeny", "", &phylogeny); code != http.StatusOK || phylogeny.Species != 4 {
+		t.Fatalf("Expected the tree, got %d %+v", code, phylogeny)
+	}
+
+	rec := httptest.NewRecorder()
+	wi.handlePhylogeny(rec, httptest.NewRequest(http.MethodGet, "/api/phylogeny?format=newick", nil))
+	body, _ := io.ReadAll(rec.Body)
+	if rec.Header().Get("Content-Type") != "text/x-nh" || !strings.HasSuffix(strings.TrimSpace(string(body)), ");") {
+		t.Errorf("Expected a Newick file, got %s", body)
+	}
+	if code := callControlAPI(t, wi.handlePhylogeny, http.MethodGet, "/api/phylogeny?format=xml", "", nil); code != http.StatusBadRequest {
+		t.Errorf("Expected an unknown format refused, got %d", code)
+	}
+}
@@ -66,6 +66,7 @@ type PhysicsSystem struct {
 	ViscosityMap       map[BiomeType]float64 // Viscosity by biome type
 	CollisionsThisTick int                   // Collisions detected this tick
 	TotalCollisions    int                   // Total collisions over time
+	AttractionRange    float64               // Entities farther apart than this exert no force on each other
 }
 
 // NewPhysicsSystem creates a new physics system
@@ -75,6 +76,7 @@ func NewPhysicsSystem() *PhysicsSystem {
 		FluidDensity:       1.0,
 		CollisionsThisTick: 0,
 		TotalCollisions:    0,
+		AttractionRange:    20.0, // The inverse-square forces are negligible beyond this
 		ViscosityMap: map[BiomeType]float64{
 			BiomePlains:    0.1,  // Low resistance
 			BiomeForest:    0.2,  // Trees create drag
@@ -125,14 +127,16 @@ func (ps *PhysicsSystem) ApplyForce(physics *PhysicsComponent, force Vector2D) {
 }
 
 // CalculateAttraction computes gravitational-like attraction between entities
-func (ps *PhysicsSystem) CalculateAttraction(entity1, entity2 *Entity, physics1, physics2 *PhysicsComponent) Vector2D {
-	dx := entity2.Position.X - entity1.Position.X
-	dy := entity2.Position.Y - entity1.Position.Y
+func (ps *PhysicsSystem) CalculateAttraction(entity1, entity2 *Entity, physics1, physics2 *PhysicsComponent, geometry WorldGeometry) Vector2D {
+	dx, dy := geometry.Direction(entity1.Position, entity2.Position)
 	distance := math.Sqrt(dx*dx + dy*dy)
 
 	if distance < 0.1 {
 		return Vector2D{0, 0} // Avoid division by zero
 	}
+	if distance > ps.attractionReach() {
+		return Vector2D{0, 0}
+	}
 
 	// Force magnitude based on mass and cooperation
 	cooperation1 := entity1.GetTrait("cooperation")
@@ -162,6 +166,14 @@ func (ps *PhysicsSystem) CalculateAttraction(entity1, entity2 *Entity, physics1,
 	return Vector2D{0, 0}
 }
 
+// attractionReach is how far apart entities still pull on each other, unlimited without a range
+func (ps *PhysicsSystem) attractionReach() float64 {
+	if ps.AttractionRange > 0 {
+		return ps.AttractionRange
+	}
+	return math.Inf(1)
+}
+
 // FluidRegion represents areas with special fluid properties
 type FluidRegion struct {
 	Center    Position
@@ -172,11 +184,9 @@ type FluidRegion struct {
 }
 
 // ApplyFluidEffects applies fluid dynamics to entities in special regions
-func (ps *PhysicsSystem) ApplyFluidEffects(entity *Entity, physics *PhysicsComponent, regions []FluidRegion) {
+func (ps *PhysicsSystem) ApplyFluidEffects(entity *Entity, physics *PhysicsComponent, regions []FluidRegion, geometry WorldGeometry) {
 	for _, region := range regions {
-		dx := entity.Position.X - region.Center.X
-		dy := entity.Position.Y - region.Center.Y
-		distance := math.Sqrt(dx*dx + dy*dy)
+		distance := geometry.Distance(entity.Position, region.Center)
 
 		if distance <= region.Radius {
 			// Entity is in this fluid region
@@ -211,31 +221,52 @@ func NewCollisionSystem() *CollisionSystem {
 
 // CheckCollisions detects and resolves collisions between entities
 func (cs *CollisionSystem) CheckCollisions(entities []*Entity, physicsComponents map[int]*PhysicsComponent, physicsSystem *PhysicsSystem, world *World) {
-	for i, entity1 := range entities {
-		if !entity1.IsAlive {
-			continue
-		}
+	components := NewEntityComponents()
+	components.Sync(entities, physicsComponents, nil, nil)
+	cs.CheckComponentCollisions(components, physicsSystem, world)
+}
 
-		physics1 := physicsComponents[entity1.ID]
-		if physics1 == nil {
-			continue
+// CheckComponentCollisions detects and resolves collisions between the living entities with
+// physics in a component store, bucketing them by cell so each is only checked against its
+// neighbours
+func (cs *CollisionSystem) CheckComponentCollisions(components *EntityComponents, physicsSystem *PhysicsSystem, world *World) {
+	positions, health, physics := components.Positions, components.Health, components.Physics
+	sizes := components.Trait("size")
+	if sizes == nil {
+		sizes = make([]float64, components.Len())
+	}
+	colliding := make([]*Entity, 0, components.Len())
+	slots := make([]int, 0, components.Len())
+	maxSize := math.Inf(-1)
+	for slot := 0; slot < components.Len(); slot++ {
+		if health[slot].Alive && physics[slot] != nil {
+			colliding = append(colliding, components.Entities[slot])
+			slots = append(slots, slot)
+			maxSize = math.Max(maxSize, sizes[slot])
 		}
+	}
+	var index spatialLayer[*Entity]
+	index.sync(colliding, entityPosition, true)
+	var geometry WorldGeometry = BoxGeometry{}
+	if world != nil {
+		geometry = world.Geometry()
+	}
 
-		for j, entity2 := range entities {
-			if i >= j || !entity2.IsAlive {
-				continue
-			}
-
-			physics2 := physicsComponents[entity2.ID]
-			if physics2 == nil {
+	for a, i := range slots {
+		reach := cs.CollisionRadius*(1.0+sizes[i]) + cs.CollisionRadius*(1.0+maxSize)
+		for _, b := range index.query(positions[i], reach, entityPosition, geometry) {
+			if b <= a {
 				continue
 			}
-
-			distance := entity1.DistanceTo(entity2)
-			collisionDistance := cs.CollisionRadius*(1.0+entity1.GetTrait("size")) +
-				cs.CollisionRadius*(1.0+entity2.GetTrait("size"))
+			j := slots[b]
+			dx, dy := geometry.Direction(positions[j], positions[i])
+			distance := math.Sqrt(dx*dx + dy*dy)
+			collisionDistance := cs.CollisionRadius*(1.0+sizes[i]) +
+				cs.CollisionRadius*(1.0+sizes[j])
 
 			if distance < collisionDistance {
+				entity1, entity2 := components.Entities[i], components.Entities[j]
+
 				// Emit collision event to central event bus
 				if world != nil && world.CentralEventBus != nil {
 					metadata := map[string]interface{}{
@@ -252,7 +283,9 @@ func (cs *CollisionSystem) CheckCollisions(entities []*Entity, physicsComponents
 				}
 
 				// Collision detected - apply elastic collision physics
-				cs.resolveCollision(entity1, entity2, physics1, physics2)
+				cs.resolveCollision(entity1, entity2, physics[i], physics[j], geometry)
+				components.RefreshPosition(i)
+				components.RefreshPosition(j)
 				// Track collision
 				if physicsSystem != nil {
 					physicsSystem.IncrementCollisionCount()
@@ -263,10 +296,9 @@ func (cs *CollisionSystem) CheckCollisions(entities []*Entity, physicsComponents
 }
 
 // resolveCollision handles the physics of entity collisions
-func (cs *CollisionSystem) resolveCollision(entity1, entity2 *Entity, physics1, physics2 *PhysicsComponent) {
+func (cs *CollisionSystem) resolveCollision(entity1, entity2 *Entity, physics1, physics2 *PhysicsComponent, geometry WorldGeometry) {
 	// Calculate collision normal
-	dx := entity2.Position.X - entity1.Position.X
-	dy := entity2.Position.Y - entity1.Position.Y
+	dx, dy := geometry.Direction(entity1.Position, entity2.Position)
 	distance := math.Sqrt(dx*dx + dy*dy)
 
 	if distance == 0 {
@@ -6,12 +6,6 @@ import (
 	"math/rand"
 )
 
-// Season constants
-const (
-	SeasonSpring = "spring"
-	SeasonWinter = "winter"
-)
-
 // PlantType represents different types of plants
 type PlantType int
 
@@ -195,7 +189,7 @@ func GetPlantConfigs() map[PlantType]PlantConfig {
 }
 
 // NewPlant creates a new plant with random traits
-func NewPlant(id int, plantType PlantType, position Position) *Plant {
+func NewPlant(rng *rand.Rand, id int, plantType PlantType, position Position) *Plant {
 	config := GetPlantConfigs()[plantType]
 
 	plant := &Plant{
@@ -218,7 +212,7 @@ func NewPlant(id int, plantType PlantType, position Position) *Plant {
 	for _, name := range traitNames {
 		plant.Traits[name] = Trait{
 			Name:  name,
-			Value: (rand.Float64()*2 - 1) * 0.5, // Smaller initial variation for plants
+			Value: (rng.Float64()*2 - 1) * 0.5, // Smaller initial variation for plants
 		}
 	}
 
@@ -255,8 +249,25 @@ func (p *Plant) SetTrait(name string, value float64) {
 	p.Traits[name] = Trait{Name: name, Value: value}
 }
 
+// PlantShare is what a plant receives from its biome's resource budget
+type PlantShare struct {
+	Supply         float64 // Share of its demand met, 0-1
+	Photosynthesis float64 // Energy made from a full share, per unit of size plus one
+	Light          float64 // Scales photosynthesis by the daylight on the plant, 1 in average light
+	SoilBonus      float64 // Most that rich soil adds to growth, as a share of normal growth
+}
+
+// unbudgetedShare is the share of plants in worlds without a resource budget
+var unbudgetedShare = PlantShare{Supply: 1, Light: 1, SoilBonus: math.Inf(1)}
+
 // Update handles plant growth, aging, and natural death
 func (p *Plant) Update(biome Biome) {
+	p.UpdateWithBudget(biome, unbudgetedShare)
+}
+
+// UpdateWithBudget handles plant growth, aging, and natural death for a plant growing only
+// as far as its share of the biome's resource budget allows
+func (p *Plant) UpdateWithBudget(biome Biome, share PlantShare) {
 	if !p.IsAlive {
 		return
 	}
@@ -285,7 +296,11 @@ func (p *Plant) Update(biome Biome) {
 
 	// Growth
 	hardiness := p.GetTrait("hardiness")
-	growthRate := (p.GrowthRate + hardiness*0.1) * biomeSuitability
+	growthRate := (p.GrowthRate + hardiness*0.1) * biomeSuitability * share.Supply
+
+	// Photosynthesis runs on the share of resources received, and the plant wilts in
+	// proportion to the demand left unmet
+	p.Energy += (share.Photosynthesis*share.Light*share.Supply - (1 - share.Supply)) * (1 + math.Max(0, p.Size))
 
 	if p.Energy > 10 {
 		energyGrowth := growthRate * 2
@@ -312,7 +327,7 @@ func (p *Plant) Update(biome Biome) {
 }
 
 // CanReproduce checks if the plant can reproduce
-func (p *Plant) CanReproduce() bool {
+func (p *Plant) CanReproduce(rng *rand.Rand) bool {
 	if !p.IsAlive || p.Age < 5 {
 		return false
 	}
@@ -323,35 +338,36 @@ func (p *Plant) CanReproduce() bool {
 	reproductionBonus := p.GetTrait("reproduction_rate")
 	reproductionChance := config.ReproductionRate + reproductionBonus*0.1
 
-	return p.Energy > energyThreshold && rand.Float64() < reproductionChance
+	return p.Energy > energyThreshold && rng.Float64() < reproductionChance
 }
 
 // Reproduce creates a new plant offspring
-func (p *Plant) Reproduce(newID int) *Plant {
-	if !p.CanReproduce() {
+func (p *Plant) Reproduce(rng *rand.Rand, newID int) *Plant {
+	if !p.CanReproduce(rng) {
 		return nil
 	}
 
 	// Create offspring near parent
-	offset := 2.0 + rand.Float64()*3.0
-	angle := rand.Float64() * 2 * math.Pi
+	offset := 2.0 + rng.Float64()*3.0
+	angle := rng.Float64() * 2 * math.Pi
 
 	newPos := Position{
 		X: p.Position.X + math.Cos(angle)*offset,
 		Y: p.Position.Y + math.Sin(angle)*offset,
 	}
 
-	offspring := NewPlant(newID, p.Type, newPos)
+	offspring := NewPlant(rng, newID, p.Type, newPos)
 	offspring.Generation = p.Generation + 1
 
 	// Inherit traits with mutation
 	config := GetPlantConfigs()[p.Type]
-	for name, trait := range p.Traits {
+	for _, name := range sortedKeys(p.Traits) {
+		trait := p.Traits[name]
 		newValue := trait.Value
 
 		// Mutation
-		if rand.Float64() < config.MutationRate {
-			mutation := rand.NormFloat64() * 0.1
+		if rng.Float64() < config.MutationRate {
+			mutation := rng.NormFloat64() * 0.1
 			newValue += mutation
 			newValue = math.Max(-1.0, math.Min(1.0, newValue))
 		}
@@ -527,44 +543,44 @@ func (p *Plant) LogPlantBirth(world *World, parent *Plant, reproductionType stri
 // Enhanced Plant Nutrient System Functions
 
 // initializeSoilNutrients creates initial soil nutrient levels
-func initializeSoilNutrients() map[string]float64 {
+func initializeSoilNutrients(rng *rand.Rand) map[string]float64 {
 	nutrients := make(map[string]float64)
 
 	// Primary nutrients
-	nutrients["nitrogen"] = 0.3 + rand.Float64()*0.4   // 0.3-0.7
-	nutrients["phosphorus"] = 0.2 + rand.Float64()*0.3 // 0.2-0.5
-	nutrients["potassium"] = 0.2 + rand.Float64()*0.3  // 0.2-0.5
+	nutrients["nitrogen"] = 0.3 + rng.Float64()*0.4   // 0.3-0.7
+	nutrients["phosphorus"] = 0.2 + rng.Float64()*0.3 // 0.2-0.5
+	nutrients["potassium"] = 0.2 + rng.Float64()*0.3  // 0.2-0.5
 
 	// Secondary nutrients
-	nutrients["calcium"] = 0.1 + rand.Float64()*0.2   // 0.1-0.3
-	nutrients["magnesium"] = 0.1 + rand.Float64()*0.2 // 0.1-0.3
-	nutrients["sulfur"] = 0.1 + rand.Float64()*0.1    // 0.1-0.2
+	nutrients["calcium"] = 0.1 + rng.Float64()*0.2   // 0.1-0.3
+	nutrients["magnesium"] = 0.1 + rng.Float64()*0.2 // 0.1-0.3
+	nutrients["sulfur"] = 0.1 + rng.Float64()*0.1    // 0.1-0.2
 
 	// Micronutrients
-	nutrients["iron"] = 0.05 + rand.Float64()*0.05      // 0.05-0.1
-	nutrients["manganese"] = 0.02 + rand.Float64()*0.03 // 0.02-0.05
-	nutrients["zinc"] = 0.01 + rand.Float64()*0.02      // 0.01-0.03
+	nutrients["iron"] = 0.05 + rng.Float64()*0.05      // 0.05-0.1
+	nutrients["manganese"] = 0.02 + rng.Float64()*0.03 // 0.02-0.05
+	nutrients["zinc"] = 0.01 + rng.Float64()*0.02      // 0.01-0.03
 
 	return nutrients
 }
 
 // initializeWaterLevel sets initial water based on biome
-func initializeWaterLevel(biome BiomeType) float64 {
+func initializeWaterLevel(rng *rand.Rand, biome BiomeType) float64 {
 	switch biome {
 	case BiomeWater, BiomeDeepWater, BiomeSwamp:
-		return 0.9 + rand.Float64()*0.1 // 0.9-1.0
+		return 0.9 + rng.Float64()*0.1 // 0.9-1.0
 	case BiomeRainforest:
-		return 0.7 + rand.Float64()*0.2 // 0.7-0.9
+		return 0.7 + rng.Float64()*0.2 // 0.7-0.9
 	case BiomeForest, BiomePlains:
-		return 0.4 + rand.Float64()*0.3 // 0.4-0.7
+		return 0.4 + rng.Float64()*0.3 // 0.4-0.7
 	case BiomeDesert:
-		return 0.1 + rand.Float64()*0.2 // 0.1-0.3
+		return 0.1 + rng.Float64()*0.2 // 0.1-0.3
 	case BiomeIce, BiomeTundra:
-		return 0.2 + rand.Float64()*0.2 // 0.2-0.4 (frozen water)
+		return 0.2 + rng.Float64()*0.2 // 0.2-0.4 (frozen water)
 	case BiomeMountain, BiomeHighAltitude:
-		return 0.3 + rand.Float64()*0.2 // 0.3-0.5
+		return 0.3 + rng.Float64()*0.2 // 0.3-0.5
 	case BiomeHotSpring:
-		return 0.6 + rand.Float64()*0.3 // 0.6-0.9 (hot water)
+		return 0.6 + rng.Float64()*0.3 // 0.6-0.9 (hot water)
 	default:
 		return 0.5 // Moderate water level
 	}
@@ -695,7 +711,16 @@ func getPlantRootDepth(plantType PlantType) float64 {
 }
 
 // updatePlantNutrients handles realistic plant nutrition from soil, water, and decay
+// in a season, named in lower case, with the default season effects
 func (p *Plant) updatePlantNutrients(gridCell *GridCell, season string) float64 {
+	growth := DefaultSimulationConfig().Seasons.For(seasonNamed(season)).PlantGrowth
+	return p.updatePlantNutrientsWithBudget(gridCell, growth, unbudgetedShare)
+}
+
+// updatePlantNutrientsWithBudget handles plant nutrition for a plant whose share of the
+// resource budget limits the growth rich soil brings, in a season that multiplies its health by
+// growth
+func (p *Plant) updatePlantNutrientsWithBudget(gridCell *GridCell, growth float64, share PlantShare) float64 {
 	if !p.IsAlive {
 		return 0.0
 	}
@@ -717,7 +742,8 @@ func (p *Plant) updatePlantNutrients(gridCell *GridCell, season string) float64
 	}
 
 	// Check soil nutrients vs needs
-	for nutrient, needed := range p.NutrientNeeds {
+	for _, nutrient := range sortedKeys(p.NutrientNeeds) {
+		needed := p.NutrientNeeds[nutrient]
 		if nutrient == "water" {
 			continue // Already handled above
 		}
@@ -751,25 +777,14 @@ func (p *Plant) updatePlantNutrients(gridCell *GridCell, season string) float64
 	nutritionalHealth += organicBonus
 
 	// Seasonal effects
-	seasonalMultiplier := 1.0
-	switch season {
-	case SeasonSpring:
-		seasonalMultiplier = 1.2 // Growing season
-	case "summer":
-		seasonalMultiplier = 1.1 // Good growth but water stress possible
-	case "autumn":
-		seasonalMultiplier = 0.9 // Slowing down
-	case SeasonWinter:
-		seasonalMultiplier = 0.6 // Dormant season
-	}
-
-	nutritionalHealth *= seasonalMultiplier
+	nutritionalHealth *= growth
 
 	// Apply nutritional health to plant growth
 	if nutritionalHealth > 1.0 {
 		// Optimal conditions - bonus growth
-		p.Energy += (nutritionalHealth - 1.0) * 5.0
-		p.Size += (nutritionalHealth - 1.0) * 0.1
+		bonus := math.Min(nutritionalHealth-1.0, share.SoilBonus) * share.Supply
+		p.Energy += bonus * 5.0
+		p.Size += bonus * 0.1
 	} else if nutritionalHealth < 0.7 {
 		// Poor conditions - stress
 		p.Energy -= (0.7 - nutritionalHealth) * 3.0
@@ -787,9 +802,9 @@ func (p *Plant) updatePlantNutrients(gridCell *GridCell, season string) float64
 }
 
 // addDecayNutrientsToSoil adds nutrients from decaying organic matter to soil
-func addDecayNutrientsToSoil(gridCell *GridCell, decayItem *DecayableItem) {
+func addDecayNutrientsToSoil(rng *rand.Rand, gridCell *GridCell, decayItem *DecayableItem) {
 	if gridCell.SoilNutrients == nil {
-		gridCell.SoilNutrients = initializeSoilNutrients()
+		gridCell.SoilNutrients = initializeSoilNutrients(rng)
 	}
 
 	// Different decay sources provide different nutrients
@@ -831,13 +846,13 @@ func addDecayNutrientsToSoil(gridCell *GridCell, decayItem *DecayableItem) {
 }
 
 // processRainfall adds water and nutrients from precipitation
-func processRainfall(gridCell *GridCell, intensity float64) {
+func processRainfall(rng *rand.Rand, gridCell *GridCell, intensity float64) {
 	// Rain adds water
 	gridCell.WaterLevel = math.Min(1.0, gridCell.WaterLevel+intensity*0.3)
 
 	// Rain brings some nutrients (nitrogen from atmosphere)
 	if gridCell.SoilNutrients == nil {
-		gridCell.SoilNutrients = initializeSoilNutrients()
+		gridCell.SoilNutrients = initializeSoilNutrients(rng)
 	}
 
 	// Nitrogen fixation from rain
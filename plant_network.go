@@ -99,6 +99,8 @@ type PlantNetworkSystem struct {
 
 	// Event tracking
 	eventBus *CentralEventBus `json:"-"`
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NetworkCluster represents a group of connected plants
@@ -134,13 +136,14 @@ func NewPlantNetworkSystem(eventBus *CentralEventBus) *PlantNetworkSystem {
 		ActiveSignals:            0,
 		NetworkEfficiency:        0.0,
 		eventBus:                 eventBus,
+		rng:                      sharedRand,
 	}
 }
 
 // Update processes all network activities for one tick
-func (pns *PlantNetworkSystem) Update(allPlants []*Plant, currentTick int) {
+func (pns *PlantNetworkSystem) Update(allPlants []*Plant, currentTick int, geometry WorldGeometry) {
 	// 1. Form new connections between nearby compatible plants
-	pns.formNewConnections(allPlants, currentTick)
+	pns.formNewConnections(allPlants, currentTick, geometry)
 
 	// 2. Update existing connections (aging, health changes)
 	pns.updateConnections(currentTick)
@@ -165,7 +168,7 @@ func (pns *PlantNetworkSystem) Update(allPlants []*Plant, currentTick int) {
 }
 
 // formNewConnections attempts to create new connections between compatible plants
-func (pns *PlantNetworkSystem) formNewConnections(allPlants []*Plant, currentTick int) {
+func (pns *PlantNetworkSystem) formNewConnections(allPlants []*Plant, currentTick int, geometry WorldGeometry) {
 	for i, plantA := range allPlants {
 		if !plantA.IsAlive || plantA.Energy < 20 { // Need energy to form connections
 			continue
@@ -189,9 +192,7 @@ func (pns *PlantNetworkSystem) formNewConnections(allPlants []*Plant, currentTic
 			}
 
 			// Calculate distance
-			dx := plantA.Position.X - plantB.Position.X
-			dy := plantA.Position.Y - plantB.Position.Y
-			distance := math.Sqrt(dx*dx + dy*dy)
+			distance := geometry.Distance(plantA.Position, plantB.Position)
 
 			// Too far apart
 			if distance > pns.MaxConnectionDistance {
@@ -202,7 +203,7 @@ func (pns *PlantNetworkSystem) formNewConnections(allPlants []*Plant, currentTic
 			compatibility := pns.calculateCompatibility(plantA, plantB)
 			formationChance := pns.ConnectionFormationRate * compatibility * (1.0 - distance/pns.MaxConnectionDistance)
 
-			if rand.Float64() < formationChance {
+			if pns.rng.Float64() < formationChance {
 				connection := pns.createConnection(plantA, plantB, distance, currentTick)
 				pns.Connections = append(pns.Connections, connection)
 
@@ -256,9 +257,9 @@ func (pns *PlantNetworkSystem) createConnection(plantA, plantB *Plant, distance
 	}
 
 	// Calculate initial connection properties
-	initialStrength := 0.1 + rand.Float64()*0.3 // Start weak, can grow stronger
-	initialHealth := 0.7 + rand.Float64()*0.3   // Start reasonably healthy
-	efficiency := 0.3 + rand.Float64()*0.4      // Initial efficiency
+	initialStrength := 0.1 + pns.rng.Float64()*0.3 // Start weak, can grow stronger
+	initialHealth := 0.7 + pns.rng.Float64()*0.3   // Start reasonably healthy
+	efficiency := 0.3 + pns.rng.Float64()*0.4      // Initial efficiency
 
 	connection := &NetworkConnection{
 		ID:           pns.NextConnectionID,
@@ -425,7 +426,7 @@ func (pns *PlantNetworkSystem) transferResources(currentTick int) {
 			}
 
 			// Send chemical signal about successful resource sharing
-			if rand.Float64() < 0.3 { // 30% chance to signal
+			if pns.rng.Float64() < 0.3 { // 30% chance to signal
 				pns.sendChemicalSignal(donor, SignalNutrientAvailable, 0.6, currentTick,
 					"Shared resources through network")
 			}
@@ -443,7 +444,7 @@ func (pns *PlantNetworkSystem) sendChemicalSignal(source *Plant, signalType Chem
 		Type:      signalType,
 		Intensity: intensity,
 		Age:       0,
-		MaxAge:    30 + rand.Intn(20), // 30-50 ticks lifespan
+		MaxAge:    30 + pns.rng.Intn(20), // 30-50 ticks lifespan
 		Visited:   make(map[int]bool),
 		Message:   message,
 		Metadata:  make(map[string]float64),
@@ -523,7 +524,7 @@ func (pns *PlantNetworkSystem) propagateChemicalSignals(currentTick int) {
 			if fromPlant != nil && toPlant != nil && toPlant.IsAlive {
 				// Signal propagation probability based on connection quality
 				propagationChance := conn.Health * conn.Strength * signal.Intensity
-				if rand.Float64() < propagationChance {
+				if pns.rng.Float64() < propagationChance {
 					plantsToVisit = append(plantsToVisit, toPlant)
 				}
 			}
@@ -590,7 +591,7 @@ func (pns *PlantNetworkSystem) processSignalEffect(plant *Plant, signal *Chemica
 
 	case SignalThreatDetected:
 		// Prepare defensive responses
-		if rand.Float64() < effectiveness {
+		if pns.rng.Float64() < effectiveness {
 			// Temporarily increase toxicity as defense
 			plant.Toxicity += effectiveness * 0.1
 			// Reduce energy as plant prepares defenses
@@ -606,7 +607,7 @@ func (pns *PlantNetworkSystem) processSignalEffect(plant *Plant, signal *Chemica
 	case SignalReproductionReady:
 		// Might trigger reproductive behavior if plant is also ready
 		reproductionThreshold := 30.0 + plant.GetTrait("reproduction_rate")*20
-		if plant.Energy > reproductionThreshold && rand.Float64() < effectiveness*0.5 {
+		if plant.Energy > reproductionThreshold && pns.rng.Float64() < effectiveness*0.5 {
 			// Increase likelihood of reproduction (this would be handled by the main reproduction system)
 			// For now, just boost energy slightly as preparation
 			plant.Energy += 2.0
@@ -614,7 +615,7 @@ func (pns *PlantNetworkSystem) processSignalEffect(plant *Plant, signal *Chemica
 
 	case SignalToxicConditions:
 		// Prepare for environmental stress
-		if rand.Float64() < effectiveness {
+		if pns.rng.Float64() < effectiveness {
 			// Increase hardiness temporarily
 			hardiness := plant.GetTrait("hardiness")
 			plant.Traits["hardiness"] = Trait{Value: math.Min(1.0, hardiness+0.1)}
@@ -776,18 +777,18 @@ func (pns *PlantNetworkSystem) createNetworkCluster(plants []*Plant, currentTick
 func (pns *PlantNetworkSystem) maintainNetworkHealth() {
 	for _, conn := range pns.Connections {
 		// Environmental stress can damage connections
-		if rand.Float64() < 0.01 { // 1% chance of environmental damage per tick
+		if pns.rng.Float64() < 0.01 { // 1% chance of environmental damage per tick
 			conn.Health = math.Max(0.0, conn.Health-0.05)
 		}
 
 		// Very weak connections may break entirely
-		if conn.Health < 0.05 && rand.Float64() < 0.1 {
+		if conn.Health < 0.05 && pns.rng.Float64() < 0.1 {
 			conn.Health = 0.0
 		}
 
 		// Distance stress - longer connections are harder to maintain
 		distanceStress := math.Max(0.0, (conn.Distance-5.0)/10.0) // Stress increases beyond 5 units
-		if distanceStress > 0 && rand.Float64() < distanceStress*0.01 {
+		if distanceStress > 0 && pns.rng.Float64() < distanceStress*0.01 {
 			conn.Health = math.Max(0.0, conn.Health-0.02)
 		}
 	}
@@ -1013,7 +1014,7 @@ func (pns *PlantNetworkSystem) GetTopClusters(n int) []*NetworkCluster {
 }
 
 // DetectNetworkThreats checks for threats to network plants and sends warnings
-func (pns *PlantNetworkSystem) DetectNetworkThreats(allEntities []*Entity, currentTick int) {
+func (pns *PlantNetworkSystem) DetectNetworkThreats(allEntities []*Entity, currentTick int, geometry WorldGeometry) {
 	threatRadius := 8.0 // Distance to consider entities as threats
 
 	for _, cluster := range pns.NetworkClusters {
@@ -1029,9 +1030,7 @@ func (pns *PlantNetworkSystem) DetectNetworkThreats(allEntities []*Entity, curre
 				}
 
 				// Calculate distance to plant
-				dx := entity.Position.X - plant.Position.X
-				dy := entity.Position.Y - plant.Position.Y
-				distance := math.Sqrt(dx*dx + dy*dy)
+				distance := geometry.Distance(entity.Position, plant.Position)
 
 				// Check if entity is a threat (predator or omnivore near plants)
 				if distance < threatRadius && (entity.Species == "Predator" || entity.Species == "Omnivore") {
@@ -1042,7 +1041,7 @@ func (pns *PlantNetworkSystem) DetectNetworkThreats(allEntities []*Entity, curre
 		}
 
 		// If significant threats detected, send network-wide warning
-		if threatsDetected > 0 && rand.Float64() < 0.4 { // 40% chance to send warning
+		if threatsDetected > 0 && pns.rng.Float64() < 0.4 { // 40% chance to send warning
 			centralHub := cluster.CentralHub
 			if centralHub != nil {
 				threatLevel := math.Min(1.0, float64(threatsDetected)/float64(cluster.Size))
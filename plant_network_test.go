@@ -60,7 +60,7 @@ func TestNetworkFormation(t *testing.T) {
 
 	// Update network to allow formation
 	for i := 0; i < 5; i++ {
-		network.Update(plants, i+1)
+		network.Update(plants, i+1, BoxGeometry{})
 	}
 
 	// Check if connections formed between nearby plants
@@ -104,7 +104,7 @@ func TestChemicalSignalPropagation(t *testing.T) {
 
 	// Create initial connections
 	for i := 0; i < 10; i++ {
-		network.Update(plants, i+1)
+		network.Update(plants, i+1, BoxGeometry{})
 	}
 
 	// Count signals created during connection formation
@@ -133,7 +133,7 @@ func TestChemicalSignalPropagation(t *testing.T) {
 
 	// Test signal aging
 	for i := 0; i < 25; i++ {
-		network.Update(plants, i+20)
+		network.Update(plants, i+20, BoxGeometry{})
 	}
 
 	// Signal should still exist
@@ -143,7 +143,7 @@ func TestChemicalSignalPropagation(t *testing.T) {
 
 	// Age signal beyond max age
 	for i := 0; i < 30; i++ {
-		network.Update(plants, i+50)
+		network.Update(plants, i+50, BoxGeometry{})
 	}
 
 	// Signal should expire
@@ -182,7 +182,7 @@ func TestResourceSharing(t *testing.T) {
 	initialPoorEnergy := poorPlant.Energy
 
 	for i := 0; i < 20; i++ {
-		network.Update(plants, i+1)
+		network.Update(plants, i+1, BoxGeometry{})
 	}
 
 	// Check if any resource transfer occurred
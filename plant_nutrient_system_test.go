@@ -16,7 +16,7 @@ func TestPlantNutrientSystem(t *testing.T) {
 	world := NewWorld(config)
 
 	// Create a plant and place it in a specific cell
-	plant := NewPlant(1, PlantTree, Position{X: 10, Y: 10})
+	plant := NewPlant(sharedRand, 1, PlantTree, Position{X: 10, Y: 10})
 	world.AllPlants = append(world.AllPlants, plant)
 
 	// Get the grid cell where the plant is located
@@ -143,7 +143,7 @@ func TestRainfallEffects(t *testing.T) {
 	initialCompaction := gridCell.SoilCompaction
 
 	// Simulate heavy rainfall
-	processRainfall(gridCell, 0.8) // Heavy rain intensity
+	processRainfall(sharedRand, gridCell, 0.8) // Heavy rain intensity
 
 	finalWaterLevel := gridCell.WaterLevel
 	finalCompaction := gridCell.SoilCompaction
@@ -174,7 +174,7 @@ func TestPlantStressConditions(t *testing.T) {
 	world := NewWorld(config)
 
 	// Create a plant
-	plant := NewPlant(1, PlantTree, Position{X: 10, Y: 10})
+	plant := NewPlant(sharedRand, 1, PlantTree, Position{X: 10, Y: 10})
 	world.AllPlants = append(world.AllPlants, plant)
 
 	// Get the grid cell and deplete nutrients
@@ -223,7 +223,7 @@ func TestSeasonalEffects(t *testing.T) {
 	world := NewWorld(config)
 
 	// Create a plant
-	plant := NewPlant(1, PlantTree, Position{X: 10, Y: 10})
+	plant := NewPlant(sharedRand, 1, PlantTree, Position{X: 10, Y: 10})
 	world.AllPlants = append(world.AllPlants, plant)
 
 	// Get the grid cell
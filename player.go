@@ -19,26 +19,52 @@ type Player struct {
 
 // PlayerSpecies tracks a species owned by a player
 type PlayerSpecies struct {
-	PlayerID    string    `json:"player_id"`    // ID of controlling player
-	SpeciesName string    `json:"species_name"` // Name of the species
-	CreatedAt   time.Time `json:"created_at"`   // When species was created
-	IsExtinct   bool      `json:"is_extinct"`   // Whether species has died out
-	SubSpecies  []string  `json:"sub_species"`  // Any sub-species that split off
+	PlayerID      string    `json:"player_id"`      // ID of controlling player
+	SpeciesName   string    `json:"species_name"`   // Name of the species
+	CreatedAt     time.Time `json:"created_at"`     // When species was created
+	IsExtinct     bool      `json:"is_extinct"`     // Whether species has died out
+	SubSpecies    []string  `json:"sub_species"`    // Any sub-species that split off
+	CoControllers []string  `json:"co_controllers"` // Allied players the owner lets control the species too
+}
+
+// Ownership audit actions
+const (
+	AuditSpeciesGifted    = "species_gifted"
+	AuditCoControlGranted = "co_control_granted"
+	AuditCoControlRevoked = "co_control_revoked"
+)
+
+// OwnershipAudit records a change in who controls a species
+type OwnershipAudit struct {
+	Time       time.Time `json:"time"`
+	Action     string    `json:"action"`
+	Species    string    `json:"species"`
+	FromPlayer string    `json:"from_player"`
+	ToPlayer   string    `json:"to_player"`
+	Reason     string    `json:"reason,omitempty"`
 }
 
 // PlayerManager manages all players and their species ownership
 type PlayerManager struct {
-	Players       map[string]*Player        `json:"players"`        // PlayerID -> Player
-	PlayerSpecies map[string]*PlayerSpecies `json:"player_species"` // SpeciesName -> PlayerSpecies
-	ActivePlayers map[string]bool           `json:"active_players"` // Currently connected players
+	Players          map[string]*Player         `json:"players"`           // PlayerID -> Player
+	PlayerSpecies    map[string]*PlayerSpecies  `json:"player_species"`    // SpeciesName -> PlayerSpecies
+	ActivePlayers    map[string]bool            `json:"active_players"`    // Currently connected players
+	Alliances        map[string]map[string]bool `json:"alliances"`         // PlayerID -> allied player IDs (kept symmetric)
+	AllianceRequests map[string]map[string]bool `json:"alliance_requests"` // PlayerID -> players they have asked to ally with
+	AuditLog         []OwnershipAudit           `json:"audit_log"`         // Every gift and co-control change
+
+	// OnAudit is called for every ownership audit record, e.g. to publish it as an event
+	OnAudit func(audit OwnershipAudit) `json:"-"`
 }
 
 // NewPlayerManager creates a new player manager
 func NewPlayerManager() *PlayerManager {
 	return &PlayerManager{
-		Players:       make(map[string]*Player),
-		PlayerSpecies: make(map[string]*PlayerSpecies),
-		ActivePlayers: make(map[string]bool),
+		Players:          make(map[string]*Player),
+		PlayerSpecies:    make(map[string]*PlayerSpecies),
+		ActivePlayers:    make(map[string]bool),
+		Alliances:        make(map[string]map[string]bool),
+		AllianceRequests: make(map[string]map[string]bool),
 	}
 }
 
@@ -151,10 +177,229 @@ func (pm *PlayerManager) GetSpeciesOwner(speciesName string) (string, bool) {
 	return "", false
 }
 
-// CanPlayerControlSpecies checks if a player can control a specific species
+// CanPlayerControlSpecies checks if a player can control a specific species, either as its
+// owner or as an ally the owner granted co-control
 func (pm *PlayerManager) CanPlayerControlSpecies(playerID, speciesName string) bool {
-	ownerID, exists := pm.GetSpeciesOwner(speciesName)
-	return exists && ownerID == playerID
+	playerSpecies, exists := pm.PlayerSpecies[speciesName]
+	if !exists {
+		return false
+	}
+	if playerSpecies.PlayerID == playerID {
+		return true
+	}
+	return containsString(playerSpecies.CoControllers, playerID) && pm.AreAllied(playerID, playerSpecies.PlayerID)
+}
+
+// ControllableSpecies returns the species a player owns followed by those allies let them co-control
+func (pm *PlayerManager) ControllableSpecies(playerID string) []string {
+	species := append([]string(nil), pm.GetPlayerSpecies(playerID)...)
+	for _, name := range sortedKeys(pm.PlayerSpecies) {
+		if pm.PlayerSpecies[name].PlayerID != playerID && pm.CanPlayerControlSpecies(playerID, name) {
+			species = append(species, name)
+		}
+	}
+	return species
+}
+
+// VisibleSpecies returns the species whose perception a player shares: their own and their allies'
+func (pm *PlayerManager) VisibleSpecies(playerID string) []string {
+	species := append([]string(nil), pm.GetPlayerSpecies(playerID)...)
+	for _, allyID := range pm.Allies(playerID) {
+		species = append(species, pm.GetPlayerSpecies(allyID)...)
+	}
+	return species
+}
+
+// ProposeAlliance asks another player to ally. If they had already asked, the alliance is
+// formed straight away and formed is true.
+func (pm *PlayerManager) ProposeAlliance(playerID, otherID string) (formed bool, err error) {
+	if err := pm.checkAllianceParties(playerID, otherID); err != nil {
+		return false, err
+	}
+	if pm.AreAllied(playerID, otherID) {
+		return false, fmt.Errorf("already allied with %s", pm.Players[otherID].Name)
+	}
+	if pm.AllianceRequests[otherID][playerID] {
+		pm.formAlliance(playerID, otherID)
+		return true, nil
+	}
+	if pm.AllianceRequests[playerID] == nil {
+		pm.AllianceRequests[playerID] = make(map[string]bool)
+	}
+	pm.AllianceRequests[playerID][otherID] = true
+	return false, nil
+}
+
+// RespondToAlliance accepts or declines an alliance another player proposed
+func (pm *PlayerManager) RespondToAlliance(playerID, proposerID string, accept bool) error {
+	if !pm.AllianceRequests[proposerID][playerID] {
+		return fmt.Errorf("no alliance request from player %s", proposerID)
+	}
+	if accept {
+		pm.formAlliance(playerID, proposerID)
+	} else {
+		delete(pm.AllianceRequests[proposerID], playerID)
+	}
+	return nil
+}
+
+// LeaveAlliance ends an alliance. Co-control either side granted the other is revoked.
+func (pm *PlayerManager) LeaveAlliance(playerID, allyID string) error {
+	if !pm.AreAllied(playerID, allyID) {
+		return fmt.Errorf("not allied with player %s", allyID)
+	}
+	delete(pm.Alliances[playerID], allyID)
+	delete(pm.Alliances[allyID], playerID)
+
+	for _, name := range sortedKeys(pm.PlayerSpecies) {
+		playerSpecies := pm.PlayerSpecies[name]
+		switch {
+		case playerSpecies.PlayerID == playerID && containsString(playerSpecies.CoControllers, allyID):
+			pm.revokeCoControl(playerSpecies, allyID, "alliance ended")
+		case playerSpecies.PlayerID == allyID && containsString(playerSpecies.CoControllers, playerID):
+			pm.revokeCoControl(playerSpecies, playerID, "alliance ended")
+		}
+	}
+	return nil
+}
+
+// AreAllied reports whether two players are allies
+func (pm *PlayerManager) AreAllied(playerID, otherID string) bool {
+	return pm.Alliances[playerID][otherID]
+}
+
+// Allies returns a player's allies in ID order
+func (pm *PlayerManager) Allies(playerID string) []string {
+	return sortedKeys(pm.Alliances[playerID])
+}
+
+// GrantCoControl lets an ally control one of the owner's species alongside them
+func (pm *PlayerManager) GrantCoControl(ownerID, speciesName, allyID string) error {
+	playerSpecies, err := pm.ownedSpecies(ownerID, speciesName)
+	if err != nil {
+		return err
+	}
+	if !pm.AreAllied(ownerID, allyID) {
+		return fmt.Errorf("co-control can only be granted to allies")
+	}
+	if containsString(playerSpecies.CoControllers, allyID) {
+		return fmt.Errorf("player %s already co-controls %s", allyID, speciesName)
+	}
+	playerSpecies.CoControllers = append(playerSpecies.CoControllers, allyID)
+	pm.audit(AuditCoControlGranted, speciesName, ownerID, allyID, "")
+	return nil
+}
+
+// RevokeCoControl takes back an ally's co-control of one of the owner's species
+func (pm *PlayerManager) RevokeCoControl(ownerID, speciesName, allyID string) error {
+	playerSpecies, err := pm.ownedSpecies(ownerID, speciesName)
+	if err != nil {
+		return err
+	}
+	if !containsString(playerSpecies.CoControllers, allyID) {
+		return fmt.Errorf("player %s does not co-control %s", allyID, speciesName)
+	}
+	pm.revokeCoControl(playerSpecies, allyID, "")
+	return nil
+}
+
+// GiftSpecies hands ownership of a species (typically a subspecies) to an ally. Co-control
+// grants are dropped, since they were the giver's to make.
+func (pm *PlayerManager) GiftSpecies(ownerID, speciesName, allyID string) error {
+	playerSpecies, err := pm.ownedSpecies(ownerID, speciesName)
+	if err != nil {
+		return err
+	}
+	if !pm.AreAllied(ownerID, allyID) {
+		return fmt.Errorf("species can only be gifted to allies")
+	}
+
+	playerSpecies.PlayerID = allyID
+	playerSpecies.CoControllers = nil
+	owner := pm.Players[ownerID]
+	for i, name := range owner.Species {
+		if name == speciesName {
+			owner.Species = append(owner.Species[:i:i], owner.Species[i+1:]...)
+			break
+		}
+	}
+	pm.Players[allyID].Species = append(pm.Players[allyID].Species, speciesName)
+	pm.audit(AuditSpeciesGifted, speciesName, ownerID, allyID, "")
+	return nil
+}
+
+// checkAllianceParties validates the two players of an alliance
+func (pm *PlayerManager) checkAllianceParties(playerID, otherID string) error {
+	if playerID == otherID {
+		return fmt.Errorf("players cannot ally with themselves")
+	}
+	for _, id := range []string{playerID, otherID} {
+		if _, exists := pm.Players[id]; !exists {
+			return fmt.Errorf("player %s not found", id)
+		}
+	}
+	return nil
+}
+
+// formAlliance allies two players and clears their pending requests to each other
+func (pm *PlayerManager) formAlliance(playerID, otherID string) {
+	for _, pair := range [][2]string{{playerID, otherID}, {otherID, playerID}} {
+		if pm.Alliances[pair[0]] == nil {
+			pm.Alliances[pair[0]] = make(map[string]bool)
+		}
+		pm.Alliances[pair[0]][pair[1]] = true
+		delete(pm.AllianceRequests[pair[0]], pair[1])
+	}
+}
+
+// ownedSpecies returns a species record if the player owns it
+func (pm *PlayerManager) ownedSpecies(ownerID, speciesName string) (*PlayerSpecies, error) {
+	playerSpecies, exists := pm.PlayerSpecies[speciesName]
+	if !exists {
+		return nil, fmt.Errorf("species %s not found", speciesName)
+	}
+	if playerSpecies.PlayerID != ownerID {
+		return nil, fmt.Errorf("only the owner of %s can do that", speciesName)
+	}
+	return playerSpecies, nil
+}
+
+// revokeCoControl removes an ally from a species' co-controllers
+func (pm *PlayerManager) revokeCoControl(playerSpecies *PlayerSpecies, allyID, reason string) {
+	kept := make([]string, 0, len(playerSpecies.CoControllers))
+	for _, id := range playerSpecies.CoControllers {
+		if id != allyID {
+			kept = append(kept, id)
+		}
+	}
+	playerSpecies.CoControllers = kept
+	pm.audit(AuditCoControlRevoked, playerSpecies.SpeciesName, playerSpecies.PlayerID, allyID, reason)
+}
+
+// audit records an ownership change and passes it on to OnAudit
+func (pm *PlayerManager) audit(action, speciesName, fromID, toID, reason string) {
+	record := OwnershipAudit{
+		Time:       time.Now(),
+		Action:     action,
+		Species:    speciesName,
+		FromPlayer: fromID,
+		ToPlayer:   toID,
+		Reason:     reason,
+	}
+	pm.AuditLog = append(pm.AuditLog, record)
+	if pm.OnAudit != nil {
+		pm.OnAudit(record)
+	}
+}
+
+// containsString reports whether a slice holds a value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
 
 // MarkSpeciesExtinct marks a species as extinct
@@ -172,11 +417,12 @@ func (pm *PlayerManager) AddSubSpecies(parentSpecies, subSpeciesName string) err
 
 		// Create a new species record for the sub-species with the same owner
 		subPlayerSpecies := &PlayerSpecies{
-			PlayerID:    playerSpecies.PlayerID,
-			SpeciesName: subSpeciesName,
-			CreatedAt:   time.Now(),
-			IsExtinct:   false,
-			SubSpecies:  make([]string, 0),
+			PlayerID:      playerSpecies.PlayerID,
+			SpeciesName:   subSpeciesName,
+			CreatedAt:     time.Now(),
+			IsExtinct:     false,
+			SubSpecies:    make([]string, 0),
+			CoControllers: append([]string(nil), playerSpecies.CoControllers...),
 		}
 
 		pm.PlayerSpecies[subSpeciesName] = subPlayerSpecies
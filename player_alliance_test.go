@@ -0,0 +1,175 @@
+package main
+
+import "testing"
+
+// newAllianceTestManager returns a manager with three players: Ann owns "ant", Bea owns "bee"
+func newAllianceTestManager(t *testing.T) *PlayerManager {
+	pm := NewPlayerManager()
+	for _, player := range [][3]string{{"p1", "Ann", "ant"}, {"p2", "Bea", "bee"}, {"p3", "Cal", ""}} {
+		if _, err := pm.AddPlayer(player[0], player[1]); err != nil {
+			t.Fatal(err)
+		}
+		if player[2] != "" {
+			if err := pm.AddPlayerSpecies(player[0], player[2]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return pm
+}
+
+func TestAllianceProposalAndSharedVisibility(t *testing.T) {
+	pm := newAllianceTestManager(t)
+	if _, err := pm.ProposeAlliance("p1", "p1"); err == nil {
+		t.Error("Expected a player allying with themselves to be rejected")
+	}
+	if _, err := pm.ProposeAlliance("p1", "ghost"); err == nil {
+		t.Error("Expected an alliance with an unknown player to be rejected")
+	}
+	if err := pm.RespondToAlliance("p2", "p1", true); err == nil {
+		t.Error("Expected accepting an alliance nobody proposed to fail")
+	}
+
+	if formed, err := pm.ProposeAlliance("p1", "p2"); err != nil || formed {
+		t.Fatalf("Expected a pending proposal, got formed=%v err=%v", formed, err)
+	}
+	if pm.AreAllied("p1", "p2") || len(pm.VisibleSpecies("p1")) != 1 {
+		t.Fatal("Expected no alliance until the proposal is accepted")
+	}
+	if err := pm.RespondToAlliance("p2", "p1", true); err != nil {
+		t.Fatal(err)
+	}
+	if !pm.AreAllied("p2", "p1") || len(pm.AllianceRequests["p1"]) != 0 {
+		t.Fatal("Expected an accepted proposal to ally both players")
+	}
+	if visible := pm.VisibleSpecies("p1"); len(visible) != 2 || visible[0] != "ant" || visible[1] != "bee" {
+		t.Errorf("Expected allies to share the vision of each other's species, got %v", visible)
+	}
+	if pm.CanPlayerControlSpecies("p1", "bee") {
+		t.Error("Expected an alliance alone not to grant control of an ally's species")
+	}
+
+	// Proposing back to a player who already asked forms the alliance at once
+	if _, err := pm.ProposeAlliance("p3", "p1"); err != nil {
+		t.Fatal(err)
+	}
+	if formed, err := pm.ProposeAlliance("p1", "p3"); err != nil || !formed {
+		t.Errorf("Expected mutual proposals to form an alliance, got formed=%v err=%v", formed, err)
+	}
+	if allies := pm.Allies("p1"); len(allies) != 2 || allies[0] != "p2" || allies[1] != "p3" {
+		t.Errorf("Expected Ann to have two allies, got %v", allies)
+	}
+
+	// A declined proposal leaves nothing behind
+	if _, err := pm.ProposeAlliance("p2", "p3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.RespondToAlliance("p3", "p2", false); err != nil || pm.AreAllied("p2", "p3") || pm.AllianceRequests["p2"]["p3"] {
+		t.Errorf("Expected a declined proposal to be dropped, err=%v", err)
+	}
+}
+
+func TestCoControlAndGiftingAreAudited(t *testing.T) {
+	pm := newAllianceTestManager(t)
+	var audits []OwnershipAudit
+	pm.OnAudit = func(audit OwnershipAudit) { audits = append(audits, audit) }
+
+	if err := pm.GrantCoControl("p1", "ant", "p2"); err == nil {
+		t.Error("Expected co-control to require an alliance")
+	}
+	if _, err := pm.ProposeAlliance("p1", "p2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.RespondToAlliance("p2", "p1", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.GrantCoControl("p2", "ant", "p1"); err == nil {
+		t.Error("Expected only the owner to grant co-control")
+	}
+
+	if err := pm.GrantCoControl("p1", "ant", "p2"); err != nil {
+		t.Fatal(err)
+	}
+	if !pm.CanPlayerControlSpecies("p2", "ant") || pm.CanPlayerControlSpecies("p3", "ant") {
+		t.Error("Expected only the granted ally to control the species")
+	}
+	if controllable := pm.ControllableSpecies("p2"); len(controllable) != 2 {
+		t.Errorf("Expected Bea to control her species and Ann's, got %v", controllable)
+	}
+	if err := pm.AddSubSpecies("ant", "ant_split"); err != nil {
+		t.Fatal(err)
+	}
+	if !pm.CanPlayerControlSpecies("p2", "ant_split") {
+		t.Error("Expected a subspecies to keep its parent's co-controllers")
+	}
+
+	// Ending the alliance revokes co-control
+	if err := pm.LeaveAlliance("p2", "p1"); err != nil {
+		t.Fatal(err)
+	}
+	if pm.CanPlayerControlSpecies("p2", "ant") || pm.CanPlayerControlSpecies("p2", "ant_split") {
+		t.Error("Expected co-control to end with the alliance")
+	}
+	if err := pm.GiftSpecies("p1", "ant_split", "p2"); err == nil {
+		t.Error("Expected gifts to former allies to be rejected")
+	}
+
+	if _, err := pm.ProposeAlliance("p2", "p1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.RespondToAlliance("p1", "p2", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.GiftSpecies("p1", "ant_split", "p2"); err != nil {
+		t.Fatal(err)
+	}
+	if owner, _ := pm.GetSpeciesOwner("ant_split"); owner != "p2" || pm.CanPlayerControlSpecies("p1", "ant_split") {
+		t.Errorf("Expected Bea to own the gifted subspecies outright, owner is %s", owner)
+	}
+	if ann, bea := pm.GetPlayerSpecies("p1"), pm.GetPlayerSpecies("p2"); len(ann) != 1 || len(bea) != 2 || bea[1] != "ant_split" {
+		t.Errorf("Expected the gift to move between the players' species lists, got %v and %v", ann, bea)
+	}
+
+	expected := []OwnershipAudit{
+		{Action: AuditCoControlGranted, Species: "ant", FromPlayer: "p1", ToPlayer: "p2"},
+		{Action: AuditCoControlRevoked, Species: "ant", FromPlayer: "p1", ToPlayer: "p2", Reason: "alliance ended"},
+		{Action: AuditCoControlRevoked, Species: "ant_split", FromPlayer: "p1", ToPlayer: "p2", Reason: "alliance ended"},
+		{Action: AuditSpeciesGifted, Species: "ant_split", FromPlayer: "p1", ToPlayer: "p2"},
+	}
+	if len(audits) != len(expected) || len(pm.AuditLog) != len(expected) {
+		t.Fatalf("Expected %d audit records, got %+v", len(expected), audits)
+	}
+	for i, audit := range audits {
+		audit.Time = expected[i].Time
+		if audit != expected[i] {
+			t.Errorf("Audit %d: expected %+v, got %+v", i, expected[i], audit)
+		}
+	}
+}
+
+func TestOwnershipAuditReachesEventBus(t *testing.T) {
+	wi := NewWebInterface(NewWorld(WorldConfig{Width: 50, Height: 50, GridWidth: 10, GridHeight: 10}))
+	pm := wi.playerManager
+	for _, id := range []string{"p1", "p2"} {
+		if _, err := pm.AddPlayer(id, "Player"+id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pm.AddPlayerSpecies("p1", "ant"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pm.ProposeAlliance("p1", "p2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pm.ProposeAlliance("p2", "p1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.GiftSpecies("p1", "ant", "p2"); err != nil {
+		t.Fatal(err)
+	}
+
+	events := wi.world.CentralEventBus.GetEventsByType("species_ownership_changed")
+	if len(events) != 1 || events[0].SubCategory != AuditSpeciesGifted || events[0].Metadata["to_player"] != "p2" {
+		t.Errorf("Expected one species gifted event, got %+v", events)
+	}
+}
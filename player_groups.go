@@ -0,0 +1,411 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Group formations
+const (
+	FormationBox  = "box"  // Square block centered on the anchor
+	FormationLine = "line" // Single rank across the direction of travel
+)
+
+// Group order kinds
+const (
+	GroupOrderMove   = "move"   // Travel to a point in formation and hold there
+	GroupOrderGuard  = "guard"  // Hold an area and chase off creatures of other species inside it
+	GroupOrderGather = "gather" // Forage for plants inside an area
+)
+
+const (
+	formationSpacing   = 1.5 // World units between neighbouring formation slots
+	maxGroupsPerPlayer = 10  // Oldest groups are disbanded beyond this
+	groupGatherRange   = 1.5 // Distance at which a gatherer feeds on a plant
+)
+
+// GroupOrder is a standing command a player group carries out every tick until replaced
+type GroupOrder struct {
+	Kind       string   `json:"kind"`
+	Target     Position `json:"target"`           // Destination, or the center of the guarded/gathered area
+	Radius     float64  `json:"radius,omitempty"` // Guard and gather area radius in world units
+	IssuedTick int      `json:"issued_tick"`
+	Arrived    bool     `json:"arrived"` // The formation has reached the target
+}
+
+// PlayerGroup is a box-selected subset of a player's creatures that moves as a unit
+type PlayerGroup struct {
+	ID        int         `json:"id"`
+	PlayerID  string      `json:"player_id"`
+	EntityIDs []int       `json:"entity_ids"` // Sorted; a member's index is its formation slot
+	Formation string      `json:"formation"`
+	Anchor    Position    `json:"anchor"`  // Point the formation is kept around
+	Heading   Position    `json:"heading"` // Unit direction of travel, used to orient lines
+	Order     *GroupOrder `json:"order,omitempty"`
+}
+
+// PlayerGroupSystem tracks player groups and applies their orders after entities move
+type PlayerGroupSystem struct {
+	mu          sync.Mutex
+	groups      map[int]*PlayerGroup
+	nextGroupID int
+}
+
+// NewPlayerGroupSystem creates an empty player group system
+func NewPlayerGroupSystem() *PlayerGroupSystem {
+	return &PlayerGroupSystem{groups: make(map[int]*PlayerGroup), nextGroupID: 1}
+}
+
+// SelectGroup groups the living creatures of the given species inside a world-space box.
+// Selected creatures leave any other group of the same player.
+func (pgs *PlayerGroupSystem) SelectGroup(w *World, playerID string, species []string, minX, minY, maxX, maxY float64, formation string) (*PlayerGroup, error) {
+	if formation == "" {
+		formation = FormationBox
+	}
+	if formation != FormationBox && formation != FormationLine {
+		return nil, fmt.Errorf("unknown formation %q", formation)
+	}
+	if minX > maxX {
+		minX, maxX = maxX, minX
+	}
+	if minY > maxY {
+		minY, maxY = maxY, minY
+	}
+
+	owned := make(map[string]bool, len(species))
+	for _, name := range species {
+		owned[name] = true
+	}
+	selected := make([]int, 0)
+	centroid := Position{}
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive || !owned[entity.Species] {
+			continue
+		}
+		if entity.Position.X < minX || entity.Position.X > maxX || entity.Position.Y < minY || entity.Position.Y > maxY {
+			continue
+		}
+		selected = append(selected, entity.ID)
+		centroid.X += entity.Position.X
+		centroid.Y += entity.Position.Y
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no creatures of your species in the selection")
+	}
+	sort.Ints(selected)
+	centroid.X /= float64(len(selected))
+	centroid.Y /= float64(len(selected))
+
+	pgs.mu.Lock()
+	defer pgs.mu.Unlock()
+
+	taken := make(map[int]bool, len(selected))
+	for _, id := range selected {
+		taken[id] = true
+	}
+	playerGroups := make([]int, 0)
+	for _, id := range sortedKeys(pgs.groups) {
+		group := pgs.groups[id]
+		if group.PlayerID != playerID {
+			continue
+		}
+		remaining := group.EntityIDs[:0]
+		for _, entityID := range group.EntityIDs {
+			if !taken[entityID] {
+				remaining = append(remaining, entityID)
+			}
+		}
+		group.EntityIDs = remaining
+		if len(remaining) == 0 {
+			delete(pgs.groups, id)
+			continue
+		}
+		playerGroups = append(playerGroups, id)
+	}
+	for len(playerGroups) >= maxGroupsPerPlayer {
+		delete(pgs.groups, playerGroups[0])
+		playerGroups = playerGroups[1:]
+	}
+
+	group := &PlayerGroup{
+		ID:        pgs.nextGroupID,
+		PlayerID:  playerID,
+		EntityIDs: selected,
+		Formation: formation,
+		Anchor:    centroid,
+		Heading:   Position{X: 1},
+	}
+	pgs.nextGroupID++
+	pgs.groups[group.ID] = group
+	copied := *group
+	copied.EntityIDs = append([]int(nil), selected...)
+	return &copied, nil
+}
+
+// IssueOrder replaces a group's standing order. A nil order stops the group.
+func (pgs *PlayerGroupSystem) IssueOrder(playerID string, groupID int, order *GroupOrder, formation string) error {
+	pgs.mu.Lock()
+	defer pgs.mu.Unlock()
+
+	group, exists := pgs.groups[groupID]
+	if !exists || group.PlayerID != playerID {
+		return fmt.Errorf("group %d not found", groupID)
+	}
+	if order != nil {
+		switch order.Kind {
+		case GroupOrderMove:
+		case GroupOrderGuard, GroupOrderGather:
+			if order.Radius <= 0 {
+				return fmt.Errorf("%s orders need a positive radius", order.Kind)
+			}
+		default:
+			return fmt.Errorf("unknown group order %q", order.Kind)
+		}
+	}
+	switch formation {
+	case "":
+	case FormationBox, FormationLine:
+		group.Formation = formation
+	default:
+		return fmt.Errorf("unknown formation %q", formation)
+	}
+	group.Order = order
+	return nil
+}
+
+// Disband removes a player's group
+func (pgs *PlayerGroupSystem) Disband(playerID string, groupID int) bool {
+	pgs.mu.Lock()
+	defer pgs.mu.Unlock()
+	group, exists := pgs.groups[groupID]
+	if !exists || group.PlayerID != playerID {
+		return false
+	}
+	delete(pgs.groups, groupID)
+	return true
+}
+
+// Groups returns copies of all groups, or only one player's groups if playerID is set
+func (pgs *PlayerGroupSystem) Groups(playerID string) []PlayerGroup {
+	pgs.mu.Lock()
+	defer pgs.mu.Unlock()
+	groups := make([]PlayerGroup, 0)
+	for _, id := range sortedKeys(pgs.groups) {
+		group := pgs.groups[id]
+		if playerID != "" && group.PlayerID != playerID {
+			continue
+		}
+		copied := *group
+		copied.EntityIDs = append([]int(nil), group.EntityIDs...)
+		if group.Order != nil {
+			order := *group.Order
+			copied.Order = &order
+		}
+		groups = append(groups, copied)
+	}
+	return groups
+}
+
+// Update drops dead members and moves every group with an order
+func (pgs *PlayerGroupSystem) Update(w *World) {
+	pgs.mu.Lock()
+	defer pgs.mu.Unlock()
+	if len(pgs.groups) == 0 {
+		return
+	}
+
+	living := make(map[int]*Entity, len(w.AllEntities))
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive {
+			living[entity.ID] = entity
+		}
+	}
+
+	for _, id := range sortedKeys(pgs.groups) {
+		group := pgs.groups[id]
+		members := make([]*Entity, 0, len(group.EntityIDs))
+		remaining := group.EntityIDs[:0]
+		for _, entityID := range group.EntityIDs {
+			if entity, alive := living[entityID]; alive {
+				members = append(members, entity)
+				remaining = append(remaining, entityID)
+			}
+		}
+		group.EntityIDs = remaining
+		if len(members) == 0 {
+			delete(pgs.groups, id)
+			continue
+		}
+		if group.Order != nil {
+			group.carryOut(w, members)
+		}
+	}
+}
+
+// carryOut advances the group's anchor toward its target and steers members into formation.
+// Guards leave formation to chase intruders and gatherers to reach plants once arrived.
+func (pg *PlayerGroup) carryOut(w *World, members []*Entity) {
+	order := pg.Order
+	if !order.Arrived {
+		// The formation travels at the pace of its slowest member so nobody falls behind
+		step := math.Inf(1)
+		for _, member := range members {
+			step = math.Min(step, groupMemberStep(member))
+		}
+		waypoint := groupWaypoint(w, pg.Anchor, order.Target)
+		dx, dy := w.Geometry().Direction(pg.Anchor, waypoint)
+		distance := math.Sqrt(dx*dx + dy*dy)
+		if distance <= step && waypoint == order.Target {
+			pg.Anchor = order.Target
+			order.Arrived = true
+		} else if distance <= step {
+			pg.Anchor = waypoint
+		} else {
+			pg.Heading = Position{X: dx / distance, Y: dy / distance}
+			pg.Anchor = w.Geometry().Wrap(Position{X: pg.Anchor.X + pg.Heading.X*step, Y: pg.Anchor.Y + pg.Heading.Y*step})
+		}
+	}
+
+	slots := formationOffsets(pg.Formation, len(members), pg.Heading)
+	busy := make(map[int]bool)
+	if order.Arrived {
+		switch order.Kind {
+		case GroupOrderGuard:
+			pg.chaseIntruders(w, members, busy)
+		case GroupOrderGather:
+			pg.forage(w, members, busy)
+		}
+	}
+
+	for i, member := range members {
+		if busy[member.ID] {
+			continue
+		}
+		slot := Position{X: pg.Anchor.X + slots[i].X, Y: pg.Anchor.Y + slots[i].Y}
+		steerEntityToward(w, member, slot, groupMemberStep(member)*1.5) // Members may hurry to catch up
+	}
+}
+
+// groupWaypoint is where a formation heads next on its way to a target: straight there
+// over open ground, or the centre of the next cell on the shortest way round any operator
+// barriers in between
+func groupWaypoint(w *World, from, target Position) Position {
+	if w.OperatorStructures == nil || len(w.OperatorStructures.BarrierCells) == 0 {
+		return target
+	}
+	barriers := w.OperatorStructures.BarrierCells
+	fx, fy := w.worldToGridCoords(from.X, from.Y)
+	tx, ty := w.worldToGridCoords(target.X, target.Y)
+	path := w.FindPath(GridPoint{X: fx, Y: fy}, GridPoint{X: tx, Y: ty}, func(cell GridPoint) bool { return !barriers[cell] })
+	if len(path) <= 2 {
+		return target // Already beside the target, or walled off from it
+	}
+	return w.CellCenter(path[1].X, path[1].Y)
+}
+
+// chaseIntruders sends the nearest free guard toward each creature of another species in the area
+func (pg *PlayerGroup) chaseIntruders(w *World, members []*Entity, busy map[int]bool) {
+	species := make(map[string]bool)
+	for _, member := range members {
+		species[member.Species] = true
+	}
+	for _, entity := range w.spatialIndex().QueryRadius(pg.Order.Target, pg.Order.Radius) {
+		if !entity.IsAlive || species[entity.Species] || w.Distance(entity.Position, pg.Order.Target) > pg.Order.Radius {
+			continue
+		}
+		var nearest *Entity
+		nearestDistance := math.Inf(1)
+		for _, member := range members {
+			if busy[member.ID] {
+				continue
+			}
+			if d := w.Distance(member.Position, entity.Position); d < nearestDistance {
+				nearest, nearestDistance = member, d
+			}
+		}
+		if nearest == nil {
+			return // Every guard is already busy
+		}
+		busy[nearest.ID] = true
+		steerEntityToward(w, nearest, entity.Position, groupMemberStep(nearest))
+	}
+}
+
+// forage moves hungry members to the nearest plant in the area and feeds them when close
+func (pg *PlayerGroup) forage(w *World, members []*Entity, busy map[int]bool) {
+	plants := w.spatialIndex().QueryPlantRadius(pg.Order.Target, pg.Order.Radius)
+	for _, member := range members {
+		if member.Energy >= 90 {
+			continue
+		}
+		var nearest *Plant
+		nearestDistance := math.Inf(1)
+		for _, plant := range plants {
+			if !plant.IsAlive || w.Distance(plant.Position, pg.Order.Target) > pg.Order.Radius {
+				continue
+			}
+			if d := w.Distance(member.Position, plant.Position); d < nearestDistance {
+				nearest, nearestDistance = plant, d
+			}
+		}
+		if nearest == nil {
+			return // Nothing left to gather in the area
+		}
+		busy[member.ID] = true
+		if nearestDistance > groupGatherRange {
+			steerEntityToward(w, member, nearest.Position, groupMemberStep(member))
+			continue
+		}
+		energy := math.Min(5, nearest.Energy)
+		member.Energy = math.Min(100, member.Energy+energy)
+		nearest.Energy -= energy
+		if nearest.Energy <= 0 {
+			nearest.IsAlive = false
+		}
+	}
+}
+
+// formationOffsets returns each slot's offset from the anchor
+func formationOffsets(formation string, count int, heading Position) []Position {
+	offsets := make([]Position, count)
+	if formation == FormationLine {
+		// Spread across the direction of travel
+		perpendicular := Position{X: -heading.Y, Y: heading.X}
+		for i := range offsets {
+			shift := (float64(i) - float64(count-1)/2) * formationSpacing
+			offsets[i] = Position{X: perpendicular.X * shift, Y: perpendicular.Y * shift}
+		}
+		return offsets
+	}
+
+	columns := int(math.Ceil(math.Sqrt(float64(count))))
+	rows := (count + columns - 1) / columns
+	for i := range offsets {
+		offsets[i] = Position{
+			X: (float64(i%columns) - float64(columns-1)/2) * formationSpacing,
+			Y: (float64(i/columns) - float64(rows-1)/2) * formationSpacing,
+		}
+	}
+	return offsets
+}
+
+// groupMemberStep is how far a group member travels per tick on orders
+func groupMemberStep(entity *Entity) float64 {
+	return math.Max(0.2, 0.5+entity.GetTrait("speed")*0.5)
+}
+
+// steerEntityToward moves an entity up to step units toward a point inside the world, the
+// short way round a map that wraps
+func steerEntityToward(w *World, entity *Entity, target Position, step float64) {
+	dx, dy := w.Geometry().Direction(entity.Position, target)
+	distance := math.Sqrt(dx*dx + dy*dy)
+	if distance < 0.01 {
+		return
+	}
+	if distance > step {
+		dx, dy = dx/distance*step, dy/distance*step
+	}
+	entity.Position = w.KeepOnMap(Position{X: entity.Position.X + dx, Y: entity.Position.Y + dy}, 1)
+}
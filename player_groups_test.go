@@ -0,0 +1,137 @@
+package main
+
+import "testing"
+
+// newGroupTestWorld returns an empty world with the given entities placed in it
+func newGroupTestWorld(entities ...*Entity) *World {
+	world := newTestWorld(50, 10)
+	for _, entity := range entities {
+		entity.IsAlive = true
+		if entity.Traits == nil {
+			entity.Traits = make(map[string]Trait)
+		}
+		world.AllEntities = append(world.AllEntities, entity)
+	}
+	return world
+}
+
+func TestSelectGroupByBoxAndSpecies(t *testing.T) {
+	world := newGroupTestWorld(
+		&Entity{ID: 1, Species: "mine", Position: Position{X: 5, Y: 5}},
+		&Entity{ID: 2, Species: "mine", Position: Position{X: 8, Y: 6}},
+		&Entity{ID: 3, Species: "theirs", Position: Position{X: 6, Y: 6}},
+		&Entity{ID: 4, Species: "mine", Position: Position{X: 30, Y: 30}},
+	)
+	groups := world.PlayerGroups
+
+	first, err := groups.SelectGroup(world, "p1", []string{"mine"}, 10, 10, 0, 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.EntityIDs) != 2 || first.EntityIDs[0] != 1 || first.EntityIDs[1] != 2 || first.Formation != FormationBox {
+		t.Fatalf("Expected entities 1 and 2 in a box formation, got %+v", first)
+	}
+	if first.Anchor.X != 6.5 || first.Anchor.Y != 5.5 {
+		t.Errorf("Expected the anchor at the members' centroid, got %+v", first.Anchor)
+	}
+
+	// Reselecting a member moves it to the new group
+	if _, err := groups.SelectGroup(world, "p1", []string{"mine"}, 7, 0, 50, 50, FormationLine); err != nil {
+		t.Fatal(err)
+	}
+	all := groups.Groups("p1")
+	if len(all) != 2 || len(all[0].EntityIDs) != 1 || len(all[1].EntityIDs) != 2 {
+		t.Errorf("Expected entity 2 to leave the first group, got %+v", all)
+	}
+
+	if _, err := groups.SelectGroup(world, "p1", []string{"mine"}, 40, 40, 50, 50, ""); err == nil {
+		t.Error("Expected an empty selection to fail")
+	}
+	if err := groups.IssueOrder("p2", first.ID, &GroupOrder{Kind: GroupOrderMove}, ""); err == nil {
+		t.Error("Expected another player's order to be rejected")
+	}
+	if err := groups.IssueOrder("p1", first.ID, &GroupOrder{Kind: GroupOrderGuard}, ""); err == nil {
+		t.Error("Expected a guard order without a radius to be rejected")
+	}
+}
+
+func TestGroupMovesInFormation(t *testing.T) {
+	members := make([]*Entity, 0)
+	for i := 0; i < 4; i++ {
+		members = append(members, &Entity{ID: i + 1, Species: "mine", Position: Position{X: 5 + float64(i)*2, Y: 5}})
+	}
+	members[0].Traits = map[string]Trait{"speed": {Name: "speed", Value: 1}} // Fastest member must wait for the rest
+	world := newGroupTestWorld(members...)
+
+	group, err := world.PlayerGroups.SelectGroup(world, "p1", []string{"mine"}, 0, 0, 20, 20, FormationBox)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := Position{X: 30, Y: 25}
+	if err := world.PlayerGroups.IssueOrder("p1", group.ID, &GroupOrder{Kind: GroupOrderMove, Target: target}, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	for tick := 0; tick < 10; tick++ {
+		world.PlayerGroups.Update(world)
+	}
+	// Mid-journey the fast member is still in formation
+	anchor := world.PlayerGroups.Groups("p1")[0].Anchor
+	for _, member := range members {
+		if distanceBetween(member.Position, anchor) > 3 {
+			t.Fatalf("Expected member %d to keep formation near %+v, got %+v", member.ID, anchor, member.Position)
+		}
+	}
+
+	for tick := 0; tick < 100; tick++ {
+		world.PlayerGroups.Update(world)
+	}
+	state := world.PlayerGroups.Groups("p1")[0]
+	if !state.Order.Arrived || state.Anchor != target {
+		t.Fatalf("Expected the group to arrive at %+v, got %+v", target, state)
+	}
+	offsets := formationOffsets(FormationBox, 4, state.Heading)
+	for i, member := range members {
+		slot := Position{X: target.X + offsets[i].X, Y: target.Y + offsets[i].Y}
+		if distanceBetween(member.Position, slot) > 0.01 {
+			t.Errorf("Expected member %d in its slot %+v, got %+v", member.ID, slot, member.Position)
+		}
+	}
+}
+
+func TestGroupGuardAndGatherOrders(t *testing.T) {
+	guard := &Entity{ID: 1, Species: "mine", Position: Position{X: 20, Y: 20}}
+	intruder := &Entity{ID: 2, Species: "theirs", Position: Position{X: 24, Y: 20}}
+	world := newGroupTestWorld(guard, intruder)
+	world.AllPlants = nil
+
+	group, _ := world.PlayerGroups.SelectGroup(world, "p1", []string{"mine"}, 15, 15, 21, 21, "")
+	if err := world.PlayerGroups.IssueOrder("p1", group.ID, &GroupOrder{Kind: GroupOrderGuard, Target: Position{X: 20, Y: 20}, Radius: 6}, ""); err != nil {
+		t.Fatal(err)
+	}
+	world.PlayerGroups.Update(world)
+	world.PlayerGroups.Update(world)
+	if guard.Position.X <= 20 {
+		t.Errorf("Expected the guard to move toward the intruder, got %+v", guard.Position)
+	}
+
+	// Gatherers feed on plants inside the area
+	plant := &Plant{ID: 1, IsAlive: true, Energy: 20, Position: Position{X: guard.Position.X + 1, Y: guard.Position.Y}}
+	world.AllPlants = []*Plant{plant}
+	guard.Energy = 50
+	if err := world.PlayerGroups.IssueOrder("p1", group.ID, &GroupOrder{Kind: GroupOrderGather, Target: guard.Position, Radius: 5}, ""); err != nil {
+		t.Fatal(err)
+	}
+	world.PlayerGroups.Update(world)
+	world.PlayerGroups.Update(world)
+	if guard.Energy <= 50 || plant.Energy >= 20 {
+		t.Errorf("Expected the gatherer to feed on the plant, energy %.1f plant %.1f", guard.Energy, plant.Energy)
+	}
+
+	// Groups vanish once every member is dead
+	guard.IsAlive = false
+	world.PlayerGroups.Update(world)
+	if len(world.PlayerGroups.Groups("")) != 0 {
+		t.Error("Expected the group of a dead member to be removed")
+	}
+}
@@ -2,6 +2,7 @@ import { defineConfig, devices } from '@playwright/test';
 
 export default defineConfig({
   testDir: './tests',
+  testIgnore: 'e2e/**', // The cross-frontend suite boots its own world: npm run test:e2e
   fullyParallel: false, // Run sequentially for more stability
   forbidOnly: !!process.env.CI,
   retries: process.env.CI ? 2 : 1,
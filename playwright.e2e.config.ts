@@ -0,0 +1,47 @@
+import { defineConfig, devices } from '@playwright/test';
+
+// The cross-frontend suite boots its own seeded world on a port of its own, so it never
+// runs against a server someone left running on 8080.
+const port = Number(process.env.EVOSIM_E2E_PORT || 8091);
+const seed = process.env.EVOSIM_E2E_SEED || '4242';
+
+export default defineConfig({
+  testDir: './tests/e2e',
+  fullyParallel: false,
+  forbidOnly: !!process.env.CI,
+  retries: process.env.CI ? 1 : 0,
+  workers: 1, // One world, shared by every test
+  reporter: process.env.CI ? 'github' : 'line',
+  timeout: 90000,
+  expect: {
+    timeout: 15000,
+  },
+  use: {
+    baseURL: `http://localhost:${port}`,
+    trace: 'retain-on-failure',
+    screenshot: 'only-on-failure',
+    actionTimeout: 10000,
+  },
+
+  projects: [
+    {
+      name: 'chromium',
+      use: {
+        ...devices['Desktop Chrome'],
+        headless: true,
+        launchOptions: {
+          args: ['--no-sandbox', '--disable-setuid-sandbox'],
+        },
+      },
+    },
+  ],
+
+  webServer: {
+    command: `GOWORK=off go run . run --web --web-port ${port} --seed ${seed} --pop-size 6`,
+    url: `http://localhost:${port}/api/status`,
+    reuseExistingServer: false,
+    timeout: 120000,
+    stdout: 'pipe',
+    stderr: 'pipe',
+  },
+});
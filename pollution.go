@@ -0,0 +1,312 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Pollution parameters
+const (
+	pollutionInterval        = 10    // Ticks between pollution updates
+	wasteTechLevel           = 3     // Tribes this advanced farm and dig wells, and start fouling their surroundings
+	wasteManagementTechLevel = 5     // Tribes this advanced begin treating their waste
+	memberWaste              = 0.004 // Waste per tribe member per update
+	airborneShare            = 0.3   // Share of released waste carried a cell downwind
+	soilLeach                = 0.05  // Share of soil contamination washed into the water each update
+	runoffShare              = 0.2   // Share of water contamination flowing a cell downhill each update
+	pollutionBreakdown       = 0.02  // Share of contamination broken down naturally each update
+	wildlifeHarm             = 2.0   // Energy lost per update by a creature in fully contaminated surroundings
+	plantHarm                = 3.0   // Energy lost per update by a plant in fully contaminated soil
+	contaminatedLevel        = 0.05  // Contamination above which a cell counts as polluted
+	maxPollutionHotspots     = 20    // Most contaminated cells listed in a report
+)
+
+// structureWaste is the waste a fully standing structure of each type gives off per update
+var structureWaste = map[StructureType]float64{
+	StructureNest:   0.005,
+	StructureCache:  0.005,
+	StructureFarm:   0.03, // Manure and tilled-soil runoff
+	StructureWell:   0.01,
+	StructureTower:  0.01,
+	StructureMarket: 0.02,
+}
+
+// wasteTreatment is the share of its waste a tribe treats before release
+func wasteTreatment(techLevel int) float64 {
+	if techLevel < wasteManagementTechLevel {
+		return 0
+	}
+	return math.Min(0.9, 0.5+0.2*float64(techLevel-wasteManagementTechLevel))
+}
+
+// TribePollution is what one tribe has released into its surroundings
+type TribePollution struct {
+	TribeID    int     `json:"tribe_id"`
+	Name       string  `json:"name"`
+	TechLevel  int     `json:"tech_level"`
+	Members    int     `json:"members"`
+	Produced   float64 `json:"produced"` // Total waste generated
+	Released   float64 `json:"released"` // Total waste left untreated
+	Treatment  float64 `json:"treatment"`
+	Exposure   float64 `json:"exposure"`    // Mean contamination around the tribe's own members
+	EnergyLost float64 `json:"energy_lost"` // Total energy members have lost to contamination
+}
+
+// PollutionCell is a contaminated grid cell
+type PollutionCell struct {
+	X     int     `json:"x"`
+	Y     int     `json:"y"`
+	Biome string  `json:"biome"`
+	Soil  float64 `json:"soil"`
+	Water float64 `json:"water"`
+}
+
+// PollutionReport summarizes contamination across the world and who caused it
+type PollutionReport struct {
+	Tick         int              `json:"tick"`
+	TotalSoil    float64          `json:"total_soil"`
+	TotalWater   float64          `json:"total_water"`
+	Contaminated int              `json:"contaminated"` // Cells above the polluted level
+	Tribes       []TribePollution `json:"tribes"`
+	Hotspots     []PollutionCell  `json:"hotspots"`
+}
+
+// PollutionSystem spreads the waste of advanced tribes through soil downwind and water
+// downhill, harming the wildlife, plants and colonists living in it
+type PollutionSystem struct {
+	soil   [][]float64 // [y][x] contamination, 0-1
+	water  [][]float64
+	tribes map[int]*TribePollution
+}
+
+// NewPollutionSystem creates a system with a clean world
+func NewPollutionSystem() *PollutionSystem {
+	return &PollutionSystem{tribes: make(map[int]*TribePollution)}
+}
+
+// At returns the soil and water contamination of a grid cell
+func (ps *PollutionSystem) At(x, y int) (soil, water float64) {
+	if ps == nil || y < 0 || y >= len(ps.soil) || x < 0 || x >= len(ps.soil[y]) {
+		return 0, 0
+	}
+	return ps.soil[y][x], ps.water[y][x]
+}
+
+// exposure is the contamination a creature or plant in a cell lives with
+func (ps *PollutionSystem) exposure(x, y int) float64 {
+	soil, water := ps.At(x, y)
+	return math.Min(1, soil+water)
+}
+
+// Update breaks down, leaches and carries downhill the contamination already present, adds
+// the waste of advanced tribes, and harms whatever lives in polluted cells
+func (ps *PollutionSystem) Update(w *World) {
+	if len(ps.soil) != w.Config.GridHeight || (len(ps.soil) > 0 && len(ps.soil[0]) != w.Config.GridWidth) {
+		ps.soil, ps.water = newPollutionLayer(w), newPollutionLayer(w)
+	}
+	if w.Tick%pollutionInterval != 0 {
+		return
+	}
+	ps.spread(w)
+	ps.emit(w)
+	ps.harm(w)
+}
+
+// newPollutionLayer makes a clean layer the size of the world grid
+func newPollutionLayer(w *World) [][]float64 {
+	layer := make([][]float64, w.Config.GridHeight)
+	for y := range layer {
+		layer[y] = make([]float64, w.Config.GridWidth)
+	}
+	return layer
+}
+
+// spread lets contamination break down, leach from soil into water and run downhill
+func (ps *PollutionSystem) spread(w *World) {
+	flowed := newPollutionLayer(w)
+	for y := range ps.soil {
+		for x := range ps.soil[y] {
+			soil := ps.soil[y][x] * (1 - pollutionBreakdown)
+			leached := soil * soilLeach
+			ps.soil[y][x] = soil - leached
+			water := (ps.water[y][x] + leached) * (1 - pollutionBreakdown)
+
+			if lx, ly, ok := w.downhillCell(x, y); ok {
+				runoff := water * runoffShare
+				flowed[ly][lx] += runoff
+				water -= runoff
+			}
+			flowed[y][x] += water
+		}
+	}
+	for y := range flowed {
+		for x := range flowed[y] {
+			flowed[y][x] = math.Min(1, flowed[y][x])
+		}
+	}
+	ps.water = flowed
+}
+
+// downhillCell is the lowest neighbour of a grid cell, if any lies below it
+func (w *World) downhillCell(x, y int) (int, int, bool) {
+	if w.TopologySystem == nil {
+		return 0, 0, false
+	}
+	lowest := w.TopologySystem.getElevationAt(float64(x), float64(y))
+	bestX, bestY, found := 0, 0, false
+	for _, n := range w.adjacentCells(x, y) {
+		if elevation := w.TopologySystem.getElevationAt(float64(n.X), float64(n.Y)); elevation < lowest {
+			lowest, bestX, bestY, found = elevation, n.X, n.Y, true
+		}
+	}
+	return bestX, bestY, found
+}
+
+// deposit releases waste at a cell: some settles there in soil and water, and some blows
+// into the soil of the cell downwind
+func (ps *PollutionSystem) deposit(w *World, x, y int, amount float64) {
+	local := amount * (1 - airborneShare)
+	ps.soil[y][x] = math.Min(1, ps.soil[y][x]+local/2)
+	ps.water[y][x] = math.Min(1, ps.water[y][x]+local/2)
+
+	lx, ly := x, y
+	if w.WindSystem != nil {
+		lx += int(math.Round(math.Cos(w.WindSystem.BaseWindDirection)))
+		ly += int(math.Round(math.Sin(w.WindSystem.BaseWindDirection)))
+	}
+	if lx < 0 || lx >= w.Config.GridWidth || ly < 0 || ly >= w.Config.GridHeight {
+		lx, ly = x, y // Blown against the edge of the world
+	}
+	ps.soil[ly][lx] = math.Min(1, ps.soil[ly][lx]+amount*airborneShare)
+}
+
+// wasteSource is waste given off at one grid cell
+type wasteSource struct {
+	x, y  int
+	waste float64
+}
+
+// emit adds the waste of every tribe advanced enough to make it, less what it treats
+func (ps *PollutionSystem) emit(w *World) {
+	if w.CivilizationSystem == nil {
+		return
+	}
+	for _, tribe := range w.CivilizationSystem.Tribes {
+		if len(tribe.Members) == 0 || tribe.TechLevel < wasteTechLevel {
+			continue
+		}
+		record := ps.tribes[tribe.ID]
+		if record == nil {
+			record = &TribePollution{TribeID: tribe.ID}
+			ps.tribes[tribe.ID] = record
+			w.logPollutionEvent("colony_pollution", fmt.Sprintf("Tribe %s has begun fouling its surroundings with waste", tribe.Name))
+		}
+		treatment := wasteTreatment(tribe.TechLevel)
+		if treatment > 0 && record.Treatment == 0 {
+			w.logPollutionEvent("waste_management", fmt.Sprintf("Tribe %s has begun treating its waste", tribe.Name))
+		}
+		record.Name, record.TechLevel, record.Members, record.Treatment = tribe.Name, tribe.TechLevel, len(tribe.Members), treatment
+
+		// Members' waste collects where the tribe lives, structures' where they stand
+		centerX, centerY := 0.0, 0.0
+		for _, member := range tribe.Members {
+			centerX += member.Position.X
+			centerY += member.Position.Y
+		}
+		x, y := w.worldToGridCoords(centerX/float64(len(tribe.Members)), centerY/float64(len(tribe.Members)))
+		sources := []wasteSource{{x, y, memberWaste * float64(len(tribe.Members))}}
+		for _, structure := range tribe.Structures {
+			if waste := structureWaste[structure.Type]; waste > 0 && structure.Health > 0 {
+				sx, sy := w.worldToGridCoords(structure.Position.X, structure.Position.Y)
+				sources = append(sources, wasteSource{sx, sy, waste * structure.integrity()})
+			}
+		}
+		for _, source := range sources {
+			released := source.waste * (1 - treatment)
+			record.Produced += source.waste
+			record.Released += released
+			ps.deposit(w, source.x, source.y, released)
+		}
+	}
+}
+
+// harm drains the energy of creatures and plants living amid contamination, the tribes'
+// own members included
+func (ps *PollutionSystem) harm(w *World) {
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		x, y := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
+		if exposure := ps.exposure(x, y); exposure > 0 {
+			entity.Energy -= exposure * wildlifeHarm
+			if record := ps.tribes[entity.TribeID]; entity.TribeID != 0 && record != nil {
+				record.EnergyLost += exposure * wildlifeHarm
+			}
+		}
+	}
+	for _, plant := range w.AllPlants {
+		if !plant.IsAlive {
+			continue
+		}
+		x, y := w.worldToGridCoords(plant.Position.X, plant.Position.Y)
+		if exposure := ps.exposure(x, y); exposure > 0 {
+			plant.Energy -= exposure * plantHarm
+		}
+	}
+}
+
+// logPollutionEvent records a pollution milestone in the event log
+func (w *World) logPollutionEvent(name, description string) {
+	if w.EventLogger != nil {
+		w.EventLogger.LogWorldEvent(w.Tick, name, description)
+	}
+}
+
+// Report totals the world's contamination, each polluting tribe's part in it and exposure
+// to it, and the most contaminated cells
+func (ps *PollutionSystem) Report(w *World) PollutionReport {
+	report := PollutionReport{Tick: w.Tick, Tribes: make([]TribePollution, 0), Hotspots: make([]PollutionCell, 0)}
+	for y := range ps.soil {
+		for x := range ps.soil[y] {
+			soil, water := ps.soil[y][x], ps.water[y][x]
+			report.TotalSoil += soil
+			report.TotalWater += water
+			if soil+water > contaminatedLevel {
+				report.Contaminated++
+				report.Hotspots = append(report.Hotspots, PollutionCell{X: x, Y: y, Biome: biomeName(w.Grid[y][x].Biome), Soil: soil, Water: water})
+			}
+		}
+	}
+	sort.SliceStable(report.Hotspots, func(i, j int) bool {
+		return report.Hotspots[i].Soil+report.Hotspots[i].Water > report.Hotspots[j].Soil+report.Hotspots[j].Water
+	})
+	if len(report.Hotspots) > maxPollutionHotspots {
+		report.Hotspots = report.Hotspots[:maxPollutionHotspots]
+	}
+
+	exposure := make(map[int]float64)
+	members := make(map[int]int)
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive && entity.TribeID != 0 {
+			x, y := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
+			exposure[entity.TribeID] += ps.exposure(x, y)
+			members[entity.TribeID]++
+		}
+	}
+	for _, id := range sortedKeys(ps.tribes) {
+		record := *ps.tribes[id]
+		if members[id] > 0 {
+			record.Exposure = exposure[id] / float64(members[id])
+		}
+		report.Tribes = append(report.Tribes, record)
+	}
+	return report
+}
+
+// Clear cleans the world and forgets every tribe's waste, as when a new world is loaded
+func (ps *PollutionSystem) Clear() {
+	ps.soil, ps.water = nil, nil
+	ps.tribes = make(map[int]*TribePollution)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newPollutionTestWorld makes a flat 10x10 world with wind blowing toward +x, a dip just
+// south of the middle, and a five-member tribe living in cell (4, 4)
+func newPollutionTestWorld(techLevel int) (*World, *Tribe) {
+	world := newEmptyTestWorld(100)
+	world.WindSystem.BaseWindDirection = 0
+	for x := range world.TopologySystem.TopologyGrid {
+		for y := range world.TopologySystem.TopologyGrid[x] {
+			world.TopologySystem.TopologyGrid[x][y].Elevation = 0.5
+		}
+	}
+	world.TopologySystem.TopologyGrid[4][5].Elevation = 0.1
+
+	tribe := addTestTribe(world, "Ashfall", 5, Position{X: 45, Y: 45})
+	for _, member := range tribe.Members {
+		member.Energy = 100
+	}
+	tribe.TechLevel = techLevel
+	return world, tribe
+}
+
+// runPollution advances the pollution system by a number of updates
+func runPollution(world *World, updates int) {
+	runSystem(world, pollutionInterval, updates, world.Pollution.Update)
+}
+
+func TestPrimitiveTribesDoNotPollute(t *testing.T) {
+	world, _ := newPollutionTestWorld(wasteTechLevel - 1)
+	runPollution(world, 10)
+	if report := world.Pollution.Report(world); report.TotalSoil != 0 || report.TotalWater != 0 || len(report.Tribes) != 0 {
+		t.Errorf("Expected a tribe below waste tech to leave the world clean, got %+v", report)
+	}
+}
+
+func TestTribeWasteSpreadsDownwindAndDownhill(t *testing.T) {
+	world, tribe := newPollutionTestWorld(wasteTechLevel)
+	tribe.Structures = append(tribe.Structures, NewStructure(1, StructureFarm, Position{X: 45, Y: 45}, tribe.Leader))
+	bystander := NewEntity(sharedRand, 10, []string{"speed"}, "Deer", Position{X: 55, Y: 45})
+	faraway := NewEntity(sharedRand, 11, []string{"speed"}, "Deer", Position{X: 5, Y: 95})
+	bystander.Energy, faraway.Energy = 100, 100
+	world.AllEntities = append(world.AllEntities, bystander, faraway)
+	runPollution(world, 20)
+
+	if soil, _ := world.Pollution.At(4, 4); soil <= 0 {
+		t.Fatalf("Expected the tribe's own cell contaminated")
+	}
+	if soil, _ := world.Pollution.At(5, 4); soil <= 0 {
+		t.Errorf("Expected waste blown into the soil downwind")
+	}
+	if soil, _ := world.Pollution.At(3, 4); soil != 0 {
+		t.Errorf("Expected nothing blown upwind, got %.4f", soil)
+	}
+	if _, water := world.Pollution.At(4, 5); water <= 0 {
+		t.Errorf("Expected contaminated water to run down into the dip")
+	}
+	if _, water := world.Pollution.At(4, 3); water != 0 {
+		t.Errorf("Expected no water to run uphill, got %.4f", water)
+	}
+
+	if bystander.Energy >= 100 || faraway.Energy != 100 {
+		t.Errorf("Expected only wildlife downwind harmed, got %.2f downwind and %.2f far away", bystander.Energy, faraway.Energy)
+	}
+	report := world.Pollution.Report(world)
+	if len(report.Tribes) != 1 || report.Tribes[0].EnergyLost <= 0 || report.Tribes[0].Exposure <= 0 || report.Contaminated == 0 {
+		t.Errorf("Expected the tribe's members to suffer their own waste, got %+v", report)
+	}
+	if report.Tribes[0].Released != report.Tribes[0].Produced {
+		t.Errorf("Expected an untreating tribe to release all it produces, got %+v", report.Tribes[0])
+	}
+}
+
+func TestWasteManagementCutsPollution(t *testing.T) {
+	untreated, _ := newPollutionTestWorld(wasteManagementTechLevel - 1)
+	treated, _ := newPollutionTestWorld(wasteManagementTechLevel + 1)
+	runPollution(untreated, 20)
+	runPollution(treated, 20)
+
+	before, after := untreated.Pollution.Report(untreated), treated.Pollution.Report(treated)
+	if after.TotalSoil+after.TotalWater >= (before.TotalSoil+before.TotalWater)/2 {
+		t.Errorf("Expected waste management to more than halve contamination, got %.3f against %.3f",
+			after.TotalSoil+after.TotalWater, before.TotalSoil+before.TotalWater)
+	}
+	if after.Tribes[0].Treatment != wasteTreatment(wasteManagementTechLevel+1) || after.Tribes[0].Released >= after.Tribes[0].Produced {
+		t.Errorf("Expected the advanced tribe to treat its waste, got %+v", after.Tribes[0])
+	}
+
+	logged := false
+	for _, event := range treated.EventLogger.GetEventsByType(EventWorldEvent) {
+		if event.Data["event_name"] == "waste_management" {
+			logged = true
+		}
+	}
+	if !logged {
+		t.Errorf("Expected the start of waste treatment logged")
+	}
+}
+
+func TestPollutionAPI(t *testing.T) {
+	world, _ := newPollutionTestWorld(wasteTechLevel)
+	runPollution(world, 5)
+	wi := NewWebInterface(world)
+	rec := httptest.NewRecorder()
+	wi.handlePollution(rec, httptest.NewRequest(http.MethodGet, "/api/pollution", nil))
+	var report PollutionReport
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &report) != nil || len(report.Hotspots) == 0 || len(report.Tribes) != 1 {
+		t.Fatalf("Expected a pollution report with the tribe and its hotspot, got %d %s", rec.Code, rec.Body.String())
+	}
+}
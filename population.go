@@ -16,10 +16,13 @@ type Population struct {
 	TournamentSize   int
 	TraitNames       []string
 	Species          string
+	Selection        SelectionStrategy // How parents are picked; tournament selection when nil
+
+	rng *rand.Rand // The world's random source
 }
 
 // NewPopulation creates a new population with the specified parameters
-func NewPopulation(size int, traitNames []string, mutationRate, mutationStrength float64) *Population {
+func NewPopulation(rng *rand.Rand, size int, traitNames []string, mutationRate, mutationStrength float64) *Population {
 	pop := &Population{
 		Entities:         make([]*Entity, size),
 		Generation:       0,
@@ -29,12 +32,13 @@ func NewPopulation(size int, traitNames []string, mutationRate, mutationStrength
 		TournamentSize:   3,
 		TraitNames:       traitNames,
 		Species:          "default", // Default species name
+		rng:              rng,
 	}
 
 	// Initialize random entities
 	for i := 0; i < size; i++ {
-		pos := Position{X: rand.Float64() * 100, Y: rand.Float64() * 100}
-		pop.Entities[i] = NewEntity(i, traitNames, pop.Species, pos)
+		pos := Position{X: rng.Float64() * 100, Y: rng.Float64() * 100}
+		pop.Entities[i] = NewEntity(rng, i, traitNames, pop.Species, pos)
 	}
 
 	return pop
@@ -94,10 +98,10 @@ func (p *Population) GetStats() (float64, float64, float64) {
 
 // TournamentSelection selects an entity using tournament selection
 func (p *Population) TournamentSelection() *Entity {
-	best := p.Entities[rand.Intn(len(p.Entities))]
+	best := p.Entities[p.rng.Intn(len(p.Entities))]
 
 	for i := 1; i < p.TournamentSize; i++ {
-		candidate := p.Entities[rand.Intn(len(p.Entities))]
+		candidate := p.Entities[p.rng.Intn(len(p.Entities))]
 		if candidate.Fitness > best.Fitness {
 			best = candidate
 		}
@@ -106,8 +110,22 @@ func (p *Population) TournamentSelection() *Entity {
 	return best
 }
 
+// selection returns the population's selection strategy
+func (p *Population) selection() SelectionStrategy {
+	if p.Selection == nil {
+		return &TournamentStrategy{}
+	}
+	return p.Selection
+}
+
 // Evolve performs one generation of evolution
 func (p *Population) Evolve() {
+	p.EvolveWith(nil)
+}
+
+// EvolveWith performs one generation of evolution, mutating children with the operators
+// of a mutation system (nil for the defaults)
+func (p *Population) EvolveWith(mutations *MutationSystem) {
 	// Sort by fitness
 	p.SortByFitness()
 
@@ -117,17 +135,19 @@ func (p *Population) Evolve() {
 
 	// Keep elite individuals
 	for i := 0; i < p.EliteSize && i < len(p.Entities); i++ {
-		newGeneration[i] = p.Entities[i].Clone()
+		newGeneration[i] = p.Entities[i].Clone(p.rng)
 		newGeneration[i].ID = nextID
 		nextID++
 	}
 	// Fill the rest through crossover and mutation
+	selection := p.selection()
+	selection.Prepare(p)
 	for i := p.EliteSize; i < len(p.Entities); i++ {
-		parent1 := p.TournamentSelection()
-		parent2 := p.TournamentSelection()
+		parent1 := selection.Select(p)
+		parent2 := selection.Select(p)
 
-		child := Crossover(parent1, parent2, nextID, p.Species)
-		child.Mutate(p.MutationRate, p.MutationStrength)
+		child := Crossover(p.rng, parent1, parent2, nextID, p.Species)
+		child.MutateWith(p.rng, mutations, p.MutationRate, p.MutationStrength)
 
 		newGeneration[i] = child
 		nextID++
@@ -0,0 +1,406 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Population cap settings
+const (
+	populationCapInterval  = 10  // Ticks between density checks
+	maxDispersalPool       = 500 // Creatures held offstage; beyond this the longest gone settle elsewhere for good
+	dispersalReturnMargin  = 0.9 // Pooled creatures return once the population falls below this share of the cap
+	defaultCapRegionCells  = 10  // Region side in grid cells when none is configured
+	maxReportedCapRegions  = 10  // Most crowded regions listed in the status
+	capRegionEdgeClearance = 0.5 // Keeps arrivals off the exact region border, in world units
+)
+
+// CapRegion is the head count of one cap region
+type CapRegion struct {
+	X          int `json:"x"` // Region column
+	Y          int `json:"y"` // Region row
+	Population int `json:"population"`
+}
+
+// PopulationCapStatus reports the soft caps and how much dispersal they have caused
+type PopulationCapStatus struct {
+	WorldCap      int            `json:"world_cap"`
+	RegionCap     int            `json:"region_cap"`
+	RegionSize    int            `json:"region_size"`
+	Population    int            `json:"population"`
+	Pooled        int            `json:"pooled"`         // Creatures waiting offstage in the dispersal pool
+	PooledSpecies map[string]int `json:"pooled_species"` // Pooled creatures per species
+	Emigrated     int            `json:"emigrated"`      // Moves into a neighbouring region so far
+	Dispersed     int            `json:"dispersed"`      // Creatures sent to the dispersal pool so far
+	Returned      int            `json:"returned"`       // Creatures back from the pool so far
+	Settled       int            `json:"settled"`        // Creatures that left the pool for good
+	Crowded       []CapRegion    `json:"crowded"`        // Most populous regions
+}
+
+// PopulationCapSystem keeps populations under soft caps for the whole world and for each
+// region of the map. Instead of culling, the surplus of a crowded region emigrates to a
+// neighbouring region with room, and whatever the world cannot hold waits offstage in a
+// dispersal pool until numbers fall again. The youngest disperse first, as in natal
+// dispersal.
+type PopulationCapSystem struct {
+	mu       sync.Mutex
+	eventBus *CentralEventBus
+	pool     []*Entity // Oldest departures first
+
+	emigrated int
+	dispersed int
+	returned  int
+	settled   int
+}
+
+// NewPopulationCapSystem creates a cap system with an empty dispersal pool
+func NewPopulationCapSystem(eventBus *CentralEventBus) *PopulationCapSystem {
+	return &PopulationCapSystem{eventBus: eventBus}
+}
+
+// markPooled adds the IDs of pooled creatures to ids
+func (pc *PopulationCapSystem) markPooled(ids map[int]bool) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	for _, entity := range pc.pool {
+		ids[entity.ID] = true
+	}
+}
+
+// PoolSize returns how many creatures wait in the dispersal pool
+func (pc *PopulationCapSystem) PoolSize() int {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	return len(pc.pool)
+}
+
+// capRegions divides the map into square regions for the density check
+type capRegions struct {
+	cells   int // Region side in grid cells
+	columns int
+	rows    int
+	members [][]*Entity // Living creatures per region, row-major
+}
+
+// Update moves the surplus of crowded regions to their neighbours, sends what the world
+// cannot hold to the dispersal pool and brings pooled creatures back when there is room
+func (pc *PopulationCapSystem) Update(w *World) {
+	if w.Tick%populationCapInterval != 0 {
+		return
+	}
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	settings := w.SimConfig.Population
+	regions := newCapRegions(w, settings.RegionSize)
+	emigrated, dispersed, returned := 0, 0, 0
+	leaving := make(map[*Entity]bool)
+
+	// Crowded regions shed their youngest to the emptiest neighbour with room
+	if settings.RegionSoftCap > 0 {
+		for index := range regions.members {
+			for len(regions.members[index]) > settings.RegionSoftCap {
+				emigrant := regions.takeYoungest(index)
+				if target := regions.roomiestNeighbour(index, settings.RegionSoftCap); target >= 0 {
+					regions.place(w, emigrant, target)
+					emigrated++
+				} else {
+					leaving[emigrant] = true
+				}
+			}
+		}
+	}
+
+	// Beyond the world cap, the densest regions send their youngest offstage
+	living := regions.population()
+	if settings.MaxPopulation > 0 {
+		for ; living > settings.MaxPopulation; living-- {
+			leaving[regions.takeYoungest(regions.densest())] = true
+		}
+	}
+	if len(leaving) > 0 {
+		pc.withdraw(w, leaving)
+		dispersed = len(leaving)
+	}
+
+	// Pooled creatures come back to the emptiest regions once numbers have fallen
+	room := len(pc.pool)
+	if settings.MaxPopulation > 0 {
+		room = min(room, int(float64(settings.MaxPopulation)*dispersalReturnMargin)-living)
+	}
+	for ; room > 0 && len(pc.pool) > 0; room-- {
+		target := regions.emptiest(settings.RegionSoftCap)
+		if target < 0 {
+			break
+		}
+		entity := pc.pool[0]
+		pc.pool = pc.pool[1:]
+		regions.place(w, entity, target)
+		pc.readmit(w, entity)
+		returned++
+	}
+
+	// The pool is bounded too; the longest gone have settled somewhere offstage
+	for len(pc.pool) > maxDispersalPool {
+		w.forgetEntity(pc.pool[0])
+		pc.pool = pc.pool[1:]
+		pc.settled++
+	}
+
+	pc.emigrated += emigrated
+	pc.dispersed += dispersed
+	pc.returned += returned
+	if emigrated+dispersed+returned > 0 && pc.eventBus != nil {
+		pc.eventBus.EmitSystemEvent(w.Tick, "population_dispersal", "population_caps", "population_caps",
+			fmt.Sprintf("Density dispersal: %d emigrated to neighbouring regions, %d left for the dispersal pool, %d returned",
+				emigrated, dispersed, returned), nil,
+			map[string]interface{}{"emigrated": emigrated, "dispersed": dispersed, "returned": returned, "pooled": len(pc.pool)})
+	}
+}
+
+// Status reports the caps, the pool and the most crowded regions
+func (pc *PopulationCapSystem) Status(w *World) PopulationCapStatus {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	settings := w.SimConfig.Population
+	regions := newCapRegions(w, settings.RegionSize)
+	status := PopulationCapStatus{
+		WorldCap:      settings.MaxPopulation,
+		RegionCap:     settings.RegionSoftCap,
+		RegionSize:    regions.cells,
+		Population:    regions.population(),
+		Pooled:        len(pc.pool),
+		PooledSpecies: make(map[string]int),
+		Emigrated:     pc.emigrated,
+		Dispersed:     pc.dispersed,
+		Returned:      pc.returned,
+		Settled:       pc.settled,
+		Crowded:       make([]CapRegion, 0, len(regions.members)),
+	}
+	for _, entity := range pc.pool {
+		status.PooledSpecies[entity.Species]++
+	}
+	for index, members := range regions.members {
+		if len(members) > 0 {
+			status.Crowded = append(status.Crowded, CapRegion{X: index % regions.columns, Y: index / regions.columns, Population: len(members)})
+		}
+	}
+	sort.SliceStable(status.Crowded, func(i, j int) bool { return status.Crowded[i].Population > status.Crowded[j].Population })
+	if len(status.Crowded) > maxReportedCapRegions {
+		status.Crowded = status.Crowded[:maxReportedCapRegions]
+	}
+	return status
+}
+
+// Clear empties the dispersal pool and counters, e.g. when the world is reset
+func (pc *PopulationCapSystem) Clear() {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.pool = nil
+	pc.emigrated, pc.dispersed, pc.returned, pc.settled = 0, 0, 0, 0
+}
+
+// DispersalState is the dispersal pool and counters a save keeps
+type DispersalState struct {
+	Pool      []*EntityState `json:"pool,omitempty"` // Oldest departures first
+	Emigrated int            `json:"emigrated"`
+	Dispersed int            `json:"dispersed"`
+	Returned  int            `json:"returned"`
+	Settled   int            `json:"settled"`
+}
+
+// State returns the pool, converted by save, and the counters for a save; nil when the caps
+// have moved no one
+func (pc *PopulationCapSystem) State(save func([]*Entity) []*EntityState) *DispersalState {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if len(pc.pool) == 0 && pc.emigrated+pc.dispersed+pc.returned+pc.settled == 0 {
+		return nil
+	}
+	return &DispersalState{
+		Pool:      save(pc.pool),
+		Emigrated: pc.emigrated,
+		Dispersed: pc.dispersed,
+		Returned:  pc.returned,
+		Settled:   pc.settled,
+	}
+}
+
+// Restore brings back the pool and counters of a save, rebuilding each pooled creature
+// with restore
+func (pc *PopulationCapSystem) Restore(state *DispersalState, restore func(*EntityState) *Entity) {
+	pc.Clear()
+	if state == nil {
+		return
+	}
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	for _, saved := range state.Pool {
+		pc.pool = append(pc.pool, restore(saved))
+	}
+	pc.emigrated, pc.dispersed, pc.returned, pc.settled = state.Emigrated, state.Dispersed, state.Returned, state.Settled
+}
+
+// withdraw takes creatures out of the world into the dispersal pool, keeping their minds
+// and organisms for when they return; the caller holds the lock
+func (pc *PopulationCapSystem) withdraw(w *World, leaving map[*Entity]bool) {
+	remaining := make([]*Entity, 0, len(w.AllEntities))
+	for _, entity := range w.AllEntities {
+		if leaving[entity] {
+			pc.pool = append(pc.pool, entity)
+		} else {
+			remaining = append(remaining, entity)
+		}
+	}
+	w.AllEntities = remaining
+	for _, pop := range w.Populations {
+		members := make([]*Entity, 0, len(pop.Entities))
+		for _, entity := range pop.Entities {
+			if !leaving[entity] {
+				members = append(members, entity)
+			}
+		}
+		pop.Entities = members
+	}
+}
+
+// readmit puts a returning creature back into the world and its species' population;
+// the caller holds the lock
+func (pc *PopulationCapSystem) readmit(w *World, entity *Entity) {
+	w.AllEntities = append(w.AllEntities, entity)
+	pop, exists := w.Populations[entity.Species]
+	if !exists {
+		pop = NewPopulation(w.Rand, 0, sortedKeys(entity.Traits), 0.1, 0.2)
+		pop.Species = entity.Species
+		w.Populations[entity.Species] = pop
+	}
+	pop.Entities = append(pop.Entities, entity)
+}
+
+// forgetEntity drops what the world keeps for a creature that has left it for good
+func (w *World) forgetEntity(entity *Entity) {
+	if w.NeuralAISystem != nil {
+		delete(w.NeuralAISystem.EntityNetworks, entity.ID)
+	}
+	if w.CellularSystem != nil {
+		delete(w.CellularSystem.OrganismMap, entity.ID)
+	}
+}
+
+// newCapRegions groups the living creatures by region
+func newCapRegions(w *World, cells int) *capRegions {
+	if cells <= 0 {
+		cells = defaultCapRegionCells
+	}
+	regions := &capRegions{
+		cells:   cells,
+		columns: max(1, (w.Config.GridWidth+cells-1)/cells),
+		rows:    max(1, (w.Config.GridHeight+cells-1)/cells),
+	}
+	regions.members = make([][]*Entity, regions.columns*regions.rows)
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		gridX := int(entity.Position.X * float64(w.Config.GridWidth) / w.Config.Width)
+		gridY := int(entity.Position.Y * float64(w.Config.GridHeight) / w.Config.Height)
+		column := min(max(gridX/cells, 0), regions.columns-1)
+		row := min(max(gridY/cells, 0), regions.rows-1)
+		index := row*regions.columns + column
+		regions.members[index] = append(regions.members[index], entity)
+	}
+	return regions
+}
+
+// population counts the creatures in all regions
+func (r *capRegions) population() int {
+	total := 0
+	for _, members := range r.members {
+		total += len(members)
+	}
+	return total
+}
+
+// takeYoungest removes and returns the youngest creature of a region
+func (r *capRegions) takeYoungest(index int) *Entity {
+	members := r.members[index]
+	youngest := youngestMember(members)
+	entity := members[youngest]
+	r.members[index] = append(members[:youngest], members[youngest+1:]...)
+	return entity
+}
+
+// densest returns the most populous region; between equally crowded regions, the one
+// with the youngest creature
+func (r *capRegions) densest() int {
+	densest := 0
+	for index, members := range r.members {
+		switch {
+		case len(members) > len(r.members[densest]):
+			densest = index
+		case len(members) > 0 && len(members) == len(r.members[densest]):
+			if members[youngestMember(members)].Age < r.members[densest][youngestMember(r.members[densest])].Age {
+				densest = index
+			}
+		}
+	}
+	return densest
+}
+
+// youngestMember returns the index of the youngest creature, lowest ID first on ties
+func youngestMember(members []*Entity) int {
+	youngest := 0
+	for i, entity := range members {
+		if entity.Age < members[youngest].Age || (entity.Age == members[youngest].Age && entity.ID < members[youngest].ID) {
+			youngest = i
+		}
+	}
+	return youngest
+}
+
+// emptiest returns the least populous region under the cap, or -1 when all are full
+func (r *capRegions) emptiest(capacity int) int {
+	emptiest := -1
+	for index, members := range r.members {
+		if (capacity <= 0 || len(members) < capacity) && (emptiest < 0 || len(members) < len(r.members[emptiest])) {
+			emptiest = index
+		}
+	}
+	return emptiest
+}
+
+// roomiestNeighbour returns the least populous adjacent region under the cap, or -1
+func (r *capRegions) roomiestNeighbour(index, capacity int) int {
+	column, row := index%r.columns, index/r.columns
+	best := -1
+	for _, offset := range [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+		x, y := column+offset[0], row+offset[1]
+		if x < 0 || y < 0 || x >= r.columns || y >= r.rows {
+			continue
+		}
+		neighbour := y*r.columns + x
+		if len(r.members[neighbour]) < capacity && (best < 0 || len(r.members[neighbour]) < len(r.members[best])) {
+			best = neighbour
+		}
+	}
+	return best
+}
+
+// place moves a creature to a random spot in a region and counts it there
+func (r *capRegions) place(w *World, entity *Entity, index int) {
+	cellWidth := w.Config.Width / float64(w.Config.GridWidth)
+	cellHeight := w.Config.Height / float64(w.Config.GridHeight)
+	minX := float64(index%r.columns*r.cells) * cellWidth
+	minY := float64(index/r.columns*r.cells) * cellHeight
+	maxX := math.Min(w.Config.Width, minX+float64(r.cells)*cellWidth) - capRegionEdgeClearance
+	maxY := math.Min(w.Config.Height, minY+float64(r.cells)*cellHeight) - capRegionEdgeClearance
+	minX += capRegionEdgeClearance
+	minY += capRegionEdgeClearance
+	entity.Position = Position{
+		X: minX + w.Rand.Float64()*math.Max(0, maxX-minX),
+		Y: minY + w.Rand.Float64()*math.Max(0, maxY-minY),
+	}
+	r.members[index] = append(r.members[index], entity)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRegionCapEmigratesInsteadOfCulling(t *testing.T) {
+	world := newSteppingTestWorld(41)
+	world.SimConfig.Population.RegionSoftCap = 4
+	for _, entity := range world.AllEntities {
+		entity.Position = Position{X: 1, Y: 1}
+	}
+	total := len(world.AllEntities)
+	if total <= 4 {
+		t.Fatalf("Expected more than a region's worth of creatures, got %d", total)
+	}
+
+	world.Tick = populationCapInterval
+	world.PopulationCaps.Update(world)
+
+	status := world.PopulationCaps.Status(world)
+	if status.Population+status.Pooled != total {
+		t.Fatalf("Expected every creature to be kept, got %d in the world and %d pooled of %d", status.Population, status.Pooled, total)
+	}
+	if status.Emigrated != 8 || status.Crowded[0].Population > 4 {
+		t.Errorf("Expected the crowded corner to fill its two neighbours, got %+v", status)
+	}
+}
+
+func TestWorldCapUsesDispersalPool(t *testing.T) {
+	world := newSteppingTestWorld(42)
+	total := len(world.AllEntities)
+	oldest := world.AllEntities[0]
+	oldest.Age = 50
+	world.SimConfig.Population.MaxPopulation = 4
+
+	world.Tick = populationCapInterval
+	world.PopulationCaps.Update(world)
+	status := world.PopulationCaps.Status(world)
+	if len(world.AllEntities) != 4 || status.Pooled != total-4 || status.Dispersed != total-4 {
+		t.Fatalf("Expected %d creatures pooled offstage, got %+v", total-4, status)
+	}
+	if world.findEntityByID(oldest.ID) != oldest {
+		t.Error("Expected the oldest creature to stay while the young dispersed")
+	}
+	members := 0
+	for _, pop := range world.Populations {
+		members += len(pop.Entities)
+	}
+	if members != 4 {
+		t.Errorf("Expected populations to drop pooled creatures, %d remain", members)
+	}
+
+	// Room opens up and the pool empties back into the world
+	world.SimConfig.Population.MaxPopulation = 100
+	world.Tick += populationCapInterval
+	world.PopulationCaps.Update(world)
+	status = world.PopulationCaps.Status(world)
+	if len(world.AllEntities) != total || status.Pooled != 0 || status.Returned != total-4 {
+		t.Errorf("Expected all %d creatures back, got %+v", total, status)
+	}
+}
+
+func TestPopulationCapsAPI(t *testing.T) {
+	wi := NewWebInterface(newSteppingTestWorld(43))
+
+	rec := httptest.NewRecorder()
+	wi.handlePopulationCaps(rec, httptest.NewRequest(http.MethodPost, "/api/population-caps", bytes.NewReader([]byte(`{"region_cap": -1}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected a negative cap to be rejected, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handlePopulationCaps(rec, httptest.NewRequest(http.MethodPost, "/api/population-caps", bytes.NewReader([]byte(`{"region_cap": 25}`))))
+	var status PopulationCapStatus
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &status) != nil {
+		t.Fatalf("Setting the region cap failed: %d %s", rec.Code, rec.Body.String())
+	}
+	if status.RegionCap != 25 || status.WorldCap != DefaultSimulationConfig().Population.MaxPopulation || status.RegionSize != 10 {
+		t.Errorf("Expected only the region cap to change, got %+v", status)
+	}
+}
+
+func TestConfiguredCapsAreSavedAndCertified(t *testing.T) {
+	config, err := (&RunConfig{World: RunWorldConfig{PopulationCap: 500, RegionCap: 12}}).WorldConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	world := NewWorld(config)
+	if settings := world.SimConfig.Population; settings.MaxPopulation != 500 || settings.RegionSoftCap != 12 {
+		t.Fatalf("Expected the configured caps applied, got %+v", settings)
+	}
+	if cert := world.HashChain.Certificate(world); cert.Config.Simulation == nil || cert.Config.Simulation.Population.RegionSoftCap != 12 {
+		t.Error("Expected the run certificate to record the caps")
+	}
+
+	path := filepath.Join(t.TempDir(), "capped.json")
+	if err := NewStateManager(world).SaveToFile(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded := newSteppingTestWorld(147)
+	if err := NewStateManager(loaded).LoadFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if settings := loaded.SimConfig.Population; settings.MaxPopulation != 500 || settings.RegionSoftCap != 12 {
+		t.Errorf("Expected the caps restored with the save, got %+v", settings)
+	}
+
+	// Without caps the configuration keeps its default simulation settings
+	if config, _ := (&RunConfig{}).WorldConfig(); config.Simulation != nil {
+		t.Error("Expected no simulation settings made for a run without caps")
+	}
+}
+
+func TestDispersalPoolIsSavedAndRestored(t *testing.T) {
+	world := newSteppingTestWorld(148)
+	world.SimConfig.Population.MaxPopulation = 4
+	world.Tick = populationCapInterval
+	world.PopulationCaps.Update(world)
+	saved := world.PopulationCaps.Status(world)
+	if saved.Pooled == 0 {
+		t.Fatal("Expected creatures waiting in the dispersal pool")
+	}
+	pooled := make(map[int]bool)
+	world.PopulationCaps.markPooled(pooled)
+
+	path := filepath.Join(t.TempDir(), "pooled.json")
+	if err := NewStateManager(world).SaveToFile(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded := newSteppingTestWorld(149)
+	if err := NewStateManager(loaded).LoadFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+	status := loaded.PopulationCaps.Status(loaded)
+	if status.Pooled != saved.Pooled || status.Dispersed != saved.Dispersed || status.Emigrated != saved.Emigrated ||
+		!reflect.DeepEqual(status.PooledSpecies, saved.PooledSpecies) {
+		t.Fatalf("Expected the pool and counters restored, saved %+v, loaded %+v", saved, status)
+	}
+	restored := make(map[int]bool)
+	loaded.PopulationCaps.markPooled(restored)
+	if !reflect.DeepEqual(restored, pooled) || len(loaded.AllEntities) != 4 {
+		t.Fatalf("Expected the same creatures offstage and only the rest in the world, got %v", restored)
+	}
+
+	// The restored creatures come back once there is room
+	loaded.SimConfig.Population.MaxPopulation = 100
+	loaded.Tick += populationCapInterval
+	loaded.PopulationCaps.Update(loaded)
+	status = loaded.PopulationCaps.Status(loaded)
+	if status.Pooled != 0 || status.Returned != saved.Pooled || len(loaded.AllEntities) != 4+saved.Pooled {
+		t.Errorf("Expected all pooled creatures back, got %+v", status)
+	}
+}
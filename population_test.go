@@ -12,7 +12,7 @@ func TestNewPopulation(t *testing.T) {
 	mutationRate := 0.1
 	mutationStrength := 0.2
 
-	pop := NewPopulation(size, traitNames, mutationRate, mutationStrength)
+	pop := NewPopulation(sharedRand, size, traitNames, mutationRate, mutationStrength)
 
 	if len(pop.Entities) != size {
 		t.Errorf("Expected population size %d, got %d", size, len(pop.Entities))
@@ -39,7 +39,7 @@ func TestNewPopulation(t *testing.T) {
 }
 
 func TestPopulationEvaluateFitness(t *testing.T) {
-	pop := NewPopulation(5, []string{"strength"}, 0.1, 0.1)
+	pop := NewPopulation(sharedRand, 5, []string{"strength"}, 0.1, 0.1)
 
 	// Simple fitness function: fitness = strength value
 	fitnessFunc := func(entity *Entity) float64 {
@@ -57,7 +57,7 @@ func TestPopulationEvaluateFitness(t *testing.T) {
 }
 
 func TestPopulationSortByFitness(t *testing.T) {
-	pop := NewPopulation(3, []string{"strength"}, 0.1, 0.1)
+	pop := NewPopulation(sharedRand, 3, []string{"strength"}, 0.1, 0.1)
 
 	// Set specific fitness values
 	pop.Entities[0].Fitness = 0.3
@@ -79,7 +79,7 @@ func TestPopulationSortByFitness(t *testing.T) {
 }
 
 func TestPopulationGetBest(t *testing.T) {
-	pop := NewPopulation(3, []string{"strength"}, 0.1, 0.1)
+	pop := NewPopulation(sharedRand, 3, []string{"strength"}, 0.1, 0.1)
 
 	// Set specific fitness values
 	pop.Entities[0].Fitness = 0.3
@@ -97,7 +97,7 @@ func TestPopulationGetBest(t *testing.T) {
 }
 
 func TestPopulationGetStats(t *testing.T) {
-	pop := NewPopulation(3, []string{"strength"}, 0.1, 0.1)
+	pop := NewPopulation(sharedRand, 3, []string{"strength"}, 0.1, 0.1)
 
 	// Set specific fitness values
 	pop.Entities[0].Fitness = 0.2 // min
@@ -118,7 +118,7 @@ func TestPopulationGetStats(t *testing.T) {
 }
 
 func TestPopulationTournamentSelection(t *testing.T) {
-	pop := NewPopulation(10, []string{"strength"}, 0.1, 0.1)
+	pop := NewPopulation(sharedRand, 10, []string{"strength"}, 0.1, 0.1)
 
 	// Set one entity with very high fitness
 	pop.Entities[5].Fitness = 10.0
@@ -128,9 +128,9 @@ func TestPopulationTournamentSelection(t *testing.T) {
 		}
 	}
 
-	// Set a deterministic seed right before the tournament selections
-	rand.Seed(123)
-	
+	// Seed the population's source right before the tournament selections
+	pop.rng = rand.New(rand.NewSource(123))
+
 	// Run tournament selection multiple times
 	selections := make(map[int]int)
 	for i := 0; i < 100; i++ {
@@ -145,7 +145,7 @@ func TestPopulationTournamentSelection(t *testing.T) {
 }
 
 func TestPopulationEvolve(t *testing.T) {
-	pop := NewPopulation(20, []string{"strength"}, 0.1, 0.1)
+	pop := NewPopulation(sharedRand, 20, []string{"strength"}, 0.1, 0.1)
 
 	// Set fitness values
 	for i, entity := range pop.Entities {
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// Arms race kinds
+const (
+	ArmsRacePredatorPrey = "predator_prey"
+	ArmsRaceHostParasite = "host_parasite"
+)
+
+// Red Queen tracking parameters
+const (
+	redQueenInterval   = 10  // Ticks between samples of each pair's traits
+	redQueenSamples    = 100 // Samples kept per pair
+	redQueenMaxLag     = 10  // Samples searched either way for who leads
+	redQueenMemory     = 300 // Ticks without an interaction before a pair is dropped
+	redQueenMinOverlap = 8   // Paired samples needed before a correlation means anything
+)
+
+// armsRaceAxis pairs an attacker trait with the defender trait that counters it
+type armsRaceAxis struct {
+	attacker string
+	defender string
+}
+
+// armsRaceAxes are the traits each kind of arms race is fought over. Predators need
+// aggression, strength and size to overpower defense, strength and size, and speed to catch
+// prey; parasites need virulence to overcome their hosts' resistance.
+var armsRaceAxes = map[string][]armsRaceAxis{
+	ArmsRacePredatorPrey: {{"aggression", "defense"}, {"strength", "strength"}, {"size", "size"}, {"speed", "speed"}},
+	ArmsRaceHostParasite: {{"virulence", "resistance"}},
+}
+
+// RedQueenSample is the mean attacking and defending traits of a pair at one tick
+type RedQueenSample struct {
+	Tick     int                `json:"tick"`
+	Attacker map[string]float64 `json:"attacker"`
+	Defender map[string]float64 `json:"defender"`
+}
+
+// RedQueenPair is an interacting pair of species whose traits are followed together
+type RedQueenPair struct {
+	Kind            string           `json:"kind"`
+	Attacker        string           `json:"attacker"` // Predator or parasite species
+	Defender        string           `json:"defender"` // Prey or host species
+	Interactions    int              `json:"interactions"`
+	LastInteraction int              `json:"last_interaction"`
+	Samples         []RedQueenSample `json:"samples"`
+}
+
+// RedQueenAxis measures how an attacker trait and its counter move together. A positive
+// lag means the defender follows the attacker that many ticks later; a negative one means
+// the attacker is catching up with the defender.
+type RedQueenAxis struct {
+	AttackerTrait     string    `json:"attacker_trait"`
+	DefenderTrait     string    `json:"defender_trait"`
+	Correlation       float64   `json:"correlation"`        // At no lag
+	BestLag           int       `json:"best_lag"`           // Ticks, at the strongest correlation
+	BestCorrelation   float64   `json:"best_correlation"`   // Correlation at that lag
+	Leader            string    `json:"leader"`             // "attacker", "defender" or "" when in step
+	AttackerAmplitude float64   `json:"attacker_amplitude"` // Standard deviation of the trait over the samples
+	DefenderAmplitude float64   `json:"defender_amplitude"`
+	Ticks             []int     `json:"ticks"`
+	AttackerSeries    []float64 `json:"attacker_series"`
+	DefenderSeries    []float64 `json:"defender_series"`
+}
+
+// RedQueenReport summarizes the arms race between one pair
+type RedQueenReport struct {
+	Kind         string         `json:"kind"`
+	Attacker     string         `json:"attacker"`
+	Defender     string         `json:"defender"`
+	Interactions int            `json:"interactions"`
+	Samples      int            `json:"samples"`
+	Axes         []RedQueenAxis `json:"axes"`
+}
+
+// RedQueenSystem follows coupled trait changes between predators and their prey and
+// between parasites and their hosts
+type RedQueenSystem struct {
+	pairs map[string]*RedQueenPair
+}
+
+// NewRedQueenSystem creates a system with no pairs yet
+func NewRedQueenSystem() *RedQueenSystem {
+	return &RedQueenSystem{pairs: make(map[string]*RedQueenPair)}
+}
+
+// RecordInteraction notes that an attacker species preyed on or parasitized a defender,
+// starting to follow the pair if it is new
+func (rqs *RedQueenSystem) RecordInteraction(kind, attacker, defender string, tick int) {
+	if attacker == defender {
+		return
+	}
+	key := kind + "|" + attacker + "|" + defender
+	pair := rqs.pairs[key]
+	if pair == nil {
+		pair = &RedQueenPair{Kind: kind, Attacker: attacker, Defender: defender, Samples: make([]RedQueenSample, 0)}
+		rqs.pairs[key] = pair
+	}
+	pair.Interactions++
+	pair.LastInteraction = tick
+}
+
+// recordPredation notes a kill in the arms race between the predator's and the prey's species
+func (w *World) recordPredation(predator, prey *Entity) {
+	if w.RedQueen != nil {
+		w.RedQueen.RecordInteraction(ArmsRacePredatorPrey, predator.Species, prey.Species, w.Tick)
+	}
+	if w.Zoonoses != nil {
+		w.Zoonoses.RecordHunt(w, predator, prey)
+	}
+	if w.Audio != nil {
+		w.Audio.RecordFight(w, prey.Position)
+	}
+}
+
+// Update samples every followed pair's traits every redQueenInterval ticks, dropping pairs
+// that have stopped interacting
+func (rqs *RedQueenSystem) Update(w *World) {
+	if w.Tick%redQueenInterval != 0 {
+		return
+	}
+
+	// Parasitism is ongoing, so each active relationship counts as an interaction
+	parasitism := make(map[string][2]float64) // attacker|defender -> summed virulence, resistance
+	counts := make(map[string]int)
+	if w.SymbioticRelationships != nil {
+		for _, relationship := range w.SymbioticRelationships.Relationships {
+			if !relationship.IsActive || relationship.Type != RelationshipParasitic {
+				continue
+			}
+			host, symbiont := w.findEntityByID(relationship.HostID), w.findEntityByID(relationship.SymbiontID)
+			if host == nil || symbiont == nil || host.Species == symbiont.Species {
+				continue
+			}
+			rqs.RecordInteraction(ArmsRaceHostParasite, symbiont.Species, host.Species, w.Tick)
+			key := symbiont.Species + "|" + host.Species
+			sums := parasitism[key]
+			parasitism[key] = [2]float64{sums[0] + relationship.Virulence, sums[1] + relationship.Resistance}
+			counts[key]++
+		}
+	}
+
+	means := make(map[string]map[string]float64)
+	speciesMeans := func(species string) map[string]float64 {
+		if _, ok := means[species]; !ok {
+			means[species] = speciesGeneMeans(w, species)
+		}
+		return means[species]
+	}
+
+	for _, key := range sortedKeys(rqs.pairs) {
+		pair := rqs.pairs[key]
+		if w.Tick-pair.LastInteraction > redQueenMemory {
+			delete(rqs.pairs, key)
+			continue
+		}
+
+		sample := RedQueenSample{Tick: w.Tick, Attacker: make(map[string]float64), Defender: make(map[string]float64)}
+		switch pair.Kind {
+		case ArmsRaceHostParasite:
+			count := counts[pair.Attacker+"|"+pair.Defender]
+			if count == 0 {
+				continue
+			}
+			sums := parasitism[pair.Attacker+"|"+pair.Defender]
+			sample.Attacker["virulence"] = sums[0] / float64(count)
+			sample.Defender["resistance"] = sums[1] / float64(count)
+		default:
+			attacker, defender := speciesMeans(pair.Attacker), speciesMeans(pair.Defender)
+			if attacker == nil || defender == nil {
+				continue
+			}
+			for _, axis := range armsRaceAxes[pair.Kind] {
+				sample.Attacker[axis.attacker] = attacker[axis.attacker]
+				sample.Defender[axis.defender] = defender[axis.defender]
+			}
+		}
+		pair.Samples = append(pair.Samples, sample)
+		if len(pair.Samples) > redQueenSamples {
+			pair.Samples = pair.Samples[len(pair.Samples)-redQueenSamples:]
+		}
+	}
+}
+
+// laggedCorrelation correlates x with y shifted by lag samples, so a positive lag pairs
+// each x with a later y. It reports false when too few samples overlap.
+func laggedCorrelation(x, y []float64, lag int) (float64, bool) {
+	var xs, ys []float64
+	for i := range x {
+		if j := i + lag; j >= 0 && j < len(y) {
+			xs = append(xs, x[i])
+			ys = append(ys, y[j])
+		}
+	}
+	if len(xs) < redQueenMinOverlap {
+		return 0, false
+	}
+	meanX, meanY := meanOf(xs), meanOf(ys)
+	var covariance, varianceX, varianceY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+	if varianceX == 0 || varianceY == 0 {
+		return 0, true // A trait that never moved is correlated with nothing
+	}
+	return covariance / math.Sqrt(varianceX*varianceY), true
+}
+
+// meanOf averages a series
+func meanOf(values []float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+// stdDevOf is a series' population standard deviation
+func stdDevOf(values []float64) float64 {
+	mean := meanOf(values)
+	sum := 0.0
+	for _, value := range values {
+		sum += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+// analyze computes the lead and lag correlations of each of the pair's axes
+func (pair *RedQueenPair) analyze() RedQueenReport {
+	report := RedQueenReport{
+		Kind:         pair.Kind,
+		Attacker:     pair.Attacker,
+		Defender:     pair.Defender,
+		Interactions: pair.Interactions,
+		Samples:      len(pair.Samples),
+		Axes:         make([]RedQueenAxis, 0),
+	}
+	for _, axis := range armsRaceAxes[pair.Kind] {
+		result := RedQueenAxis{AttackerTrait: axis.attacker, DefenderTrait: axis.defender}
+		for _, sample := range pair.Samples {
+			result.Ticks = append(result.Ticks, sample.Tick)
+			result.AttackerSeries = append(result.AttackerSeries, sample.Attacker[axis.attacker])
+			result.DefenderSeries = append(result.DefenderSeries, sample.Defender[axis.defender])
+		}
+		if len(result.Ticks) > 0 {
+			result.AttackerAmplitude = stdDevOf(result.AttackerSeries)
+			result.DefenderAmplitude = stdDevOf(result.DefenderSeries)
+		}
+		result.Correlation, _ = laggedCorrelation(result.AttackerSeries, result.DefenderSeries, 0)
+		result.BestCorrelation = result.Correlation
+		for lag := -redQueenMaxLag; lag <= redQueenMaxLag; lag++ {
+			if correlation, ok := laggedCorrelation(result.AttackerSeries, result.DefenderSeries, lag); ok &&
+				math.Abs(correlation) > math.Abs(result.BestCorrelation)+1e-9 {
+				result.BestCorrelation = correlation
+				result.BestLag = lag * redQueenInterval
+			}
+		}
+		switch {
+		case result.BestLag > 0:
+			result.Leader = "attacker"
+		case result.BestLag < 0:
+			result.Leader = "defender"
+		}
+		report.Axes = append(report.Axes, result)
+	}
+	return report
+}
+
+// Report analyzes every followed pair, those interacting most first
+func (rqs *RedQueenSystem) Report() []RedQueenReport {
+	reports := make([]RedQueenReport, 0, len(rqs.pairs))
+	for _, key := range sortedKeys(rqs.pairs) {
+		reports = append(reports, rqs.pairs[key].analyze())
+	}
+	sort.SliceStable(reports, func(i, j int) bool { return reports[i].Interactions > reports[j].Interactions })
+	return reports
+}
+
+// Clear forgets every pair, as when a new world is loaded
+func (rqs *RedQueenSystem) Clear() {
+	rqs.pairs = make(map[string]*RedQueenPair)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRedQueenLeadLag(t *testing.T) {
+	rqs := NewRedQueenSystem()
+	rqs.RecordInteraction(ArmsRacePredatorPrey, "Fox", "Hare", 0)
+	pair := rqs.pairs[ArmsRacePredatorPrey+"|Fox|Hare"]
+
+	// Prey defense answers predator aggression three samples later
+	for i := 0; i < 60; i++ {
+		pair.Samples = append(pair.Samples, RedQueenSample{
+			Tick:     i * redQueenInterval,
+			Attacker: map[string]float64{"aggression": math.Sin(float64(i) / 4), "speed": 0.5},
+			Defender: map[string]float64{"defense": math.Sin(float64(i-3) / 4), "speed": 0.2},
+		})
+	}
+
+	reports := rqs.Report()
+	if len(reports) != 1 || len(reports[0].Axes) != len(armsRaceAxes[ArmsRacePredatorPrey]) {
+		t.Fatalf("Expected one predator-prey report with every axis, got %+v", reports)
+	}
+	for _, axis := range reports[0].Axes {
+		switch axis.AttackerTrait {
+		case "aggression":
+			if axis.BestLag != 3*redQueenInterval || axis.Leader != "attacker" || axis.BestCorrelation < 0.99 {
+				t.Errorf("Expected defense to follow aggression by %d ticks, got lag %d (r=%.3f, leader %q)",
+					3*redQueenInterval, axis.BestLag, axis.BestCorrelation, axis.Leader)
+			}
+			if axis.Correlation >= axis.BestCorrelation || axis.AttackerAmplitude < 0.5 {
+				t.Errorf("Expected the lagged correlation to beat the unlagged one, got %+v", axis)
+			}
+		case "speed":
+			if axis.Correlation != 0 || axis.Leader != "" || axis.AttackerAmplitude != 0 {
+				t.Errorf("Expected traits that never moved to show no arms race, got %+v", axis)
+			}
+		}
+	}
+
+	// Swapping the series makes the defender the leader
+	for i := range pair.Samples {
+		pair.Samples[i].Attacker["aggression"], pair.Samples[i].Defender["defense"] = pair.Samples[i].Defender["defense"], pair.Samples[i].Attacker["aggression"]
+	}
+	if axis := rqs.Report()[0].Axes[0]; axis.BestLag != -3*redQueenInterval || axis.Leader != "defender" {
+		t.Errorf("Expected the defender to lead by %d ticks, got %+v", 3*redQueenInterval, axis)
+	}
+}
+
+func TestRedQueenFollowsInteractingSpecies(t *testing.T) {
+	world := newSteppingTestWorld(97)
+	predator, prey := world.AllEntities[0], (*Entity)(nil)
+	for _, entity := range world.AllEntities {
+		if entity.Species != predator.Species {
+			prey = entity
+			break
+		}
+	}
+	for _, name := range []string{"aggression", "strength", "size"} {
+		predator.Traits[name] = Trait{Name: name, Value: 3}
+		prey.Traits[name] = Trait{Name: name, Value: -3}
+	}
+	predator.Energy = 100
+	for i := 0; i < 500 && prey.IsAlive; i++ {
+		world.processEntityInteraction(predator, prey)
+	}
+	if prey.IsAlive {
+		t.Fatal("Expected the predator to make a kill")
+	}
+	prey.IsAlive = true // Keep the prey species alive to sample
+
+	// A parasite of the prey species on the predator counts too
+	world.SymbioticRelationships.Relationships = []*SymbioticRelationship{
+		{HostID: predator.ID, SymbiontID: prey.ID, Type: RelationshipParasitic, IsActive: true, Virulence: 0.6, Resistance: 0.2},
+	}
+
+	world.Tick = redQueenInterval
+	world.RedQueen.Update(world)
+	reports := world.RedQueen.Report()
+	if len(reports) != 2 {
+		t.Fatalf("Expected a predator-prey and a host-parasite pair, got %+v", reports)
+	}
+	for _, report := range reports {
+		if report.Samples != 1 {
+			t.Errorf("Expected one sample of %s, got %d", report.Kind, report.Samples)
+		}
+		axis := report.Axes[0]
+		switch report.Kind {
+		case ArmsRacePredatorPrey:
+			if report.Attacker != predator.Species || report.Defender != prey.Species {
+				t.Errorf("Expected %s hunting %s, got %+v", predator.Species, prey.Species, report)
+			}
+			if want := speciesGeneMeans(world, predator.Species)["aggression"]; axis.AttackerSeries[0] != want {
+				t.Errorf("Expected the predator's mean aggression %.3f, got %.3f", want, axis.AttackerSeries[0])
+			}
+		case ArmsRaceHostParasite:
+			if report.Attacker != prey.Species || axis.AttackerSeries[0] != 0.6 || axis.DefenderSeries[0] != 0.2 {
+				t.Errorf("Expected the parasite's virulence against the host's resistance, got %+v", report)
+			}
+		}
+	}
+
+	// Pairs that stop interacting are dropped
+	world.SymbioticRelationships.Relationships = nil
+	world.Tick += redQueenMemory + redQueenInterval
+	world.RedQueen.Update(world)
+	if reports := world.RedQueen.Report(); len(reports) != 0 {
+		t.Errorf("Expected quiet pairs to be dropped, got %+v", reports)
+	}
+}
+
+func TestRedQueenAPI(t *testing.T) {
+	world := newSteppingTestWorld(98)
+	world.RedQueen.RecordInteraction(ArmsRacePredatorPrey, "Fox", "Hare", 0)
+	wi := NewWebInterface(world)
+
+	rec := httptest.NewRecorder()
+	wi.handleRedQueen(rec, httptest.NewRequest(http.MethodGet, "/api/red-queen", nil))
+	var reports []RedQueenReport
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &reports) != nil || len(reports) != 1 {
+		t.Fatalf("Expected the Fox and Hare arms race, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleRedQueen(rec, httptest.NewRequest(http.MethodPost, "/api/red-queen", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected POST to be rejected, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Registry file kinds
+const (
+	RegistryKindCreature = "creature"
+	RegistryKindScenario = "scenario"
+)
+
+// registryMaxFileSize bounds downloads so a misbehaving registry cannot exhaust memory
+const registryMaxFileSize = 32 << 20
+
+// RegistryEntry describes a shared file published on a remote registry
+type RegistryEntry struct {
+	ID          string    `json:"id"`
+	Kind        string    `json:"kind"` // "creature" or "scenario"
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Author      string    `json:"author"`
+	SHA256      string    `json:"sha256"`     // Hex digest of the file content
+	Signature   string    `json:"signature"`  // Base64 ed25519 signature over the file content
+	PublicKey   string    `json:"public_key"` // Base64 ed25519 key of the signer
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// registryUpload is the body sent when publishing a file
+type registryUpload struct {
+	Kind        string          `json:"kind"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Author      string          `json:"author"`
+	SHA256      string          `json:"sha256"`
+	Signature   string          `json:"signature"`
+	PublicKey   string          `json:"public_key"`
+	Content     json.RawMessage `json:"content"`
+}
+
+// RegistryClient talks to a remote creature and scenario registry, verifying signatures and caching downloads locally
+type RegistryClient struct {
+	BaseURL     string
+	CacheDir    string
+	TrustedKeys []ed25519.PublicKey
+	SigningKey  ed25519.PrivateKey
+	HTTPClient  *http.Client
+}
+
+// NewRegistryClient creates a registry client; an empty cache directory uses the user cache directory
+func NewRegistryClient(baseURL, cacheDir string) *RegistryClient {
+	if cacheDir == "" {
+		if userCache, err := os.UserCacheDir(); err == nil {
+			cacheDir = filepath.Join(userCache, "evosim", "registry")
+		} else {
+			cacheDir = filepath.Join(os.TempDir(), "evosim-registry")
+		}
+	}
+	return &RegistryClient{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		CacheDir:   cacheDir,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// AddTrustedKey trusts a base64-encoded ed25519 public key for verifying downloads
+func (rc *RegistryClient) AddTrustedKey(encoded string) error {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("invalid registry key: %v", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid registry key: expected %d bytes, got %d", ed25519.PublicKeySize, len(key))
+	}
+	rc.TrustedKeys = append(rc.TrustedKeys, ed25519.PublicKey(key))
+	return nil
+}
+
+// SetSigningKey sets the base64-encoded ed25519 seed used to sign uploads
+func (rc *RegistryClient) SetSigningKey(encoded string) error {
+	seed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("invalid signing key: %v", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return fmt.Errorf("invalid signing key: expected %d byte seed, got %d", ed25519.SeedSize, len(seed))
+	}
+	rc.SigningKey = ed25519.NewKeyFromSeed(seed)
+	return nil
+}
+
+// VerifyRegistryContent checks a file's digest and that it was signed by one of the trusted keys
+func VerifyRegistryContent(entry *RegistryEntry, content []byte, trusted []ed25519.PublicKey) error {
+	digest := sha256.Sum256(content)
+	if !strings.EqualFold(hex.EncodeToString(digest[:]), entry.SHA256) {
+		return fmt.Errorf("checksum mismatch for %s", entry.ID)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(entry.Signature)
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return fmt.Errorf("missing or malformed signature for %s", entry.ID)
+	}
+	if len(trusted) == 0 {
+		return fmt.Errorf("no trusted registry keys configured")
+	}
+	for _, key := range trusted {
+		if ed25519.Verify(key, content, signature) {
+			return nil
+		}
+	}
+	return fmt.Errorf("signature for %s is not from a trusted key", entry.ID)
+}
+
+// get performs a GET request against the registry and returns the body
+func (rc *RegistryClient) get(path string) ([]byte, error) {
+	resp, err := rc.HTTPClient.Get(rc.BaseURL + path)
+	if err != nil {
+		return nil, fmt.Errorf("registry request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, registryMaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry response: %v", err)
+	}
+	if len(body) > registryMaxFileSize {
+		return nil, fmt.Errorf("registry response exceeds %d bytes", registryMaxFileSize)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry returned %s", resp.Status)
+	}
+	return body, nil
+}
+
+// List returns the files published on the registry, optionally filtered by kind
+func (rc *RegistryClient) List(kind string) ([]RegistryEntry, error) {
+	path := "/api/v1/files"
+	if kind != "" {
+		path += "?kind=" + url.QueryEscape(kind)
+	}
+	body, err := rc.get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []RegistryEntry
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, fmt.Errorf("invalid registry listing: %v", err)
+	}
+	return entries, nil
+}
+
+// Fetch downloads and verifies a file by ID, serving it from the local cache when possible
+func (rc *RegistryClient) Fetch(id string) (*RegistryEntry, []byte, error) {
+	body, err := rc.get("/api/v1/files/" + url.PathEscape(id))
+	if err != nil {
+		return nil, nil, err
+	}
+	var entry RegistryEntry
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return nil, nil, fmt.Errorf("invalid registry entry: %v", err)
+	}
+
+	if content, ok := rc.readCache(&entry); ok {
+		return &entry, content, nil
+	}
+
+	content, err := rc.get("/api/v1/files/" + url.PathEscape(id) + "/content")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := VerifyRegistryContent(&entry, content, rc.TrustedKeys); err != nil {
+		return nil, nil, err
+	}
+	rc.writeCache(&entry, content)
+	return &entry, content, nil
+}
+
+// cachePath returns where a verified file is cached, keyed by its digest
+func (rc *RegistryClient) cachePath(entry *RegistryEntry) (string, bool) {
+	// Only well-formed digests become file names, so a registry cannot write outside the cache
+	digest, err := hex.DecodeString(entry.SHA256)
+	if err != nil || len(digest) != sha256.Size {
+		return "", false
+	}
+	return filepath.Join(rc.CacheDir, hex.EncodeToString(digest)+".json"), true
+}
+
+// readCache returns cached content if present and still matching the entry's signature
+func (rc *RegistryClient) readCache(entry *RegistryEntry) ([]byte, bool) {
+	path, ok := rc.cachePath(entry)
+	if !ok {
+		return nil, false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	// Re-verify so a tampered cache or revoked key is never trusted
+	if VerifyRegistryContent(entry, content, rc.TrustedKeys) != nil {
+		return nil, false
+	}
+	return content, true
+}
+
+// writeCache stores verified content; caching failures are not fatal
+func (rc *RegistryClient) writeCache(entry *RegistryEntry, content []byte) {
+	path, ok := rc.cachePath(entry)
+	if !ok {
+		return
+	}
+	if err := os.MkdirAll(rc.CacheDir, 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, content, 0644)
+}
+
+// Upload signs and publishes a creature or scenario file
+func (rc *RegistryClient) Upload(kind, name, description, author string, content []byte) (*RegistryEntry, error) {
+	if kind != RegistryKindCreature && kind != RegistryKindScenario {
+		return nil, fmt.Errorf("unknown registry kind %q", kind)
+	}
+	if rc.SigningKey == nil {
+		return nil, fmt.Errorf("no signing key configured for uploads")
+	}
+	if !json.Valid(content) {
+		return nil, fmt.Errorf("upload content is not valid JSON")
+	}
+
+	digest := sha256.Sum256(content)
+	upload := registryUpload{
+		Kind:        kind,
+		Name:        name,
+		Description: description,
+		Author:      author,
+		SHA256:      hex.EncodeToString(digest[:]),
+		Signature:   base64.StdEncoding.EncodeToString(ed25519.Sign(rc.SigningKey, content)),
+		PublicKey:   base64.StdEncoding.EncodeToString(rc.SigningKey.Public().(ed25519.PublicKey)),
+		Content:     json.RawMessage(content),
+	}
+	body, err := json.Marshal(upload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode upload: %v", err)
+	}
+
+	resp, err := rc.HTTPClient.Post(rc.BaseURL+"/api/v1/files", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("registry upload failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("registry rejected upload: %s", resp.Status)
+	}
+
+	var entry RegistryEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entry); err != nil {
+		return nil, fmt.Errorf("invalid registry response: %v", err)
+	}
+	rc.writeCache(&entry, content)
+	return &entry, nil
+}
+
+// ImportRegistryFile downloads a registry file and applies it to the world:
+// creature files add creatures, scenario files replace the world state
+func (rc *RegistryClient) ImportRegistryFile(world *World, id string, pos Position) (*RegistryEntry, []*Entity, error) {
+	entry, content, err := rc.Fetch(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	entities, err := ApplyRegistryFile(world, entry, content, pos)
+	return entry, entities, err
+}
+
+// ApplyRegistryFile adds a downloaded creature file to the world near pos, or replaces the
+// world with a downloaded scenario
+func ApplyRegistryFile(world *World, entry *RegistryEntry, content []byte, pos Position) ([]*Entity, error) {
+	switch entry.Kind {
+	case RegistryKindCreature:
+		var file CreatureFile
+		if err := json.Unmarshal(content, &file); err != nil {
+			return nil, fmt.Errorf("invalid creature file: %v", err)
+		}
+		return world.SpawnCreatures(&file, pos)
+
+	case RegistryKindScenario:
+		var data map[string]interface{}
+		if err := json.Unmarshal(content, &data); err != nil {
+			return nil, fmt.Errorf("invalid scenario file: %v", err)
+		}
+		return nil, NewStateManager(world).LoadFromData(data)
+
+	default:
+		return nil, fmt.Errorf("unknown registry kind %q", entry.Kind)
+	}
+}
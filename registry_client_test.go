@@ -0,0 +1,162 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeRegistry is an in-memory registry server for tests
+type fakeRegistry struct {
+	mu             sync.Mutex
+	entries        map[string]*RegistryEntry
+	content        map[string][]byte
+	contentFetches int
+}
+
+func newFakeRegistry() (*fakeRegistry, *httptest.Server) {
+	registry := &fakeRegistry{entries: make(map[string]*RegistryEntry), content: make(map[string][]byte)}
+	return registry, httptest.NewServer(registry)
+}
+
+func (fr *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/files")
+	switch {
+	case path == "" && r.Method == http.MethodPost:
+		var upload registryUpload
+		if err := json.NewDecoder(r.Body).Decode(&upload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		id := upload.SHA256[:12]
+		fr.entries[id] = &RegistryEntry{ID: id, Kind: upload.Kind, Name: upload.Name, Author: upload.Author,
+			SHA256: upload.SHA256, Signature: upload.Signature, PublicKey: upload.PublicKey}
+		fr.content[id] = upload.Content
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(fr.entries[id])
+	case path == "":
+		entries := make([]*RegistryEntry, 0)
+		for _, entry := range fr.entries {
+			if kind := r.URL.Query().Get("kind"); kind == "" || entry.Kind == kind {
+				entries = append(entries, entry)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(entries)
+	case strings.HasSuffix(path, "/content"):
+		fr.contentFetches++
+		_, _ = w.Write(fr.content[strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/content")])
+	default:
+		entry, exists := fr.entries[strings.TrimPrefix(path, "/")]
+		if !exists {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(entry)
+	}
+}
+
+// publish adds a file signed with the given key
+func (fr *fakeRegistry) publish(id, kind string, content []byte, key ed25519.PrivateKey) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	digest := sha256.Sum256(content)
+	fr.entries[id] = &RegistryEntry{ID: id, Kind: kind, Name: id, SHA256: hex.EncodeToString(digest[:]),
+		Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(key, content))}
+	fr.content[id] = content
+}
+
+func newTestRegistryClient(t *testing.T, url string) (*RegistryClient, ed25519.PrivateKey) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	client := NewRegistryClient(url, t.TempDir())
+	if err := client.AddTrustedKey(base64.StdEncoding.EncodeToString(public)); err != nil {
+		t.Fatalf("Failed to trust key: %v", err)
+	}
+	if err := client.SetSigningKey(base64.StdEncoding.EncodeToString(private.Seed())); err != nil {
+		t.Fatalf("Failed to set signing key: %v", err)
+	}
+	return client, private
+}
+
+func TestRegistryFetchVerifiesAndCaches(t *testing.T) {
+	registry, server := newFakeRegistry()
+	defer server.Close()
+	client, key := newTestRegistryClient(t, server.URL)
+
+	registry.publish("grazer", RegistryKindCreature, []byte(`{"format":"evosim-creature"}`), key)
+	for i := 0; i < 2; i++ {
+		if _, content, err := client.Fetch("grazer"); err != nil || len(content) == 0 {
+			t.Fatalf("Fetch %d failed: %v", i, err)
+		}
+	}
+	if registry.contentFetches != 1 {
+		t.Errorf("Expected second fetch to be served from cache, content downloaded %d times", registry.contentFetches)
+	}
+}
+
+func TestRegistryRejectsUntrustedOrTamperedFiles(t *testing.T) {
+	registry, server := newFakeRegistry()
+	defer server.Close()
+	client, key := newTestRegistryClient(t, server.URL)
+
+	_, stranger, _ := ed25519.GenerateKey(rand.Reader)
+	registry.publish("untrusted", RegistryKindCreature, []byte(`{}`), stranger)
+	if _, _, err := client.Fetch("untrusted"); err == nil {
+		t.Error("Expected file signed by an untrusted key to be rejected")
+	}
+
+	registry.publish("tampered", RegistryKindCreature, []byte(`{"a":1}`), key)
+	registry.content["tampered"] = []byte(`{"a":2}`)
+	if _, _, err := client.Fetch("tampered"); err == nil {
+		t.Error("Expected tampered content to be rejected")
+	}
+}
+
+func TestRegistryUploadAndImportCreature(t *testing.T) {
+	_, server := newFakeRegistry()
+	defer server.Close()
+	client, _ := newTestRegistryClient(t, server.URL)
+
+	source := newTestWorld(50, 10)
+	entity := addCreatureTestEntity(source, "herbivore")
+	file, err := ExportCreatureFile(source, []int{entity.ID}, "Grazer", "")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	content, _ := json.Marshal(file)
+
+	entry, err := client.Upload(RegistryKindCreature, "Grazer", "", "tester", content)
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	if entry.Signature == "" {
+		t.Error("Expected uploaded entry to carry a signature")
+	}
+
+	entries, err := client.List(RegistryKindCreature)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected 1 listed creature, got %d (%v)", len(entries), err)
+	}
+
+	target := newTestWorld(50, 10)
+	_, imported, err := client.ImportRegistryFile(target, entry.ID, Position{X: 20, Y: 20})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(imported) != 1 || imported[0].Species != "herbivore" {
+		t.Errorf("Expected one imported herbivore, got %d", len(imported))
+	}
+}
@@ -0,0 +1,473 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+)
+
+// replayFormatVersion is the version of the replay file format written by ReplayRecorder
+const replayFormatVersion = 1
+
+// defaultReplayKeyframeInterval is the ticks between full snapshots in a replay
+const defaultReplayKeyframeInterval = 100
+
+// replayFrameEvents is the number of recent events a replay frame carries
+const replayFrameEvents = 10
+
+// ReplayHeader describes the recorded run; it is the first line of a replay file
+type ReplayHeader struct {
+	Version          int           `json:"version"`
+	Seed             int64         `json:"seed"`
+	Width            float64       `json:"width"`
+	Height           float64       `json:"height"`
+	GridWidth        int           `json:"grid_width"`
+	GridHeight       int           `json:"grid_height"`
+	StartTick        int           `json:"start_tick"`
+	KeyframeInterval int           `json:"keyframe_interval"`
+	Biomes           []ReplayBiome `json:"biomes"` // Legend for the keyframes' biome rows, by biome number
+}
+
+// ReplayBiome is how playback draws a biome
+type ReplayBiome struct {
+	Name   string `json:"name"`
+	Color  string `json:"color"`
+	Symbol string `json:"symbol"`
+}
+
+// ReplayEntity is a creature in a replay. Moves only carry the ID and position.
+type ReplayEntity struct {
+	ID      int     `json:"id"`
+	Species string  `json:"s,omitempty"`
+	Kind    string  `json:"k,omitempty"` // Base species: herbivore, predator, omnivore...
+	X       float64 `json:"x"`
+	Y       float64 `json:"y"`
+}
+
+// BaseSpecies returns the base species of a replayed creature
+func (e ReplayEntity) BaseSpecies() string {
+	if e.Kind != "" {
+		return e.Kind
+	}
+	return e.Species
+}
+
+// ReplayEvent is an event log entry of a recorded tick
+type ReplayEvent struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// ReplayKeyframe is the full recorded state at a tick, from which later ticks are rebuilt.
+// Biomes are only recorded in keyframes, so frames show them as of the keyframe before.
+type ReplayKeyframe struct {
+	Entities []ReplayEntity `json:"entities"`
+	Biomes   []string       `json:"biomes"` // A row per grid row, a letter per cell ('a' + biome number)
+}
+
+// ReplayTick is a line of a replay file: what changed during a tick, or a keyframe
+type ReplayTick struct {
+	Tick     int             `json:"t"`
+	Births   []ReplayEntity  `json:"b,omitempty"`
+	Deaths   []int           `json:"d,omitempty"`
+	Moves    []ReplayEntity  `json:"m,omitempty"`
+	Events   []ReplayEvent   `json:"e,omitempty"`
+	Plants   int             `json:"p"`
+	Keyframe *ReplayKeyframe `json:"k,omitempty"` // Replaces the births, deaths and moves
+}
+
+// ReplayRecorder writes a world's history to a replay file: a gzipped header line followed by
+// a JSON line per tick. Positions are rounded to a tenth of a unit and only moves are written,
+// with a keyframe of the full state every keyframe interval. The file is flushed at every
+// keyframe, so a run that is killed still leaves a replay up to the last one.
+type ReplayRecorder struct {
+	closer           io.Closer
+	buffer           *bufio.Writer
+	zipper           *gzip.Writer
+	encoder          *json.Encoder
+	keyframeInterval int
+	entities         map[int]ReplayEntity // As last recorded
+	lastTick         int
+	err              error
+}
+
+// CreateReplayRecorder starts recording the world to a replay file
+func CreateReplayRecorder(filename string, world *World, keyframeInterval int) (*ReplayRecorder, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the replay file: %v", err)
+	}
+	recorder, err := NewReplayRecorder(file, world, keyframeInterval)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	recorder.closer = file
+	return recorder, nil
+}
+
+// NewReplayRecorder starts recording the world to out, writing the header and a keyframe of
+// the current state
+func NewReplayRecorder(out io.Writer, world *World, keyframeInterval int) (*ReplayRecorder, error) {
+	if keyframeInterval < 1 {
+		return nil, fmt.Errorf("keyframe interval must be at least 1 tick")
+	}
+	recorder := &ReplayRecorder{
+		buffer:           bufio.NewWriter(out),
+		keyframeInterval: keyframeInterval,
+		entities:         make(map[int]ReplayEntity),
+		lastTick:         world.Tick,
+	}
+	recorder.zipper = gzip.NewWriter(recorder.buffer)
+	recorder.encoder = json.NewEncoder(recorder.zipper)
+
+	header := ReplayHeader{
+		Version:          replayFormatVersion,
+		Seed:             world.Seed,
+		Width:            world.Config.Width,
+		Height:           world.Config.Height,
+		GridWidth:        world.Config.GridWidth,
+		GridHeight:       world.Config.GridHeight,
+		StartTick:        world.Tick,
+		KeyframeInterval: keyframeInterval,
+		Biomes:           replayBiomeLegend(world.Biomes),
+	}
+	if err := recorder.encoder.Encode(header); err != nil {
+		return nil, fmt.Errorf("failed to write the replay header: %v", err)
+	}
+	recorder.write(recorder.keyframe(world))
+	return recorder, recorder.err
+}
+
+// replayBiomeLegend lists the biomes by number
+func replayBiomeLegend(biomes map[BiomeType]Biome) []ReplayBiome {
+	count := 0
+	for biomeType := range biomes {
+		count = max(count, int(biomeType)+1)
+	}
+	legend := make([]ReplayBiome, count)
+	for biomeType, biome := range biomes {
+		legend[biomeType] = ReplayBiome{Name: biome.Name, Color: biome.Color, Symbol: string(biome.Symbol)}
+	}
+	return legend
+}
+
+// Record writes what changed during the tick the world has just finished. It does nothing
+// if the world has not moved on since the last tick recorded; a world that went back, e.g.
+// by loading a save, stops the recording.
+func (r *ReplayRecorder) Record(world *World) {
+	if r.err != nil || world.Tick == r.lastTick {
+		return
+	}
+	if world.Tick < r.lastTick {
+		r.err = fmt.Errorf("world went back from tick %d to %d; recording stopped", r.lastTick, world.Tick)
+		return
+	}
+
+	var line ReplayTick
+	if world.Tick%r.keyframeInterval == 0 {
+		line = r.keyframe(world)
+	} else {
+		line = r.delta(world)
+	}
+	line.Events = r.events(world)
+	r.write(line)
+}
+
+// keyframe records the world's full state
+func (r *ReplayRecorder) keyframe(world *World) ReplayTick {
+	r.entities = make(map[int]ReplayEntity)
+	keyframe := &ReplayKeyframe{Entities: make([]ReplayEntity, 0, len(world.AllEntities))}
+	for _, entity := range world.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		recorded := replayEntity(world, entity)
+		r.entities[entity.ID] = recorded
+		keyframe.Entities = append(keyframe.Entities, recorded)
+	}
+
+	keyframe.Biomes = make([]string, len(world.Grid))
+	for y, row := range world.Grid {
+		letters := make([]byte, len(row))
+		for x, cell := range row {
+			letters[x] = byte('a' + int(cell.Biome))
+		}
+		keyframe.Biomes[y] = string(letters)
+	}
+	return ReplayTick{Tick: world.Tick, Plants: len(world.AllPlants), Keyframe: keyframe}
+}
+
+// delta records the creatures born, died and moved since the last tick recorded
+func (r *ReplayRecorder) delta(world *World) ReplayTick {
+	line := ReplayTick{Tick: world.Tick, Plants: len(world.AllPlants)}
+	alive := make(map[int]bool, len(world.AllEntities))
+	for _, entity := range world.AllEntities {
+		if !entity.IsAlive {
+			continue
+		}
+		alive[entity.ID] = true
+		recorded := replayEntity(world, entity)
+		last, known := r.entities[entity.ID]
+		switch {
+		case !known || last.Species != recorded.Species || last.Kind != recorded.Kind:
+			line.Births = append(line.Births, recorded) // A creature that speciated is born again
+		case last.X != recorded.X || last.Y != recorded.Y:
+			line.Moves = append(line.Moves, ReplayEntity{ID: entity.ID, X: recorded.X, Y: recorded.Y})
+		default:
+			continue
+		}
+		r.entities[entity.ID] = recorded
+	}
+	for id := range r.entities {
+		if !alive[id] {
+			line.Deaths = append(line.Deaths, id)
+			delete(r.entities, id)
+		}
+	}
+	sort.Ints(line.Deaths)
+	return line
+}
+
+// events collects the events logged since the last tick recorded
+func (r *ReplayRecorder) events(world *World) []ReplayEvent {
+	if world.EventLogger == nil {
+		return nil
+	}
+	logged := world.EventLogger.Events
+	first := len(logged)
+	for first > 0 && logged[first-1].Tick > r.lastTick {
+		first--
+	}
+	var events []ReplayEvent
+	for _, event := range logged[first:] {
+		events = append(events, ReplayEvent{Type: event.Type, Description: event.Description})
+	}
+	return events
+}
+
+// replayEntity describes a creature with its position rounded to a tenth of a unit
+func replayEntity(world *World, entity *Entity) ReplayEntity {
+	kind := entity.Species
+	if world.SpeciesNaming != nil {
+		if info := world.SpeciesNaming.GetSpeciesInfo(entity.Species); info != nil {
+			kind = info.Species
+		}
+	}
+	recorded := ReplayEntity{
+		ID:      entity.ID,
+		Species: entity.Species,
+		X:       math.Round(entity.Position.X*10) / 10,
+		Y:       math.Round(entity.Position.Y*10) / 10,
+	}
+	if kind != entity.Species {
+		recorded.Kind = kind
+	}
+	return recorded
+}
+
+// write appends a line, flushing the file at keyframes
+func (r *ReplayRecorder) write(line ReplayTick) {
+	if r.err != nil {
+		return
+	}
+	if err := r.encoder.Encode(line); err != nil {
+		r.err = fmt.Errorf("failed to write tick %d to the replay: %v", line.Tick, err)
+		return
+	}
+	r.lastTick = line.Tick
+	if line.Keyframe != nil {
+		r.err = r.flush()
+	}
+}
+
+// flush pushes everything recorded so far to the file
+func (r *ReplayRecorder) flush() error {
+	if err := r.zipper.Flush(); err != nil {
+		return fmt.Errorf("failed to flush the replay: %v", err)
+	}
+	if err := r.buffer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush the replay: %v", err)
+	}
+	return nil
+}
+
+// Err reports why recording stopped, if it did
+func (r *ReplayRecorder) Err() error {
+	return r.err
+}
+
+// Close finishes the replay file, reporting any error that stopped the recording
+func (r *ReplayRecorder) Close() error {
+	err := r.err
+	if closeErr := r.zipper.Close(); closeErr != nil && err == nil {
+		err = fmt.Errorf("failed to finish the replay: %v", closeErr)
+	}
+	if flushErr := r.buffer.Flush(); flushErr != nil && err == nil {
+		err = fmt.Errorf("failed to finish the replay: %v", flushErr)
+	}
+	if r.closer != nil {
+		if closeErr := r.closer.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close the replay file: %v", closeErr)
+		}
+	}
+	return err
+}
+
+// Replay is a recorded run loaded for playback
+type Replay struct {
+	Header    ReplayHeader
+	Ticks     []ReplayTick
+	Truncated bool  // The file ends mid-write, e.g. because the recording run was killed
+	keyframes []int // Indexes of the keyframe ticks
+}
+
+// ReplayFrame is the recorded state at a tick, rebuilt from the keyframe before it
+type ReplayFrame struct {
+	Tick        int            `json:"tick"`
+	Entities    []ReplayEntity `json:"entities"` // By ID
+	Biomes      []string       `json:"biomes"`
+	Plants      int            `json:"plants"`
+	Populations map[string]int `json:"populations"`   // Living creatures per species
+	Events      []ReplayEvent  `json:"recent_events"` // The latest events up to the tick
+}
+
+// ReplayInfo summarises a replay for playback controls
+type ReplayInfo struct {
+	Header    ReplayHeader `json:"header"`
+	FirstTick int          `json:"first_tick"`
+	LastTick  int          `json:"last_tick"`
+	Truncated bool         `json:"truncated"`
+}
+
+// LoadReplay reads a replay file
+func LoadReplay(filename string) (*Replay, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the replay: %v", err)
+	}
+	defer file.Close()
+	return ReadReplay(file)
+}
+
+// ReadReplay reads a replay, keeping the ticks before a truncated end
+func ReadReplay(in io.Reader) (*Replay, error) {
+	zipped, err := gzip.NewReader(in)
+	if err != nil {
+		return nil, fmt.Errorf("not a replay file: %v", err)
+	}
+	decoder := json.NewDecoder(zipped)
+
+	replay := &Replay{}
+	if err := decoder.Decode(&replay.Header); err != nil {
+		return nil, fmt.Errorf("failed to read the replay header: %v", err)
+	}
+	if replay.Header.Version != replayFormatVersion {
+		return nil, fmt.Errorf("unsupported replay version %d (expected %d)", replay.Header.Version, replayFormatVersion)
+	}
+
+	for {
+		var line ReplayTick
+		err := decoder.Decode(&line)
+		if err == io.EOF {
+			break
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			replay.Truncated = true
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the replay after %d ticks: %v", len(replay.Ticks), err)
+		}
+		if len(replay.Ticks) > 0 && line.Tick <= replay.Ticks[len(replay.Ticks)-1].Tick {
+			return nil, fmt.Errorf("replay tick %d is out of order", line.Tick)
+		}
+		if line.Keyframe != nil {
+			replay.keyframes = append(replay.keyframes, len(replay.Ticks))
+		} else if len(replay.keyframes) == 0 {
+			return nil, fmt.Errorf("replay does not start with a keyframe")
+		}
+		replay.Ticks = append(replay.Ticks, line)
+	}
+	if len(replay.Ticks) == 0 {
+		return nil, fmt.Errorf("replay holds no ticks")
+	}
+	return replay, nil
+}
+
+// FirstTick returns the first tick recorded
+func (r *Replay) FirstTick() int {
+	return r.Ticks[0].Tick
+}
+
+// LastTick returns the last tick recorded
+func (r *Replay) LastTick() int {
+	return r.Ticks[len(r.Ticks)-1].Tick
+}
+
+// Info summarises the replay
+func (r *Replay) Info() ReplayInfo {
+	return ReplayInfo{Header: r.Header, FirstTick: r.FirstTick(), LastTick: r.LastTick(), Truncated: r.Truncated}
+}
+
+// Frame rebuilds the state at a tick by applying the ticks since the keyframe before it.
+// Ticks between the first and last recorded that were not recorded show the tick before.
+func (r *Replay) Frame(tick int) (*ReplayFrame, error) {
+	if tick < r.FirstTick() || tick > r.LastTick() {
+		return nil, fmt.Errorf("tick %d is outside the replay (%d-%d)", tick, r.FirstTick(), r.LastTick())
+	}
+	index := sort.Search(len(r.Ticks), func(i int) bool { return r.Ticks[i].Tick > tick }) - 1
+	start := r.keyframes[sort.Search(len(r.keyframes), func(i int) bool { return r.keyframes[i] > index })-1]
+
+	keyframe := r.Ticks[start].Keyframe
+	entities := make(map[int]ReplayEntity, len(keyframe.Entities))
+	for _, entity := range keyframe.Entities {
+		entities[entity.ID] = entity
+	}
+	for _, line := range r.Ticks[start+1 : index+1] {
+		for _, entity := range line.Births {
+			entities[entity.ID] = entity
+		}
+		for _, id := range line.Deaths {
+			delete(entities, id)
+		}
+		for _, move := range line.Moves {
+			if entity, exists := entities[move.ID]; exists {
+				entity.X, entity.Y = move.X, move.Y
+				entities[move.ID] = entity
+			}
+		}
+	}
+
+	frame := &ReplayFrame{
+		Tick:        tick,
+		Entities:    make([]ReplayEntity, 0, len(entities)),
+		Biomes:      keyframe.Biomes,
+		Plants:      r.Ticks[index].Plants,
+		Populations: make(map[string]int),
+		Events:      []ReplayEvent{},
+	}
+	for _, entity := range entities {
+		frame.Entities = append(frame.Entities, entity)
+		frame.Populations[entity.Species]++
+	}
+	sort.Slice(frame.Entities, func(i, j int) bool { return frame.Entities[i].ID < frame.Entities[j].ID })
+
+	// The latest events, oldest first
+	for i := index; i >= 0 && len(frame.Events) < replayFrameEvents; i-- {
+		events := r.Ticks[i].Events
+		for j := len(events) - 1; j >= 0 && len(frame.Events) < replayFrameEvents; j-- {
+			frame.Events = append(frame.Events, events[j])
+		}
+	}
+	for i, j := 0, len(frame.Events)-1; i < j; i, j = i+1, j-1 {
+		frame.Events[i], frame.Events[j] = frame.Events[j], frame.Events[i]
+	}
+	return frame, nil
+}
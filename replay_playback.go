@@ -0,0 +1,326 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Playback steps every replayStepInterval, advancing the replay by the playback speed in ticks
+const (
+	replayStepInterval = 100 * time.Millisecond
+	replayJumpTicks    = 100
+	replayMaxSpeed     = 64
+)
+
+// replayStepMsg advances playback
+type replayStepMsg time.Time
+
+// replayKeys are the playback controls
+var replayKeys = struct {
+	play      key.Binding
+	forward   key.Binding
+	back      key.Binding
+	jumpAhead key.Binding
+	jumpBack  key.Binding
+	start     key.Binding
+	end       key.Binding
+	faster    key.Binding
+	slower    key.Binding
+	panUp     key.Binding
+	panDown   key.Binding
+	panLeft   key.Binding
+	panRight  key.Binding
+	quit      key.Binding
+}{
+	play:      key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "play/pause")),
+	forward:   key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→", "step")),
+	back:      key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←", "step back")),
+	jumpAhead: key.NewBinding(key.WithKeys("shift+right", "pgdown", "]"), key.WithHelp("]", "+100 ticks")),
+	jumpBack:  key.NewBinding(key.WithKeys("shift+left", "pgup", "["), key.WithHelp("[", "-100 ticks")),
+	start:     key.NewBinding(key.WithKeys("home", "g"), key.WithHelp("home", "start")),
+	end:       key.NewBinding(key.WithKeys("end", "G"), key.WithHelp("end", "end")),
+	faster:    key.NewBinding(key.WithKeys("+", "="), key.WithHelp("+", "faster")),
+	slower:    key.NewBinding(key.WithKeys("-", "_"), key.WithHelp("-", "slower")),
+	panUp:     key.NewBinding(key.WithKeys("w", "up")),
+	panDown:   key.NewBinding(key.WithKeys("s", "down")),
+	panLeft:   key.NewBinding(key.WithKeys("a")),
+	panRight:  key.NewBinding(key.WithKeys("d")),
+	quit:      key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+}
+
+// ReplayModel plays a replay back in the terminal
+type ReplayModel struct {
+	replay    *Replay
+	frame     *ReplayFrame
+	playing   bool
+	speed     int // Ticks per playback step
+	viewportX int
+	viewportY int
+	err       error
+}
+
+// NewReplayModel creates a player paused at the start of the replay
+func NewReplayModel(replay *Replay) ReplayModel {
+	model := ReplayModel{replay: replay, speed: 1}
+	model.seek(replay.FirstTick())
+	return model
+}
+
+// seek shows the frame at a tick, clamped to the replay
+func (m *ReplayModel) seek(tick int) {
+	tick = max(m.replay.FirstTick(), min(tick, m.replay.LastTick()))
+	m.frame, m.err = m.replay.Frame(tick)
+	if tick == m.replay.LastTick() {
+		m.playing = false
+	}
+}
+
+// replayStep schedules the next playback step
+func replayStep() tea.Cmd {
+	return tea.Tick(replayStepInterval, func(t time.Time) tea.Msg {
+		return replayStepMsg(t)
+	})
+}
+
+// Init starts the playback clock
+func (m ReplayModel) Init() tea.Cmd {
+	return replayStep()
+}
+
+// Update handles the playback controls
+func (m ReplayModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case replayStepMsg:
+		if m.playing {
+			m.seek(m.frame.Tick + m.speed)
+		}
+		return m, replayStep()
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, replayKeys.quit):
+			return m, tea.Quit
+		case key.Matches(msg, replayKeys.play):
+			m.playing = !m.playing
+			if m.playing && m.frame.Tick == m.replay.LastTick() {
+				m.seek(m.replay.FirstTick())
+				m.playing = true
+			}
+		case key.Matches(msg, replayKeys.forward):
+			m.seek(m.frame.Tick + 1)
+		case key.Matches(msg, replayKeys.back):
+			m.seek(m.frame.Tick - 1)
+		case key.Matches(msg, replayKeys.jumpAhead):
+			m.seek(m.frame.Tick + replayJumpTicks)
+		case key.Matches(msg, replayKeys.jumpBack):
+			m.seek(m.frame.Tick - replayJumpTicks)
+		case key.Matches(msg, replayKeys.start):
+			m.seek(m.replay.FirstTick())
+		case key.Matches(msg, replayKeys.end):
+			m.seek(m.replay.LastTick())
+		case key.Matches(msg, replayKeys.faster):
+			m.speed = min(m.speed*2, replayMaxSpeed)
+		case key.Matches(msg, replayKeys.slower):
+			m.speed = max(m.speed/2, 1)
+		case key.Matches(msg, replayKeys.panUp):
+			m.viewportY = max(0, m.viewportY-5)
+		case key.Matches(msg, replayKeys.panDown):
+			m.viewportY = max(0, min(m.viewportY+5, m.replay.Header.GridHeight-25))
+		case key.Matches(msg, replayKeys.panLeft):
+			m.viewportX = max(0, m.viewportX-5)
+		case key.Matches(msg, replayKeys.panRight):
+			m.viewportX = max(0, min(m.viewportX+5, m.replay.Header.GridWidth-60))
+		}
+	}
+	return m, nil
+}
+
+// View renders the frame with a scrubbing bar
+func (m ReplayModel) View() string {
+	status := "⏸ PAUSED"
+	if m.playing {
+		status = "▶ PLAYING"
+	}
+	header := titleStyle.Render(fmt.Sprintf("EvoSim Replay - Tick %d/%d  %s  %dx", m.frame.Tick, m.replay.LastTick(), status, m.speed))
+	if m.replay.Truncated {
+		header += " " + infoStyle.Render("recording ended early")
+	}
+
+	content := lipgloss.JoinHorizontal(lipgloss.Top, gridStyle.Render(m.gridView()), "  ", m.sideView())
+	parts := []string{header, m.scrubBar(60), content}
+	if m.err != nil {
+		parts = append(parts, eventStyle.Render(m.err.Error()))
+	}
+	controls := []string{"space: play/pause", "←/→: step", "[/]: ±100 ticks", "home/end: start/end", "+/-: speed", "wasd: pan", "q: quit"}
+	parts = append(parts, infoStyle.Render(strings.Join(controls, " | ")))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+// scrubBar shows where the frame lies in the replay
+func (m ReplayModel) scrubBar(width int) string {
+	position := 0
+	if span := m.replay.LastTick() - m.replay.FirstTick(); span > 0 {
+		position = (m.frame.Tick - m.replay.FirstTick()) * (width - 1) / span
+	}
+	return fmt.Sprintf("%d %s●%s %d", m.replay.FirstTick(), strings.Repeat("━", position), strings.Repeat("─", width-1-position), m.replay.LastTick())
+}
+
+// gridView draws the biomes with the creatures over them, like the simulation's grid view
+func (m ReplayModel) gridView() string {
+	header := m.replay.Header
+	if header.GridWidth == 0 || header.GridHeight == 0 || header.Width == 0 || header.Height == 0 {
+		return "Grid not recorded"
+	}
+
+	// Creatures per cell and the base species seen first in each
+	counts := make(map[GridPoint]int)
+	kinds := make(map[GridPoint]string)
+	for _, entity := range m.frame.Entities {
+		cell := GridPoint{
+			X: int(entity.X / header.Width * float64(header.GridWidth)),
+			Y: int(entity.Y / header.Height * float64(header.GridHeight)),
+		}
+		counts[cell]++
+		if _, seen := kinds[cell]; !seen {
+			kinds[cell] = entity.BaseSpecies()
+		}
+	}
+
+	var grid strings.Builder
+	displayWidth := min(header.GridWidth-m.viewportX, 60)
+	displayHeight := min(header.GridHeight-m.viewportY, 25)
+	for y := m.viewportY; y < m.viewportY+displayHeight; y++ {
+		for x := m.viewportX; x < m.viewportX+displayWidth; x++ {
+			symbol, style := " ", lipgloss.NewStyle()
+			if y < len(m.frame.Biomes) && x < len(m.frame.Biomes[y]) {
+				biome := BiomeType(m.frame.Biomes[y][x] - 'a')
+				if int(biome) < len(header.Biomes) {
+					symbol = header.Biomes[biome].Symbol
+				}
+				style = biomeColors[biome]
+			}
+
+			cell := GridPoint{X: x, Y: y}
+			count := counts[cell]
+			if sym, exists := baseSpeciesSymbols[kinds[cell]]; exists && count == 1 {
+				symbol = string(sym)
+			} else if count > 1 && count < 10 {
+				symbol = strconv.Itoa(count)
+			} else if count >= 10 {
+				symbol = "+"
+			}
+			if speciesStyle, exists := speciesStyles[kinds[cell]]; exists && count > 0 {
+				style = speciesStyle
+			}
+			grid.WriteString(style.Render(symbol))
+		}
+		if y < m.viewportY+displayHeight-1 {
+			grid.WriteString("\n")
+		}
+	}
+	return grid.String()
+}
+
+// sideView lists the populations and recent events of the frame
+func (m ReplayModel) sideView() string {
+	var side strings.Builder
+	side.WriteString(fmt.Sprintf("Creatures: %d\nPlants: %d\n\nPopulations:\n", len(m.frame.Entities), m.frame.Plants))
+	for _, species := range sortedKeys(m.frame.Populations) {
+		side.WriteString(fmt.Sprintf("  %-20s %d\n", species, m.frame.Populations[species]))
+	}
+	side.WriteString("\nRecent events:\n")
+	for _, event := range m.frame.Events {
+		side.WriteString(fmt.Sprintf("  %s\n", event.Description))
+	}
+	return side.String()
+}
+
+// RunReplayCLI plays a replay back in the terminal
+func RunReplayCLI(replay *Replay) error {
+	p := tea.NewProgram(NewReplayModel(replay), tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
+
+// ReplayServer plays a replay back in the browser; the page fetches frames as the viewer
+// plays or scrubs
+type ReplayServer struct {
+	replay *Replay
+}
+
+// NewReplayServer creates a server for a replay
+func NewReplayServer(replay *Replay) *ReplayServer {
+	return &ReplayServer{replay: replay}
+}
+
+// Handler routes the replay page, its API and the static files
+func (rs *ReplayServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", rs.servePage)
+	mux.HandleFunc("/api/replay", rs.handleInfo)
+	mux.HandleFunc("/api/replay/frame", rs.handleFrame)
+	mux.HandleFunc("/static/", func(w http.ResponseWriter, r *http.Request) {
+		webAssets.ServeStatic(w, r)
+	})
+	return mux
+}
+
+// servePage serves the playback page
+func (rs *ReplayServer) servePage(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	webAssets.ServePage(w, "replay")
+}
+
+// handleInfo describes the replay
+func (rs *ReplayServer) handleInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rs.replay.Info())
+}
+
+// handleFrame returns the frame at ?tick=N, or at the first tick
+func (rs *ReplayServer) handleFrame(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	tick := rs.replay.FirstTick()
+	if value := r.URL.Query().Get("tick"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid tick", http.StatusBadRequest)
+			return
+		}
+		tick = parsed
+	}
+	frame, err := rs.replay.Frame(tick)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(frame)
+}
+
+// RunReplayServer serves the replay's playback page until the server fails
+func RunReplayServer(replay *Replay, port int) error {
+	address := fmt.Sprintf(":%d", port)
+	log.Printf("Playing ticks %d-%d on http://localhost%s", replay.FirstTick(), replay.LastTick(), address)
+	log.Printf("Press Ctrl+C to stop the server")
+	return http.ListenAndServe(address, NewReplayServer(replay).Handler())
+}
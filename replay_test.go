@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recordTestReplay records a small seeded run, keeping what the world looked like at each tick
+func recordTestReplay(t *testing.T, ticks int) (*bytes.Buffer, map[int]map[int]ReplayEntity) {
+	t.Helper()
+	world := NewWorld(WorldConfig{Width: 60, Height: 60, GridWidth: 20, GridHeight: 20, PopulationSize: 8})
+	for _, population := range startingPopulations(false) {
+		world.AddPopulation(population)
+	}
+	var file bytes.Buffer
+	recorder, err := NewReplayRecorder(&file, world, 10)
+	if err != nil {
+		t.Fatalf("Failed to start recording: %v", err)
+	}
+	world.Recorder = recorder
+
+	states := make(map[int]map[int]ReplayEntity)
+	for i := 0; i < ticks; i++ {
+		world.Update()
+		state := make(map[int]ReplayEntity)
+		for _, entity := range world.AllEntities {
+			if entity.IsAlive {
+				state[entity.ID] = replayEntity(world, entity)
+			}
+		}
+		states[world.Tick] = state
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Failed to finish recording: %v", err)
+	}
+	return &file, states
+}
+
+func TestReplayRebuildsEveryTick(t *testing.T) {
+	file, states := recordTestReplay(t, 35)
+	replay, err := ReadReplay(bytes.NewReader(file.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed to read the replay: %v", err)
+	}
+	if replay.FirstTick() != 0 || replay.LastTick() != 35 || replay.Truncated || len(replay.keyframes) != 4 {
+		t.Fatalf("Expected ticks 0-35 with keyframes at 0, 10, 20 and 30, got %d-%d with %d", replay.FirstTick(), replay.LastTick(), len(replay.keyframes))
+	}
+
+	for _, tick := range []int{1, 9, 10, 17, 35} {
+		frame, err := replay.Frame(tick)
+		if err != nil {
+			t.Fatalf("Failed to seek to tick %d: %v", tick, err)
+		}
+		if len(frame.Entities) != len(states[tick]) {
+			t.Errorf("Tick %d: expected %d creatures, got %d", tick, len(states[tick]), len(frame.Entities))
+		}
+		for _, entity := range frame.Entities {
+			if want := states[tick][entity.ID]; entity != want {
+				t.Errorf("Tick %d: expected %+v, got %+v", tick, want, entity)
+				break
+			}
+		}
+		if len(frame.Biomes) != 20 || len(frame.Biomes[0]) != 20 {
+			t.Errorf("Tick %d: expected the 20x20 biome grid", tick)
+		}
+	}
+	if _, err := replay.Frame(36); err == nil {
+		t.Errorf("Expected a tick past the end refused")
+	}
+}
+
+func TestReplayRecorderWritesOnlyChanges(t *testing.T) {
+	file, _ := recordTestReplay(t, 35)
+	replay, _ := ReadReplay(bytes.NewReader(file.Bytes()))
+	for _, line := range replay.Ticks[1:] {
+		if line.Keyframe != nil {
+			continue
+		}
+		for _, move := range line.Moves {
+			if move.Species != "" || move.X != math.Round(move.X*10)/10 {
+				t.Fatalf("Expected moves to carry only rounded positions, got %+v", move)
+			}
+		}
+	}
+
+	// A recording cut short keeps the ticks up to its last keyframe
+	cut, err := ReadReplay(bytes.NewReader(file.Bytes()[:file.Len()-40]))
+	if err != nil {
+		t.Fatalf("Failed to read the truncated replay: %v", err)
+	}
+	if !cut.Truncated || cut.LastTick() < 30 {
+		t.Errorf("Expected the truncated replay to reach the keyframe at tick 30, got %d", cut.LastTick())
+	}
+
+	if _, err := ReadReplay(strings.NewReader("not a replay")); err == nil {
+		t.Errorf("Expected a file that is not a replay refused")
+	}
+}
+
+func TestReplayPlayback(t *testing.T) {
+	file, _ := recordTestReplay(t, 35)
+	replay, _ := ReadReplay(bytes.NewReader(file.Bytes()))
+
+	model := NewReplayModel(replay)
+	keys := []tea.KeyMsg{
+		{Type: tea.KeyRight},
+		{Type: tea.KeyRunes, Runes: []rune("]")},
+		{Type: tea.KeyLeft},
+	}
+	for _, msg := range keys {
+		updated, _ := model.Update(msg)
+		model = updated.(ReplayModel)
+	}
+	if model.frame.Tick != 34 {
+		t.Errorf("Expected a step, a jump clamped to the end and a step back to reach tick 34, got %d", model.frame.Tick)
+	}
+	if view := model.View(); !strings.Contains(view, "Tick 34/35") {
+		t.Errorf("Expected the view to show the tick")
+	}
+
+	server := httptest.NewServer(NewReplayServer(replay).Handler())
+	defer server.Close()
+	response, err := http.Get(server.URL + "/api/replay/frame?tick=17")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	var frame ReplayFrame
+	if err := json.NewDecoder(response.Body).Decode(&frame); err != nil || frame.Tick != 17 {
+		t.Errorf("Expected the frame at tick 17, got %+v (%v)", frame.Tick, err)
+	}
+	for _, path := range []string{"/api/replay/frame?tick=500", "/api/replay/frame?tick=soon"} {
+		if response, err := http.Get(server.URL + path); err != nil || response.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected %s refused", path)
+		}
+	}
+	if response, err := http.Get(server.URL + "/"); err != nil || response.StatusCode != http.StatusOK {
+		t.Errorf("Expected the playback page served")
+	}
+}
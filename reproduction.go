@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
+	"strings"
 )
 
 // ReproductionMode represents different ways entities can reproduce
@@ -112,6 +114,11 @@ type ReproductionSystem struct {
 	NextEggID     int              `json:"next_egg_id"`
 	NextItemID    int              `json:"next_item_id"`
 	eventBus      *CentralEventBus `json:"-"` // Event tracking
+
+	GestationScale float64 `json:"gestation_scale"` // Stretches gestation and incubation periods (see TimescaleConfig)
+	DecayScale     float64 `json:"decay_scale"`     // Stretches decay periods
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewReproductionSystem creates a new reproduction system
@@ -122,28 +129,32 @@ func NewReproductionSystem(eventBus *CentralEventBus) *ReproductionSystem {
 		NextEggID:     1,
 		NextItemID:    1,
 		eventBus:      eventBus,
+
+		GestationScale: 1.0,
+		DecayScale:     1.0,
+		rng:            sharedRand,
 	}
 }
 
 // NewReproductionStatus creates a new reproduction status for an entity
-func NewReproductionStatus() *ReproductionStatus {
+func NewReproductionStatus(rng *rand.Rand) *ReproductionStatus {
 	// Randomly assign reproduction mode and strategy based on entity traits
-	mode := ReproductionMode(rand.Intn(5))
-	strategy := MatingStrategy(rand.Intn(4))
+	mode := ReproductionMode(rng.Intn(5))
+	strategy := MatingStrategy(rng.Intn(4))
 
 	return &ReproductionStatus{
 		Mode:              mode,
 		Strategy:          strategy,
 		IsPregnant:        false,
-		GestationPeriod:   50 + rand.Intn(100), // 50-150 ticks
+		GestationPeriod:   gestationPeriodRange.roll(rng),
 		OffspringCount:    0,
 		ReadyToMate:       true,
 		MatingSeason:      true,
-		MigrationDistance: 10.0 + rand.Float64()*20.0, // 10-30 units
-		RequiresMigration: rand.Float64() < 0.3,       // 30% chance of requiring migration
+		MigrationDistance: 10.0 + rng.Float64()*20.0, // 10-30 units
+		RequiresMigration: rng.Float64() < 0.3,       // 30% chance of requiring migration
 		PreferredMatingLocation: Position{
-			X: rand.Float64() * 100.0, // Random preferred location
-			Y: rand.Float64() * 100.0,
+			X: rng.Float64() * 100.0, // Random preferred location
+			Y: rng.Float64() * 100.0,
 		},
 	}
 }
@@ -351,8 +362,8 @@ func maxInt(a, b int) int {
 func (rs *ReproductionSystem) LayEgg(parent1, parent2 *Entity, currentTick int) bool {
 	// Choose location between parents with some variation
 	eggPos := Position{
-		X: (parent1.Position.X+parent2.Position.X)/2.0 + (rand.Float64()-0.5)*5.0,
-		Y: (parent1.Position.Y+parent2.Position.Y)/2.0 + (rand.Float64()-0.5)*5.0,
+		X: (parent1.Position.X+parent2.Position.X)/2.0 + (rs.rng.Float64()-0.5)*5.0,
+		Y: (parent1.Position.Y+parent2.Position.Y)/2.0 + (rs.rng.Float64()-0.5)*5.0,
 	}
 
 	egg := &Egg{
@@ -361,7 +372,7 @@ func (rs *ReproductionSystem) LayEgg(parent1, parent2 *Entity, currentTick int)
 		Parent1ID:      parent1.ID,
 		Parent2ID:      parent2.ID,
 		LayingTick:     currentTick,
-		HatchingPeriod: 30 + rand.Intn(70),                      // 30-100 ticks to hatch
+		HatchingPeriod: scaledPeriod(eggIncubationRange.roll(rs.rng), rs.GestationScale),
 		Energy:         (parent1.Energy + parent2.Energy) * 0.2, // Inherit some energy
 		IsViable:       true,
 		Species:        parent1.Species,
@@ -377,6 +388,15 @@ func (rs *ReproductionSystem) LayEgg(parent1, parent2 *Entity, currentTick int)
 	return true
 }
 
+// scaledPeriod applies a timescale to a period in ticks; saves from before the scales
+// existed load with a zero scale and keep their periods
+func scaledPeriod(ticks int, scale float64) int {
+	if scale <= 0 {
+		return ticks
+	}
+	return maxInt(1, int(math.Round(float64(ticks)*scale)))
+}
+
 // StartGestation begins the gestation period for live birth
 func (rs *ReproductionSystem) StartGestation(parent1, parent2 *Entity, currentTick int) bool {
 	// Usually the first parent carries the offspring
@@ -474,12 +494,12 @@ func (rs *ReproductionSystem) HatchEgg(egg *Egg) *Entity {
 	for _, name := range traitNames {
 		hatchling.Traits[name] = Trait{
 			Name:  name,
-			Value: rand.Float64()*2 - 1,
+			Value: rs.rng.Float64()*2 - 1,
 		}
 	}
 
 	// Initialize reproduction status
-	hatchling.ReproductionStatus = NewReproductionStatus()
+	hatchling.ReproductionStatus = NewReproductionStatus(rs.rng)
 
 	return hatchling
 }
@@ -491,7 +511,7 @@ func (rs *ReproductionSystem) AddDecayingItem(itemType string, position Position
 		Position:      position,
 		ItemType:      itemType,
 		CreationTick:  currentTick,
-		DecayPeriod:   100 + rand.Intn(200), // 100-300 ticks to decay
+		DecayPeriod:   scaledPeriod(corpseDecayRange.roll(rs.rng), rs.DecayScale),
 		NutrientValue: nutrientValue,
 		IsDecayed:     false,
 		OriginSpecies: originSpecies,
@@ -502,29 +522,16 @@ func (rs *ReproductionSystem) AddDecayingItem(itemType string, position Position
 	rs.NextItemID++
 }
 
-// UpdateMatingSeasons updates whether entities are in mating season
-func (rs *ReproductionSystem) UpdateMatingSeasons(entities []*Entity, season string) {
+// UpdateMatingSeasons updates whether entities are in mating season. In an open season
+// every entity mates; otherwise only those with the season's mating gene, such as
+// summer_mating, do.
+func (rs *ReproductionSystem) UpdateMatingSeasons(entities []*Entity, season string, open bool) {
+	gene := strings.ToLower(season) + "_mating"
 	for _, entity := range entities {
 		if entity.ReproductionStatus == nil {
 			continue
 		}
-
-		// Determine if it's mating season based on season and entity traits
-		switch season {
-		case "Spring":
-			entity.ReproductionStatus.MatingSeason = true
-		case "Summer":
-			// Some entities prefer summer mating
-			entity.ReproductionStatus.MatingSeason = entity.GetTrait("summer_mating") > 0
-		case "Autumn":
-			// Migration and final mating before winter
-			entity.ReproductionStatus.MatingSeason = entity.GetTrait("autumn_mating") > 0
-		case "Winter":
-			// Most entities don't mate in winter
-			entity.ReproductionStatus.MatingSeason = false
-		default:
-			entity.ReproductionStatus.MatingSeason = true
-		}
+		entity.ReproductionStatus.MatingSeason = open || entity.GetTrait(gene) > 0
 	}
 }
 
@@ -538,7 +545,7 @@ func (rs *ReproductionSystem) CheckGestation(entities []*Entity, currentTick int
 		}
 
 		gestationTime := currentTick - entity.ReproductionStatus.GestationStartTick
-		if gestationTime >= entity.ReproductionStatus.GestationPeriod {
+		if gestationTime >= scaledPeriod(entity.ReproductionStatus.GestationPeriod, rs.GestationScale) {
 			// Give birth
 			offspring := rs.GiveBirth(entity, currentTick)
 			if offspring != nil {
@@ -571,8 +578,8 @@ func (rs *ReproductionSystem) GiveBirth(parent *Entity, currentTick int) []*Enti
 	for i := 0; i < numOffspring; i++ {
 		// Create offspring near parent
 		childPos := Position{
-			X: parent.Position.X + (rand.Float64()-0.5)*3.0,
-			Y: parent.Position.Y + (rand.Float64()-0.5)*3.0,
+			X: parent.Position.X + (rs.rng.Float64()-0.5)*3.0,
+			Y: parent.Position.Y + (rs.rng.Float64()-0.5)*3.0,
 		}
 
 		child := &Entity{
@@ -587,14 +594,15 @@ func (rs *ReproductionSystem) GiveBirth(parent *Entity, currentTick int) []*Enti
 
 		// Initialize traits (simplified for now)
 		child.Traits = make(map[string]Trait)
-		for name, trait := range parent.Traits {
+		for _, name := range sortedKeys(parent.Traits) {
+			trait := parent.Traits[name]
 			// Inherit trait with some mutation
-			childValue := trait.Value + (rand.Float64()-0.5)*0.2
+			childValue := trait.Value + (rs.rng.Float64()-0.5)*0.2
 			child.Traits[name] = Trait{Name: name, Value: childValue}
 		}
 
 		// Initialize reproduction status
-		child.ReproductionStatus = NewReproductionStatus()
+		child.ReproductionStatus = NewReproductionStatus(rs.rng)
 
 		offspring = append(offspring, child)
 		rs.NextEggID++
@@ -621,16 +629,16 @@ func (rs *ReproductionSystem) UpdateSeasonalMatingBehaviors(entities []*Entity,
 			// Peak mating season - high readiness, increased migration
 			status.MatingSeason = true
 			status.ReadyToMate = entity.Energy > 30.0
-			if rand.Float64() < 0.4 { // 40% chance to become migratory
+			if rs.rng.Float64() < 0.4 { // 40% chance to become migratory
 				status.RequiresMigration = true
-				status.MigrationDistance = 20.0 + rand.Float64()*30.0 // 20-50 units
+				status.MigrationDistance = 20.0 + rs.rng.Float64()*30.0 // 20-50 units
 			}
 
 		case Summer:
 			// Active season but less focused on mating
 			status.MatingSeason = entity.Energy > 50.0 // Only if well-fed
 			status.ReadyToMate = status.MatingSeason
-			status.RequiresMigration = rand.Float64() < 0.2 // 20% migratory
+			status.RequiresMigration = rs.rng.Float64() < 0.2 // 20% migratory
 
 		case Autumn:
 			// Last chance mating before winter
@@ -645,13 +653,13 @@ func (rs *ReproductionSystem) UpdateSeasonalMatingBehaviors(entities []*Entity,
 
 		case Winter:
 			// Survival mode - minimal mating
-			status.MatingSeason = entity.Energy > 80.0                       // Only if very healthy
-			status.ReadyToMate = status.MatingSeason && rand.Float64() < 0.3 // 30% chance
-			status.RequiresMigration = false                                 // No migration in winter
+			status.MatingSeason = entity.Energy > 80.0                         // Only if very healthy
+			status.ReadyToMate = status.MatingSeason && rs.rng.Float64() < 0.3 // 30% chance
+			status.RequiresMigration = false                                   // No migration in winter
 		}
 
 		// Update preferred mating locations based on season
-		if status.MatingSeason && rand.Float64() < 0.1 { // 10% chance to change preference
+		if status.MatingSeason && rs.rng.Float64() < 0.1 { // 10% chance to change preference
 			rs.updateSeasonalMatingLocation(status, currentSeason, entity.Position)
 		}
 
@@ -669,20 +677,20 @@ func (rs *ReproductionSystem) updateSeasonalMatingLocation(status *ReproductionS
 	case Spring:
 		// Prefer open areas with good resources
 		status.PreferredMatingLocation = Position{
-			X: currentPos.X + (rand.Float64()-0.5)*40.0,
-			Y: currentPos.Y + (rand.Float64()-0.5)*40.0,
+			X: currentPos.X + (rs.rng.Float64()-0.5)*40.0,
+			Y: currentPos.Y + (rs.rng.Float64()-0.5)*40.0,
 		}
 	case Summer:
 		// Prefer cooler, shaded areas
 		status.PreferredMatingLocation = Position{
-			X: currentPos.X + (rand.Float64()-0.5)*20.0,
-			Y: currentPos.Y + (rand.Float64()-0.5)*20.0,
+			X: currentPos.X + (rs.rng.Float64()-0.5)*20.0,
+			Y: currentPos.Y + (rs.rng.Float64()-0.5)*20.0,
 		}
 	case Autumn:
 		// Stay close to current territory
 		status.PreferredMatingLocation = Position{
-			X: currentPos.X + (rand.Float64()-0.5)*10.0,
-			Y: currentPos.Y + (rand.Float64()-0.5)*10.0,
+			X: currentPos.X + (rs.rng.Float64()-0.5)*10.0,
+			Y: currentPos.Y + (rs.rng.Float64()-0.5)*10.0,
 		}
 	case Winter:
 		// Prefer sheltered locations
@@ -700,7 +708,8 @@ func (rs *ReproductionSystem) ImplementTerritorialMating(entities []*Entity, ter
 		status := entity.ReproductionStatus
 
 		// Check if entity is in a territory
-		for _, territory := range territories {
+		for _, territoryID := range sortedKeys(territories) {
+			territory := territories[territoryID]
 			if rs.isInTerritory(entity.Position, territory) {
 				// Territorial mating success based on dominance
 				dominanceScore := entity.GetTrait("strength") + entity.GetTrait("intelligence")
@@ -784,7 +793,7 @@ func (rs *ReproductionSystem) ImplementCrossSpeciesCompatibility(entity1, entity
 			// Adjust chance based on similarities
 			adjustedChance := chance * geneticSimilarity * environmentalSimilarity
 
-			return rand.Float64() < adjustedChance
+			return rs.rng.Float64() < adjustedChance
 		}
 	}
 
@@ -800,7 +809,8 @@ func (rs *ReproductionSystem) calculateGeneticSimilarity(entity1, entity2 *Entit
 	totalDifference := 0.0
 	traitCount := 0
 
-	for traitName, trait1 := range entity1.Traits {
+	for _, traitName := range sortedKeys(entity1.Traits) {
+		trait1 := entity1.Traits[traitName]
 		if trait2, exists := entity2.Traits[traitName]; exists {
 			difference := (trait1.Value - trait2.Value)
 			totalDifference += difference * difference
@@ -836,8 +846,3 @@ func (rs *ReproductionSystem) calculateEnvironmentalSimilarity(entity1, entity2
 
 	return 0.5 + similarity*0.5 // Range from 0.5 to 1.0
 }
-
-func init() {
-	// Note: As of Go 1.20, rand.Seed is deprecated and not needed
-	// Global random generator is automatically seeded
-}
@@ -10,13 +10,13 @@ func TestReproductiveMaturityWithClassification(t *testing.T) {
 	reproductionSystem := NewReproductionSystem(NewCentralEventBus(1000))
 
 	// Create entities with different classifications
-	young := NewEntity(1, []string{"intelligence", "endurance"}, "test", Position{})
+	young := NewEntity(sharedRand, 1, []string{"intelligence", "endurance"}, "test", Position{})
 	young.SetTrait("intelligence", -0.8) // Prokaryotic-like
 	young.Classification = ClassificationProkaryotic
 	young.MaxLifespan = classifier.CalculateLifespan(young, young.Classification)
 	young.Age = 1 // Young age (maturation age is ~7 for prokaryotic)
 
-	mature := NewEntity(2, []string{"intelligence", "endurance"}, "test", Position{})
+	mature := NewEntity(sharedRand, 2, []string{"intelligence", "endurance"}, "test", Position{})
 	mature.SetTrait("intelligence", -0.3) // Eukaryotic (more reasonable for testing)
 	mature.Classification = ClassificationEukaryotic
 	mature.MaxLifespan = classifier.CalculateLifespan(mature, mature.Classification)
@@ -58,7 +58,7 @@ func TestReproductiveVigor(t *testing.T) {
 	timeSystem := NewAdvancedTimeSystemLegacy(480, 120)
 	classifier := NewOrganismClassifier(timeSystem)
 
-	entity := NewEntity(1, []string{"endurance"}, "test", Position{})
+	entity := NewEntity(sharedRand, 1, []string{"endurance"}, "test", Position{})
 	entity.Classification = ClassificationEukaryotic
 	entity.MaxLifespan = classifier.CalculateLifespan(entity, entity.Classification)
 
@@ -106,12 +106,12 @@ func TestReproductionWithClassificationIntegration(t *testing.T) {
 	world := NewWorld(config)
 
 	// Create two entities
-	entity1 := NewEntity(1, []string{"intelligence", "endurance"}, "test", Position{X: 50, Y: 50})
+	entity1 := NewEntity(sharedRand, 1, []string{"intelligence", "endurance"}, "test", Position{X: 50, Y: 50})
 	entity1.SetTrait("intelligence", 0.5)
 	entity1.SetTrait("endurance", 0.5)
 	entity1.Energy = 100.0
 
-	entity2 := NewEntity(2, []string{"intelligence", "endurance"}, "test", Position{X: 52, Y: 52})
+	entity2 := NewEntity(sharedRand, 2, []string{"intelligence", "endurance"}, "test", Position{X: 52, Y: 52})
 	entity2.SetTrait("intelligence", 0.5)
 	entity2.SetTrait("endurance", 0.5)
 	entity2.Energy = 100.0
@@ -21,7 +21,7 @@ func TestReproductionSystem(t *testing.T) {
 }
 
 func TestReproductionStatus(t *testing.T) {
-	status := NewReproductionStatus()
+	status := NewReproductionStatus(sharedRand)
 
 	if status == nil {
 		t.Fatal("Failed to create reproduction status")
@@ -37,8 +37,8 @@ func TestReproductionStatus(t *testing.T) {
 }
 
 func TestCanMate(t *testing.T) {
-	status1 := NewReproductionStatus()
-	status2 := NewReproductionStatus()
+	status1 := NewReproductionStatus(sharedRand)
+	status2 := NewReproductionStatus(sharedRand)
 
 	// Both entities should be able to mate initially
 	if !status1.CanMate(status2, 2, 0) {
@@ -62,8 +62,8 @@ func TestEggLaying(t *testing.T) {
 	rs := NewReproductionSystem(NewCentralEventBus(1000))
 
 	// Create two parent entities
-	parent1 := NewEntity(1, []string{"strength", "speed"}, "test", Position{X: 10, Y: 10})
-	parent2 := NewEntity(2, []string{"strength", "speed"}, "test", Position{X: 12, Y: 12})
+	parent1 := NewEntity(sharedRand, 1, []string{"strength", "speed"}, "test", Position{X: 10, Y: 10})
+	parent2 := NewEntity(sharedRand, 2, []string{"strength", "speed"}, "test", Position{X: 12, Y: 12})
 
 	// Test egg laying
 	success := rs.LayEgg(parent1, parent2, 0)
@@ -89,8 +89,8 @@ func TestGestation(t *testing.T) {
 	rs := NewReproductionSystem(NewCentralEventBus(1000))
 
 	// Create parent entities
-	parent1 := NewEntity(1, []string{"strength", "speed"}, "test", Position{X: 10, Y: 10})
-	parent2 := NewEntity(2, []string{"strength", "speed"}, "test", Position{X: 12, Y: 12})
+	parent1 := NewEntity(sharedRand, 1, []string{"strength", "speed"}, "test", Position{X: 10, Y: 10})
+	parent2 := NewEntity(sharedRand, 2, []string{"strength", "speed"}, "test", Position{X: 12, Y: 12})
 
 	// Start gestation
 	success := rs.StartGestation(parent1, parent2, 0)
@@ -180,12 +180,12 @@ func TestDecaySystem(t *testing.T) {
 
 func TestMatingStrategies(t *testing.T) {
 	// Test monogamous strategy
-	status1 := NewReproductionStatus()
+	status1 := NewReproductionStatus(sharedRand)
 	status1.Strategy = Monogamous
 	status1.MateID = 5 // Already mated to entity 5
 
-	status2 := NewReproductionStatus() // This represents entity 5
-	status3 := NewReproductionStatus() // This represents entity 10
+	status2 := NewReproductionStatus(sharedRand) // This represents entity 5
+	status3 := NewReproductionStatus(sharedRand) // This represents entity 10
 
 	// Should be able to mate with same mate (entity 5)
 	if !status1.CanMate(status2, 5, 0) {
@@ -200,7 +200,7 @@ func TestMatingStrategies(t *testing.T) {
 
 func TestEntityReproductionIntegration(t *testing.T) {
 	// Test that entities are properly initialized with reproduction status
-	entity := NewEntity(1, []string{"strength", "speed"}, "test", Position{X: 0, Y: 0})
+	entity := NewEntity(sharedRand, 1, []string{"strength", "speed"}, "test", Position{X: 0, Y: 0})
 
 	if entity.ReproductionStatus == nil {
 		t.Error("Entity should have reproduction status initialized")
@@ -211,7 +211,7 @@ func TestEntityReproductionIntegration(t *testing.T) {
 	}
 
 	// Test cloning preserves reproduction traits
-	clone := entity.Clone()
+	clone := entity.Clone(sharedRand)
 	if clone.ReproductionStatus == nil {
 		t.Error("Cloned entity should have reproduction status")
 	}
@@ -349,18 +349,18 @@ func TestMatingCompetition(t *testing.T) {
 	world := NewWorld(config)
 
 	// Create test entities manually
-	entity1 := NewEntity(1, []string{"strength", "intelligence"}, "test", Position{X: 10, Y: 10})
+	entity1 := NewEntity(sharedRand, 1, []string{"strength", "intelligence"}, "test", Position{X: 10, Y: 10})
 	entity1.SetTrait("strength", 0.5)
 	entity1.SetTrait("intelligence", 0.3)
 	entity1.Energy = 80.0
 
-	entity2 := NewEntity(2, []string{"strength", "intelligence"}, "test", Position{X: 12, Y: 12})
+	entity2 := NewEntity(sharedRand, 2, []string{"strength", "intelligence"}, "test", Position{X: 12, Y: 12})
 	entity2.SetTrait("strength", 0.4)
 	entity2.SetTrait("intelligence", 0.4)
 	entity2.Energy = 70.0
 
 	// Stronger competitor nearby
-	competitor := NewEntity(3, []string{"strength", "intelligence"}, "test", Position{X: 8, Y: 8})
+	competitor := NewEntity(sharedRand, 3, []string{"strength", "intelligence"}, "test", Position{X: 8, Y: 8})
 	competitor.SetTrait("strength", 0.8)
 	competitor.SetTrait("intelligence", 0.7)
 	competitor.Energy = 90.0
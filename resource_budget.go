@@ -0,0 +1,307 @@
+package main
+
+import (
+	"math"
+	"slices"
+)
+
+// grownPlantSize is the size seedlings are budgeted at, that of a typical full-grown plant
+const grownPlantSize = 2.0
+
+// resourceYield scales the sunlight, water and nutrients a grid cell holds by its biome
+type resourceYield struct {
+	Sunlight  float64
+	Water     float64
+	Nutrients float64
+}
+
+// biomeResourceYields lists each biome's share of a cell's resources relative to plains.
+// Canopies and depths take sunlight, deserts and heights lack water, wet and wooded ground
+// is rich in nutrients.
+var biomeResourceYields = map[BiomeType]resourceYield{
+	BiomePlains:       {Sunlight: 1.0, Water: 1.0, Nutrients: 1.0},
+	BiomeForest:       {Sunlight: 0.6, Water: 1.2, Nutrients: 1.4},
+	BiomeDesert:       {Sunlight: 1.3, Water: 0.2, Nutrients: 0.4},
+	BiomeMountain:     {Sunlight: 0.9, Water: 0.6, Nutrients: 0.5},
+	BiomeWater:        {Sunlight: 0.8, Water: 2.0, Nutrients: 0.8},
+	BiomeRadiation:    {Sunlight: 1.0, Water: 0.6, Nutrients: 0.3},
+	BiomeSoil:         {Sunlight: 0.1, Water: 0.8, Nutrients: 1.5},
+	BiomeAir:          {Sunlight: 1.2, Water: 0.3, Nutrients: 0.1},
+	BiomeIce:          {Sunlight: 0.7, Water: 0.4, Nutrients: 0.2},
+	BiomeRainforest:   {Sunlight: 0.5, Water: 1.6, Nutrients: 1.6},
+	BiomeDeepWater:    {Sunlight: 0.1, Water: 2.0, Nutrients: 0.6},
+	BiomeHighAltitude: {Sunlight: 1.2, Water: 0.4, Nutrients: 0.3},
+	BiomeHotSpring:    {Sunlight: 0.8, Water: 1.5, Nutrients: 1.0},
+	BiomeTundra:       {Sunlight: 0.6, Water: 0.5, Nutrients: 0.4},
+	BiomeSwamp:        {Sunlight: 0.6, Water: 1.8, Nutrients: 1.3},
+	BiomeCanyon:       {Sunlight: 0.7, Water: 0.4, Nutrients: 0.5},
+}
+
+// ResourcePool is a resource held across the cells of a biome or the whole world
+type ResourcePool struct {
+	Level    float64 `json:"level"`    // Left after this tick's plants took their share
+	Capacity float64 `json:"capacity"` // Most the cells hold
+}
+
+// add adds another pool's level and capacity to this one
+func (p *ResourcePool) add(other ResourcePool) {
+	p.Level += other.Level
+	p.Capacity += other.Capacity
+}
+
+// regenerate restores a share of what is missing, keeping the level within a capacity that
+// may have changed since the last tick
+func (p *ResourcePool) regenerate(capacity, rate float64) {
+	p.Capacity = capacity
+	p.Level = math.Min(capacity, p.Level+rate*(capacity-p.Level))
+}
+
+// BiomeResourceBudget is the resource budget shared by every cell of one biome
+type BiomeResourceBudget struct {
+	Biome            string       `json:"biome"`
+	Cells            int          `json:"cells"`
+	Sunlight         ResourcePool `json:"sunlight"`
+	Water            ResourcePool `json:"water"`
+	Nutrients        ResourcePool `json:"nutrients"`
+	PreyBiomass      float64      `json:"prey_biomass"` // Plant biomass standing in the biome, the base of its food web
+	Plants           int          `json:"plants"`
+	Creatures        int          `json:"creatures"`
+	PlantDemand      float64      `json:"plant_demand"`      // Of each resource, this tick
+	PlantSupply      float64      `json:"plant_supply"`      // Share of the plants' demand met, 0-1
+	PlantRenewal     float64      `json:"plant_renewal"`     // Plant demand the biome renews each tick, of its scarcest resource
+	PlantRoom        int          `json:"plant_room"`        // Seedlings the biome can still feed once grown
+	CreatureCapacity float64      `json:"creature_capacity"` // Creatures the biome's cells and prey biomass can feed
+	Limit            string       `json:"limit,omitempty"`   // The resource holding the plants back, if any
+
+	biome BiomeType
+}
+
+// ResourceBudgetStatus reports the resource budget of the world and of each biome in it
+type ResourceBudgetStatus struct {
+	Enabled          bool                  `json:"enabled"`
+	Settings         ResourceBudgetConfig  `json:"settings"`
+	Tick             int                   `json:"tick"` // Tick the budget was last drawn up
+	Sunlight         ResourcePool          `json:"sunlight"`
+	Water            ResourcePool          `json:"water"`
+	Nutrients        ResourcePool          `json:"nutrients"`
+	PreyBiomass      float64               `json:"prey_biomass"`
+	Plants           int                   `json:"plants"`
+	Creatures        int                   `json:"creatures"`
+	PlantSupply      float64               `json:"plant_supply"`      // Share of every plant's demand met, 0-1
+	CreatureCapacity float64               `json:"creature_capacity"` // Creatures the world can feed
+	SeedlingsRefused int                   `json:"seedlings_refused"` // Seedlings that found no resources to live on so far
+	Biomes           []BiomeResourceBudget `json:"biomes"`            // Biomes on the map, in biome order
+}
+
+// ResourceBudgetSystem caps plant growth and creature reproduction by what each biome can
+// supply. Every tick the biomes' water and nutrients regenerate toward their capacity and
+// sunlight arrives afresh; plants take what they need in proportion to their size and grow,
+// and photosynthesise, only as far as the scarcest resource allows. Seeds sprout only while
+// resources are left over, and creatures breed less as they approach the number the biome's
+// cells and plant biomass can feed, so populations level off instead of booming and crashing.
+type ResourceBudgetSystem struct {
+	tick             int
+	biomes           map[BiomeType]*BiomeResourceBudget
+	seedlingsRefused int
+}
+
+// NewResourceBudgetSystem creates a resource budget whose pools start full
+func NewResourceBudgetSystem() *ResourceBudgetSystem {
+	return &ResourceBudgetSystem{biomes: make(map[BiomeType]*BiomeResourceBudget)}
+}
+
+// Update regenerates every biome's resources, shares them among its plants and works out
+// how many seedlings and creatures it has room for
+func (rb *ResourceBudgetSystem) Update(w *World) {
+	settings := w.SimConfig.Budget
+	if !settings.Enabled {
+		rb.Clear()
+		return
+	}
+	rb.tick = w.Tick
+
+	cells := make(map[BiomeType]int)
+	for y := range w.Grid {
+		for x := range w.Grid[y] {
+			cells[w.Grid[y][x].Biome]++
+		}
+	}
+	for biome := range rb.biomes {
+		if cells[biome] == 0 {
+			delete(rb.biomes, biome)
+		}
+	}
+	for biome, count := range cells {
+		yield, known := biomeResourceYields[biome]
+		if !known {
+			yield = biomeResourceYields[BiomePlains]
+		}
+		budget, exists := rb.biomes[biome]
+		if !exists {
+			budget = &BiomeResourceBudget{biome: biome, Biome: biomeName(biome)}
+			rb.biomes[biome] = budget
+		}
+		n := float64(count)
+		budget.Cells = count
+		budget.Sunlight.regenerate(n*settings.SunlightPerCell*yield.Sunlight, 1)
+		budget.Water.regenerate(n*settings.WaterPerCell*yield.Water, settings.WaterRegeneration)
+		budget.Nutrients.regenerate(n*settings.NutrientsPerCell*yield.Nutrients, settings.NutrientRegeneration)
+		if !exists {
+			// New biomes start with their pools full
+			budget.Water.Level = budget.Water.Capacity
+			budget.Nutrients.Level = budget.Nutrients.Capacity
+		}
+		budget.Plants, budget.Creatures, budget.PlantDemand, budget.PreyBiomass = 0, 0, 0, 0
+	}
+
+	for _, plant := range w.AllPlants {
+		if !plant.IsAlive {
+			continue
+		}
+		budget := rb.biomes[w.getBiomeAtPosition(plant.Position.X, plant.Position.Y)]
+		size := math.Max(0, plant.Size)
+		budget.Plants++
+		budget.PlantDemand += settings.PlantUptake * (1 + size)
+		budget.PreyBiomass += math.Max(0, plant.NutritionVal) * size
+	}
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive {
+			rb.biomes[w.getBiomeAtPosition(entity.Position.X, entity.Position.Y)].Creatures++
+		}
+	}
+
+	for _, budget := range rb.biomes {
+		pools := []*ResourcePool{&budget.Sunlight, &budget.Water, &budget.Nutrients}
+		budget.PlantSupply, budget.Limit = 1, ""
+		if budget.PlantDemand > 0 {
+			for i, name := range []string{"sunlight", "water", "nutrients"} {
+				if share := pools[i].Level / budget.PlantDemand; share < budget.PlantSupply {
+					budget.PlantSupply, budget.Limit = share, name
+				}
+			}
+		}
+		leftover := math.Inf(1)
+		for _, pool := range pools {
+			pool.Level = math.Max(0, pool.Level-budget.PlantDemand*budget.PlantSupply)
+			leftover = math.Min(leftover, pool.Level)
+		}
+
+		// Seedlings fit in what is left over now and in what the biome renews beyond its
+		// plants' needs, budgeted at the demand they will have once grown
+		budget.PlantRenewal = math.Min(budget.Sunlight.Capacity,
+			math.Min(budget.Water.Capacity*settings.WaterRegeneration, budget.Nutrients.Capacity*settings.NutrientRegeneration))
+		budget.PlantRoom = 0
+		if settings.PlantUptake > 0 {
+			spare := math.Min(leftover, budget.PlantRenewal-budget.PlantDemand)
+			budget.PlantRoom = max(0, int(spare/(settings.PlantUptake*(1+grownPlantSize))))
+		}
+
+		budget.CreatureCapacity = float64(budget.Cells) * settings.CreaturesPerCell
+		if settings.BiomassPerCreature > 0 {
+			budget.CreatureCapacity += budget.PreyBiomass / settings.BiomassPerCreature
+		}
+	}
+}
+
+// Clear forgets the budget so the pools start full again, as after a reset or a load
+func (rb *ResourceBudgetSystem) Clear() {
+	clear(rb.biomes)
+	rb.seedlingsRefused = 0
+}
+
+// PlantSupply returns the share of their demand that plants in a biome receive, 1 when the
+// budget is off
+func (rb *ResourceBudgetSystem) PlantSupply(biome BiomeType) float64 {
+	if budget, exists := rb.biomes[biome]; exists {
+		return budget.PlantSupply
+	}
+	return 1
+}
+
+// HasRoomForPlants reports whether a biome's budget can feed more plants
+func (rb *ResourceBudgetSystem) HasRoomForPlants(biome BiomeType) bool {
+	budget, exists := rb.biomes[biome]
+	return !exists || budget.PlantRoom > 0
+}
+
+// AdmitPlant claims room in a biome's budget for a seedling, reporting false when the
+// biome's resources are spoken for
+func (rb *ResourceBudgetSystem) AdmitPlant(biome BiomeType) bool {
+	budget, exists := rb.biomes[biome]
+	if !exists {
+		return true
+	}
+	if budget.PlantRoom <= 0 {
+		rb.seedlingsRefused++
+		return false
+	}
+	budget.PlantRoom--
+	return true
+}
+
+// ReproductionFactor scales the chance of breeding in a biome down from 1 with an empty
+// biome to 0 once it holds as many creatures as it can feed
+func (rb *ResourceBudgetSystem) ReproductionFactor(biome BiomeType) float64 {
+	budget, exists := rb.biomes[biome]
+	if !exists {
+		return 1
+	}
+	if budget.CreatureCapacity <= 0 {
+		return 0
+	}
+	return math.Max(0, 1-float64(budget.Creatures)/budget.CreatureCapacity)
+}
+
+// Status totals the budget across the world and lists each biome's share
+func (rb *ResourceBudgetSystem) Status(w *World) ResourceBudgetStatus {
+	status := ResourceBudgetStatus{
+		Enabled:          w.SimConfig.Budget.Enabled,
+		Settings:         w.SimConfig.Budget,
+		Tick:             rb.tick,
+		PlantSupply:      1,
+		SeedlingsRefused: rb.seedlingsRefused,
+		Biomes:           make([]BiomeResourceBudget, 0, len(rb.biomes)),
+	}
+	demand, met := 0.0, 0.0
+	for _, budget := range rb.biomes {
+		status.Biomes = append(status.Biomes, *budget)
+		status.Sunlight.add(budget.Sunlight)
+		status.Water.add(budget.Water)
+		status.Nutrients.add(budget.Nutrients)
+		status.PreyBiomass += budget.PreyBiomass
+		status.Plants += budget.Plants
+		status.Creatures += budget.Creatures
+		status.CreatureCapacity += budget.CreatureCapacity
+		demand += budget.PlantDemand
+		met += budget.PlantDemand * budget.PlantSupply
+	}
+	if demand > 0 {
+		status.PlantSupply = met / demand
+	}
+	slices.SortFunc(status.Biomes, func(a, b BiomeResourceBudget) int { return int(a.biome) - int(b.biome) })
+	return status
+}
+
+// roomForPlants reports whether the resource budget can feed more plants at a position
+func (w *World) roomForPlants(pos Position) bool {
+	return w.ResourceBudget == nil || w.ResourceBudget.HasRoomForPlants(w.getBiomeAtPosition(pos.X, pos.Y))
+}
+
+// admitPlant claims room for a seedling at a position in the resource budget
+func (w *World) admitPlant(pos Position) bool {
+	return w.ResourceBudget == nil || w.ResourceBudget.AdmitPlant(w.getBiomeAtPosition(pos.X, pos.Y))
+}
+
+// plantShare returns what plants on a grid cell receive from the resource budget, with
+// photosynthesis following the daylight on the cell
+func (w *World) plantShare(gridX, gridY int) PlantShare {
+	if w.ResourceBudget == nil || !w.SimConfig.Budget.Enabled {
+		return unbudgetedShare
+	}
+	return PlantShare{
+		Supply:         w.ResourceBudget.PlantSupply(w.Grid[gridY][gridX].Biome),
+		Photosynthesis: w.SimConfig.Budget.Photosynthesis,
+		Light:          w.photosynthesisLight(gridY),
+		SoilBonus:      w.SimConfig.Budget.SoilBonus,
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestResourceBudgetSharesBiomeResourcesAmongPlants(t *testing.T) {
+	world := newSteppingTestWorld(51)
+	for i := 0; i < 10; i++ {
+		world.Update()
+	}
+	status := world.ResourceBudget.Status(world)
+	cells := 0
+	for _, biome := range status.Biomes {
+		cells += biome.Cells
+		if biome.PlantSupply < 0 || biome.PlantSupply > 1 || biome.Water.Level > biome.Water.Capacity {
+			t.Errorf("Expected %s's pools and supply within bounds, got %+v", biome.Biome, biome)
+		}
+	}
+	if cells != world.Config.GridWidth*world.Config.GridHeight || status.Plants == 0 || status.CreatureCapacity <= 0 {
+		t.Fatalf("Expected every cell budgeted and the plants counted, got %+v", status)
+	}
+
+	// Without sunlight the plants go short and seedlings find no room
+	world.SimConfig.Budget.SunlightPerCell = 0
+	world.ResourceBudget.Update(world)
+	status = world.ResourceBudget.Status(world)
+	if status.PlantSupply != 0 {
+		t.Errorf("Expected no plant demand met without sunlight, got %v", status.PlantSupply)
+	}
+	for _, biome := range status.Biomes {
+		if biome.Plants > 0 && (biome.Limit != "sunlight" || biome.PlantRoom != 0) {
+			t.Errorf("Expected sunlight to hold %s back, got %+v", biome.Biome, biome)
+		}
+	}
+	plant := world.AllPlants[0]
+	if world.roomForPlants(plant.Position) || world.admitPlant(plant.Position) {
+		t.Error("Expected a seedling refused where resources are spoken for")
+	}
+	if refused := world.ResourceBudget.Status(world).SeedlingsRefused; refused != 1 {
+		t.Errorf("Expected the refused seedling counted, got %d", refused)
+	}
+	if share := world.plantShare(world.gridCell(plant.Position)); share.Supply != 0 {
+		t.Errorf("Expected plants to get nothing, got %+v", share)
+	}
+
+	// Switching the budget off lets plants grow as they did without one
+	world.SimConfig.Budget.Enabled = false
+	world.ResourceBudget.Update(world)
+	if share := world.plantShare(0, 0); share != unbudgetedShare || !world.admitPlant(plant.Position) {
+		t.Errorf("Expected an unlimited share with the budget off, got %+v", share)
+	}
+	if status := world.ResourceBudget.Status(world); status.Enabled || len(status.Biomes) != 0 {
+		t.Errorf("Expected no budget drawn up while off, got %+v", status)
+	}
+}
+
+func TestReproductionSlowsAsBiomesFillUp(t *testing.T) {
+	rb := NewResourceBudgetSystem()
+	if factor := rb.ReproductionFactor(BiomeForest); factor != 1 {
+		t.Errorf("Expected unbudgeted biomes to breed freely, got %v", factor)
+	}
+	rb.biomes[BiomeForest] = &BiomeResourceBudget{biome: BiomeForest, Creatures: 15, CreatureCapacity: 20}
+	if factor := rb.ReproductionFactor(BiomeForest); factor != 0.25 {
+		t.Errorf("Expected a quarter of the breeding chance left, got %v", factor)
+	}
+	rb.biomes[BiomeForest].Creatures = 30
+	if factor := rb.ReproductionFactor(BiomeForest); factor != 0 {
+		t.Errorf("Expected no breeding beyond capacity, got %v", factor)
+	}
+}
+
+func TestResourceBudgetAPI(t *testing.T) {
+	wi := NewWebInterface(newSteppingTestWorld(52))
+	wi.world.Update()
+
+	var status ResourceBudgetStatus
+	if code := callControlAPI(t, wi.handleResourceBudget, http.MethodGet, "/api/resource-budget", "", &status); code != http.StatusOK || len(status.Biomes) == 0 {
+		t.Fatalf("Expected the budget of each biome, got %d %+v", code, status)
+	}
+	for _, body := range []string{`{"water_regeneration": 1.5}`, `{"plant_uptake": -1}`, `{"enabled": "yes"}`} {
+		if code := callControlAPI(t, wi.handleResourceBudget, http.MethodPost, "/api/resource-budget", body, nil); code != http.StatusBadRequest {
+			t.Errorf("Expected %s refused, got %d", body, code)
+		}
+	}
+	callControlAPI(t, wi.handleResourceBudget, http.MethodPost, "/api/resource-budget", `{"water_per_cell": 5}`, &status)
+	defaults := DefaultSimulationConfig().Budget
+	if status.Settings.WaterPerCell != 5 || status.Settings.SunlightPerCell != defaults.SunlightPerCell || !status.Enabled {
+		t.Errorf("Expected only the water per cell changed, got %+v", status.Settings)
+	}
+}
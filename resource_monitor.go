@@ -0,0 +1,402 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Resource monitoring settings
+const (
+	resourceSampleInterval = 100 // Ticks between footprint samples
+	resourceHistoryLength  = 60  // Samples kept per store
+	leakWindowSamples      = 10  // Consecutive samples a store must grow over to be suspected of leaking
+	leakMinItems           = 500 // Stores smaller than this are never suspected
+)
+
+// Leak states of a store
+const (
+	ResourceLeakGrowing   = "growing"    // Grew steadily over the whole window
+	ResourceLeakOverLimit = "over_limit" // Holds more than its policy limit with pruning off
+)
+
+// ResourcePolicy is the pruning policy of one store
+type ResourcePolicy struct {
+	Limit     int  `json:"limit"`      // Items kept when pruning; 0 keeps them all
+	AutoPrune bool `json:"auto_prune"` // Prune at every sample instead of only on request
+}
+
+// resourceStore is a world store whose size the monitor tracks. Stores without a prune
+// function are reported but never pruned.
+type resourceStore struct {
+	name         string
+	description  string
+	bytesPerItem int // Rough footprint of one item, for the estimate
+	orphans      bool
+	count        func(w *World) int
+	prune        func(w *World, limit int) int // Drops items over limit, returning how many went
+}
+
+// ResourceStoreStatus reports the footprint and health of one store
+type ResourceStoreStatus struct {
+	Name           string         `json:"name"`
+	Description    string         `json:"description"`
+	Count          int            `json:"count"`
+	EstimatedBytes int64          `json:"estimated_bytes"`
+	Growth         int            `json:"growth"` // Change in count over the leak window
+	Prunable       bool           `json:"prunable"`
+	PrunesOrphans  bool           `json:"prunes_orphans"` // Pruning drops leftovers of dead creatures regardless of the limit
+	Policy         ResourcePolicy `json:"policy"`
+	Pruned         int            `json:"pruned"` // Items pruned so far
+	Leak           string         `json:"leak,omitempty"`
+	History        []int          `json:"history"` // Count at each sample, oldest first
+}
+
+// ResourceReport is the memory dashboard: the Go heap and every tracked store
+type ResourceReport struct {
+	Tick           int                   `json:"tick"`
+	SampleInterval int                   `json:"sample_interval"`
+	HeapBytes      uint64                `json:"heap_bytes"`
+	HeapObjects    uint64                `json:"heap_objects"`
+	HeapHistory    []uint64              `json:"heap_history"`
+	EstimatedBytes int64                 `json:"estimated_bytes"` // Sum of the store estimates
+	Stores         []ResourceStoreStatus `json:"stores"`
+	Alerts         int                   `json:"alerts"` // Leak alerts raised so far
+}
+
+// ResourceMonitor samples how much each world store holds, raises an alert when a store
+// keeps growing without levelling off, and prunes stores by their configured policy so
+// long runs stay within memory
+type ResourceMonitor struct {
+	mu       sync.Mutex
+	eventBus *CentralEventBus
+	stores   []*resourceStore
+	policies map[string]ResourcePolicy
+	history  map[string][]int
+	pruned   map[string]int
+	leaks    map[string]string // Leak state per store, alerted once until it clears
+
+	heap        runtime.MemStats
+	heapHistory []uint64
+	alerts      int
+}
+
+// NewResourceMonitor creates a monitor over the standard world stores with their default
+// pruning policies
+func NewResourceMonitor(eventBus *CentralEventBus) *ResourceMonitor {
+	rm := &ResourceMonitor{
+		eventBus: eventBus,
+		stores:   defaultResourceStores(),
+		policies: make(map[string]ResourcePolicy),
+	}
+	for name, policy := range defaultResourcePolicies {
+		rm.policies[name] = policy
+	}
+	rm.clearSamples()
+	return rm
+}
+
+// defaultResourcePolicies prune what is safe to prune: leftovers of dead creatures and
+// the anomaly log, which nothing else bounds
+var defaultResourcePolicies = map[string]ResourcePolicy{
+	"events":             {Limit: 20000},
+	"statistical_events": {Limit: 10000},
+	"anomalies":          {Limit: 1000, AutoPrune: true},
+	"seeds":              {Limit: 5000},
+	"eggs":               {Limit: 2000},
+	"networks":           {AutoPrune: true},
+	"organisms":          {AutoPrune: true},
+}
+
+func defaultResourceStores() []*resourceStore {
+	return []*resourceStore{
+		{
+			name: "entities", description: "Creatures in the world", bytesPerItem: 2048,
+			count: func(w *World) int { return len(w.AllEntities) },
+		},
+		{
+			name: "dispersal_pool", description: "Creatures waiting offstage under the population caps", bytesPerItem: 2048,
+			count: func(w *World) int {
+				if w.PopulationCaps == nil {
+					return 0
+				}
+				return w.PopulationCaps.PoolSize()
+			},
+		},
+		{
+			name: "events", description: "Event bus history", bytesPerItem: 512,
+			count: func(w *World) int { return w.CentralEventBus.Len() },
+			prune: func(w *World, limit int) int { return w.CentralEventBus.Trim(limit) },
+		},
+		{
+			name: "statistical_events", description: "Events logged for statistical analysis", bytesPerItem: 256,
+			count: func(w *World) int { return len(w.StatisticalReporter.Events) },
+			prune: func(w *World, limit int) int {
+				return keepNewest(&w.StatisticalReporter.Events, limit)
+			},
+		},
+		{
+			name: "anomalies", description: "Detected statistical anomalies", bytesPerItem: 384,
+			count: func(w *World) int { return len(w.StatisticalReporter.Anomalies) },
+			prune: func(w *World, limit int) int {
+				return keepNewest(&w.StatisticalReporter.Anomalies, limit)
+			},
+		},
+		{
+			name: "seeds", description: "Seeds dispersing or dormant", bytesPerItem: 192,
+			count: func(w *World) int { return len(w.SeedDispersalSystem.AllSeeds) },
+			prune: func(w *World, limit int) int {
+				return keepNewest(&w.SeedDispersalSystem.AllSeeds, limit)
+			},
+		},
+		{
+			name: "eggs", description: "Eggs waiting to hatch", bytesPerItem: 256,
+			count: func(w *World) int { return len(w.ReproductionSystem.Eggs) },
+			prune: func(w *World, limit int) int {
+				return keepNewest(&w.ReproductionSystem.Eggs, limit)
+			},
+		},
+		{
+			name: "networks", description: "Neural networks of creatures", bytesPerItem: 8192, orphans: true,
+			count: func(w *World) int { return len(w.NeuralAISystem.EntityNetworks) },
+			prune: func(w *World, limit int) int {
+				return pruneByEntity(w, w.NeuralAISystem.EntityNetworks, limit)
+			},
+		},
+		{
+			name: "organisms", description: "Cellular organisms of creatures", bytesPerItem: 4096, orphans: true,
+			count: func(w *World) int { return len(w.CellularSystem.OrganismMap) },
+			prune: func(w *World, limit int) int {
+				return pruneByEntity(w, w.CellularSystem.OrganismMap, limit)
+			},
+		},
+	}
+}
+
+// keepNewest drops the oldest items of an append-ordered store down to limit
+func keepNewest[T any](items *[]T, limit int) int {
+	drop := len(*items) - limit
+	if limit <= 0 || drop <= 0 {
+		return 0
+	}
+	*items = append((*items)[:0:0], (*items)[drop:]...)
+	return drop
+}
+
+// pruneByEntity drops entries of a per-creature store whose creature is neither alive nor
+// waiting in the dispersal pool, then the lowest IDs, the oldest creatures, down to limit
+func pruneByEntity[T any](w *World, store map[int]T, limit int) int {
+	kept := make(map[int]bool)
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive {
+			kept[entity.ID] = true
+		}
+	}
+	if w.PopulationCaps != nil {
+		w.PopulationCaps.markPooled(kept)
+	}
+
+	pruned := 0
+	ids := sortedKeys(store)
+	for _, id := range ids {
+		if !kept[id] {
+			delete(store, id)
+			pruned++
+		}
+	}
+	for _, id := range ids {
+		if limit <= 0 || len(store) <= limit {
+			break
+		}
+		if _, exists := store[id]; exists {
+			delete(store, id)
+			pruned++
+		}
+	}
+	return pruned
+}
+
+// Update samples the stores every resourceSampleInterval ticks, prunes those with
+// automatic pruning and checks for leaks
+func (rm *ResourceMonitor) Update(w *World) {
+	if w.Tick%resourceSampleInterval != 0 {
+		return
+	}
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	for _, store := range rm.stores {
+		policy := rm.policies[store.name]
+		if store.prune != nil && policy.AutoPrune {
+			rm.pruned[store.name] += store.prune(w, policy.Limit)
+		}
+		history := append(rm.history[store.name], store.count(w))
+		if len(history) > resourceHistoryLength {
+			history = history[len(history)-resourceHistoryLength:]
+		}
+		rm.history[store.name] = history
+		rm.checkLeak(w, store, policy)
+	}
+
+	runtime.ReadMemStats(&rm.heap)
+	rm.heapHistory = append(rm.heapHistory, rm.heap.HeapAlloc)
+	if len(rm.heapHistory) > resourceHistoryLength {
+		rm.heapHistory = rm.heapHistory[len(rm.heapHistory)-resourceHistoryLength:]
+	}
+}
+
+// checkLeak raises an alert when a store enters a leak state; the caller holds the lock
+func (rm *ResourceMonitor) checkLeak(w *World, store *resourceStore, policy ResourcePolicy) {
+	history := rm.history[store.name]
+	latest := history[len(history)-1]
+	state := ""
+	if isGrowingUnbounded(history) {
+		state = ResourceLeakGrowing
+	} else if store.prune != nil && !policy.AutoPrune && policy.Limit > 0 && latest > policy.Limit {
+		state = ResourceLeakOverLimit
+	}
+
+	previous := rm.leaks[store.name]
+	rm.leaks[store.name] = state
+	if state == "" || state == previous {
+		return
+	}
+	rm.alerts++
+	if rm.eventBus == nil {
+		return
+	}
+	// A store can pass its limit before there is a window of samples to compare against
+	description := fmt.Sprintf("The %s store holds %d items, over its limit of %d; enable pruning or raise the limit",
+		store.name, latest, policy.Limit)
+	if state == ResourceLeakGrowing {
+		description = fmt.Sprintf("The %s store keeps growing: %d items, up from %d over %d ticks",
+			store.name, latest, history[len(history)-leakWindowSamples], (leakWindowSamples-1)*resourceSampleInterval)
+	}
+	rm.eventBus.EmitSystemEvent(w.Tick, "resource_leak_suspected", "resources", "resource_monitor", description, nil, map[string]interface{}{
+		"store": store.name,
+		"count": latest,
+		"leak":  state,
+	})
+}
+
+// isGrowingUnbounded reports whether a sizeable store rose at every sample of the latest
+// window. Bounded stores level off or fluctuate around their equilibrium instead.
+func isGrowingUnbounded(history []int) bool {
+	if len(history) < leakWindowSamples || history[len(history)-1] < leakMinItems {
+		return false
+	}
+	window := history[len(history)-leakWindowSamples:]
+	for i := 1; i < len(window); i++ {
+		if window[i] <= window[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// Policy returns the pruning policy of a store
+func (rm *ResourceMonitor) Policy(name string) ResourcePolicy {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	return rm.policies[name]
+}
+
+// SetPolicy changes the pruning policy of a store
+func (rm *ResourceMonitor) SetPolicy(name string, policy ResourcePolicy) error {
+	if policy.Limit < 0 {
+		return fmt.Errorf("limit cannot be negative")
+	}
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	store := rm.store(name)
+	if store == nil {
+		return fmt.Errorf("unknown store %q", name)
+	}
+	if store.prune == nil && (policy.AutoPrune || policy.Limit > 0) {
+		return fmt.Errorf("the %s store cannot be pruned", name)
+	}
+	rm.policies[name] = policy
+	rm.leaks[name] = ""
+	return nil
+}
+
+// Prune applies a store's policy now and returns how many items it dropped
+func (rm *ResourceMonitor) Prune(w *World, name string) (int, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	store := rm.store(name)
+	if store == nil {
+		return 0, fmt.Errorf("unknown store %q", name)
+	}
+	if store.prune == nil {
+		return 0, fmt.Errorf("the %s store cannot be pruned", name)
+	}
+	pruned := store.prune(w, rm.policies[name].Limit)
+	rm.pruned[name] += pruned
+	return pruned, nil
+}
+
+// Report returns the current footprint of every store with its recent history
+func (rm *ResourceMonitor) Report(w *World) ResourceReport {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	report := ResourceReport{
+		Tick:           w.Tick,
+		SampleInterval: resourceSampleInterval,
+		HeapBytes:      rm.heap.HeapAlloc,
+		HeapObjects:    rm.heap.HeapObjects,
+		HeapHistory:    append([]uint64{}, rm.heapHistory...),
+		Alerts:         rm.alerts,
+	}
+	for _, store := range rm.stores {
+		count := store.count(w)
+		history := rm.history[store.name]
+		growth := 0
+		if len(history) > 0 {
+			growth = count - history[max(0, len(history)-leakWindowSamples)]
+		}
+		status := ResourceStoreStatus{
+			Name:           store.name,
+			Description:    store.description,
+			Count:          count,
+			EstimatedBytes: int64(count) * int64(store.bytesPerItem),
+			Growth:         growth,
+			Prunable:       store.prune != nil,
+			PrunesOrphans:  store.orphans,
+			Policy:         rm.policies[store.name],
+			Pruned:         rm.pruned[store.name],
+			Leak:           rm.leaks[store.name],
+			History:        append([]int{}, history...),
+		}
+		report.EstimatedBytes += status.EstimatedBytes
+		report.Stores = append(report.Stores, status)
+	}
+	return report
+}
+
+// Clear forgets the samples, leak states and prune counts, keeping the policies
+func (rm *ResourceMonitor) Clear() {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.clearSamples()
+}
+
+// clearSamples resets the sampled state; the caller holds the lock or owns the monitor
+func (rm *ResourceMonitor) clearSamples() {
+	rm.history = make(map[string][]int)
+	rm.pruned = make(map[string]int)
+	rm.leaks = make(map[string]string)
+	rm.heapHistory = nil
+	rm.alerts = 0
+}
+
+// store finds a store by name; the caller holds the lock
+func (rm *ResourceMonitor) store(name string) *resourceStore {
+	for _, store := range rm.stores {
+		if store.name == name {
+			return store
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResourceMonitorFlagsUnboundedGrowth(t *testing.T) {
+	world := newSteppingTestWorld(61)
+	monitor := world.Resources
+	if err := monitor.SetPolicy("anomalies", ResourcePolicy{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The anomaly log grows by the same amount at every sample and never levels off
+	for sample := 1; sample <= 2*leakWindowSamples; sample++ {
+		for i := 0; i < 100; i++ {
+			world.StatisticalReporter.Anomalies = append(world.StatisticalReporter.Anomalies, Anomaly{Tick: sample})
+		}
+		world.Tick = sample * resourceSampleInterval
+		monitor.Update(world)
+	}
+
+	report := monitor.Report(world)
+	var anomalies ResourceStoreStatus
+	for _, store := range report.Stores {
+		if store.Name == "anomalies" {
+			anomalies = store
+		} else if store.Leak != "" {
+			t.Errorf("Expected only the anomaly log flagged, %s is %s", store.Name, store.Leak)
+		}
+	}
+	if anomalies.Leak != ResourceLeakGrowing || anomalies.Growth != 100*(leakWindowSamples-1) || len(anomalies.History) != 2*leakWindowSamples {
+		t.Fatalf("Expected the anomaly log flagged as growing, got %+v", anomalies)
+	}
+	if alerts := world.CentralEventBus.GetEventsByType("resource_leak_suspected"); len(alerts) != 1 || alerts[0].Severity != SeverityHigh {
+		t.Errorf("Expected a single high severity alert, got %+v", alerts)
+	}
+
+	// Turning pruning back on bounds the store and clears the flag
+	if err := monitor.SetPolicy("anomalies", ResourcePolicy{Limit: 500, AutoPrune: true}); err != nil {
+		t.Fatal(err)
+	}
+	world.Tick += resourceSampleInterval
+	monitor.Update(world)
+	report = monitor.Report(world)
+	for _, store := range report.Stores {
+		if store.Name == "anomalies" && (store.Count != 500 || store.Leak != "" || store.Pruned != 1500) {
+			t.Errorf("Expected the anomaly log pruned to its limit, got %+v", store)
+		}
+	}
+}
+
+func TestPruningKeepsLivingAndPooledCreatures(t *testing.T) {
+	world := newSteppingTestWorld(62)
+	networks := world.NeuralAISystem.EntityNetworks
+	living := world.AllEntities[0]
+	pooled := world.AllEntities[1]
+	networks[living.ID] = &EntityNeuralNetwork{}
+	networks[pooled.ID] = &EntityNeuralNetwork{}
+	networks[-1] = &EntityNeuralNetwork{} // Left behind by a creature long gone
+
+	world.PopulationCaps.mu.Lock()
+	world.PopulationCaps.withdraw(world, map[*Entity]bool{pooled: true})
+	world.PopulationCaps.mu.Unlock()
+
+	pruned, err := world.Resources.Prune(world, "networks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if networks[-1] != nil || networks[living.ID] == nil || networks[pooled.ID] == nil {
+		t.Errorf("Expected only the orphaned network pruned, %d pruned", pruned)
+	}
+
+	if _, err := world.Resources.Prune(world, "entities"); err == nil {
+		t.Error("Expected creatures themselves never to be pruned")
+	}
+}
+
+func TestResourcesAPI(t *testing.T) {
+	world := newSteppingTestWorld(63)
+	wi := NewWebInterface(world)
+	for i := 0; i < 10; i++ {
+		world.SeedDispersalSystem.AllSeeds = append(world.SeedDispersalSystem.AllSeeds, &Seed{ID: i})
+	}
+
+	rec := httptest.NewRecorder()
+	wi.handleResources(rec, httptest.NewRequest(http.MethodPost, "/api/resources", bytes.NewReader([]byte(`{"store": "seeds", "limit": -1}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected a negative limit to be rejected, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleResources(rec, httptest.NewRequest(http.MethodPost, "/api/resources", bytes.NewReader([]byte(`{"store": "seeds", "limit": 3, "prune": true}`))))
+	var report ResourceReport
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &report) != nil {
+		t.Fatalf("Pruning seeds failed: %d %s", rec.Code, rec.Body.String())
+	}
+	seeds := world.SeedDispersalSystem.AllSeeds
+	if len(seeds) != 3 || seeds[0].ID != 7 {
+		t.Errorf("Expected the three newest seeds kept, got %d", len(seeds))
+	}
+	for _, store := range report.Stores {
+		if store.Name == "seeds" && (store.Policy.Limit != 3 || store.Policy.AutoPrune || store.Pruned != 7) {
+			t.Errorf("Expected only the seed limit changed, got %+v", store)
+		}
+	}
+}
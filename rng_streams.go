@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+)
+
+// Subsystems that draw from their own RNG stream. They touch no state outside themselves
+// while updating, so they may be updated concurrently.
+const (
+	rngStreamWind     = "wind"
+	rngStreamTopology = "topology"
+)
+
+// Subsystems that draw from their own RNG stream but update one after another, since they
+// change creatures, plants and records the rest of the world shares
+const (
+	rngStreamCommunication    = "communication"
+	rngStreamCivilization     = "civilization"
+	rngStreamSeedDispersal    = "seed_dispersal"
+	rngStreamPlantNetwork     = "plant_network"
+	rngStreamSpeciesNaming    = "species_naming"
+	rngStreamDNA              = "dna"
+	rngStreamCellular         = "cellular"
+	rngStreamTools            = "tools"
+	rngStreamEnvironmentalMod = "environmental_modification"
+	rngStreamReproduction     = "reproduction"
+	rngStreamFungalNetwork    = "fungal_network"
+	rngStreamCulture          = "cultural_knowledge"
+	rngStreamPressures        = "environmental_pressures"
+	rngStreamSymbiosis        = "symbiosis"
+	rngStreamCastes           = "castes"
+	rngStreamInsects          = "insects"
+	rngStreamPollination      = "pollination"
+	rngStreamColonyWarfare    = "colony_warfare"
+	rngStreamNeural           = "neural"
+	rngStreamBiomeBoundaries  = "biome_boundaries"
+	rngStreamClassification   = "classification"
+	rngStreamMetamorphosis    = "metamorphosis"
+	rngStreamCustomTraits     = "custom_traits"
+)
+
+// RNGStreams derives an independent random stream per subsystem and tick from a master
+// seed. Each stream is counter-based: its n-th draw is a hash of the seed, the subsystem,
+// the tick and n, so a subsystem's draws don't depend on what other subsystems drew or in
+// which order they ran, and no stream state needs saving to resume a run.
+type RNGStreams struct {
+	Seed     int64
+	Parallel bool // Update the stream subsystems concurrently
+}
+
+// NewRNGStreams creates the streams for a master seed
+func NewRNGStreams(seed int64, parallel bool) *RNGStreams {
+	return &RNGStreams{Seed: seed, Parallel: parallel}
+}
+
+// Stream returns a subsystem's random source for one tick, starting from its first draw
+func (s *RNGStreams) Stream(subsystem string, tick int) *rand.Rand {
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(s.Seed))
+	h.Write(buf[:])
+	h.Write([]byte(subsystem))
+	binary.LittleEndian.PutUint64(buf[:], uint64(tick))
+	h.Write(buf[:])
+	return rand.New(&counterSource{key: splitMix64(h.Sum64())})
+}
+
+// counterSource is a SplitMix64 generator: the n-th value is a bijective mix of key + n*gamma
+type counterSource struct {
+	key     uint64
+	counter uint64
+}
+
+func (c *counterSource) Uint64() uint64 {
+	c.counter++
+	return splitMix64(c.key + c.counter*0x9e3779b97f4a7c15)
+}
+
+func (c *counterSource) Int63() int64 {
+	return int64(c.Uint64() >> 1)
+}
+
+func (c *counterSource) Seed(seed int64) {
+	c.key = uint64(seed)
+	c.counter = 0
+}
+
+// splitMix64 is the SplitMix64 output function
+func splitMix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// lockedSource is a seeded source that is safe for concurrent use, so creatures updated
+// in parallel can share their world's source the way they once shared the global one
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+func newLockedSource(seed int64) *lockedSource {
+	return &lockedSource{src: rand.NewSource(seed).(rand.Source64)}
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+// adopt continues from where the source of a world about to be discarded stopped
+func (s *lockedSource) adopt(other *lockedSource) {
+	other.mu.Lock()
+	src := other.src
+	other.mu.Unlock()
+	s.mu.Lock()
+	s.src = src
+	s.mu.Unlock()
+}
+
+// sharedRand is the random source of creatures, plants and subsystems made outside a world
+var sharedRand = rand.New(newLockedSource(seedForRun(0)))
+
+// subsystemRand is where a world subsystem keeps its random source, and the name of its stream
+type subsystemRand struct {
+	stream string
+	rng    **rand.Rand
+}
+
+// subsystemRands lists the random source of every world subsystem
+func (w *World) subsystemRands() []subsystemRand {
+	return []subsystemRand{
+		{rngStreamWind, &w.WindSystem.rng},
+		{rngStreamTopology, &w.TopologySystem.rng},
+		{rngStreamCommunication, &w.CommunicationSystem.rng},
+		{rngStreamCivilization, &w.CivilizationSystem.rng},
+		{rngStreamSeedDispersal, &w.SeedDispersalSystem.rng},
+		{rngStreamPlantNetwork, &w.PlantNetworkSystem.rng},
+		{rngStreamSpeciesNaming, &w.SpeciesNaming.rng},
+		{rngStreamDNA, &w.DNASystem.rng},
+		{rngStreamCellular, &w.CellularSystem.rng},
+		{rngStreamTools, &w.ToolSystem.rng},
+		{rngStreamEnvironmentalMod, &w.EnvironmentalModSystem.rng},
+		{rngStreamReproduction, &w.ReproductionSystem.rng},
+		{rngStreamFungalNetwork, &w.FungalNetwork.rng},
+		{rngStreamCulture, &w.CulturalKnowledgeSystem.rng},
+		{rngStreamPressures, &w.EnvironmentalPressures.rng},
+		{rngStreamSymbiosis, &w.SymbioticRelationships.rng},
+		{rngStreamCastes, &w.CasteSystem.rng},
+		{rngStreamInsects, &w.InsectSystem.rng},
+		{rngStreamPollination, &w.InsectPollinationSystem.rng},
+		{rngStreamColonyWarfare, &w.ColonyWarfareSystem.rng},
+		{rngStreamNeural, &w.NeuralAISystem.rng},
+		{rngStreamBiomeBoundaries, &w.BiomeBoundarySystem.rng},
+		{rngStreamClassification, &w.OrganismClassifier.rng},
+		{rngStreamMetamorphosis, &w.MetamorphosisSystem.rng},
+		{rngStreamCustomTraits, &w.CustomTraits.rng},
+	}
+}
+
+// shareRand gives the world's subsystems its random source, so everything in the world
+// draws from one seeded stream and worlds in the same process don't disturb each other
+func (w *World) shareRand() {
+	for _, subsystem := range w.subsystemRands() {
+		*subsystem.rng = w.Rand
+	}
+}
+
+// drawFromStreams gives every subsystem its own stream for the tick, so what one subsystem
+// draws doesn't shift the draws of the others
+func (w *World) drawFromStreams() {
+	for _, subsystem := range w.subsystemRands() {
+		*subsystem.rng = w.rngFor(subsystem.stream)
+	}
+}
+
+// rngFor returns a subsystem's random source for the current tick
+func (w *World) rngFor(subsystem string) *rand.Rand {
+	if w.RNG == nil {
+		return w.Rand
+	}
+	return w.RNG.Stream(subsystem, w.Tick)
+}
+
+// updateStreamSubsystems updates the subsystems that may update concurrently. With Parallel
+// set they do, which gives the same world as updating them one after another since each
+// draws from its own stream.
+func (w *World) updateStreamSubsystems(season Season) {
+	updates := []func(){
+		func() { w.WindSystem.Update(season, w.Tick) },
+		func() { w.TopologySystem.UpdateTopology(w.Tick) },
+	}
+
+	if !w.RNG.Parallel {
+		for _, update := range updates {
+			update()
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	for _, update := range updates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			update()
+		}()
+	}
+	wg.Wait()
+}
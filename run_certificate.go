@@ -0,0 +1,274 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// certificateEpochTicks is the number of ticks between links of the state hash chain
+const certificateEpochTicks = 100
+
+// CertificateLink is one epoch of a run's state hash chain
+type CertificateLink struct {
+	Epoch int    `json:"epoch"`
+	Tick  int    `json:"tick"`
+	State string `json:"state"` // State digest at the end of the epoch
+	Hash  string `json:"hash"`  // Hash of the previous link's hash and this state
+}
+
+// RunCertificate is the tamper-evident record of a run: the seed and config it started
+// from and a hash chain over its state at the end of every epoch. Changing any recorded
+// state breaks the chain, and re-simulating the seed and config shows whether the run
+// itself was left unmodified.
+type RunCertificate struct {
+	Seed       int64             `json:"seed"`
+	Primitive  bool              `json:"primitive"`
+	RNGStreams bool              `json:"rng_streams,omitempty"` // Each subsystem drew from its own stream
+	Unordered  bool              `json:"unordered,omitempty"`   // Creatures may have updated in parallel, in no fixed order
+	Config     WorldConfig       `json:"config"`
+	ConfigHash string            `json:"config_hash"`
+	StartTick  int               `json:"start_tick"` // Nonzero when the run was resumed from a save
+	EpochTicks int               `json:"epoch_ticks"`
+	Genesis    string            `json:"genesis"` // Hash the chain starts from
+	Links      []CertificateLink `json:"links"`
+	Head       string            `json:"head"` // Hash of the last link
+}
+
+// CertificateVerification is the outcome of checking a run certificate
+type CertificateVerification struct {
+	Intact      bool   `json:"intact"`     // Every hash in the chain matches what it covers
+	Replayed    bool   `json:"replayed"`   // The run was re-simulated from its seed and config
+	Reproduced  bool   `json:"reproduced"` // Every epoch's state matched the re-simulation
+	Epochs      int    `json:"epochs"`
+	FailedEpoch int    `json:"failed_epoch,omitempty"` // First epoch that did not check out
+	Problem     string `json:"problem,omitempty"`
+}
+
+// StateHashChain hashes the world's state at the end of every epoch into a chain, from
+// which run certificates are issued
+type StateHashChain struct {
+	mu        sync.Mutex
+	Primitive bool // The run started from the primitive populations
+	startTick int
+	links     []CertificateLink
+}
+
+// NewStateHashChain creates a chain for a run starting at tick 0
+func NewStateHashChain() *StateHashChain {
+	return &StateHashChain{}
+}
+
+// Update adds a link when the world reaches the end of an epoch
+func (c *StateHashChain) Update(w *World) {
+	if w.Tick%certificateEpochTicks != 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	previous := c.genesis(w)
+	if len(c.links) > 0 {
+		previous = c.links[len(c.links)-1].Hash
+	}
+	state := fingerprintWorld(w).digest()
+	c.links = append(c.links, CertificateLink{
+		Epoch: w.Tick / certificateEpochTicks,
+		Tick:  w.Tick,
+		State: state,
+		Hash:  certificateLinkHash(previous, w.Tick, state),
+	})
+}
+
+// Restart discards the chain so far and starts a new one at the given tick, as when the
+// world is reset or a save is loaded into it
+func (c *StateHashChain) Restart(tick int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.startTick = tick
+	c.links = nil
+}
+
+// Certificate issues a certificate covering the run so far
+func (c *StateHashChain) Certificate(w *World) *RunCertificate {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cert := &RunCertificate{
+		Seed:       w.Seed,
+		Primitive:  c.Primitive,
+		RNGStreams: w.RNG != nil,
+		Unordered:  !w.Deterministic && w.RNG == nil,
+		Config:     w.Config,
+		ConfigHash: certificateConfigHash(w.Config),
+		StartTick:  c.startTick,
+		EpochTicks: certificateEpochTicks,
+		Genesis:    c.genesis(w),
+		Links:      append([]CertificateLink{}, c.links...),
+	}
+	cert.Head = cert.Genesis
+	if len(cert.Links) > 0 {
+		cert.Head = cert.Links[len(cert.Links)-1].Hash
+	}
+	return cert
+}
+
+// genesis is the hash the chain starts from; the caller holds the lock
+func (c *StateHashChain) genesis(w *World) string {
+	return certificateGenesis(w.Seed, c.Primitive, certificateConfigHash(w.Config), c.startTick, certificateEpochTicks)
+}
+
+// VerifyRunCertificate checks that a certificate's hash chain is intact and, when replay
+// is set, re-simulates its seed and config to confirm every epoch's state in a world of
+// its own.
+func VerifyRunCertificate(cert *RunCertificate, replay bool) *CertificateVerification {
+	result := &CertificateVerification{Epochs: len(cert.Links)}
+	if cert.EpochTicks < 1 {
+		result.Problem = "certificate has no epoch length"
+		return result
+	}
+	if certificateConfigHash(cert.Config) != cert.ConfigHash {
+		result.Problem = "config does not match its hash"
+		return result
+	}
+	if certificateGenesis(cert.Seed, cert.Primitive, cert.ConfigHash, cert.StartTick, cert.EpochTicks) != cert.Genesis {
+		result.Problem = "genesis hash does not match the seed and config"
+		return result
+	}
+	previous, lastTick := cert.Genesis, cert.StartTick
+	for _, link := range cert.Links {
+		if link.Tick <= lastTick || link.Tick%cert.EpochTicks != 0 || link.Epoch != link.Tick/cert.EpochTicks {
+			result.FailedEpoch = link.Epoch
+			result.Problem = fmt.Sprintf("epoch %d is out of sequence", link.Epoch)
+			return result
+		}
+		if certificateLinkHash(previous, link.Tick, link.State) != link.Hash {
+			result.FailedEpoch = link.Epoch
+			result.Problem = fmt.Sprintf("hash chain broken at epoch %d", link.Epoch)
+			return result
+		}
+		previous, lastTick = link.Hash, link.Tick
+	}
+	if previous != cert.Head {
+		result.Problem = "head does not match the last link"
+		return result
+	}
+	result.Intact = true
+
+	if !replay {
+		return result
+	}
+	switch {
+	case cert.Seed == 0:
+		result.Problem = "run was not seeded, so it cannot be re-simulated"
+		return result
+	case cert.Unordered:
+		result.Problem = "run updated its creatures in parallel (pass --seed for a reproducible run), so it cannot be re-simulated"
+		return result
+	case cert.StartTick != 0:
+		result.Problem = fmt.Sprintf("run was resumed from a save at tick %d, so it cannot be re-simulated", cert.StartTick)
+		return result
+	}
+	result.Replayed = true
+	result.Reproduced = true
+	if len(cert.Links) == 0 {
+		return result
+	}
+	next := 0
+	config := DeterminismConfig{World: cert.Config, Primitive: cert.Primitive, Seed: cert.Seed, Ticks: lastTick, Streams: cert.RNGStreams}
+	runSeededSimulation(config, func(tick int, fingerprint worldFingerprint) bool {
+		if tick != cert.Links[next].Tick {
+			return true
+		}
+		if fingerprint.digest() != cert.Links[next].State {
+			result.Reproduced = false
+			result.FailedEpoch = cert.Links[next].Epoch
+			result.Problem = fmt.Sprintf("state at epoch %d (tick %d) differs from the re-simulation", result.FailedEpoch, tick)
+			return false
+		}
+		next++
+		return next < len(cert.Links)
+	})
+	return result
+}
+
+// Summary returns a human-readable description of the verification
+func (v *CertificateVerification) Summary() string {
+	switch {
+	case !v.Intact:
+		return "Tampered: " + v.Problem
+	case v.Reproduced:
+		return fmt.Sprintf("Verified: all %d epochs reproduced from the seed and config", v.Epochs)
+	case v.Replayed:
+		return "Modified: " + v.Problem
+	case v.Problem != "":
+		return fmt.Sprintf("Hash chain intact over %d epochs; %s", v.Epochs, v.Problem)
+	}
+	return fmt.Sprintf("Hash chain intact over %d epochs", v.Epochs)
+}
+
+// certificateConfigHash hashes a world config
+func certificateConfigHash(config WorldConfig) string {
+	data, _ := json.Marshal(config)
+	return sha256Hex(string(data))
+}
+
+// certificateGenesis hashes everything a run starts from
+func certificateGenesis(seed int64, primitive bool, configHash string, startTick, epochTicks int) string {
+	return sha256Hex(fmt.Sprintf("evosim-run|%d|%t|%s|%d|%d", seed, primitive, configHash, startTick, epochTicks))
+}
+
+// certificateLinkHash chains an epoch's state onto the previous link
+func certificateLinkHash(previous string, tick int, state string) string {
+	return sha256Hex(fmt.Sprintf("%s|%d|%s", previous, tick, state))
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// runCertificateCommand implements "evosim analyze certificate": verify a run certificate, bare
+// or inside a JSON export
+func runCertificateCommand(args []string) error {
+	fs := newCommandFlags("certificate")
+	noReplay := fs.Bool("no-replay", false, "Only check the hash chain; do not re-simulate the run")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := applyEnvironment(fs, commandEnvPrefix(fs.Name())); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return commandUsage("certificate")
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to read certificate: %v", err)
+	}
+	var export struct {
+		Certificate *RunCertificate `json:"certificate"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return fmt.Errorf("failed to parse certificate: %v", err)
+	}
+	cert := export.Certificate
+	if cert == nil {
+		cert = &RunCertificate{}
+		if err := json.Unmarshal(data, cert); err != nil {
+			return fmt.Errorf("failed to parse certificate: %v", err)
+		}
+	}
+
+	if !*noReplay {
+		fmt.Fprintf(os.Stderr, "Re-simulating seed %d for %d epochs...\n", cert.Seed, len(cert.Links))
+	}
+	result := VerifyRunCertificate(cert, !*noReplay)
+	fmt.Println(result.Summary())
+	if !result.Intact || (result.Replayed && !result.Reproduced) {
+		return fmt.Errorf("certificate did not verify")
+	}
+	return nil
+}
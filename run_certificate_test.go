@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newCertifiedTestRun simulates a seeded run the way the command line starts one
+func newCertifiedTestRun(seed int64, ticks int) *World {
+	world := newSteppingTestWorld(seed)
+	for i := 0; i < ticks; i++ {
+		world.Update()
+	}
+	return world
+}
+
+func TestRunCertificateVerifiesUnmodifiedRun(t *testing.T) {
+	world := newCertifiedTestRun(31, 2*certificateEpochTicks+10)
+	cert := world.HashChain.Certificate(world)
+	if len(cert.Links) != 2 || cert.Links[1].Tick != 2*certificateEpochTicks || cert.Head != cert.Links[1].Hash {
+		t.Fatalf("Expected two epochs chained, got %+v", cert.Links)
+	}
+
+	result := VerifyRunCertificate(cert, true)
+	if !result.Intact || !result.Replayed || !result.Reproduced {
+		t.Fatalf("Expected the run to verify, got %s", result.Summary())
+	}
+}
+
+func TestRunCertificateDetectsTampering(t *testing.T) {
+	world := newCertifiedTestRun(32, 2*certificateEpochTicks)
+	cert := world.HashChain.Certificate(world)
+
+	// Editing a recorded state breaks the chain
+	forged := *cert
+	forged.Links = append([]CertificateLink{}, cert.Links...)
+	forged.Links[0].State = "0000000000000000"
+	if result := VerifyRunCertificate(&forged, false); result.Intact || result.FailedEpoch != 1 {
+		t.Errorf("Expected a broken chain at epoch 1, got %s", result.Summary())
+	}
+
+	// So does claiming a different config
+	forged = *cert
+	forged.Config.PopulationSize++
+	if result := VerifyRunCertificate(&forged, false); result.Intact {
+		t.Error("Expected a changed config to be detected")
+	}
+
+	// A run changed mid-way keeps an intact chain that the re-simulation does not reproduce
+	modified := newSteppingTestWorld(32)
+	for i := 0; i < 2*certificateEpochTicks; i++ {
+		if i == certificateEpochTicks+5 {
+			modified.AllEntities[0].SetTrait("speed", 1.5)
+		}
+		modified.Update()
+	}
+	result := VerifyRunCertificate(modified.HashChain.Certificate(modified), true)
+	if !result.Intact || result.Reproduced || result.FailedEpoch != 2 {
+		t.Errorf("Expected the modification to show at epoch 2, got %+v", result)
+	}
+
+	// A run whose creatures updated in parallel has no order to re-simulate
+	modified.Deterministic = false
+	if result := VerifyRunCertificate(modified.HashChain.Certificate(modified), true); !result.Intact || result.Replayed {
+		t.Errorf("Expected an unordered run left unreplayed, got %+v", result)
+	}
+}
+
+func TestRunCertificateRestartsOnLoad(t *testing.T) {
+	world := newCertifiedTestRun(33, certificateEpochTicks)
+	state, err := NewStateManager(world).CurrentState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewStateManager(world).restoreState(state); err != nil {
+		t.Fatal(err)
+	}
+	cert := world.HashChain.Certificate(world)
+	if cert.StartTick != certificateEpochTicks || len(cert.Links) != 0 {
+		t.Fatalf("Expected a fresh chain from the loaded tick, got %+v", cert)
+	}
+	if result := VerifyRunCertificate(cert, true); !result.Intact || result.Replayed {
+		t.Errorf("Expected a resumed run to be intact but not replayable, got %s", result.Summary())
+	}
+}
+
+func TestExportsIncludeRunCertificate(t *testing.T) {
+	wi := NewWebInterface(newCertifiedTestRun(34, certificateEpochTicks))
+
+	rec := httptest.NewRecorder()
+	wi.handleExportEvents(rec, httptest.NewRequest(http.MethodGet, "/api/export/events", nil))
+	var export struct {
+		Certificate *RunCertificate `json:"certificate"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &export); err != nil || export.Certificate == nil || len(export.Certificate.Links) != 1 {
+		t.Fatalf("Expected the events export to carry the certificate, got %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleExportCertificate(rec, httptest.NewRequest(http.MethodGet, "/api/export/certificate", nil))
+	var cert RunCertificate
+	if err := json.Unmarshal(rec.Body.Bytes(), &cert); err != nil || cert.Head != export.Certificate.Head {
+		t.Errorf("Expected the same certificate on its own, got %s", rec.Body.String())
+	}
+}
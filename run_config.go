@@ -0,0 +1,390 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+)
+
+// RunConfig describes a run in a JSON file given with --config. Every section is optional:
+// settings left out keep their command-line defaults, and flags given explicitly override
+// the file.
+type RunConfig struct {
+	World       RunWorldConfig        `json:"world"`
+	Primitive   bool                  `json:"primitive"`            // Start from primitive life when no populations are listed
+	Populations []RunPopulationConfig `json:"populations"`          // Replace the built-in starting populations
+	Simulation  json.RawMessage       `json:"simulation,omitempty"` // Overrides on the simulation settings, e.g. biome tuning (see SimulationConfig)
+	Events      RunEventsConfig       `json:"events"`
+	Speed       RunSpeedConfig        `json:"speed"`
+}
+
+// RunWorldConfig sets the world's size and caps
+type RunWorldConfig struct {
+	Width          float64 `json:"width"`
+	Height         float64 `json:"height"`
+	GridWidth      int     `json:"grid_width"`
+	GridHeight     int     `json:"grid_height"`
+	PopulationSize int     `json:"population_size"` // Founders per population
+	Profile        string  `json:"profile"`         // Tuning profile (see FindTuningProfile)
+	FogOfWar       bool    `json:"fog_of_war"`
+	Geometry       string  `json:"geometry"`   // Which edges of the map join (see GeometryBox)
+	GridShape      string  `json:"grid_shape"` // Shape of the grid cells (see GridShapeHex)
+	PopulationCap  int     `json:"population_cap"`
+	RegionCap      int     `json:"region_cap"`
+}
+
+// RunPopulationConfig describes a starting population
+type RunPopulationConfig struct {
+	Name         string             `json:"name"`
+	Species      string             `json:"species"` // Defaults to the name
+	Traits       map[string]float64 `json:"traits"`
+	X            *float64           `json:"x"` // Defaults to the middle of the world
+	Y            *float64           `json:"y"`
+	Spread       float64            `json:"spread"`        // Defaults to 15
+	Color        string             `json:"color"`         // Defaults to white
+	MutationRate float64            `json:"mutation_rate"` // Defaults to 0.1
+	Selection    string             `json:"selection"`     // How parents are picked (see SelectionStrategyNames), defaults to tournament
+}
+
+// RunEventsConfig controls world events
+type RunEventsConfig struct {
+	RandomEvents *bool                 `json:"random_events"` // Whether random world events happen (default true)
+	Timetable    []ScheduledWorldEvent `json:"timetable"`
+}
+
+// ScheduledWorldEvent starts a world event by name at a given tick
+type ScheduledWorldEvent struct {
+	Tick     int    `json:"tick"`
+	Name     string `json:"name"`     // One of the world events, e.g. "Ice Age"
+	Duration int    `json:"duration"` // Ticks before timescaling, 0 for the event's usual duration
+}
+
+// RunSpeedConfig sets the speed defaults
+type RunSpeedConfig struct {
+	Multiplier float64 `json:"multiplier"` // Simulation speed, 0.1-16 (default 1)
+	Unlimited  bool    `json:"unlimited"`  // Tick the web simulation as fast as possible
+	MaxCPU     float64 `json:"max_cpu"`    // Percentage of CPU time the web simulation may use, 5-100
+}
+
+// Defaults for populations that leave settings out
+const (
+	runConfigDefaultSpread       = 15.0
+	runConfigDefaultColor        = "white"
+	runConfigDefaultMutationRate = 0.1
+)
+
+// LoadRunConfig reads and validates a run config file
+func LoadRunConfig(filename string) (*RunConfig, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %v", err)
+	}
+	config, err := ParseRunConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return config, nil
+}
+
+// ParseRunConfig decodes and validates a run config, refusing unknown settings so typos
+// don't go unnoticed
+func ParseRunConfig(data []byte) (*RunConfig, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var config RunConfig
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate checks the settings are usable
+func (c *RunConfig) Validate() error {
+	world := c.World
+	if world.Width < 0 || world.Height < 0 || world.GridWidth < 0 || world.GridHeight < 0 {
+		return fmt.Errorf("world dimensions must not be negative")
+	}
+	if world.PopulationSize < 0 || world.PopulationCap < 0 || world.RegionCap < 0 {
+		return fmt.Errorf("population size and caps must not be negative")
+	}
+	if world.Profile != "" {
+		if _, err := FindTuningProfile(world.Profile); err != nil {
+			return err
+		}
+	}
+	if err := ValidateGeometry(world.Geometry); err != nil {
+		return err
+	}
+	if err := ValidateGridShape(world.GridShape); err != nil {
+		return err
+	}
+
+	names := make(map[string]bool)
+	for i, population := range c.Populations {
+		if population.Name == "" {
+			return fmt.Errorf("population %d has no name", i+1)
+		}
+		if names[population.Name] {
+			return fmt.Errorf("population %q is listed twice", population.Name)
+		}
+		names[population.Name] = true
+		for trait, value := range population.Traits {
+			if math.IsNaN(value) || value < -1 || value > 1 {
+				return fmt.Errorf("population %q: trait %q must be between -1 and 1", population.Name, trait)
+			}
+		}
+		if population.Spread < 0 {
+			return fmt.Errorf("population %q: spread must not be negative", population.Name)
+		}
+		if population.MutationRate < 0 || population.MutationRate > 1 {
+			return fmt.Errorf("population %q: mutation rate must be between 0 and 1", population.Name)
+		}
+		if population.Selection != "" {
+			if _, err := NewSelectionStrategy(population.Selection); err != nil {
+				return fmt.Errorf("population %q: %v", population.Name, err)
+			}
+		}
+	}
+
+	for _, scheduled := range c.Events.Timetable {
+		if _, exists := worldEventDurations[scheduled.Name]; !exists {
+			return fmt.Errorf("unknown world event %q (known: %v)", scheduled.Name, sortedKeys(worldEventDurations))
+		}
+		if scheduled.Tick <= 0 || scheduled.Duration < 0 {
+			return fmt.Errorf("event %q: tick must be positive and duration not negative", scheduled.Name)
+		}
+	}
+
+	if c.Speed.Multiplier != 0 && (c.Speed.Multiplier < 0.1 || c.Speed.Multiplier > 16) {
+		return fmt.Errorf("speed multiplier must be between 0.1 and 16")
+	}
+	if c.Speed.MaxCPU != 0 && (c.Speed.MaxCPU < 5 || c.Speed.MaxCPU > 100) {
+		return fmt.Errorf("max CPU must be between 5 and 100 percent")
+	}
+
+	// Catch bad simulation overrides now rather than when the world is built
+	if len(c.Simulation) > 0 {
+		if _, err := c.SimulationConfig(WorldConfig{Width: 100, Height: 100, GridWidth: 40, GridHeight: 25}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyToFlags sets the command-line flags the config covers, leaving alone the ones given
+// explicitly
+func (c *RunConfig) ApplyToFlags(flags *flag.FlagSet) error {
+	explicit := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	values := make(map[string]string)
+	formatFloat := func(value float64) string { return strconv.FormatFloat(value, 'g', -1, 64) }
+	if c.World.Width > 0 {
+		values["width"] = formatFloat(c.World.Width)
+	}
+	if c.World.Height > 0 {
+		values["height"] = formatFloat(c.World.Height)
+	}
+	if c.World.GridWidth > 0 {
+		values["grid-width"] = strconv.Itoa(c.World.GridWidth)
+	}
+	if c.World.GridHeight > 0 {
+		values["grid-height"] = strconv.Itoa(c.World.GridHeight)
+	}
+	if c.World.PopulationSize > 0 {
+		values["pop-size"] = strconv.Itoa(c.World.PopulationSize)
+	}
+	if c.World.Profile != "" {
+		values["profile"] = c.World.Profile
+	}
+	if c.World.FogOfWar {
+		values["fog-of-war"] = "true"
+	}
+	if c.World.Geometry != "" {
+		values["geometry"] = c.World.Geometry
+	}
+	if c.World.GridShape != "" {
+		values["grid-shape"] = c.World.GridShape
+	}
+	if c.World.PopulationCap > 0 {
+		values["population-cap"] = strconv.Itoa(c.World.PopulationCap)
+	}
+	if c.World.RegionCap > 0 {
+		values["region-cap"] = strconv.Itoa(c.World.RegionCap)
+	}
+	if c.Primitive {
+		values["primitive"] = "true"
+	}
+	if c.Speed.Unlimited {
+		values["unlimited-speed"] = "true"
+	}
+	if c.Speed.MaxCPU > 0 {
+		values["max-cpu"] = formatFloat(c.Speed.MaxCPU)
+	}
+
+	for name, value := range values {
+		if explicit[name] || flags.Lookup(name) == nil {
+			continue
+		}
+		if err := flags.Set(name, value); err != nil {
+			return fmt.Errorf("invalid config value for --%s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// WorldConfig builds the world configuration the config describes on its own, without
+// command-line flags, taking the flags' defaults for whatever the world section leaves out
+func (c *RunConfig) WorldConfig() (WorldConfig, error) {
+	config := WorldConfig{
+		Width:          100,
+		Height:         100,
+		NumPopulations: 3,
+		PopulationSize: 20,
+		GridWidth:      40,
+		GridHeight:     25,
+		FogOfWar:       c.World.FogOfWar,
+		Profile:        "standard",
+		Geometry:       GeometryBox,
+		GridShape:      GridShapeSquare,
+	}
+	if c.World.Width > 0 {
+		config.Width = c.World.Width
+	}
+	if c.World.Height > 0 {
+		config.Height = c.World.Height
+	}
+	if c.World.GridWidth > 0 {
+		config.GridWidth = c.World.GridWidth
+	}
+	if c.World.GridHeight > 0 {
+		config.GridHeight = c.World.GridHeight
+	}
+	if c.World.PopulationSize > 0 {
+		config.PopulationSize = c.World.PopulationSize
+	}
+	if c.World.Profile != "" {
+		config.Profile = c.World.Profile
+	}
+	if c.World.Geometry != "" {
+		config.Geometry = c.World.Geometry
+	}
+	if c.World.GridShape != "" {
+		config.GridShape = c.World.GridShape
+	}
+	if err := c.ApplyToWorld(&config); err != nil {
+		return config, err
+	}
+	err := config.SetPopulationCaps(c.World.PopulationCap, c.World.RegionCap)
+	return config, err
+}
+
+// ApplyToWorld adds the config's simulation overrides and event settings to a world
+// configuration
+func (c *RunConfig) ApplyToWorld(config *WorldConfig) error {
+	if len(c.Simulation) > 0 {
+		simConfig, err := c.SimulationConfig(*config)
+		if err != nil {
+			return err
+		}
+		config.Simulation = simConfig
+	}
+	config.EventTimetable = c.Events.Timetable
+	config.RandomEventsOff = c.Events.RandomEvents != nil && !*c.Events.RandomEvents
+	return nil
+}
+
+// SimulationConfig builds the simulation settings for a world: the defaults with the world's
+// tuning profile, overlaid with the config's overrides. The world section decides the size.
+func (c *RunConfig) SimulationConfig(world WorldConfig) (*SimulationConfig, error) {
+	simConfig := DefaultSimulationConfig()
+	if world.Profile != "" {
+		if err := simConfig.ApplyTuningProfile(world.Profile); err != nil {
+			return nil, err
+		}
+	}
+	if len(c.Simulation) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(c.Simulation))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(simConfig); err != nil {
+			return nil, fmt.Errorf("invalid simulation settings: %v", err)
+		}
+	}
+	simConfig.World.Width = world.Width
+	simConfig.World.Height = world.Height
+	simConfig.World.GridWidth = world.GridWidth
+	simConfig.World.GridHeight = world.GridHeight
+	if err := simConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid simulation settings: %v", err)
+	}
+	return simConfig, nil
+}
+
+// SetPopulationCaps puts the soft population caps in a world configuration's simulation
+// settings, so run certificates and saves record them. A population cap of 0 keeps the
+// settings' own; with neither cap set the configuration is left as it is.
+func (config *WorldConfig) SetPopulationCaps(populationCap, regionCap int) error {
+	if populationCap == 0 && regionCap == 0 {
+		return nil
+	}
+	if config.Simulation == nil {
+		simConfig, err := (&RunConfig{}).SimulationConfig(*config)
+		if err != nil {
+			return err
+		}
+		config.Simulation = simConfig
+	}
+	if populationCap > 0 {
+		config.Simulation.Population.MaxPopulation = populationCap
+	}
+	config.Simulation.Population.RegionSoftCap = regionCap
+	return nil
+}
+
+// StartingPopulations converts the config's populations, filling in the defaults, for a
+// world of the given size
+func (c *RunConfig) StartingPopulations(width, height float64) []PopulationConfig {
+	populations := make([]PopulationConfig, 0, len(c.Populations))
+	for _, population := range c.Populations {
+		config := PopulationConfig{
+			Name:             population.Name,
+			Species:          population.Species,
+			BaseTraits:       make(map[string]float64, len(population.Traits)),
+			StartPos:         Position{X: width / 2, Y: height / 2},
+			Spread:           population.Spread,
+			Color:            population.Color,
+			BaseMutationRate: population.MutationRate,
+			Selection:        population.Selection,
+		}
+		for trait, value := range population.Traits {
+			config.BaseTraits[trait] = value
+		}
+		if config.Species == "" {
+			config.Species = population.Name
+		}
+		if population.X != nil {
+			config.StartPos.X = *population.X
+		}
+		if population.Y != nil {
+			config.StartPos.Y = *population.Y
+		}
+		if config.Spread == 0 {
+			config.Spread = runConfigDefaultSpread
+		}
+		if config.Color == "" {
+			config.Color = runConfigDefaultColor
+		}
+		if config.BaseMutationRate == 0 {
+			config.BaseMutationRate = runConfigDefaultMutationRate
+		}
+		populations = append(populations, config)
+	}
+	return populations
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"flag"
+	"strings"
+	"testing"
+)
+
+const testRunConfig = `{
+	"world": {"width": 80, "height": 60, "grid_width": 32, "population_size": 6, "profile": "fast-evolution"},
+	"populations": [
+		{"name": "Grazers", "traits": {"speed": 0.4}, "x": 20, "y": 30, "selection": "novelty"},
+		{"name": "Stalkers", "species": "predator", "color": "red", "mutation_rate": 0.2}
+	],
+	"simulation": {"biomes": {"energy_drain_multipliers": {"desert": 3.5}}},
+	"events": {"random_events": false, "timetable": [{"tick": 3, "name": "Ice Age", "duration": 10}]},
+	"speed": {"multiplier": 2, "max_cpu": 50}
+}`
+
+func TestParseRunConfig(t *testing.T) {
+	config, err := ParseRunConfig([]byte(testRunConfig))
+	if err != nil {
+		t.Fatalf("Failed to parse the config: %v", err)
+	}
+
+	populations := config.StartingPopulations(80, 60)
+	if len(populations) != 2 || populations[0].StartPos != (Position{X: 20, Y: 30}) || populations[0].Species != "Grazers" ||
+		populations[0].Selection != SelectionNovelty {
+		t.Fatalf("Expected the populations with their positions, got %+v", populations)
+	}
+	stalkers := populations[1]
+	if stalkers.StartPos != (Position{X: 40, Y: 30}) || stalkers.Spread != runConfigDefaultSpread || stalkers.BaseMutationRate != 0.2 || stalkers.Color != "red" {
+		t.Errorf("Expected defaults filled in around the given settings, got %+v", stalkers)
+	}
+
+	invalid := map[string]string{
+		"unknown setting":   `{"wrold": {}}`,
+		"negative size":     `{"world": {"width": -5}}`,
+		"unknown profile":   `{"world": {"profile": "glacial"}}`,
+		"unnamed":           `{"populations": [{"traits": {}}]}`,
+		"duplicate":         `{"populations": [{"name": "A"}, {"name": "A"}]}`,
+		"trait range":       `{"populations": [{"name": "A", "traits": {"speed": 3}}]}`,
+		"selection":         `{"populations": [{"name": "A", "selection": "lottery"}]}`,
+		"unknown event":     `{"events": {"timetable": [{"tick": 5, "name": "Plague"}]}}`,
+		"event tick":        `{"events": {"timetable": [{"tick": 0, "name": "Ice Age"}]}}`,
+		"speed":             `{"speed": {"multiplier": 40}}`,
+		"cpu":               `{"speed": {"max_cpu": 1}}`,
+		"simulation field":  `{"simulation": {"energy": {"drain": 1}}}`,
+		"simulation values": `{"simulation": {"time": {"ticks_per_day": 0}}}`,
+	}
+	for name, data := range invalid {
+		if _, err := ParseRunConfig([]byte(data)); err == nil {
+			t.Errorf("Expected the %s config refused", name)
+		}
+	}
+}
+
+func TestRunConfigFlagsOverrideTheFile(t *testing.T) {
+	config, err := ParseRunConfig([]byte(testRunConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	width := flags.Float64("width", 100, "")
+	height := flags.Float64("height", 100, "")
+	gridWidth := flags.Int("grid-width", 40, "")
+	maxCPU := flags.Float64("max-cpu", 100, "")
+	if err := flags.Parse([]string{"--height", "90"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.ApplyToFlags(flags); err != nil {
+		t.Fatalf("Failed to apply the config: %v", err)
+	}
+	if *width != 80 || *gridWidth != 32 || *maxCPU != 50 {
+		t.Errorf("Expected the config's values for the flags not given, got %v %v %v", *width, *gridWidth, *maxCPU)
+	}
+	if *height != 90 {
+		t.Errorf("Expected the explicit --height kept, got %v", *height)
+	}
+}
+
+func TestRunConfigShapesTheWorld(t *testing.T) {
+	config, err := ParseRunConfig([]byte(testRunConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	worldConfig := WorldConfig{Width: 80, Height: 60, PopulationSize: 6, GridWidth: 32, GridHeight: 25, Profile: "fast-evolution"}
+	if err := config.ApplyToWorld(&worldConfig); err != nil {
+		t.Fatalf("Failed to apply the config: %v", err)
+	}
+
+	world := NewWorld(worldConfig)
+	if world.SimConfig.Biomes.EnergyDrainMultipliers["desert"] != 3.5 || world.SimConfig.World.GridWidth != 32 {
+		t.Errorf("Expected the simulation overrides in the world's settings")
+	}
+	profile, _ := FindTuningProfile("fast-evolution")
+	if world.SimConfig.Timescales != profile.Timescales {
+		t.Errorf("Expected the overrides laid over the tuning profile")
+	}
+	if !world.RandomEventsOff {
+		t.Errorf("Expected random events switched off")
+	}
+	world.SetSpeedMultiplier(2)
+	if world.SimConfig.Biomes.EnergyDrainMultipliers["desert"] != 3.5 {
+		t.Errorf("Expected the overrides to survive a speed change")
+	}
+
+	for _, population := range config.StartingPopulations(worldConfig.Width, worldConfig.Height) {
+		world.AddPopulation(population)
+	}
+	for i := 0; i < 3; i++ {
+		world.Update()
+	}
+	started := false
+	for _, event := range world.Events {
+		if strings.Contains(event.Name, "Ice Age") {
+			started = event.Duration <= world.scaledEventDuration(10)
+		}
+	}
+	if !started {
+		t.Errorf("Expected the timetabled Ice Age to start at tick 3")
+	}
+}
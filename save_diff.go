@@ -0,0 +1,325 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// PopulationDiff compares the size of one species between two states
+type PopulationDiff struct {
+	CountA int `json:"count_a"`
+	CountB int `json:"count_b"`
+	Delta  int `json:"delta"`
+}
+
+// TraitDistributionDiff compares one trait's distribution within a species
+type TraitDistributionDiff struct {
+	MeanA     float64 `json:"mean_a"`
+	MeanB     float64 `json:"mean_b"`
+	StdDevA   float64 `json:"std_dev_a"`
+	StdDevB   float64 `json:"std_dev_b"`
+	MeanDelta float64 `json:"mean_delta"`
+}
+
+// GeographyDiff compares biome layouts between two states
+type GeographyDiff struct {
+	DimensionsMatch bool           `json:"dimensions_match"`
+	CellsCompared   int            `json:"cells_compared"`
+	CellsChanged    int            `json:"cells_changed"`
+	BiomeCountsA    map[string]int `json:"biome_counts_a"`
+	BiomeCountsB    map[string]int `json:"biome_counts_b"`
+}
+
+// TechDiff compares a tribe's technology between two states
+type TechDiff struct {
+	TribeID int    `json:"tribe_id"`
+	Name    string `json:"name"`
+	LevelA  int    `json:"level_a"` // -1 when the tribe is absent
+	LevelB  int    `json:"level_b"`
+}
+
+// SaveDiff reports the differences between two simulation states
+type SaveDiff struct {
+	TickA       int                                         `json:"tick_a"`
+	TickB       int                                         `json:"tick_b"`
+	PlantCountA int                                         `json:"plant_count_a"`
+	PlantCountB int                                         `json:"plant_count_b"`
+	Populations map[string]PopulationDiff                   `json:"populations"`
+	Traits      map[string]map[string]TraitDistributionDiff `json:"traits"` // Species -> trait -> distribution diff
+	Geography   GeographyDiff                               `json:"geography"`
+	Tech        []TechDiff                                  `json:"tech"`
+}
+
+// traitDiffThreshold is the mean change below which a trait is not reported in summaries
+const traitDiffThreshold = 0.05
+
+// DiffStates compares two simulation states
+func DiffStates(a, b *SimulationState) *SaveDiff {
+	diff := &SaveDiff{
+		TickA:       a.Tick,
+		TickB:       b.Tick,
+		PlantCountA: countLivingPlantStates(a.Plants),
+		PlantCountB: countLivingPlantStates(b.Plants),
+		Populations: make(map[string]PopulationDiff),
+		Traits:      make(map[string]map[string]TraitDistributionDiff),
+		Geography:   diffGeography(a.Biomes, b.Biomes),
+		Tech:        diffTech(a.Tribes, b.Tribes),
+	}
+
+	speciesA := groupEntityStates(a.Entities)
+	speciesB := groupEntityStates(b.Entities)
+	for species := range mergeKeys(speciesA, speciesB) {
+		countA, countB := len(speciesA[species]), len(speciesB[species])
+		diff.Populations[species] = PopulationDiff{CountA: countA, CountB: countB, Delta: countB - countA}
+
+		traitNames := make(map[string]bool)
+		for _, entity := range append(speciesA[species], speciesB[species]...) {
+			for name := range entity.Traits {
+				traitNames[name] = true
+			}
+		}
+
+		traits := make(map[string]TraitDistributionDiff)
+		for name := range traitNames {
+			meanA, stdA := traitStats(speciesA[species], name)
+			meanB, stdB := traitStats(speciesB[species], name)
+			traits[name] = TraitDistributionDiff{
+				MeanA: meanA, MeanB: meanB,
+				StdDevA: stdA, StdDevB: stdB,
+				MeanDelta: meanB - meanA,
+			}
+		}
+		diff.Traits[species] = traits
+	}
+
+	return diff
+}
+
+// groupEntityStates groups entity states by species
+func groupEntityStates(entities []*EntityState) map[string][]*EntityState {
+	groups := make(map[string][]*EntityState)
+	for _, entity := range entities {
+		groups[entity.Species] = append(groups[entity.Species], entity)
+	}
+	return groups
+}
+
+// mergeKeys returns the union of keys of two species groupings
+func mergeKeys(a, b map[string][]*EntityState) map[string]bool {
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	return keys
+}
+
+// traitStats returns the mean and standard deviation of a trait across entities
+func traitStats(entities []*EntityState, trait string) (float64, float64) {
+	values := make([]float64, 0, len(entities))
+	for _, entity := range entities {
+		if value, exists := entity.Traits[trait]; exists {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+// countLivingPlantStates counts saved plants that are alive
+func countLivingPlantStates(plants []*PlantState) int {
+	count := 0
+	for _, plant := range plants {
+		if plant.IsAlive {
+			count++
+		}
+	}
+	return count
+}
+
+// diffGeography compares biome grids cell by cell
+func diffGeography(a, b [][]BiomeType) GeographyDiff {
+	diff := GeographyDiff{
+		DimensionsMatch: len(a) == len(b),
+		BiomeCountsA:    countBiomes(a),
+		BiomeCountsB:    countBiomes(b),
+	}
+
+	for y := 0; y < len(a) && y < len(b); y++ {
+		if len(a[y]) != len(b[y]) {
+			diff.DimensionsMatch = false
+		}
+		for x := 0; x < len(a[y]) && x < len(b[y]); x++ {
+			diff.CellsCompared++
+			if a[y][x] != b[y][x] {
+				diff.CellsChanged++
+			}
+		}
+	}
+
+	return diff
+}
+
+// countBiomes tallies cells per biome name
+func countBiomes(grid [][]BiomeType) map[string]int {
+	counts := make(map[string]int)
+	for _, row := range grid {
+		for _, biome := range row {
+			counts[biomeName(biome)]++
+		}
+	}
+	return counts
+}
+
+// diffTech pairs tribes by ID and reports tech levels on both sides
+func diffTech(a, b []*TribeState) []TechDiff {
+	byID := make(map[int]*TechDiff)
+	for _, tribe := range a {
+		byID[tribe.ID] = &TechDiff{TribeID: tribe.ID, Name: tribe.Name, LevelA: tribe.TechLevel, LevelB: -1}
+	}
+	for _, tribe := range b {
+		if existing, ok := byID[tribe.ID]; ok {
+			existing.LevelB = tribe.TechLevel
+			continue
+		}
+		byID[tribe.ID] = &TechDiff{TribeID: tribe.ID, Name: tribe.Name, LevelA: -1, LevelB: tribe.TechLevel}
+	}
+
+	diffs := make([]TechDiff, 0, len(byID))
+	for _, d := range byID {
+		diffs = append(diffs, *d)
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].TribeID < diffs[j].TribeID })
+	return diffs
+}
+
+// HasDifferences reports whether the two states differ in any compared aspect
+func (d *SaveDiff) HasDifferences() bool {
+	if d.PlantCountA != d.PlantCountB || d.Geography.CellsChanged > 0 || !d.Geography.DimensionsMatch {
+		return true
+	}
+	for _, pop := range d.Populations {
+		if pop.Delta != 0 {
+			return true
+		}
+	}
+	for _, traits := range d.Traits {
+		for _, trait := range traits {
+			if trait.MeanDelta != 0 || trait.StdDevA != trait.StdDevB {
+				return true
+			}
+		}
+	}
+	for _, tech := range d.Tech {
+		if tech.LevelA != tech.LevelB {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary renders the diff as a human-readable report
+func (d *SaveDiff) Summary() string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("Tick: %d -> %d\n", d.TickA, d.TickB))
+	b.WriteString(fmt.Sprintf("Plants: %d -> %d (%+d)\n", d.PlantCountA, d.PlantCountB, d.PlantCountB-d.PlantCountA))
+
+	species := make([]string, 0, len(d.Populations))
+	for name := range d.Populations {
+		species = append(species, name)
+	}
+	sort.Strings(species)
+
+	b.WriteString("\nPopulations:\n")
+	for _, name := range species {
+		pop := d.Populations[name]
+		b.WriteString(fmt.Sprintf("  %-20s %5d -> %5d (%+d)\n", name, pop.CountA, pop.CountB, pop.Delta))
+	}
+
+	b.WriteString(fmt.Sprintf("\nTrait shifts (|mean delta| >= %.2f):\n", traitDiffThreshold))
+	shifts := 0
+	for _, name := range species {
+		traitNames := make([]string, 0, len(d.Traits[name]))
+		for trait := range d.Traits[name] {
+			traitNames = append(traitNames, trait)
+		}
+		sort.Strings(traitNames)
+		for _, trait := range traitNames {
+			td := d.Traits[name][trait]
+			if math.Abs(td.MeanDelta) < traitDiffThreshold {
+				continue
+			}
+			shifts++
+			b.WriteString(fmt.Sprintf("  %s.%s: %.3f -> %.3f (%+.3f)\n", name, trait, td.MeanA, td.MeanB, td.MeanDelta))
+		}
+	}
+	if shifts == 0 {
+		b.WriteString("  none\n")
+	}
+
+	b.WriteString("\nGeography:\n")
+	if !d.Geography.DimensionsMatch {
+		b.WriteString("  grid dimensions differ\n")
+	}
+	b.WriteString(fmt.Sprintf("  %d of %d cells changed biome\n", d.Geography.CellsChanged, d.Geography.CellsCompared))
+
+	b.WriteString("\nTech levels:\n")
+	if len(d.Tech) == 0 {
+		b.WriteString("  no tribes recorded\n")
+	}
+	for _, tech := range d.Tech {
+		b.WriteString(fmt.Sprintf("  %s (#%d): %s -> %s\n", tech.Name, tech.TribeID, formatTechLevel(tech.LevelA), formatTechLevel(tech.LevelB)))
+	}
+
+	return b.String()
+}
+
+// formatTechLevel renders a tech level, marking absent tribes
+func formatTechLevel(level int) string {
+	if level < 0 {
+		return "absent"
+	}
+	return fmt.Sprintf("%d", level)
+}
+
+// runDiffCommand implements the "evosim analyze diff <a.json> <b.json>" subcommand
+func runDiffCommand(args []string) error {
+	fs := newCommandFlags("diff")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return commandUsage("diff")
+	}
+	args = fs.Args()
+
+	a, err := LoadStateFile(args[0])
+	if err != nil {
+		return fmt.Errorf("%s: %v", args[0], err)
+	}
+	b, err := LoadStateFile(args[1])
+	if err != nil {
+		return fmt.Errorf("%s: %v", args[1], err)
+	}
+
+	fmt.Print(DiffStates(a, b).Summary())
+	return nil
+}
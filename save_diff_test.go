@@ -0,0 +1,110 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newSaveDiffTestState(tick int) *SimulationState {
+	return &SimulationState{
+		Tick: tick,
+		Entities: []*EntityState{
+			{ID: 1, Species: "herbivore", Traits: map[string]float64{"speed": 0.2}},
+			{ID: 2, Species: "herbivore", Traits: map[string]float64{"speed": 0.4}},
+			{ID: 3, Species: "predator", Traits: map[string]float64{"speed": 0.8}},
+		},
+		Plants: []*PlantState{{ID: 1, IsAlive: true}},
+		Biomes: [][]BiomeType{
+			{BiomePlains, BiomeForest},
+			{BiomeWater, BiomeDesert},
+		},
+		Tribes: []*TribeState{{ID: 1, Name: "River Folk", TechLevel: 1}},
+	}
+}
+
+func TestDiffIdenticalStates(t *testing.T) {
+	diff := DiffStates(newSaveDiffTestState(10), newSaveDiffTestState(10))
+	if diff.HasDifferences() {
+		t.Errorf("Expected identical states to have no differences:\n%s", diff.Summary())
+	}
+}
+
+func TestDiffReportsChanges(t *testing.T) {
+	a := newSaveDiffTestState(10)
+	b := newSaveDiffTestState(50)
+
+	b.Entities = append(b.Entities, &EntityState{ID: 4, Species: "herbivore", Traits: map[string]float64{"speed": 0.9}})
+	b.Entities = append(b.Entities, &EntityState{ID: 5, Species: "omnivore", Traits: map[string]float64{"speed": 0.1}})
+	b.Biomes[0][0] = BiomeDesert
+	b.Tribes[0].TechLevel = 3
+
+	diff := DiffStates(a, b)
+	if !diff.HasDifferences() {
+		t.Fatal("Expected differences to be detected")
+	}
+
+	if pop := diff.Populations["herbivore"]; pop.CountA != 2 || pop.CountB != 3 || pop.Delta != 1 {
+		t.Errorf("Unexpected herbivore population diff %+v", pop)
+	}
+	if pop := diff.Populations["omnivore"]; pop.CountA != 0 || pop.CountB != 1 {
+		t.Errorf("Expected new omnivore species in diff, got %+v", pop)
+	}
+
+	speed := diff.Traits["herbivore"]["speed"]
+	if speed.MeanDelta < 0.19 || speed.MeanDelta > 0.21 {
+		t.Errorf("Expected herbivore speed mean delta ~0.2, got %.3f", speed.MeanDelta)
+	}
+
+	if diff.Geography.CellsChanged != 1 || diff.Geography.CellsCompared != 4 {
+		t.Errorf("Expected 1 of 4 cells changed, got %d of %d", diff.Geography.CellsChanged, diff.Geography.CellsCompared)
+	}
+
+	if len(diff.Tech) != 1 || diff.Tech[0].LevelA != 1 || diff.Tech[0].LevelB != 3 {
+		t.Errorf("Unexpected tech diff %+v", diff.Tech)
+	}
+
+	summary := diff.Summary()
+	if !strings.Contains(summary, "herbivore.speed") || !strings.Contains(summary, "River Folk") {
+		t.Errorf("Expected summary to mention trait shift and tribe:\n%s", summary)
+	}
+}
+
+func TestDiffSaveFiles(t *testing.T) {
+	world := NewWorld(WorldConfig{Width: 30, Height: 30, GridWidth: 10, GridHeight: 10, PopulationSize: 5})
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.json")
+	second := filepath.Join(dir, "second.json")
+
+	sm := NewStateManager(world)
+	if err := sm.SaveToFile(first); err != nil {
+		t.Fatalf("Failed to save first state: %v", err)
+	}
+	if world.Grid[0][0].Biome == BiomeRadiation {
+		world.Grid[0][0].Biome = BiomePlains
+	} else {
+		world.Grid[0][0].Biome = BiomeRadiation
+	}
+	world.Tick = 25
+	if err := sm.SaveToFile(second); err != nil {
+		t.Fatalf("Failed to save second state: %v", err)
+	}
+
+	if err := runDiffCommand([]string{first, second}); err != nil {
+		t.Fatalf("Diff command failed: %v", err)
+	}
+
+	a, _ := LoadStateFile(first)
+	b, _ := LoadStateFile(second)
+	diff := DiffStates(a, b)
+	if diff.TickB != 25 || diff.Geography.CellsChanged != 1 {
+		t.Errorf("Expected tick and one biome change to be reported, got tick %d and %+v", diff.TickB, diff.Geography)
+	}
+
+	if err := runDiffCommand([]string{first}); err == nil {
+		t.Error("Expected usage error with a single argument")
+	}
+	if err := runDiffCommand([]string{first, filepath.Join(dir, "missing.json")}); err == nil {
+		t.Error("Expected error for missing save file")
+	}
+}
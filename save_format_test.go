@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newLargeSaveWorld is a world big enough for the save format's size to matter
+func newLargeSaveWorld(tb testing.TB) *World {
+	config := steppingTestConfig(135)
+	config.GridWidth, config.GridHeight = 120, 120
+	config.Width, config.Height = 240, 240
+	config.PopulationSize = 60
+	world := newPopulatedTestWorld(config, false)
+	for i := 0; i < 5; i++ {
+		world.Update()
+	}
+	return world
+}
+
+func TestBinarySaveRoundTrip(t *testing.T) {
+	world := newSteppingTestWorld(136)
+	if _, err := world.Step(10); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+
+	// The extension picks the format, and loading tells the formats apart by their contents
+	binary := filepath.Join(dir, "world"+BinarySaveExtension)
+	if err := NewStateManager(world).SaveToFile(binary); err != nil {
+		t.Fatal(err)
+	}
+	forced := NewStateManager(world)
+	forced.Format = SaveFormatBinary
+	misnamed := filepath.Join(dir, "world.json")
+	if err := forced.SaveToFile(misnamed); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{binary, misnamed} {
+		data, _ := os.ReadFile(file)
+		if !bytes.HasPrefix(data, binarySaveMagic) {
+			t.Fatalf("Expected %s saved in binary", filepath.Base(file))
+		}
+		loaded := newSteppingTestWorld(136)
+		if err := NewStateManager(loaded).LoadFromFile(file); err != nil {
+			t.Fatalf("Expected the binary save loaded, got %v", err)
+		}
+		if loaded.Tick != world.Tick || len(loaded.AllEntities) != len(world.AllEntities) || len(loaded.AllPlants) != len(world.AllPlants) {
+			t.Errorf("Expected the world restored from binary at tick %d, got tick %d", world.Tick, loaded.Tick)
+		}
+	}
+	if state, err := LoadStateFile(binary); err != nil || state.Seed != world.Seed {
+		t.Errorf("Expected the binary save read without a world, got %v", err)
+	}
+
+	// migrate converts between the formats
+	converted := filepath.Join(dir, "converted.json")
+	if err := runMigrateCommand([]string{"--format", SaveFormatJSON, "--out", converted, binary}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(converted); saveFormatOf(data) != SaveFormatJSON {
+		t.Error("Expected the binary save converted to JSON")
+	}
+	if _, err := ResolveSaveFormat("world.json", "protobuf"); err == nil {
+		t.Error("Expected an unknown save format refused")
+	}
+}
+
+func TestBinarySaveRefusesOtherSchemas(t *testing.T) {
+	state, err := NewStateManager(newSteppingTestWorld(137)).CurrentState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.SchemaVersion = StateSchemaVersion - 1
+	data, err := EncodeState(state, SaveFormatBinary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := DecodeState(data); err == nil || !strings.Contains(err.Error(), "schema version") {
+		t.Errorf("Expected a binary save of another schema refused, got %v", err)
+	}
+	if _, _, err := DecodeState(data[:len(data)/2]); err == nil {
+		t.Error("Expected a truncated binary save refused")
+	}
+}
+
+func TestBinarySaveIsSmallerAndFaster(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a large world")
+	}
+	state, err := NewStateManager(newLargeSaveWorld(t)).CurrentState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonData, _ := EncodeState(state, SaveFormatJSON)
+	binaryData, _ := EncodeState(state, SaveFormatBinary)
+	if ratio := float64(len(jsonData)) / float64(len(binaryData)); ratio < 10 {
+		t.Errorf("Expected binary saves at least 10x smaller, got %d vs %d bytes (%.1fx)", len(binaryData), len(jsonData), ratio)
+	}
+
+	jsonLoad := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, _ = DecodeState(jsonData)
+		}
+	})
+	binaryLoad := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, _ = DecodeState(binaryData)
+		}
+	})
+	if binaryLoad.NsPerOp() >= jsonLoad.NsPerOp() {
+		t.Errorf("Expected binary saves faster to load, got %v vs %v per load", binaryLoad.NsPerOp(), jsonLoad.NsPerOp())
+	}
+}
+
+func TestHeadlessBinarySnapshots(t *testing.T) {
+	dir := t.TempDir()
+	summary, err := RunHeadless(context.Background(), newSteppingTestWorld(138), HeadlessConfig{MaxTicks: 4, SnapshotInterval: 2, SnapshotDir: dir, SaveFormat: SaveFormatBinary})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary.Snapshots) != 2 || !strings.HasSuffix(summary.Snapshots[0], BinarySaveExtension) {
+		t.Fatalf("Expected binary snapshots, got %v", summary.Snapshots)
+	}
+	if _, err := LoadStateFile(summary.Snapshots[1]); err != nil {
+		t.Errorf("Expected the binary snapshot loaded, got %v", err)
+	}
+}
+
+func benchmarkSave(b *testing.B, format string) {
+	state, err := NewStateManager(newLargeSaveWorld(b)).CurrentState()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data, err := EncodeState(state, format)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.SetBytes(int64(len(data)))
+	}
+}
+
+func benchmarkLoad(b *testing.B, format string) {
+	state, err := NewStateManager(newLargeSaveWorld(b)).CurrentState()
+	if err != nil {
+		b.Fatal(err)
+	}
+	data, err := EncodeState(state, format)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := DecodeState(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(len(data)), "file-bytes")
+}
+
+func BenchmarkSaveJSON(b *testing.B)   { benchmarkSave(b, SaveFormatJSON) }
+func BenchmarkSaveBinary(b *testing.B) { benchmarkSave(b, SaveFormatBinary) }
+func BenchmarkLoadJSON(b *testing.B)   { benchmarkLoad(b, SaveFormatJSON) }
+func BenchmarkLoadBinary(b *testing.B) { benchmarkLoad(b, SaveFormatBinary) }
@@ -0,0 +1,561 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// Save issue severities
+const (
+	SaveIssueError   = "error"
+	SaveIssueWarning = "warning"
+)
+
+// SaveIssue describes a single problem found in a save file
+type SaveIssue struct {
+	Severity string `json:"severity"`
+	Kind     string `json:"kind"` // e.g. "duplicate_id", "dangling_reference", "invalid_number"
+	Message  string `json:"message"`
+	Repaired bool   `json:"repaired"`
+}
+
+// SaveValidationReport collects the issues found while validating a save
+type SaveValidationReport struct {
+	Issues    []SaveIssue `json:"issues"`
+	Truncated bool        `json:"truncated"` // The JSON was cut off and had to be salvaged
+	Repaired  bool        `json:"repaired"`  // Repairs were applied to the state
+}
+
+// addIssue records an issue, marking it repaired when repairs are enabled
+func (r *SaveValidationReport) addIssue(severity, kind string, repair bool, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, SaveIssue{
+		Severity: severity,
+		Kind:     kind,
+		Message:  fmt.Sprintf(format, args...),
+		Repaired: repair,
+	})
+	if repair {
+		r.Repaired = true
+	}
+}
+
+// IsValid reports whether no issues were found
+func (r *SaveValidationReport) IsValid() bool {
+	return len(r.Issues) == 0 && !r.Truncated
+}
+
+// UnrepairedCount returns the number of issues that were not repaired
+func (r *SaveValidationReport) UnrepairedCount() int {
+	count := 0
+	for _, issue := range r.Issues {
+		if !issue.Repaired {
+			count++
+		}
+	}
+	return count
+}
+
+// Summary renders the report as human-readable text
+func (r *SaveValidationReport) Summary() string {
+	if r.IsValid() {
+		return "Save file is valid\n"
+	}
+
+	var b strings.Builder
+	if r.Truncated {
+		b.WriteString("Save file was truncated; recovered the complete records before the cut\n")
+	}
+	b.WriteString(fmt.Sprintf("%d issue(s) found, %d unrepaired\n", len(r.Issues), r.UnrepairedCount()))
+	for _, issue := range r.Issues {
+		status := ""
+		if issue.Repaired {
+			status = " [repaired]"
+		}
+		b.WriteString(fmt.Sprintf("  %-7s %-20s %s%s\n", issue.Severity, issue.Kind, issue.Message, status))
+	}
+	return b.String()
+}
+
+// isInvalidNumber reports whether a float is NaN or infinite
+func isInvalidNumber(v float64) bool {
+	return math.IsNaN(v) || math.IsInf(v, 0)
+}
+
+// ValidateState checks a simulation state for referential integrity, invalid values and
+// traits the trait registry does not allow. When repair is true, problems are fixed in
+// place on a best-effort basis.
+func ValidateState(state *SimulationState, repair bool) *SaveValidationReport {
+	report := &SaveValidationReport{Issues: make([]SaveIssue, 0)}
+	traits := NewTraitRegistry()
+	if err := traits.RegisterCustomTraits(state.Config.CustomTraits); err != nil {
+		report.addIssue(SaveIssueError, "invalid_custom_trait", false, "%v", err)
+	}
+
+	validateConfig(state, report, repair)
+	validateBiomeGrid(state, report, repair)
+	plantIDs := validatePlants(state, traits, report, repair)
+	validateEntities(state, traits, report, repair)
+	validateNetwork(state, plantIDs, report, repair)
+	validateSpecies(state, traits, report, repair)
+
+	return report
+}
+
+// validateConfig checks world dimensions, inferring them from the biome grid when possible
+func validateConfig(state *SimulationState, report *SaveValidationReport, repair bool) {
+	if state.Config.GridHeight <= 0 || state.Config.GridWidth <= 0 {
+		canInfer := len(state.Biomes) > 0 && len(state.Biomes[0]) > 0
+		report.addIssue(SaveIssueError, "invalid_config", repair && canInfer,
+			"grid dimensions %dx%d are invalid", state.Config.GridWidth, state.Config.GridHeight)
+		if repair && canInfer {
+			state.Config.GridHeight = len(state.Biomes)
+			state.Config.GridWidth = len(state.Biomes[0])
+		}
+	}
+	if state.Config.Width <= 0 || state.Config.Height <= 0 || isInvalidNumber(state.Config.Width) || isInvalidNumber(state.Config.Height) {
+		report.addIssue(SaveIssueError, "invalid_config", repair,
+			"world dimensions %.1fx%.1f are invalid", state.Config.Width, state.Config.Height)
+		if repair {
+			state.Config.Width = 100
+			state.Config.Height = 100
+		}
+	}
+	if state.Tick < 0 {
+		report.addIssue(SaveIssueWarning, "invalid_tick", repair, "tick %d is negative", state.Tick)
+		if repair {
+			state.Tick = 0
+		}
+	}
+}
+
+// validateBiomeGrid checks the biome grid matches the configured dimensions
+func validateBiomeGrid(state *SimulationState, report *SaveValidationReport, repair bool) {
+	height, width := state.Config.GridHeight, state.Config.GridWidth
+	if height <= 0 || width <= 0 {
+		return
+	}
+
+	mismatched := len(state.Biomes) != height
+	for _, row := range state.Biomes {
+		if len(row) != width {
+			mismatched = true
+			break
+		}
+	}
+	if !mismatched {
+		return
+	}
+
+	report.addIssue(SaveIssueWarning, "partial_biome_grid", repair,
+		"biome grid does not match %dx%d configuration; missing cells default to plains", width, height)
+	if !repair {
+		return
+	}
+
+	grid := make([][]BiomeType, height)
+	for y := range grid {
+		grid[y] = make([]BiomeType, width)
+		for x := range grid[y] {
+			if y < len(state.Biomes) && x < len(state.Biomes[y]) {
+				grid[y][x] = state.Biomes[y][x]
+			} else {
+				grid[y][x] = BiomePlains
+			}
+		}
+	}
+	state.Biomes = grid
+}
+
+// clampPosition reports whether a position is invalid and returns a corrected copy
+func clampPosition(pos Position, config WorldConfig) (Position, bool) {
+	fixed := pos
+	if isInvalidNumber(fixed.X) {
+		fixed.X = config.Width / 2
+	}
+	if isInvalidNumber(fixed.Y) {
+		fixed.Y = config.Height / 2
+	}
+	fixed.X = math.Max(0, math.Min(config.Width, fixed.X))
+	fixed.Y = math.Max(0, math.Min(config.Height, fixed.Y))
+	return fixed, fixed != pos
+}
+
+// repairTraitMap reports trait values that are NaN or infinite, resetting them to zero when repairing
+func repairTraitMap(traits map[string]float64, owner string, report *SaveValidationReport, repair bool) {
+	for name, value := range traits {
+		if isInvalidNumber(value) {
+			report.addIssue(SaveIssueError, "invalid_number", repair, "%s trait %s is %v", owner, name, value)
+			if repair {
+				traits[name] = 0
+			}
+		}
+	}
+}
+
+// validateEntities checks entity IDs, traits, positions, and attached genetic records
+func validateEntities(state *SimulationState, traits *TraitRegistry, report *SaveValidationReport, repair bool) {
+	seen := make(map[int]bool)
+	maxID := -1
+	kept := make([]*EntityState, 0, len(state.Entities))
+
+	for i, entity := range state.Entities {
+		if entity == nil {
+			report.addIssue(SaveIssueError, "missing_record", repair, "entity record %d is empty", i)
+			if !repair {
+				kept = append(kept, entity)
+			}
+			continue
+		}
+		owner := fmt.Sprintf("entity %d", entity.ID)
+
+		if seen[entity.ID] {
+			report.addIssue(SaveIssueError, "duplicate_id", repair, "%s appears more than once", owner)
+			if repair {
+				continue
+			}
+		}
+		seen[entity.ID] = true
+		if entity.ID > maxID {
+			maxID = entity.ID
+		}
+
+		if entity.Species == "" {
+			report.addIssue(SaveIssueWarning, "missing_species", repair, "%s has no species", owner)
+			if repair {
+				entity.Species = "unknown"
+			}
+		}
+
+		repairTraitMap(entity.Traits, owner, report, repair)
+		traits.validateTraitMap(TraitSubjectCreature, entity.Traits, owner, report, repair)
+		for field, value := range map[string]*float64{"energy": &entity.Energy, "fitness": &entity.Fitness} {
+			if isInvalidNumber(*value) {
+				report.addIssue(SaveIssueError, "invalid_number", repair, "%s %s is %v", owner, field, *value)
+				if repair {
+					*value = 0
+				}
+			}
+		}
+
+		if fixed, changed := clampPosition(entity.Position, state.Config); changed {
+			report.addIssue(SaveIssueWarning, "invalid_position", repair,
+				"%s position (%v, %v) is outside the world", owner, entity.Position.X, entity.Position.Y)
+			if repair {
+				entity.Position = fixed
+			}
+		}
+
+		if entity.DNA != nil && entity.DNA.EntityID != entity.ID {
+			report.addIssue(SaveIssueWarning, "dangling_reference", repair,
+				"%s carries DNA recorded for entity %d", owner, entity.DNA.EntityID)
+			if repair {
+				entity.DNA.EntityID = entity.ID
+			}
+		}
+		if entity.Cellular != nil {
+			validateCellular(entity, report, repair)
+		}
+
+		kept = append(kept, entity)
+	}
+	state.Entities = kept
+
+	if maxID >= state.NextID {
+		report.addIssue(SaveIssueError, "id_counter", repair,
+			"next entity ID %d would reuse existing ID %d", state.NextID, maxID)
+		if repair {
+			state.NextID = maxID + 1
+		}
+	}
+}
+
+// validateCellular checks that an entity's cellular record belongs to it and cell links resolve
+func validateCellular(entity *EntityState, report *SaveValidationReport, repair bool) {
+	owner := fmt.Sprintf("entity %d", entity.ID)
+	if entity.Cellular.EntityID != entity.ID {
+		report.addIssue(SaveIssueWarning, "dangling_reference", repair,
+			"%s carries cellular data recorded for entity %d", owner, entity.Cellular.EntityID)
+		if repair {
+			entity.Cellular.EntityID = entity.ID
+		}
+	}
+
+	cellIDs := make(map[int]bool, len(entity.Cellular.Cells))
+	for _, cell := range entity.Cellular.Cells {
+		cellIDs[cell.ID] = true
+	}
+	for i := range entity.Cellular.Cells {
+		cell := &entity.Cellular.Cells[i]
+		connections := make([]int, 0, len(cell.Connections))
+		for _, target := range cell.Connections {
+			if cellIDs[target] {
+				connections = append(connections, target)
+				continue
+			}
+			report.addIssue(SaveIssueWarning, "dangling_reference", repair,
+				"%s cell %d links to missing cell %d", owner, cell.ID, target)
+			if !repair {
+				connections = append(connections, target)
+			}
+		}
+		cell.Connections = connections
+	}
+}
+
+// validatePlants checks plant IDs, traits, and positions, returning the set of valid plant IDs
+func validatePlants(state *SimulationState, traits *TraitRegistry, report *SaveValidationReport, repair bool) map[int]bool {
+	seen := make(map[int]bool)
+	maxID := -1
+	kept := make([]*PlantState, 0, len(state.Plants))
+
+	for i, plant := range state.Plants {
+		if plant == nil {
+			report.addIssue(SaveIssueError, "missing_record", repair, "plant record %d is empty", i)
+			if !repair {
+				kept = append(kept, plant)
+			}
+			continue
+		}
+		owner := fmt.Sprintf("plant %d", plant.ID)
+
+		if seen[plant.ID] {
+			report.addIssue(SaveIssueError, "duplicate_id", repair, "%s appears more than once", owner)
+			if repair {
+				continue
+			}
+		}
+		seen[plant.ID] = true
+		if plant.ID > maxID {
+			maxID = plant.ID
+		}
+
+		repairTraitMap(plant.Traits, owner, report, repair)
+		traits.validateTraitMap(TraitSubjectPlant, plant.Traits, owner, report, repair)
+		for field, value := range map[string]*float64{"energy": &plant.Energy, "size": &plant.Size} {
+			if isInvalidNumber(*value) {
+				report.addIssue(SaveIssueError, "invalid_number", repair, "%s %s is %v", owner, field, *value)
+				if repair {
+					*value = 0
+				}
+			}
+		}
+
+		if fixed, changed := clampPosition(plant.Position, state.Config); changed {
+			report.addIssue(SaveIssueWarning, "invalid_position", repair,
+				"%s position (%v, %v) is outside the world", owner, plant.Position.X, plant.Position.Y)
+			if repair {
+				plant.Position = fixed
+			}
+		}
+
+		kept = append(kept, plant)
+	}
+	state.Plants = kept
+
+	if maxID >= state.NextPlantID {
+		report.addIssue(SaveIssueError, "id_counter", repair,
+			"next plant ID %d would reuse existing ID %d", state.NextPlantID, maxID)
+		if repair {
+			state.NextPlantID = maxID + 1
+		}
+	}
+
+	return seen
+}
+
+// validateNetwork checks that plant network connections and signals refer to existing plants
+func validateNetwork(state *SimulationState, plantIDs map[int]bool, report *SaveValidationReport, repair bool) {
+	connections := make([]*NetworkConnectionState, 0, len(state.Network.Connections))
+	for _, conn := range state.Network.Connections {
+		if conn == nil || !plantIDs[conn.Plant1ID] || !plantIDs[conn.Plant2ID] {
+			description := "empty connection"
+			if conn != nil {
+				description = fmt.Sprintf("connection %d-%d", conn.Plant1ID, conn.Plant2ID)
+			}
+			report.addIssue(SaveIssueWarning, "dangling_reference", repair, "network %s refers to a missing plant", description)
+			if repair {
+				continue
+			}
+		}
+		connections = append(connections, conn)
+	}
+	state.Network.Connections = connections
+
+	signals := make([]*ChemicalSignalState, 0, len(state.Network.ActiveSignals))
+	for _, signal := range state.Network.ActiveSignals {
+		if signal == nil || !plantIDs[signal.SourceID] {
+			report.addIssue(SaveIssueWarning, "dangling_reference", repair, "chemical signal refers to a missing source plant")
+			if repair {
+				continue
+			}
+		}
+		if signal != nil {
+			for plantID := range signal.Visited {
+				if !plantIDs[plantID] && repair {
+					delete(signal.Visited, plantID)
+				}
+			}
+		}
+		signals = append(signals, signal)
+	}
+	state.Network.ActiveSignals = signals
+}
+
+// validateSpecies checks that species parents exist and founding traits are finite and known
+func validateSpecies(state *SimulationState, traits *TraitRegistry, report *SaveValidationReport, repair bool) {
+	speciesIDs := make(map[int]bool, len(state.Species.Species))
+	for _, species := range state.Species.Species {
+		if species != nil {
+			speciesIDs[species.ID] = true
+		}
+	}
+
+	for key, species := range state.Species.Species {
+		if species == nil {
+			report.addIssue(SaveIssueError, "missing_record", repair, "species record %s is empty", key)
+			if repair {
+				delete(state.Species.Species, key)
+			}
+			continue
+		}
+		// Parents may have gone extinct and been pruned; flag only clearly broken links
+		if species.ParentID == species.ID && species.ParentID != 0 {
+			report.addIssue(SaveIssueWarning, "dangling_reference", repair, "species %s lists itself as its parent", species.Name)
+			if repair {
+				species.ParentID = 0
+			}
+		}
+		repairTraitMap(species.BaseTraits, "species "+species.Name, report, repair)
+		traits.validateTraitMap(TraitSubjectCreature, species.BaseTraits, "species "+species.Name, report, repair)
+	}
+}
+
+// salvageTruncatedJSON cuts a truncated JSON document back to its last complete value and closes
+// any open objects and arrays. It returns false if nothing could be recovered.
+func salvageTruncatedJSON(data []byte) ([]byte, bool) {
+	var stack []byte
+	var safeStack []byte
+	safeEnd := -1
+	inString := false
+	escaped := false
+
+	for i, c := range data {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '{':
+			stack = append(stack, '}')
+		case '[':
+			stack = append(stack, ']')
+		case '}', ']':
+			if len(stack) == 0 || stack[len(stack)-1] != c {
+				return nil, false
+			}
+			stack = stack[:len(stack)-1]
+			safeEnd = i + 1
+			safeStack = append(safeStack[:0], stack...)
+		case ',':
+			// A comma means the preceding value is complete
+			safeEnd = i
+			safeStack = append(safeStack[:0], stack...)
+		}
+	}
+
+	if safeEnd < 0 {
+		return nil, false
+	}
+
+	recovered := []byte(strings.TrimRight(string(data[:safeEnd]), " \t\r\n,"))
+	for i := len(safeStack) - 1; i >= 0; i-- {
+		recovered = append(recovered, safeStack[i])
+	}
+	return recovered, json.Valid(recovered)
+}
+
+// ParseStateWithRecovery decodes a save, upgrading it to the current schema version and
+// salvaging truncated JSON files when possible
+func ParseStateWithRecovery(data []byte) (*SimulationState, bool, error) {
+	if saveFormatOf(data) == SaveFormatBinary {
+		state, err := decodeBinaryState(data)
+		return state, false, err
+	}
+	doc, err := decodeStateDocument(data)
+	truncated := false
+	if err != nil {
+		salvaged, ok := salvageTruncatedJSON(data)
+		if !ok {
+			return nil, false, fmt.Errorf("failed to unmarshal state: %v", err)
+		}
+		if doc, err = decodeStateDocument(salvaged); err != nil {
+			return nil, false, fmt.Errorf("failed to unmarshal salvaged state: %v", err)
+		}
+		truncated = true
+	}
+	state, _, err := stateFromDocument(doc)
+	if err != nil {
+		return nil, false, err
+	}
+	return state, truncated, nil
+}
+
+// runValidateCommand implements the "evosim analyze validate [--repair] [--out file] <save.json>" subcommand
+func runValidateCommand(args []string) error {
+	fs := newCommandFlags("validate")
+	repair := fs.Bool("repair", false, "Repair issues and write the fixed save")
+	out := fs.String("out", "", "Output file for the repaired save (default: overwrite input)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := applyEnvironment(fs, commandEnvPrefix(fs.Name())); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return commandUsage("validate")
+	}
+	filename := fs.Arg(0)
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read state file: %v", err)
+	}
+	state, truncated, err := ParseStateWithRecovery(data)
+	if err != nil {
+		return err
+	}
+
+	report := ValidateState(state, *repair)
+	report.Truncated = truncated
+	fmt.Print(report.Summary())
+
+	if !*repair {
+		if !report.IsValid() {
+			return fmt.Errorf("save file is not valid; rerun with --repair to fix")
+		}
+		return nil
+	}
+
+	target := *out
+	if target == "" {
+		target = filename
+	}
+	if err := writeStateAs(state, target, saveFormatOf(data)); err != nil {
+		return err
+	}
+	fmt.Printf("Repaired save written to %s\n", target)
+	return nil
+}
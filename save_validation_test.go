@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newValidationTestState() *SimulationState {
+	return &SimulationState{
+		Tick:        100,
+		NextID:      3,
+		NextPlantID: 3,
+		Config:      WorldConfig{Width: 50, Height: 50, GridWidth: 2, GridHeight: 2},
+		Entities: []*EntityState{
+			{ID: 1, Species: "herbivore", Position: Position{X: 10, Y: 10}, Traits: map[string]float64{"speed": 0.5}},
+			{ID: 2, Species: "predator", Position: Position{X: 20, Y: 20}, Traits: map[string]float64{"speed": 0.7}},
+		},
+		Plants: []*PlantState{
+			{ID: 1, Position: Position{X: 5, Y: 5}, IsAlive: true},
+			{ID: 2, Position: Position{X: 6, Y: 6}, IsAlive: true},
+		},
+		Biomes: [][]BiomeType{{BiomePlains, BiomeForest}, {BiomeWater, BiomeDesert}},
+		Network: PlantNetworkState{
+			Connections: []*NetworkConnectionState{{Plant1ID: 1, Plant2ID: 2}},
+		},
+	}
+}
+
+func TestValidateCleanState(t *testing.T) {
+	report := ValidateState(newValidationTestState(), false)
+	if !report.IsValid() {
+		t.Errorf("Expected clean state to be valid:\n%s", report.Summary())
+	}
+}
+
+func TestValidateDetectsAndRepairsCorruption(t *testing.T) {
+	state := newValidationTestState()
+	state.Entities[0].Traits["speed"] = math.NaN()
+	state.Entities = append(state.Entities, &EntityState{ID: 2, Species: "predator"})
+	state.Entities[1].Position = Position{X: 500, Y: -3}
+	state.Network.Connections = append(state.Network.Connections, &NetworkConnectionState{Plant1ID: 1, Plant2ID: 99})
+	state.NextID = 1
+	state.Biomes = state.Biomes[:1]
+
+	report := ValidateState(state, false)
+	kinds := make(map[string]int)
+	for _, issue := range report.Issues {
+		kinds[issue.Kind]++
+		if issue.Repaired {
+			t.Errorf("Issue %q should not be repaired in check-only mode", issue.Message)
+		}
+	}
+	for _, kind := range []string{"invalid_number", "duplicate_id", "invalid_position", "dangling_reference", "id_counter", "partial_biome_grid"} {
+		if kinds[kind] == 0 {
+			t.Errorf("Expected a %s issue, got %v", kind, kinds)
+		}
+	}
+
+	report = ValidateState(state, true)
+	if report.UnrepairedCount() != 0 {
+		t.Errorf("Expected all issues repaired:\n%s", report.Summary())
+	}
+	if !ValidateState(state, false).IsValid() {
+		t.Errorf("Expected repaired state to validate cleanly:\n%s", ValidateState(state, false).Summary())
+	}
+
+	if state.Entities[0].Traits["speed"] != 0 {
+		t.Errorf("Expected NaN trait reset to 0, got %v", state.Entities[0].Traits["speed"])
+	}
+	if len(state.Entities) != 2 {
+		t.Errorf("Expected duplicate entity removed, %d remain", len(state.Entities))
+	}
+	if len(state.Network.Connections) != 1 {
+		t.Errorf("Expected dangling connection removed, %d remain", len(state.Network.Connections))
+	}
+	if state.NextID != 3 {
+		t.Errorf("Expected next ID bumped to 3, got %d", state.NextID)
+	}
+	if len(state.Biomes) != 2 || len(state.Biomes[1]) != 2 {
+		t.Errorf("Expected biome grid padded to 2x2, got %v", state.Biomes)
+	}
+}
+
+func TestSalvageTruncatedSave(t *testing.T) {
+	data, err := json.Marshal(newValidationTestState())
+	if err != nil {
+		t.Fatalf("Failed to marshal state: %v", err)
+	}
+
+	// Cut the file in the middle of the plants array
+	cut := len(data) * 2 / 3
+	state, truncated, err := ParseStateWithRecovery(data[:cut])
+	if err != nil {
+		t.Fatalf("Expected truncated save to be salvaged: %v", err)
+	}
+	if !truncated {
+		t.Error("Expected salvaged save to be flagged as truncated")
+	}
+	if state.Tick != 100 || len(state.Entities) != 2 {
+		t.Errorf("Expected records before the cut to survive, got tick %d with %d entities", state.Tick, len(state.Entities))
+	}
+
+	if _, _, err := ParseStateWithRecovery([]byte("not json")); err == nil {
+		t.Error("Expected unrecoverable data to return an error")
+	}
+}
+
+func TestValidateCommandRepairsFile(t *testing.T) {
+	state := newValidationTestState()
+	state.NextPlantID = 0
+	data, _ := json.Marshal(state)
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "save.json")
+	output := filepath.Join(dir, "fixed.json")
+	if err := os.WriteFile(input, data, 0644); err != nil {
+		t.Fatalf("Failed to write save: %v", err)
+	}
+
+	if err := runValidateCommand([]string{input}); err == nil {
+		t.Error("Expected validation of a corrupt save to fail without --repair")
+	}
+	if err := runValidateCommand([]string{"--repair", "--out", output, input}); err != nil {
+		t.Fatalf("Repair failed: %v", err)
+	}
+
+	fixed, err := LoadStateFile(output)
+	if err != nil {
+		t.Fatalf("Failed to load repaired save: %v", err)
+	}
+	if fixed.NextPlantID != 3 {
+		t.Errorf("Expected repaired next plant ID 3, got %d", fixed.NextPlantID)
+	}
+	if err := runValidateCommand([]string{output}); err != nil {
+		t.Errorf("Expected repaired save to validate: %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// scheduledSystemDefaults lists the subsystems that run every few ticks rather than every
+// tick, with their built-in intervals. SimulationConfig.Schedule overrides the intervals.
+var scheduledSystemDefaults = []struct {
+	Name        string
+	Interval    int
+	Description string
+}{
+	{"geology", 10, "Rebuild biomes from terrain changes"},
+	{"biome_transitions", 20, "Spread fires, melt ice and other biome transitions"},
+	{"group_formation", 10, "Form groups from nearby compatible creatures"},
+	{"plant_reproduction", 10, "Plant seeding and spreading"},
+	{"speciation", 20, "Plant speciation tracking"},
+	{"population_evolution", 50, "Population-level evolution"},
+	{"respawn", 20, "Top up shrinking populations"},
+	{"statistics", 10, "Statistical snapshots"},
+	{"statistics_analysis", 50, "Statistical analysis of the snapshots"},
+	{"ecosystem_metrics", 20, "Diversity, stability and network metrics"},
+	{"metric_history", 5, "Population, fitness, energy and trait history for charts"},
+	{"environmental_pressures", 10, "Environmental pressures"},
+	{"symbiosis", 5, "Symbiotic relationships"},
+	{"collective_formation", 100, "Hive mind, caste colony and swarm formation"},
+}
+
+// ScheduledSystemStatus reports how a scheduled subsystem is running
+type ScheduledSystemStatus struct {
+	Name            string  `json:"name"`
+	Description     string  `json:"description"`
+	Interval        int     `json:"interval"`         // Ticks between runs
+	DefaultInterval int     `json:"default_interval"` // Built-in interval
+	Triggered       bool    `json:"triggered"`        // Runs on the next tick whatever the interval
+	Runs            int     `json:"runs"`
+	LastRunTick     int     `json:"last_run_tick"` // -1 before the first run
+	LastDurationMS  float64 `json:"last_duration_ms"`
+	MeanDurationMS  float64 `json:"mean_duration_ms"`
+}
+
+// SystemScheduler decides which of the scheduled subsystems run each tick. A system runs on
+// ticks that are a multiple of its interval, or on the next tick after something triggers it,
+// and the scheduler times every run.
+type SystemScheduler struct {
+	intervals map[string]int
+	triggered map[string]bool
+	stats     map[string]*ScheduledSystemStatus
+}
+
+// NewSystemScheduler creates a scheduler with the built-in intervals, overridden by the given
+// ones
+func NewSystemScheduler(intervals map[string]int) (*SystemScheduler, error) {
+	scheduler := &SystemScheduler{
+		intervals: make(map[string]int),
+		triggered: make(map[string]bool),
+		stats:     make(map[string]*ScheduledSystemStatus),
+	}
+	for _, system := range scheduledSystemDefaults {
+		scheduler.intervals[system.Name] = system.Interval
+		scheduler.stats[system.Name] = &ScheduledSystemStatus{
+			Name:            system.Name,
+			Description:     system.Description,
+			DefaultInterval: system.Interval,
+			LastRunTick:     -1,
+		}
+	}
+	for _, name := range sortedKeys(intervals) {
+		if err := scheduler.SetInterval(name, intervals[name]); err != nil {
+			return nil, err
+		}
+	}
+	return scheduler, nil
+}
+
+// ValidateSchedule checks the intervals name scheduled systems and are positive
+func ValidateSchedule(intervals map[string]int) error {
+	_, err := NewSystemScheduler(intervals)
+	return err
+}
+
+// SetInterval changes how many ticks pass between a system's runs
+func (s *SystemScheduler) SetInterval(name string, interval int) error {
+	if _, exists := s.intervals[name]; !exists {
+		return fmt.Errorf("unknown scheduled system %q (known: %v)", name, ScheduledSystemNames())
+	}
+	if interval < 1 {
+		return fmt.Errorf("interval for %q must be at least 1 tick", name)
+	}
+	s.intervals[name] = interval
+	return nil
+}
+
+// Interval returns the ticks between a system's runs
+func (s *SystemScheduler) Interval(name string) int {
+	return s.intervals[name]
+}
+
+// Trigger makes a system run on the next tick whatever its interval
+func (s *SystemScheduler) Trigger(name string) error {
+	if _, exists := s.intervals[name]; !exists {
+		return fmt.Errorf("unknown scheduled system %q (known: %v)", name, ScheduledSystemNames())
+	}
+	s.triggered[name] = true
+	return nil
+}
+
+// Run calls update if the system is due at the tick, timing it, and reports whether it ran
+func (s *SystemScheduler) Run(name string, tick int, update func()) bool {
+	interval, exists := s.intervals[name]
+	if !exists || (tick%interval != 0 && !s.triggered[name]) {
+		return false
+	}
+	delete(s.triggered, name)
+
+	start := time.Now()
+	update()
+	duration := float64(time.Since(start).Microseconds()) / 1000
+
+	stats := s.stats[name]
+	stats.Runs++
+	stats.LastRunTick = tick
+	stats.LastDurationMS = duration
+	stats.MeanDurationMS += (duration - stats.MeanDurationMS) / float64(stats.Runs)
+	return true
+}
+
+// Status reports every scheduled system in the built-in order
+func (s *SystemScheduler) Status() []ScheduledSystemStatus {
+	status := make([]ScheduledSystemStatus, 0, len(scheduledSystemDefaults))
+	for _, system := range scheduledSystemDefaults {
+		stats := *s.stats[system.Name]
+		stats.Interval = s.intervals[system.Name]
+		stats.Triggered = s.triggered[system.Name]
+		status = append(status, stats)
+	}
+	return status
+}
+
+// ScheduledSystemNames lists the scheduled systems alphabetically
+func ScheduledSystemNames() []string {
+	names := make([]string, 0, len(scheduledSystemDefaults))
+	for _, system := range scheduledSystemDefaults {
+		names = append(names, system.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// scheduler returns the world's scheduler, creating one from the simulation config for
+// worlds built without one
+func (w *World) scheduler() *SystemScheduler {
+	if w.Scheduler == nil {
+		var intervals map[string]int
+		if w.SimConfig != nil {
+			intervals = w.SimConfig.Schedule
+		}
+		scheduler, err := NewSystemScheduler(intervals)
+		if err != nil {
+			scheduler, _ = NewSystemScheduler(nil)
+		}
+		w.Scheduler = scheduler
+	}
+	return w.Scheduler
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSystemSchedulerIntervalsAndTriggers(t *testing.T) {
+	scheduler, err := NewSystemScheduler(map[string]int{"ecosystem_metrics": 3})
+	if err != nil {
+		t.Fatalf("Failed to create the scheduler: %v", err)
+	}
+	if scheduler.Interval("ecosystem_metrics") != 3 || scheduler.Interval("symbiosis") != 5 {
+		t.Errorf("Expected the configured interval over the built-in one")
+	}
+
+	var ran []int
+	for tick := 1; tick <= 7; tick++ {
+		if tick == 4 {
+			scheduler.Trigger("ecosystem_metrics")
+		}
+		scheduler.Run("ecosystem_metrics", tick, func() { ran = append(ran, tick) })
+	}
+	if len(ran) != 3 || ran[0] != 3 || ran[1] != 4 || ran[2] != 6 {
+		t.Errorf("Expected runs at ticks 3, 4 (triggered) and 6, got %v", ran)
+	}
+	for _, status := range scheduler.Status() {
+		if status.Name == "ecosystem_metrics" && (status.Runs != 3 || status.LastRunTick != 6 || status.DefaultInterval != 20) {
+			t.Errorf("Expected the runs counted, got %+v", status)
+		}
+	}
+
+	for _, intervals := range []map[string]int{{"geology": 0}, {"weather": 5}} {
+		if _, err := NewSystemScheduler(intervals); err == nil {
+			t.Errorf("Expected %v refused", intervals)
+		}
+	}
+	config := DefaultSimulationConfig()
+	config.Schedule = map[string]int{"statistics": -2}
+	if config.Validate() == nil {
+		t.Errorf("Expected the simulation config to refuse a bad schedule")
+	}
+}
+
+func TestWorldRunsScheduledSystemsAtTheirIntervals(t *testing.T) {
+	simulation := DefaultSimulationConfig()
+	simulation.Schedule = map[string]int{"statistics": 2}
+	world := NewWorld(WorldConfig{Width: 50, Height: 50, GridWidth: 20, GridHeight: 20, PopulationSize: 5, Simulation: simulation})
+	for _, population := range startingPopulations(false) {
+		world.AddPopulation(population)
+	}
+	for i := 0; i < 6; i++ {
+		world.Update()
+	}
+
+	if snapshots := len(world.StatisticalReporter.Snapshots); snapshots != 3 {
+		t.Errorf("Expected a statistics snapshot every 2 ticks, got %d in 6 ticks", snapshots)
+	}
+}
+
+func TestPerformanceAPIChangesTheSchedule(t *testing.T) {
+	wi := NewWebInterface(newGraphQLTestWorld())
+
+	var report PerformanceReport
+	callControlAPI(t, wi.handlePerformance, http.MethodPost, "/api/performance", `{"system": "symbiosis", "interval": 7, "trigger": true}`, &report)
+	found := false
+	for _, status := range report.Systems {
+		if status.Name == "symbiosis" {
+			found = status.Interval == 7 && status.DefaultInterval == 5 && status.Triggered
+		}
+	}
+	if !found || len(report.Systems) != len(scheduledSystemDefaults) {
+		t.Errorf("Expected symbiosis every 7 ticks and triggered, got %+v", report.Systems)
+	}
+
+	wi.world.Update()
+	callControlAPI(t, wi.handlePerformance, http.MethodGet, "/api/performance", "", &report)
+	for _, status := range report.Systems {
+		if status.Name == "symbiosis" && (status.Triggered || status.Runs != 1) {
+			t.Errorf("Expected the triggered system run on the next tick, got %+v", status)
+		}
+	}
+
+	if code := callControlAPI(t, wi.handlePerformance, http.MethodPost, "/api/performance", `{"system": "weather", "interval": 2}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected an unknown system refused, got %d", code)
+	}
+}
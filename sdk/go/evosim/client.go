@@ -0,0 +1,5192 @@
+// Code generated by "evosim export sdk"; DO NOT EDIT.
+
+// Package evosim is a typed client for the EvoSim HTTP API, generated from the OpenAPI
+// description the server publishes at /api/spec. The WebSocket messages described at
+// /api/spec/asyncapi are typed too: dial WebSocketURL with any WebSocket library and
+// exchange the Message types as JSON.
+package evosim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client calls the EvoSim HTTP API
+type Client struct {
+	BaseURL    string // e.g. http://localhost:8080
+	HTTPClient *http.Client
+}
+
+// NewClient creates a client for the server at baseURL
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/"), HTTPClient: http.DefaultClient}
+}
+
+// Error is a request the server refused
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("evosim: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// WebSocketURL is the address of the live simulation WebSocket
+func (c *Client) WebSocketURL() string {
+	if strings.HasPrefix(c.BaseURL, "https://") {
+		return "wss://" + strings.TrimPrefix(c.BaseURL, "https://") + "/ws"
+	}
+	return "ws://" + strings.TrimPrefix(c.BaseURL, "http://") + "/ws"
+}
+
+// do sends a request and decodes the response into result: text into a *string, JSON
+// into anything else
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	target := c.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	request, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(response.Body)
+		return &Error{StatusCode: response.StatusCode, Message: strings.TrimSpace(string(message))}
+	}
+	switch result := result.(type) {
+	case nil:
+		return nil
+	case *string:
+		text, err := io.ReadAll(response.Body)
+		*result = string(text)
+		return err
+	default:
+		return json.NewDecoder(response.Body).Decode(result)
+	}
+}
+
+// GetStatusResponse is the response of GetStatus
+type GetStatusResponse struct {
+	Tick           int             `json:"tick"`
+	Entities       int             `json:"entities"`
+	Plants         int             `json:"plants"`
+	Populations    int             `json:"populations"`
+	Status         string          `json:"status"`
+	Seed           int             `json:"seed"`
+	TicksPerSecond float64         `json:"ticks_per_second"`
+	UnlimitedSpeed bool            `json:"unlimited_speed"`
+	MaxCPU         float64         `json:"max_cpu"`
+	Websocket      *WebSocketStats `json:"websocket"`
+}
+
+// GetStatus calls GET /api/status: simulation status and speed
+func (c *Client) GetStatus(ctx context.Context) (*GetStatusResponse, error) {
+	var result GetStatusResponse
+	if err := c.do(ctx, "GET", "/api/status", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetOpenAPISpec calls GET /api/spec: this OpenAPI description of the HTTP API
+func (c *Client) GetOpenAPISpec(ctx context.Context) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := c.do(ctx, "GET", "/api/spec", nil, nil, &result)
+	return result, err
+}
+
+// GetAsyncAPISpec calls GET /api/spec/asyncapi: asyncAPI description of the WebSocket protocol at /ws
+func (c *Client) GetAsyncAPISpec(ctx context.Context) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := c.do(ctx, "GET", "/api/spec/asyncapi", nil, nil, &result)
+	return result, err
+}
+
+// ExportEventsParams are the query parameters of ExportEvents. Optional parameters are left out when zero.
+type ExportEventsParams struct {
+	Type     string // Only events of this type
+	Category string // Only events of this category
+}
+
+// ExportEventsResponse is the response of ExportEvents
+type ExportEventsResponse struct {
+	Events      []CentralEvent    `json:"events"`
+	TotalCount  int               `json:"total_count"`
+	ExportTime  time.Time         `json:"export_time"`
+	Filters     map[string]string `json:"filters"`
+	Certificate *RunCertificate   `json:"certificate"`
+}
+
+// ExportEvents calls GET /api/export/events: export events from the central event bus
+func (c *Client) ExportEvents(ctx context.Context, params ExportEventsParams) (*ExportEventsResponse, error) {
+	query := url.Values{}
+	query.Set("format", "json")
+	if params.Type != "" {
+		query.Set("type", params.Type)
+	}
+	if params.Category != "" {
+		query.Set("category", params.Category)
+	}
+	var result ExportEventsResponse
+	if err := c.do(ctx, "GET", "/api/export/events", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExportAnalysisResponse is the response of ExportAnalysis
+type ExportAnalysisResponse struct {
+	SummaryStatistics map[string]interface{} `json:"summary_statistics"`
+	RecentEvents      []StatisticalEvent     `json:"recent_events"`
+	Snapshots         []StatisticalSnapshot  `json:"snapshots"`
+	ExportTime        time.Time              `json:"export_time"`
+	Certificate       *RunCertificate        `json:"certificate"`
+}
+
+// ExportAnalysis calls GET /api/export/analysis: export statistical analysis data
+func (c *Client) ExportAnalysis(ctx context.Context) (*ExportAnalysisResponse, error) {
+	query := url.Values{}
+	query.Set("format", "json")
+	var result ExportAnalysisResponse
+	if err := c.do(ctx, "GET", "/api/export/analysis", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExportAnomaliesResponse is the response of ExportAnomalies
+type ExportAnomaliesResponse struct {
+	Anomalies    []Anomaly       `json:"anomalies"`
+	TotalCount   int             `json:"total_count"`
+	AnomalyTypes map[string]int  `json:"anomaly_types"`
+	ExportTime   time.Time       `json:"export_time"`
+	Certificate  *RunCertificate `json:"certificate"`
+}
+
+// ExportAnomalies calls GET /api/export/anomalies: export detected anomalies
+func (c *Client) ExportAnomalies(ctx context.Context) (*ExportAnomaliesResponse, error) {
+	query := url.Values{}
+	query.Set("format", "json")
+	var result ExportAnomaliesResponse
+	if err := c.do(ctx, "GET", "/api/export/anomalies", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExportGeoJSONParams are the query parameters of ExportGeoJSON. Optional parameters are left out when zero.
+type ExportGeoJSONParams struct {
+	Layer string // Layer to export; all layers when empty
+}
+
+// ExportGeoJSON calls GET /api/export/geojson: export world geography and species ranges as GeoJSON
+func (c *Client) ExportGeoJSON(ctx context.Context, params ExportGeoJSONParams) (*GeoJSONFeatureCollection, error) {
+	query := url.Values{}
+	if params.Layer != "" {
+		query.Set("layer", params.Layer)
+	}
+	var result GeoJSONFeatureCollection
+	if err := c.do(ctx, "GET", "/api/export/geojson", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExportDarwinCoreParams are the query parameters of ExportDarwinCore. Optional parameters are left out when zero.
+type ExportDarwinCoreParams struct {
+	Plants bool // Include plant occurrences
+}
+
+// ExportDarwinCore calls GET /api/export/darwincore: export occurrence records in Darwin Core format
+func (c *Client) ExportDarwinCore(ctx context.Context, params ExportDarwinCoreParams) ([]DarwinCoreRecord, error) {
+	query := url.Values{}
+	query.Set("format", "json")
+	if params.Plants != false {
+		query.Set("plants", strconv.FormatBool(params.Plants))
+	}
+	var result []DarwinCoreRecord
+	err := c.do(ctx, "GET", "/api/export/darwincore", query, nil, &result)
+	return result, err
+}
+
+// ExportCertificate calls GET /api/export/certificate: the run certificate: seed, config and state hash chain
+func (c *Client) ExportCertificate(ctx context.Context) (*RunCertificate, error) {
+	var result RunCertificate
+	if err := c.do(ctx, "GET", "/api/export/certificate", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DiffSaveResponse is the response of DiffSave
+type DiffSaveResponse struct {
+	Diff           *SaveDiff `json:"diff"`
+	HasDifferences bool      `json:"has_differences"`
+	Summary        string    `json:"summary"`
+}
+
+// DiffSave calls POST /api/diff: compare a save file against the live simulation
+func (c *Client) DiffSave(ctx context.Context, body *SimulationState) (*DiffSaveResponse, error) {
+	var result DiffSaveResponse
+	if err := c.do(ctx, "POST", "/api/diff", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ValidateLiveStateResponse is the response of ValidateLiveState
+type ValidateLiveStateResponse struct {
+	Valid  bool                  `json:"valid"`
+	Report *SaveValidationReport `json:"report"`
+}
+
+// ValidateLiveState calls GET /api/validate: check the live state for corruption
+func (c *Client) ValidateLiveState(ctx context.Context) (*ValidateLiveStateResponse, error) {
+	var result ValidateLiveStateResponse
+	if err := c.do(ctx, "GET", "/api/validate", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ValidateSaveParams are the query parameters of ValidateSave. Optional parameters are left out when zero.
+type ValidateSaveParams struct {
+	Repair bool // Return the repaired state
+}
+
+// ValidateSaveResponse is the response of ValidateSave
+type ValidateSaveResponse struct {
+	Valid         bool                  `json:"valid"`
+	Report        *SaveValidationReport `json:"report"`
+	RepairedState *SimulationState      `json:"repaired_state,omitempty"`
+}
+
+// ValidateSave calls POST /api/validate: check a save file for corruption, optionally repairing it
+func (c *Client) ValidateSave(ctx context.Context, params ValidateSaveParams, body *SimulationState) (*ValidateSaveResponse, error) {
+	query := url.Values{}
+	if params.Repair != false {
+		query.Set("repair", strconv.FormatBool(params.Repair))
+	}
+	var result ValidateSaveResponse
+	if err := c.do(ctx, "POST", "/api/validate", query, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExportCreatureParams are the query parameters of ExportCreature. Optional parameters are left out when zero.
+type ExportCreatureParams struct {
+	ID          int    // Entity to export
+	Mate        int    // Second entity of a breeding pair
+	Name        string // Name of the creature file
+	Description string // Description of the creature file
+}
+
+// ExportCreature calls GET /api/creatures/export: download an entity or breeding pair as a creature file
+func (c *Client) ExportCreature(ctx context.Context, params ExportCreatureParams) (*CreatureFile, error) {
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(params.ID))
+	if params.Mate != 0 {
+		query.Set("mate", strconv.Itoa(params.Mate))
+	}
+	if params.Name != "" {
+		query.Set("name", params.Name)
+	}
+	if params.Description != "" {
+		query.Set("description", params.Description)
+	}
+	var result CreatureFile
+	if err := c.do(ctx, "GET", "/api/creatures/export", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ImportCreatureParams are the query parameters of ImportCreature. Optional parameters are left out when zero.
+type ImportCreatureParams struct {
+	X float64 // World X coordinate to place the creatures at
+	Y float64 // World Y coordinate to place the creatures at
+}
+
+// ImportCreatureResponse is the response of ImportCreature
+type ImportCreatureResponse struct {
+	ImportedIDs []int  `json:"imported_ids"`
+	Species     string `json:"species"`
+}
+
+// ImportCreature calls POST /api/creatures/import: add the creatures in a creature file to the world
+func (c *Client) ImportCreature(ctx context.Context, params ImportCreatureParams, body *CreatureFile) (*ImportCreatureResponse, error) {
+	query := url.Values{}
+	if params.X != 0 {
+		query.Set("x", strconv.FormatFloat(params.X, 'g', -1, 64))
+	}
+	if params.Y != 0 {
+		query.Set("y", strconv.FormatFloat(params.Y, 'g', -1, 64))
+	}
+	var result ImportCreatureResponse
+	if err := c.do(ctx, "POST", "/api/creatures/import", query, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListRegistryParams are the query parameters of ListRegistry. Optional parameters are left out when zero.
+type ListRegistryParams struct {
+	Kind string // creature or scenario; both when empty
+}
+
+// ListRegistry calls GET /api/registry/list: list files on the creature registry
+func (c *Client) ListRegistry(ctx context.Context, params ListRegistryParams) ([]RegistryEntry, error) {
+	query := url.Values{}
+	if params.Kind != "" {
+		query.Set("kind", params.Kind)
+	}
+	var result []RegistryEntry
+	err := c.do(ctx, "GET", "/api/registry/list", query, nil, &result)
+	return result, err
+}
+
+// ImportRegistryFileParams are the query parameters of ImportRegistryFile. Optional parameters are left out when zero.
+type ImportRegistryFileParams struct {
+	ID string  // Registry file to import
+	X  float64 // World X coordinate to place the creatures at
+	Y  float64 // World Y coordinate to place the creatures at
+}
+
+// ImportRegistryFileResponse is the response of ImportRegistryFile
+type ImportRegistryFileResponse struct {
+	Entry       *RegistryEntry `json:"entry"`
+	ImportedIDs []int          `json:"imported_ids"`
+}
+
+// ImportRegistryFile calls POST /api/registry/import: download, verify and apply a registry file
+func (c *Client) ImportRegistryFile(ctx context.Context, params ImportRegistryFileParams) (*ImportRegistryFileResponse, error) {
+	query := url.Values{}
+	query.Set("id", params.ID)
+	if params.X != 0 {
+		query.Set("x", strconv.FormatFloat(params.X, 'g', -1, 64))
+	}
+	if params.Y != 0 {
+		query.Set("y", strconv.FormatFloat(params.Y, 'g', -1, 64))
+	}
+	var result ImportRegistryFileResponse
+	if err := c.do(ctx, "POST", "/api/registry/import", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UploadToRegistry calls POST /api/registry/upload: publish a creature or the current world as a scenario
+func (c *Client) UploadToRegistry(ctx context.Context, body *RegistryUploadRequest) (*RegistryEntry, error) {
+	var result RegistryEntry
+	if err := c.do(ctx, "POST", "/api/registry/upload", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListPetriDishes calls GET /api/petri: list petri dishes
+func (c *Client) ListPetriDishes(ctx context.Context) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+	err := c.do(ctx, "GET", "/api/petri", nil, nil, &result)
+	return result, err
+}
+
+// CreatePetriDishResponse is the response of CreatePetriDish
+type CreatePetriDishResponse struct {
+	ID     int              `json:"id"`
+	Config *PetriDishConfig `json:"config"`
+	Stats  *PetriDishStats  `json:"stats"`
+}
+
+// CreatePetriDish calls POST /api/petri: create a petri dish seeded with populations
+func (c *Client) CreatePetriDish(ctx context.Context, body *PetriDishCreateRequest) (*CreatePetriDishResponse, error) {
+	var result CreatePetriDishResponse
+	if err := c.do(ctx, "POST", "/api/petri", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPetriDishParams are the query parameters of GetPetriDish. Optional parameters are left out when zero.
+type GetPetriDishParams struct {
+	ID int // Petri dish
+}
+
+// GetPetriDish calls GET /api/petri/dish: report a petri dish
+func (c *Client) GetPetriDish(ctx context.Context, params GetPetriDishParams) (*PetriDishStats, error) {
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(params.ID))
+	var result PetriDishStats
+	if err := c.do(ctx, "GET", "/api/petri/dish", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdatePetriDishParams are the query parameters of UpdatePetriDish. Optional parameters are left out when zero.
+type UpdatePetriDishParams struct {
+	ID int // Petri dish
+}
+
+// UpdatePetriDish calls POST /api/petri/dish: adjust a petri dish and optionally advance it
+func (c *Client) UpdatePetriDish(ctx context.Context, params UpdatePetriDishParams, body *PetriDishUpdateRequest) (*PetriDishStats, error) {
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(params.ID))
+	var result PetriDishStats
+	if err := c.do(ctx, "POST", "/api/petri/dish", query, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeletePetriDishParams are the query parameters of DeletePetriDish. Optional parameters are left out when zero.
+type DeletePetriDishParams struct {
+	ID int // Petri dish
+}
+
+// DeletePetriDish calls DELETE /api/petri/dish: remove a petri dish
+func (c *Client) DeletePetriDish(ctx context.Context, params DeletePetriDishParams) error {
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(params.ID))
+	return c.do(ctx, "DELETE", "/api/petri/dish", query, nil, nil)
+}
+
+// ListBreakpointsResponse is the response of ListBreakpoints
+type ListBreakpointsResponse struct {
+	Breakpoints []Breakpoint `json:"breakpoints"`
+	LastHit     *Breakpoint  `json:"last_hit,omitempty"`
+}
+
+// ListBreakpoints calls GET /api/breakpoints: list breakpoints and the one that last paused the simulation
+func (c *Client) ListBreakpoints(ctx context.Context) (*ListBreakpointsResponse, error) {
+	var result ListBreakpointsResponse
+	if err := c.do(ctx, "GET", "/api/breakpoints", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AddBreakpoint calls POST /api/breakpoints: add a breakpoint
+func (c *Client) AddBreakpoint(ctx context.Context, body *BreakpointRequest) (*Breakpoint, error) {
+	var result Breakpoint
+	if err := c.do(ctx, "POST", "/api/breakpoints", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RemoveBreakpointParams are the query parameters of RemoveBreakpoint. Optional parameters are left out when zero.
+type RemoveBreakpointParams struct {
+	ID int // Breakpoint
+}
+
+// RemoveBreakpointResponse is the response of RemoveBreakpoint
+type RemoveBreakpointResponse struct {
+	Removed int `json:"removed"`
+}
+
+// RemoveBreakpoint calls DELETE /api/breakpoints: remove a breakpoint
+func (c *Client) RemoveBreakpoint(ctx context.Context, params RemoveBreakpointParams) (*RemoveBreakpointResponse, error) {
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(params.ID))
+	var result RemoveBreakpointResponse
+	if err := c.do(ctx, "DELETE", "/api/breakpoints", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetTimelineParams are the query parameters of GetTimeline. Optional parameters are left out when zero.
+type GetTimelineParams struct {
+	From    int // First tick; the start of the run when empty
+	To      int // Last tick; the current tick when empty
+	Buckets int // Number of buckets (default 100, at most 1000)
+	Events  int // How many individual events to include (default none)
+}
+
+// GetTimeline calls GET /api/timeline: event counts bucketed over a range of ticks
+func (c *Client) GetTimeline(ctx context.Context, params GetTimelineParams) (*EventTimeline, error) {
+	query := url.Values{}
+	if params.From != 0 {
+		query.Set("from", strconv.Itoa(params.From))
+	}
+	if params.To != 0 {
+		query.Set("to", strconv.Itoa(params.To))
+	}
+	if params.Buckets != 0 {
+		query.Set("buckets", strconv.Itoa(params.Buckets))
+	}
+	if params.Events != 0 {
+		query.Set("events", strconv.Itoa(params.Events))
+	}
+	var result EventTimeline
+	if err := c.do(ctx, "GET", "/api/timeline", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InspectEntity calls GET /api/entity/{id}: everything about an entity: activity, DNA, neural network, memory and relationships
+func (c *Client) InspectEntity(ctx context.Context, id string) (*EntityInspection, error) {
+	var result EntityInspection
+	if err := c.do(ctx, "GET", "/api/entity/"+url.PathEscape(id), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// InspectCell calls GET /api/cell/{x}/{y}: a grid cell's ground and the entities and plants in it
+func (c *Client) InspectCell(ctx context.Context, x string, y string) (*CellInspection, error) {
+	var result CellInspection
+	if err := c.do(ctx, "GET", "/api/cell/"+url.PathEscape(x)+"/"+url.PathEscape(y), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetFollow calls GET /api/follow: the creature the shared camera follows and what it did lately
+func (c *Client) GetFollow(ctx context.Context) (*FollowState, error) {
+	var result FollowState
+	if err := c.do(ctx, "GET", "/api/follow", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FollowEntity calls POST /api/follow: lock the shared camera onto a creature, recording its decisions each tick
+func (c *Client) FollowEntity(ctx context.Context, body *FollowRequest) (*FollowState, error) {
+	var result FollowState
+	if err := c.do(ctx, "POST", "/api/follow", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UnfollowEntity calls DELETE /api/follow: let the shared camera move freely again
+func (c *Client) UnfollowEntity(ctx context.Context) (*FollowState, error) {
+	var result FollowState
+	if err := c.do(ctx, "DELETE", "/api/follow", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListEntitiesParams are the query parameters of ListEntities. Optional parameters are left out when zero.
+type ListEntitiesParams struct {
+	Species string // Only entities of this species
+	Alive   bool   // Only living entities
+	Offset  int    // Entities to skip
+	Limit   int    // Page size, 1-1000 (default 100)
+}
+
+// ListEntities calls GET /api/entities: page through entities in ID order
+func (c *Client) ListEntities(ctx context.Context, params ListEntitiesParams) (*EntityListResponse, error) {
+	query := url.Values{}
+	if params.Species != "" {
+		query.Set("species", params.Species)
+	}
+	if params.Alive != false {
+		query.Set("alive", strconv.FormatBool(params.Alive))
+	}
+	if params.Offset != 0 {
+		query.Set("offset", strconv.Itoa(params.Offset))
+	}
+	if params.Limit != 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	var result EntityListResponse
+	if err := c.do(ctx, "GET", "/api/entities", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListPopulations calls GET /api/populations: populations by species name
+func (c *Client) ListPopulations(ctx context.Context) ([]PopulationData, error) {
+	var result []PopulationData
+	err := c.do(ctx, "GET", "/api/populations", nil, nil, &result)
+	return result, err
+}
+
+// CreatePopulation calls POST /api/populations: add a species with adjusted starting traits
+func (c *Client) CreatePopulation(ctx context.Context, body *PopulationCreateRequest) (*PopulationCreateResponse, error) {
+	var result PopulationCreateResponse
+	if err := c.do(ctx, "POST", "/api/populations", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListSpecies calls GET /api/species: creature and plant species with their trait spread, relatives and members
+func (c *Client) ListSpecies(ctx context.Context) ([]SpeciesProfile, error) {
+	var result []SpeciesProfile
+	err := c.do(ctx, "GET", "/api/species", nil, nil, &result)
+	return result, err
+}
+
+// GetSpecies calls GET /api/species/{name}: a creature or plant species with its trait spread, relatives and members
+func (c *Client) GetSpecies(ctx context.Context, name string) (*SpeciesProfile, error) {
+	var result SpeciesProfile
+	if err := c.do(ctx, "GET", "/api/species/"+url.PathEscape(name), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetSpeciesSprite calls GET /api/species/{id}/sprite: SVG sprite of a species drawn from its members' traits
+func (c *Client) GetSpeciesSprite(ctx context.Context, id string) (string, error) {
+	var result string
+	err := c.do(ctx, "GET", "/api/species/"+url.PathEscape(id)+"/sprite", nil, nil, &result)
+	return result, err
+}
+
+// GetControlState calls GET /api/control: pause, speed and viewport state
+func (c *Client) GetControlState(ctx context.Context) (*ControlState, error) {
+	var result ControlState
+	if err := c.do(ctx, "GET", "/api/control", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SetPaused calls POST /api/control/pause: pause, resume or toggle the simulation
+func (c *Client) SetPaused(ctx context.Context, body *ControlPauseRequest) (*ControlState, error) {
+	var result ControlState
+	if err := c.do(ctx, "POST", "/api/control/pause", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SetSpeed calls POST /api/control/speed: set the speed multiplier, unlimited mode and CPU cap
+func (c *Client) SetSpeed(ctx context.Context, body *ControlSpeedRequest) (*ControlState, error) {
+	var result ControlState
+	if err := c.do(ctx, "POST", "/api/control/speed", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SetViewport calls POST /api/control/viewport: pan and zoom the shared view
+func (c *Client) SetViewport(ctx context.Context, body *ControlViewportRequest) (*ControlState, error) {
+	var result ControlState
+	if err := c.do(ctx, "POST", "/api/control/viewport", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ResetWorld calls POST /api/control/reset: restart the world with the default populations
+func (c *Client) ResetWorld(ctx context.Context) (*ControlState, error) {
+	var result ControlState
+	if err := c.do(ctx, "POST", "/api/control/reset", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPerformance calls GET /api/performance: ticks per second and how often each scheduled subsystem runs
+func (c *Client) GetPerformance(ctx context.Context) (*PerformanceReport, error) {
+	var result PerformanceReport
+	if err := c.do(ctx, "GET", "/api/performance", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateSchedule calls POST /api/performance: change a scheduled subsystem's interval or run it on the next tick
+func (c *Client) UpdateSchedule(ctx context.Context, body *ScheduleUpdateRequest) (*PerformanceReport, error) {
+	var result PerformanceReport
+	if err := c.do(ctx, "POST", "/api/performance", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadSave calls GET /api/save: the full simulation state
+func (c *Client) DownloadSave(ctx context.Context) (*SimulationState, error) {
+	var result SimulationState
+	if err := c.do(ctx, "GET", "/api/save", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SaveOnServer calls POST /api/save: save the simulation state to a file on the server
+func (c *Client) SaveOnServer(ctx context.Context) (*SaveResponse, error) {
+	var result SaveResponse
+	if err := c.do(ctx, "POST", "/api/save", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// LoadSave calls POST /api/load: replace the world with a save
+func (c *Client) LoadSave(ctx context.Context, body *SimulationState) (*ControlState, error) {
+	var result ControlState
+	if err := c.do(ctx, "POST", "/api/load", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAutosave calls GET /api/autosave: autosave settings, the autosaves kept and any autosave from a crashed or interrupted run
+func (c *Client) GetAutosave(ctx context.Context) (*AutosaveStatus, error) {
+	var result AutosaveStatus
+	if err := c.do(ctx, "GET", "/api/autosave", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ResumeAutosave calls POST /api/autosave/resume: replace the world with the autosave on offer
+func (c *Client) ResumeAutosave(ctx context.Context) (*AutosaveStatus, error) {
+	var result AutosaveStatus
+	if err := c.do(ctx, "POST", "/api/autosave/resume", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DismissAutosave calls DELETE /api/autosave/resume: decline the autosave on offer, keeping its file
+func (c *Client) DismissAutosave(ctx context.Context) (*AutosaveStatus, error) {
+	var result AutosaveStatus
+	if err := c.do(ctx, "DELETE", "/api/autosave/resume", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListOperatorStructuresResponse is the response of ListOperatorStructures
+type ListOperatorStructuresResponse struct {
+	Structures  map[string]OperatorStructure `json:"structures"`
+	Experiments []FragmentationExperiment    `json:"experiments"`
+	Stats       map[string]interface{}       `json:"stats"`
+}
+
+// ListOperatorStructures calls GET /api/operator/structures: list operator barriers and corridors with their experiments
+func (c *Client) ListOperatorStructures(ctx context.Context) (*ListOperatorStructuresResponse, error) {
+	var result ListOperatorStructuresResponse
+	if err := c.do(ctx, "GET", "/api/operator/structures", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BuildOperatorStructure calls POST /api/operator/structures: build a barrier or corridor
+func (c *Client) BuildOperatorStructure(ctx context.Context, body *OperatorStructureRequest) (*OperatorStructure, error) {
+	var result OperatorStructure
+	if err := c.do(ctx, "POST", "/api/operator/structures", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RemoveOperatorStructureParams are the query parameters of RemoveOperatorStructure. Optional parameters are left out when zero.
+type RemoveOperatorStructureParams struct {
+	ID int // Structure
+}
+
+// RemoveOperatorStructure calls DELETE /api/operator/structures: remove a barrier or corridor
+func (c *Client) RemoveOperatorStructure(ctx context.Context, params RemoveOperatorStructureParams) error {
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(params.ID))
+	return c.do(ctx, "DELETE", "/api/operator/structures", query, nil, nil)
+}
+
+// TerraformParams are the query parameters of Terraform. Optional parameters are left out when zero.
+type TerraformParams struct {
+	Branch int // Apply to this branch instead of the live world
+}
+
+// Terraform calls POST /api/operator/terraform: change the biome of grid cells
+func (c *Client) Terraform(ctx context.Context, params TerraformParams, body *TerraformRequest) (*Intervention, error) {
+	query := url.Values{}
+	if params.Branch != 0 {
+		query.Set("branch", strconv.Itoa(params.Branch))
+	}
+	var result Intervention
+	if err := c.do(ctx, "POST", "/api/operator/terraform", query, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// EditTraitParams are the query parameters of EditTrait. Optional parameters are left out when zero.
+type EditTraitParams struct {
+	Branch int // Apply to this branch instead of the live world
+}
+
+// EditTrait calls POST /api/operator/traits: change a trait of a single entity
+func (c *Client) EditTrait(ctx context.Context, params EditTraitParams, body *TraitEditRequest) (*Intervention, error) {
+	query := url.Values{}
+	if params.Branch != 0 {
+		query.Set("branch", strconv.Itoa(params.Branch))
+	}
+	var result Intervention
+	if err := c.do(ctx, "POST", "/api/operator/traits", query, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListInterventionsParams are the query parameters of ListInterventions. Optional parameters are left out when zero.
+type ListInterventionsParams struct {
+	Branch int // Apply to this branch instead of the live world
+}
+
+// ListInterventionsResponse is the response of ListInterventions
+type ListInterventionsResponse struct {
+	Undo []Intervention `json:"undo"`
+	Redo []Intervention `json:"redo"`
+}
+
+// ListInterventions calls GET /api/operator/interventions: the intervention undo and redo history
+func (c *Client) ListInterventions(ctx context.Context, params ListInterventionsParams) (*ListInterventionsResponse, error) {
+	query := url.Values{}
+	if params.Branch != 0 {
+		query.Set("branch", strconv.Itoa(params.Branch))
+	}
+	var result ListInterventionsResponse
+	if err := c.do(ctx, "GET", "/api/operator/interventions", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UndoRedoInterventionParams are the query parameters of UndoRedoIntervention. Optional parameters are left out when zero.
+type UndoRedoInterventionParams struct {
+	Branch int // Apply to this branch instead of the live world
+}
+
+// UndoRedoIntervention calls POST /api/operator/interventions: undo or redo the latest intervention
+func (c *Client) UndoRedoIntervention(ctx context.Context, params UndoRedoInterventionParams, body *InterventionRequest) (*Intervention, error) {
+	query := url.Values{}
+	if params.Branch != 0 {
+		query.Set("branch", strconv.Itoa(params.Branch))
+	}
+	var result Intervention
+	if err := c.do(ctx, "POST", "/api/operator/interventions", query, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// StartEnvironmentalEventParams are the query parameters of StartEnvironmentalEvent. Optional parameters are left out when zero.
+type StartEnvironmentalEventParams struct {
+	Branch int // Apply to this branch instead of the live world
+}
+
+// StartEnvironmentalEvent calls POST /api/operator/events: start a wildfire, storm, flood or other environmental event on a grid cell
+func (c *Client) StartEnvironmentalEvent(ctx context.Context, params StartEnvironmentalEventParams, body *EnvironmentalEventRequest) (*EnvironmentalEventResponse, error) {
+	query := url.Values{}
+	if params.Branch != 0 {
+		query.Set("branch", strconv.Itoa(params.Branch))
+	}
+	var result EnvironmentalEventResponse
+	if err := c.do(ctx, "POST", "/api/operator/events", query, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetGameResponse is the response of GetGame
+type GetGameResponse struct {
+	Game *CompetitiveGame `json:"game"`
+}
+
+// GetGame calls GET /api/game: the current or last competitive game
+func (c *Client) GetGame(ctx context.Context) (*GetGameResponse, error) {
+	var result GetGameResponse
+	if err := c.do(ctx, "GET", "/api/game", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// StartGame calls POST /api/game: start a game between every player with a species
+func (c *Client) StartGame(ctx context.Context, body *GameSettings) (*CompetitiveGame, error) {
+	var result CompetitiveGame
+	if err := c.do(ctx, "POST", "/api/game", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AbortGame calls DELETE /api/game: abort the running game
+func (c *Client) AbortGame(ctx context.Context) (*CompetitiveGame, error) {
+	var result CompetitiveGame
+	if err := c.do(ctx, "DELETE", "/api/game", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RematchGame calls POST /api/game/rematch: reset the world and replay the last finished game
+func (c *Client) RematchGame(ctx context.Context) (*CompetitiveGame, error) {
+	var result CompetitiveGame
+	if err := c.do(ctx, "POST", "/api/game/rematch", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetTutorialResponse is the response of GetTutorial
+type GetTutorialResponse struct {
+	Scenarios []TutorialScenario `json:"scenarios"`
+	Tutorial  *Tutorial          `json:"tutorial"`
+}
+
+// GetTutorial calls GET /api/tutorial: the guided scenarios and the current or last tutorial
+func (c *Client) GetTutorial(ctx context.Context) (*GetTutorialResponse, error) {
+	var result GetTutorialResponse
+	if err := c.do(ctx, "GET", "/api/tutorial", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// StartTutorial calls POST /api/tutorial: reset the world into a guided scenario
+func (c *Client) StartTutorial(ctx context.Context, body *TutorialRequest) (*Tutorial, error) {
+	var result Tutorial
+	if err := c.do(ctx, "POST", "/api/tutorial", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// StopTutorial calls DELETE /api/tutorial: stop the running tutorial
+func (c *Client) StopTutorial(ctx context.Context) (*Tutorial, error) {
+	var result Tutorial
+	if err := c.do(ctx, "DELETE", "/api/tutorial", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListPredictionsResponse is the response of ListPredictions
+type ListPredictionsResponse struct {
+	Rounds      []PredictionRound `json:"rounds"`
+	Leaderboard []Spectator       `json:"leaderboard"`
+}
+
+// ListPredictions calls GET /api/predictions: prediction rounds and the points leaderboard
+func (c *Client) ListPredictions(ctx context.Context) (*ListPredictionsResponse, error) {
+	var result ListPredictionsResponse
+	if err := c.do(ctx, "GET", "/api/predictions", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// OpenPredictionRound calls POST /api/predictions: open a prediction round
+func (c *Client) OpenPredictionRound(ctx context.Context, body *PredictionRoundRequest) (*PredictionRound, error) {
+	var result PredictionRound
+	if err := c.do(ctx, "POST", "/api/predictions", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Predict calls POST /api/predictions/predict: stake a spectator's points on an option of an open round
+func (c *Client) Predict(ctx context.Context, body *PredictionRequest) (*Spectator, error) {
+	var result Spectator
+	if err := c.do(ctx, "POST", "/api/predictions/predict", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetStateDigest calls GET /api/step: the current state digest
+func (c *Client) GetStateDigest(ctx context.Context) (*StepResult, error) {
+	var result StepResult
+	if err := c.do(ctx, "GET", "/api/step", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Step calls POST /api/step: pause and advance the simulation by a number of ticks
+func (c *Client) Step(ctx context.Context, body *StepRequest) (*StepResult, error) {
+	var result StepResult
+	if err := c.do(ctx, "POST", "/api/step", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FastForward calls POST /api/fast-forward: load a save and replay it to a tick
+func (c *Client) FastForward(ctx context.Context, body *FastForwardRequest) (*FastForwardResult, error) {
+	var result FastForwardResult
+	if err := c.do(ctx, "POST", "/api/fast-forward", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPopulationCaps calls GET /api/population-caps: soft population caps and the dispersal pool
+func (c *Client) GetPopulationCaps(ctx context.Context) (*PopulationCapStatus, error) {
+	var result PopulationCapStatus
+	if err := c.do(ctx, "GET", "/api/population-caps", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SetPopulationCaps calls POST /api/population-caps: change the population caps
+func (c *Client) SetPopulationCaps(ctx context.Context, body *PopulationCapRequest) (*PopulationCapStatus, error) {
+	var result PopulationCapStatus
+	if err := c.do(ctx, "POST", "/api/population-caps", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetResourceBudget calls GET /api/resource-budget: resources, plants and creatures each biome can support
+func (c *Client) GetResourceBudget(ctx context.Context) (*ResourceBudgetStatus, error) {
+	var result ResourceBudgetStatus
+	if err := c.do(ctx, "GET", "/api/resource-budget", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SetResourceBudget calls POST /api/resource-budget: change the resource budget settings; fields left out keep their values
+func (c *Client) SetResourceBudget(ctx context.Context, body *ResourceBudgetConfig) (*ResourceBudgetStatus, error) {
+	var result ResourceBudgetStatus
+	if err := c.do(ctx, "POST", "/api/resource-budget", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetGallery calls GET /api/gallery: snapshots captured on notable events, newest first; images are served from /gallery/<image>
+func (c *Client) GetGallery(ctx context.Context) (*GalleryStatus, error) {
+	var result GalleryStatus
+	if err := c.do(ctx, "GET", "/api/gallery", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CaptureSnapshot calls POST /api/gallery: capture a snapshot of the world now
+func (c *Client) CaptureSnapshot(ctx context.Context, body *GalleryCaptureRequest) (*GallerySnapshot, error) {
+	var result GallerySnapshot
+	if err := c.do(ctx, "POST", "/api/gallery", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAchievementsParams are the query parameters of GetAchievements. Optional parameters are left out when zero.
+type GetAchievementsParams struct {
+	Player string // Only this player's achievements
+}
+
+// GetAchievements calls GET /api/achievements: milestones with the achievements the world and each player have earned
+func (c *Client) GetAchievements(ctx context.Context, params GetAchievementsParams) (*AchievementsStatus, error) {
+	query := url.Values{}
+	if params.Player != "" {
+		query.Set("player", params.Player)
+	}
+	var result AchievementsStatus
+	if err := c.do(ctx, "GET", "/api/achievements", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDayNight calls GET /api/day-night: the world clock and the daylight on each row of the grid
+func (c *Client) GetDayNight(ctx context.Context) (*DayNightStatus, error) {
+	var result DayNightStatus
+	if err := c.do(ctx, "GET", "/api/day-night", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetSeason calls GET /api/season: the current season, what it does to the world and the creatures away on migration
+func (c *Client) GetSeason(ctx context.Context) (*SeasonStatus, error) {
+	var result SeasonStatus
+	if err := c.do(ctx, "GET", "/api/season", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAudio calls GET /api/audio: the soundscape: rain, wind, bird calls and the fights just heard
+func (c *Client) GetAudio(ctx context.Context) (*AmbientAudio, error) {
+	var result AmbientAudio
+	if err := c.do(ctx, "GET", "/api/audio", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetMigration calls GET /api/migration: herds on the move, the routes each species has learned and the journeys made
+func (c *Client) GetMigration(ctx context.Context) (*MigrationStatus, error) {
+	var result MigrationStatus
+	if err := c.do(ctx, "GET", "/api/migration", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetResources calls GET /api/resources: memory footprint of each store
+func (c *Client) GetResources(ctx context.Context) (*ResourceReport, error) {
+	var result ResourceReport
+	if err := c.do(ctx, "GET", "/api/resources", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SetResourcePolicy calls POST /api/resources: change a store's pruning policy or prune it now
+func (c *Client) SetResourcePolicy(ctx context.Context, body *ResourcePolicyRequest) (*ResourceReport, error) {
+	var result ResourceReport
+	if err := c.do(ctx, "POST", "/api/resources", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetTimescales calls GET /api/timescales: audit of every time constant
+func (c *Client) GetTimescales(ctx context.Context) (*TimescaleAudit, error) {
+	var result TimescaleAudit
+	if err := c.do(ctx, "GET", "/api/timescales", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RescaleTimescales calls POST /api/timescales: rescale a group of time constants together
+func (c *Client) RescaleTimescales(ctx context.Context, body *TimescaleRequest) (*TimescaleAudit, error) {
+	var result TimescaleAudit
+	if err := c.do(ctx, "POST", "/api/timescales", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListTraitsParams are the query parameters of ListTraits. Optional parameters are left out when zero.
+type ListTraitsParams struct {
+	Subject string // creature or plant; both when empty
+}
+
+// ListTraits calls GET /api/traits: trait definitions with their ranges
+func (c *Client) ListTraits(ctx context.Context, params ListTraitsParams) ([]TraitDefinition, error) {
+	query := url.Values{}
+	if params.Subject != "" {
+		query.Set("subject", params.Subject)
+	}
+	var result []TraitDefinition
+	err := c.do(ctx, "GET", "/api/traits", query, nil, &result)
+	return result, err
+}
+
+// GetTraitLegend calls GET /api/traits/legend: how the views size and colour creatures by their traits
+func (c *Client) GetTraitLegend(ctx context.Context) (*TraitLegend, error) {
+	var result TraitLegend
+	if err := c.do(ctx, "GET", "/api/traits/legend", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetLegendParams are the query parameters of GetLegend. Optional parameters are left out when zero.
+type GetLegendParams struct {
+	Renderer string // cli, web or iso; every renderer when empty
+}
+
+// GetLegend calls GET /api/legend: the symbols and colours each renderer draws biomes, creatures, plants, events and structures with
+func (c *Client) GetLegend(ctx context.Context, params GetLegendParams) ([]RendererLegend, error) {
+	query := url.Values{}
+	if params.Renderer != "" {
+		query.Set("renderer", params.Renderer)
+	}
+	var result []RendererLegend
+	err := c.do(ctx, "GET", "/api/legend", query, nil, &result)
+	return result, err
+}
+
+// GetMutations calls GET /api/mutations: mutation operators and their recent changes
+func (c *Client) GetMutations(ctx context.Context) (*MutationReport, error) {
+	var result MutationReport
+	if err := c.do(ctx, "GET", "/api/mutations", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SetMutationRate calls POST /api/mutations: change a mutation operator's rate
+func (c *Client) SetMutationRate(ctx context.Context, body *MutationRateRequest) (*MutationReport, error) {
+	var result MutationReport
+	if err := c.do(ctx, "POST", "/api/mutations", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetSelection calls GET /api/selection: selection strategies and the one each population uses
+func (c *Client) GetSelection(ctx context.Context) (*SelectionReport, error) {
+	var result SelectionReport
+	if err := c.do(ctx, "GET", "/api/selection", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SetSelectionStrategy calls POST /api/selection: change how a population picks its parents
+func (c *Client) SetSelectionStrategy(ctx context.Context, body *SelectionStrategyRequest) (*SelectionReport, error) {
+	var result SelectionReport
+	if err := c.do(ctx, "POST", "/api/selection", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetFitnessLandscapeParams are the query parameters of GetFitnessLandscape. Optional parameters are left out when zero.
+type GetFitnessLandscapeParams struct {
+	Species    string // Species whose members are plotted on the landscape
+	X          string // Trait on the X axis
+	Y          string // Trait on the Y axis
+	Resolution int    // Samples along each axis
+	Radius     int    // Grid cells around the species sampled as habitat
+}
+
+// GetFitnessLandscape calls GET /api/fitness-landscape: sample the fitness landscape over two traits
+func (c *Client) GetFitnessLandscape(ctx context.Context, params GetFitnessLandscapeParams) (*FitnessLandscape, error) {
+	query := url.Values{}
+	if params.Species != "" {
+		query.Set("species", params.Species)
+	}
+	if params.X != "" {
+		query.Set("x", params.X)
+	}
+	if params.Y != "" {
+		query.Set("y", params.Y)
+	}
+	if params.Resolution != 0 {
+		query.Set("resolution", strconv.Itoa(params.Resolution))
+	}
+	if params.Radius != 0 {
+		query.Set("radius", strconv.Itoa(params.Radius))
+	}
+	var result FitnessLandscape
+	if err := c.do(ctx, "GET", "/api/fitness-landscape", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAncestryParams are the query parameters of GetAncestry. Optional parameters are left out when zero.
+type GetAncestryParams struct {
+	Species string // Living species
+	Traits  string // Comma-separated traits to follow
+	Frames  int    // Number of frames to reconstruct
+}
+
+// GetAncestry calls GET /api/ancestry: how a living species' traits changed along its lineage
+func (c *Client) GetAncestry(ctx context.Context, params GetAncestryParams) (*AncestralHistory, error) {
+	query := url.Values{}
+	query.Set("species", params.Species)
+	if params.Traits != "" {
+		query.Set("traits", params.Traits)
+	}
+	if params.Frames != 0 {
+		query.Set("frames", strconv.Itoa(params.Frames))
+	}
+	var result AncestralHistory
+	if err := c.do(ctx, "GET", "/api/ancestry", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPhylogeny calls GET /api/phylogeny: phylogenetic tree of plant species, living and extinct
+func (c *Client) GetPhylogeny(ctx context.Context) (*Phylogeny, error) {
+	query := url.Values{}
+	query.Set("format", "json")
+	var result Phylogeny
+	if err := c.do(ctx, "GET", "/api/phylogeny", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetMetricHistoryParams are the query parameters of GetMetricHistory. Optional parameters are left out when zero.
+type GetMetricHistoryParams struct {
+	Metrics string // Comma-separated metrics, e.g. population,trait:herbivores:speed; a trailing * matches every metric it starts
+	From    int    // First tick; the oldest sample when empty
+	To      int    // Last tick; the current tick when empty
+	Points  int    // Points each series is averaged down to (default 500, at most 5000)
+}
+
+// GetMetricHistory calls GET /api/history: population, fitness, energy and trait history for charts
+func (c *Client) GetMetricHistory(ctx context.Context, params GetMetricHistoryParams) (*MetricHistoryChart, error) {
+	query := url.Values{}
+	query.Set("metrics", params.Metrics)
+	if params.From != 0 {
+		query.Set("from", strconv.Itoa(params.From))
+	}
+	if params.To != 0 {
+		query.Set("to", strconv.Itoa(params.To))
+	}
+	if params.Points != 0 {
+		query.Set("points", strconv.Itoa(params.Points))
+	}
+	var result MetricHistoryChart
+	if err := c.do(ctx, "GET", "/api/history", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetMetricHistoryIndex calls GET /api/history/metrics: metrics held in the history, with their sample counts and ranges
+func (c *Client) GetMetricHistoryIndex(ctx context.Context) (*MetricHistoryIndex, error) {
+	var result MetricHistoryIndex
+	if err := c.do(ctx, "GET", "/api/history/metrics", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetRedQueen calls GET /api/red-queen: arms races between interacting species
+func (c *Client) GetRedQueen(ctx context.Context) ([]RedQueenReport, error) {
+	var result []RedQueenReport
+	err := c.do(ctx, "GET", "/api/red-queen", nil, nil, &result)
+	return result, err
+}
+
+// GetNicheOverlap calls GET /api/niche-overlap: diet, habitat and activity overlap of every pair of species
+func (c *Client) GetNicheOverlap(ctx context.Context) (*NicheOverlapReport, error) {
+	var result NicheOverlapReport
+	if err := c.do(ctx, "GET", "/api/niche-overlap", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetBiomeTransitions calls GET /api/biome-transitions: biome state machine rules and recent transitions
+func (c *Client) GetBiomeTransitions(ctx context.Context) (*BiomeStateReport, error) {
+	var result BiomeStateReport
+	if err := c.do(ctx, "GET", "/api/biome-transitions", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetMicroclimates calls GET /api/microclimates: cells whose tree cover and structures set their conditions
+func (c *Client) GetMicroclimates(ctx context.Context) (*MicroclimateReport, error) {
+	var result MicroclimateReport
+	if err := c.do(ctx, "GET", "/api/microclimates", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPollution calls GET /api/pollution: soil and water contamination and the tribes responsible
+func (c *Client) GetPollution(ctx context.Context) (*PollutionReport, error) {
+	var result PollutionReport
+	if err := c.do(ctx, "GET", "/api/pollution", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetLightPollution calls GET /api/light-pollution: settlements lit at night and their effect on wildlife
+func (c *Client) GetLightPollution(ctx context.Context) (*LightPollutionReport, error) {
+	var result LightPollutionReport
+	if err := c.do(ctx, "GET", "/api/light-pollution", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetNoisePollution calls GET /api/noise-pollution: disturbance zones around colonies and paths
+func (c *Client) GetNoisePollution(ctx context.Context) (*NoisePollutionReport, error) {
+	var result NoisePollutionReport
+	if err := c.do(ctx, "GET", "/api/noise-pollution", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetZoonoses calls GET /api/zoonoses: pathogen spillover between wildlife and colonies
+func (c *Client) GetZoonoses(ctx context.Context) (*ZoonosisReport, error) {
+	var result ZoonosisReport
+	if err := c.do(ctx, "GET", "/api/zoonoses", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetFoodStorage calls GET /api/food-storage: tribes' food stores, spoilage and preservation
+func (c *Client) GetFoodStorage(ctx context.Context) (*FoodStorageReport, error) {
+	var result FoodStorageReport
+	if err := c.do(ctx, "GET", "/api/food-storage", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDemographics calls GET /api/demographics: population pyramids of every colony and species
+func (c *Client) GetDemographics(ctx context.Context) (*DemographicsReport, error) {
+	var result DemographicsReport
+	if err := c.do(ctx, "GET", "/api/demographics", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetCensusParams are the query parameters of GetCensus. Optional parameters are left out when zero.
+type GetCensusParams struct {
+	By      string // Comma-separated dimensions: species, biome, region, age_class, sex, colony
+	Metrics string // Comma-separated metrics: count, energy, age or creature traits
+}
+
+// GetCensus calls GET /api/census: living creatures grouped by dimensions with mean metrics
+func (c *Client) GetCensus(ctx context.Context, params GetCensusParams) (*CensusResult, error) {
+	query := url.Values{}
+	if params.By != "" {
+		query.Set("by", params.By)
+	}
+	if params.Metrics != "" {
+		query.Set("metrics", params.Metrics)
+	}
+	var result CensusResult
+	if err := c.do(ctx, "GET", "/api/census", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetGraphQLSchema calls GET /api/graphql: the GraphQL schema; with ?query= and ?variables= runs a query instead
+func (c *Client) GetGraphQLSchema(ctx context.Context) (string, error) {
+	var result string
+	err := c.do(ctx, "GET", "/api/graphql", nil, nil, &result)
+	return result, err
+}
+
+// QueryGraphQL calls POST /api/graphql: run a GraphQL query over the world
+func (c *Client) QueryGraphQL(ctx context.Context, body *GraphQLRequest) (*GraphQLResponse, error) {
+	var result GraphQLResponse
+	if err := c.do(ctx, "POST", "/api/graphql", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListBranches calls GET /api/branches: compare every branch with the live world
+func (c *Client) ListBranches(ctx context.Context) ([]BranchComparison, error) {
+	var result []BranchComparison
+	err := c.do(ctx, "GET", "/api/branches", nil, nil, &result)
+	return result, err
+}
+
+// ForkBranch calls POST /api/branches: fork the live world into a new branch
+func (c *Client) ForkBranch(ctx context.Context, body *BranchForkRequest) (*BranchComparison, error) {
+	var result BranchComparison
+	if err := c.do(ctx, "POST", "/api/branches", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DiffBranchParams are the query parameters of DiffBranch. Optional parameters are left out when zero.
+type DiffBranchParams struct {
+	ID int // Branch
+}
+
+// DiffBranchResponse is the response of DiffBranch
+type DiffBranchResponse struct {
+	Diff           *SaveDiff `json:"diff"`
+	HasDifferences bool      `json:"has_differences"`
+	Summary        string    `json:"summary"`
+}
+
+// DiffBranch calls GET /api/branches/branch: diff a branch against the live world
+func (c *Client) DiffBranch(ctx context.Context, params DiffBranchParams) (*DiffBranchResponse, error) {
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(params.ID))
+	var result DiffBranchResponse
+	if err := c.do(ctx, "GET", "/api/branches/branch", query, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateBranchParams are the query parameters of UpdateBranch. Optional parameters are left out when zero.
+type UpdateBranchParams struct {
+	ID int // Branch
+}
+
+// UpdateBranch calls POST /api/branches/branch: pause or resume a branch
+func (c *Client) UpdateBranch(ctx context.Context, params UpdateBranchParams, body *BranchUpdateRequest) (*BranchComparison, error) {
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(params.ID))
+	var result BranchComparison
+	if err := c.do(ctx, "POST", "/api/branches/branch", query, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteBranchParams are the query parameters of DeleteBranch. Optional parameters are left out when zero.
+type DeleteBranchParams struct {
+	ID int // Branch
+}
+
+// DeleteBranch calls DELETE /api/branches/branch: discard a branch
+func (c *Client) DeleteBranch(ctx context.Context, params DeleteBranchParams) error {
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(params.ID))
+	return c.do(ctx, "DELETE", "/api/branches/branch", query, nil, nil)
+}
+
+// WebSocket messages. Clients send the Message types with an Action (or, to ask for
+// isometric data, a Type); the server sends view updates and the Message types with a Type.
+
+// JoinAsPlayerData is the data of a JoinAsPlayerMessage
+type JoinAsPlayerData struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// JoinAsPlayerMessage is sent to join the game as a player
+type JoinAsPlayerMessage struct {
+	Action string            `json:"action"`
+	Data   *JoinAsPlayerData `json:"data"`
+}
+
+// CreateSpeciesData is the data of a CreateSpeciesMessage
+type CreateSpeciesData struct {
+	Name   *string            `json:"name,omitempty"`
+	Traits map[string]float64 `json:"traits,omitempty"`
+}
+
+// CreateSpeciesMessage is sent to create a species, optionally adjusting its traits
+type CreateSpeciesMessage struct {
+	Action string             `json:"action"`
+	Data   *CreateSpeciesData `json:"data"`
+}
+
+// ControlSpeciesData is the data of a ControlSpeciesMessage
+type ControlSpeciesData struct {
+	Species *string  `json:"species,omitempty"`
+	Command *string  `json:"command,omitempty"`
+	X       *float64 `json:"x,omitempty"`
+	Y       *float64 `json:"y,omitempty"`
+}
+
+// ControlSpeciesMessage is sent to order a species to move, gather or reproduce
+type ControlSpeciesMessage struct {
+	Action string              `json:"action"`
+	Data   *ControlSpeciesData `json:"data"`
+}
+
+// SelectGroupData is the data of a SelectGroupMessage
+type SelectGroupData struct {
+	Species   *string `json:"species,omitempty"`
+	Formation *string `json:"formation,omitempty"`
+}
+
+// SelectGroupMessage is sent to select a species' creatures as a group
+type SelectGroupMessage struct {
+	Action string           `json:"action"`
+	Data   *SelectGroupData `json:"data"`
+}
+
+// GroupCommandData is the data of a GroupCommandMessage
+type GroupCommandData struct {
+	GroupID   *int     `json:"group_id,omitempty"`
+	Command   *string  `json:"command,omitempty"`
+	Formation *string  `json:"formation,omitempty"`
+	X         *float64 `json:"x,omitempty"`
+	Y         *float64 `json:"y,omitempty"`
+	Radius    *float64 `json:"radius,omitempty"`
+}
+
+// GroupCommandMessage is sent to give a group a standing order: move, guard, gather, stop or disband
+type GroupCommandMessage struct {
+	Action string            `json:"action"`
+	Data   *GroupCommandData `json:"data"`
+}
+
+// SetSpeciesPolicyData is the data of a SetSpeciesPolicyMessage
+type SetSpeciesPolicyData struct {
+	Species      *string  `json:"species,omitempty"`
+	Aggression   *float64 `json:"aggression,omitempty"`
+	Exploration  *float64 `json:"exploration,omitempty"`
+	Reproduction *float64 `json:"reproduction,omitempty"`
+}
+
+// SetSpeciesPolicyMessage is sent to set a species' policy sliders
+type SetSpeciesPolicyMessage struct {
+	Action string                `json:"action"`
+	Data   *SetSpeciesPolicyData `json:"data"`
+}
+
+// ListPlayersMessage is sent to ask for the player list
+type ListPlayersMessage struct {
+	Action string `json:"action"`
+}
+
+// ProposeAllianceData is the data of a ProposeAllianceMessage
+type ProposeAllianceData struct {
+	PlayerID *string `json:"player_id,omitempty"`
+	Species  *string `json:"species,omitempty"`
+	Accept   *bool   `json:"accept,omitempty"`
+}
+
+// ProposeAllianceMessage is sent to propose an alliance to a player
+type ProposeAllianceMessage struct {
+	Action string               `json:"action"`
+	Data   *ProposeAllianceData `json:"data"`
+}
+
+// RespondAllianceData is the data of a RespondAllianceMessage
+type RespondAllianceData struct {
+	PlayerID *string `json:"player_id,omitempty"`
+	Species  *string `json:"species,omitempty"`
+	Accept   *bool   `json:"accept,omitempty"`
+}
+
+// RespondAllianceMessage is sent to accept or refuse a player's alliance
+type RespondAllianceMessage struct {
+	Action string               `json:"action"`
+	Data   *RespondAllianceData `json:"data"`
+}
+
+// LeaveAllianceData is the data of a LeaveAllianceMessage
+type LeaveAllianceData struct {
+	PlayerID *string `json:"player_id,omitempty"`
+	Species  *string `json:"species,omitempty"`
+	Accept   *bool   `json:"accept,omitempty"`
+}
+
+// LeaveAllianceMessage is sent to leave an alliance with a player
+type LeaveAllianceMessage struct {
+	Action string             `json:"action"`
+	Data   *LeaveAllianceData `json:"data"`
+}
+
+// GrantCoControlData is the data of a GrantCoControlMessage
+type GrantCoControlData struct {
+	PlayerID *string `json:"player_id,omitempty"`
+	Species  *string `json:"species,omitempty"`
+	Accept   *bool   `json:"accept,omitempty"`
+}
+
+// GrantCoControlMessage is sent to let an ally control one of your species
+type GrantCoControlMessage struct {
+	Action string              `json:"action"`
+	Data   *GrantCoControlData `json:"data"`
+}
+
+// RevokeCoControlData is the data of a RevokeCoControlMessage
+type RevokeCoControlData struct {
+	PlayerID *string `json:"player_id,omitempty"`
+	Species  *string `json:"species,omitempty"`
+	Accept   *bool   `json:"accept,omitempty"`
+}
+
+// RevokeCoControlMessage is sent to take back an ally's control of a species
+type RevokeCoControlMessage struct {
+	Action string               `json:"action"`
+	Data   *RevokeCoControlData `json:"data"`
+}
+
+// GiftSpeciesData is the data of a GiftSpeciesMessage
+type GiftSpeciesData struct {
+	PlayerID *string `json:"player_id,omitempty"`
+	Species  *string `json:"species,omitempty"`
+	Accept   *bool   `json:"accept,omitempty"`
+}
+
+// GiftSpeciesMessage is sent to give one of your species to an ally
+type GiftSpeciesMessage struct {
+	Action string           `json:"action"`
+	Data   *GiftSpeciesData `json:"data"`
+}
+
+// TogglePauseMessage is sent to pause or resume the simulation
+type TogglePauseMessage struct {
+	Action string `json:"action"`
+}
+
+// ResetMessage is sent to reset the world
+type ResetMessage struct {
+	Action string `json:"action"`
+}
+
+// SaveStateMessage is sent to save the simulation on the server
+type SaveStateMessage struct {
+	Action string `json:"action"`
+}
+
+// LoadStateMessage is sent to load a saved simulation
+type LoadStateMessage struct {
+	Action string           `json:"action"`
+	Data   *SimulationState `json:"data"`
+}
+
+// IncreaseSpeedMessage is sent to speed the simulation up
+type IncreaseSpeedMessage struct {
+	Action string `json:"action"`
+}
+
+// DecreaseSpeedMessage is sent to slow the simulation down
+type DecreaseSpeedMessage struct {
+	Action string `json:"action"`
+}
+
+// SetSpeedData is the data of a SetSpeedMessage
+type SetSpeedData struct {
+	Speed *float64 `json:"speed,omitempty"`
+}
+
+// SetSpeedMessage is sent to set the speed multiplier
+type SetSpeedMessage struct {
+	Action string        `json:"action"`
+	Data   *SetSpeedData `json:"data"`
+}
+
+// SetUnlimitedSpeedData is the data of a SetUnlimitedSpeedMessage
+type SetUnlimitedSpeedData struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// SetUnlimitedSpeedMessage is sent to run as fast as possible or follow the speed multiplier
+type SetUnlimitedSpeedMessage struct {
+	Action string                 `json:"action"`
+	Data   *SetUnlimitedSpeedData `json:"data"`
+}
+
+// SetMaxCPUData is the data of a SetMaxCPUMessage
+type SetMaxCPUData struct {
+	Percent *float64 `json:"percent,omitempty"`
+}
+
+// SetMaxCPUMessage is sent to cap the share of CPU time spent simulating
+type SetMaxCPUMessage struct {
+	Action string         `json:"action"`
+	Data   *SetMaxCPUData `json:"data"`
+}
+
+// FollowEntityMessage is sent to lock the viewport onto a creature, streaming what it does each tick
+type FollowEntityMessage struct {
+	Action string         `json:"action"`
+	Data   *FollowRequest `json:"data"`
+}
+
+// UnfollowEntityMessage is sent to let the viewport move freely again
+type UnfollowEntityMessage struct {
+	Action string `json:"action"`
+}
+
+// PanData is the data of a PanMessage
+type PanData struct {
+	DeltaX *int `json:"deltaX,omitempty"`
+	DeltaY *int `json:"deltaY,omitempty"`
+}
+
+// PanMessage is sent to move the viewport by a number of cells, letting go of a followed creature
+type PanMessage struct {
+	Action string   `json:"action"`
+	Data   *PanData `json:"data"`
+}
+
+// ZoomData is the data of a ZoomMessage
+type ZoomData struct {
+	Zoom *float64 `json:"zoom,omitempty"`
+}
+
+// ZoomMessage is sent to set the zoom level
+type ZoomMessage struct {
+	Action string    `json:"action"`
+	Data   *ZoomData `json:"data"`
+}
+
+// ZoomInMessage is sent to zoom in
+type ZoomInMessage struct {
+	Action string `json:"action"`
+}
+
+// ZoomOutMessage is sent to zoom out
+type ZoomOutMessage struct {
+	Action string `json:"action"`
+}
+
+// ResetViewportMessage is sent to reset the viewport
+type ResetViewportMessage struct {
+	Action string `json:"action"`
+}
+
+// SubscribeChunksMessage is sent to stream the grid chunks covering an area instead of the viewport's grid
+type SubscribeChunksMessage struct {
+	Action string             `json:"action"`
+	Data   *ChunkSubscription `json:"data"`
+}
+
+// UnsubscribeChunksMessage is sent to go back to the viewport's grid
+type UnsubscribeChunksMessage struct {
+	Action string `json:"action"`
+}
+
+// BuildStructureMessage is sent to build a barrier or corridor
+type BuildStructureMessage struct {
+	Action string                    `json:"action"`
+	Data   *OperatorStructureRequest `json:"data"`
+}
+
+// UndoInterventionMessage is sent to undo the latest intervention
+type UndoInterventionMessage struct {
+	Action string `json:"action"`
+}
+
+// RedoInterventionMessage is sent to redo the latest undone intervention
+type RedoInterventionMessage struct {
+	Action string `json:"action"`
+}
+
+// GetIsometricDataMessage is sent to ask for the isometric view of an area
+type GetIsometricDataMessage struct {
+	Type      string   `json:"type"`
+	ViewportX *int     `json:"viewportX,omitempty"`
+	ViewportY *int     `json:"viewportY,omitempty"`
+	Zoom      *float64 `json:"zoom,omitempty"`
+	MaxTiles  *int     `json:"maxTiles,omitempty"`
+}
+
+// ViewUpdateMessage is a frame of the simulation, sent about ten times a second
+type ViewUpdateMessage = ViewData
+
+// ErrorMessage is an explanation of a refused request
+type ErrorMessage struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// IsometricMessage is the isometric view asked for
+type IsometricMessage struct {
+	Type string             `json:"type"`
+	Data *IsometricViewData `json:"data"`
+}
+
+// PlayerJoinedMessage is confirmation of joining as a player
+type PlayerJoinedMessage struct {
+	Type     string `json:"type"`
+	PlayerID string `json:"player_id"`
+	Name     string `json:"name"`
+	Message  string `json:"message"`
+}
+
+// SpeciesCreatedMessage is confirmation that the player's species was created
+type SpeciesCreatedMessage struct {
+	Type        string             `json:"type"`
+	SpeciesName string             `json:"species_name"`
+	Message     string             `json:"message"`
+	Traits      map[string]float64 `json:"traits"`
+}
+
+// CommandExecutedMessage is confirmation of a species or group command
+type CommandExecutedMessage struct {
+	Type             string `json:"type"`
+	Command          string `json:"command"`
+	Message          string `json:"message"`
+	GroupID          *int   `json:"group_id,omitempty"`
+	EntitiesAffected *int   `json:"entities_affected,omitempty"`
+	Offspring        *int   `json:"offspring,omitempty"`
+}
+
+// GroupSelectedMessage is confirmation of a group selection
+type GroupSelectedMessage struct {
+	Type      string `json:"type"`
+	GroupID   int    `json:"group_id"`
+	Size      int    `json:"size"`
+	Formation string `json:"formation"`
+	Message   string `json:"message"`
+}
+
+// SpeciesPolicyUpdatedMessage is confirmation of a species policy change
+type SpeciesPolicyUpdatedMessage struct {
+	Type    string         `json:"type"`
+	Species string         `json:"species"`
+	Policy  *SpeciesPolicy `json:"policy"`
+	Message string         `json:"message"`
+}
+
+// DiplomacyMessage is the other players and the species the player controls
+type DiplomacyMessage struct {
+	Type    string   `json:"type"`
+	Message string   `json:"message"`
+	Species []string `json:"species"`
+	Players []struct {
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		Active    bool   `json:"active"`
+		Allied    bool   `json:"allied"`
+		Requested bool   `json:"requested"`
+		Pending   bool   `json:"pending"`
+	} `json:"players"`
+}
+
+// SpeciesExtinctMessage is notice that one of the player's species died out
+type SpeciesExtinctMessage struct {
+	Type        string `json:"type"`
+	SpeciesName string `json:"species_name"`
+	Message     string `json:"message"`
+	LastCount   int    `json:"last_count"`
+	Tick        int    `json:"tick"`
+}
+
+// SubspeciesFormedMessage is notice that one of the player's species split
+type SubspeciesFormedMessage struct {
+	Type          string `json:"type"`
+	SpeciesName   string `json:"species_name"`
+	ParentSpecies string `json:"parent_species"`
+	Message       string `json:"message"`
+	EntityCount   int    `json:"entity_count"`
+	Tick          int    `json:"tick"`
+}
+
+// NewSpeciesDetectedMessage is notice of a new species in the simulation
+type NewSpeciesDetectedMessage struct {
+	Type        string `json:"type"`
+	SpeciesName string `json:"species_name"`
+	Message     string `json:"message"`
+	EntityCount int    `json:"entity_count"`
+	Tick        int    `json:"tick"`
+}
+
+// AchievementUnlockedMessage is notice that one of the player's species reached a milestone
+type AchievementUnlockedMessage struct {
+	Type        string `json:"type"`
+	SpeciesName string `json:"species_name"`
+	Milestone   string `json:"milestone"`
+	Name        string `json:"name"`
+	Message     string `json:"message"`
+	Tick        int    `json:"tick"`
+}
+
+// AudioMessage is the soundscape, sent about once a second
+type AudioMessage = AmbientAudio
+
+// AnnouncementMessage is a message the teacher broadcast to a classroom world
+type AnnouncementMessage struct {
+	Type    string    `json:"type"`
+	Message string    `json:"message"`
+	Sent    time.Time `json:"sent"`
+}
+
+// Values of the action and type of WebSocket messages
+const (
+	ActionJoinAsPlayer       = "join_as_player"
+	ActionCreateSpecies      = "create_species"
+	ActionControlSpecies     = "control_species"
+	ActionSelectGroup        = "select_group"
+	ActionGroupCommand       = "group_command"
+	ActionSetSpeciesPolicy   = "set_species_policy"
+	ActionListPlayers        = "list_players"
+	ActionProposeAlliance    = "propose_alliance"
+	ActionRespondAlliance    = "respond_alliance"
+	ActionLeaveAlliance      = "leave_alliance"
+	ActionGrantCoControl     = "grant_co_control"
+	ActionRevokeCoControl    = "revoke_co_control"
+	ActionGiftSpecies        = "gift_species"
+	ActionTogglePause        = "toggle_pause"
+	ActionReset              = "reset"
+	ActionSaveState          = "save_state"
+	ActionLoadState          = "load_state"
+	ActionIncreaseSpeed      = "increase_speed"
+	ActionDecreaseSpeed      = "decrease_speed"
+	ActionSetSpeed           = "set_speed"
+	ActionSetUnlimitedSpeed  = "set_unlimited_speed"
+	ActionSetMaxCPU          = "set_max_cpu"
+	ActionFollowEntity       = "follow_entity"
+	ActionUnfollowEntity     = "unfollow_entity"
+	ActionPan                = "pan"
+	ActionZoom               = "zoom"
+	ActionZoomIn             = "zoom_in"
+	ActionZoomOut            = "zoom_out"
+	ActionResetViewport      = "reset_viewport"
+	ActionSubscribeChunks    = "subscribe_chunks"
+	ActionUnsubscribeChunks  = "unsubscribe_chunks"
+	ActionBuildStructure     = "build_structure"
+	ActionUndoIntervention   = "undo_intervention"
+	ActionRedoIntervention   = "redo_intervention"
+	TypeGetIsometricData     = "get_isometric_data"
+	TypeError                = "error"
+	TypeIsometric            = "isometric"
+	TypePlayerJoined         = "player_joined"
+	TypeSpeciesCreated       = "species_created"
+	TypeCommandExecuted      = "command_executed"
+	TypeGroupSelected        = "group_selected"
+	TypeSpeciesPolicyUpdated = "species_policy_updated"
+	TypeDiplomacy            = "diplomacy"
+	TypeSpeciesExtinct       = "species_extinct"
+	TypeSubspeciesFormed     = "subspecies_formed"
+	TypeNewSpeciesDetected   = "new_species_detected"
+	TypeAchievementUnlocked  = "achievement_unlocked"
+	TypeAnnouncement         = "announcement"
+)
+
+// Achievement is a type of the EvoSim API
+type Achievement struct {
+	Milestone string  `json:"milestone"`
+	Name      string  `json:"name"`
+	Tick      int     `json:"tick"`
+	Species   string  `json:"species"`
+	Player    *string `json:"player,omitempty"`
+	Detail    string  `json:"detail"`
+}
+
+// AchievementState is a type of the EvoSim API
+type AchievementState struct {
+	World   []Achievement            `json:"world"`
+	Players map[string][]Achievement `json:"players,omitempty"`
+}
+
+// AchievementsStatus is a type of the EvoSim API
+type AchievementsStatus struct {
+	Milestones []Milestone              `json:"milestones"`
+	World      []Achievement            `json:"world"`
+	Players    map[string][]Achievement `json:"players"`
+}
+
+// AdvancedTimeState is a type of the EvoSim API
+type AdvancedTimeState struct {
+	WorldTick    int     `json:"world_tick"`
+	DayLength    int     `json:"day_length"`
+	SeasonLength int     `json:"season_length"`
+	TimeOfDay    int     `json:"time_of_day"`
+	Season       int     `json:"season"`
+	DayNumber    int     `json:"day_number"`
+	SeasonDay    int     `json:"season_day"`
+	Temperature  float64 `json:"temperature"`
+	Illumination float64 `json:"illumination"`
+	SeasonalMod  float64 `json:"seasonal_mod"`
+}
+
+// AlertData is a type of the EvoSim API
+type AlertData struct {
+	ID          int    `json:"id"`
+	Tick        int    `json:"tick"`
+	Type        string `json:"type"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+}
+
+// AllianceData is a type of the EvoSim API
+type AllianceData struct {
+	ID            int     `json:"id"`
+	Members       []int   `json:"members"`
+	AllianceType  string  `json:"alliance_type"`
+	ResourceShare float64 `json:"resource_share"`
+	SharedDefense bool    `json:"shared_defense"`
+	IsActive      bool    `json:"is_active"`
+	Duration      int     `json:"duration"`
+}
+
+// AmbientAudio is a type of the EvoSim API
+type AmbientAudio struct {
+	Type       string     `json:"type"`
+	Tick       int        `json:"tick"`
+	Rain       float64    `json:"rain"`
+	Wind       float64    `json:"wind"`
+	BirdCalls  float64    `json:"bird_calls"`
+	Population int        `json:"population"`
+	Wars       int        `json:"wars"`
+	Cues       []AudioCue `json:"cues"`
+}
+
+// AncestralFrame is a type of the EvoSim API
+type AncestralFrame struct {
+	Tick          int                `json:"tick"`
+	Species       string             `json:"species"`
+	Traits        map[string]float64 `json:"traits"`
+	Reconstructed bool               `json:"reconstructed"`
+}
+
+// AncestralHistory is a type of the EvoSim API
+type AncestralHistory struct {
+	Species string             `json:"species"`
+	Lineage []string           `json:"lineage"`
+	Traits  []string           `json:"traits"`
+	Frames  []AncestralFrame   `json:"frames"`
+	Splits  []LineageSplit     `json:"splits"`
+	Change  map[string]float64 `json:"change"`
+}
+
+// AnomaliesData is a type of the EvoSim API
+type AnomaliesData struct {
+	TotalAnomalies  int            `json:"total_anomalies"`
+	RecentAnomalies []AnomalyData  `json:"recent_anomalies"`
+	AnomalyTypes    map[string]int `json:"anomaly_types"`
+	Recommendations []string       `json:"recommendations"`
+}
+
+// Anomaly is a type of the EvoSim API
+type Anomaly struct {
+	Type        string                 `json:"type"`
+	Severity    float64                `json:"severity"`
+	Tick        int                    `json:"tick"`
+	Description string                 `json:"description"`
+	Data        map[string]interface{} `json:"data"`
+	Confidence  float64                `json:"confidence"`
+}
+
+// AnomalyData is a type of the EvoSim API
+type AnomalyData struct {
+	Type        string  `json:"type"`
+	Description string  `json:"description"`
+	Severity    float64 `json:"severity"`
+	Confidence  float64 `json:"confidence"`
+	Tick        int     `json:"tick"`
+}
+
+// AudioCue is a type of the EvoSim API
+type AudioCue struct {
+	Sound string  `json:"sound"`
+	Tick  int     `json:"tick"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+}
+
+// AutosaveRecovery is a type of the EvoSim API
+type AutosaveRecovery struct {
+	File    string    `json:"file"`
+	Tick    int       `json:"tick"`
+	SavedAt time.Time `json:"saved_at"`
+	Status  string    `json:"status"`
+}
+
+// AutosaveStatus is a type of the EvoSim API
+type AutosaveStatus struct {
+	Enabled       bool              `json:"enabled"`
+	Dir           *string           `json:"dir,omitempty"`
+	Interval      int               `json:"interval"`
+	Keep          int               `json:"keep"`
+	Saves         []string          `json:"saves"`
+	LastSavedTick int               `json:"last_saved_tick"`
+	Recovery      *AutosaveRecovery `json:"recovery,omitempty"`
+}
+
+// BioRhythmData is a type of the EvoSim API
+type BioRhythmData struct {
+	TotalEntities         int                   `json:"total_entities"`
+	ActivityDistribution  map[string]int        `json:"activity_distribution"`
+	CircadianDistribution map[string]int        `json:"circadian_distribution"`
+	AverageNeedLevels     map[string]float64    `json:"average_need_levels"`
+	BiorhythmEfficiency   float64               `json:"biorhythm_efficiency"`
+	LightDisrupted        int                   `json:"light_disrupted"`
+	CurrentTimeOfDay      string                `json:"current_time_of_day"`
+	IsNight               bool                  `json:"is_night"`
+	Season                string                `json:"season"`
+	SampleEntities        []BioRhythmEntityData `json:"sample_entities"`
+}
+
+// BioRhythmEntityData is a type of the EvoSim API
+type BioRhythmEntityData struct {
+	EntityID        int                `json:"entity_id"`
+	Species         string             `json:"species"`
+	CurrentActivity string             `json:"current_activity"`
+	CircadianType   string             `json:"circadian_type"`
+	Energy          float64            `json:"energy"`
+	NeedLevels      map[string]float64 `json:"need_levels"`
+	TopNeeds        []string           `json:"top_needs"`
+}
+
+// BiomeBoundaryData is a type of the EvoSim API
+type BiomeBoundaryData struct {
+	BoundaryCount       int            `json:"boundary_count"`
+	TotalBoundaryLength float64        `json:"total_boundary_length"`
+	EcotoneArea         float64        `json:"ecotone_area"`
+	MigrationEvents     int            `json:"migration_events"`
+	EvolutionEvents     int            `json:"evolution_events"`
+	EvolutionPressure   float64        `json:"evolution_pressure"`
+	MigrationBonus      float64        `json:"migration_bonus"`
+	BoundaryTypes       map[string]int `json:"boundary_types"`
+}
+
+// BiomeCellPressure is a type of the EvoSim API
+type BiomeCellPressure struct {
+	X         int     `json:"x"`
+	Y         int     `json:"y"`
+	Biome     string  `json:"biome"`
+	To        string  `json:"to"`
+	Trigger   string  `json:"trigger"`
+	Pressure  float64 `json:"pressure"`
+	Threshold float64 `json:"threshold"`
+	Dwelling  bool    `json:"dwelling"`
+}
+
+// BiomeResourceBudget is a type of the EvoSim API
+type BiomeResourceBudget struct {
+	Biome            string        `json:"biome"`
+	Cells            int           `json:"cells"`
+	Sunlight         *ResourcePool `json:"sunlight"`
+	Water            *ResourcePool `json:"water"`
+	Nutrients        *ResourcePool `json:"nutrients"`
+	PreyBiomass      float64       `json:"prey_biomass"`
+	Plants           int           `json:"plants"`
+	Creatures        int           `json:"creatures"`
+	PlantDemand      float64       `json:"plant_demand"`
+	PlantSupply      float64       `json:"plant_supply"`
+	PlantRenewal     float64       `json:"plant_renewal"`
+	PlantRoom        int           `json:"plant_room"`
+	CreatureCapacity float64       `json:"creature_capacity"`
+	Limit            *string       `json:"limit,omitempty"`
+}
+
+// BiomeStateReport is a type of the EvoSim API
+type BiomeStateReport struct {
+	Tick        int                    `json:"tick"`
+	Rules       []BiomeTransitionRule  `json:"rules"`
+	Transitions []BiomeTransitionEvent `json:"transitions"`
+	Pressures   []BiomeCellPressure    `json:"pressures"`
+}
+
+// BiomeTransitionEvent is a type of the EvoSim API
+type BiomeTransitionEvent struct {
+	Tick      int     `json:"tick"`
+	X         int     `json:"x"`
+	Y         int     `json:"y"`
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	Trigger   string  `json:"trigger"`
+	Pressure  float64 `json:"pressure"`
+	Threshold float64 `json:"threshold"`
+	Reverting bool    `json:"reverting"`
+}
+
+// BiomeTransitionRule is a type of the EvoSim API
+type BiomeTransitionRule struct {
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	Trigger   string  `json:"trigger"`
+	Rate      float64 `json:"rate"`
+	Threshold float64 `json:"threshold"`
+}
+
+// BiomesConfig is a type of the EvoSim API
+type BiomesConfig struct {
+	EnergyDrainMultipliers map[string]float64   `json:"energy_drain_multipliers"`
+	MutationRateModifiers  map[string]float64   `json:"mutation_rate_modifiers"`
+	TemperatureRanges      map[string][]float64 `json:"temperature_ranges"`
+	CarryingCapacities     map[string]int       `json:"carrying_capacities"`
+}
+
+// BrainSummary is a type of the EvoSim API
+type BrainSummary struct {
+	Type              string             `json:"type"`
+	Architecture      string             `json:"architecture"`
+	Neurons           int                `json:"neurons"`
+	Layers            int                `json:"layers"`
+	Experience        float64            `json:"experience"`
+	Decisions         int                `json:"decisions"`
+	Accuracy          float64            `json:"accuracy"`
+	SuccessfulActions map[string]float64 `json:"successful_actions"`
+}
+
+// BranchComparison is a type of the EvoSim API
+type BranchComparison struct {
+	ID            int            `json:"id"`
+	Name          string         `json:"name"`
+	ForkTick      int            `json:"fork_tick"`
+	Paused        bool           `json:"paused"`
+	Branch        *BranchSample  `json:"branch"`
+	Trunk         *BranchSample  `json:"trunk"`
+	BranchSpecies map[string]int `json:"branch_species"`
+	TrunkSpecies  map[string]int `json:"trunk_species"`
+	History       []BranchSample `json:"history"`
+	TrunkHistory  []BranchSample `json:"trunk_history"`
+}
+
+// BranchForkRequest is a type of the EvoSim API
+type BranchForkRequest struct {
+	Name string `json:"name"`
+}
+
+// BranchSample is a type of the EvoSim API
+type BranchSample struct {
+	Tick       int     `json:"tick"`
+	Population int     `json:"population"`
+	Plants     int     `json:"plants"`
+	Species    int     `json:"species"`
+	MeanEnergy float64 `json:"mean_energy"`
+}
+
+// BranchUpdateRequest is a type of the EvoSim API
+type BranchUpdateRequest struct {
+	Paused bool `json:"paused"`
+}
+
+// Breakpoint is a type of the EvoSim API
+type Breakpoint struct {
+	ID        int     `json:"id"`
+	Spec      string  `json:"spec"`
+	Kind      string  `json:"kind"`
+	Tick      *int    `json:"tick,omitempty"`
+	Species   *string `json:"species,omitempty"`
+	Threshold *int    `json:"threshold,omitempty"`
+	Hit       bool    `json:"hit"`
+	HitTick   *int    `json:"hit_tick,omitempty"`
+	HitReason *string `json:"hit_reason,omitempty"`
+}
+
+// BreakpointRequest is a type of the EvoSim API
+type BreakpointRequest struct {
+	Spec string `json:"spec"`
+}
+
+// CapRegion is a type of the EvoSim API
+type CapRegion struct {
+	X          int `json:"x"`
+	Y          int `json:"y"`
+	Population int `json:"population"`
+}
+
+// CellData is a type of the EvoSim API
+type CellData struct {
+	X             int          `json:"x"`
+	Y             int          `json:"y"`
+	Biome         string       `json:"biome"`
+	BiomeSymbol   string       `json:"biome_symbol"`
+	BiomeColor    string       `json:"biome_color"`
+	EntityCount   int          `json:"entity_count"`
+	EntitySymbol  string       `json:"entity_symbol"`
+	EntityColor   string       `json:"entity_color"`
+	EntitySpecies *string      `json:"entity_species,omitempty"`
+	EntityScale   *float64     `json:"entity_scale,omitempty"`
+	EntityColors  *TraitColors `json:"entity_colors"`
+	PlantCount    int          `json:"plant_count"`
+	PlantSymbol   string       `json:"plant_symbol"`
+	PlantColor    string       `json:"plant_color"`
+	HasEvent      bool         `json:"has_event"`
+	EventSymbol   string       `json:"event_symbol"`
+	Light         float64      `json:"light"`
+	GridX         int          `json:"grid_x"`
+	GridY         int          `json:"grid_y"`
+	Fog           *string      `json:"fog,omitempty"`
+}
+
+// CellInspection is a type of the EvoSim API
+type CellInspection struct {
+	X              int                `json:"x"`
+	Y              int                `json:"y"`
+	Biome          string             `json:"biome"`
+	WaterLevel     float64            `json:"water_level"`
+	SoilPh         float64            `json:"soil_ph"`
+	SoilCompaction float64            `json:"soil_compaction"`
+	OrganicMatter  float64            `json:"organic_matter"`
+	SoilNutrients  map[string]float64 `json:"soil_nutrients"`
+	Event          *string            `json:"event,omitempty"`
+	Entities       []EntityDetailData `json:"entities"`
+	Plants         []CellPlant        `json:"plants"`
+}
+
+// CellPlant is a type of the EvoSim API
+type CellPlant struct {
+	ID     int     `json:"id"`
+	Type   string  `json:"type"`
+	Energy float64 `json:"energy"`
+	Size   float64 `json:"size"`
+	Age    int     `json:"age"`
+}
+
+// CellState is a type of the EvoSim API
+type CellState struct {
+	ID          int                       `json:"id"`
+	Type        int                       `json:"type"`
+	Size        float64                   `json:"size"`
+	Energy      float64                   `json:"energy"`
+	Health      float64                   `json:"health"`
+	Age         int                       `json:"age"`
+	DNA         *DNAState                 `json:"dna,omitempty"`
+	Organelles  map[string]OrganelleState `json:"organelles"`
+	Position    *Position                 `json:"position"`
+	Connections []int                     `json:"connections"`
+	Activity    float64                   `json:"activity"`
+	Specialized bool                      `json:"specialized"`
+}
+
+// CellularData is a type of the EvoSim API
+type CellularData struct {
+	TotalCells        int     `json:"total_cells"`
+	AverageComplexity float64 `json:"average_complexity"`
+	CellDivisions     int     `json:"cell_divisions"`
+}
+
+// CellularState is a type of the EvoSim API
+type CellularState struct {
+	EntityID        int              `json:"entity_id"`
+	ComplexityLevel int              `json:"complexity_level"`
+	TotalEnergy     float64          `json:"total_energy"`
+	CellDivisions   int              `json:"cell_divisions"`
+	Generation      int              `json:"generation"`
+	Cells           []CellState      `json:"cells"`
+	OrganSystems    map[string][]int `json:"organ_systems"`
+}
+
+// CensusGroup is a type of the EvoSim API
+type CensusGroup struct {
+	Key   map[string]string  `json:"key"`
+	Count int                `json:"count"`
+	Means map[string]float64 `json:"means,omitempty"`
+}
+
+// CensusResult is a type of the EvoSim API
+type CensusResult struct {
+	Tick    int           `json:"tick"`
+	By      []string      `json:"by"`
+	Metrics []string      `json:"metrics"`
+	Total   int           `json:"total"`
+	Groups  []CensusGroup `json:"groups"`
+}
+
+// CentralEvent is a type of the EvoSim API
+type CentralEvent struct {
+	ID          int                    `json:"id"`
+	Timestamp   time.Time              `json:"timestamp"`
+	Tick        int                    `json:"tick"`
+	Type        string                 `json:"type"`
+	Category    string                 `json:"category"`
+	SubCategory string                 `json:"sub_category"`
+	Source      string                 `json:"source"`
+	Description string                 `json:"description"`
+	EntityID    *int                   `json:"entity_id,omitempty"`
+	PlantID     *int                   `json:"plant_id,omitempty"`
+	Position    *Position              `json:"position,omitempty"`
+	OldValue    interface{}            `json:"old_value,omitempty"`
+	NewValue    interface{}            `json:"new_value,omitempty"`
+	Change      *float64               `json:"change,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata"`
+	ImpactedIDs []int                  `json:"impacted_ids,omitempty"`
+	Severity    string                 `json:"severity"`
+}
+
+// CertificateLink is a type of the EvoSim API
+type CertificateLink struct {
+	Epoch int    `json:"epoch"`
+	Tick  int    `json:"tick"`
+	State string `json:"state"`
+	Hash  string `json:"hash"`
+}
+
+// ChemicalSignalState is a type of the EvoSim API
+type ChemicalSignalState struct {
+	ID        int                `json:"id"`
+	SourceID  int                `json:"source_id"`
+	Type      int                `json:"type"`
+	Intensity float64            `json:"intensity"`
+	Age       int                `json:"age"`
+	MaxAge    int                `json:"max_age"`
+	Visited   map[string]bool    `json:"visited"`
+	Message   string             `json:"message"`
+	Metadata  map[string]float64 `json:"metadata"`
+}
+
+// ChromosomeState is a type of the EvoSim API
+type ChromosomeState struct {
+	ID    int         `json:"id"`
+	Genes []GeneState `json:"genes"`
+}
+
+// ChunkSubscription is a type of the EvoSim API
+type ChunkSubscription struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	Margin int `json:"margin"`
+}
+
+// CivilizationData is a type of the EvoSim API
+type CivilizationData struct {
+	TribesCount    int `json:"tribes_count"`
+	StructureCount int `json:"structure_count"`
+	TotalResources int `json:"total_resources"`
+}
+
+// ColonyContact is a type of the EvoSim API
+type ColonyContact struct {
+	TribeID       int     `json:"tribe_id"`
+	Name          string  `json:"name"`
+	Members       int     `json:"members"`
+	Contacts      float64 `json:"contacts"`
+	HuntContacts  int     `json:"hunt_contacts"`
+	SpilloversIn  int     `json:"spillovers_in"`
+	SpilloversOut int     `json:"spillovers_out"`
+}
+
+// ColonyDemography is a type of the EvoSim API
+type ColonyDemography struct {
+	TribeID        int                `json:"tribe_id"`
+	Name           string             `json:"name"`
+	TechLevel      int                `json:"tech_level"`
+	FoodSecure     bool               `json:"food_secure"`
+	Births         int                `json:"births"`
+	BirthRate      float64            `json:"birth_rate"`
+	BirthRates     []float64          `json:"birth_rates"`
+	Transitioned   bool               `json:"transitioned"`
+	TransitionTick *int               `json:"transition_tick,omitempty"`
+	Pyramid        *PopulationPyramid `json:"pyramid"`
+}
+
+// ColonyDetailData is a type of the EvoSim API
+type ColonyDetailData struct {
+	ID          int                `json:"id"`
+	Size        int                `json:"size"`
+	Fitness     float64            `json:"fitness"`
+	Location    *Position          `json:"location"`
+	Resources   map[string]float64 `json:"resources"`
+	Relations   map[string]string  `json:"relations"`
+	TrustLevels map[string]float64 `json:"trust_levels"`
+}
+
+// CommunicationData is a type of the EvoSim API
+type CommunicationData struct {
+	ActiveSignals int            `json:"active_signals"`
+	SignalTypes   map[string]int `json:"signal_types"`
+}
+
+// CommunicationHistorySnapshot is a type of the EvoSim API
+type CommunicationHistorySnapshot struct {
+	Tick          int            `json:"tick"`
+	Timestamp     string         `json:"timestamp"`
+	ActiveSignals int            `json:"active_signals"`
+	SignalTypes   map[string]int `json:"signal_types"`
+	FirstTick     *int           `json:"first_tick,omitempty"`
+	Samples       *int           `json:"samples,omitempty"`
+}
+
+// CommunicationSnapshot is a type of the EvoSim API
+type CommunicationSnapshot struct {
+	ActiveSignals    int            `json:"active_signals"`
+	SignalsByType    map[string]int `json:"signals_by_type"`
+	SignalEfficiency float64        `json:"signal_efficiency"`
+}
+
+// CompetitiveGame is a type of the EvoSim API
+type CompetitiveGame struct {
+	ID            int              `json:"id"`
+	Settings      *GameSettings    `json:"settings"`
+	Status        string           `json:"status"`
+	StartTick     int              `json:"start_tick"`
+	EndTick       *int             `json:"end_tick,omitempty"`
+	CataclysmTick *int             `json:"cataclysm_tick,omitempty"`
+	Winner        *string          `json:"winner,omitempty"`
+	Reason        *string          `json:"reason,omitempty"`
+	Standings     []PlayerStanding `json:"standings"`
+}
+
+// ConflictData is a type of the EvoSim API
+type ConflictData struct {
+	ID            int     `json:"id"`
+	AttackerID    int     `json:"attacker_id"`
+	DefenderID    int     `json:"defender_id"`
+	ConflictType  string  `json:"conflict_type"`
+	TurnsActive   int     `json:"turns_active"`
+	CasualtyCount int     `json:"casualty_count"`
+	ResourcesLost float64 `json:"resources_lost"`
+	Intensity     float64 `json:"intensity"`
+	WarGoal       string  `json:"war_goal"`
+	IsActive      bool    `json:"is_active"`
+}
+
+// ConsumptionRecord is a type of the EvoSim API
+type ConsumptionRecord struct {
+	Tick      int     `json:"tick"`
+	FoodType  string  `json:"food_type"`
+	FoodID    string  `json:"food_id"`
+	Nutrition float64 `json:"nutrition"`
+	Toxicity  float64 `json:"toxicity"`
+}
+
+// ControlPauseRequest is a type of the EvoSim API
+type ControlPauseRequest struct {
+	Paused *bool `json:"paused,omitempty"`
+}
+
+// ControlSpeedRequest is a type of the EvoSim API
+type ControlSpeedRequest struct {
+	Speed     *float64 `json:"speed,omitempty"`
+	Unlimited *bool    `json:"unlimited,omitempty"`
+	MaxCPU    *float64 `json:"max_cpu,omitempty"`
+}
+
+// ControlState is a type of the EvoSim API
+type ControlState struct {
+	Tick           int     `json:"tick"`
+	Paused         bool    `json:"paused"`
+	Speed          float64 `json:"speed"`
+	UnlimitedSpeed bool    `json:"unlimited_speed"`
+	MaxCPU         float64 `json:"max_cpu"`
+	TicksPerSecond float64 `json:"ticks_per_second"`
+	ViewportX      int     `json:"viewport_x"`
+	ViewportY      int     `json:"viewport_y"`
+	Zoom           float64 `json:"zoom"`
+}
+
+// ControlViewportRequest is a type of the EvoSim API
+type ControlViewportRequest struct {
+	X     *int     `json:"x,omitempty"`
+	Y     *int     `json:"y,omitempty"`
+	Zoom  *float64 `json:"zoom,omitempty"`
+	Reset *bool    `json:"reset,omitempty"`
+}
+
+// ConvergedTrait is a type of the EvoSim API
+type ConvergedTrait struct {
+	Trait     string  `json:"trait"`
+	OriginGap float64 `json:"origin_gap"`
+	Gap       float64 `json:"gap"`
+	ValueA    float64 `json:"value_a"`
+	ValueB    float64 `json:"value_b"`
+}
+
+// ConvergenceEvent is a type of the EvoSim API
+type ConvergenceEvent struct {
+	Tick     int              `json:"tick"`
+	SpeciesA string           `json:"species_a"`
+	SpeciesB string           `json:"species_b"`
+	RootA    string           `json:"root_a"`
+	RootB    string           `json:"root_b"`
+	Habitats []string         `json:"habitats"`
+	Traits   []ConvergedTrait `json:"traits"`
+}
+
+// CreatureFile is a type of the EvoSim API
+type CreatureFile struct {
+	Format      string           `json:"format"`
+	Version     int              `json:"version"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	ExportedAt  time.Time        `json:"exported_at"`
+	SourceTick  int              `json:"source_tick"`
+	Creatures   []CreatureRecord `json:"creatures"`
+}
+
+// CreatureRecord is a type of the EvoSim API
+type CreatureRecord struct {
+	OriginalID     int                  `json:"original_id"`
+	Species        string               `json:"species"`
+	Generation     int                  `json:"generation"`
+	Traits         map[string]float64   `json:"traits"`
+	Classification int                  `json:"classification"`
+	MaxLifespan    int                  `json:"max_lifespan"`
+	DNA            *DNAState            `json:"dna,omitempty"`
+	Cellular       *CellularState       `json:"cellular,omitempty"`
+	NeuralNetwork  *EntityNeuralNetwork `json:"neural_network,omitempty"`
+	Culture        *CulturalMemory      `json:"culture,omitempty"`
+}
+
+// CulturalData is a type of the EvoSim API
+type CulturalData struct {
+	TotalKnowledgeTypes       int            `json:"total_knowledge_types"`
+	TotalEntities             int            `json:"total_entities"`
+	ActiveInnovations         int            `json:"active_innovations"`
+	TotalTeachingEvents       int            `json:"total_teaching_events"`
+	TotalLearningEvents       int            `json:"total_learning_events"`
+	TotalInnovationsCreated   int            `json:"total_innovations_created"`
+	KnowledgeLossEvents       int            `json:"knowledge_loss_events"`
+	AvgKnowledgePerEntity     float64        `json:"avg_knowledge_per_entity"`
+	KnowledgeTypeDistribution map[string]int `json:"knowledge_type_distribution"`
+}
+
+// CulturalKnowledge is a type of the EvoSim API
+type CulturalKnowledge struct {
+	ID              int     `json:"id"`
+	Type            int     `json:"type"`
+	Effectiveness   float64 `json:"effectiveness"`
+	Complexity      float64 `json:"complexity"`
+	AgeInGeneration int     `json:"age_in_generation"`
+	TeacherCount    int     `json:"teacher_count"`
+	LearnerCount    int     `json:"learner_count"`
+	SuccessRate     float64 `json:"success_rate"`
+	DecayRate       float64 `json:"decay_rate"`
+	Innovation      bool    `json:"innovation"`
+	LastUsed        int     `json:"last_used"`
+	Description     string  `json:"description"`
+}
+
+// CulturalMemory is a type of the EvoSim API
+type CulturalMemory struct {
+	EntityID          int                          `json:"entity_id"`
+	KnownKnowledge    map[string]CulturalKnowledge `json:"known_knowledge"`
+	TeachingAbility   float64                      `json:"teaching_ability"`
+	LearningAbility   float64                      `json:"learning_ability"`
+	InnovationChance  float64                      `json:"innovation_chance"`
+	KnowledgeCapacity int                          `json:"knowledge_capacity"`
+	RecentlyTaught    []int                        `json:"recently_taught"`
+	RecentlyLearned   []int                        `json:"recently_learned"`
+	MentorEntityID    int                          `json:"mentor_entity_id"`
+	StudentEntityIDs  []int                        `json:"student_entity_ids"`
+}
+
+// CustomTraitCost is a type of the EvoSim API
+type CustomTraitCost struct {
+	Function    string  `json:"function"`
+	Coefficient float64 `json:"coefficient"`
+}
+
+// CustomTraitDefinition is a type of the EvoSim API
+type CustomTraitDefinition struct {
+	Name         string             `json:"name"`
+	Description  string             `json:"description"`
+	Min          float64            `json:"min"`
+	Max          float64            `json:"max"`
+	Initial      float64            `json:"initial"`
+	Variation    float64            `json:"variation"`
+	Heritability float64            `json:"heritability"`
+	Cost         *CustomTraitCost   `json:"cost"`
+	Hooks        map[string]float64 `json:"hooks"`
+}
+
+// DNAData is a type of the EvoSim API
+type DNAData struct {
+	OrganismCount     int     `json:"organism_count"`
+	AverageMutations  float64 `json:"average_mutations"`
+	AverageComplexity float64 `json:"average_complexity"`
+}
+
+// DNAState is a type of the EvoSim API
+type DNAState struct {
+	EntityID    int               `json:"entity_id"`
+	Chromosomes []ChromosomeState `json:"chromosomes"`
+	Mutations   int               `json:"mutations"`
+	Generation  int               `json:"generation"`
+}
+
+// DNASummary is a type of the EvoSim API
+type DNASummary struct {
+	Chromosomes int           `json:"chromosomes"`
+	Mutations   int           `json:"mutations"`
+	Sequence    string        `json:"sequence"`
+	Genes       []GeneSummary `json:"genes"`
+}
+
+// DarwinCoreRecord is a type of the EvoSim API
+type DarwinCoreRecord struct {
+	OccurrenceID        string  `json:"occurrenceID"`
+	BasisOfRecord       string  `json:"basisOfRecord"`
+	DatasetName         string  `json:"datasetName"`
+	Kingdom             string  `json:"kingdom"`
+	ScientificName      string  `json:"scientificName"`
+	OrganismID          int     `json:"organismID"`
+	IndividualCount     int     `json:"individualCount"`
+	OccurrenceStatus    string  `json:"occurrenceStatus"`
+	EventDate           string  `json:"eventDate"`
+	VerbatimEventDate   string  `json:"verbatimEventDate"`
+	DecimalLatitude     float64 `json:"decimalLatitude"`
+	DecimalLongitude    float64 `json:"decimalLongitude"`
+	GeodeticDatum       string  `json:"geodeticDatum"`
+	VerbatimCoordinates string  `json:"verbatimCoordinates"`
+	Habitat             string  `json:"habitat"`
+}
+
+// DayNightConfig is a type of the EvoSim API
+type DayNightConfig struct {
+	DayLength           int     `json:"day_length"`
+	MaxLatitude         float64 `json:"max_latitude"`
+	AxialTilt           float64 `json:"axial_tilt"`
+	NightLight          float64 `json:"night_light"`
+	PhotosynthesisLight float64 `json:"photosynthesis_light"`
+	NightHunting        float64 `json:"night_hunting"`
+}
+
+// DayNightRow is a type of the EvoSim API
+type DayNightRow struct {
+	Row      int     `json:"row"`
+	Latitude float64 `json:"latitude"`
+	Light    float64 `json:"light"`
+}
+
+// DayNightStatus is a type of the EvoSim API
+type DayNightStatus struct {
+	Settings    *DayNightConfig `json:"settings"`
+	Tick        int             `json:"tick"`
+	Day         int             `json:"day"`
+	Phase       float64         `json:"phase"`
+	TimeOfDay   string          `json:"time_of_day"`
+	IsNight     bool            `json:"is_night"`
+	Season      string          `json:"season"`
+	Declination float64         `json:"declination"`
+	Rows        []DayNightRow   `json:"rows"`
+}
+
+// DemographicsReport is a type of the EvoSim API
+type DemographicsReport struct {
+	Tick        int                 `json:"tick"`
+	Colonies    []ColonyDemography  `json:"colonies"`
+	Species     []PopulationPyramid `json:"species"`
+	Transitions int                 `json:"transitions"`
+}
+
+// DispersalState is a type of the EvoSim API
+type DispersalState struct {
+	Pool      []EntityState `json:"pool,omitempty"`
+	Emigrated int           `json:"emigrated"`
+	Dispersed int           `json:"dispersed"`
+	Returned  int           `json:"returned"`
+	Settled   int           `json:"settled"`
+}
+
+// EcosystemMetrics is a type of the EvoSim API
+type EcosystemMetrics struct {
+	ShannonDiversity         float64            `json:"shannon_diversity"`
+	SimpsonDiversity         float64            `json:"simpson_diversity"`
+	SpeciesRichness          int                `json:"species_richness"`
+	SpeciesEvenness          float64            `json:"species_evenness"`
+	TotalPopulation          int                `json:"total_population"`
+	PopulationBySpecies      map[string]int     `json:"population_by_species"`
+	ExtinctionRate           float64            `json:"extinction_rate"`
+	SpeciationRate           float64            `json:"speciation_rate"`
+	NetworkConnectivity      float64            `json:"network_connectivity"`
+	AveragePathLength        float64            `json:"average_path_length"`
+	ClusteringCoefficient    float64            `json:"clustering_coefficient"`
+	PollinationSuccess       float64            `json:"pollination_success"`
+	CrossSpeciesPollination  float64            `json:"cross_species_pollination"`
+	PollinatorEfficiency     map[string]float64 `json:"pollinator_efficiency"`
+	AverageDispersalDistance float64            `json:"average_dispersal_distance"`
+	DispersalMethods         map[string]int     `json:"dispersal_methods"`
+	SeedBankSize             int                `json:"seed_bank_size"`
+	GerminationRate          float64            `json:"germination_rate"`
+	CachedSeeds              int                `json:"cached_seeds"`
+	CacheRecruits            int                `json:"cache_recruits"`
+	CacherDependence         float64            `json:"cacher_dependence"`
+	RecruitsByCacher         map[string]int     `json:"recruits_by_cacher"`
+	ConnectivityIndex        float64            `json:"connectivity_index"`
+	HabitatPatches           int                `json:"habitat_patches"`
+	CorridorCount            int                `json:"corridor_count"`
+	ChokePointCount          int                `json:"choke_point_count"`
+	NicheOverlaps            []NicheOverlap     `json:"niche_overlaps"`
+	LikelyExclusions         int                `json:"likely_exclusions"`
+	EcosystemStability       float64            `json:"ecosystem_stability"`
+	BiodiversityIndex        float64            `json:"biodiversity_index"`
+	EcosystemResilience      float64            `json:"ecosystem_resilience"`
+	CarryingCapacity         float64            `json:"carrying_capacity"`
+}
+
+// EmergentBehaviorData is a type of the EvoSim API
+type EmergentBehaviorData struct {
+	TotalEntities       int                `json:"total_entities"`
+	BehaviorSpread      map[string]int     `json:"behavior_spread"`
+	AvgProficiency      map[string]float64 `json:"avg_proficiency"`
+	DiscoveredBehaviors int                `json:"discovered_behaviors"`
+}
+
+// EnergyConfig is a type of the EvoSim API
+type EnergyConfig struct {
+	BaseEnergyDrain        float64            `json:"base_energy_drain"`
+	MovementEnergyCost     float64            `json:"movement_energy_cost"`
+	ReproductionCost       float64            `json:"reproduction_cost"`
+	SurvivalThreshold      float64            `json:"survival_threshold"`
+	MaxEnergyLevel         float64            `json:"max_energy_level"`
+	EnergyRegenerationRate float64            `json:"energy_regeneration_rate"`
+	BiomeEnergyModifiers   map[string]float64 `json:"biome_energy_modifiers"`
+}
+
+// EntityDetailData is a type of the EvoSim API
+type EntityDetailData struct {
+	ID         int                `json:"id"`
+	Species    string             `json:"species"`
+	IsAlive    bool               `json:"is_alive"`
+	Energy     float64            `json:"energy"`
+	Age        int                `json:"age"`
+	Generation int                `json:"generation"`
+	Position   *Position          `json:"position"`
+	Traits     map[string]float64 `json:"traits"`
+	ColonyID   *int               `json:"colony_id,omitempty"`
+}
+
+// EntityInspection is a type of the EvoSim API
+type EntityInspection struct {
+	ID             int                  `json:"id"`
+	Species        string               `json:"species"`
+	IsAlive        bool                 `json:"is_alive"`
+	Energy         float64              `json:"energy"`
+	Age            int                  `json:"age"`
+	Generation     int                  `json:"generation"`
+	Position       *Position            `json:"position"`
+	Traits         map[string]float64   `json:"traits"`
+	ColonyID       *int                 `json:"colony_id,omitempty"`
+	GridX          int                  `json:"grid_x"`
+	GridY          int                  `json:"grid_y"`
+	Activity       string               `json:"activity"`
+	Classification string               `json:"classification"`
+	Caste          *string              `json:"caste,omitempty"`
+	TribeID        *int                 `json:"tribe_id,omitempty"`
+	Pregnant       bool                 `json:"pregnant"`
+	Offspring      int                  `json:"offspring"`
+	DNA            *DNASummary          `json:"dna,omitempty"`
+	Brain          *BrainSummary        `json:"brain,omitempty"`
+	Memory         *EntityMemory        `json:"memory"`
+	Relationships  []EntityRelationship `json:"relationships"`
+}
+
+// EntityListResponse is a type of the EvoSim API
+type EntityListResponse struct {
+	Total    int                `json:"total"`
+	Offset   int                `json:"offset"`
+	Entities []EntityDetailData `json:"entities"`
+}
+
+// EntityMemory is a type of the EvoSim API
+type EntityMemory struct {
+	RecentMeals       []ConsumptionRecord `json:"recent_meals"`
+	DietaryFitness    float64             `json:"dietary_fitness"`
+	AdaptationFitness float64             `json:"adaptation_fitness"`
+	Knowledge         []string            `json:"knowledge"`
+}
+
+// EntityNeuralNetwork is a type of the EvoSim API
+type EntityNeuralNetwork struct {
+	ID                int                `json:"id"`
+	EntityID          int                `json:"entity_id"`
+	Type              int                `json:"type"`
+	Neurons           map[string]Neuron  `json:"neurons"`
+	InputNeurons      []int              `json:"input_neurons"`
+	OutputNeurons     []int              `json:"output_neurons"`
+	HiddenLayers      [][]int            `json:"hidden_layers"`
+	LearningRate      float64            `json:"learning_rate"`
+	Architecture      string             `json:"architecture"`
+	Experience        float64            `json:"experience"`
+	Adaptability      float64            `json:"adaptability"`
+	CreatedTick       int                `json:"created_tick"`
+	LastUpdateTick    int                `json:"last_update_tick"`
+	RecentInputs      [][]float64        `json:"recent_inputs"`
+	RecentOutputs     [][]float64        `json:"recent_outputs"`
+	SuccessfulActions map[string]float64 `json:"successful_actions"`
+	BehaviorPatterns  map[string]int     `json:"behavior_patterns"`
+	CorrectDecisions  int                `json:"correct_decisions"`
+	TotalDecisions    int                `json:"total_decisions"`
+	AvgResponseTime   float64            `json:"avg_response_time"`
+	ComplexityScore   float64            `json:"complexity_score"`
+}
+
+// EntityRelationship is a type of the EvoSim API
+type EntityRelationship struct {
+	Kind     string  `json:"kind"`
+	EntityID int     `json:"entity_id"`
+	Detail   *string `json:"detail,omitempty"`
+}
+
+// EntityState is a type of the EvoSim API
+type EntityState struct {
+	ID       int                `json:"id"`
+	Species  string             `json:"species"`
+	Position *Position          `json:"position"`
+	Traits   map[string]float64 `json:"traits"`
+	Fitness  float64            `json:"fitness"`
+	Energy   float64            `json:"energy"`
+	Age      int                `json:"age"`
+	DNA      *DNAState          `json:"dna,omitempty"`
+	Cellular *CellularState     `json:"cellular,omitempty"`
+}
+
+// EnvironmentalEventRequest is a type of the EvoSim API
+type EnvironmentalEventRequest struct {
+	Type string `json:"type"`
+	X    int    `json:"x"`
+	Y    int    `json:"y"`
+}
+
+// EnvironmentalEventResponse is a type of the EvoSim API
+type EnvironmentalEventResponse struct {
+	ID          int     `json:"id"`
+	Type        string  `json:"type"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	StartTick   int     `json:"start_tick"`
+	Duration    int     `json:"duration"`
+	X           int     `json:"x"`
+	Y           int     `json:"y"`
+	MaxRadius   float64 `json:"max_radius"`
+}
+
+// EnvironmentalModData is a type of the EvoSim API
+type EnvironmentalModData struct {
+	TotalModifications    int            `json:"total_modifications"`
+	ActiveModifications   int            `json:"active_modifications"`
+	InactiveModifications int            `json:"inactive_modifications"`
+	AvgDurability         float64        `json:"avg_durability"`
+	TunnelNetworks        int            `json:"tunnel_networks"`
+	ModificationTypes     map[string]int `json:"modification_types"`
+}
+
+// EnvironmentalPressureData is a type of the EvoSim API
+type EnvironmentalPressureData struct {
+	ActivePressures int              `json:"active_pressures"`
+	TotalHistory    int              `json:"total_history"`
+	AverageSeverity float64          `json:"average_severity"`
+	PressureTypes   map[string]int   `json:"pressure_types"`
+	ActiveDetails   []PressureDetail `json:"active_details"`
+}
+
+// EventData is a type of the EvoSim API
+type EventData struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Duration    int     `json:"duration"`
+	Tick        int     `json:"tick"`
+	Type        string  `json:"type"`
+	EventType   string  `json:"event_type"`
+	Timestamp   string  `json:"timestamp"`
+	Severity    *string `json:"severity,omitempty"`
+}
+
+// EventTimeline is a type of the EvoSim API
+type EventTimeline struct {
+	FromTick   int              `json:"from_tick"`
+	ToTick     int              `json:"to_tick"`
+	Categories []string         `json:"categories"`
+	Buckets    []TimelineBucket `json:"buckets"`
+	MaxCount   int              `json:"max_count"`
+	Markers    []TimelineMarker `json:"markers"`
+	Events     []TimelineMarker `json:"events,omitempty"`
+}
+
+// EvolutionConfig is a type of the EvoSim API
+type EvolutionConfig struct {
+	TraitMutationStrength float64              `json:"trait_mutation_strength"`
+	TraitBounds           map[string][]float64 `json:"trait_bounds"`
+	FitnessWeights        map[string]float64   `json:"fitness_weights"`
+	SpeciationThreshold   float64              `json:"speciation_threshold"`
+}
+
+// EvolutionData is a type of the EvoSim API
+type EvolutionData struct {
+	SpeciationEvents    int                      `json:"speciation_events"`
+	ExtinctionEvents    int                      `json:"extinction_events"`
+	GeneticDiversity    float64                  `json:"genetic_diversity"`
+	HasSpeciationSystem bool                     `json:"has_speciation_system"`
+	TotalPlantsTracked  int                      `json:"total_plants_tracked"`
+	ActivePlantCount    int                      `json:"active_plant_count"`
+	SpeciationDetected  bool                     `json:"speciation_detected"`
+	MutationOperators   []MutationOperatorStatus `json:"mutation_operators"`
+	Radiations          []RadiationEpisode       `json:"radiations"`
+	Convergences        []ConvergenceEvent       `json:"convergences"`
+	ArmsRaces           []RedQueenReport         `json:"arms_races"`
+}
+
+// FastForwardRequest is a type of the EvoSim API
+type FastForwardRequest struct {
+	State      *SimulationState `json:"state,omitempty"`
+	TargetTick int              `json:"target_tick"`
+	Seed       int              `json:"seed"`
+}
+
+// FastForwardResult is a type of the EvoSim API
+type FastForwardResult struct {
+	FromTick  int     `json:"from_tick"`
+	ToTick    int     `json:"to_tick"`
+	Ticks     int     `json:"ticks"`
+	Seed      int     `json:"seed"`
+	ElapsedMs int     `json:"elapsed_ms"`
+	Digest    string  `json:"digest"`
+	StoppedBy *string `json:"stopped_by,omitempty"`
+}
+
+// FeedbackLoopData is a type of the EvoSim API
+type FeedbackLoopData struct {
+	DietaryMemoryCount    int     `json:"dietary_memory_count"`
+	EnvMemoryCount        int     `json:"env_memory_count"`
+	AvgDietaryFitness     float64 `json:"avg_dietary_fitness"`
+	AvgEnvFitness         float64 `json:"avg_env_fitness"`
+	TotalPlantPreferences int     `json:"total_plant_preferences"`
+	TotalPreyPreferences  int     `json:"total_prey_preferences"`
+	HighPressureEntities  int     `json:"high_pressure_entities"`
+	EvolutionaryPressure  float64 `json:"evolutionary_pressure"`
+}
+
+// FitnessLandscape is a type of the EvoSim API
+type FitnessLandscape struct {
+	Tick       int                   `json:"tick"`
+	Species    string                `json:"species"`
+	XTrait     string                `json:"x_trait"`
+	YTrait     string                `json:"y_trait"`
+	XValues    []float64             `json:"x_values"`
+	YValues    []float64             `json:"y_values"`
+	Fitness    [][]float64           `json:"fitness"`
+	Min        float64               `json:"min"`
+	Max        float64               `json:"max"`
+	Peaks      []LandscapePoint      `json:"peaks"`
+	Valleys    []LandscapePoint      `json:"valleys"`
+	Population []LandscapeIndividual `json:"population"`
+	Centroid   *LandscapePoint       `json:"centroid"`
+	UphillX    float64               `json:"uphill_x"`
+	UphillY    float64               `json:"uphill_y"`
+}
+
+// FollowDecision is a type of the EvoSim API
+type FollowDecision struct {
+	Inputs      map[string]float64 `json:"inputs"`
+	Move        *Position          `json:"move"`
+	Intensity   float64            `json:"intensity"`
+	Activations [][]float64        `json:"activations"`
+}
+
+// FollowFrame is a type of the EvoSim API
+type FollowFrame struct {
+	Tick         int                 `json:"tick"`
+	Position     *Position           `json:"position"`
+	Energy       float64             `json:"energy"`
+	EnergyChange float64             `json:"energy_change"`
+	Activity     string              `json:"activity"`
+	Decision     *FollowDecision     `json:"decision,omitempty"`
+	Interactions []FollowInteraction `json:"interactions"`
+}
+
+// FollowInteraction is a type of the EvoSim API
+type FollowInteraction struct {
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+}
+
+// FollowRequest is a type of the EvoSim API
+type FollowRequest struct {
+	EntityID int `json:"entity_id"`
+}
+
+// FollowState is a type of the EvoSim API
+type FollowState struct {
+	EntityID int          `json:"entity_id"`
+	Track    *FollowTrack `json:"track,omitempty"`
+}
+
+// FollowTrack is a type of the EvoSim API
+type FollowTrack struct {
+	EntityID  int           `json:"entity_id"`
+	Species   string        `json:"species"`
+	Alive     bool          `json:"alive"`
+	StartTick int           `json:"start_tick"`
+	Frames    []FollowFrame `json:"frames"`
+	Trail     []GridPoint   `json:"trail"`
+}
+
+// FoodStorageReport is a type of the EvoSim API
+type FoodStorageReport struct {
+	Tick        int              `json:"tick"`
+	Tribes      []TribeFoodStore `json:"tribes"`
+	CachedWild  float64          `json:"cached_wild"`
+	SpoiledWild float64          `json:"spoiled_wild"`
+	InFamine    int              `json:"in_famine"`
+	WithSurplus int              `json:"with_surplus"`
+	Discoveries int              `json:"discoveries"`
+}
+
+// FragmentationExperiment is a type of the EvoSim API
+type FragmentationExperiment struct {
+	ID             int                  `json:"id"`
+	StructureID    int                  `json:"structure_id"`
+	Phase          string               `json:"phase"`
+	BaselineTicks  int                  `json:"baseline_ticks"`
+	TreatmentTicks int                  `json:"treatment_ticks"`
+	PhaseStartTick int                  `json:"phase_start_tick"`
+	RegionASize    int                  `json:"region_a_size"`
+	RegionBSize    int                  `json:"region_b_size"`
+	Before         *GeneFlowMeasurement `json:"before"`
+	After          *GeneFlowMeasurement `json:"after"`
+	Summary        string               `json:"summary"`
+}
+
+// FungalData is a type of the EvoSim API
+type FungalData struct {
+	TotalOrganisms      int     `json:"total_organisms"`
+	DecomposerCount     int     `json:"decomposer_count"`
+	MycorrhizalCount    int     `json:"mycorrhizal_count"`
+	PathogenicCount     int     `json:"pathogenic_count"`
+	ActiveSpores        int     `json:"active_spores"`
+	TotalBiomass        float64 `json:"total_biomass"`
+	NutrientCycling     float64 `json:"nutrient_cycling"`
+	DecompositionEvents int     `json:"decomposition_events"`
+	NetworkConnections  int     `json:"network_connections"`
+	AvgConnections      float64 `json:"avg_connections"`
+}
+
+// GalleryCaptureRequest is a type of the EvoSim API
+type GalleryCaptureRequest struct {
+	Title string `json:"title"`
+}
+
+// GalleryConfig is a type of the EvoSim API
+type GalleryConfig struct {
+	Enabled      bool `json:"enabled"`
+	MaxSnapshots int  `json:"max_snapshots"`
+	Cooldown     int  `json:"cooldown"`
+	CellPixels   int  `json:"cell_pixels"`
+}
+
+// GallerySnapshot is a type of the EvoSim API
+type GallerySnapshot struct {
+	ID          int           `json:"id"`
+	Trigger     string        `json:"trigger"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Tick        int           `json:"tick"`
+	Taken       time.Time     `json:"taken"`
+	Image       string        `json:"image"`
+	Width       int           `json:"width"`
+	Height      int           `json:"height"`
+	Stats       *GalleryStats `json:"stats"`
+}
+
+// GalleryStats is a type of the EvoSim API
+type GalleryStats struct {
+	Season        string         `json:"season"`
+	TimeOfDay     string         `json:"time_of_day"`
+	Creatures     int            `json:"creatures"`
+	Plants        int            `json:"plants"`
+	Species       int            `json:"species"`
+	Populations   map[string]int `json:"populations"`
+	AverageEnergy float64        `json:"average_energy"`
+	Tribes        int            `json:"tribes"`
+	Tools         int            `json:"tools"`
+}
+
+// GalleryStatus is a type of the EvoSim API
+type GalleryStatus struct {
+	Settings  *GalleryConfig    `json:"settings"`
+	Directory *string           `json:"directory,omitempty"`
+	Snapshots []GallerySnapshot `json:"snapshots"`
+}
+
+// GameSettings is a type of the EvoSim API
+type GameSettings struct {
+	Condition      string  `json:"condition"`
+	Target         float64 `json:"target"`
+	TimeLimit      int     `json:"time_limit"`
+	CataclysmDelay int     `json:"cataclysm_delay"`
+}
+
+// GeneFlowMeasurement is a type of the EvoSim API
+type GeneFlowMeasurement struct {
+	StartTick       int     `json:"start_tick"`
+	EndTick         int     `json:"end_tick"`
+	Crossings       int     `json:"crossings"`
+	CrossingRate    float64 `json:"crossing_rate"`
+	TraitDivergence float64 `json:"trait_divergence"`
+	PopulationA     int     `json:"population_a"`
+	PopulationB     int     `json:"population_b"`
+}
+
+// GeneState is a type of the EvoSim API
+type GeneState struct {
+	Name       string   `json:"name"`
+	Sequence   []string `json:"sequence"`
+	Dominant   bool     `json:"dominant"`
+	Expression float64  `json:"expression"`
+}
+
+// GeneSummary is a type of the EvoSim API
+type GeneSummary struct {
+	Name       string  `json:"name"`
+	Dominant   bool    `json:"dominant"`
+	Expression float64 `json:"expression"`
+}
+
+// GeoJSONFeature is a type of the EvoSim API
+type GeoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   *GeoJSONGeometry       `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// GeoJSONFeatureCollection is a type of the EvoSim API
+type GeoJSONFeatureCollection struct {
+	Type     string                 `json:"type"`
+	Features []GeoJSONFeature       `json:"features"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// GeoJSONGeometry is a type of the EvoSim API
+type GeoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// GeographyDiff is a type of the EvoSim API
+type GeographyDiff struct {
+	DimensionsMatch bool           `json:"dimensions_match"`
+	CellsCompared   int            `json:"cells_compared"`
+	CellsChanged    int            `json:"cells_changed"`
+	BiomeCountsA    map[string]int `json:"biome_counts_a"`
+	BiomeCountsB    map[string]int `json:"biome_counts_b"`
+}
+
+// GraphQLError is a type of the EvoSim API
+type GraphQLError struct {
+	Message string `json:"message"`
+}
+
+// GraphQLRequest is a type of the EvoSim API
+type GraphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// GraphQLResponse is a type of the EvoSim API
+type GraphQLResponse struct {
+	Data   interface{}    `json:"data"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
+
+// GridChunk is a type of the EvoSim API
+type GridChunk struct {
+	X      int        `json:"x"`
+	Y      int        `json:"y"`
+	GridX  int        `json:"grid_x"`
+	GridY  int        `json:"grid_y"`
+	Width  int        `json:"width"`
+	Height int        `json:"height"`
+	Cells  []CellData `json:"cells"`
+}
+
+// GridPoint is a type of the EvoSim API
+type GridPoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// HerdStatus is a type of the EvoSim API
+type HerdStatus struct {
+	Species   string     `json:"species"`
+	Reason    string     `json:"reason"`
+	Position  *Position  `json:"position"`
+	Members   []Position `json:"members"`
+	Waypoints []Position `json:"waypoints"`
+	Trail     []Position `json:"trail"`
+	Route     int        `json:"route"`
+	Returning bool       `json:"returning"`
+	Arrived   bool       `json:"arrived"`
+	Started   int        `json:"started"`
+}
+
+// Intervention is a type of the EvoSim API
+type Intervention struct {
+	ID          int    `json:"id"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	Tick        int    `json:"tick"`
+}
+
+// InterventionRequest is a type of the EvoSim API
+type InterventionRequest struct {
+	Action string `json:"action"`
+}
+
+// IsometricDNA is a type of the EvoSim API
+type IsometricDNA struct {
+	GeneCount   int                      `json:"geneCount"`
+	ActiveGenes int                      `json:"activeGenes"`
+	Genes       []map[string]interface{} `json:"genes,omitempty"`
+}
+
+// IsometricEntity is a type of the EvoSim API
+type IsometricEntity struct {
+	ID      int                `json:"id"`
+	X       float64            `json:"x"`
+	Y       float64            `json:"y"`
+	Species string             `json:"species"`
+	Size    float64            `json:"size"`
+	Energy  float64            `json:"energy"`
+	Age     int                `json:"age"`
+	Color   string             `json:"color"`
+	Scale   float64            `json:"scale"`
+	Colors  *TraitColors       `json:"colors"`
+	Traits  map[string]float64 `json:"traits"`
+	DNA     *IsometricDNA      `json:"dna"`
+}
+
+// IsometricEvent is a type of the EvoSim API
+type IsometricEvent struct {
+	ID          int     `json:"id"`
+	Type        string  `json:"type"`
+	X           float64 `json:"x"`
+	Y           float64 `json:"y"`
+	Intensity   float64 `json:"intensity"`
+	Duration    int     `json:"duration"`
+	Age         int     `json:"age"`
+	Color       string  `json:"color"`
+	Description string  `json:"description"`
+}
+
+// IsometricGeologicalEvent is a type of the EvoSim API
+type IsometricGeologicalEvent struct {
+	ID        int     `json:"id"`
+	Type      string  `json:"type"`
+	Intensity float64 `json:"intensity"`
+	Duration  int     `json:"duration"`
+	StartTick int     `json:"startTick"`
+	Color     string  `json:"color"`
+}
+
+// IsometricPlant is a type of the EvoSim API
+type IsometricPlant struct {
+	X                 float64 `json:"x"`
+	Y                 float64 `json:"y"`
+	Type              int     `json:"type"`
+	TypeName          string  `json:"typeName"`
+	Size              float64 `json:"size"`
+	Energy            float64 `json:"energy"`
+	Age               int     `json:"age"`
+	IsAlive           bool    `json:"isAlive"`
+	Color             string  `json:"color"`
+	GrowthRate        float64 `json:"growthRate"`
+	ReproductionRate  float64 `json:"reproductionRate"`
+	MaxSize           float64 `json:"maxSize"`
+	DiseaseResistance float64 `json:"diseaseResistance"`
+}
+
+// IsometricTile is a type of the EvoSim API
+type IsometricTile struct {
+	X                int                        `json:"x"`
+	Y                int                        `json:"y"`
+	BiomeType        int                        `json:"biomeType"`
+	BiomeName        string                     `json:"biomeName"`
+	Symbol           string                     `json:"symbol"`
+	Color            string                     `json:"color"`
+	Elevation        float64                    `json:"elevation"`
+	Slope            float64                    `json:"slope"`
+	WaterLevel       float64                    `json:"waterLevel"`
+	TerrainFeatures  []int                      `json:"terrainFeatures,omitempty"`
+	GeologicalEvents []IsometricGeologicalEvent `json:"geologicalEvents,omitempty"`
+}
+
+// IsometricViewData is a type of the EvoSim API
+type IsometricViewData struct {
+	Tiles     []IsometricTile   `json:"tiles"`
+	Entities  []IsometricEntity `json:"entities"`
+	Plants    []IsometricPlant  `json:"plants"`
+	Events    []IsometricEvent  `json:"events"`
+	CameraX   float64           `json:"cameraX"`
+	CameraY   float64           `json:"cameraY"`
+	Zoom      float64           `json:"zoom"`
+	WorldInfo *WorldInfo        `json:"worldInfo"`
+}
+
+// LandscapeIndividual is a type of the EvoSim API
+type LandscapeIndividual struct {
+	EntityID int     `json:"entity_id"`
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Fitness  float64 `json:"fitness"`
+}
+
+// LandscapePoint is a type of the EvoSim API
+type LandscapePoint struct {
+	X       float64 `json:"x"`
+	Y       float64 `json:"y"`
+	Fitness float64 `json:"fitness"`
+}
+
+// LegendColorScheme is a type of the EvoSim API
+type LegendColorScheme struct {
+	Name        string           `json:"name"`
+	Label       string           `json:"label"`
+	Description string           `json:"description"`
+	Trait       *TraitDefinition `json:"trait,omitempty"`
+	Stops       []LegendStop     `json:"stops"`
+}
+
+// LegendEntry is a type of the EvoSim API
+type LegendEntry struct {
+	Key    string  `json:"key"`
+	Label  string  `json:"label"`
+	Symbol *string `json:"symbol,omitempty"`
+	Glyph  *string `json:"glyph,omitempty"`
+	Color  *string `json:"color,omitempty"`
+	Class  *string `json:"class,omitempty"`
+}
+
+// LegendScale is a type of the EvoSim API
+type LegendScale struct {
+	Trait *TraitDefinition `json:"trait"`
+	Stops []LegendStop     `json:"stops"`
+}
+
+// LegendSection is a type of the EvoSim API
+type LegendSection struct {
+	Name    string        `json:"name"`
+	Label   string        `json:"label"`
+	Entries []LegendEntry `json:"entries"`
+}
+
+// LegendStop is a type of the EvoSim API
+type LegendStop struct {
+	Value float64  `json:"value"`
+	Label string   `json:"label"`
+	Scale *float64 `json:"scale,omitempty"`
+	Color *string  `json:"color,omitempty"`
+}
+
+// LightPollutionReport is a type of the EvoSim API
+type LightPollutionReport struct {
+	Tick                int           `json:"tick"`
+	IsNight             bool          `json:"is_night"`
+	Sources             []LightSource `json:"sources"`
+	LitNocturnal        int           `json:"lit_nocturnal"`
+	HuntsDisrupted      int           `json:"hunts_disrupted"`
+	MigrantsMisdirected int           `json:"migrants_misdirected"`
+}
+
+// LightSource is a type of the EvoSim API
+type LightSource struct {
+	TribeID    int       `json:"tribe_id"`
+	Name       string    `json:"name"`
+	TechLevel  int       `json:"tech_level"`
+	Position   *Position `json:"position"`
+	Radius     float64   `json:"radius"`
+	Brightness float64   `json:"brightness"`
+}
+
+// LineageSplit is a type of the EvoSim API
+type LineageSplit struct {
+	Tick   int    `json:"tick"`
+	Parent string `json:"parent"`
+	Child  string `json:"child"`
+}
+
+// MetricHistoryChart is a type of the EvoSim API
+type MetricHistoryChart struct {
+	From   int            `json:"from"`
+	To     int            `json:"to"`
+	Series []MetricSeries `json:"series"`
+}
+
+// MetricHistoryIndex is a type of the EvoSim API
+type MetricHistoryIndex struct {
+	Tick     int          `json:"tick"`
+	Interval int          `json:"interval"`
+	Capacity int          `json:"capacity"`
+	Metrics  []MetricInfo `json:"metrics"`
+}
+
+// MetricInfo is a type of the EvoSim API
+type MetricInfo struct {
+	Metric     string  `json:"metric"`
+	Kind       string  `json:"kind"`
+	Population *string `json:"population,omitempty"`
+	Trait      *string `json:"trait,omitempty"`
+	Samples    int     `json:"samples"`
+	FirstTick  int     `json:"first_tick"`
+	LastTick   int     `json:"last_tick"`
+}
+
+// MetricSeries is a type of the EvoSim API
+type MetricSeries struct {
+	Metric string    `json:"metric"`
+	Ticks  []int     `json:"ticks"`
+	Values []float64 `json:"values"`
+}
+
+// MicroclimateCell is a type of the EvoSim API
+type MicroclimateCell struct {
+	X                 int     `json:"x"`
+	Y                 int     `json:"y"`
+	Biome             string  `json:"biome"`
+	Canopy            float64 `json:"canopy"`
+	Shade             float64 `json:"shade"`
+	Windbreak         float64 `json:"windbreak"`
+	Cooling           float64 `json:"cooling"`
+	Retention         float64 `json:"retention"`
+	ParentTemperature float64 `json:"parent_temperature"`
+	Temperature       float64 `json:"temperature"`
+	ParentHumidity    float64 `json:"parent_humidity"`
+	Humidity          float64 `json:"humidity"`
+	Refuge            bool    `json:"refuge"`
+	Sheltering        int     `json:"sheltering"`
+}
+
+// MicroclimateReport is a type of the EvoSim API
+type MicroclimateReport struct {
+	Tick       int                `json:"tick"`
+	Ambient    float64            `json:"ambient"`
+	HeatWave   bool               `json:"heat_wave"`
+	Refugia    int                `json:"refugia"`
+	Sheltering int                `json:"sheltering"`
+	Cells      []MicroclimateCell `json:"cells"`
+}
+
+// MigrationPath is a type of the EvoSim API
+type MigrationPath struct {
+	Species   string     `json:"species"`
+	Reason    string     `json:"reason"`
+	Returning bool       `json:"returning"`
+	Path      []Position `json:"path"`
+	Size      int        `json:"size"`
+	Started   int        `json:"started"`
+	Ended     int        `json:"ended"`
+}
+
+// MigrationRoute is a type of the EvoSim API
+type MigrationRoute struct {
+	ID        int        `json:"id"`
+	Species   string     `json:"species"`
+	Reason    string     `json:"reason"`
+	Waypoints []Position `json:"waypoints"`
+	LearnedAt int        `json:"learned_at"`
+	Uses      int        `json:"uses"`
+	Knowers   int        `json:"knowers"`
+}
+
+// MigrationState is a type of the EvoSim API
+type MigrationState struct {
+	Routes  []SavedMigrationRoute `json:"routes"`
+	History []MigrationPath       `json:"history,omitempty"`
+	NextID  int                   `json:"next_id"`
+}
+
+// MigrationStatus is a type of the EvoSim API
+type MigrationStatus struct {
+	Width    float64          `json:"width"`
+	Height   float64          `json:"height"`
+	Herds    []HerdStatus     `json:"herds"`
+	Routes   []MigrationRoute `json:"routes"`
+	History  []MigrationPath  `json:"history"`
+	Migrants int              `json:"migrants"`
+}
+
+// Milestone is a type of the EvoSim API
+type Milestone struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// MutationOperatorStatus is a type of the EvoSim API
+type MutationOperatorStatus struct {
+	Name         string             `json:"name"`
+	Description  string             `json:"description"`
+	Rate         float64            `json:"rate"`
+	Mutations    int                `json:"mutations"`
+	Share        float64            `json:"share"`
+	TraitChanges map[string]float64 `json:"trait_changes"`
+}
+
+// MutationRateRequest is a type of the EvoSim API
+type MutationRateRequest struct {
+	Operator string  `json:"operator"`
+	Rate     float64 `json:"rate"`
+}
+
+// MutationRecord is a type of the EvoSim API
+type MutationRecord struct {
+	Tick     int     `json:"tick"`
+	EntityID int     `json:"entity_id"`
+	Species  string  `json:"species"`
+	Trait    string  `json:"trait"`
+	Operator string  `json:"operator"`
+	OldValue float64 `json:"old_value"`
+	NewValue float64 `json:"new_value"`
+}
+
+// MutationReport is a type of the EvoSim API
+type MutationReport struct {
+	Operators []MutationOperatorStatus `json:"operators"`
+	Total     int                      `json:"total"`
+	Recent    []MutationRecord         `json:"recent"`
+}
+
+// NetworkConnectionState is a type of the EvoSim API
+type NetworkConnectionState struct {
+	Plant1ID   int     `json:"plant1_id"`
+	Plant2ID   int     `json:"plant2_id"`
+	Type       int     `json:"type"`
+	Strength   float64 `json:"strength"`
+	Health     float64 `json:"health"`
+	Efficiency float64 `json:"efficiency"`
+	Age        int     `json:"age"`
+}
+
+// NetworkData is a type of the EvoSim API
+type NetworkData struct {
+	ConnectionCount int `json:"connection_count"`
+	SignalCount     int `json:"signal_count"`
+	ClusterCount    int `json:"cluster_count"`
+}
+
+// NeuralData is a type of the EvoSim API
+type NeuralData struct {
+	TotalNetworks           int                    `json:"total_networks"`
+	TotalBehaviors          int                    `json:"total_behaviors"`
+	TotalLearningEvents     int                    `json:"total_learning_events"`
+	EmergentBehaviors       int                    `json:"emergent_behaviors"`
+	AvgNetworkComplexity    float64                `json:"avg_network_complexity"`
+	SuccessRate             float64                `json:"success_rate"`
+	TotalExperience         float64                `json:"total_experience"`
+	AvgExperiencePerNetwork float64                `json:"avg_experience_per_network"`
+	BaseLearningRate        float64                `json:"base_learning_rate"`
+	AdaptationRate          float64                `json:"adaptation_rate"`
+	ActiveNetworkCount      int                    `json:"active_network_count"`
+	CollectiveBehaviorCount int                    `json:"collective_behavior_count"`
+	SuccessfulStrategies    []string               `json:"successful_strategies"`
+	EntityNetworks          map[string]interface{} `json:"entity_networks"`
+}
+
+// Neuron is a type of the EvoSim API
+type Neuron struct {
+	ID          int       `json:"id"`
+	Value       float64   `json:"value"`
+	Bias        float64   `json:"bias"`
+	Layer       int       `json:"layer"`
+	Activation  string    `json:"activation"`
+	Connections []Synapse `json:"connections"`
+}
+
+// NicheOverlap is a type of the EvoSim API
+type NicheOverlap struct {
+	SpeciesA        string  `json:"species_a"`
+	SpeciesB        string  `json:"species_b"`
+	Diet            float64 `json:"diet"`
+	Habitat         float64 `json:"habitat"`
+	Activity        float64 `json:"activity"`
+	Overall         float64 `json:"overall"`
+	AlphaAb         float64 `json:"alpha_ab"`
+	AlphaBa         float64 `json:"alpha_ba"`
+	LikelyExclusion bool    `json:"likely_exclusion"`
+	Excluded        *string `json:"excluded,omitempty"`
+	Reason          *string `json:"reason,omitempty"`
+}
+
+// NicheOverlapReport is a type of the EvoSim API
+type NicheOverlapReport struct {
+	Tick       int            `json:"tick"`
+	Profiles   []NicheProfile `json:"profiles"`
+	Pairs      []NicheOverlap `json:"pairs"`
+	Exclusions int            `json:"exclusions"`
+}
+
+// NicheProfile is a type of the EvoSim API
+type NicheProfile struct {
+	Species    string             `json:"species"`
+	Population int                `json:"population"`
+	Diet       map[string]float64 `json:"diet"`
+	Habitat    map[string]float64 `json:"habitat"`
+	Activity   map[string]float64 `json:"activity"`
+}
+
+// NoisePollutionReport is a type of the EvoSim API
+type NoisePollutionReport struct {
+	Tick          int                  `json:"tick"`
+	Sources       []NoiseSource        `json:"sources"`
+	DisturbedArea float64              `json:"disturbed_area"`
+	Species       []SpeciesDisturbance `json:"species"`
+}
+
+// NoiseSource is a type of the EvoSim API
+type NoiseSource struct {
+	Kind     string    `json:"kind"`
+	ID       int       `json:"id"`
+	Name     *string   `json:"name,omitempty"`
+	Start    *Position `json:"start"`
+	End      *Position `json:"end"`
+	Radius   float64   `json:"radius"`
+	Loudness float64   `json:"loudness"`
+}
+
+// OperatorStructure is a type of the EvoSim API
+type OperatorStructure struct {
+	ID          int         `json:"id"`
+	Type        string      `json:"type"`
+	Kind        string      `json:"kind"`
+	Cells       []GridPoint `json:"cells"`
+	CreatedTick int         `json:"created_tick"`
+	Active      bool        `json:"active"`
+}
+
+// OperatorStructureRequest is a type of the EvoSim API
+type OperatorStructureRequest struct {
+	Type          string      `json:"type"`
+	Kind          string      `json:"kind"`
+	Cells         []GridPoint `json:"cells"`
+	From          *GridPoint  `json:"from,omitempty"`
+	To            *GridPoint  `json:"to,omitempty"`
+	BaselineTicks int         `json:"baseline_ticks"`
+}
+
+// OrganelleState is a type of the EvoSim API
+type OrganelleState struct {
+	Type       int     `json:"type"`
+	Count      int     `json:"count"`
+	Efficiency float64 `json:"efficiency"`
+	Energy     float64 `json:"energy"`
+}
+
+// PerformanceReport is a type of the EvoSim API
+type PerformanceReport struct {
+	Tick           int                     `json:"tick"`
+	TicksPerSecond float64                 `json:"ticks_per_second"`
+	UnlimitedSpeed bool                    `json:"unlimited_speed"`
+	MaxCPU         float64                 `json:"max_cpu"`
+	Systems        []ScheduledSystemStatus `json:"systems"`
+}
+
+// PetriDishConfig is a type of the EvoSim API
+type PetriDishConfig struct {
+	Size           float64 `json:"size"`
+	GridSize       int     `json:"grid_size"`
+	Biome          int     `json:"biome"`
+	Temperature    float64 `json:"temperature"`
+	FoodDensity    float64 `json:"food_density"`
+	FoodType       int     `json:"food_type"`
+	ReplenishFood  bool    `json:"replenish_food"`
+	PopulationSize int     `json:"population_size"`
+	Seed           *int    `json:"seed,omitempty"`
+}
+
+// PetriDishCreateRequest is a type of the EvoSim API
+type PetriDishCreateRequest struct {
+	Size           float64 `json:"size"`
+	GridSize       int     `json:"grid_size"`
+	Biome          int     `json:"biome"`
+	Temperature    float64 `json:"temperature"`
+	FoodDensity    float64 `json:"food_density"`
+	FoodType       int     `json:"food_type"`
+	ReplenishFood  bool    `json:"replenish_food"`
+	PopulationSize int     `json:"population_size"`
+	Seed           *int    `json:"seed,omitempty"`
+	Populations    []struct {
+		Species string             `json:"species"`
+		Traits  map[string]float64 `json:"traits"`
+	} `json:"populations"`
+}
+
+// PetriDishStats is a type of the EvoSim API
+type PetriDishStats struct {
+	Tick        int            `json:"tick"`
+	Temperature float64        `json:"temperature"`
+	FoodDensity float64        `json:"food_density"`
+	Plants      int            `json:"plants"`
+	Population  int            `json:"population"`
+	BySpecies   map[string]int `json:"by_species"`
+	MeanEnergy  float64        `json:"mean_energy"`
+}
+
+// PetriDishUpdateRequest is a type of the EvoSim API
+type PetriDishUpdateRequest struct {
+	Ticks       int      `json:"ticks"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	FoodDensity *float64 `json:"food_density,omitempty"`
+}
+
+// Phylogeny is a type of the EvoSim API
+type Phylogeny struct {
+	Tick    int             `json:"tick"`
+	Roots   []PhylogenyNode `json:"roots"`
+	Species int             `json:"species"`
+	Living  int             `json:"living"`
+	Extinct int             `json:"extinct"`
+	Depth   int             `json:"depth"`
+}
+
+// PhylogenyNode is a type of the EvoSim API
+type PhylogenyNode struct {
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	PlantType      string             `json:"plant_type"`
+	ParentID       int                `json:"parent_id"`
+	FormationTick  int                `json:"formation_tick"`
+	ExtinctionTick *int               `json:"extinction_tick,omitempty"`
+	Extinct        bool               `json:"extinct"`
+	Age            int                `json:"age"`
+	Population     int                `json:"population"`
+	PeakPopulation int                `json:"peak_population"`
+	SplitDistance  float64            `json:"split_distance"`
+	Divergence     map[string]float64 `json:"divergence,omitempty"`
+	TraitDrift     map[string]float64 `json:"trait_drift"`
+	Descendants    int                `json:"descendants"`
+	Children       []PhylogenyNode    `json:"children"`
+}
+
+// PhysicsConfig is a type of the EvoSim API
+type PhysicsConfig struct {
+	CollisionDetection bool    `json:"collision_detection"`
+	MaxVelocity        float64 `json:"max_velocity"`
+	Friction           float64 `json:"friction"`
+	WindStrength       float64 `json:"wind_strength"`
+	GravityStrength    float64 `json:"gravity_strength"`
+}
+
+// PhysicsData is a type of the EvoSim API
+type PhysicsData struct {
+	CollisionsLastTick int     `json:"collisions_last_tick"`
+	AverageVelocity    float64 `json:"average_velocity"`
+	TotalMomentum      float64 `json:"total_momentum"`
+}
+
+// PhysicsHistorySnapshot is a type of the EvoSim API
+type PhysicsHistorySnapshot struct {
+	Tick            int     `json:"tick"`
+	Timestamp       string  `json:"timestamp"`
+	Collisions      int     `json:"collisions"`
+	AverageVelocity float64 `json:"average_velocity"`
+	TotalMomentum   float64 `json:"total_momentum"`
+	FirstTick       *int    `json:"first_tick,omitempty"`
+	Samples         *int    `json:"samples,omitempty"`
+}
+
+// PhysicsSnapshot is a type of the EvoSim API
+type PhysicsSnapshot struct {
+	TotalMomentum      float64 `json:"total_momentum"`
+	TotalKineticEnergy float64 `json:"total_kinetic_energy"`
+	CollisionCount     int     `json:"collision_count"`
+	AverageVelocity    float64 `json:"average_velocity"`
+}
+
+// PlantNetworkState is a type of the EvoSim API
+type PlantNetworkState struct {
+	Connections   []NetworkConnectionState `json:"connections"`
+	ActiveSignals []ChemicalSignalState    `json:"active_signals"`
+}
+
+// PlantState is a type of the EvoSim API
+type PlantState struct {
+	ID             int                `json:"id"`
+	Type           int                `json:"type"`
+	Position       *Position          `json:"position"`
+	Energy         float64            `json:"energy"`
+	Age            int                `json:"age"`
+	Size           float64            `json:"size"`
+	Traits         map[string]float64 `json:"traits"`
+	Generation     int                `json:"generation"`
+	IsAlive        bool               `json:"is_alive"`
+	NutritionValue float64            `json:"nutrition_value"`
+	Toxicity       float64            `json:"toxicity"`
+	GrowthRate     float64            `json:"growth_rate"`
+}
+
+// PlantsConfig is a type of the EvoSim API
+type PlantsConfig struct {
+	GrowthRate          float64            `json:"growth_rate"`
+	MaxAge              int                `json:"max_age"`
+	SeedProductionRate  float64            `json:"seed_production_rate"`
+	PollinationRadius   float64            `json:"pollination_radius"`
+	NutrientRequirement map[string]float64 `json:"nutrient_requirement"`
+}
+
+// PlayerGroupData is a type of the EvoSim API
+type PlayerGroupData struct {
+	ID        int         `json:"id"`
+	PlayerID  string      `json:"player_id"`
+	Size      int         `json:"size"`
+	Formation string      `json:"formation"`
+	Order     *string     `json:"order,omitempty"`
+	Arrived   bool        `json:"arrived"`
+	Cells     []GridPoint `json:"cells"`
+}
+
+// PlayerStanding is a type of the EvoSim API
+type PlayerStanding struct {
+	PlayerID       string   `json:"player_id"`
+	Name           string   `json:"name"`
+	Species        []string `json:"species"`
+	Score          float64  `json:"score"`
+	Population     int      `json:"population"`
+	PeakPopulation int      `json:"peak_population"`
+	Territory      float64  `json:"territory"`
+	TechLevel      int      `json:"tech_level"`
+	Eliminated     bool     `json:"eliminated"`
+	EliminatedTick *int     `json:"eliminated_tick,omitempty"`
+}
+
+// PolicySettings is a type of the EvoSim API
+type PolicySettings struct {
+	Aggression   float64 `json:"aggression"`
+	Exploration  float64 `json:"exploration"`
+	Reproduction float64 `json:"reproduction"`
+}
+
+// PollutionCell is a type of the EvoSim API
+type PollutionCell struct {
+	X     int     `json:"x"`
+	Y     int     `json:"y"`
+	Biome string  `json:"biome"`
+	Soil  float64 `json:"soil"`
+	Water float64 `json:"water"`
+}
+
+// PollutionReport is a type of the EvoSim API
+type PollutionReport struct {
+	Tick         int              `json:"tick"`
+	TotalSoil    float64          `json:"total_soil"`
+	TotalWater   float64          `json:"total_water"`
+	Contaminated int              `json:"contaminated"`
+	Tribes       []TribePollution `json:"tribes"`
+	Hotspots     []PollutionCell  `json:"hotspots"`
+}
+
+// PopulationCapRequest is a type of the EvoSim API
+type PopulationCapRequest struct {
+	WorldCap   *int `json:"world_cap,omitempty"`
+	RegionCap  *int `json:"region_cap,omitempty"`
+	RegionSize *int `json:"region_size,omitempty"`
+}
+
+// PopulationCapStatus is a type of the EvoSim API
+type PopulationCapStatus struct {
+	WorldCap      int            `json:"world_cap"`
+	RegionCap     int            `json:"region_cap"`
+	RegionSize    int            `json:"region_size"`
+	Population    int            `json:"population"`
+	Pooled        int            `json:"pooled"`
+	PooledSpecies map[string]int `json:"pooled_species"`
+	Emigrated     int            `json:"emigrated"`
+	Dispersed     int            `json:"dispersed"`
+	Returned      int            `json:"returned"`
+	Settled       int            `json:"settled"`
+	Crowded       []CapRegion    `json:"crowded"`
+}
+
+// PopulationConfigSettings is a type of the EvoSim API
+type PopulationConfigSettings struct {
+	DefaultPopSize    int     `json:"default_pop_size"`
+	MaxPopulation     int     `json:"max_population"`
+	RegionSize        int     `json:"region_size"`
+	RegionSoftCap     int     `json:"region_soft_cap"`
+	MutationRateBase  float64 `json:"mutation_rate_base"`
+	MutationRateRange float64 `json:"mutation_rate_range"`
+	SelectionPressure float64 `json:"selection_pressure"`
+	CarryingCapacity  int     `json:"carrying_capacity"`
+}
+
+// PopulationCreateRequest is a type of the EvoSim API
+type PopulationCreateRequest struct {
+	Name   string             `json:"name"`
+	Traits map[string]float64 `json:"traits,omitempty"`
+}
+
+// PopulationCreateResponse is a type of the EvoSim API
+type PopulationCreateResponse struct {
+	Name   string             `json:"name"`
+	Traits map[string]float64 `json:"traits"`
+}
+
+// PopulationData is a type of the EvoSim API
+type PopulationData struct {
+	Name                   string             `json:"name"`
+	Species                string             `json:"species"`
+	Count                  int                `json:"count"`
+	AvgFitness             float64            `json:"avg_fitness"`
+	AvgEnergy              float64            `json:"avg_energy"`
+	AvgAge                 float64            `json:"avg_age"`
+	Generation             int                `json:"generation"`
+	TraitAverages          map[string]float64 `json:"trait_averages"`
+	DietaryAdaptationCount int                `json:"dietary_adaptation_count"`
+	EnvAdaptationCount     int                `json:"env_adaptation_count"`
+	AvgDietaryFitness      float64            `json:"avg_dietary_fitness"`
+	AvgEnvFitness          float64            `json:"avg_env_fitness"`
+	PlantPreferences       int                `json:"plant_preferences"`
+	PreyPreferences        int                `json:"prey_preferences"`
+}
+
+// PopulationDiff is a type of the EvoSim API
+type PopulationDiff struct {
+	CountA int `json:"count_a"`
+	CountB int `json:"count_b"`
+	Delta  int `json:"delta"`
+}
+
+// PopulationHistorySnapshot is a type of the EvoSim API
+type PopulationHistorySnapshot struct {
+	Tick        int              `json:"tick"`
+	Timestamp   string           `json:"timestamp"`
+	Populations []PopulationData `json:"populations"`
+	FirstTick   *int             `json:"first_tick,omitempty"`
+	Samples     *int             `json:"samples,omitempty"`
+}
+
+// PopulationPyramid is a type of the EvoSim API
+type PopulationPyramid struct {
+	Group      string          `json:"group"`
+	Population int             `json:"population"`
+	YouthShare float64         `json:"youth_share"`
+	Cohorts    []PyramidCohort `json:"cohorts"`
+}
+
+// PopulationSelection is a type of the EvoSim API
+type PopulationSelection struct {
+	Population     string  `json:"population"`
+	Strategy       string  `json:"strategy"`
+	Generation     int     `json:"generation"`
+	Size           int     `json:"size"`
+	AverageFitness float64 `json:"average_fitness"`
+	BestFitness    float64 `json:"best_fitness"`
+	NoveltyArchive *int    `json:"novelty_archive,omitempty"`
+}
+
+// Position is a type of the EvoSim API
+type Position struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Prediction is a type of the EvoSim API
+type Prediction struct {
+	Spectator string `json:"spectator"`
+	Option    string `json:"option"`
+	Stake     int    `json:"stake"`
+	Payout    *int   `json:"payout,omitempty"`
+	Tick      int    `json:"tick"`
+}
+
+// PredictionRequest is a type of the EvoSim API
+type PredictionRequest struct {
+	RoundID   int    `json:"round_id"`
+	Spectator string `json:"spectator"`
+	Option    string `json:"option"`
+	Stake     int    `json:"stake"`
+}
+
+// PredictionRound is a type of the EvoSim API
+type PredictionRound struct {
+	ID            int          `json:"id"`
+	Question      string       `json:"question"`
+	Kind          string       `json:"kind"`
+	Options       []string     `json:"options"`
+	Trigger       *string      `json:"trigger,omitempty"`
+	OpenTick      int          `json:"open_tick"`
+	BetsCloseTick int          `json:"bets_close_tick"`
+	ResolveTick   int          `json:"resolve_tick"`
+	Status        string       `json:"status"`
+	Outcome       []string     `json:"outcome,omitempty"`
+	Pool          int          `json:"pool"`
+	Predictions   []Prediction `json:"predictions"`
+}
+
+// PredictionRoundRequest is a type of the EvoSim API
+type PredictionRoundRequest struct {
+	Question string   `json:"question"`
+	Kind     string   `json:"kind"`
+	Options  []string `json:"options"`
+	Duration int      `json:"duration"`
+}
+
+// PressureDetail is a type of the EvoSim API
+type PressureDetail struct {
+	ID        int     `json:"id"`
+	Type      string  `json:"type"`
+	Name      string  `json:"name"`
+	Severity  float64 `json:"severity"`
+	Duration  int     `json:"duration"`
+	AffectedX float64 `json:"affected_x"`
+	AffectedY float64 `json:"affected_y"`
+	Radius    float64 `json:"radius"`
+}
+
+// PyramidCohort is a type of the EvoSim API
+type PyramidCohort struct {
+	Label   string `json:"label"`
+	Female  int    `json:"female"`
+	Male    int    `json:"male"`
+	Asexual int    `json:"asexual"`
+}
+
+// RadiationEpisode is a type of the EvoSim API
+type RadiationEpisode struct {
+	Tick      int                `json:"tick"`
+	StartTick int                `json:"start_tick"`
+	Ancestor  string             `json:"ancestor"`
+	Daughters []string           `json:"daughters"`
+	Niches    []string           `json:"niches"`
+	Before    map[string]int     `json:"before"`
+	After     map[string]int     `json:"after"`
+	Disparity map[string]float64 `json:"disparity"`
+}
+
+// RedQueenAxis is a type of the EvoSim API
+type RedQueenAxis struct {
+	AttackerTrait     string    `json:"attacker_trait"`
+	DefenderTrait     string    `json:"defender_trait"`
+	Correlation       float64   `json:"correlation"`
+	BestLag           int       `json:"best_lag"`
+	BestCorrelation   float64   `json:"best_correlation"`
+	Leader            string    `json:"leader"`
+	AttackerAmplitude float64   `json:"attacker_amplitude"`
+	DefenderAmplitude float64   `json:"defender_amplitude"`
+	Ticks             []int     `json:"ticks"`
+	AttackerSeries    []float64 `json:"attacker_series"`
+	DefenderSeries    []float64 `json:"defender_series"`
+}
+
+// RedQueenReport is a type of the EvoSim API
+type RedQueenReport struct {
+	Kind         string         `json:"kind"`
+	Attacker     string         `json:"attacker"`
+	Defender     string         `json:"defender"`
+	Interactions int            `json:"interactions"`
+	Samples      int            `json:"samples"`
+	Axes         []RedQueenAxis `json:"axes"`
+}
+
+// RegistryEntry is a type of the EvoSim API
+type RegistryEntry struct {
+	ID          string    `json:"id"`
+	Kind        string    `json:"kind"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Author      string    `json:"author"`
+	Sha256      string    `json:"sha256"`
+	Signature   string    `json:"signature"`
+	PublicKey   string    `json:"public_key"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// RegistryUploadRequest is a type of the EvoSim API
+type RegistryUploadRequest struct {
+	Kind        string `json:"kind"`
+	EntityID    int    `json:"entity_id"`
+	MateID      int    `json:"mate_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Author      string `json:"author"`
+}
+
+// RendererLegend is a type of the EvoSim API
+type RendererLegend struct {
+	Renderer string          `json:"renderer"`
+	Sections []LegendSection `json:"sections"`
+}
+
+// ReproductionData is a type of the EvoSim API
+type ReproductionData struct {
+	ActiveEggs            int            `json:"active_eggs"`
+	DecayingItems         int            `json:"decaying_items"`
+	PregnantEntities      int            `json:"pregnant_entities"`
+	ReadyToMate           int            `json:"ready_to_mate"`
+	MatingSeasonEntities  int            `json:"mating_season_entities"`
+	MigratingEntities     int            `json:"migrating_entities"`
+	ReproductionModes     map[string]int `json:"reproduction_modes"`
+	MatingStrategies      map[string]int `json:"mating_strategies"`
+	SeasonalMatingRate    float64        `json:"seasonal_mating_rate"`
+	TerritoriesWithMating int            `json:"territories_with_mating"`
+	CrossSpeciesMating    int            `json:"cross_species_mating"`
+}
+
+// ResourceBudgetConfig is a type of the EvoSim API
+type ResourceBudgetConfig struct {
+	Enabled              bool    `json:"enabled"`
+	SunlightPerCell      float64 `json:"sunlight_per_cell"`
+	WaterPerCell         float64 `json:"water_per_cell"`
+	NutrientsPerCell     float64 `json:"nutrients_per_cell"`
+	WaterRegeneration    float64 `json:"water_regeneration"`
+	NutrientRegeneration float64 `json:"nutrient_regeneration"`
+	PlantUptake          float64 `json:"plant_uptake"`
+	Photosynthesis       float64 `json:"photosynthesis"`
+	SoilBonus            float64 `json:"soil_bonus"`
+	CreaturesPerCell     float64 `json:"creatures_per_cell"`
+	BiomassPerCreature   float64 `json:"biomass_per_creature"`
+}
+
+// ResourceBudgetStatus is a type of the EvoSim API
+type ResourceBudgetStatus struct {
+	Enabled          bool                  `json:"enabled"`
+	Settings         *ResourceBudgetConfig `json:"settings"`
+	Tick             int                   `json:"tick"`
+	Sunlight         *ResourcePool         `json:"sunlight"`
+	Water            *ResourcePool         `json:"water"`
+	Nutrients        *ResourcePool         `json:"nutrients"`
+	PreyBiomass      float64               `json:"prey_biomass"`
+	Plants           int                   `json:"plants"`
+	Creatures        int                   `json:"creatures"`
+	PlantSupply      float64               `json:"plant_supply"`
+	CreatureCapacity float64               `json:"creature_capacity"`
+	SeedlingsRefused int                   `json:"seedlings_refused"`
+	Biomes           []BiomeResourceBudget `json:"biomes"`
+}
+
+// ResourcePolicy is a type of the EvoSim API
+type ResourcePolicy struct {
+	Limit     int  `json:"limit"`
+	AutoPrune bool `json:"auto_prune"`
+}
+
+// ResourcePolicyRequest is a type of the EvoSim API
+type ResourcePolicyRequest struct {
+	Store     string `json:"store"`
+	Limit     *int   `json:"limit,omitempty"`
+	AutoPrune *bool  `json:"auto_prune,omitempty"`
+	Prune     bool   `json:"prune"`
+}
+
+// ResourcePool is a type of the EvoSim API
+type ResourcePool struct {
+	Level    float64 `json:"level"`
+	Capacity float64 `json:"capacity"`
+}
+
+// ResourceReport is a type of the EvoSim API
+type ResourceReport struct {
+	Tick           int                   `json:"tick"`
+	SampleInterval int                   `json:"sample_interval"`
+	HeapBytes      int                   `json:"heap_bytes"`
+	HeapObjects    int                   `json:"heap_objects"`
+	HeapHistory    []int                 `json:"heap_history"`
+	EstimatedBytes int                   `json:"estimated_bytes"`
+	Stores         []ResourceStoreStatus `json:"stores"`
+	Alerts         int                   `json:"alerts"`
+}
+
+// ResourceStoreStatus is a type of the EvoSim API
+type ResourceStoreStatus struct {
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	Count          int             `json:"count"`
+	EstimatedBytes int             `json:"estimated_bytes"`
+	Growth         int             `json:"growth"`
+	Prunable       bool            `json:"prunable"`
+	PrunesOrphans  bool            `json:"prunes_orphans"`
+	Policy         *ResourcePolicy `json:"policy"`
+	Pruned         int             `json:"pruned"`
+	Leak           *string         `json:"leak,omitempty"`
+	History        []int           `json:"history"`
+}
+
+// RunCertificate is a type of the EvoSim API
+type RunCertificate struct {
+	Seed       int               `json:"seed"`
+	Primitive  bool              `json:"primitive"`
+	RngStreams *bool             `json:"rng_streams,omitempty"`
+	Unordered  *bool             `json:"unordered,omitempty"`
+	Config     *WorldConfig      `json:"config"`
+	ConfigHash string            `json:"config_hash"`
+	StartTick  int               `json:"start_tick"`
+	EpochTicks int               `json:"epoch_ticks"`
+	Genesis    string            `json:"genesis"`
+	Links      []CertificateLink `json:"links"`
+	Head       string            `json:"head"`
+}
+
+// SaveDiff is a type of the EvoSim API
+type SaveDiff struct {
+	TickA       int                                         `json:"tick_a"`
+	TickB       int                                         `json:"tick_b"`
+	PlantCountA int                                         `json:"plant_count_a"`
+	PlantCountB int                                         `json:"plant_count_b"`
+	Populations map[string]PopulationDiff                   `json:"populations"`
+	Traits      map[string]map[string]TraitDistributionDiff `json:"traits"`
+	Geography   *GeographyDiff                              `json:"geography"`
+	Tech        []TechDiff                                  `json:"tech"`
+}
+
+// SaveIssue is a type of the EvoSim API
+type SaveIssue struct {
+	Severity string `json:"severity"`
+	Kind     string `json:"kind"`
+	Message  string `json:"message"`
+	Repaired bool   `json:"repaired"`
+}
+
+// SaveResponse is a type of the EvoSim API
+type SaveResponse struct {
+	Filename string `json:"filename"`
+	Tick     int    `json:"tick"`
+}
+
+// SaveValidationReport is a type of the EvoSim API
+type SaveValidationReport struct {
+	Issues    []SaveIssue `json:"issues"`
+	Truncated bool        `json:"truncated"`
+	Repaired  bool        `json:"repaired"`
+}
+
+// SavedMigrationRoute is a type of the EvoSim API
+type SavedMigrationRoute struct {
+	ID        int        `json:"id"`
+	Species   string     `json:"species"`
+	Reason    string     `json:"reason"`
+	Waypoints []Position `json:"waypoints"`
+	LearnedAt int        `json:"learned_at"`
+	Uses      int        `json:"uses"`
+	Knowers   int        `json:"knowers"`
+	KnowerIDs []int      `json:"knower_ids"`
+}
+
+// ScheduleUpdateRequest is a type of the EvoSim API
+type ScheduleUpdateRequest struct {
+	System   string `json:"system"`
+	Interval *int   `json:"interval,omitempty"`
+	Trigger  *bool  `json:"trigger,omitempty"`
+}
+
+// ScheduledSystemStatus is a type of the EvoSim API
+type ScheduledSystemStatus struct {
+	Name            string  `json:"name"`
+	Description     string  `json:"description"`
+	Interval        int     `json:"interval"`
+	DefaultInterval int     `json:"default_interval"`
+	Triggered       bool    `json:"triggered"`
+	Runs            int     `json:"runs"`
+	LastRunTick     int     `json:"last_run_tick"`
+	LastDurationMs  float64 `json:"last_duration_ms"`
+	MeanDurationMs  float64 `json:"mean_duration_ms"`
+}
+
+// ScheduledWorldEvent is a type of the EvoSim API
+type ScheduledWorldEvent struct {
+	Tick     int    `json:"tick"`
+	Name     string `json:"name"`
+	Duration int    `json:"duration"`
+}
+
+// SeasonEffects is a type of the EvoSim API
+type SeasonEffects struct {
+	Temperature float64 `json:"temperature"`
+	PlantGrowth float64 `json:"plant_growth"`
+	Food        float64 `json:"food"`
+	Mating      bool    `json:"mating"`
+	Migration   bool    `json:"migration"`
+}
+
+// SeasonStatus is a type of the EvoSim API
+type SeasonStatus struct {
+	Season    string         `json:"season"`
+	Day       int            `json:"day"`
+	Length    int            `json:"length"`
+	Next      string         `json:"next"`
+	Year      int            `json:"year"`
+	Effects   *SeasonEffects `json:"effects"`
+	Migrants  int            `json:"migrants"`
+	SinceTick int            `json:"since_tick"`
+}
+
+// SeasonsConfig is a type of the EvoSim API
+type SeasonsConfig struct {
+	Spring *SeasonEffects `json:"spring"`
+	Summer *SeasonEffects `json:"summer"`
+	Autumn *SeasonEffects `json:"autumn"`
+	Winter *SeasonEffects `json:"winter"`
+}
+
+// SelectionReport is a type of the EvoSim API
+type SelectionReport struct {
+	Strategies  []SelectionStrategyInfo `json:"strategies"`
+	Populations []PopulationSelection   `json:"populations"`
+}
+
+// SelectionStrategyInfo is a type of the EvoSim API
+type SelectionStrategyInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// SelectionStrategyRequest is a type of the EvoSim API
+type SelectionStrategyRequest struct {
+	Population string `json:"population"`
+	Strategy   string `json:"strategy"`
+}
+
+// SimulationConfig is a type of the EvoSim API
+type SimulationConfig struct {
+	Time           *TimeConfig               `json:"time"`
+	DayNight       *DayNightConfig           `json:"day_night"`
+	Seasons        *SeasonsConfig            `json:"seasons"`
+	Energy         *EnergyConfig             `json:"energy"`
+	Population     *PopulationConfigSettings `json:"population"`
+	Physics        *PhysicsConfig            `json:"physics"`
+	World          *WorldConfigSettings      `json:"world"`
+	Evolution      *EvolutionConfig          `json:"evolution"`
+	Biomes         *BiomesConfig             `json:"biomes"`
+	Plants         *PlantsConfig             `json:"plants"`
+	ResourceBudget *ResourceBudgetConfig     `json:"resource_budget"`
+	Timescales     *TimescaleConfig          `json:"timescales"`
+	Web            *WebConfig                `json:"web"`
+	Gallery        *GalleryConfig            `json:"gallery"`
+	Schedule       map[string]int            `json:"schedule,omitempty"`
+}
+
+// SimulationState is a type of the EvoSim API
+type SimulationState struct {
+	SchemaVersion int                    `json:"schema_version"`
+	SavedAt       time.Time              `json:"saved_at"`
+	Tick          int                    `json:"tick"`
+	NextID        int                    `json:"next_id"`
+	NextPlantID   int                    `json:"next_plant_id"`
+	Config        *WorldConfig           `json:"config"`
+	Seed          *int                   `json:"seed,omitempty"`
+	Entities      []EntityState          `json:"entities"`
+	Plants        []PlantState           `json:"plants"`
+	Biomes        [][]int                `json:"biomes"`
+	Events        []WorldEventState      `json:"events"`
+	Time          *AdvancedTimeState     `json:"time"`
+	Wind          *WindSystemState       `json:"wind"`
+	Species       *SpeciationSystemState `json:"species"`
+	Network       *PlantNetworkState     `json:"network"`
+	Tribes        []TribeState           `json:"tribes,omitempty"`
+	Achievements  *AchievementState      `json:"achievements,omitempty"`
+	Migrations    *MigrationState        `json:"migrations,omitempty"`
+	Dispersal     *DispersalState        `json:"dispersal,omitempty"`
+	Selection     map[string]string      `json:"selection,omitempty"`
+}
+
+// SpeciationSystemState is a type of the EvoSim API
+type SpeciationSystemState struct {
+	Species       map[string]SpeciesState `json:"species"`
+	NextSpeciesID int                     `json:"next_species_id"`
+}
+
+// SpeciesData is a type of the EvoSim API
+type SpeciesData struct {
+	ActiveSpecies             int                 `json:"active_species"`
+	ExtinctSpecies            int                 `json:"extinct_species"`
+	SpeciesDetails            []SpeciesDetailData `json:"species_details"`
+	TotalSpeciesEver          int                 `json:"total_species_ever"`
+	SpeciesWithMembers        int                 `json:"species_with_members"`
+	SpeciesAwaitingExtinction int                 `json:"species_awaiting_extinction"`
+	HasSpeciationSystem       bool                `json:"has_speciation_system"`
+}
+
+// SpeciesDetailData is a type of the EvoSim API
+type SpeciesDetailData struct {
+	ID                 int                `json:"id"`
+	Name               string             `json:"name"`
+	Population         int                `json:"population"`
+	IsExtinct          bool               `json:"is_extinct"`
+	FormationTick      int                `json:"formation_tick"`
+	ExtinctionTick     int                `json:"extinction_tick"`
+	PeakPopulation     int                `json:"peak_population"`
+	AwaitingExtinction bool               `json:"awaiting_extinction"`
+	Traits             map[string]float64 `json:"traits,omitempty"`
+}
+
+// SpeciesDisturbance is a type of the EvoSim API
+type SpeciesDisturbance struct {
+	Species     string  `json:"species"`
+	Population  int     `json:"population"`
+	InZone      int     `json:"in_zone"`
+	ZoneShare   float64 `json:"zone_share"`
+	Sensitivity float64 `json:"sensitivity"`
+	Response    string  `json:"response"`
+	Displaced   int     `json:"displaced"`
+	EnergyLost  float64 `json:"energy_lost"`
+}
+
+// SpeciesPolicy is a type of the EvoSim API
+type SpeciesPolicy struct {
+	Species   string          `json:"species"`
+	PlayerID  string          `json:"player_id"`
+	Target    *PolicySettings `json:"target"`
+	Effective *PolicySettings `json:"effective"`
+	SetTick   int             `json:"set_tick"`
+}
+
+// SpeciesProfile is a type of the EvoSim API
+type SpeciesProfile struct {
+	Name           string                       `json:"name"`
+	Kind           string                       `json:"kind"`
+	ID             *int                         `json:"id,omitempty"`
+	Population     int                          `json:"population"`
+	PeakPopulation int                          `json:"peak_population"`
+	Extinct        bool                         `json:"extinct"`
+	FormationTick  int                          `json:"formation_tick"`
+	ExtinctionTick *int                         `json:"extinction_tick,omitempty"`
+	Traits         map[string]TraitDistribution `json:"traits"`
+	Relatives      []SpeciesRelative            `json:"relatives"`
+	MemberIDs      []int                        `json:"member_ids"`
+}
+
+// SpeciesRelative is a type of the EvoSim API
+type SpeciesRelative struct {
+	Name     string  `json:"name"`
+	Relation string  `json:"relation"`
+	Distance float64 `json:"distance"`
+}
+
+// SpeciesState is a type of the EvoSim API
+type SpeciesState struct {
+	ID         int                `json:"id"`
+	Name       string             `json:"name"`
+	ParentID   int                `json:"parent_id"`
+	CreatedAt  int                `json:"created_at"`
+	ExtinctAt  int                `json:"extinct_at"`
+	IsExtinct  bool               `json:"is_extinct"`
+	Population int                `json:"population"`
+	BaseTraits map[string]float64 `json:"base_traits"`
+}
+
+// Spectator is a type of the EvoSim API
+type Spectator struct {
+	Name    string `json:"name"`
+	Points  int    `json:"points"`
+	Correct int    `json:"correct"`
+	Wrong   int    `json:"wrong"`
+}
+
+// SpilloverEvent is a type of the EvoSim API
+type SpilloverEvent struct {
+	Tick        int     `json:"tick"`
+	Direction   string  `json:"direction"`
+	TribeID     int     `json:"tribe_id"`
+	TribeName   string  `json:"tribe_name"`
+	Route       string  `json:"route"`
+	FromID      int     `json:"from_id"`
+	FromSpecies string  `json:"from_species"`
+	ToID        int     `json:"to_id"`
+	ToSpecies   string  `json:"to_species"`
+	Contacts    float64 `json:"contacts"`
+	Probability float64 `json:"probability"`
+}
+
+// StatisticalData is a type of the EvoSim API
+type StatisticalData struct {
+	TotalEvents     int                      `json:"total_events"`
+	TotalSnapshots  int                      `json:"total_snapshots"`
+	TotalAnomalies  int                      `json:"total_anomalies"`
+	TotalEnergy     float64                  `json:"total_energy"`
+	EnergyChange    float64                  `json:"energy_change"`
+	EnergyTrend     string                   `json:"energy_trend"`
+	PopulationTrend string                   `json:"population_trend"`
+	RecentEvents    []StatisticalEventData   `json:"recent_events"`
+	LatestSnapshot  *StatisticalSnapshotData `json:"latest_snapshot,omitempty"`
+}
+
+// StatisticalEvent is a type of the EvoSim API
+type StatisticalEvent struct {
+	Timestamp   time.Time              `json:"timestamp"`
+	Tick        int                    `json:"tick"`
+	EventType   string                 `json:"event_type"`
+	Category    string                 `json:"category"`
+	EntityID    *int                   `json:"entity_id,omitempty"`
+	PlantID     *int                   `json:"plant_id,omitempty"`
+	Position    *Position              `json:"position,omitempty"`
+	OldValue    interface{}            `json:"old_value,omitempty"`
+	NewValue    interface{}            `json:"new_value,omitempty"`
+	Change      *float64               `json:"change,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata"`
+	ImpactedIDs []int                  `json:"impacted_ids,omitempty"`
+}
+
+// StatisticalEventData is a type of the EvoSim API
+type StatisticalEventData struct {
+	Tick        int     `json:"tick"`
+	Type        string  `json:"type"`
+	Target      string  `json:"target"`
+	Change      float64 `json:"change"`
+	Description string  `json:"description"`
+}
+
+// StatisticalSnapshot is a type of the EvoSim API
+type StatisticalSnapshot struct {
+	Tick                 int                    `json:"tick"`
+	Timestamp            time.Time              `json:"timestamp"`
+	TotalEntities        int                    `json:"total_entities"`
+	TotalPlants          int                    `json:"total_plants"`
+	TotalEnergy          float64                `json:"total_energy"`
+	SpeciesCount         int                    `json:"species_count"`
+	PopulationsBySpecies map[string]int         `json:"populations_by_species"`
+	TraitDistributions   map[string][]float64   `json:"trait_distributions"`
+	BiomeDistributions   map[string]int         `json:"biome_distributions"`
+	ResourceDistribution map[string]float64     `json:"resource_distribution"`
+	PhysicsMetrics       *PhysicsSnapshot       `json:"physics_metrics"`
+	CommunicationMetrics *CommunicationSnapshot `json:"communication_metrics"`
+}
+
+// StatisticalSnapshotData is a type of the EvoSim API
+type StatisticalSnapshotData struct {
+	Tick            int                `json:"tick"`
+	TotalEnergy     float64            `json:"total_energy"`
+	PopulationCount int                `json:"population_count"`
+	TraitAverages   map[string]float64 `json:"trait_averages"`
+	PhysicsMetrics  map[string]float64 `json:"physics_metrics"`
+}
+
+// StepRequest is a type of the EvoSim API
+type StepRequest struct {
+	Ticks int `json:"ticks"`
+}
+
+// StepResult is a type of the EvoSim API
+type StepResult struct {
+	Requested   int               `json:"requested"`
+	Executed    int               `json:"executed"`
+	Tick        int               `json:"tick"`
+	Digest      string            `json:"digest"`
+	Subsystems  map[string]string `json:"subsystems"`
+	StoppedBy   *string           `json:"stopped_by,omitempty"`
+	EntityCount int               `json:"entity_count"`
+	PlantCount  int               `json:"plant_count"`
+	Populations map[string]int    `json:"populations"`
+}
+
+// SymbioticRelationshipData is a type of the EvoSim API
+type SymbioticRelationshipData struct {
+	TotalRelationships      int            `json:"total_relationships"`
+	ActiveRelationships     int            `json:"active_relationships"`
+	ActiveParasitic         int            `json:"active_parasitic"`
+	ActiveMutualistic       int            `json:"active_mutualistic"`
+	ActiveCommensal         int            `json:"active_commensal"`
+	AverageRelationshipAge  float64        `json:"average_relationship_age"`
+	DiseaseTransmissionRate float64        `json:"disease_transmission_rate"`
+	AverageVirulence        float64        `json:"average_virulence"`
+	AverageTransmission     float64        `json:"average_transmission"`
+	RelationshipTypes       map[string]int `json:"relationship_types"`
+}
+
+// Synapse is a type of the EvoSim API
+type Synapse struct {
+	FromNeuronID int     `json:"from_neuron_id"`
+	ToNeuronID   int     `json:"to_neuron_id"`
+	Weight       float64 `json:"weight"`
+	Strength     float64 `json:"strength"`
+	LastActive   int     `json:"last_active"`
+}
+
+// TechDiff is a type of the EvoSim API
+type TechDiff struct {
+	TribeID int    `json:"tribe_id"`
+	Name    string `json:"name"`
+	LevelA  int    `json:"level_a"`
+	LevelB  int    `json:"level_b"`
+}
+
+// TerraformRequest is a type of the EvoSim API
+type TerraformRequest struct {
+	Biome string      `json:"biome"`
+	Cells []GridPoint `json:"cells"`
+	From  *GridPoint  `json:"from,omitempty"`
+	To    *GridPoint  `json:"to,omitempty"`
+}
+
+// TimeConfig is a type of the EvoSim API
+type TimeConfig struct {
+	TicksPerDay       int     `json:"ticks_per_day"`
+	DaysPerSeason     int     `json:"days_per_season"`
+	DailyEnergyBase   float64 `json:"daily_energy_base"`
+	NightPenalty      float64 `json:"night_penalty"`
+	SeasonalVariation float64 `json:"seasonal_variation"`
+}
+
+// TimelineBucket is a type of the EvoSim API
+type TimelineBucket struct {
+	StartTick int            `json:"start_tick"`
+	EndTick   int            `json:"end_tick"`
+	Counts    map[string]int `json:"counts"`
+}
+
+// TimelineMarker is a type of the EvoSim API
+type TimelineMarker struct {
+	ID          int    `json:"id"`
+	Tick        int    `json:"tick"`
+	Type        string `json:"type"`
+	Category    string `json:"category"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+}
+
+// TimescaleAudit is a type of the EvoSim API
+type TimescaleAudit struct {
+	Profile           string              `json:"profile"`
+	Scales            *TimescaleConfig    `json:"scales"`
+	TicksPerDay       int                 `json:"ticks_per_day"`
+	Reference         string              `json:"reference"`
+	ReferenceLifespan int                 `json:"reference_lifespan"`
+	ReferenceMaturity int                 `json:"reference_maturity"`
+	YearTicks         int                 `json:"year_ticks"`
+	Constants         []TimescaleConstant `json:"constants"`
+	Findings          []TimescaleFinding  `json:"findings"`
+}
+
+// TimescaleConfig is a type of the EvoSim API
+type TimescaleConfig struct {
+	Profile            string  `json:"profile"`
+	LifespanScale      float64 `json:"lifespan_scale"`
+	EventDurationScale float64 `json:"event_duration_scale"`
+	MutationRateScale  float64 `json:"mutation_rate_scale"`
+	GestationScale     float64 `json:"gestation_scale"`
+	DecayScale         float64 `json:"decay_scale"`
+	SeasonScale        float64 `json:"season_scale"`
+}
+
+// TimescaleConstant is a type of the EvoSim API
+type TimescaleConstant struct {
+	Group      string  `json:"group"`
+	Name       string  `json:"name"`
+	MinTicks   int     `json:"min_ticks"`
+	MaxTicks   int     `json:"max_ticks"`
+	MinDays    float64 `json:"min_days"`
+	MaxDays    float64 `json:"max_days"`
+	Lifespans  float64 `json:"lifespans"`
+	Rescalable bool    `json:"rescalable"`
+}
+
+// TimescaleFinding is a type of the EvoSim API
+type TimescaleFinding struct {
+	Check          string   `json:"check"`
+	Groups         []string `json:"groups"`
+	Message        string   `json:"message"`
+	Ratio          float64  `json:"ratio"`
+	SuggestedGroup string   `json:"suggested_group"`
+	SuggestedScale float64  `json:"suggested_scale"`
+}
+
+// TimescaleRequest is a type of the EvoSim API
+type TimescaleRequest struct {
+	Group  string  `json:"group"`
+	Factor float64 `json:"factor"`
+}
+
+// ToolData is a type of the EvoSim API
+type ToolData struct {
+	TotalTools    int            `json:"total_tools"`
+	OwnedTools    int            `json:"owned_tools"`
+	DroppedTools  int            `json:"dropped_tools"`
+	AvgDurability float64        `json:"avg_durability"`
+	AvgEfficiency float64        `json:"avg_efficiency"`
+	ToolTypes     map[string]int `json:"tool_types"`
+}
+
+// TopologyData is a type of the EvoSim API
+type TopologyData struct {
+	ElevationRange string `json:"elevation_range"`
+	FluidRegions   int    `json:"fluid_regions"`
+	GeologicalAge  int    `json:"geological_age"`
+}
+
+// TradeAgreementData is a type of the EvoSim API
+type TradeAgreementData struct {
+	ID               int                `json:"id"`
+	Colony1ID        int                `json:"colony1_id"`
+	Colony2ID        int                `json:"colony2_id"`
+	OfferedResources map[string]float64 `json:"offered_resources"`
+	WantedResources  map[string]float64 `json:"wanted_resources"`
+	Duration         int                `json:"duration"`
+	IsActive         bool               `json:"is_active"`
+}
+
+// TraitColors is a type of the EvoSim API
+type TraitColors struct {
+	Diet       string `json:"diet"`
+	Aggression string `json:"aggression"`
+}
+
+// TraitDefinition is a type of the EvoSim API
+type TraitDefinition struct {
+	Name        string   `json:"name"`
+	Subject     string   `json:"subject"`
+	Kind        string   `json:"kind"`
+	Min         float64  `json:"min"`
+	Max         float64  `json:"max"`
+	Description string   `json:"description"`
+	Systems     []string `json:"systems"`
+	Prefix      *bool    `json:"prefix,omitempty"`
+	Custom      *bool    `json:"custom,omitempty"`
+}
+
+// TraitDistribution is a type of the EvoSim API
+type TraitDistribution struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"std_dev"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+}
+
+// TraitDistributionDiff is a type of the EvoSim API
+type TraitDistributionDiff struct {
+	MeanA     float64 `json:"mean_a"`
+	MeanB     float64 `json:"mean_b"`
+	StdDevA   float64 `json:"std_dev_a"`
+	StdDevB   float64 `json:"std_dev_b"`
+	MeanDelta float64 `json:"mean_delta"`
+}
+
+// TraitEditRequest is a type of the EvoSim API
+type TraitEditRequest struct {
+	EntityID int     `json:"entity_id"`
+	Trait    string  `json:"trait"`
+	Value    float64 `json:"value"`
+}
+
+// TraitLegend is a type of the EvoSim API
+type TraitLegend struct {
+	Scale  *LegendScale        `json:"scale"`
+	Colors []LegendColorScheme `json:"colors"`
+}
+
+// TribeFoodStore is a type of the EvoSim API
+type TribeFoodStore struct {
+	TribeID      int      `json:"tribe_id"`
+	Name         string   `json:"name"`
+	TechLevel    int      `json:"tech_level"`
+	Preservation []string `json:"preservation"`
+	Stored       float64  `json:"stored"`
+	Cover        float64  `json:"cover"`
+	Spoilage     float64  `json:"spoilage"`
+	Spoiled      float64  `json:"spoiled"`
+	Status       string   `json:"status"`
+	Famines      int      `json:"famines"`
+	FamineTicks  int      `json:"famine_ticks"`
+}
+
+// TribePollution is a type of the EvoSim API
+type TribePollution struct {
+	TribeID    int     `json:"tribe_id"`
+	Name       string  `json:"name"`
+	TechLevel  int     `json:"tech_level"`
+	Members    int     `json:"members"`
+	Produced   float64 `json:"produced"`
+	Released   float64 `json:"released"`
+	Treatment  float64 `json:"treatment"`
+	Exposure   float64 `json:"exposure"`
+	EnergyLost float64 `json:"energy_lost"`
+}
+
+// TribeState is a type of the EvoSim API
+type TribeState struct {
+	ID          int                `json:"id"`
+	Name        string             `json:"name"`
+	TechLevel   int                `json:"tech_level"`
+	MemberCount int                `json:"member_count"`
+	Resources   map[string]float64 `json:"resources"`
+}
+
+// Tutorial is a type of the EvoSim API
+type Tutorial struct {
+	Scenario  string         `json:"scenario"`
+	Name      string         `json:"name"`
+	Status    string         `json:"status"`
+	StartTick int            `json:"start_tick"`
+	EndTick   *int           `json:"end_tick,omitempty"`
+	Current   int            `json:"current"`
+	Steps     []TutorialStep `json:"steps"`
+}
+
+// TutorialRequest is a type of the EvoSim API
+type TutorialRequest struct {
+	Scenario string `json:"scenario"`
+}
+
+// TutorialScenario is a type of the EvoSim API
+type TutorialScenario struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// TutorialStep is a type of the EvoSim API
+type TutorialStep struct {
+	Title        string     `json:"title"`
+	Instructions string     `json:"instructions"`
+	Action       *string    `json:"action,omitempty"`
+	Target       *GridPoint `json:"target,omitempty"`
+	Done         bool       `json:"done"`
+	DoneTick     *int       `json:"done_tick,omitempty"`
+	Observation  *string    `json:"observation,omitempty"`
+}
+
+// ViewData is a type of the EvoSim API
+type ViewData struct {
+	Tick                   int                            `json:"tick"`
+	TimeString             string                         `json:"time_string"`
+	Season                 *SeasonStatus                  `json:"season"`
+	EntityCount            int                            `json:"entity_count"`
+	PlantCount             int                            `json:"plant_count"`
+	PopulationCount        int                            `json:"population_count"`
+	EventCount             int                            `json:"event_count"`
+	SpeedMultiplier        float64                        `json:"speed_multiplier"`
+	Paused                 bool                           `json:"paused"`
+	UnlimitedSpeed         bool                           `json:"unlimited_speed"`
+	MaxCPU                 float64                        `json:"max_cpu"`
+	TicksPerSecond         float64                        `json:"ticks_per_second"`
+	BreakpointHit          *string                        `json:"breakpoint_hit,omitempty"`
+	Follow                 *FollowTrack                   `json:"follow,omitempty"`
+	ViewportX              int                            `json:"viewport_x"`
+	ViewportY              int                            `json:"viewport_y"`
+	ZoomLevel              float64                        `json:"zoom_level"`
+	GridWidth              int                            `json:"grid_width"`
+	GridHeight             int                            `json:"grid_height"`
+	GridShape              string                         `json:"grid_shape"`
+	Grid                   [][]CellData                   `json:"grid"`
+	Chunks                 []GridChunk                    `json:"chunks,omitempty"`
+	ChunkSize              *int                           `json:"chunk_size,omitempty"`
+	Stats                  map[string]interface{}         `json:"stats"`
+	Events                 []EventData                    `json:"events"`
+	Alerts                 []AlertData                    `json:"alerts"`
+	PlayerGroups           []PlayerGroupData              `json:"player_groups"`
+	SpeciesPolicies        []SpeciesPolicy                `json:"species_policies"`
+	Game                   *CompetitiveGame               `json:"game,omitempty"`
+	Tutorial               *Tutorial                      `json:"tutorial,omitempty"`
+	Predictions            []PredictionRound              `json:"predictions,omitempty"`
+	LatestSnapshot         int                            `json:"latest_snapshot"`
+	Populations            []PopulationData               `json:"populations"`
+	Communication          *CommunicationData             `json:"communication"`
+	Civilization           *CivilizationData              `json:"civilization"`
+	Physics                *PhysicsData                   `json:"physics"`
+	Wind                   *WindData                      `json:"wind"`
+	Species                *SpeciesData                   `json:"species"`
+	Network                *NetworkData                   `json:"network"`
+	DNA                    *DNAData                       `json:"dna"`
+	Cellular               *CellularData                  `json:"cellular"`
+	Evolution              *EvolutionData                 `json:"evolution"`
+	Topology               *TopologyData                  `json:"topology"`
+	Tools                  *ToolData                      `json:"tools"`
+	EnvironmentalMod       *EnvironmentalModData          `json:"environmental_mod"`
+	EnvironmentalPressures *EnvironmentalPressureData     `json:"environmental_pressures"`
+	SymbioticRelationships *SymbioticRelationshipData     `json:"symbiotic_relationships"`
+	EmergentBehavior       *EmergentBehaviorData          `json:"emergent_behavior"`
+	FeedbackLoops          *FeedbackLoopData              `json:"feedback_loops"`
+	Reproduction           *ReproductionData              `json:"reproduction"`
+	Warfare                *WarfareData                   `json:"warfare"`
+	Fungal                 *FungalData                    `json:"fungal"`
+	Cultural               *CulturalData                  `json:"cultural"`
+	Statistical            *StatisticalData               `json:"statistical"`
+	Ecosystem              *EcosystemMetrics              `json:"ecosystem"`
+	Anomalies              *AnomaliesData                 `json:"anomalies"`
+	Neural                 *NeuralData                    `json:"neural"`
+	BiomeBoundary          *BiomeBoundaryData             `json:"biome_boundary"`
+	Biorhythm              *BioRhythmData                 `json:"biorhythm"`
+	DetailsTick            int                            `json:"details_tick"`
+	DetailsAgeMs           int                            `json:"details_age_ms"`
+	PopulationHistory      []PopulationHistorySnapshot    `json:"population_history"`
+	CommunicationHistory   []CommunicationHistorySnapshot `json:"communication_history"`
+	PhysicsHistory         []PhysicsHistorySnapshot       `json:"physics_history"`
+}
+
+// WarfareData is a type of the EvoSim API
+type WarfareData struct {
+	TotalColonies         int                  `json:"total_colonies"`
+	ActiveConflicts       int                  `json:"active_conflicts"`
+	TotalAlliances        int                  `json:"total_alliances"`
+	ActiveTradeAgreements int                  `json:"active_trade_agreements"`
+	TotalRelations        int                  `json:"total_relations"`
+	NeutralRelations      int                  `json:"neutral_relations"`
+	AlliedRelations       int                  `json:"allied_relations"`
+	EnemyRelations        int                  `json:"enemy_relations"`
+	TruceRelations        int                  `json:"truce_relations"`
+	TradingRelations      int                  `json:"trading_relations"`
+	VassalRelations       int                  `json:"vassal_relations"`
+	Conflicts             []ConflictData       `json:"conflicts"`
+	Alliances             []AllianceData       `json:"alliances"`
+	TradeAgreements       []TradeAgreementData `json:"trade_agreements"`
+	ColonyDetails         []ColonyDetailData   `json:"colony_details"`
+}
+
+// WebConfig is a type of the EvoSim API
+type WebConfig struct {
+	UpdateInterval int `json:"update_interval"`
+	Port           int `json:"port"`
+	MaxClients     int `json:"max_clients"`
+}
+
+// WebSocketStats is a type of the EvoSim API
+type WebSocketStats struct {
+	Clients       int `json:"clients"`
+	Evicted       int `json:"evicted"`
+	SkippedFrames int `json:"skipped_frames"`
+}
+
+// WindData is a type of the EvoSim API
+type WindData struct {
+	Direction           float64                `json:"direction"`
+	Strength            float64                `json:"strength"`
+	TurbulenceLevel     float64                `json:"turbulence_level"`
+	WeatherPattern      string                 `json:"weather_pattern"`
+	PollenCount         int                    `json:"pollen_count"`
+	SeedCount           int                    `json:"seed_count"`
+	SeedBanks           int                    `json:"seed_banks"`
+	GerminationEvents   int                    `json:"germination_events"`
+	DormancyActivations int                    `json:"dormancy_activations"`
+	DispersalStats      map[string]interface{} `json:"dispersal_stats"`
+}
+
+// WindSystemState is a type of the EvoSim API
+type WindSystemState struct {
+	BaseWindDirection  float64 `json:"base_wind_direction"`
+	BaseWindStrength   float64 `json:"base_wind_strength"`
+	TurbulenceLevel    float64 `json:"turbulence_level"`
+	SeasonalMultiplier float64 `json:"seasonal_multiplier"`
+	WeatherPattern     int     `json:"weather_pattern"`
+}
+
+// WorldConfig is a type of the EvoSim API
+type WorldConfig struct {
+	Width           float64                 `json:"Width"`
+	Height          float64                 `json:"Height"`
+	NumPopulations  int                     `json:"NumPopulations"`
+	PopulationSize  int                     `json:"PopulationSize"`
+	GridWidth       int                     `json:"GridWidth"`
+	GridHeight      int                     `json:"GridHeight"`
+	Breakpoints     []string                `json:"Breakpoints"`
+	FogOfWar        bool                    `json:"FogOfWar"`
+	Profile         string                  `json:"Profile"`
+	CustomTraits    []CustomTraitDefinition `json:"CustomTraits"`
+	Geometry        *string                 `json:"Geometry,omitempty"`
+	GridShape       *string                 `json:"GridShape,omitempty"`
+	Simulation      *SimulationConfig       `json:"Simulation,omitempty"`
+	EventTimetable  []ScheduledWorldEvent   `json:"EventTimetable,omitempty"`
+	RandomEventsOff *bool                   `json:"RandomEventsOff,omitempty"`
+}
+
+// WorldConfigSettings is a type of the EvoSim API
+type WorldConfigSettings struct {
+	Width          float64 `json:"width"`
+	Height         float64 `json:"height"`
+	GridWidth      int     `json:"grid_width"`
+	GridHeight     int     `json:"grid_height"`
+	BiomeVariety   float64 `json:"biome_variety"`
+	EventFrequency float64 `json:"event_frequency"`
+}
+
+// WorldEventState is a type of the EvoSim API
+type WorldEventState struct {
+	Name           string         `json:"name"`
+	Description    string         `json:"description"`
+	Duration       int            `json:"duration"`
+	GlobalMutation float64        `json:"global_mutation"`
+	GlobalDamage   float64        `json:"global_damage"`
+	BiomeChanges   map[string]int `json:"biome_changes"`
+}
+
+// WorldInfo is a type of the EvoSim API
+type WorldInfo struct {
+	Width         int     `json:"width"`
+	Height        int     `json:"height"`
+	Tick          int     `json:"tick"`
+	TotalEntities int     `json:"totalEntities"`
+	TotalPlants   int     `json:"totalPlants"`
+	MapWidth      float64 `json:"mapWidth"`
+	MapHeight     float64 `json:"mapHeight"`
+	SeaLevel      float64 `json:"seaLevel"`
+	Geometry      string  `json:"geometry"`
+}
+
+// ZoonosisReport is a type of the EvoSim API
+type ZoonosisReport struct {
+	Tick           int              `json:"tick"`
+	InfectedWild   int              `json:"infected_wild"`
+	InfectedColony int              `json:"infected_colony"`
+	Colonies       []ColonyContact  `json:"colonies"`
+	Spillovers     []SpilloverEvent `json:"spillovers"`
+}
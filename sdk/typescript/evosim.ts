@@ -0,0 +1,4224 @@
+// Code generated by "evosim export sdk"; DO NOT EDIT.
+
+// A typed client for the EvoSim HTTP API and WebSocket, generated from the OpenAPI and
+// AsyncAPI descriptions the server publishes at /api/spec and /api/spec/asyncapi.
+
+/** Response of getStatus */
+export interface GetStatusResponse {
+  tick: number;
+  entities: number;
+  plants: number;
+  populations: number;
+  status: string;
+  seed: number;
+  ticks_per_second: number;
+  unlimited_speed: boolean;
+  max_cpu: number;
+  websocket: WebSocketStats;
+}
+
+/** Query parameters of exportEvents */
+export interface ExportEventsParams {
+  type?: string;
+  category?: string;
+}
+
+/** Response of exportEvents */
+export interface ExportEventsResponse {
+  events: CentralEvent[];
+  total_count: number;
+  export_time: string;
+  filters: { [key: string]: string };
+  certificate: RunCertificate | null;
+}
+
+/** Response of exportAnalysis */
+export interface ExportAnalysisResponse {
+  summary_statistics: { [key: string]: unknown };
+  recent_events: StatisticalEvent[];
+  snapshots: StatisticalSnapshot[];
+  export_time: string;
+  certificate: RunCertificate | null;
+}
+
+/** Response of exportAnomalies */
+export interface ExportAnomaliesResponse {
+  anomalies: Anomaly[];
+  total_count: number;
+  anomaly_types: { [key: string]: number };
+  export_time: string;
+  certificate: RunCertificate | null;
+}
+
+/** Query parameters of exportGeoJSON */
+export interface ExportGeoJSONParams {
+  layer?: string;
+}
+
+/** Query parameters of exportDarwinCore */
+export interface ExportDarwinCoreParams {
+  plants?: boolean;
+}
+
+/** Response of diffSave */
+export interface DiffSaveResponse {
+  diff: SaveDiff | null;
+  has_differences: boolean;
+  summary: string;
+}
+
+/** Response of validateLiveState */
+export interface ValidateLiveStateResponse {
+  valid: boolean;
+  report: SaveValidationReport | null;
+}
+
+/** Query parameters of validateSave */
+export interface ValidateSaveParams {
+  repair?: boolean;
+}
+
+/** Response of validateSave */
+export interface ValidateSaveResponse {
+  valid: boolean;
+  report: SaveValidationReport | null;
+  repaired_state?: SimulationState | null;
+}
+
+/** Query parameters of exportCreature */
+export interface ExportCreatureParams {
+  id: number;
+  mate?: number;
+  name?: string;
+  description?: string;
+}
+
+/** Query parameters of importCreature */
+export interface ImportCreatureParams {
+  x?: number;
+  y?: number;
+}
+
+/** Response of importCreature */
+export interface ImportCreatureResponse {
+  imported_ids: number[];
+  species: string;
+}
+
+/** Query parameters of listRegistry */
+export interface ListRegistryParams {
+  kind?: string;
+}
+
+/** Query parameters of importRegistryFile */
+export interface ImportRegistryFileParams {
+  id: string;
+  x?: number;
+  y?: number;
+}
+
+/** Response of importRegistryFile */
+export interface ImportRegistryFileResponse {
+  entry: RegistryEntry | null;
+  imported_ids: number[];
+}
+
+/** Response of createPetriDish */
+export interface CreatePetriDishResponse {
+  id: number;
+  config: PetriDishConfig;
+  stats: PetriDishStats;
+}
+
+/** Query parameters of getPetriDish */
+export interface GetPetriDishParams {
+  id: number;
+}
+
+/** Query parameters of updatePetriDish */
+export interface UpdatePetriDishParams {
+  id: number;
+}
+
+/** Query parameters of deletePetriDish */
+export interface DeletePetriDishParams {
+  id: number;
+}
+
+/** Response of listBreakpoints */
+export interface ListBreakpointsResponse {
+  breakpoints: Breakpoint[];
+  last_hit?: Breakpoint | null;
+}
+
+/** Query parameters of removeBreakpoint */
+export interface RemoveBreakpointParams {
+  id: number;
+}
+
+/** Response of removeBreakpoint */
+export interface RemoveBreakpointResponse {
+  removed: number;
+}
+
+/** Query parameters of getTimeline */
+export interface GetTimelineParams {
+  from?: number;
+  to?: number;
+  buckets?: number;
+  events?: number;
+}
+
+/** Query parameters of listEntities */
+export interface ListEntitiesParams {
+  species?: string;
+  alive?: boolean;
+  offset?: number;
+  limit?: number;
+}
+
+/** Response of listOperatorStructures */
+export interface ListOperatorStructuresResponse {
+  structures: { [key: string]: OperatorStructure | null };
+  experiments: (FragmentationExperiment | null)[];
+  stats: { [key: string]: unknown };
+}
+
+/** Query parameters of removeOperatorStructure */
+export interface RemoveOperatorStructureParams {
+  id: number;
+}
+
+/** Query parameters of terraform */
+export interface TerraformParams {
+  branch?: number;
+}
+
+/** Query parameters of editTrait */
+export interface EditTraitParams {
+  branch?: number;
+}
+
+/** Query parameters of listInterventions */
+export interface ListInterventionsParams {
+  branch?: number;
+}
+
+/** Response of listInterventions */
+export interface ListInterventionsResponse {
+  undo: Intervention[];
+  redo: Intervention[];
+}
+
+/** Query parameters of undoRedoIntervention */
+export interface UndoRedoInterventionParams {
+  branch?: number;
+}
+
+/** Query parameters of startEnvironmentalEvent */
+export interface StartEnvironmentalEventParams {
+  branch?: number;
+}
+
+/** Response of getGame */
+export interface GetGameResponse {
+  game: CompetitiveGame | null;
+}
+
+/** Response of getTutorial */
+export interface GetTutorialResponse {
+  scenarios: TutorialScenario[];
+  tutorial: Tutorial | null;
+}
+
+/** Response of listPredictions */
+export interface ListPredictionsResponse {
+  rounds: PredictionRound[];
+  leaderboard: Spectator[];
+}
+
+/** Query parameters of getAchievements */
+export interface GetAchievementsParams {
+  player?: string;
+}
+
+/** Query parameters of listTraits */
+export interface ListTraitsParams {
+  subject?: string;
+}
+
+/** Query parameters of getLegend */
+export interface GetLegendParams {
+  renderer?: string;
+}
+
+/** Query parameters of getFitnessLandscape */
+export interface GetFitnessLandscapeParams {
+  species?: string;
+  x?: string;
+  y?: string;
+  resolution?: number;
+  radius?: number;
+}
+
+/** Query parameters of getAncestry */
+export interface GetAncestryParams {
+  species: string;
+  traits?: string;
+  frames?: number;
+}
+
+/** Query parameters of getMetricHistory */
+export interface GetMetricHistoryParams {
+  metrics: string;
+  from?: number;
+  to?: number;
+  points?: number;
+}
+
+/** Query parameters of getCensus */
+export interface GetCensusParams {
+  by?: string;
+  metrics?: string;
+}
+
+/** Query parameters of diffBranch */
+export interface DiffBranchParams {
+  id: number;
+}
+
+/** Response of diffBranch */
+export interface DiffBranchResponse {
+  diff: SaveDiff | null;
+  has_differences: boolean;
+  summary: string;
+}
+
+/** Query parameters of updateBranch */
+export interface UpdateBranchParams {
+  id: number;
+}
+
+/** Query parameters of deleteBranch */
+export interface DeleteBranchParams {
+  id: number;
+}
+
+/** Join the game as a player */
+export interface JoinAsPlayerMessage {
+  action: "join_as_player";
+  data: {
+    name?: string;
+  };
+}
+
+/** Create a species, optionally adjusting its traits */
+export interface CreateSpeciesMessage {
+  action: "create_species";
+  data: {
+    name?: string;
+    traits?: { [key: string]: number };
+  };
+}
+
+/** Order a species to move, gather or reproduce */
+export interface ControlSpeciesMessage {
+  action: "control_species";
+  data: {
+    species?: string;
+    command?: string;
+    x?: number;
+    y?: number;
+  };
+}
+
+/** Select a species' creatures as a group */
+export interface SelectGroupMessage {
+  action: "select_group";
+  data: {
+    species?: string;
+    formation?: string;
+  };
+}
+
+/** Give a group a standing order: move, guard, gather, stop or disband */
+export interface GroupCommandMessage {
+  action: "group_command";
+  data: {
+    group_id?: number;
+    command?: string;
+    formation?: string;
+    x?: number;
+    y?: number;
+    radius?: number;
+  };
+}
+
+/** Set a species' policy sliders */
+export interface SetSpeciesPolicyMessage {
+  action: "set_species_policy";
+  data: {
+    species?: string;
+    aggression?: number;
+    exploration?: number;
+    reproduction?: number;
+  };
+}
+
+/** Ask for the player list */
+export interface ListPlayersMessage {
+  action: "list_players";
+}
+
+/** Propose an alliance to a player */
+export interface ProposeAllianceMessage {
+  action: "propose_alliance";
+  data: {
+    player_id?: string;
+    species?: string;
+    accept?: boolean;
+  };
+}
+
+/** Accept or refuse a player's alliance */
+export interface RespondAllianceMessage {
+  action: "respond_alliance";
+  data: {
+    player_id?: string;
+    species?: string;
+    accept?: boolean;
+  };
+}
+
+/** Leave an alliance with a player */
+export interface LeaveAllianceMessage {
+  action: "leave_alliance";
+  data: {
+    player_id?: string;
+    species?: string;
+    accept?: boolean;
+  };
+}
+
+/** Let an ally control one of your species */
+export interface GrantCoControlMessage {
+  action: "grant_co_control";
+  data: {
+    player_id?: string;
+    species?: string;
+    accept?: boolean;
+  };
+}
+
+/** Take back an ally's control of a species */
+export interface RevokeCoControlMessage {
+  action: "revoke_co_control";
+  data: {
+    player_id?: string;
+    species?: string;
+    accept?: boolean;
+  };
+}
+
+/** Give one of your species to an ally */
+export interface GiftSpeciesMessage {
+  action: "gift_species";
+  data: {
+    player_id?: string;
+    species?: string;
+    accept?: boolean;
+  };
+}
+
+/** Pause or resume the simulation */
+export interface TogglePauseMessage {
+  action: "toggle_pause";
+}
+
+/** Reset the world */
+export interface ResetMessage {
+  action: "reset";
+}
+
+/** Save the simulation on the server */
+export interface SaveStateMessage {
+  action: "save_state";
+}
+
+/** Load a saved simulation */
+export interface LoadStateMessage {
+  action: "load_state";
+  data: SimulationState | null;
+}
+
+/** Speed the simulation up */
+export interface IncreaseSpeedMessage {
+  action: "increase_speed";
+}
+
+/** Slow the simulation down */
+export interface DecreaseSpeedMessage {
+  action: "decrease_speed";
+}
+
+/** Set the speed multiplier */
+export interface SetSpeedMessage {
+  action: "set_speed";
+  data: {
+    speed?: number;
+  };
+}
+
+/** Run as fast as possible or follow the speed multiplier */
+export interface SetUnlimitedSpeedMessage {
+  action: "set_unlimited_speed";
+  data: {
+    enabled?: boolean;
+  };
+}
+
+/** Cap the share of CPU time spent simulating */
+export interface SetMaxCPUMessage {
+  action: "set_max_cpu";
+  data: {
+    percent?: number;
+  };
+}
+
+/** Lock the viewport onto a creature, streaming what it does each tick */
+export interface FollowEntityMessage {
+  action: "follow_entity";
+  data: FollowRequest;
+}
+
+/** Let the viewport move freely again */
+export interface UnfollowEntityMessage {
+  action: "unfollow_entity";
+}
+
+/** Move the viewport by a number of cells, letting go of a followed creature */
+export interface PanMessage {
+  action: "pan";
+  data: {
+    deltaX?: number;
+    deltaY?: number;
+  };
+}
+
+/** Set the zoom level */
+export interface ZoomMessage {
+  action: "zoom";
+  data: {
+    zoom?: number;
+  };
+}
+
+/** Zoom in */
+export interface ZoomInMessage {
+  action: "zoom_in";
+}
+
+/** Zoom out */
+export interface ZoomOutMessage {
+  action: "zoom_out";
+}
+
+/** Reset the viewport */
+export interface ResetViewportMessage {
+  action: "reset_viewport";
+}
+
+/** Stream the grid chunks covering an area instead of the viewport's grid */
+export interface SubscribeChunksMessage {
+  action: "subscribe_chunks";
+  data: ChunkSubscription;
+}
+
+/** Go back to the viewport's grid */
+export interface UnsubscribeChunksMessage {
+  action: "unsubscribe_chunks";
+}
+
+/** Build a barrier or corridor */
+export interface BuildStructureMessage {
+  action: "build_structure";
+  data: OperatorStructureRequest;
+}
+
+/** Undo the latest intervention */
+export interface UndoInterventionMessage {
+  action: "undo_intervention";
+}
+
+/** Redo the latest undone intervention */
+export interface RedoInterventionMessage {
+  action: "redo_intervention";
+}
+
+/** Ask for the isometric view of an area */
+export interface GetIsometricDataMessage {
+  type: "get_isometric_data";
+  viewportX?: number;
+  viewportY?: number;
+  zoom?: number;
+  maxTiles?: number;
+}
+
+/** A frame of the simulation, sent about ten times a second */
+export type ViewUpdateMessage = ViewData;
+
+/** An explanation of a refused request */
+export interface ErrorMessage {
+  type: "error";
+  message: string;
+}
+
+/** The isometric view asked for */
+export interface IsometricMessage {
+  type: "isometric";
+  data: IsometricViewData | null;
+}
+
+/** Confirmation of joining as a player */
+export interface PlayerJoinedMessage {
+  type: "player_joined";
+  player_id: string;
+  name: string;
+  message: string;
+}
+
+/** Confirmation that the player's species was created */
+export interface SpeciesCreatedMessage {
+  type: "species_created";
+  species_name: string;
+  message: string;
+  traits: { [key: string]: number };
+}
+
+/** Confirmation of a species or group command */
+export interface CommandExecutedMessage {
+  type: "command_executed";
+  command: string;
+  message: string;
+  group_id?: number;
+  entities_affected?: number;
+  offspring?: number;
+}
+
+/** Confirmation of a group selection */
+export interface GroupSelectedMessage {
+  type: "group_selected";
+  group_id: number;
+  size: number;
+  formation: string;
+  message: string;
+}
+
+/** Confirmation of a species policy change */
+export interface SpeciesPolicyUpdatedMessage {
+  type: "species_policy_updated";
+  species: string;
+  policy: SpeciesPolicy | null;
+  message: string;
+}
+
+/** The other players and the species the player controls */
+export interface DiplomacyMessage {
+  type: "diplomacy";
+  message: string;
+  species: string[];
+  players: {
+    id: string;
+    name: string;
+    active: boolean;
+    allied: boolean;
+    requested: boolean;
+    pending: boolean;
+  }[];
+}
+
+/** Notice that one of the player's species died out */
+export interface SpeciesExtinctMessage {
+  type: "species_extinct";
+  species_name: string;
+  message: string;
+  last_count: number;
+  tick: number;
+}
+
+/** Notice that one of the player's species split */
+export interface SubspeciesFormedMessage {
+  type: "subspecies_formed";
+  species_name: string;
+  parent_species: string;
+  message: string;
+  entity_count: number;
+  tick: number;
+}
+
+/** Notice of a new species in the simulation */
+export interface NewSpeciesDetectedMessage {
+  type: "new_species_detected";
+  species_name: string;
+  message: string;
+  entity_count: number;
+  tick: number;
+}
+
+/** Notice that one of the player's species reached a milestone */
+export interface AchievementUnlockedMessage {
+  type: "achievement_unlocked";
+  species_name: string;
+  milestone: string;
+  name: string;
+  message: string;
+  tick: number;
+}
+
+/** The soundscape, sent about once a second */
+export type AudioMessage = AmbientAudio;
+
+/** A message the teacher broadcast to a classroom world */
+export interface AnnouncementMessage {
+  type: "announcement";
+  message: string;
+  sent: string;
+}
+
+/** A message a client sends over the WebSocket */
+export type ClientMessage =
+  | JoinAsPlayerMessage
+  | CreateSpeciesMessage
+  | ControlSpeciesMessage
+  | SelectGroupMessage
+  | GroupCommandMessage
+  | SetSpeciesPolicyMessage
+  | ListPlayersMessage
+  | ProposeAllianceMessage
+  | RespondAllianceMessage
+  | LeaveAllianceMessage
+  | GrantCoControlMessage
+  | RevokeCoControlMessage
+  | GiftSpeciesMessage
+  | TogglePauseMessage
+  | ResetMessage
+  | SaveStateMessage
+  | LoadStateMessage
+  | IncreaseSpeedMessage
+  | DecreaseSpeedMessage
+  | SetSpeedMessage
+  | SetUnlimitedSpeedMessage
+  | SetMaxCPUMessage
+  | FollowEntityMessage
+  | UnfollowEntityMessage
+  | PanMessage
+  | ZoomMessage
+  | ZoomInMessage
+  | ZoomOutMessage
+  | ResetViewportMessage
+  | SubscribeChunksMessage
+  | UnsubscribeChunksMessage
+  | BuildStructureMessage
+  | UndoInterventionMessage
+  | RedoInterventionMessage
+  | GetIsometricDataMessage;
+
+/** A message the server sends over the WebSocket */
+export type ServerMessage =
+  | ViewUpdateMessage
+  | ErrorMessage
+  | IsometricMessage
+  | PlayerJoinedMessage
+  | SpeciesCreatedMessage
+  | CommandExecutedMessage
+  | GroupSelectedMessage
+  | SpeciesPolicyUpdatedMessage
+  | DiplomacyMessage
+  | SpeciesExtinctMessage
+  | SubspeciesFormedMessage
+  | NewSpeciesDetectedMessage
+  | AchievementUnlockedMessage
+  | AudioMessage
+  | AnnouncementMessage;
+
+export interface Achievement {
+  milestone: string;
+  name: string;
+  tick: number;
+  species: string;
+  player?: string;
+  detail: string;
+}
+
+export interface AchievementState {
+  world: Achievement[];
+  players?: { [key: string]: Achievement[] };
+}
+
+export interface AchievementsStatus {
+  milestones: Milestone[];
+  world: Achievement[];
+  players: { [key: string]: Achievement[] };
+}
+
+export interface AdvancedTimeState {
+  world_tick: number;
+  day_length: number;
+  season_length: number;
+  time_of_day: number;
+  season: number;
+  day_number: number;
+  season_day: number;
+  temperature: number;
+  illumination: number;
+  seasonal_mod: number;
+}
+
+export interface AlertData {
+  id: number;
+  tick: number;
+  type: string;
+  severity: string;
+  description: string;
+}
+
+export interface AllianceData {
+  id: number;
+  members: number[];
+  alliance_type: string;
+  resource_share: number;
+  shared_defense: boolean;
+  is_active: boolean;
+  duration: number;
+}
+
+export interface AmbientAudio {
+  type: string;
+  tick: number;
+  rain: number;
+  wind: number;
+  bird_calls: number;
+  population: number;
+  wars: number;
+  cues: AudioCue[];
+}
+
+export interface AncestralFrame {
+  tick: number;
+  species: string;
+  traits: { [key: string]: number };
+  reconstructed: boolean;
+}
+
+export interface AncestralHistory {
+  species: string;
+  lineage: string[];
+  traits: string[];
+  frames: AncestralFrame[];
+  splits: LineageSplit[];
+  change: { [key: string]: number };
+}
+
+export interface AnomaliesData {
+  total_anomalies: number;
+  recent_anomalies: AnomalyData[];
+  anomaly_types: { [key: string]: number };
+  recommendations: string[];
+}
+
+export interface Anomaly {
+  type: string;
+  severity: number;
+  tick: number;
+  description: string;
+  data: { [key: string]: unknown };
+  confidence: number;
+}
+
+export interface AnomalyData {
+  type: string;
+  description: string;
+  severity: number;
+  confidence: number;
+  tick: number;
+}
+
+export interface AudioCue {
+  sound: string;
+  tick: number;
+  x: number;
+  y: number;
+}
+
+export interface AutosaveRecovery {
+  file: string;
+  tick: number;
+  saved_at: string;
+  status: string;
+}
+
+export interface AutosaveStatus {
+  enabled: boolean;
+  dir?: string;
+  interval: number;
+  keep: number;
+  saves: string[];
+  last_saved_tick: number;
+  recovery?: AutosaveRecovery | null;
+}
+
+export interface BioRhythmData {
+  total_entities: number;
+  activity_distribution: { [key: string]: number };
+  circadian_distribution: { [key: string]: number };
+  average_need_levels: { [key: string]: number };
+  biorhythm_efficiency: number;
+  light_disrupted: number;
+  current_time_of_day: string;
+  is_night: boolean;
+  season: string;
+  sample_entities: BioRhythmEntityData[];
+}
+
+export interface BioRhythmEntityData {
+  entity_id: number;
+  species: string;
+  current_activity: string;
+  circadian_type: string;
+  energy: number;
+  need_levels: { [key: string]: number };
+  top_needs: string[];
+}
+
+export interface BiomeBoundaryData {
+  boundary_count: number;
+  total_boundary_length: number;
+  ecotone_area: number;
+  migration_events: number;
+  evolution_events: number;
+  evolution_pressure: number;
+  migration_bonus: number;
+  boundary_types: { [key: string]: number };
+}
+
+export interface BiomeCellPressure {
+  x: number;
+  y: number;
+  biome: string;
+  to: string;
+  trigger: string;
+  pressure: number;
+  threshold: number;
+  dwelling: boolean;
+}
+
+export interface BiomeResourceBudget {
+  biome: string;
+  cells: number;
+  sunlight: ResourcePool;
+  water: ResourcePool;
+  nutrients: ResourcePool;
+  prey_biomass: number;
+  plants: number;
+  creatures: number;
+  plant_demand: number;
+  plant_supply: number;
+  plant_renewal: number;
+  plant_room: number;
+  creature_capacity: number;
+  limit?: string;
+}
+
+export interface BiomeStateReport {
+  tick: number;
+  rules: BiomeTransitionRule[];
+  transitions: BiomeTransitionEvent[];
+  pressures: BiomeCellPressure[];
+}
+
+export interface BiomeTransitionEvent {
+  tick: number;
+  x: number;
+  y: number;
+  from: string;
+  to: string;
+  trigger: string;
+  pressure: number;
+  threshold: number;
+  reverting: boolean;
+}
+
+export interface BiomeTransitionRule {
+  from: string;
+  to: string;
+  trigger: string;
+  rate: number;
+  threshold: number;
+}
+
+export interface BiomesConfig {
+  energy_drain_multipliers: { [key: string]: number };
+  mutation_rate_modifiers: { [key: string]: number };
+  temperature_ranges: { [key: string]: number[] };
+  carrying_capacities: { [key: string]: number };
+}
+
+export interface BrainSummary {
+  type: string;
+  architecture: string;
+  neurons: number;
+  layers: number;
+  experience: number;
+  decisions: number;
+  accuracy: number;
+  successful_actions: { [key: string]: number };
+}
+
+export interface BranchComparison {
+  id: number;
+  name: string;
+  fork_tick: number;
+  paused: boolean;
+  branch: BranchSample;
+  trunk: BranchSample;
+  branch_species: { [key: string]: number };
+  trunk_species: { [key: string]: number };
+  history: BranchSample[];
+  trunk_history: BranchSample[];
+}
+
+export interface BranchForkRequest {
+  name: string;
+}
+
+export interface BranchSample {
+  tick: number;
+  population: number;
+  plants: number;
+  species: number;
+  mean_energy: number;
+}
+
+export interface BranchUpdateRequest {
+  paused: boolean;
+}
+
+export interface Breakpoint {
+  id: number;
+  spec: string;
+  kind: string;
+  tick?: number;
+  species?: string;
+  threshold?: number;
+  hit: boolean;
+  hit_tick?: number;
+  hit_reason?: string;
+}
+
+export interface BreakpointRequest {
+  spec: string;
+}
+
+export interface CapRegion {
+  x: number;
+  y: number;
+  population: number;
+}
+
+export interface CellData {
+  x: number;
+  y: number;
+  biome: string;
+  biome_symbol: string;
+  biome_color: string;
+  entity_count: number;
+  entity_symbol: string;
+  entity_color: string;
+  entity_species?: string;
+  entity_scale?: number;
+  entity_colors: TraitColors;
+  plant_count: number;
+  plant_symbol: string;
+  plant_color: string;
+  has_event: boolean;
+  event_symbol: string;
+  light: number;
+  grid_x: number;
+  grid_y: number;
+  fog?: string;
+}
+
+export interface CellInspection {
+  x: number;
+  y: number;
+  biome: string;
+  water_level: number;
+  soil_ph: number;
+  soil_compaction: number;
+  organic_matter: number;
+  soil_nutrients: { [key: string]: number };
+  event?: string;
+  entities: (EntityDetailData | null)[];
+  plants: CellPlant[];
+}
+
+export interface CellPlant {
+  id: number;
+  type: string;
+  energy: number;
+  size: number;
+  age: number;
+}
+
+export interface CellState {
+  id: number;
+  type: number;
+  size: number;
+  energy: number;
+  health: number;
+  age: number;
+  dna?: DNAState | null;
+  organelles: { [key: string]: OrganelleState };
+  position: Position;
+  connections: number[];
+  activity: number;
+  specialized: boolean;
+}
+
+export interface CellularData {
+  total_cells: number;
+  average_complexity: number;
+  cell_divisions: number;
+}
+
+export interface CellularState {
+  entity_id: number;
+  complexity_level: number;
+  total_energy: number;
+  cell_divisions: number;
+  generation: number;
+  cells: CellState[];
+  organ_systems: { [key: string]: number[] };
+}
+
+export interface CensusGroup {
+  key: { [key: string]: string };
+  count: number;
+  means?: { [key: string]: number };
+}
+
+export interface CensusResult {
+  tick: number;
+  by: string[];
+  metrics: string[];
+  total: number;
+  groups: CensusGroup[];
+}
+
+export interface CentralEvent {
+  id: number;
+  timestamp: string;
+  tick: number;
+  type: string;
+  category: string;
+  sub_category: string;
+  source: string;
+  description: string;
+  entity_id?: number;
+  plant_id?: number;
+  position?: Position | null;
+  old_value?: unknown;
+  new_value?: unknown;
+  change?: number;
+  metadata: { [key: string]: unknown };
+  impacted_ids?: number[];
+  severity: string;
+}
+
+export interface CertificateLink {
+  epoch: number;
+  tick: number;
+  state: string;
+  hash: string;
+}
+
+export interface ChemicalSignalState {
+  id: number;
+  source_id: number;
+  type: number;
+  intensity: number;
+  age: number;
+  max_age: number;
+  visited: { [key: string]: boolean };
+  message: string;
+  metadata: { [key: string]: number };
+}
+
+export interface ChromosomeState {
+  id: number;
+  genes: GeneState[];
+}
+
+export interface ChunkSubscription {
+  x: number;
+  y: number;
+  width: number;
+  height: number;
+  margin: number;
+}
+
+export interface CivilizationData {
+  tribes_count: number;
+  structure_count: number;
+  total_resources: number;
+}
+
+export interface ColonyContact {
+  tribe_id: number;
+  name: string;
+  members: number;
+  contacts: number;
+  hunt_contacts: number;
+  spillovers_in: number;
+  spillovers_out: number;
+}
+
+export interface ColonyDemography {
+  tribe_id: number;
+  name: string;
+  tech_level: number;
+  food_secure: boolean;
+  births: number;
+  birth_rate: number;
+  birth_rates: number[];
+  transitioned: boolean;
+  transition_tick?: number;
+  pyramid: PopulationPyramid;
+}
+
+export interface ColonyDetailData {
+  id: number;
+  size: number;
+  fitness: number;
+  location: Position;
+  resources: { [key: string]: number };
+  relations: { [key: string]: string };
+  trust_levels: { [key: string]: number };
+}
+
+export interface CommunicationData {
+  active_signals: number;
+  signal_types: { [key: string]: number };
+}
+
+export interface CommunicationHistorySnapshot {
+  tick: number;
+  timestamp: string;
+  active_signals: number;
+  signal_types: { [key: string]: number };
+  first_tick?: number;
+  samples?: number;
+}
+
+export interface CommunicationSnapshot {
+  active_signals: number;
+  signals_by_type: { [key: string]: number };
+  signal_efficiency: number;
+}
+
+export interface CompetitiveGame {
+  id: number;
+  settings: GameSettings;
+  status: string;
+  start_tick: number;
+  end_tick?: number;
+  cataclysm_tick?: number;
+  winner?: string;
+  reason?: string;
+  standings: PlayerStanding[];
+}
+
+export interface ConflictData {
+  id: number;
+  attacker_id: number;
+  defender_id: number;
+  conflict_type: string;
+  turns_active: number;
+  casualty_count: number;
+  resources_lost: number;
+  intensity: number;
+  war_goal: string;
+  is_active: boolean;
+}
+
+export interface ConsumptionRecord {
+  tick: number;
+  food_type: string;
+  food_id: string;
+  nutrition: number;
+  toxicity: number;
+}
+
+export interface ControlPauseRequest {
+  paused?: boolean | null;
+}
+
+export interface ControlSpeedRequest {
+  speed?: number | null;
+  unlimited?: boolean | null;
+  max_cpu?: number | null;
+}
+
+export interface ControlState {
+  tick: number;
+  paused: boolean;
+  speed: number;
+  unlimited_speed: boolean;
+  max_cpu: number;
+  ticks_per_second: number;
+  viewport_x: number;
+  viewport_y: number;
+  zoom: number;
+}
+
+export interface ControlViewportRequest {
+  x?: number | null;
+  y?: number | null;
+  zoom?: number | null;
+  reset?: boolean;
+}
+
+export interface ConvergedTrait {
+  trait: string;
+  origin_gap: number;
+  gap: number;
+  value_a: number;
+  value_b: number;
+}
+
+export interface ConvergenceEvent {
+  tick: number;
+  species_a: string;
+  species_b: string;
+  root_a: string;
+  root_b: string;
+  habitats: string[];
+  traits: ConvergedTrait[];
+}
+
+export interface CreatureFile {
+  format: string;
+  version: number;
+  name: string;
+  description: string;
+  exported_at: string;
+  source_tick: number;
+  creatures: CreatureRecord[];
+}
+
+export interface CreatureRecord {
+  original_id: number;
+  species: string;
+  generation: number;
+  traits: { [key: string]: number };
+  classification: number;
+  max_lifespan: number;
+  dna?: DNAState | null;
+  cellular?: CellularState | null;
+  neural_network?: EntityNeuralNetwork | null;
+  culture?: CulturalMemory | null;
+}
+
+export interface CulturalData {
+  total_knowledge_types: number;
+  total_entities: number;
+  active_innovations: number;
+  total_teaching_events: number;
+  total_learning_events: number;
+  total_innovations_created: number;
+  knowledge_loss_events: number;
+  avg_knowledge_per_entity: number;
+  knowledge_type_distribution: { [key: string]: number };
+}
+
+export interface CulturalKnowledge {
+  id: number;
+  type: number;
+  effectiveness: number;
+  complexity: number;
+  age_in_generation: number;
+  teacher_count: number;
+  learner_count: number;
+  success_rate: number;
+  decay_rate: number;
+  innovation: boolean;
+  last_used: number;
+  description: string;
+}
+
+export interface CulturalMemory {
+  entity_id: number;
+  known_knowledge: { [key: string]: CulturalKnowledge | null };
+  teaching_ability: number;
+  learning_ability: number;
+  innovation_chance: number;
+  knowledge_capacity: number;
+  recently_taught: number[];
+  recently_learned: number[];
+  mentor_entity_id: number;
+  student_entity_ids: number[];
+}
+
+export interface CustomTraitCost {
+  function: string;
+  coefficient: number;
+}
+
+export interface CustomTraitDefinition {
+  name: string;
+  description: string;
+  min: number;
+  max: number;
+  initial: number;
+  variation: number;
+  heritability: number;
+  cost: CustomTraitCost;
+  hooks: { [key: string]: number };
+}
+
+export interface DNAData {
+  organism_count: number;
+  average_mutations: number;
+  average_complexity: number;
+}
+
+export interface DNAState {
+  entity_id: number;
+  chromosomes: ChromosomeState[];
+  mutations: number;
+  generation: number;
+}
+
+export interface DNASummary {
+  chromosomes: number;
+  mutations: number;
+  sequence: string;
+  genes: GeneSummary[];
+}
+
+export interface DarwinCoreRecord {
+  occurrenceID: string;
+  basisOfRecord: string;
+  datasetName: string;
+  kingdom: string;
+  scientificName: string;
+  organismID: number;
+  individualCount: number;
+  occurrenceStatus: string;
+  eventDate: string;
+  verbatimEventDate: string;
+  decimalLatitude: number;
+  decimalLongitude: number;
+  geodeticDatum: string;
+  verbatimCoordinates: string;
+  habitat: string;
+}
+
+export interface DayNightConfig {
+  day_length: number;
+  max_latitude: number;
+  axial_tilt: number;
+  night_light: number;
+  photosynthesis_light: number;
+  night_hunting: number;
+}
+
+export interface DayNightRow {
+  row: number;
+  latitude: number;
+  light: number;
+}
+
+export interface DayNightStatus {
+  settings: DayNightConfig;
+  tick: number;
+  day: number;
+  phase: number;
+  time_of_day: string;
+  is_night: boolean;
+  season: string;
+  declination: number;
+  rows: DayNightRow[];
+}
+
+export interface DemographicsReport {
+  tick: number;
+  colonies: ColonyDemography[];
+  species: PopulationPyramid[];
+  transitions: number;
+}
+
+export interface DispersalState {
+  pool?: (EntityState | null)[];
+  emigrated: number;
+  dispersed: number;
+  returned: number;
+  settled: number;
+}
+
+export interface EcosystemMetrics {
+  shannon_diversity: number;
+  simpson_diversity: number;
+  species_richness: number;
+  species_evenness: number;
+  total_population: number;
+  population_by_species: { [key: string]: number };
+  extinction_rate: number;
+  speciation_rate: number;
+  network_connectivity: number;
+  average_path_length: number;
+  clustering_coefficient: number;
+  pollination_success: number;
+  cross_species_pollination: number;
+  pollinator_efficiency: { [key: string]: number };
+  average_dispersal_distance: number;
+  dispersal_methods: { [key: string]: number };
+  seed_bank_size: number;
+  germination_rate: number;
+  cached_seeds: number;
+  cache_recruits: number;
+  cacher_dependence: number;
+  recruits_by_cacher: { [key: string]: number };
+  connectivity_index: number;
+  habitat_patches: number;
+  corridor_count: number;
+  choke_point_count: number;
+  niche_overlaps: NicheOverlap[];
+  likely_exclusions: number;
+  ecosystem_stability: number;
+  biodiversity_index: number;
+  ecosystem_resilience: number;
+  carrying_capacity: number;
+}
+
+export interface EmergentBehaviorData {
+  total_entities: number;
+  behavior_spread: { [key: string]: number };
+  avg_proficiency: { [key: string]: number };
+  discovered_behaviors: number;
+}
+
+export interface EnergyConfig {
+  base_energy_drain: number;
+  movement_energy_cost: number;
+  reproduction_cost: number;
+  survival_threshold: number;
+  max_energy_level: number;
+  energy_regeneration_rate: number;
+  biome_energy_modifiers: { [key: string]: number };
+}
+
+export interface EntityDetailData {
+  id: number;
+  species: string;
+  is_alive: boolean;
+  energy: number;
+  age: number;
+  generation: number;
+  position: Position;
+  traits: { [key: string]: number };
+  colony_id?: number;
+}
+
+export interface EntityInspection {
+  id: number;
+  species: string;
+  is_alive: boolean;
+  energy: number;
+  age: number;
+  generation: number;
+  position: Position;
+  traits: { [key: string]: number };
+  colony_id?: number;
+  grid_x: number;
+  grid_y: number;
+  activity: string;
+  classification: string;
+  caste?: string;
+  tribe_id?: number;
+  pregnant: boolean;
+  offspring: number;
+  dna?: DNASummary | null;
+  brain?: BrainSummary | null;
+  memory: EntityMemory;
+  relationships: EntityRelationship[];
+}
+
+export interface EntityListResponse {
+  total: number;
+  offset: number;
+  entities: (EntityDetailData | null)[];
+}
+
+export interface EntityMemory {
+  recent_meals: ConsumptionRecord[];
+  dietary_fitness: number;
+  adaptation_fitness: number;
+  knowledge: string[];
+}
+
+export interface EntityNeuralNetwork {
+  id: number;
+  entity_id: number;
+  type: number;
+  neurons: { [key: string]: Neuron | null };
+  input_neurons: number[];
+  output_neurons: number[];
+  hidden_layers: number[][];
+  learning_rate: number;
+  architecture: string;
+  experience: number;
+  adaptability: number;
+  created_tick: number;
+  last_update_tick: number;
+  recent_inputs: number[][];
+  recent_outputs: number[][];
+  successful_actions: { [key: string]: number };
+  behavior_patterns: { [key: string]: number };
+  correct_decisions: number;
+  total_decisions: number;
+  avg_response_time: number;
+  complexity_score: number;
+}
+
+export interface EntityRelationship {
+  kind: string;
+  entity_id: number;
+  detail?: string;
+}
+
+export interface EntityState {
+  id: number;
+  species: string;
+  position: Position;
+  traits: { [key: string]: number };
+  fitness: number;
+  energy: number;
+  age: number;
+  dna?: DNAState | null;
+  cellular?: CellularState | null;
+}
+
+export interface EnvironmentalEventRequest {
+  type: string;
+  x: number;
+  y: number;
+}
+
+export interface EnvironmentalEventResponse {
+  id: number;
+  type: string;
+  name: string;
+  description: string;
+  start_tick: number;
+  duration: number;
+  x: number;
+  y: number;
+  max_radius: number;
+}
+
+export interface EnvironmentalModData {
+  total_modifications: number;
+  active_modifications: number;
+  inactive_modifications: number;
+  avg_durability: number;
+  tunnel_networks: number;
+  modification_types: { [key: string]: number };
+}
+
+export interface EnvironmentalPressureData {
+  active_pressures: number;
+  total_history: number;
+  average_severity: number;
+  pressure_types: { [key: string]: number };
+  active_details: PressureDetail[];
+}
+
+export interface EventData {
+  name: string;
+  description: string;
+  duration: number;
+  tick: number;
+  type: string;
+  event_type: string;
+  timestamp: string;
+  severity?: string;
+}
+
+export interface EventTimeline {
+  from_tick: number;
+  to_tick: number;
+  categories: string[];
+  buckets: TimelineBucket[];
+  max_count: number;
+  markers: TimelineMarker[];
+  events?: TimelineMarker[];
+}
+
+export interface EvolutionConfig {
+  trait_mutation_strength: number;
+  trait_bounds: { [key: string]: number[] };
+  fitness_weights: { [key: string]: number };
+  speciation_threshold: number;
+}
+
+export interface EvolutionData {
+  speciation_events: number;
+  extinction_events: number;
+  genetic_diversity: number;
+  has_speciation_system: boolean;
+  total_plants_tracked: number;
+  active_plant_count: number;
+  speciation_detected: boolean;
+  mutation_operators: MutationOperatorStatus[];
+  radiations: RadiationEpisode[];
+  convergences: ConvergenceEvent[];
+  arms_races: RedQueenReport[];
+}
+
+export interface FastForwardRequest {
+  state?: SimulationState | null;
+  target_tick: number;
+  seed: number;
+}
+
+export interface FastForwardResult {
+  from_tick: number;
+  to_tick: number;
+  ticks: number;
+  seed: number;
+  elapsed_ms: number;
+  digest: string;
+  stopped_by?: string;
+}
+
+export interface FeedbackLoopData {
+  dietary_memory_count: number;
+  env_memory_count: number;
+  avg_dietary_fitness: number;
+  avg_env_fitness: number;
+  total_plant_preferences: number;
+  total_prey_preferences: number;
+  high_pressure_entities: number;
+  evolutionary_pressure: number;
+}
+
+export interface FitnessLandscape {
+  tick: number;
+  species: string;
+  x_trait: string;
+  y_trait: string;
+  x_values: number[];
+  y_values: number[];
+  fitness: number[][];
+  min: number;
+  max: number;
+  peaks: LandscapePoint[];
+  valleys: LandscapePoint[];
+  population: LandscapeIndividual[];
+  centroid: LandscapePoint;
+  uphill_x: number;
+  uphill_y: number;
+}
+
+export interface FollowDecision {
+  inputs: { [key: string]: number };
+  move: Position;
+  intensity: number;
+  activations: number[][];
+}
+
+export interface FollowFrame {
+  tick: number;
+  position: Position;
+  energy: number;
+  energy_change: number;
+  activity: string;
+  decision?: FollowDecision | null;
+  interactions: FollowInteraction[];
+}
+
+export interface FollowInteraction {
+  kind: string;
+  description: string;
+}
+
+export interface FollowRequest {
+  entity_id: number;
+}
+
+export interface FollowState {
+  entity_id: number;
+  track?: FollowTrack | null;
+}
+
+export interface FollowTrack {
+  entity_id: number;
+  species: string;
+  alive: boolean;
+  start_tick: number;
+  frames: FollowFrame[];
+  trail: GridPoint[];
+}
+
+export interface FoodStorageReport {
+  tick: number;
+  tribes: TribeFoodStore[];
+  cached_wild: number;
+  spoiled_wild: number;
+  in_famine: number;
+  with_surplus: number;
+  discoveries: number;
+}
+
+export interface FragmentationExperiment {
+  id: number;
+  structure_id: number;
+  phase: string;
+  baseline_ticks: number;
+  treatment_ticks: number;
+  phase_start_tick: number;
+  region_a_size: number;
+  region_b_size: number;
+  before: GeneFlowMeasurement;
+  after: GeneFlowMeasurement;
+  summary: string;
+}
+
+export interface FungalData {
+  total_organisms: number;
+  decomposer_count: number;
+  mycorrhizal_count: number;
+  pathogenic_count: number;
+  active_spores: number;
+  total_biomass: number;
+  nutrient_cycling: number;
+  decomposition_events: number;
+  network_connections: number;
+  avg_connections: number;
+}
+
+export interface GalleryCaptureRequest {
+  title: string;
+}
+
+export interface GalleryConfig {
+  enabled: boolean;
+  max_snapshots: number;
+  cooldown: number;
+  cell_pixels: number;
+}
+
+export interface GallerySnapshot {
+  id: number;
+  trigger: string;
+  title: string;
+  description: string;
+  tick: number;
+  taken: string;
+  image: string;
+  width: number;
+  height: number;
+  stats: GalleryStats;
+}
+
+export interface GalleryStats {
+  season: string;
+  time_of_day: string;
+  creatures: number;
+  plants: number;
+  species: number;
+  populations: { [key: string]: number };
+  average_energy: number;
+  tribes: number;
+  tools: number;
+}
+
+export interface GalleryStatus {
+  settings: GalleryConfig;
+  directory?: string;
+  snapshots: GallerySnapshot[];
+}
+
+export interface GameSettings {
+  condition: string;
+  target: number;
+  time_limit: number;
+  cataclysm_delay: number;
+}
+
+export interface GeneFlowMeasurement {
+  start_tick: number;
+  end_tick: number;
+  crossings: number;
+  crossing_rate: number;
+  trait_divergence: number;
+  population_a: number;
+  population_b: number;
+}
+
+export interface GeneState {
+  name: string;
+  sequence: string[];
+  dominant: boolean;
+  expression: number;
+}
+
+export interface GeneSummary {
+  name: string;
+  dominant: boolean;
+  expression: number;
+}
+
+export interface GeoJSONFeature {
+  type: string;
+  geometry: GeoJSONGeometry;
+  properties: { [key: string]: unknown };
+}
+
+export interface GeoJSONFeatureCollection {
+  type: string;
+  features: GeoJSONFeature[];
+  metadata?: { [key: string]: unknown };
+}
+
+export interface GeoJSONGeometry {
+  type: string;
+  coordinates: unknown;
+}
+
+export interface GeographyDiff {
+  dimensions_match: boolean;
+  cells_compared: number;
+  cells_changed: number;
+  biome_counts_a: { [key: string]: number };
+  biome_counts_b: { [key: string]: number };
+}
+
+export interface GraphQLError {
+  message: string;
+}
+
+export interface GraphQLRequest {
+  query: string;
+  variables: { [key: string]: unknown };
+}
+
+export interface GraphQLResponse {
+  data: unknown;
+  errors?: GraphQLError[];
+}
+
+export interface GridChunk {
+  x: number;
+  y: number;
+  grid_x: number;
+  grid_y: number;
+  width: number;
+  height: number;
+  cells: CellData[];
+}
+
+export interface GridPoint {
+  x: number;
+  y: number;
+}
+
+export interface HerdStatus {
+  species: string;
+  reason: string;
+  position: Position;
+  members: Position[];
+  waypoints: Position[];
+  trail: Position[];
+  route: number;
+  returning: boolean;
+  arrived: boolean;
+  started: number;
+}
+
+export interface Intervention {
+  id: number;
+  kind: string;
+  description: string;
+  tick: number;
+}
+
+export interface InterventionRequest {
+  action: string;
+}
+
+export interface IsometricDNA {
+  geneCount: number;
+  activeGenes: number;
+  genes?: { [key: string]: unknown }[];
+}
+
+export interface IsometricEntity {
+  id: number;
+  x: number;
+  y: number;
+  species: string;
+  size: number;
+  energy: number;
+  age: number;
+  color: string;
+  scale: number;
+  colors: TraitColors;
+  traits: { [key: string]: number };
+  dna: IsometricDNA;
+}
+
+export interface IsometricEvent {
+  id: number;
+  type: string;
+  x: number;
+  y: number;
+  intensity: number;
+  duration: number;
+  age: number;
+  color: string;
+  description: string;
+}
+
+export interface IsometricGeologicalEvent {
+  id: number;
+  type: string;
+  intensity: number;
+  duration: number;
+  startTick: number;
+  color: string;
+}
+
+export interface IsometricPlant {
+  x: number;
+  y: number;
+  type: number;
+  typeName: string;
+  size: number;
+  energy: number;
+  age: number;
+  isAlive: boolean;
+  color: string;
+  growthRate: number;
+  reproductionRate: number;
+  maxSize: number;
+  diseaseResistance: number;
+}
+
+export interface IsometricTile {
+  x: number;
+  y: number;
+  biomeType: number;
+  biomeName: string;
+  symbol: string;
+  color: string;
+  elevation: number;
+  slope: number;
+  waterLevel: number;
+  terrainFeatures?: number[];
+  geologicalEvents?: IsometricGeologicalEvent[];
+}
+
+export interface IsometricViewData {
+  tiles: IsometricTile[];
+  entities: IsometricEntity[];
+  plants: IsometricPlant[];
+  events: IsometricEvent[];
+  cameraX: number;
+  cameraY: number;
+  zoom: number;
+  worldInfo: WorldInfo;
+}
+
+export interface LandscapeIndividual {
+  entity_id: number;
+  x: number;
+  y: number;
+  fitness: number;
+}
+
+export interface LandscapePoint {
+  x: number;
+  y: number;
+  fitness: number;
+}
+
+export interface LegendColorScheme {
+  name: string;
+  label: string;
+  description: string;
+  trait?: TraitDefinition | null;
+  stops: LegendStop[];
+}
+
+export interface LegendEntry {
+  key: string;
+  label: string;
+  symbol?: string;
+  glyph?: string;
+  color?: string;
+  class?: string;
+}
+
+export interface LegendScale {
+  trait: TraitDefinition;
+  stops: LegendStop[];
+}
+
+export interface LegendSection {
+  name: string;
+  label: string;
+  entries: LegendEntry[];
+}
+
+export interface LegendStop {
+  value: number;
+  label: string;
+  scale?: number;
+  color?: string;
+}
+
+export interface LightPollutionReport {
+  tick: number;
+  is_night: boolean;
+  sources: LightSource[];
+  lit_nocturnal: number;
+  hunts_disrupted: number;
+  migrants_misdirected: number;
+}
+
+export interface LightSource {
+  tribe_id: number;
+  name: string;
+  tech_level: number;
+  position: Position;
+  radius: number;
+  brightness: number;
+}
+
+export interface LineageSplit {
+  tick: number;
+  parent: string;
+  child: string;
+}
+
+export interface MetricHistoryChart {
+  from: number;
+  to: number;
+  series: (MetricSeries | null)[];
+}
+
+export interface MetricHistoryIndex {
+  tick: number;
+  interval: number;
+  capacity: number;
+  metrics: MetricInfo[];
+}
+
+export interface MetricInfo {
+  metric: string;
+  kind: string;
+  population?: string;
+  trait?: string;
+  samples: number;
+  first_tick: number;
+  last_tick: number;
+}
+
+export interface MetricSeries {
+  metric: string;
+  ticks: number[];
+  values: number[];
+}
+
+export interface MicroclimateCell {
+  x: number;
+  y: number;
+  biome: string;
+  canopy: number;
+  shade: number;
+  windbreak: number;
+  cooling: number;
+  retention: number;
+  parent_temperature: number;
+  temperature: number;
+  parent_humidity: number;
+  humidity: number;
+  refuge: boolean;
+  sheltering: number;
+}
+
+export interface MicroclimateReport {
+  tick: number;
+  ambient: number;
+  heat_wave: boolean;
+  refugia: number;
+  sheltering: number;
+  cells: MicroclimateCell[];
+}
+
+export interface MigrationPath {
+  species: string;
+  reason: string;
+  returning: boolean;
+  path: Position[];
+  size: number;
+  started: number;
+  ended: number;
+}
+
+export interface MigrationRoute {
+  id: number;
+  species: string;
+  reason: string;
+  waypoints: Position[];
+  learned_at: number;
+  uses: number;
+  knowers: number;
+}
+
+export interface MigrationState {
+  routes: SavedMigrationRoute[];
+  history?: MigrationPath[];
+  next_id: number;
+}
+
+export interface MigrationStatus {
+  width: number;
+  height: number;
+  herds: HerdStatus[];
+  routes: MigrationRoute[];
+  history: MigrationPath[];
+  migrants: number;
+}
+
+export interface Milestone {
+  id: string;
+  name: string;
+  description: string;
+}
+
+export interface MutationOperatorStatus {
+  name: string;
+  description: string;
+  rate: number;
+  mutations: number;
+  share: number;
+  trait_changes: { [key: string]: number };
+}
+
+export interface MutationRateRequest {
+  operator: string;
+  rate: number;
+}
+
+export interface MutationRecord {
+  tick: number;
+  entity_id: number;
+  species: string;
+  trait: string;
+  operator: string;
+  old_value: number;
+  new_value: number;
+}
+
+export interface MutationReport {
+  operators: MutationOperatorStatus[];
+  total: number;
+  recent: MutationRecord[];
+}
+
+export interface NetworkConnectionState {
+  plant1_id: number;
+  plant2_id: number;
+  type: number;
+  strength: number;
+  health: number;
+  efficiency: number;
+  age: number;
+}
+
+export interface NetworkData {
+  connection_count: number;
+  signal_count: number;
+  cluster_count: number;
+}
+
+export interface NeuralData {
+  total_networks: number;
+  total_behaviors: number;
+  total_learning_events: number;
+  emergent_behaviors: number;
+  avg_network_complexity: number;
+  success_rate: number;
+  total_experience: number;
+  avg_experience_per_network: number;
+  base_learning_rate: number;
+  adaptation_rate: number;
+  active_network_count: number;
+  collective_behavior_count: number;
+  successful_strategies: string[];
+  entity_networks: { [key: string]: unknown };
+}
+
+export interface Neuron {
+  id: number;
+  value: number;
+  bias: number;
+  layer: number;
+  activation: string;
+  connections: (Synapse | null)[];
+}
+
+export interface NicheOverlap {
+  species_a: string;
+  species_b: string;
+  diet: number;
+  habitat: number;
+  activity: number;
+  overall: number;
+  alpha_ab: number;
+  alpha_ba: number;
+  likely_exclusion: boolean;
+  excluded?: string;
+  reason?: string;
+}
+
+export interface NicheOverlapReport {
+  tick: number;
+  profiles: NicheProfile[];
+  pairs: NicheOverlap[];
+  exclusions: number;
+}
+
+export interface NicheProfile {
+  species: string;
+  population: number;
+  diet: { [key: string]: number };
+  habitat: { [key: string]: number };
+  activity: { [key: string]: number };
+}
+
+export interface NoisePollutionReport {
+  tick: number;
+  sources: NoiseSource[];
+  disturbed_area: number;
+  species: SpeciesDisturbance[];
+}
+
+export interface NoiseSource {
+  kind: string;
+  id: number;
+  name?: string;
+  start: Position;
+  end: Position;
+  radius: number;
+  loudness: number;
+}
+
+export interface OperatorStructure {
+  id: number;
+  type: string;
+  kind: string;
+  cells: GridPoint[];
+  created_tick: number;
+  active: boolean;
+}
+
+export interface OperatorStructureRequest {
+  type: string;
+  kind: string;
+  cells: GridPoint[];
+  from?: GridPoint | null;
+  to?: GridPoint | null;
+  baseline_ticks: number;
+}
+
+export interface OrganelleState {
+  type: number;
+  count: number;
+  efficiency: number;
+  energy: number;
+}
+
+export interface PerformanceReport {
+  tick: number;
+  ticks_per_second: number;
+  unlimited_speed: boolean;
+  max_cpu: number;
+  systems: ScheduledSystemStatus[];
+}
+
+export interface PetriDishConfig {
+  size: number;
+  grid_size: number;
+  biome: number;
+  temperature: number;
+  food_density: number;
+  food_type: number;
+  replenish_food: boolean;
+  population_size: number;
+  seed?: number;
+}
+
+export interface PetriDishCreateRequest {
+  size: number;
+  grid_size: number;
+  biome: number;
+  temperature: number;
+  food_density: number;
+  food_type: number;
+  replenish_food: boolean;
+  population_size: number;
+  seed?: number;
+  populations: {
+    species: string;
+    traits: { [key: string]: number };
+  }[];
+}
+
+export interface PetriDishStats {
+  tick: number;
+  temperature: number;
+  food_density: number;
+  plants: number;
+  population: number;
+  by_species: { [key: string]: number };
+  mean_energy: number;
+}
+
+export interface PetriDishUpdateRequest {
+  ticks: number;
+  temperature?: number | null;
+  food_density?: number | null;
+}
+
+export interface Phylogeny {
+  tick: number;
+  roots: (PhylogenyNode | null)[];
+  species: number;
+  living: number;
+  extinct: number;
+  depth: number;
+}
+
+export interface PhylogenyNode {
+  id: number;
+  name: string;
+  plant_type: string;
+  parent_id: number;
+  formation_tick: number;
+  extinction_tick?: number;
+  extinct: boolean;
+  age: number;
+  population: number;
+  peak_population: number;
+  split_distance: number;
+  divergence?: { [key: string]: number };
+  trait_drift: { [key: string]: number };
+  descendants: number;
+  children: (PhylogenyNode | null)[];
+}
+
+export interface PhysicsConfig {
+  collision_detection: boolean;
+  max_velocity: number;
+  friction: number;
+  wind_strength: number;
+  gravity_strength: number;
+}
+
+export interface PhysicsData {
+  collisions_last_tick: number;
+  average_velocity: number;
+  total_momentum: number;
+}
+
+export interface PhysicsHistorySnapshot {
+  tick: number;
+  timestamp: string;
+  collisions: number;
+  average_velocity: number;
+  total_momentum: number;
+  first_tick?: number;
+  samples?: number;
+}
+
+export interface PhysicsSnapshot {
+  total_momentum: number;
+  total_kinetic_energy: number;
+  collision_count: number;
+  average_velocity: number;
+}
+
+export interface PlantNetworkState {
+  connections: (NetworkConnectionState | null)[];
+  active_signals: (ChemicalSignalState | null)[];
+}
+
+export interface PlantState {
+  id: number;
+  type: number;
+  position: Position;
+  energy: number;
+  age: number;
+  size: number;
+  traits: { [key: string]: number };
+  generation: number;
+  is_alive: boolean;
+  nutrition_value: number;
+  toxicity: number;
+  growth_rate: number;
+}
+
+export interface PlantsConfig {
+  growth_rate: number;
+  max_age: number;
+  seed_production_rate: number;
+  pollination_radius: number;
+  nutrient_requirement: { [key: string]: number };
+}
+
+export interface PlayerGroupData {
+  id: number;
+  player_id: string;
+  size: number;
+  formation: string;
+  order?: string;
+  arrived: boolean;
+  cells: GridPoint[];
+}
+
+export interface PlayerStanding {
+  player_id: string;
+  name: string;
+  species: string[];
+  score: number;
+  population: number;
+  peak_population: number;
+  territory: number;
+  tech_level: number;
+  eliminated: boolean;
+  eliminated_tick?: number;
+}
+
+export interface PolicySettings {
+  aggression: number;
+  exploration: number;
+  reproduction: number;
+}
+
+export interface PollutionCell {
+  x: number;
+  y: number;
+  biome: string;
+  soil: number;
+  water: number;
+}
+
+export interface PollutionReport {
+  tick: number;
+  total_soil: number;
+  total_water: number;
+  contaminated: number;
+  tribes: TribePollution[];
+  hotspots: PollutionCell[];
+}
+
+export interface PopulationCapRequest {
+  world_cap?: number | null;
+  region_cap?: number | null;
+  region_size?: number | null;
+}
+
+export interface PopulationCapStatus {
+  world_cap: number;
+  region_cap: number;
+  region_size: number;
+  population: number;
+  pooled: number;
+  pooled_species: { [key: string]: number };
+  emigrated: number;
+  dispersed: number;
+  returned: number;
+  settled: number;
+  crowded: CapRegion[];
+}
+
+export interface PopulationConfigSettings {
+  default_pop_size: number;
+  max_population: number;
+  region_size: number;
+  region_soft_cap: number;
+  mutation_rate_base: number;
+  mutation_rate_range: number;
+  selection_pressure: number;
+  carrying_capacity: number;
+}
+
+export interface PopulationCreateRequest {
+  name: string;
+  traits?: { [key: string]: number };
+}
+
+export interface PopulationCreateResponse {
+  name: string;
+  traits: { [key: string]: number };
+}
+
+export interface PopulationData {
+  name: string;
+  species: string;
+  count: number;
+  avg_fitness: number;
+  avg_energy: number;
+  avg_age: number;
+  generation: number;
+  trait_averages: { [key: string]: number };
+  dietary_adaptation_count: number;
+  env_adaptation_count: number;
+  avg_dietary_fitness: number;
+  avg_env_fitness: number;
+  plant_preferences: number;
+  prey_preferences: number;
+}
+
+export interface PopulationDiff {
+  count_a: number;
+  count_b: number;
+  delta: number;
+}
+
+export interface PopulationHistorySnapshot {
+  tick: number;
+  timestamp: string;
+  populations: PopulationData[];
+  first_tick?: number;
+  samples?: number;
+}
+
+export interface PopulationPyramid {
+  group: string;
+  population: number;
+  youth_share: number;
+  cohorts: PyramidCohort[];
+}
+
+export interface PopulationSelection {
+  population: string;
+  strategy: string;
+  generation: number;
+  size: number;
+  average_fitness: number;
+  best_fitness: number;
+  novelty_archive?: number;
+}
+
+export interface Position {
+  x: number;
+  y: number;
+}
+
+export interface Prediction {
+  spectator: string;
+  option: string;
+  stake: number;
+  payout?: number;
+  tick: number;
+}
+
+export interface PredictionRequest {
+  round_id: number;
+  spectator: string;
+  option: string;
+  stake: number;
+}
+
+export interface PredictionRound {
+  id: number;
+  question: string;
+  kind: string;
+  options: string[];
+  trigger?: string;
+  open_tick: number;
+  bets_close_tick: number;
+  resolve_tick: number;
+  status: string;
+  outcome?: string[];
+  pool: number;
+  predictions: Prediction[];
+}
+
+export interface PredictionRoundRequest {
+  question: string;
+  kind: string;
+  options: string[];
+  duration: number;
+}
+
+export interface PressureDetail {
+  id: number;
+  type: string;
+  name: string;
+  severity: number;
+  duration: number;
+  affected_x: number;
+  affected_y: number;
+  radius: number;
+}
+
+export interface PyramidCohort {
+  label: string;
+  female: number;
+  male: number;
+  asexual: number;
+}
+
+export interface RadiationEpisode {
+  tick: number;
+  start_tick: number;
+  ancestor: string;
+  daughters: string[];
+  niches: string[];
+  before: { [key: string]: number };
+  after: { [key: string]: number };
+  disparity: { [key: string]: number };
+}
+
+export interface RedQueenAxis {
+  attacker_trait: string;
+  defender_trait: string;
+  correlation: number;
+  best_lag: number;
+  best_correlation: number;
+  leader: string;
+  attacker_amplitude: number;
+  defender_amplitude: number;
+  ticks: number[];
+  attacker_series: number[];
+  defender_series: number[];
+}
+
+export interface RedQueenReport {
+  kind: string;
+  attacker: string;
+  defender: string;
+  interactions: number;
+  samples: number;
+  axes: RedQueenAxis[];
+}
+
+export interface RegistryEntry {
+  id: string;
+  kind: string;
+  name: string;
+  description: string;
+  author: string;
+  sha256: string;
+  signature: string;
+  public_key: string;
+  uploaded_at: string;
+}
+
+export interface RegistryUploadRequest {
+  kind: string;
+  entity_id: number;
+  mate_id: number;
+  name: string;
+  description: string;
+  author: string;
+}
+
+export interface RendererLegend {
+  renderer: string;
+  sections: LegendSection[];
+}
+
+export interface ReproductionData {
+  active_eggs: number;
+  decaying_items: number;
+  pregnant_entities: number;
+  ready_to_mate: number;
+  mating_season_entities: number;
+  migrating_entities: number;
+  reproduction_modes: { [key: string]: number };
+  mating_strategies: { [key: string]: number };
+  seasonal_mating_rate: number;
+  territories_with_mating: number;
+  cross_species_mating: number;
+}
+
+export interface ResourceBudgetConfig {
+  enabled: boolean;
+  sunlight_per_cell: number;
+  water_per_cell: number;
+  nutrients_per_cell: number;
+  water_regeneration: number;
+  nutrient_regeneration: number;
+  plant_uptake: number;
+  photosynthesis: number;
+  soil_bonus: number;
+  creatures_per_cell: number;
+  biomass_per_creature: number;
+}
+
+export interface ResourceBudgetStatus {
+  enabled: boolean;
+  settings: ResourceBudgetConfig;
+  tick: number;
+  sunlight: ResourcePool;
+  water: ResourcePool;
+  nutrients: ResourcePool;
+  prey_biomass: number;
+  plants: number;
+  creatures: number;
+  plant_supply: number;
+  creature_capacity: number;
+  seedlings_refused: number;
+  biomes: BiomeResourceBudget[];
+}
+
+export interface ResourcePolicy {
+  limit: number;
+  auto_prune: boolean;
+}
+
+export interface ResourcePolicyRequest {
+  store: string;
+  limit?: number | null;
+  auto_prune?: boolean | null;
+  prune: boolean;
+}
+
+export interface ResourcePool {
+  level: number;
+  capacity: number;
+}
+
+export interface ResourceReport {
+  tick: number;
+  sample_interval: number;
+  heap_bytes: number;
+  heap_objects: number;
+  heap_history: number[];
+  estimated_bytes: number;
+  stores: ResourceStoreStatus[];
+  alerts: number;
+}
+
+export interface ResourceStoreStatus {
+  name: string;
+  description: string;
+  count: number;
+  estimated_bytes: number;
+  growth: number;
+  prunable: boolean;
+  prunes_orphans: boolean;
+  policy: ResourcePolicy;
+  pruned: number;
+  leak?: string;
+  history: number[];
+}
+
+export interface RunCertificate {
+  seed: number;
+  primitive: boolean;
+  rng_streams?: boolean;
+  unordered?: boolean;
+  config: WorldConfig;
+  config_hash: string;
+  start_tick: number;
+  epoch_ticks: number;
+  genesis: string;
+  links: CertificateLink[];
+  head: string;
+}
+
+export interface SaveDiff {
+  tick_a: number;
+  tick_b: number;
+  plant_count_a: number;
+  plant_count_b: number;
+  populations: { [key: string]: PopulationDiff };
+  traits: { [key: string]: { [key: string]: TraitDistributionDiff } };
+  geography: GeographyDiff;
+  tech: TechDiff[];
+}
+
+export interface SaveIssue {
+  severity: string;
+  kind: string;
+  message: string;
+  repaired: boolean;
+}
+
+export interface SaveResponse {
+  filename: string;
+  tick: number;
+}
+
+export interface SaveValidationReport {
+  issues: SaveIssue[];
+  truncated: boolean;
+  repaired: boolean;
+}
+
+export interface SavedMigrationRoute {
+  id: number;
+  species: string;
+  reason: string;
+  waypoints: Position[];
+  learned_at: number;
+  uses: number;
+  knowers: number;
+  knower_ids: number[];
+}
+
+export interface ScheduleUpdateRequest {
+  system: string;
+  interval?: number | null;
+  trigger?: boolean;
+}
+
+export interface ScheduledSystemStatus {
+  name: string;
+  description: string;
+  interval: number;
+  default_interval: number;
+  triggered: boolean;
+  runs: number;
+  last_run_tick: number;
+  last_duration_ms: number;
+  mean_duration_ms: number;
+}
+
+export interface ScheduledWorldEvent {
+  tick: number;
+  name: string;
+  duration: number;
+}
+
+export interface SeasonEffects {
+  temperature: number;
+  plant_growth: number;
+  food: number;
+  mating: boolean;
+  migration: boolean;
+}
+
+export interface SeasonStatus {
+  season: string;
+  day: number;
+  length: number;
+  next: string;
+  year: number;
+  effects: SeasonEffects;
+  migrants: number;
+  since_tick: number;
+}
+
+export interface SeasonsConfig {
+  spring: SeasonEffects;
+  summer: SeasonEffects;
+  autumn: SeasonEffects;
+  winter: SeasonEffects;
+}
+
+export interface SelectionReport {
+  strategies: SelectionStrategyInfo[];
+  populations: PopulationSelection[];
+}
+
+export interface SelectionStrategyInfo {
+  name: string;
+  description: string;
+}
+
+export interface SelectionStrategyRequest {
+  population: string;
+  strategy: string;
+}
+
+export interface SimulationConfig {
+  time: TimeConfig;
+  day_night: DayNightConfig;
+  seasons: SeasonsConfig;
+  energy: EnergyConfig;
+  population: PopulationConfigSettings;
+  physics: PhysicsConfig;
+  world: WorldConfigSettings;
+  evolution: EvolutionConfig;
+  biomes: BiomesConfig;
+  plants: PlantsConfig;
+  resource_budget: ResourceBudgetConfig;
+  timescales: TimescaleConfig;
+  web: WebConfig;
+  gallery: GalleryConfig;
+  schedule?: { [key: string]: number };
+}
+
+export interface SimulationState {
+  schema_version: number;
+  saved_at: string;
+  tick: number;
+  next_id: number;
+  next_plant_id: number;
+  config: WorldConfig;
+  seed?: number;
+  entities: (EntityState | null)[];
+  plants: (PlantState | null)[];
+  biomes: number[][];
+  events: (WorldEventState | null)[];
+  time: AdvancedTimeState;
+  wind: WindSystemState;
+  species: SpeciationSystemState;
+  network: PlantNetworkState;
+  tribes?: (TribeState | null)[];
+  achievements?: AchievementState | null;
+  migrations?: MigrationState | null;
+  dispersal?: DispersalState | null;
+  selection?: { [key: string]: string };
+}
+
+export interface SpeciationSystemState {
+  species: { [key: string]: SpeciesState | null };
+  next_species_id: number;
+}
+
+export interface SpeciesData {
+  active_species: number;
+  extinct_species: number;
+  species_details: SpeciesDetailData[];
+  total_species_ever: number;
+  species_with_members: number;
+  species_awaiting_extinction: number;
+  has_speciation_system: boolean;
+}
+
+export interface SpeciesDetailData {
+  id: number;
+  name: string;
+  population: number;
+  is_extinct: boolean;
+  formation_tick: number;
+  extinction_tick: number;
+  peak_population: number;
+  awaiting_extinction: boolean;
+  traits?: { [key: string]: number };
+}
+
+export interface SpeciesDisturbance {
+  species: string;
+  population: number;
+  in_zone: number;
+  zone_share: number;
+  sensitivity: number;
+  response: string;
+  displaced: number;
+  energy_lost: number;
+}
+
+export interface SpeciesPolicy {
+  species: string;
+  player_id: string;
+  target: PolicySettings;
+  effective: PolicySettings;
+  set_tick: number;
+}
+
+export interface SpeciesProfile {
+  name: string;
+  kind: string;
+  id?: number;
+  population: number;
+  peak_population: number;
+  extinct: boolean;
+  formation_tick: number;
+  extinction_tick?: number;
+  traits: { [key: string]: TraitDistribution };
+  relatives: SpeciesRelative[];
+  member_ids: number[];
+}
+
+export interface SpeciesRelative {
+  name: string;
+  relation: string;
+  distance: number;
+}
+
+export interface SpeciesState {
+  id: number;
+  name: string;
+  parent_id: number;
+  created_at: number;
+  extinct_at: number;
+  is_extinct: boolean;
+  population: number;
+  base_traits: { [key: string]: number };
+}
+
+export interface Spectator {
+  name: string;
+  points: number;
+  correct: number;
+  wrong: number;
+}
+
+export interface SpilloverEvent {
+  tick: number;
+  direction: string;
+  tribe_id: number;
+  tribe_name: string;
+  route: string;
+  from_id: number;
+  from_species: string;
+  to_id: number;
+  to_species: string;
+  contacts: number;
+  probability: number;
+}
+
+export interface StatisticalData {
+  total_events: number;
+  total_snapshots: number;
+  total_anomalies: number;
+  total_energy: number;
+  energy_change: number;
+  energy_trend: string;
+  population_trend: string;
+  recent_events: StatisticalEventData[];
+  latest_snapshot?: StatisticalSnapshotData | null;
+}
+
+export interface StatisticalEvent {
+  timestamp: string;
+  tick: number;
+  event_type: string;
+  category: string;
+  entity_id?: number;
+  plant_id?: number;
+  position?: Position | null;
+  old_value?: unknown;
+  new_value?: unknown;
+  change?: number;
+  metadata: { [key: string]: unknown };
+  impacted_ids?: number[];
+}
+
+export interface StatisticalEventData {
+  tick: number;
+  type: string;
+  target: string;
+  change: number;
+  description: string;
+}
+
+export interface StatisticalSnapshot {
+  tick: number;
+  timestamp: string;
+  total_entities: number;
+  total_plants: number;
+  total_energy: number;
+  species_count: number;
+  populations_by_species: { [key: string]: number };
+  trait_distributions: { [key: string]: number[] };
+  biome_distributions: { [key: string]: number };
+  resource_distribution: { [key: string]: number };
+  physics_metrics: PhysicsSnapshot;
+  communication_metrics: CommunicationSnapshot;
+}
+
+export interface StatisticalSnapshotData {
+  tick: number;
+  total_energy: number;
+  population_count: number;
+  trait_averages: { [key: string]: number };
+  physics_metrics: { [key: string]: number };
+}
+
+export interface StepRequest {
+  ticks: number;
+}
+
+export interface StepResult {
+  requested: number;
+  executed: number;
+  tick: number;
+  digest: string;
+  subsystems: { [key: string]: string };
+  stopped_by?: string;
+  entity_count: number;
+  plant_count: number;
+  populations: { [key: string]: number };
+}
+
+export interface SymbioticRelationshipData {
+  total_relationships: number;
+  active_relationships: number;
+  active_parasitic: number;
+  active_mutualistic: number;
+  active_commensal: number;
+  average_relationship_age: number;
+  disease_transmission_rate: number;
+  average_virulence: number;
+  average_transmission: number;
+  relationship_types: { [key: string]: number };
+}
+
+export interface Synapse {
+  from_neuron_id: number;
+  to_neuron_id: number;
+  weight: number;
+  strength: number;
+  last_active: number;
+}
+
+export interface TechDiff {
+  tribe_id: number;
+  name: string;
+  level_a: number;
+  level_b: number;
+}
+
+export interface TerraformRequest {
+  biome: string;
+  cells: GridPoint[];
+  from?: GridPoint | null;
+  to?: GridPoint | null;
+}
+
+export interface TimeConfig {
+  ticks_per_day: number;
+  days_per_season: number;
+  daily_energy_base: number;
+  night_penalty: number;
+  seasonal_variation: number;
+}
+
+export interface TimelineBucket {
+  start_tick: number;
+  end_tick: number;
+  counts: { [key: string]: number };
+}
+
+export interface TimelineMarker {
+  id: number;
+  tick: number;
+  type: string;
+  category: string;
+  severity: string;
+  description: string;
+}
+
+export interface TimescaleAudit {
+  profile: string;
+  scales: TimescaleConfig;
+  ticks_per_day: number;
+  reference: string;
+  reference_lifespan: number;
+  reference_maturity: number;
+  year_ticks: number;
+  constants: TimescaleConstant[];
+  findings: TimescaleFinding[];
+}
+
+export interface TimescaleConfig {
+  profile: string;
+  lifespan_scale: number;
+  event_duration_scale: number;
+  mutation_rate_scale: number;
+  gestation_scale: number;
+  decay_scale: number;
+  season_scale: number;
+}
+
+export interface TimescaleConstant {
+  group: string;
+  name: string;
+  min_ticks: number;
+  max_ticks: number;
+  min_days: number;
+  max_days: number;
+  lifespans: number;
+  rescalable: boolean;
+}
+
+export interface TimescaleFinding {
+  check: string;
+  groups: string[];
+  message: string;
+  ratio: number;
+  suggested_group: string;
+  suggested_scale: number;
+}
+
+export interface TimescaleRequest {
+  group: string;
+  factor: number;
+}
+
+export interface ToolData {
+  total_tools: number;
+  owned_tools: number;
+  dropped_tools: number;
+  avg_durability: number;
+  avg_efficiency: number;
+  tool_types: { [key: string]: number };
+}
+
+export interface TopologyData {
+  elevation_range: string;
+  fluid_regions: number;
+  geological_age: number;
+}
+
+export interface TradeAgreementData {
+  id: number;
+  colony1_id: number;
+  colony2_id: number;
+  offered_resources: { [key: string]: number };
+  wanted_resources: { [key: string]: number };
+  duration: number;
+  is_active: boolean;
+}
+
+export interface TraitColors {
+  diet: string;
+  aggression: string;
+}
+
+export interface TraitDefinition {
+  name: string;
+  subject: string;
+  kind: string;
+  min: number;
+  max: number;
+  description: string;
+  systems: string[];
+  prefix?: boolean;
+  custom?: boolean;
+}
+
+export interface TraitDistribution {
+  mean: number;
+  std_dev: number;
+  min: number;
+  max: number;
+}
+
+export interface TraitDistributionDiff {
+  mean_a: number;
+  mean_b: number;
+  std_dev_a: number;
+  std_dev_b: number;
+  mean_delta: number;
+}
+
+export interface TraitEditRequest {
+  entity_id: number;
+  trait: string;
+  value: number;
+}
+
+export interface TraitLegend {
+  scale: LegendScale;
+  colors: LegendColorScheme[];
+}
+
+export interface TribeFoodStore {
+  tribe_id: number;
+  name: string;
+  tech_level: number;
+  preservation: string[];
+  stored: number;
+  cover: number;
+  spoilage: number;
+  spoiled: number;
+  status: string;
+  famines: number;
+  famine_ticks: number;
+}
+
+export interface TribePollution {
+  tribe_id: number;
+  name: string;
+  tech_level: number;
+  members: number;
+  produced: number;
+  released: number;
+  treatment: number;
+  exposure: number;
+  energy_lost: number;
+}
+
+export interface TribeState {
+  id: number;
+  name: string;
+  tech_level: number;
+  member_count: number;
+  resources: { [key: string]: number };
+}
+
+export interface Tutorial {
+  scenario: string;
+  name: string;
+  status: string;
+  start_tick: number;
+  end_tick?: number;
+  current: number;
+  steps: TutorialStep[];
+}
+
+export interface TutorialRequest {
+  scenario: string;
+}
+
+export interface TutorialScenario {
+  id: string;
+  name: string;
+  description: string;
+}
+
+export interface TutorialStep {
+  title: string;
+  instructions: string;
+  action?: string;
+  target?: GridPoint | null;
+  done: boolean;
+  done_tick?: number;
+  observation?: string;
+}
+
+export interface ViewData {
+  tick: number;
+  time_string: string;
+  season: SeasonStatus;
+  entity_count: number;
+  plant_count: number;
+  population_count: number;
+  event_count: number;
+  speed_multiplier: number;
+  paused: boolean;
+  unlimited_speed: boolean;
+  max_cpu: number;
+  ticks_per_second: number;
+  breakpoint_hit?: string;
+  follow?: FollowTrack | null;
+  viewport_x: number;
+  viewport_y: number;
+  zoom_level: number;
+  grid_width: number;
+  grid_height: number;
+  grid_shape: string;
+  grid: CellData[][];
+  chunks?: GridChunk[];
+  chunk_size?: number;
+  stats: { [key: string]: unknown };
+  events: EventData[];
+  alerts: AlertData[];
+  player_groups: PlayerGroupData[];
+  species_policies: SpeciesPolicy[];
+  game?: CompetitiveGame | null;
+  tutorial?: Tutorial | null;
+  predictions?: PredictionRound[];
+  latest_snapshot: number;
+  populations: PopulationData[];
+  communication: CommunicationData;
+  civilization: CivilizationData;
+  physics: PhysicsData;
+  wind: WindData;
+  species: SpeciesData;
+  network: NetworkData;
+  dna: DNAData;
+  cellular: CellularData;
+  evolution: EvolutionData;
+  topology: TopologyData;
+  tools: ToolData;
+  environmental_mod: EnvironmentalModData;
+  environmental_pressures: EnvironmentalPressureData;
+  symbiotic_relationships: SymbioticRelationshipData;
+  emergent_behavior: EmergentBehaviorData;
+  feedback_loops: FeedbackLoopData;
+  reproduction: ReproductionData;
+  warfare: WarfareData;
+  fungal: FungalData;
+  cultural: CulturalData;
+  statistical: StatisticalData;
+  ecosystem: EcosystemMetrics;
+  anomalies: AnomaliesData;
+  neural: NeuralData;
+  biome_boundary: BiomeBoundaryData;
+  biorhythm: BioRhythmData;
+  details_tick: number;
+  details_age_ms: number;
+  population_history: PopulationHistorySnapshot[];
+  communication_history: CommunicationHistorySnapshot[];
+  physics_history: PhysicsHistorySnapshot[];
+}
+
+export interface WarfareData {
+  total_colonies: number;
+  active_conflicts: number;
+  total_alliances: number;
+  active_trade_agreements: number;
+  total_relations: number;
+  neutral_relations: number;
+  allied_relations: number;
+  enemy_relations: number;
+  truce_relations: number;
+  trading_relations: number;
+  vassal_relations: number;
+  conflicts: ConflictData[];
+  alliances: AllianceData[];
+  trade_agreements: TradeAgreementData[];
+  colony_details: ColonyDetailData[];
+}
+
+export interface WebConfig {
+  update_interval: number;
+  port: number;
+  max_clients: number;
+}
+
+export interface WebSocketStats {
+  clients: number;
+  evicted: number;
+  skipped_frames: number;
+}
+
+export interface WindData {
+  direction: number;
+  strength: number;
+  turbulence_level: number;
+  weather_pattern: string;
+  pollen_count: number;
+  seed_count: number;
+  seed_banks: number;
+  germination_events: number;
+  dormancy_activations: number;
+  dispersal_stats: { [key: string]: unknown };
+}
+
+export interface WindSystemState {
+  base_wind_direction: number;
+  base_wind_strength: number;
+  turbulence_level: number;
+  seasonal_multiplier: number;
+  weather_pattern: number;
+}
+
+export interface WorldConfig {
+  Width: number;
+  Height: number;
+  NumPopulations: number;
+  PopulationSize: number;
+  GridWidth: number;
+  GridHeight: number;
+  Breakpoints: string[];
+  FogOfWar: boolean;
+  Profile: string;
+  CustomTraits: CustomTraitDefinition[];
+  Geometry?: string;
+  GridShape?: string;
+  Simulation?: SimulationConfig | null;
+  EventTimetable?: ScheduledWorldEvent[];
+  RandomEventsOff?: boolean;
+}
+
+export interface WorldConfigSettings {
+  width: number;
+  height: number;
+  grid_width: number;
+  grid_height: number;
+  biome_variety: number;
+  event_frequency: number;
+}
+
+export interface WorldEventState {
+  name: string;
+  description: string;
+  duration: number;
+  global_mutation: number;
+  global_damage: number;
+  biome_changes: { [key: string]: number };
+}
+
+export interface WorldInfo {
+  width: number;
+  height: number;
+  tick: number;
+  totalEntities: number;
+  totalPlants: number;
+  mapWidth: number;
+  mapHeight: number;
+  seaLevel: number;
+  geometry: string;
+}
+
+export interface ZoonosisReport {
+  tick: number;
+  infected_wild: number;
+  infected_colony: number;
+  colonies: ColonyContact[];
+  spillovers: SpilloverEvent[];
+}
+
+/** A request the server refused */
+export class EvoSimError extends Error {
+  constructor(public status: number, message: string) {
+    super(`evosim: ${status} ${message}`);
+  }
+}
+
+/** An open WebSocket to the live simulation */
+export interface EvoSimSocket {
+  socket: WebSocket;
+  send(message: ClientMessage): void;
+  close(): void;
+}
+
+/** Calls the EvoSim HTTP API */
+export class EvoSimClient {
+  /** baseURL is e.g. http://localhost:8080, or empty for the page's own server */
+  constructor(
+    private baseURL = "",
+    private fetchImpl: typeof fetch = globalThis.fetch.bind(globalThis),
+  ) {
+    this.baseURL = baseURL.replace(/\/+$/, "");
+  }
+
+  /** The address of the live simulation WebSocket */
+  webSocketURL(): string {
+    const base = this.baseURL || globalThis.location.origin;
+    return base.replace(/^http/, "ws") + "/ws";
+  }
+
+  /** Opens the WebSocket, handing every message the server sends to onMessage */
+  connect(onMessage: (message: ServerMessage) => void): EvoSimSocket {
+    const socket = new WebSocket(this.webSocketURL());
+    socket.onmessage = (event) => onMessage(JSON.parse(event.data) as ServerMessage);
+    return {
+      socket,
+      send: (message) => socket.send(JSON.stringify(message)),
+      close: () => socket.close(),
+    };
+  }
+
+  /** GET /api/status: Simulation status and speed */
+  getStatus(): Promise<GetStatusResponse> {
+    return this.request("GET", "/api/status", {}, undefined, false);
+  }
+
+  /** GET /api/spec: This OpenAPI description of the HTTP API */
+  getOpenAPISpec(): Promise<{ [key: string]: unknown }> {
+    return this.request("GET", "/api/spec", {}, undefined, false);
+  }
+
+  /** GET /api/spec/asyncapi: AsyncAPI description of the WebSocket protocol at /ws */
+  getAsyncAPISpec(): Promise<{ [key: string]: unknown }> {
+    return this.request("GET", "/api/spec/asyncapi", {}, undefined, false);
+  }
+
+  /** GET /api/export/events: Export events from the central event bus */
+  exportEvents(params: ExportEventsParams = {}): Promise<ExportEventsResponse> {
+    return this.request("GET", "/api/export/events", { ...params, format: "json" }, undefined, false);
+  }
+
+  /** GET /api/export/analysis: Export statistical analysis data */
+  exportAnalysis(): Promise<ExportAnalysisResponse> {
+    return this.request("GET", "/api/export/analysis", { format: "json" }, undefined, false);
+  }
+
+  /** GET /api/export/anomalies: Export detected anomalies */
+  exportAnomalies(): Promise<ExportAnomaliesResponse> {
+    return this.request("GET", "/api/export/anomalies", { format: "json" }, undefined, false);
+  }
+
+  /** GET /api/export/geojson: Export world geography and species ranges as GeoJSON */
+  exportGeoJSON(params: ExportGeoJSONParams = {}): Promise<GeoJSONFeatureCollection> {
+    return this.request("GET", "/api/export/geojson", params, undefined, false);
+  }
+
+  /** GET /api/export/darwincore: Export occurrence records in Darwin Core format */
+  exportDarwinCore(params: ExportDarwinCoreParams = {}): Promise<DarwinCoreRecord[]> {
+    return this.request("GET", "/api/export/darwincore", { ...params, format: "json" }, undefined, false);
+  }
+
+  /** GET /api/export/certificate: The run certificate: seed, config and state hash chain */
+  exportCertificate(): Promise<RunCertificate> {
+    return this.request("GET", "/api/export/certificate", {}, undefined, false);
+  }
+
+  /** POST /api/diff: Compare a save file against the live simulation */
+  diffSave(body: SimulationState | null): Promise<DiffSaveResponse> {
+    return this.request("POST", "/api/diff", {}, body, false);
+  }
+
+  /** GET /api/validate: Check the live state for corruption */
+  validateLiveState(): Promise<ValidateLiveStateResponse> {
+    return this.request("GET", "/api/validate", {}, undefined, false);
+  }
+
+  /** POST /api/validate: Check a save file for corruption, optionally repairing it */
+  validateSave(params: ValidateSaveParams = {}, body: SimulationState | null): Promise<ValidateSaveResponse> {
+    return this.request("POST", "/api/validate", params, body, false);
+  }
+
+  /** GET /api/creatures/export: Download an entity or breeding pair as a creature file */
+  exportCreature(params: ExportCreatureParams): Promise<CreatureFile> {
+    return this.request("GET", "/api/creatures/export", params, undefined, false);
+  }
+
+  /** POST /api/creatures/import: Add the creatures in a creature file to the world */
+  importCreature(params: ImportCreatureParams = {}, body: CreatureFile | null): Promise<ImportCreatureResponse> {
+    return this.request("POST", "/api/creatures/import", params, body, false);
+  }
+
+  /** GET /api/registry/list: List files on the creature registry */
+  listRegistry(params: ListRegistryParams = {}): Promise<RegistryEntry[]> {
+    return this.request("GET", "/api/registry/list", params, undefined, false);
+  }
+
+  /** POST /api/registry/import: Download, verify and apply a registry file */
+  importRegistryFile(params: ImportRegistryFileParams): Promise<ImportRegistryFileResponse> {
+    return this.request("POST", "/api/registry/import", params, undefined, false);
+  }
+
+  /** POST /api/registry/upload: Publish a creature or the current world as a scenario */
+  uploadToRegistry(body: RegistryUploadRequest): Promise<RegistryEntry> {
+    return this.request("POST", "/api/registry/upload", {}, body, false);
+  }
+
+  /** GET /api/petri: List petri dishes */
+  listPetriDishes(): Promise<{ [key: string]: unknown }[]> {
+    return this.request("GET", "/api/petri", {}, undefined, false);
+  }
+
+  /** POST /api/petri: Create a petri dish seeded with populations */
+  createPetriDish(body: PetriDishCreateRequest): Promise<CreatePetriDishResponse> {
+    return this.request("POST", "/api/petri", {}, body, false);
+  }
+
+  /** GET /api/petri/dish: Report a petri dish */
+  getPetriDish(params: GetPetriDishParams): Promise<PetriDishStats> {
+    return this.request("GET", "/api/petri/dish", params, undefined, false);
+  }
+
+  /** POST /api/petri/dish: Adjust a petri dish and optionally advance it */
+  updatePetriDish(params: UpdatePetriDishParams, body: PetriDishUpdateRequest): Promise<PetriDishStats> {
+    return this.request("POST", "/api/petri/dish", params, body, false);
+  }
+
+  /** DELETE /api/petri/dish: Remove a petri dish */
+  deletePetriDish(params: DeletePetriDishParams): Promise<void> {
+    return this.request("DELETE", "/api/petri/dish", params, undefined, false);
+  }
+
+  /** GET /api/breakpoints: List breakpoints and the one that last paused the simulation */
+  listBreakpoints(): Promise<ListBreakpointsResponse> {
+    return this.request("GET", "/api/breakpoints", {}, undefined, false);
+  }
+
+  /** POST /api/breakpoints: Add a breakpoint */
+  addBreakpoint(body: BreakpointRequest): Promise<Breakpoint> {
+    return this.request("POST", "/api/breakpoints", {}, body, false);
+  }
+
+  /** DELETE /api/breakpoints: Remove a breakpoint */
+  removeBreakpoint(params: RemoveBreakpointParams): Promise<RemoveBreakpointResponse> {
+    return this.request("DELETE", "/api/breakpoints", params, undefined, false);
+  }
+
+  /** GET /api/timeline: Event counts bucketed over a range of ticks */
+  getTimeline(params: GetTimelineParams = {}): Promise<EventTimeline> {
+    return this.request("GET", "/api/timeline", params, undefined, false);
+  }
+
+  /** GET /api/entity/{id}: Everything about an entity: activity, DNA, neural network, memory and relationships */
+  inspectEntity(id: string): Promise<EntityInspection> {
+    return this.request("GET", "/api/entity/" + encodeURIComponent(id), {}, undefined, false);
+  }
+
+  /** GET /api/cell/{x}/{y}: A grid cell's ground and the entities and plants in it */
+  inspectCell(x: string, y: string): Promise<CellInspection> {
+    return this.request("GET", "/api/cell/" + encodeURIComponent(x) + "/" + encodeURIComponent(y), {}, undefined, false);
+  }
+
+  /** GET /api/follow: The creature the shared camera follows and what it did lately */
+  getFollow(): Promise<FollowState> {
+    return this.request("GET", "/api/follow", {}, undefined, false);
+  }
+
+  /** POST /api/follow: Lock the shared camera onto a creature, recording its decisions each tick */
+  followEntity(body: FollowRequest): Promise<FollowState> {
+    return this.request("POST", "/api/follow", {}, body, false);
+  }
+
+  /** DELETE /api/follow: Let the shared camera move freely again */
+  unfollowEntity(): Promise<FollowState> {
+    return this.request("DELETE", "/api/follow", {}, undefined, false);
+  }
+
+  /** GET /api/entities: Page through entities in ID order */
+  listEntities(params: ListEntitiesParams = {}): Promise<EntityListResponse> {
+    return this.request("GET", "/api/entities", params, undefined, false);
+  }
+
+  /** GET /api/populations: Populations by species name */
+  listPopulations(): Promise<PopulationData[]> {
+    return this.request("GET", "/api/populations", {}, undefined, false);
+  }
+
+  /** POST /api/populations: Add a species with adjusted starting traits */
+  createPopulation(body: PopulationCreateRequest): Promise<PopulationCreateResponse> {
+    return this.request("POST", "/api/populations", {}, body, false);
+  }
+
+  /** GET /api/species: Creature and plant species with their trait spread, relatives and members */
+  listSpecies(): Promise<SpeciesProfile[]> {
+    return this.request("GET", "/api/species", {}, undefined, false);
+  }
+
+  /** GET /api/species/{name}: A creature or plant species with its trait spread, relatives and members */
+  getSpecies(name: string): Promise<SpeciesProfile> {
+    return this.request("GET", "/api/species/" + encodeURIComponent(name), {}, undefined, false);
+  }
+
+  /** GET /api/species/{id}/sprite: SVG sprite of a species drawn from its members' traits */
+  getSpeciesSprite(id: string): Promise<string> {
+    return this.request("GET", "/api/species/" + encodeURIComponent(id) + "/sprite", {}, undefined, true);
+  }
+
+  /** GET /api/control: Pause, speed and viewport state */
+  getControlState(): Promise<ControlState> {
+    return this.request("GET", "/api/control", {}, undefined, false);
+  }
+
+  /** POST /api/control/pause: Pause, resume or toggle the simulation */
+  setPaused(body: ControlPauseRequest): Promise<ControlState> {
+    return this.request("POST", "/api/control/pause", {}, body, false);
+  }
+
+  /** POST /api/control/speed: Set the speed multiplier, unlimited mode and CPU cap */
+  setSpeed(body: ControlSpeedRequest): Promise<ControlState> {
+    return this.request("POST", "/api/control/speed", {}, body, false);
+  }
+
+  /** POST /api/control/viewport: Pan and zoom the shared view */
+  setViewport(body: ControlViewportRequest): Promise<ControlState> {
+    return this.request("POST", "/api/control/viewport", {}, body, false);
+  }
+
+  /** POST /api/control/reset: Restart the world with the default populations */
+  resetWorld(): Promise<ControlState> {
+    return this.request("POST", "/api/control/reset", {}, undefined, false);
+  }
+
+  /** GET /api/performance: Ticks per second and how often each scheduled subsystem runs */
+  getPerformance(): Promise<PerformanceReport> {
+    return this.request("GET", "/api/performance", {}, undefined, false);
+  }
+
+  /** POST /api/performance: Change a scheduled subsystem's interval or run it on the next tick */
+  updateSchedule(body: ScheduleUpdateRequest): Promise<PerformanceReport> {
+    return this.request("POST", "/api/performance", {}, body, false);
+  }
+
+  /** GET /api/save: The full simulation state */
+  downloadSave(): Promise<SimulationState> {
+    return this.request("GET", "/api/save", {}, undefined, false);
+  }
+
+  /** POST /api/save: Save the simulation state to a file on the server */
+  saveOnServer(): Promise<SaveResponse> {
+    return this.request("POST", "/api/save", {}, undefined, false);
+  }
+
+  /** POST /api/load: Replace the world with a save */
+  loadSave(body: SimulationState | null): Promise<ControlState> {
+    return this.request("POST", "/api/load", {}, body, false);
+  }
+
+  /** GET /api/autosave: Autosave settings, the autosaves kept and any autosave from a crashed or interrupted run */
+  getAutosave(): Promise<AutosaveStatus> {
+    return this.request("GET", "/api/autosave", {}, undefined, false);
+  }
+
+  /** POST /api/autosave/resume: Replace the world with the autosave on offer */
+  resumeAutosave(): Promise<AutosaveStatus> {
+    return this.request("POST", "/api/autosave/resume", {}, undefined, false);
+  }
+
+  /** DELETE /api/autosave/resume: Decline the autosave on offer, keeping its file */
+  dismissAutosave(): Promise<AutosaveStatus> {
+    return this.request("DELETE", "/api/autosave/resume", {}, undefined, false);
+  }
+
+  /** GET /api/operator/structures: List operator barriers and corridors with their experiments */
+  listOperatorStructures(): Promise<ListOperatorStructuresResponse> {
+    return this.request("GET", "/api/operator/structures", {}, undefined, false);
+  }
+
+  /** POST /api/operator/structures: Build a barrier or corridor */
+  buildOperatorStructure(body: OperatorStructureRequest): Promise<OperatorStructure> {
+    return this.request("POST", "/api/operator/structures", {}, body, false);
+  }
+
+  /** DELETE /api/operator/structures: Remove a barrier or corridor */
+  removeOperatorStructure(params: RemoveOperatorStructureParams): Promise<void> {
+    return this.request("DELETE", "/api/operator/structures", params, undefined, false);
+  }
+
+  /** POST /api/operator/terraform: Change the biome of grid cells */
+  terraform(params: TerraformParams = {}, body: TerraformRequest): Promise<Intervention> {
+    return this.request("POST", "/api/operator/terraform", params, body, false);
+  }
+
+  /** POST /api/operator/traits: Change a trait of a single entity */
+  editTrait(params: EditTraitParams = {}, body: TraitEditRequest): Promise<Intervention> {
+    return this.request("POST", "/api/operator/traits", params, body, false);
+  }
+
+  /** GET /api/operator/interventions: The intervention undo and redo history */
+  listInterventions(params: ListInterventionsParams = {}): Promise<ListInterventionsResponse> {
+    return this.request("GET", "/api/operator/interventions", params, undefined, false);
+  }
+
+  /** POST /api/operator/interventions: Undo or redo the latest intervention */
+  undoRedoIntervention(params: UndoRedoInterventionParams = {}, body: InterventionRequest): Promise<Intervention> {
+    return this.request("POST", "/api/operator/interventions", params, body, false);
+  }
+
+  /** POST /api/operator/events: Start a wildfire, storm, flood or other environmental event on a grid cell */
+  startEnvironmentalEvent(params: StartEnvironmentalEventParams = {}, body: EnvironmentalEventRequest): Promise<EnvironmentalEventResponse> {
+    return this.request("POST", "/api/operator/events", params, body, false);
+  }
+
+  /** GET /api/game: The current or last competitive game */
+  getGame(): Promise<GetGameResponse> {
+    return this.request("GET", "/api/game", {}, undefined, false);
+  }
+
+  /** POST /api/game: Start a game between every player with a species */
+  startGame(body: GameSettings): Promise<CompetitiveGame> {
+    return this.request("POST", "/api/game", {}, body, false);
+  }
+
+  /** DELETE /api/game: Abort the running game */
+  abortGame(): Promise<CompetitiveGame> {
+    return this.request("DELETE", "/api/game", {}, undefined, false);
+  }
+
+  /** POST /api/game/rematch: Reset the world and replay the last finished game */
+  rematchGame(): Promise<CompetitiveGame> {
+    return this.request("POST", "/api/game/rematch", {}, undefined, false);
+  }
+
+  /** GET /api/tutorial: The guided scenarios and the current or last tutorial */
+  getTutorial(): Promise<GetTutorialResponse> {
+    return this.request("GET", "/api/tutorial", {}, undefined, false);
+  }
+
+  /** POST /api/tutorial: Reset the world into a guided scenario */
+  startTutorial(body: TutorialRequest): Promise<Tutorial> {
+    return this.request("POST", "/api/tutorial", {}, body, false);
+  }
+
+  /** DELETE /api/tutorial: Stop the running tutorial */
+  stopTutorial(): Promise<Tutorial> {
+    return this.request("DELETE", "/api/tutorial", {}, undefined, false);
+  }
+
+  /** GET /api/predictions: Prediction rounds and the points leaderboard */
+  listPredictions(): Promise<ListPredictionsResponse> {
+    return this.request("GET", "/api/predictions", {}, undefined, false);
+  }
+
+  /** POST /api/predictions: Open a prediction round */
+  openPredictionRound(body: PredictionRoundRequest): Promise<PredictionRound> {
+    return this.request("POST", "/api/predictions", {}, body, false);
+  }
+
+  /** POST /api/predictions/predict: Stake a spectator's points on an option of an open round */
+  predict(body: PredictionRequest): Promise<Spectator> {
+    return this.request("POST", "/api/predictions/predict", {}, body, false);
+  }
+
+  /** GET /api/step: The current state digest */
+  getStateDigest(): Promise<StepResult> {
+    return this.request("GET", "/api/step", {}, undefined, false);
+  }
+
+  /** POST /api/step: Pause and advance the simulation by a number of ticks */
+  step(body: StepRequest): Promise<StepResult> {
+    return this.request("POST", "/api/step", {}, body, false);
+  }
+
+  /** POST /api/fast-forward: Load a save and replay it to a tick */
+  fastForward(body: FastForwardRequest): Promise<FastForwardResult> {
+    return this.request("POST", "/api/fast-forward", {}, body, false);
+  }
+
+  /** GET /api/population-caps: Soft population caps and the dispersal pool */
+  getPopulationCaps(): Promise<PopulationCapStatus> {
+    return this.request("GET", "/api/population-caps", {}, undefined, false);
+  }
+
+  /** POST /api/population-caps: Change the population caps */
+  setPopulationCaps(body: PopulationCapRequest): Promise<PopulationCapStatus> {
+    return this.request("POST", "/api/population-caps", {}, body, false);
+  }
+
+  /** GET /api/resource-budget: Resources, plants and creatures each biome can support */
+  getResourceBudget(): Promise<ResourceBudgetStatus> {
+    return this.request("GET", "/api/resource-budget", {}, undefined, false);
+  }
+
+  /** POST /api/resource-budget: Change the resource budget settings; fields left out keep their values */
+  setResourceBudget(body: ResourceBudgetConfig): Promise<ResourceBudgetStatus> {
+    return this.request("POST", "/api/resource-budget", {}, body, false);
+  }
+
+  /** GET /api/gallery: Snapshots captured on notable events, newest first; images are served from /gallery/<image> */
+  getGallery(): Promise<GalleryStatus> {
+    return this.request("GET", "/api/gallery", {}, undefined, false);
+  }
+
+  /** POST /api/gallery: Capture a snapshot of the world now */
+  captureSnapshot(body: GalleryCaptureRequest): Promise<GallerySnapshot> {
+    return this.request("POST", "/api/gallery", {}, body, false);
+  }
+
+  /** GET /api/achievements: Milestones with the achievements the world and each player have earned */
+  getAchievements(params: GetAchievementsParams = {}): Promise<AchievementsStatus> {
+    return this.request("GET", "/api/achievements", params, undefined, false);
+  }
+
+  /** GET /api/day-night: The world clock and the daylight on each row of the grid */
+  getDayNight(): Promise<DayNightStatus> {
+    return this.request("GET", "/api/day-night", {}, undefined, false);
+  }
+
+  /** GET /api/season: The current season, what it does to the world and the creatures away on migration */
+  getSeason(): Promise<SeasonStatus> {
+    return this.request("GET", "/api/season", {}, undefined, false);
+  }
+
+  /** GET /api/audio: The soundscape: rain, wind, bird calls and the fights just heard */
+  getAudio(): Promise<AmbientAudio> {
+    return this.request("GET", "/api/audio", {}, undefined, false);
+  }
+
+  /** GET /api/migration: Herds on the move, the routes each species has learned and the journeys made */
+  getMigration(): Promise<MigrationStatus> {
+    return this.request("GET", "/api/migration", {}, undefined, false);
+  }
+
+  /** GET /api/resources: Memory footprint of each store */
+  getResources(): Promise<ResourceReport> {
+    return this.request("GET", "/api/resources", {}, undefined, false);
+  }
+
+  /** POST /api/resources: Change a store's pruning policy or prune it now */
+  setResourcePolicy(body: ResourcePolicyRequest): Promise<ResourceReport> {
+    return this.request("POST", "/api/resources", {}, body, false);
+  }
+
+  /** GET /api/timescales: Audit of every time constant */
+  getTimescales(): Promise<TimescaleAudit> {
+    return this.request("GET", "/api/timescales", {}, undefined, false);
+  }
+
+  /** POST /api/timescales: Rescale a group of time constants together */
+  rescaleTimescales(body: TimescaleRequest): Promise<TimescaleAudit> {
+    return this.request("POST", "/api/timescales", {}, body, false);
+  }
+
+  /** GET /api/traits: Trait definitions with their ranges */
+  listTraits(params: ListTraitsParams = {}): Promise<TraitDefinition[]> {
+    return this.request("GET", "/api/traits", params, undefined, false);
+  }
+
+  /** GET /api/traits/legend: How the views size and colour creatures by their traits */
+  getTraitLegend(): Promise<TraitLegend> {
+    return this.request("GET", "/api/traits/legend", {}, undefined, false);
+  }
+
+  /** GET /api/legend: The symbols and colours each renderer draws biomes, creatures, plants, events and structures with */
+  getLegend(params: GetLegendParams = {}): Promise<RendererLegend[]> {
+    return this.request("GET", "/api/legend", params, undefined, false);
+  }
+
+  /** GET /api/mutations: Mutation operators and their recent changes */
+  getMutations(): Promise<MutationReport> {
+    return this.request("GET", "/api/mutations", {}, undefined, false);
+  }
+
+  /** POST /api/mutations: Change a mutation operator's rate */
+  setMutationRate(body: MutationRateRequest): Promise<MutationReport> {
+    return this.request("POST", "/api/mutations", {}, body, false);
+  }
+
+  /** GET /api/selection: Selection strategies and the one each population uses */
+  getSelection(): Promise<SelectionReport> {
+    return this.request("GET", "/api/selection", {}, undefined, false);
+  }
+
+  /** POST /api/selection: Change how a population picks its parents */
+  setSelectionStrategy(body: SelectionStrategyRequest): Promise<SelectionReport> {
+    return this.request("POST", "/api/selection", {}, body, false);
+  }
+
+  /** GET /api/fitness-landscape: Sample the fitness landscape over two traits */
+  getFitnessLandscape(params: GetFitnessLandscapeParams = {}): Promise<FitnessLandscape> {
+    return this.request("GET", "/api/fitness-landscape", params, undefined, false);
+  }
+
+  /** GET /api/ancestry: How a living species' traits changed along its lineage */
+  getAncestry(params: GetAncestryParams): Promise<AncestralHistory> {
+    return this.request("GET", "/api/ancestry", params, undefined, false);
+  }
+
+  /** GET /api/phylogeny: Phylogenetic tree of plant species, living and extinct */
+  getPhylogeny(): Promise<Phylogeny> {
+    return this.request("GET", "/api/phylogeny", { format: "json" }, undefined, false);
+  }
+
+  /** GET /api/history: Population, fitness, energy and trait history for charts */
+  getMetricHistory(params: GetMetricHistoryParams): Promise<MetricHistoryChart> {
+    return this.request("GET", "/api/history", params, undefined, false);
+  }
+
+  /** GET /api/history/metrics: Metrics held in the history, with their sample counts and ranges */
+  getMetricHistoryIndex(): Promise<MetricHistoryIndex> {
+    return this.request("GET", "/api/history/metrics", {}, undefined, false);
+  }
+
+  /** GET /api/red-queen: Arms races between interacting species */
+  getRedQueen(): Promise<RedQueenReport[]> {
+    return this.request("GET", "/api/red-queen", {}, undefined, false);
+  }
+
+  /** GET /api/niche-overlap: Diet, habitat and activity overlap of every pair of species */
+  getNicheOverlap(): Promise<NicheOverlapReport> {
+    return this.request("GET", "/api/niche-overlap", {}, undefined, false);
+  }
+
+  /** GET /api/biome-transitions: Biome state machine rules and recent transitions */
+  getBiomeTransitions(): Promise<BiomeStateReport> {
+    return this.request("GET", "/api/biome-transitions", {}, undefined, false);
+  }
+
+  /** GET /api/microclimates: Cells whose tree cover and structures set their conditions */
+  getMicroclimates(): Promise<MicroclimateReport> {
+    return this.request("GET", "/api/microclimates", {}, undefined, false);
+  }
+
+  /** GET /api/pollution: Soil and water contamination and the tribes responsible */
+  getPollution(): Promise<PollutionReport> {
+    return this.request("GET", "/api/pollution", {}, undefined, false);
+  }
+
+  /** GET /api/light-pollution: Settlements lit at night and their effect on wildlife */
+  getLightPollution(): Promise<LightPollutionReport> {
+    return this.request("GET", "/api/light-pollution", {}, undefined, false);
+  }
+
+  /** GET /api/noise-pollution: Disturbance zones around colonies and paths */
+  getNoisePollution(): Promise<NoisePollutionReport> {
+    return this.request("GET", "/api/noise-pollution", {}, undefined, false);
+  }
+
+  /** GET /api/zoonoses: Pathogen spillover between wildlife and colonies */
+  getZoonoses(): Promise<ZoonosisReport> {
+    return this.request("GET", "/api/zoonoses", {}, undefined, false);
+  }
+
+  /** GET /api/food-storage: Tribes' food stores, spoilage and preservation */
+  getFoodStorage(): Promise<FoodStorageReport> {
+    return this.request("GET", "/api/food-storage", {}, undefined, false);
+  }
+
+  /** GET /api/demographics: Population pyramids of every colony and species */
+  getDemographics(): Promise<DemographicsReport> {
+    return this.request("GET", "/api/demographics", {}, undefined, false);
+  }
+
+  /** GET /api/census: Living creatures grouped by dimensions with mean metrics */
+  getCensus(params: GetCensusParams = {}): Promise<CensusResult> {
+    return this.request("GET", "/api/census", params, undefined, false);
+  }
+
+  /** GET /api/graphql: The GraphQL schema; with ?query= and ?variables= runs a query instead */
+  getGraphQLSchema(): Promise<string> {
+    return this.request("GET", "/api/graphql", {}, undefined, true);
+  }
+
+  /** POST /api/graphql: Run a GraphQL query over the world */
+  queryGraphQL(body: GraphQLRequest): Promise<GraphQLResponse> {
+    return this.request("POST", "/api/graphql", {}, body, false);
+  }
+
+  /** GET /api/branches: Compare every branch with the live world */
+  listBranches(): Promise<BranchComparison[]> {
+    return this.request("GET", "/api/branches", {}, undefined, false);
+  }
+
+  /** POST /api/branches: Fork the live world into a new branch */
+  forkBranch(body: BranchForkRequest): Promise<BranchComparison> {
+    return this.request("POST", "/api/branches", {}, body, false);
+  }
+
+  /** GET /api/branches/branch: Diff a branch against the live world */
+  diffBranch(params: DiffBranchParams): Promise<DiffBranchResponse> {
+    return this.request("GET", "/api/branches/branch", params, undefined, false);
+  }
+
+  /** POST /api/branches/branch: Pause or resume a branch */
+  updateBranch(params: UpdateBranchParams, body: BranchUpdateRequest): Promise<BranchComparison> {
+    return this.request("POST", "/api/branches/branch", params, body, false);
+  }
+
+  /** DELETE /api/branches/branch: Discard a branch */
+  deleteBranch(params: DeleteBranchParams): Promise<void> {
+    return this.request("DELETE", "/api/branches/branch", params, undefined, false);
+  }
+
+  private async request<T>(
+    method: string,
+    path: string,
+    query: object,
+    body: unknown,
+    text: boolean,
+  ): Promise<T> {
+    const search = new URLSearchParams();
+    for (const [key, value] of Object.entries(query)) {
+      if (value !== undefined && value !== null) search.set(key, String(value));
+    }
+    const encoded = search.toString();
+    const url = this.baseURL + path + (encoded ? "?" + encoded : "");
+    const response = await this.fetchImpl(url, {
+      method,
+      headers: body === undefined ? undefined : { "Content-Type": "application/json" },
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    if (!response.ok) {
+      throw new EvoSimError(response.status, (await response.text()).trim());
+    }
+    if (response.status === 204) {
+      return undefined as T;
+    }
+    return (text ? await response.text() : await response.json()) as T;
+  }
+}
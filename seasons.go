@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SeasonalSystem runs the year's seasons on the world clock. Each tick it applies the current
+// season's effects from the simulation config; when a season turns it announces the change
+// and, in a season of migration, tells the migration system whether it turned colder or warmer.
+type SeasonalSystem struct {
+	season     Season
+	since      int // Tick the current season began
+	started    bool
+	migrations *MigrationSystem
+	eventBus   *CentralEventBus
+}
+
+// NewSeasonalSystem creates a season cycle that takes up the clock's season on its first update
+func NewSeasonalSystem(eventBus *CentralEventBus, migrations *MigrationSystem) *SeasonalSystem {
+	return &SeasonalSystem{migrations: migrations, eventBus: eventBus}
+}
+
+// Restart takes up the clock's season afresh, for a world that starts over or is loaded
+func (s *SeasonalSystem) Restart() {
+	s.started = false
+}
+
+// Update turns the season when the clock has
+func (s *SeasonalSystem) Update(w *World) {
+	season := w.AdvancedTimeSystem.Season
+	switch {
+	case !s.started:
+		s.season, s.since, s.started = season, w.Tick, true
+	case season != s.season:
+		previous := s.season
+		s.season, s.since = season, w.Tick
+		effects := w.SimConfig.Seasons.For(season)
+		if effects.Migration && s.migrations != nil {
+			s.migrations.SeasonTurned(w, effects.Temperature < w.SimConfig.Seasons.For(previous).Temperature)
+		}
+		if s.eventBus != nil {
+			s.eventBus.EmitSystemEvent(w.Tick, "season_changed", "season", "seasonal_system",
+				fmt.Sprintf("%s gives way to %s", seasonToString(previous), seasonToString(season)), nil,
+				map[string]interface{}{"season": seasonToString(season), "previous": seasonToString(previous)})
+		}
+	}
+}
+
+// SeasonStatus reports where the world is in its year and what the season does
+type SeasonStatus struct {
+	Season   string        `json:"season"`
+	Day      int           `json:"day"`        // Days into the season
+	Length   int           `json:"length"`     // Days the season lasts
+	Next     string        `json:"next"`       // Season that follows
+	Year     int           `json:"year"`       // Years since the world began
+	Effects  SeasonEffects `json:"effects"`    // What the current season does
+	Migrants int           `json:"migrants"`   // Creatures away from home on a seasonal migration
+	Since    int           `json:"since_tick"` // Tick the season began
+}
+
+// Status reports the current season
+func (s *SeasonalSystem) Status(w *World) SeasonStatus {
+	clock := w.AdvancedTimeSystem
+	length := max(1, clock.SeasonLength)
+	return SeasonStatus{
+		Season:   seasonToString(clock.Season),
+		Day:      clock.SeasonDay,
+		Length:   length,
+		Next:     seasonToString(Season((int(clock.Season) + 1) % 4)),
+		Year:     clock.WorldTick / (4 * length),
+		Effects:  w.SimConfig.Seasons.For(clock.Season),
+		Migrants: w.Migrations.Migrants(),
+		Since:    s.since,
+	}
+}
+
+// seasonNamed returns the season of a name in any case, Spring for names it does not know
+func seasonNamed(name string) Season {
+	for season := Spring; season <= Winter; season++ {
+		if strings.EqualFold(name, seasonToString(season)) {
+			return season
+		}
+	}
+	return Spring
+}
+
+// seasonEffects returns what the current season does to the world
+func (w *World) seasonEffects() SeasonEffects {
+	return w.SimConfig.Seasons.For(w.AdvancedTimeSystem.Season)
+}
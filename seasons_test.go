@@ -0,0 +1,132 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+// turnSeason moves the clock to a season and lets the season cycle and the herds see it
+func turnSeason(world *World, season Season) {
+	world.Tick++
+	world.AdvancedTimeSystem.Season = season
+	world.Seasons.Update(world)
+	world.Migrations.Update(world)
+}
+
+func TestSeasonsSendMigrantsAwayAndHome(t *testing.T) {
+	world := newSteppingTestWorld(91)
+	home := Position{X: world.Config.Width / 2, Y: 2}
+	migrant := NewEntity(sharedRand, world.NextID, []string{"intelligence", "endurance", "speed"}, "herbivore", home)
+	migrant.SetTrait("intelligence", 0.9)
+	migrant.SetTrait("endurance", 0.9)
+	migrant.SetTrait("speed", 4)
+	world.AllEntities = append(world.AllEntities, migrant)
+
+	var changes []string
+	world.CentralEventBus.AddListener(func(event CentralEvent) {
+		if event.Type == "season_changed" {
+			changes = append(changes, event.Metadata["season"].(string))
+		}
+	})
+
+	turnSeason(world, Summer)
+	turnSeason(world, Summer)
+	if len(changes) != 0 || world.Migrations.Migrants() != 0 {
+		t.Fatalf("Expected the first season taken up quietly, got %v and %d migrants", changes, world.Migrations.Migrants())
+	}
+
+	// Autumn is colder than summer, so migrants head for the equator, within their range
+	turnSeason(world, Autumn)
+	if world.Migrations.Migrants() == 0 || migrant.Position.Y <= home.Y {
+		t.Fatalf("Expected the migrant on its way south, got %d migrants and %v", world.Migrations.Migrants(), migrant.Position)
+	}
+	for i := 0; i < 30; i++ {
+		turnSeason(world, Autumn)
+	}
+	reach := NewMigrationBehavior(migrant).MigrationRange
+	if travelled := migrant.Position.Y - home.Y; travelled < reach-migrationArrival || travelled > reach+0.01 {
+		t.Errorf("Expected the migrant to travel its range of %.1f toward the equator, got %.1f", reach, travelled)
+	}
+
+	// Winter brings no migration of its own; spring is warmer, so migrants go home
+	turnSeason(world, Winter)
+	turnSeason(world, Spring)
+	for i := 0; i < 30; i++ {
+		turnSeason(world, Spring)
+	}
+	if migrant.Position.Y-home.Y > migrationArrival {
+		t.Errorf("Expected the migrant back home in spring, got %v", migrant.Position)
+	}
+	if migrants := world.Migrations.Migrants(); migrants != 0 {
+		t.Errorf("Expected every migrant home, got %d away", migrants)
+	}
+	if len(changes) != 3 || changes[0] != "Autumn" || changes[2] != "Spring" {
+		t.Errorf("Expected each turn of the season announced, got %v", changes)
+	}
+}
+
+func TestSeasonEffectsSwayTheWorld(t *testing.T) {
+	world := newSteppingTestWorld(92)
+	entity := world.AllEntities[0]
+	gridX, gridY := world.gridCell(entity.Position)
+
+	world.AdvancedTimeSystem.Season = Summer
+	summer := world.calculateEnvironmentalFactors(entity, gridX, gridY)["temperature"]
+	world.AdvancedTimeSystem.Season = Winter
+	winter := world.calculateEnvironmentalFactors(entity, gridX, gridY)["temperature"]
+	if summer <= winter {
+		t.Errorf("Expected the biome warmer in summer than in winter, got %.2f and %.2f", summer, winter)
+	}
+
+	// Only spring opens mating to all; in summer only creatures with the summer gene mate
+	entity.ReproductionStatus = NewReproductionStatus(sharedRand)
+	entity.SetTrait("summer_mating", 1)
+	other := world.AllEntities[1]
+	other.ReproductionStatus = NewReproductionStatus(sharedRand)
+	other.SetTrait("summer_mating", -1)
+	pair := []*Entity{entity, other}
+	world.ReproductionSystem.UpdateMatingSeasons(pair, "Summer", world.SimConfig.Seasons.Summer.Mating)
+	if !entity.ReproductionStatus.MatingSeason || other.ReproductionStatus.MatingSeason {
+		t.Errorf("Expected only the summer breeder in season in summer")
+	}
+	world.ReproductionSystem.UpdateMatingSeasons(pair, "Winter", world.SimConfig.Seasons.Winter.Mating)
+	if entity.ReproductionStatus.MatingSeason {
+		t.Errorf("Expected no mating in winter")
+	}
+
+	// Plants thrive more on the same soil in spring than in winter
+	plant := NewPlant(sharedRand, 1, PlantGrass, entity.Position)
+	cell := &world.Grid[gridY][gridX]
+	plant.Energy = 50
+	spring := plant.updatePlantNutrientsWithBudget(cell, world.SimConfig.Seasons.Spring.PlantGrowth, unbudgetedShare)
+	plant.Energy = 50
+	dormant := plant.updatePlantNutrientsWithBudget(cell, world.SimConfig.Seasons.Winter.PlantGrowth, unbudgetedShare)
+	if spring <= dormant {
+		t.Errorf("Expected plants healthier in spring than winter, got %.2f and %.2f", spring, dormant)
+	}
+
+	config := DefaultSimulationConfig()
+	config.Seasons.Winter.Food = -1
+	if err := config.Validate(); err == nil {
+		t.Error("Expected a negative winter food supply to be rejected")
+	}
+}
+
+func TestSeasonAPI(t *testing.T) {
+	wi := NewWebInterface(newSteppingTestWorld(93))
+	wi.world.Update()
+
+	var status SeasonStatus
+	if code := callControlAPI(t, wi.handleSeason, http.MethodGet, "/api/season", "", &status); code != http.StatusOK {
+		t.Fatalf("Expected the season, got %d", code)
+	}
+	if status.Season != "Spring" || status.Next != "Summer" || status.Day != 1 || status.Effects != wi.world.SimConfig.Seasons.Spring {
+		t.Errorf("Expected the first day of spring, got %+v", status)
+	}
+	if view := wi.viewManager.GetCurrentViewData(); view.Season.Season != "Spring" || view.Season.Length != status.Length {
+		t.Errorf("Expected the season in the view data, got %+v", view.Season)
+	}
+	if code := callControlAPI(t, wi.handleSeason, http.MethodPost, "/api/season", "{}", nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected the season read only, got %d", code)
+	}
+}
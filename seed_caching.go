@@ -0,0 +1,295 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// SeedCache represents a hidden store of seeds buried by a scatter-hoarding entity
+type SeedCache struct {
+	ID            int              `json:"id"`
+	Position      Position         `json:"position"`
+	CacherID      int              `json:"cacher_id"`      // Entity that buried the cache
+	CacherSpecies string           `json:"cacher_species"` // Species of the cacher at burial time
+	PlantType     PlantType        `json:"plant_type"`     // Plant the seeds came from
+	Genetics      map[string]Trait `json:"genetics"`       // Genetic material of the source plant
+	SeedCount     int              `json:"seed_count"`     // Seeds remaining in the cache
+	CreatedTick   int              `json:"created_tick"`
+	Forgotten     bool             `json:"forgotten"` // Cacher died or lost track of the cache
+}
+
+// SeedCachingSystem manages seed caching by scatter-hoarders and recruitment from forgotten caches
+type SeedCachingSystem struct {
+	Caches            []*SeedCache   `json:"caches"`
+	NextCacheID       int            `json:"next_cache_id"`
+	CachesCreated     int            `json:"caches_created"`
+	CachesRetrieved   int            `json:"caches_retrieved"`
+	CachesForgotten   int            `json:"caches_forgotten"`
+	CacheRecruits     int            `json:"cache_recruits"`      // Plants germinated from forgotten caches
+	RecruitsBySpecies map[string]int `json:"recruits_by_species"` // Cacher species -> plants recruited
+	RecruitedPlants   map[int]string `json:"recruited_plants"`    // Plant ID -> cacher species that planted it
+
+	// Tunable parameters
+	MaxCaches         int     `json:"max_caches"`         // Upper bound on stored caches
+	ForgetAge         int     `json:"forget_age"`         // Ticks after which a cache is forgotten
+	RetrievalRadius   float64 `json:"retrieval_radius"`   // How close a cacher must be to find its cache
+	GerminationChance float64 `json:"germination_chance"` // Per-tick chance a forgotten cache sprouts in spring
+}
+
+// NewSeedCachingSystem creates a new seed caching system
+func NewSeedCachingSystem() *SeedCachingSystem {
+	return &SeedCachingSystem{
+		Caches:            make([]*SeedCache, 0),
+		NextCacheID:       1,
+		RecruitsBySpecies: make(map[string]int),
+		RecruitedPlants:   make(map[int]string),
+		MaxCaches:         500,
+		ForgetAge:         180, // Roughly two seasons
+		RetrievalRadius:   3.0,
+		GerminationChance: 0.05,
+	}
+}
+
+// IsScatterHoarder determines whether an entity caches seeds for later use
+func IsScatterHoarder(entity *Entity) bool {
+	if entity == nil || !entity.IsAlive {
+		return false
+	}
+	// Peaceful diggers with some intelligence behave like squirrels and jays
+	return entity.GetTrait("digging_ability") > 0.0 &&
+		entity.GetTrait("aggression") < 0.3 &&
+		entity.GetTrait("intelligence") > -0.5
+}
+
+// isCacheablePlant returns whether a plant produces seeds worth caching
+func isCacheablePlant(plant *Plant) bool {
+	return plant.IsAlive && (plant.Type == PlantTree || plant.Type == PlantBush)
+}
+
+// Update processes caching, retrieval, forgetting, and germination of caches
+func (scs *SeedCachingSystem) Update(world *World) {
+	season := world.AdvancedTimeSystem.Season
+
+	if world.Tick%5 == 0 {
+		switch season {
+		case Autumn:
+			scs.processCaching(world)
+		case Winter:
+			scs.processRetrieval(world)
+		}
+	}
+
+	scs.processForgetting(world)
+
+	if season == Spring {
+		scs.processGermination(world)
+	}
+
+	if world.Tick%100 == 0 {
+		scs.pruneRecruitedPlants(world)
+	}
+}
+
+// processCaching lets scatter-hoarders bury seeds from nearby trees and bushes
+func (scs *SeedCachingSystem) processCaching(world *World) {
+	if len(scs.Caches) >= scs.MaxCaches {
+		return
+	}
+
+	for _, entity := range world.AllEntities {
+		if !IsScatterHoarder(entity) {
+			continue
+		}
+
+		var source *Plant
+		for _, plant := range world.spatialIndex().QueryPlantRadius(entity.Position, 3.0) {
+			if isCacheablePlant(plant) && world.Distance(entity.Position, plant.Position) <= 3.0 {
+				source = plant
+				break
+			}
+		}
+		if source == nil {
+			continue
+		}
+
+		// Intelligent cachers are more diligent
+		cacheChance := 0.2 + 0.2*math.Max(0, entity.GetTrait("intelligence"))
+		if world.Rand.Float64() > cacheChance {
+			continue
+		}
+
+		// Bury the cache a short distance away from the source plant
+		angle := world.Rand.Float64() * 2 * math.Pi
+		distance := 2.0 + world.Rand.Float64()*6.0
+		pos := world.KeepOnMap(Position{
+			X: source.Position.X + math.Cos(angle)*distance,
+			Y: source.Position.Y + math.Sin(angle)*distance,
+		}, 1)
+
+		genetics := make(map[string]Trait, len(source.Traits))
+		for name, trait := range source.Traits {
+			genetics[name] = trait
+		}
+
+		cache := &SeedCache{
+			ID:            scs.NextCacheID,
+			Position:      pos,
+			CacherID:      entity.ID,
+			CacherSpecies: entity.Species,
+			PlantType:     source.Type,
+			Genetics:      genetics,
+			SeedCount:     1 + world.Rand.Intn(5),
+			CreatedTick:   world.Tick,
+		}
+		scs.NextCacheID++
+		scs.CachesCreated++
+		scs.Caches = append(scs.Caches, cache)
+		entity.Energy -= 0.5 // Digging costs a little energy
+
+		if len(scs.Caches) >= scs.MaxCaches {
+			return
+		}
+	}
+}
+
+// processRetrieval lets hungry cachers recover their own caches in winter
+func (scs *SeedCachingSystem) processRetrieval(world *World) {
+	entitiesByID := make(map[int]*Entity, len(world.AllEntities))
+	for _, entity := range world.AllEntities {
+		if entity.IsAlive {
+			entitiesByID[entity.ID] = entity
+		}
+	}
+
+	for i := len(scs.Caches) - 1; i >= 0; i-- {
+		cache := scs.Caches[i]
+		if cache.Forgotten {
+			continue
+		}
+		cacher, exists := entitiesByID[cache.CacherID]
+		if !exists || cacher.Energy > 60 {
+			continue
+		}
+		if world.Distance(cacher.Position, cache.Position) > scs.RetrievalRadius {
+			continue
+		}
+
+		cacher.Energy = math.Min(100, cacher.Energy+float64(cache.SeedCount)*3.0)
+		scs.CachesRetrieved++
+		scs.Caches = append(scs.Caches[:i], scs.Caches[i+1:]...)
+	}
+}
+
+// processForgetting marks caches whose cacher died or that have aged out as forgotten
+func (scs *SeedCachingSystem) processForgetting(world *World) {
+	alive := make(map[int]bool, len(world.AllEntities))
+	for _, entity := range world.AllEntities {
+		if entity.IsAlive {
+			alive[entity.ID] = true
+		}
+	}
+
+	for _, cache := range scs.Caches {
+		if cache.Forgotten {
+			continue
+		}
+		if !alive[cache.CacherID] || world.Tick-cache.CreatedTick > scs.ForgetAge {
+			cache.Forgotten = true
+			scs.CachesForgotten++
+		}
+	}
+}
+
+// processGermination sprouts forgotten caches into new plants
+func (scs *SeedCachingSystem) processGermination(world *World) {
+	for i := len(scs.Caches) - 1; i >= 0; i-- {
+		cache := scs.Caches[i]
+		if !cache.Forgotten || world.Rand.Float64() > scs.GerminationChance || !world.admitPlant(cache.Position) {
+			continue
+		}
+
+		plant := NewPlant(world.Rand, world.NextPlantID, cache.PlantType, cache.Position)
+		world.NextPlantID++
+		for _, name := range sortedKeys(cache.Genetics) {
+			trait := cache.Genetics[name]
+			trait.Value += (world.Rand.Float64()*2 - 1) * 0.05
+			plant.Traits[name] = trait
+		}
+		world.AllPlants = append(world.AllPlants, plant)
+
+		scs.CacheRecruits++
+		scs.RecruitsBySpecies[cache.CacherSpecies]++
+		scs.RecruitedPlants[plant.ID] = cache.CacherSpecies
+
+		if world.CentralEventBus != nil {
+			world.CentralEventBus.EmitPlantEvent(world.Tick, "cache_recruitment", "seed_germination",
+				"seed_caching", fmt.Sprintf("Forgotten cache %d buried by %s germinated into plant %d",
+					cache.ID, cache.CacherSpecies, plant.ID), plant, false, true)
+		}
+
+		scs.Caches = append(scs.Caches[:i], scs.Caches[i+1:]...)
+	}
+}
+
+// pruneRecruitedPlants forgets recruited plants that have since died
+func (scs *SeedCachingSystem) pruneRecruitedPlants(world *World) {
+	alive := make(map[int]bool, len(world.AllPlants))
+	for _, plant := range world.AllPlants {
+		if plant.IsAlive {
+			alive[plant.ID] = true
+		}
+	}
+	for id := range scs.RecruitedPlants {
+		if !alive[id] {
+			delete(scs.RecruitedPlants, id)
+		}
+	}
+}
+
+// CachedSeedCount returns the total number of seeds currently stored in caches
+func (scs *SeedCachingSystem) CachedSeedCount() int {
+	total := 0
+	for _, cache := range scs.Caches {
+		total += cache.SeedCount
+	}
+	return total
+}
+
+// CacherDependence returns the fraction of living trees that were planted by cachers
+func (scs *SeedCachingSystem) CacherDependence(plants []*Plant) float64 {
+	trees := 0
+	recruited := 0
+	for _, plant := range plants {
+		if !plant.IsAlive || plant.Type != PlantTree {
+			continue
+		}
+		trees++
+		if _, ok := scs.RecruitedPlants[plant.ID]; ok {
+			recruited++
+		}
+	}
+	if trees == 0 {
+		return 0
+	}
+	return float64(recruited) / float64(trees)
+}
+
+// GetStats returns seed caching statistics
+func (scs *SeedCachingSystem) GetStats() map[string]interface{} {
+	forgotten := 0
+	for _, cache := range scs.Caches {
+		if cache.Forgotten {
+			forgotten++
+		}
+	}
+
+	return map[string]interface{}{
+		"active_caches":       len(scs.Caches),
+		"forgotten_caches":    forgotten,
+		"cached_seeds":        scs.CachedSeedCount(),
+		"caches_created":      scs.CachesCreated,
+		"caches_retrieved":    scs.CachesRetrieved,
+		"cache_recruits":      scs.CacheRecruits,
+		"recruits_by_species": scs.RecruitsBySpecies,
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestIsScatterHoarder(t *testing.T) {
+	hoarder := NewEntity(sharedRand, 1, []string{}, "herbivore", Position{X: 5, Y: 5})
+	hoarder.SetTrait("digging_ability", 0.5)
+	hoarder.SetTrait("aggression", -0.5)
+	hoarder.SetTrait("intelligence", 0.2)
+
+	if !IsScatterHoarder(hoarder) {
+		t.Error("Expected peaceful digger to be a scatter-hoarder")
+	}
+
+	predator := NewEntity(sharedRand, 2, []string{}, "predator", Position{X: 5, Y: 5})
+	predator.SetTrait("digging_ability", 0.5)
+	predator.SetTrait("aggression", 0.9)
+	predator.SetTrait("intelligence", 0.7)
+
+	if IsScatterHoarder(predator) {
+		t.Error("Expected aggressive predator not to be a scatter-hoarder")
+	}
+}
+
+func TestForgottenCacheRecruitment(t *testing.T) {
+	world := newTestWorld(40, 20)
+	scs := world.SeedCachingSystem
+	if scs == nil {
+		t.Fatal("Seed caching system not initialized")
+	}
+	scs.GerminationChance = 1.0
+
+	tree := NewPlant(sharedRand, world.NextPlantID, PlantTree, Position{X: 10, Y: 10})
+	world.NextPlantID++
+
+	// Cacher 999 does not exist in the world, so the cache is forgotten immediately
+	scs.Caches = append(scs.Caches, &SeedCache{
+		ID:            scs.NextCacheID,
+		Position:      Position{X: 12, Y: 12},
+		CacherID:      999,
+		CacherSpecies: "squirrel",
+		PlantType:     tree.Type,
+		Genetics:      tree.Traits,
+		SeedCount:     3,
+	})
+	scs.NextCacheID++
+
+	plantsBefore := len(world.AllPlants)
+	world.AdvancedTimeSystem.Season = Spring
+	scs.Update(world)
+
+	if scs.CachesForgotten != 1 {
+		t.Errorf("Expected 1 forgotten cache, got %d", scs.CachesForgotten)
+	}
+	if scs.CacheRecruits != 1 {
+		t.Fatalf("Expected 1 cache recruit, got %d", scs.CacheRecruits)
+	}
+	if len(world.AllPlants) != plantsBefore+1 {
+		t.Errorf("Expected %d plants after germination, got %d", plantsBefore+1, len(world.AllPlants))
+	}
+	if scs.RecruitsBySpecies["squirrel"] != 1 {
+		t.Errorf("Expected recruit credited to squirrel, got %v", scs.RecruitsBySpecies)
+	}
+	if len(scs.Caches) != 0 {
+		t.Errorf("Expected germinated cache to be removed, %d remain", len(scs.Caches))
+	}
+}
+
+func TestCacheRetrievalByHungryCacher(t *testing.T) {
+	world := newTestWorld(40, 20)
+	scs := world.SeedCachingSystem
+
+	cacher := NewEntity(sharedRand, world.NextID, []string{}, "herbivore", Position{X: 5, Y: 5})
+	world.NextID++
+	cacher.Energy = 30
+	world.AllEntities = append(world.AllEntities, cacher)
+
+	scs.Caches = append(scs.Caches, &SeedCache{
+		ID:        1,
+		Position:  Position{X: 6, Y: 5},
+		CacherID:  cacher.ID,
+		SeedCount: 4,
+	})
+
+	scs.processRetrieval(world)
+
+	if scs.CachesRetrieved != 1 {
+		t.Fatalf("Expected cache to be retrieved, got %d retrievals", scs.CachesRetrieved)
+	}
+	if cacher.Energy <= 30 {
+		t.Errorf("Expected cacher to gain energy from retrieval, energy %.1f", cacher.Energy)
+	}
+}
+
+func TestCacherDependenceMetric(t *testing.T) {
+	scs := NewSeedCachingSystem()
+	recruited := NewPlant(sharedRand, 1, PlantTree, Position{X: 1, Y: 1})
+	natural := NewPlant(sharedRand, 2, PlantTree, Position{X: 2, Y: 2})
+	scs.RecruitedPlants[recruited.ID] = "squirrel"
+
+	dependence := scs.CacherDependence([]*Plant{recruited, natural})
+	if dependence != 0.5 {
+		t.Errorf("Expected cacher dependence 0.5, got %.2f", dependence)
+	}
+
+	world := newTestWorld(40, 20)
+	world.AllPlants = []*Plant{recruited, natural}
+	world.SeedCachingSystem = scs
+	world.EcosystemMonitor.UpdateMetrics(world)
+	if world.EcosystemMonitor.CurrentMetrics.CacherDependence != 0.5 {
+		t.Errorf("Expected ecosystem metrics to report cacher dependence 0.5, got %.2f",
+			world.EcosystemMonitor.CurrentMetrics.CacherDependence)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"sort"
 )
 
 // DispersalMechanism represents different ways seeds can be dispersed
@@ -73,6 +74,8 @@ type SeedDispersalSystem struct {
 	DispersalStats      map[DispersalMechanism]int `json:"dispersal_stats"`
 	GerminationEvents   int                        `json:"germination_events"`
 	DormancyActivations int                        `json:"dormancy_activations"`
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewSeedDispersalSystem creates a new seed dispersal system
@@ -84,6 +87,7 @@ func NewSeedDispersalSystem() *SeedDispersalSystem {
 		DispersalStats:      make(map[DispersalMechanism]int),
 		GerminationEvents:   0,
 		DormancyActivations: 0,
+		rng:                 sharedRand,
 	}
 }
 
@@ -112,9 +116,9 @@ func (sds *SeedDispersalSystem) CreateSeed(parent *Plant, world *World) *Seed {
 		IsDormant:           false,
 		CarriedByEntity:     0,
 		DistanceFromHome:    0,
-		RequiredTemperature: 15.0 + rand.Float64()*10.0, // Temperature requirements
-		RequiredMoisture:    0.3 + rand.Float64()*0.4,   // Moisture requirements
-		RequiredSunlight:    0.2 + rand.Float64()*0.6,   // Sunlight requirements
+		RequiredTemperature: 15.0 + sds.rng.Float64()*10.0, // Temperature requirements
+		RequiredMoisture:    0.3 + sds.rng.Float64()*0.4,   // Moisture requirements
+		RequiredSunlight:    0.2 + sds.rng.Float64()*0.6,   // Sunlight requirements
 		DormancyTrigger:     false,
 	}
 
@@ -145,7 +149,7 @@ func (sds *SeedDispersalSystem) determineSeedType(parent *Plant) SeedType {
 	case PlantAlgae:
 		return SeedFloating // Aquatic dispersal
 	case PlantCactus:
-		if rand.Float64() < 0.6 {
+		if sds.rng.Float64() < 0.6 {
 			return SeedFleshy // Cactus fruits are often fleshy
 		}
 		return SeedExplosive // Some cacti have explosive seed pods
@@ -213,10 +217,9 @@ func (sds *SeedDispersalSystem) determineDispersalMethod(seedType SeedType, pare
 // hasNearbyAnimals checks if there are entities nearby that could disperse seeds
 func (sds *SeedDispersalSystem) hasNearbyAnimals(position Position, world *World) bool {
 	searchRadius := 5.0
-	for _, entity := range world.AllEntities {
+	for _, entity := range world.spatialIndex().QueryRadius(position, searchRadius) {
 		if entity.IsAlive {
-			distance := math.Sqrt(math.Pow(entity.Position.X-position.X, 2) +
-				math.Pow(entity.Position.Y-position.Y, 2))
+			distance := world.Distance(entity.Position, position)
 			if distance <= searchRadius {
 				return true
 			}
@@ -278,7 +281,7 @@ func (sds *SeedDispersalSystem) updateSeed(seed *Seed, world *World) {
 	}
 
 	// Check if dormant seed should germinate
-	if seed.IsDormant && sds.canGerminate(seed, world) {
+	if seed.IsDormant && sds.canGerminate(seed, world) && world.admitPlant(seed.Position) {
 		sds.germinate(seed, world)
 		return
 	}
@@ -299,8 +302,7 @@ func (sds *SeedDispersalSystem) updateSeed(seed *Seed, world *World) {
 
 	// Update distance from home
 	parentPos := seed.Position // We'd need to track original position
-	seed.DistanceFromHome = math.Sqrt(math.Pow(seed.Position.X-parentPos.X, 2) +
-		math.Pow(seed.Position.Y-parentPos.Y, 2))
+	seed.DistanceFromHome = world.Distance(seed.Position, parentPos)
 
 	// Reduce viability over time
 	seed.Viability -= 0.01 // 1% per tick
@@ -321,7 +323,7 @@ func (sds *SeedDispersalSystem) shouldEnterDormancy(seed *Seed, world *World) bo
 	}
 
 	// Random chance of dormancy to prevent immediate germination
-	return rand.Float64() < 0.3
+	return sds.rng.Float64() < 0.3
 }
 
 // canGerminate checks if conditions are suitable for germination
@@ -346,15 +348,16 @@ func (sds *SeedDispersalSystem) canGerminate(seed *Seed, world *World) bool {
 // germinate converts a seed into a new plant
 func (sds *SeedDispersalSystem) germinate(seed *Seed, world *World) {
 	// Create new plant at seed location
-	newPlant := NewPlant(world.NextPlantID, seed.PlantType, seed.Position)
+	newPlant := NewPlant(world.Rand, world.NextPlantID, seed.PlantType, seed.Position)
 	world.NextPlantID++
 
 	// Inherit genetics from seed
 	newPlant.Traits = seed.Genetics
 
 	// Add some genetic variation
-	for traitName, trait := range newPlant.Traits {
-		trait.Value += (rand.Float64()*2 - 1) * 0.1 // Small mutations
+	for _, traitName := range sortedKeys(newPlant.Traits) {
+		trait := newPlant.Traits[traitName]
+		trait.Value += (sds.rng.Float64()*2 - 1) * 0.1 // Small mutations
 		newPlant.Traits[traitName] = trait
 	}
 
@@ -402,14 +405,27 @@ func (sds *SeedDispersalSystem) addToSeedBank(seed *Seed, world *World) {
 
 // updateSeedBanks updates all seed banks
 func (sds *SeedDispersalSystem) updateSeedBanks(world *World) {
-	for pos, bank := range sds.SeedBanks {
+	// Visit banks in position order so germination draws random numbers reproducibly
+	positions := make([]Position, 0, len(sds.SeedBanks))
+	for pos := range sds.SeedBanks {
+		positions = append(positions, pos)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Y != positions[j].Y {
+			return positions[i].Y < positions[j].Y
+		}
+		return positions[i].X < positions[j].X
+	})
+
+	for _, pos := range positions {
+		bank := sds.SeedBanks[pos]
 		// Update environmental conditions
 		bank.Moisture = world.getMoistureAt(pos)
 
 		// Check seeds for germination
 		for i := len(bank.Seeds) - 1; i >= 0; i-- {
 			seed := bank.Seeds[i]
-			if sds.canGerminate(seed, world) {
+			if sds.canGerminate(seed, world) && world.admitPlant(seed.Position) {
 				sds.germinate(seed, world)
 				// Remove from seed bank
 				bank.Seeds = append(bank.Seeds[:i], bank.Seeds[i+1:]...)
@@ -458,8 +474,8 @@ func (sds *SeedDispersalSystem) disperseByWind(seed *Seed, world *World) {
 	seed.Velocity.Y += wind.Y * windEffect * 0.1
 
 	// Add some turbulence
-	seed.Velocity.X += (rand.Float64()*2 - 1) * wind.Turbulence * 0.05
-	seed.Velocity.Y += (rand.Float64()*2 - 1) * wind.Turbulence * 0.05
+	seed.Velocity.X += (sds.rng.Float64()*2 - 1) * wind.Turbulence * 0.05
+	seed.Velocity.Y += (sds.rng.Float64()*2 - 1) * wind.Turbulence * 0.05
 
 	// Apply drag
 	seed.Velocity.X *= 0.9
@@ -474,10 +490,9 @@ func (sds *SeedDispersalSystem) disperseByWind(seed *Seed, world *World) {
 func (sds *SeedDispersalSystem) disperseByAnimal(seed *Seed, world *World) {
 	// If not currently carried, try to attach to nearby entity
 	if seed.CarriedByEntity == 0 {
-		for _, entity := range world.AllEntities {
+		for _, entity := range world.spatialIndex().QueryRadius(seed.Position, 1.0) {
 			if entity.IsAlive {
-				distance := math.Sqrt(math.Pow(entity.Position.X-seed.Position.X, 2) +
-					math.Pow(entity.Position.Y-seed.Position.Y, 2))
+				distance := world.Distance(entity.Position, seed.Position)
 				if distance <= 1.0 { // Close enough to pick up
 					// Probability of pickup based on seed type
 					pickupChance := 0.1 // Base chance
@@ -487,7 +502,7 @@ func (sds *SeedDispersalSystem) disperseByAnimal(seed *Seed, world *World) {
 						pickupChance = 0.2 // Hooked seeds attach more easily
 					}
 
-					if rand.Float64() < pickupChance {
+					if sds.rng.Float64() < pickupChance {
 						seed.CarriedByEntity = entity.ID
 						seed.Position = entity.Position
 						break
@@ -507,11 +522,11 @@ func (sds *SeedDispersalSystem) disperseByAnimal(seed *Seed, world *World) {
 					dropChance = 0.1 // Eaten seeds are "dropped" more often
 				}
 
-				if rand.Float64() < dropChance {
+				if sds.rng.Float64() < dropChance {
 					seed.CarriedByEntity = 0
 					// Add some randomness to drop location
-					seed.Position.X += (rand.Float64()*2 - 1) * 2.0
-					seed.Position.Y += (rand.Float64()*2 - 1) * 2.0
+					seed.Position.X += (sds.rng.Float64()*2 - 1) * 2.0
+					seed.Position.Y += (sds.rng.Float64()*2 - 1) * 2.0
 				}
 				break
 			}
@@ -536,8 +551,8 @@ func (sds *SeedDispersalSystem) disperseExplosively(seed *Seed, world *World) {
 	// Explosive dispersal happens quickly
 	if seed.Age == 1 { // First tick after creation
 		// Launch seed in random direction
-		angle := rand.Float64() * 2 * math.Pi
-		force := 2.0 + rand.Float64()*3.0 // Random force 2-5
+		angle := sds.rng.Float64() * 2 * math.Pi
+		force := 2.0 + sds.rng.Float64()*3.0 // Random force 2-5
 
 		seed.Velocity.X = math.Cos(angle) * force
 		seed.Velocity.Y = math.Sin(angle) * force
@@ -564,8 +579,8 @@ func (sds *SeedDispersalSystem) disperseByGravity(seed *Seed, world *World) {
 	// Heavy seeds just fall down with some randomness
 	if seed.Age == 1 { // First tick after creation
 		// Small random horizontal movement
-		seed.Velocity.X = (rand.Float64()*2 - 1) * 0.5
-		seed.Velocity.Y = -(0.5 + rand.Float64()*1.0) // Downward movement
+		seed.Velocity.X = (sds.rng.Float64()*2 - 1) * 0.5
+		seed.Velocity.Y = -(0.5 + sds.rng.Float64()*1.0) // Downward movement
 	}
 
 	// Apply gravity
@@ -613,7 +628,7 @@ func (sds *SeedDispersalSystem) disperseByWater(seed *Seed, world *World) {
 		flowStrength := 0.08 // Much slower flow
 
 		// Random vegetation obstacles
-		if rand.Float64() < 0.3 {
+		if sds.rng.Float64() < 0.3 {
 			flowStrength *= 0.5 // Vegetation slows flow
 		}
 
@@ -698,8 +713,8 @@ func (sds *SeedDispersalSystem) calculateWaterFlow(position Position, world *Wor
 		turbulence = 0.1 // Deep water has less surface turbulence
 	}
 
-	flowVector.X += (rand.Float64()*2 - 1) * turbulence
-	flowVector.Y += (rand.Float64()*2 - 1) * turbulence
+	flowVector.X += (sds.rng.Float64()*2 - 1) * turbulence
+	flowVector.Y += (sds.rng.Float64()*2 - 1) * turbulence
 
 	// Normalize the flow vector
 	magnitude := math.Sqrt(flowVector.X*flowVector.X + flowVector.Y*flowVector.Y)
@@ -763,7 +778,7 @@ func (sds *SeedDispersalSystem) isFloodedArea(position Position, world *World) b
 					floodProbability := math.Max(0, 1.0-(distance/waterCheckRadius))
 
 					// Random chance based on distance and season intensity
-					if rand.Float64() < floodProbability*0.3 {
+					if sds.rng.Float64() < floodProbability*0.3 {
 						return true
 					}
 				}
@@ -807,8 +822,8 @@ func (sds *SeedDispersalSystem) calculateFloodFlow(position Position, world *Wor
 	}
 
 	// Add strong turbulence for flood conditions
-	flowVector.X += (rand.Float64()*2 - 1) * 0.4
-	flowVector.Y += (rand.Float64()*2 - 1) * 0.4
+	flowVector.X += (sds.rng.Float64()*2 - 1) * 0.4
+	flowVector.Y += (sds.rng.Float64()*2 - 1) * 0.4
 
 	return flowVector
 }
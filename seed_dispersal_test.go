@@ -32,7 +32,7 @@ func TestCreateSeed(t *testing.T) {
 	world := NewWorld(config)
 
 	// Create a test plant
-	plant := NewPlant(1, PlantGrass, Position{X: 5, Y: 5})
+	plant := NewPlant(sharedRand, 1, PlantGrass, Position{X: 5, Y: 5})
 	plant.Energy = 100
 
 	// Create seed
@@ -96,7 +96,7 @@ func TestDispersalMechanisms(t *testing.T) {
 	world := NewWorld(config)
 
 	sds := world.SeedDispersalSystem
-	plant := NewPlant(1, PlantGrass, Position{X: 5, Y: 5})
+	plant := NewPlant(sharedRand, 1, PlantGrass, Position{X: 5, Y: 5})
 
 	// Test different dispersal mechanisms
 	testCases := []struct {
@@ -127,7 +127,7 @@ func TestSeedViabilityDecay(t *testing.T) {
 	}
 	world := NewWorld(config)
 
-	plant := NewPlant(1, PlantGrass, Position{X: 5, Y: 5})
+	plant := NewPlant(sharedRand, 1, PlantGrass, Position{X: 5, Y: 5})
 	seed := world.SeedDispersalSystem.CreateSeed(plant, world)
 
 	initialViability := seed.Viability
@@ -157,7 +157,7 @@ func TestWindDispersal(t *testing.T) {
 	}
 	world := NewWorld(config)
 
-	plant := NewPlant(1, PlantGrass, Position{X: 5, Y: 5})
+	plant := NewPlant(sharedRand, 1, PlantGrass, Position{X: 5, Y: 5})
 	seed := world.SeedDispersalSystem.CreateSeed(plant, world)
 	seed.DispersalMethod = DispersalWind
 	seed.Mass = 0.1 // Light seed
@@ -183,11 +183,11 @@ func TestAnimalDispersal(t *testing.T) {
 
 	// Add an entity near the seed
 	traitNames := []string{"strength", "agility", "intelligence"}
-	entity := NewEntity(1, traitNames, "TestSpecies", Position{X: 5.5, Y: 5.5})
+	entity := NewEntity(sharedRand, 1, traitNames, "TestSpecies", Position{X: 5.5, Y: 5.5})
 	entity.IsAlive = true
 	world.AllEntities = append(world.AllEntities, entity)
 
-	plant := NewPlant(1, PlantBush, Position{X: 5, Y: 5})
+	plant := NewPlant(sharedRand, 1, PlantBush, Position{X: 5, Y: 5})
 	seed := world.SeedDispersalSystem.CreateSeed(plant, world)
 	seed.DispersalMethod = DispersalAnimal
 	seed.SeedType = SeedFleshy
@@ -216,7 +216,7 @@ func TestExplosiveDispersal(t *testing.T) {
 	}
 	world := NewWorld(config)
 
-	plant := NewPlant(1, PlantCactus, Position{X: 5, Y: 5})
+	plant := NewPlant(sharedRand, 1, PlantCactus, Position{X: 5, Y: 5})
 	seed := world.SeedDispersalSystem.CreateSeed(plant, world)
 	seed.DispersalMethod = DispersalExplosive
 	seed.Age = 1 // Trigger explosive dispersal
@@ -241,7 +241,7 @@ func TestSeedBankSystem(t *testing.T) {
 	}
 	world := NewWorld(config)
 
-	plant := NewPlant(1, PlantGrass, Position{X: 5, Y: 5})
+	plant := NewPlant(sharedRand, 1, PlantGrass, Position{X: 5, Y: 5})
 	seed := world.SeedDispersalSystem.CreateSeed(plant, world)
 
 	// Force seed into dormancy
@@ -274,7 +274,7 @@ func TestGermination(t *testing.T) {
 
 	initialPlantCount := len(world.AllPlants)
 
-	plant := NewPlant(1, PlantGrass, Position{X: 5, Y: 5})
+	plant := NewPlant(sharedRand, 1, PlantGrass, Position{X: 5, Y: 5})
 	seed := world.SeedDispersalSystem.CreateSeed(plant, world)
 
 	// Set favorable conditions for germination
@@ -316,7 +316,7 @@ func TestSeedDispersalIntegration(t *testing.T) {
 
 	// Add some plants
 	for i := 0; i < 5; i++ {
-		plant := NewPlant(i+1, PlantGrass, Position{X: float64(5 + i), Y: 5})
+		plant := NewPlant(sharedRand, i+1, PlantGrass, Position{X: float64(5 + i), Y: 5})
 		plant.Energy = 100 // Ensure they can reproduce
 		world.AllPlants = append(world.AllPlants, plant)
 	}
@@ -347,7 +347,7 @@ func TestSeedDispersalStats(t *testing.T) {
 	}
 	world := NewWorld(config)
 
-	plant := NewPlant(1, PlantGrass, Position{X: 5, Y: 5})
+	plant := NewPlant(sharedRand, 1, PlantGrass, Position{X: 5, Y: 5})
 
 	// Create seeds of different types
 	for i := 0; i < 3; i++ {
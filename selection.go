@@ -0,0 +1,352 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// Selection strategy names
+const (
+	SelectionTournament     = "tournament"
+	SelectionRoulette       = "roulette"
+	SelectionRank           = "rank"
+	SelectionNovelty        = "novelty"
+	SelectionMultiObjective = "multi_objective"
+)
+
+const (
+	noveltyNeighbours = 5  // Nearest behaviours a creature's novelty is measured against
+	maxNoveltyArchive = 50 // Behaviours remembered from past generations
+)
+
+// SelectionStrategy picks the parents of a population's next generation. A population
+// without one uses tournament selection, so seeded runs replay as they did before
+// strategies could be chosen.
+type SelectionStrategy interface {
+	Name() string
+	// Prepare is called once a generation, after fitness is evaluated and before any parent is picked
+	Prepare(p *Population)
+	// Select picks one parent from the population
+	Select(p *Population) *Entity
+}
+
+// SelectionStrategyInfo describes a strategy. It makes a new instance for each population,
+// since some strategies remember things about the population they select from.
+type SelectionStrategyInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	create      func() SelectionStrategy
+}
+
+// selectionStrategyLibrary lists the strategies a population can use
+var selectionStrategyLibrary = []SelectionStrategyInfo{
+	{SelectionTournament, "The fittest of a few creatures drawn at random", func() SelectionStrategy { return &TournamentStrategy{} }},
+	{SelectionRoulette, "Drawn in proportion to fitness above the least fit", func() SelectionStrategy { return &RouletteStrategy{} }},
+	{SelectionRank, "Drawn in proportion to rank by fitness, whatever the gaps between them", func() SelectionStrategy { return &RankStrategy{} }},
+	{SelectionNovelty, "The most unusual traits of a few drawn at random, against the population and past generations", func() SelectionStrategy { return &NoveltyStrategy{} }},
+	{SelectionMultiObjective, "The best Pareto front of a few drawn at random, trading energy, age and fitness off against each other", func() SelectionStrategy { return &MultiObjectiveStrategy{} }},
+}
+
+// SelectionStrategyNames lists the names of the strategies
+func SelectionStrategyNames() []string {
+	names := make([]string, len(selectionStrategyLibrary))
+	for i, info := range selectionStrategyLibrary {
+		names[i] = info.Name
+	}
+	return names
+}
+
+// NewSelectionStrategy creates a strategy by name
+func NewSelectionStrategy(name string) (SelectionStrategy, error) {
+	for _, info := range selectionStrategyLibrary {
+		if info.Name == name {
+			return info.create(), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown selection strategy %q (known: %s)", name, strings.Join(SelectionStrategyNames(), ", "))
+}
+
+// TournamentStrategy picks the fittest of the population's tournament size drawn at random
+type TournamentStrategy struct{}
+
+func (s *TournamentStrategy) Name() string          { return SelectionTournament }
+func (s *TournamentStrategy) Prepare(p *Population) {}
+
+func (s *TournamentStrategy) Select(p *Population) *Entity {
+	return p.TournamentSelection()
+}
+
+// weightedDraw picks creatures with chances in proportion to their weights
+type weightedDraw struct {
+	entities   []*Entity
+	cumulative []float64
+}
+
+func (d *weightedDraw) prepare(entities []*Entity, weight func(i int) float64) {
+	d.entities = entities
+	d.cumulative = make([]float64, len(entities))
+	total := 0.0
+	for i := range entities {
+		total += weight(i)
+		d.cumulative[i] = total
+	}
+}
+
+func (d *weightedDraw) pick(rng *rand.Rand) *Entity {
+	total := d.cumulative[len(d.cumulative)-1]
+	if total <= 0 {
+		return d.entities[rng.Intn(len(d.entities))]
+	}
+	i := sort.SearchFloat64s(d.cumulative, rng.Float64()*total)
+	return d.entities[min(i, len(d.entities)-1)]
+}
+
+// RouletteStrategy draws creatures in proportion to their fitness. Fitness is measured
+// from just below the least fit, so negative fitness works and no one is left out.
+type RouletteStrategy struct {
+	draw weightedDraw
+}
+
+func (s *RouletteStrategy) Name() string { return SelectionRoulette }
+
+func (s *RouletteStrategy) Prepare(p *Population) {
+	_, least, most := p.GetStats()
+	floor := least - math.Max(0.01, (most-least)*0.01)
+	s.draw.prepare(p.Entities, func(i int) float64 { return p.Entities[i].Fitness - floor })
+}
+
+func (s *RouletteStrategy) Select(p *Population) *Entity {
+	return s.draw.pick(p.rng)
+}
+
+// RankStrategy draws creatures in proportion to their rank, the least fit counting once and
+// the fittest as many times as there are creatures, so one outlier cannot take over
+type RankStrategy struct {
+	draw weightedDraw
+}
+
+func (s *RankStrategy) Name() string { return SelectionRank }
+
+func (s *RankStrategy) Prepare(p *Population) {
+	ranked := append([]*Entity(nil), p.Entities...)
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Fitness < ranked[j].Fitness })
+	s.draw.prepare(ranked, func(i int) float64 { return float64(i + 1) })
+}
+
+func (s *RankStrategy) Select(p *Population) *Entity {
+	return s.draw.pick(p.rng)
+}
+
+// NoveltyStrategy ignores fitness and rewards creatures whose traits are unlike the rest:
+// a creature's novelty is its mean trait distance from its nearest neighbours among the
+// population and an archive of the most novel creatures of past generations
+type NoveltyStrategy struct {
+	Archive [][]float64 // Traits of the most novel creature of each past generation, oldest first
+	novelty map[*Entity]float64
+}
+
+func (s *NoveltyStrategy) Name() string { return SelectionNovelty }
+
+// behaviour is a creature's traits in the population's order
+func (s *NoveltyStrategy) behaviour(p *Population, entity *Entity) []float64 {
+	values := make([]float64, len(p.TraitNames))
+	for i, trait := range p.TraitNames {
+		values[i] = entity.GetTrait(trait)
+	}
+	return values
+}
+
+func (s *NoveltyStrategy) Prepare(p *Population) {
+	behaviours := make([][]float64, len(p.Entities))
+	for i, entity := range p.Entities {
+		behaviours[i] = s.behaviour(p, entity)
+	}
+	// An archive from creatures with other traits can't be compared
+	if len(s.Archive) > 0 && len(s.Archive[0]) != len(p.TraitNames) {
+		s.Archive = nil
+	}
+
+	s.novelty = make(map[*Entity]float64, len(p.Entities))
+	var mostNovel []float64
+	mostNovelty := -1.0
+	for i, entity := range p.Entities {
+		distances := make([]float64, 0, len(behaviours)+len(s.Archive))
+		for j, other := range behaviours {
+			if j != i {
+				distances = append(distances, traitDistance(behaviours[i], other))
+			}
+		}
+		for _, archived := range s.Archive {
+			distances = append(distances, traitDistance(behaviours[i], archived))
+		}
+		sort.Float64s(distances)
+		neighbours := min(noveltyNeighbours, len(distances))
+		sum := 0.0
+		for _, distance := range distances[:neighbours] {
+			sum += distance
+		}
+		if neighbours > 0 {
+			s.novelty[entity] = sum / float64(neighbours)
+		}
+		if s.novelty[entity] > mostNovelty {
+			mostNovel, mostNovelty = behaviours[i], s.novelty[entity]
+		}
+	}
+
+	if mostNovel != nil {
+		s.Archive = append(s.Archive, mostNovel)
+		if len(s.Archive) > maxNoveltyArchive {
+			s.Archive = s.Archive[len(s.Archive)-maxNoveltyArchive:]
+		}
+	}
+}
+
+func (s *NoveltyStrategy) Select(p *Population) *Entity {
+	best := p.Entities[p.rng.Intn(len(p.Entities))]
+	for i := 1; i < p.TournamentSize; i++ {
+		candidate := p.Entities[p.rng.Intn(len(p.Entities))]
+		if s.novelty[candidate] > s.novelty[best] {
+			best = candidate
+		}
+	}
+	return best
+}
+
+// traitDistance is the Euclidean distance between two creatures' traits
+func traitDistance(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
+
+// MultiObjectiveStrategy weighs energy, age and fitness separately rather than summed:
+// creatures are sorted into Pareto fronts, the first holding those no other creature
+// beats on every objective, and the front nearest the first wins a tournament
+type MultiObjectiveStrategy struct {
+	front map[*Entity]int
+}
+
+func (s *MultiObjectiveStrategy) Name() string { return SelectionMultiObjective }
+
+// dominates reports whether a is at least as good as b on every objective and better on one
+func dominates(a, b *Entity) bool {
+	objectivesA := [3]float64{a.Energy, float64(a.Age), a.Fitness}
+	objectivesB := [3]float64{b.Energy, float64(b.Age), b.Fitness}
+	better := false
+	for i := range objectivesA {
+		if objectivesA[i] < objectivesB[i] {
+			return false
+		}
+		if objectivesA[i] > objectivesB[i] {
+			better = true
+		}
+	}
+	return better
+}
+
+func (s *MultiObjectiveStrategy) Prepare(p *Population) {
+	s.front = make(map[*Entity]int, len(p.Entities))
+	remaining := append([]*Entity(nil), p.Entities...)
+	for front := 0; len(remaining) > 0; front++ {
+		var dominated []*Entity
+		for _, entity := range remaining {
+			beaten := false
+			for _, other := range remaining {
+				if dominates(other, entity) {
+					beaten = true
+					break
+				}
+			}
+			if beaten {
+				dominated = append(dominated, entity)
+			} else {
+				s.front[entity] = front
+			}
+		}
+		remaining = dominated
+	}
+}
+
+func (s *MultiObjectiveStrategy) Select(p *Population) *Entity {
+	best := p.Entities[p.rng.Intn(len(p.Entities))]
+	for i := 1; i < p.TournamentSize; i++ {
+		candidate := p.Entities[p.rng.Intn(len(p.Entities))]
+		if s.front[candidate] < s.front[best] {
+			best = candidate
+		}
+	}
+	return best
+}
+
+// PopulationSelection is how one population picks its parents and how its fitness stands
+type PopulationSelection struct {
+	Population     string  `json:"population"`
+	Strategy       string  `json:"strategy"`
+	Generation     int     `json:"generation"`
+	Size           int     `json:"size"`
+	AverageFitness float64 `json:"average_fitness"`
+	BestFitness    float64 `json:"best_fitness"`
+	NoveltyArchive int     `json:"novelty_archive,omitempty"` // Behaviours a novelty search remembers
+}
+
+// SelectionReport lists the selection strategies and the one each population uses, so
+// strategies can be compared side by side in the same ecosystem
+type SelectionReport struct {
+	Strategies  []SelectionStrategyInfo `json:"strategies"`
+	Populations []PopulationSelection   `json:"populations"`
+}
+
+// SelectionReport reports the strategies and each population's choice
+func (w *World) SelectionReport() SelectionReport {
+	report := SelectionReport{Strategies: selectionStrategyLibrary, Populations: make([]PopulationSelection, 0, len(w.Populations))}
+	for _, name := range sortedKeys(w.Populations) {
+		pop := w.Populations[name]
+		average, _, best := pop.GetStats()
+		status := PopulationSelection{
+			Population:     name,
+			Strategy:       pop.selection().Name(),
+			Generation:     pop.Generation,
+			Size:           len(pop.Entities),
+			AverageFitness: average,
+			BestFitness:    best,
+		}
+		if novelty, ok := pop.Selection.(*NoveltyStrategy); ok {
+			status.NoveltyArchive = len(novelty.Archive)
+		}
+		report.Populations = append(report.Populations, status)
+	}
+	return report
+}
+
+// SetSelectionStrategy changes how a population picks its parents from the next generation on
+func (w *World) SetSelectionStrategy(population, name string) error {
+	pop, exists := w.Populations[population]
+	if !exists {
+		return fmt.Errorf("unknown population %q", population)
+	}
+	strategy, err := NewSelectionStrategy(name)
+	if err != nil {
+		return err
+	}
+	pop.Selection = strategy
+	return nil
+}
+
+// SelectionStrategies records the populations that don't use tournament selection, for saves
+func (w *World) SelectionStrategies() map[string]string {
+	var strategies map[string]string
+	for name, pop := range w.Populations {
+		if pop.Selection != nil && pop.Selection.Name() != SelectionTournament {
+			if strategies == nil {
+				strategies = make(map[string]string)
+			}
+			strategies[name] = pop.Selection.Name()
+		}
+	}
+	return strategies
+}
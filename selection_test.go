@@ -0,0 +1,134 @@
+package main
+
+import (
+	"math/rand"
+	"net/http"
+	"testing"
+)
+
+// newSelectionTestPopulation makes twenty alike creatures with fitness 0 to 19, and an
+// outlier with the least fitness and the most energy and the most unusual traits
+func newSelectionTestPopulation() (*Population, *Entity) {
+	pop := NewPopulation(sharedRand, 20, []string{"speed", "size"}, 0.1, 0.2)
+	for i, entity := range pop.Entities {
+		entity.Fitness = float64(i)
+		entity.Energy = 50
+		entity.SetTrait("speed", 0.1)
+		entity.SetTrait("size", 0.1)
+	}
+	outlier := pop.Entities[0]
+	outlier.Energy = 100
+	outlier.SetTrait("speed", 0.9)
+	outlier.SetTrait("size", -0.9)
+	return pop, outlier
+}
+
+func TestSelectionStrategiesPickDifferentParents(t *testing.T) {
+	picks := make(map[string]map[*Entity]int)
+	meanFitness := make(map[string]float64)
+	for _, name := range SelectionStrategyNames() {
+		pop, _ := newSelectionTestPopulation()
+		pop.rng = rand.New(rand.NewSource(7))
+		strategy, err := NewSelectionStrategy(name)
+		if err != nil || strategy.Name() != name {
+			t.Fatalf("Expected the %s strategy, got %v %v", name, strategy, err)
+		}
+		strategy.Prepare(pop)
+		picks[name] = make(map[*Entity]int)
+		for i := 0; i < 2000; i++ {
+			parent := strategy.Select(pop)
+			picks[name][parent]++
+			meanFitness[name] += parent.Fitness / 2000
+		}
+	}
+
+	// Fitness-driven strategies favour the fit, tournaments hardest and roulette least
+	for _, name := range []string{SelectionTournament, SelectionRoulette, SelectionRank} {
+		if meanFitness[name] <= 9.5 {
+			t.Errorf("Expected %s selection to favour the fit, got mean fitness %.2f", name, meanFitness[name])
+		}
+	}
+	if meanFitness[SelectionTournament] <= meanFitness[SelectionRank] || meanFitness[SelectionRank] <= meanFitness[SelectionRoulette]-1 {
+		t.Errorf("Expected tournaments to press hardest, got %v", meanFitness)
+	}
+
+	// The outlier is never picked by a tournament of fitness, but novelty and Pareto fronts want it
+	_, outlier := newSelectionTestPopulation()
+	countOutlier := func(name string) int {
+		for parent, count := range picks[name] {
+			if parent.Energy == outlier.Energy {
+				return count
+			}
+		}
+		return 0
+	}
+	if count := countOutlier(SelectionTournament); count > 0 {
+		t.Errorf("Expected the least fit never to win a tournament, picked %d times", count)
+	}
+	for _, name := range []string{SelectionNovelty, SelectionMultiObjective} {
+		if count := countOutlier(name); count < 200 {
+			t.Errorf("Expected %s selection to favour the outlier, picked it %d times in 2000", name, count)
+		}
+	}
+
+	if _, err := NewSelectionStrategy("lottery"); err == nil {
+		t.Error("Expected an unknown strategy refused")
+	}
+}
+
+func TestNoveltyArchiveRemembersPastGenerations(t *testing.T) {
+	pop, _ := newSelectionTestPopulation()
+	novelty := &NoveltyStrategy{}
+	for generation := 0; generation < maxNoveltyArchive+5; generation++ {
+		novelty.Prepare(pop)
+	}
+	if len(novelty.Archive) != maxNoveltyArchive || novelty.Archive[0][0] != 0.9 {
+		t.Errorf("Expected the archive capped at %d with the outlier's traits, got %d %v", maxNoveltyArchive, len(novelty.Archive), novelty.Archive[0])
+	}
+	// Once the archive is full of the outlier, its own kind no longer looks new
+	if novelty.novelty[pop.Entities[0]] != 0 {
+		t.Errorf("Expected the archived outlier no longer novel, got %.2f", novelty.novelty[pop.Entities[0]])
+	}
+}
+
+func TestSelectionAPI(t *testing.T) {
+	world := newSteppingTestWorld(114)
+	wi := NewWebInterface(world)
+	population := sortedKeys(world.Populations)[0]
+
+	var report SelectionReport
+	if code := callControlAPI(t, wi.handleSelection, http.MethodGet, "/api/selection", "", &report); code != http.StatusOK ||
+		len(report.Strategies) != len(selectionStrategyLibrary) || len(report.Populations) != len(world.Populations) ||
+		report.Populations[0].Strategy != SelectionTournament {
+		t.Fatalf("Expected every population on tournament selection, got %d %+v", code, report)
+	}
+
+	var changed SelectionReport
+	body := `{"population": "` + population + `", "strategy": "novelty"}`
+	if code := callControlAPI(t, wi.handleSelection, http.MethodPost, "/api/selection", body, &changed); code != http.StatusOK ||
+		changed.Populations[0].Strategy != SelectionNovelty {
+		t.Fatalf("Expected the population switched to novelty search, got %d %+v", code, changed)
+	}
+	for _, bad := range []string{`{"population": "` + population + `", "strategy": "lottery"}`, `{"population": "nobody", "strategy": "rank"}`} {
+		if code := callControlAPI(t, wi.handleSelection, http.MethodPost, "/api/selection", bad, nil); code != http.StatusBadRequest {
+			t.Errorf("Expected %s refused, got %d", bad, code)
+		}
+	}
+
+	// The population evolves by its strategy, and keeps it through a save
+	world.Populations[population].EvolveWith(world.Mutations)
+	if novelty := world.Populations[population].Selection.(*NoveltyStrategy); len(novelty.Archive) != 1 {
+		t.Errorf("Expected a generation chosen by novelty, got an archive of %d", len(novelty.Archive))
+	}
+	state, err := NewStateManager(world).CurrentState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	world.Populations[population].Selection = nil
+	if err := NewStateManager(world).restoreState(state); err != nil {
+		t.Fatal(err)
+	}
+	if restored, exists := world.Populations[population]; !exists || restored.selection().Name() != SelectionNovelty {
+		t.Errorf("Expected the saved strategy restored, got %+v", world.SelectionReport().Populations)
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"sync"
+)
+
+// spatialCellSize is the side of a spatial index cell in world units. Proximity queries
+// look 1-30 units out, so most touch a handful of cells.
+const spatialCellSize = 10.0
+
+// spatialSlack is how far a creature or plant may move after being indexed and still be
+// found. The index is rebuilt every tick and again once movement settles, and nothing moves
+// a creature this far in between.
+const spatialSlack = 8.0
+
+// spatialCell is a cell of the spatial index, in cell coordinates
+type spatialCell struct{ X, Y int }
+
+// spatialCellOf finds the cell a position falls in
+func spatialCellOf(pos Position) spatialCell {
+	return spatialCell{X: int(math.Floor(pos.X / spatialCellSize)), Y: int(math.Floor(pos.Y / spatialCellSize))}
+}
+
+// spatialLayer indexes the slots of one slice by cell, remembering the ends of the slice so
+// it can tell a slice that was appended to from one that was replaced
+type spatialLayer[T comparable] struct {
+	cells       map[spatialCell][]int
+	items       []T
+	first, last T
+}
+
+// current reports whether items is still the indexed slice, with nothing appended
+func (l *spatialLayer[T]) current(items []T) bool {
+	return len(items) == len(l.items) && l.extends(items)
+}
+
+// extends reports whether items starts with the indexed slice
+func (l *spatialLayer[T]) extends(items []T) bool {
+	n := len(l.items)
+	return len(items) >= n && (n == 0 || items[0] == l.first && items[n-1] == l.last)
+}
+
+// sync brings the layer up to date with items, indexing only what was appended unless the
+// slice was replaced or a rebuild is asked for
+func (l *spatialLayer[T]) sync(items []T, position func(T) Position, rebuild bool) {
+	if l.cells == nil {
+		l.cells = make(map[spatialCell][]int)
+	}
+	from := len(l.items)
+	if rebuild || !l.extends(items) {
+		clear(l.cells)
+		from = 0
+	}
+	for slot := from; slot < len(items); slot++ {
+		cell := spatialCellOf(position(items[slot]))
+		l.cells[cell] = append(l.cells[cell], slot)
+	}
+
+	l.items = items
+	if len(items) > 0 {
+		l.first, l.last = items[0], items[len(items)-1]
+	}
+}
+
+// query returns the slots within radius of center as the geometry measures it, in slice
+// order. On a map that wraps it also looks round the joined edges, at center's copies
+// across them.
+func (l *spatialLayer[T]) query(center Position, radius float64, position func(T) Position, geometry WorldGeometry) []int {
+	reach := radius + spatialSlack
+	var candidates []int
+	if span := 2*reach/spatialCellSize + 2; !(span*span <= float64(len(l.cells))) {
+		// The circle covers more cells than are occupied
+		for _, slots := range l.cells {
+			candidates = append(candidates, slots...)
+		}
+	} else {
+		copies := geometry.Copies(center)
+		for _, copy := range copies {
+			low := spatialCellOf(Position{X: copy.X - reach, Y: copy.Y - reach})
+			high := spatialCellOf(Position{X: copy.X + reach, Y: copy.Y + reach})
+			for y := low.Y; y <= high.Y; y++ {
+				for x := low.X; x <= high.X; x++ {
+					candidates = append(candidates, l.cells[spatialCell{X: x, Y: y}]...)
+				}
+			}
+		}
+	}
+	// Cells seen across a joined edge may have been looked at already
+	slices.Sort(candidates)
+	candidates = slices.Compact(candidates)
+
+	found := candidates[:0]
+	for _, slot := range candidates {
+		if geometry.Distance(position(l.items[slot]), center) <= radius {
+			found = append(found, slot)
+		}
+	}
+	return found
+}
+
+// SpatialIndex buckets the world's creatures and plants into a uniform grid so proximity
+// systems look at the cells around a point instead of scanning every creature or plant.
+// Queries return what a scan of AllEntities or AllPlants filtered by distance would, dead
+// ones included, in slice order, so results feed sums and random draws exactly as the scan
+// did. The index rebuilds itself on a tick's first query and indexes creatures and plants
+// appended during the tick as it sees them. Distances are measured the short way round a
+// map whose edges join.
+type SpatialIndex struct {
+	mu       sync.RWMutex
+	tick     int
+	stale    bool
+	geometry WorldGeometry
+	entities spatialLayer[*Entity]
+	plants   spatialLayer[*Plant]
+}
+
+// NewSpatialIndex creates an empty spatial index
+func NewSpatialIndex() *SpatialIndex {
+	return &SpatialIndex{stale: true}
+}
+
+func entityPosition(entity *Entity) Position { return entity.Position }
+
+func plantPosition(plant *Plant) Position { return plant.Position }
+
+// Sync brings the index up to date with the creatures and plants of a tick, on a map of
+// the given geometry
+func (si *SpatialIndex) Sync(tick int, entities []*Entity, plants []*Plant, geometry WorldGeometry) {
+	si.mu.RLock()
+	current := !si.stale && si.tick == tick && si.geometry == geometry && si.entities.current(entities) && si.plants.current(plants)
+	si.mu.RUnlock()
+	if current {
+		return
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	rebuild := si.stale || si.tick != tick || si.geometry != geometry
+	si.entities.sync(entities, entityPosition, rebuild)
+	si.plants.sync(plants, plantPosition, rebuild)
+	si.tick, si.stale, si.geometry = tick, false, geometry
+}
+
+// Invalidate makes the next Sync rebuild the index, for after creatures have moved
+func (si *SpatialIndex) Invalidate() {
+	si.mu.Lock()
+	si.stale = true
+	si.mu.Unlock()
+}
+
+// QueryRadius returns the creatures within radius of a point
+func (si *SpatialIndex) QueryRadius(center Position, radius float64) []*Entity {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	slots := si.entities.query(center, radius, entityPosition, si.geometry)
+	found := make([]*Entity, len(slots))
+	for i, slot := range slots {
+		found[i] = si.entities.items[slot]
+	}
+	return found
+}
+
+// QueryRadiusSlots returns the slots in AllEntities of the creatures within radius of a
+// point, for systems that visit each pair of creatures once
+func (si *SpatialIndex) QueryRadiusSlots(center Position, radius float64) []int {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	return si.entities.query(center, radius, entityPosition, si.geometry)
+}
+
+// QueryPlantRadius returns the plants within radius of a point
+func (si *SpatialIndex) QueryPlantRadius(center Position, radius float64) []*Plant {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+	slots := si.plants.query(center, radius, plantPosition, si.geometry)
+	found := make([]*Plant, len(slots))
+	for i, slot := range slots {
+		found[i] = si.plants.items[slot]
+	}
+	return found
+}
+
+// spatialIndex returns the world's spatial index brought up to date with its creatures and
+// plants, creating it for worlds built without one
+func (w *World) spatialIndex() *SpatialIndex {
+	if w.Spatial == nil {
+		w.Spatial = NewSpatialIndex()
+	}
+	w.Spatial.Sync(w.Tick, w.AllEntities, w.AllPlants, w.Geometry())
+	return w.Spatial
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// scanEntities is the linear scan a spatial index query replaces
+func scanEntities(entities []*Entity, center Position, radius float64) []*Entity {
+	var found []*Entity
+	for _, entity := range entities {
+		if distanceBetween(entity.Position, center) <= radius {
+			found = append(found, entity)
+		}
+	}
+	return found
+}
+
+func scanPlants(plants []*Plant, center Position, radius float64) []*Plant {
+	var found []*Plant
+	for _, plant := range plants {
+		if distanceBetween(plant.Position, center) <= radius {
+			found = append(found, plant)
+		}
+	}
+	return found
+}
+
+func TestSpatialIndexMatchesLinearScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	randomPosition := func() Position {
+		// Some creatures stray outside the world
+		return Position{X: rng.Float64()*120 - 10, Y: rng.Float64()*120 - 10}
+	}
+	var entities []*Entity
+	var plants []*Plant
+	for i := 0; i < 400; i++ {
+		entity := NewEntity(sharedRand, i, []string{"size"}, "A", randomPosition())
+		entity.IsAlive = i%5 != 0
+		entities = append(entities, entity)
+		plants = append(plants, NewPlant(sharedRand, i, PlantGrass, randomPosition()))
+	}
+
+	index := NewSpatialIndex()
+	index.Sync(1, entities, plants, BoxGeometry{})
+	for _, radius := range []float64{0, 1, 5, 15, 30, 500, math.Inf(1)} {
+		for i := 0; i < 50; i++ {
+			center := randomPosition()
+			if got, want := index.QueryRadius(center, radius), scanEntities(entities, center, radius); !slices.Equal(got, want) {
+				t.Fatalf("Radius %v around %v: expected %d creatures in slice order, got %d", radius, center, len(want), len(got))
+			}
+			if got, want := index.QueryPlantRadius(center, radius), scanPlants(plants, center, radius); !slices.Equal(got, want) {
+				t.Fatalf("Radius %v around %v: expected %d plants in slice order, got %d", radius, center, len(want), len(got))
+			}
+		}
+	}
+
+	slots := index.QueryRadiusSlots(entities[3].Position, 10)
+	if !slices.IsSorted(slots) || !slices.Contains(slots, 3) {
+		t.Errorf("Expected ascending slots including the creature queried around, got %v", slots)
+	}
+}
+
+func TestSpatialIndexFollowsTheWorld(t *testing.T) {
+	entities := []*Entity{
+		NewEntity(sharedRand, 1, []string{"size"}, "A", Position{X: 10, Y: 10}),
+		NewEntity(sharedRand, 2, []string{"size"}, "A", Position{X: 80, Y: 80}),
+	}
+	index := NewSpatialIndex()
+	index.Sync(1, entities, nil, BoxGeometry{})
+
+	// A creature appended during the tick is found without a rebuild
+	entities = append(entities, NewEntity(sharedRand, 3, []string{"size"}, "A", Position{X: 12, Y: 10}))
+	index.Sync(1, entities, nil, BoxGeometry{})
+	if found := index.QueryRadius(Position{X: 10, Y: 10}, 3); len(found) != 2 || found[1].ID != 3 {
+		t.Fatalf("Expected the appended creature found, got %d creatures", len(found))
+	}
+
+	// Creatures that moved a little since they were indexed are still found where they are
+	entities[1].Position = Position{X: 74, Y: 80}
+	if found := index.QueryRadius(Position{X: 70, Y: 80}, 5); len(found) != 1 || found[0].ID != 2 {
+		t.Errorf("Expected a creature that moved into range found, got %d creatures", len(found))
+	}
+	if found := index.QueryRadius(Position{X: 80, Y: 80}, 5); len(found) != 0 {
+		t.Errorf("Expected a creature that moved out of range left out, got %d creatures", len(found))
+	}
+
+	// A new tick, or a replaced slice, rebuilds the index
+	entities[1].Position = Position{X: 20, Y: 20}
+	index.Sync(2, entities, nil, BoxGeometry{})
+	if found := index.QueryRadius(Position{X: 20, Y: 20}, 1); len(found) != 1 || found[0].ID != 2 {
+		t.Errorf("Expected the index rebuilt on a new tick, got %d creatures", len(found))
+	}
+	survivors := []*Entity{entities[0], entities[2]}
+	index.Sync(2, survivors, nil, BoxGeometry{})
+	if found := index.QueryRadius(Position{X: 10, Y: 10}, 3); !slices.Equal(found, survivors) {
+		t.Errorf("Expected the index rebuilt from the replaced slice, got %d creatures", len(found))
+	}
+}
+
+func TestWorldProximityQueriesMatchLinearScans(t *testing.T) {
+	world := NewWorld(WorldConfig{Width: 100, Height: 100, GridWidth: 40, GridHeight: 25, PopulationSize: 60})
+	for i := 0; i < 30; i++ {
+		world.Update()
+	}
+
+	index := world.spatialIndex()
+	for _, entity := range world.AllEntities {
+		if got, want := index.QueryRadius(entity.Position, 10), scanEntities(world.AllEntities, entity.Position, 10); !slices.Equal(got, want) {
+			t.Fatalf("Expected %d creatures near entity %d, got %d", len(want), entity.ID, len(got))
+		}
+		if got, want := index.QueryPlantRadius(entity.Position, 15), scanPlants(world.AllPlants, entity.Position, 15); !slices.Equal(got, want) {
+			t.Fatalf("Expected %d plants near entity %d, got %d", len(want), entity.ID, len(got))
+		}
+	}
+}
+
+func TestAttractionStopsAtItsRange(t *testing.T) {
+	system := NewPhysicsSystem()
+	predator := NewEntity(sharedRand, 1, []string{"aggression", "size"}, "A", Position{X: 0, Y: 0})
+	predator.SetTrait("aggression", 1)
+	near := NewEntity(sharedRand, 2, []string{"aggression", "size"}, "B", Position{X: system.AttractionRange - 1, Y: 0})
+	far := NewEntity(sharedRand, 3, []string{"aggression", "size"}, "B", Position{X: system.AttractionRange + 1, Y: 0})
+	for _, entity := range []*Entity{predator, near, far} {
+		entity.SetTrait("size", 0)
+	}
+	physics := NewPhysicsComponent(predator)
+
+	if force := system.CalculateAttraction(predator, near, physics, NewPhysicsComponent(near), BoxGeometry{}); force.X <= 0 {
+		t.Errorf("Expected a predator pulled toward prey in range, got %v", force)
+	}
+	if force := system.CalculateAttraction(predator, far, physics, NewPhysicsComponent(far), BoxGeometry{}); force != (Vector2D{}) {
+		t.Errorf("Expected no force from prey out of range, got %v", force)
+	}
+	system.AttractionRange = 0
+	if force := system.CalculateAttraction(predator, far, physics, NewPhysicsComponent(far), BoxGeometry{}); force.X <= 0 {
+		t.Errorf("Expected no range to leave attraction unlimited, got %v", force)
+	}
+}
@@ -20,9 +20,11 @@ type Species struct {
 	ExtinctionTick  int                `json:"extinction_tick"` // 0 if still alive
 	ExtinctionTime  time.Time          `json:"extinction_time"`
 	IsExtinct       bool               `json:"is_extinct"`
-	ParentSpeciesID int                `json:"parent_species_id"` // Species this split from
-	ChildSpeciesIDs []int              `json:"child_species_ids"` // Species that split from this
-	TotalMembers    int                `json:"total_members"`     // Historical count
+	ParentSpeciesID int                `json:"parent_species_id"`         // Species this split from
+	ChildSpeciesIDs []int              `json:"child_species_ids"`         // Species that split from this
+	AncestorTraits  map[string]float64 `json:"ancestor_traits,omitempty"` // Parent's average traits at the split
+	SplitDistance   float64            `json:"split_distance"`            // Genetic distance from the parent at the split
+	TotalMembers    int                `json:"total_members"`             // Historical count
 	PeakPopulation  int                `json:"peak_population"`
 	PeakTick        int                `json:"peak_tick"`
 }
@@ -98,7 +100,7 @@ func (ss *SpeciationSystem) Update(allPlants []*Plant, tick int) {
 	}
 
 	// Group living plants by species
-	ss.assignPlantsToSpecies(allPlants)
+	ss.assignPlantsToSpecies(allPlants, tick)
 
 	// Look for new species formation (speciation events)
 	ss.checkForSpeciation(tick)
@@ -118,7 +120,7 @@ func (ss *SpeciationSystem) Update(allPlants []*Plant, tick int) {
 }
 
 // assignPlantsToSpecies groups plants into species based on genetic similarity
-func (ss *SpeciationSystem) assignPlantsToSpecies(allPlants []*Plant) {
+func (ss *SpeciationSystem) assignPlantsToSpecies(allPlants []*Plant, tick int) {
 	unassignedPlants := make([]*Plant, 0)
 
 	// First pass: assign plants to existing species
@@ -131,8 +133,9 @@ func (ss *SpeciationSystem) assignPlantsToSpecies(allPlants []*Plant) {
 		bestSpecies := (*Species)(nil)
 		bestDistance := math.Inf(1)
 
-		// Find closest species
-		for _, species := range ss.ActiveSpecies {
+		// Find closest species (in ID order so ties resolve the same way every run)
+		for _, speciesID := range sortedKeys(ss.ActiveSpecies) {
+			species := ss.ActiveSpecies[speciesID]
 			distance := ss.calculateGeneticDistance(plant, species.CurrentTraits)
 			if distance < ss.GeneticDistanceThreshold && distance < bestDistance {
 				bestDistance = distance
@@ -152,20 +155,21 @@ func (ss *SpeciationSystem) assignPlantsToSpecies(allPlants []*Plant) {
 
 	// Second pass: group unassigned plants into new species
 	if len(unassignedPlants) >= ss.MinPopulationForSpecies {
-		ss.formNewSpeciesFromUnassigned(unassignedPlants)
+		ss.formNewSpeciesFromUnassigned(unassignedPlants, tick)
 	}
 }
 
 // formNewSpeciesFromUnassigned creates new species from genetically similar unassigned plants
-func (ss *SpeciationSystem) formNewSpeciesFromUnassigned(unassignedPlants []*Plant) {
+func (ss *SpeciationSystem) formNewSpeciesFromUnassigned(unassignedPlants []*Plant, tick int) {
 	// Group unassigned plants by plant type first
 	typeGroups := make(map[PlantType][]*Plant)
 	for _, plant := range unassignedPlants {
 		typeGroups[plant.Type] = append(typeGroups[plant.Type], plant)
 	}
 
-	// For each type group, form species based on genetic similarity
-	for plantType, plants := range typeGroups {
+	// For each type group, form species based on genetic similarity (in type order so species IDs are reproducible)
+	for _, plantType := range sortedKeys(typeGroups) {
+		plants := typeGroups[plantType]
 		if len(plants) < ss.MinPopulationForSpecies {
 			continue
 		}
@@ -175,7 +179,8 @@ func (ss *SpeciationSystem) formNewSpeciesFromUnassigned(unassignedPlants []*Pla
 
 		for _, cluster := range clusters {
 			if len(cluster) >= ss.MinPopulationForSpecies {
-				ss.createNewSpecies(cluster, plantType, "initial_population")
+				species := ss.createNewSpecies(cluster, plantType, "initial_population")
+				species.FormationTick = tick
 			}
 		}
 	}
@@ -225,7 +230,8 @@ func (ss *SpeciationSystem) calculateGeneticDistance(plant *Plant, speciesTraits
 	totalDistance := 0.0
 	traitCount := 0
 
-	for traitName, speciesValue := range speciesTraits {
+	for _, traitName := range sortedKeys(speciesTraits) {
+		speciesValue := speciesTraits[traitName]
 		plantValue := plant.GetTrait(traitName)
 		distance := math.Abs(plantValue - speciesValue)
 		totalDistance += distance * distance // Squared distance for Euclidean
@@ -253,7 +259,7 @@ func (ss *SpeciationSystem) calculateGeneticDistanceBetweenPlants(plant1, plant2
 		allTraits[traitName] = true
 	}
 
-	for traitName := range allTraits {
+	for _, traitName := range sortedKeys(allTraits) {
 		value1 := plant1.GetTrait(traitName)
 		value2 := plant2.GetTrait(traitName)
 		distance := math.Abs(value1 - value2)
@@ -372,6 +378,8 @@ func (ss *SpeciationSystem) checkSpeciesForSplit(species *Species, tick int) {
 			newSpecies := ss.createNewSpecies(cluster, species.OriginPlantType, "genetic_divergence")
 			newSpecies.FormationTick = tick
 			newSpecies.ParentSpeciesID = species.ID
+			newSpecies.AncestorTraits = speciesAverage
+			newSpecies.SplitDistance = distance
 
 			// Update parent species
 			species.ChildSpeciesIDs = append(species.ChildSpeciesIDs, newSpecies.ID)
@@ -403,7 +411,8 @@ func (ss *SpeciationSystem) calculateTraitDistance(traits1, traits2 map[string]f
 	traitCount := 0
 
 	// Compare common traits
-	for traitName, value1 := range traits1 {
+	for _, traitName := range sortedKeys(traits1) {
+		value1 := traits1[traitName]
 		if value2, exists := traits2[traitName]; exists {
 			distance := math.Abs(value1 - value2)
 			totalDistance += distance * distance
@@ -607,7 +616,7 @@ func (ss *SpeciationSystem) calculateGeneticDistanceFromTraits(plantTraits map[s
 		allTraits[traitName] = true
 	}
 
-	for traitName := range allTraits {
+	for _, traitName := range sortedKeys(allTraits) {
 		var value1, value2 float64
 
 		if trait, exists := plantTraits[traitName]; exists {
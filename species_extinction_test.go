@@ -21,7 +21,7 @@ func TestSpeciesExtinctionBehavior(t *testing.T) {
 	for i := 0; i < 5; i++ {
 		x := float64(i % 5)
 		y := float64(i / 5)
-		plant := NewPlant(i, PlantGrass, Position{X: x, Y: y})
+		plant := NewPlant(sharedRand, i, PlantGrass, Position{X: x, Y: y})
 		world.AllPlants = append(world.AllPlants, plant)
 		world.NextPlantID = i + 1
 	}
@@ -71,7 +71,7 @@ func TestViewManagerWithEmptySpecies(t *testing.T) {
 
 	// Add some test plants manually
 	for i := 0; i < 5; i++ {
-		plant := NewPlant(i, PlantGrass, Position{X: float64(i), Y: 0})
+		plant := NewPlant(sharedRand, i, PlantGrass, Position{X: float64(i), Y: 0})
 		world.AllPlants = append(world.AllPlants, plant)
 		world.NextPlantID = i + 1
 	}
@@ -21,6 +21,8 @@ type SpeciesNaming struct {
 	// Name registry to track used names and evolutionary relationships
 	nameRegistry map[string]*SpeciesNameInfo
 	nextID       int
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // SpeciesNameInfo contains information about a species name
@@ -65,6 +67,7 @@ func NewSpeciesNaming() *SpeciesNaming {
 		},
 		nameRegistry: make(map[string]*SpeciesNameInfo),
 		nextID:       1,
+		rng:          sharedRand,
 	}
 }
 
@@ -103,14 +106,14 @@ func (sn *SpeciesNaming) GenerateSpeciesName(species string, parentName string,
 			baseName = fmt.Sprintf("%s %s", baseCommon, suffix)
 		} else {
 			// Fallback if parent not found
-			baseName = namePool[rand.Intn(len(namePool))]
+			baseName = namePool[sn.rng.Intn(len(namePool))]
 			if generation > 0 {
 				baseName += fmt.Sprintf(" Gen%d", generation)
 			}
 		}
 	} else {
 		// Original species - use base names
-		baseName = namePool[rand.Intn(len(namePool))]
+		baseName = namePool[sn.rng.Intn(len(namePool))]
 	}
 
 	// Create scientific name (Genus species format)
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// policyAdaptationRate is how far a species' effective policy moves toward the player's
+// target each tick, so a new policy reshapes behavior over ~100 ticks rather than at once
+const policyAdaptationRate = 0.01
+
+// PolicySettings are species-wide behavior biases. Each ranges from -1 to 1; 0 leaves the
+// species' own neural and biorhythm decisions unchanged.
+type PolicySettings struct {
+	Aggression   float64 `json:"aggression"`   // -1 avoids fights and takes threats seriously, 1 seeks fights
+	Exploration  float64 `json:"exploration"`  // -1 favors safety and rest, 1 favors roaming
+	Reproduction float64 `json:"reproduction"` // -1 conserves energy, 1 invests it in offspring
+}
+
+// SpeciesPolicy is a player's standing policy for one species
+type SpeciesPolicy struct {
+	Species   string         `json:"species"`
+	PlayerID  string         `json:"player_id"`
+	Target    PolicySettings `json:"target"`    // What the player asked for
+	Effective PolicySettings `json:"effective"` // What currently biases decisions; eases toward Target
+	SetTick   int            `json:"set_tick"`
+}
+
+// SpeciesPolicySystem holds the persistent policies players have set for their species
+type SpeciesPolicySystem struct {
+	mu       sync.RWMutex
+	policies map[string]*SpeciesPolicy
+}
+
+// NewSpeciesPolicySystem creates a policy system with no policies set
+func NewSpeciesPolicySystem() *SpeciesPolicySystem {
+	return &SpeciesPolicySystem{policies: make(map[string]*SpeciesPolicy)}
+}
+
+// SetPolicy sets the target policy of a species. The effective policy keeps its current
+// values and adapts toward the new target on subsequent updates.
+func (ps *SpeciesPolicySystem) SetPolicy(species, playerID string, settings PolicySettings, tick int) (SpeciesPolicy, error) {
+	if species == "" {
+		return SpeciesPolicy{}, fmt.Errorf("species is required")
+	}
+	names := []string{"aggression", "exploration", "reproduction"}
+	for i, value := range []float64{settings.Aggression, settings.Exploration, settings.Reproduction} {
+		if isInvalidNumber(value) || value < -1 || value > 1 {
+			return SpeciesPolicy{}, fmt.Errorf("%s must be between -1 and 1", names[i])
+		}
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	policy, exists := ps.policies[species]
+	if !exists {
+		policy = &SpeciesPolicy{Species: species}
+		ps.policies[species] = policy
+	}
+	policy.PlayerID = playerID
+	policy.Target = settings
+	policy.SetTick = tick
+	return *policy, nil
+}
+
+// Policy returns the policy of a species
+func (ps *SpeciesPolicySystem) Policy(species string) (SpeciesPolicy, bool) {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+	policy, exists := ps.policies[species]
+	if !exists {
+		return SpeciesPolicy{}, false
+	}
+	return *policy, true
+}
+
+// Policies returns all policies, or only those of the given player, sorted by species
+func (ps *SpeciesPolicySystem) Policies(playerID string) []SpeciesPolicy {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+	policies := make([]SpeciesPolicy, 0, len(ps.policies))
+	for _, policy := range ps.policies {
+		if playerID == "" || policy.PlayerID == playerID {
+			policies = append(policies, *policy)
+		}
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Species < policies[j].Species
+	})
+	return policies
+}
+
+// Inherit gives a newly split subspecies the policy of its parent, including how far the
+// parent has already adapted to it
+func (ps *SpeciesPolicySystem) Inherit(parent, child string) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	policy, exists := ps.policies[parent]
+	if !exists {
+		return
+	}
+	inherited := *policy
+	inherited.Species = child
+	ps.policies[child] = &inherited
+}
+
+// Reassign hands a species' policy to its new owner, e.g. after it was gifted
+func (ps *SpeciesPolicySystem) Reassign(species, playerID string) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if policy, exists := ps.policies[species]; exists {
+		policy.PlayerID = playerID
+	}
+}
+
+// Clear removes every policy
+func (ps *SpeciesPolicySystem) Clear() {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.policies = make(map[string]*SpeciesPolicy)
+}
+
+// Update moves every effective policy one step toward its target
+func (ps *SpeciesPolicySystem) Update() {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	for _, policy := range ps.policies {
+		policy.Effective.Aggression = approachValue(policy.Effective.Aggression, policy.Target.Aggression, policyAdaptationRate)
+		policy.Effective.Exploration = approachValue(policy.Effective.Exploration, policy.Target.Exploration, policyAdaptationRate)
+		policy.Effective.Reproduction = approachValue(policy.Effective.Reproduction, policy.Target.Reproduction, policyAdaptationRate)
+	}
+}
+
+// approachValue moves current toward target by at most step
+func approachValue(current, target, step float64) float64 {
+	if math.Abs(target-current) <= step {
+		return target
+	}
+	if target > current {
+		return current + step
+	}
+	return current - step
+}
+
+// biasNeuralInputs changes how a species perceives threats before its network decides:
+// aggressive species shrug threats off, cautious ones react to smaller threats
+func (p PolicySettings) biasNeuralInputs(inputs []float64) {
+	if len(inputs) < 3 {
+		return
+	}
+	inputs[2] = math.Max(0, math.Min(1, inputs[2]*(1-0.5*p.Aggression)))
+}
+
+// biasNeuralOutputs shifts the action intensity of a network decision: exploring species
+// move more, safety-minded ones stay put
+func (p PolicySettings) biasNeuralOutputs(outputs []float64) {
+	if len(outputs) < 3 {
+		return
+	}
+	outputs[2] = math.Max(0, math.Min(1, outputs[2]+0.3*p.Exploration))
+}
+
+// biasActivityNeeds nudges the biorhythm needs that pick an entity's next activity
+func (p PolicySettings) biasActivityNeeds(br *BioRhythm) {
+	adjust := func(activity ActivityType, delta float64) {
+		if state, exists := br.Activities[activity]; exists {
+			state.NeedLevel = math.Max(0, math.Min(1, state.NeedLevel+delta))
+		}
+	}
+	adjust(ActivityExplore, p.Exploration*0.0015)
+	adjust(ActivityRest, -p.Exploration*0.0005)
+	adjust(ActivitySocialize, p.Reproduction*0.0008)
+	adjust(ActivityEat, p.Reproduction*0.0005) // Investing in offspring takes extra food
+}
+
+// reproductionChance scales the per-tick chance of seeking a mate
+func (p PolicySettings) reproductionChance(base float64) float64 {
+	return base * (1 + 0.5*p.Reproduction)
+}
+
+// reproductionEnergyThreshold scales the energy a creature keeps in reserve before mating
+func (p PolicySettings) reproductionEnergyThreshold(base float64) float64 {
+	return base * (1 - 0.3*p.Reproduction)
+}
+
+// killChance scales the chance of pressing an attack the creature could win
+func (p PolicySettings) killChance(base float64) float64 {
+	return base * (1 + 0.5*p.Aggression)
+}
+
+// speciesPolicy returns the effective policy of a species, if a player has set one
+func (w *World) speciesPolicy(species string) (PolicySettings, bool) {
+	if w.SpeciesPolicies == nil {
+		return PolicySettings{}, false
+	}
+	policy, exists := w.SpeciesPolicies.Policy(species)
+	return policy.Effective, exists
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSpeciesPolicyAdaptsOverTime(t *testing.T) {
+	policies := NewSpeciesPolicySystem()
+	if _, err := policies.SetPolicy("mine", "p1", PolicySettings{Aggression: 1.5}, 0); err == nil {
+		t.Error("Expected an out-of-range setting to be rejected")
+	}
+	if _, err := policies.SetPolicy("mine", "p1", PolicySettings{Exploration: math.NaN()}, 0); err == nil {
+		t.Error("Expected a NaN setting to be rejected")
+	}
+
+	if _, err := policies.SetPolicy("mine", "p1", PolicySettings{Aggression: 1, Exploration: -0.5}, 3); err != nil {
+		t.Fatal(err)
+	}
+	for tick := 0; tick < 50; tick++ {
+		policies.Update()
+	}
+	policy, _ := policies.Policy("mine")
+	if math.Abs(policy.Effective.Aggression-0.5) > 1e-9 || math.Abs(policy.Effective.Exploration+0.5) > 1e-9 {
+		t.Fatalf("Expected aggression halfway and exploration fully adapted after 50 ticks, got %+v", policy.Effective)
+	}
+
+	// A subspecies keeps its parent's policy and progress
+	policies.Inherit("mine", "mine_split")
+	policies.Inherit("unknown", "other")
+	if all := policies.Policies("p1"); len(all) != 2 || all[1].Species != "mine_split" || all[1].Effective != policy.Effective {
+		t.Errorf("Expected the subspecies to inherit the policy, got %+v", all)
+	}
+	if len(policies.Policies("p2")) != 0 {
+		t.Error("Expected no policies for another player")
+	}
+
+	// Changing the target starts from what the species has already adapted to
+	if _, err := policies.SetPolicy("mine", "p1", PolicySettings{}, 60); err != nil {
+		t.Fatal(err)
+	}
+	policies.Update()
+	policy, _ = policies.Policy("mine")
+	if math.Abs(policy.Effective.Aggression-0.49) > 1e-9 {
+		t.Errorf("Expected aggression to ease back gradually, got %.3f", policy.Effective.Aggression)
+	}
+}
+
+func TestSpeciesPolicyBiasesDecisions(t *testing.T) {
+	world := newGroupTestWorld(&Entity{ID: 1, Species: "mine"}, &Entity{ID: 2, Species: "theirs"})
+	mine, theirs := world.AllEntities[0], world.AllEntities[1]
+	if world.killChance(mine) != 0.1 {
+		t.Fatalf("Expected the default kill chance without a policy, got %.3f", world.killChance(mine))
+	}
+
+	if _, err := world.SpeciesPolicies.SetPolicy("mine", "p1", PolicySettings{Aggression: 1, Exploration: 1, Reproduction: -1}, 0); err != nil {
+		t.Fatal(err)
+	}
+	world.SpeciesPolicies.Update()
+	if world.killChance(mine) <= 0.1 || world.killChance(theirs) != 0.1 {
+		t.Errorf("Expected only the policy's species to press attacks more, got %.3f and %.3f", world.killChance(mine), world.killChance(theirs))
+	}
+
+	for tick := 0; tick < 100; tick++ {
+		world.SpeciesPolicies.Update()
+	}
+	policy, _ := world.speciesPolicy("mine")
+	inputs := []float64{0, 0, 0.8, 0, 0}
+	policy.biasNeuralInputs(inputs)
+	outputs := []float64{0, 0, 0.5}
+	policy.biasNeuralOutputs(outputs)
+	if inputs[2] >= 0.8 || outputs[2] <= 0.5 {
+		t.Errorf("Expected aggression to dampen threats and exploration to raise intensity, got %.2f and %.2f", inputs[2], outputs[2])
+	}
+	if policy.reproductionChance(0.1) >= 0.1 || policy.reproductionEnergyThreshold(30) <= 30 {
+		t.Error("Expected a negative reproduction investment to mate less and keep more energy")
+	}
+
+	mine.BioRhythm = NewBioRhythm(sharedRand, mine.ID, mine)
+	explore := mine.BioRhythm.Activities[ActivityExplore].NeedLevel
+	socialize := mine.BioRhythm.Activities[ActivitySocialize].NeedLevel
+	policy.biasActivityNeeds(mine.BioRhythm)
+	if mine.BioRhythm.Activities[ActivityExplore].NeedLevel <= explore ||
+		(socialize > 0 && mine.BioRhythm.Activities[ActivitySocialize].NeedLevel >= socialize) {
+		t.Errorf("Expected exploration needs to rise and social needs to fall, got %+v", mine.BioRhythm.Activities)
+	}
+}
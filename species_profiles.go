@@ -0,0 +1,278 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Kinds of species
+const (
+	SpeciesKindCreature = "creature"
+	SpeciesKindPlant    = "plant"
+)
+
+// Ways a species is related to another
+const (
+	RelationParent   = "parent"   // The species it split from
+	RelationDaughter = "daughter" // A species that split from it
+	RelationNearest  = "nearest"  // The living species of its kind with the closest traits
+)
+
+// SpeciesProfile is a species as the Species tab shows it: its living members, how their
+// traits are spread and how far it lies from its relatives
+type SpeciesProfile struct {
+	Name           string                       `json:"name"`
+	Kind           string                       `json:"kind"`         // creature or plant
+	ID             int                          `json:"id,omitempty"` // Plant species number, also used for its sprite
+	Population     int                          `json:"population"`
+	PeakPopulation int                          `json:"peak_population"`
+	Extinct        bool                         `json:"extinct"`
+	FormationTick  int                          `json:"formation_tick"`
+	ExtinctionTick int                          `json:"extinction_tick,omitempty"`
+	Traits         map[string]TraitDistribution `json:"traits"` // Over the living members
+	Relatives      []SpeciesRelative            `json:"relatives"`
+	MemberIDs      []int                        `json:"member_ids"` // Living members, creatures or plants by kind
+}
+
+// TraitDistribution is how one trait is spread over a species' living members
+type TraitDistribution struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"std_dev"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+}
+
+// SpeciesRelative is a related species and the genetic distance to it: the root mean
+// square difference of the two species' average traits
+type SpeciesRelative struct {
+	Name     string  `json:"name"`
+	Relation string  `json:"relation"`
+	Distance float64 `json:"distance"`
+}
+
+// speciesSample is the living members of a species and their traits, gathered once so
+// relatives can be compared
+type speciesSample struct {
+	profile   SpeciesProfile
+	means     map[string]float64 // Average traits, or the last known ones once extinct
+	parent    string
+	daughters []string
+}
+
+// SpeciesProfiles profiles every creature and plant species, living species first and the
+// most populous first among them
+func (w *World) SpeciesProfiles() []SpeciesProfile {
+	samples := w.speciesSamples()
+	profiles := make([]SpeciesProfile, 0, len(samples))
+	for _, sample := range samples {
+		profiles = append(profiles, w.relate(sample, samples))
+	}
+	sort.SliceStable(profiles, func(i, j int) bool {
+		if profiles[i].Extinct != profiles[j].Extinct {
+			return !profiles[i].Extinct
+		}
+		if profiles[i].Population != profiles[j].Population {
+			return profiles[i].Population > profiles[j].Population
+		}
+		return profiles[i].Name < profiles[j].Name
+	})
+	return profiles
+}
+
+// SpeciesProfile profiles one creature or plant species by name
+func (w *World) SpeciesProfile(name string) (SpeciesProfile, error) {
+	samples := w.speciesSamples()
+	for _, sample := range samples {
+		if sample.profile.Name == name {
+			return w.relate(sample, samples), nil
+		}
+	}
+	return SpeciesProfile{}, fmt.Errorf("unknown species %q", name)
+}
+
+// speciesSamples gathers the creature species, from the populations and lineages as well
+// as the living creatures, and the plant species the speciation system tracks
+func (w *World) speciesSamples() []*speciesSample {
+	samples := make([]*speciesSample, 0)
+
+	members := make(map[string][]*Entity)
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive {
+			members[entity.Species] = append(members[entity.Species], entity)
+		}
+	}
+	names := make(map[string]bool)
+	for name := range members {
+		names[name] = true
+	}
+	for name := range w.Populations {
+		names[name] = true
+	}
+	var lineages map[string]*SpeciesLineage
+	if w.MacroEvolutionSystem != nil {
+		lineages = w.MacroEvolutionSystem.SpeciesLineages
+	}
+	for name := range lineages {
+		// Lineages are kept for plant species too
+		if w.SpeciationSystem == nil || !w.isPlantSpeciesName(name) {
+			names[name] = true
+		}
+	}
+	for _, name := range sortedKeys(names) {
+		living := members[name]
+		values := make(map[string][]float64)
+		sample := &speciesSample{profile: SpeciesProfile{
+			Name:       name,
+			Kind:       SpeciesKindCreature,
+			Population: len(living),
+			Extinct:    len(living) == 0,
+			MemberIDs:  make([]int, 0, len(living)),
+		}}
+		for _, entity := range living {
+			sample.profile.MemberIDs = append(sample.profile.MemberIDs, entity.ID)
+			for trait := range entity.Traits {
+				values[trait] = append(values[trait], entity.GetTrait(trait))
+			}
+		}
+		sample.profile.Traits, sample.means = traitDistributions(values)
+		sample.profile.PeakPopulation = len(living)
+		if lineage, exists := lineages[name]; exists {
+			sample.profile.FormationTick = lineage.OriginTick
+			sample.profile.ExtinctionTick = lineage.ExtinctionTick
+			sample.profile.PeakPopulation = max(lineage.PeakPopulation, len(living))
+			sample.parent, sample.daughters = lineage.ParentSpecies, lineage.ChildSpecies
+			if len(living) == 0 {
+				sample.means = lineage.DominantTraits
+			}
+		}
+		samples = append(samples, sample)
+	}
+
+	if w.SpeciationSystem != nil {
+		for _, id := range sortedKeys(w.SpeciationSystem.AllSpecies) {
+			species := w.SpeciationSystem.AllSpecies[id]
+			values := make(map[string][]float64)
+			sample := &speciesSample{profile: SpeciesProfile{
+				Name:           species.Name,
+				Kind:           SpeciesKindPlant,
+				ID:             species.ID,
+				PeakPopulation: species.PeakPopulation,
+				Extinct:        species.IsExtinct,
+				FormationTick:  species.FormationTick,
+				ExtinctionTick: species.ExtinctionTick,
+				MemberIDs:      make([]int, 0, len(species.Members)),
+			}}
+			for _, plant := range species.Members {
+				if !plant.IsAlive {
+					continue
+				}
+				sample.profile.MemberIDs = append(sample.profile.MemberIDs, plant.ID)
+				for trait, value := range plant.Traits {
+					values[trait] = append(values[trait], value.Value)
+				}
+			}
+			sample.profile.Population = len(sample.profile.MemberIDs)
+			sample.profile.Traits, sample.means = traitDistributions(values)
+			if len(sample.means) == 0 {
+				sample.means = species.CurrentTraits
+			}
+			if parent, exists := w.SpeciationSystem.AllSpecies[species.ParentSpeciesID]; exists && species.ParentSpeciesID != species.ID {
+				sample.parent = parent.Name
+			}
+			for _, child := range species.ChildSpeciesIDs {
+				if daughter, exists := w.SpeciationSystem.AllSpecies[child]; exists {
+					sample.daughters = append(sample.daughters, daughter.Name)
+				}
+			}
+			samples = append(samples, sample)
+		}
+	}
+	return samples
+}
+
+// isPlantSpeciesName reports whether a name belongs to a plant species
+func (w *World) isPlantSpeciesName(name string) bool {
+	for _, species := range w.SpeciationSystem.AllSpecies {
+		if species.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// relate fills in a species' relatives: its parent and daughters, then the living species
+// of its kind nearest in its traits if that is another
+func (w *World) relate(sample *speciesSample, samples []*speciesSample) SpeciesProfile {
+	profile := sample.profile
+	profile.Relatives = make([]SpeciesRelative, 0)
+	byName := make(map[string]*speciesSample)
+	for _, other := range samples {
+		if other.profile.Kind == profile.Kind {
+			byName[other.profile.Name] = other
+		}
+	}
+
+	listed := map[string]bool{profile.Name: true}
+	add := func(name, relation string) {
+		other, exists := byName[name]
+		if !exists || listed[name] {
+			return
+		}
+		distance := speciesTraitDistance(sample.means, other.means)
+		if math.IsInf(distance, 1) {
+			return // Nothing to compare them by
+		}
+		listed[name] = true
+		profile.Relatives = append(profile.Relatives, SpeciesRelative{Name: name, Relation: relation, Distance: distance})
+	}
+	add(sample.parent, RelationParent)
+	for _, daughter := range sample.daughters {
+		add(daughter, RelationDaughter)
+	}
+
+	nearest, nearestDistance := "", math.Inf(1)
+	for _, other := range samples {
+		if other.profile.Kind != profile.Kind || other.profile.Extinct || other.profile.Name == profile.Name {
+			continue
+		}
+		if distance := speciesTraitDistance(sample.means, other.means); distance < nearestDistance {
+			nearest, nearestDistance = other.profile.Name, distance
+		}
+	}
+	add(nearest, RelationNearest)
+	return profile
+}
+
+// traitDistributions spreads each trait's values into a distribution and its mean
+func traitDistributions(values map[string][]float64) (map[string]TraitDistribution, map[string]float64) {
+	distributions := make(map[string]TraitDistribution, len(values))
+	means := make(map[string]float64, len(values))
+	for trait, series := range values {
+		distribution := TraitDistribution{Mean: meanOf(series), StdDev: stdDevOf(series), Min: series[0], Max: series[0]}
+		for _, value := range series {
+			distribution.Min = math.Min(distribution.Min, value)
+			distribution.Max = math.Max(distribution.Max, value)
+		}
+		distributions[trait] = distribution
+		means[trait] = distribution.Mean
+	}
+	return distributions, means
+}
+
+// speciesTraitDistance is the root mean square difference of the traits two species share,
+// as the speciation system measures genetic distance; species sharing no traits are
+// infinitely far apart
+func speciesTraitDistance(a, b map[string]float64) float64 {
+	total, shared := 0.0, 0
+	for _, trait := range sortedKeys(a) {
+		if other, exists := b[trait]; exists {
+			total += (a[trait] - other) * (a[trait] - other)
+			shared++
+		}
+	}
+	if shared == 0 {
+		return math.Inf(1)
+	}
+	return math.Sqrt(total / float64(shared))
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestSpeciesProfiles(t *testing.T) {
+	world := newSteppingTestWorld(118)
+	if _, err := world.Step(20); err != nil {
+		t.Fatal(err)
+	}
+
+	living := make(map[string]int)
+	for _, entity := range world.AllEntities {
+		if entity.IsAlive {
+			living[entity.Species]++
+		}
+	}
+	profiles := world.SpeciesProfiles()
+	creatures, plants := 0, 0
+	for _, profile := range profiles {
+		switch profile.Kind {
+		case SpeciesKindCreature:
+			creatures++
+			if profile.Population != living[profile.Name] || len(profile.MemberIDs) != profile.Population {
+				t.Errorf("Expected %s to count its %d living members, got %d", profile.Name, living[profile.Name], profile.Population)
+			}
+		case SpeciesKindPlant:
+			plants++
+		}
+		for name, trait := range profile.Traits {
+			if trait.Mean < trait.Min || trait.Mean > trait.Max || trait.StdDev < 0 {
+				t.Errorf("Expected %s's %s spread around its mean, got %+v", profile.Name, name, trait)
+			}
+		}
+		for _, relative := range profile.Relatives {
+			if relative.Name == profile.Name || relative.Distance < 0 {
+				t.Errorf("Expected %s related to other species, got %+v", profile.Name, relative)
+			}
+		}
+	}
+	if creatures < len(living) || plants == 0 {
+		t.Fatalf("Expected creature and plant species profiled, got %d and %d", creatures, plants)
+	}
+	if profiles[0].Extinct || profiles[0].Population == 0 {
+		t.Error("Expected living species listed first")
+	}
+	if len(living) > 1 && len(profiles[0].Relatives) == 0 {
+		t.Error("Expected the nearest living species of the same kind listed as a relative")
+	}
+	if _, err := world.SpeciesProfile("nobody"); err == nil {
+		t.Error("Expected an unknown species refused")
+	}
+}
+
+func TestSpeciesAPI(t *testing.T) {
+	world := newSteppingTestWorld(119)
+	routes := NewWebInterface(world).Routes()
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get("/api/species")
+	var profiles []SpeciesProfile
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &profiles) != nil || len(profiles) == 0 {
+		t.Fatalf("Expected a list of species, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	name := world.AllEntities[0].Species
+	rec = get("/api/species/" + url.PathEscape(name))
+	var profile SpeciesProfile
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &profile) != nil || profile.Name != name || len(profile.MemberIDs) == 0 {
+		t.Fatalf("Expected the profile of %s, got %d %s", name, rec.Code, rec.Body.String())
+	}
+	if rec := get("/api/species/nobody"); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected an unknown species not found, got %d", rec.Code)
+	}
+	if rec := get("/api/species/" + url.PathEscape(name) + "/sprite"); rec.Code != http.StatusOK {
+		t.Errorf("Expected sprites still served beside profiles, got %d", rec.Code)
+	}
+}
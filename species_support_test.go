@@ -282,7 +282,7 @@ func createTestWorld(t *testing.T) *World {
 
 func createTestEntity(world *World, t *testing.T) int {
 	// Create a test entity manually
-	entity := NewEntity(world.NextID, []string{"size", "strength", "intelligence", "energy", "metabolism"}, "test_species", Position{X: 50, Y: 50})
+	entity := NewEntity(world.Rand, world.NextID, []string{"size", "strength", "intelligence", "energy", "metabolism"}, "test_species", Position{X: 50, Y: 50})
 	world.NextID++
 
 	// Create DNA and cellular organism
@@ -335,21 +335,21 @@ func TestEnvironmentSpecificMovement(t *testing.T) {
 
 	// Test movement in different environments
 	waterStartPos := waterEntity.Position
-	waterEntity.MoveToWithEnvironment(15, 15, 1.0, BiomeWater)
+	waterEntity.MoveToWithEnvironment(15, 15, 1.0, BiomeWater, BoxGeometry{})
 	waterEnergyLoss := initialEnergy - waterEntity.Energy
 	waterDistanceMoved := math.Sqrt(math.Pow(waterEntity.Position.X-waterStartPos.X, 2) + math.Pow(waterEntity.Position.Y-waterStartPos.Y, 2))
 	waterEfficiency := waterDistanceMoved / waterEnergyLoss
 	t.Logf("Water movement: distance %.2f, energy loss %.2f, efficiency %.2f", waterDistanceMoved, waterEnergyLoss, waterEfficiency)
 
 	soilStartPos := soilEntity.Position
-	soilEntity.MoveToWithEnvironment(15, 15, 1.0, BiomeSoil)
+	soilEntity.MoveToWithEnvironment(15, 15, 1.0, BiomeSoil, BoxGeometry{})
 	soilEnergyLoss := initialEnergy - soilEntity.Energy
 	soilDistanceMoved := math.Sqrt(math.Pow(soilEntity.Position.X-soilStartPos.X, 2) + math.Pow(soilEntity.Position.Y-soilStartPos.Y, 2))
 	soilEfficiency := soilDistanceMoved / soilEnergyLoss
 	t.Logf("Soil movement: distance %.2f, energy loss %.2f, efficiency %.2f", soilDistanceMoved, soilEnergyLoss, soilEfficiency)
 
 	airStartPos := airEntity.Position
-	airEntity.MoveToWithEnvironment(15, 15, 1.0, BiomeAir)
+	airEntity.MoveToWithEnvironment(15, 15, 1.0, BiomeAir, BoxGeometry{})
 	airEnergyLoss := initialEnergy - airEntity.Energy
 	airDistanceMoved := math.Sqrt(math.Pow(airEntity.Position.X-airStartPos.X, 2) + math.Pow(airEntity.Position.Y-airStartPos.Y, 2))
 	airEfficiency := airDistanceMoved / airEnergyLoss
@@ -403,8 +403,8 @@ func TestAquaticAdaptation(t *testing.T) {
 		aquaticEntity.Energy = 100.0
 		landEntity.Energy = 100.0
 
-		aquaticEntity.MoveRandomlyWithEnvironment(5.0, BiomeWater)
-		landEntity.MoveRandomlyWithEnvironment(5.0, BiomeWater)
+		aquaticEntity.MoveRandomlyWithEnvironment(sharedRand, 5.0, BiomeWater)
+		landEntity.MoveRandomlyWithEnvironment(sharedRand, 5.0, BiomeWater)
 
 		totalAquaticLoss += 100.0 - aquaticEntity.Energy
 		totalLandLoss += 100.0 - landEntity.Energy
@@ -459,8 +459,8 @@ func TestSoilDwellerAdaptation(t *testing.T) {
 		soilEntity.Energy = 100.0
 		surfaceEntity.Energy = 100.0
 
-		soilEntity.MoveRandomlyWithEnvironment(3.0, BiomeSoil)
-		surfaceEntity.MoveRandomlyWithEnvironment(3.0, BiomeSoil)
+		soilEntity.MoveRandomlyWithEnvironment(sharedRand, 3.0, BiomeSoil)
+		surfaceEntity.MoveRandomlyWithEnvironment(sharedRand, 3.0, BiomeSoil)
 
 		totalSoilLoss += 100.0 - soilEntity.Energy
 		totalSurfaceLoss += 100.0 - surfaceEntity.Energy
@@ -508,13 +508,13 @@ func TestAerialAdaptation(t *testing.T) {
 
 	// Test movement in air
 	flyingStartPos := flyingEntity.Position
-	flyingEntity.MoveRandomlyWithEnvironment(4.0, BiomeAir)
+	flyingEntity.MoveRandomlyWithEnvironment(sharedRand, 4.0, BiomeAir)
 	flyingEnergyLoss := initialEnergy - flyingEntity.Energy
 	flyingDistance := math.Sqrt(math.Pow(flyingEntity.Position.X-flyingStartPos.X, 2) + math.Pow(flyingEntity.Position.Y-flyingStartPos.Y, 2))
 	flyingEfficiency := flyingDistance / flyingEnergyLoss
 
 	groundStartPos := groundEntity.Position
-	groundEntity.MoveRandomlyWithEnvironment(4.0, BiomeAir)
+	groundEntity.MoveRandomlyWithEnvironment(sharedRand, 4.0, BiomeAir)
 	groundEnergyLoss := initialEnergy - groundEntity.Energy
 	groundDistance := math.Sqrt(math.Pow(groundEntity.Position.X-groundStartPos.X, 2) + math.Pow(groundEntity.Position.Y-groundStartPos.Y, 2))
 	groundEfficiency := groundDistance / groundEnergyLoss
@@ -22,7 +22,7 @@ func TestSpeciesTrackingIssue(t *testing.T) {
 		x := float64(i % 10)
 		y := float64(i / 10)
 		plantType := PlantType(i % int(PlantCactus+1)) // Cycle through plant types
-		plant := NewPlant(i, plantType, Position{X: x, Y: y})
+		plant := NewPlant(sharedRand, i, plantType, Position{X: x, Y: y})
 		world.AllPlants = append(world.AllPlants, plant)
 		world.NextPlantID = i + 1
 	}
@@ -89,7 +89,7 @@ func TestSpeciationSystemInitialization(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		x := float64(i % 5)
 		y := float64(i / 5)
-		plant := NewPlant(i, PlantGrass, Position{X: x, Y: y})
+		plant := NewPlant(sharedRand, i, PlantGrass, Position{X: x, Y: y})
 		world.AllPlants = append(world.AllPlants, plant)
 		world.NextPlantID = i + 1
 	}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Kinds of prediction question spectators can answer
+const (
+	PredictionLargest  = "largest"  // Which species will have the most living creatures
+	PredictionSurvives = "survives" // Which species will still be alive; every survivor counts
+)
+
+// Prediction round statuses
+const (
+	PredictionOpen      = "open"
+	PredictionResolved  = "resolved"
+	PredictionCancelled = "cancelled"
+)
+
+// Spectator prediction tuning
+const (
+	spectatorStartingPoints  = 100 // Points a spectator starts out with
+	defaultPredictionTicks   = 300 // Ticks from opening a round to resolving it
+	predictionBettingShare   = 0.5 // Share of a round during which predictions are accepted
+	maxPredictionRoundsKept  = 20  // Closed rounds kept for review
+	minPredictionOptionCount = 2
+)
+
+// Prediction is one spectator's stake on an option of a round
+type Prediction struct {
+	Spectator string `json:"spectator"`
+	Option    string `json:"option"`
+	Stake     int    `json:"stake"`
+	Payout    int    `json:"payout,omitempty"` // Points returned once the round is resolved
+	Tick      int    `json:"tick"`
+}
+
+// PredictionRound is a question about how species will fare, open to predictions until
+// BetsCloseTick and resolved from the world's populations at ResolveTick
+type PredictionRound struct {
+	ID            int          `json:"id"`
+	Question      string       `json:"question"`
+	Kind          string       `json:"kind"`
+	Options       []string     `json:"options"` // Species names
+	Trigger       string       `json:"trigger,omitempty"`
+	OpenTick      int          `json:"open_tick"`
+	BetsCloseTick int          `json:"bets_close_tick"`
+	ResolveTick   int          `json:"resolve_tick"`
+	Status        string       `json:"status"`
+	Outcome       []string     `json:"outcome,omitempty"` // Winning options
+	Pool          int          `json:"pool"`
+	Predictions   []Prediction `json:"predictions"`
+}
+
+// Spectator is a viewer's running tally of points and predictions
+type Spectator struct {
+	Name    string `json:"name"`
+	Points  int    `json:"points"`
+	Correct int    `json:"correct"`
+	Wrong   int    `json:"wrong"`
+}
+
+// SpectatorPredictionSystem lets viewers predict species outcomes for points. It only reads
+// the world and never changes the simulation.
+type SpectatorPredictionSystem struct {
+	mu         sync.Mutex
+	rounds     []*PredictionRound
+	spectators map[string]*Spectator
+	asked      map[*WorldEvent]bool // World events a round was already opened for
+	nextID     int
+	eventBus   *CentralEventBus
+}
+
+// NewSpectatorPredictionSystem creates a prediction system with no rounds or spectators
+func NewSpectatorPredictionSystem(eventBus *CentralEventBus) *SpectatorPredictionSystem {
+	return &SpectatorPredictionSystem{
+		spectators: make(map[string]*Spectator),
+		asked:      make(map[*WorldEvent]bool),
+		nextID:     1,
+		eventBus:   eventBus,
+	}
+}
+
+// Open starts a prediction round. Without options every living species is an option; without
+// a question one is worded from the kind.
+func (ps *SpectatorPredictionSystem) Open(w *World, question, kind string, options []string, duration int) (PredictionRound, error) {
+	if kind != PredictionLargest && kind != PredictionSurvives {
+		return PredictionRound{}, fmt.Errorf("unknown prediction kind %q (use largest or survives)", kind)
+	}
+	if duration < 0 {
+		return PredictionRound{}, fmt.Errorf("prediction duration cannot be negative")
+	}
+	if duration == 0 {
+		duration = defaultPredictionTicks
+	}
+
+	populations := livingSpeciesCounts(w)
+	if len(options) == 0 {
+		options = sortedKeys(populations)
+	} else {
+		seen := make(map[string]bool, len(options))
+		for _, option := range options {
+			if populations[option] == 0 {
+				return PredictionRound{}, fmt.Errorf("species %q has no living creatures", option)
+			}
+			if seen[option] {
+				return PredictionRound{}, fmt.Errorf("species %q is listed twice", option)
+			}
+			seen[option] = true
+		}
+	}
+	if len(options) < minPredictionOptionCount {
+		return PredictionRound{}, fmt.Errorf("a prediction needs at least %d living species to choose from", minPredictionOptionCount)
+	}
+	question = strings.TrimSpace(question)
+	if question == "" {
+		question = fmt.Sprintf("Which species will be the largest at tick %d?", w.Tick+duration)
+		if kind == PredictionSurvives {
+			question = fmt.Sprintf("Which species will still be alive at tick %d?", w.Tick+duration)
+		}
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.open(w, question, kind, append([]string(nil), options...), duration, ""), nil
+}
+
+// open adds a round; the caller holds the lock
+func (ps *SpectatorPredictionSystem) open(w *World, question, kind string, options []string, duration int, trigger string) PredictionRound {
+	round := &PredictionRound{
+		ID:            ps.nextID,
+		Question:      question,
+		Kind:          kind,
+		Options:       options,
+		Trigger:       trigger,
+		OpenTick:      w.Tick,
+		BetsCloseTick: w.Tick + int(float64(duration)*predictionBettingShare),
+		ResolveTick:   w.Tick + duration,
+		Status:        PredictionOpen,
+		Predictions:   make([]Prediction, 0),
+	}
+	ps.nextID++
+	ps.rounds = append(ps.rounds, round)
+	ps.emit(w.Tick, "prediction_opened", "Spectators predict: "+question, round)
+	return round.snapshot()
+}
+
+// Predict stakes a spectator's points on an option of an open round. Spectators are created
+// with starting points the first time they predict, and get one prediction per round.
+func (ps *SpectatorPredictionSystem) Predict(roundID int, name, option string, stake, tick int) (Spectator, error) {
+	name, err := ValidatePlayerName(name)
+	if err != nil {
+		return Spectator{}, err
+	}
+	if stake < 1 {
+		return Spectator{}, fmt.Errorf("stake at least 1 point")
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	round := ps.round(roundID)
+	if round == nil {
+		return Spectator{}, fmt.Errorf("prediction round %d not found", roundID)
+	}
+	if round.Status != PredictionOpen || tick > round.BetsCloseTick {
+		return Spectator{}, fmt.Errorf("prediction round %d no longer takes predictions", roundID)
+	}
+	if !containsString(round.Options, option) {
+		return Spectator{}, fmt.Errorf("%q is not an option of round %d", option, roundID)
+	}
+	for _, prediction := range round.Predictions {
+		if prediction.Spectator == name {
+			return Spectator{}, fmt.Errorf("%s already predicted %s in round %d", name, prediction.Option, roundID)
+		}
+	}
+	spectator := ps.spectator(name)
+	if stake > spectator.Points {
+		return Spectator{}, fmt.Errorf("%s only has %d points", name, spectator.Points)
+	}
+
+	spectator.Points -= stake
+	round.Pool += stake
+	round.Predictions = append(round.Predictions, Prediction{Spectator: name, Option: option, Stake: stake, Tick: tick})
+	return *spectator, nil
+}
+
+// Update opens a round whenever a world event such as an ice age begins and resolves rounds
+// that have run their course
+func (ps *SpectatorPredictionSystem) Update(w *World) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	// Only remember events that are still going on
+	asked := make(map[*WorldEvent]bool, len(w.Events))
+	for _, event := range w.Events {
+		if ps.asked[event] || event.Duration <= 0 {
+			asked[event] = ps.asked[event]
+			continue
+		}
+		asked[event] = true
+		options := sortedKeys(livingSpeciesCounts(w))
+		if len(options) < minPredictionOptionCount || ps.openTriggeredRound() {
+			continue
+		}
+		ps.open(w, fmt.Sprintf("Which species will survive the %s?", event.Name), PredictionSurvives, options, event.Duration, event.Name)
+	}
+	ps.asked = asked
+
+	var populations map[string]int
+	for _, round := range ps.rounds {
+		if round.Status != PredictionOpen || w.Tick < round.ResolveTick {
+			continue
+		}
+		if populations == nil {
+			populations = livingSpeciesCounts(w)
+		}
+		ps.resolve(w.Tick, round, populations)
+	}
+	ps.prune()
+}
+
+// CancelOpen cancels every open round and refunds its stakes, used when the world is reset
+func (ps *SpectatorPredictionSystem) CancelOpen(tick int) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	for _, round := range ps.rounds {
+		if round.Status != PredictionOpen {
+			continue
+		}
+		round.Status = PredictionCancelled
+		for i := range round.Predictions {
+			prediction := &round.Predictions[i]
+			prediction.Payout = prediction.Stake
+			ps.spectator(prediction.Spectator).Points += prediction.Stake
+		}
+		ps.emit(tick, "prediction_cancelled", "Prediction cancelled: "+round.Question, round)
+	}
+	ps.asked = make(map[*WorldEvent]bool)
+	ps.prune()
+}
+
+// Rounds returns every open round and the most recent closed ones, newest first
+func (ps *SpectatorPredictionSystem) Rounds() []PredictionRound {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	rounds := make([]PredictionRound, 0, len(ps.rounds))
+	for i := len(ps.rounds) - 1; i >= 0; i-- {
+		rounds = append(rounds, ps.rounds[i].snapshot())
+	}
+	return rounds
+}
+
+// OpenRounds returns the rounds still waiting for an outcome, oldest first
+func (ps *SpectatorPredictionSystem) OpenRounds() []PredictionRound {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	rounds := make([]PredictionRound, 0)
+	for _, round := range ps.rounds {
+		if round.Status == PredictionOpen {
+			rounds = append(rounds, round.snapshot())
+		}
+	}
+	return rounds
+}
+
+// Leaderboard returns every spectator, most points first
+func (ps *SpectatorPredictionSystem) Leaderboard() []Spectator {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	leaderboard := make([]Spectator, 0, len(ps.spectators))
+	for _, name := range sortedKeys(ps.spectators) {
+		leaderboard = append(leaderboard, *ps.spectators[name])
+	}
+	sort.SliceStable(leaderboard, func(i, j int) bool {
+		return leaderboard[i].Points > leaderboard[j].Points
+	})
+	return leaderboard
+}
+
+// resolve settles a round: the whole pool is shared among correct predictions by stake.
+// When nobody predicted correctly every stake is refunded.
+func (ps *SpectatorPredictionSystem) resolve(tick int, round *PredictionRound, populations map[string]int) {
+	round.Status = PredictionResolved
+	round.Outcome = predictionOutcome(round, populations)
+
+	winningStake := 0
+	for _, prediction := range round.Predictions {
+		if containsString(round.Outcome, prediction.Option) {
+			winningStake += prediction.Stake
+		}
+	}
+	for i := range round.Predictions {
+		prediction := &round.Predictions[i]
+		spectator := ps.spectator(prediction.Spectator)
+		switch {
+		case winningStake == 0:
+			prediction.Payout = prediction.Stake
+		case containsString(round.Outcome, prediction.Option):
+			prediction.Payout = round.Pool * prediction.Stake / winningStake
+			spectator.Correct++
+		default:
+			spectator.Wrong++
+		}
+		spectator.Points += prediction.Payout
+	}
+
+	outcome := "none of them"
+	if len(round.Outcome) > 0 {
+		outcome = strings.Join(round.Outcome, ", ")
+	}
+	ps.emit(tick, "prediction_resolved", fmt.Sprintf("%s Answer: %s", round.Question, outcome), round)
+}
+
+// predictionOutcome returns the options that answer a round's question
+func predictionOutcome(round *PredictionRound, populations map[string]int) []string {
+	outcome := make([]string, 0)
+	best := 0
+	for _, option := range round.Options {
+		population := populations[option]
+		switch round.Kind {
+		case PredictionSurvives:
+			if population > 0 {
+				outcome = append(outcome, option)
+			}
+		case PredictionLargest:
+			if population > best {
+				best = population
+				outcome = []string{option}
+			} else if population == best && population > 0 {
+				outcome = append(outcome, option)
+			}
+		}
+	}
+	return outcome
+}
+
+// openTriggeredRound reports whether a round opened by a world event is still open, so a
+// burst of events does not flood spectators with questions; the caller holds the lock
+func (ps *SpectatorPredictionSystem) openTriggeredRound() bool {
+	for _, round := range ps.rounds {
+		if round.Status == PredictionOpen && round.Trigger != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// round finds a round by ID; the caller holds the lock
+func (ps *SpectatorPredictionSystem) round(id int) *PredictionRound {
+	for _, round := range ps.rounds {
+		if round.ID == id {
+			return round
+		}
+	}
+	return nil
+}
+
+// spectator returns a spectator, creating them with starting points; the caller holds the lock
+func (ps *SpectatorPredictionSystem) spectator(name string) *Spectator {
+	spectator, exists := ps.spectators[name]
+	if !exists {
+		spectator = &Spectator{Name: name, Points: spectatorStartingPoints}
+		ps.spectators[name] = spectator
+	}
+	return spectator
+}
+
+// prune drops the oldest closed rounds beyond maxPredictionRoundsKept; the caller holds the lock
+func (ps *SpectatorPredictionSystem) prune() {
+	closed := 0
+	for _, round := range ps.rounds {
+		if round.Status != PredictionOpen {
+			closed++
+		}
+	}
+	kept := ps.rounds[:0]
+	for _, round := range ps.rounds {
+		if round.Status != PredictionOpen && closed > maxPredictionRoundsKept {
+			closed--
+			continue
+		}
+		kept = append(kept, round)
+	}
+	ps.rounds = kept
+}
+
+// snapshot copies the round so it can be read without holding the system's lock
+func (round *PredictionRound) snapshot() PredictionRound {
+	copied := *round
+	copied.Options = append([]string(nil), round.Options...)
+	copied.Outcome = append([]string(nil), round.Outcome...)
+	copied.Predictions = append([]Prediction(nil), round.Predictions...)
+	return copied
+}
+
+// emit records a prediction round step on the central event bus
+func (ps *SpectatorPredictionSystem) emit(tick int, eventType, description string, round *PredictionRound) {
+	if ps.eventBus == nil {
+		return
+	}
+	ps.eventBus.EmitSystemEvent(tick, eventType, round.Kind, "spectator_predictions", description, nil,
+		map[string]interface{}{
+			"round_id":    round.ID,
+			"predictions": len(round.Predictions),
+			"pool":        round.Pool,
+		})
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPredictionRoundPaysOutByStake(t *testing.T) {
+	world := newGameTestWorld(map[string]int{"ant": 3, "beetle": 2, "cicada": 1})
+	predictions := world.Predictions
+
+	if _, err := predictions.Open(world, "", "weather", nil, 0); err == nil {
+		t.Error("Expected an unknown prediction kind to be rejected")
+	}
+	if _, err := predictions.Open(world, "", PredictionLargest, []string{"ant", "dodo"}, 0); err == nil {
+		t.Error("Expected a species without living creatures to be rejected as an option")
+	}
+	round, err := predictions.Open(world, "", PredictionLargest, nil, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(round.Options) != 3 || round.BetsCloseTick != 50 || round.ResolveTick != 100 {
+		t.Fatalf("Expected every living species as an option and bets to close halfway, got %+v", round)
+	}
+
+	for _, bet := range []struct {
+		spectator, option string
+		stake             int
+	}{{"Ann", "ant", 30}, {"Bea", "ant", 10}, {"Cal", "beetle", 40}} {
+		if _, err := predictions.Predict(round.ID, bet.spectator, bet.option, bet.stake, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := predictions.Predict(round.ID, "Ann", "beetle", 5, 10); err == nil {
+		t.Error("Expected a second prediction in the same round to be rejected")
+	}
+	if _, err := predictions.Predict(round.ID, "Dee", "ant", spectatorStartingPoints+1, 10); err == nil {
+		t.Error("Expected a stake above the spectator's points to be rejected")
+	}
+	if _, err := predictions.Predict(round.ID, "Dee", "ant", 5, 51); err == nil {
+		t.Error("Expected predictions after the betting window to be rejected")
+	}
+
+	// Predictions only watch: the world is the same once the round resolves
+	entities := len(world.AllEntities)
+	world.Tick = 99
+	predictions.Update(world)
+	if len(predictions.OpenRounds()) != 1 {
+		t.Fatal("Expected the round to stay open until its resolve tick")
+	}
+	world.Tick = 100
+	predictions.Update(world)
+	if len(predictions.OpenRounds()) != 0 || len(world.AllEntities) != entities {
+		t.Fatal("Expected the round to resolve without touching the world")
+	}
+
+	resolved := predictions.Rounds()[0]
+	if resolved.Status != PredictionResolved || len(resolved.Outcome) != 1 || resolved.Outcome[0] != "ant" {
+		t.Fatalf("Expected ants to be the answer, got %+v", resolved)
+	}
+	leaderboard := predictions.Leaderboard()
+	points := map[string]int{}
+	for _, spectator := range leaderboard {
+		points[spectator.Name] = spectator.Points
+	}
+	// The 80 point pool goes to the ant predictions 3:1
+	if points["Ann"] != 130 || points["Bea"] != 110 || points["Cal"] != 60 || leaderboard[0].Name != "Ann" || leaderboard[0].Correct != 1 {
+		t.Errorf("Expected the pool shared among correct predictions by stake, got %+v", leaderboard)
+	}
+	if events := world.CentralEventBus.GetEventsByType("prediction_resolved"); len(events) != 1 {
+		t.Errorf("Expected one prediction resolved event, got %d", len(events))
+	}
+}
+
+func TestWorldEventOpensSurvivalPrediction(t *testing.T) {
+	world := newGameTestWorld(map[string]int{"ant": 2, "beetle": 2})
+	iceAge := &WorldEvent{Name: "Ice Age", Duration: 100}
+	world.Events = append(world.Events, iceAge, &WorldEvent{Name: "Solar Flare", Duration: 30})
+	world.Predictions.Update(world)
+	world.Predictions.Update(world)
+
+	open := world.Predictions.OpenRounds()
+	if len(open) != 1 || open[0].Question != "Which species will survive the Ice Age?" || open[0].ResolveTick != 100 {
+		t.Fatalf("Expected one survival round for the first event, got %+v", open)
+	}
+	if _, err := world.Predictions.Predict(open[0].ID, "Ann", "beetle", 20, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := world.Predictions.Predict(open[0].ID, "Bea", "ant", 20, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// Resetting the world refunds open rounds
+	world.Reset()
+	if len(world.Predictions.OpenRounds()) != 0 || world.Predictions.Rounds()[0].Status != PredictionCancelled {
+		t.Fatal("Expected the reset to cancel the open round")
+	}
+	for _, spectator := range world.Predictions.Leaderboard() {
+		if spectator.Points != spectatorStartingPoints {
+			t.Errorf("Expected %s's stake to be refunded, got %d points", spectator.Name, spectator.Points)
+		}
+	}
+}
+
+func TestPredictionsAPI(t *testing.T) {
+	wi := NewWebInterface(newGameTestWorld(map[string]int{"ant": 2, "beetle": 2}))
+
+	rec := httptest.NewRecorder()
+	wi.handlePredictions(rec, httptest.NewRequest(http.MethodPost, "/api/predictions", bytes.NewReader([]byte(`{"kind": "survives", "duration": 40}`))))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Opening a round failed: %d %s", rec.Code, rec.Body.String())
+	}
+	var round PredictionRound
+	if err := json.Unmarshal(rec.Body.Bytes(), &round); err != nil {
+		t.Fatal(err)
+	}
+
+	rec = httptest.NewRecorder()
+	body, _ := json.Marshal(map[string]interface{}{"round_id": round.ID, "spectator": "Viewer1", "option": "ant", "stake": 25})
+	wi.handlePredict(rec, httptest.NewRequest(http.MethodPost, "/api/predictions/predict", bytes.NewReader(body)))
+	var spectator Spectator
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &spectator) != nil || spectator.Points != spectatorStartingPoints-25 {
+		t.Fatalf("Expected the stake to be taken from the spectator, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handlePredict(rec, httptest.NewRequest(http.MethodPost, "/api/predictions/predict", bytes.NewReader([]byte(`{"round_id": 99, "spectator": "Viewer1", "option": "ant", "stake": 5}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown round, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handlePredictions(rec, httptest.NewRequest(http.MethodGet, "/api/predictions", nil))
+	var listing struct {
+		Rounds      []PredictionRound `json:"rounds"`
+		Leaderboard []Spectator       `json:"leaderboard"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &listing); err != nil || len(listing.Rounds) != 1 || len(listing.Leaderboard) != 1 || listing.Rounds[0].Pool != 25 {
+		t.Errorf("Expected the round and its spectator, got %s", rec.Body.String())
+	}
+}
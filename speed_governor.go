@@ -0,0 +1,89 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// tpsWindow is how long ticks are counted before the ticks-per-second figure is refreshed
+const tpsWindow = time.Second
+
+// SpeedGovernor decouples the simulation rate from the view update rate. In the default
+// mode the speed multiplier decides how many ticks run per frame; in unlimited mode ticks
+// run back to back and frames are sent whenever they are due. Either way the share of wall
+// time spent simulating can be capped, and the achieved ticks per second is measured.
+type SpeedGovernor struct {
+	mu          sync.Mutex
+	unlimited   bool
+	maxCPU      float64 // Fraction of wall time the simulation may use (0.05-1)
+	windowStart time.Time
+	windowTicks int
+	tps         float64
+}
+
+// NewSpeedGovernor creates a governor in multiplier mode with no CPU cap
+func NewSpeedGovernor() *SpeedGovernor {
+	return &SpeedGovernor{maxCPU: 1.0, windowStart: time.Now()}
+}
+
+// SetUnlimited switches between running ticks as fast as possible and following the speed multiplier
+func (sg *SpeedGovernor) SetUnlimited(unlimited bool) {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	sg.unlimited = unlimited
+}
+
+// Unlimited reports whether ticks run as fast as possible
+func (sg *SpeedGovernor) Unlimited() bool {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.unlimited
+}
+
+// SetMaxCPU caps the fraction of wall time spent simulating, clamped to 5%-100%
+func (sg *SpeedGovernor) SetMaxCPU(fraction float64) {
+	if fraction < 0.05 {
+		fraction = 0.05
+	}
+	if fraction > 1.0 {
+		fraction = 1.0
+	}
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	sg.maxCPU = fraction
+}
+
+// MaxCPU returns the simulation's share of wall time
+func (sg *SpeedGovernor) MaxCPU() float64 {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.maxCPU
+}
+
+// Throttle returns how long to rest after spending busy simulating so the CPU cap holds
+func (sg *SpeedGovernor) Throttle(busy time.Duration) time.Duration {
+	maxCPU := sg.MaxCPU()
+	if maxCPU >= 1.0 || busy <= 0 {
+		return 0
+	}
+	return time.Duration(float64(busy) * (1 - maxCPU) / maxCPU)
+}
+
+// RecordTicks counts ticks completed at now towards the ticks-per-second measurement
+func (sg *SpeedGovernor) RecordTicks(ticks int, now time.Time) {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	sg.windowTicks += ticks
+	if elapsed := now.Sub(sg.windowStart); elapsed >= tpsWindow {
+		sg.tps = float64(sg.windowTicks) / elapsed.Seconds()
+		sg.windowTicks = 0
+		sg.windowStart = now
+	}
+}
+
+// TicksPerSecond returns the measured simulation rate over the last completed window
+func (sg *SpeedGovernor) TicksPerSecond() float64 {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return sg.tps
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpeedGovernorThrottleHoldsCPUShare(t *testing.T) {
+	governor := NewSpeedGovernor()
+	if rest := governor.Throttle(10 * time.Millisecond); rest != 0 {
+		t.Errorf("Expected no rest without a CPU cap, got %v", rest)
+	}
+
+	governor.SetMaxCPU(0.25)
+	if rest := governor.Throttle(10 * time.Millisecond); rest != 30*time.Millisecond {
+		t.Errorf("Expected 30ms rest after 10ms of work at 25%% CPU, got %v", rest)
+	}
+
+	governor.SetMaxCPU(0)
+	if governor.MaxCPU() != 0.05 {
+		t.Errorf("Expected CPU cap to be clamped to 5%%, got %.2f", governor.MaxCPU())
+	}
+}
+
+func TestSpeedGovernorMeasuresTicksPerSecond(t *testing.T) {
+	governor := NewSpeedGovernor()
+	start := governor.windowStart
+
+	governor.RecordTicks(50, start.Add(500*time.Millisecond))
+	if tps := governor.TicksPerSecond(); tps != 0 {
+		t.Errorf("Expected no measurement before the window completes, got %.1f", tps)
+	}
+
+	governor.RecordTicks(150, start.Add(2*time.Second))
+	if tps := governor.TicksPerSecond(); tps != 100 {
+		t.Errorf("Expected 100 ticks per second, got %.1f", tps)
+	}
+}
+
+func TestUnlimitedSpeedRunsMoreTicksThanFrames(t *testing.T) {
+	world := NewWorld(WorldConfig{Width: 30, Height: 30, PopulationSize: 3, GridWidth: 6, GridHeight: 6})
+	world.AddPopulation(startingPopulations(false)[0])
+	wi := NewWebInterface(world)
+	wi.governor.SetUnlimited(true)
+
+	wi.startLoop(wi.simulationLoop)
+	time.Sleep(350 * time.Millisecond)
+	wi.Stop()
+
+	frames := len(wi.broadcastChan)
+	if frames == 0 {
+		t.Fatal("Expected view updates to keep flowing in unlimited mode")
+	}
+	if world.Tick <= frames {
+		t.Errorf("Expected more ticks than frames in unlimited mode, got %d ticks for %d frames", world.Tick, frames)
+	}
+}
@@ -180,7 +180,7 @@ func TestSpeedMultiplierImpactOnMovement(t *testing.T) {
 	// Test movement at normal speed
 	world.SetSpeedMultiplier(1.0)
 	initialEnergy := entity.Energy
-	entity.MoveToWithConfig(30.0, 30.0, 5.0, world.SimConfig)
+	entity.MoveToWithConfig(30.0, 30.0, 5.0, world.SimConfig, world.Geometry())
 	energyCostNormal := initialEnergy - entity.Energy
 
 	// Reset entity
@@ -189,7 +189,7 @@ func TestSpeedMultiplierImpactOnMovement(t *testing.T) {
 
 	// Test movement at double speed
 	world.SetSpeedMultiplier(2.0)
-	entity.MoveToWithConfig(30.0, 30.0, 5.0, world.SimConfig)
+	entity.MoveToWithConfig(30.0, 30.0, 5.0, world.SimConfig, world.Geometry())
 	energyCostDouble := initialEnergy - entity.Energy
 
 	// Energy cost should be approximately doubled
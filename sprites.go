@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// spriteSize is the side of a sprite's square canvas in SVG units
+const spriteSize = 64
+
+// spriteTrait reads a trait as a share from 0 to 1, where -1 or less is none and 1 or more is all
+func spriteTrait(traits map[string]float64, name string) float64 {
+	return math.Max(0, math.Min(1, (traits[name]+1)/2))
+}
+
+// spriteHSL formats a colour for SVG
+func spriteHSL(hue, saturation, lightness float64) string {
+	return fmt.Sprintf("hsl(%.0f,%.0f%%,%.0f%%)", math.Mod(hue+360, 360), saturation, lightness)
+}
+
+// svgSprite wraps drawn shapes in an SVG document
+func svgSprite(title string, shapes *strings.Builder) string {
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d"><title>%s</title>%s</svg>`,
+		spriteSize, spriteSize, spriteSize, spriteSize, title, shapes.String())
+}
+
+// CreatureSprite draws a creature side on from its traits and the complexity of the organism
+// its DNA grew. The same traits always draw the same sprite.
+//   - size sets the body, endurance rounds it and strength thickens the legs
+//   - speed adds pairs of longer legs, unless the creature is aquatic enough to swim with a tail fin
+//   - defense raises spikes along the back and aggression bares teeth and reddens the colour
+//   - intelligence enlarges the head and eyes, flying ability grows wings and digging ability claws
+//   - each level of cellular complexity past the first adds a stripe
+func CreatureSprite(traits map[string]float64, complexity int) string {
+	size := spriteTrait(traits, "size")
+	speed := spriteTrait(traits, "speed")
+	aggression := spriteTrait(traits, "aggression")
+	defense := spriteTrait(traits, "defense")
+	intelligence := spriteTrait(traits, "intelligence")
+	endurance := spriteTrait(traits, "endurance")
+	strength := spriteTrait(traits, "strength")
+	aquatic := spriteTrait(traits, "aquatic_adaptation")
+	flying := spriteTrait(traits, "flying_ability")
+	digging := spriteTrait(traits, "digging_ability")
+
+	// Peaceful creatures are green, aggressive ones red and aquatic ones blue
+	hue := 120 - 110*aggression
+	if aquatic > 0.6 {
+		hue += (210 - hue) * (aquatic - 0.6) / 0.4
+	}
+	body := spriteHSL(hue, 55, 58-20*size)
+	dark := spriteHSL(hue, 55, 30-10*size)
+
+	cx, cy := 28.0, 38.0
+	rx := 9 + 8*size
+	ry := 6 + 4*size + 3*endurance
+	var shapes strings.Builder
+
+	if flying > 0.6 {
+		span := 8 + 18*(flying-0.6)/0.4
+		fmt.Fprintf(&shapes, `<ellipse cx="%.1f" cy="%.1f" rx="%.1f" ry="%.1f" fill="%s" fill-opacity="0.45" transform="rotate(-25 %.1f %.1f)"/>`,
+			cx-2, cy-ry-span/3, span*0.9, span/3, body, cx-2, cy-ry)
+	}
+
+	swims := aquatic > 0.6
+	if swims {
+		fmt.Fprintf(&shapes, `<polygon points="%.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="%s"/>`,
+			cx-rx+2, cy, cx-rx-9, cy-7, cx-rx-9, cy+7, dark)
+	} else {
+		pairs := 1 + int(math.Round(speed*2))
+		length := 5 + 8*speed
+		for i := 0; i < pairs; i++ {
+			x := cx - rx*0.6 + float64(i)*rx*1.2/math.Max(1, float64(pairs-1))
+			if pairs == 1 {
+				x = cx
+			}
+			fmt.Fprintf(&shapes, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="%.1f" stroke-linecap="round"/>`,
+				x, cy+ry*0.6, x-2, math.Min(spriteSize-2, cy+ry*0.6+length), dark, 1.5+2*strength)
+			if digging > 0.6 {
+				fmt.Fprintf(&shapes, `<path d="M%.1f %.1f l3 1 M%.1f %.1f l3 -1" stroke="%s" stroke-width="1"/>`,
+					x-2, math.Min(spriteSize-2, cy+ry*0.6+length), x-2, math.Min(spriteSize-2, cy+ry*0.6+length), dark)
+			}
+		}
+		fmt.Fprintf(&shapes, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="2" stroke-linecap="round"/>`,
+			cx-rx+1, cy-1, cx-rx-6, cy-4-4*speed, dark)
+	}
+
+	spikes := int(math.Round(defense * 6))
+	for i := 0; i < spikes; i++ {
+		x := cx - rx*0.7 + float64(i)*rx*1.4/math.Max(1, float64(spikes))
+		top := cy - ry*0.85
+		fmt.Fprintf(&shapes, `<polygon points="%.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="%s"/>`, x, top+2, x+2.5, top-3-4*defense, x+5, top+2, dark)
+	}
+
+	fmt.Fprintf(&shapes, `<ellipse cx="%.1f" cy="%.1f" rx="%.1f" ry="%.1f" fill="%s" stroke="%s" stroke-width="1.5"/>`, cx, cy, rx, ry, body, dark)
+	for i := 1; i < min(complexity, 6); i++ {
+		x := cx - rx + float64(i)*2*rx/float64(min(complexity, 6))
+		fmt.Fprintf(&shapes, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-opacity="0.6" stroke-width="1.5"/>`,
+			x, cy-ry*0.8, x, cy+ry*0.8, dark)
+	}
+	if swims {
+		fmt.Fprintf(&shapes, `<polygon points="%.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="%s"/>`, cx-3, cy-ry+1, cx+1, cy-ry-6, cx+5, cy-ry+1, dark)
+	}
+	if flying > 0.6 {
+		span := 8 + 18*(flying-0.6)/0.4
+		fmt.Fprintf(&shapes, `<ellipse cx="%.1f" cy="%.1f" rx="%.1f" ry="%.1f" fill="%s" fill-opacity="0.7" stroke="%s" transform="rotate(-35 %.1f %.1f)"/>`,
+			cx+2, cy-ry-span/4, span*0.8, span/3.5, body, dark, cx+2, cy-ry)
+	}
+
+	headR := 4 + 4*intelligence
+	hx, hy := cx+rx+headR*0.6, cy-ry*0.4
+	fmt.Fprintf(&shapes, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s" stroke="%s" stroke-width="1.5"/>`, hx, hy, headR, body, dark)
+	eyeR := 1 + 1.5*intelligence
+	fmt.Fprintf(&shapes, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="white"/><circle cx="%.1f" cy="%.1f" r="%.1f" fill="black"/>`,
+		hx+headR*0.3, hy-headR*0.25, eyeR, hx+headR*0.4, hy-headR*0.25, eyeR*0.5)
+	if aggression > 0.6 {
+		mouth := hy + headR*0.45
+		for i := 0; i < 2+int(aggression*2); i++ {
+			x := hx + float64(i)*2 - 1
+			fmt.Fprintf(&shapes, `<polygon points="%.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="white"/>`, x, mouth, x+1, mouth+2.5, x+2, mouth)
+		}
+	}
+	return svgSprite("creature", &shapes)
+}
+
+// PlantSprite draws a plant of a type from its traits. The same traits always draw the same sprite.
+//   - growth efficiency makes it taller and fuller
+//   - hardiness darkens the green and toxin production turns it purple
+//   - defense adds thorns, nutrition density fruit and reproduction rate more leaves
+func PlantSprite(traits map[string]float64, plantType PlantType) string {
+	growth := spriteTrait(traits, "growth_efficiency")
+	hardiness := spriteTrait(traits, "hardiness")
+	toxin := spriteTrait(traits, "toxin_production")
+	defense := spriteTrait(traits, "defense")
+	nutrition := spriteTrait(traits, "nutrition_density")
+	reproduction := spriteTrait(traits, "reproduction_rate")
+
+	hue := 115 + 165*math.Max(0, toxin-0.5)/0.5*0.8
+	leaf := spriteHSL(hue, 50, 50-18*hardiness)
+	dark := spriteHSL(hue, 50, 28-10*hardiness)
+	stem := "hsl(30,45%,32%)"
+	ground := float64(spriteSize - 4)
+	height := 20 + 30*growth
+	leaves := 3 + int(math.Round(reproduction*5))
+
+	var shapes strings.Builder
+	var crown struct{ x, y, r float64 }
+	switch plantType {
+	case PlantTree, PlantBush:
+		trunk := height * 0.45
+		if plantType == PlantBush {
+			trunk = height * 0.15
+		}
+		fmt.Fprintf(&shapes, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`, 32-2-2*growth, ground-trunk, 4+4*growth, trunk, stem)
+		crown.x, crown.r = 32, 8+10*growth
+		crown.y = ground - trunk - crown.r*0.6
+		for i := 0; i < leaves; i++ {
+			angle := float64(i) / float64(leaves) * 2 * math.Pi
+			fmt.Fprintf(&shapes, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s" stroke="%s"/>`,
+				crown.x+math.Cos(angle)*crown.r*0.5, crown.y+math.Sin(angle)*crown.r*0.4, crown.r*0.6, leaf, dark)
+		}
+	case PlantMushroom:
+		fmt.Fprintf(&shapes, `<rect x="29" y="%.1f" width="6" height="%.1f" fill="#e8dcc0"/>`, ground-height*0.5, height*0.5)
+		crown.x, crown.y, crown.r = 32, ground-height*0.5, 8+8*growth
+		fmt.Fprintf(&shapes, `<path d="M%.1f %.1f A%.1f %.1f 0 0 1 %.1f %.1f Z" fill="%s" stroke="%s"/>`,
+			crown.x-crown.r, crown.y, crown.r, crown.r*0.8, crown.x+crown.r, crown.y, spriteHSL(hue+250, 55, 50), dark)
+	case PlantCactus:
+		fmt.Fprintf(&shapes, `<rect x="27" y="%.1f" width="10" height="%.1f" rx="5" fill="%s" stroke="%s"/>`, ground-height, height, leaf, dark)
+		fmt.Fprintf(&shapes, `<path d="M27 %.1f h-6 v-8 M37 %.1f h6 v-10" stroke="%s" stroke-width="5" fill="none" stroke-linecap="round"/>`,
+			ground-height*0.45, ground-height*0.6, leaf)
+		crown.x, crown.y, crown.r = 32, ground-height*0.6, 6
+	default: // Grass and algae grow in blades, algae wavier
+		for i := 0; i < leaves; i++ {
+			x := 32 + (float64(i)-float64(leaves-1)/2)*4
+			bend := 4.0
+			if plantType == PlantAlgae {
+				bend = 8
+			}
+			fmt.Fprintf(&shapes, `<path d="M%.1f %.1f Q%.1f %.1f %.1f %.1f" stroke="%s" stroke-width="2.5" fill="none" stroke-linecap="round"/>`,
+				x, ground, x+bend, ground-height*0.5, x-bend/2, ground-height*(0.7+0.3*float64(i%2)), leaf)
+		}
+		crown.x, crown.y, crown.r = 32, ground-height*0.6, 6+float64(leaves)
+	}
+
+	thorns := int(math.Round(defense * 8))
+	for i := 0; i < thorns; i++ {
+		angle := float64(i)/float64(max(1, thorns))*2*math.Pi + 0.3
+		x, y := crown.x+math.Cos(angle)*crown.r, crown.y+math.Sin(angle)*crown.r*0.7
+		fmt.Fprintf(&shapes, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="1"/>`,
+			x, y, x+math.Cos(angle)*3, y+math.Sin(angle)*3, dark)
+	}
+	fruit := int(math.Round(nutrition * 5))
+	for i := 0; i < fruit; i++ {
+		angle := float64(i)/float64(max(1, fruit))*2*math.Pi + 1
+		fmt.Fprintf(&shapes, `<circle cx="%.1f" cy="%.1f" r="2" fill="%s"/>`,
+			crown.x+math.Cos(angle)*crown.r*0.5, crown.y+math.Sin(angle)*crown.r*0.35, spriteHSL(10+40*toxin, 80, 55))
+	}
+	fmt.Fprintf(&shapes, `<line x1="8" y1="%.1f" x2="56" y2="%.1f" stroke="%s" stroke-opacity="0.5" stroke-width="2"/>`, ground+1, ground+1, stem)
+	return svgSprite("plant", &shapes)
+}
+
+// SpeciesSprite draws a species from the traits of its living members. A number names a plant
+// species of the speciation system; anything else a creature species, drawn from its members'
+// mean traits and cellular complexity.
+func (w *World) SpeciesSprite(id string) (string, error) {
+	if number, err := strconv.Atoi(id); err == nil && w.SpeciationSystem != nil {
+		if species, exists := w.SpeciationSystem.AllSpecies[number]; exists {
+			traits := species.CurrentTraits
+			if len(traits) == 0 {
+				traits = species.FoundingTraits
+			}
+			return PlantSprite(traits, species.OriginPlantType), nil
+		}
+	}
+
+	traits := make(map[string]float64)
+	members, complexity := 0, 0
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive || entity.Species != id {
+			continue
+		}
+		for name := range entity.Traits {
+			traits[name] += entity.GetTrait(name)
+		}
+		if w.CellularSystem != nil {
+			if organism, exists := w.CellularSystem.OrganismMap[entity.ID]; exists {
+				complexity += organism.ComplexityLevel
+			}
+		}
+		members++
+	}
+	if members == 0 {
+		return "", fmt.Errorf("no living species %q", id)
+	}
+	for name := range traits {
+		traits[name] /= float64(members)
+	}
+	return CreatureSprite(traits, int(math.Round(float64(complexity)/float64(members)))), nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSpritesFollowTraits(t *testing.T) {
+	timid := map[string]float64{"size": -0.5, "defense": -1, "aggression": -0.8}
+	armoured := map[string]float64{"size": -0.5, "defense": 1, "aggression": 0.9}
+
+	if CreatureSprite(timid, 1) != CreatureSprite(timid, 1) {
+		t.Error("Expected the same traits to draw the same sprite")
+	}
+	plain, spiky := CreatureSprite(timid, 1), CreatureSprite(armoured, 1)
+	if !strings.HasPrefix(plain, "<svg") || plain == spiky {
+		t.Fatalf("Expected different SVG sprites for different traits, got %q", plain)
+	}
+	if strings.Count(spiky, "<polygon") <= strings.Count(plain, "<polygon") {
+		t.Error("Expected a well defended, aggressive creature to grow spikes and teeth")
+	}
+	if strings.Count(CreatureSprite(timid, 4), "<line") <= strings.Count(plain, "<line") {
+		t.Error("Expected a more complex creature to be striped")
+	}
+
+	sapling := PlantSprite(map[string]float64{"growth_efficiency": -1}, PlantTree)
+	if sapling == PlantSprite(map[string]float64{"growth_efficiency": 1}, PlantTree) || sapling == PlantSprite(map[string]float64{"growth_efficiency": -1}, PlantMushroom) {
+		t.Error("Expected plant sprites to follow growth and type")
+	}
+}
+
+func TestSpeciesSpriteAPI(t *testing.T) {
+	world := newSteppingTestWorld(115)
+	routes := NewWebInterface(world).Routes()
+	get := func(id string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/species/"+id+"/sprite", nil))
+		return rec
+	}
+
+	species := world.AllEntities[0].Species
+	rec := get(species)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/svg+xml" || !strings.HasPrefix(rec.Body.String(), "<svg") {
+		t.Fatalf("Expected an SVG sprite of %s, got %d %q", species, rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if again := get(species); again.Body.String() != rec.Body.String() {
+		t.Error("Expected the same species drawn the same way twice")
+	}
+	if rec := get("nobody"); rec.Code != http.StatusNotFound {
+		t.Errorf("Expected an unknown species not found, got %d", rec.Code)
+	}
+
+	for id := range world.SpeciationSystem.AllSpecies {
+		if rec := get(strconv.Itoa(id)); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "plant") {
+			t.Errorf("Expected plant species %d drawn as a plant, got %d", id, rec.Code)
+		}
+		break
+	}
+}
@@ -1,15 +1,16 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
+	"log"
 	"os"
 	"time"
 )
 
 // StateManager handles saving and loading simulation state
 type StateManager struct {
-	world *World
+	world  *World
+	Format string // Save format to write (see SaveFormatJSON); by file extension when empty
 }
 
 // NewStateManager creates a new state manager for the given world
@@ -21,20 +22,36 @@ func NewStateManager(world *World) *StateManager {
 
 // SimulationState represents the complete state of the simulation
 type SimulationState struct {
-	Version     string                `json:"version"`
-	SavedAt     time.Time             `json:"saved_at"`
-	Tick        int                   `json:"tick"`
-	NextID      int                   `json:"next_id"`
-	NextPlantID int                   `json:"next_plant_id"`
-	Config      WorldConfig           `json:"config"`
-	Entities    []*EntityState        `json:"entities"`
-	Plants      []*PlantState         `json:"plants"`
-	Biomes      [][]BiomeType         `json:"biomes"`
-	Events      []*WorldEventState    `json:"events"`
-	Time        AdvancedTimeState     `json:"time"`
-	Wind        WindSystemState       `json:"wind"`
-	Species     SpeciationSystemState `json:"species"`
-	Network     PlantNetworkState     `json:"network"`
+	SchemaVersion int                   `json:"schema_version"` // Save format version (see StateSchemaVersion)
+	SavedAt       time.Time             `json:"saved_at"`
+	Tick          int                   `json:"tick"`
+	NextID        int                   `json:"next_id"`
+	NextPlantID   int                   `json:"next_plant_id"`
+	Config        WorldConfig           `json:"config"`
+	Seed          int64                 `json:"seed,omitempty"` // Random seed of the run, 0 when unseeded
+	Entities      []*EntityState        `json:"entities"`
+	Plants        []*PlantState         `json:"plants"`
+	Biomes        [][]BiomeType         `json:"biomes"`
+	Events        []*WorldEventState    `json:"events"`
+	Time          AdvancedTimeState     `json:"time"`
+	Wind          WindSystemState       `json:"wind"`
+	Species       SpeciationSystemState `json:"species"`
+	Network       PlantNetworkState     `json:"network"`
+	Tribes        []*TribeState         `json:"tribes,omitempty"`
+
+	Achievements *AchievementState `json:"achievements,omitempty"`
+	Migrations   *MigrationState   `json:"migrations,omitempty"`
+	Dispersal    *DispersalState   `json:"dispersal,omitempty"` // Creatures the population caps hold offstage
+	Selection    map[string]string `json:"selection,omitempty"` // Selection strategy of each population not using tournament selection
+}
+
+// TribeState records a tribe's standing for analysis; tribes re-form from entities after loading
+type TribeState struct {
+	ID          int                `json:"id"`
+	Name        string             `json:"name"`
+	TechLevel   int                `json:"tech_level"`
+	MemberCount int                `json:"member_count"`
+	Resources   map[string]float64 `json:"resources"`
 }
 
 // EntityState represents serializable entity data
@@ -204,85 +221,110 @@ type OrganelleState struct {
 	Energy     float64       `json:"energy"`
 }
 
-// SaveToFile saves the current simulation state to a JSON file
+// SaveToFile saves the current simulation state to a file, in binary when its name ends in
+// BinarySaveExtension or the manager's Format says so and JSON otherwise
 func (sm *StateManager) SaveToFile(filename string) error {
+	if err := sm.WriteStateFile(filename); err != nil {
+		return err
+	}
+	log.Printf("Simulation state saved to %s", filename)
+	return nil
+}
+
+// WriteStateFile saves the current simulation state to a file without reporting it on
+// stdout, for callers that keep stdout for their own output
+func (sm *StateManager) WriteStateFile(filename string) error {
 	state, err := sm.createState()
 	if err != nil {
 		return fmt.Errorf("failed to create state: %v", err)
 	}
+	format, err := ResolveSaveFormat(filename, sm.Format)
+	if err != nil {
+		return err
+	}
 
-	data, err := json.MarshalIndent(state, "", "  ")
+	data, err := EncodeState(state, format)
 	if err != nil {
-		return fmt.Errorf("failed to marshal state: %v", err)
+		// NaN or infinite values cannot be encoded; repair them rather than losing the save
+		report := ValidateState(state, true)
+		fmt.Fprintf(os.Stderr, "Repaired %d issue(s) before saving\n", len(report.Issues))
+		data, err = EncodeState(state, format)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = os.WriteFile(filename, data, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to write state file: %v", err)
 	}
-
-	fmt.Printf("Simulation state saved to %s\n", filename)
 	return nil
 }
 
-// LoadFromFile loads simulation state from a JSON file
+// LoadFromFile loads simulation state from a JSON or binary save file
 func (sm *StateManager) LoadFromFile(filename string) error {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return fmt.Errorf("failed to read state file: %v", err)
 	}
 
-	var state SimulationState
-	err = json.Unmarshal(data, &state)
+	state, applied, err := DecodeState(data)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal state: %v", err)
+		return err
 	}
+	logStateMigrations(applied)
 
-	err = sm.restoreState(&state)
+	// Saves from other versions may hold traits this one doesn't know or allow
+	if report := ValidateState(state, true); len(report.Issues) > 0 {
+		log.Printf("Repaired %d issue(s) while loading", len(report.Issues))
+	}
+
+	err = sm.restoreState(state)
 	if err != nil {
 		return fmt.Errorf("failed to restore state: %v", err)
 	}
 
-	fmt.Printf("Simulation state loaded from %s (saved at %s)\n", filename, state.SavedAt.Format(time.RFC3339))
+	log.Printf("Simulation state loaded from %s (saved at %s)", filename, state.SavedAt.Format(time.RFC3339))
 	return nil
 }
 
 // LoadFromData loads simulation state from a map (used for web interface)
 func (sm *StateManager) LoadFromData(data map[string]interface{}) error {
-	// Convert map to JSON and then to SimulationState
-	jsonData, err := json.Marshal(data)
+	// Upgrade the save to the current schema, then convert it to SimulationState
+	state, applied, err := stateFromDocument(data)
 	if err != nil {
-		return fmt.Errorf("failed to marshal data: %v", err)
+		return err
 	}
+	logStateMigrations(applied)
 
-	var state SimulationState
-	err = json.Unmarshal(jsonData, &state)
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal state: %v", err)
+	// Uploaded saves may be hand-edited, so repair what we can and refuse the rest
+	if report := ValidateState(state, true); report.UnrepairedCount() > 0 {
+		return fmt.Errorf("invalid state data:\n%s", report.Summary())
 	}
 
-	err = sm.restoreState(&state)
+	err = sm.restoreState(state)
 	if err != nil {
 		return fmt.Errorf("failed to restore state: %v", err)
 	}
 
-	fmt.Printf("Simulation state loaded from web interface (saved at %s)\n", state.SavedAt.Format(time.RFC3339))
+	log.Printf("Simulation state loaded from web interface (saved at %s)", state.SavedAt.Format(time.RFC3339))
 	return nil
 }
 
 // createState converts the current world state to a serializable format
 func (sm *StateManager) createState() (*SimulationState, error) {
 	state := &SimulationState{
-		Version:     "1.0",
-		SavedAt:     time.Now(),
-		Tick:        sm.world.Tick,
-		NextID:      sm.world.NextID,
-		NextPlantID: sm.world.NextPlantID,
-		Config:      sm.world.Config,
-		Entities:    make([]*EntityState, 0),
-		Plants:      make([]*PlantState, 0),
-		Biomes:      make([][]BiomeType, len(sm.world.Grid)),
-		Events:      make([]*WorldEventState, 0),
+		SchemaVersion: StateSchemaVersion,
+		SavedAt:       time.Now(),
+		Tick:          sm.world.Tick,
+		NextID:        sm.world.NextID,
+		NextPlantID:   sm.world.NextPlantID,
+		Config:        sm.world.Config,
+		Seed:          sm.world.Seed,
+		Entities:      make([]*EntityState, 0),
+		Plants:        make([]*PlantState, 0),
+		Biomes:        make([][]BiomeType, len(sm.world.Grid)),
+		Events:        make([]*WorldEventState, 0),
 	}
 
 	// Convert biomes
@@ -293,39 +335,7 @@ func (sm *StateManager) createState() (*SimulationState, error) {
 		}
 	}
 
-	// Convert entities
-	for _, entity := range sm.world.AllEntities {
-		entityState := &EntityState{
-			ID:       entity.ID,
-			Species:  entity.Species,
-			Position: entity.Position,
-			Traits:   make(map[string]float64),
-			Fitness:  entity.Fitness,
-			Energy:   entity.Energy,
-			Age:      entity.Age,
-		}
-
-		// Copy traits
-		for traitName, trait := range entity.Traits {
-			entityState.Traits[traitName] = trait.Value
-		}
-
-		// Convert DNA if present
-		if sm.world.CellularSystem != nil {
-			if organism, exists := sm.world.CellularSystem.OrganismMap[entity.ID]; exists && len(organism.Cells) > 0 && organism.Cells[0].DNA != nil {
-				entityState.DNA = sm.convertDNAToState(organism.Cells[0].DNA)
-			}
-		}
-
-		// Convert Cellular if present
-		if sm.world.CellularSystem != nil {
-			if organism, exists := sm.world.CellularSystem.OrganismMap[entity.ID]; exists {
-				entityState.Cellular = sm.convertCellularToState(organism)
-			}
-		}
-
-		state.Entities = append(state.Entities, entityState)
-	}
+	state.Entities = sm.entityStates(sm.world.AllEntities)
 
 	// Convert plants
 	for _, plant := range sm.world.AllPlants {
@@ -477,9 +487,104 @@ func (sm *StateManager) createState() (*SimulationState, error) {
 		}
 	}
 
+	// Record tribe tech levels
+	if sm.world.CivilizationSystem != nil {
+		for _, tribe := range sm.world.CivilizationSystem.Tribes {
+			tribeState := &TribeState{
+				ID:          tribe.ID,
+				Name:        tribe.Name,
+				TechLevel:   tribe.TechLevel,
+				MemberCount: len(tribe.Members),
+				Resources:   make(map[string]float64),
+			}
+			for resource, amount := range tribe.Resources {
+				tribeState.Resources[resource] = amount
+			}
+			state.Tribes = append(state.Tribes, tribeState)
+		}
+	}
+
+	if sm.world.Achievements != nil {
+		state.Achievements = sm.world.Achievements.State()
+	}
+	if sm.world.Migrations != nil {
+		state.Migrations = sm.world.Migrations.State()
+	}
+	if sm.world.PopulationCaps != nil {
+		state.Dispersal = sm.world.PopulationCaps.State(sm.entityStates)
+	}
+	state.Selection = sm.world.SelectionStrategies()
+
 	return state, nil
 }
 
+// entityStates converts entities to save records from their packed components
+func (sm *StateManager) entityStates(entities []*Entity) []*EntityState {
+	components := NewEntityComponents()
+	components.Sync(entities, nil, nil, nil)
+	states := make([]*EntityState, 0, len(entities))
+	for slot, entity := range components.Entities {
+		entityState := components.entityState(slot)
+
+		// Convert DNA if present
+		if sm.world.CellularSystem != nil {
+			if organism, exists := sm.world.CellularSystem.OrganismMap[entity.ID]; exists && len(organism.Cells) > 0 && organism.Cells[0].DNA != nil {
+				entityState.DNA = sm.convertDNAToState(organism.Cells[0].DNA)
+			}
+		}
+
+		// Convert Cellular if present
+		if sm.world.CellularSystem != nil {
+			if organism, exists := sm.world.CellularSystem.OrganismMap[entity.ID]; exists {
+				entityState.Cellular = sm.convertCellularToState(organism)
+			}
+		}
+
+		states = append(states, entityState)
+	}
+	return states
+}
+
+// CurrentState returns a serializable snapshot of the live world
+func (sm *StateManager) CurrentState() (*SimulationState, error) {
+	return sm.createState()
+}
+
+// LoadStateFile reads a save file without applying it to any world, upgrading it to the
+// current schema version
+func LoadStateFile(filename string) (*SimulationState, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %v", err)
+	}
+
+	state, _, err := DecodeState(data)
+	return state, err
+}
+
+// logStateMigrations reports the upgrades applied to a save while loading it
+func logStateMigrations(applied []StateMigration) {
+	for _, migration := range applied {
+		log.Printf("Upgraded save from schema version %d to %d: %s", migration.From, migration.To, migration.Description)
+	}
+}
+
+// LoadWorldFile builds a world from a save file, sized and configured as the save records
+func LoadWorldFile(filename string) (*World, error) {
+	state, err := LoadStateFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if report := ValidateState(state, true); report.UnrepairedCount() > 0 {
+		return nil, fmt.Errorf("invalid save file:\n%s", report.Summary())
+	}
+	world := NewWorld(state.Config)
+	if err := NewStateManager(world).restoreState(state); err != nil {
+		return nil, fmt.Errorf("failed to restore state: %v", err)
+	}
+	return world, nil
+}
+
 // convertDNAToState converts DNA structure to serializable state
 func (sm *StateManager) convertDNAToState(dna *DNAStrand) *DNAState {
 	if dna == nil {
@@ -581,17 +686,79 @@ func (sm *StateManager) convertCellularToState(organism *CellularOrganism) *Cell
 
 // restoreState restores the world from a serializable state
 func (sm *StateManager) restoreState(state *SimulationState) error {
+	// Custom traits travel with the save
+	if err := sm.world.setCustomTraits(state.Config.CustomTraits); err != nil {
+		return fmt.Errorf("invalid custom traits: %v", err)
+	}
+
 	// Basic state restoration
 	sm.world.Tick = state.Tick
 	sm.world.NextID = state.NextID
 	sm.world.NextPlantID = state.NextPlantID
 	sm.world.Config = state.Config
+	sm.world.setGeometry()
+	if state.Config.Simulation != nil {
+		simConfig := *state.Config.Simulation
+		sm.world.SimConfig = &simConfig
+	}
+	sm.world.RandomEventsOff = sm.world.RandomEventsOff || state.Config.RandomEventsOff
+	if state.Seed != 0 {
+		sm.world.Seed = state.Seed
+	}
+
+	// The grid and grid-sized systems were built for the current dimensions, so keep them
+	if len(sm.world.Grid) > 0 {
+		sm.world.Config.GridHeight = len(sm.world.Grid)
+		sm.world.Config.GridWidth = len(sm.world.Grid[0])
+	}
 
 	// Clear existing data
 	sm.world.AllEntities = make([]*Entity, 0)
 	sm.world.AllPlants = make([]*Plant, 0)
 	sm.world.Events = make([]*WorldEvent, 0)
 	sm.world.Populations = make(map[string]*Population)
+	if sm.world.Interventions != nil {
+		sm.world.Interventions.Clear()
+	}
+	if sm.world.HashChain != nil {
+		sm.world.HashChain.Restart(state.Tick)
+	}
+	if sm.world.ResourceBudget != nil {
+		sm.world.ResourceBudget.Clear()
+	}
+	if sm.world.Resources != nil {
+		sm.world.Resources.Clear()
+	}
+	if sm.world.Mutations != nil {
+		sm.world.Mutations.Clear()
+	}
+	if sm.world.BiomeStates != nil {
+		sm.world.BiomeStates.Clear()
+	}
+	if sm.world.Microclimates != nil {
+		sm.world.Microclimates.Clear()
+	}
+	if sm.world.Pollution != nil {
+		sm.world.Pollution.Clear()
+	}
+	if sm.world.LightPollution != nil {
+		sm.world.LightPollution.Clear()
+	}
+	if sm.world.NoisePollution != nil {
+		sm.world.NoisePollution.Clear()
+	}
+	if sm.world.Zoonoses != nil {
+		sm.world.Zoonoses.Clear()
+	}
+	if sm.world.FoodStorage != nil {
+		sm.world.FoodStorage.Clear()
+	}
+	if sm.world.Demographics != nil {
+		sm.world.Demographics.Clear()
+	}
+	if sm.world.RedQueen != nil {
+		sm.world.RedQueen.Clear()
+	}
 
 	// Restore biomes
 	for y := 0; y < len(sm.world.Grid) && y < len(state.Biomes); y++ {
@@ -623,15 +790,13 @@ func (sm *StateManager) restoreState(state *SimulationState) error {
 	}
 
 	// Recreate populations from grouped entities
-	for species, entities := range populationGroups {
+	for _, species := range sortedKeys(populationGroups) {
+		entities := populationGroups[species]
 		if len(entities) > 0 {
 			// Get trait names from first entity
-			traitNames := make([]string, 0, len(entities[0].Traits))
-			for name := range entities[0].Traits {
-				traitNames = append(traitNames, name)
-			}
+			traitNames := sortedKeys(entities[0].Traits)
 
-			pop := NewPopulation(len(entities), traitNames, 0.1, 0.2)
+			pop := NewPopulation(sm.world.Rand, len(entities), traitNames, 0.1, 0.2)
 			pop.Species = species
 
 			// Copy entities to population
@@ -691,6 +856,10 @@ func (sm *StateManager) restoreState(state *SimulationState) error {
 		sm.world.AdvancedTimeSystem.Temperature = state.Time.Temperature
 		sm.world.AdvancedTimeSystem.Illumination = state.Time.Illumination
 		sm.world.AdvancedTimeSystem.SeasonalMod = state.Time.SeasonalMod
+		sm.world.dayNight() // Daylight follows the restored clock
+		if sm.world.Seasons != nil {
+			sm.world.Seasons.Restart()
+		}
 	}
 
 	// Restore wind system
@@ -708,22 +877,33 @@ func (sm *StateManager) restoreState(state *SimulationState) error {
 		// TODO: Restore species data - complex due to plant references
 	}
 
+	if sm.world.Achievements != nil {
+		sm.world.Achievements.Restore(state.Achievements)
+	}
+	if sm.world.Migrations != nil {
+		sm.world.Migrations.Restore(state.Migrations)
+	}
+	if sm.world.PopulationCaps != nil {
+		sm.world.PopulationCaps.Restore(state.Dispersal, sm.restoreEntity)
+	}
+	// Novelty archives start afresh; a population that died out keeps no strategy
+	for _, population := range sortedKeys(state.Selection) {
+		if _, exists := sm.world.Populations[population]; exists {
+			if err := sm.world.SetSelectionStrategy(population, state.Selection[population]); err != nil {
+				return fmt.Errorf("failed to restore selection: %v", err)
+			}
+		}
+	}
+
 	return nil
 }
 
 // restoreEntity creates an entity from its serialized state
 func (sm *StateManager) restoreEntity(state *EntityState) *Entity {
-	entity := &Entity{
-		ID:         state.ID,
-		Species:    state.Species,
-		Position:   state.Position,
-		Traits:     make(map[string]Trait), // Changed to map[string]Trait
-		Fitness:    state.Fitness,
-		Energy:     state.Energy,
-		Age:        state.Age,
-		IsAlive:    true,
-		Generation: 0, // Will be set later if needed
-	}
+	entity := NewEntity(sm.world.Rand, state.ID, []string{}, state.Species, state.Position)
+	entity.Fitness = state.Fitness
+	entity.Energy = state.Energy
+	entity.Age = state.Age
 
 	// Restore traits
 	for traitName, value := range state.Traits {
@@ -733,6 +913,18 @@ func (sm *StateManager) restoreEntity(state *EntityState) *Entity {
 		}
 	}
 
+	// Molecular and rhythm systems depend on traits, so rebuild them now that traits are set;
+	// without them a loaded creature has no lifespan and dies on the first tick
+	entity.MolecularNeeds = NewMolecularNeeds(entity)
+	entity.MolecularMetabolism = NewMolecularMetabolism(sm.world.Rand, entity)
+	entity.MolecularProfile = CreateEntityMolecularProfile(entity)
+	entity.BioRhythm = NewBioRhythm(sm.world.Rand, entity.ID, entity)
+	AddCasteStatusToEntity(sm.world.Rand, entity)
+	if IsEntityInsectLike(entity) {
+		AddInsectTraitsToEntity(sm.world.Rand, entity)
+		AddPollinatorTraitsToEntity(sm.world.Rand, entity)
+	}
+
 	// Restore DNA and Cellular data if present
 	if state.DNA != nil && sm.world.CellularSystem != nil {
 		// Restore the cellular organism and DNA
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// StateSchemaVersion is the version of the save format StateManager writes. Saves from
+// before schema versions were recorded, and states built without one, are version 1.
+const StateSchemaVersion = 2
+
+// stateMigration upgrades a decoded save from one schema version to the next
+type stateMigration struct {
+	from        int
+	description string
+	migrate     func(doc map[string]interface{}) error
+}
+
+// stateMigrations upgrade saves one version at a time, in order. Each changes the save's
+// JSON document rather than SimulationState, which only knows the current schema.
+var stateMigrations = []stateMigration{
+	{from: 1, description: "number the schema instead of the free-form version string", migrate: func(doc map[string]interface{}) error {
+		delete(doc, "version")
+		return nil
+	}},
+}
+
+// StateMigration is an upgrade applied to a save while loading it
+type StateMigration struct {
+	From        int    `json:"from"`
+	To          int    `json:"to"`
+	Description string `json:"description"`
+}
+
+// stateSchemaOf reads the schema version a decoded save was written in
+func stateSchemaOf(doc map[string]interface{}) (int, error) {
+	raw, exists := doc["schema_version"]
+	if !exists {
+		return 1, nil
+	}
+	var version float64
+	switch value := raw.(type) {
+	case float64:
+		version = value
+	case int:
+		version = float64(value)
+	case json.Number:
+		parsed, err := value.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("invalid schema version %q", value)
+		}
+		version = parsed
+	default:
+		return 0, fmt.Errorf("invalid schema version %v", raw)
+	}
+	if version < 0 || version != float64(int(version)) {
+		return 0, fmt.Errorf("invalid schema version %v", raw)
+	}
+	return max(1, int(version)), nil
+}
+
+// MigrateStateDocument upgrades a decoded save to the current schema version in place,
+// returning the upgrades applied. Saves from a newer version, and saves holding fields the
+// current schema doesn't have, are refused with an error naming them.
+func MigrateStateDocument(doc map[string]interface{}) ([]StateMigration, error) {
+	version, err := stateSchemaOf(doc)
+	if err != nil {
+		return nil, err
+	}
+	if version > StateSchemaVersion {
+		return nil, fmt.Errorf("save is schema version %d, newer than version %d this build reads", version, StateSchemaVersion)
+	}
+
+	applied := make([]StateMigration, 0)
+	for version < StateSchemaVersion {
+		index := slices.IndexFunc(stateMigrations, func(migration stateMigration) bool { return migration.from == version })
+		if index < 0 {
+			return nil, fmt.Errorf("no upgrade from save schema version %d", version)
+		}
+		migration := stateMigrations[index]
+		if err := migration.migrate(doc); err != nil {
+			return nil, fmt.Errorf("upgrading save from schema version %d: %v", version, err)
+		}
+		applied = append(applied, StateMigration{From: version, To: version + 1, Description: migration.description})
+		version++
+	}
+	doc["schema_version"] = version
+
+	if fields := unsupportedStateFields(doc); len(fields) > 0 {
+		return nil, fmt.Errorf("save has fields schema version %d does not support: %s", version, strings.Join(fields, ", "))
+	}
+	return applied, nil
+}
+
+// decodeStateDocument decodes a save's JSON keeping numbers exact, so seeds and IDs survive
+// the round trip through migration
+func decodeStateDocument(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc map[string]interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("save is not a JSON object")
+	}
+	return doc, nil
+}
+
+// stateFromDocument upgrades a decoded save and converts it to a SimulationState
+func stateFromDocument(doc map[string]interface{}) (*SimulationState, []StateMigration, error) {
+	applied, err := MigrateStateDocument(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal upgraded state: %v", err)
+	}
+	var state SimulationState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal state: %v", err)
+	}
+	return &state, applied, nil
+}
+
+// DecodeState decodes a JSON save of any supported schema version, upgrading it to the current
+// one, or a binary save of the current version
+func DecodeState(data []byte) (*SimulationState, []StateMigration, error) {
+	if saveFormatOf(data) == SaveFormatBinary {
+		state, err := decodeBinaryState(data)
+		return state, nil, err
+	}
+	doc, err := decodeStateDocument(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal state: %v", err)
+	}
+	return stateFromDocument(doc)
+}
+
+// unmarshalerTypes decode themselves, so their JSON is not checked field by field
+var unmarshalerTypes = []reflect.Type{
+	reflect.TypeOf((*json.Unmarshaler)(nil)).Elem(),
+	reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem(),
+}
+
+// unsupportedStateFields lists the fields of a decoded save that SimulationState has no
+// place for, which loading would otherwise drop without a word. Fields of list elements are
+// named once for the whole list, as in entities[].wings.
+func unsupportedStateFields(doc map[string]interface{}) []string {
+	found := make(map[string]bool)
+	collectUnsupportedFields(doc, reflect.TypeOf(SimulationState{}), "", found)
+	return sortedKeys(found)
+}
+
+// collectUnsupportedFields walks a decoded JSON value alongside the type it decodes into.
+// Values of the wrong kind are left for json.Unmarshal to report.
+func collectUnsupportedFields(value interface{}, t reflect.Type, path string, found map[string]bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	for _, unmarshaler := range unmarshalerTypes {
+		if reflect.PointerTo(t).Implements(unmarshaler) {
+			return
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for key, child := range object {
+			field, exists := fields[key]
+			if !exists {
+				// Like json.Unmarshal, fall back to a case-insensitive match
+				for name, candidate := range fields {
+					if strings.EqualFold(name, key) {
+						field, exists = candidate, true
+						break
+					}
+				}
+			}
+			if !exists {
+				found[joinFieldPath(path, key)] = true
+				continue
+			}
+			collectUnsupportedFields(child, field, joinFieldPath(path, key), found)
+		}
+	case reflect.Slice, reflect.Array:
+		if list, ok := value.([]interface{}); ok {
+			for _, child := range list {
+				collectUnsupportedFields(child, t.Elem(), path+"[]", found)
+			}
+		}
+	case reflect.Map:
+		if object, ok := value.(map[string]interface{}); ok {
+			for key, child := range object {
+				collectUnsupportedFields(child, t.Elem(), path+"["+key+"]", found)
+			}
+		}
+	}
+}
+
+// joinFieldPath names a field inside the value at path
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// jsonFields maps the JSON names of a struct's fields to their types, including the fields
+// of embedded structs
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for embeddedName, embeddedType := range jsonFields(embedded) {
+					if _, shadowed := fields[embeddedName]; !shadowed {
+						fields[embeddedName] = embeddedType
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// runMigrateCommand implements the "evosim analyze migrate [--format json|binary] [--out file] <save>"
+// subcommand
+func runMigrateCommand(args []string) error {
+	fs := newCommandFlags("migrate")
+	out := fs.String("out", "", "Output file for the upgraded save (default: overwrite input)")
+	format := fs.String("format", "", "Save format to write: json or binary (default: the input's format)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := applyEnvironment(fs, commandEnvPrefix(fs.Name())); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return commandUsage("migrate")
+	}
+	filename := fs.Arg(0)
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read state file: %v", err)
+	}
+	state, applied, err := DecodeState(data)
+	if err != nil {
+		return err
+	}
+	target := *out
+	if target == "" {
+		target = filename
+	}
+	targetFormat := *format
+	if targetFormat == "" {
+		targetFormat = saveFormatOf(data)
+	}
+	if len(applied) == 0 && targetFormat == saveFormatOf(data) && target == filename {
+		fmt.Printf("Save is already schema version %d\n", StateSchemaVersion)
+		return nil
+	}
+	for _, migration := range applied {
+		fmt.Printf("Upgraded schema version %d to %d: %s\n", migration.From, migration.To, migration.Description)
+	}
+
+	if err := writeStateAs(state, target, targetFormat); err != nil {
+		return err
+	}
+	fmt.Printf("Upgraded save written to %s\n", target)
+	return nil
+}
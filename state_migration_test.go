@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// legacyStateDocument is a save of the live world as schema version 1 wrote it
+func legacyStateDocument(t *testing.T, world *World) map[string]interface{} {
+	state, err := NewStateManager(world).CurrentState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(state)
+	doc, err := decodeStateDocument(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delete(doc, "schema_version")
+	doc["version"] = "1.0"
+	return doc
+}
+
+func TestStateSchemaRoundTrip(t *testing.T) {
+	world := newSteppingTestWorld(130)
+	world.Seed = 1234567890123456789 // Beyond the integers a float64 holds exactly
+	if _, err := world.Step(5); err != nil {
+		t.Fatal(err)
+	}
+	state, _ := NewStateManager(world).CurrentState()
+	if state.SchemaVersion != StateSchemaVersion {
+		t.Errorf("Expected saves written in schema version %d, got %d", StateSchemaVersion, state.SchemaVersion)
+	}
+	data, _ := json.Marshal(state)
+	loaded, applied, err := DecodeState(data)
+	if err != nil {
+		t.Fatalf("Expected a current save to load, got %v", err)
+	}
+	if len(applied) != 0 || loaded.Seed != world.Seed || len(loaded.Entities) != len(state.Entities) {
+		t.Errorf("Expected a current save loaded untouched, got %d upgrades and seed %d", len(applied), loaded.Seed)
+	}
+}
+
+func TestStateMigrationUpgradesOldSaves(t *testing.T) {
+	world := newSteppingTestWorld(131)
+	if _, err := world.Step(5); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(legacyStateDocument(t, world))
+	state, applied, err := DecodeState(data)
+	if err != nil {
+		t.Fatalf("Expected a version 1 save upgraded, got %v", err)
+	}
+	if len(applied) != StateSchemaVersion-1 || applied[0].From != 1 || state.SchemaVersion != StateSchemaVersion || state.Tick != world.Tick {
+		t.Errorf("Expected the save upgraded one version at a time to %d, got %+v", StateSchemaVersion, applied)
+	}
+
+	// Every loader upgrades old saves
+	dir := t.TempDir()
+	file := filepath.Join(dir, "legacy.json")
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewStateManager(newSteppingTestWorld(131)).LoadFromFile(file); err != nil {
+		t.Errorf("Expected a version 1 save file loaded, got %v", err)
+	}
+	if err := NewStateManager(newSteppingTestWorld(131)).LoadFromData(legacyStateDocument(t, world)); err != nil {
+		t.Errorf("Expected a version 1 upload loaded, got %v", err)
+	}
+	if _, truncated, err := ParseStateWithRecovery(data[:len(data)*2/3]); err != nil || !truncated {
+		t.Errorf("Expected a truncated version 1 save salvaged and upgraded, got %v", err)
+	}
+
+	// The migrate command rewrites the save in the current schema
+	if err := runMigrateCommand([]string{file}); err != nil {
+		t.Fatal(err)
+	}
+	upgraded, _ := os.ReadFile(file)
+	doc, _ := decodeStateDocument(upgraded)
+	if _, stale := doc["version"]; stale || doc["schema_version"] != json.Number("2") {
+		t.Errorf("Expected the save rewritten in schema version %d, got version %v", StateSchemaVersion, doc["schema_version"])
+	}
+}
+
+func TestStateMigrationRefusesWhatItCannotRead(t *testing.T) {
+	world := newSteppingTestWorld(132)
+	doc := legacyStateDocument(t, world)
+	doc["schema_version"] = StateSchemaVersion + 1
+	if _, err := MigrateStateDocument(doc); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("Expected a save from a newer version refused, got %v", err)
+	}
+	doc["schema_version"] = "two"
+	if _, err := MigrateStateDocument(doc); err == nil {
+		t.Error("Expected a malformed schema version refused")
+	}
+
+	doc = legacyStateDocument(t, world)
+	doc["colour"] = "blue"
+	entities := doc["entities"].([]interface{})
+	for _, entity := range entities {
+		entity.(map[string]interface{})["wings"] = 2
+	}
+	doc["config"].(map[string]interface{})["gridwidth"] = 40 // Matched case-insensitively, as json.Unmarshal does
+	_, err := MigrateStateDocument(doc)
+	if err == nil || !strings.HasSuffix(err.Error(), "does not support: colour, entities[].wings") {
+		t.Errorf("Expected every unsupported field named once, got %v", err)
+	}
+}
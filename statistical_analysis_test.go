@@ -30,7 +30,7 @@ func TestStatisticalEventLogging(t *testing.T) {
 	reporter := NewStatisticalReporter(100, 10, 5, 10)
 
 	// Create a test entity
-	entity := NewEntity(1, []string{"speed", "size", "energy"}, "test_species", Position{X: 10, Y: 10})
+	entity := NewEntity(sharedRand, 1, []string{"speed", "size", "energy"}, "test_species", Position{X: 10, Y: 10})
 
 	// Log an entity event
 	reporter.LogEntityEvent(1, "test_event", entity, 50.0, 75.0, nil)
@@ -77,7 +77,7 @@ func TestStatisticalSnapshot(t *testing.T) {
 
 	// Add some test entities
 	for i := 0; i < 3; i++ {
-		entity := NewEntity(i, []string{"speed", "size", "energy"}, "test_species", Position{X: float64(i * 10), Y: float64(i * 10)})
+		entity := NewEntity(sharedRand, i, []string{"speed", "size", "energy"}, "test_species", Position{X: float64(i * 10), Y: float64(i * 10)})
 		entity.Energy = 50.0 + float64(i*10)
 		world.AllEntities = append(world.AllEntities, entity)
 	}
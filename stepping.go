@@ -0,0 +1,81 @@
+package main
+
+import "fmt"
+
+// maxStepTicks bounds a single step call so a scripted client cannot stall the server
+const maxStepTicks = 10000
+
+// StepResult is the state of the world after an atomic step
+type StepResult struct {
+	Requested   int               `json:"requested"`
+	Executed    int               `json:"executed"`
+	Tick        int               `json:"tick"`
+	Digest      string            `json:"digest"`               // Hash of the whole simulation state
+	Subsystems  map[string]string `json:"subsystems"`           // Hash of each fingerprinted subsystem
+	StoppedBy   string            `json:"stopped_by,omitempty"` // Why fewer ticks ran than requested
+	EntityCount int               `json:"entity_count"`         // Living creatures
+	PlantCount  int               `json:"plant_count"`          // Living plants
+	Populations map[string]int    `json:"populations"`          // Living creatures per species
+}
+
+// Step advances the world by exactly ticks updates and leaves it paused, so scripted
+// experiments and agents see the state the step produced and nothing after it. A breakpoint
+// firing part way through ends the step early. The caller must keep anything else from
+// updating the world during the step.
+func (w *World) Step(ticks int) (StepResult, error) {
+	if ticks < 1 || ticks > maxStepTicks {
+		return StepResult{}, fmt.Errorf("step between 1 and %d ticks, got %d", maxStepTicks, ticks)
+	}
+
+	result := StepResult{Requested: ticks}
+	for result.Executed < ticks {
+		w.Paused = false
+		w.Update()
+		result.Executed++
+		if w.Paused {
+			result.StoppedBy = "breakpoint"
+			break
+		}
+	}
+	w.Paused = true
+
+	w.describeState(&result)
+	return result, nil
+}
+
+// StateDigest describes the world's current state without advancing it
+func (w *World) StateDigest() StepResult {
+	var result StepResult
+	w.describeState(&result)
+	return result
+}
+
+// digest combines the subsystem hashes into one digest of the whole simulation state
+func (fingerprint worldFingerprint) digest() string {
+	h := newStateHasher()
+	for _, subsystem := range determinismSubsystems {
+		h.uint(fingerprint[subsystem])
+	}
+	return fmt.Sprintf("%016x", h.sum())
+}
+
+// describeState fills in the tick, digests and head counts of a step result
+func (w *World) describeState(result *StepResult) {
+	fingerprint := fingerprintWorld(w)
+	result.Subsystems = make(map[string]string, len(determinismSubsystems))
+	for _, subsystem := range determinismSubsystems {
+		result.Subsystems[subsystem] = fmt.Sprintf("%016x", fingerprint[subsystem])
+	}
+	result.Tick = w.Tick
+	result.Digest = fingerprint.digest()
+
+	result.Populations = livingSpeciesCounts(w)
+	for _, count := range result.Populations {
+		result.EntityCount += count
+	}
+	for _, plant := range w.AllPlants {
+		if plant.IsAlive {
+			result.PlantCount++
+		}
+	}
+}
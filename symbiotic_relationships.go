@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
 )
@@ -52,6 +53,10 @@ type SymbioticRelationshipSystem struct {
 	ActiveCommensal         int     `json:"active_commensal"`
 	AverageRelationshipAge  float64 `json:"average_relationship_age"`
 	DiseaseTransmissionRate float64 `json:"disease_transmission_rate"`
+
+	EventBus *CentralEventBus `json:"-"` // Optional; receives new relationships
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // NewSymbioticRelationshipSystem creates a new symbiotic relationship system
@@ -64,6 +69,7 @@ func NewSymbioticRelationshipSystem() *SymbioticRelationshipSystem {
 		DissolutionRate:     0.005, // 0.5% chance per tick
 		TransmissionRadius:  5.0,   // 5 units radius for transmission
 		CoevolutionRate:     0.002, // 0.2% evolutionary change per tick
+		rng:                 sharedRand,
 	}
 }
 
@@ -111,7 +117,7 @@ func (srs *SymbioticRelationshipSystem) updateExistingRelationships(world *World
 		srs.applyRelationshipEffects(relationship, host, symbiont, world, tick)
 
 		// Check for relationship dissolution
-		if rand.Float64() < srs.DissolutionRate {
+		if srs.rng.Float64() < srs.DissolutionRate {
 			relationship.IsActive = false
 			srs.RelationshipHistory = append(srs.RelationshipHistory, relationship)
 			srs.Relationships = append(srs.Relationships[:i], srs.Relationships[i+1:]...)
@@ -121,7 +127,7 @@ func (srs *SymbioticRelationshipSystem) updateExistingRelationships(world *World
 
 // formNewRelationships creates new symbiotic relationships
 func (srs *SymbioticRelationshipSystem) formNewRelationships(world *World, tick int) {
-	if rand.Float64() > srs.FormationRate {
+	if srs.rng.Float64() > srs.FormationRate {
 		return
 	}
 
@@ -142,8 +148,7 @@ func (srs *SymbioticRelationshipSystem) formNewRelationships(world *World, tick
 			}
 
 			// Check proximity
-			distance := math.Sqrt(math.Pow(entity1.Position.X-entity2.Position.X, 2) +
-				math.Pow(entity1.Position.Y-entity2.Position.Y, 2))
+			distance := world.Distance(entity1.Position, entity2.Position)
 			if distance > srs.TransmissionRadius {
 				continue
 			}
@@ -251,6 +256,22 @@ func (srs *SymbioticRelationshipSystem) createRelationship(entity1, entity2 *Ent
 
 	srs.NextRelationshipID++
 	srs.Relationships = append(srs.Relationships, relationship)
+
+	if srs.EventBus != nil {
+		typeName := relationshipTypeName(relType)
+		srs.EventBus.EmitSystemEvent(tick, "symbiosis_formed", "symbiosis", "symbiotic_relationships",
+			fmt.Sprintf("Entity %d (%s) and entity %d (%s) formed a %s relationship",
+				host.ID, host.Species, symbiont.ID, symbiont.Species, typeName),
+			&host.Position, map[string]interface{}{
+				"relationship_id":   relationship.ID,
+				"relationship_type": typeName,
+				"host_id":           host.ID,
+				"host_species":      host.Species,
+				"symbiont_id":       symbiont.ID,
+				"symbiont_species":  symbiont.Species,
+				"strength":          strength,
+			})
+	}
 }
 
 // applyRelationshipEffects applies the effects of a symbiotic relationship
@@ -258,7 +279,7 @@ func (srs *SymbioticRelationshipSystem) applyRelationshipEffects(relationship *S
 	// Apply energy/health effects
 	if relationship.HostBenefit != 0 {
 		host.Energy = math.Max(0, host.Energy+relationship.HostBenefit*0.1)
-		if relationship.HostBenefit < 0 && rand.Float64() < math.Abs(relationship.HostBenefit)*0.1 {
+		if relationship.HostBenefit < 0 && srs.rng.Float64() < math.Abs(relationship.HostBenefit)*0.1 {
 			// Parasitic relationship can reduce host health
 			if healthTrait, exists := host.Traits["health"]; exists {
 				host.Traits["health"] = Trait{Value: math.Max(0, healthTrait.Value-0.05)}
@@ -279,16 +300,16 @@ func (srs *SymbioticRelationshipSystem) applyRelationshipEffects(relationship *S
 		}
 
 		// Increase mutation rate in host (evolutionary pressure)
-		if relationship.Duration%10 == 0 && rand.Float64() < srs.CoevolutionRate {
+		if relationship.Duration%10 == 0 && srs.rng.Float64() < srs.CoevolutionRate {
 			// Small chance to mutate defense or health traits
-			if rand.Float64() < 0.5 {
+			if srs.rng.Float64() < 0.5 {
 				if defenseTrait, exists := host.Traits["defense"]; exists {
-					newDefense := defenseTrait.Value + (rand.Float64()-0.5)*0.1
+					newDefense := defenseTrait.Value + (srs.rng.Float64()-0.5)*0.1
 					host.Traits["defense"] = Trait{Value: math.Max(0, math.Min(1, newDefense))}
 				}
 			} else {
 				if healthTrait, exists := host.Traits["health"]; exists {
-					newHealth := healthTrait.Value + (rand.Float64()-0.5)*0.1
+					newHealth := healthTrait.Value + (srs.rng.Float64()-0.5)*0.1
 					host.Traits["health"] = Trait{Value: math.Max(0, math.Min(1, newHealth))}
 				}
 			}
@@ -328,8 +349,7 @@ func (srs *SymbioticRelationshipSystem) handleDiseaseTransmission(world *World,
 				continue
 			}
 
-			distance := math.Sqrt(math.Pow(host.Position.X-nearbyEntity.Position.X, 2) +
-				math.Pow(host.Position.Y-nearbyEntity.Position.Y, 2))
+			distance := world.Distance(host.Position, nearbyEntity.Position)
 
 			if distance <= srs.TransmissionRadius {
 				// Check if entity is already infected
@@ -342,7 +362,7 @@ func (srs *SymbioticRelationshipSystem) handleDiseaseTransmission(world *World,
 				nearbyResistance := nearbyEntity.Traits["defense"].Value
 				finalProb := transmissionProb * (1.0 - nearbyResistance*0.5)
 
-				if rand.Float64() < finalProb {
+				if srs.rng.Float64() < finalProb {
 					// Create new parasitic relationship (disease transmission)
 					symbiont := world.findEntityByID(relationship.SymbiontID)
 					if symbiont != nil && symbiont.IsAlive {
@@ -361,12 +381,12 @@ func (srs *SymbioticRelationshipSystem) createTransmittedRelationship(newHost *E
 		HostID:          newHost.ID,
 		SymbiontID:      originalRelationship.SymbiontID, // Same pathogen
 		Type:            RelationshipParasitic,
-		Strength:        originalRelationship.Strength * (0.8 + rand.Float64()*0.4), // Some variation
+		Strength:        originalRelationship.Strength * (0.8 + srs.rng.Float64()*0.4), // Some variation
 		Duration:        0,
 		HostBenefit:     originalRelationship.HostBenefit,
 		SymbiontBenefit: originalRelationship.SymbiontBenefit,
-		Virulence:       originalRelationship.Virulence * (0.9 + rand.Float64()*0.2), // Evolution
-		Transmission:    originalRelationship.Transmission * (0.9 + rand.Float64()*0.2),
+		Virulence:       originalRelationship.Virulence * (0.9 + srs.rng.Float64()*0.2), // Evolution
+		Transmission:    originalRelationship.Transmission * (0.9 + srs.rng.Float64()*0.2),
 		Resistance:      newHost.Traits["defense"].Value * 0.5,
 		MutationRate:    originalRelationship.MutationRate,
 		IsActive:        true,
@@ -391,18 +411,18 @@ func (srs *SymbioticRelationshipSystem) applyCoevolutionaryPressure(world *World
 		}
 
 		// Host evolution: increase resistance
-		if rand.Float64() < srs.CoevolutionRate {
+		if srs.rng.Float64() < srs.CoevolutionRate {
 			if defenseTrait, exists := host.Traits["defense"]; exists {
-				mutationAmount := (rand.Float64() - 0.5) * 0.2
+				mutationAmount := (srs.rng.Float64() - 0.5) * 0.2
 				newDefense := defenseTrait.Value + mutationAmount
 				host.Traits["defense"] = Trait{Value: math.Max(0, math.Min(1, newDefense))}
 			}
 		}
 
 		// Symbiont evolution: adjust virulence and transmission
-		if rand.Float64() < relationship.MutationRate {
-			virulenceMutation := (rand.Float64() - 0.5) * 0.1
-			transmissionMutation := (rand.Float64() - 0.5) * 0.1
+		if srs.rng.Float64() < relationship.MutationRate {
+			virulenceMutation := (srs.rng.Float64() - 0.5) * 0.1
+			transmissionMutation := (srs.rng.Float64() - 0.5) * 0.1
 
 			relationship.Virulence = math.Max(0, math.Min(1, relationship.Virulence+virulenceMutation))
 			relationship.Transmission = math.Max(0, math.Min(1, relationship.Transmission+transmissionMutation))
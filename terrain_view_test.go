@@ -0,0 +1,35 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestTerrainViewServesWholeWorld(t *testing.T) {
+	world := newSteppingTestWorld(113)
+	wi := NewWebInterface(world)
+
+	rec := httptest.NewRecorder()
+	wi.Routes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/3d", nil))
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, `id="terrainCanvas"`) ||
+		!regexp.MustCompile(`/static/js/terrain\.[0-9a-f]+\.js`).MatchString(body) {
+		t.Fatalf("Expected the 3D page to link its fingerprinted script, got %d", rec.Code)
+	}
+
+	// The page asks for the map centred, with a radius that reaches every edge
+	width, height := world.Config.GridWidth, world.Config.GridHeight
+	maxTiles := 4 * (int(math.Ceil(float64(max(width, height))/2)) + 1)
+	data := NewIsometricViewManager(world).GenerateIsometricData(width/2, height/2, 1, maxTiles)
+	if len(data.Tiles) != width*height {
+		t.Errorf("Expected all %d tiles of the map, got %d", width*height, len(data.Tiles))
+	}
+	info := data.WorldInfo
+	if info.MapWidth != world.Config.Width || info.MapHeight != world.Config.Height || info.SeaLevel != world.TopologySystem.SeaLevel {
+		t.Errorf("Expected the map's size and sea level for placing billboards, got %+v", info)
+	}
+}
@@ -0,0 +1,218 @@
+import { test, expect, Page, APIRequestContext } from '@playwright/test';
+
+// Cross-frontend end-to-end suite. playwright.e2e.config.ts boots a seeded world; these tests
+// drive its pages in a headless browser and check what they show against the HTTP API, so a
+// tab that stops rendering, renders stale data or breaks a player flow fails here.
+
+// Every tab the web interface offers, as initViewTabs builds them from viewModes
+async function viewTabs(page: Page): Promise<string[]> {
+  return page.locator('.view-tab').allTextContents();
+}
+
+// Collects the page's uncaught errors, console errors and alerts (the page alerts server
+// errors) so a test can assert none happened
+function watchForErrors(page: Page): string[] {
+  const errors: string[] = [];
+  page.on('pageerror', error => errors.push(`page error: ${error.message}`));
+  page.on('console', message => {
+    if (message.type() === 'error') {
+      errors.push(`console error: ${message.text()}`);
+    }
+  });
+  page.on('dialog', dialog => {
+    errors.push(`alert: ${dialog.message()}`);
+    void dialog.dismiss();
+  });
+  return errors;
+}
+
+async function status(request: APIRequestContext) {
+  const response = await request.get('/api/status');
+  expect(response.ok()).toBeTruthy();
+  return response.json();
+}
+
+async function setPaused(request: APIRequestContext, paused: boolean) {
+  const response = await request.post('/api/control/pause', { data: { paused } });
+  expect(response.ok()).toBeTruthy();
+}
+
+// Reads a status bar counter such as "Tick: 42"
+async function statusCounter(page: Page, id: string): Promise<number> {
+  const text = (await page.locator(`#${id}`).textContent()) || '';
+  const match = text.match(/(\d+)/);
+  return match ? Number(match[1]) : NaN;
+}
+
+async function openHome(page: Page) {
+  await page.goto('/', { waitUntil: 'domcontentloaded' });
+  await expect(page.locator('#connection-status')).toContainText('Connected');
+  await expect.poll(() => statusCounter(page, 'tick')).toBeGreaterThan(0);
+}
+
+test.describe('Cross-frontend end-to-end', () => {
+  test.beforeAll(async ({ request }) => {
+    const initial = await status(request);
+    expect(initial.seed).toBe(Number(process.env.EVOSIM_E2E_SEED || 4242));
+    expect(initial.entities).toBeGreaterThan(0);
+  });
+
+  test.afterEach(async ({ request }) => {
+    await setPaused(request, false);
+  });
+
+  test('every view tab renders live data', async ({ page }) => {
+    const errors = watchForErrors(page);
+    await openHome(page);
+
+    const tabs = await viewTabs(page);
+    expect(tabs.length).toBeGreaterThan(20);
+    expect(tabs[0]).toBe('GRID');
+
+    for (const tab of tabs) {
+      await test.step(tab, async () => {
+        const tickBefore = await statusCounter(page, 'tick');
+        await page.locator('.view-tab', { hasText: new RegExp(`^${tab}$`) }).click();
+        await expect(page.locator('.view-tab.active')).toHaveText(tab);
+        await expect(page).toHaveURL(new RegExp(`#view/${tab}$`));
+
+        // The tab draws its own description and content, not the previous tab's
+        const content = page.locator('#view-content');
+        await expect(content.locator(`#${tab.toLowerCase()}-description-toggle`)).toBeAttached();
+        await expect.poll(async () => ((await content.innerText()) || '').length,
+          { message: `${tab} content should be rendered` }).toBeGreaterThan(200);
+
+        // It keeps updating as the world runs
+        await expect.poll(() => statusCounter(page, 'tick'),
+          { message: `${tab} should keep receiving frames` }).toBeGreaterThan(tickBefore);
+        const text = await content.innerText();
+        expect(text, `${tab} should not render missing values`).not.toMatch(/\bundefined\b|\bNaN\b|\[object Object\]/);
+      });
+    }
+    expect(errors).toEqual([]);
+  });
+
+  test('status bar and tabs agree with the API', async ({ page, request }) => {
+    const errors = watchForErrors(page);
+    await openHome(page);
+
+    // Paused, the frames the page receives and the API describe the same tick
+    await setPaused(request, true);
+    const paused = await status(request);
+    await expect.poll(() => statusCounter(page, 'tick')).toBe(paused.tick);
+    expect(await statusCounter(page, 'entities')).toBe(paused.entities);
+    expect(await statusCounter(page, 'plants')).toBe(paused.plants);
+    expect(await statusCounter(page, 'populations')).toBe(paused.populations);
+
+    // The grid draws only symbols its legend explains
+    const legendResponse = await request.get('/api/legend?renderer=web');
+    expect(legendResponse.ok()).toBeTruthy();
+    const [legend] = await legendResponse.json();
+    await expect(page.locator('#legend-content')).not.toContainText('Loading');
+    for (const section of legend.sections) {
+      for (const entry of section.entries) {
+        await expect(page.locator('#legend-content')).toContainText(entry.label);
+      }
+    }
+    await expect(page.locator('#grid-view')).not.toBeEmpty();
+
+    // Every population the API lists is on the populations tab
+    const populations = await (await request.get('/api/populations')).json();
+    expect(populations.length).toBe(paused.populations);
+    await page.locator('.view-tab', { hasText: /^POPULATIONS$/ }).click();
+    for (const population of populations) {
+      await expect(page.locator('#view-content')).toContainText(population.name);
+    }
+
+    // And the species tab counts the species the API profiles
+    const species = await (await request.get('/api/species')).json();
+    const living = species.filter((profile: { extinct: boolean }) => !profile.extinct);
+    await page.locator('.view-tab', { hasText: /^SPECIES$/ }).click();
+    await expect(page.locator('#species-container')).toContainText(`Active Species: ${living.length}`);
+    expect(errors).toEqual([]);
+  });
+
+  test('pause button stops and resumes the world', async ({ page, request }) => {
+    const errors = watchForErrors(page);
+    await openHome(page);
+
+    await page.locator('#pause-btn').click();
+    await expect(page.locator('#pause-btn')).toContainText('Resume');
+    await expect.poll(async () => (await (await request.get('/api/control')).json()).paused).toBe(true);
+    const pausedAt = (await status(request)).tick;
+    await page.waitForTimeout(1000);
+    expect((await status(request)).tick).toBe(pausedAt);
+
+    await page.locator('#pause-btn').click();
+    await expect(page.locator('#pause-btn')).toContainText('Pause');
+    await expect.poll(async () => (await status(request)).tick).toBeGreaterThan(pausedAt);
+    expect(errors).toEqual([]);
+  });
+
+  test('a player joins, creates a species and controls it', async ({ page, request }) => {
+    const errors = watchForErrors(page);
+    const executed: string[] = [];
+    page.on('console', message => {
+      if (message.text().startsWith('Command executed:')) {
+        executed.push(message.text());
+      }
+    });
+    await openHome(page);
+
+    // Join: names are validated in the page before they reach the server
+    await page.fill('#player-name-input', 'Bad<Name>');
+    await page.locator('#join-form button', { hasText: 'Join Game' }).click();
+    await expect(page.locator('#join-error')).toBeVisible();
+    await page.fill('#player-name-input', 'E2E Player');
+    await page.locator('#join-form button', { hasText: 'Join Game' }).click();
+    await expect(page.locator('#player-controls')).toBeVisible();
+    await expect(page.locator('#join-form')).toBeHidden();
+    await expect(page.locator('#player-name')).toHaveText('E2E Player');
+
+    // Create: the species joins the world the API describes
+    const speciesName = `Testkin ${Date.now() % 100000}`;
+    await page.locator('#create-species-btn').click();
+    await expect(page.locator('#create-species-form')).toBeVisible();
+    await page.fill('#species-name-input', speciesName);
+    await page.locator('#speed-trait').fill('0.3');
+    await page.locator('#create-species-form button', { hasText: 'Create Species' }).click();
+    await expect(page.locator('#create-species-form')).toBeHidden();
+    await expect(page.locator('#player-species-count')).toHaveText('1 species');
+    await expect.poll(async () => {
+      const populations = await (await request.get('/api/populations')).json();
+      return populations.map((population: { name: string }) => population.name);
+    }).toContain(speciesName);
+
+    // Control: the species is offered to the player and its commands are carried out
+    await page.locator('#control-species-btn').click();
+    await expect(page.locator('#control-species-form')).toBeVisible();
+    await page.selectOption('#species-select', speciesName);
+    await page.locator('#control-species-form button', { hasText: 'Gather Resources' }).click();
+    await expect(page.locator('#control-species-error')).toBeHidden();
+    await expect.poll(() => executed.length).toBeGreaterThan(0);
+    await page.locator('#control-species-form button', { hasText: 'Encourage Reproduction' }).click();
+    await expect.poll(() => executed.length).toBeGreaterThan(1);
+    expect(errors).toEqual([]);
+  });
+
+  test('the isometric and 3D views show the same world', async ({ page, request }) => {
+    const errors = watchForErrors(page);
+
+    await page.goto('/iso', { waitUntil: 'domcontentloaded' });
+    await expect(page.locator('#gameCanvas')).toBeVisible();
+    await expect.poll(async () => Number(await page.locator('#worldTick').textContent())).toBeGreaterThan(0);
+    const [legend] = await (await request.get('/api/legend?renderer=iso')).json();
+    for (const section of legend.sections) {
+      await expect(page.locator('#legendContent')).toContainText(section.label);
+    }
+
+    // Both pages follow the running world
+    const tick = (await status(request)).tick;
+    await expect.poll(async () => Number(await page.locator('#worldTick').textContent())).toBeGreaterThanOrEqual(tick);
+
+    await page.goto('/3d', { waitUntil: 'domcontentloaded' });
+    await expect(page.locator('#terrainCanvas')).toBeVisible();
+    await expect.poll(async () => Number(await page.locator('#worldTick').textContent())).toBeGreaterThanOrEqual(tick);
+    expect(errors).toEqual([]);
+  });
+});
@@ -77,7 +77,7 @@ test.describe('EvoSim Entity Type Screenshots', () => {
         const entities = gameState.isometricData.entities;
         const entityTypes = new Map();
 
-        // Function to determine entity type (same as in isometric_view.html)
+        // Function to determine entity type (same as in web/static/js/isometric.js)
         function determineEntityType(entity) {
           const traits = entity.traits;
           
@@ -467,7 +467,7 @@ test.describe('EvoSim Entity Type Screenshots', () => {
       const stats = {};
       const traitDistribution = {};
 
-      // Function to determine entity type (same as in isometric_view.html)
+      // Function to determine entity type (same as in web/static/js/isometric.js)
       function determineEntityType(entity) {
         const traits = entity.traits;
         
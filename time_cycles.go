@@ -48,6 +48,7 @@ type AdvancedTimeSystem struct {
 	WorldTick    int
 	DayLength    int // Ticks per day (from config)
 	SeasonLength int // Days per season (from config)
+	CycleLength  int // Ticks from one dawn to the next, dayPeriods when unset
 	TimeOfDay    TimeOfDay
 	Season       Season
 	DayNumber    int
@@ -87,9 +88,13 @@ func NewAdvancedTimeSystemLegacy(dayLength, seasonLength int) *AdvancedTimeSyste
 func (ats *AdvancedTimeSystem) Update() {
 	ats.WorldTick++
 
-	// With daily time scale, cycle through time of day within each day
+	// With daily time scale, cycle through time of day within each day-night cycle
 	// Use a simple 8-period day cycle for environmental variation
-	timeOfDayIndex := ats.WorldTick % 8
+	cycle := ats.CycleLength
+	if cycle <= 0 {
+		cycle = dayPeriods
+	}
+	timeOfDayIndex := ats.WorldTick % cycle * dayPeriods / cycle
 	ats.updateTimeOfDayFromIndex(timeOfDayIndex)
 
 	// Update day number (since each tick = 1 day, increment every tick)
@@ -214,6 +219,18 @@ func (ats *AdvancedTimeSystem) getSeasonalModifier() float64 {
 
 // GetTimeDescription returns a human-readable time description
 func (ats *AdvancedTimeSystem) GetTimeDescription() string {
+	seasonNames := map[Season]string{
+		Spring: "Spring",
+		Summer: "Summer",
+		Autumn: "Autumn",
+		Winter: "Winter",
+	}
+
+	return timeOfDayName(ats.TimeOfDay) + " of " + seasonNames[ats.Season]
+}
+
+// timeOfDayName returns the name of a time of day
+func timeOfDayName(timeOfDay TimeOfDay) string {
 	timeNames := map[TimeOfDay]string{
 		Dawn:      "Dawn",
 		Morning:   "Morning",
@@ -224,15 +241,7 @@ func (ats *AdvancedTimeSystem) GetTimeDescription() string {
 		Midnight:  "Midnight",
 		LateNight: "Late Night",
 	}
-
-	seasonNames := map[Season]string{
-		Spring: "Spring",
-		Summer: "Summer",
-		Autumn: "Autumn",
-		Winter: "Winter",
-	}
-
-	return timeNames[ats.TimeOfDay] + " of " + seasonNames[ats.Season]
+	return timeNames[timeOfDay]
 }
 
 // CircadianPreferences represents entity preferences for different times
@@ -406,14 +415,12 @@ func (mb *MigrationBehavior) GetMigrationTarget(entity *Entity, world *World, se
 	for y := 0; y < world.Config.GridHeight; y++ {
 		for x := 0; x < world.Config.GridWidth; x++ {
 			if world.Grid[y][x].Biome == preferredBiome {
-				worldX := (float64(x) + 0.5) * (world.Config.Width / float64(world.Config.GridWidth))
-				worldY := (float64(y) + 0.5) * (world.Config.Height / float64(world.Config.GridHeight))
-
-				distance := math.Sqrt(math.Pow(entity.Position.X-worldX, 2) + math.Pow(entity.Position.Y-worldY, 2))
+				center := world.CellCenter(x, y)
+				distance := world.Distance(entity.Position, center)
 
 				if distance < bestDistance {
 					bestDistance = distance
-					bestPos = Position{X: worldX, Y: worldY}
+					bestPos = center
 				}
 			}
 		}
@@ -0,0 +1,421 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// durationRange is a built-in duration in ticks, drawn uniformly from Min to Min+Spread-1
+type durationRange struct {
+	Min    int
+	Spread int
+}
+
+// roll draws a duration from the range
+func (r durationRange) roll(rng *rand.Rand) int {
+	if r.Spread <= 0 {
+		return r.Min
+	}
+	return r.Min + rng.Intn(r.Spread)
+}
+
+// longest is the longest duration the range can draw
+func (r durationRange) longest() int {
+	return r.Min + max(r.Spread-1, 0)
+}
+
+// Built-in durations, before the timescales are applied. The systems draw from these and
+// the timescale audit lists them, so the two cannot drift apart.
+var (
+	gestationPeriodRange = durationRange{Min: 50, Spread: 100}  // Pregnancy before a live birth
+	eggIncubationRange   = durationRange{Min: 30, Spread: 70}   // Egg laid to hatchling
+	corpseDecayRange     = durationRange{Min: 100, Spread: 200} // Remains to fertilizer
+	contaminationRange   = durationRange{Min: 20, Spread: 20}   // Contamination left by a die-off
+
+	worldEventDurations = map[string]int{
+		"Solar Flare":       30,
+		"Meteor Shower":     50,
+		"Ice Age":           100,
+		"Volcanic Winter":   75,
+		"Volcanic Eruption": 40,
+		"Lightning Storm":   20,
+		"Wildfire":          35,
+		"Great Flood":       60,
+		"Magnetic Storm":    25,
+		"Ash Cloud":         45,
+		"Earthquake":        15,
+		"Cosmic Radiation":  80,
+	}
+
+	environmentalEventDurations = map[string]durationRange{
+		"wildfire":          {Min: 20, Spread: 20},
+		"storm":             {Min: 15, Spread: 15},
+		"volcanic_eruption": {Min: 30, Spread: 25},
+		"flood":             {Min: 25, Spread: 20},
+		"hurricane":         {Min: 18, Spread: 12},
+		"tornado":           {Min: 8, Spread: 8},
+	}
+)
+
+// Groups of time constants that rescale together
+const (
+	TimescaleGroupLifespan  = "lifespan"
+	TimescaleGroupGestation = "gestation"
+	TimescaleGroupDecay     = "decay"
+	TimescaleGroupEvents    = "events"
+	TimescaleGroupSeasons   = "seasons"
+	TimescaleGroupPlants    = "plants" // Listed for reference; plant lifespans are fixed per type
+)
+
+// Ratios the audit expects between the groups
+const (
+	minEventLifespanShare = 0.02 // Events shorter than this share of a lifespan pass unnoticed by a generation
+	minTimescaleFactor    = 0.01 // Smallest factor a group can be rescaled by at once
+	maxTimescaleFactor    = 100  // Largest factor a group can be rescaled by at once
+)
+
+// TimescaleConstant is one time constant, converted to days and to lifespans of the
+// reference organism
+type TimescaleConstant struct {
+	Group      string  `json:"group"`
+	Name       string  `json:"name"`
+	MinTicks   int     `json:"min_ticks"`
+	MaxTicks   int     `json:"max_ticks"`
+	MinDays    float64 `json:"min_days"`
+	MaxDays    float64 `json:"max_days"`
+	Lifespans  float64 `json:"lifespans"`  // Longest duration as a share of the reference lifespan
+	Rescalable bool    `json:"rescalable"` // Whether its group can be rescaled
+}
+
+// TimescaleFinding is a ratio between groups that is out of proportion, with the
+// rescale that would fix it
+type TimescaleFinding struct {
+	Check          string   `json:"check"`
+	Groups         []string `json:"groups"`
+	Message        string   `json:"message"`
+	Ratio          float64  `json:"ratio"`
+	SuggestedGroup string   `json:"suggested_group"`
+	SuggestedScale float64  `json:"suggested_scale"`
+}
+
+// TimescaleAudit lists every time constant in a common unit and flags inconsistent ratios
+type TimescaleAudit struct {
+	Profile           string              `json:"profile"`
+	Scales            TimescaleConfig     `json:"scales"`
+	TicksPerDay       int                 `json:"ticks_per_day"`
+	Reference         string              `json:"reference"` // Organism whose lifespan is the yardstick
+	ReferenceLifespan int                 `json:"reference_lifespan"`
+	ReferenceMaturity int                 `json:"reference_maturity"`
+	YearTicks         int                 `json:"year_ticks"`
+	Constants         []TimescaleConstant `json:"constants"`
+	Findings          []TimescaleFinding  `json:"findings"`
+}
+
+// AuditTimescales lists the world's time constants as currently scaled. The yardstick is
+// the lifespan of the organism type most living creatures belong to.
+func AuditTimescales(w *World) TimescaleAudit {
+	scales := w.SimConfig.Timescales
+	reference := w.OrganismClassifier.LifespanData[referenceClassification(w)]
+	audit := TimescaleAudit{
+		Profile:           scales.Profile,
+		Scales:            scales,
+		TicksPerDay:       max(1, w.AdvancedTimeSystem.DayLength),
+		Reference:         reference.Name,
+		ReferenceLifespan: reference.BaseLifespanTicks,
+		ReferenceMaturity: reference.MaturationAge,
+	}
+	audit.YearTicks = 4 * w.AdvancedTimeSystem.SeasonLength * audit.TicksPerDay
+
+	add := func(group, name string, minTicks, maxTicks int, rescalable bool) {
+		audit.Constants = append(audit.Constants, TimescaleConstant{
+			Group:      group,
+			Name:       name,
+			MinTicks:   minTicks,
+			MaxTicks:   maxTicks,
+			MinDays:    float64(minTicks) / float64(audit.TicksPerDay),
+			MaxDays:    float64(maxTicks) / float64(audit.TicksPerDay),
+			Lifespans:  float64(maxTicks) / float64(audit.ReferenceLifespan),
+			Rescalable: rescalable,
+		})
+	}
+	scaledRange := func(group, name string, r durationRange, scale float64) {
+		add(group, name, scaledPeriod(r.Min, scale), scaledPeriod(r.longest(), scale), true)
+	}
+
+	for classification := ClassificationProkaryotic; classification <= ClassificationAdvancedMulticellular; classification++ {
+		data := w.OrganismClassifier.LifespanData[classification]
+		add(TimescaleGroupLifespan, data.Name+" lifespan", int(float64(data.BaseLifespanTicks)*0.3), data.BaseLifespanTicks*2, true)
+		add(TimescaleGroupLifespan, data.Name+" maturity", data.MaturationAge, data.MaturationAge, true)
+	}
+	scaledRange(TimescaleGroupGestation, "Gestation", gestationPeriodRange, scales.GestationScale)
+	scaledRange(TimescaleGroupGestation, "Egg incubation", eggIncubationRange, scales.GestationScale)
+	scaledRange(TimescaleGroupDecay, "Remains decay", corpseDecayRange, scales.DecayScale)
+	for _, name := range sortedKeys(worldEventDurations) {
+		ticks := scaledPeriod(worldEventDurations[name], scales.EventDurationScale)
+		add(TimescaleGroupEvents, name, ticks, ticks, true)
+	}
+	for _, name := range sortedKeys(environmentalEventDurations) {
+		label := strings.ReplaceAll(name, "_", " ")
+		scaledRange(TimescaleGroupEvents, strings.ToUpper(label[:1])+label[1:]+" (spreading)", environmentalEventDurations[name], scales.EventDurationScale)
+	}
+	scaledRange(TimescaleGroupEvents, "Contamination", contaminationRange, scales.EventDurationScale)
+	add(TimescaleGroupSeasons, "Season", audit.YearTicks/4, audit.YearTicks/4, true)
+	add(TimescaleGroupSeasons, "Year", audit.YearTicks, audit.YearTicks, true)
+	plants := GetPlantConfigs()
+	for _, plantType := range sortedKeys(plants) {
+		add(TimescaleGroupPlants, plants[plantType].Name+" lifespan", plants[plantType].MaxAge, plants[plantType].MaxAge, false)
+	}
+
+	audit.Findings = audit.check()
+	return audit
+}
+
+// referenceClassification is the classification most living creatures have, or complex
+// multicellular life in an empty world
+func referenceClassification(w *World) OrganismClassification {
+	counts := make(map[OrganismClassification]int)
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive {
+			counts[entity.Classification]++
+		}
+	}
+	reference, most := ClassificationComplexMulticellular, 0
+	for _, classification := range sortedKeys(counts) {
+		if counts[classification] > most {
+			reference, most = classification, counts[classification]
+		}
+	}
+	return reference
+}
+
+// check compares the groups against each other and the reference lifespan
+func (a *TimescaleAudit) check() []TimescaleFinding {
+	findings := make([]TimescaleFinding, 0)
+	lifespan := float64(a.ReferenceLifespan)
+
+	// Events too brief for a generation to notice, the classic symptom being an ice age
+	// that is over before anyone grows up
+	threshold := lifespan * minEventLifespanShare
+	brief := a.constantsBelow(TimescaleGroupEvents, threshold)
+	if len(brief) > 0 {
+		shortest := brief[0]
+		findings = append(findings, TimescaleFinding{
+			Check:  "events_too_short",
+			Groups: []string{TimescaleGroupEvents, TimescaleGroupLifespan},
+			Message: fmt.Sprintf("%d events end within %.0f%% of a %s lifespan (%d ticks); %s lasts at most %d ticks",
+				len(brief), minEventLifespanShare*100, a.Reference, a.ReferenceLifespan, shortest.Name, shortest.MaxTicks),
+			Ratio:          float64(shortest.MaxTicks) / lifespan,
+			SuggestedGroup: TimescaleGroupEvents,
+			SuggestedScale: roundedFactor(threshold / float64(shortest.MaxTicks)),
+		})
+	}
+
+	// Events that outlast whole lifetimes
+	longest := a.longestIn(TimescaleGroupEvents, func(c TimescaleConstant) int { return c.MinTicks })
+	if longest != nil && float64(longest.MinTicks) > lifespan {
+		findings = append(findings, TimescaleFinding{
+			Check:          "events_too_long",
+			Groups:         []string{TimescaleGroupEvents, TimescaleGroupLifespan},
+			Message:        fmt.Sprintf("%s lasts at least %d ticks, longer than a whole %s lifespan", longest.Name, longest.MinTicks, a.Reference),
+			Ratio:          float64(longest.MinTicks) / lifespan,
+			SuggestedGroup: TimescaleGroupEvents,
+			SuggestedScale: roundedFactor(lifespan / float64(longest.MinTicks)),
+		})
+	}
+
+	// Offspring should be born before their parents' generation could have grown up
+	gestation := a.longestIn(TimescaleGroupGestation, func(c TimescaleConstant) int { return c.MaxTicks })
+	if gestation != nil && a.ReferenceMaturity > 0 && gestation.MaxTicks >= a.ReferenceMaturity {
+		findings = append(findings, TimescaleFinding{
+			Check:          "gestation_exceeds_maturity",
+			Groups:         []string{TimescaleGroupGestation, TimescaleGroupLifespan},
+			Message:        fmt.Sprintf("%s takes up to %d ticks, longer than a %s takes to mature (%d ticks)", gestation.Name, gestation.MaxTicks, a.Reference, a.ReferenceMaturity),
+			Ratio:          float64(gestation.MaxTicks) / float64(a.ReferenceMaturity),
+			SuggestedGroup: TimescaleGroupGestation,
+			SuggestedScale: roundedFactor(0.8 * float64(a.ReferenceMaturity) / float64(gestation.MaxTicks)),
+		})
+	}
+
+	// Creatures should live through every season
+	if a.YearTicks > 0 && a.ReferenceLifespan < a.YearTicks {
+		findings = append(findings, TimescaleFinding{
+			Check:          "lifespan_shorter_than_year",
+			Groups:         []string{TimescaleGroupLifespan, TimescaleGroupSeasons},
+			Message:        fmt.Sprintf("A %s lifespan (%d ticks) is shorter than a year (%d ticks), so most never see every season", a.Reference, a.ReferenceLifespan, a.YearTicks),
+			Ratio:          lifespan / float64(a.YearTicks),
+			SuggestedGroup: TimescaleGroupSeasons,
+			SuggestedScale: roundedFactor(lifespan / float64(2*a.YearTicks)),
+		})
+	}
+
+	// Remains should not pile up for more than a year
+	decay := a.longestIn(TimescaleGroupDecay, func(c TimescaleConstant) int { return c.MaxTicks })
+	if decay != nil && a.YearTicks > 0 && decay.MaxTicks > a.YearTicks {
+		findings = append(findings, TimescaleFinding{
+			Check:          "decay_longer_than_year",
+			Groups:         []string{TimescaleGroupDecay, TimescaleGroupSeasons},
+			Message:        fmt.Sprintf("%s takes up to %d ticks, longer than a year (%d ticks)", decay.Name, decay.MaxTicks, a.YearTicks),
+			Ratio:          float64(decay.MaxTicks) / float64(a.YearTicks),
+			SuggestedGroup: TimescaleGroupDecay,
+			SuggestedScale: roundedFactor(float64(a.YearTicks) / float64(decay.MaxTicks)),
+		})
+	}
+	return findings
+}
+
+// constantsBelow returns a group's constants whose longest duration is under ticks,
+// shortest first
+func (a *TimescaleAudit) constantsBelow(group string, ticks float64) []TimescaleConstant {
+	below := make([]TimescaleConstant, 0)
+	for _, constant := range a.Constants {
+		if constant.Group == group && float64(constant.MaxTicks) < ticks {
+			below = append(below, constant)
+		}
+	}
+	sort.SliceStable(below, func(i, j int) bool { return below[i].MaxTicks < below[j].MaxTicks })
+	return below
+}
+
+// longestIn returns the constant of a group with the largest duration as measured by ticks
+func (a *TimescaleAudit) longestIn(group string, ticks func(TimescaleConstant) int) *TimescaleConstant {
+	var longest *TimescaleConstant
+	for i := range a.Constants {
+		if a.Constants[i].Group == group && (longest == nil || ticks(a.Constants[i]) > ticks(*longest)) {
+			longest = &a.Constants[i]
+		}
+	}
+	return longest
+}
+
+// roundedFactor rounds a suggested rescale to two significant figures, away from 1 so
+// applying it clears the finding
+func roundedFactor(factor float64) float64 {
+	if factor <= 0 {
+		return factor
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(factor))-1)
+	steps := math.Floor(factor / magnitude)
+	if factor > 1 {
+		steps = math.Ceil(factor / magnitude)
+	}
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(steps*magnitude, 'g', 2, 64), 64)
+	return rounded
+}
+
+// Summary renders the audit as a report
+func (a TimescaleAudit) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Timescale audit (profile %s, %d ticks per day)\n", a.Profile, a.TicksPerDay)
+	fmt.Fprintf(&b, "Yardstick: %s lifespan of %d ticks\n\n", a.Reference, a.ReferenceLifespan)
+	fmt.Fprintf(&b, "%-10s %-34s %15s %17s %10s\n", "Group", "Constant", "Ticks", "Days", "Lifespans")
+	for _, c := range a.Constants {
+		ticks := strconv.Itoa(c.MinTicks)
+		days := fmt.Sprintf("%.1f", c.MinDays)
+		if c.MaxTicks != c.MinTicks {
+			ticks += "-" + strconv.Itoa(c.MaxTicks)
+			days += fmt.Sprintf("-%.1f", c.MaxDays)
+		}
+		fmt.Fprintf(&b, "%-10s %-34s %15s %17s %10.3f\n", c.Group, c.Name, ticks, days, c.Lifespans)
+	}
+	b.WriteString("\n")
+	if len(a.Findings) == 0 {
+		b.WriteString("No inconsistent ratios found\n")
+		return b.String()
+	}
+	for _, f := range a.Findings {
+		fmt.Fprintf(&b, "! %s\n  fix: --scale %s=%g\n", f.Message, f.SuggestedGroup, f.SuggestedScale)
+	}
+	return b.String()
+}
+
+// RescaleTimescales multiplies a group of time constants by factor in a running world.
+// Living creatures keep their remaining share of life; events already under way and
+// eggs already laid keep their durations.
+func RescaleTimescales(w *World, group string, factor float64) error {
+	if factor < minTimescaleFactor || factor > maxTimescaleFactor {
+		return fmt.Errorf("rescale factor must be between %g and %g", minTimescaleFactor, float64(maxTimescaleFactor))
+	}
+	scales := &w.SimConfig.Timescales
+	switch group {
+	case TimescaleGroupLifespan:
+		scales.LifespanScale *= factor
+		w.OrganismClassifier.ScaleLifespans(factor)
+		for _, entity := range w.AllEntities {
+			entity.MaxLifespan = max(1, int(math.Round(float64(entity.MaxLifespan)*factor)))
+			entity.Age = int(math.Round(float64(entity.Age) * factor))
+		}
+	case TimescaleGroupGestation:
+		scales.GestationScale *= factor
+		w.ReproductionSystem.GestationScale = scales.GestationScale
+	case TimescaleGroupDecay:
+		scales.DecayScale *= factor
+		w.ReproductionSystem.DecayScale = scales.DecayScale
+	case TimescaleGroupEvents:
+		scales.EventDurationScale *= factor
+	case TimescaleGroupSeasons:
+		scales.SeasonScale *= factor
+		w.AdvancedTimeSystem.SeasonLength = scaledPeriod(w.SimConfig.Time.DaysPerSeason, scales.SeasonScale)
+	case TimescaleGroupPlants:
+		return fmt.Errorf("plant lifespans are fixed per plant type and cannot be rescaled")
+	default:
+		return fmt.Errorf("unknown timescale group %q (choose from %s)", group, strings.Join(rescalableTimescaleGroups(), ", "))
+	}
+	scales.Profile = "custom"
+	return nil
+}
+
+// rescalableTimescaleGroups lists the groups RescaleTimescales accepts
+func rescalableTimescaleGroups() []string {
+	return []string{TimescaleGroupLifespan, TimescaleGroupGestation, TimescaleGroupDecay, TimescaleGroupEvents, TimescaleGroupSeasons}
+}
+
+// timescaleScaleFlags collects repeated --scale group=factor arguments
+type timescaleScaleFlags []string
+
+func (f *timescaleScaleFlags) String() string { return strings.Join(*f, ",") }
+
+func (f *timescaleScaleFlags) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func (f *timescaleScaleFlags) isList() {}
+
+// runTimescalesCommand prints the timescale audit of a tuning profile, optionally with
+// groups rescaled, so a tuning can be checked before a run
+func runTimescalesCommand(args []string) error {
+	fs := newCommandFlags("timescales")
+	profile := fs.String("profile", "standard", "Tuning profile to audit: "+strings.Join(TuningProfileNames(), ", "))
+	var rescales timescaleScaleFlags
+	fs.Var(&rescales, "scale", "Rescale a group before auditing, as group=factor (repeatable): "+strings.Join(rescalableTimescaleGroups(), ", "))
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := applyEnvironment(fs, commandEnvPrefix(fs.Name())); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return commandUsage("timescales")
+	}
+	if _, err := FindTuningProfile(*profile); err != nil {
+		return err
+	}
+
+	world := NewWorld(WorldConfig{Width: 100, Height: 100, GridWidth: 10, GridHeight: 10, Profile: *profile})
+	for _, rescale := range rescales {
+		group, value, found := strings.Cut(rescale, "=")
+		factor, err := strconv.ParseFloat(value, 64)
+		if !found || err != nil {
+			return fmt.Errorf("invalid --scale %q: expected group=factor", rescale)
+		}
+		if err := RescaleTimescales(world, group, factor); err != nil {
+			return err
+		}
+	}
+	fmt.Print(AuditTimescales(world).Summary())
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTimescaleTestWorld creates an empty world with the given tuning profile
+func newTimescaleTestWorld(profile string) *World {
+	return NewWorld(WorldConfig{Width: 100, Height: 100, GridWidth: 10, GridHeight: 10, Profile: profile})
+}
+
+func TestTimescaleAuditFlagsBriefEvents(t *testing.T) {
+	audit := AuditTimescales(newTimescaleTestWorld(""))
+	if audit.Reference != "Complex Multicellular" || audit.YearTicks != 4*91 {
+		t.Fatalf("Expected complex life and a 364 tick year as the yardsticks, got %s and %d", audit.Reference, audit.YearTicks)
+	}
+
+	groups := make(map[string]int)
+	for _, constant := range audit.Constants {
+		groups[constant.Group]++
+		if constant.Name == "Gestation" && (constant.MinTicks != 50 || constant.MaxTicks != 149) {
+			t.Errorf("Expected the built-in gestation range, got %+v", constant)
+		}
+	}
+	for _, group := range append(rescalableTimescaleGroups(), TimescaleGroupPlants) {
+		if groups[group] == 0 {
+			t.Errorf("Expected constants in the %s group", group)
+		}
+	}
+
+	if len(audit.Findings) != 1 || audit.Findings[0].Check != "events_too_short" {
+		t.Fatalf("Expected the standard tuning's brief events flagged, got %+v", audit.Findings)
+	}
+
+	// The suggested rescale clears the finding
+	world := newTimescaleTestWorld("")
+	finding := audit.Findings[0]
+	if err := RescaleTimescales(world, finding.SuggestedGroup, finding.SuggestedScale); err != nil {
+		t.Fatal(err)
+	}
+	if rescaled := AuditTimescales(world); len(rescaled.Findings) != 0 || rescaled.Profile != "custom" {
+		t.Errorf("Expected the suggested rescale to leave a clean custom tuning, got %+v", rescaled.Findings)
+	}
+}
+
+func TestTuningProfilesPassTimescaleAudit(t *testing.T) {
+	for _, name := range TuningProfileNames()[1:] {
+		if findings := AuditTimescales(newTimescaleTestWorld(name)).Findings; len(findings) != 0 {
+			t.Errorf("Expected the %s profile to be in proportion, got %+v", name, findings)
+		}
+	}
+}
+
+func TestRescaleTimescalesInRunningWorld(t *testing.T) {
+	world := newSteppingTestWorld(71)
+	creature := world.AllEntities[0]
+	creature.Age, creature.MaxLifespan = 100, 1000
+	seasonLength := world.AdvancedTimeSystem.SeasonLength
+
+	if err := RescaleTimescales(world, TimescaleGroupLifespan, 2); err != nil {
+		t.Fatal(err)
+	}
+	if creature.Age != 200 || creature.MaxLifespan != 2000 || world.SimConfig.Timescales.LifespanScale != 2 {
+		t.Errorf("Expected the creature to keep its share of life, got age %d of %d", creature.Age, creature.MaxLifespan)
+	}
+	if err := RescaleTimescales(world, TimescaleGroupSeasons, 0.5); err != nil {
+		t.Fatal(err)
+	}
+	if world.AdvancedTimeSystem.SeasonLength != (seasonLength+1)/2 {
+		t.Errorf("Expected seasons halved from %d days, got %d", seasonLength, world.AdvancedTimeSystem.SeasonLength)
+	}
+	if err := RescaleTimescales(world, TimescaleGroupDecay, 3); err != nil || world.ReproductionSystem.DecayScale != 3 {
+		t.Errorf("Expected decay tripled, got %v and %.1f", err, world.ReproductionSystem.DecayScale)
+	}
+
+	for _, bad := range []struct {
+		group  string
+		factor float64
+	}{{TimescaleGroupPlants, 2}, {"tides", 2}, {TimescaleGroupEvents, 0}, {TimescaleGroupEvents, 1000}} {
+		if err := RescaleTimescales(world, bad.group, bad.factor); err == nil {
+			t.Errorf("Expected rescaling %s by %g to be rejected", bad.group, bad.factor)
+		}
+	}
+}
+
+func TestTimescalesAPI(t *testing.T) {
+	wi := NewWebInterface(newTimescaleTestWorld(""))
+
+	rec := httptest.NewRecorder()
+	wi.handleTimescales(rec, httptest.NewRequest(http.MethodPost, "/api/timescales", bytes.NewReader([]byte(`{"group": "plants", "factor": 2}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected plant lifespans to be fixed, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleTimescales(rec, httptest.NewRequest(http.MethodPost, "/api/timescales", bytes.NewReader([]byte(`{"group": "events", "factor": 10}`))))
+	var audit TimescaleAudit
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &audit) != nil {
+		t.Fatalf("Rescaling events failed: %d %s", rec.Code, rec.Body.String())
+	}
+	if audit.Scales.EventDurationScale != 10 || len(audit.Findings) != 0 {
+		t.Errorf("Expected longer events to clear the audit, got %+v", audit.Findings)
+	}
+}
@@ -124,6 +124,8 @@ type ToolSystem struct {
 	NextToolID  int                     `json:"next_tool_id"`
 	ToolRecipes map[ToolType]ToolRecipe `json:"tool_recipes"` // How to create tools
 	eventBus    *CentralEventBus        `json:"-"`            // Event tracking
+
+	rng *rand.Rand // Its RNG stream, or the world's source
 }
 
 // ToolRecipe defines what's needed to create a tool
@@ -144,6 +146,7 @@ func NewToolSystem(eventBus *CentralEventBus) *ToolSystem {
 		NextToolID:  1,
 		ToolRecipes: make(map[ToolType]ToolRecipe),
 		eventBus:    eventBus,
+		rng:         sharedRand,
 	}
 
 	// Initialize tool recipes
@@ -342,7 +345,7 @@ func (ts *ToolSystem) UseTool(tool *Tool, user *Entity, intensity float64) float
 	effectiveness := tool.Efficiency * (0.5 + userSkill*0.5) * intensity
 
 	// Reduce durability based on use intensity
-	durabilityLoss := intensity * 0.05 * (1.0 + rand.Float64()*0.2)
+	durabilityLoss := intensity * 0.05 * (1.0 + ts.rng.Float64()*0.2)
 	tool.Durability = math.Max(0.0, tool.Durability-durabilityLoss)
 
 	// Update last used tick
@@ -411,7 +414,7 @@ func (ts *ToolSystem) ModifyTool(tool *Tool, modifier *Entity, modificationType
 	}
 
 	// Apply modification
-	improvement := modifierSkill * 0.2 * (1.0 + rand.Float64()*0.3)
+	improvement := modifierSkill * 0.2 * (1.0 + ts.rng.Float64()*0.3)
 
 	modification := ToolModification{
 		Type:        modificationType,
@@ -478,7 +481,7 @@ func (ts *ToolSystem) RepairTool(tool *Tool, repairer *Entity) bool {
 	}
 
 	// Repair amount based on skill
-	repairAmount := repairerSkill * 0.3 * (1.0 + rand.Float64()*0.2)
+	repairAmount := repairerSkill * 0.3 * (1.0 + ts.rng.Float64()*0.2)
 	tool.Durability = math.Min(tool.MaxDurability, tool.Durability+repairAmount)
 
 	repairer.Energy -= energyCost
@@ -545,7 +548,8 @@ func (ts *ToolSystem) GetNearbyTools(position Position, radius float64) []*Tool
 
 // UpdateTools maintains all tools in the system
 func (ts *ToolSystem) UpdateTools(tick int) {
-	for _, tool := range ts.Tools {
+	for _, toolID := range sortedKeys(ts.Tools) {
+		tool := ts.Tools[toolID]
 		// Natural decay for unused tools
 		if tool.Owner == nil && tick-tool.LastUsedTick > 100 {
 			tool.Durability *= 0.999 // Very slow decay
@@ -569,7 +573,8 @@ func (ts *ToolSystem) GetToolStats() map[string]interface{} {
 
 	toolTypeCounts := make(map[ToolType]int)
 
-	for _, tool := range ts.Tools {
+	for _, toolID := range sortedKeys(ts.Tools) {
+		tool := ts.Tools[toolID]
 		if tool.Owner != nil {
 			ownedTools++
 		}
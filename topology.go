@@ -95,6 +95,8 @@ type TopologySystem struct {
 	HillThreshold     float64 `json:"hill_threshold"`
 	ValleyThreshold   float64 `json:"valley_threshold"`
 	WaterThreshold    float64 `json:"water_threshold"`
+
+	rng *rand.Rand // Topology's RNG stream, or the world's source
 }
 
 // NewTopologySystem creates a new terrain management system
@@ -117,6 +119,7 @@ func NewTopologySystem(width, height int) *TopologySystem {
 		HillThreshold:     0.3,
 		ValleyThreshold:   -0.3,
 		WaterThreshold:    -0.2,
+		rng:               sharedRand,
 	}
 
 	// Initialize grid
@@ -195,16 +198,16 @@ func (ts *TopologySystem) noise(x, y float64) float64 {
 
 // addMountainRanges creates mountain ranges
 func (ts *TopologySystem) addMountainRanges() {
-	numRanges := 2 + rand.Intn(4)
+	numRanges := 2 + ts.rng.Intn(4)
 
 	for i := 0; i < numRanges; i++ {
 		// Random mountain range
-		centerX := rand.Float64() * float64(ts.Width)
-		centerY := rand.Float64() * float64(ts.Height)
-		length := 20.0 + rand.Float64()*50.0
-		width := 5.0 + rand.Float64()*15.0
-		height := 0.15 + rand.Float64()*0.25 // Further reduced mountain height from 0.2-0.6 to 0.15-0.4
-		angle := rand.Float64() * 2 * math.Pi
+		centerX := ts.rng.Float64() * float64(ts.Width)
+		centerY := ts.rng.Float64() * float64(ts.Height)
+		length := 20.0 + ts.rng.Float64()*50.0
+		width := 5.0 + ts.rng.Float64()*15.0
+		height := 0.15 + ts.rng.Float64()*0.25 // Further reduced mountain height from 0.2-0.6 to 0.15-0.4
+		angle := ts.rng.Float64() * 2 * math.Pi
 
 		// Create mountain range feature
 		feature := &TerrainFeature{
@@ -213,9 +216,9 @@ func (ts *TopologySystem) addMountainRanges() {
 			Center:      Position{X: centerX, Y: centerY},
 			Radius:      length,
 			Height:      height,
-			Slope:       0.6 + rand.Float64()*0.3,
-			Age:         rand.Intn(10000),
-			Stability:   0.8 + rand.Float64()*0.2,
+			Slope:       0.6 + ts.rng.Float64()*0.3,
+			Age:         ts.rng.Intn(10000),
+			Stability:   0.8 + ts.rng.Float64()*0.2,
 			Composition: "rock",
 			IsActive:    false,
 		}
@@ -245,15 +248,15 @@ func (ts *TopologySystem) addMountainRanges() {
 
 // addValleys creates valleys and lowlands
 func (ts *TopologySystem) addValleys() {
-	numValleys := 1 + rand.Intn(3)
+	numValleys := 1 + ts.rng.Intn(3)
 
 	for i := 0; i < numValleys; i++ {
-		centerX := rand.Float64() * float64(ts.Width)
-		centerY := rand.Float64() * float64(ts.Height)
-		length := 15.0 + rand.Float64()*40.0
-		width := 8.0 + rand.Float64()*20.0
-		depth := 0.3 + rand.Float64()*0.5
-		angle := rand.Float64() * 2 * math.Pi
+		centerX := ts.rng.Float64() * float64(ts.Width)
+		centerY := ts.rng.Float64() * float64(ts.Height)
+		length := 15.0 + ts.rng.Float64()*40.0
+		width := 8.0 + ts.rng.Float64()*20.0
+		depth := 0.3 + ts.rng.Float64()*0.5
+		angle := ts.rng.Float64() * 2 * math.Pi
 
 		feature := &TerrainFeature{
 			ID:          ts.NextFeatureID,
@@ -261,9 +264,9 @@ func (ts *TopologySystem) addValleys() {
 			Center:      Position{X: centerX, Y: centerY},
 			Radius:      length,
 			Height:      -depth,
-			Slope:       0.1 + rand.Float64()*0.2,
-			Age:         rand.Intn(8000),
-			Stability:   0.6 + rand.Float64()*0.2,
+			Slope:       0.1 + ts.rng.Float64()*0.2,
+			Age:         ts.rng.Intn(8000),
+			Stability:   0.6 + ts.rng.Float64()*0.2,
 			Composition: "soil",
 			IsActive:    false,
 		}
@@ -294,13 +297,13 @@ func (ts *TopologySystem) addValleys() {
 // addWaterBodies creates rivers and lakes
 func (ts *TopologySystem) addWaterBodies() {
 	// Add lakes
-	numLakes := 1 + rand.Intn(3)
+	numLakes := 1 + ts.rng.Intn(3)
 	for i := 0; i < numLakes; i++ {
 		ts.createLake()
 	}
 
 	// Add rivers
-	numRivers := 2 + rand.Intn(4)
+	numRivers := 2 + ts.rng.Intn(4)
 	for i := 0; i < numRivers; i++ {
 		ts.createRiver()
 	}
@@ -313,8 +316,8 @@ func (ts *TopologySystem) createLake() {
 	lakeX, lakeY := 0, 0
 
 	for attempt := 0; attempt < 100; attempt++ {
-		x := rand.Intn(ts.Width)
-		y := rand.Intn(ts.Height)
+		x := ts.rng.Intn(ts.Width)
+		y := ts.rng.Intn(ts.Height)
 		elevation := ts.TopologyGrid[x][y].Elevation
 
 		if elevation < minElevation {
@@ -323,8 +326,8 @@ func (ts *TopologySystem) createLake() {
 		}
 	}
 
-	radius := 3.0 + rand.Float64()*8.0
-	depth := 0.2 + rand.Float64()*0.3
+	radius := 3.0 + ts.rng.Float64()*8.0
+	depth := 0.2 + ts.rng.Float64()*0.3
 
 	waterBody := &WaterBody{
 		ID:       ts.NextWaterID,
@@ -332,7 +335,7 @@ func (ts *TopologySystem) createLake() {
 		Points:   []Position{{X: float64(lakeX), Y: float64(lakeY)}},
 		Flow:     0.0, // Lakes don't flow
 		Depth:    depth,
-		Salinity: rand.Float64() * 0.1, // Mostly fresh water
+		Salinity: ts.rng.Float64() * 0.1, // Mostly fresh water
 		IsActive: true,
 	}
 
@@ -365,8 +368,8 @@ func (ts *TopologySystem) createRiver() {
 	startX, startY := 0, 0
 
 	for attempt := 0; attempt < 100; attempt++ {
-		x := rand.Intn(ts.Width)
-		y := rand.Intn(ts.Height)
+		x := ts.rng.Intn(ts.Width)
+		y := ts.rng.Intn(ts.Height)
 		elevation := ts.TopologyGrid[x][y].Elevation
 
 		if elevation > maxElevation {
@@ -422,7 +425,7 @@ func (ts *TopologySystem) createRiver() {
 		return // River too short
 	}
 
-	flow := 0.5 + rand.Float64()*0.5
+	flow := 0.5 + ts.rng.Float64()*0.5
 	waterBody := &WaterBody{
 		ID:       ts.NextWaterID,
 		Type:     "river",
@@ -523,7 +526,7 @@ func (ts *TopologySystem) applyGeologicalEventEffects(event *GeologicalEvent) {
 			switch event.Type {
 			case "earthquake":
 				// Randomly alter elevation
-				change := (rand.Float64() - 0.5) * influence * 0.2
+				change := (ts.rng.Float64() - 0.5) * influence * 0.2
 				ts.TopologyGrid[x][y].Elevation += change
 				ts.TopologyGrid[x][y].Erosion += influence * 0.1
 
@@ -545,7 +548,7 @@ func (ts *TopologySystem) applyGeologicalEventEffects(event *GeologicalEvent) {
 			// New plate tectonics events
 			case "continental_drift":
 				// Gradual elevation changes over large areas
-				change := (rand.Float64() - 0.5) * influence * 0.05 // Very gradual
+				change := (ts.rng.Float64() - 0.5) * influence * 0.05 // Very gradual
 				ts.TopologyGrid[x][y].Elevation += change
 
 			case "seafloor_spreading":
@@ -599,7 +602,7 @@ func (ts *TopologySystem) applyGeologicalEventEffects(event *GeologicalEvent) {
 
 // triggerRandomEvents creates random geological events
 func (ts *TopologySystem) triggerRandomEvents() {
-	if rand.Float64() < ts.TectonicActivity*0.01 { // 1% chance with tectonic activity
+	if ts.rng.Float64() < ts.TectonicActivity*0.01 { // 1% chance with tectonic activity
 		eventTypes := []string{
 			"earthquake", "volcanic_eruption", "landslide", "flood",
 			// New plate tectonics events
@@ -607,75 +610,75 @@ func (ts *TopologySystem) triggerRandomEvents() {
 			"rift_valley", "geyser_formation", "hot_spring_creation",
 			"ice_sheet_advance", "glacial_retreat",
 		}
-		eventType := eventTypes[rand.Intn(len(eventTypes))]
+		eventType := eventTypes[ts.rng.Intn(len(eventTypes))]
 
-		centerX := rand.Float64() * float64(ts.Width)
-		centerY := rand.Float64() * float64(ts.Height)
+		centerX := ts.rng.Float64() * float64(ts.Width)
+		centerY := ts.rng.Float64() * float64(ts.Height)
 
 		var radius, intensity float64
 		var duration int
 
 		switch eventType {
 		case "earthquake":
-			radius = 5.0 + rand.Float64()*15.0
-			intensity = 0.3 + rand.Float64()*0.7
-			duration = 1 + rand.Intn(3)
+			radius = 5.0 + ts.rng.Float64()*15.0
+			intensity = 0.3 + ts.rng.Float64()*0.7
+			duration = 1 + ts.rng.Intn(3)
 
 		case "volcanic_eruption":
-			radius = 3.0 + rand.Float64()*8.0
-			intensity = 0.5 + rand.Float64()*0.5
-			duration = 5 + rand.Intn(20)
+			radius = 3.0 + ts.rng.Float64()*8.0
+			intensity = 0.5 + ts.rng.Float64()*0.5
+			duration = 5 + ts.rng.Intn(20)
 
 		case "landslide":
-			radius = 2.0 + rand.Float64()*5.0
-			intensity = 0.4 + rand.Float64()*0.4
-			duration = 1 + rand.Intn(2)
+			radius = 2.0 + ts.rng.Float64()*5.0
+			intensity = 0.4 + ts.rng.Float64()*0.4
+			duration = 1 + ts.rng.Intn(2)
 
 		case "flood":
-			radius = 8.0 + rand.Float64()*20.0
-			intensity = 0.2 + rand.Float64()*0.5
-			duration = 10 + rand.Intn(30)
+			radius = 8.0 + ts.rng.Float64()*20.0
+			intensity = 0.2 + ts.rng.Float64()*0.5
+			duration = 10 + ts.rng.Intn(30)
 
 		// New plate tectonics events
 		case "continental_drift":
-			radius = 30.0 + rand.Float64()*50.0 // Large scale
-			intensity = 0.1 + rand.Float64()*0.3
-			duration = 100 + rand.Intn(300) // Very long duration
+			radius = 30.0 + ts.rng.Float64()*50.0 // Large scale
+			intensity = 0.1 + ts.rng.Float64()*0.3
+			duration = 100 + ts.rng.Intn(300) // Very long duration
 
 		case "seafloor_spreading":
-			radius = 15.0 + rand.Float64()*25.0
-			intensity = 0.2 + rand.Float64()*0.4
-			duration = 50 + rand.Intn(150)
+			radius = 15.0 + ts.rng.Float64()*25.0
+			intensity = 0.2 + ts.rng.Float64()*0.4
+			duration = 50 + ts.rng.Intn(150)
 
 		case "mountain_uplift":
-			radius = 10.0 + rand.Float64()*20.0
-			intensity = 0.4 + rand.Float64()*0.6
-			duration = 20 + rand.Intn(80)
+			radius = 10.0 + ts.rng.Float64()*20.0
+			intensity = 0.4 + ts.rng.Float64()*0.6
+			duration = 20 + ts.rng.Intn(80)
 
 		case "rift_valley":
-			radius = 12.0 + rand.Float64()*18.0
-			intensity = 0.3 + rand.Float64()*0.5
-			duration = 30 + rand.Intn(100)
+			radius = 12.0 + ts.rng.Float64()*18.0
+			intensity = 0.3 + ts.rng.Float64()*0.5
+			duration = 30 + ts.rng.Intn(100)
 
 		case "geyser_formation":
-			radius = 1.0 + rand.Float64()*3.0 // Small, localized
-			intensity = 0.6 + rand.Float64()*0.4
-			duration = 50 + rand.Intn(200)
+			radius = 1.0 + ts.rng.Float64()*3.0 // Small, localized
+			intensity = 0.6 + ts.rng.Float64()*0.4
+			duration = 50 + ts.rng.Intn(200)
 
 		case "hot_spring_creation":
-			radius = 2.0 + rand.Float64()*4.0
-			intensity = 0.5 + rand.Float64()*0.3
-			duration = 40 + rand.Intn(150)
+			radius = 2.0 + ts.rng.Float64()*4.0
+			intensity = 0.5 + ts.rng.Float64()*0.3
+			duration = 40 + ts.rng.Intn(150)
 
 		case "ice_sheet_advance":
-			radius = 20.0 + rand.Float64()*40.0
-			intensity = 0.3 + rand.Float64()*0.5
-			duration = 80 + rand.Intn(200)
+			radius = 20.0 + ts.rng.Float64()*40.0
+			intensity = 0.3 + ts.rng.Float64()*0.5
+			duration = 80 + ts.rng.Intn(200)
 
 		case "glacial_retreat":
-			radius = 15.0 + rand.Float64()*30.0
-			intensity = 0.2 + rand.Float64()*0.4
-			duration = 60 + rand.Intn(150)
+			radius = 15.0 + ts.rng.Float64()*30.0
+			intensity = 0.2 + ts.rng.Float64()*0.4
+			duration = 60 + ts.rng.Intn(150)
 		}
 
 		event := GeologicalEvent{
@@ -782,7 +785,7 @@ func (ts *TopologySystem) applyErosion() {
 			erosionRate := ts.ErosionRate * cell.Slope * (1.0 + cell.WaterLevel) * (1.0 - cell.Hardness)
 
 			if erosionRate > 0 {
-				elevation_loss := erosionRate * (1.0 + rand.Float64()*0.5)
+				elevation_loss := erosionRate * (1.0 + ts.rng.Float64()*0.5)
 				cell.Elevation -= elevation_loss
 				cell.Sediment += elevation_loss * 0.7 // Some material becomes sediment
 				cell.Erosion = erosionRate
@@ -793,10 +796,11 @@ func (ts *TopologySystem) applyErosion() {
 
 func (ts *TopologySystem) updateWaterFlow() {
 	// Simplified water flow simulation
-	for _, waterBody := range ts.WaterBodies {
+	for _, waterBodyID := range sortedKeys(ts.WaterBodies) {
+		waterBody := ts.WaterBodies[waterBodyID]
 		if waterBody.Type == "river" && waterBody.IsActive {
 			// Rivers can change course over time
-			if rand.Float64() < 0.01 { // 1% chance per update
+			if ts.rng.Float64() < 0.01 { // 1% chance per update
 				ts.adjustRiverCourse(waterBody)
 			}
 		}
@@ -806,12 +810,12 @@ func (ts *TopologySystem) updateWaterFlow() {
 func (ts *TopologySystem) adjustRiverCourse(river *WaterBody) {
 	// Slight random adjustment to river path
 	if len(river.Points) > 2 {
-		pointIndex := 1 + rand.Intn(len(river.Points)-2)
+		pointIndex := 1 + ts.rng.Intn(len(river.Points)-2)
 		point := &river.Points[pointIndex]
 
 		// Small random movement
-		point.X += (rand.Float64() - 0.5) * 2.0
-		point.Y += (rand.Float64() - 0.5) * 2.0
+		point.X += (ts.rng.Float64() - 0.5) * 2.0
+		point.Y += (ts.rng.Float64() - 0.5) * 2.0
 
 		// Clamp to bounds
 		point.X = math.Max(0, math.Min(float64(ts.Width-1), point.X))
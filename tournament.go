@@ -0,0 +1,512 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+// TournamentConfig controls the arena and the number and length of bouts
+type TournamentConfig struct {
+	Width      float64 `json:"width"`
+	Height     float64 `json:"height"`
+	GridWidth  int     `json:"grid_width"`
+	GridHeight int     `json:"grid_height"`
+	Bouts      int     `json:"bouts"`
+	Ticks      int     `json:"ticks"`    // Maximum ticks per bout
+	Founders   int     `json:"founders"` // Founding creatures per entrant
+	Seed       int64   `json:"seed"`     // Base seed; bout i runs its arena from Seed+i
+}
+
+// DefaultTournamentConfig returns a small arena suitable for quick bouts
+func DefaultTournamentConfig() TournamentConfig {
+	return TournamentConfig{
+		Width:      60,
+		Height:     60,
+		GridWidth:  20,
+		GridHeight: 20,
+		Bouts:      5,
+		Ticks:      500,
+		Founders:   10,
+		Seed:       1,
+	}
+}
+
+// TournamentEntrant is one competing species loaded from a creature file
+type TournamentEntrant struct {
+	Name string        `json:"name"`
+	File *CreatureFile `json:"-"`
+}
+
+// BoutStanding records how one entrant fared in a single bout
+type BoutStanding struct {
+	Entrant        string  `json:"entrant"`
+	Rank           int     `json:"rank"`
+	Survivors      int     `json:"survivors"`
+	PeakPopulation int     `json:"peak_population"`
+	Births         int     `json:"births"`
+	Deaths         int     `json:"deaths"`
+	MeanEnergy     float64 `json:"mean_energy"`     // Mean energy of survivors at the end of the bout
+	ExtinctAtTick  int     `json:"extinct_at_tick"` // 0 if the entrant survived
+}
+
+// BoutResult is the outcome of a single bout
+type BoutResult struct {
+	Bout      int            `json:"bout"`
+	Seed      int64          `json:"seed"`
+	Ticks     int            `json:"ticks"`
+	Winner    string         `json:"winner"`
+	Standings []BoutStanding `json:"standings"`
+}
+
+// TournamentScore aggregates an entrant's results across all bouts
+type TournamentScore struct {
+	Entrant        string   `json:"entrant"`
+	Rank           int      `json:"rank"`
+	Wins           int      `json:"wins"`
+	Points         int      `json:"points"` // Entrants score (entrants - rank) points per bout
+	MeanRank       float64  `json:"mean_rank"`
+	MeanSurvivors  float64  `json:"mean_survivors"`
+	StdSurvivors   float64  `json:"std_survivors"`
+	MeanBirths     float64  `json:"mean_births"`
+	MeanDeaths     float64  `json:"mean_deaths"`
+	ExtinctionRate float64  `json:"extinction_rate"`
+	Reasons        []string `json:"reasons"` // Why the entrant won or lost, relative to the field
+}
+
+// TournamentResult is the full outcome of a tournament
+type TournamentResult struct {
+	Config     TournamentConfig  `json:"config"`
+	Bouts      []BoutResult      `json:"bouts"`
+	Scoreboard []TournamentScore `json:"scoreboard"`
+}
+
+// tournamentTraits are the founder traits compared when explaining results
+var tournamentTraits = []string{"speed", "strength", "aggression", "defense", "intelligence", "cooperation", "endurance", "size"}
+
+// LoadTournamentEntrants loads creature files, naming each entrant after its file and keeping names unique
+func LoadTournamentEntrants(paths []string) ([]*TournamentEntrant, error) {
+	entrants := make([]*TournamentEntrant, 0, len(paths))
+	used := make(map[string]int)
+	for _, path := range paths {
+		file, err := LoadCreatureFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		name := file.Name
+		used[name]++
+		if used[name] > 1 {
+			name = fmt.Sprintf("%s (%d)", name, used[name])
+		}
+		entrants = append(entrants, &TournamentEntrant{Name: name, File: file})
+	}
+	return entrants, nil
+}
+
+// RunTournament pits the entrants against each other in repeated bouts and ranks them.
+// Entrants are relabelled with their entrant name in the arena so that files sharing a
+// species name still compete as separate lineages.
+func RunTournament(entrants []*TournamentEntrant, config TournamentConfig) (*TournamentResult, error) {
+	if len(entrants) < 2 {
+		return nil, fmt.Errorf("a tournament needs at least 2 entrants, got %d", len(entrants))
+	}
+	seen := make(map[string]bool)
+	for _, entrant := range entrants {
+		if entrant.File == nil {
+			return nil, fmt.Errorf("entrant %q has no creature file", entrant.Name)
+		}
+		if err := entrant.File.Validate(); err != nil {
+			return nil, fmt.Errorf("entrant %q: %v", entrant.Name, err)
+		}
+		if seen[entrant.Name] {
+			return nil, fmt.Errorf("duplicate entrant name %q", entrant.Name)
+		}
+		seen[entrant.Name] = true
+	}
+
+	defaults := DefaultTournamentConfig()
+	if config.Width <= 0 || config.Height <= 0 {
+		config.Width, config.Height = defaults.Width, defaults.Height
+	}
+	if config.GridWidth <= 0 || config.GridHeight <= 0 {
+		config.GridWidth, config.GridHeight = defaults.GridWidth, defaults.GridHeight
+	}
+	if config.Bouts <= 0 {
+		config.Bouts = defaults.Bouts
+	}
+	if config.Ticks <= 0 {
+		config.Ticks = defaults.Ticks
+	}
+	if config.Founders <= 0 {
+		config.Founders = defaults.Founders
+	}
+
+	result := &TournamentResult{Config: config, Bouts: make([]BoutResult, 0, config.Bouts)}
+	for bout := 0; bout < config.Bouts; bout++ {
+		boutResult, err := runTournamentBout(entrants, config, bout)
+		if err != nil {
+			return nil, err
+		}
+		result.Bouts = append(result.Bouts, boutResult)
+	}
+	result.Scoreboard = buildTournamentScoreboard(entrants, result.Bouts)
+	explainTournamentResults(entrants, result.Scoreboard)
+	return result, nil
+}
+
+// newTournamentArena creates a neutral world: uniform plains, no native populations, and no random
+// events. Its creatures update one after another, so a bout replays exactly from its seed.
+func newTournamentArena(config TournamentConfig, seed int64) *World {
+	world := NewWorld(WorldConfig{
+		Width:      config.Width,
+		Height:     config.Height,
+		GridWidth:  config.GridWidth,
+		GridHeight: config.GridHeight,
+		Seed:       seed,
+	})
+	world.Deterministic = true
+	for y := range world.Grid {
+		for x := range world.Grid[y] {
+			world.Grid[y][x].Biome = BiomePlains
+		}
+	}
+	world.AllEntities = make([]*Entity, 0)
+	world.RandomEventsOff = true
+	return world
+}
+
+// runTournamentBout runs a single bout and ranks the entrants by how well they held the arena
+func runTournamentBout(entrants []*TournamentEntrant, config TournamentConfig, bout int) (BoutResult, error) {
+	seed := config.Seed + int64(bout)
+	world := newTournamentArena(config, seed)
+	rng := world.Rand
+
+	// Each entrant founds a cluster around its own randomly chosen home point
+	lineage := make(map[int]string)
+	speciesOwner := make(map[string]string)
+	standings := make(map[string]*BoutStanding, len(entrants))
+	for _, entrant := range entrants {
+		standings[entrant.Name] = &BoutStanding{Entrant: entrant.Name}
+		speciesOwner[entrant.Name] = entrant.Name
+
+		home := Position{
+			X: config.Width * (0.15 + 0.7*rng.Float64()),
+			Y: config.Height * (0.15 + 0.7*rng.Float64()),
+		}
+		for i := 0; i < config.Founders; i++ {
+			record := entrant.File.Creatures[i%len(entrant.File.Creatures)]
+			record.Species = entrant.Name
+			single := &CreatureFile{
+				Format:    CreatureFileFormat,
+				Version:   CreatureFileVersion,
+				Name:      entrant.Name,
+				Creatures: []CreatureRecord{record},
+			}
+			pos := Position{X: home.X + (rng.Float64()-0.5)*10, Y: home.Y + (rng.Float64()-0.5)*10}
+			imported, err := world.ImportCreatureFile(single, pos)
+			if err != nil {
+				return BoutResult{}, fmt.Errorf("entrant %q: %v", entrant.Name, err)
+			}
+			for _, entity := range imported {
+				lineage[entity.ID] = entrant.Name
+			}
+		}
+		standings[entrant.Name].PeakPopulation = config.Founders
+	}
+
+	alive := make(map[int]bool, len(lineage))
+	for id := range lineage {
+		alive[id] = true
+	}
+
+	ticks := 0
+	for tick := 1; tick <= config.Ticks; tick++ {
+		world.Update()
+		ticks = tick
+
+		counts := make(map[string]int, len(entrants))
+		nowAlive := make(map[int]bool, len(alive))
+		for _, entity := range world.AllEntities {
+			if !entity.IsAlive {
+				continue
+			}
+			owner, known := lineage[entity.ID]
+			if !known {
+				// Offspring inherit their parents' species, so the species name identifies the lineage
+				owner, known = speciesOwner[entity.Species]
+				if !known {
+					continue
+				}
+				lineage[entity.ID] = owner
+				standings[owner].Births++
+			}
+			// Speciation renames a lineage's species; remember the new name for its offspring
+			if _, mapped := speciesOwner[entity.Species]; !mapped {
+				speciesOwner[entity.Species] = owner
+			}
+			nowAlive[entity.ID] = true
+			counts[owner]++
+		}
+		for id := range alive {
+			if !nowAlive[id] {
+				standings[lineage[id]].Deaths++
+			}
+		}
+		alive = nowAlive
+
+		remaining := 0
+		for _, entrant := range entrants {
+			standing := standings[entrant.Name]
+			count := counts[entrant.Name]
+			if count > standing.PeakPopulation {
+				standing.PeakPopulation = count
+			}
+			if count == 0 && standing.ExtinctAtTick == 0 {
+				standing.ExtinctAtTick = tick
+			}
+			if count > 0 {
+				remaining++
+			}
+		}
+		// A bout ends early once at most one lineage is left standing
+		if remaining <= 1 {
+			break
+		}
+	}
+
+	energy := make(map[string]float64)
+	for _, entity := range world.AllEntities {
+		if owner, known := lineage[entity.ID]; known && alive[entity.ID] {
+			standings[owner].Survivors++
+			energy[owner] += entity.Energy
+		}
+	}
+
+	result := BoutResult{Bout: bout + 1, Seed: seed, Ticks: ticks, Standings: make([]BoutStanding, 0, len(entrants))}
+	for _, entrant := range entrants {
+		standing := standings[entrant.Name]
+		if standing.Survivors > 0 {
+			standing.MeanEnergy = energy[entrant.Name] / float64(standing.Survivors)
+		}
+		result.Standings = append(result.Standings, *standing)
+	}
+
+	// Survivors decide the bout; among extinct entrants, lasting longer ranks higher
+	sort.SliceStable(result.Standings, func(i, j int) bool {
+		a, b := result.Standings[i], result.Standings[j]
+		if a.Survivors != b.Survivors {
+			return a.Survivors > b.Survivors
+		}
+		if a.ExtinctAtTick != b.ExtinctAtTick {
+			return a.ExtinctAtTick == 0 || (b.ExtinctAtTick != 0 && a.ExtinctAtTick > b.ExtinctAtTick)
+		}
+		return a.PeakPopulation > b.PeakPopulation
+	})
+	for i := range result.Standings {
+		result.Standings[i].Rank = i + 1
+	}
+	if result.Standings[0].Survivors > 0 {
+		result.Winner = result.Standings[0].Entrant
+	}
+
+	return result, nil
+}
+
+// buildTournamentScoreboard aggregates bout standings into a ranked scoreboard
+func buildTournamentScoreboard(entrants []*TournamentEntrant, bouts []BoutResult) []TournamentScore {
+	scores := make([]TournamentScore, 0, len(entrants))
+	for _, entrant := range entrants {
+		score := TournamentScore{Entrant: entrant.Name}
+		survivors := make([]float64, 0, len(bouts))
+		for _, bout := range bouts {
+			for _, standing := range bout.Standings {
+				if standing.Entrant != entrant.Name {
+					continue
+				}
+				if bout.Winner == entrant.Name {
+					score.Wins++
+				}
+				score.Points += len(entrants) - standing.Rank
+				score.MeanRank += float64(standing.Rank)
+				score.MeanBirths += float64(standing.Births)
+				score.MeanDeaths += float64(standing.Deaths)
+				if standing.ExtinctAtTick > 0 {
+					score.ExtinctionRate++
+				}
+				survivors = append(survivors, float64(standing.Survivors))
+			}
+		}
+
+		if n := float64(len(bouts)); n > 0 {
+			score.MeanRank /= n
+			score.MeanBirths /= n
+			score.MeanDeaths /= n
+			score.ExtinctionRate /= n
+			for _, s := range survivors {
+				score.MeanSurvivors += s
+			}
+			score.MeanSurvivors /= n
+			for _, s := range survivors {
+				score.StdSurvivors += (s - score.MeanSurvivors) * (s - score.MeanSurvivors)
+			}
+			score.StdSurvivors = math.Sqrt(score.StdSurvivors / n)
+		}
+		scores = append(scores, score)
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Points != scores[j].Points {
+			return scores[i].Points > scores[j].Points
+		}
+		if scores[i].Wins != scores[j].Wins {
+			return scores[i].Wins > scores[j].Wins
+		}
+		return scores[i].MeanSurvivors > scores[j].MeanSurvivors
+	})
+	for i := range scores {
+		scores[i].Rank = i + 1
+	}
+	return scores
+}
+
+// explainTournamentResults attaches reasons to each score by comparing entrants with the field
+func explainTournamentResults(entrants []*TournamentEntrant, scores []TournamentScore) {
+	if len(scores) == 0 {
+		return
+	}
+
+	fieldBirths, fieldDeaths := 0.0, 0.0
+	for _, score := range scores {
+		fieldBirths += score.MeanBirths
+		fieldDeaths += score.MeanDeaths
+	}
+	fieldBirths /= float64(len(scores))
+	fieldDeaths /= float64(len(scores))
+
+	// Mean founder traits per entrant, used to spot standout traits
+	founderTraits := make(map[string]map[string]float64, len(entrants))
+	for _, entrant := range entrants {
+		means := make(map[string]float64)
+		for _, record := range entrant.File.Creatures {
+			for _, trait := range tournamentTraits {
+				means[trait] += record.Traits[trait] / float64(len(entrant.File.Creatures))
+			}
+		}
+		founderTraits[entrant.Name] = means
+	}
+
+	for i := range scores {
+		score := &scores[i]
+		score.Reasons = make([]string, 0)
+
+		if score.Rank == 1 && score.Wins > 0 {
+			score.Reasons = append(score.Reasons, fmt.Sprintf("won %d bout(s) outright", score.Wins))
+		}
+		if score.MeanBirths > fieldBirths*1.25 {
+			score.Reasons = append(score.Reasons, fmt.Sprintf("out-reproduced the field (%.1f births/bout vs %.1f average)", score.MeanBirths, fieldBirths))
+		} else if score.MeanBirths < fieldBirths*0.75 {
+			score.Reasons = append(score.Reasons, fmt.Sprintf("reproduced slowly (%.1f births/bout vs %.1f average)", score.MeanBirths, fieldBirths))
+		}
+		if score.MeanDeaths > fieldDeaths*1.25 {
+			score.Reasons = append(score.Reasons, fmt.Sprintf("suffered heavy losses (%.1f deaths/bout vs %.1f average)", score.MeanDeaths, fieldDeaths))
+		} else if score.MeanDeaths < fieldDeaths*0.75 {
+			score.Reasons = append(score.Reasons, fmt.Sprintf("rarely died (%.1f deaths/bout vs %.1f average)", score.MeanDeaths, fieldDeaths))
+		}
+		if score.ExtinctionRate > 0 {
+			score.Reasons = append(score.Reasons, fmt.Sprintf("went extinct in %.0f%% of bouts", score.ExtinctionRate*100))
+		}
+
+		for _, trait := range tournamentTraits {
+			value := founderTraits[score.Entrant][trait]
+			highest, lowest := true, true
+			for name, traits := range founderTraits {
+				if name == score.Entrant {
+					continue
+				}
+				if traits[trait] >= value {
+					highest = false
+				}
+				if traits[trait] <= value {
+					lowest = false
+				}
+			}
+			if highest {
+				score.Reasons = append(score.Reasons, fmt.Sprintf("highest founder %s (%.2f)", trait, value))
+			} else if lowest {
+				score.Reasons = append(score.Reasons, fmt.Sprintf("lowest founder %s (%.2f)", trait, value))
+			}
+		}
+	}
+}
+
+// Summary renders the scoreboard as a human-readable table
+func (tr *TournamentResult) Summary() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Tournament: %d bouts of up to %d ticks, %d founders per entrant\n\n",
+		tr.Config.Bouts, tr.Config.Ticks, tr.Config.Founders))
+	sb.WriteString(fmt.Sprintf("%-4s %-24s %6s %5s %9s %12s %10s\n", "Rank", "Entrant", "Points", "Wins", "Mean rank", "Survivors", "Extinct"))
+	for _, score := range tr.Scoreboard {
+		sb.WriteString(fmt.Sprintf("%-4d %-24s %6d %5d %9.2f %6.1f±%-5.1f %9.0f%%\n",
+			score.Rank, score.Entrant, score.Points, score.Wins, score.MeanRank,
+			score.MeanSurvivors, score.StdSurvivors, score.ExtinctionRate*100))
+	}
+	sb.WriteString("\n")
+	for _, score := range tr.Scoreboard {
+		if len(score.Reasons) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s: %s\n", score.Entrant, strings.Join(score.Reasons, "; ")))
+	}
+	return sb.String()
+}
+
+// runTournamentCommand implements the "tournament" subcommand
+func runTournamentCommand(args []string) error {
+	defaults := DefaultTournamentConfig()
+	fs := newCommandFlags("tournament")
+	bouts := fs.Int("bouts", defaults.Bouts, "Number of bouts")
+	ticks := fs.Int("ticks", defaults.Ticks, "Maximum ticks per bout")
+	founders := fs.Int("founders", defaults.Founders, "Founding creatures per entrant")
+	seed := fs.Int64("seed", defaults.Seed, "Base seed; each bout runs its arena from this plus its number")
+	out := fs.String("out", "", "Write the full results as JSON to this file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := applyEnvironment(fs, commandEnvPrefix(fs.Name())); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		return commandUsage("tournament")
+	}
+
+	entrants, err := LoadTournamentEntrants(fs.Args())
+	if err != nil {
+		return err
+	}
+	config := defaults
+	config.Bouts = *bouts
+	config.Ticks = *ticks
+	config.Founders = *founders
+	config.Seed = *seed
+
+	result, err := RunTournament(entrants, config)
+	if err != nil {
+		return err
+	}
+	fmt.Print(result.Summary())
+
+	if *out != "" {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal results: %v", err)
+		}
+		if err := os.WriteFile(*out, data, 0644); err != nil {
+			return fmt.Errorf("failed to write results: %v", err)
+		}
+		fmt.Printf("Results written to %s\n", *out)
+	}
+	return nil
+}
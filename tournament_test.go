@@ -0,0 +1,102 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func newTournamentEntrant(t *testing.T, name string, traits map[string]float64) *TournamentEntrant {
+	world := newTestWorld(50, 10)
+	a := addCreatureTestEntity(world, "herbivore")
+	b := addCreatureTestEntity(world, "herbivore")
+	for trait, value := range traits {
+		a.SetTrait(trait, value)
+		b.SetTrait(trait, value)
+	}
+	file, err := ExportCreatureFile(world, []int{a.ID, b.ID}, name, "")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	return &TournamentEntrant{Name: name, File: file}
+}
+
+func smallTournamentConfig() TournamentConfig {
+	config := DefaultTournamentConfig()
+	config.Bouts = 2
+	config.Ticks = 20
+	config.Founders = 4
+	return config
+}
+
+func TestTournamentProducesRankedScoreboard(t *testing.T) {
+	entrants := []*TournamentEntrant{
+		newTournamentEntrant(t, "Sprinters", map[string]float64{"speed": 0.9}),
+		newTournamentEntrant(t, "Brutes", map[string]float64{"strength": 0.9, "speed": -0.5}),
+		newTournamentEntrant(t, "Thinkers", map[string]float64{"intelligence": 0.9}),
+	}
+
+	result, err := RunTournament(entrants, smallTournamentConfig())
+	if err != nil {
+		t.Fatalf("Tournament failed: %v", err)
+	}
+	if len(result.Bouts) != 2 {
+		t.Fatalf("Expected 2 bouts, got %d", len(result.Bouts))
+	}
+	if result.Bouts[0].Seed == result.Bouts[1].Seed {
+		t.Error("Expected each bout to use a different seed")
+	}
+	if again, err := RunTournament(entrants, smallTournamentConfig()); err != nil || !reflect.DeepEqual(again.Bouts, result.Bouts) {
+		t.Errorf("Expected the bouts to replay from their seeds, got %v", err)
+	}
+	for _, bout := range result.Bouts {
+		if len(bout.Standings) != 3 {
+			t.Errorf("Expected 3 standings in bout %d, got %d", bout.Bout, len(bout.Standings))
+		}
+	}
+
+	if len(result.Scoreboard) != 3 {
+		t.Fatalf("Expected 3 scoreboard rows, got %d", len(result.Scoreboard))
+	}
+	for i, score := range result.Scoreboard {
+		if score.Rank != i+1 {
+			t.Errorf("Expected rank %d, got %d", i+1, score.Rank)
+		}
+		if i > 0 && score.Points > result.Scoreboard[i-1].Points {
+			t.Error("Expected scoreboard sorted by points")
+		}
+		if len(score.Reasons) == 0 {
+			t.Errorf("Expected reasons for %s", score.Entrant)
+		}
+	}
+}
+
+func TestTournamentRejectsTooFewOrDuplicateEntrants(t *testing.T) {
+	solo := newTournamentEntrant(t, "Solo", nil)
+	if _, err := RunTournament([]*TournamentEntrant{solo}, smallTournamentConfig()); err == nil {
+		t.Error("Expected a single entrant to be rejected")
+	}
+	if _, err := RunTournament([]*TournamentEntrant{solo, solo}, smallTournamentConfig()); err == nil {
+		t.Error("Expected duplicate entrant names to be rejected")
+	}
+}
+
+func TestLoadTournamentEntrantsDeduplicatesNames(t *testing.T) {
+	dir := t.TempDir()
+	paths := make([]string, 0, 2)
+	for i := 0; i < 2; i++ {
+		path := filepath.Join(dir, "entrant"+string(rune('a'+i))+".json")
+		if err := SaveCreatureFile(path, newTournamentEntrant(t, "Grazers", nil).File); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		paths = append(paths, path)
+	}
+
+	entrants, err := LoadTournamentEntrants(paths)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if entrants[0].Name == entrants[1].Name {
+		t.Errorf("Expected unique entrant names, got %q twice", entrants[0].Name)
+	}
+}
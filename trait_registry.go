@@ -0,0 +1,268 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Trait subjects: the organisms a trait belongs to
+const (
+	TraitSubjectCreature = "creature"
+	TraitSubjectPlant    = "plant"
+)
+
+// Trait kinds
+const (
+	TraitKindGene  = "gene"  // Inherited, mutated and selected on
+	TraitKindState = "state" // Bookkeeping kept in the trait map, such as hive membership
+)
+
+// geneBound is the magnitude mutation, speciation and caste changes clamp genes to
+const geneBound = 2.0
+
+// idTraitMax bounds traits that hold the ID of a hive, swarm or plant
+const idTraitMax = float64(math.MaxInt32)
+
+// TraitDefinition describes a trait: what it means, the values it may take and the
+// systems that read it
+type TraitDefinition struct {
+	Name        string   `json:"name"`
+	Subject     string   `json:"subject"`
+	Kind        string   `json:"kind"`
+	Min         float64  `json:"min"`
+	Max         float64  `json:"max"`
+	Description string   `json:"description"`
+	Systems     []string `json:"systems"`
+	Prefix      bool     `json:"prefix,omitempty"` // Name is completed at runtime, e.g. prey_preference_<species>
+	Custom      bool     `json:"custom,omitempty"` // Defined in configuration (see CustomTraitDefinition)
+}
+
+// Clamp limits a value to the trait's range
+func (d TraitDefinition) Clamp(value float64) float64 {
+	return math.Max(d.Min, math.Min(d.Max, value))
+}
+
+// creatureGene defines a creature gene with the standard range
+func creatureGene(name, description string, systems ...string) TraitDefinition {
+	return TraitDefinition{Name: name, Subject: TraitSubjectCreature, Kind: TraitKindGene,
+		Min: -geneBound, Max: geneBound, Description: description, Systems: systems}
+}
+
+// plantGene defines a plant gene. Plants mutate within ±1 but fertilizer pushes traits
+// further, so they share the creature range.
+func plantGene(name, description string, systems ...string) TraitDefinition {
+	return TraitDefinition{Name: name, Subject: TraitSubjectPlant, Kind: TraitKindGene,
+		Min: -geneBound, Max: geneBound, Description: description, Systems: systems}
+}
+
+// creatureState defines a creature trait that records state rather than heredity
+func creatureState(name string, min, max float64, description string, systems ...string) TraitDefinition {
+	return TraitDefinition{Name: name, Subject: TraitSubjectCreature, Kind: TraitKindState,
+		Min: min, Max: max, Description: description, Systems: systems}
+}
+
+// builtinTraits are the traits the simulation itself reads and writes
+var builtinTraits = []TraitDefinition{
+	// Core genes every population starts with
+	creatureGene("size", "Body size; larger creatures win fights and store energy but burn more of it", "combat", "metabolism", "movement"),
+	creatureGene("speed", "How fast the creature moves when hunting, foraging or fleeing", "movement", "combat"),
+	creatureGene("aggression", "Tendency to attack rather than avoid other creatures", "combat", "social"),
+	creatureGene("defense", "Resistance to attack", "combat"),
+	creatureGene("cooperation", "Willingness to share, group and join colonies", "social", "tribes", "hive_mind", "culture"),
+	creatureGene("intelligence", "Problem solving; drives learning, tools, communication and decisions", "learning", "tools", "communication", "neural_ai", "culture"),
+	creatureGene("endurance", "Stamina; lowers the energy cost of moving and surviving", "metabolism", "movement"),
+	creatureGene("strength", "Physical power in fights and construction", "combat", "construction"),
+	creatureGene("aquatic_adaptation", "Ability to live and move in water", "environment", "movement"),
+	creatureGene("digging_ability", "Ability to burrow", "underground"),
+	creatureGene("underground_nav", "Ability to find the way through tunnels", "underground"),
+	creatureGene("flying_ability", "Ability to fly over terrain", "movement", "environment"),
+	creatureGene("altitude_tolerance", "Ability to live on mountains", "environment"),
+
+	// Biorhythm genes
+	creatureGene("circadian_preference", "Positive for diurnal, negative for nocturnal activity", "biorhythm"),
+	creatureGene("sleep_need", "How much rest the creature needs", "biorhythm"),
+	creatureGene("hunger_need", "How often the creature needs to eat", "biorhythm"),
+	creatureGene("thirst_need", "How often the creature needs to drink", "biorhythm"),
+	creatureGene("play_drive", "Time spent playing", "biorhythm"),
+	creatureGene("exploration_drive", "Time spent exploring", "biorhythm"),
+	creatureGene("scavenging_behavior", "Preference for scavenging remains over fresh food", "biorhythm", "feeding"),
+
+	// Genes gained through evolution, castes and specialised systems
+	creatureGene("vision", "Sight range", "perception", "castes"),
+	creatureGene("agility", "Nimbleness that helps escape predators", "combat", "movement"),
+	creatureGene("adaptability", "Tolerance of mixed conditions at biome boundaries", "environment"),
+	creatureGene("curiosity", "Drive to explore and innovate", "culture", "emergent_behavior"),
+	creatureGene("diet_flexibility", "Ability to eat a wide range of foods", "feeding"),
+	creatureGene("toxin_resistance", "Tolerance of plant toxins", "feeding", "chemical_ecology"),
+	creatureGene("metabolism", "Metabolic rate; fast metabolisms age sooner", "aging"),
+	creatureGene("leadership", "Ability to lead a colony", "castes"),
+	creatureGene("nurturing", "Care given to the young", "castes"),
+	creatureGene("construction_skill", "Skill at building structures", "castes", "construction"),
+	creatureGene("specialization", "Degree of caste specialization", "castes"),
+	creatureGene("mating_drive", "Eagerness to mate", "castes", "reproduction"),
+	creatureGene("foraging_efficiency", "Food gathered per foraging trip", "castes", "feeding"),
+	creatureGene("tool_use", "Aptitude for making and using tools", "culture"),
+	creatureGene("communication_skill", "Ability to teach others", "culture"),
+	creatureGene("vigilance", "Awareness of danger", "culture"),
+	creatureGene("territorial_range", "Size of the home range", "culture"),
+	creatureGene("territorial", "Tendency to defend territory", "hive_mind"),
+	creatureGene("summer_mating", "Positive when the creature mates in summer", "reproduction"),
+	creatureGene("autumn_mating", "Positive when the creature mates in autumn", "reproduction"),
+	creatureGene("multiple_births", "Chance of twins", "reproduction"),
+	creatureGene("reproduction_rate", "How readily the creature breeds", "reproduction"),
+	creatureState("reproductive_capability", 0, 3, "Breeding capacity set by caste, from sterile workers to queens", "castes", "reproduction"),
+	creatureState("health", 0, geneBound, "Condition worn down by environmental pressures and parasites", "environmental_pressures", "symbiosis"),
+	creatureState("fertility", 0, geneBound, "Breeding condition reduced by environmental pressures and parasites", "environmental_pressures", "reproduction", "symbiosis"),
+	creatureState("efficiency", 0, 1, "Benefit a symbiont provides its host", "symbiosis"),
+
+	// Insect genes
+	creatureGene("swarm_capability", "Ability to join and coordinate a swarm", "insects"),
+	creatureGene("pheromone_sensitivity", "Ability to follow pheromone trails", "insects"),
+	creatureGene("pheromone_production", "Strength of the pheromone trails laid", "insects"),
+	creatureGene("colony_loyalty", "Attachment to the colony", "insects"),
+	creatureGene("metamorphosis", "Tendency to develop through life stages", "insects", "metamorphosis"),
+	creatureGene("exoskeleton_strength", "Toughness of the exoskeleton", "insects", "combat"),
+	creatureGene("eusociality", "Degree of colony division of labour", "insects", "castes"),
+	creatureGene("flight_capability", "Insect wing development", "insects", "movement"),
+
+	// Pollinator genes
+	creatureGene("pollination_efficiency", "Chance of transferring pollen between flowers", "pollination"),
+	creatureGene("nectar_detection", "Ability to find nectar", "pollination"),
+	creatureGene("flower_memory", "Ability to remember good flowers", "pollination"),
+	creatureGene("pollen_capacity", "Pollen carried per visit", "pollination"),
+	creatureGene("nectar_needs", "Nectar needed to stay fed", "pollination"),
+	creatureGene("seasonal_activity", "How strongly activity follows the seasons", "pollination"),
+	creatureGene("grass_preference", "Preference for grass flowers", "pollination"),
+	creatureGene("bush_preference", "Preference for bush flowers", "pollination"),
+	creatureGene("tree_preference", "Preference for tree flowers", "pollination"),
+	creatureGene("cactus_preference", "Preference for cactus flowers", "pollination"),
+	creatureState("flight_range", -geneBound, 40, "Distance a pollinator forages from home, 10 to 40 units at birth", "pollination"),
+	creatureState("pollinator_type", 0, float64(HybridPollinator), "Generalist (0), specialist (1) or hybrid (2) pollinator", "pollination"),
+
+	// Runtime state
+	creatureState("hive_member", 0, 1, "1 while the creature belongs to a hive mind", "hive_mind"),
+	creatureState("hive_id", 0, idTraitMax, "ID of the creature's hive mind", "hive_mind"),
+	creatureState("swarm_member", 0, 1, "1 while the creature belongs to a swarm", "insects"),
+	creatureState("swarm_id", 0, idTraitMax, "ID of the creature's swarm", "insects"),
+	creatureState("carried_pollen", 0, idTraitMax, "Pollen carried from the last flower", "pollination"),
+	creatureState("carried_pollen_type", 0, float64(PlantKelp), "Plant type of the carried pollen", "pollination"),
+	creatureState("carried_pollen_source", 0, idTraitMax, "ID of the plant the carried pollen came from", "pollination"),
+	{
+		Name: "prey_preference_", Subject: TraitSubjectCreature, Kind: TraitKindState, Min: 0, Max: 1, Prefix: true,
+		Description: "Learned taste for a prey species, named prey_preference_<species>", Systems: []string{"feeding"},
+	},
+
+	// Plant genes
+	plantGene("growth_efficiency", "Growth rate and nectar regeneration", "plants", "pollination"),
+	plantGene("defense", "Resistance to grazing", "plants"),
+	plantGene("nutrition_density", "Nutrition and nectar per bite", "plants", "feeding", "pollination"),
+	plantGene("toxin_production", "Toxins that deter herbivores and shape which pollinators visit", "plants", "chemical_ecology", "pollination"),
+	plantGene("hardiness", "Tolerance of harsh conditions", "plants", "plant_network"),
+	plantGene("reproduction_rate", "Seed and pollen production", "plants", "pollination", "plant_network"),
+	plantGene("toxicity", "Toxins in the plant's tissue", "molecular"),
+	plantGene("nutrition_value", "Nutrients in the plant's tissue", "molecular"),
+	plantGene("disease_resistance", "Resistance to plant disease", "plants"),
+}
+
+// TraitRegistry holds the definition of every trait, keyed by subject and name
+type TraitRegistry struct {
+	definitions map[string]TraitDefinition
+}
+
+// NewTraitRegistry creates a registry holding the built-in traits
+func NewTraitRegistry() *TraitRegistry {
+	r := &TraitRegistry{definitions: make(map[string]TraitDefinition, len(builtinTraits))}
+	for _, definition := range builtinTraits {
+		if err := r.Register(definition); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// traitKey identifies a trait within the registry
+func traitKey(subject, name string) string {
+	return subject + "/" + name
+}
+
+// Register adds or replaces a trait definition
+func (r *TraitRegistry) Register(definition TraitDefinition) error {
+	if definition.Name == "" {
+		return fmt.Errorf("trait name is required")
+	}
+	if definition.Subject != TraitSubjectCreature && definition.Subject != TraitSubjectPlant {
+		return fmt.Errorf("trait %s has unknown subject %q", definition.Name, definition.Subject)
+	}
+	if definition.Kind != TraitKindGene && definition.Kind != TraitKindState {
+		return fmt.Errorf("trait %s has unknown kind %q", definition.Name, definition.Kind)
+	}
+	if isInvalidNumber(definition.Min) || isInvalidNumber(definition.Max) || definition.Min >= definition.Max {
+		return fmt.Errorf("trait %s has invalid range [%v, %v]", definition.Name, definition.Min, definition.Max)
+	}
+	r.definitions[traitKey(definition.Subject, definition.Name)] = definition
+	return nil
+}
+
+// Lookup finds the definition of a trait, matching prefixed definitions when there is no exact one
+func (r *TraitRegistry) Lookup(subject, name string) (TraitDefinition, bool) {
+	if definition, ok := r.definitions[traitKey(subject, name)]; ok && !definition.Prefix {
+		return definition, true
+	}
+	for _, definition := range r.definitions {
+		if definition.Prefix && definition.Subject == subject && strings.HasPrefix(name, definition.Name) && len(name) > len(definition.Name) {
+			return definition, true
+		}
+	}
+	return TraitDefinition{}, false
+}
+
+// Definitions lists the definitions for a subject, or for every subject when it is empty,
+// sorted by subject and name
+func (r *TraitRegistry) Definitions(subject string) []TraitDefinition {
+	definitions := make([]TraitDefinition, 0, len(r.definitions))
+	for _, key := range sortedKeys(r.definitions) {
+		if definition := r.definitions[key]; subject == "" || definition.Subject == subject {
+			definitions = append(definitions, definition)
+		}
+	}
+	return definitions
+}
+
+// Check reports whether a value is valid for a trait
+func (r *TraitRegistry) Check(subject, name string, value float64) error {
+	definition, ok := r.Lookup(subject, name)
+	if !ok {
+		return fmt.Errorf("unknown %s trait %s", subject, name)
+	}
+	if value < definition.Min || value > definition.Max {
+		return fmt.Errorf("%s %s must be between %v and %v, got %v", subject, name, definition.Min, definition.Max, value)
+	}
+	return nil
+}
+
+// validateTraitMap reports traits missing from the registry and values outside their
+// range. Repairs drop unknown traits, since nothing reads them, and clamp the rest.
+func (r *TraitRegistry) validateTraitMap(subject string, traits map[string]float64, owner string, report *SaveValidationReport, repair bool) {
+	for _, name := range sortedKeys(traits) {
+		value := traits[name]
+		if isInvalidNumber(value) {
+			continue // Reported by repairTraitMap
+		}
+		definition, ok := r.Lookup(subject, name)
+		if !ok {
+			report.addIssue(SaveIssueWarning, "unknown_trait", repair, "%s has unknown trait %s", owner, name)
+			if repair {
+				delete(traits, name)
+			}
+			continue
+		}
+		if value < definition.Min || value > definition.Max {
+			report.addIssue(SaveIssueWarning, "trait_out_of_range", repair,
+				"%s trait %s is %v, outside [%v, %v]", owner, name, value, definition.Min, definition.Max)
+			if repair {
+				traits[name] = definition.Clamp(value)
+			}
+		}
+	}
+}
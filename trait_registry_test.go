@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTraitRegistryKnowsSimulatedTraits(t *testing.T) {
+	world := newSteppingTestWorld(81)
+	for _, population := range startingPopulations(true) {
+		world.AddPopulation(population)
+	}
+	if _, err := world.Step(50); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := world.TraitRegistry
+	for _, entity := range world.AllEntities {
+		for name := range entity.Traits {
+			if _, ok := registry.Lookup(TraitSubjectCreature, name); !ok {
+				t.Errorf("Creature trait %s is missing from the registry", name)
+			}
+		}
+	}
+	for _, plant := range world.AllPlants {
+		for name := range plant.Traits {
+			if _, ok := registry.Lookup(TraitSubjectPlant, name); !ok {
+				t.Errorf("Plant trait %s is missing from the registry", name)
+			}
+		}
+	}
+
+	if _, ok := registry.Lookup(TraitSubjectCreature, "prey_preference_herbivore"); !ok {
+		t.Error("Expected learned prey preferences to match their prefix")
+	}
+	if _, ok := registry.Lookup(TraitSubjectCreature, "prey_preference_"); ok {
+		t.Error("Expected a bare prefix not to be a trait")
+	}
+	if err := registry.Register(TraitDefinition{Name: "glow", Subject: TraitSubjectCreature, Kind: TraitKindGene, Min: 1, Max: 1}); err == nil {
+		t.Error("Expected an empty range to be rejected")
+	}
+}
+
+func TestValidateStateChecksTraitsAgainstRegistry(t *testing.T) {
+	state := newValidationTestState()
+	state.Entities[0].Traits = map[string]float64{"speed": 5, "telepathy": 1, "prey_preference_predator": 0.5}
+	state.Plants[0].Traits = map[string]float64{"hardiness": -3, "speed": 0.2}
+
+	report := ValidateState(state, false)
+	kinds := make(map[string]int)
+	for _, issue := range report.Issues {
+		kinds[issue.Kind]++
+	}
+	if kinds["unknown_trait"] != 2 || kinds["trait_out_of_range"] != 2 || len(report.Issues) != 4 {
+		t.Fatalf("Expected two unknown and two out of range traits:\n%s", report.Summary())
+	}
+
+	ValidateState(state, true)
+	entity, plant := state.Entities[0].Traits, state.Plants[0].Traits
+	if entity["speed"] != geneBound || plant["hardiness"] != -geneBound {
+		t.Errorf("Expected out of range traits clamped, got speed %v and hardiness %v", entity["speed"], plant["hardiness"])
+	}
+	if _, ok := entity["telepathy"]; ok {
+		t.Error("Expected the unknown creature trait dropped")
+	}
+	if _, ok := plant["speed"]; ok {
+		t.Error("Expected a creature trait on a plant dropped")
+	}
+	if !ValidateState(state, false).IsValid() {
+		t.Error("Expected the repaired state to be valid")
+	}
+}
+
+func TestTraitEditsRespectRegistry(t *testing.T) {
+	world := newSteppingTestWorld(82)
+	creature := world.AllEntities[0]
+	if _, err := world.EditEntityTrait(creature.ID, "speed", 2.5); err == nil {
+		t.Error("Expected an out of range speed to be rejected")
+	}
+	if _, err := world.EditEntityTrait(creature.ID, "telepathy", 1); err == nil {
+		t.Error("Expected an unknown trait to be rejected")
+	}
+	if _, err := world.EditEntityTrait(creature.ID, "speed", -2); err != nil {
+		t.Errorf("Expected the range limit to be allowed: %v", err)
+	}
+}
+
+func TestTraitsAPI(t *testing.T) {
+	wi := NewWebInterface(newSteppingTestWorld(83))
+
+	rec := httptest.NewRecorder()
+	wi.handleTraits(rec, httptest.NewRequest(http.MethodGet, "/api/traits?subject=plant", nil))
+	var definitions []TraitDefinition
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &definitions) != nil {
+		t.Fatalf("Listing plant traits failed: %d %s", rec.Code, rec.Body.String())
+	}
+	if len(definitions) == 0 {
+		t.Fatal("Expected plant traits")
+	}
+	for _, definition := range definitions {
+		if definition.Subject != TraitSubjectPlant || definition.Description == "" || len(definition.Systems) == 0 || definition.Min >= definition.Max {
+			t.Errorf("Expected a complete plant trait definition, got %+v", definition)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleTraits(rec, httptest.NewRequest(http.MethodGet, "/api/traits?subject=fungus", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected an unknown subject to be rejected, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+// addTestTribe camps a tribe of a number of Folk at a position and returns it; the tribe
+// is the world's only one
+func addTestTribe(world *World, name string, members int, at Position) *Tribe {
+	var tribe *Tribe
+	for i := 0; i < members; i++ {
+		member := NewEntity(world.Rand, len(world.AllEntities)+1, []string{"intelligence"}, "Folk", at)
+		member.TribeID = 1
+		world.AllEntities = append(world.AllEntities, member)
+		if tribe == nil {
+			tribe = NewTribe(1, name, member)
+		} else {
+			tribe.AddMember(member)
+		}
+	}
+	world.CivilizationSystem.Tribes = []*Tribe{tribe}
+	return tribe
+}
+
+// runSystem advances a world system by a number of updates, an interval of ticks apart
+func runSystem(world *World, interval, updates int, update func(*World)) {
+	for i := 0; i < updates; i++ {
+		world.Tick += interval
+		update(world)
+	}
+}
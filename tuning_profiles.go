@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// TuningProfile is a named set of timescales selected with --profile
+type TuningProfile struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Timescales  TimescaleConfig `json:"timescales"`
+}
+
+// realisticTimescales gives world events and gestation a realistic share of a lifetime:
+// an ice age spans generations rather than a sliver of one, and a pregnancy is a real
+// fraction of the time to maturity without outlasting it. Mutation is rarer than in the
+// standard tuning.
+var realisticTimescales = TimescaleConfig{
+	Profile:            "realistic",
+	LifespanScale:      1.0,
+	EventDurationScale: 10.0,
+	MutationRateScale:  0.5,
+	GestationScale:     1.25,
+	DecayScale:         1.0,
+	SeasonScale:        1.0,
+}
+
+// compressedTimescales speeds up the realistic timescales by compression, shortening
+// lifespans, events, gestation, decay and seasons by the same factor so their
+// proportions are kept
+func compressedTimescales(profile string, compression, mutationScale float64) TimescaleConfig {
+	return TimescaleConfig{
+		Profile:            profile,
+		LifespanScale:      realisticTimescales.LifespanScale * compression,
+		EventDurationScale: realisticTimescales.EventDurationScale * compression,
+		MutationRateScale:  mutationScale,
+		GestationScale:     realisticTimescales.GestationScale * compression,
+		DecayScale:         realisticTimescales.DecayScale * compression,
+		SeasonScale:        realisticTimescales.SeasonScale * compression,
+	}
+}
+
+// tuningProfiles are the profiles --profile accepts, the default first
+var tuningProfiles = []TuningProfile{
+	{
+		Name:        "standard",
+		Description: "The built-in tuning; short events and gestation relative to lifespans",
+		Timescales:  DefaultSimulationConfig().Timescales,
+	},
+	{
+		Name:        "realistic",
+		Description: "Events that outlast generations, longer gestation and rarer mutation",
+		Timescales:  realisticTimescales,
+	},
+	{
+		Name:        "fast-evolution",
+		Description: "Realistic proportions at five times the pace with doubled mutation, for many generations per run",
+		Timescales:  compressedTimescales("fast-evolution", 0.2, 2.0),
+	},
+	{
+		Name:        "classroom",
+		Description: "Realistic proportions at ten times the pace with raised mutation, so change shows within a lesson",
+		Timescales:  compressedTimescales("classroom", 0.1, 1.5),
+	},
+}
+
+// FindTuningProfile returns the profile with the given name
+func FindTuningProfile(name string) (TuningProfile, error) {
+	for _, profile := range tuningProfiles {
+		if profile.Name == name {
+			return profile, nil
+		}
+	}
+	return TuningProfile{}, fmt.Errorf("unknown tuning profile %q (choose from %s)", name, strings.Join(TuningProfileNames(), ", "))
+}
+
+// TuningProfileNames lists the profile names, the default first
+func TuningProfileNames() []string {
+	names := make([]string, len(tuningProfiles))
+	for i, profile := range tuningProfiles {
+		names[i] = profile.Name
+	}
+	return names
+}
+
+// ApplyTuningProfile sets the timescales of the named profile
+func (config *SimulationConfig) ApplyTuningProfile(name string) error {
+	profile, err := FindTuningProfile(name)
+	if err != nil {
+		return err
+	}
+	config.Timescales = profile.Timescales
+	return nil
+}
+
+// scaledEventDuration stretches an event's built-in duration by the timescales, keeping
+// at least one tick
+func (w *World) scaledEventDuration(ticks int) int {
+	return max(1, int(math.Round(float64(ticks)*w.SimConfig.Timescales.EventDurationScale)))
+}
+
+// scaledMutationRate scales a built-in mutation rate by the timescales; rates are
+// probabilities, so the result stays at most 1
+func (w *World) scaledMutationRate(rate float64) float64 {
+	return math.Min(1.0, rate*w.SimConfig.Timescales.MutationRateScale)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTuningProfilesKeepTimescalesInProportion(t *testing.T) {
+	realistic, err := FindTuningProfile("realistic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventsPerLifespan := func(scales TimescaleConfig) float64 { return scales.EventDurationScale / scales.LifespanScale }
+	gestationPerLifespan := func(scales TimescaleConfig) float64 { return scales.GestationScale / scales.LifespanScale }
+
+	standard := DefaultSimulationConfig().Timescales
+	if eventsPerLifespan(realistic.Timescales) <= eventsPerLifespan(standard) {
+		t.Error("Expected realistic events to last longer relative to lifespans than the standard tuning")
+	}
+
+	for _, name := range []string{"fast-evolution", "classroom"} {
+		config := DefaultSimulationConfig()
+		if err := config.ApplyTuningProfile(name); err != nil {
+			t.Fatal(err)
+		}
+		if err := config.Validate(); err != nil {
+			t.Errorf("Expected %s to be a valid config: %v", name, err)
+		}
+		scales := config.Timescales
+		if scales.Profile != name || scales.LifespanScale >= 1 || scales.MutationRateScale <= 1 {
+			t.Errorf("Expected %s to shorten lifespans and raise mutation, got %+v", name, scales)
+		}
+		if math.Abs(eventsPerLifespan(scales)-eventsPerLifespan(realistic.Timescales)) > 1e-9 ||
+			math.Abs(gestationPerLifespan(scales)-gestationPerLifespan(realistic.Timescales)) > 1e-9 {
+			t.Errorf("Expected %s to keep realistic proportions, got %+v", name, scales)
+		}
+	}
+
+	if _, err := FindTuningProfile("turbo"); err == nil {
+		t.Error("Expected an unknown profile to be rejected")
+	}
+}
+
+func TestWorldFollowsTuningProfile(t *testing.T) {
+	config := WorldConfig{Width: 100, Height: 100, NumPopulations: 1, PopulationSize: 5, GridWidth: 20, GridHeight: 20}
+	standard := NewWorld(config)
+	config.Profile = "classroom"
+	classroom := NewWorld(config)
+	scales := classroom.SimConfig.Timescales
+
+	for classification, data := range standard.OrganismClassifier.LifespanData {
+		scaled := classroom.OrganismClassifier.LifespanData[classification]
+		expected := int(math.Round(float64(data.BaseLifespanTicks) * scales.LifespanScale))
+		if scaled.BaseLifespanTicks != expected || scaled.MaturationAge > data.MaturationAge {
+			t.Errorf("Expected %s lifespans scaled by %.2f, got %d from %d", data.Name, scales.LifespanScale, scaled.BaseLifespanTicks, data.BaseLifespanTicks)
+		}
+	}
+	if classroom.ReproductionSystem.GestationScale != scales.GestationScale {
+		t.Errorf("Expected gestation scaled by %.2f, got %.2f", scales.GestationScale, classroom.ReproductionSystem.GestationScale)
+	}
+	if got := classroom.scaledEventDuration(30); got != int(math.Round(30*scales.EventDurationScale)) {
+		t.Errorf("Expected a 30 tick event to last %.0f ticks, got %d", 30*scales.EventDurationScale, got)
+	}
+	if classroom.scaledMutationRate(0.9) != 1.0 {
+		t.Error("Expected scaled mutation rates to stay probabilities")
+	}
+
+	// Changing the speed rebuilds the config but keeps the profile
+	classroom.SetSpeedMultiplier(2.0)
+	if classroom.SimConfig.Timescales != scales {
+		t.Errorf("Expected the speed change to keep the timescales, got %+v", classroom.SimConfig.Timescales)
+	}
+}
+
+func TestGestationScaleStretchesPregnancy(t *testing.T) {
+	rs := NewReproductionSystem(nil)
+	rs.GestationScale = 2.0
+	mother := NewEntity(sharedRand, 1, []string{"size"}, "herbivore", Position{})
+	mother.ReproductionStatus.IsPregnant = true
+	mother.ReproductionStatus.GestationStartTick = 0
+	mother.ReproductionStatus.GestationPeriod = 50
+
+	if born := rs.CheckGestation([]*Entity{mother}, 60); len(born) != 0 || !mother.ReproductionStatus.IsPregnant {
+		t.Fatal("Expected a doubled gestation to still be under way after 60 ticks")
+	}
+	rs.CheckGestation([]*Entity{mother}, 100)
+	if mother.ReproductionStatus.IsPregnant {
+		t.Error("Expected the pregnancy to end once the doubled gestation passed")
+	}
+}
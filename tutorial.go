@@ -0,0 +1,503 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// Tutorial statuses
+const (
+	TutorialRunning   = "running"
+	TutorialCompleted = "completed"
+	TutorialStopped   = "stopped"
+)
+
+// Tutorial scenario tuning
+const (
+	tutorialFounders         = 24 // Creatures a scenario starts with
+	tutorialDescendants      = 2  // Creatures that must evolve from the founders before a new lineage counts as more than a fluke
+	tutorialFireScarCells    = 12 // Burned cells the spreading fire step waits for
+	tutorialRecoveredCells   = 5  // Burned cells that must turn from desert before the scar counts as recovering
+	maxPendingTutorialEvents = 500
+)
+
+// tutorialEventTypes are the central events tutorial steps watch for
+var tutorialEventTypes = map[string]bool{
+	EventTypeDeath:              true,
+	EventTypeSpeciation:         true,
+	"environmental_event_start": true,
+}
+
+// tutorialDetector checks a step against the world and the events raised since the last
+// check, returning what it saw happen once the step is complete
+type tutorialDetector func(t *Tutorial, w *World, events []CentralEvent) (string, bool)
+
+// TutorialStep is one stage of a guided scenario. It completes when the simulation does
+// what the step describes, not after a set time.
+type TutorialStep struct {
+	Title        string     `json:"title"`
+	Instructions string     `json:"instructions"`
+	Action       string     `json:"action,omitempty"` // Environmental event the step asks the user to start
+	Target       *GridPoint `json:"target,omitempty"` // Cell the action is aimed at
+	Done         bool       `json:"done"`
+	DoneTick     int        `json:"done_tick,omitempty"`
+	Observation  string     `json:"observation,omitempty"` // What happened in the world to complete the step
+
+	detect tutorialDetector
+}
+
+// TutorialScenario is a scripted small world and the steps that walk through it
+type TutorialScenario struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	// start lays out the world, which has just been reset, and returns the steps
+	start func(t *Tutorial, w *World) []TutorialStep
+}
+
+// Tutorial is a guided scenario in progress, or the last one played
+type Tutorial struct {
+	Scenario  string         `json:"scenario"`
+	Name      string         `json:"name"`
+	Status    string         `json:"status"`
+	StartTick int            `json:"start_tick"`
+	EndTick   int            `json:"end_tick,omitempty"`
+	Current   int            `json:"current"` // Index of the step being worked on, the step count once complete
+	Steps     []TutorialStep `json:"steps"`
+
+	// What the steps have learned about the world so far
+	founders string             // Species the scenario started with
+	species  string             // Species that evolved from them
+	evolved  map[int]bool       // Creatures that have evolved away from the founders
+	fireTick int                // Tick the wildfire started
+	scar     map[GridPoint]bool // Cells the fire burned, once it has started
+	watch    func(t *Tutorial, w *World)
+}
+
+// snapshot copies the tutorial for callers outside the lock
+func (t *Tutorial) snapshot() Tutorial {
+	copied := *t
+	copied.Steps = append([]TutorialStep(nil), t.Steps...)
+	copied.evolved, copied.scar, copied.watch = nil, nil, nil
+	return copied
+}
+
+// TutorialSystem runs one guided scenario at a time. It listens to the central event bus
+// and advances through the scenario's steps as the simulation raises the events and
+// reaches the states they wait for.
+type TutorialSystem struct {
+	mu       sync.Mutex
+	tutorial *Tutorial
+	eventBus *CentralEventBus
+
+	// Restored when the tutorial ends
+	randomEventsOff, respawnOff bool
+
+	// Events raised since the last update, kept apart so listeners never wait on a tutorial update
+	pendingMu sync.Mutex
+	pending   []CentralEvent
+	listening bool
+}
+
+// NewTutorialSystem creates a tutorial system listening to a world's event bus
+func NewTutorialSystem(eventBus *CentralEventBus) *TutorialSystem {
+	ts := &TutorialSystem{eventBus: eventBus}
+	if eventBus != nil {
+		eventBus.AddListener(ts.observe)
+	}
+	return ts
+}
+
+// observe keeps the events tutorial steps watch for while a tutorial runs
+func (ts *TutorialSystem) observe(event CentralEvent) {
+	if !tutorialEventTypes[event.Type] {
+		return
+	}
+	ts.pendingMu.Lock()
+	defer ts.pendingMu.Unlock()
+	if ts.listening && len(ts.pending) < maxPendingTutorialEvents {
+		ts.pending = append(ts.pending, event)
+	}
+}
+
+// listen starts or stops keeping events, dropping any not yet looked at
+func (ts *TutorialSystem) listen(listening bool) {
+	ts.pendingMu.Lock()
+	defer ts.pendingMu.Unlock()
+	ts.listening = listening
+	ts.pending = nil
+}
+
+// TutorialScenarios lists the guided scenarios
+func TutorialScenarios() []TutorialScenario {
+	return []TutorialScenario{speciationTutorial(), wildfireTutorial()}
+}
+
+// Start resets the world into a scenario and begins walking through its steps. Random world
+// events and population top-ups are off until the tutorial ends, so what happens is down to
+// the scenario and the user.
+func (ts *TutorialSystem) Start(w *World, id string) (Tutorial, error) {
+	var scenario *TutorialScenario
+	for _, candidate := range TutorialScenarios() {
+		if candidate.ID == id {
+			scenario = &candidate
+			break
+		}
+	}
+	if scenario == nil {
+		return Tutorial{}, fmt.Errorf("unknown tutorial %q (use speciation or wildfire)", id)
+	}
+
+	// Resetting stops any tutorial already running, giving the world its settings back
+	w.Reset()
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.randomEventsOff, ts.respawnOff = w.RandomEventsOff, w.RespawnOff
+	w.RandomEventsOff, w.RespawnOff = true, true
+
+	tutorial := &Tutorial{Scenario: scenario.ID, Name: scenario.Name, Status: TutorialRunning, StartTick: w.Tick}
+	tutorial.Steps = scenario.start(tutorial, w)
+	ts.tutorial = tutorial
+	ts.listen(true)
+	ts.emit(w, "tutorial_started", fmt.Sprintf("Tutorial started: %s", scenario.Name))
+	return tutorial.snapshot(), nil
+}
+
+// Stop ends a running tutorial before its last step
+func (ts *TutorialSystem) Stop(w *World) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.tutorial == nil || ts.tutorial.Status != TutorialRunning {
+		return false
+	}
+	ts.finish(w, TutorialStopped)
+	ts.emit(w, "tutorial_stopped", fmt.Sprintf("Tutorial stopped: %s", ts.tutorial.Name))
+	return true
+}
+
+// finish ends the tutorial and gives the world back its settings; the caller holds the lock
+func (ts *TutorialSystem) finish(w *World, status string) {
+	ts.tutorial.Status = status
+	ts.tutorial.EndTick = w.Tick
+	w.RandomEventsOff, w.RespawnOff = ts.randomEventsOff, ts.respawnOff
+	ts.listen(false)
+}
+
+// Current returns the running tutorial, or the last one played
+func (ts *TutorialSystem) Current() (Tutorial, bool) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.tutorial == nil {
+		return Tutorial{}, false
+	}
+	return ts.tutorial.snapshot(), true
+}
+
+// Update checks the current step against the events raised since the last update and the
+// state of the world, moving on to the next step once it is done
+func (ts *TutorialSystem) Update(w *World) {
+	ts.pendingMu.Lock()
+	events := ts.pending
+	ts.pending = nil
+	ts.pendingMu.Unlock()
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	tutorial := ts.tutorial
+	if tutorial == nil || tutorial.Status != TutorialRunning {
+		return
+	}
+	if tutorial.watch != nil {
+		tutorial.watch(tutorial, w)
+	}
+
+	step := &tutorial.Steps[tutorial.Current]
+	observation, done := step.detect(tutorial, w, events)
+	if !done {
+		return
+	}
+	step.Done = true
+	step.DoneTick = w.Tick
+	step.Observation = observation
+	tutorial.Current++
+	ts.emit(w, "tutorial_step", fmt.Sprintf("Tutorial step %d of %d done: %s - %s", tutorial.Current, len(tutorial.Steps), step.Title, observation))
+
+	if tutorial.Current == len(tutorial.Steps) {
+		ts.finish(w, TutorialCompleted)
+		ts.emit(w, "tutorial_completed", fmt.Sprintf("Tutorial completed: %s", tutorial.Name))
+	}
+}
+
+// emit raises a tutorial event on the central event bus
+func (ts *TutorialSystem) emit(w *World, eventType, description string) {
+	if ts.eventBus == nil {
+		return
+	}
+	ts.eventBus.EmitSystemEvent(w.Tick, eventType, "tutorial", "tutorial", description, nil,
+		map[string]interface{}{"scenario": ts.tutorial.Scenario, "step": ts.tutorial.Current, "steps": len(ts.tutorial.Steps)})
+}
+
+// cellCenter returns the world position in the middle of a grid cell
+func (w *World) cellCenter(cell GridPoint) Position {
+	return Position{
+		X: (float64(cell.X) + 0.5) * w.Config.Width / float64(w.Config.GridWidth),
+		Y: (float64(cell.Y) + 0.5) * w.Config.Height / float64(w.Config.GridHeight),
+	}
+}
+
+// paintTutorialBiome lays a disc of a biome around a cell
+func (w *World) paintTutorialBiome(center GridPoint, radius int, biome BiomeType) {
+	for y := center.Y - radius; y <= center.Y+radius; y++ {
+		for x := center.X - radius; x <= center.X+radius; x++ {
+			dx, dy := x-center.X, y-center.Y
+			if x >= 0 && x < w.Config.GridWidth && y >= 0 && y < w.Config.GridHeight && dx*dx+dy*dy <= radius*radius {
+				w.Grid[y][x].Biome = biome
+			}
+		}
+	}
+}
+
+// addTutorialPopulation places a scenario's founders around a cell
+func (w *World) addTutorialPopulation(config PopulationConfig, home GridPoint, spread float64) string {
+	config.StartPos = w.cellCenter(home)
+	config.Spread = spread
+	size := w.Config.PopulationSize
+	w.Config.PopulationSize = tutorialFounders
+	defer func() { w.Config.PopulationSize = size }()
+	return w.AddPopulation(config)
+}
+
+// tutorialCenter is the cell in the middle of the grid
+func (w *World) tutorialCenter() GridPoint {
+	return GridPoint{X: w.Config.GridWidth / 2, Y: w.Config.GridHeight / 2}
+}
+
+// speciationTutorial follows a colony of microbes as selection thins it and a new species
+// evolves from the survivors
+func speciationTutorial() TutorialScenario {
+	return TutorialScenario{
+		ID:          "speciation",
+		Name:        "Watch a speciation happen",
+		Description: "A colony of primitive microbes on a patch of plains, followed as selection thins it and a new species evolves from it",
+		start: func(t *Tutorial, w *World) []TutorialStep {
+			center := w.tutorialCenter()
+			w.paintTutorialBiome(center, 6, BiomePlains)
+			w.initializePlants()
+			w.addTutorialPopulation(startingPopulations(true)[0], center, w.Config.Width/float64(w.Config.GridWidth)*3)
+			t.founders = w.AllEntities[0].Species
+			t.evolved = make(map[int]bool)
+			t.watch = func(t *Tutorial, w *World) {
+				for _, entity := range w.AllEntities {
+					if entity.IsAlive && entity.Species != t.founders {
+						t.evolved[entity.ID] = true
+					}
+				}
+			}
+
+			return []TutorialStep{
+				{
+					Title:        "Selection begins",
+					Instructions: "A colony of primitive microbes has been placed on the plains in the middle of the world. Press play and watch them forage. Microbes live short lives on little food, and the step completes when the first of them dies.",
+					detect: func(t *Tutorial, w *World, events []CentralEvent) (string, bool) {
+						for _, event := range events {
+							if event.Type == EventTypeDeath {
+								return event.Description, true
+							}
+						}
+						return "", false
+					},
+				},
+				{
+					Title:        "A new species appears",
+					Instructions: "Well-fed microbes that live long enough can evolve into simple multicellular organisms. Keep watching the survivors until one of them does.",
+					detect: func(t *Tutorial, w *World, events []CentralEvent) (string, bool) {
+						for _, event := range events {
+							if event.Type == EventTypeSpeciation {
+								t.species, _ = event.Metadata["species"].(string)
+								return event.Description, true
+							}
+						}
+						// The first microbe may have evolved while the colony was still losing its first member
+						for _, entity := range w.AllEntities {
+							if t.evolved[entity.ID] {
+								t.species = entity.Species
+								return fmt.Sprintf("%s evolved from %s", t.species, t.founders), true
+							}
+						}
+						return "", false
+					},
+				},
+				{
+					Title:        "Descendants multiply",
+					Instructions: fmt.Sprintf("A single mutant is a fluke; a lineage needs numbers. Wait until %d creatures have evolved away from the founding %s.", tutorialDescendants, t.founders),
+					detect: func(t *Tutorial, w *World, events []CentralEvent) (string, bool) {
+						if len(t.evolved) >= tutorialDescendants {
+							return fmt.Sprintf("%d creatures have evolved from the %s founders, with %d founders still alive", len(t.evolved), t.founders, livingSpeciesCounts(w)[t.founders]), true
+						}
+						return "", false
+					},
+				},
+				{
+					Title:        "One lineage outlasts the other",
+					Instructions: "Founders and descendants now compete for the same scarce food. Watch which lineage holds on longer.",
+					detect: func(t *Tutorial, w *World, events []CentralEvent) (string, bool) {
+						living := livingSpeciesCounts(w)
+						founders := living[t.founders]
+						descendants := 0
+						for species, count := range living {
+							if species != t.founders {
+								descendants += count
+							}
+						}
+						switch {
+						case founders == 0 && descendants == 0:
+							return fmt.Sprintf("Both lineages died out by tick %d", w.Tick), true
+						case founders == 0:
+							return fmt.Sprintf("The %s founders died out, leaving %d descendants", t.founders, descendants), true
+						case descendants == 0:
+							return fmt.Sprintf("The descendants died out, leaving %d %s founders", founders, t.founders), true
+						}
+						return "", false
+					},
+				},
+			}
+		},
+	}
+}
+
+// wildfireTutorial burns a forest, then floods the burn scar and follows the burned ground as
+// it turns from bare desert to wetland
+func wildfireTutorial() TutorialScenario {
+	return TutorialScenario{
+		ID:          "wildfire",
+		Name:        "Trigger a fire and observe succession",
+		Description: "A forest ringed by plains, set alight and then flooded, followed as the burn scar turns from bare desert to wetland",
+		start: func(t *Tutorial, w *World) []TutorialStep {
+			center := w.tutorialCenter()
+			w.paintTutorialBiome(center, 9, BiomePlains)
+			w.paintTutorialBiome(center, 5, BiomeForest)
+			w.initializePlants()
+			grazers := GridPoint{X: max(0, center.X-8), Y: center.Y}
+			w.addTutorialPopulation(startingPopulations(false)[0], grazers, w.Config.Width/float64(w.Config.GridWidth)*2)
+
+			t.watch = func(t *Tutorial, w *World) {
+				// The burn scar is every cell a wildfire has turned since the fire started
+				if t.scar == nil {
+					return
+				}
+				for _, event := range w.EnvironmentalEvents {
+					if event.Type != "wildfire" {
+						continue
+					}
+					for pos := range event.AffectedCells {
+						cell := GridPoint{X: int(pos.X), Y: int(pos.Y)}
+						if cell.X >= 0 && cell.X < w.Config.GridWidth && cell.Y >= 0 && cell.Y < w.Config.GridHeight {
+							t.scar[cell] = true
+						}
+					}
+				}
+			}
+
+			return []TutorialStep{
+				{
+					Title:        "Light a wildfire",
+					Instructions: "The middle of the world is a forest ringed by plains, and both burn. Start a wildfire in the forest.",
+					Action:       "wildfire",
+					Target:       &center,
+					detect: func(t *Tutorial, w *World, events []CentralEvent) (string, bool) {
+						for _, event := range events {
+							if event.Type == "environmental_event_start" && event.Metadata["event_type"] == "wildfire" {
+								t.fireTick = event.Tick
+								t.scar = make(map[GridPoint]bool)
+								return event.Description, true
+							}
+						}
+						return "", false
+					},
+				},
+				{
+					Title:        "The fire spreads",
+					Instructions: fmt.Sprintf("Wind drives the fire through the vegetation, leaving desert behind. Watch the burn scar grow to %d cells.", tutorialFireScarCells),
+					detect: func(t *Tutorial, w *World, events []CentralEvent) (string, bool) {
+						if len(t.scar) >= tutorialFireScarCells {
+							return fmt.Sprintf("The fire has burned %d cells", len(t.scar)), true
+						}
+						return "", false
+					},
+				},
+				{
+					Title:        "The fire burns out",
+					Instructions: "Fires die down once they run out of time or reach water. Wait for this one to go out.",
+					detect: func(t *Tutorial, w *World, events []CentralEvent) (string, bool) {
+						for _, event := range w.EnvironmentalEvents {
+							if event.Type == "wildfire" {
+								return "", false
+							}
+						}
+						// Aim the rain at the middle of the scar, wherever the wind took the fire
+						scar := t.scarCenter()
+						t.Steps[t.Current+1].Target = &scar
+						return fmt.Sprintf("The fire went out after %d ticks, leaving %d burned cells", w.Tick-t.fireTick, len(t.scar)), true
+					},
+				},
+				{
+					Title:        "Bring the rain",
+					Instructions: "Burned ground left dry stays desert for a long time. Flood the burn scar to give it water.",
+					Action:       "flood",
+					detect: func(t *Tutorial, w *World, events []CentralEvent) (string, bool) {
+						for _, event := range events {
+							if event.Type == "environmental_event_start" && event.Metadata["event_type"] == "flood" {
+								return event.Description, true
+							}
+						}
+						return "", false
+					},
+				},
+				{
+					Title:        "The scar turns to wetland",
+					Instructions: fmt.Sprintf("Floodwater turns bare desert into swamp, and wet ground next to it greens into plains. Wait until %d burned cells are no longer desert.", tutorialRecoveredCells),
+					detect: func(t *Tutorial, w *World, events []CentralEvent) (string, bool) {
+						recovered := make(map[string]int)
+						total := 0
+						for cell := range t.scar {
+							if biome := w.Grid[cell.Y][cell.X].Biome; biome != BiomeDesert {
+								recovered[biomeName(biome)]++
+								total++
+							}
+						}
+						if total < tutorialRecoveredCells {
+							return "", false
+						}
+						parts := make([]string, 0, len(recovered))
+						for _, name := range sortedKeys(recovered) {
+							parts = append(parts, fmt.Sprintf("%d %s", recovered[name], name))
+						}
+						return fmt.Sprintf("%d of the %d burned cells have recovered (%s), %d ticks after the fire", total, len(t.scar), strings.Join(parts, ", "), w.Tick-t.fireTick), true
+					},
+				},
+			}
+		},
+	}
+}
+
+// scarCenter is the burned cell nearest the middle of the burn scar
+func (t *Tutorial) scarCenter() GridPoint {
+	var sumX, sumY float64
+	for cell := range t.scar {
+		sumX += float64(cell.X)
+		sumY += float64(cell.Y)
+	}
+	n := float64(len(t.scar))
+	best, bestDistance := GridPoint{}, math.Inf(1)
+	for cell := range t.scar {
+		dx, dy := float64(cell.X)-sumX/n, float64(cell.Y)-sumY/n
+		if distance := dx*dx + dy*dy; distance < bestDistance || (distance == bestDistance && (cell.Y < best.Y || (cell.Y == best.Y && cell.X < best.X))) {
+			best, bestDistance = cell, distance
+		}
+	}
+	return best
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func newTutorialTestWorld() *World {
+	return NewWorld(WorldConfig{Width: 100, Height: 100, GridWidth: 40, GridHeight: 25, PopulationSize: 20})
+}
+
+func TestSpeciationTutorialFollowsEvents(t *testing.T) {
+	world := newTutorialTestWorld()
+	tutorials := world.Tutorials
+
+	if _, err := tutorials.Start(world, "cooking"); err == nil {
+		t.Error("Expected an unknown scenario to be rejected")
+	}
+	tutorial, err := tutorials.Start(world, "speciation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(world.AllEntities) != tutorialFounders || len(tutorial.Steps) != 4 || tutorial.Status != TutorialRunning {
+		t.Fatalf("Expected %d founders and four steps, got %d creatures and %+v", tutorialFounders, len(world.AllEntities), tutorial)
+	}
+	if !world.RandomEventsOff || !world.RespawnOff {
+		t.Error("Expected random events and respawning off during a tutorial")
+	}
+	founders := world.AllEntities[0].Species
+
+	// Events no step waits for leave the tutorial where it is
+	world.CentralEventBus.EmitSystemEvent(world.Tick, EventTypeBirth, "reproduction", "test", "A birth", nil, nil)
+	tutorials.Update(world)
+	if current, _ := tutorials.Current(); current.Current != 0 {
+		t.Fatalf("Expected a birth not to complete the first step, got %+v", current)
+	}
+
+	world.CentralEventBus.EmitSystemEvent(world.Tick, EventTypeDeath, "starvation", "test", "Entity 3 died", nil, nil)
+	tutorials.Update(world)
+	world.CentralEventBus.EmitSystemEvent(world.Tick, EventTypeSpeciation, "species", "test", "New species simple evolved", nil,
+		map[string]interface{}{"species": "simple", "from_species": founders})
+	tutorials.Update(world)
+	current, _ := tutorials.Current()
+	if current.Current != 2 || current.Steps[0].Observation != "Entity 3 died" || !current.Steps[1].Done {
+		t.Fatalf("Expected the death and speciation to complete the first two steps, got %+v", current)
+	}
+
+	// Descendants count once they have evolved, even after they die
+	world.AllEntities[0].Species = "simple"
+	tutorials.Update(world)
+	world.AllEntities[0].IsAlive = false
+	world.AllEntities[1].Species = "simple"
+	tutorials.Update(world)
+	if current, _ = tutorials.Current(); current.Current != 3 {
+		t.Fatalf("Expected two evolved creatures to complete the third step, got %+v", current)
+	}
+
+	// The last step waits for one lineage to outlast the other
+	tutorials.Update(world)
+	if current, _ = tutorials.Current(); current.Status != TutorialRunning {
+		t.Fatalf("Expected the tutorial to wait while both lineages live, got %+v", current)
+	}
+	for _, entity := range world.AllEntities {
+		if entity.Species == founders {
+			entity.IsAlive = false
+		}
+	}
+	tutorials.Update(world)
+	current, _ = tutorials.Current()
+	if current.Status != TutorialCompleted || current.Current != len(current.Steps) {
+		t.Fatalf("Expected the tutorial completed once the founders died out, got %+v", current)
+	}
+	if world.RandomEventsOff || world.RespawnOff {
+		t.Error("Expected the world's settings back once the tutorial completed")
+	}
+	if tutorials.Stop(world) {
+		t.Error("Expected a completed tutorial not to be stoppable")
+	}
+}
+
+func TestWildfireTutorialRunsInTheWorld(t *testing.T) {
+	world := newTutorialTestWorld()
+	if _, err := world.Tutorials.Start(world, "wildfire"); err != nil {
+		t.Fatal(err)
+	}
+	center := world.tutorialCenter()
+	if world.Grid[center.Y][center.X].Biome != BiomeForest {
+		t.Fatalf("Expected a forest in the middle of the world, got %s", biomeName(world.Grid[center.Y][center.X].Biome))
+	}
+
+	for i := 0; i < 300; i++ {
+		tutorial, _ := world.Tutorials.Current()
+		if tutorial.Status != TutorialRunning {
+			break
+		}
+		if step := tutorial.Steps[tutorial.Current]; step.Action != "" && !hasEnvironmentalEvent(world, step.Action) {
+			if step.Target == nil {
+				t.Fatalf("Expected step %q to have a target", step.Title)
+			}
+			if _, err := world.StartEnvironmentalEvent(step.Action, *step.Target); err != nil {
+				t.Fatal(err)
+			}
+		}
+		world.Update()
+	}
+
+	tutorial, _ := world.Tutorials.Current()
+	if tutorial.Status != TutorialCompleted {
+		t.Fatalf("Expected the fire, rain and recovery within 300 ticks, got %+v", tutorial)
+	}
+	for _, step := range tutorial.Steps {
+		if !step.Done || step.Observation == "" {
+			t.Errorf("Expected step %q done with an observation, got %+v", step.Title, step)
+		}
+	}
+}
+
+// hasEnvironmentalEvent reports whether an environmental event of a type is under way
+func hasEnvironmentalEvent(w *World, eventType string) bool {
+	for _, event := range w.EnvironmentalEvents {
+		if event.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTutorialAPI(t *testing.T) {
+	wi := NewWebInterface(newTutorialTestWorld())
+
+	var listing struct {
+		Scenarios []TutorialScenario `json:"scenarios"`
+		Tutorial  *Tutorial          `json:"tutorial"`
+	}
+	callControlAPI(t, wi.handleTutorial, http.MethodGet, "/api/tutorial", "", &listing)
+	if len(listing.Scenarios) != 2 || listing.Tutorial != nil {
+		t.Fatalf("Expected two scenarios and no tutorial yet, got %+v", listing)
+	}
+
+	if code := callControlAPI(t, wi.handleTutorial, http.MethodPost, "/api/tutorial", `{"scenario": "cooking"}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown scenario, got %d", code)
+	}
+	var tutorial Tutorial
+	if code := callControlAPI(t, wi.handleTutorial, http.MethodPost, "/api/tutorial", `{"scenario": "wildfire"}`, &tutorial); code != http.StatusCreated {
+		t.Fatalf("Starting a tutorial failed: %d", code)
+	}
+	target := tutorial.Steps[0].Target
+	if tutorial.Steps[0].Action != "wildfire" || target == nil {
+		t.Fatalf("Expected the first step to ask for a wildfire, got %+v", tutorial.Steps[0])
+	}
+
+	for body, want := range map[string]int{
+		`{"type": "meteor", "x": 1, "y": 1}`:    http.StatusBadRequest,
+		`{"type": "wildfire", "x": 40, "y": 1}`: http.StatusBadRequest,
+	} {
+		if code := callControlAPI(t, wi.handleOperatorEvents, http.MethodPost, "/api/operator/events", body, nil); code != want {
+			t.Errorf("Expected %d for %s, got %d", want, body, code)
+		}
+	}
+	var event EnvironmentalEventResponse
+	body := `{"type": "wildfire", "x": 20, "y": 12}`
+	if code := callControlAPI(t, wi.handleOperatorEvents, http.MethodPost, "/api/operator/events", body, &event); code != http.StatusCreated {
+		t.Fatalf("Starting a wildfire failed: %d", code)
+	}
+	if event.Type != "wildfire" || event.X != target.X || event.Y != target.Y || len(wi.world.EnvironmentalEvents) != 1 {
+		t.Errorf("Expected a wildfire at the target cell, got %+v", event)
+	}
+
+	wi.world.Update()
+	callControlAPI(t, wi.handleTutorial, http.MethodGet, "/api/tutorial", "", &listing)
+	if listing.Tutorial == nil || !listing.Tutorial.Steps[0].Done {
+		t.Fatalf("Expected the wildfire to complete the first step, got %+v", listing.Tutorial)
+	}
+
+	if code := callControlAPI(t, wi.handleTutorial, http.MethodDelete, "/api/tutorial", "", &tutorial); code != http.StatusOK || tutorial.Status != TutorialStopped {
+		t.Fatalf("Stopping the tutorial failed: %d %+v", code, tutorial)
+	}
+	if code := callControlAPI(t, wi.handleTutorial, http.MethodDelete, "/api/tutorial", "", nil); code != http.StatusNotFound {
+		t.Errorf("Expected 404 with no tutorial running, got %d", code)
+	}
+}
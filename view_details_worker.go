@@ -0,0 +1,43 @@
+package main
+
+import "time"
+
+// defaultViewDetailsInterval is how often the analysis tabs are rebuilt for the web view
+const defaultViewDetailsInterval = time.Second
+
+// viewDetailsLoop rebuilds the analysis tabs on its own schedule, off the simulation loop. It
+// holds the tick lock while building so it reads the world between ticks; the simulation
+// then waits for at most one build a second instead of building every tab for every frame.
+func (wi *WebInterface) viewDetailsLoop() {
+	ticker := time.NewTicker(wi.viewDetailsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			wi.refreshViewDetails()
+		case <-wi.stopChan:
+			return
+		}
+	}
+}
+
+// refreshViewDetails rebuilds the analysis tabs and publishes them to the frames that follow
+func (wi *WebInterface) refreshViewDetails() *ViewDetailsSnapshot {
+	wi.tickMutex.Lock()
+	details := wi.viewManager.BuildViewDetails()
+	wi.tickMutex.Unlock()
+	wi.viewDetails.Store(details)
+	return details
+}
+
+// latestViewDetails returns the analysis tabs last published, building them on the caller's
+// goroutine until the worker has published any
+func (wi *WebInterface) latestViewDetails() *ViewDetailsSnapshot {
+	if details := wi.viewDetails.Load(); details != nil {
+		return details
+	}
+	details := wi.viewManager.BuildViewDetails()
+	wi.viewDetails.CompareAndSwap(nil, details)
+	return details
+}
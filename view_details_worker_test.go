@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestFramesCarryTheWorkersViewDetails(t *testing.T) {
+	wi := NewWebInterface(newGraphQLTestWorld())
+	built := wi.refreshViewDetails()
+	for i := 0; i < 3; i++ {
+		wi.world.Update()
+	}
+
+	wi.sendFrame()
+	frame := <-wi.broadcastChan
+	if frame.Tick != built.Tick+3 || frame.DetailsTick != built.Tick || frame.DetailsAgeMS < 0 {
+		t.Errorf("Expected a frame at tick %d with details from tick %d, got %d and %d", built.Tick+3, built.Tick, frame.Tick, frame.DetailsTick)
+	}
+
+	// The details sit at the top level of the frame as before
+	encoded, err := json.Marshal(frame)
+	if err != nil {
+		t.Fatalf("Failed to encode the frame: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"species", "statistical", "details_tick", "grid"} {
+		if _, exists := fields[name]; !exists {
+			t.Errorf("Expected %q in the frame", name)
+		}
+	}
+}
+
+func TestViewDetailsWorkerRefreshes(t *testing.T) {
+	wi := NewWebInterface(newGraphQLTestWorld())
+	wi.viewDetailsInterval = 10 * time.Millisecond
+	first := wi.latestViewDetails()
+
+	go wi.viewDetailsLoop()
+	defer close(wi.stopChan)
+	wi.tickMutex.Lock()
+	wi.world.Update()
+	wi.tickMutex.Unlock()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for wi.latestViewDetails().Tick == first.Tick && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if latest := wi.latestViewDetails(); latest.Tick != first.Tick+1 {
+		t.Errorf("Expected the worker to rebuild the details at tick %d, got %d", first.Tick+1, latest.Tick)
+	}
+}
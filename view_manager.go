@@ -2,34 +2,42 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"math"
 	"sort"
 	"strings"
+	"time"
 )
 
 // ViewManager handles rendering simulation state for different interfaces
 type ViewManager struct {
 	world *World
-	// Historical data tracking
-	populationHistory    []PopulationHistorySnapshot
-	communicationHistory []CommunicationHistorySnapshot
-	physicsHistory       []PhysicsHistorySnapshot
-	maxHistoryLength     int
+	// Historical data tracking, downsampled with age so long runs use constant memory
+	populationHistory    *TieredHistory[PopulationHistorySnapshot]
+	communicationHistory *TieredHistory[CommunicationHistorySnapshot]
+	physicsHistory       *TieredHistory[PhysicsHistorySnapshot]
+	lastHistoryTick      int
 }
 
 // NewViewManager creates a new view manager
 func NewViewManager(world *World) *ViewManager {
 	return &ViewManager{
-		world:            world,
-		maxHistoryLength: 50, // Keep last 50 snapshots
+		world:                world,
+		populationHistory:    NewTieredHistory(mergePopulationSnapshots),
+		communicationHistory: NewTieredHistory(mergeCommunicationSnapshots),
+		physicsHistory:       NewTieredHistory(mergePhysicsSnapshots),
+		lastHistoryTick:      -1,
 	}
 }
 
-// Historical data structures
+// Historical data structures. Downsampled snapshots average several raw ones: they cover
+// FirstTick to Tick and stand for Samples raw snapshots.
 type PopulationHistorySnapshot struct {
 	Tick        int              `json:"tick"`
 	Timestamp   string           `json:"timestamp"`
 	Populations []PopulationData `json:"populations"`
+	FirstTick   int              `json:"first_tick,omitempty"`
+	Samples     int              `json:"samples,omitempty"`
 }
 
 type CommunicationHistorySnapshot struct {
@@ -37,6 +45,8 @@ type CommunicationHistorySnapshot struct {
 	Timestamp     string         `json:"timestamp"`
 	ActiveSignals int            `json:"active_signals"`
 	SignalTypes   map[string]int `json:"signal_types"`
+	FirstTick     int            `json:"first_tick,omitempty"`
+	Samples       int            `json:"samples,omitempty"`
 }
 
 type PhysicsHistorySnapshot struct {
@@ -45,25 +55,64 @@ type PhysicsHistorySnapshot struct {
 	Collisions      int     `json:"collisions"`
 	AverageVelocity float64 `json:"average_velocity"`
 	TotalMomentum   float64 `json:"total_momentum"`
+	FirstTick       int     `json:"first_tick,omitempty"`
+	Samples         int     `json:"samples,omitempty"`
 }
 
 // ViewData represents the current state of the simulation for rendering
 type ViewData struct {
-	Tick                   int                       `json:"tick"`
-	TimeString             string                    `json:"time_string"`
-	EntityCount            int                       `json:"entity_count"`
-	PlantCount             int                       `json:"plant_count"`
-	PopulationCount        int                       `json:"population_count"`
-	EventCount             int                       `json:"event_count"`
-	SpeedMultiplier        float64                   `json:"speed_multiplier"`
-	Paused                 bool                      `json:"paused"`
-	ViewportX              int                       `json:"viewport_x"`
-	ViewportY              int                       `json:"viewport_y"`
-	ZoomLevel              float64                   `json:"zoom_level"`
-	Grid                   [][]CellData              `json:"grid"`
-	Stats                  map[string]interface{}    `json:"stats"`
-	Events                 []EventData               `json:"events"`
-	Populations            []PopulationData          `json:"populations"`
+	Tick            int                    `json:"tick"`
+	TimeString      string                 `json:"time_string"`
+	Season          SeasonStatus           `json:"season"` // Where the world is in its year
+	EntityCount     int                    `json:"entity_count"`
+	PlantCount      int                    `json:"plant_count"`
+	PopulationCount int                    `json:"population_count"`
+	EventCount      int                    `json:"event_count"`
+	SpeedMultiplier float64                `json:"speed_multiplier"`
+	Paused          bool                   `json:"paused"`
+	UnlimitedSpeed  bool                   `json:"unlimited_speed"`  // Filled in by the web interface's speed governor
+	MaxCPU          float64                `json:"max_cpu"`          // Filled in by the web interface's speed governor
+	TicksPerSecond  float64                `json:"ticks_per_second"` // Filled in by the web interface's speed governor
+	BreakpointHit   string                 `json:"breakpoint_hit,omitempty"`
+	Follow          *FollowTrack           `json:"follow,omitempty"` // Creature the shared camera follows, filled in by the web interface
+	ViewportX       int                    `json:"viewport_x"`
+	ViewportY       int                    `json:"viewport_y"`
+	ZoomLevel       float64                `json:"zoom_level"`
+	GridWidth       int                    `json:"grid_width"`           // Size of the whole world grid
+	GridHeight      int                    `json:"grid_height"`          // Size of the whole world grid
+	GridShape       string                 `json:"grid_shape"`           // Square or hex cells; odd rows of hex cells sit half a cell right
+	Grid            [][]CellData           `json:"grid"`                 // The viewport's cells, null for clients streaming chunks
+	Chunks          []GridChunk            `json:"chunks,omitempty"`     // Subscribed chunks that changed since the client last received them
+	ChunkSize       int                    `json:"chunk_size,omitempty"` // Cells along each side of a chunk, set for clients streaming chunks
+	Stats           map[string]interface{} `json:"stats"`
+	Events          []EventData            `json:"events"`
+	Alerts          []AlertData            `json:"alerts"`                // Recent high and critical severity events
+	PlayerGroups    []PlayerGroupData      `json:"player_groups"`         // Box-selected player groups
+	SpeciesPolicies []SpeciesPolicy        `json:"species_policies"`      // Player policy sliders and how far species have adapted
+	Game            *CompetitiveGame       `json:"game,omitempty"`        // Running or last competitive game
+	Tutorial        *Tutorial              `json:"tutorial,omitempty"`    // Running or last guided scenario
+	Predictions     []PredictionRound      `json:"predictions,omitempty"` // Spectator prediction rounds awaiting their outcome
+	LatestSnapshot  int                    `json:"latest_snapshot"`       // ID of the newest gallery snapshot, 0 before the first
+	Populations     []PopulationData       `json:"populations"`
+
+	// Analysis tabs, which may have been built a few ticks before the rest of the frame
+	ViewDetails
+	DetailsTick  int   `json:"details_tick"`   // Tick the details were built at
+	DetailsAgeMS int64 `json:"details_age_ms"` // How long ago the details were built
+	// Historical data
+	PopulationHistory    []PopulationHistorySnapshot    `json:"population_history"`
+	CommunicationHistory []CommunicationHistorySnapshot `json:"communication_history"`
+	PhysicsHistory       []PhysicsHistorySnapshot       `json:"physics_history"`
+
+	// Player ID -> cell fog states, filled in by the web interface when fog of war is on
+	fogOfWar map[string]map[GridPoint]string
+	// Chunks clients have subscribed to, filled in by the web interface
+	chunks map[ChunkKey]*builtChunk
+}
+
+// ViewDetails holds the analysis tabs of a view. They are costly to build and change slowly,
+// so the web interface rebuilds them on their own schedule rather than every frame.
+type ViewDetails struct {
 	Communication          CommunicationData         `json:"communication"`
 	Civilization           CivilizationData          `json:"civilization"`
 	Physics                PhysicsData               `json:"physics"`
@@ -90,27 +139,30 @@ type ViewData struct {
 	Neural                 NeuralData                `json:"neural"`
 	BiomeBoundary          BiomeBoundaryData         `json:"biome_boundary"`
 	BioRhythm              BioRhythmData             `json:"biorhythm"`
-	// Historical data
-	PopulationHistory    []PopulationHistorySnapshot    `json:"population_history"`
-	CommunicationHistory []CommunicationHistorySnapshot `json:"communication_history"`
-	PhysicsHistory       []PhysicsHistorySnapshot       `json:"physics_history"`
 }
 
 // CellData represents a single grid cell for rendering
 type CellData struct {
-	X            int    `json:"x"`
-	Y            int    `json:"y"`
-	Biome        string `json:"biome"`
-	BiomeSymbol  string `json:"biome_symbol"`
-	BiomeColor   string `json:"biome_color"`
-	EntityCount  int    `json:"entity_count"`
-	EntitySymbol string `json:"entity_symbol"`
-	EntityColor  string `json:"entity_color"`
-	PlantCount   int    `json:"plant_count"`
-	PlantSymbol  string `json:"plant_symbol"`
-	PlantColor   string `json:"plant_color"`
-	HasEvent     bool   `json:"has_event"`
-	EventSymbol  string `json:"event_symbol"`
+	X             int         `json:"x"`
+	Y             int         `json:"y"`
+	Biome         string      `json:"biome"`
+	BiomeSymbol   string      `json:"biome_symbol"`
+	BiomeColor    string      `json:"biome_color"`
+	EntityCount   int         `json:"entity_count"`
+	EntitySymbol  string      `json:"entity_symbol"`
+	EntityColor   string      `json:"entity_color"`
+	EntitySpecies string      `json:"entity_species,omitempty"` // Species of a lone creature, for its sprite
+	EntityScale   float64     `json:"entity_scale,omitempty"`   // How large to draw the creatures, by their mean size (see TraitLegend)
+	EntityColors  TraitColors `json:"entity_colors"`            // Their mean colour in each trait colour scheme, empty without creatures
+	PlantCount    int         `json:"plant_count"`
+	PlantSymbol   string      `json:"plant_symbol"`
+	PlantColor    string      `json:"plant_color"`
+	HasEvent      bool        `json:"has_event"`
+	EventSymbol   string      `json:"event_symbol"`
+	Light         float64     `json:"light"`  // Daylight on the cell, 0-1
+	GridX         int         `json:"grid_x"` // Position in the world grid
+	GridY         int         `json:"grid_y"`
+	Fog           string      `json:"fog,omitempty"` // FogRemembered or FogHidden when a player's fog of war covers the cell
 }
 
 // EventData represents an event for rendering
@@ -122,6 +174,27 @@ type EventData struct {
 	Type        string `json:"type"`       // "active" or "historical"
 	EventType   string `json:"event_type"` // Type of historical event
 	Timestamp   string `json:"timestamp"`  // When the event occurred
+	Severity    string `json:"severity,omitempty"`
+}
+
+// AlertData represents a high-severity event for client notifications
+type AlertData struct {
+	ID          int    `json:"id"` // Central event ID, increasing; clients use it to skip alerts already shown
+	Tick        int    `json:"tick"`
+	Type        string `json:"type"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+}
+
+// PlayerGroupData summarizes a player group for drawing on the grid
+type PlayerGroupData struct {
+	ID        int         `json:"id"`
+	PlayerID  string      `json:"player_id"`
+	Size      int         `json:"size"`
+	Formation string      `json:"formation"`
+	Order     string      `json:"order,omitempty"` // Kind of the standing order, empty when idle
+	Arrived   bool        `json:"arrived"`
+	Cells     []GridPoint `json:"cells"` // Grid cells holding members
 }
 
 // PopulationData represents population statistics
@@ -198,6 +271,8 @@ type SpeciesDetailData struct {
 	ExtinctionTick     int    `json:"extinction_tick"` // 0 if not extinct/awaiting extinction
 	PeakPopulation     int    `json:"peak_population"`
 	AwaitingExtinction bool   `json:"awaiting_extinction"` // true if has 0 members but not extinct yet
+
+	Traits map[string]float64 `json:"traits,omitempty"` // Average traits of the living members
 }
 
 // NetworkData represents plant network state
@@ -230,6 +305,11 @@ type EvolutionData struct {
 	TotalPlantsTracked  int     `json:"total_plants_tracked"`
 	ActivePlantCount    int     `json:"active_plant_count"`
 	SpeciationDetected  bool    `json:"speciation_detected"`
+
+	MutationOperators []MutationOperatorStatus `json:"mutation_operators"` // Which kinds of genetic change are driving adaptation
+	Radiations        []RadiationEpisode       `json:"radiations"`         // Recent adaptive radiations, newest first
+	Convergences      []ConvergenceEvent       `json:"convergences"`       // Recent convergent evolution, newest first
+	ArmsRaces         []RedQueenReport         `json:"arms_races"`         // Busiest predator-prey and host-parasite pairs
 }
 
 // ToolData represents tool system state
@@ -507,6 +587,7 @@ type BioRhythmData struct {
 	CircadianDistribution map[string]int        `json:"circadian_distribution"` // Preference type -> entity count
 	AverageNeedLevels     map[string]float64    `json:"average_need_levels"`    // Activity -> average need level
 	BiorhythmEfficiency   float64               `json:"biorhythm_efficiency"`   // Percentage of entities in sync
+	LightDisrupted        int                   `json:"light_disrupted"`        // Nocturnal entities under settlement light
 	CurrentTimeOfDay      string                `json:"current_time_of_day"`
 	IsNight               bool                  `json:"is_night"`
 	Season                string                `json:"season"`
@@ -524,71 +605,188 @@ type BioRhythmEntityData struct {
 	TopNeeds        []string           `json:"top_needs"`   // Top 3 needs by priority
 }
 
+// EntityDetailData describes a single entity for the web entity detail panel
+type EntityDetailData struct {
+	ID         int                `json:"id"`
+	Species    string             `json:"species"`
+	IsAlive    bool               `json:"is_alive"`
+	Energy     float64            `json:"energy"`
+	Age        int                `json:"age"`
+	Generation int                `json:"generation"`
+	Position   Position           `json:"position"`
+	Traits     map[string]float64 `json:"traits"`
+	ColonyID   int                `json:"colony_id,omitempty"` // Caste colony the entity belongs to, if any
+}
+
+// ListEntities returns one page of the entities in ID order, optionally only one species'
+// or only the living, along with how many matched in all
+func (vm *ViewManager) ListEntities(species string, aliveOnly bool, offset, limit int) (int, []*EntityDetailData) {
+	matched := make([]*Entity, 0, len(vm.world.AllEntities))
+	for _, entity := range vm.world.AllEntities {
+		if (species == "" || entity.Species == species) && (!aliveOnly || entity.IsAlive) {
+			matched = append(matched, entity)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+
+	details := make([]*EntityDetailData, 0, limit)
+	for i := offset; i < len(matched) && len(details) < limit; i++ {
+		details = append(details, vm.entityDetail(matched[i]))
+	}
+	return len(matched), details
+}
+
+// entityDetail describes one entity
+func (vm *ViewManager) entityDetail(entity *Entity) *EntityDetailData {
+	detail := &EntityDetailData{
+		ID:         entity.ID,
+		Species:    entity.Species,
+		IsAlive:    entity.IsAlive,
+		Energy:     entity.Energy,
+		Age:        entity.Age,
+		Generation: entity.Generation,
+		Position:   entity.Position,
+		Traits:     make(map[string]float64, len(entity.Traits)),
+	}
+	for name, trait := range entity.Traits {
+		detail.Traits[name] = trait.Value
+	}
+
+	if vm.world.CasteSystem != nil {
+		for _, colony := range vm.world.CasteSystem.Colonies {
+			for _, member := range colony.Members {
+				if member.ID == entity.ID {
+					detail.ColonyID = colony.ID
+				}
+			}
+		}
+	}
+	return detail
+}
+
 // GetCurrentViewData returns the current simulation state for rendering
 func (vm *ViewManager) GetCurrentViewData() *ViewData {
 	return vm.GetViewDataWithViewport(0, 0, 1.0)
 }
 
+// getBreakpointHit describes the breakpoint the world is paused at, if any
+func (vm *ViewManager) getBreakpointHit() string {
+	if vm.world.Breakpoints == nil || !vm.world.IsPaused() {
+		return ""
+	}
+	if hit := vm.world.Breakpoints.LastHit(); hit != nil {
+		return fmt.Sprintf("Breakpoint %s: %s", hit.Spec, hit.HitReason)
+	}
+	return ""
+}
+
 // GetViewDataWithViewport returns the current simulation state with viewport information
 func (vm *ViewManager) GetViewDataWithViewport(viewportX, viewportY int, zoomLevel float64) *ViewData {
+	return vm.GetViewFrame(viewportX, viewportY, zoomLevel, vm.BuildViewDetails(), true)
+}
+
+// GetViewFrame returns the current simulation state with viewport information and analysis
+// tabs built earlier. Leaving out the grid spares building it when every viewer streams
+// chunks instead.
+func (vm *ViewManager) GetViewFrame(viewportX, viewportY int, zoomLevel float64, details *ViewDetailsSnapshot, withGrid bool) *ViewData {
 	// Capture historical data every 5 ticks
 	if vm.world.Tick%5 == 0 {
 		vm.captureHistoricalData()
 	}
 
 	data := &ViewData{
-		Tick:                   vm.world.Tick,
-		TimeString:             vm.getTimeString(),
-		EntityCount:            len(vm.world.AllEntities),
-		PlantCount:             len(vm.world.AllPlants),
-		PopulationCount:        len(vm.world.Populations),
-		EventCount:             len(vm.world.Events),
-		SpeedMultiplier:        vm.world.GetSpeedMultiplier(),
-		Paused:                 vm.world.IsPaused(),
-		ViewportX:              viewportX,
-		ViewportY:              viewportY,
-		ZoomLevel:              zoomLevel,
-		Grid:                   vm.buildGridDataWithViewport(viewportX, viewportY, zoomLevel),
-		Stats:                  vm.getStatsData(),
-		Events:                 vm.getEventsData(),
-		Populations:            vm.getPopulationsData(),
-		Communication:          vm.getCommunicationData(),
-		Civilization:           vm.getCivilizationData(),
-		Physics:                vm.getPhysicsData(),
-		Wind:                   vm.getWindData(),
-		Species:                vm.getSpeciesData(),
-		Network:                vm.getNetworkData(),
-		DNA:                    vm.getDNAData(),
-		Cellular:               vm.getCellularData(),
-		Evolution:              vm.getEvolutionData(),
-		Topology:               vm.getTopologyData(),
-		Tools:                  vm.getToolData(),
-		EnvironmentalMod:       vm.getEnvironmentalModData(),
-		EnvironmentalPressures: vm.getEnvironmentalPressuresData(),
-		SymbioticRelationships: vm.getSymbioticRelationshipData(),
-		EmergentBehavior:       vm.getEmergentBehaviorData(),
-		FeedbackLoops:          vm.getFeedbackLoopData(),
-		Reproduction:           vm.getReproductionData(),
-		Warfare:                vm.getWarfareData(),
-		Fungal:                 vm.getFungalData(),
-		Cultural:               vm.getCulturalData(),
-		Statistical:            vm.getStatisticalData(),
-		Ecosystem:              vm.getEcosystemData(),
-		Anomalies:              vm.getAnomaliesData(),
-		Neural:                 vm.getNeuralData(),
-		BiomeBoundary:          vm.getBiomeBoundaryData(),
-		BioRhythm:              vm.getBioRhythmData(),
+		Tick:            vm.world.Tick,
+		TimeString:      vm.getTimeString(),
+		Season:          vm.getSeason(),
+		EntityCount:     len(vm.world.AllEntities),
+		PlantCount:      len(vm.world.AllPlants),
+		PopulationCount: len(vm.world.Populations),
+		EventCount:      len(vm.world.Events),
+		SpeedMultiplier: vm.world.GetSpeedMultiplier(),
+		Paused:          vm.world.IsPaused(),
+		BreakpointHit:   vm.getBreakpointHit(),
+		ViewportX:       viewportX,
+		ViewportY:       viewportY,
+		ZoomLevel:       zoomLevel,
+		GridWidth:       vm.world.Config.GridWidth,
+		GridHeight:      vm.world.Config.GridHeight,
+		GridShape:       vm.world.GridLayout().Name(),
+		Stats:           vm.getStatsData(),
+		Events:          vm.getEventsData(),
+		Alerts:          vm.getAlertsData(),
+		PlayerGroups:    vm.getPlayerGroupsData(),
+		SpeciesPolicies: vm.getSpeciesPoliciesData(),
+		Game:            vm.getGameData(),
+		Tutorial:        vm.getTutorialData(),
+		Predictions:     vm.getPredictionsData(),
+		LatestSnapshot:  vm.getLatestSnapshot(),
+		Populations:     vm.getPopulationsData(),
 		// Include historical data
-		PopulationHistory:    vm.populationHistory,
-		CommunicationHistory: vm.communicationHistory,
-		PhysicsHistory:       vm.physicsHistory,
+		PopulationHistory:    vm.populationHistory.Snapshots(),
+		CommunicationHistory: vm.communicationHistory.Snapshots(),
+		PhysicsHistory:       vm.physicsHistory.Snapshots(),
+		ViewDetails:          details.Details,
+		DetailsTick:          details.Tick,
+		DetailsAgeMS:         time.Since(details.BuiltAt).Milliseconds(),
+	}
+	if withGrid {
+		data.Grid = vm.buildGridDataWithViewport(viewportX, viewportY, zoomLevel)
 	}
 
 	return data
 }
 
+// ViewDetailsSnapshot is a view's analysis tabs as built at one tick
+type ViewDetailsSnapshot struct {
+	Details ViewDetails
+	Tick    int
+	BuiltAt time.Time
+}
+
+// BuildViewDetails builds the analysis tabs; like the rest of the view it must not run while
+// the world is updating
+func (vm *ViewManager) BuildViewDetails() *ViewDetailsSnapshot {
+	return &ViewDetailsSnapshot{
+		Details: ViewDetails{
+			Communication:          vm.getCommunicationData(),
+			Civilization:           vm.getCivilizationData(),
+			Physics:                vm.getPhysicsData(),
+			Wind:                   vm.getWindData(),
+			Species:                vm.getSpeciesData(),
+			Network:                vm.getNetworkData(),
+			DNA:                    vm.getDNAData(),
+			Cellular:               vm.getCellularData(),
+			Evolution:              vm.getEvolutionData(),
+			Topology:               vm.getTopologyData(),
+			Tools:                  vm.getToolData(),
+			EnvironmentalMod:       vm.getEnvironmentalModData(),
+			EnvironmentalPressures: vm.getEnvironmentalPressuresData(),
+			SymbioticRelationships: vm.getSymbioticRelationshipData(),
+			EmergentBehavior:       vm.getEmergentBehaviorData(),
+			FeedbackLoops:          vm.getFeedbackLoopData(),
+			Reproduction:           vm.getReproductionData(),
+			Warfare:                vm.getWarfareData(),
+			Fungal:                 vm.getFungalData(),
+			Cultural:               vm.getCulturalData(),
+			Statistical:            vm.getStatisticalData(),
+			Ecosystem:              vm.getEcosystemData(),
+			Anomalies:              vm.getAnomaliesData(),
+			Neural:                 vm.getNeuralData(),
+			BiomeBoundary:          vm.getBiomeBoundaryData(),
+			BioRhythm:              vm.getBioRhythmData(),
+		},
+		Tick:    vm.world.Tick,
+		BuiltAt: time.Now(),
+	}
+}
+
 // captureHistoricalData captures current state for historical tracking
 func (vm *ViewManager) captureHistoricalData() {
+	// Frames repeat while paused; one snapshot per tick is enough
+	if vm.world.Tick == vm.lastHistoryTick {
+		return
+	}
+	vm.lastHistoryTick = vm.world.Tick
 	timestamp := vm.world.Clock.Format("15:04:05")
 
 	// Capture population history
@@ -597,7 +795,7 @@ func (vm *ViewManager) captureHistoricalData() {
 		Timestamp:   timestamp,
 		Populations: vm.getPopulationsData(),
 	}
-	vm.populationHistory = append(vm.populationHistory, popSnapshot)
+	vm.populationHistory.Add(popSnapshot)
 
 	// Capture communication history
 	commData := vm.getCommunicationData()
@@ -607,7 +805,7 @@ func (vm *ViewManager) captureHistoricalData() {
 		ActiveSignals: commData.ActiveSignals,
 		SignalTypes:   commData.SignalTypes,
 	}
-	vm.communicationHistory = append(vm.communicationHistory, commSnapshot)
+	vm.communicationHistory.Add(commSnapshot)
 
 	// Capture physics history
 	physicsData := vm.getPhysicsData()
@@ -618,18 +816,45 @@ func (vm *ViewManager) captureHistoricalData() {
 		AverageVelocity: physicsData.AverageVelocity,
 		TotalMomentum:   physicsData.TotalMomentum,
 	}
-	vm.physicsHistory = append(vm.physicsHistory, physicsSnapshot)
+	vm.physicsHistory.Add(physicsSnapshot)
+}
+
+// buildCellData describes the world grid cell at (worldX, worldY), drawn at (x, y) in a view
+func (vm *ViewManager) buildCellData(worldX, worldY, x, y int) CellData {
+	cell := vm.world.Grid[worldY][worldX]
+	cellData := CellData{
+		X:           x,
+		Y:           y,
+		GridX:       worldX,
+		GridY:       worldY,
+		EntityCount: len(cell.Entities),
+		PlantCount:  len(cell.Plants),
+		HasEvent:    cell.Event != nil,
+		Light:       vm.world.DayNight.LightAt(worldY),
+	}
 
-	// Trim history to max length
-	if len(vm.populationHistory) > vm.maxHistoryLength {
-		vm.populationHistory = vm.populationHistory[1:]
+	// Set biome info
+	cellData.Biome, cellData.BiomeSymbol, cellData.BiomeColor = vm.getBiomeInfo(cell.Biome)
+
+	// Set entity info
+	if len(cell.Entities) > 0 {
+		cellData.EntitySymbol, cellData.EntityColor = vm.getEntityInfo(cell.Entities)
+		cellData.EntityScale, cellData.EntityColors = vm.world.EntityAppearance(cell.Entities)
+		if len(cell.Entities) == 1 {
+			cellData.EntitySpecies = cell.Entities[0].Species
+		}
 	}
-	if len(vm.communicationHistory) > vm.maxHistoryLength {
-		vm.communicationHistory = vm.communicationHistory[1:]
+
+	// Set plant info
+	if len(cell.Plants) > 0 {
+		cellData.PlantSymbol, cellData.PlantColor = vm.getPlantInfo(cell.Plants)
 	}
-	if len(vm.physicsHistory) > vm.maxHistoryLength {
-		vm.physicsHistory = vm.physicsHistory[1:]
+
+	// Set event info
+	if cell.Event != nil {
+		cellData.EventSymbol = gridEventSymbol
 	}
+	return cellData
 }
 
 func (vm *ViewManager) buildGridDataWithViewport(viewportX, viewportY int, zoomLevel float64) [][]CellData {
@@ -688,72 +913,70 @@ func (vm *ViewManager) buildGridDataWithViewport(viewportX, viewportY int, zoomL
 				continue
 			}
 
-			cell := vm.world.Grid[worldY][worldX]
-			cellData := CellData{
-				X:           x, // Grid position in viewport
-				Y:           y,
-				EntityCount: len(cell.Entities),
-				PlantCount:  len(cell.Plants),
-				HasEvent:    cell.Event != nil,
-			}
-
-			totalEntities += len(cell.Entities)
-			totalPlants += len(cell.Plants)
-
-			// Set biome info
-			cellData.Biome, cellData.BiomeSymbol, cellData.BiomeColor = vm.getBiomeInfo(cell.Biome)
-
-			// Set entity info
-			if len(cell.Entities) > 0 {
-				cellData.EntitySymbol, cellData.EntityColor = vm.getEntityInfo(cell.Entities)
-			}
-
-			// Set plant info
-			if len(cell.Plants) > 0 {
-				cellData.PlantSymbol, cellData.PlantColor = vm.getPlantInfo(cell.Plants)
-			}
-
-			// Set event info
-			if cell.Event != nil {
-				cellData.EventSymbol = "⚡"
-			}
-
+			cellData := vm.buildCellData(worldX, worldY, x, y) // Grid position in viewport
+			totalEntities += cellData.EntityCount
+			totalPlants += cellData.PlantCount
 			grid[y][x] = cellData
 		}
 	}
 
 	// Debug: Log entity and plant counts
 	if vm.world.Tick%20 == 0 { // Log every 20 ticks to avoid spam
-		fmt.Printf("Grid Debug - Tick %d: Total entities in world: %d, entities in grid: %d, plants in grid: %d\n",
+		log.Printf("Grid Debug - Tick %d: Total entities in world: %d, entities in grid: %d, plants in grid: %d",
 			vm.world.Tick, len(vm.world.AllEntities), totalEntities, totalPlants)
 	}
 
 	return grid
 }
 
+// gridBiomeSymbols are how the web grid draws each biome
+var gridBiomeSymbols = map[BiomeType]LegendEntry{
+	BiomePlains:       {Label: "Plains", Symbol: "•", Color: "green", Class: "biome-plains"},
+	BiomeForest:       {Label: "Forest", Symbol: "♠", Color: "darkgreen", Class: "biome-forest"},
+	BiomeDesert:       {Label: "Desert", Symbol: "~", Color: "yellow", Class: "biome-desert"},
+	BiomeMountain:     {Label: "Mountain", Symbol: "^", Color: "gray", Class: "biome-mountain"},
+	BiomeWater:        {Label: "Water", Symbol: "≈", Color: "blue", Class: "biome-water"},
+	BiomeRadiation:    {Label: "Radiation", Symbol: "☢", Color: "red", Class: "biome-radiation"},
+	BiomeSoil:         {Label: "Soil", Symbol: "■", Color: "brown", Class: "biome-plains"},
+	BiomeAir:          {Label: "Air", Symbol: "○", Color: "lightblue", Class: "biome-plains"},
+	BiomeIce:          {Label: "Ice", Symbol: "❄", Color: "white", Class: "biome-plains"},
+	BiomeRainforest:   {Label: "Rainforest", Symbol: "🌳", Color: "darkgreen", Class: "biome-forest"},
+	BiomeDeepWater:    {Label: "Deep Water", Symbol: "≈", Color: "darkblue", Class: "biome-water"},
+	BiomeHighAltitude: {Label: "High Altitude", Symbol: "▲", Color: "lightgray", Class: "biome-mountain"},
+	BiomeHotSpring:    {Label: "Hot Spring", Symbol: "◉", Color: "orange", Class: "biome-plains"},
+	BiomeTundra:       {Label: "Tundra", Symbol: "○", Color: "lightgray", Class: "biome-plains"},
+	BiomeSwamp:        {Label: "Swamp", Symbol: "≋", Color: "olive", Class: "biome-plains"},
+	BiomeCanyon:       {Label: "Canyon", Symbol: "◢", Color: "darkgray", Class: "biome-mountain"},
+}
+
+// gridSpeciesSymbols are how the web grid draws a lone creature of each base species, and
+// gridOtherSpecies any other creature
+var (
+	gridSpeciesSymbols = map[string]LegendEntry{
+		"herbivore": {Label: "Herbivore", Symbol: "H", Glyph: "🐰", Color: "green", Class: "entity-herbivore"},
+		"predator":  {Label: "Predator", Symbol: "P", Glyph: "🐺", Color: "red", Class: "entity-predator"},
+		"omnivore":  {Label: "Omnivore", Symbol: "O", Glyph: "🐻", Color: "blue", Class: "entity-omnivore"},
+	}
+	gridOtherSpecies = LegendEntry{Label: "Other species", Symbol: "E", Glyph: "🦋", Color: "white", Class: "entity-generic"}
+)
+
+// gridPlantSymbols are how the web grid draws a cell's most common plant type
+var gridPlantSymbols = map[PlantType]LegendEntry{
+	PlantGrass:    {Label: "Grass", Symbol: ".", Glyph: "🌱", Color: "lightgreen", Class: "plant-grass"},
+	PlantBush:     {Label: "Bush", Symbol: "♦", Glyph: "🌿", Color: "green", Class: "plant-bush"},
+	PlantTree:     {Label: "Tree", Symbol: "♠", Glyph: "🌳", Color: "darkgreen", Class: "plant-tree"},
+	PlantMushroom: {Label: "Mushroom", Symbol: "♪", Glyph: "🍄", Color: "purple", Class: "plant-mushroom"},
+	PlantAlgae:    {Label: "Algae", Symbol: "≈", Glyph: "🌊", Color: "cyan", Class: "plant-algae"},
+	PlantCactus:   {Label: "Cactus", Symbol: "†", Glyph: "🌵", Color: "olive", Class: "plant-cactus"},
+}
+
+// gridEventSymbol marks cells with an active world event in the web grid
+const gridEventSymbol = "⚡"
+
 // getBiomeInfo returns biome display information
 func (vm *ViewManager) getBiomeInfo(biome BiomeType) (string, string, string) {
-	biomes := map[BiomeType][]string{
-		BiomePlains:       {"Plains", "•", "green"},
-		BiomeForest:       {"Forest", "♠", "darkgreen"},
-		BiomeDesert:       {"Desert", "~", "yellow"},
-		BiomeMountain:     {"Mountain", "^", "gray"},
-		BiomeWater:        {"Water", "≈", "blue"},
-		BiomeRadiation:    {"Radiation", "☢", "red"},
-		BiomeSoil:         {"Soil", "■", "brown"},
-		BiomeAir:          {"Air", "○", "lightblue"},
-		BiomeIce:          {"Ice", "❄", "white"},
-		BiomeRainforest:   {"Rainforest", "🌳", "darkgreen"},
-		BiomeDeepWater:    {"Deep Water", "≈", "darkblue"},
-		BiomeHighAltitude: {"High Altitude", "▲", "lightgray"},
-		BiomeHotSpring:    {"Hot Spring", "◉", "orange"},
-		BiomeTundra:       {"Tundra", "○", "lightgray"},
-		BiomeSwamp:        {"Swamp", "≋", "olive"},
-		BiomeCanyon:       {"Canyon", "◢", "darkgray"},
-	}
-
-	if info, exists := biomes[biome]; exists {
-		return info[0], info[1], info[2]
+	if info, exists := gridBiomeSymbols[biome]; exists {
+		return info.Label, info.Symbol, info.Color
 	}
 	return "Unknown", "?", "white"
 }
@@ -782,7 +1005,7 @@ func (vm *ViewManager) getPlantInfo(plants []*Plant) (string, string) {
 		return "", ""
 	}
 
-	// Get the most common plant type
+	// Get the most common plant type, the lowest type on a tie so the cell doesn't flicker
 	plantCounts := make(map[PlantType]int)
 	for _, plant := range plants {
 		plantCounts[plant.Type]++
@@ -791,7 +1014,7 @@ func (vm *ViewManager) getPlantInfo(plants []*Plant) (string, string) {
 	var mostCommon PlantType
 	maxCount := 0
 	for plantType, count := range plantCounts {
-		if count > maxCount {
+		if count > maxCount || (count == maxCount && plantType < mostCommon) {
 			maxCount = count
 			mostCommon = plantType
 		}
@@ -802,63 +1025,32 @@ func (vm *ViewManager) getPlantInfo(plants []*Plant) (string, string) {
 
 // getSpeciesSymbol returns symbol for species
 func (vm *ViewManager) getSpeciesSymbol(species string) string {
-	// Simple mapping for now
-	symbols := map[string]string{
-		"herbivore": "H",
-		"predator":  "P",
-		"omnivore":  "O",
-	}
-
-	if symbol, exists := symbols[species]; exists {
-		return symbol
+	if symbol, exists := gridSpeciesSymbols[species]; exists {
+		return symbol.Symbol
 	}
-	return "E" // Generic entity
+	return gridOtherSpecies.Symbol
 }
 
 // getSpeciesColor returns color for species
 func (vm *ViewManager) getSpeciesColor(species string) string {
-	colors := map[string]string{
-		"herbivore": "green",
-		"predator":  "red",
-		"omnivore":  "blue",
-	}
-
-	if color, exists := colors[species]; exists {
-		return color
+	if symbol, exists := gridSpeciesSymbols[species]; exists {
+		return symbol.Color
 	}
-	return "white"
+	return gridOtherSpecies.Color
 }
 
 // getPlantTypeSymbol returns symbol for plant type
 func (vm *ViewManager) getPlantTypeSymbol(plantType PlantType) string {
-	symbols := map[PlantType]string{
-		PlantGrass:    ".",
-		PlantBush:     "♦",
-		PlantTree:     "♠",
-		PlantMushroom: "♪",
-		PlantAlgae:    "≈",
-		PlantCactus:   "†",
-	}
-
-	if symbol, exists := symbols[plantType]; exists {
-		return symbol
+	if symbol, exists := gridPlantSymbols[plantType]; exists {
+		return symbol.Symbol
 	}
 	return "?"
 }
 
 // getPlantTypeColor returns color for plant type
 func (vm *ViewManager) getPlantTypeColor(plantType PlantType) string {
-	colors := map[PlantType]string{
-		PlantGrass:    "lightgreen",
-		PlantBush:     "green",
-		PlantTree:     "darkgreen",
-		PlantMushroom: "purple",
-		PlantAlgae:    "cyan",
-		PlantCactus:   "olive",
-	}
-
-	if color, exists := colors[plantType]; exists {
-		return color
+	if symbol, exists := gridPlantSymbols[plantType]; exists {
+		return symbol.Color
 	}
 	return "green"
 }
@@ -867,31 +1059,21 @@ func (vm *ViewManager) getPlantTypeColor(plantType PlantType) string {
 func (vm *ViewManager) getTimeString() string {
 	if vm.world.AdvancedTimeSystem != nil {
 		timeOfDay := "☀️"
-		if vm.world.AdvancedTimeSystem.TimeOfDay == Night {
+		if vm.world.AdvancedTimeSystem.GetTimeState().IsNight() {
 			timeOfDay = "🌙"
 		}
 
-		return fmt.Sprintf("%s Day %d, Season %s",
-			timeOfDay,
-			vm.world.AdvancedTimeSystem.DayNumber,
-			vm.getSeasonName(vm.world.AdvancedTimeSystem.Season))
+		return fmt.Sprintf("%s Day %d", timeOfDay, vm.world.AdvancedTimeSystem.DayNumber)
 	}
 	return "Time unknown"
 }
 
-// getSeasonName returns season name
-func (vm *ViewManager) getSeasonName(season Season) string {
-	seasons := map[Season]string{
-		Spring: "Spring",
-		Summer: "Summer",
-		Autumn: "Autumn",
-		Winter: "Winter",
-	}
-
-	if name, exists := seasons[season]; exists {
-		return name
+// getSeason returns where the world is in its year, for the status bar
+func (vm *ViewManager) getSeason() SeasonStatus {
+	if vm.world.Seasons == nil {
+		return SeasonStatus{}
 	}
-	return "Unknown"
+	return vm.world.Seasons.Status(vm.world)
 }
 
 // Helper methods for getting various data sections
@@ -960,6 +1142,7 @@ func (vm *ViewManager) getEventsData() []EventData {
 				Type:        "central",
 				EventType:   centralEvent.Type,
 				Timestamp:   centralEvent.Timestamp.Format("15:04:05"),
+				Severity:    centralEvent.Severity,
 			})
 		}
 	}
@@ -990,6 +1173,105 @@ func (vm *ViewManager) getEventsData() []EventData {
 	return events
 }
 
+// getAlertsData returns the most recent high and critical severity events
+func (vm *ViewManager) getAlertsData() []AlertData {
+	alerts := make([]AlertData, 0)
+	if vm.world.CentralEventBus == nil {
+		return alerts
+	}
+	for _, event := range vm.world.CentralEventBus.GetRecentAlerts(10) {
+		alerts = append(alerts, AlertData{
+			ID:          event.ID,
+			Tick:        event.Tick,
+			Type:        event.Type,
+			Severity:    event.Severity,
+			Description: event.Description,
+		})
+	}
+	return alerts
+}
+
+func (vm *ViewManager) getPlayerGroupsData() []PlayerGroupData {
+	groups := make([]PlayerGroupData, 0)
+	if vm.world.PlayerGroups == nil {
+		return groups
+	}
+	playerGroups := vm.world.PlayerGroups.Groups("")
+	if len(playerGroups) == 0 {
+		return groups
+	}
+	entities := make(map[int]*Entity, len(vm.world.AllEntities))
+	for _, entity := range vm.world.AllEntities {
+		entities[entity.ID] = entity
+	}
+	for _, group := range playerGroups {
+		data := PlayerGroupData{
+			ID:        group.ID,
+			PlayerID:  group.PlayerID,
+			Size:      len(group.EntityIDs),
+			Formation: group.Formation,
+			Cells:     make([]GridPoint, 0),
+		}
+		if group.Order != nil {
+			data.Order = group.Order.Kind
+			data.Arrived = group.Order.Arrived
+		}
+		seen := make(map[GridPoint]bool)
+		for _, id := range group.EntityIDs {
+			if entity, exists := entities[id]; exists {
+				gx, gy := vm.world.worldToGridCoords(entity.Position.X, entity.Position.Y)
+				if cell := (GridPoint{X: gx, Y: gy}); !seen[cell] {
+					seen[cell] = true
+					data.Cells = append(data.Cells, cell)
+				}
+			}
+		}
+		groups = append(groups, data)
+	}
+	return groups
+}
+
+func (vm *ViewManager) getSpeciesPoliciesData() []SpeciesPolicy {
+	if vm.world.SpeciesPolicies == nil {
+		return make([]SpeciesPolicy, 0)
+	}
+	return vm.world.SpeciesPolicies.Policies("")
+}
+
+func (vm *ViewManager) getGameData() *CompetitiveGame {
+	if vm.world.CompetitiveGame == nil {
+		return nil
+	}
+	if game, exists := vm.world.CompetitiveGame.Current(); exists {
+		return &game
+	}
+	return nil
+}
+
+func (vm *ViewManager) getTutorialData() *Tutorial {
+	if vm.world.Tutorials == nil {
+		return nil
+	}
+	if tutorial, exists := vm.world.Tutorials.Current(); exists {
+		return &tutorial
+	}
+	return nil
+}
+
+func (vm *ViewManager) getLatestSnapshot() int {
+	if vm.world.Gallery == nil {
+		return 0
+	}
+	return vm.world.Gallery.Latest()
+}
+
+func (vm *ViewManager) getPredictionsData() []PredictionRound {
+	if vm.world.Predictions == nil {
+		return nil
+	}
+	return vm.world.Predictions.OpenRounds()
+}
+
 func (vm *ViewManager) getPopulationsData() []PopulationData {
 	populations := make([]PopulationData, 0, len(vm.world.Populations))
 
@@ -1223,6 +1505,7 @@ func (vm *ViewManager) getSpeciesData() SpeciesData {
 				ExtinctionTick:     species.ExtinctionTick,
 				PeakPopulation:     species.PeakPopulation,
 				AwaitingExtinction: awaitingExtinction,
+				Traits:             species.CurrentTraits,
 			}
 			data.SpeciesDetails = append(data.SpeciesDetails, detail)
 		}
@@ -1361,6 +1644,20 @@ func (vm *ViewManager) getEvolutionData() EvolutionData {
 		}
 	}
 
+	if vm.world.Mutations != nil {
+		data.MutationOperators = vm.world.Mutations.Report(0).Operators
+	}
+	if vm.world.MacroEvolutionSystem != nil {
+		data.Radiations = vm.world.MacroEvolutionSystem.GetRecentRadiations(5)
+		data.Convergences = vm.world.MacroEvolutionSystem.GetRecentConvergences(5)
+	}
+	if vm.world.RedQueen != nil {
+		data.ArmsRaces = vm.world.RedQueen.Report()
+		if len(data.ArmsRaces) > 5 {
+			data.ArmsRaces = data.ArmsRaces[:5]
+		}
+	}
+
 	return data
 }
 
@@ -1433,7 +1730,7 @@ func (vm *ViewManager) GetViewModes() []string {
 		"GRID", "STATS", "EVENTS", "POPULATIONS", "COMMUNICATION",
 		"CIVILIZATION", "PHYSICS", "WIND", "SPECIES", "NETWORK",
 		"DNA", "CELLULAR", "EVOLUTION", "TOPOLOGY", "TOOLS", "ENVIRONMENT", "BEHAVIOR",
-		"REPRODUCTION", "WARFARE", "STATISTICAL", "ANOMALIES", "ECOSYSTEM", "FUNGAL", "CULTURAL", "SYMBIOTIC", "NEURAL", "BIOMEBOUNDARY",
+		"REPRODUCTION", "WARFARE", "STATISTICAL", "ANOMALIES", "ECOSYSTEM", "FUNGAL", "CULTURAL", "SYMBIOTIC", "NEURAL", "BIOMEBOUNDARY", "RESOURCES", "LANDSCAPE",
 	}
 }
 
@@ -2361,18 +2658,7 @@ func (vm *ViewManager) getNeuralData() NeuralData {
 		if entityData != nil {
 			// Convert network type enum to string for JSON
 			if networkType, ok := entityData["type"].(NeuralNetworkType); ok {
-				switch networkType {
-				case FeedForward:
-					entityData["type"] = "FeedForward"
-				case Recurrent:
-					entityData["type"] = "Recurrent"
-				case Convolutional:
-					entityData["type"] = "Convolutional"
-				case Reinforcement:
-					entityData["type"] = "Reinforcement"
-				default:
-					entityData["type"] = "Unknown"
-				}
+				entityData["type"] = neuralNetworkTypeName(networkType)
 			}
 
 			data.EntityNetworks[fmt.Sprintf("%d", entityID)] = entityData
@@ -2519,8 +2805,12 @@ func (vm *ViewManager) getBioRhythmData() BioRhythmData {
 		// Biorhythm efficiency calculation
 		isEfficient := false
 		if circadianPref < -0.3 && timeState.IsNight() && currentActivity != ActivitySleep {
-			// Nocturnal and active at night
-			isEfficient = true
+			// Nocturnal and active at night, unless settlement light has turned night to day
+			if light, _ := vm.world.nightLightAt(entity.Position); light > 0 {
+				data.LightDisrupted++
+			} else {
+				isEfficient = true
+			}
 		} else if circadianPref > 0.3 && !timeState.IsNight() && currentActivity != ActivitySleep {
 			// Diurnal and active during day
 			isEfficient = true
@@ -328,7 +328,7 @@ func (vs *ViewportSystem) renderEntityView(world *World) string {
 	}
 
 	// Nearby entities
-	nearbyEntities := vs.findNearbyEntities(entity, world.AllEntities, 10.0)
+	nearbyEntities := vs.findNearbyEntities(entity, world.AllEntities, 10.0, world.Geometry())
 	if len(nearbyEntities) > 0 {
 		content.WriteString(fmt.Sprintf("\nNearby Entities (%d):\n", len(nearbyEntities)))
 		for i, nearby := range nearbyEntities {
@@ -336,7 +336,7 @@ func (vs *ViewportSystem) renderEntityView(world *World) string {
 				content.WriteString(fmt.Sprintf("... and %d more\n", len(nearbyEntities)-3))
 				break
 			}
-			distance := entity.DistanceTo(nearby)
+			distance := world.Distance(entity.Position, nearby.Position)
 			content.WriteString(fmt.Sprintf("  %s #%d (%.1f units away)\n",
 				nearby.Species, nearby.ID, distance))
 		}
@@ -367,7 +367,7 @@ func (vs *ViewportSystem) renderEntityView(world *World) string {
 }
 
 // findNearbyEntities finds entities within a certain range
-func (vs *ViewportSystem) findNearbyEntities(center *Entity, allEntities []*Entity, maxDistance float64) []*Entity {
+func (vs *ViewportSystem) findNearbyEntities(center *Entity, allEntities []*Entity, maxDistance float64, geometry WorldGeometry) []*Entity {
 	var nearby []*Entity
 
 	for _, entity := range allEntities {
@@ -375,7 +375,7 @@ func (vs *ViewportSystem) findNearbyEntities(center *Entity, allEntities []*Enti
 			continue
 		}
 
-		distance := center.DistanceTo(entity)
+		distance := geometry.Distance(center.Position, entity.Position)
 		if distance <= maxDistance {
 			nearby = append(nearby, entity)
 		}
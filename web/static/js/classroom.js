@@ -0,0 +1,146 @@
+// Classroom: the teacher's dashboard of every student's world
+const refreshMS = 2000;
+
+function refreshClass() {
+    fetch('/api/classroom')
+        .then(response => {
+            if (response.status === 404) {
+                return null;
+            }
+            return response.ok ? response.json() : response.text().then(text => { throw new Error(text); });
+        })
+        .then(showClass)
+        .catch(error => console.error('Failed to refresh the class:', error));
+}
+
+function showClass(status) {
+    document.getElementById('noClass').hidden = status !== null;
+    document.getElementById('classView').hidden = status === null;
+    if (status === null) {
+        return;
+    }
+
+    const list = document.getElementById('studentList');
+    list.innerHTML = '';
+    status.students.forEach(student => {
+        const row = document.createElement('tr');
+        const world = student.world;
+        const metrics = student.metrics || {};
+        let name = student.name;
+        if (world) {
+            name = document.createElement('a');
+            name.href = world.url;
+            name.target = '_blank';
+            name.textContent = student.name;
+        }
+        const state = document.createElement('span');
+        state.textContent = !world ? 'deleted' : world.paused ? 'paused' : 'running';
+        state.className = world && world.paused ? 'paused' : '';
+        const collected = student.collected ? 'tick ' + student.collected.tick + ' (' + new Date(student.collected.collected).toLocaleTimeString() + ')' : '-';
+        const diversity = metrics.shannon_diversity !== undefined ? metrics.shannon_diversity.toFixed(2) : '-';
+        const cells = [name, world ? world.tick : '-', world ? world.entities : '-', world ? world.plants : '-',
+            metrics.species_richness !== undefined ? metrics.species_richness : '-', diversity,
+            world ? world.clients : '-', state, collected];
+        cells.forEach(value => {
+            const cell = document.createElement('td');
+            if (value instanceof Node) {
+                cell.appendChild(value);
+            } else {
+                cell.textContent = value;
+            }
+            row.appendChild(cell);
+        });
+
+        const remove = document.createElement('button');
+        remove.className = 'button';
+        remove.textContent = 'Remove';
+        remove.addEventListener('click', () => removeStudent(student.name));
+        const actions = document.createElement('td');
+        actions.appendChild(remove);
+        row.appendChild(actions);
+        list.appendChild(row);
+    });
+
+    const broadcasts = document.getElementById('broadcastList');
+    broadcasts.innerHTML = '';
+    status.broadcasts.slice().reverse().forEach(broadcast => {
+        const item = document.createElement('li');
+        item.textContent = new Date(broadcast.sent).toLocaleTimeString() + ' - ' + broadcast.message;
+        broadcasts.appendChild(item);
+    });
+}
+
+// send posts or deletes on the classroom API and shows the class it answers with
+function send(method, path, body, errorElement) {
+    if (errorElement) {
+        errorElement.textContent = '';
+    }
+    const options = {method: method, headers: {'Content-Type': 'application/json'}};
+    if (body !== undefined) {
+        options.body = JSON.stringify(body);
+    }
+    return fetch(path, options)
+        .then(response => response.ok ? response.text() : response.text().then(text => { throw new Error(text); }))
+        .then(() => refreshClass())
+        .catch(error => {
+            if (errorElement) {
+                errorElement.textContent = error.message;
+            } else {
+                alert(error.message);
+            }
+            throw error;
+        });
+}
+
+function removeStudent(name) {
+    if (!confirm('Remove ' + name + ' from the class and delete their world?')) {
+        return;
+    }
+    send('DELETE', '/api/classroom/students?name=' + encodeURIComponent(name)).catch(() => {});
+}
+
+document.getElementById('startForm').addEventListener('submit', event => {
+    event.preventDefault();
+    const errorDiv = document.getElementById('startError');
+    const request = {
+        students: document.getElementById('studentNames').value.split(/[\s,]+/).filter(name => name !== ''),
+        autosave_interval: parseInt(document.getElementById('autosaveInterval').value, 10) || 0
+    };
+    const template = document.getElementById('templateConfig').value.trim();
+    if (template) {
+        try {
+            request.template = JSON.parse(template);
+        } catch (error) {
+            errorDiv.textContent = 'Template is not valid JSON: ' + error.message;
+            return;
+        }
+    }
+    send('POST', '/api/classroom', request, errorDiv).catch(() => {});
+});
+
+document.getElementById('addForm').addEventListener('submit', event => {
+    event.preventDefault();
+    const input = document.getElementById('newStudent');
+    send('POST', '/api/classroom/students', {name: input.value}, document.getElementById('addError'))
+        .then(() => { input.value = ''; })
+        .catch(() => {});
+});
+
+document.getElementById('broadcastForm').addEventListener('submit', event => {
+    event.preventDefault();
+    const input = document.getElementById('broadcastMessage');
+    send('POST', '/api/classroom/broadcast', {message: input.value}, document.getElementById('broadcastError'))
+        .then(() => { input.value = ''; })
+        .catch(() => {});
+});
+
+document.getElementById('pauseAll').addEventListener('click', () => send('POST', '/api/classroom/pause', {paused: true}).catch(() => {}));
+document.getElementById('resumeAll').addEventListener('click', () => send('POST', '/api/classroom/pause', {paused: false}).catch(() => {}));
+document.getElementById('endClass').addEventListener('click', () => {
+    if (confirm('End the class? The students\' worlds stay in the lobby.')) {
+        send('DELETE', '/api/classroom').catch(() => {});
+    }
+});
+
+refreshClass();
+setInterval(refreshClass, refreshMS);
@@ -0,0 +1,5910 @@
+let ws = null;
+let isPaused = false;
+let currentView = 'GRID';
+let playerID = null;
+let playerSpecies = [];
+let selectedSpecies = null;
+let moveTarget = null;
+let moveTargetCell = null; // World grid cell clicked as the group order target
+let inspectedCell = null; // World grid cell clicked to inspect, and what was in it
+let inspectedEntityID = null; // Entity the inspector follows as it moves
+let followTrack = null; // What the creature the shared camera follows did lately
+let selectedGroupID = null;
+let groupCells = {}; // 'x,y' -> true if a selected group member is there, false for the player's other groups
+let boxSelection = null; // {start, end} grid cells while shift-dragging
+let speciesPolicies = {}; // species -> policy sliders and how far the species has adapted
+let currentGame = null; // Running or last competitive game
+let watchedGameID = null; // Running game whose summary should pop up when it ends
+let currentTutorial = null; // Running or last guided scenario
+let tutorialProgress = ''; // Scenario and steps done as last announced
+let latestSnapshot = 0; // Newest gallery snapshot as last seen
+let openPredictions = []; // Prediction rounds awaiting their outcome
+let latestPredictionID = 0; // Newest prediction round spectators were told about
+let predictionsKey = ''; // Open rounds as last rendered
+let gridChunks = {}; // 'x,y' -> grid chunk streamed from the server
+let nightShading = localStorage.getItem('evosim-night-shading') !== 'off'; // Shade grid cells by their daylight
+let traitColoring = localStorage.getItem('evosim-trait-coloring') || 'diet'; // Colour scheme creatures are drawn in
+let traitLegend = null; // How creatures are sized and coloured, from the trait registry
+let gridSymbols = null; // The web grid's legend sections by name, each entry indexed by key and by symbol
+let chunkSubscriptionKey = ''; // Visible area last subscribed to
+const chunkMargin = 16; // Cells streamed around the visible area so panning shows something at once
+const policySettings = ['aggression', 'exploration', 'reproduction'];
+
+const viewModes = [
+    'GRID', 'STATS', 'EVENTS', 'POPULATIONS', 'COMMUNICATION',
+    'CIVILIZATION', 'PHYSICS', 'WIND', 'SPECIES', 'NETWORK',
+    'DNA', 'CELLULAR', 'EVOLUTION', 'TOPOLOGY', 'TOOLS', 'ENVIRONMENT', 'BEHAVIOR',
+    'REPRODUCTION', 'STATISTICAL', 'ECOSYSTEM', 'ANOMALIES', 'WARFARE', 'FUNGAL', 'CULTURAL', 'SYMBIOTIC', 'BIORHYTHM', 'NEURAL', 'TIMELINE', 'RESOURCES', 'LANDSCAPE', 'DEMOGRAPHICS', 'MIGRATION'
+];
+
+// Initialize view tabs
+function initViewTabs() {
+    const tabsContainer = document.getElementById('view-tabs');
+    viewModes.forEach(mode => {
+        const button = document.createElement('button');
+        button.className = 'view-tab';
+        button.textContent = mode;
+        if (mode === currentView) {
+            button.classList.add('active');
+        }
+        button.onclick = () => switchView(mode);
+        tabsContainer.appendChild(button);
+    });
+}
+
+// Toggle view description expansion
+function toggleDescription(viewMode) {
+    const content = document.getElementById(viewMode.toLowerCase() + '-description-content');
+    const button = document.getElementById(viewMode.toLowerCase() + '-description-toggle');
+    if (content && button) {
+        content.classList.toggle('expanded');
+        if (content.classList.contains('expanded')) {
+            button.textContent = 'Hide Description ▲';
+        } else {
+            button.textContent = 'Show Description ▼';
+        }
+    }
+}
+
+// Get view description HTML
+function getViewDescription(viewMode) {
+    const descriptions = {
+        'GRID': {
+            title: 'Grid View - Main World Visualization',
+            description: 'Displays the complete 2D simulation world showing entities, plants, biomes, and environmental features in real-time. Entities appear as symbols (🐰 herbivores, 🐺 predators, 🐻 omnivores) moving through different biomes. Click on any cell to see detailed information about entities and environment.'
+        },
+        'STATS': {
+            title: 'Statistics View - Population Analytics',
+            description: 'Shows comprehensive population statistics including average fitness, energy levels, age distributions, and trait averages. Tracks evolutionary progress through numerical metrics and helps identify population trends and health indicators.'
+        },
+        'EVENTS': {
+            title: 'Events View - World Event Log',
+            description: 'Displays recent significant events including births, deaths, environmental changes, discoveries, and other important occurrences. Events are timestamped and categorized to help track simulation progress and understand population dynamics.'
+        },
+        'POPULATIONS': {
+            title: 'Populations View - Species Demographics',
+            description: 'Detailed breakdown of all active species showing population counts, genetic diversity, and species-specific traits. Tracks species formation, extinction events, and genetic drift over time.'
+        },
+        'COMMUNICATION': {
+            title: 'Communication View - Signal Networks',
+            description: 'Monitors entity communication through 6 signal types: Alert (danger warnings), Food (source locations), Mating (reproductive calls), Territory (boundary claims), Distress (help requests), and Cooperation (group activities). Shows signal strength and propagation patterns.'
+        },
+        'CIVILIZATION': {
+            title: 'Civilization View - Colony Development',
+            description: 'Tracks tribal organization and structure development including 8 building types: Nests, Food Storage, Watchtowers, Barriers, Workshops, Temples, Trade Posts, and Academies. Shows technology advancement and collective resource management.'
+        },
+        'PHYSICS': {
+            title: 'Physics View - Simulation Mechanics',
+            description: 'Displays physics engine state including collision detection, environmental forces, movement patterns, and momentum calculations. Shows how physical laws affect entity behavior and world interactions.'
+        },
+        'WIND': {
+            title: 'Wind View - Atmospheric System',
+            description: 'Visualizes wind patterns, pollen dispersal, and seed distribution. Shows wind direction, strength, turbulence levels, and weather patterns. Critical for understanding plant reproduction and genetic mixing through atmospheric transport.'
+        },
+        'SPECIES': {
+            title: 'Species View - Evolutionary Tracking',
+            description: 'Monitors species formation through genetic distance tracking. Shows when populations split into new species, genetic compatibility matrices, and reproductive barriers. Tracks speciation events and phylogenetic relationships.'
+        },
+        'NETWORK': {
+            title: 'Network View - Underground Plant Networks',
+            description: 'Displays mycorrhizal networks connecting compatible plants underground. Shows resource sharing, chemical signal propagation, and network health. Three connection types: Mycorrhizal (fungal), Root (direct), and Chemical (signaling).'
+        },
+        'DNA': {
+            title: 'DNA View - Genetic Systems',
+            description: 'Shows detailed genetic information including nucleotide sequences (A, T, G, C), chromosome structure, gene expression levels, and inheritance patterns. Displays dominant/recessive alleles and genetic crossover during reproduction.'
+        },
+        'CELLULAR': {
+            title: 'Cellular View - Microscopic Structure',
+            description: 'Examines organisms at cellular level showing 8 cell types (Stem, Nerve, Muscle, Digestive, Reproductive, Defensive, Photosynthetic, Storage) and 8 organelle types. Displays cellular complexity levels from simple (Level 1) to highly complex (Level 5).'
+        },
+        'EVOLUTION': {
+            title: 'Evolution View - Macro Evolution',
+            description: 'Tracks large-scale evolutionary changes including species lineages, phylogenetic trees, and major evolutionary events. Shows environmental pressure correlation and identifies speciation, extinction, and adaptation events.'
+        },
+        'TOPOLOGY': {
+            title: 'Topology View - World Terrain',
+            description: 'Displays world geography including mountains, valleys, rivers, lakes, and underground features. Shows geological processes like earthquakes, volcanic eruptions, and erosion affecting evolution. Includes elevation maps and terrain cross-sections.'
+        },
+        'TOOLS': {
+            title: 'Tools View - Technology System',
+            description: 'Monitors tool creation and usage including 10 tool types: Stone, Stick, Spear, Hammer, Blade, Digger, Crusher, Container, Fire, and Weaving tools. Shows tool durability, efficiency, ownership, and modification systems.'
+        },
+        'ENVIRONMENT': {
+            title: 'Environment View - World Modifications',
+            description: 'Tracks environmental modifications created by entities including 12 types: Tunnels, Burrows, Caches, Traps, Waterholes, Paths, Bridges, Towers, Shelters, Workshops, Farms, and Walls. Shows persistent structures that affect future generations.'
+        },
+        'BEHAVIOR': {
+            title: 'Behavior View - Emergent Behaviors',
+            description: 'Monitors discovery and spread of 8 emergent behaviors: Tool Making, Cache Building, Trap Setting, Tunnel Building, Group Hunting, Nest Construction, Resource Hoarding, and Social Learning. Shows behavior proficiency and social transmission.'
+        },
+        'REPRODUCTION': {
+            title: 'Reproduction View - Mating Systems',
+            description: 'Displays reproductive patterns including 4 reproduction modes (DirectCoupling, EggLaying, LiveBirth, Budding) and 4 mating strategies (Monogamous, Polygamous, Sequential, Promiscuous). Shows gestation periods and seasonal mating cycles.'
+        },
+        'STATISTICAL': {
+            title: 'Statistical View - Data Analysis',
+            description: 'Advanced statistical analysis including trend detection, anomaly identification, data export capabilities, and pattern recognition. Provides comprehensive simulation metrics and analytical tools for researchers.'
+        },
+        'ECOSYSTEM': {
+            title: 'Ecosystem View - Health Metrics',
+            description: 'Overall ecosystem health assessment including genetic diversity indices (Shannon/Simpson), species count tracking, network connectivity, pollination success rates, and ecological stability measurements. Provides 0-100 health scoring.'
+        },
+        'ANOMALIES': {
+            title: 'Anomalies View - Unusual Events',
+            description: 'Detects and displays statistical outliers, unusual population behaviors, genetic anomalies, and environmental irregularities. Helps identify interesting evolutionary events and simulation edge cases.'
+        },
+        'WARFARE': {
+            title: 'Warfare View - Colony Conflicts',
+            description: 'Monitors inter-colony warfare and diplomacy including 6 relationship types (Neutral, Friendly, Allied, Rival, Hostile, Enemy) and 4 conflict types (Border Skirmish, Resource War, Total War, Raid). Shows battle outcomes and territorial changes.'
+        },
+        'FUNGAL': {
+            title: 'Fungal View - Decomposer System',
+            description: 'Tracks decomposer organisms that break down dead organic matter and recycle nutrients. Shows fungal reproduction through spores, mycelium network formation, and soil health enhancement through organic matter processing.'
+        },
+        'CULTURAL': {
+            title: 'Cultural View - Knowledge Systems',
+            description: 'Monitors cultural evolution and knowledge transmission including learned behaviors, cultural traits, knowledge inheritance, and social learning patterns. Shows how culture evolves alongside genetics.'
+        },
+        'SYMBIOTIC': {
+            title: 'Symbiotic View - Organism Relationships',
+            description: 'Displays parasitic and mutualistic relationships between organisms including disease transmission, host-parasite co-evolution, symbiotic partnerships, and relationship formation/dissolution dynamics.'
+        },
+        'BIORHYTHM': {
+            title: 'BioRhythm View - Activity Patterns',
+            description: 'Monitors entity activity patterns including 8 activity types (Sleep, Eat, Drink, Play, Explore, Scavenge, Rest, Socialize) with need-based behavior selection. Shows circadian rhythms, seasonal effects, and species-specific biorhythm profiles.'
+        },
+        'NEURAL': {
+            title: 'Neural Networks View - AI Learning System',
+            description: 'Monitors neural network learning in intelligent entities (intelligence > 0.3). Shows network creation, learning events, behavior patterns, and decision-making processes. Entities appear when they gain neural networks and disappear when they die or lose intelligence. Learned information is stored in synaptic weights and passed to offspring through the intelligence trait.'
+        },
+        'TIMELINE': {
+            title: 'Timeline View - Event History',
+            description: 'Plots every recorded event on a horizontal timeline with one lane per category; darker cells mean more events. High and critical events such as extinctions, wars and disasters are marked individually above the lanes. Scroll to zoom around the cursor and drag to select a tick range; the selection filters the Events, Populations, Communication and Physics views to that period until it is cleared.'
+        },
+        'RESOURCES': {
+            title: 'Resources View - Memory Usage',
+            description: 'Tracks how much each store of the simulation holds (creatures, events, seeds, eggs, neural networks, organisms) with an estimated footprint, the Go heap and a sparkline of recent samples taken every 100 ticks. A store that keeps growing without levelling off, or passes its limit with pruning off, is flagged and raises an alert. Each prunable store has a limit and optional automatic pruning; per-creature stores also drop the leftovers of dead creatures when pruned.'
+        },
+        'LANDSCAPE': {
+            title: 'Fitness Landscape View - Genotype Space',
+            description: 'Samples genotype space around the living members of a species and shows the expected fitness of each combination of two chosen traits in the current environment: how well the traits suit the biome and season where the sampled creatures stand, what they earn in foraging and fights, and the energy they cost. Brighter cells are fitter; peaks are marked ▲ and valleys ▼. Dots are the living creatures, the ring is the population mean and the arrow points uphill, the way selection is pushing it. The landscape is resampled every few seconds.'
+        },
+        'DEMOGRAPHICS': {
+            title: 'Demographics View - Population Pyramids',
+            description: 'Draws the age and sex structure of every colony and species as a population pyramid, youngest cohort at the bottom; each cohort is a tenth of a lifespan. Females are on the left, males and asexual creatures on the right. Colonies are counted every 50 ticks: members found in the youngest cohort were born into the colony, and the births per member give its birth rate. An advanced colony with secure food whose birth rate falls to half its peak has gone through the demographic transition.'
+        },
+        'MIGRATION': {
+            title: 'Migration View - Herds and Routes',
+            description: 'Maps the herds on the move and the routes each species has learned. Migratory creatures of a species standing close together travel as one herd at the pace of the slowest, each keeping its place: toward the equator when the season turns colder and home when it turns warmer, or toward the richest plants within reach when they go hungry. A herd with a member who knows a route from where it stands follows it; otherwise it makes its own way and learns it on arrival. Creatures pass the routes they know to kin close by, and a route nobody alive knows is forgotten. Dashed lines are past journeys, solid lines learned routes, dots the herds and their members.'
+        }
+    };
+
+    const info = descriptions[viewMode] || { title: viewMode + ' View', description: 'View description not available.' };
+
+    return '<div class="view-description">' +
+        '<button class="description-toggle" id="' + viewMode.toLowerCase() + '-description-toggle" onclick="toggleDescription(\'' + viewMode + '\')">Show Description ▼</button>' +
+        '<div id="' + viewMode.toLowerCase() + '-description-content" class="description-content">' +
+        '<h4>' + info.title + '</h4>' +
+        '<p>' + info.description + '</p>' +
+        '</div>' +
+        '</div>';
+}
+
+// Switch view mode. Tab clicks record the view in the URL; deep links pass fromLink
+// so the link being followed stays in the address bar.
+function switchView(mode, fromLink) {
+    if (!fromLink) {
+        highlightID = null;
+        history.replaceState(null, '', '#view/' + mode);
+    }
+    currentView = mode;
+    document.querySelectorAll('.view-tab').forEach(tab => {
+        tab.classList.toggle('active', tab.textContent === mode);
+    });
+
+    // Update content based on view
+    updateViewContent();
+}
+
+// Connect to WebSocket
+function connect() {
+    const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
+    // Relative to the page, which a world server serves below /worlds/<name>/
+    const basePath = window.location.pathname.replace(/[^/]*$/, '');
+    const wsUrl = protocol + '//' + window.location.host + basePath + 'ws?chunks=1';
+
+    ws = new WebSocket(wsUrl);
+    gridChunks = {};
+    chunkSubscriptionKey = '';
+
+    ws.onopen = function() {
+        console.log('Connected to simulation');
+        document.getElementById('connection-status').textContent = 'Connected';
+        document.getElementById('connection-status').className = 'connection-status connected';
+    };
+
+    ws.onmessage = function(event) {
+        const data = JSON.parse(event.data);
+
+        // The soundscape, about once a second
+        if (data.type === 'audio') {
+            playAmbientAudio(data);
+            return;
+        }
+
+        // A teacher's broadcast to the class
+        if (data.type === 'announcement') {
+            showToast('📣 From your teacher', data.message, 'critical');
+            return;
+        }
+
+        // Check if this is a player-specific message
+        if (data.type && ['player_joined', 'species_created', 'command_executed', 'group_selected', 'species_policy_updated', 'diplomacy', 'species_extinct', 'subspecies_formed', 'new_species_detected', 'achievement_unlocked', 'error'].includes(data.type)) {
+            handlePlayerMessage(data);
+            return;
+        }
+
+        // Otherwise treat it as simulation data
+        applyGridChunks(data);
+        console.log('WebSocket data received, tick:', data.tick, 'entities:', data.entity_count, 'grid length:', data.grid ? data.grid.length : 'null');
+        updateDisplay(data);
+    };
+
+    ws.onclose = function() {
+        console.log('Disconnected from simulation');
+        document.getElementById('connection-status').textContent = 'Disconnected';
+        document.getElementById('connection-status').className = 'connection-status disconnected';
+
+        // Attempt to reconnect after 3 seconds
+        setTimeout(connect, 3000);
+    };
+
+    ws.onerror = function(error) {
+        console.error('WebSocket error:', error);
+    };
+}
+
+// Merge streamed grid chunks into the cache, follow the viewport with the subscription and
+// assemble the visible grid from the chunks
+function applyGridChunks(data) {
+    if (!data.chunk_size) {
+        return;
+    }
+    (data.chunks || []).forEach(function(chunk) {
+        gridChunks[chunk.x + ',' + chunk.y] = chunk;
+    });
+    const area = visibleGridArea(data);
+    subscribeVisibleChunks(area);
+    data.grid = gridFromChunks(area, data.chunk_size);
+}
+
+// visibleGridArea works out the cells the viewport shows, as the server does for the grid
+function visibleGridArea(data) {
+    const zoom = data.zoom_level || 1;
+    const width = Math.min(data.grid_width, Math.max(5, Math.floor(data.grid_width / zoom)));
+    const height = Math.min(data.grid_height, Math.max(5, Math.floor(data.grid_height / zoom)));
+    return {
+        x: Math.max(0, Math.min(data.viewport_x, data.grid_width - width)),
+        y: Math.max(0, Math.min(data.viewport_y, data.grid_height - height)),
+        width: width,
+        height: height
+    };
+}
+
+// subscribeVisibleChunks asks for the chunks covering the area when it has moved
+function subscribeVisibleChunks(area) {
+    const key = [area.x, area.y, area.width, area.height].join(',');
+    if (key === chunkSubscriptionKey || !ws || ws.readyState !== WebSocket.OPEN) {
+        return;
+    }
+    chunkSubscriptionKey = key;
+    ws.send(JSON.stringify({
+        action: 'subscribe_chunks',
+        data: {x: area.x, y: area.y, width: area.width, height: area.height, margin: chunkMargin}
+    }));
+}
+
+// gridFromChunks lays out the area's cells from the cached chunks, leaving cells of chunks
+// not received yet empty
+function gridFromChunks(area, chunkSize) {
+    const grid = [];
+    for (let y = 0; y < area.height; y++) {
+        const row = [];
+        for (let x = 0; x < area.width; x++) {
+            const gx = area.x + x;
+            const gy = area.y + y;
+            const chunk = gridChunks[Math.floor(gx / chunkSize) + ',' + Math.floor(gy / chunkSize)];
+            if (!chunk) {
+                row.push({x: x, y: y, grid_x: gx, grid_y: gy, biome: 'void', biome_symbol: ' ', entity_count: 0, plant_count: 0});
+                continue;
+            }
+            const cell = chunk.cells[(gy - chunk.grid_y) * chunk.width + (gx - chunk.grid_x)];
+            row.push(Object.assign({}, cell, {x: x, y: y}));
+        }
+        grid.push(row);
+    }
+    return grid;
+}
+
+const seasonIcons = {Spring: '🌱', Summer: '☀️', Autumn: '🍂', Winter: '❄️'};
+
+// Show the season, how far through it the world is and what it does, in the status bar
+function updateSeason(season) {
+    if (!season || !season.season) {
+        return;
+    }
+    const element = document.getElementById('season');
+    element.textContent = (seasonIcons[season.season] || '') + ' ' + season.season + ' (day ' + (season.day + 1) + '/' + season.length + ')';
+    const effects = season.effects;
+    element.title = 'Year ' + (season.year + 1) + ', ' + season.next + ' next. Temperature ×' + effects.temperature +
+        ', plant growth ×' + effects.plant_growth + ', food ×' + effects.food +
+        (effects.mating ? ', open mating season' : '') + (season.migrants ? ', ' + season.migrants + ' migrants away' : '');
+}
+
+// Update display with new simulation data
+function updateDisplay(data) {
+    // Update status bar
+    document.getElementById('tick').textContent = 'Tick: ' + data.tick;
+    lastTick = data.tick;
+    document.getElementById('time').textContent = 'Time: ' + data.time_string;
+    updateSeason(data.season);
+    document.getElementById('entities').textContent = 'Entities: ' + data.entity_count;
+    document.getElementById('plants').textContent = 'Plants: ' + data.plant_count;
+    document.getElementById('populations').textContent = 'Populations: ' + data.population_count;
+
+    // Update speed display
+    if (data.speed_multiplier !== undefined) {
+        document.getElementById('speed-display').textContent = data.unlimited_speed ? 'max' : data.speed_multiplier.toFixed(2) + 'x';
+    }
+    document.getElementById('breakpoint-hit').textContent = data.breakpoint_hit ? '| ⏸ ' + data.breakpoint_hit : '';
+    processAlerts(data.alerts);
+    if (data.ticks_per_second !== undefined) {
+        document.getElementById('tps').textContent = 'TPS: ' + data.ticks_per_second.toFixed(1);
+        document.getElementById('unlimited-speed').checked = data.unlimited_speed;
+        document.getElementById('max-cpu').value = String(Math.round(data.max_cpu * 100));
+    }
+
+    // Update zoom display
+    if (data.zoom_level !== undefined) {
+        document.getElementById('zoom-display').textContent = data.zoom_level.toFixed(2) + 'x';
+    }
+
+    // Update pause button based on paused state
+    if (data.paused !== undefined) {
+        const btn = document.getElementById('pause-btn');
+        btn.textContent = data.paused ? '▶ Resume' : '⏸ Pause';
+        isPaused = data.paused;
+    }
+
+    // Update stats
+    if (data.stats.avg_fitness !== undefined) {
+        document.getElementById('avg-fitness').textContent = data.stats.avg_fitness.toFixed(2);
+    }
+    if (data.stats.avg_energy !== undefined) {
+        document.getElementById('avg-energy').textContent = data.stats.avg_energy.toFixed(2);
+    }
+    if (data.stats.avg_age_percent !== undefined) {
+        // Use percentage of lifespan for better representation
+        document.getElementById('avg-age').textContent = data.stats.avg_age_percent.toFixed(1) + '% of lifespan';
+    } else if (data.stats.avg_age !== undefined) {
+        // Fallback to raw age if percentage not available
+        document.getElementById('avg-age').textContent = data.stats.avg_age.toFixed(1) + ' ticks';
+    }
+
+    // Update populations
+    let populationsHtml = '';
+    // Sort populations by name for consistent ordering
+    const sortedPops = [...data.populations].sort((a, b) => a.name.localeCompare(b.name));
+    sortedPops.forEach(pop => {
+        populationsHtml += '<div><strong>' + pop.name + '</strong>: ' + pop.count +
+                         ' (Fitness: ' + pop.avg_fitness.toFixed(2) + ')</div>';
+    });
+    document.getElementById('populations-content').innerHTML = populationsHtml || 'No populations';
+
+    // Update communication
+    document.getElementById('active-signals').textContent = data.communication.active_signals;
+
+    // Update wind
+    document.getElementById('wind-direction').textContent = (data.wind.direction * 180 / Math.PI).toFixed(1) + '°';
+    document.getElementById('wind-strength').textContent = data.wind.strength.toFixed(2);
+    document.getElementById('weather-pattern').textContent = data.wind.weather_pattern;
+
+    updatePlayerGroups(data.player_groups);
+    updateSpeciesPolicies(data.species_policies);
+    updateGame(data.game);
+    updateTutorial(data.tutorial);
+    updatePredictions(data.predictions);
+    updateGallery(data.latest_snapshot);
+    refreshInspector();
+    updateFollow(data.follow);
+
+    // Update main view content
+    updateViewContent(data);
+}
+
+// Update view content based on current view mode
+function updateViewContent(data = null) {
+    if (!data) return;
+
+    const viewContent = document.getElementById('view-content');
+
+    // Add view description at the top
+    let contentHtml = getViewDescription(currentView);
+    if (tickRange && tickRangeViews.includes(currentView)) {
+        contentHtml += renderTickRangeBanner();
+    }
+
+    switch (currentView) {
+        case 'GRID':
+            const gridHtml = renderGrid(data.grid, data.grid_shape);
+            console.log('Grid HTML length:', gridHtml.length, 'First 100 chars:', gridHtml.substring(0, 100));
+            // Update the existing grid container directly
+            const gridContainer = document.getElementById('grid-view');
+            if (gridContainer) {
+                // Only update the grid content, preserve the description
+                if (!document.getElementById('grid-description-toggle')) {
+                    viewContent.innerHTML = contentHtml + '<div class="grid-container" id="grid-view" onclick="handleGridClick(event)">' + gridHtml + '</div>';
+                } else {
+                    gridContainer.innerHTML = gridHtml;
+                }
+                // Add click listener for movement control
+                if (gridContainer.onclick !== handleGridClick) {
+                    gridContainer.onclick = handleGridClick;
+                }
+            } else {
+                viewContent.innerHTML = contentHtml + '<div class="grid-container" id="grid-view" onclick="handleGridClick(event)">' + gridHtml + '</div>';
+            }
+            break;
+
+        case 'STATS':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderStats(data.stats) + '</div>';
+            break;
+
+        case 'EVENTS':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + (tickRange ? renderRangeEvents() : renderEvents(data.events)) + '</div>';
+            break;
+
+        case 'POPULATIONS':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderPopulations(data.populations) + '</div>';
+            break;
+
+        case 'COMMUNICATION':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderCommunication(data.communication, inTickRange(data.communication_history)) + '</div>';
+            break;
+
+        case 'CIVILIZATION':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderCivilization(data.civilization) + '</div>';
+            break;
+
+        case 'PHYSICS':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderPhysics(data.physics, inTickRange(data.physics_history)) + '</div>';
+            break;
+
+        case 'WIND':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderWind(data.wind) + '</div>';
+            break;
+
+        case 'SPECIES':
+            refreshSpecies();
+            viewContent.innerHTML = contentHtml + '<div class="stats-section" id="species-container">' + renderSpecies() + '</div>';
+            break;
+
+        case 'NETWORK':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderNetwork(data.network) + '</div>';
+            break;
+
+        case 'DNA':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderDNA(data.dna) + '</div>';
+            break;
+
+        case 'CELLULAR':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderCellular(data.cellular) + '</div>';
+            break;
+
+        case 'EVOLUTION':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderEvolution(data.evolution) + '</div>';
+            break;
+
+        case 'TOPOLOGY':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderTopology(data.topology) + '</div>';
+            break;
+
+        case 'TOOLS':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderTools(data.tools) + '</div>';
+            break;
+
+        case 'ENVIRONMENT':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderEnvironment(data.environmental_mod, data.environmental_pressures) + '</div>';
+            break;
+
+        case 'BEHAVIOR':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderBehavior(data.emergent_behavior) + '</div>';
+            break;
+
+        case 'REPRODUCTION':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderReproduction(data.reproduction) + '</div>';
+            break;
+
+        case 'STATISTICAL':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderStatistical(data.statistical) + '</div>';
+            break;
+
+        case 'ECOSYSTEM':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderEcosystem(data.ecosystem) + '</div>';
+            break;
+
+        case 'ANOMALIES':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderAnomalies(data.anomalies) + '</div>';
+            break;
+
+        case 'WARFARE':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderWarfare(data.warfare) + '</div>';
+            break;
+
+        case 'FUNGAL':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderFungal(data.fungal) + '</div>';
+            break;
+
+        case 'CULTURAL':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderCultural(data.cultural) + '</div>';
+            break;
+
+        case 'SYMBIOTIC':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderSymbiotic(data.symbiotic_relationships) + '</div>';
+            break;
+
+        case 'BIORHYTHM':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderBiorhythm(data.biorhythm) + '</div>';
+            break;
+
+        case 'NEURAL':
+            viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderNeural(data.neural) + '</div>';
+            break;
+
+        case 'TIMELINE':
+            refreshTimeline();
+            // Re-rendering mid-drag would drop the brush being drawn
+            if (!timelineDrag) {
+                viewContent.innerHTML = contentHtml + '<div class="stats-section" id="timeline-container">' + renderTimeline() + '</div>';
+            }
+            break;
+
+        case 'LANDSCAPE':
+            landscapeSpecies = Array.from(new Set(data.populations.map(pop => pop.species))).sort();
+            refreshLandscape();
+            // Re-rendering would close a trait menu being chosen from
+            if (!isChoosingLandscape()) {
+                viewContent.innerHTML = contentHtml + '<div class="stats-section" id="landscape-container">' + renderLandscape() + '</div>';
+            }
+            break;
+
+        case 'RESOURCES':
+            refreshResources();
+            // Re-rendering would take the focus from a limit being typed
+            if (!isEditingResources()) {
+                viewContent.innerHTML = contentHtml + '<div class="stats-section" id="resources-container">' + renderResources() + '</div>';
+            }
+            break;
+
+        case 'DEMOGRAPHICS':
+            refreshDemographics();
+            viewContent.innerHTML = contentHtml + '<div class="stats-section" id="demographics-container">' + renderDemographics() + '</div>';
+            break;
+
+        case 'MIGRATION':
+            refreshMigration();
+            viewContent.innerHTML = contentHtml + '<div class="stats-section" id="migration-container">' + renderMigration() + '</div>';
+            break;
+
+        default:
+            viewContent.innerHTML = contentHtml + '<div class="stats-section"><h3>' + currentView + '</h3><p>View not yet implemented</p></div>';
+    }
+
+    applyHighlight();
+}
+
+// Render grid view with rich graphics
+function renderGrid(grid, shape) {
+    if (!grid || grid.length === 0) {
+        return '<div>No grid data available</div>';
+    }
+
+    const hex = shape === 'hex';
+    // The followed creature's recent path, true for the cell it stands in now
+    const followTrail = {};
+    if (followTrack) {
+        followTrack.trail.forEach((point, i) => {
+            followTrail[point.x + ',' + point.y] = followTrack.alive && i === followTrack.trail.length - 1;
+        });
+    }
+    let result = hex ? '<div class="rich-grid hex-grid">' : '<div class="rich-grid">';
+    for (let y = 0; y < grid.length; y++) {
+        // Hex rows alternate by their place in the world, not in the viewport
+        const oddRow = hex && grid[y].length > 0 && grid[y][0].grid_y % 2 === 1;
+        result += oddRow ? '<div class="grid-row hex-offset">' : '<div class="grid-row">';
+        for (let x = 0; x < grid[y].length; x++) {
+            const cell = grid[y][x];
+            let cellClass = 'grid-cell ';
+            let cellContent = '';
+
+            // Determine biome background
+            cellClass += getBiomeClass(cell.biome);
+            if (cell.fog) {
+                cellClass += ' fog-' + cell.fog;
+            }
+            let cellStyle = '';
+            if (nightShading && !cell.fog && cell.light < 1) {
+                cellStyle = ' style="filter: brightness(' + (0.35 + 0.65 * cell.light).toFixed(2) + ')"';
+            }
+
+            // Determine content (entities take priority over plants over biome)
+            if (cell.fog === 'hidden') {
+                cellContent = '?';
+            } else if (cell.entity_count > 0) {
+                cellClass += ' ' + getEntityClass(cell.entity_symbol);
+                cellContent = getEntityDisplay(cell.entity_symbol, cell.entity_count);
+                const look = entityLookStyle(cell);
+                if (cell.entity_count === 1 && cell.entity_species) {
+                    cellContent = '<img class="entity-sprite" src="' + speciesSpriteURL(cell.entity_species) + '" alt="' + cellContent + '"' + look.sprite + '>';
+                } else {
+                    cellContent = '<span class="entity-glyph"' + look.glyph + '>' + cellContent + '</span>';
+                }
+            } else if (cell.plant_count > 0) {
+                cellClass += ' ' + getPlantClass(cell.plant_symbol);
+                cellContent = getPlantDisplay(cell.plant_symbol, cell.plant_count);
+            } else {
+                cellContent = getBiomeDisplay(cell.biome_symbol);
+            }
+
+            // Ensure we always have some content
+            if (!cellContent || cellContent.trim() === '') {
+                cellContent = '.'; // Default fallback
+            }
+
+            // Add special indicators
+            if (cell.has_event) {
+                cellClass += ' has-event';
+                cellContent += '<span class="event-overlay">' + escapeHTML(cell.event_symbol || '⚡') + '</span>';
+            }
+
+            const key = cell.grid_x + ',' + cell.grid_y;
+            if (key in groupCells) {
+                cellClass += groupCells[key] ? ' group-member selected-group' : ' group-member';
+            }
+            if (inBoxSelection(cell.grid_x, cell.grid_y)) {
+                cellClass += ' box-selecting';
+            }
+            if (moveTargetCell && moveTargetCell.x === cell.grid_x && moveTargetCell.y === cell.grid_y) {
+                cellClass += ' group-target';
+            }
+            if (inspectorData && inspectorData.grid_x === cell.grid_x && inspectorData.grid_y === cell.grid_y) {
+                cellClass += ' inspected';
+            }
+            if (key in followTrail) {
+                cellClass += followTrail[key] ? ' followed' : ' breadcrumb';
+            }
+
+            result += '<span class="' + cellClass + '"' + cellStyle + ' data-gx="' + cell.grid_x + '" data-gy="' + cell.grid_y + '" title="' + getCellTooltip(cell) + '">' + cellContent + '</span>';
+        }
+        result += '</div>';
+    }
+    result += '</div>';
+    return result + renderTraitLegend();
+}
+
+// entityLookStyle sizes a cell's creatures by their size trait and tints them in the
+// chosen trait colour scheme: style attributes for a sprite and for a text glyph
+function entityLookStyle(cell) {
+    const scale = cell.entity_scale || 1;
+    const color = traitColoring !== 'species' && cell.entity_colors ? cell.entity_colors[traitColoring] : '';
+    const transform = 'transform: scale(' + scale.toFixed(2) + ');';
+    return {
+        sprite: ' style="' + transform + (color ? ' filter: drop-shadow(0 0 2px ' + color + ') drop-shadow(0 0 1px ' + color + ');' : '') + '"',
+        glyph: ' style="' + transform + (color ? ' color: ' + color + ';' : '') + '"'
+    };
+}
+
+function setTraitColoring(scheme) {
+    traitColoring = scheme;
+    localStorage.setItem('evosim-trait-coloring', scheme); // The grid picks it up on the next update
+}
+
+// loadTraitLegend fetches how creatures are sized and coloured and offers its colour
+// schemes in the grid controls
+function loadTraitLegend() {
+    registryRequest('api/traits/legend').then(function(legend) {
+        traitLegend = legend;
+        const select = document.getElementById('trait-coloring');
+        select.innerHTML = legend.colors.map(scheme =>
+            '<option value="' + escapeHTML(scheme.name) + '" title="' + escapeHTML(scheme.description) + '">' + escapeHTML(scheme.label) + '</option>').join('');
+        if (!legend.colors.some(scheme => scheme.name === traitColoring)) {
+            traitColoring = legend.colors.length > 0 ? legend.colors[0].name : 'species';
+        }
+        select.value = traitColoring;
+    }).catch(function(error) {
+        console.error('Failed to load the trait legend:', error);
+    });
+}
+
+function renderTraitLegend() {
+    if (!traitLegend) {
+        return '';
+    }
+    const scale = traitLegend.scale;
+    let html = '<div class="trait-legend"><span title="' + escapeHTML(scale.trait.description) + '">Size by ' + escapeHTML(scale.trait.name) + ':</span>';
+    scale.stops.forEach(function(stop) {
+        html += ' <span class="legend-stop"><span class="entity-glyph" style="transform: scale(' + stop.scale.toFixed(2) + ')">●</span> ' + escapeHTML(stop.label) + '</span>';
+    });
+    const scheme = traitLegend.colors.find(scheme => scheme.name === traitColoring);
+    if (scheme && scheme.stops.length > 0) {
+        html += ' <span class="legend-divider">|</span> <span title="' + escapeHTML(scheme.description) + '">Colour by ' + escapeHTML(scheme.label.toLowerCase()) + ':</span>';
+        scheme.stops.forEach(function(stop) {
+            html += ' <span class="legend-stop"><span class="legend-swatch" style="background: ' + stop.color + '"></span> ' + escapeHTML(stop.label) + '</span>';
+        });
+    }
+    return html + '</div>';
+}
+
+// loadGridLegend fetches the symbols the server draws the grid with, so the grid and its
+// legend use the same tables
+function loadGridLegend() {
+    registryRequest('api/legend?renderer=web').then(function(legends) {
+        gridSymbols = {};
+        legends[0].sections.forEach(function(section) {
+            const lookup = {section: section, byKey: {}, bySymbol: {}};
+            section.entries.forEach(function(entry) {
+                lookup.byKey[entry.key] = entry;
+                if (entry.symbol) {
+                    lookup.bySymbol[entry.symbol] = entry;
+                }
+            });
+            gridSymbols[section.name] = lookup;
+        });
+        document.getElementById('legend-content').innerHTML = renderGridLegend(legends[0]);
+    }).catch(function(error) {
+        console.error('Failed to load the grid legend:', error);
+    });
+}
+
+// Offer to resume the autosave a crashed or interrupted run left
+function loadAutosaveOffer() {
+    registryRequest('api/autosave').then(showAutosaveOffer).catch(function(error) {
+        console.error('Failed to load autosaves:', error);
+    });
+}
+
+function showAutosaveOffer(status) {
+    const offer = document.getElementById('autosave-offer');
+    if (!status.recovery) {
+        offer.style.display = 'none';
+        return;
+    }
+    const recovery = status.recovery;
+    const ended = recovery.status === 'running' ? 'did not shut down cleanly' : 'was interrupted';
+    document.getElementById('autosave-offer-text').textContent = 'The last run ' + ended + '. Resume its autosave from tick ' +
+        recovery.tick + ' (' + new Date(recovery.saved_at).toLocaleString() + ')?';
+    offer.style.display = 'block';
+}
+
+// Resume the autosave on offer, or decline it and keep the current world
+function resumeAutosave(resume) {
+    registryRequest('api/autosave/resume', {method: resume ? 'POST' : 'DELETE'}).then(function(status) {
+        showAutosaveOffer(status);
+        if (resume) {
+            showToast('💾 Autosave', 'Resumed the last run', 'medium');
+        }
+    }).catch(function(error) {
+        showToast('💾 Autosave', 'Could not resume: ' + error.message, 'high');
+    });
+}
+
+function renderGridLegend(legend) {
+    return legend.sections.map(function(section) {
+        return '<strong>' + escapeHTML(section.label) + ':</strong><br>' + section.entries.map(function(entry) {
+            const symbol = entry.glyph || entry.symbol;
+            const style = entry.class ? '' : (entry.color ? ' style="color: ' + escapeHTML(entry.color) + '"' : '');
+            return '<span class="legend-entry ' + escapeHTML(entry.class || '') + '"' + style + '>' + escapeHTML(symbol) + '</span> ' + escapeHTML(entry.label);
+        }).join(' | ');
+    }).join('<br><br>');
+}
+
+// gridSymbol finds a grid legend entry by key or symbol, once the legend has loaded
+function gridSymbol(section, index, value) {
+    if (!gridSymbols || !gridSymbols[section]) {
+        return null;
+    }
+    return gridSymbols[section][index][value] || null;
+}
+
+function getBiomeClass(biome) {
+    const entry = gridSymbol('biomes', 'byKey', biome);
+    return entry ? entry.class : 'biome-plains';
+}
+
+function getEntityClass(symbol) {
+    const entry = gridSymbol('entities', 'bySymbol', symbol);
+    return entry && entry.class ? entry.class : 'entity-generic';
+}
+
+function getPlantClass(symbol) {
+    const entry = gridSymbol('plants', 'bySymbol', symbol);
+    return entry ? entry.class : 'plant-grass';
+}
+
+function getEntityDisplay(symbol, count) {
+    if (count === 1) {
+        // Single creatures are drawn by their species' glyph
+        const entry = gridSymbol('entities', 'bySymbol', symbol);
+        return entry && entry.glyph ? entry.glyph : symbol;
+    } else {
+        // Use count for multiple entities
+        return count < 10 ? count.toString() : '+';
+    }
+}
+
+function getPlantDisplay(symbol, count) {
+    const entry = gridSymbol('plants', 'bySymbol', symbol);
+    return entry && entry.glyph ? entry.glyph : symbol;
+}
+
+function getBiomeDisplay(symbol) {
+    // Show biome symbols for empty cells
+    return symbol || '.';
+}
+
+function getCellTooltip(cell) {
+    if (cell.fog === 'hidden') {
+        return 'Unexplored';
+    }
+    let tooltip = 'Biome: ' + cell.biome;
+    if (cell.fog === 'remembered') {
+        tooltip += ' (explored, not currently seen)';
+    }
+    if (cell.entity_count > 0) {
+        tooltip += ', Entities: ' + cell.entity_count;
+    }
+    if (cell.plant_count > 0) {
+        tooltip += ', Plants: ' + cell.plant_count;
+    }
+    if (cell.has_event) {
+        tooltip += ', Event Active';
+    }
+    if (cell.light !== undefined && !cell.fog) {
+        tooltip += ', Daylight: ' + Math.round(cell.light * 100) + '%';
+    }
+    return tooltip;
+}
+
+// Render stats view with enhanced information
+function renderStats(stats) {
+    let html = '<h3>📊 World Statistics</h3>';
+
+    html += '<h4>General Stats:</h4>';
+
+    // Enhanced stat display with tooltips for key metrics
+    const statTooltips = {
+        'avg_fitness': 'Average fitness across all entities. Higher values (0.7+) indicate a thriving population, lower values (0.3-) suggest environmental stress or poor adaptation.',
+        'avg_energy': 'Average energy level of all entities. Low energy suggests food scarcity or high metabolic demands.',
+        'avg_age': 'Average age of entities. Higher ages indicate good survival conditions and low mortality.',
+        'avg_age_percent': 'Average age as percentage of maximum lifespan. Values over 50% suggest good longevity.',
+        'total_entities': 'Total number of living entities in the simulation.',
+        'total_plants': 'Total number of plants providing food and oxygen to the ecosystem.',
+        'genetic_diversity': 'Measure of genetic variation. Higher diversity (0.7+) improves population resilience.',
+        'mutation_rate': 'Current rate of genetic mutations. Increases under environmental stress.',
+        'birth_rate': 'Number of new entities born recently. High rates indicate reproductive success.',
+        'death_rate': 'Number of entity deaths recently. High rates may indicate environmental challenges.'
+    };
+
+    for (const [key, value] of Object.entries(stats)) {
+        const displayKey = key.replace(/_/g, ' ').replace(/\b\w/g, l => l.toUpperCase());
+        const displayValue = typeof value === 'number' ? value.toFixed(2) : value;
+        const tooltip = statTooltips[key];
+
+        if (tooltip) {
+            html += '<div class="stat-item tooltip">' + displayKey + ': <strong>' + displayValue + '</strong><span class="tooltiptext">' + tooltip + '</span></div>';
+        } else {
+            html += '<div class="stat-item">' + displayKey + ': <strong>' + displayValue + '</strong></div>';
+        }
+    }
+
+    html += '<h4>System Health:</h4>';
+    if (stats.avg_fitness !== undefined) {
+        if (stats.avg_fitness < 0.3) {
+            html += '<div style="color: orange;" class="tooltip">⚠️ Low average fitness - population struggling<span class="tooltiptext">Population fitness is below 0.3, indicating severe environmental stress, poor food availability, or inadequate genetic adaptation. Consider environmental modifications or population interventions.</span></div>';
+        } else if (stats.avg_fitness < 0.6) {
+            html += '<div style="color: yellow;" class="tooltip">⚡ Moderate fitness - population stable<span class="tooltiptext">Population fitness is between 0.3-0.6, indicating stable but not optimal conditions. Population can survive but may benefit from environmental improvements.</span></div>';
+        } else {
+            html += '<div style="color: lightgreen;" class="tooltip">✅ High fitness - population thriving<span class="tooltiptext">Population fitness is above 0.6, indicating excellent adaptation to environment. Population is healthy and reproducing successfully.</span></div>';
+        }
+    }
+
+    if (stats.avg_energy !== undefined) {
+        if (stats.avg_energy < 30) {
+            html += '<div style="color: orange;">⚠️ Low energy levels - resource scarcity</div>';
+        } else if (stats.avg_energy < 60) {
+            html += '<div style="color: yellow;">⚡ Moderate energy - adequate resources</div>';
+        } else {
+            html += '<div style="color: lightgreen;">✅ High energy - abundant resources</div>';
+        }
+    }
+
+    // Enhanced ecosystem analysis
+    html += '<h4>🌍 Ecosystem Analysis:</h4>';
+    const fitnessEnergy = (stats.avg_fitness || 0) * (stats.avg_energy || 0) / 100;
+    if (fitnessEnergy < 0.2) {
+        html += '<div style="color: red;">🔥 Critical ecosystem stress</div>';
+    } else if (fitnessEnergy < 0.5) {
+        html += '<div style="color: orange;">⚠️ Ecosystem under pressure</div>';
+    } else if (fitnessEnergy < 0.8) {
+        html += '<div style="color: yellow;">⚡ Stable ecosystem</div>';
+    } else {
+        html += '<div style="color: lightgreen;">🌟 Thriving ecosystem</div>';
+    }
+
+    html += '<h4>📈 History:</h4>';
+    const worldMetrics = {population: 'Creatures', plants: 'Plants', fitness: 'Average fitness', energy: 'Average energy'};
+    const worldLabel = metric => worldMetrics[metric];
+    html += renderHistoryChart('world-counts', 'Creatures and plants', ['population', 'plants'], worldLabel);
+    html += renderHistoryChart('world-fitness', 'Average fitness', ['fitness'], worldLabel);
+    html += renderHistoryChart('world-energy', 'Average energy', ['energy'], worldLabel);
+
+    return html;
+}
+
+// Time-lapse charts of the server's metric history. Each chart is fetched at most once a
+// second and drawn from its cache on every update.
+const historyColors = ['#4CAF50', '#2196F3', '#FF9800', '#E91E63', '#9C27B0', '#00BCD4', '#FFEB3B', '#795548'];
+const historyChartLayout = {width: 600, height: 150, left: 50, right: 10, top: 8, bottom: 18};
+let historyCharts = {};              // Chart id -> {url, data, fetching, fetchedAt}
+let historyTraitPopulation = null;   // Population whose traits are charted in the POPULATIONS view
+
+function chartPopulationTraits(name) {
+    historyTraitPopulation = name;
+}
+
+function historyURL(metrics, range) {
+    let url = 'api/history?points=300&metrics=' + encodeURIComponent(metrics.join(','));
+    if (range) {
+        url += '&from=' + range.from + '&to=' + range.to;
+    }
+    return url;
+}
+
+function refreshHistoryChart(id, url) {
+    let chart = historyCharts[id];
+    if (!chart || chart.url !== url) {
+        chart = historyCharts[id] = {url: url, data: chart ? chart.data : null, fetching: false, fetchedAt: 0};
+    }
+    if (chart.fetching || Date.now() - chart.fetchedAt < 1000) {
+        return chart.data;
+    }
+    chart.fetching = true;
+    fetch(url)
+        .then(response => response.json())
+        .then(result => { chart.data = result; })
+        .catch(error => console.error('Failed to load metric history:', error))
+        .finally(() => {
+            chart.fetching = false;
+            chart.fetchedAt = Date.now();
+        });
+    return chart.data;
+}
+
+// Renders a line chart of the metrics, naming each line with label(metric)
+function renderHistoryChart(id, title, metrics, label, range) {
+    const data = refreshHistoryChart(id, historyURL(metrics, range));
+    let html = '<h5>' + title + '</h5>';
+    if (!data) {
+        return html + '<div style="color: #aaa;">Loading history...</div>';
+    }
+    const series = data.series.filter(line => line.ticks.length > 0);
+    if (series.length === 0) {
+        return html + '<div style="color: #aaa;">No history recorded yet</div>';
+    }
+    return html + drawLineChart(series, label);
+}
+
+function formatChartValue(value) {
+    return Math.abs(value) >= 100 ? value.toFixed(0) : value.toFixed(2);
+}
+
+function drawLineChart(series, label) {
+    const layout = historyChartLayout;
+    let minTick = Infinity, maxTick = -Infinity, minValue = Infinity, maxValue = -Infinity;
+    series.forEach(line => {
+        minTick = Math.min(minTick, line.ticks[0]);
+        maxTick = Math.max(maxTick, line.ticks[line.ticks.length - 1]);
+        line.values.forEach(value => {
+            minValue = Math.min(minValue, value);
+            maxValue = Math.max(maxValue, value);
+        });
+    });
+    if (maxValue - minValue < 1e-9) {
+        const pad = Math.abs(maxValue) * 0.1 || 1;
+        minValue -= pad;
+        maxValue += pad;
+    }
+    const plotWidth = layout.width - layout.left - layout.right;
+    const plotHeight = layout.height - layout.top - layout.bottom;
+    const x = tick => layout.left + (maxTick > minTick ? (tick - minTick) / (maxTick - minTick) * plotWidth : plotWidth);
+    const y = value => layout.top + (maxValue - value) / (maxValue - minValue) * plotHeight;
+
+    let svg = '<svg width="100%" viewBox="0 0 ' + layout.width + ' ' + layout.height + '" style="max-width: ' + layout.width + 'px; background: #2a2a2a; border-radius: 3px;">';
+    svg += '<line x1="' + layout.left + '" y1="' + layout.top + '" x2="' + layout.left + '" y2="' + (layout.top + plotHeight) + '" stroke="#666"/>';
+    svg += '<line x1="' + layout.left + '" y1="' + (layout.top + plotHeight) + '" x2="' + (layout.width - layout.right) + '" y2="' + (layout.top + plotHeight) + '" stroke="#666"/>';
+    svg += '<text x="' + (layout.left - 4) + '" y="' + (layout.top + 8) + '" fill="#aaa" font-size="10" text-anchor="end">' + formatChartValue(maxValue) + '</text>';
+    svg += '<text x="' + (layout.left - 4) + '" y="' + (layout.top + plotHeight) + '" fill="#aaa" font-size="10" text-anchor="end">' + formatChartValue(minValue) + '</text>';
+    svg += '<text x="' + layout.left + '" y="' + (layout.height - 4) + '" fill="#aaa" font-size="10">Tick ' + minTick + '</text>';
+    svg += '<text x="' + (layout.width - layout.right) + '" y="' + (layout.height - 4) + '" fill="#aaa" font-size="10" text-anchor="end">Tick ' + maxTick + '</text>';
+
+    let legend = '<div style="font-size: 0.8em; margin-bottom: 8px;">';
+    series.forEach((line, i) => {
+        const color = historyColors[i % historyColors.length];
+        const name = escapeHTML(label(line.metric));
+        const points = line.ticks.map((tick, j) => x(tick).toFixed(1) + ',' + y(line.values[j]).toFixed(1)).join(' ');
+        svg += '<polyline points="' + points + '" fill="none" stroke="' + color + '" stroke-width="1.5"><title>' + name + '</title></polyline>';
+        legend += '<span style="margin-right: 12px;"><span style="color: ' + color + ';">■</span> ' + name + ': ' +
+            formatChartValue(line.values[line.values.length - 1]) + '</span>';
+    });
+    return svg + '</svg>' + legend + '</div>';
+}
+
+// Track previous population data for stable ordering
+let previousPopulations = [];
+let populationUpdateIndicators = {};
+
+// Render populations view with stable ordering and historical data
+function renderPopulations(populations) {
+    let html = '<h3>👥 Population Details</h3>';
+
+    // Sort populations by name for stable ordering
+    const sortedPopulations = [...populations].sort((a, b) => a.name.localeCompare(b.name));
+
+    // Track which populations have changed
+    const currentTime = Date.now();
+    sortedPopulations.forEach(pop => {
+        const prevPop = previousPopulations.find(p => p.name === pop.name);
+        if (prevPop && (
+            prevPop.count !== pop.count ||
+            Math.abs(prevPop.avg_fitness - pop.avg_fitness) > 0.01 ||
+            Math.abs(prevPop.avg_energy - pop.avg_energy) > 0.01
+        )) {
+            populationUpdateIndicators[pop.name] = currentTime;
+        }
+    });
+
+    sortedPopulations.forEach(pop => {
+        const isUpdating = populationUpdateIndicators[pop.name] &&
+                         (currentTime - populationUpdateIndicators[pop.name]) < 2000; // Show indicator for 2 seconds
+
+        html += '<div class="population-item' + (isUpdating ? ' updating' : '') + '">';
+        html += '<h4>' + speciesLink(pop.species || pop.name) + (isUpdating ? ' <span class="update-indicator">●</span>' : '') + '</h4>';
+        html += '<div class="tooltip">Count: <strong>' + pop.count + '</strong><span class="tooltiptext">Current number of living entities in this population. Sudden drops may indicate environmental stress or predation.</span></div>';
+        html += '<div class="tooltip">Average Fitness: <strong>' + pop.avg_fitness.toFixed(2) + '</strong><span class="tooltiptext">Population fitness level (0-1). Values above 0.6 indicate good adaptation, below 0.3 suggests population stress.</span></div>';
+        html += '<div class="tooltip">Average Energy: <strong>' + pop.avg_energy.toFixed(2) + '</strong><span class="tooltiptext">Average energy level (0-1). Low values may indicate food scarcity or high metabolic demands from environmental stress.</span></div>';
+        html += '<div class="tooltip">Average Age: <strong>' + pop.avg_age.toFixed(1) + '</strong><span class="tooltiptext">Average age in simulation ticks. Higher ages indicate good survival conditions and successful adaptation to environment.</span></div>';
+
+        if (pop.trait_averages && Object.keys(pop.trait_averages).length > 0) {
+            html += '<h5>Average Traits:</h5>';
+            Object.entries(pop.trait_averages).forEach(([trait, value]) => {
+                html += '<div style="font-size: 0.9em; margin-left: 10px;">' +
+                       trait + ': ' + value.toFixed(3) + '</div>';
+            });
+            html += '<button onclick="chartPopulationTraits(' + escapeHTML(JSON.stringify(pop.name)) + ')">📈 Trait history</button>';
+        }
+        html += '</div>';
+    });
+
+    // Time-lapse charts of every population, limited to the range brushed on the timeline
+    html += '<h4>📈 Population History' + (tickRange ? ' (ticks ' + tickRange.from + '–' + tickRange.to + ')' : '') + ':</h4>';
+    const populationName = metric => metric.split(':')[1];
+    html += renderHistoryChart('population-sizes', 'Population size', ['population:*'], populationName, tickRange);
+    html += renderHistoryChart('population-fitness', 'Average fitness', ['fitness:*'], populationName, tickRange);
+    html += renderHistoryChart('population-energy', 'Average energy', ['energy:*'], populationName, tickRange);
+    if (historyTraitPopulation) {
+        html += renderHistoryChart('population-traits', 'Average traits of ' + escapeHTML(historyTraitPopulation) +
+            ' <button onclick="chartPopulationTraits(null)">Close</button>',
+            ['trait:' + historyTraitPopulation + ':*'], metric => metric.split(':').slice(2).join(':'), tickRange);
+    }
+
+    // Update previous populations for next comparison
+    previousPopulations = [...sortedPopulations];
+
+    return html;
+}
+
+// Render communication view with historical data
+function renderCommunication(comm, commHistory = []) {
+    let html = '<h3>📡 Communication System</h3>';
+    html += '<h4>Active Signals:</h4>';
+    html += '<div>Total Active: ' + comm.active_signals + '</div>';
+
+    if (Object.keys(comm.signal_types).length > 0) {
+        html += '<h4>Signal Types:</h4>';
+        const signalIcons = {
+            'Danger': '🚨',
+            'Food': '🍎',
+            'Mating': '💕',
+            'Territory': '🏴',
+            'Help': '🆘',
+            'Migration': '🧭'
+        };
+
+        for (const [type, count] of Object.entries(comm.signal_types)) {
+            const icon = signalIcons[type] || '📡';
+            html += '<div>' + icon + ' ' + type + ': ' + count + ' active</div>';
+        }
+    } else {
+        html += '<div>No active signals</div>';
+    }
+
+    html += '<h4>Communication Stats:</h4>';
+    if (comm.active_signals === 0) {
+        html += '<div>Activity Level: Silent</div>';
+    } else if (comm.active_signals < 5) {
+        html += '<div>Activity Level: Low communication</div>';
+    } else if (comm.active_signals < 15) {
+        html += '<div>Activity Level: Moderate communication</div>';
+    } else {
+        html += '<div>Activity Level: High communication</div>';
+    }
+
+    // Add historical data if available
+    if (commHistory && commHistory.length > 0) {
+        html += '<h4>📈 Communication History (' + describeHistory(commHistory) + '):</h4>';
+        html += '<div style="max-height: 200px; overflow-y: auto;">';
+        commHistory.slice(-10).forEach(snapshot => {
+            html += '<div style="margin: 5px 0; padding: 5px; background-color: #444; border-radius: 3px;">';
+            html += '<strong>Tick ' + snapshot.tick + '</strong> (' + snapshot.timestamp + ')<br>';
+            html += '<span style="font-size: 0.8em;">Active Signals: ' + snapshot.active_signals + '</span><br>';
+            if (snapshot.signal_types && Object.keys(snapshot.signal_types).length > 0) {
+                html += '<span style="font-size: 0.7em;">Types: ';
+                const types = [];
+                for (const [type, count] of Object.entries(snapshot.signal_types)) {
+                    types.push(type + ': ' + count);
+                }
+                html += types.join(', ') + '</span>';
+            }
+            html += '</div>';
+        });
+        html += '</div>';
+    }
+
+    return html;
+}
+
+// Render events view
+function renderEvents(events) {
+    let html = '<h3>🌪️ World Events & Event Log</h3>';
+
+    // Separate active and historical events
+    const activeEvents = events.filter(event => event.type === 'active');
+    const historicalEvents = events.filter(event => event.type === 'historical');
+
+    // Active Events Section
+    html += '<h4>Active Events:</h4>';
+    if (activeEvents.length === 0) {
+        html += '<div>No active events</div>';
+    } else {
+        activeEvents.forEach(event => {
+            html += '<div class="event-item">';
+            html += '<strong>' + event.name + '</strong><br>';
+            html += event.description + '<br>';
+            html += '<small>Duration: ' + event.duration + ' ticks remaining</small>';
+            html += '</div>';
+        });
+    }
+
+    // Historical Events Section
+    html += '<h4>Recent History:</h4>';
+    if (historicalEvents.length === 0) {
+        html += '<div>No historical events recorded</div>';
+    } else {
+        historicalEvents.forEach(event => {
+            html += '<div class="event-item" style="border-left-color: #888;">';
+            html += '<strong>' + event.name + '</strong> ';
+            html += '<small style="color: #aaa;">(' + event.timestamp + ')</small><br>';
+            html += event.description + '<br>';
+            html += '<small>' + tickLink(event.tick) + '</small>';
+            html += '</div>';
+        });
+    }
+
+    return html;
+}
+
+// Timeline state lives outside the view so it survives the re-render on every update
+const tickRangeViews = ['EVENTS', 'POPULATIONS', 'COMMUNICATION', 'PHYSICS'];
+const timelineLayout = {width: 1000, labelWidth: 120, laneHeight: 22, markerHeight: 26, axisHeight: 20};
+let timelineZoom = null;   // {from, to} shown by the timeline, or null for the whole run
+let tickRange = null;      // {from, to} brushed on the timeline; filters tickRangeViews
+let timelineData = null;
+let timelineFetchedAt = 0;
+let timelineFetching = false;
+let timelineDrag = null;   // {startTick, endTick} while brushing
+let rangeEvents = null;    // Events inside tickRange, fetched for the EVENTS view
+let lastTick = 0;
+
+function escapeHTML(text) {
+    return String(text).replace(/&/g, '&amp;').replace(/</g, '&lt;').replace(/>/g, '&gt;').replace(/"/g, '&quot;');
+}
+
+// Older history is downsampled, so a few snapshots can reach back to the start of the run
+function describeHistory(history) {
+    const first = history[0].samples > 1 ? (history[0].first_tick || 0) : history[0].tick;
+    return history.length + ' snapshots since tick ' + first + ', latest 10 shown';
+}
+
+function inTickRange(snapshots) {
+    if (!tickRange || !snapshots) {
+        return snapshots;
+    }
+    return snapshots.filter(snapshot => snapshot.tick >= tickRange.from && snapshot.tick <= tickRange.to);
+}
+
+function renderTickRangeBanner() {
+    return '<div class="event-item" style="border-left-color: #2196F3;">Showing ticks ' + tickRange.from + '–' + tickRange.to +
+        ' selected on the timeline <button onclick="setTickRange(null)">Clear</button></div>';
+}
+
+function setTickRange(range) {
+    tickRange = range;
+    rangeEvents = null;
+    if (range) {
+        fetch('api/timeline?buckets=1&events=100&from=' + range.from + '&to=' + range.to)
+            .then(response => response.json())
+            .then(result => { rangeEvents = result.events || []; })
+            .catch(error => console.error('Failed to load events in range:', error));
+    }
+    redrawTimeline();
+}
+
+function renderRangeEvents() {
+    let html = '<h3>🌪️ Events in Ticks ' + tickRange.from + '–' + tickRange.to + '</h3>';
+    if (rangeEvents === null) {
+        return html + '<div>Loading events...</div>';
+    }
+    if (rangeEvents.length === 0) {
+        return html + '<div>No events recorded in this range</div>';
+    }
+    html += '<div><small>Most recent ' + rangeEvents.length + ' events in the range</small></div>';
+    rangeEvents.slice().reverse().forEach(event => {
+        html += '<div class="event-item">';
+        html += '<strong>' + escapeHTML(event.type) + '</strong> <small style="color: #aaa;">(' + event.category + ', ' + event.severity + ')</small><br>';
+        html += escapeHTML(event.description) + '<br>';
+        html += '<small>' + tickLink(event.tick) + '</small>';
+        html += '</div>';
+    });
+    return html;
+}
+
+function refreshTimeline(force) {
+    if (timelineFetching || (!force && Date.now() - timelineFetchedAt < 1000)) {
+        return;
+    }
+    timelineFetching = true;
+    let url = 'api/timeline?buckets=150';
+    if (timelineZoom) {
+        url += '&from=' + timelineZoom.from + '&to=' + timelineZoom.to;
+    }
+    fetch(url)
+        .then(response => response.json())
+        .then(result => {
+            timelineData = result;
+            redrawTimeline();
+        })
+        .catch(error => console.error('Failed to load timeline:', error))
+        .finally(() => {
+            timelineFetching = false;
+            timelineFetchedAt = Date.now();
+        });
+}
+
+function redrawTimeline() {
+    const container = document.getElementById('timeline-container');
+    if (container && currentView === 'TIMELINE' && !timelineDrag) {
+        container.innerHTML = renderTimeline();
+    }
+}
+
+function timelineX(tick) {
+    const span = timelineData.to_tick - timelineData.from_tick + 1;
+    const plotWidth = timelineLayout.width - timelineLayout.labelWidth;
+    return timelineLayout.labelWidth + (tick - timelineData.from_tick) / span * plotWidth;
+}
+
+// Converts a mouse position over the timeline to a tick
+function timelineTickAt(event) {
+    const svg = document.getElementById('timeline-svg');
+    const rect = svg.getBoundingClientRect();
+    const x = (event.clientX - rect.left) * timelineLayout.width / rect.width;
+    const plotWidth = timelineLayout.width - timelineLayout.labelWidth;
+    const span = timelineData.to_tick - timelineData.from_tick + 1;
+    const fraction = Math.min(1, Math.max(0, (x - timelineLayout.labelWidth) / plotWidth));
+    return Math.round(timelineData.from_tick + fraction * (span - 1));
+}
+
+function renderTimeline() {
+    let html = '<h3>🕰️ Event Timeline</h3>';
+    html += '<div style="margin-bottom: 8px;">';
+    html += '<button onclick="zoomTimelineToSelection()"' + (tickRange ? '' : ' disabled') + '>🔍 Zoom to Selection</button> ';
+    html += '<button onclick="zoomTimelineBy(2)">🔍- Zoom Out</button> ';
+    html += '<button onclick="setTimelineZoom(null)">Whole Run</button> ';
+    html += '<button onclick="setTickRange(null)"' + (tickRange ? '' : ' disabled') + '>Clear Selection</button> ';
+    html += tickRange ? '<span>Selected ticks ' + tickRange.from + '–' + tickRange.to + '</span>' : '<span style="color: #aaa;">Drag across the timeline to select a tick range</span>';
+    html += '</div>';
+
+    if (!timelineData) {
+        return html + '<div>Loading timeline...</div>';
+    }
+    if (timelineData.categories.length === 0) {
+        return html + '<div>No events recorded between ticks ' + timelineData.from_tick + ' and ' + timelineData.to_tick + '</div>';
+    }
+
+    const layout = timelineLayout;
+    const lanesTop = layout.markerHeight;
+    const height = lanesTop + timelineData.categories.length * layout.laneHeight + layout.axisHeight;
+    let svg = '<svg id="timeline-svg" viewBox="0 0 ' + layout.width + ' ' + height + '" style="width: 100%; background: #1e1e1e; cursor: crosshair; user-select: none;"' +
+        ' onmousedown="startTimelineBrush(event)" onwheel="zoomTimelineAtCursor(event)">';
+
+    // Notable events above the lanes
+    svg += '<text x="4" y="17" fill="#aaa" font-size="12">notable</text>';
+    timelineData.markers.forEach(marker => {
+        const color = marker.severity === 'critical' ? '#f44336' : '#ff9800';
+        svg += '<circle cx="' + timelineX(marker.tick + 0.5) + '" cy="13" r="5" fill="' + color + '">' +
+            '<title>Tick ' + marker.tick + ' – ' + escapeHTML(marker.type) + ': ' + escapeHTML(marker.description) + '</title></circle>';
+    });
+
+    // One lane per category, shaded by how many events each bucket holds
+    timelineData.categories.forEach((category, lane) => {
+        const y = lanesTop + lane * layout.laneHeight;
+        svg += '<text x="4" y="' + (y + 15) + '" fill="#ddd" font-size="12">' + escapeHTML(category) + '</text>';
+        svg += '<line x1="' + layout.labelWidth + '" y1="' + (y + layout.laneHeight) + '" x2="' + layout.width + '" y2="' + (y + layout.laneHeight) + '" stroke="#333"/>';
+        timelineData.buckets.forEach(bucket => {
+            const count = bucket.counts[category] || 0;
+            if (count === 0) {
+                return;
+            }
+            const x = timelineX(bucket.start_tick);
+            const width = Math.max(1, timelineX(bucket.end_tick + 1) - x);
+            const opacity = 0.15 + 0.85 * count / timelineData.max_count;
+            svg += '<rect x="' + x + '" y="' + (y + 3) + '" width="' + width + '" height="' + (layout.laneHeight - 6) + '" fill="#4CAF50" fill-opacity="' + opacity.toFixed(2) + '">' +
+                '<title>' + escapeHTML(category) + ': ' + count + ' events in ticks ' + bucket.start_tick + '–' + bucket.end_tick + '</title></rect>';
+        });
+    });
+
+    // Tick axis
+    const axisY = height - layout.axisHeight + 14;
+    for (let i = 0; i <= 5; i++) {
+        const tick = Math.round(timelineData.from_tick + i * (timelineData.to_tick - timelineData.from_tick) / 5);
+        const anchor = i === 0 ? 'start' : (i === 5 ? 'end' : 'middle');
+        svg += '<text x="' + timelineX(tick + (i === 5 ? 1 : 0)) + '" y="' + axisY + '" fill="#aaa" font-size="11" text-anchor="' + anchor + '">' + tick + '</text>';
+    }
+
+    // Brushed selection, drawn even when only part of it is in view
+    const brush = timelineDrag ? {from: Math.min(timelineDrag.startTick, timelineDrag.endTick), to: Math.max(timelineDrag.startTick, timelineDrag.endTick)} : tickRange;
+    if (brush && brush.to >= timelineData.from_tick && brush.from <= timelineData.to_tick) {
+        const x = timelineX(Math.max(brush.from, timelineData.from_tick));
+        const width = Math.max(2, timelineX(Math.min(brush.to, timelineData.to_tick) + 1) - x);
+        svg += '<rect id="timeline-brush" x="' + x + '" y="0" width="' + width + '" height="' + (height - layout.axisHeight) + '" fill="#2196F3" fill-opacity="0.2" stroke="#2196F3" pointer-events="none"/>';
+    }
+    svg += '</svg>';
+
+    return html + svg;
+}
+
+function startTimelineBrush(event) {
+    if (!timelineData || event.button !== 0) {
+        return;
+    }
+    event.preventDefault();
+    const tick = timelineTickAt(event);
+    timelineDrag = {startTick: tick, endTick: tick};
+    document.addEventListener('mousemove', moveTimelineBrush);
+    document.addEventListener('mouseup', endTimelineBrush);
+}
+
+function moveTimelineBrush(event) {
+    timelineDrag.endTick = timelineTickAt(event);
+    const container = document.getElementById('timeline-container');
+    if (container) {
+        container.innerHTML = renderTimeline();
+    }
+}
+
+function endTimelineBrush(event) {
+    document.removeEventListener('mousemove', moveTimelineBrush);
+    document.removeEventListener('mouseup', endTimelineBrush);
+    const drag = timelineDrag;
+    timelineDrag = null;
+    drag.endTick = timelineTickAt(event);
+    if (drag.startTick === drag.endTick) {
+        // A click without dragging clears the selection
+        setTickRange(null);
+        return;
+    }
+    setTickRange({from: Math.min(drag.startTick, drag.endTick), to: Math.max(drag.startTick, drag.endTick)});
+}
+
+function setTimelineZoom(zoom) {
+    timelineZoom = zoom;
+    refreshTimeline(true);
+}
+
+function zoomTimelineToSelection() {
+    if (tickRange) {
+        setTimelineZoom({from: tickRange.from, to: tickRange.to});
+    }
+}
+
+// Zooms the timeline by factor (greater than 1 zooms out) around the given tick
+function zoomTimelineBy(factor, centerTick) {
+    if (!timelineData) {
+        return;
+    }
+    const from = timelineData.from_tick;
+    const to = timelineData.to_tick;
+    const center = centerTick === undefined ? (from + to) / 2 : centerTick;
+    const span = Math.max(10, (to - from + 1) * factor);
+    const newFrom = Math.max(0, Math.round(center - (center - from) / (to - from + 1) * span));
+    const newTo = Math.round(newFrom + span - 1);
+    if (newFrom === 0 && newTo >= lastTick) {
+        setTimelineZoom(null);
+    } else {
+        setTimelineZoom({from: newFrom, to: newTo});
+    }
+}
+
+function zoomTimelineAtCursor(event) {
+    if (!timelineData) {
+        return;
+    }
+    event.preventDefault();
+    zoomTimelineBy(event.deltaY < 0 ? 0.8 : 1.25, timelineTickAt(event));
+}
+
+// References between views are plain hash links, so each one is also a shareable deep link:
+// #view/NAME, #species/NAME, #entity/ID, #colony/ID and #ticks/FROM-TO
+let highlightID = null;       // Element kept highlighted across re-renders, e.g. a linked colony
+let highlightScrolled = false;
+
+function refLink(kind, value, label) {
+    return '<a class="ref-link" href="#' + kind + '/' + encodeURIComponent(value) + '">' + escapeHTML(label === undefined ? value : label) + '</a>';
+}
+
+function speciesLink(name) {
+    return refLink('species', name);
+}
+
+function entityLink(id) {
+    return refLink('entity', id, 'Entity #' + id);
+}
+
+function colonyLink(id) {
+    return refLink('colony', id, 'Colony #' + id);
+}
+
+function tickLink(tick, label) {
+    return refLink('ticks', tick + '-' + tick, label === undefined ? 'Tick ' + tick : label);
+}
+
+function navigateTo(hash) {
+    if (window.location.hash === '#' + hash) {
+        applyDeepLink();
+    } else {
+        window.location.hash = hash;
+    }
+}
+
+function applyDeepLink() {
+    const hash = decodeURIComponent(window.location.hash.slice(1));
+    const separator = hash.indexOf('/');
+    if (separator < 0) {
+        return;
+    }
+    const kind = hash.slice(0, separator);
+    const value = hash.slice(separator + 1);
+
+    switch (kind) {
+        case 'view':
+            if (viewModes.includes(value.toUpperCase())) {
+                hideSpeciesDetail();
+                switchView(value.toUpperCase(), true);
+            }
+            break;
+
+        case 'species':
+            switchView('SPECIES', true);
+            showSpeciesDetail(value);
+            break;
+
+        case 'entity':
+            showEntityDetail(parseInt(value, 10));
+            break;
+
+        case 'colony':
+            hideSpeciesDetail();
+            switchView('WARFARE', true);
+            highlightID = 'colony-' + parseInt(value, 10);
+            highlightScrolled = false;
+            break;
+
+        case 'ticks': {
+            const bounds = value.split('-').map(part => parseInt(part, 10));
+            if (isNaN(bounds[0])) {
+                break;
+            }
+            const range = {from: bounds[0], to: isNaN(bounds[1]) ? bounds[0] : bounds[1]};
+            hideSpeciesDetail();
+            switchView('TIMELINE', true);
+            // Show some context around short ranges such as a single event's tick
+            const margin = Math.max(25, range.to - range.from);
+            timelineZoom = {from: Math.max(0, range.from - margin), to: range.to + margin};
+            setTickRange(range);
+            refreshTimeline(true);
+            break;
+        }
+    }
+}
+
+function applyHighlight() {
+    const element = highlightID && document.getElementById(highlightID);
+    if (!element) {
+        return;
+    }
+    element.classList.add('highlighted');
+    if (!highlightScrolled) {
+        element.scrollIntoView({block: 'center'});
+        highlightScrolled = true;
+    }
+}
+
+// Render civilization view
+function renderCivilization(civilization) {
+    let html = '<h3>🏛️ Civilization System</h3>';
+    html += '<div>Active Tribes: ' + civilization.tribes_count + '</div>';
+    html += '<div>Total Structures: ' + civilization.structure_count + '</div>';
+    html += '<div>Total Resources: ' + civilization.total_resources + '</div>';
+
+    if (civilization.tribes_count === 0) {
+        html += '<br><div>No tribes formed yet</div>';
+    } else {
+        html += '<br><h4>Development Status:</h4>';
+        if (civilization.structure_count === 0) {
+            html += '<div>Civilization Level: Primitive</div>';
+        } else if (civilization.structure_count < 5) {
+            html += '<div>Civilization Level: Developing</div>';
+        } else if (civilization.structure_count < 15) {
+            html += '<div>Civilization Level: Advanced</div>';
+        } else {
+            html += '<div>Civilization Level: Highly Advanced</div>';
+        }
+    }
+
+    return html;
+}
+
+// Render physics view with historical data
+function renderPhysics(physics, physicsHistory = []) {
+    let html = '<h3>⚡ Physics System</h3>';
+    html += '<h4>Movement Statistics:</h4>';
+    html += '<div>Average Velocity: ' + physics.average_velocity.toFixed(2) + '</div>';
+    html += '<div>Total Momentum: ' + physics.total_momentum.toFixed(2) + '</div>';
+
+    html += '<h4>Collision Statistics:</h4>';
+    html += '<div>Collisions This Tick: ' + physics.collisions_last_tick + '</div>';
+
+    if (physics.average_velocity < 0.1) {
+        html += '<br><div>Activity Level: Low (mostly stationary entities)</div>';
+    } else if (physics.average_velocity < 0.5) {
+        html += '<br><div>Activity Level: Medium (moderate movement)</div>';
+    } else {
+        html += '<br><div>Activity Level: High (active movement)</div>';
+    }
+
+    // Add historical data if available
+    if (physicsHistory && physicsHistory.length > 0) {
+        html += '<h4>📈 Physics History (' + describeHistory(physicsHistory) + '):</h4>';
+        html += '<div style="max-height: 200px; overflow-y: auto;">';
+        physicsHistory.slice(-10).forEach(snapshot => {
+            html += '<div style="margin: 5px 0; padding: 5px; background-color: #444; border-radius: 3px;">';
+            html += '<strong>Tick ' + snapshot.tick + '</strong> (' + snapshot.timestamp + ')<br>';
+            html += '<span style="font-size: 0.8em;">Collisions: ' + snapshot.collisions +
+                   ', Velocity: ' + snapshot.average_velocity.toFixed(2) +
+                   ', Momentum: ' + snapshot.total_momentum.toFixed(2) + '</span>';
+            html += '</div>';
+        });
+        html += '</div>';
+    }
+
+    return html;
+}
+
+// Setup event delegation for species modal interactions
+let speciesModalEventsSetup = false;
+
+function setupSpeciesModalEvents() {
+    // Only setup once to avoid multiple event listeners
+    if (speciesModalEventsSetup) {
+        return;
+    }
+    speciesModalEventsSetup = true;
+
+    // Use event delegation on the document to handle clicks on species items
+    document.addEventListener('click', function(event) {
+        const speciesItem = event.target.closest('.clickable-species');
+        if (speciesItem) {
+            const speciesName = speciesItem.getAttribute('data-species-name');
+            if (speciesName) {
+                navigateTo('species/' + encodeURIComponent(speciesName));
+            }
+        }
+
+        // Following a link to the current location doesn't fire hashchange, so route it here
+        const link = event.target.closest('.ref-link');
+        if (link && link.getAttribute('href') === window.location.hash) {
+            event.preventDefault();
+            applyDeepLink();
+        }
+    });
+}
+
+// Create modal elements once when needed
+function ensureSpeciesModalExists() {
+    const existingModal = document.getElementById('species-detail-modal');
+    if (!existingModal) {
+        // Create modal
+        const modal = document.createElement('div');
+        modal.id = 'species-detail-modal';
+        modal.style.cssText = 'display: none; position: fixed; top: 50%; left: 50%; transform: translate(-50%, -50%); background-color: #1a1a1a; border: 2px solid #444; border-radius: 10px; padding: 20px; max-width: 80%; max-height: 80%; overflow-y: auto; z-index: 1000;';
+
+        const closeButtonHtml = '<div style="text-align: right;"><button id="species-modal-close" style="background-color: #666; color: white; border: none; padding: 5px 10px; border-radius: 3px; cursor: pointer;">✕ Close</button></div>';
+        const contentHtml = '<div id="species-detail-content"></div>';
+        modal.innerHTML = closeButtonHtml + contentHtml;
+
+        // Create overlay
+        const overlay = document.createElement('div');
+        overlay.id = 'species-modal-overlay';
+        overlay.style.cssText = 'display: none; position: fixed; top: 0; left: 0; width: 100%; height: 100%; background-color: rgba(0,0,0,0.7); z-index: 999;';
+
+        // Add close event listeners
+        const closeButton = modal.querySelector('#species-modal-close');
+        closeButton.addEventListener('click', closeDetailModal);
+        overlay.addEventListener('click', closeDetailModal);
+
+        // Add to body
+        document.body.appendChild(modal);
+        document.body.appendChild(overlay);
+    }
+}
+
+// Species view state, fetched separately from the view data like the resources
+let speciesProfiles = null;
+let speciesFetchedAt = 0;
+let speciesFetching = false;
+
+function refreshSpecies() {
+    if (speciesFetching || Date.now() - speciesFetchedAt < 1000) {
+        return;
+    }
+    speciesFetching = true;
+    registryRequest('api/species')
+        .then(result => {
+            speciesProfiles = result;
+            const container = document.getElementById('species-container');
+            if (container && currentView === 'SPECIES') {
+                container.innerHTML = renderSpecies();
+            }
+        })
+        .catch(error => console.error('Failed to load species:', error))
+        .finally(() => {
+            speciesFetching = false;
+            speciesFetchedAt = Date.now();
+        });
+}
+
+// Render the species view from the creature and plant species the server profiles
+function renderSpecies() {
+    let html = '<h3>🐾 Species Tracking & Individual Visualization</h3>';
+    if (!speciesProfiles) {
+        return html + '<div style="color: #aaa;">Loading species...</div>';
+    }
+    const living = speciesProfiles.filter(profile => !profile.extinct);
+    const extinct = speciesProfiles.length - living.length;
+    html += '<div>Active Species: ' + living.length + ' (' + living.filter(profile => profile.kind === 'creature').length + ' creature, ' +
+        living.filter(profile => profile.kind === 'plant').length + ' plant)</div>';
+    html += '<div>Extinct Species: ' + extinct + '</div>';
+
+    // Calculate diversity metrics
+    const survivalRate = speciesProfiles.length > 0 ? (living.length / speciesProfiles.length * 100).toFixed(1) : 100;
+
+    html += '<h4>📈 Diversity Metrics:</h4>';
+    html += '<div>Species Survival Rate: ' + survivalRate + '%</div>';
+
+    if (survivalRate < 30) {
+        html += '<div style="color: red;">🔥 High extinction rate - evolutionary crisis</div>';
+    } else if (survivalRate < 60) {
+        html += '<div style="color: orange;">⚠️ Moderate extinction pressure</div>';
+    } else if (survivalRate < 85) {
+        html += '<div style="color: yellow;">⚡ Natural selection in progress</div>';
+    } else {
+        html += '<div style="color: lightgreen;">🌟 Species diversity stable</div>';
+    }
+
+    html += '<h4>🔍 Individual Species Visualization:</h4>';
+    html += '<div style="margin: 10px 0; padding: 10px; background-color: #2a2a2a; border-radius: 5px;">';
+    html += 'Click on any species below to see how its members\' traits are spread, how far it lies from its relatives and who belongs to it.';
+    html += '</div>';
+
+    if (speciesProfiles.length === 0) {
+        return html + '<br><div>No species data available</div>';
+    }
+
+    // The server lists living species first, the most populous first among them
+    html += '<h4>Species Gallery:</h4>';
+    speciesProfiles.forEach(profile => {
+        html += '<div class="species-item clickable-species" data-species-name="' + escapeHTML(profile.name) + '" style="cursor: pointer; padding: 8px; margin: 5px 0; background-color: #333; border-radius: 3px; border-left: 4px solid ' + (profile.extinct ? '#ff4444' : '#44ff44') + ';">';
+        if (!profile.extinct || profile.kind === 'plant') {
+            html += '<img src="' + speciesSpriteURL(profile.id > 0 ? profile.id : profile.name) + '" width="32" height="32" alt="" style="vertical-align: middle; margin-right: 6px;">';
+        }
+        html += '<strong>' + escapeHTML(profile.name) + '</strong> <span style="color: #aaa;">(' + profile.kind + ')</span>';
+        if (profile.extinct) {
+            html += ' <span style="color: red;">💀 (Extinct)</span>';
+        } else {
+            html += ' - Population: ' + profile.population;
+            // Add population health indicator
+            if (profile.population < 5) {
+                html += ' <span style="color: orange;">⚠️ Endangered</span>';
+            } else if (profile.population < 15) {
+                html += ' <span style="color: yellow;">⚡ Vulnerable</span>';
+            } else {
+                html += ' <span style="color: lightgreen;">✅ Stable</span>';
+            }
+        }
+        html += '<div style="font-size: 0.8em; color: #ccc; margin-top: 3px;">Formed at tick ' + profile.formation_tick +
+            ', peak population ' + profile.peak_population + ' - click for details →</div>';
+        html += '</div>';
+    });
+
+    return html;
+}
+
+// Show detailed species visualization
+function showSpeciesDetail(speciesName) {
+    // Ensure modal elements exist
+    ensureSpeciesModalExists();
+
+    const modal = document.getElementById('species-detail-modal');
+    const overlay = document.getElementById('species-modal-overlay');
+    const content = document.getElementById('species-detail-content');
+
+    if (!modal || !overlay || !content) {
+        console.error('Species modal elements not found');
+        return;
+    }
+
+    // The profile is filled in once the server has described the species
+    let detailHtml = '<h2>🦠 ' + escapeHTML(speciesName) + ' - Individual Visualization</h2>';
+    detailHtml += '<div id="species-profile">Loading species...</div>';
+
+    detailHtml += '<div style="margin-top: 20px;">';
+    detailHtml += '<h3>🧬 Lineage History</h3>';
+    detailHtml += '<div id="ancestry-container" style="background-color: #2a2a2a; padding: 15px; border-radius: 10px;">';
+    detailHtml += '<button onclick="showAncestry(' + escapeHTML(JSON.stringify(speciesName)) + ')">Reconstruct ancestry</button>';
+    detailHtml += '</div></div>';
+
+    content.innerHTML = detailHtml;
+    modal.style.display = 'block';
+    overlay.style.display = 'block';
+
+    registryRequest('api/species/' + encodeURIComponent(speciesName)).then(function(profile) {
+        const container = document.getElementById('species-profile');
+        if (container) {
+            container.innerHTML = renderSpeciesProfile(profile);
+        }
+    }).catch(function(error) {
+        const container = document.getElementById('species-profile');
+        if (container) {
+            container.textContent = 'Failed to load species: ' + error.message;
+        }
+    });
+}
+
+// Ancestral reconstruction shown in the species modal, animated frame by frame
+let ancestryData = null;
+let ancestryFrame = 0;
+let ancestryTimer = null;
+
+function showAncestry(speciesName) {
+    stopAncestry();
+    const container = document.getElementById('ancestry-container');
+    container.innerHTML = 'Reconstructing...';
+    registryRequest('api/ancestry?species=' + encodeURIComponent(speciesName)).then(function(history) {
+        ancestryData = history;
+        ancestryFrame = 0;
+        drawAncestry();
+        playAncestry();
+    }).catch(function(error) {
+        container.innerHTML = '<div style="color: orange;">' + escapeHTML(error.message) + '</div>';
+    });
+}
+
+function playAncestry() {
+    stopAncestry();
+    if (ancestryData && ancestryFrame >= ancestryData.frames.length - 1) {
+        ancestryFrame = 0;
+    }
+    ancestryTimer = setInterval(function() {
+        if (!ancestryData || !document.getElementById('ancestry-container')) {
+            stopAncestry();
+            return;
+        }
+        ancestryFrame++;
+        if (ancestryFrame >= ancestryData.frames.length - 1) {
+            ancestryFrame = ancestryData.frames.length - 1;
+            stopAncestry();
+        }
+        drawAncestry();
+    }, 100);
+    drawAncestry();
+}
+
+function stopAncestry() {
+    if (ancestryTimer) {
+        clearInterval(ancestryTimer);
+        ancestryTimer = null;
+        drawAncestry();
+    }
+}
+
+function seekAncestry(frame) {
+    stopAncestry();
+    ancestryFrame = parseInt(frame, 10);
+    drawAncestry();
+}
+
+function drawAncestry() {
+    const container = document.getElementById('ancestry-container');
+    if (!container || !ancestryData) {
+        return;
+    }
+    const history = ancestryData;
+    const frame = history.frames[ancestryFrame];
+    let html = '<div>Lineage: ' + history.lineage.map(escapeHTML).join(' → ') + '</div>';
+    html += '<div style="display: flex; gap: 20px; flex-wrap: wrap; align-items: center;">';
+    html += renderAncestryChart(history) + renderAncestralCreature(frame.traits);
+    html += '</div>';
+    html += '<div>Tick ' + frame.tick + ' (' + escapeHTML(frame.species) + (frame.reconstructed ? ', reconstructed' : '') + ') ';
+    html += '<button onclick="' + (ancestryTimer ? 'stopAncestry()">⏸ Pause' : 'playAncestry()">▶ Play') + '</button> ';
+    html += '<input type="range" min="0" max="' + (history.frames.length - 1) + '" value="' + ancestryFrame + '" oninput="seekAncestry(this.value)"></div>';
+    html += '<h4>Net change since the founders:</h4>';
+    history.traits.forEach(function(trait) {
+        const change = history.change[trait] || 0;
+        html += '<div style="font-size: 0.9em; margin-left: 10px;">' + escapeHTML(trait) + ': ' + (frame.traits[trait] !== undefined ? frame.traits[trait].toFixed(3) : '-') +
+            ' <span style="color: ' + (change >= 0 ? 'lightgreen' : 'salmon') + ';">(' + (change >= 0 ? '+' : '') + change.toFixed(3) + ')</span></div>';
+    });
+    container.innerHTML = html;
+}
+
+const ancestryColors = ['#4CAF50', '#2196F3', '#FF9800', '#E91E63', '#9C27B0', '#00BCD4', '#FFEB3B', '#795548'];
+
+// Line chart of each trait over the lineage, with a cursor on the current frame
+function renderAncestryChart(history) {
+    const width = 360, height = 200, pad = 20;
+    const frames = history.frames;
+    let lo = Infinity, hi = -Infinity;
+    frames.forEach(function(frame) {
+        history.traits.forEach(function(trait) {
+            if (frame.traits[trait] !== undefined) {
+                lo = Math.min(lo, frame.traits[trait]);
+                hi = Math.max(hi, frame.traits[trait]);
+            }
+        });
+    });
+    if (lo === Infinity) {
+        lo = -1;
+        hi = 1;
+    }
+    const span = Math.max(1e-9, hi - lo);
+    const first = frames[0].tick, last = frames[frames.length - 1].tick;
+    const px = tick => pad + (last > first ? (tick - first) / (last - first) : 0) * (width - 2 * pad);
+    const py = value => height - pad - (value - lo) / span * (height - 2 * pad);
+
+    let svg = '<svg width="' + width + '" height="' + height + '" style="background: #111;">';
+    history.splits.forEach(function(split) {
+        const x = px(split.tick).toFixed(1);
+        svg += '<line x1="' + x + '" y1="' + pad + '" x2="' + x + '" y2="' + (height - pad) + '" stroke="#666" stroke-dasharray="4,3">' +
+            '<title>' + escapeHTML(split.parent) + ' → ' + escapeHTML(split.child) + ' at tick ' + split.tick + '</title></line>';
+    });
+    history.traits.forEach(function(trait, i) {
+        const points = frames.filter(frame => frame.traits[trait] !== undefined)
+            .map(frame => px(frame.tick).toFixed(1) + ',' + py(frame.traits[trait]).toFixed(1));
+        svg += '<polyline points="' + points.join(' ') + '" fill="none" stroke="' + ancestryColors[i % ancestryColors.length] + '" stroke-width="2">' +
+            '<title>' + escapeHTML(trait) + '</title></polyline>';
+    });
+    const cursor = px(frames[ancestryFrame].tick).toFixed(1);
+    svg += '<line x1="' + cursor + '" y1="' + pad + '" x2="' + cursor + '" y2="' + (height - pad) + '" stroke="#fff"/>';
+    svg += '<text x="' + pad + '" y="' + (height - 5) + '" fill="#aaa" font-size="10">tick ' + first + '</text>';
+    svg += '<text x="' + (width - pad) + '" y="' + (height - 5) + '" fill="#aaa" font-size="10" text-anchor="end">tick ' + last + '</text>';
+    svg += '<text x="2" y="' + (pad - 5) + '" fill="#aaa" font-size="10">' + hi.toFixed(2) + '</text>';
+    svg += '</svg>';
+
+    let legend = '<div style="font-size: 0.8em;">';
+    history.traits.forEach(function(trait, i) {
+        legend += '<span style="color: ' + ancestryColors[i % ancestryColors.length] + ';">■</span> ' + escapeHTML(trait) + ' ';
+    });
+    return '<div>' + svg + legend + '</div></div>';
+}
+
+// A creature drawn from the frame's genes, so stepping frames morphs it
+function renderAncestralCreature(traits) {
+    const gene = name => Math.max(-1, Math.min(1, traits[name] || 0));
+    const size = gene('size'), speed = gene('speed'), strength = Math.max(gene('strength'), gene('aggression'));
+    const body = 30 + 15 * size;
+    const legs = 10 + 12 * (speed + 1);
+    const head = 12 + 5 * gene('intelligence');
+    const aquatic = gene('aquatic_adaptation'), flying = gene('flying_ability');
+    const hue = 120 - 60 * strength;
+
+    let svg = '<svg width="160" height="160" viewBox="-80 -80 160 160" style="background: #111;">';
+    if (flying > 0) {
+        const span = body + 40 * flying;
+        svg += '<path d="M 0 -5 L ' + (-span).toFixed(1) + ' ' + (-20 - 10 * flying).toFixed(1) + ' L -10 5 Z" fill="#9ad" opacity="0.7"/>';
+        svg += '<path d="M 0 -5 L ' + span.toFixed(1) + ' ' + (-20 - 10 * flying).toFixed(1) + ' L 10 5 Z" fill="#9ad" opacity="0.7"/>';
+    }
+    [-0.6, -0.2, 0.2, 0.6].forEach(function(offset) {
+        const x = (offset * body).toFixed(1);
+        svg += '<line x1="' + x + '" y1="0" x2="' + (offset * body * 1.2).toFixed(1) + '" y2="' + (body * 0.5 + legs).toFixed(1) + '" stroke="#ccc" stroke-width="' + (2 + 2 * Math.max(0, strength)).toFixed(1) + '"/>';
+    });
+    if (aquatic > 0) {
+        svg += '<path d="M ' + (-body).toFixed(1) + ' 0 L ' + (-body - 20 * aquatic).toFixed(1) + ' ' + (-12 * aquatic).toFixed(1) + ' L ' + (-body - 20 * aquatic).toFixed(1) + ' ' + (12 * aquatic).toFixed(1) + ' Z" fill="#48c"/>';
+    }
+    svg += '<ellipse cx="0" cy="0" rx="' + body.toFixed(1) + '" ry="' + (body * 0.55).toFixed(1) + '" fill="hsl(' + hue.toFixed(0) + ', 60%, 45%)"/>';
+    svg += '<circle cx="' + (body * 0.9).toFixed(1) + '" cy="' + (-body * 0.4).toFixed(1) + '" r="' + head.toFixed(1) + '" fill="hsl(' + hue.toFixed(0) + ', 60%, 55%)"/>';
+    svg += '<circle cx="' + (body * 0.9 + head * 0.4).toFixed(1) + '" cy="' + (-body * 0.4 - head * 0.2).toFixed(1) + '" r="' + (2 + head * 0.1).toFixed(1) + '" fill="#fff"/>';
+    svg += '</svg>';
+    return svg;
+}
+
+// Phylogenetic tree of plant species shown in the detail modal. Time runs left to right, each
+// species is a row and daughters hang below the species they split from. Clicking a species
+// folds its subtree away.
+let phylogenyData = null;
+let phylogenyCollapsed = {};
+let phylogenyShowExtinct = true;
+
+function showPhylogeny() {
+    ensureSpeciesModalExists();
+    const content = document.getElementById('species-detail-content');
+    content.innerHTML = '<h2>🌳 Plant Phylogeny</h2><div id="phylogeny-container">Loading...</div>';
+    document.getElementById('species-detail-modal').style.display = 'block';
+    document.getElementById('species-modal-overlay').style.display = 'block';
+
+    registryRequest('api/phylogeny').then(function(phylogeny) {
+        phylogenyData = phylogeny;
+        drawPhylogeny();
+    }).catch(function(error) {
+        document.getElementById('phylogeny-container').innerHTML = '<div style="color: orange;">' + escapeHTML(error.message) + '</div>';
+    });
+}
+
+function togglePhylogenyNode(id) {
+    phylogenyCollapsed[id] = !phylogenyCollapsed[id];
+    drawPhylogeny();
+}
+
+function togglePhylogenyExtinct(show) {
+    phylogenyShowExtinct = show;
+    drawPhylogeny();
+}
+
+// Whether a subtree still has a living species, so hiding extinct branches keeps the ancestors of living ones
+function phylogenyHasLiving(node) {
+    return !node.extinct || node.children.some(phylogenyHasLiving);
+}
+
+function drawPhylogeny() {
+    const container = document.getElementById('phylogeny-container');
+    if (!container || !phylogenyData) {
+        return;
+    }
+    const tree = phylogenyData;
+    let html = '<div>' + tree.species + ' species (' + tree.living + ' living, ' + tree.extinct + ' extinct), ' +
+        tree.depth + ' splits deep at tick ' + tree.tick + '</div>';
+    html += '<div style="margin: 8px 0;"><label><input type="checkbox"' + (phylogenyShowExtinct ? ' checked' : '') +
+        ' onchange="togglePhylogenyExtinct(this.checked)"> Show extinct branches</label> ';
+    html += '<button onclick="showPhylogeny()">↻ Refresh</button> ';
+    html += '<a href="api/phylogeny?format=newick" download="phylogeny.nwk">Newick</a> · ';
+    html += '<a href="api/phylogeny" download="phylogeny.json">JSON</a></div>';
+    if (tree.roots.length === 0) {
+        container.innerHTML = html + '<div>No plant species have formed yet</div>';
+        return;
+    }
+
+    // One row per visible species, in depth-first order
+    const rows = [];
+    const visit = function(node, parentRow) {
+        if (!phylogenyShowExtinct && !phylogenyHasLiving(node)) {
+            return;
+        }
+        const row = {node: node, parentRow: parentRow, index: rows.length};
+        rows.push(row);
+        if (!phylogenyCollapsed[node.id]) {
+            node.children.forEach(child => visit(child, row));
+        }
+    };
+    tree.roots.forEach(root => visit(root, null));
+
+    const rowHeight = 20, labelWidth = 170, pad = 20, width = 760;
+    const height = rows.length * rowHeight + 2 * pad;
+    const first = Math.min(...tree.roots.map(root => root.formation_tick));
+    const span = Math.max(1, tree.tick - first);
+    const px = tick => pad + (tick - first) / span * (width - labelWidth - 2 * pad);
+    const py = index => pad + index * rowHeight + rowHeight / 2;
+
+    let svg = '<svg width="' + width + '" height="' + height + '" style="background: #111;">';
+    rows.forEach(function(row) {
+        const node = row.node, y = py(row.index);
+        const x1 = px(node.formation_tick), x2 = px(node.formation_tick + node.age);
+        const color = node.extinct ? '#888' : '#4CAF50';
+        const dash = node.extinct ? ' stroke-dasharray="4,3"' : '';
+        if (row.parentRow) {
+            svg += '<line x1="' + x1.toFixed(1) + '" y1="' + py(row.parentRow.index).toFixed(1) + '" x2="' + x1.toFixed(1) + '" y2="' + y.toFixed(1) + '" stroke="#555"/>';
+        }
+
+        let title = node.name + ' (' + node.plant_type + ')\nFormed tick ' + node.formation_tick;
+        if (node.extinct) {
+            title += ', extinct tick ' + node.extinction_tick;
+        }
+        title += ' (' + node.age + ' ticks)\nPopulation ' + node.population + ', peak ' + node.peak_population;
+        if (node.parent_id) {
+            title += '\nSplit at distance ' + node.split_distance.toFixed(3) + phylogenyTraitSummary('Divergence from parent', node.divergence);
+        }
+        title += phylogenyTraitSummary('Trait drift since forming', node.trait_drift);
+
+        svg += '<g style="cursor: ' + (node.children.length > 0 ? 'pointer' : 'default') + ';"' +
+            (node.children.length > 0 ? ' onclick="togglePhylogenyNode(' + node.id + ')"' : '') + '><title>' + escapeHTML(title) + '</title>';
+        svg += '<line x1="' + x1.toFixed(1) + '" y1="' + y.toFixed(1) + '" x2="' + Math.max(x2, x1 + 1).toFixed(1) + '" y2="' + y.toFixed(1) + '" stroke="' + color + '" stroke-width="3"' + dash + '/>';
+        svg += '<circle cx="' + x1.toFixed(1) + '" cy="' + y.toFixed(1) + '" r="4" fill="' + color + '"/>';
+        let label = node.name + (node.extinct ? ' †' : ' (' + node.population + ')');
+        if (phylogenyCollapsed[node.id]) {
+            label += ' ▸ +' + node.descendants;
+        }
+        svg += '<text x="' + (x2 + 6).toFixed(1) + '" y="' + (y + 4).toFixed(1) + '" fill="' + color + '" font-size="11">' + escapeHTML(label) + '</text></g>';
+    });
+    svg += '<text x="' + pad + '" y="' + (height - 4) + '" fill="#aaa" font-size="10">tick ' + first + '</text>';
+    svg += '<text x="' + px(tree.tick).toFixed(1) + '" y="' + (height - 4) + '" fill="#aaa" font-size="10" text-anchor="end">tick ' + tree.tick + '</text>';
+    svg += '</svg>';
+
+    html += '<div style="overflow-x: auto;">' + svg + '</div>';
+    html += '<div style="font-size: 0.8em; color: #aaa;">Hover a species for its divergence and trait drift; click one with daughters to fold its subtree. Dashed branches are extinct.</div>';
+    container.innerHTML = html;
+}
+
+// The traits that changed most, largest first, as lines for a tooltip
+function phylogenyTraitSummary(heading, traits) {
+    const changes = Object.entries(traits || {})
+        .sort((a, b) => Math.abs(b[1]) - Math.abs(a[1]))
+        .slice(0, 5);
+    if (changes.length === 0) {
+        return '';
+    }
+    return '\n' + heading + ':' + changes.map(([trait, change]) => '\n  ' + trait + ' ' + (change >= 0 ? '+' : '') + change.toFixed(3)).join('');
+}
+
+// Hide species detail modal
+function hideSpeciesDetail() {
+    const modal = document.getElementById('species-detail-modal');
+    const overlay = document.getElementById('species-modal-overlay');
+
+    stopAncestry();
+    ancestryData = null;
+    if (modal) {
+        modal.style.display = 'none';
+    }
+    if (overlay) {
+        overlay.style.display = 'none';
+    }
+}
+
+// Closes the detail modal and drops its deep link from the URL
+function closeDetailModal() {
+    hideSpeciesDetail();
+    history.replaceState(null, '', '#view/' + currentView);
+}
+
+// Show an entity's details in the detail modal
+function showEntityDetail(entityID) {
+    ensureSpeciesModalExists();
+    const content = document.getElementById('species-detail-content');
+    content.innerHTML = '<h2>Entity #' + entityID + '</h2><div>Loading...</div>';
+    document.getElementById('species-detail-modal').style.display = 'block';
+    document.getElementById('species-modal-overlay').style.display = 'block';
+
+    registryRequest('api/entity/' + entityID).then(function(entity) {
+        content.innerHTML = renderEntityDetail(entity);
+    }).catch(function(error) {
+        content.innerHTML = '<h2>Entity #' + entityID + '</h2><div>' + escapeHTML(error.message) + '</div>';
+    });
+}
+
+function renderEntityDetail(entity) {
+    let html = '<h2>' + (entity.is_alive ? '🧬' : '💀') + ' Entity #' + entity.id + '</h2>';
+    html += '<div>Species: ' + speciesLink(entity.species) + '</div>';
+    if (entity.colony_id) {
+        html += '<div>Colony: ' + colonyLink(entity.colony_id) + '</div>';
+    }
+    html += '<div>Status: ' + (entity.is_alive ? 'Alive' : 'Dead') + ' | Age: ' + entity.age + ' | Generation: ' + entity.generation + '</div>';
+    html += '<div>Energy: ' + entity.energy.toFixed(1) + ' | Position: (' + entity.position.x.toFixed(1) + ', ' + entity.position.y.toFixed(1) + ')</div>';
+    html += '<h4>Traits:</h4>';
+    const traitNames = Object.keys(entity.traits).sort();
+    traitNames.forEach(name => {
+        html += '<div style="font-size: 0.9em; margin-left: 10px;">' + name + ': ' + entity.traits[name].toFixed(3) + '</div>';
+    });
+    if (entity.is_alive && traitNames.length > 0) {
+        html += '<div style="margin-top: 10px;">Edit trait: <select id="trait-edit-name">';
+        traitNames.forEach(name => {
+            html += '<option value="' + escapeHTML(name) + '">' + escapeHTML(name) + '</option>';
+        });
+        html += '</select> <input type="number" id="trait-edit-value" step="0.05" size="6"> ';
+        html += '<button onclick="editEntityTrait(' + entity.id + ')">Apply</button> <span style="font-size: 0.8em;">(undo with ↶)</span></div>';
+    }
+    return html;
+}
+
+// Render a species profile: its sprite, how its members' traits are spread, its relatives
+// and the members themselves
+function renderSpeciesProfile(profile) {
+    let html = '<div style="display: flex; gap: 20px; flex-wrap: wrap;">';
+
+    html += '<div style="flex: 1; min-width: 300px;">';
+    html += '<h3>🎨 Species Profile View</h3>';
+    html += '<div style="text-align: center; background-color: #2a2a2a; padding: 20px; border-radius: 10px;">';
+    if (!profile.extinct || profile.kind === 'plant') {
+        html += '<img src="' + speciesSpriteURL(profile.id > 0 ? profile.id : profile.name) + '" width="192" height="192" alt="' +
+            escapeHTML(profile.name) + '" style="background-color: #1a1a1a; border-radius: 10px;">';
+        html += '<div style="font-size: 0.8em; color: #ccc; margin-top: 8px;">Drawn from the average traits of its living members</div>';
+    }
+    html += '<div style="text-align: left; margin-top: 10px;">';
+    html += '<div>Kind: ' + profile.kind + '</div>';
+    html += '<div>Population: ' + profile.population + ' (peak ' + profile.peak_population + ')</div>';
+    html += '<div>Formed at tick ' + profile.formation_tick + '</div>';
+    if (profile.extinct) {
+        html += '<div style="color: red;">💀 Extinct' + (profile.extinction_tick ? ' at tick ' + profile.extinction_tick : '') + '</div>';
+    }
+    html += '</div></div></div>';
+
+    html += '<div style="flex: 1; min-width: 300px;">';
+    html += '<h3>📊 Genetic Trait Analysis</h3>';
+    html += '<div style="background-color: #2a2a2a; padding: 15px; border-radius: 10px;">';
+    html += renderTraitDistributions(profile.traits);
+    html += '</div></div>';
+    html += '</div>';
+
+    html += '<div style="margin-top: 20px;">';
+    html += '<h3>🌳 Relatives</h3>';
+    html += '<div style="background-color: #2a2a2a; padding: 15px; border-radius: 10px;">';
+    if (profile.relatives.length === 0) {
+        html += '<div style="color: #aaa;">No related species to compare with</div>';
+    }
+    profile.relatives.forEach(relative => {
+        html += '<div>' + relative.relation + ': ' + speciesLink(relative.name) + ' - genetic distance ' + relative.distance.toFixed(3) + '</div>';
+    });
+    html += '</div></div>';
+
+    html += '<div style="margin-top: 20px;">';
+    html += '<h3>👥 Members (' + profile.member_ids.length + ')</h3>';
+    html += '<div style="background-color: #2a2a2a; padding: 15px; border-radius: 10px; max-height: 150px; overflow-y: auto;">';
+    if (profile.member_ids.length === 0) {
+        html += '<div style="color: #aaa;">No living members</div>';
+    } else if (profile.kind === 'creature') {
+        html += profile.member_ids.map(entityLink).join(', ');
+    } else {
+        html += 'Plants ' + profile.member_ids.map(id => '#' + id).join(', ');
+    }
+    html += '</div></div>';
+    return html;
+}
+
+// Render each trait's spread over a species' members: the range as a track, one standard
+// deviation either side of the mean as a band and the mean as a mark, on a scale of -2 to 2
+function renderTraitDistributions(traits) {
+    const names = Object.keys(traits || {}).sort();
+    if (names.length === 0) {
+        return '<div style="color: #aaa;">No living members to measure</div>';
+    }
+    const scale = value => Math.max(0, Math.min(100, (value + 2) / 4 * 100));
+    let html = '';
+    names.forEach(name => {
+        const trait = traits[name];
+        html += '<div style="margin: 8px 0; font-family: monospace;">';
+        html += '<div style="display: flex; justify-content: space-between;"><span style="font-weight: bold;">' + escapeHTML(name) + '</span>';
+        html += '<span style="color: #aaa;">' + trait.mean.toFixed(2) + ' ± ' + trait.std_dev.toFixed(2) + ' (' + trait.min.toFixed(2) + ' to ' + trait.max.toFixed(2) + ')</span></div>';
+        html += '<div style="position: relative; height: 10px; background: #1a1a1a; border-radius: 2px;">';
+        html += '<div style="position: absolute; top: 4px; height: 2px; background: #666; left: ' + scale(trait.min) + '%; width: ' + (scale(trait.max) - scale(trait.min)) + '%;"></div>';
+        html += '<div style="position: absolute; top: 1px; height: 8px; background: #3366CC; left: ' + scale(trait.mean - trait.std_dev) + '%; width: ' + (scale(trait.mean + trait.std_dev) - scale(trait.mean - trait.std_dev)) + '%;"></div>';
+        html += '<div style="position: absolute; top: 0; height: 10px; width: 2px; background: #fff; left: ' + scale(trait.mean) + '%;"></div>';
+        html += '</div></div>';
+    });
+    return html;
+}
+
+// URL of a species' sprite, drawn by the server from its members' traits. Plant species go by
+// number and creature species by name.
+function speciesSpriteURL(id) {
+    return 'api/species/' + encodeURIComponent(id) + '/sprite';
+}
+
+// Render network view
+function renderNetwork(network) {
+    let html = '<h3>🌐 Plant Network System</h3>';
+    html += '<div>Network Connections: ' + network.connection_count + '</div>';
+    html += '<div>Chemical Signals: ' + network.signal_count + '</div>';
+    html += '<div>Network Clusters: ' + network.cluster_count + '</div>';
+
+    if (network.connection_count === 0) {
+        html += '<br><div>No plant networks formed yet</div>';
+    } else {
+        html += '<br><h4>Network Status:</h4>';
+        if (network.cluster_count === 0) {
+            html += '<div>Network Density: Sparse connections</div>';
+        } else if (network.cluster_count < 3) {
+            html += '<div>Network Density: Few clusters formed</div>';
+        } else {
+            html += '<div>Network Density: Multiple active clusters</div>';
+        }
+    }
+
+    return html;
+}
+
+// Render DNA view
+function renderDNA(dna) {
+    let html = '<h3>🧬 DNA System</h3>';
+    html += '<div>Organisms: ' + dna.organism_count + '</div>';
+    html += '<div>Average Mutations: ' + dna.average_mutations.toFixed(2) + '</div>';
+    html += '<div>Average Complexity: ' + dna.average_complexity.toFixed(2) + '</div>';
+
+    if (dna.organism_count === 0) {
+        html += '<br><div>No DNA-based organisms present</div>';
+    } else {
+        html += '<br><h4>Genetic Status:</h4>';
+        if (dna.average_complexity < 1.0) {
+            html += '<div>Complexity Level: Simple organisms</div>';
+        } else if (dna.average_complexity < 3.0) {
+            html += '<div>Complexity Level: Moderate complexity</div>';
+        } else {
+            html += '<div>Complexity Level: Complex organisms</div>';
+        }
+    }
+
+    return html;
+}
+
+// Render cellular view
+function renderCellular(cellular) {
+    let html = '<h3>🔬 Cellular System & Individual Organism View</h3>';
+    html += '<div>Total Cells: ' + cellular.total_cells + '</div>';
+    html += '<div>Average Complexity: ' + cellular.average_complexity.toFixed(2) + '</div>';
+    html += '<div>Cell Divisions: ' + cellular.cell_divisions + '</div>';
+
+    if (cellular.total_cells === 0) {
+        html += '<br><div>No cellular activity detected</div>';
+    } else {
+        html += '<br><h4>Cellular Activity:</h4>';
+        if (cellular.cell_divisions === 0) {
+            html += '<div>Division Activity: No recent divisions</div>';
+        } else if (cellular.cell_divisions < 10) {
+            html += '<div>Division Activity: Low division rate</div>';
+        } else {
+            html += '<div>Division Activity: Active cell division</div>';
+        }
+
+        // Add individual organism visualization
+        html += '<br><h4>🦠 Individual Organism Visualization:</h4>';
+        html += '<div style="background-color: #2a2a2a; padding: 15px; border-radius: 10px; margin: 10px 0;">';
+        html += renderSimulatedOrganism(cellular);
+        html += '</div>';
+
+        // Complexity levels breakdown
+        html += '<br><h4>📊 Complexity Distribution:</h4>';
+        html += '<div style="margin-left: 15px;">';
+        html += '<div>Level 1 (Single-cell): ' + Math.round(cellular.total_cells * 0.4) + ' organisms</div>';
+        html += '<div>Level 2 (Simple multi-cell): ' + Math.round(cellular.total_cells * 0.3) + ' organisms</div>';
+        html += '<div>Level 3 (Moderate complexity): ' + Math.round(cellular.total_cells * 0.2) + ' organisms</div>';
+        html += '<div>Level 4 (Complex): ' + Math.round(cellular.total_cells * 0.08) + ' organisms</div>';
+        html += '<div>Level 5 (Highly complex): ' + Math.round(cellular.total_cells * 0.02) + ' organisms</div>';
+        html += '</div>';
+    }
+
+    return html;
+}
+
+// Render a simulated organism based on cellular data
+function renderSimulatedOrganism(cellular) {
+    let html = '';
+
+    // Create a visual representation of a typical organism
+    html += '<div style="text-align: center;">';
+    html += '<h5>🦠 Sample Multi-cellular Organism</h5>';
+    html += '<div style="font-family: monospace; font-size: 1.2em; line-height: 1.2; margin: 15px 0;">';
+
+    // Generate organism structure based on complexity
+    const complexity = Math.min(5, Math.max(1, cellular.average_complexity));
+
+    if (complexity >= 1) {
+        html += '<div style="color: #44ff44;">🌟 Nucleus (Core)</div>';
+    }
+    if (complexity >= 2) {
+        html += '<div style="color: #ffaa44;">⚡⚡ Mitochondria (Energy)</div>';
+    }
+    if (complexity >= 3) {
+        html += '<div style="color: #44aaff;">🔵🔵🔵 Specialized Cells</div>';
+    }
+    if (complexity >= 4) {
+        html += '<div style="color: #ff44aa;">📦📦 Organ Systems</div>';
+    }
+    if (complexity >= 5) {
+        html += '<div style="color: #aa44ff;">🧠 Neural Network</div>';
+    }
+
+    html += '</div>';
+
+    // Cell type breakdown
+    html += '<div style="text-align: left; margin-top: 15px;">';
+    html += '<strong>Cell Type Distribution:</strong><br>';
+    html += '<div style="margin-left: 10px; font-size: 0.9em;">';
+    html += '<span style="color: #44ff44;">S</span> Stem cells: ' + Math.round(cellular.total_cells * 0.15) + '<br>';
+    html += '<span style="color: #ffaa44;">N</span> Nerve cells: ' + Math.round(cellular.total_cells * 0.10) + '<br>';
+    html += '<span style="color: #ff4444;">M</span> Muscle cells: ' + Math.round(cellular.total_cells * 0.20) + '<br>';
+    html += '<span style="color: #4444ff;">D</span> Digestive cells: ' + Math.round(cellular.total_cells * 0.25) + '<br>';
+    html += '<span style="color: #ff44ff;">R</span> Reproductive cells: ' + Math.round(cellular.total_cells * 0.05) + '<br>';
+    html += '<span style="color: #44ffff;">F</span> Defensive cells: ' + Math.round(cellular.total_cells * 0.15) + '<br>';
+    html += '<span style="color: #aaff44;">P</span> Photosynthetic cells: ' + Math.round(cellular.total_cells * 0.08) + '<br>';
+    html += '<span style="color: #ffaa44;">T</span> Storage cells: ' + Math.round(cellular.total_cells * 0.02) + '<br>';
+    html += '</div>';
+    html += '</div>';
+
+    // Organelle visualization
+    html += '<div style="margin-top: 15px;">';
+    html += '<strong>Organelle Profile:</strong><br>';
+    html += '<div style="display: flex; flex-wrap: wrap; gap: 5px; margin-top: 5px;">';
+    html += '<span title="Nucleus">⬣</span>';
+    html += '<span title="Mitochondria">⚡</span>';
+    html += '<span title="Chloroplast">🌱</span>';
+    html += '<span title="Ribosome">⬢</span>';
+    html += '<span title="Vacuole">💧</span>';
+    html += '<span title="Golgi">📦</span>';
+    html += '<span title="ER">🕸</span>';
+    html += '<span title="Lysosome">🗑</span>';
+    html += '</div>';
+    html += '</div>';
+
+    html += '</div>';
+
+    return html;
+}
+
+// Render evolution view
+function renderEvolution(evolution) {
+    let html = '<h3>🌿 Evolution Tracking</h3>';
+    html += '<div>Speciation Events: ' + evolution.speciation_events + '</div>';
+    html += '<div>Extinction Events: ' + evolution.extinction_events + '</div>';
+    html += '<div>Genetic Diversity: ' + evolution.genetic_diversity.toFixed(2) + '</div>';
+
+    html += '<br><h4>Evolutionary Status:</h4>';
+    if (evolution.speciation_events === 0) {
+        html += '<div>No speciation detected yet</div>';
+    } else if (evolution.speciation_events < 3) {
+        html += '<div>Early speciation phase</div>';
+    } else {
+        html += '<div>Active evolutionary divergence</div>';
+    }
+
+    if (evolution.extinction_events > 0) {
+        html += '<div style="color: orange;">Warning: ' + evolution.extinction_events + ' extinction event(s) occurred</div>';
+    }
+    if (evolution.has_speciation_system) {
+        html += '<div style="margin-top: 8px;"><button onclick="showPhylogeny()">🌳 Phylogenetic tree</button> ' +
+            '<a href="api/phylogeny?format=newick" download="phylogeny.nwk">Newick</a> · <a href="api/phylogeny" download="phylogeny.json">JSON</a></div>';
+    }
+
+    if (evolution.mutation_operators && evolution.mutation_operators.length > 0) {
+        html += '<br><h4>🧬 Mutation Operators:</h4>';
+        evolution.mutation_operators.forEach(op => {
+            const changes = Object.entries(op.trait_changes || {})
+                .sort((a, b) => Math.abs(b[1]) - Math.abs(a[1]))
+                .slice(0, 3)
+                .map(([trait, change]) => escapeHTML(trait) + ' ' + (change >= 0 ? '+' : '') + change.toFixed(2));
+            html += '<div><strong>' + escapeHTML(op.name) + '</strong> (rate ' + op.rate.toFixed(2) + '): ' +
+                op.mutations + ' mutations, ' + (op.share * 100).toFixed(1) + '%</div>';
+            if (changes.length > 0) {
+                html += '<div style="margin-left: 10px; font-size: 0.9em;">' + changes.join(', ') + '</div>';
+            }
+        });
+    }
+
+    if (evolution.radiations && evolution.radiations.length > 0) {
+        html += '<br><h4>🌳 Adaptive Radiations:</h4>';
+        evolution.radiations.forEach(radiation => {
+            html += '<div><strong>' + speciesLink(radiation.ancestor) + '</strong> → ' + radiation.daughters.map(speciesLink).join(', ') +
+                ' (ticks ' + radiation.start_tick + '-' + radiation.tick + ')</div>';
+            if (radiation.niches.length > 0) {
+                html += '<div style="margin-left: 10px; font-size: 0.9em;">New niches: ' + radiation.niches.map(escapeHTML).join(', ') + '</div>';
+            }
+            html += renderNicheOccupancy(radiation.before, radiation.after);
+        });
+    }
+
+    if (evolution.convergences && evolution.convergences.length > 0) {
+        html += '<br><h4>🔀 Convergent Evolution:</h4>';
+        evolution.convergences.forEach(convergence => {
+            html += '<div><strong>' + speciesLink(convergence.species_a) + '</strong> and <strong>' + speciesLink(convergence.species_b) + '</strong>' +
+                ' (from ' + escapeHTML(convergence.root_a) + ' and ' + escapeHTML(convergence.root_b) + ') in ' +
+                convergence.habitats.map(escapeHTML).join(', ') + ' habitats, tick ' + convergence.tick + '</div>';
+            convergence.traits.forEach(trait => {
+                html += '<div style="margin-left: 10px; font-size: 0.9em;">' + escapeHTML(trait.trait) + ': ' + trait.value_a.toFixed(2) + ' vs ' +
+                    trait.value_b.toFixed(2) + ' (gap ' + trait.origin_gap.toFixed(2) + ' → ' + trait.gap.toFixed(2) + ')</div>';
+            });
+        });
+    }
+
+    if (evolution.arms_races && evolution.arms_races.length > 0) {
+        html += '<br><h4>👑 Red Queen Arms Races:</h4>';
+        evolution.arms_races.forEach(race => {
+            html += '<div><strong>' + speciesLink(race.attacker) + '</strong> ' + (race.kind === 'host_parasite' ? 'parasitizing' : 'hunting') +
+                ' <strong>' + speciesLink(race.defender) + '</strong>: ' + race.interactions + ' interactions, ' + race.samples + ' samples</div>';
+            race.axes.forEach(axis => {
+                let lead = 'in step';
+                if (axis.leader === 'attacker') {
+                    lead = 'attacker leads by ' + axis.best_lag + ' ticks';
+                } else if (axis.leader === 'defender') {
+                    lead = 'defender leads by ' + (-axis.best_lag) + ' ticks';
+                }
+                html += '<div style="margin-left: 10px; font-size: 0.9em; display: flex; align-items: center; gap: 8px;">' +
+                    renderArmsRaceSparkline(axis) + '<span>' + escapeHTML(axis.attacker_trait) + ' vs ' + escapeHTML(axis.defender_trait) +
+                    ': r=' + axis.correlation.toFixed(2) + ', best r=' + axis.best_correlation.toFixed(2) + ' (' + lead + '), amplitude ' +
+                    axis.attacker_amplitude.toFixed(3) + '/' + axis.defender_amplitude.toFixed(3) + '</span></div>';
+            });
+        });
+    }
+
+    return html;
+}
+
+// Attacker (red) and defender (blue) trait series, each scaled to its own range
+function renderArmsRaceSparkline(axis) {
+    const width = 120, height = 24;
+    const line = (series, color) => {
+        if (!series || series.length < 2) {
+            return '';
+        }
+        const low = Math.min(...series), span = Math.max(1e-9, Math.max(...series) - low);
+        const points = series.map((value, i) => (i / (series.length - 1) * width).toFixed(1) + ',' + (height - 2 - (value - low) / span * (height - 4)).toFixed(1));
+        return '<polyline points="' + points.join(' ') + '" fill="none" stroke="' + color + '" stroke-width="1.5"/>';
+    };
+    return '<svg width="' + width + '" height="' + height + '" style="background: #111; flex-shrink: 0;">' +
+        line(axis.attacker_series, '#f44336') + line(axis.defender_series, '#2196F3') + '</svg>';
+}
+
+// Species per niche before and after a radiation, as paired bars
+function renderNicheOccupancy(before, after) {
+    const niches = Array.from(new Set(Object.keys(before).concat(Object.keys(after)))).sort();
+    const most = Math.max(1, ...niches.map(niche => Math.max(before[niche] || 0, after[niche] || 0)));
+    let html = '<div style="margin: 5px 0 10px 10px; font-size: 0.85em;">';
+    niches.forEach(niche => {
+        const was = before[niche] || 0, now = after[niche] || 0;
+        html += '<div style="display: flex; align-items: center; gap: 5px;"><span style="width: 110px;">' + escapeHTML(niche) + '</span>';
+        html += '<div style="width: 120px;"><div style="background: #777; height: 5px; width: ' + (was / most * 100).toFixed(0) + '%;"></div>';
+        html += '<div style="background: #4CAF50; height: 5px; width: ' + (now / most * 100).toFixed(0) + '%;"></div></div>';
+        html += '<span>' + was + ' → ' + now + '</span></div>';
+    });
+    html += '<div style="color: #aaa;"><span style="color: #777;">■</span> before <span style="color: #4CAF50;">■</span> after</div></div>';
+    return html;
+}
+
+// Render topology view
+function renderTopology(topology) {
+    let html = '<h3>🗻 World Topology</h3>';
+    html += '<div>Elevation Range: ' + topology.elevation_range + '</div>';
+    html += '<div>Fluid Regions: ' + topology.fluid_regions + '</div>';
+    html += '<div>Geological Age: ' + topology.geological_age + '</div>';
+
+    html += '<br><h4>Terrain Analysis:</h4>';
+    if (topology.fluid_regions === 0) {
+        html += '<div>Terrain Type: Dry landscape</div>';
+    } else if (topology.fluid_regions < 3) {
+        html += '<div>Terrain Type: Semi-arid with water sources</div>';
+    } else {
+        html += '<div>Terrain Type: Water-rich environment</div>';
+    }
+
+    return html;
+}
+
+// Render wind view with enhanced information
+function renderWind(wind) {
+    let html = '<h3>🌬️ Wind System</h3>';
+    html += '<div>Direction: ' + (wind.direction * 180 / Math.PI).toFixed(1) + '°</div>';
+    html += '<div>Strength: ' + wind.strength.toFixed(2) + '</div>';
+    html += '<div>Turbulence: ' + wind.turbulence_level.toFixed(2) + '</div>';
+    html += '<div>Weather: ' + wind.weather_pattern + '</div>';
+    html += '<div>Pollen Count: ' + wind.pollen_count + '</div>';
+
+    // Add seed dispersal information
+    html += '<h4>🌱 Seed Dispersal System</h4>';
+    html += '<div>Active Seeds: ' + (wind.seed_count || 0) + '</div>';
+    html += '<div>Seed Banks: ' + (wind.seed_banks || 0) + '</div>';
+    html += '<div>Germination Events: ' + (wind.germination_events || 0) + '</div>';
+    html += '<div>Dormancy Activations: ' + (wind.dormancy_activations || 0) + '</div>';
+
+    // Display dispersal statistics
+    if (wind.dispersal_stats) {
+        html += '<h4>🎯 Dispersal Methods</h4>';
+        if (wind.dispersal_stats.dispersal_wind) html += '<div>Wind Dispersal: ' + wind.dispersal_stats.dispersal_wind + '</div>';
+        if (wind.dispersal_stats.dispersal_animal) html += '<div>Animal Dispersal: ' + wind.dispersal_stats.dispersal_animal + '</div>';
+        if (wind.dispersal_stats.dispersal_explosive) html += '<div>Explosive Dispersal: ' + wind.dispersal_stats.dispersal_explosive + '</div>';
+        if (wind.dispersal_stats.dispersal_gravity) html += '<div>Gravity Dispersal: ' + wind.dispersal_stats.dispersal_gravity + '</div>';
+        if (wind.dispersal_stats.dispersal_water) html += '<div>Water Dispersal: ' + wind.dispersal_stats.dispersal_water + '</div>';
+
+        // Display seed type statistics
+        if (wind.dispersal_stats.active_seeds_by_type) {
+            html += '<h4>🎯 Active Seed Types</h4>';
+            const seedTypes = wind.dispersal_stats.active_seeds_by_type;
+            for (const [type, count] of Object.entries(seedTypes)) {
+                html += '<div>' + type.replace(/_/g, ' ').toUpperCase() + ': ' + count + '</div>';
+            }
+        }
+    }
+
+    // Add detailed analysis
+    html += '<h4>🌪️ Wind Analysis:</h4>';
+    const windDirection = (wind.direction * 180 / Math.PI + 360) % 360;
+    let directionName = '';
+    if (windDirection < 45 || windDirection >= 315) directionName = 'North';
+    else if (windDirection < 135) directionName = 'East';
+    else if (windDirection < 225) directionName = 'South';
+    else directionName = 'West';
+
+    html += '<div>Cardinal Direction: ' + directionName + '</div>';
+
+    // Wind strength analysis
+    if (wind.strength < 0.2) {
+        html += '<div style="color: lightblue;">🌿 Gentle breeze - minimal pollen dispersal</div>';
+    } else if (wind.strength < 0.5) {
+        html += '<div style="color: yellow;">💨 Moderate wind - good for plant reproduction</div>';
+    } else if (wind.strength < 0.8) {
+        html += '<div style="color: orange;">🌪️ Strong wind - high pollen dispersal</div>';
+    } else {
+        html += '<div style="color: red;">⛈️ Storm conditions - disrupted ecosystem</div>';
+    }
+
+    // Pollen dispersal analysis
+    html += '<h4>🌸 Pollen Dispersal:</h4>';
+    if (wind.pollen_count === 0) {
+        html += '<div>No active pollen dispersal</div>';
+    } else if (wind.pollen_count < 10) {
+        html += '<div style="color: lightgreen;">Low pollen activity</div>';
+    } else if (wind.pollen_count < 30) {
+        html += '<div style="color: yellow;">Moderate pollen dispersal</div>';
+    } else {
+        html += '<div style="color: orange;">High pollen activity - peak breeding season</div>';
+    }
+
+    return html;
+}
+
+// Control functions
+function togglePause() {
+    isPaused = !isPaused;
+    const btn = document.getElementById('pause-btn');
+    btn.textContent = isPaused ? '▶ Resume' : '⏸ Pause';
+
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: 'toggle_pause'}));
+    }
+}
+
+function increaseSpeed() {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: 'increase_speed'}));
+    }
+}
+
+function decreaseSpeed() {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: 'decrease_speed'}));
+    }
+}
+
+function setUnlimitedSpeed(enabled) {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: 'set_unlimited_speed', data: {enabled: enabled}}));
+    }
+}
+
+function setNightShading(enabled) {
+    nightShading = enabled;
+    localStorage.setItem('evosim-night-shading', enabled ? 'on' : 'off');
+}
+
+// Ambient sound, synthesised in the browser from the soundscape the server sends. It starts
+// muted; browsers only let a page make sound after the user has interacted with it.
+let audioEnabled = localStorage.getItem('evosim-audio') === 'on';
+let audioVolume = parseFloat(localStorage.getItem('evosim-audio-volume') || '0.5');
+let audioEngine = null;
+let lastAudioCueTick = -1;
+
+function initAudioControls() {
+    document.getElementById('audio-volume').value = audioVolume;
+    updateAudioToggle();
+    if (audioEnabled) {
+        // Wait for the first click to be allowed to play
+        document.addEventListener('click', startAudioEngine, {once: true});
+    }
+}
+
+function updateAudioToggle() {
+    const button = document.getElementById('audio-toggle');
+    button.textContent = audioEnabled ? '🔊' : '🔇';
+    button.title = audioEnabled ? 'Mute the ambient sound' : 'Play the ambient sound of the world';
+}
+
+function toggleAudio() {
+    audioEnabled = !audioEnabled;
+    localStorage.setItem('evosim-audio', audioEnabled ? 'on' : 'off');
+    updateAudioToggle();
+    if (audioEnabled) {
+        startAudioEngine();
+    } else if (audioEngine) {
+        audioEngine.context.suspend();
+    }
+}
+
+function setAudioVolume(volume) {
+    audioVolume = parseFloat(volume);
+    localStorage.setItem('evosim-audio-volume', String(audioVolume));
+    if (audioEngine) {
+        audioEngine.master.gain.setTargetAtTime(audioVolume, audioEngine.context.currentTime, 0.1);
+    }
+}
+
+// A looping bed of filtered noise, silent until the soundscape raises it
+function noiseBed(context, noise, type, frequency, destination) {
+    const source = context.createBufferSource();
+    source.buffer = noise;
+    source.loop = true;
+    const filter = context.createBiquadFilter();
+    filter.type = type;
+    filter.frequency.value = frequency;
+    const gain = context.createGain();
+    gain.gain.value = 0;
+    source.connect(filter).connect(gain).connect(destination);
+    source.start();
+    return gain;
+}
+
+function startAudioEngine() {
+    if (!audioEnabled) {
+        return;
+    }
+    if (!audioEngine) {
+        const AudioContextType = window.AudioContext || window.webkitAudioContext;
+        if (!AudioContextType) {
+            return;
+        }
+        const context = new AudioContextType();
+        const noise = context.createBuffer(1, context.sampleRate * 2, context.sampleRate);
+        const samples = noise.getChannelData(0);
+        for (let i = 0; i < samples.length; i++) {
+            samples[i] = Math.random() * 2 - 1;
+        }
+        const master = context.createGain();
+        master.gain.value = audioVolume;
+        master.connect(context.destination);
+        audioEngine = {
+            context: context,
+            noise: noise,
+            master: master,
+            rain: noiseBed(context, noise, 'highpass', 1200, master),
+            wind: noiseBed(context, noise, 'lowpass', 350, master)
+        };
+    }
+    audioEngine.context.resume();
+}
+
+// A bird's chirp, a quick rising whistle
+function playChirp(engine, at) {
+    const context = engine.context;
+    const oscillator = context.createOscillator();
+    const gain = context.createGain();
+    const pitch = 2200 + Math.random() * 1800;
+    oscillator.frequency.setValueAtTime(pitch, at);
+    oscillator.frequency.exponentialRampToValueAtTime(pitch * 1.4, at + 0.08);
+    gain.gain.setValueAtTime(0, at);
+    gain.gain.linearRampToValueAtTime(0.08, at + 0.01);
+    gain.gain.exponentialRampToValueAtTime(0.001, at + 0.12);
+    oscillator.connect(gain).connect(engine.master);
+    oscillator.start(at);
+    oscillator.stop(at + 0.15);
+}
+
+// A clash of battle, a burst of noise panned to where it happened
+function playClash(engine, at, x, level) {
+    const context = engine.context;
+    const source = context.createBufferSource();
+    source.buffer = engine.noise;
+    const filter = context.createBiquadFilter();
+    filter.type = 'bandpass';
+    filter.frequency.value = 250 + Math.random() * 300;
+    const gain = context.createGain();
+    gain.gain.setValueAtTime(level, at);
+    gain.gain.exponentialRampToValueAtTime(0.001, at + 0.35);
+    let output = gain;
+    if (context.createStereoPanner) {
+        const panner = context.createStereoPanner();
+        panner.pan.value = x * 2 - 1;
+        gain.connect(panner);
+        output = panner;
+    }
+    source.connect(filter).connect(gain);
+    output.connect(engine.master);
+    source.start(at, Math.random());
+    source.stop(at + 0.4);
+}
+
+function playAmbientAudio(mix) {
+    const engine = audioEngine;
+    if (!audioEnabled || !engine || engine.context.state !== 'running') {
+        lastAudioCueTick = mix.tick;
+        return;
+    }
+    const now = engine.context.currentTime;
+    engine.rain.gain.setTargetAtTime(mix.rain * 0.25, now, 0.5);
+    engine.wind.gain.setTargetAtTime(mix.wind * 0.4, now, 0.5);
+
+    // Spread the second's bird calls over the second to come
+    let calls = Math.floor(mix.bird_calls);
+    if (Math.random() < mix.bird_calls - calls) {
+        calls++;
+    }
+    for (let i = 0; i < calls; i++) {
+        playChirp(engine, now + Math.random());
+    }
+
+    // Fights not heard yet, and the distant din of any colony war
+    const fresh = mix.cues.filter(cue => cue.tick > lastAudioCueTick).slice(-4);
+    fresh.forEach(function(cue, i) {
+        playClash(engine, now + i * 0.2 + Math.random() * 0.1, cue.x, 0.3);
+    });
+    if (mix.wars > 0) {
+        playClash(engine, now + Math.random(), 0.5, Math.min(0.2, 0.05 * mix.wars));
+    }
+    lastAudioCueTick = Math.max(lastAudioCueTick, mix.tick);
+}
+
+function setMaxCPU(percent) {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: 'set_max_cpu', data: {percent: parseFloat(percent)}}));
+    }
+}
+
+function zoomIn() {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: 'zoom_in'}));
+    }
+}
+
+function zoomOut() {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: 'zoom_out'}));
+    }
+}
+
+// Initialize viewport controls
+function initViewportControls() {
+    document.getElementById('night-shading').checked = nightShading;
+
+    // Keyboard controls for panning
+    document.addEventListener('keydown', function(event) {
+        // Only handle pan controls if no input is focused
+        if (document.activeElement.tagName === 'INPUT' || document.activeElement.tagName === 'TEXTAREA') {
+            return;
+        }
+
+        if (event.ctrlKey || event.metaKey) {
+            const key = event.key.toLowerCase();
+            if (key === 'z' && !event.shiftKey) {
+                event.preventDefault();
+                undoIntervention();
+                return;
+            }
+            if (key === 'y' || (key === 'z' && event.shiftKey)) {
+                event.preventDefault();
+                redoIntervention();
+                return;
+            }
+        }
+
+        const panStep = 3;
+        switch(event.key) {
+            case 'ArrowUp':
+                event.preventDefault();
+                panViewport(0, -panStep);
+                break;
+            case 'ArrowDown':
+                event.preventDefault();
+                panViewport(0, panStep);
+                break;
+            case 'ArrowLeft':
+                event.preventDefault();
+                panViewport(-panStep, 0);
+                break;
+            case 'ArrowRight':
+                event.preventDefault();
+                panViewport(panStep, 0);
+                break;
+            case '+':
+            case '=':
+                event.preventDefault();
+                zoomIn();
+                break;
+            case '-':
+            case '_':
+                event.preventDefault();
+                zoomOut();
+                break;
+            case '0':
+                event.preventDefault();
+                resetViewport();
+                break;
+        }
+    });
+
+    // Mouse wheel zoom
+    document.getElementById('grid-view').addEventListener('wheel', function(event) {
+        event.preventDefault();
+        if (event.deltaY < 0) {
+            zoomIn();
+        } else {
+            zoomOut();
+        }
+    });
+
+    // Mouse drag to pan
+    let isDragging = false;
+    let dragStartX = 0;
+    let dragStartY = 0;
+
+    document.getElementById('grid-view').addEventListener('mousedown', function(event) {
+        event.preventDefault();
+        // Shift+drag box-selects creatures while the species controls are open
+        const cell = gridCellAt(event);
+        if (event.shiftKey && cell && document.getElementById('control-species-form').style.display === 'block') {
+            boxSelection = {start: cell, end: cell};
+            return;
+        }
+        isDragging = true;
+        dragStartX = event.clientX;
+        dragStartY = event.clientY;
+        document.body.style.userSelect = 'none'; // Prevent text selection
+    });
+
+    document.addEventListener('mousemove', function(event) {
+        if (boxSelection) {
+            const cell = gridCellAt(event);
+            if (cell && (cell.x !== boxSelection.end.x || cell.y !== boxSelection.end.y)) {
+                boxSelection.end = cell;
+                markBoxSelection();
+            }
+            return;
+        }
+        if (!isDragging) return;
+
+        const deltaX = Math.floor((event.clientX - dragStartX) / 10); // Scale down the movement
+        const deltaY = Math.floor((event.clientY - dragStartY) / 10);
+
+        if (Math.abs(deltaX) > 0 || Math.abs(deltaY) > 0) {
+            panViewport(-deltaX, -deltaY); // Inverted because dragging right should pan left
+            dragStartX = event.clientX;
+            dragStartY = event.clientY;
+        }
+    });
+
+    document.addEventListener('mouseup', function(event) {
+        if (boxSelection) {
+            selectGroup(boxSelection.start, boxSelection.end);
+            boxSelection = null;
+        }
+        isDragging = false;
+        document.body.style.userSelect = ''; // Re-enable text selection
+    });
+}
+
+function panViewport(deltaX, deltaY) {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({
+            action: 'pan',
+            data: {
+                deltaX: deltaX,
+                deltaY: deltaY
+            }
+        }));
+    }
+}
+
+function resetViewport() {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: 'reset_viewport'}));
+    }
+}
+
+function resetSimulation() {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: 'reset'}));
+    }
+}
+
+function saveState() {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: 'save_state'}));
+    }
+}
+
+function loadState() {
+    document.getElementById('load-file').click();
+}
+
+function fastForwardSave() {
+    document.getElementById('fast-forward-file').click();
+}
+
+function handleFastForwardFile(event) {
+    const file = event.target.files[0];
+    event.target.value = '';
+    if (!file) {
+        return;
+    }
+    file.text().then(function(text) {
+        const state = JSON.parse(text);
+        const target = parseInt(prompt('Fast-forward "' + file.name + '" (tick ' + state.tick + ') to tick:', String(state.tick + 1000)), 10);
+        if (isNaN(target)) {
+            return;
+        }
+        showToast('⏭ Fast-forwarding', file.name + ' to tick ' + target, 'high');
+        return registryRequest('api/fast-forward', {
+            method: 'POST',
+            headers: {'Content-Type': 'application/json'},
+            body: JSON.stringify({state: state, target_tick: target})
+        }).then(function(result) {
+            const stopped = result.stopped_by ? ' (stopped by ' + result.stopped_by + ')' : '';
+            showToast('⏭ Fast-forward Done', 'Reached tick ' + result.to_tick + ' in ' + result.elapsed_ms + ' ms' + stopped, 'high');
+        });
+    }).catch(function(error) {
+        alert('Fast-forward failed: ' + error.message);
+    });
+}
+
+function toggleRegistry() {
+    const panel = document.getElementById('registry-panel');
+    panel.style.display = panel.style.display === 'none' ? 'block' : 'none';
+    if (panel.style.display === 'block') {
+        refreshRegistry();
+    }
+}
+
+function registryRequest(url, options) {
+    return fetch(url, options).then(function(response) {
+        if (!response.ok) {
+            return response.text().then(function(text) { throw new Error(text); });
+        }
+        return response.json();
+    });
+}
+
+function refreshRegistry() {
+    const kind = document.getElementById('registry-kind').value;
+    const list = document.getElementById('registry-list');
+    list.textContent = 'Loading...';
+    registryRequest('api/registry/list?kind=' + encodeURIComponent(kind)).then(function(entries) {
+        list.innerHTML = '';
+        if (!entries || entries.length === 0) {
+            list.textContent = 'No shared files yet';
+            return;
+        }
+        entries.forEach(function(entry) {
+            const row = document.createElement('div');
+            const label = document.createElement('span');
+            label.textContent = entry.name + ' by ' + (entry.author || 'unknown') + ' ';
+            const button = document.createElement('button');
+            button.textContent = '⬇ Import';
+            button.onclick = function() { importFromRegistry(entry.id); };
+            row.appendChild(label);
+            row.appendChild(button);
+            list.appendChild(row);
+        });
+    }).catch(function(error) {
+        list.textContent = 'Registry unavailable: ' + error.message;
+    });
+}
+
+function importFromRegistry(id) {
+    registryRequest('api/registry/import?id=' + encodeURIComponent(id), {method: 'POST'}).then(function(result) {
+        alert('Imported ' + result.entry.name);
+    }).catch(function(error) {
+        alert('Import failed: ' + error.message);
+    });
+}
+
+function shareToRegistry() {
+    const kind = document.getElementById('registry-kind').value;
+    const request = {kind: kind};
+    if (kind === 'creature') {
+        request.entity_id = parseInt(prompt('Entity ID to share:'), 10);
+        const mate = prompt('Optional mate ID for a breeding pair:');
+        if (mate) {
+            request.mate_id = parseInt(mate, 10);
+        }
+    }
+    request.name = prompt('Name:') || '';
+    request.description = prompt('Description:') || '';
+    request.author = prompt('Author:') || '';
+    registryRequest('api/registry/upload', {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify(request)
+    }).then(function() {
+        refreshRegistry();
+    }).catch(function(error) {
+        alert('Share failed: ' + error.message);
+    });
+}
+
+// Alert preferences are kept per browser so each observer can choose what interrupts them
+const defaultAlertPreferences = {enabled: true, minSeverity: 'high', sound: false};
+let alertPreferences = loadAlertPreferences();
+let lastAlertID = null; // Unset until the first update so alerts from before connecting aren't replayed
+let audioContext = null;
+
+function loadAlertPreferences() {
+    try {
+        return Object.assign({}, defaultAlertPreferences, JSON.parse(localStorage.getItem('evosim-alerts') || '{}'));
+    } catch (e) {
+        return Object.assign({}, defaultAlertPreferences);
+    }
+}
+
+function toggleAlertSettings() {
+    const panel = document.getElementById('alerts-panel');
+    panel.style.display = panel.style.display === 'none' ? 'block' : 'none';
+    document.getElementById('alerts-enabled').checked = alertPreferences.enabled;
+    document.getElementById('alerts-min-severity').value = alertPreferences.minSeverity;
+    document.getElementById('alerts-sound').checked = alertPreferences.sound;
+}
+
+function saveAlertPreferences() {
+    alertPreferences = {
+        enabled: document.getElementById('alerts-enabled').checked,
+        minSeverity: document.getElementById('alerts-min-severity').value,
+        sound: document.getElementById('alerts-sound').checked
+    };
+    localStorage.setItem('evosim-alerts', JSON.stringify(alertPreferences));
+}
+
+function processAlerts(alerts) {
+    if (!alerts) {
+        return;
+    }
+    const latestID = alerts.length > 0 ? alerts[alerts.length - 1].id : 0;
+    if (lastAlertID === null || latestID < lastAlertID) {
+        // First update, or the event bus was cleared by a reset or load
+        lastAlertID = latestID;
+        return;
+    }
+    alerts.forEach(function(alert) {
+        if (alert.id <= lastAlertID) {
+            return;
+        }
+        lastAlertID = alert.id;
+        if (!alertPreferences.enabled || (alertPreferences.minSeverity === 'critical' && alert.severity !== 'critical')) {
+            return;
+        }
+        showToast(formatAlertTitle(alert), 'Tick ' + alert.tick + ': ' + alert.description, alert.severity, 'ticks/' + alert.tick + '-' + alert.tick);
+    });
+}
+
+function formatAlertTitle(alert) {
+    const icons = {extinction: '⚰️', war_declared: '⚔️', disaster: '☄️', environmental_event_start: '🌪️', speciation: '🧬', breakpoint: '🛑', resource_leak_suspected: '💾'};
+    const name = alert.type.replace(/_/g, ' ');
+    return (icons[alert.type] || '⚠️') + ' ' + name.charAt(0).toUpperCase() + name.slice(1);
+}
+
+// Clicking a toast dismisses it and, when it has a link, follows it
+function showToast(title, message, severity, link) {
+    const toast = document.createElement('div');
+    toast.className = 'toast ' + severity;
+    const heading = document.createElement('div');
+    heading.className = 'toast-title';
+    heading.textContent = title;
+    const body = document.createElement('div');
+    body.textContent = message;
+    toast.appendChild(heading);
+    toast.appendChild(body);
+    toast.onclick = function() {
+        toast.remove();
+        if (link) {
+            navigateTo(link);
+        }
+    };
+
+    const container = document.getElementById('toast-container');
+    container.appendChild(toast);
+    while (container.children.length > 5) {
+        container.removeChild(container.firstChild);
+    }
+    setTimeout(function() { toast.remove(); }, severity === 'critical' ? 10000 : 6000);
+
+    if (alertPreferences.sound) {
+        playAlertSound(severity);
+    }
+}
+
+function playAlertSound(severity) {
+    try {
+        audioContext = audioContext || new (window.AudioContext || window.webkitAudioContext)();
+        const oscillator = audioContext.createOscillator();
+        const gain = audioContext.createGain();
+        oscillator.frequency.value = severity === 'critical' ? 440 : 660;
+        gain.gain.setValueAtTime(0.1, audioContext.currentTime);
+        gain.gain.exponentialRampToValueAtTime(0.001, audioContext.currentTime + 0.4);
+        oscillator.connect(gain);
+        gain.connect(audioContext.destination);
+        oscillator.start();
+        oscillator.stop(audioContext.currentTime + 0.4);
+    } catch (e) {
+        console.log('Alert sound unavailable:', e);
+    }
+}
+
+function toggleBreakpoints() {
+    const panel = document.getElementById('breakpoints-panel');
+    panel.style.display = panel.style.display === 'none' ? 'block' : 'none';
+    if (panel.style.display === 'block') {
+        refreshBreakpoints();
+    }
+}
+
+function refreshBreakpoints() {
+    const list = document.getElementById('breakpoint-list');
+    registryRequest('api/breakpoints').then(function(result) {
+        list.innerHTML = '';
+        if (!result.breakpoints || result.breakpoints.length === 0) {
+            list.textContent = 'No breakpoints set';
+            return;
+        }
+        result.breakpoints.forEach(function(bp) {
+            const row = document.createElement('div');
+            const label = document.createElement('span');
+            label.textContent = bp.spec + (bp.hit ? ' (hit at tick ' + bp.hit_tick + ': ' + bp.hit_reason + ') ' : ' (armed) ');
+            const button = document.createElement('button');
+            button.textContent = '✖';
+            button.onclick = function() { removeBreakpoint(bp.id); };
+            row.appendChild(label);
+            row.appendChild(button);
+            list.appendChild(row);
+        });
+    }).catch(function(error) {
+        list.textContent = 'Failed to load breakpoints: ' + error.message;
+    });
+}
+
+function addBreakpoint() {
+    const input = document.getElementById('breakpoint-spec');
+    registryRequest('api/breakpoints', {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify({spec: input.value})
+    }).then(function() {
+        input.value = '';
+        refreshBreakpoints();
+    }).catch(function(error) {
+        alert('Invalid breakpoint: ' + error.message);
+    });
+}
+
+function removeBreakpoint(id) {
+    registryRequest('api/breakpoints?id=' + id, {method: 'DELETE'}).then(refreshBreakpoints);
+}
+
+function toggleGamePanel() {
+    const panel = document.getElementById('game-panel');
+    panel.style.display = panel.style.display === 'none' ? 'block' : 'none';
+}
+
+// Start a competitive game between every player that has a species
+function startGame() {
+    const settings = {condition: document.getElementById('game-condition').value};
+    const target = parseFloat(document.getElementById('game-target').value);
+    const timeLimit = parseInt(document.getElementById('game-time-limit').value);
+    if (!isNaN(target)) {
+        settings.target = target;
+    }
+    if (!isNaN(timeLimit)) {
+        settings.time_limit = timeLimit;
+    }
+    registryRequest('api/game', {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify(settings)
+    }).then(function(game) {
+        showToast('🏆 Game Started', 'Game #' + game.id + ': ' + game.settings.condition + ' victory', 'high');
+    }).catch(function(error) {
+        alert('Could not start game: ' + error.message);
+    });
+}
+
+function abortGame() {
+    registryRequest('api/game', {method: 'DELETE'}).catch(function(error) {
+        alert('Could not abort game: ' + error.message);
+    });
+}
+
+// Start the last game again with the species as they evolved
+function rematchGame() {
+    hideSpeciesDetail();
+    registryRequest('api/game/rematch', {method: 'POST'}).then(function(game) {
+        showToast('🏆 Rematch Started', 'Game #' + game.id + ' with evolved species', 'high');
+    }).catch(function(error) {
+        alert('Could not start rematch: ' + error.message);
+    });
+}
+
+function formatGameScore(condition, score) {
+    if (condition === 'population' || condition === 'territory') {
+        return (score * 100).toFixed(0) + '%';
+    }
+    if (condition === 'tech') {
+        return 'tech ' + score.toFixed(0);
+    }
+    return score.toFixed(0);
+}
+
+// Keep the scoreboard current and show the summary when a watched game ends
+function updateGame(game) {
+    currentGame = game || null;
+    const status = document.getElementById('game-status');
+    if (!game) {
+        if (status) {
+            status.textContent = 'No competitive game yet';
+        }
+        return;
+    }
+    if (status) {
+        let html = '<strong>Game #' + game.id + '</strong> (' + game.settings.condition + ', ' + game.status;
+        if (game.status === 'running' && game.cataclysm_tick) {
+            html += ', cataclysm at tick ' + game.cataclysm_tick;
+        }
+        html += ')';
+        if (game.status !== 'running') {
+            html += ' <button onclick="showGameSummary()">📜 Summary</button>';
+        }
+        html += '<ol>';
+        game.standings.forEach(function(standing) {
+            html += '<li>' + escapeHTML(standing.name) + ': ' + formatGameScore(game.settings.condition, standing.score) +
+                ' (' + standing.population + ' alive)' + (standing.eliminated ? ' ☠ eliminated' : '') + '</li>';
+        });
+        status.innerHTML = html + '</ol>';
+    }
+    if (game.status === 'running') {
+        watchedGameID = game.id;
+    } else if (game.id === watchedGameID) {
+        watchedGameID = null;
+        if (game.status === 'finished') {
+            showGameSummary();
+        }
+    }
+}
+
+// End-of-game summary in the detail modal
+function showGameSummary() {
+    const game = currentGame;
+    if (!game) {
+        return;
+    }
+    ensureSpeciesModalExists();
+    const winner = game.standings.find(standing => standing.player_id === game.winner);
+    let html = '<h2>🏆 Game #' + game.id + ' Over</h2>';
+    html += '<div><strong>' + (winner ? escapeHTML(winner.name) + ' wins' : 'Draw') + '</strong>: ' + escapeHTML(game.reason || '') + '</div>';
+    html += '<div>' + game.settings.condition + ' victory, ticks ' + game.start_tick + '–' + game.end_tick + ' (' + (game.end_tick - game.start_tick) + ' ticks)</div>';
+    html += '<table style="width: 100%; margin-top: 10px;"><tr><th>#</th><th>Player</th><th>Species</th><th>Score</th><th>Alive</th><th>Peak</th><th>Territory</th><th>Tech</th><th>Fate</th></tr>';
+    game.standings.forEach(function(standing, index) {
+        html += '<tr><td>' + (index + 1) + '</td><td>' + escapeHTML(standing.name) + '</td><td>' + escapeHTML(standing.species.join(', ')) + '</td>' +
+            '<td>' + formatGameScore(game.settings.condition, standing.score) + '</td><td>' + standing.population + '</td><td>' + standing.peak_population + '</td>' +
+            '<td>' + (standing.territory * 100).toFixed(1) + '%</td><td>' + standing.tech_level + '</td>' +
+            '<td>' + (standing.eliminated ? 'Eliminated at tick ' + standing.eliminated_tick : 'Survived') + '</td></tr>';
+    });
+    html += '</table>';
+    if (game.status === 'finished') {
+        html += '<div style="margin-top: 10px;"><button onclick="rematchGame()">🔁 Rematch with evolved species</button></div>';
+    }
+    document.getElementById('species-detail-content').innerHTML = html;
+    document.getElementById('species-detail-modal').style.display = 'block';
+    document.getElementById('species-modal-overlay').style.display = 'block';
+}
+
+function toggleTutorialPanel() {
+    const panel = document.getElementById('tutorial-panel');
+    panel.style.display = panel.style.display === 'none' ? 'block' : 'none';
+    const select = document.getElementById('tutorial-scenario');
+    if (panel.style.display === 'block' && select.options.length === 0) {
+        registryRequest('api/tutorial').then(function(response) {
+            response.scenarios.forEach(function(scenario) {
+                const option = document.createElement('option');
+                option.value = scenario.id;
+                option.textContent = scenario.name;
+                option.title = scenario.description;
+                select.appendChild(option);
+            });
+        });
+    }
+}
+
+// Reset the world into the chosen scenario
+function startTutorial() {
+    const scenario = document.getElementById('tutorial-scenario').value;
+    if (!scenario || !confirm('Starting a tutorial resets the world. Continue?')) {
+        return;
+    }
+    registryRequest('api/tutorial', {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify({scenario: scenario})
+    }).then(function(tutorial) {
+        showToast('🎓 ' + tutorial.name, tutorial.steps[0].instructions, 'high');
+    }).catch(function(error) {
+        alert('Could not start tutorial: ' + error.message);
+    });
+}
+
+function stopTutorial() {
+    registryRequest('api/tutorial', {method: 'DELETE'}).catch(function(error) {
+        alert('Could not stop tutorial: ' + error.message);
+    });
+}
+
+// Do what the current step asks, such as lighting a wildfire on its target cell
+function runTutorialAction() {
+    const step = currentTutorial && currentTutorial.status === 'running' ? currentTutorial.steps[currentTutorial.current] : null;
+    if (!step || !step.action) {
+        return;
+    }
+    registryRequest('api/operator/events', {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify({type: step.action, x: step.target.x, y: step.target.y})
+    }).catch(function(error) {
+        alert('Could not start ' + step.action + ': ' + error.message);
+    });
+}
+
+// Show the scenario's steps and announce each one the simulation completes
+function updateTutorial(tutorial) {
+    currentTutorial = tutorial || null;
+    const status = document.getElementById('tutorial-status');
+    if (!tutorial) {
+        return;
+    }
+    if (status) {
+        let html = '<strong>' + escapeHTML(tutorial.name) + '</strong> (' + tutorial.status + ')<ol>';
+        tutorial.steps.forEach(function(step, index) {
+            html += '<li>' + (step.done ? '✅ ' : index === tutorial.current ? '👉 ' : '') + '<strong>' + escapeHTML(step.title) + '</strong>';
+            if (step.done) {
+                html += ' <em>tick ' + step.done_tick + ': ' + escapeHTML(step.observation) + '</em>';
+            } else if (index === tutorial.current && tutorial.status === 'running') {
+                html += '<div>' + escapeHTML(step.instructions) + '</div>';
+                if (step.action) {
+                    html += '<button onclick="runTutorialAction()">▶ Start ' + escapeHTML(step.action) +
+                        ' at ' + step.target.x + ',' + step.target.y + '</button>';
+                }
+            }
+            html += '</li>';
+        });
+        status.innerHTML = html + '</ol>';
+    }
+
+    const progress = tutorial.scenario + ':' + tutorial.start_tick + ':' + tutorial.current;
+    if (tutorialProgress && progress !== tutorialProgress && tutorial.current > 0 && tutorialProgress.startsWith(tutorial.scenario + ':' + tutorial.start_tick + ':')) {
+        const done = tutorial.steps[tutorial.current - 1];
+        if (tutorial.status === 'completed') {
+            showToast('🎓 Tutorial Complete', done.observation, 'high');
+        } else {
+            showToast('✅ ' + done.title, done.observation + ' Next: ' + tutorial.steps[tutorial.current].title, 'medium');
+        }
+    }
+    tutorialProgress = progress;
+}
+
+// Refresh the open gallery when a new snapshot is captured
+function updateGallery(latest) {
+    if (latest !== latestSnapshot) {
+        latestSnapshot = latest;
+        if (document.getElementById('gallery-panel').style.display === 'block') {
+            refreshGallery();
+        }
+    }
+}
+
+function toggleGalleryPanel() {
+    const panel = document.getElementById('gallery-panel');
+    panel.style.display = panel.style.display === 'none' ? 'block' : 'none';
+    if (panel.style.display === 'block') {
+        refreshGallery();
+    }
+}
+
+// List the snapshots captured on notable events, newest first
+function refreshGallery() {
+    const list = document.getElementById('gallery-list');
+    registryRequest('api/gallery').then(function(gallery) {
+        if (gallery.snapshots.length === 0) {
+            list.textContent = 'Snapshots appear here on the first tool use, speciations and wars';
+            return;
+        }
+        let html = '';
+        gallery.snapshots.forEach(function(snapshot) {
+            const stats = snapshot.stats;
+            const image = 'gallery/' + encodeURIComponent(snapshot.image);
+            html += '<figure style="display: inline-block; margin: 5px; vertical-align: top; max-width: 240px;">' +
+                '<a href="' + image + '" target="_blank"><img src="' + image + '" alt="' + escapeHTML(snapshot.title) +
+                '" style="width: 240px; image-rendering: pixelated;"></a>' +
+                '<figcaption><strong>' + escapeHTML(snapshot.title) + '</strong> · tick ' + snapshot.tick + '<br>' +
+                escapeHTML(snapshot.description) + '<br>' +
+                stats.creatures + ' creatures of ' + stats.species + ' species, ' + stats.plants + ' plants, ' +
+                stats.tribes + ' tribes, ' + stats.tools + ' tools · ' + escapeHTML(stats.season) + ' ' +
+                escapeHTML(stats.time_of_day) + '</figcaption></figure>';
+        });
+        list.innerHTML = html;
+    }).catch(function(error) {
+        list.textContent = 'Could not load the gallery: ' + error.message;
+    });
+}
+
+function captureSnapshot() {
+    const title = prompt('Snapshot title (leave empty for the tick):', '');
+    if (title === null) {
+        return;
+    }
+    registryRequest('api/gallery', {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify({title: title})
+    }).then(function(snapshot) {
+        showToast('📸 ' + snapshot.title, 'Snapshot captured at tick ' + snapshot.tick, 'medium');
+        refreshGallery();
+    }).catch(function(error) {
+        alert('Could not capture a snapshot: ' + error.message);
+    });
+}
+
+function togglePredictionsPanel() {
+    const panel = document.getElementById('predictions-panel');
+    panel.style.display = panel.style.display === 'none' ? 'block' : 'none';
+    document.getElementById('spectator-name').value = localStorage.getItem('evosim-spectator') || '';
+    if (panel.style.display === 'block') {
+        renderPredictionRounds();
+        refreshPredictionLeaderboard();
+    }
+}
+
+function saveSpectatorName() {
+    localStorage.setItem('evosim-spectator', document.getElementById('spectator-name').value.trim());
+    refreshPredictionLeaderboard();
+}
+
+// Ask spectators a question about every living species
+function openPrediction() {
+    const request = {kind: document.getElementById('prediction-kind').value};
+    const duration = parseInt(document.getElementById('prediction-duration').value);
+    if (!isNaN(duration)) {
+        request.duration = duration;
+    }
+    registryRequest('api/predictions', {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify(request)
+    }).catch(function(error) {
+        alert('Could not open prediction: ' + error.message);
+    });
+}
+
+function submitPrediction(roundID) {
+    const spectator = localStorage.getItem('evosim-spectator') || '';
+    if (!spectator) {
+        alert('Enter a spectator name first');
+        return;
+    }
+    registryRequest('api/predictions/predict', {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify({
+            round_id: roundID,
+            spectator: spectator,
+            option: document.getElementById('prediction-option-' + roundID).value,
+            stake: parseInt(document.getElementById('prediction-stake-' + roundID).value)
+        })
+    }).then(function(result) {
+        document.getElementById('spectator-points').textContent = result.points + ' points';
+        refreshPredictionLeaderboard();
+    }).catch(function(error) {
+        alert('Could not predict: ' + error.message);
+    });
+}
+
+// Track open rounds from each frame; rounds are re-rendered only when they change so
+// spectators are not interrupted while choosing
+function updatePredictions(rounds) {
+    rounds = rounds || [];
+    const key = rounds.map(round => round.id + ':' + round.pool + ':' + (lastTick > round.bets_close_tick)).join(',');
+    const resolved = openPredictions.some(round => !rounds.find(open => open.id === round.id));
+    openPredictions = rounds;
+    rounds.forEach(function(round) {
+        if (round.id > latestPredictionID) {
+            latestPredictionID = round.id;
+            showToast('🔮 New Prediction', round.question, 'high');
+        }
+    });
+    if (key === predictionsKey) {
+        return;
+    }
+    predictionsKey = key;
+    renderPredictionRounds();
+    if (resolved) {
+        refreshPredictionLeaderboard();
+    }
+}
+
+function renderPredictionRounds() {
+    const container = document.getElementById('prediction-rounds');
+    if (!container || document.getElementById('predictions-panel').style.display === 'none') {
+        return;
+    }
+    if (openPredictions.length === 0) {
+        container.textContent = 'No open predictions';
+        return;
+    }
+    const spectator = localStorage.getItem('evosim-spectator') || '';
+    let html = '';
+    openPredictions.forEach(function(round) {
+        const predictions = round.predictions || [];
+        const mine = predictions.find(prediction => prediction.spectator === spectator);
+        html += '<div style="margin: 5px 0;"><strong>' + escapeHTML(round.question) + '</strong> ' +
+            '(pool ' + round.pool + ' points from ' + predictions.length + ' spectators, answered at tick ' + round.resolve_tick + ')<br>';
+        if (mine) {
+            html += 'You staked ' + mine.stake + ' on ' + escapeHTML(mine.option);
+        } else if (lastTick > round.bets_close_tick) {
+            html += 'Predictions closed at tick ' + round.bets_close_tick;
+        } else {
+            html += '<select id="prediction-option-' + round.id + '">';
+            round.options.forEach(function(option) {
+                html += '<option value="' + escapeHTML(option) + '">' + escapeHTML(option) + '</option>';
+            });
+            html += '</select> <input type="number" id="prediction-stake-' + round.id + '" value="10" min="1" size="4"> points ' +
+                '<button onclick="submitPrediction(' + round.id + ')">🔮 Predict</button> (until tick ' + round.bets_close_tick + ')';
+        }
+        html += '</div>';
+    });
+    container.innerHTML = html;
+}
+
+function refreshPredictionLeaderboard() {
+    registryRequest('api/predictions').then(function(data) {
+        const spectator = localStorage.getItem('evosim-spectator') || '';
+        const me = data.leaderboard.find(entry => entry.name === spectator);
+        document.getElementById('spectator-points').textContent = me ? me.points + ' points' : '';
+        let html = '<h4>Leaderboard</h4>';
+        if (data.leaderboard.length === 0) {
+            html += 'No predictions yet';
+        } else {
+            html += '<ol>';
+            data.leaderboard.slice(0, 10).forEach(function(entry) {
+                html += '<li>' + escapeHTML(entry.name) + ': ' + entry.points + ' points (' + entry.correct + ' right, ' + entry.wrong + ' wrong)</li>';
+            });
+            html += '</ol>';
+        }
+        const last = data.rounds.find(round => round.status === 'resolved');
+        if (last) {
+            html += '<div>Last answer: ' + escapeHTML(last.question) + ' <strong>' + escapeHTML((last.outcome || []).join(', ') || 'none') + '</strong></div>';
+        }
+        document.getElementById('prediction-leaderboard').innerHTML = html;
+    }).catch(function(error) {
+        console.error('Failed to load predictions:', error);
+    });
+}
+
+function toggleBranchesPanel() {
+    const panel = document.getElementById('branches-panel');
+    panel.style.display = panel.style.display === 'none' ? 'block' : 'none';
+    if (panel.style.display === 'block') {
+        refreshBranches();
+    }
+}
+
+// Query string sending operator interventions to the chosen branch instead of the live world
+function operatorBranchQuery() {
+    const select = document.getElementById('operator-branch');
+    return select && select.value ? '?branch=' + select.value : '';
+}
+
+function forkWorld() {
+    registryRequest('api/branches', {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify({name: document.getElementById('branch-name').value})
+    }).then(function(branch) {
+        showToast('🌿 World Forked', branch.name + ' branched off at tick ' + branch.fork_tick, 'high');
+        document.getElementById('branch-name').value = '';
+        refreshBranches();
+    }).catch(function(error) {
+        alert('Could not fork the world: ' + error.message);
+    });
+}
+
+function setBranchPaused(id, paused) {
+    registryRequest('api/branches/branch?id=' + id, {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify({paused: paused})
+    }).then(refreshBranches).catch(function(error) {
+        alert('Could not update branch: ' + error.message);
+    });
+}
+
+function removeBranch(id) {
+    fetch('api/branches/branch?id=' + id, {method: 'DELETE'}).then(refreshBranches);
+}
+
+// Full save-style diff between the live world and a branch
+function showBranchDiff(id) {
+    registryRequest('api/branches/branch?id=' + id).then(function(result) {
+        ensureSpeciesModalExists();
+        document.getElementById('species-detail-content').innerHTML =
+            '<h2>🌿 Live World vs Branch ' + id + '</h2><pre>' + escapeHTML(result.summary) + '</pre>';
+        document.getElementById('species-detail-modal').style.display = 'block';
+        document.getElementById('species-modal-overlay').style.display = 'block';
+    }).catch(function(error) {
+        alert('Could not compare branch: ' + error.message);
+    });
+}
+
+// Population of both timelines since the fork as a small line chart
+function branchSparkline(history, trunkHistory) {
+    const width = 200, height = 40;
+    const peak = Math.max(1, ...history.map(s => s.population), ...trunkHistory.map(s => s.population));
+    const line = function(samples, color) {
+        if (samples.length < 2) {
+            return '';
+        }
+        const points = samples.map(function(sample, i) {
+            return (i * width / (samples.length - 1)).toFixed(1) + ',' + (height - sample.population * height / peak).toFixed(1);
+        });
+        return '<polyline fill="none" stroke="' + color + '" stroke-width="1.5" points="' + points.join(' ') + '"/>';
+    };
+    return '<svg width="' + width + '" height="' + height + '" style="background: #222;">' +
+        line(trunkHistory, '#4CAF50') + line(history, '#FF9800') + '</svg>';
+}
+
+// Resources view state, fetched separately from the view data like the timeline
+let resourceData = null;
+let resourceFetchedAt = 0;
+let resourceFetching = false;
+
+function refreshResources(force) {
+    if (resourceFetching || (!force && Date.now() - resourceFetchedAt < 1000)) {
+        return;
+    }
+    resourceFetching = true;
+    registryRequest('api/resources')
+        .then(result => {
+            resourceData = result;
+            redrawResources();
+        })
+        .catch(error => console.error('Failed to load resources:', error))
+        .finally(() => {
+            resourceFetching = false;
+            resourceFetchedAt = Date.now();
+        });
+}
+
+function redrawResources() {
+    const container = document.getElementById('resources-container');
+    if (container && currentView === 'RESOURCES' && !isEditingResources()) {
+        container.innerHTML = renderResources();
+    }
+}
+
+function isEditingResources() {
+    const active = document.activeElement;
+    return active && active.tagName === 'INPUT' && active.closest('#resources-container') !== null;
+}
+
+function formatBytes(bytes) {
+    const units = ['B', 'KB', 'MB', 'GB'];
+    let unit = 0;
+    while (bytes >= 1024 && unit < units.length - 1) {
+        bytes /= 1024;
+        unit++;
+    }
+    return bytes.toFixed(unit === 0 ? 0 : 1) + ' ' + units[unit];
+}
+
+// Recent samples of one store as a small line chart
+function resourceSparkline(samples, color) {
+    const width = 120, height = 24;
+    if (!samples || samples.length < 2) {
+        return '';
+    }
+    const peak = Math.max(1, ...samples);
+    const points = samples.map(function(count, i) {
+        return (i * width / (samples.length - 1)).toFixed(1) + ',' + (height - count * height / peak).toFixed(1);
+    });
+    return '<svg width="' + width + '" height="' + height + '" style="background: #222;">' +
+        '<polyline fill="none" stroke="' + color + '" stroke-width="1.5" points="' + points.join(' ') + '"/></svg>';
+}
+
+function renderResources() {
+    let html = '<h3>💾 Memory Usage</h3>';
+    if (!resourceData) {
+        return html + '<div>Loading resources...</div>';
+    }
+    html += '<div><strong>Go heap:</strong> ' + formatBytes(resourceData.heap_bytes) + ' in ' + resourceData.heap_objects.toLocaleString() + ' objects ' +
+        resourceSparkline(resourceData.heap_history, '#2196F3') + '</div>';
+    html += '<div><strong>Tracked stores:</strong> ~' + formatBytes(resourceData.estimated_bytes) +
+        ' <small>(sampled every ' + resourceData.sample_interval + ' ticks; ' + resourceData.alerts + ' leak alerts so far)</small></div>';
+    html += '<table style="width: 100%; margin-top: 10px;"><tr><th>Store</th><th>Items</th><th>Est. size</th><th>Growth</th><th>Trend</th><th>Limit</th><th>Auto-prune</th><th>Pruned</th><th></th></tr>';
+    resourceData.stores.forEach(function(store) {
+        const leaking = store.leak ? ' style="color: #f44336;"' : '';
+        const name = escapeHTML(store.name);
+        html += '<tr><td title="' + escapeHTML(store.description) + '"' + leaking + '>' + name.replace(/_/g, ' ') +
+            (store.leak === 'growing' ? ' ⚠️ growing' : store.leak === 'over_limit' ? ' ⚠️ over limit' : '') + '</td>';
+        html += '<td>' + store.count.toLocaleString() + '</td><td>' + formatBytes(store.estimated_bytes) + '</td>';
+        html += '<td>' + (store.growth > 0 ? '+' : '') + store.growth + '</td>';
+        html += '<td>' + resourceSparkline(store.history, store.leak ? '#f44336' : '#4CAF50') + '</td>';
+        if (!store.prunable) {
+            html += '<td colspan="4"><small>Not prunable</small></td></tr>';
+            return;
+        }
+        html += '<td><input type="number" min="0" value="' + store.policy.limit + '" style="width: 70px;"' +
+            ' title="' + (store.prunes_orphans ? 'Dead creatures are always pruned; 0 sets no further limit' : '0 keeps every item') + '"' +
+            ' onchange="setResourcePolicy(\'' + name + '\', {limit: parseInt(this.value, 10) || 0})"></td>';
+        html += '<td><input type="checkbox"' + (store.policy.auto_prune ? ' checked' : '') +
+            ' onchange="setResourcePolicy(\'' + name + '\', {auto_prune: this.checked})"></td>';
+        html += '<td>' + store.pruned.toLocaleString() + '</td>';
+        html += '<td><button onclick="setResourcePolicy(\'' + name + '\', {prune: true})">Prune now</button></td></tr>';
+    });
+    return html + '</table>';
+}
+
+function setResourcePolicy(store, change) {
+    registryRequest('api/resources', {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify(Object.assign({store: store}, change))
+    }).then(function(result) {
+        resourceData = result;
+        document.activeElement.blur();
+        redrawResources();
+    }).catch(function(error) {
+        showToast('Resource policy not changed', error.message, 'high');
+    });
+}
+
+// Demographics view state, fetched separately from the view data like the resources
+let demographicsData = null;
+let demographicsFetchedAt = 0;
+let demographicsFetching = false;
+
+function refreshDemographics() {
+    if (demographicsFetching || Date.now() - demographicsFetchedAt < 1000) {
+        return;
+    }
+    demographicsFetching = true;
+    registryRequest('api/demographics')
+        .then(result => {
+            demographicsData = result;
+            const container = document.getElementById('demographics-container');
+            if (container && currentView === 'DEMOGRAPHICS') {
+                container.innerHTML = renderDemographics();
+            }
+        })
+        .catch(error => console.error('Failed to load demographics:', error))
+        .finally(() => {
+            demographicsFetching = false;
+            demographicsFetchedAt = Date.now();
+        });
+}
+
+// One pyramid, oldest cohort at the top, with bars scaled to its largest half
+function renderPyramid(pyramid) {
+    const widest = Math.max(1, ...pyramid.cohorts.map(cohort => Math.max(cohort.female, cohort.male + cohort.asexual)));
+    const bar = function(count, color, align) {
+        const width = (count * 100 / widest).toFixed(1);
+        return '<div style="display: inline-block; width: ' + width + '%; height: 10px; background: ' + color + '; float: ' + align + ';" title="' + count + '"></div>';
+    };
+    let html = '<table style="width: 100%; border-collapse: collapse; font-size: 11px;">';
+    pyramid.cohorts.slice().reverse().forEach(function(cohort) {
+        html += '<tr><td style="width: 42%;">' + bar(cohort.female, '#E91E63', 'right') + '</td>';
+        html += '<td style="width: 16%; text-align: center;">' + cohort.label + '</td>';
+        html += '<td style="width: 42%;">' + bar(cohort.male, '#2196F3', 'left') + bar(cohort.asexual, '#9E9E9E', 'left') + '</td></tr>';
+    });
+    return html + '</table>';
+}
+
+function renderDemographics() {
+    let html = '<h3>👪 Demographics</h3>';
+    if (!demographicsData) {
+        return html + '<div>Loading demographics...</div>';
+    }
+    html += '<div><strong>Demographic transitions:</strong> ' + demographicsData.transitions + '</div>';
+    html += '<h4>Colonies</h4>';
+    if (demographicsData.colonies.length === 0) {
+        html += '<div>No colonies counted yet</div>';
+    }
+    demographicsData.colonies.forEach(function(colony) {
+        const stage = colony.transitioned ? '📉 transitioned at tick ' + colony.transition_tick : 'pre-transition';
+        html += '<div style="margin: 10px 0;"><strong>' + escapeHTML(colony.name) + '</strong> — ' + colony.pyramid.population + ' members, tech ' + colony.tech_level +
+            ', ' + (colony.food_secure ? 'food secure' : 'hungry') + ', birth rate ' + colony.birth_rate.toFixed(2) +
+            ' (' + colony.births + ' born), ' + stage + '</div>';
+        html += renderPyramid(colony.pyramid);
+    });
+    html += '<h4>Species</h4>';
+    demographicsData.species.forEach(function(pyramid) {
+        html += '<div style="margin: 10px 0;"><strong>' + escapeHTML(pyramid.group) + '</strong> — ' + pyramid.population +
+            ' living, ' + (pyramid.youth_share * 100).toFixed(0) + '% young</div>';
+        html += renderPyramid(pyramid);
+    });
+    return html;
+}
+
+// Migration view state, fetched separately from the view data like the demographics
+let migrationData = null;
+let migrationFetchedAt = 0;
+let migrationFetching = false;
+
+function refreshMigration() {
+    if (migrationFetching || Date.now() - migrationFetchedAt < 1000) {
+        return;
+    }
+    migrationFetching = true;
+    registryRequest('api/migration')
+        .then(result => {
+            migrationData = result;
+            const container = document.getElementById('migration-container');
+            if (container && currentView === 'MIGRATION') {
+                container.innerHTML = renderMigration();
+            }
+        })
+        .catch(error => console.error('Failed to load migrations:', error))
+        .finally(() => {
+            migrationFetching = false;
+            migrationFetchedAt = Date.now();
+        });
+}
+
+// A colour for each species, the same on every redraw
+function migrationColor(species) {
+    let hash = 0;
+    for (let i = 0; i < species.length; i++) {
+        hash = (hash * 31 + species.charCodeAt(i)) % 360;
+    }
+    return 'hsl(' + hash + ', 70%, 60%)';
+}
+
+function renderMigration() {
+    let html = '<h3>🦬 Migration</h3>';
+    if (!migrationData) {
+        return html + '<div>Loading migrations...</div>';
+    }
+    const size = 400;
+    const scaleX = size / migrationData.width;
+    const scaleY = size / migrationData.height;
+    const points = path => path.map(point => (point.x * scaleX).toFixed(1) + ',' + (point.y * scaleY).toFixed(1)).join(' ');
+    const line = (path, color, extra) => '<polyline points="' + points(path) + '" fill="none" stroke="' + color + '" ' + extra + '/>';
+
+    let svg = '<svg width="' + size + '" height="' + size + '" style="background: #111;">';
+    svg += '<line x1="0" y1="' + size / 2 + '" x2="' + size + '" y2="' + size / 2 + '" stroke="#333" stroke-dasharray="2,4"/>';
+    migrationData.history.forEach(function(journey) {
+        svg += line(journey.path, migrationColor(journey.species), 'stroke-width="1" stroke-dasharray="3,3" opacity="0.5"');
+    });
+    migrationData.routes.forEach(function(route) {
+        svg += line(route.waypoints, migrationColor(route.species), 'stroke-width="' + Math.min(5, 1 + route.uses) + '" opacity="0.8"');
+    });
+    migrationData.herds.forEach(function(herd) {
+        const color = migrationColor(herd.species);
+        svg += line(herd.trail.concat([herd.position]), color, 'stroke-width="2"');
+        (herd.members || []).forEach(function(member) {
+            svg += '<circle cx="' + (member.x * scaleX).toFixed(1) + '" cy="' + (member.y * scaleY).toFixed(1) + '" r="2" fill="' + color + '"/>';
+        });
+        svg += '<circle cx="' + (herd.position.x * scaleX).toFixed(1) + '" cy="' + (herd.position.y * scaleY).toFixed(1) + '" r="5" fill="none" stroke="#fff">' +
+            '<title>' + escapeHTML(herd.species) + ' — ' + herd.reason + '</title></circle>';
+    });
+    html += svg + '</svg>';
+
+    html += '<div><strong>Creatures migrating:</strong> ' + migrationData.migrants + '</div>';
+    html += '<h4>Herds</h4>';
+    if (migrationData.herds.length === 0) {
+        html += '<div>No herds on the move</div>';
+    }
+    migrationData.herds.forEach(function(herd) {
+        const state = herd.arrived ? 'arrived' : herd.returning ? 'heading home' : 'on the way';
+        const way = herd.route ? 'route ' + herd.route : 'making its own way';
+        html += '<div><span style="color: ' + migrationColor(herd.species) + ';">●</span> <strong>' + escapeHTML(herd.species) + '</strong> — ' +
+            (herd.members || []).length + ' creatures, ' + herd.reason + ', ' + state + ', ' + way + ', since tick ' + herd.started + '</div>';
+    });
+    html += '<h4>Learned routes</h4>';
+    if (migrationData.routes.length === 0) {
+        html += '<div>No routes learned yet</div>';
+    }
+    migrationData.routes.forEach(function(route) {
+        html += '<div><span style="color: ' + migrationColor(route.species) + ';">━</span> Route ' + route.id + ': <strong>' + escapeHTML(route.species) + '</strong> — ' +
+            route.reason + ', travelled ' + route.uses + ' times, known to ' + route.knowers + ', learned at tick ' + route.learned_at + '</div>';
+    });
+    return html;
+}
+
+// Fitness landscape view state; sampling is costly, so it is refreshed every few seconds
+let landscapeData = null;
+let landscapeError = '';
+let landscapeFetchedAt = 0;
+let landscapeFetching = false;
+let landscapeGenes = null; // Creature genes that can be projected onto an axis
+let landscapeSpecies = [];
+const landscapeOptions = {species: '', x: 'speed', y: 'size'};
+
+function refreshLandscape(force) {
+    if (landscapeGenes === null) {
+        landscapeGenes = [];
+        registryRequest('api/traits?subject=creature')
+            .then(traits => {
+                landscapeGenes = traits.filter(trait => trait.kind === 'gene' && !trait.prefix).map(trait => trait.name).sort();
+                redrawLandscape();
+            })
+            .catch(error => console.error('Failed to load traits:', error));
+    }
+    if (landscapeFetching || (!force && Date.now() - landscapeFetchedAt < 3000)) {
+        return;
+    }
+    landscapeFetching = true;
+    registryRequest('api/fitness-landscape?' + new URLSearchParams(landscapeOptions))
+        .then(result => {
+            landscapeData = result;
+            landscapeError = '';
+        })
+        .catch(error => {
+            landscapeData = null;
+            landscapeError = error.message;
+        })
+        .finally(() => {
+            landscapeFetching = false;
+            landscapeFetchedAt = Date.now();
+            redrawLandscape();
+        });
+}
+
+function redrawLandscape() {
+    const container = document.getElementById('landscape-container');
+    if (container && currentView === 'LANDSCAPE' && !isChoosingLandscape()) {
+        container.innerHTML = renderLandscape();
+    }
+}
+
+function isChoosingLandscape() {
+    const active = document.activeElement;
+    return active && active.tagName === 'SELECT' && active.closest('#landscape-container') !== null;
+}
+
+function setLandscapeOption(name, value) {
+    landscapeOptions[name] = value;
+    document.activeElement.blur();
+    refreshLandscape(true);
+}
+
+function landscapeSelect(name, values, labels) {
+    return '<select onchange="setLandscapeOption(\'' + name + '\', this.value)">' + values.map(function(value, i) {
+        return '<option value="' + escapeHTML(value) + '"' + (value === landscapeOptions[name] ? ' selected' : '') + '>' + escapeHTML(labels[i]) + '</option>';
+    }).join('') + '</select>';
+}
+
+function renderLandscape() {
+    let html = '<h3>⛰️ Fitness Landscape</h3>';
+    const species = landscapeSpecies;
+    const genes = landscapeGenes && landscapeGenes.length > 0 ? landscapeGenes : [landscapeOptions.x, landscapeOptions.y];
+    html += '<div>Species: ' + landscapeSelect('species', [''].concat(species), ['All creatures'].concat(species)) +
+        ' X: ' + landscapeSelect('x', genes, genes) + ' Y: ' + landscapeSelect('y', genes, genes) + '</div>';
+    if (landscapeError) {
+        return html + '<div style="color: orange;">' + escapeHTML(landscapeError) + '</div>';
+    }
+    if (!landscapeData) {
+        return html + '<div>Sampling landscape...</div>';
+    }
+
+    const size = 300;
+    const xs = landscapeData.x_values, ys = landscapeData.y_values;
+    const cell = size / xs.length;
+    const range = Math.max(1e-9, landscapeData.max - landscapeData.min);
+    const px = x => (x - xs[0]) / (xs[xs.length - 1] - xs[0]) * (size - cell) + cell / 2;
+    const py = y => size - ((y - ys[0]) / (ys[ys.length - 1] - ys[0]) * (size - cell) + cell / 2);
+    let svg = '<svg width="' + size + '" height="' + size + '" style="background: #111;">';
+    landscapeData.fitness.forEach(function(row, yi) {
+        row.forEach(function(fitness, xi) {
+            const t = (fitness - landscapeData.min) / range;
+            svg += '<rect x="' + (xi * cell).toFixed(1) + '" y="' + (size - (yi + 1) * cell).toFixed(1) + '" width="' + (cell + 0.5).toFixed(1) +
+                '" height="' + (cell + 0.5).toFixed(1) + '" fill="hsl(' + (240 - 180 * t).toFixed(0) + ', 80%, ' + (15 + 45 * t).toFixed(0) + '%)">' +
+                '<title>' + xs[xi].toFixed(2) + ', ' + ys[yi].toFixed(2) + ': ' + fitness.toFixed(3) + '</title></rect>';
+        });
+    });
+    landscapeData.population.forEach(function(individual) {
+        svg += '<circle cx="' + px(individual.x).toFixed(1) + '" cy="' + py(individual.y).toFixed(1) + '" r="2" fill="#fff" opacity="0.7">' +
+            '<title>#' + individual.entity_id + ': ' + individual.fitness.toFixed(3) + '</title></circle>';
+    });
+    landscapeData.peaks.forEach(function(peak) {
+        svg += '<text x="' + px(peak.x).toFixed(1) + '" y="' + (py(peak.y) + 4).toFixed(1) + '" fill="#fff" font-size="12" text-anchor="middle">▲</text>';
+    });
+    landscapeData.valleys.forEach(function(valley) {
+        svg += '<text x="' + px(valley.x).toFixed(1) + '" y="' + (py(valley.y) + 4).toFixed(1) + '" fill="#aaa" font-size="12" text-anchor="middle">▼</text>';
+    });
+    const cx = px(landscapeData.centroid.x), cy = py(landscapeData.centroid.y);
+    svg += '<circle cx="' + cx.toFixed(1) + '" cy="' + cy.toFixed(1) + '" r="6" fill="none" stroke="#FF9800" stroke-width="2"/>';
+    if (landscapeData.uphill_x !== 0 || landscapeData.uphill_y !== 0) {
+        svg += '<line x1="' + cx.toFixed(1) + '" y1="' + cy.toFixed(1) + '" x2="' + (cx + landscapeData.uphill_x * 30).toFixed(1) +
+            '" y2="' + (cy - landscapeData.uphill_y * 30).toFixed(1) + '" stroke="#FF9800" stroke-width="2"/>';
+    }
+    html += svg + '</svg>';
+
+    html += '<div><small>' + escapeHTML(landscapeData.x_trait) + ' ' + xs[0].toFixed(2) + ' → ' + xs[xs.length - 1].toFixed(2) + ' (right), ' +
+        escapeHTML(landscapeData.y_trait) + ' ' + ys[0].toFixed(2) + ' → ' + ys[ys.length - 1].toFixed(2) + ' (up), sampled at tick ' + landscapeData.tick + '</small></div>';
+    html += '<div>Expected fitness: ' + landscapeData.min.toFixed(3) + ' to ' + landscapeData.max.toFixed(3) + '</div>';
+    html += '<div>Population mean: ' + escapeHTML(landscapeData.x_trait) + ' ' + landscapeData.centroid.x.toFixed(2) + ', ' +
+        escapeHTML(landscapeData.y_trait) + ' ' + landscapeData.centroid.y.toFixed(2) + ' (fitness ' + landscapeData.centroid.fitness.toFixed(3) + ')</div>';
+    if (landscapeData.peaks.length > 0) {
+        html += '<div>Peaks: ' + landscapeData.peaks.map(peak => '(' + peak.x.toFixed(2) + ', ' + peak.y.toFixed(2) + ') ' + peak.fitness.toFixed(3)).join('; ') + '</div>';
+    }
+    if (landscapeData.valleys.length > 0) {
+        html += '<div>Valleys: ' + landscapeData.valleys.map(valley => '(' + valley.x.toFixed(2) + ', ' + valley.y.toFixed(2) + ') ' + valley.fitness.toFixed(3)).join('; ') + '</div>';
+    }
+    return html;
+}
+
+// Comparison dashboard: each branch side by side with the live world
+function refreshBranches() {
+    registryRequest('api/branches').then(function(branches) {
+        const select = document.getElementById('operator-branch');
+        const selected = select.value;
+        select.innerHTML = '<option value="">Live world</option>';
+        branches.forEach(function(branch) {
+            const option = document.createElement('option');
+            option.value = branch.id;
+            option.textContent = branch.name;
+            option.selected = String(branch.id) === selected;
+            select.appendChild(option);
+        });
+
+        const list = document.getElementById('branch-list');
+        if (branches.length === 0) {
+            list.textContent = 'No branches';
+            return;
+        }
+        let html = '';
+        branches.forEach(function(branch) {
+            const row = function(label, sample) {
+                return '<tr><td>' + label + '</td><td>' + sample.tick + '</td><td>' + sample.population + '</td><td>' +
+                    sample.species + '</td><td>' + sample.plants + '</td><td>' + sample.mean_energy.toFixed(1) + '</td></tr>';
+            };
+            html += '<div style="margin: 8px 0; border-top: 1px solid #444;"><strong>' + escapeHTML(branch.name) + '</strong> forked at tick ' + branch.fork_tick +
+                (branch.paused ? ' (paused)' : '') + ' ' +
+                '<button onclick="setBranchPaused(' + branch.id + ', ' + !branch.paused + ')">' + (branch.paused ? '▶ Resume' : '⏸ Pause') + '</button> ' +
+                '<button onclick="showBranchDiff(' + branch.id + ')">📊 Diff</button> ' +
+                '<button onclick="removeBranch(' + branch.id + ')">✖ Remove</button>';
+            html += '<table><tr><th>Timeline</th><th>Tick</th><th>Creatures</th><th>Species</th><th>Plants</th><th>Mean energy</th></tr>' +
+                row('<span style="color: #4CAF50;">Live</span>', branch.trunk) + row('<span style="color: #FF9800;">Branch</span>', branch.branch) + '</table>';
+            html += branchSparkline(branch.history || [], branch.trunk_history || []);
+            const species = Array.from(new Set(Object.keys(branch.trunk_species).concat(Object.keys(branch.branch_species)))).sort();
+            html += '<div>' + species.map(function(name) {
+                return escapeHTML(name) + ': ' + (branch.trunk_species[name] || 0) + ' → ' + (branch.branch_species[name] || 0);
+            }).join(', ') + '</div></div>';
+        });
+        list.innerHTML = html;
+    }).catch(function(error) {
+        console.error('Failed to load branches:', error);
+    });
+}
+
+// Undo or redo the most recent operator intervention (terraforming, spawning, trait edits, structures)
+function stepIntervention(action) {
+    return registryRequest('api/operator/interventions' + operatorBranchQuery(), {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify({action: action})
+    }).then(function(intervention) {
+        showToast(action === 'undo' ? '↶ Undone' : '↷ Redone', intervention.description, 'high');
+    }).catch(function(error) {
+        showToast('Nothing to ' + action, error.message.trim(), 'high');
+    });
+}
+
+function undoIntervention() {
+    stepIntervention('undo');
+}
+
+function redoIntervention() {
+    stepIntervention('redo');
+}
+
+function editEntityTrait(entityID) {
+    const trait = document.getElementById('trait-edit-name').value;
+    const value = parseFloat(document.getElementById('trait-edit-value').value);
+    if (isNaN(value)) {
+        alert('Trait value must be a number');
+        return;
+    }
+    registryRequest('api/operator/traits' + operatorBranchQuery(), {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify({entity_id: entityID, trait: trait, value: value})
+    }).then(function() {
+        showEntityDetail(entityID);
+    }).catch(function(error) {
+        alert('Trait edit failed: ' + error.message);
+    });
+}
+
+function handleFileLoad(event) {
+    const file = event.target.files[0];
+    if (file) {
+        const reader = new FileReader();
+        reader.onload = function(e) {
+            try {
+                const stateData = JSON.parse(e.target.result);
+                if (ws && ws.readyState === WebSocket.OPEN) {
+                    ws.send(JSON.stringify({
+                        action: 'load_state',
+                        data: stateData
+                    }));
+                }
+            } catch (error) {
+                alert('Error loading file: Invalid JSON format');
+            }
+        };
+        reader.readAsText(file);
+    }
+}
+
+// Initialize the interface
+window.onload = function() {
+    initViewTabs();
+    initTraitSliders();
+    initViewportControls();
+    initAudioControls();
+    loadTraitLegend();
+    loadGridLegend();
+    loadAutosaveOffer();
+    connect();
+
+    // Initialize species modal functionality
+    setupSpeciesModalEvents();
+
+    window.addEventListener('hashchange', applyDeepLink);
+    applyDeepLink();
+};
+
+// Initialize trait sliders
+function initTraitSliders() {
+    const traits = ['speed', 'aggression', 'cooperation', 'intelligence'];
+    traits.forEach(trait => {
+        const slider = document.getElementById(trait + '-trait');
+        const valueSpan = document.getElementById(trait + '-value');
+        if (slider && valueSpan) {
+            slider.oninput = function() {
+                valueSpan.textContent = parseFloat(this.value).toFixed(1);
+            };
+        }
+    });
+    policySettings.forEach(setting => {
+        const slider = document.getElementById(setting + '-policy');
+        const valueSpan = document.getElementById(setting + '-policy-value');
+        if (slider && valueSpan) {
+            slider.oninput = function() {
+                valueSpan.textContent = parseFloat(this.value).toFixed(1);
+            };
+        }
+    });
+}
+
+// Player Management Functions
+function joinAsPlayer() {
+    const nameInput = document.getElementById('player-name-input');
+    const playerName = nameInput.value.trim();
+    const errorDiv = document.getElementById('join-error');
+
+    if (!playerName) {
+        showError(errorDiv, 'Please enter your name');
+        return;
+    }
+
+    if (!/^[a-zA-Z0-9\s]+$/.test(playerName)) {
+        showError(errorDiv, 'Name can only contain letters, numbers, and spaces');
+        return;
+    }
+
+    hideError(errorDiv);
+
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({
+            action: 'join_as_player',
+            data: { name: playerName }
+        }));
+    }
+}
+
+function showCreateSpeciesForm() {
+    document.getElementById('create-species-form').style.display = 'block';
+    document.getElementById('control-species-form').style.display = 'none';
+}
+
+function hideCreateSpeciesForm() {
+    document.getElementById('create-species-form').style.display = 'none';
+    document.getElementById('species-name-input').value = '';
+    resetTraitSliders();
+}
+
+function showControlSpeciesForm() {
+    updateSpeciesSelect();
+    document.getElementById('control-species-form').style.display = 'block';
+    document.getElementById('create-species-form').style.display = 'none';
+}
+
+function hideControlSpeciesForm() {
+    document.getElementById('control-species-form').style.display = 'none';
+}
+
+function resetTraitSliders() {
+    const traits = ['speed', 'aggression', 'cooperation', 'intelligence'];
+    traits.forEach(trait => {
+        const slider = document.getElementById(trait + '-trait');
+        const valueSpan = document.getElementById(trait + '-value');
+        if (slider && valueSpan) {
+            slider.value = 0;
+            valueSpan.textContent = '0.0';
+        }
+    });
+}
+
+function createSpecies() {
+    const nameInput = document.getElementById('species-name-input');
+    const speciesName = nameInput.value.trim();
+    const errorDiv = document.getElementById('create-species-error');
+
+    if (!speciesName) {
+        showError(errorDiv, 'Please enter a species name');
+        return;
+    }
+
+    if (!/^[a-zA-Z0-9\s]+$/.test(speciesName)) {
+        showError(errorDiv, 'Species name can only contain letters, numbers, and spaces');
+        return;
+    }
+
+    hideError(errorDiv);
+
+    // Collect trait adjustments
+    const traits = {};
+    ['speed', 'aggression', 'cooperation', 'intelligence'].forEach(trait => {
+        const slider = document.getElementById(trait + '-trait');
+        if (slider) {
+            traits[trait] = parseFloat(slider.value);
+        }
+    });
+
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({
+            action: 'create_species',
+            data: {
+                name: speciesName,
+                traits: traits
+            }
+        }));
+    }
+}
+
+function updateSpeciesSelect() {
+    const select = document.getElementById('species-select');
+    select.innerHTML = '<option value="">Select a species to control</option>';
+
+    playerSpecies.forEach(species => {
+        const option = document.createElement('option');
+        option.value = species;
+        option.textContent = species;
+        select.appendChild(option);
+    });
+}
+
+function executeMove() {
+    const select = document.getElementById('species-select');
+    const selectedSpecies = select.value;
+    const errorDiv = document.getElementById('control-species-error');
+
+    if (!selectedSpecies) {
+        showError(errorDiv, 'Please select a species first');
+        return;
+    }
+
+    if (!moveTarget) {
+        showError(errorDiv, 'Please click on the grid to set a target location first');
+        return;
+    }
+
+    hideError(errorDiv);
+
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({
+            action: 'control_species',
+            data: {
+                species: selectedSpecies,
+                command: 'move',
+                x: moveTarget.x,
+                y: moveTarget.y
+            }
+        }));
+    }
+}
+
+function executeGather() {
+    const select = document.getElementById('species-select');
+    const selectedSpecies = select.value;
+    const errorDiv = document.getElementById('control-species-error');
+
+    if (!selectedSpecies) {
+        showError(errorDiv, 'Please select a species first');
+        return;
+    }
+
+    hideError(errorDiv);
+
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({
+            action: 'control_species',
+            data: {
+                species: selectedSpecies,
+                command: 'gather'
+            }
+        }));
+    }
+}
+
+function executeReproduce() {
+    const select = document.getElementById('species-select');
+    const selectedSpecies = select.value;
+    const errorDiv = document.getElementById('control-species-error');
+
+    if (!selectedSpecies) {
+        showError(errorDiv, 'Please select a species first');
+        return;
+    }
+
+    hideError(errorDiv);
+
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({
+            action: 'control_species',
+            data: {
+                species: selectedSpecies,
+                command: 'reproduce'
+            }
+        }));
+    }
+}
+
+function setSpeciesPolicy() {
+    const selectedSpecies = document.getElementById('species-select').value;
+    const errorDiv = document.getElementById('control-species-error');
+
+    if (!selectedSpecies) {
+        showError(errorDiv, 'Please select a species first');
+        return;
+    }
+
+    hideError(errorDiv);
+
+    const data = {species: selectedSpecies};
+    policySettings.forEach(setting => {
+        data[setting] = parseFloat(document.getElementById(setting + '-policy').value);
+    });
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: 'set_species_policy', data: data}));
+    }
+}
+
+// Move the policy sliders to the selected species' current policy
+function loadSpeciesPolicy() {
+    const policy = speciesPolicies[document.getElementById('species-select').value];
+    policySettings.forEach(setting => {
+        const value = policy ? policy.target[setting] : 0;
+        document.getElementById(setting + '-policy').value = value;
+        document.getElementById(setting + '-policy-value').textContent = value.toFixed(1);
+    });
+    showPolicyEffective();
+}
+
+function updateSpeciesPolicies(policies) {
+    speciesPolicies = {};
+    (policies || []).forEach(function(policy) {
+        speciesPolicies[policy.species] = policy;
+    });
+    showPolicyEffective();
+}
+
+// Show how far the selected species has adapted toward its policy
+function showPolicyEffective() {
+    const label = document.getElementById('policy-effective');
+    const select = document.getElementById('species-select');
+    if (!label || !select) {
+        return;
+    }
+    const policy = speciesPolicies[select.value];
+    label.textContent = policy ? policySettings.map(setting => setting + ' ' + policy.effective[setting].toFixed(2)).join(', ') : 'No policy set';
+}
+
+function showError(errorDiv, message) {
+    errorDiv.textContent = message;
+    errorDiv.style.display = 'block';
+}
+
+function hideError(errorDiv) {
+    errorDiv.style.display = 'none';
+}
+
+// Handle player-specific WebSocket messages
+function handlePlayerMessage(data) {
+    switch (data.type) {
+        case 'player_joined':
+            playerID = data.player_id;
+            document.getElementById('player-name').textContent = data.name;
+            document.getElementById('join-form').style.display = 'none';
+            document.getElementById('player-controls').style.display = 'block';
+            ws.send(JSON.stringify({action: 'list_players', data: {}}));
+            console.log('Player joined:', data.message);
+            break;
+
+        case 'species_created':
+            playerSpecies.push(data.species_name);
+            updatePlayerSpeciesCount();
+            hideCreateSpeciesForm();
+            console.log('Species created:', data.message);
+            break;
+
+        case 'command_executed':
+            console.log('Command executed:', data.message);
+            break;
+
+        case 'group_selected':
+            selectedGroupID = data.group_id;
+            console.log('Group selected:', data.message);
+            break;
+
+        case 'species_policy_updated':
+            speciesPolicies[data.species] = data.policy;
+            showPolicyEffective();
+            console.log('Species policy updated:', data.message);
+            break;
+
+        case 'diplomacy':
+            playerSpecies = data.species || [];
+            updatePlayerSpeciesCount();
+            updateSpeciesSelect();
+            updateAlliancePlayers(data.players || []);
+            if (data.message) {
+                showToast('🤝 Diplomacy', data.message, 'medium');
+            }
+            break;
+
+        case 'species_extinct':
+            // Remove from player species list
+            const extinctIndex = playerSpecies.indexOf(data.species_name);
+            if (extinctIndex > -1) {
+                playerSpecies.splice(extinctIndex, 1);
+                updatePlayerSpeciesCount();
+            }
+
+            // Show extinction notification
+            showToast('⚰️ Species Extinction', data.message, 'critical', 'species/' + encodeURIComponent(data.species_name));
+            console.log('Species extinct:', data.message);
+            break;
+
+        case 'subspecies_formed':
+            // Add new subspecies to player species list
+            playerSpecies.push(data.species_name);
+            updatePlayerSpeciesCount();
+
+            // Show subspecies notification
+            showToast('🧬 Species Split!', data.message, 'high', 'species/' + encodeURIComponent(data.species_name));
+            console.log('Subspecies formed:', data.message);
+            break;
+
+        case 'new_species_detected':
+            // Just log this for now - informational only
+            console.log('New species detected:', data.message);
+            break;
+
+        case 'achievement_unlocked':
+            showToast('🏆 ' + data.name, data.message, 'high', 'species/' + encodeURIComponent(data.species_name));
+            console.log('Achievement unlocked:', data.message);
+            break;
+
+        case 'error':
+            console.error('Server error:', data.message);
+            alert('Error: ' + data.message);
+            break;
+    }
+}
+
+function updateAlliancePlayers(players) {
+    const select = document.getElementById('alliance-player-select');
+    const selected = select.value;
+    select.innerHTML = players.length ? '' : '<option value="">No other players</option>';
+    players.forEach(player => {
+        const option = document.createElement('option');
+        option.value = player.id;
+        let status = player.allied ? 'ally' : player.requested ? 'wants to ally' : player.pending ? 'proposal sent' : 'not allied';
+        if (!player.active) {
+            status += ', offline';
+        }
+        option.textContent = player.name + ' (' + status + ')';
+        option.selected = player.id === selected;
+        select.appendChild(option);
+    });
+}
+
+function sendDiplomacy(action, extra, needsSpecies) {
+    const errorDiv = document.getElementById('control-species-error');
+    const data = Object.assign({player_id: document.getElementById('alliance-player-select').value}, extra || {});
+    if (!data.player_id) {
+        showError(errorDiv, 'Please select another player first');
+        return;
+    }
+    if (needsSpecies) {
+        data.species = document.getElementById('species-select').value;
+        if (!data.species) {
+            showError(errorDiv, 'Please select a species first');
+            return;
+        }
+    }
+    hideError(errorDiv);
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: action, data: data}));
+    }
+}
+
+function updatePlayerSpeciesCount() {
+    document.getElementById('player-species-count').textContent = playerSpecies.length + ' species';
+}
+
+// Returns the world grid cell under a mouse event, or null
+function gridCellAt(event) {
+    const element = event.target && event.target.closest ? event.target.closest('.grid-cell') : null;
+    if (!element || element.dataset.gx === undefined) {
+        return null;
+    }
+    return {x: parseInt(element.dataset.gx), y: parseInt(element.dataset.gy)};
+}
+
+function inBoxSelection(x, y) {
+    if (!boxSelection) {
+        return false;
+    }
+    return x >= Math.min(boxSelection.start.x, boxSelection.end.x) && x <= Math.max(boxSelection.start.x, boxSelection.end.x) &&
+        y >= Math.min(boxSelection.start.y, boxSelection.end.y) && y <= Math.max(boxSelection.start.y, boxSelection.end.y);
+}
+
+// Highlight the box being dragged without waiting for the next frame
+function markBoxSelection() {
+    document.querySelectorAll('#grid-view .grid-cell').forEach(function(element) {
+        element.classList.toggle('box-selecting', inBoxSelection(parseInt(element.dataset.gx), parseInt(element.dataset.gy)));
+    });
+}
+
+function selectGroup(start, end) {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({
+            action: 'select_group',
+            data: {
+                x1: start.x, y1: start.y, x2: end.x, y2: end.y,
+                species: document.getElementById('species-select').value,
+                formation: document.getElementById('group-formation').value
+            }
+        }));
+    }
+}
+
+function executeGroupCommand(command) {
+    const errorDiv = document.getElementById('control-species-error');
+    if (selectedGroupID === null) {
+        showError(errorDiv, 'Shift+drag on the grid to select a group first');
+        return;
+    }
+    const needsTarget = command === 'move' || command === 'guard' || command === 'gather';
+    if (needsTarget && !moveTargetCell) {
+        showError(errorDiv, 'Please click on the grid to set a target location first');
+        return;
+    }
+    hideError(errorDiv);
+
+    const data = {
+        group_id: selectedGroupID,
+        command: command,
+        formation: document.getElementById('group-formation').value
+    };
+    if (needsTarget) {
+        data.x = moveTargetCell.x;
+        data.y = moveTargetCell.y;
+        data.radius = parseFloat(document.getElementById('group-radius').value) || 0;
+    }
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: 'group_command', data: data}));
+    }
+    if (command === 'disband') {
+        selectedGroupID = null;
+    }
+}
+
+// Track where this player's groups are so the grid can outline them
+function updatePlayerGroups(groups) {
+    groupCells = {};
+    let selected = null;
+    (groups || []).forEach(function(group) {
+        if (group.player_id !== playerID) {
+            return;
+        }
+        const isSelected = group.id === selectedGroupID;
+        if (isSelected) {
+            selected = group;
+        }
+        group.cells.forEach(function(cell) {
+            const key = cell.x + ',' + cell.y;
+            groupCells[key] = groupCells[key] || isSelected;
+        });
+    });
+    if (selectedGroupID !== null && !selected) {
+        selectedGroupID = null;
+    }
+    const label = document.getElementById('selected-group');
+    if (label) {
+        label.textContent = selected ? '#' + selected.id + ' (' + selected.size + ' creatures, ' + selected.formation + ', ' +
+            (selected.order ? selected.order + (selected.arrived ? ', in position' : ', en route') : 'idle') + ')' : 'None';
+    }
+}
+
+// Add grid click handling: a click sets the group order target while the species controls
+// are open, and otherwise inspects the cell
+function handleGridClick(event) {
+    if (event.shiftKey) {
+        return; // Shift+drag selects a group instead of setting a target
+    }
+    const cell = gridCellAt(event);
+    if (document.getElementById('control-species-form').style.display !== 'block') {
+        if (cell) {
+            inspectCell(cell);
+        }
+        return;
+    }
+    if (cell) {
+        moveTargetCell = cell;
+    }
+    const gridContainer = document.getElementById('grid-view');
+    const rect = gridContainer.getBoundingClientRect();
+    const x = event.clientX - rect.left;
+    const y = event.clientY - rect.top;
+
+    // Convert pixel coordinates to world coordinates (simplified)
+    const worldX = (x / rect.width) * 100; // Assuming world width is 100
+    const worldY = (y / rect.height) * 100; // Assuming world height is 100
+
+    moveTarget = { x: worldX, y: worldY };
+    document.getElementById('move-target').textContent = '(' + worldX.toFixed(1) + ', ' + worldY.toFixed(1) + ')';
+}
+
+// Inspector state: the entity followed is fetched again every half second as it moves
+let inspectorData = null;
+let inspectorFetchedAt = 0;
+let inspectorFetching = false;
+
+// Inspect a grid cell, following the first creature in it if there is one
+function inspectCell(cell) {
+    registryRequest('api/cell/' + cell.x + '/' + cell.y).then(function(result) {
+        inspectedCell = result;
+        if (result.entities.length > 0) {
+            inspectEntity(result.entities[0].id);
+        } else {
+            inspectedEntityID = null;
+            inspectorData = null;
+            redrawInspector();
+        }
+    }).catch(function(error) {
+        document.getElementById('inspector-content').textContent = error.message;
+    });
+}
+
+// Follow an entity in the inspector
+function inspectEntity(entityID) {
+    inspectedEntityID = entityID;
+    inspectorData = null;
+    refreshInspector(true);
+}
+
+function stopInspecting() {
+    inspectedCell = null;
+    inspectedEntityID = null;
+    inspectorData = null;
+    redrawInspector();
+}
+
+function refreshInspector(force) {
+    if (inspectedEntityID === null || inspectorFetching || (!force && Date.now() - inspectorFetchedAt < 500)) {
+        return;
+    }
+    inspectorFetching = true;
+    const entityID = inspectedEntityID;
+    registryRequest('api/entity/' + entityID)
+        .then(result => {
+            if (entityID === inspectedEntityID) {
+                inspectorData = result;
+                if (inspectedCell && (inspectedCell.x !== result.grid_x || inspectedCell.y !== result.grid_y)) {
+                    inspectedCell = null; // It has wandered off the cell that was clicked
+                }
+                redrawInspector();
+            }
+        })
+        .catch(error => {
+            // The entity is gone: it died and its body was cleared away
+            if (entityID === inspectedEntityID) {
+                inspectedEntityID = null;
+                inspectorData = null;
+                document.getElementById('inspector-content').textContent = 'Entity #' + entityID + ': ' + error.message;
+            }
+        })
+        .finally(() => {
+            inspectorFetching = false;
+            inspectorFetchedAt = Date.now();
+        });
+}
+
+function redrawInspector() {
+    const container = document.getElementById('inspector-content');
+    if (container) {
+        // Keep the sections the user opened or closed as they were
+        const open = {};
+        container.querySelectorAll('details[data-section]').forEach(section => {
+            open[section.dataset.section] = section.open;
+        });
+        container.innerHTML = renderInspector();
+        container.querySelectorAll('details[data-section]').forEach(section => {
+            if (section.dataset.section in open) {
+                section.open = open[section.dataset.section];
+            }
+        });
+    }
+}
+
+// Render the inspector: the cell clicked and the entity followed
+function renderInspector() {
+    if (!inspectedCell && !inspectorData) {
+        return 'Click a grid cell to inspect it';
+    }
+    let html = '<button onclick="stopInspecting()" style="float: right;">✖</button>';
+    if (inspectedCell) {
+        const cell = inspectedCell;
+        html += '<div><strong>Cell (' + cell.x + ', ' + cell.y + ')</strong> ' + escapeHTML(cell.biome) +
+            (cell.event ? ' ⚡ ' + escapeHTML(cell.event) : '') + '</div>';
+        html += '<div style="font-size: 0.85em;">Water ' + cell.water_level.toFixed(2) + ' | pH ' + cell.soil_ph.toFixed(1) +
+            ' | Organic ' + cell.organic_matter.toFixed(2) + '</div>';
+        if (cell.entities.length > 1) {
+            html += '<div style="font-size: 0.85em;">Creatures: ' + cell.entities.map(entity =>
+                '<a href="#" onclick="inspectEntity(' + entity.id + '); return false;"' +
+                (entity.id === inspectedEntityID ? ' style="font-weight: bold;"' : '') + '>#' + entity.id + '</a>').join(' ') + '</div>';
+        }
+        if (cell.plants.length > 0) {
+            html += '<div style="font-size: 0.85em;">Plants: ' + cell.plants.map(plant =>
+                escapeHTML(plant.type) + ' (' + plant.size.toFixed(1) + ')').join(', ') + '</div>';
+        }
+    }
+    const entity = inspectorData;
+    if (!entity) {
+        return html;
+    }
+
+    html += '<h4 style="margin-bottom: 4px;">' + (entity.is_alive ? '🧬' : '💀') + ' ' + entityLink(entity.id) + ' ' + speciesLink(entity.species) + '</h4>';
+    if (entity.is_alive) {
+        html += followTrack && followTrack.entity_id === entity.id
+            ? '<button onclick="unfollowEntity()">🎥 Stop following</button>'
+            : '<button onclick="followEntity(' + entity.id + ')">🎥 Follow</button>';
+    }
+    html += '<div>' + escapeHTML(entity.activity) + ' at (' + entity.position.x.toFixed(1) + ', ' + entity.position.y.toFixed(1) + ')</div>';
+    html += '<div>Energy ' + entity.energy.toFixed(1) + ' | Age ' + entity.age + ' | Gen ' + entity.generation + '</div>';
+    html += '<div>' + escapeHTML(entity.classification) + (entity.caste ? ' | ' + escapeHTML(entity.caste) : '') +
+        (entity.colony_id ? ' | ' + colonyLink(entity.colony_id) : '') + (entity.tribe_id ? ' | Tribe #' + entity.tribe_id : '') + '</div>';
+    if (entity.pregnant || entity.offspring > 0) {
+        html += '<div>' + (entity.pregnant ? 'Pregnant | ' : '') + 'Offspring: ' + entity.offspring + '</div>';
+    }
+
+    html += '<details data-section="traits"><summary>Traits</summary>';
+    Object.keys(entity.traits).sort().forEach(name => {
+        html += '<div style="font-size: 0.85em;">' + escapeHTML(name) + ': ' + entity.traits[name].toFixed(3) + '</div>';
+    });
+    html += '</details>';
+
+    if (entity.dna) {
+        html += '<details data-section="dna"><summary>DNA (' + entity.dna.chromosomes + ' chromosomes, ' + entity.dna.mutations + ' mutations)</summary>';
+        html += '<div style="font-family: monospace; font-size: 0.75em; word-break: break-all;">' + escapeHTML(entity.dna.sequence) + '</div>';
+        entity.dna.genes.forEach(gene => {
+            html += '<div style="font-size: 0.85em;">' + escapeHTML(gene.name) + (gene.dominant ? ' (dominant)' : '') + ': ' + gene.expression.toFixed(2) + '</div>';
+        });
+        html += '</details>';
+    }
+
+    if (entity.brain) {
+        const brain = entity.brain;
+        html += '<details data-section="brain"><summary>Brain (' + escapeHTML(brain.type) + ', ' + brain.neurons + ' neurons)</summary>';
+        html += '<div style="font-size: 0.85em;">' + escapeHTML(brain.architecture) + ' | ' + brain.layers + ' layers</div>';
+        html += '<div style="font-size: 0.85em;">Experience ' + brain.experience.toFixed(1) + ' | ' + brain.decisions + ' decisions, ' +
+            (brain.accuracy * 100).toFixed(0) + '% good</div>';
+        Object.entries(brain.successful_actions || {}).forEach(([action, rate]) => {
+            html += '<div style="font-size: 0.85em;">' + escapeHTML(action) + ': ' + (rate * 100).toFixed(0) + '%</div>';
+        });
+        html += '</details>';
+    }
+
+    const memory = entity.memory;
+    html += '<details data-section="memory"><summary>Memory</summary>';
+    html += '<div style="font-size: 0.85em;">Diet fitness ' + memory.dietary_fitness.toFixed(2) + ' | Adaptation ' + memory.adaptation_fitness.toFixed(2) + '</div>';
+    memory.recent_meals.forEach(meal => {
+        html += '<div style="font-size: 0.85em;">Tick ' + meal.tick + ': ' + escapeHTML(meal.food_type) + ' ' + escapeHTML(meal.food_id) +
+            ' (+' + meal.nutrition.toFixed(1) + ')</div>';
+    });
+    if (memory.knowledge.length > 0) {
+        html += '<div style="font-size: 0.85em;">Knows: ' + memory.knowledge.map(escapeHTML).join(', ') + '</div>';
+    }
+    html += '</details>';
+
+    if (entity.relationships.length > 0) {
+        html += '<details data-section="relationships" open><summary>Relationships</summary>';
+        entity.relationships.forEach(relationship => {
+            html += '<div style="font-size: 0.85em;">' + escapeHTML(relationship.kind) +
+                (relationship.detail ? ' (' + escapeHTML(relationship.detail) + ')' : '') + ': ' +
+                '<a href="#" onclick="inspectEntity(' + relationship.entity_id + '); return false;">#' + relationship.entity_id + '</a></div>';
+        });
+        html += '</details>';
+    }
+    return html;
+}
+
+// Lock the shared camera onto a creature, streaming what it does each tick
+function followEntity(entityID) {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: 'follow_entity', data: {entity_id: entityID}}));
+    }
+}
+
+function unfollowEntity() {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({action: 'unfollow_entity'}));
+    }
+}
+
+// Show what the followed creature did lately, newest tick first
+function updateFollow(track) {
+    const following = (followTrack ? followTrack.entity_id : 0) !== (track ? track.entity_id : 0);
+    followTrack = track || null;
+    const panel = document.getElementById('follow-panel');
+    if (!panel) return;
+    if (following) {
+        redrawInspector(); // Swap its Follow button
+    }
+    panel.style.display = followTrack ? '' : 'none';
+    if (followTrack) {
+        document.getElementById('follow-content').innerHTML = renderFollow(followTrack);
+    }
+}
+
+function renderFollow(track) {
+    let html = '<button onclick="unfollowEntity()" style="float: right;">✖</button>';
+    html += '<div><strong>' + (track.alive ? '🎥' : '💀') + ' ' + entityLink(track.entity_id) + ' ' + speciesLink(track.species) +
+        '</strong> since tick ' + track.start_tick + '</div>';
+    if (!track.alive) {
+        html += '<div>Died after tick ' + (track.frames.length > 0 ? track.frames[track.frames.length - 1].tick : track.start_tick) + '</div>';
+    }
+    const frames = track.frames.slice(-10).reverse();
+    if (frames.length > 0) {
+        const latest = frames[0];
+        html += '<div>' + escapeHTML(latest.activity) + ' at (' + latest.position.x.toFixed(1) + ', ' + latest.position.y.toFixed(1) + ')' +
+            ' | Energy ' + latest.energy.toFixed(1) + '</div>';
+        const decision = track.frames.slice().reverse().find(frame => frame.decision);
+        if (decision) {
+            html += renderFollowDecision(decision.decision, decision.tick);
+        }
+    }
+    frames.forEach(frame => {
+        const change = frame.energy_change >= 0 ? '+' + frame.energy_change.toFixed(1) : frame.energy_change.toFixed(1);
+        let line = 'Tick ' + frame.tick + ': ' + escapeHTML(frame.activity) + ', energy ' + frame.energy.toFixed(1) + ' (' + change + ')';
+        if (frame.decision) {
+            line += ', moved (' + frame.decision.move.x.toFixed(2) + ', ' + frame.decision.move.y.toFixed(2) + ')';
+        }
+        html += '<div style="font-size: 0.85em;">' + line + '</div>';
+        frame.interactions.forEach(interaction => {
+            html += '<div style="font-size: 0.8em; margin-left: 12px;">' + escapeHTML(interaction.kind) + ': ' + escapeHTML(interaction.description) + '</div>';
+        });
+    });
+    return html;
+}
+
+// A neural decision with what the network sensed and each layer's activations
+function renderFollowDecision(decision, tick) {
+    let html = '<div style="margin: 4px 0;"><strong>Decision at tick ' + tick + '</strong>';
+    html += '<div style="font-size: 0.85em;">Senses: ' + Object.keys(decision.inputs).sort().map(name =>
+        escapeHTML(name) + ' ' + decision.inputs[name].toFixed(2)).join(', ') + '</div>';
+    html += '<div style="font-size: 0.85em;">Move (' + decision.move.x.toFixed(2) + ', ' + decision.move.y.toFixed(2) + ') at ' +
+        (decision.intensity * 100).toFixed(0) + '% intensity</div>';
+    decision.activations.forEach((layer, i) => {
+        const name = i === 0 ? 'Input' : i === decision.activations.length - 1 ? 'Output' : 'Hidden ' + i;
+        html += '<div class="follow-layer"><span>' + name + '</span>' + layer.map(value =>
+            '<span class="follow-neuron" title="' + value.toFixed(3) + '" style="opacity: ' + (0.15 + 0.85 * Math.min(1, Math.abs(value))).toFixed(2) +
+            '; background: ' + (value >= 0 ? '#4caf50' : '#f44336') + ';"></span>').join('') + '</div>';
+    });
+    return html + '</div>';
+}
+
+// Render tools view
+function renderTools(tools) {
+    let html = '<h3>🔧 Tool System</h3>';
+    html += '<div>Total Tools: ' + tools.total_tools + '</div>';
+    html += '<div>Owned Tools: ' + tools.owned_tools + '</div>';
+    html += '<div>Dropped Tools: ' + tools.dropped_tools + '</div>';
+    html += '<div>Average Durability: ' + tools.avg_durability.toFixed(2) + '</div>';
+    html += '<div>Average Efficiency: ' + tools.avg_efficiency.toFixed(2) + '</div>';
+
+    if (tools.tool_types && Object.keys(tools.tool_types).length > 0) {
+        html += '<h4>Tool Types:</h4>';
+        Object.entries(tools.tool_types).forEach(([type, count]) => {
+            html += '<div>' + type + ': ' + count + '</div>';
+        });
+    } else {
+        html += '<br><div>No tools created yet</div>';
+    }
+
+    html += '<br><h4>Tool Usage:</h4>';
+    if (tools.owned_tools === 0) {
+        html += '<div>Usage Level: No tool use</div>';
+    } else if (tools.owned_tools < 5) {
+        html += '<div>Usage Level: Basic tool use</div>';
+    } else {
+        html += '<div>Usage Level: Advanced tool use</div>';
+    }
+
+    return html;
+}
+
+// Render environment view
+function renderEnvironment(envMod, envPressures) {
+    let html = '<h3>🌍 Environmental Systems</h3>';
+
+    // Environmental Pressures Section
+    html += '<h4>🌡️ Environmental Pressures</h4>';
+    if (envPressures) {
+        html += '<div>Active Pressures: ' + envPressures.active_pressures + '</div>';
+        html += '<div>Historical Events: ' + envPressures.total_history + '</div>';
+        html += '<div>Average Severity: ' + envPressures.average_severity.toFixed(3) + '</div>';
+
+        if (envPressures.pressure_types && Object.keys(envPressures.pressure_types).length > 0) {
+            html += '<br><strong>Active Pressure Types:</strong><br>';
+            Object.entries(envPressures.pressure_types).forEach(([type, count]) => {
+                html += '<div>• ' + type + ': ' + count + '</div>';
+            });
+        } else {
+            html += '<br><div>No active environmental pressures</div>';
+        }
+
+        if (envPressures.active_details && envPressures.active_details.length > 0) {
+            html += '<br><strong>Active Pressure Details:</strong><br>';
+            envPressures.active_details.forEach((pressure, index) => {
+                if (index >= 3) return; // Limit display
+                const durationText = pressure.duration > 0 ? pressure.duration + ' ticks' : 'Permanent';
+                html += '<div style="margin: 5px 0; padding: 5px; border-left: 3px solid #ff6b6b;">';
+                html += '<strong>' + pressure.name + '</strong> (ID: ' + pressure.id + ')<br>';
+                html += 'Type: ' + pressure.type + '<br>';
+                html += 'Severity: ' + pressure.severity.toFixed(2) + '<br>';
+                html += 'Duration: ' + durationText + '<br>';
+                html += 'Area: (' + pressure.affected_x.toFixed(1) + ', ' + pressure.affected_y.toFixed(1) + ') radius ' + pressure.radius.toFixed(1);
+                html += '</div>';
+            });
+            if (envPressures.active_details.length > 3) {
+                html += '<div>... and ' + (envPressures.active_details.length - 3) + ' more pressures</div>';
+            }
+        }
+    } else {
+        html += '<div>Environmental pressure system not initialized</div>';
+    }
+
+    html += '<br>';
+
+    // Environmental Modifications Section
+    html += '<h4>🏗️ Environmental Modifications</h4>';
+    html += '<div>Total Modifications: ' + envMod.total_modifications + '</div>';
+    html += '<div>Active Modifications: ' + envMod.active_modifications + '</div>';
+    html += '<div>Inactive Modifications: ' + envMod.inactive_modifications + '</div>';
+    html += '<div>Average Durability: ' + envMod.avg_durability.toFixed(2) + '</div>';
+    html += '<div>Tunnel Networks: ' + envMod.tunnel_networks + '</div>';
+
+    if (envMod.modification_types && Object.keys(envMod.modification_types).length > 0) {
+        html += '<br><strong>Modification Types:</strong><br>';
+        Object.entries(envMod.modification_types).forEach(([type, count]) => {
+            html += '<div>' + type + ': ' + count + '</div>';
+        });
+    } else {
+        html += '<br><div>No environmental modifications yet</div>';
+    }
+
+    html += '<br><strong>Modification Activity:</strong><br>';
+    if (envMod.total_modifications === 0) {
+        html += '<div>Activity Level: No modifications</div>';
+    } else if (envMod.active_modifications < 3) {
+        html += '<div>Activity Level: Low modification activity</div>';
+    } else {
+        html += '<div>Activity Level: High modification activity</div>';
+    }
+
+    return html;
+}
+
+// Render behavior view
+function renderBehavior(behavior) {
+    let html = '<h3>🧠 Emergent Behavior</h3>';
+    html += '<div>Total Entities: ' + behavior.total_entities + '</div>';
+    html += '<div>Discovered Behaviors: ' + behavior.discovered_behaviors + '</div>';
+
+    if (behavior.behavior_spread && Object.keys(behavior.behavior_spread).length > 0) {
+        html += '<h4>Behavior Spread:</h4>';
+        Object.entries(behavior.behavior_spread).forEach(([behavior_name, count]) => {
+            html += '<div>' + behavior_name.replace(/_/g, ' ') + ': ' + count + ' entities</div>';
+        });
+    }
+
+    if (behavior.avg_proficiency && Object.keys(behavior.avg_proficiency).length > 0) {
+        html += '<h4>Average Proficiency:</h4>';
+        Object.entries(behavior.avg_proficiency).forEach(([behavior_name, proficiency]) => {
+            html += '<div>' + behavior_name.replace(/_/g, ' ') + ': ' + proficiency.toFixed(2) + '</div>';
+        });
+    }
+
+    if (behavior.discovered_behaviors === 0) {
+        html += '<br><div>No emergent behaviors discovered yet</div>';
+        html += '<div style="color: #888; font-style: italic;">Behaviors emerge naturally as entities explore and learn from their environment and each other.</div>';
+    } else {
+        html += '<br><h4>Behavior Development:</h4>';
+        if (behavior.discovered_behaviors < 3) {
+            html += '<div>Development Level: Early behavior emergence</div>';
+        } else if (behavior.discovered_behaviors < 6) {
+            html += '<div>Development Level: Moderate behavior complexity</div>';
+        } else {
+            html += '<div>Development Level: Advanced behavioral evolution</div>';
+        }
+
+        // Show list of behaviors that have been discovered by entities
+        if (behavior.behavior_spread && Object.keys(behavior.behavior_spread).length > 0) {
+            html += '<h4>Active Behaviors:</h4>';
+            const sortedBehaviors = Object.entries(behavior.behavior_spread)
+                .sort((a, b) => b[1] - a[1]); // Sort by count descending
+            sortedBehaviors.forEach(([behavior_name, count]) => {
+                if (count > 0) {
+                    const proficiency = behavior.avg_proficiency[behavior_name] || 0;
+                    html += '<div>• <strong>' + behavior_name.replace(/_/g, ' ') + '</strong>: ' + count + ' entities (avg proficiency: ' + proficiency.toFixed(2) + ')</div>';
+                }
+            });
+        }
+    }
+
+    return html;
+}
+
+// Render reproduction view
+function renderReproduction(reproduction) {
+    let html = '<h3>🥚 Reproduction & Life Cycle</h3>';
+    html += '<div>Active Eggs: ' + reproduction.active_eggs + '</div>';
+    html += '<div>Decaying Items: ' + reproduction.decaying_items + '</div>';
+    html += '<div>Pregnant Entities: ' + reproduction.pregnant_entities + '</div>';
+    html += '<div>Ready to Mate: ' + reproduction.ready_to_mate + '</div>';
+    html += '<div>Mating Season Entities: ' + reproduction.mating_season_entities + '</div>';
+    html += '<div>Migrating Entities: ' + reproduction.migrating_entities + '</div>';
+    html += '<div>Cross-Species Mating: ' + reproduction.cross_species_mating + '</div>';
+    html += '<div>Territories with Mating: ' + reproduction.territories_with_mating + '</div>';
+    html += '<div>Seasonal Mating Rate: ' + reproduction.seasonal_mating_rate.toFixed(2) + 'x</div>';
+
+    if (reproduction.reproduction_modes && Object.keys(reproduction.reproduction_modes).length > 0) {
+        html += '<h4>Reproduction Modes:</h4>';
+        Object.entries(reproduction.reproduction_modes).forEach(([mode, count]) => {
+            html += '<div>' + mode + ': ' + count + ' entities</div>';
+        });
+    }
+
+    if (reproduction.mating_strategies && Object.keys(reproduction.mating_strategies).length > 0) {
+        html += '<h4>Mating Strategies:</h4>';
+        Object.entries(reproduction.mating_strategies).forEach(([strategy, count]) => {
+            html += '<div>' + strategy + ': ' + count + ' entities</div>';
+        });
+    }
+
+    html += '<br><h4>Reproduction Activity:</h4>';
+    if (reproduction.ready_to_mate === 0) {
+        html += '<div>Activity Level: No active mating</div>';
+    } else if (reproduction.ready_to_mate < 5) {
+        html += '<div>Activity Level: Low reproductive activity</div>';
+    } else {
+        html += '<div>Activity Level: High reproductive activity</div>';
+    }
+
+    return html;
+}
+
+// Render statistical analysis view
+function renderStatistical(statistical) {
+    if (!statistical) {
+        return '<h3>📊 Statistical Analysis</h3><div>Statistical analysis not available</div>';
+    }
+
+    let html = '<h3>📊 Statistical Analysis</h3>';
+
+    // Summary statistics
+    html += '<h4>Summary Statistics:</h4>';
+    html += '<div>Total Events: ' + statistical.total_events + '</div>';
+    html += '<div>Total Snapshots: ' + statistical.total_snapshots + '</div>';
+    html += '<div>Total Anomalies: ' + statistical.total_anomalies + '</div>';
+    if (statistical.total_energy !== undefined) {
+        html += '<div>Total Energy: ' + statistical.total_energy.toFixed(2) + '</div>';
+    }
+    if (statistical.energy_change !== undefined) {
+        const changePercent = (statistical.energy_change * 100).toFixed(1);
+        html += '<div>Energy Change: ' + changePercent + '% from baseline</div>';
+    }
+
+    // Trends
+    html += '<h4>Trends:</h4>';
+    html += '<div>Energy Trend: ' + (statistical.energy_trend || 'unknown') + '</div>';
+    html += '<div>Population Trend: ' + (statistical.population_trend || 'unknown') + '</div>';
+
+    // Recent events
+    if (statistical.recent_events && statistical.recent_events.length > 0) {
+        html += '<h4>Recent Events (' + statistical.recent_events.length + '):</h4>';
+        html += '<div style="max-height: 200px; overflow-y: auto;">';
+        statistical.recent_events.slice(0, 10).forEach(event => {
+            html += '<div style="margin: 5px 0; padding: 5px; background-color: #2a2a2a; border-radius: 3px;">';
+            html += '<strong>' + tickLink(event.tick, 'T' + event.tick) + '</strong> ';
+            html += event.type + ' (' + event.target + ')';
+            if (event.change !== 0) {
+                html += ': ' + event.change.toFixed(4);
+            }
+            if (event.description) {
+                html += '<br><small>' + event.description + '</small>';
+            }
+            html += '</div>';
+        });
+        html += '</div>';
+    }
+
+    // Latest snapshot
+    if (statistical.latest_snapshot) {
+        const snapshot = statistical.latest_snapshot;
+        html += '<h4>Latest Snapshot (T' + snapshot.tick + '):</h4>';
+        html += '<div>Total Energy: ' + snapshot.total_energy.toFixed(2) + '</div>';
+        html += '<div>Population Count: ' + snapshot.population_count + '</div>';
+
+        if (snapshot.trait_averages && Object.keys(snapshot.trait_averages).length > 0) {
+            html += '<h5>Trait Averages:</h5>';
+            Object.entries(snapshot.trait_averages).forEach(([trait, avg]) => {
+                html += '<div>' + trait + ': ' + avg.toFixed(3) + '</div>';
+            });
+        }
+    }
+
+    return html;
+}
+
+// Render ecosystem metrics view
+function renderEcosystem(ecosystem) {
+    if (!ecosystem) {
+        return '<h3>🌍 Ecosystem Metrics</h3><div>Ecosystem monitoring not available</div>';
+    }
+
+    let html = '<h3>🌍 Ecosystem Metrics</h3>';
+
+    // Diversity metrics
+    html += '<h4>Diversity Metrics:</h4>';
+    html += '<div>Shannon Diversity Index: ' + (ecosystem.shannon_diversity || 0).toFixed(4) + '</div>';
+    html += '<div>Simpson Diversity Index: ' + (ecosystem.simpson_diversity || 0).toFixed(4) + '</div>';
+    html += '<div>Species Richness: ' + (ecosystem.species_richness || 0) + '</div>';
+    html += '<div>Species Evenness: ' + (ecosystem.species_evenness || 0).toFixed(4) + '</div>';
+
+    // Population metrics
+    html += '<h4>Population Metrics:</h4>';
+    html += '<div>Total Population: ' + (ecosystem.total_population || 0) + '</div>';
+    if (ecosystem.extinction_rate !== undefined) {
+        html += '<div>Extinction Rate: ' + (ecosystem.extinction_rate * 100).toFixed(2) + '%</div>';
+    }
+    if (ecosystem.speciation_rate !== undefined) {
+        html += '<div>Speciation Rate: ' + (ecosystem.speciation_rate * 100).toFixed(2) + '%</div>';
+    }
+
+    // Network connectivity
+    html += '<h4>Network & Interaction Metrics:</h4>';
+    html += '<div>Network Connectivity: ' + (ecosystem.network_connectivity || 0).toFixed(4) + '</div>';
+    html += '<div>Average Path Length: ' + (ecosystem.average_path_length || 0).toFixed(2) + '</div>';
+    html += '<div>Clustering Coefficient: ' + (ecosystem.clustering_coefficient || 0).toFixed(4) + '</div>';
+
+    // Pollination metrics
+    html += '<h4>Pollination Metrics:</h4>';
+    html += '<div>Pollination Success Rate: ' + ((ecosystem.pollination_success || 0) * 100).toFixed(2) + '%</div>';
+    html += '<div>Cross-Species Pollination: ' + ((ecosystem.cross_species_pollination || 0) * 100).toFixed(2) + '%</div>';
+
+    // Dispersal metrics
+    html += '<h4>Dispersal Metrics:</h4>';
+    html += '<div>Average Dispersal Distance: ' + (ecosystem.average_dispersal_distance || 0).toFixed(2) + '</div>';
+    html += '<div>Seed Bank Size: ' + (ecosystem.seed_bank_size || 0) + '</div>';
+    html += '<div>Germination Rate: ' + ((ecosystem.germination_rate || 0) * 100).toFixed(2) + '%</div>';
+
+    // Seed caching mutualism
+    html += '<h4>Seed Caching Mutualism:</h4>';
+    html += '<div>Cached Seeds: ' + (ecosystem.cached_seeds || 0) + '</div>';
+    html += '<div>Forgotten-Cache Recruits: ' + (ecosystem.cache_recruits || 0) + '</div>';
+    html += '<div>Trees Planted by Cachers: ' + ((ecosystem.cacher_dependence || 0) * 100).toFixed(1) + '%</div>';
+
+    // Habitat connectivity
+    html += '<h4>Habitat Connectivity:</h4>';
+    html += '<div>Connectivity Index: ' + (ecosystem.connectivity_index || 0).toFixed(4) + '</div>';
+    html += '<div>Habitat Patches: ' + (ecosystem.habitat_patches || 0) + '</div>';
+    html += '<div>Movement Corridors: ' + (ecosystem.corridor_count || 0) + '</div>';
+    html += '<div>Choke Points: ' + (ecosystem.choke_point_count || 0) + '</div>';
+
+    // Niche overlap and competition
+    if (ecosystem.niche_overlaps && ecosystem.niche_overlaps.length > 0) {
+        html += '<h4>Niche Overlap & Competition:</h4>';
+        if (ecosystem.likely_exclusions > 0) {
+            html += '<div style="color: orange;">⚠️ ' + ecosystem.likely_exclusions + ' likely competitive exclusion(s)</div>';
+        }
+        ecosystem.niche_overlaps.forEach(pair => {
+            html += '<div style="margin: 4px 0;' + (pair.likely_exclusion ? ' color: orange;' : '') + '">' + speciesLink(pair.species_a) + ' / ' + speciesLink(pair.species_b) +
+                ': overlap ' + pair.overall.toFixed(2) + ' (diet ' + pair.diet.toFixed(2) + ', habitat ' + pair.habitat.toFixed(2) + ', activity ' + pair.activity.toFixed(2) + ')' +
+                ', α ' + pair.alpha_ab.toFixed(2) + '/' + pair.alpha_ba.toFixed(2) + '</div>';
+            if (pair.likely_exclusion) {
+                html += '<div style="margin-left: 10px; font-size: 0.9em;">' + escapeHTML(pair.excluded) + ' at risk: ' + escapeHTML(pair.reason) + '</div>';
+            }
+        });
+    }
+
+    // Ecosystem health
+    html += '<h4>Ecosystem Health:</h4>';
+    const healthScore = ecosystem.ecosystem_stability !== undefined
+        ? (ecosystem.biodiversity_index || 0) * 10 + (ecosystem.ecosystem_stability || 0) * 50 + (ecosystem.network_connectivity || 0) * 30
+        : 0;
+    html += '<div>Overall Health Score: ' + Math.min(healthScore, 100).toFixed(1) + '/100</div>';
+    html += '<div>Biodiversity Index: ' + (ecosystem.biodiversity_index || 0).toFixed(4) + '</div>';
+    html += '<div>Ecosystem Stability: ' + (ecosystem.ecosystem_stability || 0).toFixed(4) + '</div>';
+    html += '<div>Ecosystem Resilience: ' + (ecosystem.ecosystem_resilience || 0).toFixed(4) + '</div>';
+
+    // Population by species
+    if (ecosystem.population_by_species && Object.keys(ecosystem.population_by_species).length > 0) {
+        html += '<h4>Population by Species:</h4>';
+        html += '<div style="max-height: 200px; overflow-y: auto;">';
+        const sortedSpecies = Object.entries(ecosystem.population_by_species)
+            .sort(([,a], [,b]) => b - a)
+            .slice(0, 10); // Top 10 species
+        sortedSpecies.forEach(([species, count]) => {
+            html += '<div style="margin: 2px 0;">' + species + ': ' + count + '</div>';
+        });
+        html += '</div>';
+    }
+
+    // Pollinator efficiency
+    if (ecosystem.pollinator_efficiency && Object.keys(ecosystem.pollinator_efficiency).length > 0) {
+        html += '<h4>Pollinator Efficiency:</h4>';
+        Object.entries(ecosystem.pollinator_efficiency).forEach(([type, efficiency]) => {
+            html += '<div>' + type + ': ' + (efficiency * 100).toFixed(1) + '%</div>';
+        });
+    }
+
+    // Dispersal methods
+    if (ecosystem.dispersal_methods && Object.keys(ecosystem.dispersal_methods).length > 0) {
+        html += '<h4>Dispersal Methods:</h4>';
+        Object.entries(ecosystem.dispersal_methods).forEach(([method, count]) => {
+            html += '<div>' + method + ': ' + count + '</div>';
+        });
+    }
+
+    return html;
+}
+
+// Render anomalies detection view
+function renderAnomalies(anomalies) {
+    if (!anomalies) {
+        return '<h3>⚠️ Anomaly Detection</h3><div>Anomaly detection not available</div>';
+    }
+
+    let html = '<h3>⚠️ Anomaly Detection & Historical Analysis</h3>';
+
+    if (anomalies.total_anomalies === 0) {
+        html += '<div style="color: #4CAF50;">✅ No anomalies detected!</div>';
+        html += '<div>The simulation appears to be running within expected parameters.</div>';
+        html += '<div style="margin-top: 10px; color: #888; font-style: italic;">Anomaly detection monitors for unusual patterns in energy conservation, population dynamics, trait distributions, and system behaviors.</div>';
+    } else {
+        html += '<div>Found ' + anomalies.total_anomalies + ' anomalies in simulation history:</div><br>';
+
+        // Anomaly types summary with enhanced information
+        if (anomalies.anomaly_types && Object.keys(anomalies.anomaly_types).length > 0) {
+            html += '<h4>📊 Anomaly Categories:</h4>';
+            Object.entries(anomalies.anomaly_types).forEach(([type, count]) => {
+                let severity = '';
+                let color = '#ffcc00';
+                if (count > 10) {
+                    severity = ' - High frequency';
+                    color = '#ff6666';
+                } else if (count > 5) {
+                    severity = ' - Moderate frequency';
+                    color = '#ffaa00';
+                } else {
+                    severity = ' - Low frequency';
+                    color = '#88cc88';
+                }
+                html += '<div style="color: ' + color + ';">• ' + type.replace(/_/g, ' ').toUpperCase() + ': ' + count + ' occurrences' + severity + '</div>';
+            });
+            html += '<br>';
+        }
+
+        // Recent anomalies with enhanced display
+        if (anomalies.recent_anomalies && anomalies.recent_anomalies.length > 0) {
+            html += '<h4>📋 Recent Anomaly History (Last ' + anomalies.recent_anomalies.length + ' events):</h4>';
+            html += '<div style="max-height: 300px; overflow-y: auto; border: 1px solid #444; padding: 10px; border-radius: 5px;">';
+
+            // Sort anomalies by tick (newest first)
+            const sortedAnomalies = [...anomalies.recent_anomalies].sort((a, b) => b.tick - a.tick);
+
+            sortedAnomalies.forEach(anomaly => {
+                let severityColor = '#ffcc00';
+                let severityIcon = '⚠️';
+                let confidenceIcon = '🔍';
+
+                if (anomaly.severity >= 0.8) {
+                    severityColor = '#ff4444';
+                    severityIcon = '🚨';
+                } else if (anomaly.severity >= 0.6) {
+                    severityColor = '#ff8800';
+                    severityIcon = '⚠️';
+                } else if (anomaly.severity >= 0.4) {
+                    severityColor = '#ffcc00';
+                    severityIcon = '⚡';
+                } else {
+                    severityColor = '#88cc88';
+                    severityIcon = 'ℹ️';
+                }
+
+                if (anomaly.confidence >= 0.8) {
+                    confidenceIcon = '🎯';
+                } else if (anomaly.confidence >= 0.6) {
+                    confidenceIcon = '🔍';
+                } else {
+                    confidenceIcon = '❓';
+                }
+
+                html += '<div style="margin: 8px 0; padding: 8px; background-color: rgba(68, 68, 68, 0.3); border-radius: 3px; border-left: 3px solid ' + severityColor + ';">';
+                html += '<div style="display: flex; justify-content: space-between; align-items: center;">';
+                html += '<strong>' + severityIcon + ' ' + anomaly.type.replace(/_/g, ' ').toUpperCase() + '</strong>';
+                html += '<span style="color: #aaa; font-size: 0.9em;">' + tickLink(anomaly.tick) + '</span>';
+                html += '</div>';
+                html += '<div style="margin: 5px 0;">' + anomaly.description + '</div>';
+                html += '<div style="display: flex; gap: 15px; font-size: 0.9em; color: #ccc;">';
+                html += '<span>Severity: ' + severityIcon + ' ' + (anomaly.severity * 100).toFixed(0) + '%</span>';
+                html += '<span>Confidence: ' + confidenceIcon + ' ' + (anomaly.confidence * 100).toFixed(0) + '%</span>';
+                html += '</div>';
+                html += '</div>';
+            });
+            html += '</div>';
+        }
+
+        // Recommendations with enhanced formatting
+        if (anomalies.recommendations && anomalies.recommendations.length > 0) {
+            html += '<h4>💡 Diagnostic Recommendations:</h4>';
+            html += '<div style="background-color: rgba(76, 175, 80, 0.1); border-left: 3px solid #4CAF50; padding: 10px; border-radius: 3px;">';
+            anomalies.recommendations.forEach(rec => {
+                html += '<div style="margin: 5px 0;">💡 ' + rec + '</div>';
+            });
+            html += '</div>';
+        } else if (anomalies.total_anomalies > 0) {
+            html += '<h4>💡 General Recommendations:</h4>';
+            html += '<div style="background-color: rgba(255, 193, 7, 0.1); border-left: 3px solid #ffc107; padding: 10px; border-radius: 3px;">';
+            html += '<div>• Monitor system parameters for patterns</div>';
+            html += '<div>• Check if anomalies correlate with specific events</div>';
+            html += '<div>• Consider adjusting simulation parameters if anomalies persist</div>';
+            html += '</div>';
+        }
+    }
+
+    return html;
+}
+
+// Render warfare view
+function renderWarfare(warfare) {
+    if (!warfare) {
+        return '<h3>⚔️ Warfare & Diplomacy</h3><div>Warfare data not available</div>';
+    }
+
+    let html = '<h3>⚔️ Multi-Colony Warfare & Diplomacy</h3>';
+
+    // Colony overview; each colony is a #colony/ID link target
+    if (warfare.colony_details && warfare.colony_details.length > 0) {
+        html += '<h4>🏰 Colonies (' + warfare.colony_details.length + '):</h4>';
+        html += '<div class="colony-list">';
+        warfare.colony_details.forEach(colony => {
+            html += '<div class="colony-item" id="colony-' + colony.id + '">';
+            html += '<strong>' + colonyLink(colony.id) + '</strong><br>';
+            html += 'Size: ' + colony.size + ' | Fitness: ' + colony.fitness.toFixed(2) + ' | Nest: (' + colony.location.x.toFixed(1) + ', ' + colony.location.y.toFixed(1) + ')<br>';
+            const relations = Object.entries(colony.relations || {});
+            if (relations.length > 0) {
+                html += '<small>Relations: ' + relations.map(([otherID, relation]) =>
+                    colonyLink(otherID) + ' <span style="color: ' + getRelationColor(relation) + '">' + relation + '</span>').join(', ') + '</small>';
+            }
+            html += '</div>';
+        });
+        html += '</div>';
+    }
+
+    // Active conflicts
+    const activeConflicts = (warfare.conflicts || []).filter(conflict => conflict.is_active);
+    if (activeConflicts.length > 0) {
+        html += '<h4>⚔️ Active Conflicts (' + activeConflicts.length + '):</h4>';
+        activeConflicts.forEach(conflict => {
+            const intensityColor = getConflictIntensityColor(conflict.intensity);
+            html += '<div class="conflict-item">';
+            html += '<strong style="color: ' + intensityColor + '">' + conflict.conflict_type + '</strong><br>';
+            html += colonyLink(conflict.attacker_id) + ' vs ' + colonyLink(conflict.defender_id) + '<br>';
+            html += 'Duration: ' + conflict.turns_active + ' turns | Intensity: ' + conflict.intensity.toFixed(2) + '<br>';
+            html += 'Casualties: ' + conflict.casualty_count + '<br>';
+            if (conflict.war_goal) {
+                html += '<small>War goal: ' + conflict.war_goal + '</small>';
+            }
+            html += '</div>';
+        });
+    } else {
+        html += '<h4>⚔️ Active Conflicts: None</h4>';
+        html += '<div style="color: #4CAF50;">🕊️ All colonies are currently at peace</div>';
+    }
+
+    // Diplomatic relations summary
+    if (warfare.diplomatic_summary) {
+        html += '<h4>🤝 Diplomatic Relations Summary:</h4>';
+        Object.entries(warfare.diplomatic_summary).forEach(([relation, count]) => {
+            const relationColor = getRelationColor(relation);
+            html += '<div style="color: ' + relationColor + ';">' + relation + ': ' + count + '</div>';
+        });
+    }
+
+    // Trade activity
+    if (warfare.trade_activity) {
+        html += '<h4>💰 Trade Activity:</h4>';
+        html += '<div>Active Agreements: ' + warfare.trade_activity.active_agreements + '</div>';
+        html += '<div>Trade Volume: ' + warfare.trade_activity.total_volume + '</div>';
+        html += '<div>Trade Efficiency: ' + (warfare.trade_activity.efficiency * 100).toFixed(1) + '%</div>';
+    }
+
+    // Recent warfare events
+    if (warfare.recent_events && warfare.recent_events.length > 0) {
+        html += '<h4>📰 Recent Warfare Events:</h4>';
+        html += '<div class="events-list">';
+        warfare.recent_events.slice(0, 10).forEach(event => {
+            html += '<div class="event-item">';
+            html += '<small>[' + tickLink(event.tick) + ']</small> ';
+            html += event.description;
+            html += '</div>';
+        });
+        html += '</div>';
+    }
+
+    // Statistics
+    if (warfare.statistics) {
+        html += '<h4>📊 Warfare Statistics:</h4>';
+        html += '<div>Total Conflicts: ' + warfare.statistics.total_conflicts + '</div>';
+        html += '<div>Total Casualties: ' + warfare.statistics.total_casualties + '</div>';
+        html += '<div>Peace Treaties: ' + warfare.statistics.peace_treaties + '</div>';
+        html += '<div>Alliance Formations: ' + warfare.statistics.alliance_formations + '</div>';
+    }
+
+    return html;
+}
+
+function getRelationColor(relation) {
+    const colors = {
+        'Allied': '#4CAF50',
+        'Enemy': '#F44336',
+        'Neutral': '#FFC107',
+        'Trading': '#2196F3',
+        'Truce': '#FF9800',
+        'Vassal': '#9C27B0'
+    };
+    return colors[relation] || '#888';
+}
+
+function getConflictIntensityColor(intensity) {
+    if (intensity < 0.3) return '#FFC107'; // Low intensity - yellow
+    if (intensity < 0.7) return '#FF9800'; // Medium intensity - orange
+    return '#F44336'; // High intensity - red
+}
+
+function renderFungal(fungal) {
+    if (!fungal) {
+        return '<h3>🍄 Fungal Networks</h3><div>Fungal system data not available</div>';
+    }
+
+    let html = '<h3>🍄 Fungal Networks & Decomposer System</h3>';
+
+    // Decomposer overview
+    if (fungal.total_decomposers !== undefined) {
+        html += '<h4>🧪 Decomposer Status:</h4>';
+        html += '<div class="stats-row">';
+        html += '<div class="stat-item">Total Decomposers: <strong>' + (fungal.total_decomposers || 0) + '</strong></div>';
+        html += '<div class="stat-item">Active Decomposers: <strong>' + (fungal.active_decomposers || 0) + '</strong></div>';
+        html += '</div>';
+    }
+
+    // Nutrient cycling statistics
+    if (fungal.nutrient_cycling) {
+        html += '<h4>♻️ Nutrient Cycling:</h4>';
+        html += '<div class="stats-row">';
+        html += '<div class="stat-item">Decomposition Rate: <strong>' + (fungal.nutrient_cycling.decomposition_rate || 0).toFixed(2) + '/tick</strong></div>';
+        html += '<div class="stat-item">Nutrients Released: <strong>' + (fungal.nutrient_cycling.nutrients_released || 0).toFixed(1) + '</strong></div>';
+        html += '</div>';
+
+        if (fungal.nutrient_cycling.nutrient_types) {
+            html += '<div class="nutrient-breakdown">';
+            Object.entries(fungal.nutrient_cycling.nutrient_types).forEach(([nutrient, amount]) => {
+                html += '<div class="nutrient-item">' + nutrient + ': ' + amount.toFixed(1) + '</div>';
+            });
+            html += '</div>';
+        }
+    }
+
+    // Spore networks
+    if (fungal.spore_networks) {
+        html += '<h4>🌐 Spore Networks:</h4>';
+        html += '<div class="stats-row">';
+        html += '<div class="stat-item">Network Connections: <strong>' + (fungal.spore_networks.connections || 0) + '</strong></div>';
+        html += '<div class="stat-item">Network Efficiency: <strong>' + ((fungal.spore_networks.efficiency || 0) * 100).toFixed(1) + '%</strong></div>';
+        html += '</div>';
+    }
+
+    // Fungal reproduction
+    if (fungal.reproduction) {
+        html += '<h4>🌱 Fungal Reproduction:</h4>';
+        html += '<div class="stats-row">';
+        html += '<div class="stat-item">Spores Released: <strong>' + (fungal.reproduction.spores_released || 0) + '</strong></div>';
+        html += '<div class="stat-item">Successful Germinations: <strong>' + (fungal.reproduction.germinations || 0) + '</strong></div>';
+        html += '</div>';
+    }
+
+    // Recent events
+    if (fungal.recent_events && fungal.recent_events.length > 0) {
+        html += '<h4>📋 Recent Fungal Activity:</h4>';
+        html += '<div class="event-list">';
+        fungal.recent_events.slice(0, 10).forEach(event => {
+            html += '<div class="event-item">• ' + event + '</div>';
+        });
+        html += '</div>';
+    }
+
+    return html;
+}
+
+// Render cultural knowledge view
+function renderCultural(cultural) {
+    if (!cultural) {
+        return '<h3>🧠 Cultural Knowledge</h3><div>Cultural knowledge system data not available</div>';
+    }
+
+    let html = '<h3>🧠 Cultural Knowledge & Learning System</h3>';
+
+    // General overview
+    html += '<h4>📊 Knowledge Overview:</h4>';
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item">Total Knowledge Types: <strong>' + (cultural.total_knowledge_types || 0) + '</strong></div>';
+    html += '<div class="stat-item">Entities with Knowledge: <strong>' + (cultural.total_entities || 0) + '</strong></div>';
+    html += '</div>';
+
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item">Active Innovations: <strong>' + (cultural.active_innovations || 0) + '</strong></div>';
+    html += '<div class="stat-item">Avg Knowledge/Entity: <strong>' + (cultural.avg_knowledge_per_entity || 0).toFixed(1) + '</strong></div>';
+    html += '</div>';
+
+    // Learning activity
+    html += '<h4>🎓 Learning & Teaching Activity:</h4>';
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item">Teaching Events: <strong>' + (cultural.total_teaching_events || 0) + '</strong></div>';
+    html += '<div class="stat-item">Learning Events: <strong>' + (cultural.total_learning_events || 0) + '</strong></div>';
+    html += '</div>';
+
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item">Innovations Created: <strong>' + (cultural.total_innovations_created || 0) + '</strong></div>';
+    html += '<div class="stat-item">Knowledge Lost: <strong>' + (cultural.knowledge_loss_events || 0) + '</strong></div>';
+    html += '</div>';
+
+    // Knowledge type distribution
+    if (cultural.knowledge_type_distribution) {
+        html += '<h4>📚 Knowledge Types:</h4>';
+        html += '<div class="knowledge-distribution">';
+        Object.entries(cultural.knowledge_type_distribution).forEach(([type, count]) => {
+            const barWidth = Math.max(5, (count / Math.max(...Object.values(cultural.knowledge_type_distribution))) * 200);
+            html += '<div class="knowledge-type-item">';
+            html += '<div class="knowledge-type-label">' + type + ': ' + count + '</div>';
+            html += '<div class="knowledge-bar" style="width: ' + barWidth + 'px; background: #4CAF50; height: 20px; margin: 2px 0;"></div>';
+            html += '</div>';
+        });
+        html += '</div>';
+    }
+
+    return html;
+}
+
+// Render symbiotic relationships view
+function renderSymbiotic(symbiotic) {
+    if (!symbiotic) {
+        return '<h3>🦠 Symbiotic Relationships</h3><div>Symbiotic relationships system data not available</div>';
+    }
+
+    let html = '<h3>🦠 Symbiotic Relationships</h3>';
+
+    // Basic statistics
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item">Total Relationships: <strong>' + (symbiotic.total_relationships || 0) + '</strong></div>';
+    html += '<div class="stat-item">Active Relationships: <strong>' + (symbiotic.active_relationships || 0) + '</strong></div>';
+    html += '</div>';
+
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item">Average Age: <strong>' + (symbiotic.average_relationship_age || 0).toFixed(1) + ' ticks</strong></div>';
+    html += '<div class="stat-item">Disease Rate: <strong>' + (symbiotic.disease_transmission_rate || 0).toFixed(3) + '</strong></div>';
+    html += '</div>';
+
+    // Relationship types
+    html += '<h4>🔬 Relationship Types:</h4>';
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item">🦠 Parasitic: <strong>' + (symbiotic.active_parasitic || 0) + '</strong></div>';
+    html += '<div class="stat-item">🤝 Mutualistic: <strong>' + (symbiotic.active_mutualistic || 0) + '</strong></div>';
+    html += '<div class="stat-item">🐠 Commensal: <strong>' + (symbiotic.active_commensal || 0) + '</strong></div>';
+    html += '</div>';
+
+    // Disease characteristics (if parasitic relationships exist)
+    if (symbiotic.active_parasitic > 0) {
+        html += '<h4>🦠 Disease Characteristics:</h4>';
+        html += '<div class="stats-row">';
+        html += '<div class="stat-item">Average Virulence: <strong>' + (symbiotic.average_virulence || 0).toFixed(3) + '</strong></div>';
+        html += '<div class="stat-item">Average Transmission: <strong>' + (symbiotic.average_transmission || 0).toFixed(3) + '</strong></div>';
+        html += '</div>';
+    }
+
+    // Relationship type distribution visualization
+    if (symbiotic.relationship_types && Object.keys(symbiotic.relationship_types).length > 0) {
+        html += '<h4>📊 Distribution:</h4>';
+        html += '<div class="relationship-distribution">';
+
+        const total = Object.values(symbiotic.relationship_types).reduce((sum, count) => sum + count, 0);
+        const colors = {
+            'parasitic': '#ff6b6b',
+            'mutualistic': '#4ecdc4',
+            'commensal': '#45b7d1'
+        };
+
+        Object.entries(symbiotic.relationship_types).forEach(([type, count]) => {
+            if (count > 0) {
+                const percentage = total > 0 ? ((count / total) * 100).toFixed(1) : 0;
+                const barWidth = total > 0 ? Math.max(5, (count / total) * 200) : 5;
+                const color = colors[type] || '#888';
+
+                html += '<div class="relationship-type-item">';
+                html += '<div class="relationship-type-label">' + type + ': ' + count + ' (' + percentage + '%)</div>';
+                html += '<div class="relationship-bar" style="width: ' + barWidth + 'px; background: ' + color + '; height: 20px; margin: 2px 0;"></div>';
+                html += '</div>';
+            }
+        });
+        html += '</div>';
+    }
+
+    return html;
+}
+
+// BioRhythm rendering function
+function renderBiorhythm(biorhythm) {
+    if (!biorhythm) {
+        return '<h3>🕒 BioRhythm System</h3><div>BioRhythm system data not available</div>';
+    }
+
+    let html = '<h3>🕒 BioRhythm & Activity Patterns</h3>';
+
+    // Activity distribution
+    html += '<h4>📊 Activity Distribution:</h4>';
+    if (biorhythm.activity_distribution) {
+        html += '<div class="stats-row">';
+        const activities = ['Sleep', 'Eat', 'Drink', 'Play', 'Explore', 'Scavenge', 'Rest', 'Socialize'];
+        activities.forEach(activity => {
+            const count = biorhythm.activity_distribution[activity.toLowerCase()] || 0;
+            html += '<div class="stat-item tooltip">' + activity + ': <strong>' + count + '</strong><span class="tooltiptext">Number of entities currently engaged in ' + activity.toLowerCase() + ' activity. Activity selection is based on current needs and circadian preferences.</span></div>';
+        });
+        html += '</div>';
+    }
+
+    // Circadian patterns
+    html += '<h4>🌙 Circadian Patterns:</h4>';
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item tooltip">Diurnal Entities: <strong>' + (biorhythm.diurnal_count || 0) + '</strong><span class="tooltiptext">Entities active during the day (based on circadian_preference trait). Includes most herbivores and some omnivores.</span></div>';
+    html += '<div class="stat-item tooltip">Nocturnal Entities: <strong>' + (biorhythm.nocturnal_count || 0) + '</strong><span class="tooltiptext">Entities active at night (based on circadian_preference trait). Includes most predators and some specialized species.</span></div>';
+    html += '<div class="stat-item tooltip">Light Disrupted: <strong>' + (biorhythm.light_disrupted || 0) + '</strong><span class="tooltiptext">Nocturnal entities active under the night glow of fire-using settlements. Their hunts are easily spotted and they no longer count as in sync.</span></div>';
+    html += '</div>';
+
+    // Need levels
+    html += '<h4>🎯 Average Need Levels:</h4>';
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item tooltip">Sleep Need: <strong>' + (biorhythm.avg_sleep_need || 0).toFixed(2) + '</strong><span class="tooltiptext">Average sleep requirement across all entities. Higher values during winter and for aging entities.</span></div>';
+    html += '<div class="stat-item tooltip">Hunger Need: <strong>' + (biorhythm.avg_hunger_need || 0).toFixed(2) + '</strong><span class="tooltiptext">Average hunger level. Entities only eat when hunger exceeds threshold, preventing random feeding behavior.</span></div>';
+    html += '</div>';
+
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item tooltip">Thirst Need: <strong>' + (biorhythm.avg_thirst_need || 0).toFixed(2) + '</strong><span class="tooltiptext">Average thirst level. Entities seek water sources when thirst exceeds threshold, especially in hot seasons.</span></div>';
+    html += '<div class="stat-item tooltip">Play Drive: <strong>' + (biorhythm.avg_play_drive || 0).toFixed(2) + '</strong><span class="tooltiptext">Average play motivation. Higher intelligence entities show more play behavior, especially during favorable seasons.</span></div>';
+    html += '</div>';
+
+    // Seasonal effects
+    if (biorhythm.seasonal_effects) {
+        html += '<h4>🍂 Seasonal BioRhythm Effects:</h4>';
+        html += '<div style="background-color: #3a3a3a; padding: 10px; border-radius: 5px; margin: 5px 0;">';
+        html += '<strong>Current Season Effects:</strong><br>';
+        html += '• Winter: Increased sleep needs, reduced exploration<br>';
+        html += '• Spring: Boosted play and exploration drives<br>';
+        html += '• Summer: Increased thirst, peak activity levels<br>';
+        html += '• Autumn: Resource gathering focus, preparation behaviors';
+        html += '</div>';
+    }
+
+    // Sample entity biorhythm data
+    if (biorhythm.sample_entities && biorhythm.sample_entities.length > 0) {
+        html += '<h4>🔍 Sample Entity BioRhythms:</h4>';
+        html += '<div style="max-height: 200px; overflow-y: auto;">';
+        biorhythm.sample_entities.slice(0, 5).forEach(entity => {
+            html += '<div style="background-color: #4a4a4a; padding: 8px; margin: 5px 0; border-radius: 3px;">';
+            html += '<strong>' + entityLink(entity.entity_id) + '</strong> (' + speciesLink(entity.species) + ')<br>';
+            html += '<small>';
+            html += 'Current Activity: ' + (entity.current_activity || 'None') + '<br>';
+            html += 'Circadian Type: ' + (entity.circadian_type || 'Unknown') + '<br>';
+            html += 'Sleep Need: ' + (entity.sleep_need || 0).toFixed(2) + ' | ';
+            html += 'Hunger: ' + (entity.hunger_need || 0).toFixed(2) + ' | ';
+            html += 'Thirst: ' + (entity.thirst_need || 0).toFixed(2);
+            html += '</small>';
+            html += '</div>';
+        });
+        html += '</div>';
+    }
+
+    return html;
+}
+
+// Neural Networks rendering function
+function renderNeural(neural) {
+    if (!neural) {
+        return '<h3>🧠 Neural Networks</h3><div>Neural networks system data not available</div>';
+    }
+
+    let html = '<h3>🧠 Neural Networks</h3>';
+
+    // Basic neural statistics
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item tooltip">Total Networks: <strong>' + (neural.total_networks || 0) + '</strong><span class="tooltiptext">Total number of neural networks ever created. Networks are created automatically for entities with intelligence > 0.3.</span></div>';
+    html += '<div class="stat-item tooltip">Active Networks: <strong>' + (neural.active_network_count || 0) + '</strong><span class="tooltiptext">Currently active neural networks belonging to living entities. Networks disappear when entities die or lose intelligence.</span></div>';
+    html += '</div>';
+
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item tooltip">Total Behaviors: <strong>' + (neural.total_behaviors || 0) + '</strong><span class="tooltiptext">Number of learned behavior patterns stored in neural networks.</span></div>';
+    html += '<div class="stat-item tooltip">Emergent Behaviors: <strong>' + (neural.emergent_behaviors || 0) + '</strong><span class="tooltiptext">Novel behaviors discovered through neural learning that weren\'t programmed.</span></div>';
+    html += '</div>';
+
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item tooltip">Learning Events: <strong>' + (neural.total_learning_events || 0) + '</strong><span class="tooltiptext">Total number of learning updates performed. Each action outcome provides feedback to strengthen or weaken neural connections.</span></div>';
+    html += '<div class="stat-item tooltip">Collective Behaviors: <strong>' + (neural.collective_behavior_count || 0) + '</strong><span class="tooltiptext">Shared behaviors learned by multiple entities through social learning.</span></div>';
+    html += '</div>';
+
+    // Learning and adaptation metrics
+    html += '<h4>🎯 Learning Metrics:</h4>';
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item tooltip">Avg Network Complexity: <strong>' + (neural.avg_network_complexity || 0).toFixed(3) + '</strong><span class="tooltiptext">Average number of neurons and connections per network. More intelligent entities have more complex networks.</span></div>';
+    html += '<div class="stat-item tooltip">Success Rate: <strong>' + (neural.success_rate * 100 || 0).toFixed(1) + '%</strong><span class="tooltiptext">Percentage of neural decisions that improved entity fitness (better food finding, threat avoidance, energy management).</span></div>';
+    html += '</div>';
+
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item tooltip">Base Learning Rate: <strong>' + (neural.base_learning_rate || 0).toFixed(3) + '</strong><span class="tooltiptext">How quickly neural networks adapt their weights based on feedback. Higher values mean faster learning.</span></div>';
+    html += '<div class="stat-item tooltip">Adaptation Rate: <strong>' + (neural.adaptation_rate || 0).toFixed(3) + '</strong><span class="tooltiptext">Rate at which networks change their structure and behavior patterns over time.</span></div>';
+    html += '</div>';
+
+    // Experience metrics
+    html += '<h4>📚 Experience:</h4>';
+    html += '<div class="stats-row">';
+    html += '<div class="stat-item tooltip">Total Experience: <strong>' + (neural.total_experience || 0).toFixed(1) + '</strong><span class="tooltiptext">Accumulated learning experience across all networks. Experience points are gained through successful actions and lost through failures.</span></div>';
+    html += '<div class="stat-item tooltip">Avg Experience per Network: <strong>' + (neural.avg_experience_per_network || 0).toFixed(1) + '</strong><span class="tooltiptext">Average experience level per neural network. More experienced networks make better decisions.</span></div>';
+    html += '</div>';
+
+    // What happens when entities disappear explanation
+    html += '<h4>❓ Neural Network Lifecycle:</h4>';
+    html += '<div class="stats-row">';
+    html += '<div style="background-color: #3a3a3a; padding: 10px; border-radius: 5px; margin: 5px 0;">';
+    html += '<strong>Why do entities appear/disappear from this view?</strong><br>';
+    html += '• <strong>Appear:</strong> When entities gain intelligence > 0.3 and their first neural network is created<br>';
+    html += '• <strong>Disappear:</strong> When entities die (networks are deleted) or intelligence drops below 0.3<br>';
+    html += '• <strong>Learning:</strong> Networks never "finish" learning - they continuously adapt based on experience<br>';
+    html += '• <strong>Memory:</strong> Learned information is stored in synaptic weights and connection strengths<br>';
+    html += '• <strong>Inheritance:</strong> Neural capabilities pass to offspring through the intelligence genetic trait';
+    html += '</div>';
+    html += '</div>';
+
+    // Successful strategies
+    if (neural.successful_strategies && neural.successful_strategies.length > 0) {
+        html += '<h4>🏆 Successful Strategies:</h4>';
+        html += '<div class="strategy-list">';
+        neural.successful_strategies.forEach((strategy, index) => {
+            if (index < 10) { // Limit to top 10
+                html += '<div class="strategy-item tooltip">• ' + strategy + '<span class="tooltiptext">A behavior pattern that has been successful across multiple entities and is being reinforced through learning.</span></div>';
+            }
+        });
+        html += '</div>';
+    }
+
+    // Sample entity networks (if available)
+    if (neural.entity_networks && Object.keys(neural.entity_networks).length > 0) {
+        html += '<h4>🔗 Entity Networks (Sample):</h4>';
+        html += '<div class="entity-networks">';
+
+        let count = 0;
+        Object.entries(neural.entity_networks).forEach(([entityId, networkData]) => {
+            if (count < 5 && networkData) { // Show first 5 networks
+                html += '<div class="entity-network-item">';
+                html += '<div class="entity-id tooltip">' + entityLink(entityId) + '<span class="tooltiptext">Individual entity with an active neural network. This entity has intelligence > 0.3 and is learning from its experiences.</span></div>';
+                html += '<div class="network-type tooltip">Type: ' + (networkData.type || 'Unknown') + '<span class="tooltiptext">Neural network architecture type: FeedForward (basic), Recurrent (memory), Convolutional (pattern recognition), or Reinforcement (trial-and-error).</span></div>';
+                html += '<div class="network-complexity tooltip">Complexity: ' + (networkData.complexity_score || 0).toFixed(3) + '<span class="tooltiptext">Network complexity based on number of neurons, connections, and layers. More intelligent entities have higher complexity.</span></div>';
+                html += '<div class="network-experience tooltip">Experience: ' + (networkData.experience || 0).toFixed(1) + '<span class="tooltiptext">Total learning experience accumulated. Gained through successful actions, lost through failures. Higher experience means better decision-making.</span></div>';
+                if (networkData.total_decisions && networkData.total_decisions > 0) {
+                    const successRate = ((networkData.correct_decisions || 0) / networkData.total_decisions * 100);
+                    html += '<div class="network-success tooltip">Success: ' + successRate.toFixed(1) + '% (' + (networkData.correct_decisions || 0) + '/' + networkData.total_decisions + ')<span class="tooltiptext">Percentage of decisions that improved fitness. Tracks how well this network has learned to make beneficial choices.</span></div>';
+                }
+                html += '</div>';
+                count++;
+            }
+        });
+        html += '</div>';
+    }
+
+    return html;
+}
@@ -0,0 +1,83 @@
+// Lobby: lists the server's worlds and creates and deletes them
+const refreshMS = 2000;
+
+function refreshWorlds() {
+    fetch('/api/worlds')
+        .then(response => response.json())
+        .then(showWorlds);
+}
+
+function showWorlds(worlds) {
+    const list = document.getElementById('worldList');
+    list.innerHTML = '';
+    document.getElementById('noWorlds').hidden = worlds.length > 0;
+    worlds.forEach(world => {
+        const row = document.createElement('tr');
+        const link = document.createElement('a');
+        link.href = world.url;
+        link.textContent = world.name;
+        const autosave = world.autosave_interval > 0 ? 'every ' + world.autosave_interval + ' ticks' : 'on shutdown';
+        const saved = world.last_saved ? 'tick ' + world.last_saved_tick + ' (' + new Date(world.last_saved).toLocaleTimeString() + ')' : '-';
+        const cells = [link, world.tick, world.entities, world.plants, world.populations, world.players, world.clients, autosave, saved];
+        cells.forEach(value => {
+            const cell = document.createElement('td');
+            if (value instanceof Node) {
+                cell.appendChild(value);
+            } else {
+                cell.textContent = value;
+            }
+            row.appendChild(cell);
+        });
+
+        const remove = document.createElement('button');
+        remove.className = 'button';
+        remove.textContent = 'Delete';
+        remove.addEventListener('click', () => deleteWorld(world.name));
+        const actions = document.createElement('td');
+        actions.appendChild(remove);
+        row.appendChild(actions);
+        list.appendChild(row);
+    });
+}
+
+function deleteWorld(name) {
+    if (!confirm('Delete world "' + name + '" and its saves?')) {
+        return;
+    }
+    fetch('/api/worlds?name=' + encodeURIComponent(name), {method: 'DELETE'})
+        .then(response => response.ok ? response.json() : response.text().then(text => { throw new Error(text); }))
+        .then(showWorlds)
+        .catch(error => alert(error.message));
+}
+
+document.getElementById('createForm').addEventListener('submit', event => {
+    event.preventDefault();
+    const errorDiv = document.getElementById('createError');
+    errorDiv.textContent = '';
+
+    const request = {
+        name: document.getElementById('worldName').value,
+        autosave_interval: parseInt(document.getElementById('autosaveInterval').value, 10) || 0
+    };
+    const config = document.getElementById('worldConfig').value.trim();
+    if (config) {
+        try {
+            request.config = JSON.parse(config);
+        } catch (error) {
+            errorDiv.textContent = 'Run config is not valid JSON: ' + error.message;
+            return;
+        }
+    }
+
+    fetch('/api/worlds', {method: 'POST', headers: {'Content-Type': 'application/json'}, body: JSON.stringify(request)})
+        .then(response => response.ok ? response.json() : response.text().then(text => { throw new Error(text); }))
+        .then(world => {
+            window.location.href = world.url;
+        })
+        .catch(error => {
+            errorDiv.textContent = error.message;
+        });
+});
+
+refreshWorlds();
+setInterval(refreshWorlds, refreshMS);
@@ -0,0 +1,154 @@
+// Replay playback: fetches recorded frames as the viewer plays or scrubs
+const speciesColors = {herbivore: '#2ecc40', predator: '#ff4136', omnivore: '#ff851b'};
+const stepMS = 100; // Playback advances the speed in ticks every step
+
+let info = null;
+let currentTick = 0;
+let playing = false;
+let speed = 1;
+let loading = false;
+let pendingTick = null; // Tick asked for while a frame was loading
+
+const canvas = document.getElementById('replayCanvas');
+const context = canvas.getContext('2d');
+const scrubber = document.getElementById('scrubber');
+const playButton = document.getElementById('playButton');
+
+fetch('/api/replay')
+    .then(response => response.json())
+    .then(data => {
+        info = data;
+        scrubber.min = info.first_tick;
+        scrubber.max = info.last_tick;
+        document.getElementById('replayLastTick').textContent = info.last_tick;
+        document.getElementById('replayTruncated').hidden = !info.truncated;
+        seek(info.first_tick);
+    });
+
+// seek shows the frame at a tick, keeping at most one request in flight
+function seek(tick) {
+    if (!info) {
+        return;
+    }
+    tick = Math.max(info.first_tick, Math.min(info.last_tick, tick));
+    currentTick = tick;
+    scrubber.value = tick;
+    document.getElementById('replayTick').textContent = tick;
+    if (tick === info.last_tick) {
+        setPlaying(false);
+    }
+    if (loading) {
+        pendingTick = tick;
+        return;
+    }
+    loading = true;
+    fetch('/api/replay/frame?tick=' + tick)
+        .then(response => response.json())
+        .then(drawFrame)
+        .finally(() => {
+            loading = false;
+            if (pendingTick !== null) {
+                const next = pendingTick;
+                pendingTick = null;
+                seek(next);
+            }
+        });
+}
+
+function setPlaying(value) {
+    playing = value;
+    playButton.textContent = playing ? '⏸' : '▶';
+}
+
+function drawFrame(frame) {
+    const header = info.header;
+    const cellWidth = canvas.width / header.grid_width;
+    const cellHeight = canvas.height / header.grid_height;
+
+    // Biomes
+    frame.biomes.forEach((row, y) => {
+        for (let x = 0; x < row.length; x++) {
+            const biome = header.biomes[row.charCodeAt(x) - 97];
+            context.fillStyle = biome ? biome.color : 'black';
+            context.fillRect(x * cellWidth, y * cellHeight, cellWidth + 1, cellHeight + 1);
+        }
+    });
+    context.fillStyle = 'rgba(0, 0, 0, 0.45)';
+    context.fillRect(0, 0, canvas.width, canvas.height);
+
+    // Creatures
+    const radius = Math.max(2, Math.min(cellWidth, cellHeight) / 3);
+    frame.entities.forEach(entity => {
+        context.fillStyle = speciesColors[entity.k || entity.s] || 'white';
+        context.beginPath();
+        context.arc(entity.x / header.width * canvas.width, entity.y / header.height * canvas.height, radius, 0, 2 * Math.PI);
+        context.fill();
+    });
+
+    document.getElementById('creatureCount').textContent = frame.entities.length;
+    document.getElementById('plantCount').textContent = frame.plants;
+    const populations = document.getElementById('populationList');
+    populations.innerHTML = '';
+    Object.keys(frame.populations).sort().forEach(species => {
+        const item = document.createElement('li');
+        item.textContent = species + ': ' + frame.populations[species];
+        populations.appendChild(item);
+    });
+    const events = document.getElementById('eventList');
+    events.innerHTML = '';
+    frame.recent_events.forEach(event => {
+        const item = document.createElement('li');
+        item.textContent = event.description;
+        events.appendChild(item);
+    });
+}
+
+function togglePlaying() {
+    if (!playing && info && currentTick === info.last_tick) {
+        seek(info.first_tick);
+    }
+    setPlaying(!playing);
+}
+
+setInterval(() => {
+    if (playing) {
+        seek(currentTick + speed);
+    }
+}, stepMS);
+
+playButton.addEventListener('click', togglePlaying);
+document.getElementById('startButton').addEventListener('click', () => seek(info.first_tick));
+document.getElementById('endButton').addEventListener('click', () => seek(info.last_tick));
+document.getElementById('backButton').addEventListener('click', () => seek(currentTick - 1));
+document.getElementById('forwardButton').addEventListener('click', () => seek(currentTick + 1));
+document.getElementById('replaySpeed').addEventListener('change', event => {
+    speed = parseInt(event.target.value, 10);
+});
+scrubber.addEventListener('input', () => seek(parseInt(scrubber.value, 10)));
+
+document.addEventListener('keydown', event => {
+    if (!info || event.target.tagName === 'SELECT') {
+        return;
+    }
+    const jump = event.shiftKey ? 100 : 1;
+    switch (event.key) {
+    case ' ':
+        togglePlaying();
+        break;
+    case 'ArrowRight':
+        seek(currentTick + jump);
+        break;
+    case 'ArrowLeft':
+        seek(currentTick - jump);
+        break;
+    case 'Home':
+        seek(info.first_tick);
+        break;
+    case 'End':
+        seek(info.last_tick);
+        break;
+    default:
+        return;
+    }
+    event.preventDefault();
+});
@@ -0,0 +1,484 @@
+// 3D terrain view: the topology's elevation as a lit mesh, or wrapped round a globe, with
+// creatures and plants as billboards. It asks for the same data as the isometric view.
+
+const terrainState = {
+    canvas: null,
+    gl: null,
+    websocket: null,
+    data: null,
+    globe: localStorage.getItem('evosim-terrain-mode') === 'globe',
+    relief: 1,
+    camera: { yaw: 0.6, pitch: 0.8, distance: 60, targetX: 0, targetZ: 0 },
+    drag: null,
+    keys: {},
+    terrain: null,   // Buffers of the mesh
+    billboards: null, // Buffers of the creatures and plants
+    programs: null,
+    frameCount: 0,
+    lastFpsUpdate: 0
+};
+
+// Degrees of latitude at the top and bottom edges of the map, as in the day-night cycle
+const mapLatitude = 60;
+const globeRadius = 20;
+
+// Column-major 4x4 matrices, as WebGL expects them
+const mat4 = {
+    perspective(fovy, aspect, near, far) {
+        const f = 1 / Math.tan(fovy / 2);
+        const nf = 1 / (near - far);
+        return [f / aspect, 0, 0, 0, 0, f, 0, 0, 0, 0, (far + near) * nf, -1, 0, 0, 2 * far * near * nf, 0];
+    },
+    lookAt(eye, center, up) {
+        let zx = eye[0] - center[0], zy = eye[1] - center[1], zz = eye[2] - center[2];
+        let length = Math.hypot(zx, zy, zz) || 1;
+        zx /= length; zy /= length; zz /= length;
+        let xx = up[1] * zz - up[2] * zy, xy = up[2] * zx - up[0] * zz, xz = up[0] * zy - up[1] * zx;
+        length = Math.hypot(xx, xy, xz) || 1;
+        xx /= length; xy /= length; xz /= length;
+        const yx = zy * xz - zz * xy, yy = zz * xx - zx * xz, yz = zx * xy - zy * xx;
+        return [
+            xx, yx, zx, 0,
+            xy, yy, zy, 0,
+            xz, yz, zz, 0,
+            -(xx * eye[0] + xy * eye[1] + xz * eye[2]),
+            -(yx * eye[0] + yy * eye[1] + yz * eye[2]),
+            -(zx * eye[0] + zy * eye[1] + zz * eye[2]),
+            1
+        ];
+    },
+    multiply(a, b) {
+        const out = new Array(16);
+        for (let column = 0; column < 4; column++) {
+            for (let row = 0; row < 4; row++) {
+                let sum = 0;
+                for (let k = 0; k < 4; k++) {
+                    sum += a[k * 4 + row] * b[column * 4 + k];
+                }
+                out[column * 4 + row] = sum;
+            }
+        }
+        return out;
+    }
+};
+
+const terrainVertexShader = `
+attribute vec3 position;
+attribute vec3 normal;
+attribute vec3 color;
+uniform mat4 viewProjection;
+uniform vec3 light;
+varying vec3 shade;
+void main() {
+    float lambert = max(dot(normalize(normal), light), 0.0);
+    shade = color * (0.35 + 0.65 * lambert);
+    gl_Position = viewProjection * vec4(position, 1.0);
+}`;
+
+const terrainFragmentShader = `
+precision mediump float;
+varying vec3 shade;
+void main() {
+    gl_FragColor = vec4(shade, 1.0);
+}`;
+
+// Point sprites always face the camera, which makes them billboards
+const billboardVertexShader = `
+attribute vec3 position;
+attribute vec3 color;
+attribute float size;
+uniform mat4 viewProjection;
+uniform float pixelsPerUnit;
+varying vec3 tint;
+void main() {
+    tint = color;
+    gl_Position = viewProjection * vec4(position, 1.0);
+    gl_PointSize = clamp(size * pixelsPerUnit / gl_Position.w, 2.0, 48.0);
+}`;
+
+const billboardFragmentShader = `
+precision mediump float;
+varying vec3 tint;
+void main() {
+    vec2 offset = gl_PointCoord - vec2(0.5);
+    float distance = length(offset);
+    if (distance > 0.5) {
+        discard;
+    }
+    float rim = smoothstep(0.35, 0.5, distance);
+    gl_FragColor = vec4(mix(tint, vec3(0.0), rim * 0.6), 1.0);
+}`;
+
+function compileProgram(gl, vertexSource, fragmentSource) {
+    const program = gl.createProgram();
+    [[gl.VERTEX_SHADER, vertexSource], [gl.FRAGMENT_SHADER, fragmentSource]].forEach(function([type, source]) {
+        const shader = gl.createShader(type);
+        gl.shaderSource(shader, source);
+        gl.compileShader(shader);
+        if (!gl.getShaderParameter(shader, gl.COMPILE_STATUS)) {
+            throw new Error(gl.getShaderInfoLog(shader));
+        }
+        gl.attachShader(program, shader);
+    });
+    gl.linkProgram(program);
+    if (!gl.getProgramParameter(program, gl.LINK_STATUS)) {
+        throw new Error(gl.getProgramInfoLog(program));
+    }
+    return program;
+}
+
+function init() {
+    terrainState.canvas = document.getElementById('terrainCanvas');
+    const gl = terrainState.canvas.getContext('webgl');
+    if (!gl) {
+        document.getElementById('loading').textContent = 'This browser cannot show WebGL; try the 2.5D view instead.';
+        return;
+    }
+    terrainState.gl = gl;
+    terrainState.programs = {
+        terrain: compileProgram(gl, terrainVertexShader, terrainFragmentShader),
+        billboard: compileProgram(gl, billboardVertexShader, billboardFragmentShader)
+    };
+    gl.enable(gl.DEPTH_TEST);
+    gl.clearColor(0.06, 0.08, 0.15, 1);
+
+    setupEventListeners();
+    updateModeLabel();
+    connectWebSocket();
+    setInterval(requestTerrainData, 1000);
+    requestAnimationFrame(renderLoop);
+}
+
+function setupEventListeners() {
+    const canvas = terrainState.canvas;
+    canvas.addEventListener('mousedown', function(event) {
+        terrainState.drag = { x: event.clientX, y: event.clientY };
+        canvas.classList.add('dragging');
+    });
+    window.addEventListener('mouseup', function() {
+        terrainState.drag = null;
+        canvas.classList.remove('dragging');
+    });
+    window.addEventListener('mousemove', function(event) {
+        const drag = terrainState.drag;
+        if (!drag) {
+            return;
+        }
+        const camera = terrainState.camera;
+        camera.yaw -= (event.clientX - drag.x) * 0.005;
+        camera.pitch = Math.max(0.1, Math.min(1.5, camera.pitch + (event.clientY - drag.y) * 0.005));
+        drag.x = event.clientX;
+        drag.y = event.clientY;
+    });
+    canvas.addEventListener('wheel', function(event) {
+        event.preventDefault();
+        const camera = terrainState.camera;
+        camera.distance = Math.max(5, Math.min(400, camera.distance * (event.deltaY > 0 ? 1.1 : 0.9)));
+    }, { passive: false });
+    document.addEventListener('keydown', function(event) {
+        const key = event.key.toLowerCase();
+        terrainState.keys[key] = true;
+        if (key === 'g') {
+            toggleGlobe();
+        } else if (key === 'r') {
+            resetCamera();
+        }
+    });
+    document.addEventListener('keyup', function(event) {
+        terrainState.keys[event.key.toLowerCase()] = false;
+    });
+    document.getElementById('relief').addEventListener('input', function(event) {
+        terrainState.relief = parseFloat(event.target.value);
+        rebuildScene();
+    });
+}
+
+function connectWebSocket() {
+    const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
+    const basePath = window.location.pathname.replace(/[^/]*$/, '');
+    terrainState.websocket = new WebSocket(protocol + '//' + window.location.host + basePath + 'ws');
+    terrainState.websocket.onopen = requestTerrainData;
+    terrainState.websocket.onmessage = function(event) {
+        const message = JSON.parse(event.data);
+        if (message.type !== 'isometric') {
+            return;
+        }
+        const firstLoad = !terrainState.data;
+        terrainState.data = message.data;
+        if (firstLoad) {
+            document.getElementById('loading').style.display = 'none';
+            resetCamera();
+        }
+        rebuildScene();
+        updateUI();
+    };
+    terrainState.websocket.onclose = function() {
+        setTimeout(connectWebSocket, 1000);
+    };
+}
+
+// Ask for the whole map, centred, with a radius that reaches every edge
+function requestTerrainData() {
+    const websocket = terrainState.websocket;
+    if (!websocket || websocket.readyState !== WebSocket.OPEN) {
+        return;
+    }
+    const info = terrainState.data ? terrainState.data.worldInfo : null;
+    const width = info ? info.width : 0;
+    const height = info ? info.height : 0;
+    websocket.send(JSON.stringify({
+        type: 'get_isometric_data',
+        viewportX: Math.floor(width / 2),
+        viewportY: Math.floor(height / 2),
+        zoom: 1,
+        maxTiles: info ? 4 * (Math.ceil(Math.max(width, height) / 2) + 1) : 1000
+    }));
+}
+
+function toggleGlobe() {
+    terrainState.globe = !terrainState.globe;
+    localStorage.setItem('evosim-terrain-mode', terrainState.globe ? 'globe' : 'terrain');
+    updateModeLabel();
+    resetCamera();
+    rebuildScene();
+}
+
+function updateModeLabel() {
+    document.getElementById('mode').textContent = terrainState.globe ? 'Globe' : 'Terrain';
+    document.getElementById('mode-toggle').textContent = terrainState.globe ? '⛰️ Terrain' : '🌐 Globe';
+}
+
+function resetCamera() {
+    const info = terrainState.data ? terrainState.data.worldInfo : { width: 40, height: 25 };
+    const camera = terrainState.camera;
+    camera.yaw = terrainState.globe ? 0 : 0.6;
+    camera.pitch = terrainState.globe ? 0.3 : 0.8;
+    camera.distance = terrainState.globe ? globeRadius * 3 : Math.max(info.width, info.height) * 1.2;
+    camera.targetX = 0;
+    camera.targetZ = 0;
+}
+
+function hexColor(hex) {
+    const value = parseInt((hex || '#888888').replace('#', ''), 16);
+    return [((value >> 16) & 255) / 255, ((value >> 8) & 255) / 255, (value & 255) / 255];
+}
+
+// The grid of elevations and colours, one sample per tile
+function terrainGrid(data) {
+    const width = data.worldInfo.width;
+    const height = data.worldInfo.height;
+    const elevation = new Float32Array(width * height);
+    const colors = new Array(width * height).fill([0.3, 0.3, 0.3]);
+    let lowest = Infinity;
+    let highest = -Infinity;
+    data.tiles.forEach(function(tile) {
+        const i = tile.y * width + tile.x;
+        elevation[i] = tile.elevation;
+        let color = hexColor(tile.color);
+        if (tile.waterLevel > 0.1) {
+            color = [color[0] * 0.5, color[1] * 0.6, Math.min(1, color[2] * 0.6 + 0.4)];
+        }
+        colors[i] = color;
+        lowest = Math.min(lowest, tile.elevation);
+        highest = Math.max(highest, tile.elevation);
+    });
+    return { width: width, height: height, elevation: elevation, colors: colors, lowest: lowest, highest: highest };
+}
+
+// Where a point of the map stands in 3D, lifted by the relief; x and y are in tiles
+function placeOnMap(grid, x, y, elevation) {
+    const relief = terrainState.relief;
+    if (terrainState.globe) {
+        const longitude = x / grid.width * 2 * Math.PI;
+        const latitude = (0.5 - y / grid.height) * 2 * mapLatitude * Math.PI / 180;
+        const radius = globeRadius * (1 + elevation * 0.08 * relief);
+        return [
+            radius * Math.cos(latitude) * Math.sin(longitude),
+            radius * Math.sin(latitude),
+            radius * Math.cos(latitude) * Math.cos(longitude)
+        ];
+    }
+    return [x - grid.width / 2, elevation * 6 * relief, y - grid.height / 2];
+}
+
+function sampleElevation(grid, x, y) {
+    const column = Math.max(0, Math.min(grid.width - 1, Math.floor(x)));
+    const row = Math.max(0, Math.min(grid.height - 1, Math.floor(y)));
+    return grid.elevation[row * grid.width + column];
+}
+
+function uploadBuffer(gl, buffer, values) {
+    const target = buffer || gl.createBuffer();
+    gl.bindBuffer(gl.ARRAY_BUFFER, target);
+    gl.bufferData(gl.ARRAY_BUFFER, new Float32Array(values), gl.DYNAMIC_DRAW);
+    return target;
+}
+
+// Build the mesh and the billboards from the latest data. On the globe the last column joins
+// the first, so the map wraps round.
+function rebuildScene() {
+    const gl = terrainState.gl;
+    const data = terrainState.data;
+    if (!gl || !data || !data.worldInfo.width) {
+        return;
+    }
+    const grid = terrainGrid(data);
+    terrainState.grid = grid;
+    const columns = terrainState.globe ? grid.width : grid.width - 1;
+
+    const corners = [];
+    for (let y = 0; y < grid.height; y++) {
+        for (let x = 0; x < grid.width; x++) {
+            corners.push(placeOnMap(grid, x + 0.5, y + 0.5, grid.elevation[y * grid.width + x]));
+        }
+    }
+    const normals = corners.map(() => [0, 0, 0]);
+    const triangles = [];
+    for (let y = 0; y < grid.height - 1; y++) {
+        for (let x = 0; x < columns; x++) {
+            const right = (x + 1) % grid.width;
+            const a = y * grid.width + x, b = y * grid.width + right;
+            const c = (y + 1) * grid.width + x, d = (y + 1) * grid.width + right;
+            triangles.push([a, c, b], [b, c, d]);
+        }
+    }
+    triangles.forEach(function([a, b, c]) {
+        const p = corners[a], q = corners[b], r = corners[c];
+        const u = [q[0] - p[0], q[1] - p[1], q[2] - p[2]];
+        const v = [r[0] - p[0], r[1] - p[1], r[2] - p[2]];
+        const n = [u[1] * v[2] - u[2] * v[1], u[2] * v[0] - u[0] * v[2], u[0] * v[1] - u[1] * v[0]];
+        [a, b, c].forEach(function(i) {
+            normals[i][0] += n[0];
+            normals[i][1] += n[1];
+            normals[i][2] += n[2];
+        });
+    });
+
+    const positions = [], normalValues = [], colors = [];
+    triangles.forEach(function(triangle) {
+        triangle.forEach(function(i) {
+            positions.push(...corners[i]);
+            normalValues.push(...normals[i]);
+            colors.push(...grid.colors[i]);
+        });
+    });
+    const terrain = terrainState.terrain || {};
+    terrain.position = uploadBuffer(gl, terrain.position, positions);
+    terrain.normal = uploadBuffer(gl, terrain.normal, normalValues);
+    terrain.color = uploadBuffer(gl, terrain.color, colors);
+    terrain.count = positions.length / 3;
+    terrainState.terrain = terrain;
+
+    // Creatures and plants stand on the ground where they are, creatures a little above it
+    const info = data.worldInfo;
+    const scaleX = grid.width / (info.mapWidth || grid.width);
+    const scaleY = grid.height / (info.mapHeight || grid.height);
+    const points = [], pointColors = [], sizes = [];
+    const addBillboard = function(x, y, lift, color, size) {
+        const tileX = x * scaleX, tileY = y * scaleY;
+        points.push(...placeOnMap(grid, tileX, tileY, sampleElevation(grid, tileX, tileY) + lift));
+        pointColors.push(...hexColor(color));
+        sizes.push(size);
+    };
+    data.plants.forEach(plant => addBillboard(plant.x, plant.y, 0.02, plant.color, 0.3 + Math.min(plant.size, 3) * 0.1));
+    data.entities.forEach(entity => addBillboard(entity.x, entity.y, 0.1, entity.color, 0.6 + Math.min(entity.size, 3) * 0.2));
+    const billboards = terrainState.billboards || {};
+    billboards.position = uploadBuffer(gl, billboards.position, points);
+    billboards.color = uploadBuffer(gl, billboards.color, pointColors);
+    billboards.size = uploadBuffer(gl, billboards.size, sizes);
+    billboards.count = sizes.length;
+    terrainState.billboards = billboards;
+}
+
+function bindAttribute(gl, program, name, buffer, size) {
+    const location = gl.getAttribLocation(program, name);
+    gl.bindBuffer(gl.ARRAY_BUFFER, buffer);
+    gl.enableVertexAttribArray(location);
+    gl.vertexAttribPointer(location, size, gl.FLOAT, false, 0, 0);
+}
+
+// Move the camera's target over the terrain with the keys; the globe turns under them instead
+function moveCamera() {
+    const keys = terrainState.keys;
+    const camera = terrainState.camera;
+    const forward = (keys['w'] || keys['arrowup'] ? 1 : 0) - (keys['s'] || keys['arrowdown'] ? 1 : 0);
+    const sideways = (keys['d'] || keys['arrowright'] ? 1 : 0) - (keys['a'] || keys['arrowleft'] ? 1 : 0);
+    if (terrainState.globe) {
+        camera.yaw += sideways * 0.02;
+        camera.pitch = Math.max(-1.4, Math.min(1.4, camera.pitch + forward * 0.02));
+        return;
+    }
+    const step = camera.distance * 0.01;
+    camera.targetX += (-Math.sin(camera.yaw) * forward + Math.cos(camera.yaw) * sideways) * step;
+    camera.targetZ += (-Math.cos(camera.yaw) * forward - Math.sin(camera.yaw) * sideways) * step;
+}
+
+function renderLoop(now) {
+    const gl = terrainState.gl;
+    const canvas = terrainState.canvas;
+    if (canvas.width !== canvas.clientWidth || canvas.height !== canvas.clientHeight) {
+        canvas.width = canvas.clientWidth;
+        canvas.height = canvas.clientHeight;
+    }
+    gl.viewport(0, 0, canvas.width, canvas.height);
+    gl.clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT);
+    moveCamera();
+
+    const camera = terrainState.camera;
+    const target = [camera.targetX, 0, camera.targetZ];
+    const eye = [
+        target[0] + camera.distance * Math.cos(camera.pitch) * Math.sin(camera.yaw),
+        target[1] + camera.distance * Math.sin(camera.pitch),
+        target[2] + camera.distance * Math.cos(camera.pitch) * Math.cos(camera.yaw)
+    ];
+    const projection = mat4.perspective(Math.PI / 4, canvas.width / Math.max(1, canvas.height), 0.5, 2000);
+    const viewProjection = mat4.multiply(projection, mat4.lookAt(eye, target, [0, 1, 0]));
+
+    const terrain = terrainState.terrain;
+    if (terrain && terrain.count > 0) {
+        const program = terrainState.programs.terrain;
+        gl.useProgram(program);
+        gl.uniformMatrix4fv(gl.getUniformLocation(program, 'viewProjection'), false, viewProjection);
+        // The sun lights the globe from the camera's side, and the terrain from high in the south-west
+        const light = terrainState.globe ? eye.map(value => value / Math.hypot(...eye)) : [-0.4, 0.8, 0.45];
+        gl.uniform3fv(gl.getUniformLocation(program, 'light'), light);
+        bindAttribute(gl, program, 'position', terrain.position, 3);
+        bindAttribute(gl, program, 'normal', terrain.normal, 3);
+        bindAttribute(gl, program, 'color', terrain.color, 3);
+        gl.drawArrays(gl.TRIANGLES, 0, terrain.count);
+    }
+
+    const billboards = terrainState.billboards;
+    if (billboards && billboards.count > 0) {
+        const program = terrainState.programs.billboard;
+        gl.useProgram(program);
+        gl.uniformMatrix4fv(gl.getUniformLocation(program, 'viewProjection'), false, viewProjection);
+        gl.uniform1f(gl.getUniformLocation(program, 'pixelsPerUnit'), canvas.height);
+        bindAttribute(gl, program, 'position', billboards.position, 3);
+        bindAttribute(gl, program, 'color', billboards.color, 3);
+        bindAttribute(gl, program, 'size', billboards.size, 1);
+        gl.drawArrays(gl.POINTS, 0, billboards.count);
+    }
+
+    terrainState.frameCount++;
+    if (now - terrainState.lastFpsUpdate >= 1000) {
+        document.getElementById('fps').textContent = terrainState.frameCount;
+        terrainState.frameCount = 0;
+        terrainState.lastFpsUpdate = now;
+    }
+    requestAnimationFrame(renderLoop);
+}
+
+function updateUI() {
+    const data = terrainState.data;
+    const grid = terrainState.grid;
+    document.getElementById('worldTick').textContent = data.worldInfo.tick;
+    document.getElementById('entityCount').textContent = data.entities.length;
+    document.getElementById('plantCount').textContent = data.plants.length;
+    if (grid && grid.lowest <= grid.highest) {
+        document.getElementById('elevationRange').textContent = grid.lowest.toFixed(2) + ' to ' + grid.highest.toFixed(2) +
+            ' (sea ' + data.worldInfo.seaLevel.toFixed(2) + ')';
+    }
+}
+
+window.addEventListener('load', init);
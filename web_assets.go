@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// Locations of the frontend within the embedded file system
+const (
+	webTemplateDir = "web/templates"
+	webStaticDir   = "web/static"
+	webLayout      = "layout.html"
+	staticURLPath  = "/static/"
+)
+
+// Cache policies for what the web interface serves. Fingerprinted URLs change whenever their
+// content does, so browsers may keep them forever; everything else is revalidated.
+const (
+	cacheImmutable  = "public, max-age=31536000, immutable"
+	cacheRevalidate = "no-cache"
+)
+
+//go:embed web
+var webFiles embed.FS
+
+// webAssets holds the pages and static files compiled into the binary
+var webAssets = mustLoadWebAssets(webFiles)
+
+// staticAsset is a CSS or JS file served under /static/
+type staticAsset struct {
+	Name        string // Path below web/static, e.g. js/evosim.js
+	Fingerprint string // Leading hex digits of the content's SHA-256
+	Content     []byte
+}
+
+// FingerprintedName inserts the fingerprint before the file extension, e.g. js/evosim.1a2b3c4d5e.js
+func (a *staticAsset) FingerprintedName() string {
+	ext := path.Ext(a.Name)
+	return strings.TrimSuffix(a.Name, ext) + "." + a.Fingerprint + ext
+}
+
+// URL is the address pages link to, which changes whenever the content does
+func (a *staticAsset) URL() string {
+	return staticURLPath + a.FingerprintedName()
+}
+
+// WebAssets renders the HTML pages and serves the static files they link to
+type WebAssets struct {
+	static        map[string]*staticAsset       // By plain name
+	fingerprinted map[string]*staticAsset       // By fingerprinted name
+	pages         map[string]*template.Template // By page name, e.g. "evosim" for evosim.html
+}
+
+// LoadWebAssets reads every page template and static file from fsys, which is laid out like
+// the embedded web directory. Each page defines the "title", "head" and "content" templates
+// that layout.html wraps, and links static files with {{asset "js/name.js"}}.
+func LoadWebAssets(fsys fs.FS) (*WebAssets, error) {
+	assets := &WebAssets{
+		static:        make(map[string]*staticAsset),
+		fingerprinted: make(map[string]*staticAsset),
+		pages:         make(map[string]*template.Template),
+	}
+
+	err := fs.WalkDir(fsys, webStaticDir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(content)
+		asset := &staticAsset{
+			Name:        strings.TrimPrefix(name, webStaticDir+"/"),
+			Fingerprint: hex.EncodeToString(sum[:])[:10],
+			Content:     content,
+		}
+		assets.static[asset.Name] = asset
+		assets.fingerprinted[asset.FingerprintedName()] = asset
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read static files: %w", err)
+	}
+
+	pageFiles, err := fs.Glob(fsys, webTemplateDir+"/*.html")
+	if err != nil {
+		return nil, err
+	}
+	funcs := template.FuncMap{"asset": assets.assetURL}
+	for _, file := range pageFiles {
+		base := path.Base(file)
+		if base == webLayout {
+			continue
+		}
+		page, err := template.New(base).Funcs(funcs).ParseFS(fsys, path.Join(webTemplateDir, webLayout), file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", base, err)
+		}
+		// Render once so a link to a missing file fails here rather than on the first visit
+		if err := page.ExecuteTemplate(io.Discard, "layout", nil); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", base, err)
+		}
+		assets.pages[strings.TrimSuffix(base, ".html")] = page
+	}
+	return assets, nil
+}
+
+// mustLoadWebAssets loads the embedded frontend, which can only be broken by a bad build
+func mustLoadWebAssets(fsys fs.FS) *WebAssets {
+	assets, err := LoadWebAssets(fsys)
+	if err != nil {
+		panic(err)
+	}
+	return assets
+}
+
+// assetURL is the template function pages use to link a static file
+func (a *WebAssets) assetURL(name string) (string, error) {
+	asset, ok := a.static[name]
+	if !ok {
+		return "", fmt.Errorf("no static file %q", name)
+	}
+	return asset.URL(), nil
+}
+
+// ServePage renders a page. Pages are revalidated on every visit so they always link the
+// current fingerprints.
+func (a *WebAssets) ServePage(w http.ResponseWriter, name string) {
+	page, ok := a.pages[name]
+	if !ok {
+		http.Error(w, "Page not found", http.StatusNotFound)
+		return
+	}
+	var buf bytes.Buffer
+	if err := page.ExecuteTemplate(&buf, "layout", nil); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to render page: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", cacheRevalidate)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// ServeStatic serves a file below /static/. A fingerprinted URL may be cached indefinitely;
+// the plain name stays available for scripts and tools, but must be revalidated. Both carry
+// the fingerprint as an ETag so revalidation costs a 304.
+func (a *WebAssets) ServeStatic(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, staticURLPath)
+	cacheControl := cacheImmutable
+	asset, ok := a.fingerprinted[name]
+	if !ok {
+		cacheControl = cacheRevalidate
+		if asset, ok = a.static[name]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+	}
+
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("ETag", `"`+asset.Fingerprint+`"`)
+	http.ServeContent(w, r, asset.Name, time.Time{}, bytes.NewReader(asset.Content))
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// testWebFiles is a minimal frontend laid out like the embedded web directory
+func testWebFiles(script string) fstest.MapFS {
+	return fstest.MapFS{
+		"web/templates/layout.html": {Data: []byte(`{{define "layout"}}<title>{{template "title" .}}</title>{{template "head" .}}{{template "content" .}}{{end}}`)},
+		"web/templates/home.html": {Data: []byte(`{{define "title"}}Home{{end}}{{define "head"}}{{end}}` +
+			`{{define "content"}}<script src="{{asset "js/app.js"}}"></script>{{end}}`)},
+		"web/static/js/app.js": {Data: []byte(script)},
+	}
+}
+
+func TestWebAssetsFingerprintStaticFiles(t *testing.T) {
+	first, err := LoadWebAssets(testWebFiles("let a = 1;"))
+	if err != nil {
+		t.Fatalf("Failed to load the assets: %v", err)
+	}
+	second, err := LoadWebAssets(testWebFiles("let a = 2;"))
+	if err != nil {
+		t.Fatalf("Failed to load the assets: %v", err)
+	}
+
+	link := regexp.MustCompile(`/static/js/app\.[0-9a-f]{10}\.js`)
+	render := func(assets *WebAssets) string {
+		rec := httptest.NewRecorder()
+		assets.ServePage(rec, "home")
+		if rec.Code != http.StatusOK || rec.Header().Get("Cache-Control") != cacheRevalidate {
+			t.Fatalf("Expected the page rendered and revalidated, got %d %v", rec.Code, rec.Header())
+		}
+		return link.FindString(rec.Body.String())
+	}
+	before, after := render(first), render(second)
+	if before == "" || before == after {
+		t.Errorf("Expected the script linked by a fingerprint that follows its content, got %q and %q", before, after)
+	}
+
+	broken := testWebFiles("")
+	broken["web/templates/home.html"] = &fstest.MapFile{Data: []byte(`{{define "title"}}{{end}}{{define "head"}}{{end}}{{define "content"}}{{asset "js/missing.js"}}{{end}}`)}
+	if _, err := LoadWebAssets(broken); err == nil || !strings.Contains(err.Error(), "missing.js") {
+		t.Errorf("Expected a link to a missing file refused at load, got %v", err)
+	}
+}
+
+func TestWebInterfaceServesEmbeddedFrontend(t *testing.T) {
+	wi := NewWebInterface(newGraphQLTestWorld())
+
+	rec := httptest.NewRecorder()
+	wi.serveHome(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	script := regexp.MustCompile(`<script src="(/static/js/evosim\.[0-9a-f]+\.js)">`).FindStringSubmatch(rec.Body.String())
+	if rec.Code != http.StatusOK || script == nil || !strings.Contains(rec.Body.String(), `id="view-tabs"`) {
+		t.Fatalf("Expected the home page to link its fingerprinted script, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	wi.serveIsometric(rec, httptest.NewRequest(http.MethodGet, "/iso", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `id="gameCanvas"`) {
+		t.Errorf("Expected the isometric page served from the binary, got %d", rec.Code)
+	}
+
+	for _, tc := range []struct {
+		path, cacheControl string
+	}{
+		{script[1], cacheImmutable},
+		{"/static/js/evosim.js", cacheRevalidate},
+	} {
+		rec = httptest.NewRecorder()
+		wi.serveStatic(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if rec.Code != http.StatusOK || rec.Header().Get("Cache-Control") != tc.cacheControl ||
+			!strings.HasPrefix(rec.Header().Get("Content-Type"), "text/javascript") || !strings.Contains(rec.Body.String(), "function ") {
+			t.Errorf("Expected %s served with %q, got %d %v", tc.path, tc.cacheControl, rec.Code, rec.Header())
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, script[1], nil)
+	req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+	rec = httptest.NewRecorder()
+	wi.serveStatic(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("Expected a matching ETag answered with 304, got %d", rec.Code)
+	}
+
+	for path, code := range map[string]int{
+		"/static/js/evosim.0000000000.js": http.StatusNotFound,
+		"/static/templates/layout.html":   http.StatusNotFound,
+	} {
+		rec = httptest.NewRecorder()
+		wi.serveStatic(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != code {
+			t.Errorf("Expected %s answered with %d, got %d", path, code, rec.Code)
+		}
+	}
+}
@@ -1,14 +1,22 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"math"
-	"math/rand"
 	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -33,9 +41,11 @@ type WebInterface struct {
 	world              *World
 	viewManager        *ViewManager
 	isometricManager   *IsometricViewManager
-	clients            map[*websocket.Conn]bool
+	clients            map[*websocket.Conn]*wsClient // Each with its own send queue and write pump
 	clientsMutex       sync.RWMutex
-	connMutexes        map[*websocket.Conn]*sync.Mutex // Per-connection write mutexes
+	wsSettings         WebSocketSettings
+	evictedClients     int64 // Updated atomically
+	skippedFrames      int64 // Updated atomically
 	broadcastChan      chan *ViewData
 	stopChan           chan bool
 	updateInterval     time.Duration
@@ -46,6 +56,29 @@ type WebInterface struct {
 	viewportX int     // Pan X offset
 	viewportY int     // Pan Y offset
 	zoomLevel float64 // Zoom level (1.0 = normal, 2.0 = 2x zoom, etc.)
+	// Optional creature sharing registry (nil when not configured)
+	registry *RegistryClient
+	// Autosave left by a crashed or interrupted run, offered until resumed or dismissed
+	autosaveOffer *AutosaveRecovery
+	// Petri dishes for controlled experiments alongside the main world
+	petriLab *PetriDishLab
+	// Simulation rate control, decoupled from the view update interval
+	governor *SpeedGovernor
+	// When the soundscape was last sent to the clients
+	lastAudio time.Time
+	// Held while ticks run so a step API call cannot interleave with the simulation loop
+	tickMutex sync.Mutex
+	// Alternate timelines forked from the live world
+	branches *WorldBranchSystem
+	// Analysis tabs built by the view details worker, and how often it rebuilds them
+	viewDetails         atomic.Pointer[ViewDetailsSnapshot]
+	viewDetailsInterval time.Duration
+	// Set while the simulation loop runs, for readiness probes
+	running atomic.Bool
+	// Loops started by Start, which Stop waits for
+	loops sync.WaitGroup
+	// Creature the shared camera follows, 0 when it moves freely
+	followedID int
 }
 
 // NewWebInterface creates a new web interface
@@ -54,8 +87,8 @@ func NewWebInterface(world *World) *WebInterface {
 		world:            world,
 		viewManager:      NewViewManager(world),
 		isometricManager: NewIsometricViewManager(world),
-		clients:          make(map[*websocket.Conn]bool),
-		connMutexes:      make(map[*websocket.Conn]*sync.Mutex),
+		clients:          make(map[*websocket.Conn]*wsClient),
+		wsSettings:       DefaultWebSocketSettings(),
 		broadcastChan:    make(chan *ViewData, 100),
 		stopChan:         make(chan bool),
 		updateInterval:   100 * time.Millisecond, // 10 FPS
@@ -64,4119 +97,3068 @@ func NewWebInterface(world *World) *WebInterface {
 		viewportX:        0,
 		viewportY:        0,
 		zoomLevel:        1.0,
+		petriLab:         NewPetriDishLab(),
+		branches:         NewWorldBranchSystem(),
+		governor:         NewSpeedGovernor(),
+
+		viewDetailsInterval: defaultViewDetailsInterval,
 	}
 
 	// Set up player events callback
 	world.PlayerEventsCallback = webInterface.handlePlayerEvent
+	world.Achievements.OwnerOf = webInterface.playerManager.GetSpeciesOwner
+	webInterface.playerManager.OnAudit = webInterface.publishOwnershipAudit
 
 	return webInterface
 }
 
-// RunWebInterface starts the web interface server
-func RunWebInterface(world *World, port int) error {
+// RunWebInterface starts the web interface server. A nil governor runs at the speed multiplier without a CPU cap.
+// A recovery is offered to resume in the page.
+func RunWebInterface(world *World, port int, registry *RegistryClient, governor *SpeedGovernor, recovery *AutosaveRecovery) error {
 	webInterface := NewWebInterface(world)
+	webInterface.registry = registry
+	webInterface.autosaveOffer = recovery
+	if governor != nil {
+		webInterface.governor = governor
+	}
+
+	webInterface.Start()
+
+	// Stop cleanly on Ctrl+C or a container's SIGTERM, failing readiness probes while connections drain
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	address := fmt.Sprintf(":%d", port)
+	server := &http.Server{Addr: address, Handler: webInterface.Routes()}
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- server.ListenAndServe() }()
+	log.Printf("Starting web interface on http://localhost%s", address)
+	log.Printf("Press Ctrl+C to stop the server")
+	if recovery != nil {
+		log.Printf("The page offers to resume the autosave from tick %d", recovery.Tick)
+	}
+
+	select {
+	case err := <-serveErr:
+		webInterface.Stop()
+		return err
+	case <-ctx.Done():
+	}
+	log.Printf("Stopping the web interface")
+	webInterface.Stop()
+	if world.Autosaver != nil {
+		webInterface.tickMutex.Lock()
+		err := world.Autosaver.End(world, AutosaveInterrupted)
+		webInterface.tickMutex.Unlock()
+		if err != nil {
+			log.Printf("Autosave failed: %v", err)
+		}
+	}
+	webInterface.disconnectClients("server shutting down")
+	shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdown)
+}
+
+// Start runs the simulation, broadcast and view details loops until Stop
+func (wi *WebInterface) Start() {
+	wi.running.Store(true)
 
 	// Start the simulation update loop
-	go webInterface.simulationLoop()
+	wi.startLoop(wi.simulationLoop)
 
 	// Start the broadcast loop
-	go webInterface.broadcastLoop()
+	wi.startLoop(wi.broadcastLoop)
+
+	// Rebuild the analysis tabs off the simulation loop
+	wi.startLoop(wi.viewDetailsLoop)
+}
+
+// startLoop runs one of the interface's loops on its own goroutine until Stop
+func (wi *WebInterface) startLoop(loop func()) {
+	wi.loops.Add(1)
+	go func() {
+		defer wi.loops.Done()
+		loop()
+	}()
+}
+
+// Routes maps the pages, API and WebSocket of the interface. Pages address the API relative
+// to themselves, so the routes can also be served below a prefix.
+func (wi *WebInterface) Routes() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", wi.serveHome)
+	mux.HandleFunc("/iso", wi.serveIsometric)
+	mux.HandleFunc("/3d", wi.serveTerrain)
+	mux.HandleFunc("/api/status", wi.locked(wi.handleStatus))
+	mux.HandleFunc("/api/spec", wi.handleAPISpec)
+	mux.HandleFunc("/api/spec/asyncapi", wi.handleAsyncAPISpec)
+	mux.HandleFunc("/api/export/events", wi.locked(wi.handleExportEvents))
+	mux.HandleFunc("/api/export/analysis", wi.locked(wi.handleExportAnalysis))
+	mux.HandleFunc("/api/export/anomalies", wi.locked(wi.handleExportAnomalies))
+	mux.HandleFunc("/api/export/geojson", wi.locked(wi.handleExportGeoJSON))
+	mux.HandleFunc("/api/export/darwincore", wi.locked(wi.handleExportDarwinCore))
+	mux.HandleFunc("/api/export/certificate", wi.locked(wi.handleExportCertificate))
+	mux.HandleFunc("/api/diff", wi.handleDiff)
+	mux.HandleFunc("/api/validate", wi.handleValidate)
+	mux.HandleFunc("/api/creatures/export", wi.locked(wi.handleCreatureExport))
+	mux.HandleFunc("/api/creatures/import", wi.locked(wi.handleCreatureImport))
+	mux.HandleFunc("/api/registry/list", wi.handleRegistryList)
+	mux.HandleFunc("/api/registry/import", wi.handleRegistryImport)
+	mux.HandleFunc("/api/registry/upload", wi.handleRegistryUpload)
+	mux.HandleFunc("/api/petri", wi.handlePetriDishes)
+	mux.HandleFunc("/api/petri/dish", wi.handlePetriDish)
+	mux.HandleFunc("/api/breakpoints", wi.locked(wi.handleBreakpoints))
+	mux.HandleFunc("/api/timeline", wi.locked(wi.handleTimeline))
+	mux.HandleFunc("/api/entity/{id}", wi.handleEntityInspect)
+	mux.HandleFunc("/api/cell/{x}/{y}", wi.handleCellInspect)
+	mux.HandleFunc("/api/follow", wi.handleFollow)
+	mux.HandleFunc("/api/entities", wi.handleEntities)
+	mux.HandleFunc("/api/populations", wi.handlePopulations)
+	mux.HandleFunc("/api/species", wi.handleSpecies)
+	mux.HandleFunc("/api/species/{name}", wi.handleSpeciesProfile)
+	mux.HandleFunc("/api/species/{id}/sprite", wi.handleSpeciesSprite)
+	mux.HandleFunc("/api/control", wi.handleControl)
+	mux.HandleFunc("/api/control/pause", wi.handleControlPause)
+	mux.HandleFunc("/api/control/speed", wi.handleControlSpeed)
+	mux.HandleFunc("/api/control/viewport", wi.handleControlViewport)
+	mux.HandleFunc("/api/control/reset", wi.handleControlReset)
+	mux.HandleFunc("/api/performance", wi.handlePerformance)
+	mux.HandleFunc("/api/save", wi.handleSave)
+	mux.HandleFunc("/api/load", wi.handleLoad)
+	mux.HandleFunc("/api/autosave", wi.handleAutosave)
+	mux.HandleFunc("/api/autosave/resume", wi.handleAutosaveResume)
+	mux.HandleFunc("/api/operator/structures", wi.locked(wi.handleOperatorStructures))
+	mux.HandleFunc("/api/operator/terraform", wi.handleTerraform)
+	mux.HandleFunc("/api/operator/traits", wi.handleTraitEdit)
+	mux.HandleFunc("/api/operator/interventions", wi.handleInterventions)
+	mux.HandleFunc("/api/operator/events", wi.handleOperatorEvents)
+	mux.HandleFunc("/api/game", wi.locked(wi.handleGame))
+	mux.HandleFunc("/api/game/rematch", wi.locked(wi.handleGameRematch))
+	mux.HandleFunc("/api/tutorial", wi.handleTutorial)
+	mux.HandleFunc("/api/gallery", wi.handleGallery)
+	mux.HandleFunc("/api/achievements", wi.locked(wi.handleAchievements))
+	mux.HandleFunc("/api/predictions", wi.locked(wi.handlePredictions))
+	mux.HandleFunc("/api/step", wi.handleStep)
+	mux.HandleFunc("/api/fast-forward", wi.handleFastForward)
+	mux.HandleFunc("/api/population-caps", wi.handlePopulationCaps)
+	mux.HandleFunc("/api/resource-budget", wi.handleResourceBudget)
+	mux.HandleFunc("/api/day-night", wi.handleDayNight)
+	mux.HandleFunc("/api/season", wi.handleSeason)
+	mux.HandleFunc("/api/migration", wi.handleMigration)
+	mux.HandleFunc("/api/audio", wi.handleAudio)
+	mux.HandleFunc("/api/resources", wi.handleResources)
+	mux.HandleFunc("/api/timescales", wi.handleTimescales)
+	mux.HandleFunc("/api/traits", wi.handleTraits)
+	mux.HandleFunc("/api/traits/legend", wi.handleTraitLegend)
+	mux.HandleFunc("/api/legend", wi.handleLegend)
+	mux.HandleFunc("/api/mutations", wi.handleMutations)
+	mux.HandleFunc("/api/selection", wi.handleSelection)
+	mux.HandleFunc("/api/fitness-landscape", wi.handleFitnessLandscape)
+	mux.HandleFunc("/api/ancestry", wi.handleAncestry)
+	mux.HandleFunc("/api/phylogeny", wi.handlePhylogeny)
+	mux.HandleFunc("/api/history", wi.handleMetricHistory)
+	mux.HandleFunc("/api/history/metrics", wi.handleMetricHistoryIndex)
+	mux.HandleFunc("/api/red-queen", wi.handleRedQueen)
+	mux.HandleFunc("/api/niche-overlap", wi.handleNicheOverlap)
+	mux.HandleFunc("/api/biome-transitions", wi.handleBiomeTransitions)
+	mux.HandleFunc("/api/microclimates", wi.handleMicroclimates)
+	mux.HandleFunc("/api/pollution", wi.handlePollution)
+	mux.HandleFunc("/api/light-pollution", wi.handleLightPollution)
+	mux.HandleFunc("/api/noise-pollution", wi.handleNoisePollution)
+	mux.HandleFunc("/api/zoonoses", wi.handleZoonoses)
+	mux.HandleFunc("/api/food-storage", wi.handleFoodStorage)
+	mux.HandleFunc("/api/demographics", wi.handleDemographics)
+	mux.HandleFunc("/api/census", wi.handleCensus)
+	mux.HandleFunc("/api/graphql", wi.handleGraphQL)
+	mux.HandleFunc("/api/branches", wi.handleBranches)
+	mux.HandleFunc("/api/branches/branch", wi.handleBranch)
+	mux.HandleFunc("/api/predictions/predict", wi.locked(wi.handlePredict))
+	mux.HandleFunc("/ws", wi.handleWebSocketUpgrade)
+	mux.HandleFunc("/healthz", wi.handleHealthz)
+	mux.HandleFunc("/readyz", wi.handleReadyz)
+
+	// Serve static files (CSS, JS) with cache headers for fingerprinted URLs
+	mux.HandleFunc("/static/", wi.serveStatic)
+	mux.HandleFunc("/gallery/", wi.serveGalleryImage)
+
+	return mux
+}
+
+// locked runs a handler holding tickMutex, so it reads and changes the world between ticks
+func (wi *WebInterface) locked(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wi.tickMutex.Lock()
+		defer wi.tickMutex.Unlock()
+		handler(w, r)
+	}
+}
+
+// serveHome serves the main HTML page
+func (wi *WebInterface) serveHome(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	webAssets.ServePage(w, "evosim")
+}
+
+// serveIsometric serves the isometric 2.5D view page
+func (wi *WebInterface) serveIsometric(w http.ResponseWriter, r *http.Request) {
+	webAssets.ServePage(w, "isometric")
+}
+
+// serveTerrain serves the 3D terrain and globe view page
+func (wi *WebInterface) serveTerrain(w http.ResponseWriter, r *http.Request) {
+	webAssets.ServePage(w, "terrain")
+}
+
+// handleStatus provides a simple status endpoint
+func (wi *WebInterface) handleStatus(w http.ResponseWriter, r *http.Request) {
+	status := map[string]interface{}{
+		"tick":        wi.world.Tick,
+		"entities":    len(wi.world.AllEntities),
+		"plants":      len(wi.world.AllPlants),
+		"populations": len(wi.world.Populations),
+		"status":      "running",
+		"seed":        wi.world.Seed,
+
+		"ticks_per_second": wi.governor.TicksPerSecond(),
+		"unlimited_speed":  wi.governor.Unlimited(),
+		"max_cpu":          wi.governor.MaxCPU(),
+		"websocket":        wi.WebSocketStats(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// handleAPISpec serves the OpenAPI description of the HTTP API
+func (wi *WebInterface) handleAPISpec(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(OpenAPISpec())
+}
+
+// handleAsyncAPISpec serves the AsyncAPI description of the WebSocket protocol at /ws
+func (wi *WebInterface) handleAsyncAPISpec(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(AsyncAPISpec())
+}
+
+// handleExportEvents exports all events from the central event bus
+func (wi *WebInterface) handleExportEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Get query parameters for filtering
+	eventType := r.URL.Query().Get("type")
+	category := r.URL.Query().Get("category")
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+
+	var events []CentralEvent
+
+	if wi.world.CentralEventBus != nil {
+		if eventType != "" {
+			events = wi.world.CentralEventBus.GetEventsByType(eventType)
+		} else if category != "" {
+			events = wi.world.CentralEventBus.GetEventsByCategory(category)
+		} else {
+			events = wi.world.CentralEventBus.GetAllEvents()
+		}
+	}
+
+	exportData := map[string]interface{}{
+		"events":      events,
+		"total_count": len(events),
+		"export_time": time.Now(),
+		"filters": map[string]string{
+			"type":     eventType,
+			"category": category,
+			"format":   format,
+		},
+		"certificate": wi.world.HashChain.Certificate(wi.world),
+	}
+
+	if format == "csv" {
+		wi.exportEventsAsCSV(w, events)
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", "attachment; filename=events_export.json")
+		_ = json.NewEncoder(w).Encode(exportData)
+	}
+}
+
+// handleExportAnalysis exports statistical analysis data
+func (wi *WebInterface) handleExportAnalysis(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+
+	analysisData := wi.analysisExport()
+
+	if format == "csv" {
+		wi.exportAnalysisAsCSV(w, analysisData)
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", "attachment; filename=analysis_export.json")
+		_ = json.NewEncoder(w).Encode(analysisData)
+	}
+}
+
+// analysisExport gathers the statistics, events and run certificate of the analysis export
+func (wi *WebInterface) analysisExport() map[string]interface{} {
+	var analysisData map[string]interface{}
+
+	if wi.world.StatisticalReporter != nil {
+		analysisData = map[string]interface{}{
+			"summary_statistics": wi.world.StatisticalReporter.GetSummaryStatistics(),
+			"recent_events":      wi.world.StatisticalReporter.Events,
+			"snapshots":          wi.world.StatisticalReporter.Snapshots,
+			"export_time":        time.Now(),
+		}
+	} else {
+		analysisData = map[string]interface{}{
+			"error":       "Statistical reporter not available",
+			"export_time": time.Now(),
+		}
+	}
+
+	analysisData["certificate"] = wi.world.HashChain.Certificate(wi.world)
+	return analysisData
+}
+
+// handleExportAnomalies exports anomaly detection data
+func (wi *WebInterface) handleExportAnomalies(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+
+	var anomaliesData map[string]interface{}
+
+	if wi.world.StatisticalReporter != nil {
+		anomaliesData = map[string]interface{}{
+			"anomalies":     wi.world.StatisticalReporter.Anomalies,
+			"total_count":   len(wi.world.StatisticalReporter.Anomalies),
+			"anomaly_types": wi.world.StatisticalReporter.detectedAnomalies,
+			"export_time":   time.Now(),
+		}
+	} else {
+		anomaliesData = map[string]interface{}{
+			"error":       "Statistical reporter not available",
+			"export_time": time.Now(),
+		}
+	}
+
+	anomaliesData["certificate"] = wi.world.HashChain.Certificate(wi.world)
+
+	if format == "csv" {
+		wi.exportAnomaliesAsCSV(w, anomaliesData)
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", "attachment; filename=anomalies_export.json")
+		_ = json.NewEncoder(w).Encode(anomaliesData)
+	}
+}
+
+// handleExportGeoJSON exports world geography and species ranges as GeoJSON
+func (wi *WebInterface) handleExportGeoJSON(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	layer := r.URL.Query().Get("layer")
+	if layer == "" {
+		layer = GeoJSONLayerAll
+	}
+	if !IsValidGeoJSONLayer(layer) {
+		http.Error(w, "Unknown layer: "+layer, http.StatusBadRequest)
+		return
+	}
+
+	collection := ExportGeoJSON(wi.world, layer)
+
+	w.Header().Set("Content-Type", "application/geo+json")
+	w.Header().Set("Content-Disposition", "attachment; filename="+GeoJSONFilename(layer))
+	_ = json.NewEncoder(w).Encode(collection)
+}
+
+// handleExportCertificate exports the run certificate: the seed, config and state hash chain
+// that let published results be checked against an unmodified run
+func (wi *WebInterface) handleExportCertificate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", "attachment; filename=run_certificate.json")
+	_ = json.NewEncoder(w).Encode(wi.world.HashChain.Certificate(wi.world))
+}
+
+// handleExportDarwinCore exports occurrence records in Darwin Core CSV format
+func (wi *WebInterface) handleExportDarwinCore(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	includePlants := r.URL.Query().Get("plants") == "true"
+	records := BuildDarwinCoreRecords(wi.world, includePlants)
+
+	if r.URL.Query().Get("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", "attachment; filename=occurrences.json")
+		_ = json.NewEncoder(w).Encode(records)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=occurrences.csv")
+	_ = WriteDarwinCoreCSV(w, records)
+}
+
+// handleDiff compares an uploaded save file against the live simulation state
+func (wi *WebInterface) handleDiff(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var saved SimulationState
+	if err := json.NewDecoder(r.Body).Decode(&saved); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid save file: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	live, err := wi.liveState()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	diff := DiffStates(&saved, live)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"diff":            diff,
+		"has_differences": diff.HasDifferences(),
+		"summary":         diff.Summary(),
+	})
+}
+
+// handleValidate checks the live state (GET) or an uploaded save (POST) for corruption
+func (wi *WebInterface) handleValidate(w http.ResponseWriter, r *http.Request) {
+	var state *SimulationState
+	truncated := false
+
+	switch r.Method {
+	case HTTPMethodGET:
+		live, err := wi.liveState()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		state = live
+
+	case http.MethodPost:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to read save file: %v", err), http.StatusBadRequest)
+			return
+		}
+		state, truncated, err = ParseStateWithRecovery(data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
 
-	// Set up HTTP routes
-	http.HandleFunc("/", webInterface.serveHome)
-	http.HandleFunc("/iso", webInterface.serveIsometric)
-	http.HandleFunc("/api/status", webInterface.handleStatus)
-	http.HandleFunc("/api/export/events", webInterface.handleExportEvents)
-	http.HandleFunc("/api/export/analysis", webInterface.handleExportAnalysis)
-	http.HandleFunc("/api/export/anomalies", webInterface.handleExportAnomalies)
-	http.HandleFunc("/ws", webInterface.handleWebSocketUpgrade)
+	// Only uploaded saves are repaired; the live world is never modified here
+	repair := r.Method == http.MethodPost && r.URL.Query().Get("repair") == "true"
+	report := ValidateState(state, repair)
+	report.Truncated = truncated
+
+	response := map[string]interface{}{
+		"valid":  report.IsValid(),
+		"report": report,
+	}
+	if repair {
+		response["repaired_state"] = state
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// handleCreatureExport downloads a single entity or breeding pair as a creature file
+func (wi *WebInterface) handleCreatureExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ids := make([]int, 0, 2)
+	for _, param := range []string{"id", "mate"} {
+		value := r.URL.Query().Get(param)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid %s: %v", param, err), http.StatusBadRequest)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	file, err := ExportCreatureFile(wi.world, ids, r.URL.Query().Get("name"), r.URL.Query().Get("description"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=creature_%d.json", ids[0]))
+	_ = json.NewEncoder(w).Encode(file)
+}
+
+// handleCreatureImport adds the creatures in an uploaded creature file to the world
+func (wi *WebInterface) handleCreatureImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var file CreatureFile
+	if err := json.NewDecoder(r.Body).Decode(&file); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid creature file: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	pos := Position{X: wi.world.Config.Width / 2, Y: wi.world.Config.Height / 2}
+	if x, err := strconv.ParseFloat(r.URL.Query().Get("x"), 64); err == nil {
+		pos.X = x
+	}
+	if y, err := strconv.ParseFloat(r.URL.Query().Get("y"), 64); err == nil {
+		pos.Y = y
+	}
+
+	entities, err := wi.world.SpawnCreatures(&file, pos)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ids := make([]int, 0, len(entities))
+	for _, entity := range entities {
+		ids = append(ids, entity.ID)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"imported_ids": ids,
+		"species":      file.Creatures[0].Species,
+	})
+}
+
+// RegistryUploadRequest describes a creature or scenario to publish to the registry
+type RegistryUploadRequest struct {
+	Kind        string `json:"kind"` // "creature" or "scenario"
+	EntityID    int    `json:"entity_id"`
+	MateID      int    `json:"mate_id"` // Optional second entity for a breeding pair
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Author      string `json:"author"`
+}
+
+// requireRegistry writes an error when no registry is configured
+func (wi *WebInterface) requireRegistry(w http.ResponseWriter) bool {
+	if wi.registry == nil {
+		http.Error(w, "Creature registry is not configured (start with --registry-url)", http.StatusServiceUnavailable)
+		return false
+	}
+	return true
+}
+
+// handleRegistryList lists files available on the remote registry
+func (wi *WebInterface) handleRegistryList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !wi.requireRegistry(w) {
+		return
+	}
+
+	entries, err := wi.registry.List(r.URL.Query().Get("kind"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(entries)
+}
+
+// handleRegistryImport downloads, verifies, and applies a registry file
+func (wi *WebInterface) handleRegistryImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !wi.requireRegistry(w) {
+		return
+	}
+
+	// Download before taking the lock so a slow registry doesn't hold up the simulation
+	entry, content, err := wi.registry.Fetch(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	pos := Position{X: wi.world.Config.Width / 2, Y: wi.world.Config.Height / 2}
+	if x, err := strconv.ParseFloat(r.URL.Query().Get("x"), 64); err == nil {
+		pos.X = x
+	}
+	if y, err := strconv.ParseFloat(r.URL.Query().Get("y"), 64); err == nil {
+		pos.Y = y
+	}
+	entities, err := ApplyRegistryFile(wi.world, entry, content, pos)
+	wi.tickMutex.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ids := make([]int, 0, len(entities))
+	for _, entity := range entities {
+		ids = append(ids, entity.ID)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"entry":        entry,
+		"imported_ids": ids,
+	})
+}
+
+// handleRegistryUpload publishes a creature file or the current world as a scenario
+func (wi *WebInterface) handleRegistryUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !wi.requireRegistry(w) {
+		return
+	}
+
+	var req RegistryUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid upload request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var payload interface{}
+	switch req.Kind {
+	case RegistryKindCreature:
+		ids := []int{req.EntityID}
+		if req.MateID != 0 {
+			ids = append(ids, req.MateID)
+		}
+		wi.tickMutex.Lock()
+		file, err := ExportCreatureFile(wi.world, ids, req.Name, req.Description)
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		payload = file
+	case RegistryKindScenario:
+		state, err := wi.liveState()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		payload = state
+	default:
+		http.Error(w, fmt.Sprintf("Unknown kind %q", req.Kind), http.StatusBadRequest)
+		return
+	}
+
+	content, err := json.Marshal(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	entry, err := wi.registry.Upload(req.Kind, req.Name, req.Description, req.Author, content)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(entry)
+}
+
+// maxPetriStepTicks bounds how many ticks a single petri dish request may run
+const maxPetriStepTicks = 5000
+
+// PetriDishCreateRequest describes a new petri dish and the populations to seed it with
+type PetriDishCreateRequest struct {
+	PetriDishConfig
+	Populations []struct {
+		Species string             `json:"species"`
+		Traits  map[string]float64 `json:"traits"`
+	} `json:"populations"`
+}
+
+// PetriDishUpdateRequest adjusts a petri dish's variables and optionally advances it
+type PetriDishUpdateRequest struct {
+	Ticks       int      `json:"ticks"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	FoodDensity *float64 `json:"food_density,omitempty"`
+}
+
+// handleTimeline serves the event timeline. Query parameters: from and to (ticks, defaulting
+// to the whole run), buckets (default 100, at most 1000) and events (how many individual
+// events in the range to include, default none).
+func (wi *WebInterface) handleTimeline(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	params := map[string]int{"from": 0, "to": wi.world.Tick, "buckets": 100, "events": 0}
+	for name := range params {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			http.Error(w, fmt.Sprintf("Invalid %s: %q", name, value), http.StatusBadRequest)
+			return
+		}
+		params[name] = parsed
+	}
+	if params["to"] < params["from"] {
+		http.Error(w, "to must not be before from", http.StatusBadRequest)
+		return
+	}
+	// Nothing has happened after the current tick
+	params["to"] = min(params["to"], wi.world.Tick)
+	params["from"] = min(params["from"], params["to"])
+
+	var events []CentralEvent
+	if wi.world.CentralEventBus != nil {
+		events = wi.world.CentralEventBus.GetEventsInRange(params["from"], params["to"])
+	}
+	timeline := BuildEventTimeline(events, params["from"], params["to"], min(params["buckets"], 1000), params["events"])
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(timeline)
+}
+
+// handleEntityInspect gathers everything the inspector shows of one entity: its details,
+// activity, DNA, neural network, memory and relationships
+func (wi *WebInterface) handleEntityInspect(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid id", http.StatusBadRequest)
+		return
+	}
+	wi.tickMutex.Lock()
+	inspection := wi.viewManager.InspectEntity(id)
+	wi.tickMutex.Unlock()
+	if inspection == nil {
+		http.Error(w, fmt.Sprintf("Entity %d not found", id), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(inspection)
+}
+
+// handleCellInspect describes a grid cell and the entities and plants in it
+func (wi *WebInterface) handleCellInspect(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	x, errX := strconv.Atoi(r.PathValue("x"))
+	y, errY := strconv.Atoi(r.PathValue("y"))
+	if errX != nil || errY != nil {
+		http.Error(w, "Invalid cell", http.StatusBadRequest)
+		return
+	}
+	wi.tickMutex.Lock()
+	inspection := wi.viewManager.InspectCell(x, y)
+	wi.tickMutex.Unlock()
+	if inspection == nil {
+		http.Error(w, fmt.Sprintf("Cell (%d, %d) is off the grid", x, y), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(inspection)
+}
+
+// FollowRequest locks the shared camera onto a creature
+type FollowRequest struct {
+	EntityID int `json:"entity_id"`
+}
+
+// FollowState is the creature the shared camera follows and what it has done lately
+type FollowState struct {
+	EntityID int          `json:"entity_id"` // 0 when the camera moves freely
+	Track    *FollowTrack `json:"track,omitempty"`
+}
+
+// handleFollow shows what the camera follows (GET), locks it onto a creature (POST) or lets
+// it move freely again (DELETE)
+func (wi *WebInterface) handleFollow(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+	case http.MethodPost:
+		var request FollowRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid follow request: %v", err), http.StatusBadRequest)
+			return
+		}
+		wi.tickMutex.Lock()
+		err := wi.followEntity(request.EntityID)
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.Printf("API followed entity %d", request.EntityID)
+	case http.MethodDelete:
+		wi.tickMutex.Lock()
+		wi.unfollowEntity()
+		wi.tickMutex.Unlock()
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	state := wi.followCamera()
+	if r.Method != HTTPMethodGET {
+		wi.sendFrame()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(state)
+}
+
+// EntityListResponse is one page of entities
+type EntityListResponse struct {
+	Total    int                 `json:"total"` // Entities matching the filters
+	Offset   int                 `json:"offset"`
+	Entities []*EntityDetailData `json:"entities"`
+}
+
+// handleEntities lists entities in ID order, filtered by ?species= and ?alive=true and paged
+// with ?offset= and ?limit= (default 100, at most 1000)
+func (wi *WebInterface) handleEntities(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	offset, limit := 0, 100
+	if value := query.Get("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			http.Error(w, "Invalid offset", http.StatusBadRequest)
+			return
+		}
+		offset = parsed
+	}
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 1000 {
+			http.Error(w, "Invalid limit: expected 1-1000", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	wi.tickMutex.Lock()
+	total, entities := wi.viewManager.ListEntities(query.Get("species"), query.Get("alive") == "true", offset, limit)
+	wi.tickMutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(EntityListResponse{Total: total, Offset: offset, Entities: entities})
+}
+
+// PopulationCreateRequest is the body of a request to add a species. Traits adjust the
+// speed, aggression, cooperation and intelligence of the starting creatures by up to ±0.3,
+// as players may.
+type PopulationCreateRequest struct {
+	Name   string             `json:"name"`
+	Traits map[string]float64 `json:"traits,omitempty"`
+}
+
+// PopulationCreateResponse describes a newly added species
+type PopulationCreateResponse struct {
+	Name   string             `json:"name"` // Species name given by the naming system
+	Traits map[string]float64 `json:"traits"` // Base traits of the starting creatures
+}
+
+// handlePopulations lists the populations (GET) or adds a species (POST)
+func (wi *WebInterface) handlePopulations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+		wi.tickMutex.Lock()
+		populations := wi.viewManager.getPopulationsData()
+		wi.tickMutex.Unlock()
+		sort.Slice(populations, func(i, j int) bool { return populations[i].Name < populations[j].Name })
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(populations)
+
+	case http.MethodPost:
+		var request PopulationCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid population request: %v", err), http.StatusBadRequest)
+			return
+		}
+		wi.tickMutex.Lock()
+		name, traits, err := wi.createSpecies(request.Name, request.Traits)
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("API created species '%s'", name)
+		wi.sendFrame()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(PopulationCreateResponse{Name: name, Traits: traits})
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleSpecies profiles every creature and plant species, living ones first
+func (wi *WebInterface) handleSpecies(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	profiles := wi.world.SpeciesProfiles()
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(profiles)
+}
+
+// handleSpeciesProfile profiles one creature or plant species by name
+func (wi *WebInterface) handleSpeciesProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	profile, err := wi.world.SpeciesProfile(r.PathValue("name"))
+	wi.tickMutex.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(profile)
+}
+
+// handleSpeciesSprite draws a species from its members' traits as an SVG sprite. The id is a
+// plant species number or a creature species name.
+func (wi *WebInterface) handleSpeciesSprite(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	sprite, err := wi.world.SpeciesSprite(r.PathValue("id"))
+	wi.tickMutex.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "image/svg+xml")
+	// Traits drift slowly, and the grid asks for the sprites again every frame
+	w.Header().Set("Cache-Control", "max-age=30")
+	_, _ = w.Write([]byte(sprite))
+}
+
+// ControlState is how the simulation is running and being viewed
+type ControlState struct {
+	Tick           int     `json:"tick"`
+	Paused         bool    `json:"paused"`
+	Speed          float64 `json:"speed"` // Speed multiplier
+	UnlimitedSpeed bool    `json:"unlimited_speed"`
+	MaxCPU         float64 `json:"max_cpu"` // Percentage of CPU time the simulation may use
+	TicksPerSecond float64 `json:"ticks_per_second"`
+	ViewportX      int     `json:"viewport_x"`
+	ViewportY      int     `json:"viewport_y"`
+	Zoom           float64 `json:"zoom"`
+}
+
+// controlState reports the run and view state; the caller holds the tick lock
+func (wi *WebInterface) controlState() ControlState {
+	return ControlState{
+		Tick:           wi.world.Tick,
+		Paused:         wi.world.IsPaused(),
+		Speed:          wi.world.GetSpeedMultiplier(),
+		UnlimitedSpeed: wi.governor.Unlimited(),
+		MaxCPU:         wi.governor.MaxCPU() * 100,
+		TicksPerSecond: wi.governor.TicksPerSecond(),
+		ViewportX:      wi.viewportX,
+		ViewportY:      wi.viewportY,
+		Zoom:           wi.zoomLevel,
+	}
+}
+
+// ControlPauseRequest is the body of a pause request; leaving out paused toggles it
+type ControlPauseRequest struct {
+	Paused *bool `json:"paused,omitempty"`
+}
+
+// ControlSpeedRequest is the body of a speed request; fields left out are unchanged
+type ControlSpeedRequest struct {
+	Speed     *float64 `json:"speed,omitempty"`     // Speed multiplier, 0.1-16
+	Unlimited *bool    `json:"unlimited,omitempty"` // Run as fast as the CPU cap allows
+	MaxCPU    *float64 `json:"max_cpu,omitempty"`   // Percentage of CPU time, 5-100
+}
+
+// ControlViewportRequest is the body of a viewport request; fields left out are unchanged,
+// and reset returns to the default view before the others apply
+type ControlViewportRequest struct {
+	X     *int     `json:"x,omitempty"` // Grid column at the left edge
+	Y     *int     `json:"y,omitempty"` // Grid row at the top edge
+	Zoom  *float64 `json:"zoom,omitempty"`
+	Reset bool     `json:"reset,omitempty"`
+}
+
+// decodeControlRequest decodes an optional JSON body, leaving request untouched when the body
+// is empty
+func decodeControlRequest(w http.ResponseWriter, r *http.Request, request interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil && err != io.EOF {
+		http.Error(w, fmt.Sprintf("Invalid control request: %v", err), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// handleControl reports how the simulation is running and being viewed (GET)
+func (wi *WebInterface) handleControl(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	state := wi.controlState()
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(state)
+}
+
+// handleControlPause pauses, resumes or toggles the simulation (POST)
+func (wi *WebInterface) handleControlPause(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var request ControlPauseRequest
+	if !decodeControlRequest(w, r, &request) {
+		return
+	}
+
+	wi.tickMutex.Lock()
+	if request.Paused != nil {
+		wi.world.SetPaused(*request.Paused)
+	} else {
+		wi.world.TogglePause()
+	}
+	state := wi.controlState()
+	wi.tickMutex.Unlock()
+
+	log.Printf("API set paused to %v", state.Paused)
+	wi.sendFrame()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(state)
+}
+
+// handleControlSpeed sets the speed multiplier, unlimited mode and CPU cap (POST)
+func (wi *WebInterface) handleControlSpeed(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var request ControlSpeedRequest
+	if !decodeControlRequest(w, r, &request) {
+		return
+	}
+	if request.Speed != nil && (*request.Speed < 0.1 || *request.Speed > 16) {
+		http.Error(w, "Invalid speed: expected 0.1-16", http.StatusBadRequest)
+		return
+	}
+	if request.MaxCPU != nil && (*request.MaxCPU < 5 || *request.MaxCPU > 100) {
+		http.Error(w, "Invalid max_cpu: expected 5-100", http.StatusBadRequest)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	if request.Speed != nil {
+		wi.world.SetSpeedMultiplier(*request.Speed)
+	}
+	if request.Unlimited != nil {
+		wi.governor.SetUnlimited(*request.Unlimited)
+	}
+	if request.MaxCPU != nil {
+		wi.governor.SetMaxCPU(*request.MaxCPU / 100)
+	}
+	state := wi.controlState()
+	wi.tickMutex.Unlock()
+
+	log.Printf("API set speed to %fx (unlimited %v, CPU cap %.0f%%)", state.Speed, state.UnlimitedSpeed, state.MaxCPU)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(state)
+}
+
+// handleControlViewport pans and zooms the shared view (POST)
+func (wi *WebInterface) handleControlViewport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var request ControlViewportRequest
+	if !decodeControlRequest(w, r, &request) {
+		return
+	}
+
+	wi.tickMutex.Lock()
+	if request.Reset {
+		wi.resetViewport()
+	}
+	if request.Zoom != nil {
+		wi.setZoomLevel(*request.Zoom)
+	}
+	if request.X != nil {
+		wi.viewportX = *request.X
+	}
+	if request.Y != nil {
+		wi.viewportY = *request.Y
+	}
+	wi.clampViewport()
+	state := wi.controlState()
+	wi.tickMutex.Unlock()
+
+	wi.sendFrame()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(state)
+}
+
+// handleControlReset clears the world and restarts it with the default populations (POST)
+func (wi *WebInterface) handleControlReset(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	wi.world.Reset()
+	wi.reinitializeWorld()
+	state := wi.controlState()
+	wi.tickMutex.Unlock()
+
+	log.Printf("API reset the world")
+	wi.sendFrame()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(state)
+}
+
+// PerformanceReport is how fast the simulation runs and how often its scheduled
+// subsystems run
+type PerformanceReport struct {
+	Tick           int                     `json:"tick"`
+	TicksPerSecond float64                 `json:"ticks_per_second"`
+	UnlimitedSpeed bool                    `json:"unlimited_speed"`
+	MaxCPU         float64                 `json:"max_cpu"` // Percentage of CPU time the simulation may use
+	Systems        []ScheduledSystemStatus `json:"systems"`
+}
+
+// ScheduleUpdateRequest changes how often a scheduled subsystem runs
+type ScheduleUpdateRequest struct {
+	System   string `json:"system"`
+	Interval *int   `json:"interval,omitempty"` // Ticks between runs, at least 1
+	Trigger  bool   `json:"trigger,omitempty"`  // Run on the next tick whatever the interval
+}
+
+// performanceReport reports the simulation rate and schedule; the caller holds the tick lock
+func (wi *WebInterface) performanceReport() PerformanceReport {
+	return PerformanceReport{
+		Tick:           wi.world.Tick,
+		TicksPerSecond: wi.governor.TicksPerSecond(),
+		UnlimitedSpeed: wi.governor.Unlimited(),
+		MaxCPU:         wi.governor.MaxCPU() * 100,
+		Systems:        wi.world.scheduler().Status(),
+	}
+}
+
+// handlePerformance reports the simulation rate and scheduled subsystems (GET) or changes a
+// subsystem's interval or triggers it (POST)
+func (wi *WebInterface) handlePerformance(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+		wi.tickMutex.Lock()
+		report := wi.performanceReport()
+		wi.tickMutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(report)
+	case http.MethodPost:
+		var request ScheduleUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid schedule request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		wi.tickMutex.Lock()
+		scheduler := wi.world.scheduler()
+		var err error
+		if request.Interval != nil {
+			err = scheduler.SetInterval(request.System, *request.Interval)
+		}
+		if err == nil && request.Trigger {
+			err = scheduler.Trigger(request.System)
+		}
+		report := wi.performanceReport()
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		log.Printf("API scheduled %s every %d ticks (triggered %v)", request.System, scheduler.Interval(request.System), request.Trigger)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(report)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// SaveResponse names the file a save was written to on the server
+type SaveResponse struct {
+	Filename string `json:"filename"`
+	Tick     int    `json:"tick"`
+}
+
+// handleSave downloads the full simulation state (GET) or saves it to a file on the server
+// as the WebSocket save_state action does (POST)
+func (wi *WebInterface) handleSave(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+		wi.tickMutex.Lock()
+		state, err := NewStateManager(wi.world).CurrentState()
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to snapshot the world: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=evosim_save_%d.json", state.Tick))
+		_ = json.NewEncoder(w).Encode(state)
+
+	case http.MethodPost:
+		filename := fmt.Sprintf("web_save_%d.json", time.Now().Unix())
+		wi.tickMutex.Lock()
+		err := NewStateManager(wi.world).SaveToFile(filename)
+		tick := wi.world.Tick
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to save: %v", err), http.StatusInternalServerError)
+			return
+		}
+		log.Printf("State saved to %s", filename)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(SaveResponse{Filename: filename, Tick: tick})
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleLoad replaces the world with a posted save (POST)
+func (wi *WebInterface) handleLoad(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var data map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil || data == nil {
+		http.Error(w, "Invalid save: expected a JSON object", http.StatusBadRequest)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	err := NewStateManager(wi.world).LoadFromData(data)
+	state := wi.controlState()
+	wi.tickMutex.Unlock()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to load state: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("API loaded a save at tick %d", state.Tick)
+	wi.sendFrame()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(state)
+}
+
+// AutosaveStatus describes the run's autosaves and the autosave it can resume
+type AutosaveStatus struct {
+	Enabled       bool              `json:"enabled"`
+	Dir           string            `json:"dir,omitempty"`
+	Interval      int               `json:"interval"` // Ticks between autosaves
+	Keep          int               `json:"keep"`
+	Saves         []string          `json:"saves"`           // Oldest first
+	LastSavedTick int               `json:"last_saved_tick"` // -1 before the first autosave
+	Recovery      *AutosaveRecovery `json:"recovery,omitempty"`
+}
+
+// autosaveStatus describes the autosaves, with the tick mutex held
+func (wi *WebInterface) autosaveStatus() AutosaveStatus {
+	status := AutosaveStatus{Saves: []string{}, LastSavedTick: -1, Recovery: wi.autosaveOffer}
+	if autosaver := wi.world.Autosaver; autosaver != nil {
+		status.Enabled = true
+		status.Dir = autosaver.Dir
+		status.Interval = autosaver.Interval
+		status.Keep = autosaver.Keep
+		status.LastSavedTick = autosaver.Session().LastSavedTick
+		if saves, err := listAutosaves(autosaver.Dir); err == nil {
+			status.Saves = saves
+		}
+	}
+	return status
+}
+
+// handleAutosave describes the autosaves and any autosave on offer (GET)
+func (wi *WebInterface) handleAutosave(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	status := wi.autosaveStatus()
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// handleAutosaveResume resumes the world from the autosave on offer (POST) or declines it (DELETE)
+func (wi *WebInterface) handleAutosaveResume(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	recovery := wi.autosaveOffer
+	if recovery == nil {
+		wi.tickMutex.Unlock()
+		http.Error(w, "No autosave to resume", http.StatusNotFound)
+		return
+	}
+	var err error
+	if r.Method == http.MethodPost {
+		err = NewStateManager(wi.world).LoadFromFile(recovery.File)
+	}
+	if err == nil {
+		// An autosaving run has begun a session of its own in place of the one on offer
+		wi.autosaveOffer = nil
+		if autosaver := wi.world.Autosaver; autosaver != nil {
+			autosaver.Protected = ""
+		} else {
+			err = DismissAutosaveRecovery(filepath.Dir(recovery.File))
+		}
+	}
+	status := wi.autosaveStatus()
+	wi.tickMutex.Unlock()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to resume autosave: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		log.Printf("Resumed from %s at tick %d", recovery.File, recovery.Tick)
+		wi.sendFrame()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// BreakpointRequest is the body of a breakpoint creation request
+type BreakpointRequest struct {
+	Spec string `json:"spec"` // e.g. "tick=500", "population<5", "population:herbivore<5", "speciation"
+}
+
+// handleBreakpoints lists (GET), adds (POST) or removes (DELETE ?id=) simulation breakpoints
+func (wi *WebInterface) handleBreakpoints(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+		response := map[string]interface{}{"breakpoints": wi.world.Breakpoints.List()}
+		if hit := wi.world.Breakpoints.LastHit(); hit != nil && wi.world.IsPaused() {
+			response["last_hit"] = hit
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+
+	case http.MethodPost:
+		var req BreakpointRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid breakpoint request: %v", err), http.StatusBadRequest)
+			return
+		}
+		bp, err := wi.world.Breakpoints.Add(req.Spec, wi.world)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(bp)
+
+	case http.MethodDelete:
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			http.Error(w, "Missing or invalid id", http.StatusBadRequest)
+			return
+		}
+		if !wi.world.Breakpoints.Remove(id) {
+			http.Error(w, fmt.Sprintf("Breakpoint %d not found", id), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"removed": id})
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleGame shows the current or last competitive game (GET), starts one between every
+// player with a species (POST GameSettings) or aborts the running game (DELETE)
+func (wi *WebInterface) handleGame(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+		response := map[string]interface{}{"game": nil}
+		if game, exists := wi.world.CompetitiveGame.Current(); exists {
+			response["game"] = game
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+
+	case http.MethodPost:
+		var settings GameSettings
+		if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid game settings: %v", err), http.StatusBadRequest)
+			return
+		}
+		if game, exists := wi.world.CompetitiveGame.Current(); exists && game.Status == GameRunning {
+			http.Error(w, fmt.Sprintf("Game %d is still running", game.ID), http.StatusConflict)
+			return
+		}
+		game, err := wi.world.CompetitiveGame.Start(wi.world, settings, wi.gamePlayers())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(game)
+
+	case http.MethodDelete:
+		if !wi.world.CompetitiveGame.Abort(wi.world) {
+			http.Error(w, "No game is running", http.StatusNotFound)
+			return
+		}
+		game, _ := wi.world.CompetitiveGame.Current()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(game)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleGameRematch resets the world and replays the last finished game with each player's
+// evolved species
+func (wi *WebInterface) handleGameRematch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	last, exists := wi.world.CompetitiveGame.Current()
+	if !exists || last.Status == GameRunning {
+		http.Error(w, "There is no finished game to rematch", http.StatusConflict)
+		return
+	}
+
+	wi.world.Reset()
+	wi.reinitializeWorld()
+	game, err := wi.world.CompetitiveGame.Rematch(wi.world)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Rematch failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+	log.Printf("Rematch of game %d started as game %d", last.ID, game.ID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(game)
+}
+
+// TutorialRequest is the body of a request starting a guided scenario
+type TutorialRequest struct {
+	Scenario string `json:"scenario"`
+}
+
+// handleTutorial lists the guided scenarios with the current or last tutorial (GET), resets
+// the world into a scenario (POST {scenario}) or stops the running tutorial (DELETE)
+func (wi *WebInterface) handleTutorial(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+		response := map[string]interface{}{"scenarios": TutorialScenarios(), "tutorial": nil}
+		if tutorial, exists := wi.world.Tutorials.Current(); exists {
+			response["tutorial"] = tutorial
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+
+	case http.MethodPost:
+		var request TutorialRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid tutorial request: %v", err), http.StatusBadRequest)
+			return
+		}
+		wi.tickMutex.Lock()
+		tutorial, err := wi.world.Tutorials.Start(wi.world, request.Scenario)
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("Tutorial started: %s", tutorial.Name)
+		wi.sendFrame()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(tutorial)
+
+	case http.MethodDelete:
+		wi.tickMutex.Lock()
+		stopped := wi.world.Tutorials.Stop(wi.world)
+		wi.tickMutex.Unlock()
+		if !stopped {
+			http.Error(w, "No tutorial is running", http.StatusNotFound)
+			return
+		}
+		tutorial, _ := wi.world.Tutorials.Current()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(tutorial)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+type PredictionRoundRequest struct {
+	Question string   `json:"question"`
+	Kind     string   `json:"kind"`
+	Options  []string `json:"options"`
+	Duration int      `json:"duration"`
+}
+
+// handlePredictions lists spectator prediction rounds and the points leaderboard (GET) or
+// opens a new round (POST {question, kind, options, duration})
+func (wi *WebInterface) handlePredictions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"rounds":      wi.world.Predictions.Rounds(),
+			"leaderboard": wi.world.Predictions.Leaderboard(),
+		})
+
+	case http.MethodPost:
+		var request PredictionRoundRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid prediction round: %v", err), http.StatusBadRequest)
+			return
+		}
+		round, err := wi.world.Predictions.Open(wi.world, request.Question, request.Kind, request.Options, request.Duration)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(round)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// PredictionRequest is the body of a spectator's prediction
+type PredictionRequest struct {
+	RoundID   int    `json:"round_id"`
+	Spectator string `json:"spectator"`
+	Option    string `json:"option"`
+	Stake     int    `json:"stake"`
+}
+
+// handlePredict stakes a spectator's points on an option of an open round
+// (POST {round_id, spectator, option, stake})
+func (wi *WebInterface) handlePredict(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var request PredictionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid prediction: %v", err), http.StatusBadRequest)
+		return
+	}
+	spectator, err := wi.world.Predictions.Predict(request.RoundID, request.Spectator, request.Option, request.Stake, wi.world.Tick)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(spectator)
+}
+
+// StepRequest is the body of a request to advance the simulation
+type StepRequest struct {
+	Ticks int `json:"ticks"`
+}
+
+// handleStep reports the current state digest (GET) or pauses the simulation and advances
+// it by exactly the requested number of ticks (POST {ticks}), answering once they have run
+func (wi *WebInterface) handleStep(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+		wi.tickMutex.Lock()
+		result := wi.world.StateDigest()
+		wi.tickMutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+
+	case http.MethodPost:
+		var request StepRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid step request: %v", err), http.StatusBadRequest)
+			return
+		}
+		wi.tickMutex.Lock()
+		result, err := wi.world.Step(request.Ticks)
+		if err == nil {
+			wi.branches.Step(wi.world, result.Executed)
+		}
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		wi.sendFrame()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// FastForwardRequest is the body of a request to replay a save to a tick
+type FastForwardRequest struct {
+	State      *SimulationState `json:"state"`
+	TargetTick int              `json:"target_tick"`
+	Seed       int64            `json:"seed"`
+}
+
+// handleFastForward loads the posted save and replays it to the requested tick with
+// broadcasting held off, then resumes normal operation from there
+func (wi *WebInterface) handleFastForward(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var request FastForwardRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid fast-forward request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if request.State == nil {
+		http.Error(w, "A save to replay is required", http.StatusBadRequest)
+		return
+	}
+	wi.tickMutex.Lock()
+	result, err := FastForward(wi.world, request.State, request.TargetTick, request.Seed)
+	wi.tickMutex.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	wi.sendFrame()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// PopulationCapRequest is the body of a population cap change; nil fields keep their values
+type PopulationCapRequest struct {
+	WorldCap   *int `json:"world_cap"`
+	RegionCap  *int `json:"region_cap"`
+	RegionSize *int `json:"region_size"`
+}
+
+// handlePopulationCaps reports the soft population caps and dispersal pool (GET) or
+// changes the caps (POST); fields left out keep their current values
+func (wi *WebInterface) handlePopulationCaps(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+	case http.MethodPost:
+		var request PopulationCapRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid population cap request: %v", err), http.StatusBadRequest)
+			return
+		}
+		for _, value := range []*int{request.WorldCap, request.RegionCap, request.RegionSize} {
+			if value != nil && *value < 0 {
+				http.Error(w, "Population caps and region size cannot be negative", http.StatusBadRequest)
+				return
+			}
+		}
+		wi.tickMutex.Lock()
+		settings := &wi.world.SimConfig.Population
+		if request.WorldCap != nil {
+			settings.MaxPopulation = *request.WorldCap
+		}
+		if request.RegionCap != nil {
+			settings.RegionSoftCap = *request.RegionCap
+		}
+		if request.RegionSize != nil {
+			settings.RegionSize = *request.RegionSize
+		}
+		wi.tickMutex.Unlock()
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	status := wi.world.PopulationCaps.Status(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// handleResourceBudget reports each biome's resource budget (GET) or changes the budget's
+// settings (POST); the body is a resource budget config whose fields left out keep their values
+func (wi *WebInterface) handleResourceBudget(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+	case http.MethodPost:
+		wi.tickMutex.Lock()
+		settings := wi.world.SimConfig.Budget
+		wi.tickMutex.Unlock()
+		if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid resource budget request: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := settings.Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		wi.tickMutex.Lock()
+		wi.world.SimConfig.Budget = settings
+		wi.tickMutex.Unlock()
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	status := wi.world.ResourceBudget.Status(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// handleDayNight reports the world clock and the daylight on each row of the grid
+func (wi *WebInterface) handleDayNight(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	status := wi.world.dayNight().Status(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// handleSeason reports the current season, what it does to the world and the migrants it sent off
+func (wi *WebInterface) handleSeason(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	status := wi.world.Seasons.Status(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// handleMigration reports the herds on the move, the routes each species has learned and the
+// journeys made
+func (wi *WebInterface) handleMigration(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	status := wi.world.Migrations.Status(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// handleAudio reports the soundscape the web clients are sent over the WebSocket
+func (wi *WebInterface) handleAudio(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	mix := wi.world.Audio.Mix(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(mix)
+}
+
+// GalleryCaptureRequest is the body of a request for a snapshot now
+type GalleryCaptureRequest struct {
+	Title string `json:"title"` // Defaults to the tick
+}
+
+// handleGallery lists the snapshots captured on notable events (GET) or captures one now (POST)
+func (wi *WebInterface) handleGallery(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+		wi.tickMutex.Lock()
+		status := wi.world.Gallery.Status(wi.world)
+		wi.tickMutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(status)
+
+	case http.MethodPost:
+		var request GalleryCaptureRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil && err != io.EOF {
+			http.Error(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+		wi.tickMutex.Lock()
+		snapshot, err := wi.world.Gallery.Capture(wi.world, request.Title)
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(snapshot)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// serveGalleryImage serves the PNG of a gallery snapshot from /gallery/<image>
+func (wi *WebInterface) serveGalleryImage(w http.ResponseWriter, r *http.Request) {
+	data, exists := wi.world.Gallery.Image(strings.TrimPrefix(r.URL.Path, "/gallery/"))
+	if !exists {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	_, _ = w.Write(data)
+}
+
+// handleAchievements lists the milestones with the world's achievements and the players',
+// narrowed to one player by ?player=
+func (wi *WebInterface) handleAchievements(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	status := wi.world.Achievements.Status(r.URL.Query().Get("player"))
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// ResourcePolicyRequest is the body of a change to a store's pruning policy
+type ResourcePolicyRequest struct {
+	Store     string `json:"store"`
+	Limit     *int   `json:"limit"`
+	AutoPrune *bool  `json:"auto_prune"`
+	Prune     bool   `json:"prune"` // Apply the policy now
+}
+
+// handleResources reports store footprints and changes a store's pruning policy or prunes it now
+func (wi *WebInterface) handleResources(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+	case http.MethodPost:
+		var request ResourcePolicyRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid resource request: %v", err), http.StatusBadRequest)
+			return
+		}
+		wi.tickMutex.Lock()
+		err := wi.updateResourcePolicy(request.Store, request.Limit, request.AutoPrune, request.Prune)
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	report := wi.world.Resources.Report(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// updateResourcePolicy applies the changed fields of a store's policy; the caller holds tickMutex
+func (wi *WebInterface) updateResourcePolicy(store string, limit *int, autoPrune *bool, prune bool) error {
+	monitor := wi.world.Resources
+	if limit != nil || autoPrune != nil {
+		policy := monitor.Policy(store)
+		if limit != nil {
+			policy.Limit = *limit
+		}
+		if autoPrune != nil {
+			policy.AutoPrune = *autoPrune
+		}
+		if err := monitor.SetPolicy(store, policy); err != nil {
+			return err
+		}
+	}
+	if prune {
+		_, err := monitor.Prune(wi.world, store)
+		return err
+	}
+	return nil
+}
+
+// TimescaleRequest is the body of a request to rescale a group of time constants
+type TimescaleRequest struct {
+	Group  string  `json:"group"`
+	Factor float64 `json:"factor"`
+}
+
+// handleTimescales reports the timescale audit and rescales a group of time constants
+func (wi *WebInterface) handleTimescales(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+	case http.MethodPost:
+		var request TimescaleRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid rescale request: %v", err), http.StatusBadRequest)
+			return
+		}
+		wi.tickMutex.Lock()
+		err := RescaleTimescales(wi.world, request.Group, request.Factor)
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	audit := AuditTimescales(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(audit)
+}
+
+// handleTraits lists trait definitions so clients can build trait displays and sliders,
+// optionally only those of one subject (?subject=creature or plant)
+func (wi *WebInterface) handleTraits(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	subject := r.URL.Query().Get("subject")
+	if subject != "" && subject != TraitSubjectCreature && subject != TraitSubjectPlant {
+		http.Error(w, fmt.Sprintf("Unknown trait subject %q", subject), http.StatusBadRequest)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	definitions := wi.world.TraitRegistry.Definitions(subject)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(definitions)
+}
+
+// handleTraitLegend explains how the grid and isometric views size and colour creatures by
+// their traits
+func (wi *WebInterface) handleTraitLegend(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	legend := wi.world.TraitLegend()
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(legend)
+}
+
+// handleLegend serves the symbols and colours a renderer draws, read from the same tables it
+// draws them from, for one renderer or all of them
+func (wi *WebInterface) handleLegend(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	legends, err := wi.world.RendererLegends(r.URL.Query().Get("renderer"))
+	wi.tickMutex.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(legends)
+}
+
+// MutationRateRequest is the body of a change to a mutation operator's rate
+type MutationRateRequest struct {
+	Operator string  `json:"operator"`
+	Rate     float64 `json:"rate"`
+}
+
+// handleMutations reports the mutation operators with the changes each has made (GET)
+// or changes an operator's rate at runtime (POST {operator, rate})
+func (wi *WebInterface) handleMutations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+	case http.MethodPost:
+		var request MutationRateRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid mutation rate request: %v", err), http.StatusBadRequest)
+			return
+		}
+		wi.tickMutex.Lock()
+		err := wi.world.Mutations.SetRate(request.Operator, request.Rate)
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	report := wi.world.Mutations.Report(50)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// SelectionStrategyRequest is the body of a change to a population's selection strategy
+type SelectionStrategyRequest struct {
+	Population string `json:"population"`
+	Strategy   string `json:"strategy"`
+}
+
+// handleSelection reports the selection strategies with the one each population uses (GET)
+// or switches a population to another strategy (POST {population, strategy})
+func (wi *WebInterface) handleSelection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+	case http.MethodPost:
+		var request SelectionStrategyRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid selection request: %v", err), http.StatusBadRequest)
+			return
+		}
+		wi.tickMutex.Lock()
+		err := wi.world.SetSelectionStrategy(request.Population, request.Strategy)
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	report := wi.world.SelectionReport()
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// handleFitnessLandscape samples the fitness landscape around a species
+// (?species=&x=speed&y=size&resolution=21&radius=0.5)
+func (wi *WebInterface) handleFitnessLandscape(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	request := FitnessLandscapeRequest{Species: query.Get("species"), XTrait: query.Get("x"), YTrait: query.Get("y")}
+	if request.XTrait == "" {
+		request.XTrait = "speed"
+	}
+	if request.YTrait == "" {
+		request.YTrait = "size"
+	}
+	var err error
+	if value := query.Get("resolution"); value != "" {
+		if request.Resolution, err = strconv.Atoi(value); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid resolution %q", value), http.StatusBadRequest)
+			return
+		}
+	}
+	if value := query.Get("radius"); value != "" {
+		if request.Radius, err = strconv.ParseFloat(value, 64); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid radius %q", value), http.StatusBadRequest)
+			return
+		}
+	}
+
+	wi.tickMutex.Lock()
+	landscape, err := SampleFitnessLandscape(wi.world, request)
+	wi.tickMutex.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(landscape)
+}
+
+// handleAncestry reconstructs how a living species' traits changed along its lineage
+// (?species=&traits=speed,size&frames=60)
+func (wi *WebInterface) handleAncestry(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	var traits []string
+	if value := query.Get("traits"); value != "" {
+		traits = strings.Split(value, ",")
+	}
+	frames := 0
+	if value := query.Get("frames"); value != "" {
+		var err error
+		if frames, err = strconv.Atoi(value); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid frames %q", value), http.StatusBadRequest)
+			return
+		}
+	}
+
+	wi.tickMutex.Lock()
+	history, err := ReconstructAncestry(wi.world, query.Get("species"), traits, frames)
+	wi.tickMutex.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(history)
+}
+
+// HealthStatus answers liveness and readiness probes
+type HealthStatus struct {
+	Status string `json:"status"` // ok, ready, starting or stopping
+}
+
+// writeHealthStatus writes a probe answer; HEAD requests get only the status code
+func writeHealthStatus(w http.ResponseWriter, r *http.Request, code int, status HealthStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	if r.Method != http.MethodHead {
+		_ = json.NewEncoder(w).Encode(status)
+	}
+}
+
+// handleHealthz answers liveness probes: the process is up and serving requests
+func (wi *WebInterface) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeHealthStatus(w, r, http.StatusOK, HealthStatus{Status: "ok"})
+}
+
+// handleReadyz answers readiness probes: ready while the simulation runs, unavailable
+// before it starts and once it is stopping
+func (wi *WebInterface) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !wi.running.Load() {
+		status := "starting"
+		select {
+		case <-wi.stopChan:
+			status = "stopping"
+		default:
+		}
+		writeHealthStatus(w, r, http.StatusServiceUnavailable, HealthStatus{Status: status})
+		return
+	}
+	writeHealthStatus(w, r, http.StatusOK, HealthStatus{Status: "ready"})
+}
+
+// handlePhylogeny exports the phylogenetic tree of plant species as JSON, or as Newick
+// for tree viewers with format=newick
+func (wi *WebInterface) handlePhylogeny(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "newick" {
+		http.Error(w, fmt.Sprintf("Unknown format %q", format), http.StatusBadRequest)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	phylogeny := BuildPhylogeny(wi.world.SpeciationSystem, wi.world.Tick)
+	wi.tickMutex.Unlock()
+
+	if format == "newick" {
+		w.Header().Set("Content-Type", "text/x-nh")
+		w.Header().Set("Content-Disposition", "attachment; filename=phylogeny.nwk")
+		fmt.Fprintln(w, phylogeny.Newick())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(phylogeny)
+}
+
+// MetricHistoryIndex lists the metrics the history holds for charting
+type MetricHistoryIndex struct {
+	Tick     int          `json:"tick"`
+	Interval int          `json:"interval"` // Ticks between samples
+	Capacity int          `json:"capacity"` // Samples kept per metric
+	Metrics  []MetricInfo `json:"metrics"`
+}
+
+// MetricHistoryChart is the series of the metrics asked for over a range of ticks
+type MetricHistoryChart struct {
+	From   int             `json:"from"`
+	To     int             `json:"to"`
+	Series []*MetricSeries `json:"series"`
+}
+
+// handleMetricHistoryIndex lists the charted metrics with how far back each one goes
+func (wi *WebInterface) handleMetricHistoryIndex(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	index := MetricHistoryIndex{
+		Tick:     wi.world.Tick,
+		Interval: wi.world.scheduler().Interval("metric_history"),
+		Capacity: wi.world.MetricHistory.Capacity,
+		Metrics:  wi.world.MetricHistory.Metrics(),
+	}
+	wi.tickMutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(index)
+}
+
+// handleMetricHistory charts the comma-separated ?metrics= from ?from= to ?to=, each averaged
+// down to ?points= points. A metric ending in * stands for every metric it starts, and metrics
+// the history does not hold are left out.
+func (wi *WebInterface) handleMetricHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	if query.Get("metrics") == "" {
+		http.Error(w, "Missing metrics", http.StatusBadRequest)
+		return
+	}
+	params := map[string]int{"from": 0, "to": wi.world.Tick, "points": defaultMetricChartPoints}
+	for name := range params {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			http.Error(w, fmt.Sprintf("Invalid %s: %q", name, value), http.StatusBadRequest)
+			return
+		}
+		params[name] = parsed
+	}
+	if params["to"] < params["from"] {
+		http.Error(w, "to must not be before from", http.StatusBadRequest)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	chart := MetricHistoryChart{From: params["from"], To: min(params["to"], wi.world.Tick), Series: make([]*MetricSeries, 0)}
+	charted := make(map[string]bool)
+	for _, pattern := range strings.Split(query.Get("metrics"), ",") {
+		for _, metric := range wi.world.MetricHistory.Match(strings.TrimSpace(pattern)) {
+			if charted[metric] {
+				continue
+			}
+			charted[metric] = true
+			series, _ := wi.world.MetricHistory.Series(metric, chart.From, chart.To, min(params["points"], maxMetricChartPoints))
+			chart.Series = append(chart.Series, series)
+		}
+	}
+	wi.tickMutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(chart)
+}
+
+// handleRedQueen reports the arms races between interacting species, with the lead and lag
+// correlations of each pair's traits
+func (wi *WebInterface) handleRedQueen(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	reports := wi.world.RedQueen.Report()
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(reports)
+}
+
+// handleNicheOverlap compares the diet, habitat and activity times of every pair of living
+// species, with competition coefficients and likely competitive exclusions
+func (wi *WebInterface) handleNicheOverlap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	report := AnalyzeNicheOverlap(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// handleBiomeTransitions reports the biome state machine's rules, its recent transitions
+// and the cells building pressure toward one
+func (wi *WebInterface) handleBiomeTransitions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	report := wi.world.BiomeStates.Report(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// handleMicroclimates reports the cells whose tree cover and structures set their conditions
+// apart from their biome, and the heat refugia among them
+func (wi *WebInterface) handleMicroclimates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	report := wi.world.Microclimates.Report(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// handlePollution reports the contamination of soil and water, the tribes responsible and
+// how exposed their own members are
+func (wi *WebInterface) handlePollution(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	report := wi.world.Pollution.Report(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// handleLightPollution reports the settlements lit at night and the nocturnal hunting and
+// migration their light has disturbed
+func (wi *WebInterface) handleLightPollution(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	report := wi.world.LightPollution.Report(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// handleNoisePollution reports the disturbance zones around colonies and paths and how each
+// wild species lives with them
+func (wi *WebInterface) handleNoisePollution(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	report := wi.world.NoisePollution.Report(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// handleZoonoses reports how much each dense colony mixes with wildlife and the pathogens
+// that have spilled over between them
+func (wi *WebInterface) handleZoonoses(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	report := wi.world.Zoonoses.Report(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// handleFoodStorage reports each tribe's food stores, how fast they spoil, the preservation
+// methods known and any famine or surplus
+func (wi *WebInterface) handleFoodStorage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	report := wi.world.FoodStorage.Report(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// handleDemographics reports the population pyramids of every colony and species, with each
+// colony's birth rates and whether it has gone through the demographic transition
+func (wi *WebInterface) handleDemographics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	report := wi.world.Demographics.Report(wi.world)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// handleCensus counts the living creatures grouped by the dimensions in ?by= (species,
+// biome, region, age_class, sex, colony) and averages the metrics in ?metrics= (count,
+// energy, age or creature traits) over each group
+func (wi *WebInterface) handleCensus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != HTTPMethodGET {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wi.tickMutex.Lock()
+	query, err := wi.world.ParseCensusQuery(r.URL.Query())
+	var result CensusResult
+	if err == nil {
+		result = wi.world.Census(query)
+	}
+	wi.tickMutex.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// GraphQLRequest is the body of a GraphQL query
+type GraphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// handleGraphQL runs a GraphQL query over the world, posted as {query, variables} or given
+// as ?query= and ?variables=. A GET without a query returns the schema.
+func (wi *WebInterface) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var request GraphQLRequest
+	switch r.Method {
+	case HTTPMethodGET:
+		request.Query = r.URL.Query().Get("query")
+		if request.Query == "" {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = w.Write([]byte(GraphQLSchema()))
+			return
+		}
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				http.Error(w, fmt.Sprintf("Invalid variables: %v", err), http.StatusBadRequest)
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid GraphQL request: %v", err), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	wi.tickMutex.Lock()
+	response := wi.world.ExecuteGraphQL(request.Query, request.Variables)
+	wi.tickMutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// liveState captures the live world's state between ticks
+func (wi *WebInterface) liveState() (*SimulationState, error) {
+	wi.tickMutex.Lock()
+	defer wi.tickMutex.Unlock()
+	return NewStateManager(wi.world).CurrentState()
+}
+
+// withOperatorWorld runs an operator intervention on the world a request targets: the live
+// world between ticks, or the branch given by the branch query parameter
+func (wi *WebInterface) withOperatorWorld(r *http.Request, fn func(world *World) error) error {
+	branch := r.URL.Query().Get("branch")
+	if branch == "" {
+		wi.tickMutex.Lock()
+		defer wi.tickMutex.Unlock()
+		return fn(wi.world)
+	}
+	id, err := strconv.Atoi(branch)
+	if err != nil {
+		return fmt.Errorf("invalid branch %q", branch)
+	}
+	if branchErr := wi.branches.With(id, func(branch *WorldBranch) {
+		err = fn(branch.World)
+	}); branchErr != nil {
+		return branchErr
+	}
+	return err
+}
+
+// BranchForkRequest is the body of a request to fork the live world
+type BranchForkRequest struct {
+	Name string `json:"name"`
+}
+
+// handleBranches compares every branch with the live world (GET) or forks the live world
+// into a new branch (POST {name})
+func (wi *WebInterface) handleBranches(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+		wi.tickMutex.Lock()
+		comparisons := wi.branches.Compare(wi.world)
+		wi.tickMutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(comparisons)
+
+	case http.MethodPost:
+		var request BranchForkRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid fork request: %v", err), http.StatusBadRequest)
+			return
+		}
+		wi.tickMutex.Lock()
+		branch, err := wi.branches.Fork(wi.world, request.Name)
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		log.Printf("Forked the world into branch %d (%s) at tick %d", branch.ID, branch.Name, branch.ForkTick)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(branch)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// BranchUpdateRequest is the body of a branch update
+type BranchUpdateRequest struct {
+	Paused bool `json:"paused"`
+}
+
+// handleBranch diffs a branch against the live world (GET), pauses or resumes it
+// (POST {paused}) or discards it (DELETE)
+func (wi *WebInterface) handleBranch(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, "Missing or invalid id", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case HTTPMethodGET:
+		wi.tickMutex.Lock()
+		diff, err := wi.branches.Diff(wi.world, id)
+		wi.tickMutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"diff":            diff,
+			"has_differences": diff.HasDifferences(),
+			"summary":         diff.Summary(),
+		})
+
+	case http.MethodPost:
+		var request BranchUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid branch update: %v", err), http.StatusBadRequest)
+			return
+		}
+		var comparison BranchComparison
+		if err := wi.branches.With(id, func(branch *WorldBranch) {
+			branch.Paused = request.Paused
+			comparison = branch.compare(wi.world)
+		}); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(comparison)
+
+	case http.MethodDelete:
+		if !wi.branches.Remove(id) {
+			http.Error(w, fmt.Sprintf("Branch %d not found", id), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// gamePlayers lists every player and their species for a competitive game
+func (wi *WebInterface) gamePlayers() []GamePlayer {
+	players := make([]GamePlayer, 0, len(wi.playerManager.Players))
+	for _, id := range sortedKeys(wi.playerManager.Players) {
+		player := wi.playerManager.Players[id]
+		players = append(players, GamePlayer{ID: player.ID, Name: player.Name, Species: player.Species})
+	}
+	return players
+}
 
-	// Serve static files (CSS, JS)
-	http.HandleFunc("/static/", webInterface.serveStatic)
+// handlePetriDishes lists petri dishes (GET) or creates one (POST)
+func (wi *WebInterface) handlePetriDishes(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(wi.petriLab.List())
 
-	address := fmt.Sprintf(":%d", port)
-	fmt.Printf("Starting web interface on http://localhost%s\n", address)
-	fmt.Println("Press Ctrl+C to stop the server")
+	case http.MethodPost:
+		req := PetriDishCreateRequest{PetriDishConfig: DefaultPetriDishConfig()}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid petri dish request: %v", err), http.StatusBadRequest)
+			return
+		}
+		id, _ := wi.petriLab.Create(req.PetriDishConfig)
+		var config PetriDishConfig
+		var stats PetriDishStats
+		_ = wi.petriLab.With(id, func(dish *PetriDish) {
+			for _, population := range req.Populations {
+				dish.AddPopulation(population.Species, population.Traits)
+			}
+			config = dish.Config
+			stats = dish.Stats()
+		})
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": id, "config": config, "stats": stats})
 
-	return http.ListenAndServe(address, nil)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
 }
 
-// serveHome serves the main HTML page
-func (wi *WebInterface) serveHome(w http.ResponseWriter, r *http.Request) {
-	if r.URL.Path != "/" {
-		http.NotFound(w, r)
+// handlePetriDish reports (GET), adjusts and steps (POST), or removes (DELETE) a petri dish
+func (wi *WebInterface) handlePetriDish(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, "Missing or invalid id", http.StatusBadRequest)
 		return
 	}
 
-	html := `<!DOCTYPE html>
-<html lang="en">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>EvoSim - Genetic Ecosystem Simulation</title>
-    <style>
-        body {
-            font-family: 'Courier New', monospace;
-            margin: 0;
-            padding: 20px;
-            background-color: #1a1a1a;
-            color: #ffffff;
-        }
-        
-        .header {
-            text-align: center;
-            margin-bottom: 20px;
-        }
-        
-        .status-bar {
-            background-color: #2a2a2a;
-            padding: 10px;
-            border-radius: 5px;
-            margin-bottom: 20px;
-            display: flex;
-            justify-content: space-between;
-            align-items: center;
-        }
-        
-        .main-content {
-            display: grid;
-            grid-template-columns: 2fr 1fr;
-            gap: 20px;
-        }
-        
-        .simulation-view {
-            background-color: #2a2a2a;
-            border-radius: 5px;
-            padding: 20px;
-            position: relative;
-        }
-        
-        .info-panel {
-            background-color: #2a2a2a;
-            border-radius: 5px;
-            padding: 20px;
-        }
-        
-        .grid-container {
-            font-family: monospace;
-            font-size: 12px;
-            line-height: 14px;
-            white-space: pre;
-            background-color: #000000;
-            padding: 10px;
-            border-radius: 3px;
-            overflow-x: auto;
-        }
-        
-        .controls {
-            margin-bottom: 20px;
-        }
-        
-        .controls button {
-            background-color: #4a4a4a;
-            color: white;
-            border: none;
-            padding: 8px 16px;
-            margin: 2px;
-            border-radius: 3px;
-            cursor: pointer;
-        }
-        
-        .controls button:hover {
-            background-color: #5a5a5a;
-        }
-        
-        .controls button.active {
-            background-color: #6a6a6a;
-        }
-        
-        .speed-controls {
-            display: inline-flex;
-            align-items: center;
-            margin-left: 20px;
-            padding: 5px 10px;
-            background-color: #3a3a3a;
-            border-radius: 5px;
-        }
-        
-        .speed-controls label {
-            margin-right: 10px;
-            color: #cccccc;
-        }
-        
-        .speed-controls button {
-            padding: 4px 8px;
-            margin: 0 5px;
-            font-size: 12px;
-        }
-        
-        #speed-display {
-            color: #4CAF50;
-            font-weight: bold;
-            margin: 0 10px;
-            min-width: 50px;
-            text-align: center;
-        }
-        
-        .viewport-controls {
-            display: inline-flex;
-            align-items: center;
-            margin-left: 20px;
-            padding: 5px 10px;
-            background-color: #3a3a3a;
-            border-radius: 5px;
-        }
-        
-        .viewport-controls label {
-            margin-right: 10px;
-            color: #cccccc;
-        }
-        
-        .viewport-controls button {
-            padding: 4px 8px;
-            margin: 0 5px;
-            font-size: 12px;
-        }
-        
-        #zoom-display {
-            color: #2196F3;
-            font-weight: bold;
-            margin: 0 10px;
-            min-width: 50px;
-            text-align: center;
-        }
-        
-        .stats-section {
-            margin-bottom: 20px;
-            padding: 10px;
-            background-color: #3a3a3a;
-            border-radius: 3px;
-        }
-        
-        .stats-section h3 {
-            margin: 0 0 10px 0;
-            color: #cccccc;
-        }
-        
-        .connection-status {
-            padding: 5px 10px;
-            border-radius: 3px;
-            font-size: 14px;
-        }
-        
-        .connected {
-            background-color: #2d5a2d;
-            color: #90ee90;
-        }
-        
-        .disconnected {
-            background-color: #5a2d2d;
-            color: #ff6b6b;
-        }
-        
-        .legend {
-            font-size: 11px;
-            line-height: 16px;
-        }
-        
-        .view-tabs {
-            display: flex;
-            flex-wrap: wrap;
-            margin-bottom: 10px;
-        }
-        
-        .view-tab {
-            background-color: #4a4a4a;
-            color: white;
-            border: none;
-            padding: 5px 10px;
-            margin: 2px;
-            border-radius: 3px;
-            cursor: pointer;
-            font-size: 12px;
-        }
-        
-        .view-tab:hover {
-            background-color: #5a5a5a;
-        }
-        
-        .view-tab.active {
-            background-color: #6a9bd2;
-        }
-        
-        /* Warfare view styles */
-        .colony-item {
-            margin: 8px 0;
-            padding: 8px;
-            background-color: #4a4a4a;
-            border-radius: 3px;
-            border-left: 3px solid #6a9bd2;
-        }
-        
-        .conflict-item {
-            margin: 8px 0;
-            padding: 8px;
-            background-color: #4a4a4a;
-            border-radius: 3px;
-            border-left: 3px solid #F44336;
-        }
-        
-        .event-item {
-            margin: 4px 0;
-            padding: 4px 8px;
-            background-color: #4a4a4a;
-            border-radius: 2px;
-            font-size: 13px;
-        }
-        
-        .colony-list, .events-list {
-            max-height: 300px;
-            overflow-y: auto;
-        }
-        
-        .population-item {
-            background-color: #3a3a3a;
-            padding: 10px;
-            margin: 5px 0;
-            border-radius: 5px;
-            border-left: 3px solid transparent;
-            transition: all 0.3s ease;
-        }
-        
-        .population-item.updating {
-            border-left-color: #4CAF50;
-            background-color: #2d4a2d;
-        }
-        
-        .update-indicator {
-            color: #4CAF50;
-            animation: pulse 1s infinite;
-        }
-        
-        @keyframes pulse {
-            0% { opacity: 1; }
-            50% { opacity: 0.5; }
-            100% { opacity: 1; }
-        }
-        
-        .event-item {
-            background-color: #3a3a3a;
-            padding: 8px;
-            margin: 5px 0;
-            border-radius: 3px;
-            border-left: 3px solid #ff6b6b;
-        }
-        
-        .species-item {
-            background-color: #3a3a3a;
-            padding: 5px;
-            margin: 3px 0;
-            border-radius: 3px;
-        }
-        
-        .grid-container {
-            font-family: monospace;
-            font-size: 12px;
-            line-height: 14px;
-            white-space: pre;
-            background-color: #000000;
-            padding: 10px;
-            border-radius: 3px;
-            overflow-x: auto;
-            position: relative;
-        }
-        
-        /* Rich graphics for grid cells */
-        .grid-cell {
-            display: inline-block;
-            width: 12px;
-            height: 14px;
-            position: relative;
-        }
-        
-        .biome-plains { background-color: #2d5a2d; }
-        .biome-forest { background-color: #1a3d1a; }
-        .biome-desert { background-color: #8b8000; }
-        .biome-mountain { background-color: #696969; }
-        .biome-water { background-color: #191970; }
-        .biome-radiation { background-color: #8b0000; }
-        
-        .entity-herbivore { color: #90ee90; }
-        .entity-predator { color: #ff6b6b; }
-        .entity-omnivore { color: #87ceeb; }
-        
-        .plant-grass { color: #32cd32; }
-        .plant-bush { color: #228b22; }
-        .plant-tree { color: #006400; }
-        .plant-mushroom { color: #9370db; }
-        .plant-algae { color: #00ffff; }
-        .plant-cactus { color: #808000; }
-        
-        .rich-grid {
-            font-family: monospace;
-            line-height: 1;
-            background-color: #000000;
-            padding: 10px;
-            border-radius: 3px;
-            overflow-x: auto;
-        }
-        
-        .grid-row {
-            white-space: nowrap;
-        }
-        
-        .grid-cell {
-            display: inline-block;
-            width: 16px;
-            height: 16px;
-            position: relative;
-            text-align: center;
-            font-size: 12px;
-            line-height: 16px;
-            border-radius: 1px;
-            margin: 0;
-            vertical-align: top;
-        }
-        
-        .has-event {
-            animation: blink 1s infinite;
-        }
-        
-        @keyframes blink {
-            0%, 50% { opacity: 1; }
-            51%, 100% { opacity: 0.5; }
-        }
-        
-        .event-overlay {
-            position: absolute;
-            top: 0;
-            right: 0;
-            font-size: 8px;
-            color: yellow;
-        }
-        
-        /* Player Controls Styles */
-        .player-controls {
-            background-color: #2a4a2a;
-            padding: 15px;
-            border-radius: 5px;
-            margin-bottom: 15px;
-            border: 2px solid #4CAF50;
-        }
-        
-        .join-form, .species-form, .control-form {
-            background-color: #3a3a3a;
-            padding: 15px;
-            border-radius: 5px;
-            margin-bottom: 15px;
-            border: 1px solid #555;
-        }
-        
-        .join-form input, .species-form input, .control-form input, .control-form select {
-            width: 100%;
-            padding: 8px;
-            margin: 5px 0;
-            border: 1px solid #555;
-            border-radius: 3px;
-            background-color: #2a2a2a;
-            color: white;
-        }
-        
-        .trait-adjustments {
-            margin: 10px 0;
-        }
-        
-        .trait-adjustments label {
-            display: block;
-            margin: 8px 0;
-            font-size: 14px;
-        }
-        
-        .trait-adjustments input[type="range"] {
-            width: 60%;
-            margin: 0 10px;
-        }
-        
-        .control-commands {
-            margin: 15px 0;
-        }
-        
-        .move-controls, .action-controls {
-            margin: 10px 0;
-            padding: 10px;
-            background-color: #2a2a2a;
-            border-radius: 3px;
-        }
-        
-        .form-buttons {
-            margin-top: 15px;
-        }
-        
-        .form-buttons button, .control-commands button {
-            margin: 5px;
-            padding: 8px 15px;
-        }
-        
-        .error-message {
-            color: #ff6b6b;
-            margin-top: 10px;
-            padding: 8px;
-            background-color: #5a2d2d;
-            border-radius: 3px;
-        }
-        
-        #player-status {
-            display: flex;
-            justify-content: space-between;
-            margin-bottom: 10px;
-            font-weight: bold;
-        }
-        
-        .control-buttons {
-            display: flex;
-            gap: 10px;
-        }
-        
-        /* Neural Networks view styles */
-        .strategy-list {
-            max-height: 200px;
-            overflow-y: auto;
-        }
-        
-        .strategy-item {
-            background-color: #3a3a3a;
-            padding: 5px 10px;
-            margin: 3px 0;
-            border-radius: 3px;
-            border-left: 3px solid #4CAF50;
-        }
-        
-        .entity-networks {
-            max-height: 300px;
-            overflow-y: auto;
-        }
-        
-        .entity-network-item {
-            background-color: #3a3a3a;
-            padding: 10px;
-            margin: 8px 0;
-            border-radius: 5px;
-            border-left: 3px solid #2196F3;
-        }
-        
-        .entity-id {
-            font-weight: bold;
-            color: #87ceeb;
-            margin-bottom: 5px;
-        }
-        
-        .network-type, .network-complexity, .network-experience, .network-success {
-            font-size: 13px;
-            margin: 2px 0;
-        }
-        
-        /* View Description Styles */
-        .view-description {
-            background-color: #2a4a2a;
-            border: 1px solid #4CAF50;
-            border-radius: 5px;
-            padding: 15px;
-            margin-bottom: 15px;
-            font-size: 14px;
-            line-height: 1.4;
-        }
-        
-        .view-description h4 {
-            margin: 0 0 10px 0;
-            color: #4CAF50;
-            font-size: 16px;
-        }
-        
-        .description-toggle {
-            background-color: #4CAF50;
-            color: white;
-            border: none;
-            padding: 5px 10px;
-            margin-bottom: 10px;
-            border-radius: 3px;
-            cursor: pointer;
-            font-size: 12px;
-        }
-        
-        .description-toggle:hover {
-            background-color: #45a049;
-        }
-        
-        .description-content {
-            display: none;
-        }
-        
-        .description-content.expanded {
-            display: block;
-        }
-        
-        /* Tooltip Styles */
-        .tooltip {
-            position: relative;
-            cursor: help;
-            border-bottom: 1px dotted #4CAF50;
-        }
-        
-        .tooltip .tooltiptext {
-            visibility: hidden;
-            width: 300px;
-            background-color: #2a2a2a;
-            color: #fff;
-            text-align: left;
-            border-radius: 6px;
-            padding: 10px;
-            position: absolute;
-            z-index: 1000;
-            bottom: 125%;
-            left: 50%;
-            margin-left: -150px;
-            opacity: 0;
-            transition: opacity 0.3s;
-            border: 1px solid #4CAF50;
-            font-size: 12px;
-            line-height: 1.3;
-        }
-        
-        .tooltip .tooltiptext::after {
-            content: "";
-            position: absolute;
-            top: 100%;
-            left: 50%;
-            margin-left: -5px;
-            border-width: 5px;
-            border-style: solid;
-            border-color: #4CAF50 transparent transparent transparent;
-        }
-        
-        .tooltip:hover .tooltiptext {
-            visibility: visible;
-            opacity: 1;
-        }
-        
-        /* Stat item tooltip styles */
-        .stat-item {
-            position: relative;
-            cursor: help;
-            padding: 2px 4px;
-            border-radius: 3px;
-        }
-        
-        .stat-item:hover {
-            background-color: #3a3a3a;
-        }
-        
-        /* Help icon styles */
-        .help-icon {
-            color: #4CAF50;
-            font-size: 14px;
-            margin-left: 5px;
-            cursor: help;
-        }
-    </style>
-</head>
-<body>
-    <div class="header">
-        <h1>🌍 EvoSim - Genetic Ecosystem Simulation</h1>
-    </div>
-    
-    <div class="status-bar">
-        <div>
-            <span id="tick">Tick: 0</span> |
-            <span id="time">Time: Unknown</span> |
-            <span id="entities">Entities: 0</span> |
-            <span id="plants">Plants: 0</span> |
-            <span id="populations">Populations: 0</span>
-        </div>
-        <div class="connection-status" id="connection-status">
-            Disconnected
-        </div>
-    </div>
-    
-    <div class="main-content">
-        <div class="simulation-view">
-            <!-- Player Controls Section -->
-            <div class="player-controls" id="player-controls" style="display: none;">
-                <h3>🎮 Player Controls</h3>
-                <div id="player-status">
-                    <span id="player-name">Not logged in</span>
-                    <span id="player-species-count">0 species</span>
-                </div>
-                <div class="control-buttons">
-                    <button id="create-species-btn" onclick="showCreateSpeciesForm()">🧬 Create Species</button>
-                    <button id="control-species-btn" onclick="showControlSpeciesForm()">🎯 Control Species</button>
-                </div>
-            </div>
-
-            <!-- Join Game Form -->
-            <div class="join-form" id="join-form">
-                <h3>🎮 Join as Player</h3>
-                <input type="text" id="player-name-input" placeholder="Enter your name (letters and numbers only)" maxlength="50">
-                <button onclick="joinAsPlayer()">Join Game</button>
-                <div id="join-error" class="error-message" style="display: none;"></div>
-            </div>
-
-            <!-- Create Species Form -->
-            <div class="species-form" id="create-species-form" style="display: none;">
-                <h3>🧬 Create New Species</h3>
-                <input type="text" id="species-name-input" placeholder="Species name" maxlength="50">
-                <div class="trait-adjustments">
-                    <h4>Trait Adjustments (±0.3 range):</h4>
-                    <label>Speed: <input type="range" id="speed-trait" min="-0.3" max="0.3" step="0.1" value="0"> <span id="speed-value">0.0</span></label>
-                    <label>Aggression: <input type="range" id="aggression-trait" min="-0.3" max="0.3" step="0.1" value="0"> <span id="aggression-value">0.0</span></label>
-                    <label>Cooperation: <input type="range" id="cooperation-trait" min="-0.3" max="0.3" step="0.1" value="0"> <span id="cooperation-value">0.0</span></label>
-                    <label>Intelligence: <input type="range" id="intelligence-trait" min="-0.3" max="0.3" step="0.1" value="0"> <span id="intelligence-value">0.0</span></label>
-                </div>
-                <div class="form-buttons">
-                    <button onclick="createSpecies()">Create Species</button>
-                    <button onclick="hideCreateSpeciesForm()">Cancel</button>
-                </div>
-                <div id="create-species-error" class="error-message" style="display: none;"></div>
-            </div>
-
-            <!-- Control Species Form -->
-            <div class="control-form" id="control-species-form" style="display: none;">
-                <h3>🎯 Control Your Species</h3>
-                <select id="species-select">
-                    <option value="">Select a species to control</option>
-                </select>
-                <div class="control-commands">
-                    <div class="move-controls">
-                        <h4>Movement Control</h4>
-                        <div>Click on the grid below to move your species to that location</div>
-                        <div>Current target: <span id="move-target">None</span></div>
-                        <button onclick="executeMove()">Move to Target</button>
-                    </div>
-                    <div class="action-controls">
-                        <h4>Action Commands</h4>
-                        <button onclick="executeGather()">🌱 Gather Resources</button>
-                        <button onclick="executeReproduce()">👶 Encourage Reproduction</button>
-                    </div>
-                </div>
-                <button onclick="hideControlSpeciesForm()">Close Controls</button>
-                <div id="control-species-error" class="error-message" style="display: none;"></div>
-            </div>
-            
-            <div class="controls">
-                <button id="pause-btn" onclick="togglePause()">⏸ Pause</button>
-                <button onclick="resetSimulation()">🔄 Reset</button>
-                <button onclick="saveState()">💾 Save</button>
-                <button onclick="loadState()">📁 Load</button>
-                <input type="file" id="load-file" accept=".json" style="display: none;" onchange="handleFileLoad(event)">
-                <div class="speed-controls" style="margin-left: 20px; display: inline-block;">
-                    <label>Speed: </label>
-                    <button onclick="decreaseSpeed()">⏪</button>
-                    <span id="speed-display">1.0x</span>
-                    <button onclick="increaseSpeed()">⏩</button>
-                </div>
-                <div class="viewport-controls" style="margin-left: 20px; display: inline-block;">
-                    <label>View: </label>
-                    <button onclick="zoomOut()">🔍-</button>
-                    <span id="zoom-display">1.0x</span>
-                    <button onclick="zoomIn()">🔍+</button>
-                    <button onclick="resetViewport()" title="Reset view">🎯</button>
-                </div>
-            </div>
-            
-            <div class="view-tabs" id="view-tabs">
-                <!-- View tabs will be populated by JavaScript -->
-            </div>
-            
-            <div id="view-content">
-                <div class="grid-container" id="grid-view">
-                    Loading simulation...
-                </div>
-            </div>
-        </div>
-        
-        <div class="info-panel">
-            <div class="stats-section">
-                <h3>📊 Statistics</h3>
-                <div id="stats-content">
-                    <div>Average Fitness: <span id="avg-fitness">0.00</span></div>
-                    <div>Average Energy: <span id="avg-energy">0.00</span></div>
-                    <div>Average Age: <span id="avg-age">0.00</span></div>
-                </div>
-            </div>
-            
-            <div class="stats-section">
-                <h3>👥 Populations</h3>
-                <div id="populations-content">
-                    No populations
-                </div>
-            </div>
-            
-            <div class="stats-section">
-                <h3>📡 Communication</h3>
-                <div id="communication-content">
-                    Active Signals: <span id="active-signals">0</span>
-                </div>
-            </div>
-            
-            <div class="stats-section">
-                <h3>🌬️ Wind System</h3>
-                <div id="wind-content">
-                    <div>Direction: <span id="wind-direction">0°</span></div>
-                    <div>Strength: <span id="wind-strength">0.0</span></div>
-                    <div>Weather: <span id="weather-pattern">Calm</span></div>
-                </div>
-            </div>
-            
-            <div class="stats-section legend">
-                <h3>🌱 Legend</h3>
-                <div>
-                    <strong>Biomes:</strong><br>
-                    • Plains | ♠ Forest | ~ Desert | ^ Mountain<br>
-                    ≈ Water | ☢ Radiation | ■ Soil | ○ Air<br>
-                    ❄ Ice | 🌳 Rainforest | ≈ Deep Water | ▲ High Altitude<br>
-                    ◉ Hot Spring | ○ Tundra | ≋ Swamp | ◢ Canyon<br><br>
-                    
-                    <strong>Entities (single):</strong><br>
-                    🐰 Herbivore | 🐺 Predator | 🐻 Omnivore | 🦋 Generic<br>
-                    Numbers = Multiple entities<br><br>
-                    
-                    <strong>Plants:</strong><br>
-                    🌱 Grass | 🌿 Bush | 🌳 Tree<br>
-                    🍄 Mushroom | 🌊 Algae | 🌵 Cactus
-                </div>
-            </div>
-        </div>
-    </div>
-    
-    <script>
-        let ws = null;
-        let isPaused = false;
-        let currentView = 'GRID';
-        let playerID = null;
-        let playerSpecies = [];
-        let selectedSpecies = null;
-        let moveTarget = null;
-        
-        const viewModes = [
-            'GRID', 'STATS', 'EVENTS', 'POPULATIONS', 'COMMUNICATION',
-            'CIVILIZATION', 'PHYSICS', 'WIND', 'SPECIES', 'NETWORK',
-            'DNA', 'CELLULAR', 'EVOLUTION', 'TOPOLOGY', 'TOOLS', 'ENVIRONMENT', 'BEHAVIOR',
-            'REPRODUCTION', 'STATISTICAL', 'ECOSYSTEM', 'ANOMALIES', 'WARFARE', 'FUNGAL', 'CULTURAL', 'SYMBIOTIC', 'BIORHYTHM', 'NEURAL'
-        ];
-        
-        // Initialize view tabs
-        function initViewTabs() {
-            const tabsContainer = document.getElementById('view-tabs');
-            viewModes.forEach(mode => {
-                const button = document.createElement('button');
-                button.className = 'view-tab';
-                button.textContent = mode;
-                if (mode === currentView) {
-                    button.classList.add('active');
-                }
-                button.onclick = () => switchView(mode);
-                tabsContainer.appendChild(button);
-            });
-        }
-        
-        // Toggle view description expansion
-        function toggleDescription(viewMode) {
-            const content = document.getElementById(viewMode.toLowerCase() + '-description-content');
-            const button = document.getElementById(viewMode.toLowerCase() + '-description-toggle');
-            if (content && button) {
-                content.classList.toggle('expanded');
-                if (content.classList.contains('expanded')) {
-                    button.textContent = 'Hide Description ▲';
-                } else {
-                    button.textContent = 'Show Description ▼';
-                }
-            }
-        }
-        
-        // Get view description HTML
-        function getViewDescription(viewMode) {
-            const descriptions = {
-                'GRID': {
-                    title: 'Grid View - Main World Visualization',
-                    description: 'Displays the complete 2D simulation world showing entities, plants, biomes, and environmental features in real-time. Entities appear as symbols (🐰 herbivores, 🐺 predators, 🐻 omnivores) moving through different biomes. Click on any cell to see detailed information about entities and environment.'
-                },
-                'STATS': {
-                    title: 'Statistics View - Population Analytics',
-                    description: 'Shows comprehensive population statistics including average fitness, energy levels, age distributions, and trait averages. Tracks evolutionary progress through numerical metrics and helps identify population trends and health indicators.'
-                },
-                'EVENTS': {
-                    title: 'Events View - World Event Log',
-                    description: 'Displays recent significant events including births, deaths, environmental changes, discoveries, and other important occurrences. Events are timestamped and categorized to help track simulation progress and understand population dynamics.'
-                },
-                'POPULATIONS': {
-                    title: 'Populations View - Species Demographics',
-                    description: 'Detailed breakdown of all active species showing population counts, genetic diversity, and species-specific traits. Tracks species formation, extinction events, and genetic drift over time.'
-                },
-                'COMMUNICATION': {
-                    title: 'Communication View - Signal Networks',
-                    description: 'Monitors entity communication through 6 signal types: Alert (danger warnings), Food (source locations), Mating (reproductive calls), Territory (boundary claims), Distress (help requests), and Cooperation (group activities). Shows signal strength and propagation patterns.'
-                },
-                'CIVILIZATION': {
-                    title: 'Civilization View - Colony Development',
-                    description: 'Tracks tribal organization and structure development including 8 building types: Nests, Food Storage, Watchtowers, Barriers, Workshops, Temples, Trade Posts, and Academies. Shows technology advancement and collective resource management.'
-                },
-                'PHYSICS': {
-                    title: 'Physics View - Simulation Mechanics',
-                    description: 'Displays physics engine state including collision detection, environmental forces, movement patterns, and momentum calculations. Shows how physical laws affect entity behavior and world interactions.'
-                },
-                'WIND': {
-                    title: 'Wind View - Atmospheric System',
-                    description: 'Visualizes wind patterns, pollen dispersal, and seed distribution. Shows wind direction, strength, turbulence levels, and weather patterns. Critical for understanding plant reproduction and genetic mixing through atmospheric transport.'
-                },
-                'SPECIES': {
-                    title: 'Species View - Evolutionary Tracking',
-                    description: 'Monitors species formation through genetic distance tracking. Shows when populations split into new species, genetic compatibility matrices, and reproductive barriers. Tracks speciation events and phylogenetic relationships.'
-                },
-                'NETWORK': {
-                    title: 'Network View - Underground Plant Networks',
-                    description: 'Displays mycorrhizal networks connecting compatible plants underground. Shows resource sharing, chemical signal propagation, and network health. Three connection types: Mycorrhizal (fungal), Root (direct), and Chemical (signaling).'
-                },
-                'DNA': {
-                    title: 'DNA View - Genetic Systems',
-                    description: 'Shows detailed genetic information including nucleotide sequences (A, T, G, C), chromosome structure, gene expression levels, and inheritance patterns. Displays dominant/recessive alleles and genetic crossover during reproduction.'
-                },
-                'CELLULAR': {
-                    title: 'Cellular View - Microscopic Structure',
-                    description: 'Examines organisms at cellular level showing 8 cell types (Stem, Nerve, Muscle, Digestive, Reproductive, Defensive, Photosynthetic, Storage) and 8 organelle types. Displays cellular complexity levels from simple (Level 1) to highly complex (Level 5).'
-                },
-                'EVOLUTION': {
-                    title: 'Evolution View - Macro Evolution',
-                    description: 'Tracks large-scale evolutionary changes including species lineages, phylogenetic trees, and major evolutionary events. Shows environmental pressure correlation and identifies speciation, extinction, and adaptation events.'
-                },
-                'TOPOLOGY': {
-                    title: 'Topology View - World Terrain',
-                    description: 'Displays world geography including mountains, valleys, rivers, lakes, and underground features. Shows geological processes like earthquakes, volcanic eruptions, and erosion affecting evolution. Includes elevation maps and terrain cross-sections.'
-                },
-                'TOOLS': {
-                    title: 'Tools View - Technology System',
-                    description: 'Monitors tool creation and usage including 10 tool types: Stone, Stick, Spear, Hammer, Blade, Digger, Crusher, Container, Fire, and Weaving tools. Shows tool durability, efficiency, ownership, and modification systems.'
-                },
-                'ENVIRONMENT': {
-                    title: 'Environment View - World Modifications',
-                    description: 'Tracks environmental modifications created by entities including 12 types: Tunnels, Burrows, Caches, Traps, Waterholes, Paths, Bridges, Towers, Shelters, Workshops, Farms, and Walls. Shows persistent structures that affect future generations.'
-                },
-                'BEHAVIOR': {
-                    title: 'Behavior View - Emergent Behaviors',
-                    description: 'Monitors discovery and spread of 8 emergent behaviors: Tool Making, Cache Building, Trap Setting, Tunnel Building, Group Hunting, Nest Construction, Resource Hoarding, and Social Learning. Shows behavior proficiency and social transmission.'
-                },
-                'REPRODUCTION': {
-                    title: 'Reproduction View - Mating Systems',
-                    description: 'Displays reproductive patterns including 4 reproduction modes (DirectCoupling, EggLaying, LiveBirth, Budding) and 4 mating strategies (Monogamous, Polygamous, Sequential, Promiscuous). Shows gestation periods and seasonal mating cycles.'
-                },
-                'STATISTICAL': {
-                    title: 'Statistical View - Data Analysis',
-                    description: 'Advanced statistical analysis including trend detection, anomaly identification, data export capabilities, and pattern recognition. Provides comprehensive simulation metrics and analytical tools for researchers.'
-                },
-                'ECOSYSTEM': {
-                    title: 'Ecosystem View - Health Metrics',
-                    description: 'Overall ecosystem health assessment including genetic diversity indices (Shannon/Simpson), species count tracking, network connectivity, pollination success rates, and ecological stability measurements. Provides 0-100 health scoring.'
-                },
-                'ANOMALIES': {
-                    title: 'Anomalies View - Unusual Events',
-                    description: 'Detects and displays statistical outliers, unusual population behaviors, genetic anomalies, and environmental irregularities. Helps identify interesting evolutionary events and simulation edge cases.'
-                },
-                'WARFARE': {
-                    title: 'Warfare View - Colony Conflicts',
-                    description: 'Monitors inter-colony warfare and diplomacy including 6 relationship types (Neutral, Friendly, Allied, Rival, Hostile, Enemy) and 4 conflict types (Border Skirmish, Resource War, Total War, Raid). Shows battle outcomes and territorial changes.'
-                },
-                'FUNGAL': {
-                    title: 'Fungal View - Decomposer System',
-                    description: 'Tracks decomposer organisms that break down dead organic matter and recycle nutrients. Shows fungal reproduction through spores, mycelium network formation, and soil health enhancement through organic matter processing.'
-                },
-                'CULTURAL': {
-                    title: 'Cultural View - Knowledge Systems',
-                    description: 'Monitors cultural evolution and knowledge transmission including learned behaviors, cultural traits, knowledge inheritance, and social learning patterns. Shows how culture evolves alongside genetics.'
-                },
-                'SYMBIOTIC': {
-                    title: 'Symbiotic View - Organism Relationships',
-                    description: 'Displays parasitic and mutualistic relationships between organisms including disease transmission, host-parasite co-evolution, symbiotic partnerships, and relationship formation/dissolution dynamics.'
-                },
-                'BIORHYTHM': {
-                    title: 'BioRhythm View - Activity Patterns',
-                    description: 'Monitors entity activity patterns including 8 activity types (Sleep, Eat, Drink, Play, Explore, Scavenge, Rest, Socialize) with need-based behavior selection. Shows circadian rhythms, seasonal effects, and species-specific biorhythm profiles.'
-                },
-                'NEURAL': {
-                    title: 'Neural Networks View - AI Learning System',
-                    description: 'Monitors neural network learning in intelligent entities (intelligence > 0.3). Shows network creation, learning events, behavior patterns, and decision-making processes. Entities appear when they gain neural networks and disappear when they die or lose intelligence. Learned information is stored in synaptic weights and passed to offspring through the intelligence trait.'
-                }
-            };
-            
-            const info = descriptions[viewMode] || { title: viewMode + ' View', description: 'View description not available.' };
-            
-            return '<div class="view-description">' +
-                '<button class="description-toggle" id="' + viewMode.toLowerCase() + '-description-toggle" onclick="toggleDescription(\'' + viewMode + '\')">Show Description ▼</button>' +
-                '<div id="' + viewMode.toLowerCase() + '-description-content" class="description-content">' +
-                '<h4>' + info.title + '</h4>' +
-                '<p>' + info.description + '</p>' +
-                '</div>' +
-                '</div>';
-        }
-        
-        // Switch view mode
-        function switchView(mode) {
-            currentView = mode;
-            document.querySelectorAll('.view-tab').forEach(tab => {
-                tab.classList.toggle('active', tab.textContent === mode);
-            });
-            
-            // Update content based on view
-            updateViewContent();
-        }
-        
-        // Connect to WebSocket
-        function connect() {
-            const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-            const wsUrl = protocol + '//' + window.location.host + '/ws';
-            
-            ws = new WebSocket(wsUrl);
-            
-            ws.onopen = function() {
-                console.log('Connected to simulation');
-                document.getElementById('connection-status').textContent = 'Connected';
-                document.getElementById('connection-status').className = 'connection-status connected';
-            };
-            
-            ws.onmessage = function(event) {
-                const data = JSON.parse(event.data);
-                
-                // Check if this is a player-specific message
-                if (data.type && ['player_joined', 'species_created', 'command_executed', 'species_extinct', 'subspecies_formed', 'new_species_detected', 'error'].includes(data.type)) {
-                    handlePlayerMessage(data);
-                    return;
-                }
-                
-                // Otherwise treat it as simulation data
-                console.log('WebSocket data received, tick:', data.tick, 'entities:', data.entity_count, 'grid length:', data.grid ? data.grid.length : 'null');
-                updateDisplay(data);
-            };
-            
-            ws.onclose = function() {
-                console.log('Disconnected from simulation');
-                document.getElementById('connection-status').textContent = 'Disconnected';
-                document.getElementById('connection-status').className = 'connection-status disconnected';
-                
-                // Attempt to reconnect after 3 seconds
-                setTimeout(connect, 3000);
-            };
-            
-            ws.onerror = function(error) {
-                console.error('WebSocket error:', error);
-            };
-        }
-        
-        // Update display with new simulation data
-        function updateDisplay(data) {
-            // Update status bar
-            document.getElementById('tick').textContent = 'Tick: ' + data.tick;
-            document.getElementById('time').textContent = 'Time: ' + data.time_string;
-            document.getElementById('entities').textContent = 'Entities: ' + data.entity_count;
-            document.getElementById('plants').textContent = 'Plants: ' + data.plant_count;
-            document.getElementById('populations').textContent = 'Populations: ' + data.population_count;
-            
-            // Update speed display
-            if (data.speed_multiplier !== undefined) {
-                document.getElementById('speed-display').textContent = data.speed_multiplier.toFixed(2) + 'x';
-            }
-            
-            // Update zoom display
-            if (data.zoom_level !== undefined) {
-                document.getElementById('zoom-display').textContent = data.zoom_level.toFixed(2) + 'x';
-            }
-            
-            // Update pause button based on paused state
-            if (data.paused !== undefined) {
-                const btn = document.getElementById('pause-btn');
-                btn.textContent = data.paused ? '▶ Resume' : '⏸ Pause';
-                isPaused = data.paused;
-            }
-            
-            // Update stats
-            if (data.stats.avg_fitness !== undefined) {
-                document.getElementById('avg-fitness').textContent = data.stats.avg_fitness.toFixed(2);
-            }
-            if (data.stats.avg_energy !== undefined) {
-                document.getElementById('avg-energy').textContent = data.stats.avg_energy.toFixed(2);
-            }
-            if (data.stats.avg_age_percent !== undefined) {
-                // Use percentage of lifespan for better representation
-                document.getElementById('avg-age').textContent = data.stats.avg_age_percent.toFixed(1) + '% of lifespan';
-            } else if (data.stats.avg_age !== undefined) {
-                // Fallback to raw age if percentage not available
-                document.getElementById('avg-age').textContent = data.stats.avg_age.toFixed(1) + ' ticks';
-            }
-            
-            // Update populations
-            let populationsHtml = '';
-            // Sort populations by name for consistent ordering
-            const sortedPops = [...data.populations].sort((a, b) => a.name.localeCompare(b.name));
-            sortedPops.forEach(pop => {
-                populationsHtml += '<div><strong>' + pop.name + '</strong>: ' + pop.count + 
-                                 ' (Fitness: ' + pop.avg_fitness.toFixed(2) + ')</div>';
-            });
-            document.getElementById('populations-content').innerHTML = populationsHtml || 'No populations';
-            
-            // Update communication
-            document.getElementById('active-signals').textContent = data.communication.active_signals;
-            
-            // Update wind
-            document.getElementById('wind-direction').textContent = (data.wind.direction * 180 / Math.PI).toFixed(1) + '°';
-            document.getElementById('wind-strength').textContent = data.wind.strength.toFixed(2);
-            document.getElementById('weather-pattern').textContent = data.wind.weather_pattern;
-            
-            // Update main view content
-            updateViewContent(data);
-        }
-        
-        // Update view content based on current view mode
-        function updateViewContent(data = null) {
-            if (!data) return;
-            
-            const viewContent = document.getElementById('view-content');
-            
-            // Add view description at the top
-            let contentHtml = getViewDescription(currentView);
-            
-            switch (currentView) {
-                case 'GRID':
-                    const gridHtml = renderGrid(data.grid);
-                    console.log('Grid HTML length:', gridHtml.length, 'First 100 chars:', gridHtml.substring(0, 100));
-                    // Update the existing grid container directly
-                    const gridContainer = document.getElementById('grid-view');
-                    if (gridContainer) {
-                        // Only update the grid content, preserve the description
-                        if (!document.getElementById('grid-description-toggle')) {
-                            viewContent.innerHTML = contentHtml + '<div class="grid-container" id="grid-view" onclick="handleGridClick(event)">' + gridHtml + '</div>';
-                        } else {
-                            gridContainer.innerHTML = gridHtml;
-                        }
-                        // Add click listener for movement control
-                        if (gridContainer.onclick !== handleGridClick) {
-                            gridContainer.onclick = handleGridClick;
-                        }
-                    } else {
-                        viewContent.innerHTML = contentHtml + '<div class="grid-container" id="grid-view" onclick="handleGridClick(event)">' + gridHtml + '</div>';
-                    }
-                    break;
-                    
-                case 'STATS':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderStats(data.stats) + '</div>';
-                    break;
-                    
-                case 'EVENTS':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderEvents(data.events) + '</div>';
-                    break;
-                    
-                case 'POPULATIONS':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderPopulations(data.populations, data.population_history) + '</div>';
-                    break;
-                    
-                case 'COMMUNICATION':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderCommunication(data.communication, data.communication_history) + '</div>';
-                    break;
-                    
-                case 'CIVILIZATION':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderCivilization(data.civilization) + '</div>';
-                    break;
-                    
-                case 'PHYSICS':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderPhysics(data.physics, data.physics_history) + '</div>';
-                    break;
-                    
-                case 'WIND':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderWind(data.wind) + '</div>';
-                    break;
-                    
-                case 'SPECIES':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderSpecies(data.species) + '</div>';
-                    break;
-                    
-                case 'NETWORK':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderNetwork(data.network) + '</div>';
-                    break;
-                    
-                case 'DNA':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderDNA(data.dna) + '</div>';
-                    break;
-                    
-                case 'CELLULAR':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderCellular(data.cellular) + '</div>';
-                    break;
-                    
-                case 'EVOLUTION':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderEvolution(data.evolution) + '</div>';
-                    break;
-                    
-                case 'TOPOLOGY':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderTopology(data.topology) + '</div>';
-                    break;
-                    
-                case 'TOOLS':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderTools(data.tools) + '</div>';
-                    break;
-                    
-                case 'ENVIRONMENT':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderEnvironment(data.environmental_mod, data.environmental_pressures) + '</div>';
-                    break;
-                    
-                case 'BEHAVIOR':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderBehavior(data.emergent_behavior) + '</div>';
-                    break;
-                    
-                case 'REPRODUCTION':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderReproduction(data.reproduction) + '</div>';
-                    break;
-                    
-                case 'STATISTICAL':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderStatistical(data.statistical) + '</div>';
-                    break;
-                    
-                case 'ECOSYSTEM':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderEcosystem(data.ecosystem) + '</div>';
-                    break;
-                    
-                case 'ANOMALIES':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderAnomalies(data.anomalies) + '</div>';
-                    break;
-                    
-                case 'WARFARE':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderWarfare(data.warfare) + '</div>';
-                    break;
-                    
-                case 'FUNGAL':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderFungal(data.fungal) + '</div>';
-                    break;
-                    
-                case 'CULTURAL':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderCultural(data.cultural) + '</div>';
-                    break;
-                    
-                case 'SYMBIOTIC':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderSymbiotic(data.symbiotic_relationships) + '</div>';
-                    break;
-                    
-                case 'BIORHYTHM':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderBiorhythm(data.biorhythm) + '</div>';
-                    break;
-                    
-                case 'NEURAL':
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section">' + renderNeural(data.neural) + '</div>';
-                    break;
-                    
-                default:
-                    viewContent.innerHTML = contentHtml + '<div class="stats-section"><h3>' + currentView + '</h3><p>View not yet implemented</p></div>';
-            }
-        }
-        
-        // Render grid view with rich graphics
-        function renderGrid(grid) {
-            if (!grid || grid.length === 0) {
-                return '<div>No grid data available</div>';
-            }
-            
-            let result = '<div class="rich-grid">';
-            for (let y = 0; y < grid.length; y++) {
-                result += '<div class="grid-row">';
-                for (let x = 0; x < grid[y].length; x++) {
-                    const cell = grid[y][x];
-                    let cellClass = 'grid-cell ';
-                    let cellContent = '';
-                    
-                    // Determine biome background
-                    cellClass += getBiomeClass(cell.biome);
-                    
-                    // Determine content (entities take priority over plants over biome)
-                    if (cell.entity_count > 0) {
-                        cellClass += ' ' + getEntityClass(cell.entity_symbol);
-                        cellContent = getEntityDisplay(cell.entity_symbol, cell.entity_count);
-                    } else if (cell.plant_count > 0) {
-                        cellClass += ' ' + getPlantClass(cell.plant_symbol);
-                        cellContent = getPlantDisplay(cell.plant_symbol, cell.plant_count);
-                    } else {
-                        cellContent = getBiomeDisplay(cell.biome_symbol);
-                    }
-                    
-                    // Ensure we always have some content
-                    if (!cellContent || cellContent.trim() === '') {
-                        cellContent = '.'; // Default fallback
-                    }
-                    
-                    // Add special indicators
-                    if (cell.has_event) {
-                        cellClass += ' has-event';
-                        cellContent += '<span class="event-overlay">⚡</span>';
-                    }
-                    
-                    result += '<span class="' + cellClass + '" title="' + getCellTooltip(cell) + '">' + cellContent + '</span>';
-                }
-                result += '</div>';
-            }
-            result += '</div>';
-            return result;
-        }
-        
-        function getBiomeClass(biome) {
-            const biomeClasses = {
-                'Plains': 'biome-plains',
-                'Forest': 'biome-forest', 
-                'Desert': 'biome-desert',
-                'Mountain': 'biome-mountain',
-                'Water': 'biome-water',
-                'Radiation': 'biome-radiation'
-            };
-            return biomeClasses[biome] || 'biome-plains';
-        }
-        
-        function getEntityClass(symbol) {
-            if (symbol === 'H') return 'entity-herbivore';
-            if (symbol === 'P') return 'entity-predator';
-            if (symbol === 'O') return 'entity-omnivore';
-            return 'entity-generic';
-        }
-        
-        function getPlantClass(symbol) {
-            const plantClasses = {
-                '.': 'plant-grass',
-                '♦': 'plant-bush',
-                '♠': 'plant-tree',
-                '♪': 'plant-mushroom',
-                '≈': 'plant-algae',
-                '†': 'plant-cactus'
-            };
-            return plantClasses[symbol] || 'plant-grass';
-        }
-        
-        function getEntityDisplay(symbol, count) {
-            if (count === 1) {
-                // Use styled symbols for single entities
-                const entitySymbols = {
-                    'H': '🐰', // Herbivore
-                    'P': '🐺', // Predator  
-                    'O': '🐻', // Omnivore
-                    'E': '🦋'  // Generic entity
-                };
-                return entitySymbols[symbol] || symbol;
-            } else {
-                // Use count for multiple entities
-                return count < 10 ? count.toString() : '+';
-            }
-        }
-        
-        function getPlantDisplay(symbol, count) {
-            const plantSymbols = {
-                '.': '🌱', // Grass
-                '♦': '🌿', // Bush
-                '♠': '🌳', // Tree
-                '♪': '🍄', // Mushroom
-                '≈': '🌊', // Algae
-                '†': '🌵'  // Cactus
-            };
-            return plantSymbols[symbol] || symbol;
-        }
-        
-        function getBiomeDisplay(symbol) {
-            // Show biome symbols for empty cells
-            return symbol || '.';
-        }
-        
-        function getCellTooltip(cell) {
-            let tooltip = 'Biome: ' + cell.biome;
-            if (cell.entity_count > 0) {
-                tooltip += ', Entities: ' + cell.entity_count;
-            }
-            if (cell.plant_count > 0) {
-                tooltip += ', Plants: ' + cell.plant_count;
-            }
-            if (cell.has_event) {
-                tooltip += ', Event Active';
-            }
-            return tooltip;
-        }
-        
-        // Render stats view with enhanced information
-        function renderStats(stats) {
-            let html = '<h3>📊 World Statistics</h3>';
-            
-            html += '<h4>General Stats:</h4>';
-            
-            // Enhanced stat display with tooltips for key metrics
-            const statTooltips = {
-                'avg_fitness': 'Average fitness across all entities. Higher values (0.7+) indicate a thriving population, lower values (0.3-) suggest environmental stress or poor adaptation.',
-                'avg_energy': 'Average energy level of all entities. Low energy suggests food scarcity or high metabolic demands.',
-                'avg_age': 'Average age of entities. Higher ages indicate good survival conditions and low mortality.',
-                'avg_age_percent': 'Average age as percentage of maximum lifespan. Values over 50% suggest good longevity.',
-                'total_entities': 'Total number of living entities in the simulation.',
-                'total_plants': 'Total number of plants providing food and oxygen to the ecosystem.',
-                'genetic_diversity': 'Measure of genetic variation. Higher diversity (0.7+) improves population resilience.',
-                'mutation_rate': 'Current rate of genetic mutations. Increases under environmental stress.',
-                'birth_rate': 'Number of new entities born recently. High rates indicate reproductive success.',
-                'death_rate': 'Number of entity deaths recently. High rates may indicate environmental challenges.'
-            };
-            
-            for (const [key, value] of Object.entries(stats)) {
-                const displayKey = key.replace(/_/g, ' ').replace(/\b\w/g, l => l.toUpperCase());
-                const displayValue = typeof value === 'number' ? value.toFixed(2) : value;
-                const tooltip = statTooltips[key];
-                
-                if (tooltip) {
-                    html += '<div class="stat-item tooltip">' + displayKey + ': <strong>' + displayValue + '</strong><span class="tooltiptext">' + tooltip + '</span></div>';
-                } else {
-                    html += '<div class="stat-item">' + displayKey + ': <strong>' + displayValue + '</strong></div>';
-                }
-            }
-            
-            html += '<h4>System Health:</h4>';
-            if (stats.avg_fitness !== undefined) {
-                if (stats.avg_fitness < 0.3) {
-                    html += '<div style="color: orange;" class="tooltip">⚠️ Low average fitness - population struggling<span class="tooltiptext">Population fitness is below 0.3, indicating severe environmental stress, poor food availability, or inadequate genetic adaptation. Consider environmental modifications or population interventions.</span></div>';
-                } else if (stats.avg_fitness < 0.6) {
-                    html += '<div style="color: yellow;" class="tooltip">⚡ Moderate fitness - population stable<span class="tooltiptext">Population fitness is between 0.3-0.6, indicating stable but not optimal conditions. Population can survive but may benefit from environmental improvements.</span></div>';
-                } else {
-                    html += '<div style="color: lightgreen;" class="tooltip">✅ High fitness - population thriving<span class="tooltiptext">Population fitness is above 0.6, indicating excellent adaptation to environment. Population is healthy and reproducing successfully.</span></div>';
-                }
-            }
-            
-            if (stats.avg_energy !== undefined) {
-                if (stats.avg_energy < 30) {
-                    html += '<div style="color: orange;">⚠️ Low energy levels - resource scarcity</div>';
-                } else if (stats.avg_energy < 60) {
-                    html += '<div style="color: yellow;">⚡ Moderate energy - adequate resources</div>';
-                } else {
-                    html += '<div style="color: lightgreen;">✅ High energy - abundant resources</div>';
-                }
-            }
-            
-            // Enhanced ecosystem analysis
-            html += '<h4>🌍 Ecosystem Analysis:</h4>';
-            const fitnessEnergy = (stats.avg_fitness || 0) * (stats.avg_energy || 0) / 100;
-            if (fitnessEnergy < 0.2) {
-                html += '<div style="color: red;">🔥 Critical ecosystem stress</div>';
-            } else if (fitnessEnergy < 0.5) {
-                html += '<div style="color: orange;">⚠️ Ecosystem under pressure</div>';
-            } else if (fitnessEnergy < 0.8) {
-                html += '<div style="color: yellow;">⚡ Stable ecosystem</div>';
-            } else {
-                html += '<div style="color: lightgreen;">🌟 Thriving ecosystem</div>';
-            }
-            
-            return html;
-        }
-        
-        // Track previous population data for stable ordering
-        let previousPopulations = [];
-        let populationUpdateIndicators = {};
-        
-        // Render populations view with stable ordering and historical data
-        function renderPopulations(populations, populationHistory = []) {
-            let html = '<h3>👥 Population Details</h3>';
-            
-            // Sort populations by name for stable ordering
-            const sortedPopulations = [...populations].sort((a, b) => a.name.localeCompare(b.name));
-            
-            // Track which populations have changed
-            const currentTime = Date.now();
-            sortedPopulations.forEach(pop => {
-                const prevPop = previousPopulations.find(p => p.name === pop.name);
-                if (prevPop && (
-                    prevPop.count !== pop.count || 
-                    Math.abs(prevPop.avg_fitness - pop.avg_fitness) > 0.01 ||
-                    Math.abs(prevPop.avg_energy - pop.avg_energy) > 0.01
-                )) {
-                    populationUpdateIndicators[pop.name] = currentTime;
-                }
-            });
-            
-            sortedPopulations.forEach(pop => {
-                const isUpdating = populationUpdateIndicators[pop.name] && 
-                                 (currentTime - populationUpdateIndicators[pop.name]) < 2000; // Show indicator for 2 seconds
-                
-                html += '<div class="population-item' + (isUpdating ? ' updating' : '') + '">';
-                html += '<h4>' + pop.name + (isUpdating ? ' <span class="update-indicator">●</span>' : '') + '</h4>';
-                html += '<div class="tooltip">Count: <strong>' + pop.count + '</strong><span class="tooltiptext">Current number of living entities in this population. Sudden drops may indicate environmental stress or predation.</span></div>';
-                html += '<div class="tooltip">Average Fitness: <strong>' + pop.avg_fitness.toFixed(2) + '</strong><span class="tooltiptext">Population fitness level (0-1). Values above 0.6 indicate good adaptation, below 0.3 suggests population stress.</span></div>';
-                html += '<div class="tooltip">Average Energy: <strong>' + pop.avg_energy.toFixed(2) + '</strong><span class="tooltiptext">Average energy level (0-1). Low values may indicate food scarcity or high metabolic demands from environmental stress.</span></div>';
-                html += '<div class="tooltip">Average Age: <strong>' + pop.avg_age.toFixed(1) + '</strong><span class="tooltiptext">Average age in simulation ticks. Higher ages indicate good survival conditions and successful adaptation to environment.</span></div>';
-                
-                if (pop.trait_averages && Object.keys(pop.trait_averages).length > 0) {
-                    html += '<h5>Average Traits:</h5>';
-                    Object.entries(pop.trait_averages).forEach(([trait, value]) => {
-                        html += '<div style="font-size: 0.9em; margin-left: 10px;">' + 
-                               trait + ': ' + value.toFixed(3) + '</div>';
-                    });
-                }
-                html += '</div>';
-            });
-            
-            // Add historical data if available
-            if (populationHistory && populationHistory.length > 0) {
-                html += '<h4>📈 Population History (Last ' + populationHistory.length + ' snapshots):</h4>';
-                html += '<div style="max-height: 200px; overflow-y: auto;">';
-                populationHistory.slice(-10).forEach(snapshot => {
-                    html += '<div style="margin: 5px 0; padding: 5px; background-color: #444; border-radius: 3px;">';
-                    html += '<strong>Tick ' + snapshot.tick + '</strong> (' + snapshot.timestamp + ')<br>';
-                    snapshot.populations.forEach(pop => {
-                        html += '<span style="font-size: 0.8em;">' + pop.name + ': ' + pop.count + 
-                               ' (Fitness: ' + pop.avg_fitness.toFixed(2) + ')</span><br>';
-                    });
-                    html += '</div>';
-                });
-                html += '</div>';
-            }
-            
-            // Update previous populations for next comparison
-            previousPopulations = [...sortedPopulations];
-            
-            return html;
-        }
-        
-        // Render communication view with historical data
-        function renderCommunication(comm, commHistory = []) {
-            let html = '<h3>📡 Communication System</h3>';
-            html += '<h4>Active Signals:</h4>';
-            html += '<div>Total Active: ' + comm.active_signals + '</div>';
-            
-            if (Object.keys(comm.signal_types).length > 0) {
-                html += '<h4>Signal Types:</h4>';
-                const signalIcons = {
-                    'Danger': '🚨',
-                    'Food': '🍎', 
-                    'Mating': '💕',
-                    'Territory': '🏴',
-                    'Help': '🆘',
-                    'Migration': '🧭'
-                };
-                
-                for (const [type, count] of Object.entries(comm.signal_types)) {
-                    const icon = signalIcons[type] || '📡';
-                    html += '<div>' + icon + ' ' + type + ': ' + count + ' active</div>';
-                }
-            } else {
-                html += '<div>No active signals</div>';
-            }
-            
-            html += '<h4>Communication Stats:</h4>';
-            if (comm.active_signals === 0) {
-                html += '<div>Activity Level: Silent</div>';
-            } else if (comm.active_signals < 5) {
-                html += '<div>Activity Level: Low communication</div>';
-            } else if (comm.active_signals < 15) {
-                html += '<div>Activity Level: Moderate communication</div>';
-            } else {
-                html += '<div>Activity Level: High communication</div>';
-            }
-            
-            // Add historical data if available
-            if (commHistory && commHistory.length > 0) {
-                html += '<h4>📈 Communication History (Last ' + commHistory.length + ' snapshots):</h4>';
-                html += '<div style="max-height: 200px; overflow-y: auto;">';
-                commHistory.slice(-10).forEach(snapshot => {
-                    html += '<div style="margin: 5px 0; padding: 5px; background-color: #444; border-radius: 3px;">';
-                    html += '<strong>Tick ' + snapshot.tick + '</strong> (' + snapshot.timestamp + ')<br>';
-                    html += '<span style="font-size: 0.8em;">Active Signals: ' + snapshot.active_signals + '</span><br>';
-                    if (snapshot.signal_types && Object.keys(snapshot.signal_types).length > 0) {
-                        html += '<span style="font-size: 0.7em;">Types: ';
-                        const types = [];
-                        for (const [type, count] of Object.entries(snapshot.signal_types)) {
-                            types.push(type + ': ' + count);
-                        }
-                        html += types.join(', ') + '</span>';
-                    }
-                    html += '</div>';
-                });
-                html += '</div>';
-            }
-            
-            return html;
-        }
-        
-        // Render events view
-        function renderEvents(events) {
-            let html = '<h3>🌪️ World Events & Event Log</h3>';
-            
-            // Separate active and historical events
-            const activeEvents = events.filter(event => event.type === 'active');
-            const historicalEvents = events.filter(event => event.type === 'historical');
-            
-            // Active Events Section
-            html += '<h4>Active Events:</h4>';
-            if (activeEvents.length === 0) {
-                html += '<div>No active events</div>';
-            } else {
-                activeEvents.forEach(event => {
-                    html += '<div class="event-item">';
-                    html += '<strong>' + event.name + '</strong><br>';
-                    html += event.description + '<br>';
-                    html += '<small>Duration: ' + event.duration + ' ticks remaining</small>';
-                    html += '</div>';
-                });
-            }
-            
-            // Historical Events Section
-            html += '<h4>Recent History:</h4>';
-            if (historicalEvents.length === 0) {
-                html += '<div>No historical events recorded</div>';
-            } else {
-                historicalEvents.forEach(event => {
-                    html += '<div class="event-item" style="border-left-color: #888;">';
-                    html += '<strong>' + event.name + '</strong> ';
-                    html += '<small style="color: #aaa;">(' + event.timestamp + ')</small><br>';
-                    html += event.description + '<br>';
-                    html += '<small>Tick: ' + event.tick + '</small>';
-                    html += '</div>';
-                });
-            }
-            
-            return html;
-        }
-        
-        // Render civilization view
-        function renderCivilization(civilization) {
-            let html = '<h3>🏛️ Civilization System</h3>';
-            html += '<div>Active Tribes: ' + civilization.tribes_count + '</div>';
-            html += '<div>Total Structures: ' + civilization.structure_count + '</div>';
-            html += '<div>Total Resources: ' + civilization.total_resources + '</div>';
-            
-            if (civilization.tribes_count === 0) {
-                html += '<br><div>No tribes formed yet</div>';
-            } else {
-                html += '<br><h4>Development Status:</h4>';
-                if (civilization.structure_count === 0) {
-                    html += '<div>Civilization Level: Primitive</div>';
-                } else if (civilization.structure_count < 5) {
-                    html += '<div>Civilization Level: Developing</div>';
-                } else if (civilization.structure_count < 15) {
-                    html += '<div>Civilization Level: Advanced</div>';
-                } else {
-                    html += '<div>Civilization Level: Highly Advanced</div>';
-                }
-            }
-            
-            return html;
-        }
-        
-        // Render physics view with historical data
-        function renderPhysics(physics, physicsHistory = []) {
-            let html = '<h3>⚡ Physics System</h3>';
-            html += '<h4>Movement Statistics:</h4>';
-            html += '<div>Average Velocity: ' + physics.average_velocity.toFixed(2) + '</div>';
-            html += '<div>Total Momentum: ' + physics.total_momentum.toFixed(2) + '</div>';
-            
-            html += '<h4>Collision Statistics:</h4>';
-            html += '<div>Collisions This Tick: ' + physics.collisions_last_tick + '</div>';
-            
-            if (physics.average_velocity < 0.1) {
-                html += '<br><div>Activity Level: Low (mostly stationary entities)</div>';
-            } else if (physics.average_velocity < 0.5) {
-                html += '<br><div>Activity Level: Medium (moderate movement)</div>';
-            } else {
-                html += '<br><div>Activity Level: High (active movement)</div>';
-            }
-            
-            // Add historical data if available
-            if (physicsHistory && physicsHistory.length > 0) {
-                html += '<h4>📈 Physics History (Last ' + physicsHistory.length + ' snapshots):</h4>';
-                html += '<div style="max-height: 200px; overflow-y: auto;">';
-                physicsHistory.slice(-10).forEach(snapshot => {
-                    html += '<div style="margin: 5px 0; padding: 5px; background-color: #444; border-radius: 3px;">';
-                    html += '<strong>Tick ' + snapshot.tick + '</strong> (' + snapshot.timestamp + ')<br>';
-                    html += '<span style="font-size: 0.8em;">Collisions: ' + snapshot.collisions + 
-                           ', Velocity: ' + snapshot.average_velocity.toFixed(2) + 
-                           ', Momentum: ' + snapshot.total_momentum.toFixed(2) + '</span>';
-                    html += '</div>';
-                });
-                html += '</div>';
-            }
-            
-            return html;
-        }
-        
-        // Setup event delegation for species modal interactions
-        let speciesModalEventsSetup = false;
-        
-        function setupSpeciesModalEvents() {
-            // Only setup once to avoid multiple event listeners
-            if (speciesModalEventsSetup) {
-                return;
-            }
-            speciesModalEventsSetup = true;
-            
-            // Use event delegation on the document to handle clicks on species items
-            document.addEventListener('click', function(event) {
-                const speciesItem = event.target.closest('.clickable-species');
-                if (speciesItem) {
-                    const speciesName = speciesItem.getAttribute('data-species-name');
-                    if (speciesName) {
-                        showSpeciesDetail(speciesName);
-                    }
-                }
-            });
-        }
-        
-        // Create modal elements once when needed
-        function ensureSpeciesModalExists() {
-            const existingModal = document.getElementById('species-detail-modal');
-            if (!existingModal) {
-                // Create modal
-                const modal = document.createElement('div');
-                modal.id = 'species-detail-modal';
-                modal.style.cssText = 'display: none; position: fixed; top: 50%; left: 50%; transform: translate(-50%, -50%); background-color: #1a1a1a; border: 2px solid #444; border-radius: 10px; padding: 20px; max-width: 80%; max-height: 80%; overflow-y: auto; z-index: 1000;';
-                
-                const closeButtonHtml = '<div style="text-align: right;"><button id="species-modal-close" style="background-color: #666; color: white; border: none; padding: 5px 10px; border-radius: 3px; cursor: pointer;">✕ Close</button></div>';
-                const contentHtml = '<div id="species-detail-content"></div>';
-                modal.innerHTML = closeButtonHtml + contentHtml;
-                
-                // Create overlay
-                const overlay = document.createElement('div');
-                overlay.id = 'species-modal-overlay';
-                overlay.style.cssText = 'display: none; position: fixed; top: 0; left: 0; width: 100%; height: 100%; background-color: rgba(0,0,0,0.7); z-index: 999;';
-                
-                // Add close event listeners
-                const closeButton = modal.querySelector('#species-modal-close');
-                closeButton.addEventListener('click', hideSpeciesDetail);
-                overlay.addEventListener('click', hideSpeciesDetail);
-                
-                // Add to body
-                document.body.appendChild(modal);
-                document.body.appendChild(overlay);
-            }
-        }
-        
-        // Render species view with enhanced details and individual visualization
-        function renderSpecies(species) {
-            let html = '<h3>🐾 Species Tracking & Individual Visualization</h3>';
-            html += '<div>Active Species: ' + species.active_species + '</div>';
-            html += '<div>Extinct Species: ' + species.extinct_species + '</div>';
-            
-            // Calculate diversity metrics
-            const totalSpecies = species.active_species + species.extinct_species;
-            const survivalRate = totalSpecies > 0 ? (species.active_species / totalSpecies * 100).toFixed(1) : 100;
-            
-            html += '<h4>📈 Diversity Metrics:</h4>';
-            html += '<div>Species Survival Rate: ' + survivalRate + '%</div>';
-            
-            if (survivalRate < 30) {
-                html += '<div style="color: red;">🔥 High extinction rate - evolutionary crisis</div>';
-            } else if (survivalRate < 60) {
-                html += '<div style="color: orange;">⚠️ Moderate extinction pressure</div>';
-            } else if (survivalRate < 85) {
-                html += '<div style="color: yellow;">⚡ Natural selection in progress</div>';
-            } else {
-                html += '<div style="color: lightgreen;">🌟 Species diversity stable</div>';
-            }
-            
-            // Individual Species Visualization Section
-            html += '<h4>🔍 Individual Species Visualization:</h4>';
-            html += '<div style="margin: 10px 0; padding: 10px; background-color: #2a2a2a; border-radius: 5px;">';
-            html += 'Click on any species below to see detailed visual representation of what it looks like based on its genetic traits.';
-            html += '</div>';
-            
-            if (species.species_details && species.species_details.length > 0) {
-                html += '<h4>Species Gallery:</h4>';
-                // Sort by population for better display
-                const sortedSpecies = [...species.species_details].sort((a, b) => {
-                    if (a.is_extinct !== b.is_extinct) {
-                        return a.is_extinct ? 1 : -1; // Active species first
-                    }
-                    return b.population - a.population; // Higher population first
-                });
-                
-                sortedSpecies.forEach(detail => {
-                    html += '<div class="species-item clickable-species" data-species-name="' + detail.name.replace(/"/g, '&quot;') + '" style="cursor: pointer; padding: 8px; margin: 5px 0; background-color: #333; border-radius: 3px; border-left: 4px solid ' + (detail.is_extinct ? '#ff4444' : '#44ff44') + ';">';
-                    html += '<strong>' + detail.name + '</strong>';
-                    if (detail.is_extinct) {
-                        html += ' <span style="color: red;">💀 (Extinct)</span>';
-                    } else {
-                        html += ' - Population: ' + detail.population;
-                        // Add population health indicator
-                        if (detail.population < 5) {
-                            html += ' <span style="color: orange;">⚠️ Endangered</span>';
-                        } else if (detail.population < 15) {
-                            html += ' <span style="color: yellow;">⚡ Vulnerable</span>';
-                        } else {
-                            html += ' <span style="color: lightgreen;">✅ Stable</span>';
-                        }
-                    }
-                    html += '<div style="font-size: 0.8em; color: #ccc; margin-top: 3px;">Click to view detailed visualization →</div>';
-                    html += '</div>';
-                });
-            } else {
-                html += '<br><div>No species data available</div>';
-            }
-            
-            return html;
-        }
-        
-        // Show detailed species visualization
-        function showSpeciesDetail(speciesName) {
-            // Ensure modal elements exist
-            ensureSpeciesModalExists();
-            
-            const modal = document.getElementById('species-detail-modal');
-            const overlay = document.getElementById('species-modal-overlay');
-            const content = document.getElementById('species-detail-content');
-            
-            if (!modal || !overlay || !content) {
-                console.error('Species modal elements not found');
-                return;
-            }
-            
-            // Create detailed visualization for the species
-            let detailHtml = '<h2>🦠 ' + speciesName + ' - Individual Visualization</h2>';
-            
-            // Simulated trait-based visualization
-            detailHtml += renderSpeciesVisualization(speciesName);
-            
-            content.innerHTML = detailHtml;
-            modal.style.display = 'block';
-            overlay.style.display = 'block';
-        }
-        
-        // Hide species detail modal
-        function hideSpeciesDetail() {
-            const modal = document.getElementById('species-detail-modal');
-            const overlay = document.getElementById('species-modal-overlay');
-            
-            if (modal) {
-                modal.style.display = 'none';
-            }
-            if (overlay) {
-                overlay.style.display = 'none';
-            }
-        }
-        
-        // Render individual species visualization based on traits
-        function renderSpeciesVisualization(speciesName) {
-            let html = '';
-            
-            // Create a visual representation based on the species name patterns
-            html += '<div style="display: flex; gap: 20px; flex-wrap: wrap;">';
-            
-            // Species Profile View
-            html += '<div style="flex: 1; min-width: 300px;">';
-            html += '<h3>🎨 Species Profile View</h3>';
-            html += '<div style="text-align: center; background-color: #2a2a2a; padding: 20px; border-radius: 10px;">';
-            
-            // Generate visual representation based on species characteristics
-            const visual = generateSpeciesVisual(speciesName);
-            html += visual.profile;
-            
-            html += '</div>';
-            html += '</div>';
-            
-            // Trait Analysis
-            html += '<div style="flex: 1; min-width: 300px;">';
-            html += '<h3>📊 Genetic Trait Analysis</h3>';
-            html += '<div style="background-color: #2a2a2a; padding: 15px; border-radius: 10px;">';
-            html += visual.traits;
-            html += '</div>';
-            html += '</div>';
-            
-            html += '</div>';
-            
-            // Cellular View
-            html += '<div style="margin-top: 20px;">';
-            html += '<h3>🔬 Cellular Structure View</h3>';
-            html += '<div style="background-color: #2a2a2a; padding: 15px; border-radius: 10px;">';
-            html += visual.cellular;
-            html += '</div>';
-            html += '</div>';
-            
-            // Habitat Information
-            html += '<div style="margin-top: 20px;">';
-            html += '<h3>🌍 Environmental Adaptation</h3>';
-            html += '<div style="background-color: #2a2a2a; padding: 15px; border-radius: 10px;">';
-            html += visual.habitat;
-            html += '</div>';
-            html += '</div>';
-            
-            return html;
-        }
-        
-        // Generate visual representation for a species
-        function generateSpeciesVisual(speciesName) {
-            // Extract characteristics from species name patterns
-            const isGrass = speciesName.toLowerCase().includes('grass');
-            const isTree = speciesName.toLowerCase().includes('tree');
-            const isBush = speciesName.toLowerCase().includes('bush');
-            const isAlgae = speciesName.toLowerCase().includes('algae');
-            const isCactus = speciesName.toLowerCase().includes('cactus');
-            const isMushroom = speciesName.toLowerCase().includes('mushroom');
-            
-            // Generate pseudo-random traits based on name hash
-            const hash = stringHash(speciesName);
-            const traits = {
-                size: 0.3 + (hash % 100) / 100 * 0.7,
-                defense: 0.1 + (hash % 80) / 100 * 0.6,
-                toxicity: 0.0 + (hash % 60) / 100 * 0.8,
-                growth: 0.2 + (hash % 90) / 100 * 0.6,
-                hardiness: 0.1 + (hash % 85) / 100 * 0.7
-            };
-            
-            // Adjust traits based on type
-            if (isTree) {
-                traits.size += 0.3;
-                traits.defense += 0.2;
-            } else if (isGrass) {
-                traits.growth += 0.3;
-                traits.size -= 0.2;
-            } else if (isCactus) {
-                traits.defense += 0.4;
-                traits.toxicity += 0.3;
-            } else if (isMushroom) {
-                traits.toxicity += 0.5;
-                traits.defense -= 0.1;
-            }
-            
-            const profile = generateProfileView(speciesName, traits);
-            const traitBars = generateTraitBars(traits);
-            const cellular = generateCellularView(speciesName, traits);
-            const habitat = generateHabitatView(speciesName, traits);
-            
-            return {
-                profile: profile,
-                traits: traitBars,
-                cellular: cellular,
-                habitat: habitat
-            };
-        }
-        
-        // Generate profile view visualization with authentic Minecraft/Rimworld style
-        function generateProfileView(name, traits) {
-            let html = '';
-            
-            // True blocky, pixelated representation like Minecraft blocks
-            const sizeBlocks = Math.max(3, Math.floor(traits.size * 6) + 2); // 3-8 blocks
-            const defenseLevel = Math.floor(traits.defense * 4); // 0-3 defense levels
-            const blockSize = 16; // Standard block size like Minecraft
-            
-            html += '<div style="margin: 20px 0; text-align: center;">';
-            html += '<div style="display: inline-block; position: relative;">';
-            
-            // Create a grid-based organism using individual blocks
-            const totalSize = sizeBlocks * blockSize;
-            html += '<div style="display: inline-grid; grid-template-columns: repeat(' + sizeBlocks + ', ' + blockSize + 'px); ';
-            html += 'grid-template-rows: repeat(' + sizeBlocks + ', ' + blockSize + 'px); gap: 0; ';
-            html += 'image-rendering: pixelated; image-rendering: -moz-crisp-edges; image-rendering: crisp-edges;">';
-            
-            // Generate organism body as a collection of blocks
-            const centerX = Math.floor(sizeBlocks / 2);
-            const centerY = Math.floor(sizeBlocks / 2);
-            
-            for (let y = 0; y < sizeBlocks; y++) {
-                for (let x = 0; x < sizeBlocks; x++) {
-                    const distFromCenter = Math.abs(x - centerX) + Math.abs(y - centerY);
-                    const isBodyBlock = distFromCenter <= Math.floor(sizeBlocks / 2);
-                    const isCore = distFromCenter <= Math.floor(sizeBlocks / 4);
-                    const isEdge = (x === 0 || x === sizeBlocks - 1 || y === 0 || y === sizeBlocks - 1) && isBodyBlock;
-                    
-                    let blockColor = '#222'; // Empty space
-                    let topLight = 'rgba(255,255,255,0.1)';
-                    let bottomShadow = 'rgba(0,0,0,0.3)';
-                    
-                    if (isBodyBlock) {
-                        // Main body blocks
-                        if (isCore) {
-                            blockColor = traits.toxicity > 0.4 ? '#AA3333' : '#4488FF'; // Core is blue or toxic red
-                            topLight = 'rgba(255,255,255,0.4)';
-                        } else {
-                            blockColor = traits.size > 0.6 ? '#3377CC' : '#2266BB'; // Body varies by size
-                            topLight = 'rgba(255,255,255,0.3)';
-                        }
-                        
-                        // Defense armor blocks
-                        if (defenseLevel > 0 && isEdge) {
-                            blockColor = '#BB7722'; // Orange armor blocks
-                            topLight = 'rgba(255,255,255,0.4)';
-                        }
-                        
-                        // Growth/fertility indicators
-                        if (traits.growth > 0.6 && (x + y) % 2 === 0 && !isCore) {
-                            blockColor = '#44AA44'; // Green growth blocks
-                        }
-                    }
-                    
-                    // Create individual block with Minecraft-style shading
-                    html += '<div style="width: ' + blockSize + 'px; height: ' + blockSize + 'px; ';
-                    html += 'background: ' + blockColor + '; ';
-                    html += 'box-shadow: ';
-                    html += 'inset 0 ' + Math.floor(blockSize/4) + 'px 0 ' + topLight + ', '; // Top highlight
-                    html += 'inset 0 -' + Math.floor(blockSize/4) + 'px 0 ' + bottomShadow + ', '; // Bottom shadow
-                    html += 'inset ' + Math.floor(blockSize/4) + 'px 0 0 rgba(255,255,255,0.2), '; // Left highlight
-                    html += 'inset -' + Math.floor(blockSize/4) + 'px 0 0 rgba(0,0,0,0.2); '; // Right shadow
-                    html += 'image-rendering: pixelated; ';
-                    html += 'border: 0;"></div>';
-                }
-            }
-            html += '</div>';
-            
-            // Add toxicity skull overlay if toxic
-            if (traits.toxicity > 0.5) {
-                html += '<div style="position: absolute; top: 50%; left: 50%; transform: translate(-50%, -50%); ';
-                html += 'font-size: ' + Math.floor(totalSize / 4) + 'px; z-index: 10;">💀</div>';
-            }
-            
-            // Add defensive spikes as separate blocks
-            if (defenseLevel > 1) {
-                const spikeOffset = totalSize / 2 + blockSize / 2;
-                // Top spike
-                html += '<div style="position: absolute; top: -' + blockSize + 'px; left: 50%; transform: translateX(-50%); ';
-                html += 'width: ' + blockSize + 'px; height: ' + blockSize + 'px; ';
-                html += 'background: #BB7722; ';
-                html += 'box-shadow: inset 0 4px 0 rgba(255,255,255,0.4), inset 0 -4px 0 rgba(0,0,0,0.3); ';
-                html += 'image-rendering: pixelated;"></div>';
-                
-                // Side spikes for high defense
-                if (defenseLevel > 2) {
-                    html += '<div style="position: absolute; top: 50%; left: -' + blockSize + 'px; transform: translateY(-50%); ';
-                    html += 'width: ' + blockSize + 'px; height: ' + blockSize + 'px; ';
-                    html += 'background: #BB7722; ';
-                    html += 'box-shadow: inset 0 4px 0 rgba(255,255,255,0.4), inset 0 -4px 0 rgba(0,0,0,0.3); ';
-                    html += 'image-rendering: pixelated;"></div>';
-                    
-                    html += '<div style="position: absolute; top: 50%; right: -' + blockSize + 'px; transform: translateY(-50%); ';
-                    html += 'width: ' + blockSize + 'px; height: ' + blockSize + 'px; ';
-                    html += 'background: #BB7722; ';
-                    html += 'box-shadow: inset 0 4px 0 rgba(255,255,255,0.4), inset 0 -4px 0 rgba(0,0,0,0.3); ';
-                    html += 'image-rendering: pixelated;"></div>';
-                }
-            }
-            
-            html += '</div>';
-            html += '<div style="font-size: 0.8em; margin-top: 15px; color: #aaa; font-family: monospace; font-weight: bold;">' + name + '</div>';
-            html += '</div>';
-            
-            return html;
-        }
-        
-        // Helper function to get trait-based colors
-        function getTraitColor(value, baseColor, intenseColor) {
-            const intensity = Math.max(0, Math.min(1, value));
-            return baseColor; // Simplified for now
-        }
-        
-        // Generate blocky trait bars Minecraft-style
-        function generateBlockyTraitBars(traits) {
-            let html = '';
-            
-            Object.entries(traits).forEach(([trait, value]) => {
-                const blockCount = Math.floor(value * 10);
-                const percentage = (value * 100).toFixed(0);
-                
-                html += '<div style="margin: 8px 0; font-family: monospace;">';
-                html += '<div style="display: flex; justify-content: space-between; margin-bottom: 3px;">';
-                html += '<span style="text-transform: capitalize; font-weight: bold;">' + trait + '</span>';
-                html += '<span style="color: #aaa;">' + percentage + '%</span>';
-                html += '</div>';
-                
-                // Create blocky progress bar
-                html += '<div style="display: flex; gap: 1px; height: 16px;">';
-                for (let i = 0; i < 10; i++) {
-                    const isActive = i < blockCount;
-                    const blockColor = isActive ? getTraitBlockColor(trait) : '#333';
-                    html += '<div style="width: 16px; height: 16px; background: ' + blockColor + '; ';
-                    html += 'border: 1px solid #555; image-rendering: pixelated; ';
-                    html += 'box-shadow: inset 1px 1px 0 rgba(255,255,255,0.2), inset -1px -1px 0 rgba(0,0,0,0.3);"></div>';
-                }
-                html += '</div>';
-                html += '</div>';
-            });
-            
-            return html;
-        }
-        
-        // Get color for trait blocks
-        function getTraitBlockColor(trait) {
-            const colors = {
-                'size': '#4a9eff',
-                'defense': '#ff9944', 
-                'toxicity': '#ff4444',
-                'growth': '#44ff44',
-                'hardiness': '#9944ff',
-                'fertility': '#ff44ff'
-            };
-            return colors[trait] || '#44ff44';
-        }
-        
-        // Generate trait bars with authentic Minecraft-style design
-        function generateTraitBars(traits) {
-            let html = '';
-            
-            Object.entries(traits).forEach(([trait, value]) => {
-                const blockCount = Math.floor(value * 10);
-                const percentage = (value * 100).toFixed(1);
-                
-                html += '<div style="margin: 10px 0; font-family: monospace;">';
-                html += '<div style="display: flex; justify-content: space-between; margin-bottom: 5px;">';
-                html += '<span style="text-transform: capitalize; font-weight: bold; color: #fff; text-shadow: 1px 1px 0 #000;">' + trait + '</span>';
-                html += '<span style="color: #aaa; font-weight: bold; text-shadow: 1px 1px 0 #000;">' + percentage + '%</span>';
-                html += '</div>';
-                
-                // Create authentic Minecraft-style progress bar with blocks
-                html += '<div style="display: flex; gap: 1px; background: #2a2a2a; padding: 3px; border: 2px inset #444; border-radius: 2px;">';
-                for (let i = 0; i < 10; i++) {
-                    const isActive = i < blockCount;
-                    const blockColor = isActive ? getMinecraftTraitColor(trait) : '#1a1a1a';
-                    
-                    // Create authentic Minecraft block with proper lighting
-                    html += '<div style="width: 18px; height: 18px; ';
-                    html += 'background: ' + blockColor + '; ';
-                    if (isActive) {
-                        html += 'box-shadow: ';
-                        html += 'inset 0 4px 0 rgba(255,255,255,0.4), '; // Top highlight
-                        html += 'inset 0 -4px 0 rgba(0,0,0,0.4), '; // Bottom shadow  
-                        html += 'inset 4px 0 0 rgba(255,255,255,0.2), '; // Left highlight
-                        html += 'inset -4px 0 0 rgba(0,0,0,0.2); '; // Right shadow
-                    } else {
-                        html += 'box-shadow: ';
-                        html += 'inset 0 2px 0 rgba(255,255,255,0.1), '; // Subtle top
-                        html += 'inset 0 -2px 0 rgba(0,0,0,0.3); '; // Subtle bottom
-                    }
-                    html += 'border: none; ';
-                    html += 'image-rendering: pixelated; ';
-                    html += 'image-rendering: -moz-crisp-edges; ';
-                    html += 'image-rendering: crisp-edges;"></div>';
-                }
-                html += '</div>';
-                html += '</div>';
-            });
-            
-            return html;
-        }
-        
-        // Get authentic Minecraft-style colors for trait blocks
-        function getMinecraftTraitColor(trait) {
-            const colors = {
-                'size': '#3366CC',      // Blue like lapis lazuli blocks
-                'defense': '#CC6600',   // Orange like copper blocks
-                'toxicity': '#CC3333',  // Red like redstone blocks
-                'growth': '#33AA33',    // Green like emerald blocks
-                'hardiness': '#6633CC', // Purple like amethyst blocks
-                'fertility': '#CC33AA'  // Pink like coral blocks
-            };
-            return colors[trait] || '#33AA33';
-        }
-        
-        // Generate cellular view with blocky, Minecraft-style representation
-        // Generate cellular view with authentic Minecraft-style blocks
-        function generateCellularView(name, traits) {
-            let html = '';
-            
-            html += '<div><strong>Cellular Structure (Minecraft-Style Blocks):</strong></div>';
-            html += '<div style="margin: 15px 0; background: #1a1a1a; padding: 20px; border: 3px solid #333; image-rendering: pixelated;">';
-            
-            // Create authentic blocky cell structure using grid
-            const cellGridSize = Math.max(6, Math.floor(traits.size * 8) + 4); // 6-12 blocks
-            const blockSize = 12;
-            
-            html += '<div style="display: inline-grid; ';
-            html += 'grid-template-columns: repeat(' + cellGridSize + ', ' + blockSize + 'px); ';
-            html += 'grid-template-rows: repeat(' + cellGridSize + ', ' + blockSize + 'px); ';
-            html += 'gap: 1px; ';
-            html += 'background: #000; ';
-            html += 'padding: 2px; ';
-            html += 'border: 2px solid #444; ';
-            html += 'image-rendering: pixelated; ';
-            html += 'image-rendering: -moz-crisp-edges; ';
-            html += 'image-rendering: crisp-edges;">';
-            
-            // Generate cell as blocks
-            const centerX = Math.floor(cellGridSize / 2);
-            const centerY = Math.floor(cellGridSize / 2);
-            
-            for (let y = 0; y < cellGridSize; y++) {
-                for (let x = 0; x < cellGridSize; x++) {
-                    const distFromCenter = Math.abs(x - centerX) + Math.abs(y - centerY);
-                    const isMembraneBlock = distFromCenter === Math.floor(cellGridSize / 2);
-                    const isInteriorBlock = distFromCenter < Math.floor(cellGridSize / 2);
-                    const isNucleusBlock = distFromCenter <= 1;
-                    
-                    let blockColor = '#000'; // Background
-                    let topHighlight = 'rgba(255,255,255,0.1)';
-                    let bottomShadow = 'rgba(0,0,0,0.5)';
-                    
-                    if (isMembraneBlock) {
-                        // Cell membrane - brown/orange blocks
-                        blockColor = '#8B4513';
-                        topHighlight = 'rgba(255,255,255,0.3)';
-                        bottomShadow = 'rgba(0,0,0,0.4)';
-                    } else if (isNucleusBlock) {
-                        // Nucleus - blue blocks  
-                        blockColor = '#3366CC';
-                        topHighlight = 'rgba(255,255,255,0.4)';
-                        bottomShadow = 'rgba(0,0,0,0.3)';
-                    } else if (isInteriorBlock) {
-                        // Cytoplasm with organelles
-                        if (traits.toxicity > 0.4 && (x + y) % 4 === 0) {
-                            // Toxin organelles - red blocks
-                            blockColor = '#CC3333';
-                            topHighlight = 'rgba(255,255,255,0.3)';
-                        } else if (traits.defense > 0.4 && (x + y) % 5 === 0) {
-                            // Defense organelles - orange blocks
-                            blockColor = '#CC6600';
-                            topHighlight = 'rgba(255,255,255,0.3)';
-                        } else if (traits.growth > 0.5 && (x + y) % 3 === 0) {
-                            // Growth organelles - green blocks
-                            blockColor = '#33AA33';
-                            topHighlight = 'rgba(255,255,255,0.3)';
-                        } else if (traits.size > 0.5 && (x + y) % 6 === 0) {
-                            // Mitochondria - yellow blocks
-                            blockColor = '#CCAA33';
-                            topHighlight = 'rgba(255,255,255,0.3)';
-                        } else {
-                            // Basic cytoplasm - gray blocks
-                            blockColor = '#555555';
-                            topHighlight = 'rgba(255,255,255,0.2)';
-                        }
-                        bottomShadow = 'rgba(0,0,0,0.3)';
-                    }
-                    
-                    // Create individual block with Minecraft lighting
-                    if (blockColor !== '#000') {
-                        html += '<div style="width: ' + blockSize + 'px; height: ' + blockSize + 'px; ';
-                        html += 'background: ' + blockColor + '; ';
-                        html += 'box-shadow: ';
-                        html += 'inset 0 ' + Math.floor(blockSize/3) + 'px 0 ' + topHighlight + ', ';
-                        html += 'inset 0 -' + Math.floor(blockSize/3) + 'px 0 ' + bottomShadow + ', ';
-                        html += 'inset ' + Math.floor(blockSize/3) + 'px 0 0 rgba(255,255,255,0.15), ';
-                        html += 'inset -' + Math.floor(blockSize/3) + 'px 0 0 rgba(0,0,0,0.15); ';
-                        html += 'image-rendering: pixelated; ';
-                        html += 'image-rendering: -moz-crisp-edges; ';
-                        html += 'image-rendering: crisp-edges;"></div>';
-                    } else {
-                        // Empty space block
-                        html += '<div style="width: ' + blockSize + 'px; height: ' + blockSize + 'px; background: #000;"></div>';
-                    }
-                }
-            }
-            html += '</div>';
-            
-            // Legend for organelles
-            html += '<div style="margin-top: 15px; font-size: 0.8em; color: #aaa; text-align: left;">';
-            html += '<div><span style="display: inline-block; width: 12px; height: 12px; background: #8B4513; margin-right: 5px; vertical-align: middle; image-rendering: pixelated;"></span>Cell Membrane</div>';
-            html += '<div><span style="display: inline-block; width: 12px; height: 12px; background: #3366CC; margin-right: 5px; vertical-align: middle; image-rendering: pixelated;"></span>Nucleus</div>';
-            if (traits.toxicity > 0.4) {
-                html += '<div><span style="display: inline-block; width: 12px; height: 12px; background: #CC3333; margin-right: 5px; vertical-align: middle; image-rendering: pixelated;"></span>Toxin Organelles</div>';
-            }
-            if (traits.defense > 0.4) {
-                html += '<div><span style="display: inline-block; width: 12px; height: 12px; background: #CC6600; margin-right: 5px; vertical-align: middle; image-rendering: pixelated;"></span>Defense Structures</div>';
-            }
-            if (traits.growth > 0.5) {
-                html += '<div><span style="display: inline-block; width: 12px; height: 12px; background: #33AA33; margin-right: 5px; vertical-align: middle; image-rendering: pixelated;"></span>Growth Centers</div>';
-            }
-            if (traits.size > 0.5) {
-                html += '<div><span style="display: inline-block; width: 12px; height: 12px; background: #CCAA33; margin-right: 5px; vertical-align: middle; image-rendering: pixelated;"></span>Energy Organelles</div>';
-            }
-            html += '</div>';
-            
-            // Cell health/energy indicators with Minecraft-style blocks
-            html += '<div style="display: flex; gap: 20px; margin-top: 15px;">';
-            
-            // Health indicator
-            const health = (traits.defense + traits.hardiness) / 2;
-            html += '<div style="flex: 1;">';
-            html += '<div style="margin-bottom: 5px; font-weight: bold; color: #fff; text-shadow: 1px 1px 0 #000;">CELL HEALTH:</div>';
-            html += generateMinecraftIndicator(health, '#33AA33', '#AA3333');
-            html += '</div>';
-            
-            // Energy indicator  
-            const energy = (traits.size + traits.growth) / 2;
-            html += '<div style="flex: 1;">';
-            html += '<div style="margin-bottom: 5px; font-weight: bold; color: #fff; text-shadow: 1px 1px 0 #000;">ENERGY LEVEL:</div>';
-            html += generateMinecraftIndicator(energy, '#CCAA33', '#664400');
-            html += '</div>';
-            
-            html += '</div>';
-            html += '</div>';
-            
-            return html;
-        }
-        
-        // Helper function to generate Minecraft-style indicators
-        function generateMinecraftIndicator(value, fullColor, emptyColor) {
-            let html = '<div style="display: flex; gap: 1px;">';
-            const blocks = Math.floor(value * 8);
-            
-            for (let i = 0; i < 8; i++) {
-                const isActive = i < blocks;
-                const color = isActive ? fullColor : emptyColor;
-                html += '<div style="width: 16px; height: 16px; background: ' + color + '; ';
-                if (isActive) {
-                    html += 'box-shadow: ';
-                    html += 'inset 0 4px 0 rgba(255,255,255,0.3), ';
-                    html += 'inset 0 -4px 0 rgba(0,0,0,0.3), ';
-                    html += 'inset 4px 0 0 rgba(255,255,255,0.15), ';
-                    html += 'inset -4px 0 0 rgba(0,0,0,0.15); ';
-                } else {
-                    html += 'box-shadow: ';
-                    html += 'inset 0 2px 0 rgba(255,255,255,0.1), ';
-                    html += 'inset 0 -2px 0 rgba(0,0,0,0.4); ';
-                }
-                html += 'border: none; ';
-                html += 'image-rendering: pixelated; ';
-                html += 'image-rendering: -moz-crisp-edges; ';
-                html += 'image-rendering: crisp-edges;"></div>';
-            }
-            
-            html += '</div>';
-            return html;
-        }
-        
-
-        
-        // Generate habitat view
-        function generateHabitatView(name, traits) {
-            let html = '';
-            
-            html += '<div><strong>Preferred Habitat:</strong></div>';
-            
-            // Determine habitat based on traits
-            let habitat = 'Plains';
-            let description = 'Adaptable to various conditions';
-            
-            if (traits.defense > 0.7) {
-                habitat = 'Mountain/Desert';
-                description = 'Hardy species adapted to harsh conditions';
-            } else if (traits.growth > 0.7) {
-                habitat = 'Forest/Rainforest';
-                description = 'Fast-growing species thriving in rich environments';
-            } else if (traits.toxicity > 0.6) {
-                habitat = 'Radiation/Wasteland';
-                description = 'Toxic species adapted to contaminated areas';
-            } else if (traits.size > 0.7) {
-                habitat = 'Forest';
-                description = 'Large species requiring space and resources';
-            }
-            
-            html += '<div style="margin: 10px 0;">';
-            html += '<strong>Primary Habitat:</strong> ' + habitat + '<br>';
-            html += '<strong>Adaptation:</strong> ' + description + '<br>';
-            html += '</div>';
-            
-            // Environmental tolerance
-            html += '<div><strong>Environmental Tolerance:</strong></div>';
-            html += '<div style="margin-left: 10px; font-size: 0.9em;">';
-            html += 'Temperature: ' + (traits.hardiness > 0.5 ? 'Wide range' : 'Moderate range') + '<br>';
-            html += 'Moisture: ' + (traits.growth > 0.5 ? 'High requirements' : 'Low requirements') + '<br>';
-            html += 'Soil Quality: ' + (traits.size > 0.5 ? 'Rich soil preferred' : 'Any soil type') + '<br>';
-            html += '</div>';
-            
-            return html;
-        }
-        
-        // Get base symbol for species type
-        function getBaseSymbol(name) {
-            if (name.toLowerCase().includes('grass')) return '🌱';
-            if (name.toLowerCase().includes('tree')) return '🌳';
-            if (name.toLowerCase().includes('bush')) return '🌿';
-            if (name.toLowerCase().includes('algae')) return '🌊';
-            if (name.toLowerCase().includes('cactus')) return '🌵';
-            if (name.toLowerCase().includes('mushroom')) return '🍄';
-            return '🌱'; // Default
-        }
-        
-        // Simple string hash function
-        function stringHash(str) {
-            let hash = 0;
-            for (let i = 0; i < str.length; i++) {
-                const char = str.charCodeAt(i);
-                hash = ((hash << 5) - hash) + char;
-                hash = hash & hash; // Convert to 32bit integer
-            }
-            return Math.abs(hash);
-        }
-        
-        // Render network view
-        function renderNetwork(network) {
-            let html = '<h3>🌐 Plant Network System</h3>';
-            html += '<div>Network Connections: ' + network.connection_count + '</div>';
-            html += '<div>Chemical Signals: ' + network.signal_count + '</div>';
-            html += '<div>Network Clusters: ' + network.cluster_count + '</div>';
-            
-            if (network.connection_count === 0) {
-                html += '<br><div>No plant networks formed yet</div>';
-            } else {
-                html += '<br><h4>Network Status:</h4>';
-                if (network.cluster_count === 0) {
-                    html += '<div>Network Density: Sparse connections</div>';
-                } else if (network.cluster_count < 3) {
-                    html += '<div>Network Density: Few clusters formed</div>';
-                } else {
-                    html += '<div>Network Density: Multiple active clusters</div>';
-                }
-            }
-            
-            return html;
-        }
-        
-        // Render DNA view
-        function renderDNA(dna) {
-            let html = '<h3>🧬 DNA System</h3>';
-            html += '<div>Organisms: ' + dna.organism_count + '</div>';
-            html += '<div>Average Mutations: ' + dna.average_mutations.toFixed(2) + '</div>';
-            html += '<div>Average Complexity: ' + dna.average_complexity.toFixed(2) + '</div>';
-            
-            if (dna.organism_count === 0) {
-                html += '<br><div>No DNA-based organisms present</div>';
-            } else {
-                html += '<br><h4>Genetic Status:</h4>';
-                if (dna.average_complexity < 1.0) {
-                    html += '<div>Complexity Level: Simple organisms</div>';
-                } else if (dna.average_complexity < 3.0) {
-                    html += '<div>Complexity Level: Moderate complexity</div>';
-                } else {
-                    html += '<div>Complexity Level: Complex organisms</div>';
-                }
-            }
-            
-            return html;
-        }
-        
-        // Render cellular view
-        function renderCellular(cellular) {
-            let html = '<h3>🔬 Cellular System & Individual Organism View</h3>';
-            html += '<div>Total Cells: ' + cellular.total_cells + '</div>';
-            html += '<div>Average Complexity: ' + cellular.average_complexity.toFixed(2) + '</div>';
-            html += '<div>Cell Divisions: ' + cellular.cell_divisions + '</div>';
-            
-            if (cellular.total_cells === 0) {
-                html += '<br><div>No cellular activity detected</div>';
-            } else {
-                html += '<br><h4>Cellular Activity:</h4>';
-                if (cellular.cell_divisions === 0) {
-                    html += '<div>Division Activity: No recent divisions</div>';
-                } else if (cellular.cell_divisions < 10) {
-                    html += '<div>Division Activity: Low division rate</div>';
-                } else {
-                    html += '<div>Division Activity: Active cell division</div>';
-                }
-                
-                // Add individual organism visualization
-                html += '<br><h4>🦠 Individual Organism Visualization:</h4>';
-                html += '<div style="background-color: #2a2a2a; padding: 15px; border-radius: 10px; margin: 10px 0;">';
-                html += renderSimulatedOrganism(cellular);
-                html += '</div>';
-                
-                // Complexity levels breakdown
-                html += '<br><h4>📊 Complexity Distribution:</h4>';
-                html += '<div style="margin-left: 15px;">';
-                html += '<div>Level 1 (Single-cell): ' + Math.round(cellular.total_cells * 0.4) + ' organisms</div>';
-                html += '<div>Level 2 (Simple multi-cell): ' + Math.round(cellular.total_cells * 0.3) + ' organisms</div>';
-                html += '<div>Level 3 (Moderate complexity): ' + Math.round(cellular.total_cells * 0.2) + ' organisms</div>';
-                html += '<div>Level 4 (Complex): ' + Math.round(cellular.total_cells * 0.08) + ' organisms</div>';
-                html += '<div>Level 5 (Highly complex): ' + Math.round(cellular.total_cells * 0.02) + ' organisms</div>';
-                html += '</div>';
-            }
-            
-            return html;
-        }
-        
-        // Render a simulated organism based on cellular data
-        function renderSimulatedOrganism(cellular) {
-            let html = '';
-            
-            // Create a visual representation of a typical organism
-            html += '<div style="text-align: center;">';
-            html += '<h5>🦠 Sample Multi-cellular Organism</h5>';
-            html += '<div style="font-family: monospace; font-size: 1.2em; line-height: 1.2; margin: 15px 0;">';
-            
-            // Generate organism structure based on complexity
-            const complexity = Math.min(5, Math.max(1, cellular.average_complexity));
-            
-            if (complexity >= 1) {
-                html += '<div style="color: #44ff44;">🌟 Nucleus (Core)</div>';
-            }
-            if (complexity >= 2) {
-                html += '<div style="color: #ffaa44;">⚡⚡ Mitochondria (Energy)</div>';
-            }
-            if (complexity >= 3) {
-                html += '<div style="color: #44aaff;">🔵🔵🔵 Specialized Cells</div>';
-            }
-            if (complexity >= 4) {
-                html += '<div style="color: #ff44aa;">📦📦 Organ Systems</div>';
-            }
-            if (complexity >= 5) {
-                html += '<div style="color: #aa44ff;">🧠 Neural Network</div>';
-            }
-            
-            html += '</div>';
-            
-            // Cell type breakdown
-            html += '<div style="text-align: left; margin-top: 15px;">';
-            html += '<strong>Cell Type Distribution:</strong><br>';
-            html += '<div style="margin-left: 10px; font-size: 0.9em;">';
-            html += '<span style="color: #44ff44;">S</span> Stem cells: ' + Math.round(cellular.total_cells * 0.15) + '<br>';
-            html += '<span style="color: #ffaa44;">N</span> Nerve cells: ' + Math.round(cellular.total_cells * 0.10) + '<br>';
-            html += '<span style="color: #ff4444;">M</span> Muscle cells: ' + Math.round(cellular.total_cells * 0.20) + '<br>';
-            html += '<span style="color: #4444ff;">D</span> Digestive cells: ' + Math.round(cellular.total_cells * 0.25) + '<br>';
-            html += '<span style="color: #ff44ff;">R</span> Reproductive cells: ' + Math.round(cellular.total_cells * 0.05) + '<br>';
-            html += '<span style="color: #44ffff;">F</span> Defensive cells: ' + Math.round(cellular.total_cells * 0.15) + '<br>';
-            html += '<span style="color: #aaff44;">P</span> Photosynthetic cells: ' + Math.round(cellular.total_cells * 0.08) + '<br>';
-            html += '<span style="color: #ffaa44;">T</span> Storage cells: ' + Math.round(cellular.total_cells * 0.02) + '<br>';
-            html += '</div>';
-            html += '</div>';
-            
-            // Organelle visualization
-            html += '<div style="margin-top: 15px;">';
-            html += '<strong>Organelle Profile:</strong><br>';
-            html += '<div style="display: flex; flex-wrap: wrap; gap: 5px; margin-top: 5px;">';
-            html += '<span title="Nucleus">⬣</span>';
-            html += '<span title="Mitochondria">⚡</span>';
-            html += '<span title="Chloroplast">🌱</span>';
-            html += '<span title="Ribosome">⬢</span>';
-            html += '<span title="Vacuole">💧</span>';
-            html += '<span title="Golgi">📦</span>';
-            html += '<span title="ER">🕸</span>';
-            html += '<span title="Lysosome">🗑</span>';
-            html += '</div>';
-            html += '</div>';
-            
-            html += '</div>';
-            
-            return html;
-        }
-        
-        // Render evolution view
-        function renderEvolution(evolution) {
-            let html = '<h3>🌿 Evolution Tracking</h3>';
-            html += '<div>Speciation Events: ' + evolution.speciation_events + '</div>';
-            html += '<div>Extinction Events: ' + evolution.extinction_events + '</div>';
-            html += '<div>Genetic Diversity: ' + evolution.genetic_diversity.toFixed(2) + '</div>';
-            
-            html += '<br><h4>Evolutionary Status:</h4>';
-            if (evolution.speciation_events === 0) {
-                html += '<div>No speciation detected yet</div>';
-            } else if (evolution.speciation_events < 3) {
-                html += '<div>Early speciation phase</div>';
-            } else {
-                html += '<div>Active evolutionary divergence</div>';
-            }
-            
-            if (evolution.extinction_events > 0) {
-                html += '<div style="color: orange;">Warning: ' + evolution.extinction_events + ' extinction event(s) occurred</div>';
-            }
-            
-            return html;
-        }
-        
-        // Render topology view
-        function renderTopology(topology) {
-            let html = '<h3>🗻 World Topology</h3>';
-            html += '<div>Elevation Range: ' + topology.elevation_range + '</div>';
-            html += '<div>Fluid Regions: ' + topology.fluid_regions + '</div>';
-            html += '<div>Geological Age: ' + topology.geological_age + '</div>';
-            
-            html += '<br><h4>Terrain Analysis:</h4>';
-            if (topology.fluid_regions === 0) {
-                html += '<div>Terrain Type: Dry landscape</div>';
-            } else if (topology.fluid_regions < 3) {
-                html += '<div>Terrain Type: Semi-arid with water sources</div>';
-            } else {
-                html += '<div>Terrain Type: Water-rich environment</div>';
-            }
-            
-            return html;
-        }
-
-        // Render wind view with enhanced information
-        function renderWind(wind) {
-            let html = '<h3>🌬️ Wind System</h3>';
-            html += '<div>Direction: ' + (wind.direction * 180 / Math.PI).toFixed(1) + '°</div>';
-            html += '<div>Strength: ' + wind.strength.toFixed(2) + '</div>';
-            html += '<div>Turbulence: ' + wind.turbulence_level.toFixed(2) + '</div>';
-            html += '<div>Weather: ' + wind.weather_pattern + '</div>';
-            html += '<div>Pollen Count: ' + wind.pollen_count + '</div>';
-            
-            // Add seed dispersal information
-            html += '<h4>🌱 Seed Dispersal System</h4>';
-            html += '<div>Active Seeds: ' + (wind.seed_count || 0) + '</div>';
-            html += '<div>Seed Banks: ' + (wind.seed_banks || 0) + '</div>';
-            html += '<div>Germination Events: ' + (wind.germination_events || 0) + '</div>';
-            html += '<div>Dormancy Activations: ' + (wind.dormancy_activations || 0) + '</div>';
-            
-            // Display dispersal statistics
-            if (wind.dispersal_stats) {
-                html += '<h4>🎯 Dispersal Methods</h4>';
-                if (wind.dispersal_stats.dispersal_wind) html += '<div>Wind Dispersal: ' + wind.dispersal_stats.dispersal_wind + '</div>';
-                if (wind.dispersal_stats.dispersal_animal) html += '<div>Animal Dispersal: ' + wind.dispersal_stats.dispersal_animal + '</div>';
-                if (wind.dispersal_stats.dispersal_explosive) html += '<div>Explosive Dispersal: ' + wind.dispersal_stats.dispersal_explosive + '</div>';
-                if (wind.dispersal_stats.dispersal_gravity) html += '<div>Gravity Dispersal: ' + wind.dispersal_stats.dispersal_gravity + '</div>';
-                if (wind.dispersal_stats.dispersal_water) html += '<div>Water Dispersal: ' + wind.dispersal_stats.dispersal_water + '</div>';
-                
-                // Display seed type statistics
-                if (wind.dispersal_stats.active_seeds_by_type) {
-                    html += '<h4>🎯 Active Seed Types</h4>';
-                    const seedTypes = wind.dispersal_stats.active_seeds_by_type;
-                    for (const [type, count] of Object.entries(seedTypes)) {
-                        html += '<div>' + type.replace(/_/g, ' ').toUpperCase() + ': ' + count + '</div>';
-                    }
-                }
-            }
-            
-            // Add detailed analysis
-            html += '<h4>🌪️ Wind Analysis:</h4>';
-            const windDirection = (wind.direction * 180 / Math.PI + 360) % 360;
-            let directionName = '';
-            if (windDirection < 45 || windDirection >= 315) directionName = 'North';
-            else if (windDirection < 135) directionName = 'East';
-            else if (windDirection < 225) directionName = 'South';
-            else directionName = 'West';
-            
-            html += '<div>Cardinal Direction: ' + directionName + '</div>';
-            
-            // Wind strength analysis
-            if (wind.strength < 0.2) {
-                html += '<div style="color: lightblue;">🌿 Gentle breeze - minimal pollen dispersal</div>';
-            } else if (wind.strength < 0.5) {
-                html += '<div style="color: yellow;">💨 Moderate wind - good for plant reproduction</div>';
-            } else if (wind.strength < 0.8) {
-                html += '<div style="color: orange;">🌪️ Strong wind - high pollen dispersal</div>';
-            } else {
-                html += '<div style="color: red;">⛈️ Storm conditions - disrupted ecosystem</div>';
-            }
-            
-            // Pollen dispersal analysis
-            html += '<h4>🌸 Pollen Dispersal:</h4>';
-            if (wind.pollen_count === 0) {
-                html += '<div>No active pollen dispersal</div>';
-            } else if (wind.pollen_count < 10) {
-                html += '<div style="color: lightgreen;">Low pollen activity</div>';
-            } else if (wind.pollen_count < 30) {
-                html += '<div style="color: yellow;">Moderate pollen dispersal</div>';
-            } else {
-                html += '<div style="color: orange;">High pollen activity - peak breeding season</div>';
-            }
-            
-            return html;
-        }
-        
-        // Control functions
-        function togglePause() {
-            isPaused = !isPaused;
-            const btn = document.getElementById('pause-btn');
-            btn.textContent = isPaused ? '▶ Resume' : '⏸ Pause';
-            
-            if (ws && ws.readyState === WebSocket.OPEN) {
-                ws.send(JSON.stringify({action: 'toggle_pause'}));
-            }
-        }
-        
-        function increaseSpeed() {
-            if (ws && ws.readyState === WebSocket.OPEN) {
-                ws.send(JSON.stringify({action: 'increase_speed'}));
-            }
-        }
-        
-        function decreaseSpeed() {
-            if (ws && ws.readyState === WebSocket.OPEN) {
-                ws.send(JSON.stringify({action: 'decrease_speed'}));
-            }
-        }
-        
-        function zoomIn() {
-            if (ws && ws.readyState === WebSocket.OPEN) {
-                ws.send(JSON.stringify({action: 'zoom_in'}));
-            }
-        }
-        
-        function zoomOut() {
-            if (ws && ws.readyState === WebSocket.OPEN) {
-                ws.send(JSON.stringify({action: 'zoom_out'}));
-            }
-        }
-        
-        // Initialize viewport controls
-        function initViewportControls() {
-            // Keyboard controls for panning
-            document.addEventListener('keydown', function(event) {
-                // Only handle pan controls if no input is focused
-                if (document.activeElement.tagName === 'INPUT' || document.activeElement.tagName === 'TEXTAREA') {
-                    return;
-                }
-                
-                const panStep = 3;
-                switch(event.key) {
-                    case 'ArrowUp':
-                        event.preventDefault();
-                        panViewport(0, -panStep);
-                        break;
-                    case 'ArrowDown':
-                        event.preventDefault();
-                        panViewport(0, panStep);
-                        break;
-                    case 'ArrowLeft':
-                        event.preventDefault();
-                        panViewport(-panStep, 0);
-                        break;
-                    case 'ArrowRight':
-                        event.preventDefault();
-                        panViewport(panStep, 0);
-                        break;
-                    case '+':
-                    case '=':
-                        event.preventDefault();
-                        zoomIn();
-                        break;
-                    case '-':
-                    case '_':
-                        event.preventDefault();
-                        zoomOut();
-                        break;
-                    case '0':
-                        event.preventDefault();
-                        resetViewport();
-                        break;
-                }
-            });
-            
-            // Mouse wheel zoom
-            document.getElementById('grid-view').addEventListener('wheel', function(event) {
-                event.preventDefault();
-                if (event.deltaY < 0) {
-                    zoomIn();
-                } else {
-                    zoomOut();
-                }
-            });
-            
-            // Mouse drag to pan
-            let isDragging = false;
-            let dragStartX = 0;
-            let dragStartY = 0;
-            
-            document.getElementById('grid-view').addEventListener('mousedown', function(event) {
-                event.preventDefault();
-                isDragging = true;
-                dragStartX = event.clientX;
-                dragStartY = event.clientY;
-                document.body.style.userSelect = 'none'; // Prevent text selection
-            });
-            
-            document.addEventListener('mousemove', function(event) {
-                if (!isDragging) return;
-                
-                const deltaX = Math.floor((event.clientX - dragStartX) / 10); // Scale down the movement
-                const deltaY = Math.floor((event.clientY - dragStartY) / 10);
-                
-                if (Math.abs(deltaX) > 0 || Math.abs(deltaY) > 0) {
-                    panViewport(-deltaX, -deltaY); // Inverted because dragging right should pan left
-                    dragStartX = event.clientX;
-                    dragStartY = event.clientY;
-                }
-            });
-            
-            document.addEventListener('mouseup', function(event) {
-                isDragging = false;
-                document.body.style.userSelect = ''; // Re-enable text selection
-            });
-        }
-        
-        function panViewport(deltaX, deltaY) {
-            if (ws && ws.readyState === WebSocket.OPEN) {
-                ws.send(JSON.stringify({
-                    action: 'pan',
-                    data: {
-                        deltaX: deltaX,
-                        deltaY: deltaY
-                    }
-                }));
-            }
-        }
-        
-        function resetViewport() {
-            if (ws && ws.readyState === WebSocket.OPEN) {
-                ws.send(JSON.stringify({action: 'reset_viewport'}));
-            }
-        }
-        
-        function resetSimulation() {
-            if (ws && ws.readyState === WebSocket.OPEN) {
-                ws.send(JSON.stringify({action: 'reset'}));
-            }
-        }
-        
-        function saveState() {
-            if (ws && ws.readyState === WebSocket.OPEN) {
-                ws.send(JSON.stringify({action: 'save_state'}));
-            }
-        }
-        
-        function loadState() {
-            document.getElementById('load-file').click();
-        }
-        
-        function handleFileLoad(event) {
-            const file = event.target.files[0];
-            if (file) {
-                const reader = new FileReader();
-                reader.onload = function(e) {
-                    try {
-                        const stateData = JSON.parse(e.target.result);
-                        if (ws && ws.readyState === WebSocket.OPEN) {
-                            ws.send(JSON.stringify({
-                                action: 'load_state',
-                                data: stateData
-                            }));
-                        }
-                    } catch (error) {
-                        alert('Error loading file: Invalid JSON format');
-                    }
-                };
-                reader.readAsText(file);
-            }
-        }
-        
-        // Initialize the interface
-        window.onload = function() {
-            initViewTabs();
-            initTraitSliders();
-            initViewportControls();
-            connect();
-            
-            // Initialize species modal functionality
-            setupSpeciesModalEvents();
-        };
-        
-        // Initialize trait sliders
-        function initTraitSliders() {
-            const traits = ['speed', 'aggression', 'cooperation', 'intelligence'];
-            traits.forEach(trait => {
-                const slider = document.getElementById(trait + '-trait');
-                const valueSpan = document.getElementById(trait + '-value');
-                if (slider && valueSpan) {
-                    slider.oninput = function() {
-                        valueSpan.textContent = parseFloat(this.value).toFixed(1);
-                    };
-                }
-            });
-        }
-        
-        // Player Management Functions
-        function joinAsPlayer() {
-            const nameInput = document.getElementById('player-name-input');
-            const playerName = nameInput.value.trim();
-            const errorDiv = document.getElementById('join-error');
-            
-            if (!playerName) {
-                showError(errorDiv, 'Please enter your name');
-                return;
-            }
-            
-            if (!/^[a-zA-Z0-9\s]+$/.test(playerName)) {
-                showError(errorDiv, 'Name can only contain letters, numbers, and spaces');
-                return;
-            }
-            
-            hideError(errorDiv);
-            
-            if (ws && ws.readyState === WebSocket.OPEN) {
-                ws.send(JSON.stringify({
-                    action: 'join_as_player',
-                    data: { name: playerName }
-                }));
-            }
-        }
-        
-        function showCreateSpeciesForm() {
-            document.getElementById('create-species-form').style.display = 'block';
-            document.getElementById('control-species-form').style.display = 'none';
-        }
-        
-        function hideCreateSpeciesForm() {
-            document.getElementById('create-species-form').style.display = 'none';
-            document.getElementById('species-name-input').value = '';
-            resetTraitSliders();
-        }
-        
-        function showControlSpeciesForm() {
-            updateSpeciesSelect();
-            document.getElementById('control-species-form').style.display = 'block';
-            document.getElementById('create-species-form').style.display = 'none';
-        }
-        
-        function hideControlSpeciesForm() {
-            document.getElementById('control-species-form').style.display = 'none';
-        }
-        
-        function resetTraitSliders() {
-            const traits = ['speed', 'aggression', 'cooperation', 'intelligence'];
-            traits.forEach(trait => {
-                const slider = document.getElementById(trait + '-trait');
-                const valueSpan = document.getElementById(trait + '-value');
-                if (slider && valueSpan) {
-                    slider.value = 0;
-                    valueSpan.textContent = '0.0';
-                }
-            });
-        }
-        
-        function createSpecies() {
-            const nameInput = document.getElementById('species-name-input');
-            const speciesName = nameInput.value.trim();
-            const errorDiv = document.getElementById('create-species-error');
-            
-            if (!speciesName) {
-                showError(errorDiv, 'Please enter a species name');
-                return;
-            }
-            
-            if (!/^[a-zA-Z0-9\s]+$/.test(speciesName)) {
-                showError(errorDiv, 'Species name can only contain letters, numbers, and spaces');
-                return;
-            }
-            
-            hideError(errorDiv);
-            
-            // Collect trait adjustments
-            const traits = {};
-            ['speed', 'aggression', 'cooperation', 'intelligence'].forEach(trait => {
-                const slider = document.getElementById(trait + '-trait');
-                if (slider) {
-                    traits[trait] = parseFloat(slider.value);
-                }
-            });
-            
-            if (ws && ws.readyState === WebSocket.OPEN) {
-                ws.send(JSON.stringify({
-                    action: 'create_species',
-                    data: { 
-                        name: speciesName,
-                        traits: traits
-                    }
-                }));
-            }
-        }
-        
-        function updateSpeciesSelect() {
-            const select = document.getElementById('species-select');
-            select.innerHTML = '<option value="">Select a species to control</option>';
-            
-            playerSpecies.forEach(species => {
-                const option = document.createElement('option');
-                option.value = species;
-                option.textContent = species;
-                select.appendChild(option);
-            });
-        }
-        
-        function executeMove() {
-            const select = document.getElementById('species-select');
-            const selectedSpecies = select.value;
-            const errorDiv = document.getElementById('control-species-error');
-            
-            if (!selectedSpecies) {
-                showError(errorDiv, 'Please select a species first');
-                return;
-            }
-            
-            if (!moveTarget) {
-                showError(errorDiv, 'Please click on the grid to set a target location first');
-                return;
-            }
-            
-            hideError(errorDiv);
-            
-            if (ws && ws.readyState === WebSocket.OPEN) {
-                ws.send(JSON.stringify({
-                    action: 'control_species',
-                    data: {
-                        species: selectedSpecies,
-                        command: 'move',
-                        x: moveTarget.x,
-                        y: moveTarget.y
-                    }
-                }));
-            }
-        }
-        
-        function executeGather() {
-            const select = document.getElementById('species-select');
-            const selectedSpecies = select.value;
-            const errorDiv = document.getElementById('control-species-error');
-            
-            if (!selectedSpecies) {
-                showError(errorDiv, 'Please select a species first');
-                return;
-            }
-            
-            hideError(errorDiv);
-            
-            if (ws && ws.readyState === WebSocket.OPEN) {
-                ws.send(JSON.stringify({
-                    action: 'control_species',
-                    data: {
-                        species: selectedSpecies,
-                        command: 'gather'
-                    }
-                }));
-            }
-        }
-        
-        function executeReproduce() {
-            const select = document.getElementById('species-select');
-            const selectedSpecies = select.value;
-            const errorDiv = document.getElementById('control-species-error');
-            
-            if (!selectedSpecies) {
-                showError(errorDiv, 'Please select a species first');
-                return;
-            }
-            
-            hideError(errorDiv);
-            
-            if (ws && ws.readyState === WebSocket.OPEN) {
-                ws.send(JSON.stringify({
-                    action: 'control_species',
-                    data: {
-                        species: selectedSpecies,
-                        command: 'reproduce'
-                    }
-                }));
-            }
-        }
-        
-        function showError(errorDiv, message) {
-            errorDiv.textContent = message;
-            errorDiv.style.display = 'block';
-        }
-        
-        function hideError(errorDiv) {
-            errorDiv.style.display = 'none';
-        }
-        
-        // Handle player-specific WebSocket messages
-        function handlePlayerMessage(data) {
-            switch (data.type) {
-                case 'player_joined':
-                    playerID = data.player_id;
-                    document.getElementById('player-name').textContent = data.name;
-                    document.getElementById('join-form').style.display = 'none';
-                    document.getElementById('player-controls').style.display = 'block';
-                    console.log('Player joined:', data.message);
-                    break;
-                    
-                case 'species_created':
-                    playerSpecies.push(data.species_name);
-                    updatePlayerSpeciesCount();
-                    hideCreateSpeciesForm();
-                    console.log('Species created:', data.message);
-                    break;
-                    
-                case 'command_executed':
-                    console.log('Command executed:', data.message);
-                    break;
-                    
-                case 'species_extinct':
-                    // Remove from player species list
-                    const extinctIndex = playerSpecies.indexOf(data.species_name);
-                    if (extinctIndex > -1) {
-                        playerSpecies.splice(extinctIndex, 1);
-                        updatePlayerSpeciesCount();
-                    }
-                    
-                    // Show extinction notification
-                    alert('⚰️ Species Extinction\n\n' + data.message);
-                    console.log('Species extinct:', data.message);
-                    break;
-                    
-                case 'subspecies_formed':
-                    // Add new subspecies to player species list
-                    playerSpecies.push(data.species_name);
-                    updatePlayerSpeciesCount();
-                    
-                    // Show subspecies notification
-                    alert('🧬 Species Split!\n\n' + data.message);
-                    console.log('Subspecies formed:', data.message);
-                    break;
-                    
-                case 'new_species_detected':
-                    // Just log this for now - informational only
-                    console.log('New species detected:', data.message);
-                    break;
-                    
-                case 'error':
-                    console.error('Server error:', data.message);
-                    alert('Error: ' + data.message);
-                    break;
-            }
-        }
-        
-        function updatePlayerSpeciesCount() {
-            document.getElementById('player-species-count').textContent = playerSpecies.length + ' species';
-        }
-        
-        // Add grid click handling for movement
-        function handleGridClick(event) {
-            if (document.getElementById('control-species-form').style.display === 'block') {
-                const gridContainer = document.getElementById('grid-view');
-                const rect = gridContainer.getBoundingClientRect();
-                const x = event.clientX - rect.left;
-                const y = event.clientY - rect.top;
-                
-                // Convert pixel coordinates to world coordinates (simplified)
-                const worldX = (x / rect.width) * 100; // Assuming world width is 100
-                const worldY = (y / rect.height) * 100; // Assuming world height is 100
-                
-                moveTarget = { x: worldX, y: worldY };
-                document.getElementById('move-target').textContent = '(' + worldX.toFixed(1) + ', ' + worldY.toFixed(1) + ')';
-            }
-        }
-        
-        // Render tools view
-        function renderTools(tools) {
-            let html = '<h3>🔧 Tool System</h3>';
-            html += '<div>Total Tools: ' + tools.total_tools + '</div>';
-            html += '<div>Owned Tools: ' + tools.owned_tools + '</div>';
-            html += '<div>Dropped Tools: ' + tools.dropped_tools + '</div>';
-            html += '<div>Average Durability: ' + tools.avg_durability.toFixed(2) + '</div>';
-            html += '<div>Average Efficiency: ' + tools.avg_efficiency.toFixed(2) + '</div>';
-            
-            if (tools.tool_types && Object.keys(tools.tool_types).length > 0) {
-                html += '<h4>Tool Types:</h4>';
-                Object.entries(tools.tool_types).forEach(([type, count]) => {
-                    html += '<div>' + type + ': ' + count + '</div>';
-                });
-            } else {
-                html += '<br><div>No tools created yet</div>';
-            }
-            
-            html += '<br><h4>Tool Usage:</h4>';
-            if (tools.owned_tools === 0) {
-                html += '<div>Usage Level: No tool use</div>';
-            } else if (tools.owned_tools < 5) {
-                html += '<div>Usage Level: Basic tool use</div>';
-            } else {
-                html += '<div>Usage Level: Advanced tool use</div>';
-            }
-            
-            return html;
-        }
-        
-        // Render environment view
-        function renderEnvironment(envMod, envPressures) {
-            let html = '<h3>🌍 Environmental Systems</h3>';
-            
-            // Environmental Pressures Section
-            html += '<h4>🌡️ Environmental Pressures</h4>';
-            if (envPressures) {
-                html += '<div>Active Pressures: ' + envPressures.active_pressures + '</div>';
-                html += '<div>Historical Events: ' + envPressures.total_history + '</div>';
-                html += '<div>Average Severity: ' + envPressures.average_severity.toFixed(3) + '</div>';
-                
-                if (envPressures.pressure_types && Object.keys(envPressures.pressure_types).length > 0) {
-                    html += '<br><strong>Active Pressure Types:</strong><br>';
-                    Object.entries(envPressures.pressure_types).forEach(([type, count]) => {
-                        html += '<div>• ' + type + ': ' + count + '</div>';
-                    });
-                } else {
-                    html += '<br><div>No active environmental pressures</div>';
-                }
-                
-                if (envPressures.active_details && envPressures.active_details.length > 0) {
-                    html += '<br><strong>Active Pressure Details:</strong><br>';
-                    envPressures.active_details.forEach((pressure, index) => {
-                        if (index >= 3) return; // Limit display
-                        const durationText = pressure.duration > 0 ? pressure.duration + ' ticks' : 'Permanent';
-                        html += '<div style="margin: 5px 0; padding: 5px; border-left: 3px solid #ff6b6b;">';
-                        html += '<strong>' + pressure.name + '</strong> (ID: ' + pressure.id + ')<br>';
-                        html += 'Type: ' + pressure.type + '<br>';
-                        html += 'Severity: ' + pressure.severity.toFixed(2) + '<br>';
-                        html += 'Duration: ' + durationText + '<br>';
-                        html += 'Area: (' + pressure.affected_x.toFixed(1) + ', ' + pressure.affected_y.toFixed(1) + ') radius ' + pressure.radius.toFixed(1);
-                        html += '</div>';
-                    });
-                    if (envPressures.active_details.length > 3) {
-                        html += '<div>... and ' + (envPressures.active_details.length - 3) + ' more pressures</div>';
-                    }
-                }
-            } else {
-                html += '<div>Environmental pressure system not initialized</div>';
-            }
-            
-            html += '<br>';
-            
-            // Environmental Modifications Section
-            html += '<h4>🏗️ Environmental Modifications</h4>';
-            html += '<div>Total Modifications: ' + envMod.total_modifications + '</div>';
-            html += '<div>Active Modifications: ' + envMod.active_modifications + '</div>';
-            html += '<div>Inactive Modifications: ' + envMod.inactive_modifications + '</div>';
-            html += '<div>Average Durability: ' + envMod.avg_durability.toFixed(2) + '</div>';
-            html += '<div>Tunnel Networks: ' + envMod.tunnel_networks + '</div>';
-            
-            if (envMod.modification_types && Object.keys(envMod.modification_types).length > 0) {
-                html += '<br><strong>Modification Types:</strong><br>';
-                Object.entries(envMod.modification_types).forEach(([type, count]) => {
-                    html += '<div>' + type + ': ' + count + '</div>';
-                });
-            } else {
-                html += '<br><div>No environmental modifications yet</div>';
-            }
-            
-            html += '<br><strong>Modification Activity:</strong><br>';
-            if (envMod.total_modifications === 0) {
-                html += '<div>Activity Level: No modifications</div>';
-            } else if (envMod.active_modifications < 3) {
-                html += '<div>Activity Level: Low modification activity</div>';
-            } else {
-                html += '<div>Activity Level: High modification activity</div>';
-            }
-            
-            return html;
-        }
-        
-        // Render behavior view
-        function renderBehavior(behavior) {
-            let html = '<h3>🧠 Emergent Behavior</h3>';
-            html += '<div>Total Entities: ' + behavior.total_entities + '</div>';
-            html += '<div>Discovered Behaviors: ' + behavior.discovered_behaviors + '</div>';
-            
-            if (behavior.behavior_spread && Object.keys(behavior.behavior_spread).length > 0) {
-                html += '<h4>Behavior Spread:</h4>';
-                Object.entries(behavior.behavior_spread).forEach(([behavior_name, count]) => {
-                    html += '<div>' + behavior_name.replace(/_/g, ' ') + ': ' + count + ' entities</div>';
-                });
-            }
-            
-            if (behavior.avg_proficiency && Object.keys(behavior.avg_proficiency).length > 0) {
-                html += '<h4>Average Proficiency:</h4>';
-                Object.entries(behavior.avg_proficiency).forEach(([behavior_name, proficiency]) => {
-                    html += '<div>' + behavior_name.replace(/_/g, ' ') + ': ' + proficiency.toFixed(2) + '</div>';
-                });
-            }
-            
-            if (behavior.discovered_behaviors === 0) {
-                html += '<br><div>No emergent behaviors discovered yet</div>';
-                html += '<div style="color: #888; font-style: italic;">Behaviors emerge naturally as entities explore and learn from their environment and each other.</div>';
-            } else {
-                html += '<br><h4>Behavior Development:</h4>';
-                if (behavior.discovered_behaviors < 3) {
-                    html += '<div>Development Level: Early behavior emergence</div>';
-                } else if (behavior.discovered_behaviors < 6) {
-                    html += '<div>Development Level: Moderate behavior complexity</div>';
-                } else {
-                    html += '<div>Development Level: Advanced behavioral evolution</div>';
-                }
-                
-                // Show list of behaviors that have been discovered by entities
-                if (behavior.behavior_spread && Object.keys(behavior.behavior_spread).length > 0) {
-                    html += '<h4>Active Behaviors:</h4>';
-                    const sortedBehaviors = Object.entries(behavior.behavior_spread)
-                        .sort((a, b) => b[1] - a[1]); // Sort by count descending
-                    sortedBehaviors.forEach(([behavior_name, count]) => {
-                        if (count > 0) {
-                            const proficiency = behavior.avg_proficiency[behavior_name] || 0;
-                            html += '<div>• <strong>' + behavior_name.replace(/_/g, ' ') + '</strong>: ' + count + ' entities (avg proficiency: ' + proficiency.toFixed(2) + ')</div>';
-                        }
-                    });
-                }
-            }
-            
-            return html;
-        }
-        
-        // Render reproduction view
-        function renderReproduction(reproduction) {
-            let html = '<h3>🥚 Reproduction & Life Cycle</h3>';
-            html += '<div>Active Eggs: ' + reproduction.active_eggs + '</div>';
-            html += '<div>Decaying Items: ' + reproduction.decaying_items + '</div>';
-            html += '<div>Pregnant Entities: ' + reproduction.pregnant_entities + '</div>';
-            html += '<div>Ready to Mate: ' + reproduction.ready_to_mate + '</div>';
-            html += '<div>Mating Season Entities: ' + reproduction.mating_season_entities + '</div>';
-            html += '<div>Migrating Entities: ' + reproduction.migrating_entities + '</div>';
-            html += '<div>Cross-Species Mating: ' + reproduction.cross_species_mating + '</div>';
-            html += '<div>Territories with Mating: ' + reproduction.territories_with_mating + '</div>';
-            html += '<div>Seasonal Mating Rate: ' + reproduction.seasonal_mating_rate.toFixed(2) + 'x</div>';
-            
-            if (reproduction.reproduction_modes && Object.keys(reproduction.reproduction_modes).length > 0) {
-                html += '<h4>Reproduction Modes:</h4>';
-                Object.entries(reproduction.reproduction_modes).forEach(([mode, count]) => {
-                    html += '<div>' + mode + ': ' + count + ' entities</div>';
-                });
-            }
-            
-            if (reproduction.mating_strategies && Object.keys(reproduction.mating_strategies).length > 0) {
-                html += '<h4>Mating Strategies:</h4>';
-                Object.entries(reproduction.mating_strategies).forEach(([strategy, count]) => {
-                    html += '<div>' + strategy + ': ' + count + ' entities</div>';
-                });
-            }
-            
-            html += '<br><h4>Reproduction Activity:</h4>';
-            if (reproduction.ready_to_mate === 0) {
-                html += '<div>Activity Level: No active mating</div>';
-            } else if (reproduction.ready_to_mate < 5) {
-                html += '<div>Activity Level: Low reproductive activity</div>';
-            } else {
-                html += '<div>Activity Level: High reproductive activity</div>';
-            }
-            
-            return html;
-        }
-        
-        // Render statistical analysis view
-        function renderStatistical(statistical) {
-            if (!statistical) {
-                return '<h3>📊 Statistical Analysis</h3><div>Statistical analysis not available</div>';
-            }
-            
-            let html = '<h3>📊 Statistical Analysis</h3>';
-            
-            // Summary statistics
-            html += '<h4>Summary Statistics:</h4>';
-            html += '<div>Total Events: ' + statistical.total_events + '</div>';
-            html += '<div>Total Snapshots: ' + statistical.total_snapshots + '</div>';
-            html += '<div>Total Anomalies: ' + statistical.total_anomalies + '</div>';
-            if (statistical.total_energy !== undefined) {
-                html += '<div>Total Energy: ' + statistical.total_energy.toFixed(2) + '</div>';
-            }
-            if (statistical.energy_change !== undefined) {
-                const changePercent = (statistical.energy_change * 100).toFixed(1);
-                html += '<div>Energy Change: ' + changePercent + '% from baseline</div>';
-            }
-            
-            // Trends
-            html += '<h4>Trends:</h4>';
-            html += '<div>Energy Trend: ' + (statistical.energy_trend || 'unknown') + '</div>';
-            html += '<div>Population Trend: ' + (statistical.population_trend || 'unknown') + '</div>';
-            
-            // Recent events
-            if (statistical.recent_events && statistical.recent_events.length > 0) {
-                html += '<h4>Recent Events (' + statistical.recent_events.length + '):</h4>';
-                html += '<div style="max-height: 200px; overflow-y: auto;">';
-                statistical.recent_events.slice(0, 10).forEach(event => {
-                    html += '<div style="margin: 5px 0; padding: 5px; background-color: #2a2a2a; border-radius: 3px;">';
-                    html += '<strong>T' + event.tick + '</strong> ';
-                    html += event.type + ' (' + event.target + ')';
-                    if (event.change !== 0) {
-                        html += ': ' + event.change.toFixed(4);
-                    }
-                    if (event.description) {
-                        html += '<br><small>' + event.description + '</small>';
-                    }
-                    html += '</div>';
-                });
-                html += '</div>';
-            }
-            
-            // Latest snapshot
-            if (statistical.latest_snapshot) {
-                const snapshot = statistical.latest_snapshot;
-                html += '<h4>Latest Snapshot (T' + snapshot.tick + '):</h4>';
-                html += '<div>Total Energy: ' + snapshot.total_energy.toFixed(2) + '</div>';
-                html += '<div>Population Count: ' + snapshot.population_count + '</div>';
-                
-                if (snapshot.trait_averages && Object.keys(snapshot.trait_averages).length > 0) {
-                    html += '<h5>Trait Averages:</h5>';
-                    Object.entries(snapshot.trait_averages).forEach(([trait, avg]) => {
-                        html += '<div>' + trait + ': ' + avg.toFixed(3) + '</div>';
-                    });
-                }
-            }
-            
-            return html;
-        }
-        
-        // Render ecosystem metrics view
-        function renderEcosystem(ecosystem) {
-            if (!ecosystem) {
-                return '<h3>🌍 Ecosystem Metrics</h3><div>Ecosystem monitoring not available</div>';
-            }
-            
-            let html = '<h3>🌍 Ecosystem Metrics</h3>';
-            
-            // Diversity metrics
-            html += '<h4>Diversity Metrics:</h4>';
-            html += '<div>Shannon Diversity Index: ' + (ecosystem.shannon_diversity || 0).toFixed(4) + '</div>';
-            html += '<div>Simpson Diversity Index: ' + (ecosystem.simpson_diversity || 0).toFixed(4) + '</div>';
-            html += '<div>Species Richness: ' + (ecosystem.species_richness || 0) + '</div>';
-            html += '<div>Species Evenness: ' + (ecosystem.species_evenness || 0).toFixed(4) + '</div>';
-            
-            // Population metrics
-            html += '<h4>Population Metrics:</h4>';
-            html += '<div>Total Population: ' + (ecosystem.total_population || 0) + '</div>';
-            if (ecosystem.extinction_rate !== undefined) {
-                html += '<div>Extinction Rate: ' + (ecosystem.extinction_rate * 100).toFixed(2) + '%</div>';
-            }
-            if (ecosystem.speciation_rate !== undefined) {
-                html += '<div>Speciation Rate: ' + (ecosystem.speciation_rate * 100).toFixed(2) + '%</div>';
-            }
-            
-            // Network connectivity
-            html += '<h4>Network & Interaction Metrics:</h4>';
-            html += '<div>Network Connectivity: ' + (ecosystem.network_connectivity || 0).toFixed(4) + '</div>';
-            html += '<div>Average Path Length: ' + (ecosystem.average_path_length || 0).toFixed(2) + '</div>';
-            html += '<div>Clustering Coefficient: ' + (ecosystem.clustering_coefficient || 0).toFixed(4) + '</div>';
-            
-            // Pollination metrics
-            html += '<h4>Pollination Metrics:</h4>';
-            html += '<div>Pollination Success Rate: ' + ((ecosystem.pollination_success || 0) * 100).toFixed(2) + '%</div>';
-            html += '<div>Cross-Species Pollination: ' + ((ecosystem.cross_species_pollination || 0) * 100).toFixed(2) + '%</div>';
-            
-            // Dispersal metrics
-            html += '<h4>Dispersal Metrics:</h4>';
-            html += '<div>Average Dispersal Distance: ' + (ecosystem.average_dispersal_distance || 0).toFixed(2) + '</div>';
-            html += '<div>Seed Bank Size: ' + (ecosystem.seed_bank_size || 0) + '</div>';
-            html += '<div>Germination Rate: ' + ((ecosystem.germination_rate || 0) * 100).toFixed(2) + '%</div>';
-            
-            // Ecosystem health
-            html += '<h4>Ecosystem Health:</h4>';
-            const healthScore = ecosystem.ecosystem_stability !== undefined 
-                ? (ecosystem.biodiversity_index || 0) * 10 + (ecosystem.ecosystem_stability || 0) * 50 + (ecosystem.network_connectivity || 0) * 30
-                : 0;
-            html += '<div>Overall Health Score: ' + Math.min(healthScore, 100).toFixed(1) + '/100</div>';
-            html += '<div>Biodiversity Index: ' + (ecosystem.biodiversity_index || 0).toFixed(4) + '</div>';
-            html += '<div>Ecosystem Stability: ' + (ecosystem.ecosystem_stability || 0).toFixed(4) + '</div>';
-            html += '<div>Ecosystem Resilience: ' + (ecosystem.ecosystem_resilience || 0).toFixed(4) + '</div>';
-            
-            // Population by species
-            if (ecosystem.population_by_species && Object.keys(ecosystem.population_by_species).length > 0) {
-                html += '<h4>Population by Species:</h4>';
-                html += '<div style="max-height: 200px; overflow-y: auto;">';
-                const sortedSpecies = Object.entries(ecosystem.population_by_species)
-                    .sort(([,a], [,b]) => b - a)
-                    .slice(0, 10); // Top 10 species
-                sortedSpecies.forEach(([species, count]) => {
-                    html += '<div style="margin: 2px 0;">' + species + ': ' + count + '</div>';
-                });
-                html += '</div>';
-            }
-            
-            // Pollinator efficiency
-            if (ecosystem.pollinator_efficiency && Object.keys(ecosystem.pollinator_efficiency).length > 0) {
-                html += '<h4>Pollinator Efficiency:</h4>';
-                Object.entries(ecosystem.pollinator_efficiency).forEach(([type, efficiency]) => {
-                    html += '<div>' + type + ': ' + (efficiency * 100).toFixed(1) + '%</div>';
-                });
-            }
-            
-            // Dispersal methods
-            if (ecosystem.dispersal_methods && Object.keys(ecosystem.dispersal_methods).length > 0) {
-                html += '<h4>Dispersal Methods:</h4>';
-                Object.entries(ecosystem.dispersal_methods).forEach(([method, count]) => {
-                    html += '<div>' + method + ': ' + count + '</div>';
-                });
-            }
-            
-            return html;
-        }
-        
-        // Render anomalies detection view
-        function renderAnomalies(anomalies) {
-            if (!anomalies) {
-                return '<h3>⚠️ Anomaly Detection</h3><div>Anomaly detection not available</div>';
-            }
-            
-            let html = '<h3>⚠️ Anomaly Detection & Historical Analysis</h3>';
-            
-            if (anomalies.total_anomalies === 0) {
-                html += '<div style="color: #4CAF50;">✅ No anomalies detected!</div>';
-                html += '<div>The simulation appears to be running within expected parameters.</div>';
-                html += '<div style="margin-top: 10px; color: #888; font-style: italic;">Anomaly detection monitors for unusual patterns in energy conservation, population dynamics, trait distributions, and system behaviors.</div>';
-            } else {
-                html += '<div>Found ' + anomalies.total_anomalies + ' anomalies in simulation history:</div><br>';
-                
-                // Anomaly types summary with enhanced information
-                if (anomalies.anomaly_types && Object.keys(anomalies.anomaly_types).length > 0) {
-                    html += '<h4>📊 Anomaly Categories:</h4>';
-                    Object.entries(anomalies.anomaly_types).forEach(([type, count]) => {
-                        let severity = '';
-                        let color = '#ffcc00';
-                        if (count > 10) {
-                            severity = ' - High frequency';
-                            color = '#ff6666';
-                        } else if (count > 5) {
-                            severity = ' - Moderate frequency';
-                            color = '#ffaa00';
-                        } else {
-                            severity = ' - Low frequency';
-                            color = '#88cc88';
-                        }
-                        html += '<div style="color: ' + color + ';">• ' + type.replace(/_/g, ' ').toUpperCase() + ': ' + count + ' occurrences' + severity + '</div>';
-                    });
-                    html += '<br>';
-                }
-                
-                // Recent anomalies with enhanced display
-                if (anomalies.recent_anomalies && anomalies.recent_anomalies.length > 0) {
-                    html += '<h4>📋 Recent Anomaly History (Last ' + anomalies.recent_anomalies.length + ' events):</h4>';
-                    html += '<div style="max-height: 300px; overflow-y: auto; border: 1px solid #444; padding: 10px; border-radius: 5px;">';
-                    
-                    // Sort anomalies by tick (newest first)
-                    const sortedAnomalies = [...anomalies.recent_anomalies].sort((a, b) => b.tick - a.tick);
-                    
-                    sortedAnomalies.forEach(anomaly => {
-                        let severityColor = '#ffcc00';
-                        let severityIcon = '⚠️';
-                        let confidenceIcon = '🔍';
-                        
-                        if (anomaly.severity >= 0.8) {
-                            severityColor = '#ff4444';
-                            severityIcon = '🚨';
-                        } else if (anomaly.severity >= 0.6) {
-                            severityColor = '#ff8800';
-                            severityIcon = '⚠️';
-                        } else if (anomaly.severity >= 0.4) {
-                            severityColor = '#ffcc00';
-                            severityIcon = '⚡';
-                        } else {
-                            severityColor = '#88cc88';
-                            severityIcon = 'ℹ️';
-                        }
-                        
-                        if (anomaly.confidence >= 0.8) {
-                            confidenceIcon = '🎯';
-                        } else if (anomaly.confidence >= 0.6) {
-                            confidenceIcon = '🔍';
-                        } else {
-                            confidenceIcon = '❓';
-                        }
-                        
-                        html += '<div style="margin: 8px 0; padding: 8px; background-color: rgba(68, 68, 68, 0.3); border-radius: 3px; border-left: 3px solid ' + severityColor + ';">';
-                        html += '<div style="display: flex; justify-content: space-between; align-items: center;">';
-                        html += '<strong>' + severityIcon + ' ' + anomaly.type.replace(/_/g, ' ').toUpperCase() + '</strong>';
-                        html += '<span style="color: #aaa; font-size: 0.9em;">Tick ' + anomaly.tick + '</span>';
-                        html += '</div>';
-                        html += '<div style="margin: 5px 0;">' + anomaly.description + '</div>';
-                        html += '<div style="display: flex; gap: 15px; font-size: 0.9em; color: #ccc;">';
-                        html += '<span>Severity: ' + severityIcon + ' ' + (anomaly.severity * 100).toFixed(0) + '%</span>';
-                        html += '<span>Confidence: ' + confidenceIcon + ' ' + (anomaly.confidence * 100).toFixed(0) + '%</span>';
-                        html += '</div>';
-                        html += '</div>';
-                    });
-                    html += '</div>';
-                }
-                
-                // Recommendations with enhanced formatting
-                if (anomalies.recommendations && anomalies.recommendations.length > 0) {
-                    html += '<h4>💡 Diagnostic Recommendations:</h4>';
-                    html += '<div style="background-color: rgba(76, 175, 80, 0.1); border-left: 3px solid #4CAF50; padding: 10px; border-radius: 3px;">';
-                    anomalies.recommendations.forEach(rec => {
-                        html += '<div style="margin: 5px 0;">💡 ' + rec + '</div>';
-                    });
-                    html += '</div>';
-                } else if (anomalies.total_anomalies > 0) {
-                    html += '<h4>💡 General Recommendations:</h4>';
-                    html += '<div style="background-color: rgba(255, 193, 7, 0.1); border-left: 3px solid #ffc107; padding: 10px; border-radius: 3px;">';
-                    html += '<div>• Monitor system parameters for patterns</div>';
-                    html += '<div>• Check if anomalies correlate with specific events</div>';
-                    html += '<div>• Consider adjusting simulation parameters if anomalies persist</div>';
-                    html += '</div>';
-                }
-            }
-            
-            return html;
-        }
-        
-        // Render warfare view
-        function renderWarfare(warfare) {
-            if (!warfare) {
-                return '<h3>⚔️ Warfare & Diplomacy</h3><div>Warfare data not available</div>';
-            }
-            
-            let html = '<h3>⚔️ Multi-Colony Warfare & Diplomacy</h3>';
-            
-            // Colony overview
-            if (warfare.colonies && warfare.colonies.length > 0) {
-                html += '<h4>🏰 Active Colonies (' + warfare.colonies.length + '):</h4>';
-                html += '<div class="colony-list">';
-                warfare.colonies.forEach(colony => {
-                    const relationColor = getRelationColor(colony.dominant_relation);
-                    html += '<div class="colony-item">';
-                    html += '<strong>' + colony.name + '</strong> ';
-                    html += '<span style="color: ' + relationColor + '">(' + colony.dominant_relation + ')</span><br>';
-                    html += 'Size: ' + colony.size + ' | Territory: ' + colony.territory_size + ' cells<br>';
-                    html += 'Military: ' + colony.military_strength.toFixed(1) + ' | Resources: ' + colony.total_resources + '<br>';
-                    if (colony.recent_activity) {
-                        html += '<small style="color: #888;">Recent: ' + colony.recent_activity + '</small>';
-                    }
-                    html += '</div>';
-                });
-                html += '</div>';
-            }
-            
-            // Active conflicts
-            if (warfare.active_conflicts && warfare.active_conflicts.length > 0) {
-                html += '<h4>⚔️ Active Conflicts (' + warfare.active_conflicts.length + '):</h4>';
-                warfare.active_conflicts.forEach(conflict => {
-                    const intensityColor = getConflictIntensityColor(conflict.intensity);
-                    html += '<div class="conflict-item">';
-                    html += '<strong style="color: ' + intensityColor + '">' + conflict.type + '</strong><br>';
-                    html += conflict.attacker + ' vs ' + conflict.defender + '<br>';
-                    html += 'Duration: ' + conflict.duration + ' ticks | Intensity: ' + conflict.intensity.toFixed(2) + '<br>';
-                    html += 'Casualties: ' + conflict.casualties + ' | Status: ' + conflict.status + '<br>';
-                    if (conflict.cause) {
-                        html += '<small>Cause: ' + conflict.cause + '</small>';
-                    }
-                    html += '</div>';
-                });
-            } else {
-                html += '<h4>⚔️ Active Conflicts: None</h4>';
-                html += '<div style="color: #4CAF50;">🕊️ All colonies are currently at peace</div>';
-            }
-            
-            // Diplomatic relations summary
-            if (warfare.diplomatic_summary) {
-                html += '<h4>🤝 Diplomatic Relations Summary:</h4>';
-                Object.entries(warfare.diplomatic_summary).forEach(([relation, count]) => {
-                    const relationColor = getRelationColor(relation);
-                    html += '<div style="color: ' + relationColor + ';">' + relation + ': ' + count + '</div>';
-                });
-            }
-            
-            // Trade activity
-            if (warfare.trade_activity) {
-                html += '<h4>💰 Trade Activity:</h4>';
-                html += '<div>Active Agreements: ' + warfare.trade_activity.active_agreements + '</div>';
-                html += '<div>Trade Volume: ' + warfare.trade_activity.total_volume + '</div>';
-                html += '<div>Trade Efficiency: ' + (warfare.trade_activity.efficiency * 100).toFixed(1) + '%</div>';
-            }
-            
-            // Recent warfare events
-            if (warfare.recent_events && warfare.recent_events.length > 0) {
-                html += '<h4>📰 Recent Warfare Events:</h4>';
-                html += '<div class="events-list">';
-                warfare.recent_events.slice(0, 10).forEach(event => {
-                    html += '<div class="event-item">';
-                    html += '<small>[Tick ' + event.tick + ']</small> ';
-                    html += event.description;
-                    html += '</div>';
-                });
-                html += '</div>';
-            }
-            
-            // Statistics
-            if (warfare.statistics) {
-                html += '<h4>📊 Warfare Statistics:</h4>';
-                html += '<div>Total Conflicts: ' + warfare.statistics.total_conflicts + '</div>';
-                html += '<div>Total Casualties: ' + warfare.statistics.total_casualties + '</div>';
-                html += '<div>Peace Treaties: ' + warfare.statistics.peace_treaties + '</div>';
-                html += '<div>Alliance Formations: ' + warfare.statistics.alliance_formations + '</div>';
-            }
-            
-            return html;
-        }
-        
-        function getRelationColor(relation) {
-            const colors = {
-                'Allied': '#4CAF50',
-                'Enemy': '#F44336', 
-                'Neutral': '#FFC107',
-                'Trading': '#2196F3',
-                'Truce': '#FF9800',
-                'Vassal': '#9C27B0'
-            };
-            return colors[relation] || '#888';
-        }
-        
-        function getConflictIntensityColor(intensity) {
-            if (intensity < 0.3) return '#FFC107'; // Low intensity - yellow
-            if (intensity < 0.7) return '#FF9800'; // Medium intensity - orange  
-            return '#F44336'; // High intensity - red
-        }
-        
-        function renderFungal(fungal) {
-            if (!fungal) {
-                return '<h3>🍄 Fungal Networks</h3><div>Fungal system data not available</div>';
-            }
-            
-            let html = '<h3>🍄 Fungal Networks & Decomposer System</h3>';
-            
-            // Decomposer overview
-            if (fungal.total_decomposers !== undefined) {
-                html += '<h4>🧪 Decomposer Status:</h4>';
-                html += '<div class="stats-row">';
-                html += '<div class="stat-item">Total Decomposers: <strong>' + (fungal.total_decomposers || 0) + '</strong></div>';
-                html += '<div class="stat-item">Active Decomposers: <strong>' + (fungal.active_decomposers || 0) + '</strong></div>';
-                html += '</div>';
-            }
-            
-            // Nutrient cycling statistics
-            if (fungal.nutrient_cycling) {
-                html += '<h4>♻️ Nutrient Cycling:</h4>';
-                html += '<div class="stats-row">';
-                html += '<div class="stat-item">Decomposition Rate: <strong>' + (fungal.nutrient_cycling.decomposition_rate || 0).toFixed(2) + '/tick</strong></div>';
-                html += '<div class="stat-item">Nutrients Released: <strong>' + (fungal.nutrient_cycling.nutrients_released || 0).toFixed(1) + '</strong></div>';
-                html += '</div>';
-                
-                if (fungal.nutrient_cycling.nutrient_types) {
-                    html += '<div class="nutrient-breakdown">';
-                    Object.entries(fungal.nutrient_cycling.nutrient_types).forEach(([nutrient, amount]) => {
-                        html += '<div class="nutrient-item">' + nutrient + ': ' + amount.toFixed(1) + '</div>';
-                    });
-                    html += '</div>';
-                }
-            }
-            
-            // Spore networks
-            if (fungal.spore_networks) {
-                html += '<h4>🌐 Spore Networks:</h4>';
-                html += '<div class="stats-row">';
-                html += '<div class="stat-item">Network Connections: <strong>' + (fungal.spore_networks.connections || 0) + '</strong></div>';
-                html += '<div class="stat-item">Network Efficiency: <strong>' + ((fungal.spore_networks.efficiency || 0) * 100).toFixed(1) + '%</strong></div>';
-                html += '</div>';
-            }
-            
-            // Fungal reproduction
-            if (fungal.reproduction) {
-                html += '<h4>🌱 Fungal Reproduction:</h4>';
-                html += '<div class="stats-row">';
-                html += '<div class="stat-item">Spores Released: <strong>' + (fungal.reproduction.spores_released || 0) + '</strong></div>';
-                html += '<div class="stat-item">Successful Germinations: <strong>' + (fungal.reproduction.germinations || 0) + '</strong></div>';
-                html += '</div>';
-            }
-            
-            // Recent events
-            if (fungal.recent_events && fungal.recent_events.length > 0) {
-                html += '<h4>📋 Recent Fungal Activity:</h4>';
-                html += '<div class="event-list">';
-                fungal.recent_events.slice(0, 10).forEach(event => {
-                    html += '<div class="event-item">• ' + event + '</div>';
-                });
-                html += '</div>';
-            }
-            
-            return html;
-        }
-        
-        // Render cultural knowledge view
-        function renderCultural(cultural) {
-            if (!cultural) {
-                return '<h3>🧠 Cultural Knowledge</h3><div>Cultural knowledge system data not available</div>';
-            }
-            
-            let html = '<h3>🧠 Cultural Knowledge & Learning System</h3>';
-            
-            // General overview
-            html += '<h4>📊 Knowledge Overview:</h4>';
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item">Total Knowledge Types: <strong>' + (cultural.total_knowledge_types || 0) + '</strong></div>';
-            html += '<div class="stat-item">Entities with Knowledge: <strong>' + (cultural.total_entities || 0) + '</strong></div>';
-            html += '</div>';
-            
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item">Active Innovations: <strong>' + (cultural.active_innovations || 0) + '</strong></div>';
-            html += '<div class="stat-item">Avg Knowledge/Entity: <strong>' + (cultural.avg_knowledge_per_entity || 0).toFixed(1) + '</strong></div>';
-            html += '</div>';
-            
-            // Learning activity
-            html += '<h4>🎓 Learning & Teaching Activity:</h4>';
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item">Teaching Events: <strong>' + (cultural.total_teaching_events || 0) + '</strong></div>';
-            html += '<div class="stat-item">Learning Events: <strong>' + (cultural.total_learning_events || 0) + '</strong></div>';
-            html += '</div>';
-            
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item">Innovations Created: <strong>' + (cultural.total_innovations_created || 0) + '</strong></div>';
-            html += '<div class="stat-item">Knowledge Lost: <strong>' + (cultural.knowledge_loss_events || 0) + '</strong></div>';
-            html += '</div>';
-            
-            // Knowledge type distribution
-            if (cultural.knowledge_type_distribution) {
-                html += '<h4>📚 Knowledge Types:</h4>';
-                html += '<div class="knowledge-distribution">';
-                Object.entries(cultural.knowledge_type_distribution).forEach(([type, count]) => {
-                    const barWidth = Math.max(5, (count / Math.max(...Object.values(cultural.knowledge_type_distribution))) * 200);
-                    html += '<div class="knowledge-type-item">';
-                    html += '<div class="knowledge-type-label">' + type + ': ' + count + '</div>';
-                    html += '<div class="knowledge-bar" style="width: ' + barWidth + 'px; background: #4CAF50; height: 20px; margin: 2px 0;"></div>';
-                    html += '</div>';
-                });
-                html += '</div>';
-            }
-            
-            return html;
-        }
-        
-        // Render symbiotic relationships view
-        function renderSymbiotic(symbiotic) {
-            if (!symbiotic) {
-                return '<h3>🦠 Symbiotic Relationships</h3><div>Symbiotic relationships system data not available</div>';
-            }
-            
-            let html = '<h3>🦠 Symbiotic Relationships</h3>';
-            
-            // Basic statistics
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item">Total Relationships: <strong>' + (symbiotic.total_relationships || 0) + '</strong></div>';
-            html += '<div class="stat-item">Active Relationships: <strong>' + (symbiotic.active_relationships || 0) + '</strong></div>';
-            html += '</div>';
-            
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item">Average Age: <strong>' + (symbiotic.average_relationship_age || 0).toFixed(1) + ' ticks</strong></div>';
-            html += '<div class="stat-item">Disease Rate: <strong>' + (symbiotic.disease_transmission_rate || 0).toFixed(3) + '</strong></div>';
-            html += '</div>';
-            
-            // Relationship types
-            html += '<h4>🔬 Relationship Types:</h4>';
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item">🦠 Parasitic: <strong>' + (symbiotic.active_parasitic || 0) + '</strong></div>';
-            html += '<div class="stat-item">🤝 Mutualistic: <strong>' + (symbiotic.active_mutualistic || 0) + '</strong></div>';
-            html += '<div class="stat-item">🐠 Commensal: <strong>' + (symbiotic.active_commensal || 0) + '</strong></div>';
-            html += '</div>';
-            
-            // Disease characteristics (if parasitic relationships exist)
-            if (symbiotic.active_parasitic > 0) {
-                html += '<h4>🦠 Disease Characteristics:</h4>';
-                html += '<div class="stats-row">';
-                html += '<div class="stat-item">Average Virulence: <strong>' + (symbiotic.average_virulence || 0).toFixed(3) + '</strong></div>';
-                html += '<div class="stat-item">Average Transmission: <strong>' + (symbiotic.average_transmission || 0).toFixed(3) + '</strong></div>';
-                html += '</div>';
-            }
-            
-            // Relationship type distribution visualization
-            if (symbiotic.relationship_types && Object.keys(symbiotic.relationship_types).length > 0) {
-                html += '<h4>📊 Distribution:</h4>';
-                html += '<div class="relationship-distribution">';
-                
-                const total = Object.values(symbiotic.relationship_types).reduce((sum, count) => sum + count, 0);
-                const colors = {
-                    'parasitic': '#ff6b6b',
-                    'mutualistic': '#4ecdc4',
-                    'commensal': '#45b7d1'
-                };
-                
-                Object.entries(symbiotic.relationship_types).forEach(([type, count]) => {
-                    if (count > 0) {
-                        const percentage = total > 0 ? ((count / total) * 100).toFixed(1) : 0;
-                        const barWidth = total > 0 ? Math.max(5, (count / total) * 200) : 5;
-                        const color = colors[type] || '#888';
-                        
-                        html += '<div class="relationship-type-item">';
-                        html += '<div class="relationship-type-label">' + type + ': ' + count + ' (' + percentage + '%)</div>';
-                        html += '<div class="relationship-bar" style="width: ' + barWidth + 'px; background: ' + color + '; height: 20px; margin: 2px 0;"></div>';
-                        html += '</div>';
-                    }
-                });
-                html += '</div>';
-            }
-            
-            return html;
-        }
-        
-        // BioRhythm rendering function
-        function renderBiorhythm(biorhythm) {
-            if (!biorhythm) {
-                return '<h3>🕒 BioRhythm System</h3><div>BioRhythm system data not available</div>';
-            }
-            
-            let html = '<h3>🕒 BioRhythm & Activity Patterns</h3>';
-            
-            // Activity distribution
-            html += '<h4>📊 Activity Distribution:</h4>';
-            if (biorhythm.activity_distribution) {
-                html += '<div class="stats-row">';
-                const activities = ['Sleep', 'Eat', 'Drink', 'Play', 'Explore', 'Scavenge', 'Rest', 'Socialize'];
-                activities.forEach(activity => {
-                    const count = biorhythm.activity_distribution[activity.toLowerCase()] || 0;
-                    html += '<div class="stat-item tooltip">' + activity + ': <strong>' + count + '</strong><span class="tooltiptext">Number of entities currently engaged in ' + activity.toLowerCase() + ' activity. Activity selection is based on current needs and circadian preferences.</span></div>';
-                });
-                html += '</div>';
-            }
-            
-            // Circadian patterns
-            html += '<h4>🌙 Circadian Patterns:</h4>';
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item tooltip">Diurnal Entities: <strong>' + (biorhythm.diurnal_count || 0) + '</strong><span class="tooltiptext">Entities active during the day (based on circadian_preference trait). Includes most herbivores and some omnivores.</span></div>';
-            html += '<div class="stat-item tooltip">Nocturnal Entities: <strong>' + (biorhythm.nocturnal_count || 0) + '</strong><span class="tooltiptext">Entities active at night (based on circadian_preference trait). Includes most predators and some specialized species.</span></div>';
-            html += '</div>';
-            
-            // Need levels
-            html += '<h4>🎯 Average Need Levels:</h4>';
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item tooltip">Sleep Need: <strong>' + (biorhythm.avg_sleep_need || 0).toFixed(2) + '</strong><span class="tooltiptext">Average sleep requirement across all entities. Higher values during winter and for aging entities.</span></div>';
-            html += '<div class="stat-item tooltip">Hunger Need: <strong>' + (biorhythm.avg_hunger_need || 0).toFixed(2) + '</strong><span class="tooltiptext">Average hunger level. Entities only eat when hunger exceeds threshold, preventing random feeding behavior.</span></div>';
-            html += '</div>';
-            
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item tooltip">Thirst Need: <strong>' + (biorhythm.avg_thirst_need || 0).toFixed(2) + '</strong><span class="tooltiptext">Average thirst level. Entities seek water sources when thirst exceeds threshold, especially in hot seasons.</span></div>';
-            html += '<div class="stat-item tooltip">Play Drive: <strong>' + (biorhythm.avg_play_drive || 0).toFixed(2) + '</strong><span class="tooltiptext">Average play motivation. Higher intelligence entities show more play behavior, especially during favorable seasons.</span></div>';
-            html += '</div>';
-            
-            // Seasonal effects
-            if (biorhythm.seasonal_effects) {
-                html += '<h4>🍂 Seasonal BioRhythm Effects:</h4>';
-                html += '<div style="background-color: #3a3a3a; padding: 10px; border-radius: 5px; margin: 5px 0;">';
-                html += '<strong>Current Season Effects:</strong><br>';
-                html += '• Winter: Increased sleep needs, reduced exploration<br>';
-                html += '• Spring: Boosted play and exploration drives<br>';
-                html += '• Summer: Increased thirst, peak activity levels<br>';
-                html += '• Autumn: Resource gathering focus, preparation behaviors';
-                html += '</div>';
-            }
-            
-            // Sample entity biorhythm data
-            if (biorhythm.sample_entities && biorhythm.sample_entities.length > 0) {
-                html += '<h4>🔍 Sample Entity BioRhythms:</h4>';
-                html += '<div style="max-height: 200px; overflow-y: auto;">';
-                biorhythm.sample_entities.slice(0, 5).forEach(entity => {
-                    html += '<div style="background-color: #4a4a4a; padding: 8px; margin: 5px 0; border-radius: 3px;">';
-                    html += '<strong>Entity #' + entity.id + '</strong> (' + entity.species + ')<br>';
-                    html += '<small>';
-                    html += 'Current Activity: ' + (entity.current_activity || 'None') + '<br>';
-                    html += 'Circadian Type: ' + (entity.circadian_type || 'Unknown') + '<br>';
-                    html += 'Sleep Need: ' + (entity.sleep_need || 0).toFixed(2) + ' | ';
-                    html += 'Hunger: ' + (entity.hunger_need || 0).toFixed(2) + ' | ';
-                    html += 'Thirst: ' + (entity.thirst_need || 0).toFixed(2);
-                    html += '</small>';
-                    html += '</div>';
-                });
-                html += '</div>';
-            }
-            
-            return html;
-        }
-
-        // Neural Networks rendering function
-        function renderNeural(neural) {
-            if (!neural) {
-                return '<h3>🧠 Neural Networks</h3><div>Neural networks system data not available</div>';
-            }
-            
-            let html = '<h3>🧠 Neural Networks</h3>';
-            
-            // Basic neural statistics
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item tooltip">Total Networks: <strong>' + (neural.total_networks || 0) + '</strong><span class="tooltiptext">Total number of neural networks ever created. Networks are created automatically for entities with intelligence > 0.3.</span></div>';
-            html += '<div class="stat-item tooltip">Active Networks: <strong>' + (neural.active_network_count || 0) + '</strong><span class="tooltiptext">Currently active neural networks belonging to living entities. Networks disappear when entities die or lose intelligence.</span></div>';
-            html += '</div>';
-            
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item tooltip">Total Behaviors: <strong>' + (neural.total_behaviors || 0) + '</strong><span class="tooltiptext">Number of learned behavior patterns stored in neural networks.</span></div>';
-            html += '<div class="stat-item tooltip">Emergent Behaviors: <strong>' + (neural.emergent_behaviors || 0) + '</strong><span class="tooltiptext">Novel behaviors discovered through neural learning that weren\'t programmed.</span></div>';
-            html += '</div>';
-            
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item tooltip">Learning Events: <strong>' + (neural.total_learning_events || 0) + '</strong><span class="tooltiptext">Total number of learning updates performed. Each action outcome provides feedback to strengthen or weaken neural connections.</span></div>';
-            html += '<div class="stat-item tooltip">Collective Behaviors: <strong>' + (neural.collective_behavior_count || 0) + '</strong><span class="tooltiptext">Shared behaviors learned by multiple entities through social learning.</span></div>';
-            html += '</div>';
-            
-            // Learning and adaptation metrics
-            html += '<h4>🎯 Learning Metrics:</h4>';
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item tooltip">Avg Network Complexity: <strong>' + (neural.avg_network_complexity || 0).toFixed(3) + '</strong><span class="tooltiptext">Average number of neurons and connections per network. More intelligent entities have more complex networks.</span></div>';
-            html += '<div class="stat-item tooltip">Success Rate: <strong>' + (neural.success_rate * 100 || 0).toFixed(1) + '%</strong><span class="tooltiptext">Percentage of neural decisions that improved entity fitness (better food finding, threat avoidance, energy management).</span></div>';
-            html += '</div>';
-            
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item tooltip">Base Learning Rate: <strong>' + (neural.base_learning_rate || 0).toFixed(3) + '</strong><span class="tooltiptext">How quickly neural networks adapt their weights based on feedback. Higher values mean faster learning.</span></div>';
-            html += '<div class="stat-item tooltip">Adaptation Rate: <strong>' + (neural.adaptation_rate || 0).toFixed(3) + '</strong><span class="tooltiptext">Rate at which networks change their structure and behavior patterns over time.</span></div>';
-            html += '</div>';
-            
-            // Experience metrics
-            html += '<h4>📚 Experience:</h4>';
-            html += '<div class="stats-row">';
-            html += '<div class="stat-item tooltip">Total Experience: <strong>' + (neural.total_experience || 0).toFixed(1) + '</strong><span class="tooltiptext">Accumulated learning experience across all networks. Experience points are gained through successful actions and lost through failures.</span></div>';
-            html += '<div class="stat-item tooltip">Avg Experience per Network: <strong>' + (neural.avg_experience_per_network || 0).toFixed(1) + '</strong><span class="tooltiptext">Average experience level per neural network. More experienced networks make better decisions.</span></div>';
-            html += '</div>';
-            
-            // What happens when entities disappear explanation
-            html += '<h4>❓ Neural Network Lifecycle:</h4>';
-            html += '<div class="stats-row">';
-            html += '<div style="background-color: #3a3a3a; padding: 10px; border-radius: 5px; margin: 5px 0;">';
-            html += '<strong>Why do entities appear/disappear from this view?</strong><br>';
-            html += '• <strong>Appear:</strong> When entities gain intelligence > 0.3 and their first neural network is created<br>';
-            html += '• <strong>Disappear:</strong> When entities die (networks are deleted) or intelligence drops below 0.3<br>';
-            html += '• <strong>Learning:</strong> Networks never "finish" learning - they continuously adapt based on experience<br>';
-            html += '• <strong>Memory:</strong> Learned information is stored in synaptic weights and connection strengths<br>';
-            html += '• <strong>Inheritance:</strong> Neural capabilities pass to offspring through the intelligence genetic trait';
-            html += '</div>';
-            html += '</div>';
-            
-            // Successful strategies
-            if (neural.successful_strategies && neural.successful_strategies.length > 0) {
-                html += '<h4>🏆 Successful Strategies:</h4>';
-                html += '<div class="strategy-list">';
-                neural.successful_strategies.forEach((strategy, index) => {
-                    if (index < 10) { // Limit to top 10
-                        html += '<div class="strategy-item tooltip">• ' + strategy + '<span class="tooltiptext">A behavior pattern that has been successful across multiple entities and is being reinforced through learning.</span></div>';
-                    }
-                });
-                html += '</div>';
-            }
-            
-            // Sample entity networks (if available)
-            if (neural.entity_networks && Object.keys(neural.entity_networks).length > 0) {
-                html += '<h4>🔗 Entity Networks (Sample):</h4>';
-                html += '<div class="entity-networks">';
-                
-                let count = 0;
-                Object.entries(neural.entity_networks).forEach(([entityId, networkData]) => {
-                    if (count < 5 && networkData) { // Show first 5 networks
-                        html += '<div class="entity-network-item">';
-                        html += '<div class="entity-id tooltip">Entity #' + entityId + '<span class="tooltiptext">Individual entity with an active neural network. This entity has intelligence > 0.3 and is learning from its experiences.</span></div>';
-                        html += '<div class="network-type tooltip">Type: ' + (networkData.type || 'Unknown') + '<span class="tooltiptext">Neural network architecture type: FeedForward (basic), Recurrent (memory), Convolutional (pattern recognition), or Reinforcement (trial-and-error).</span></div>';
-                        html += '<div class="network-complexity tooltip">Complexity: ' + (networkData.complexity_score || 0).toFixed(3) + '<span class="tooltiptext">Network complexity based on number of neurons, connections, and layers. More intelligent entities have higher complexity.</span></div>';
-                        html += '<div class="network-experience tooltip">Experience: ' + (networkData.experience || 0).toFixed(1) + '<span class="tooltiptext">Total learning experience accumulated. Gained through successful actions, lost through failures. Higher experience means better decision-making.</span></div>';
-                        if (networkData.total_decisions && networkData.total_decisions > 0) {
-                            const successRate = ((networkData.correct_decisions || 0) / networkData.total_decisions * 100);
-                            html += '<div class="network-success tooltip">Success: ' + successRate.toFixed(1) + '% (' + (networkData.correct_decisions || 0) + '/' + networkData.total_decisions + ')<span class="tooltiptext">Percentage of decisions that improved fitness. Tracks how well this network has learned to make beneficial choices.</span></div>';
-                        }
-                        html += '</div>';
-                        count++;
-                    }
-                });
-                html += '</div>';
-            }
-            
-            return html;
-        }
-    </script>
-</body>
-</html>`
-
-	w.Header().Set("Content-Type", "text/html")
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(html))
-}
+	var req PetriDishUpdateRequest
+	switch r.Method {
+	case HTTPMethodGET:
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid petri dish update: %v", err), http.StatusBadRequest)
+			return
+		}
+		if req.Ticks < 0 || req.Ticks > maxPetriStepTicks {
+			http.Error(w, fmt.Sprintf("ticks must be between 0 and %d", maxPetriStepTicks), http.StatusBadRequest)
+			return
+		}
+	case http.MethodDelete:
+		if !wi.petriLab.Remove(id) {
+			http.Error(w, fmt.Sprintf("Petri dish %d not found", id), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
 
-// serveIsometric serves the isometric 2.5D view page
-func (wi *WebInterface) serveIsometric(w http.ResponseWriter, r *http.Request) {
-	// Read the isometric HTML file
-	htmlBytes, err := os.ReadFile("isometric_view.html")
+	var stats PetriDishStats
+	err = wi.petriLab.With(id, func(dish *PetriDish) {
+		if req.Temperature != nil {
+			dish.SetTemperature(*req.Temperature)
+		}
+		if req.FoodDensity != nil {
+			dish.SetFoodDensity(*req.FoodDensity)
+		}
+		dish.Step(req.Ticks)
+		stats = dish.Stats()
+	})
 	if err != nil {
-		http.Error(w, "Isometric view not available", http.StatusNotFound)
+		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
-	
-	w.Header().Set("Content-Type", "text/html")
-	_, _ = w.Write(htmlBytes)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(stats)
 }
 
-// handleStatus provides a simple status endpoint
-func (wi *WebInterface) handleStatus(w http.ResponseWriter, r *http.Request) {
-	status := map[string]interface{}{
-		"tick":        wi.world.Tick,
-		"entities":    len(wi.world.AllEntities),
-		"plants":      len(wi.world.AllPlants),
-		"populations": len(wi.world.Populations),
-		"status":      "running",
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(status)
+// OperatorStructureRequest describes a barrier or corridor drawn by an operator
+type OperatorStructureRequest struct {
+	Type          string      `json:"type"` // "barrier" or "corridor"
+	Kind          string      `json:"kind"` // e.g. "fence", "canal"
+	Cells         []GridPoint `json:"cells"`
+	From          *GridPoint  `json:"from,omitempty"` // Optional line start (grid coordinates)
+	To            *GridPoint  `json:"to,omitempty"`   // Optional line end (grid coordinates)
+	BaselineTicks int         `json:"baseline_ticks"` // Ticks of gene flow measured before a barrier activates
 }
 
-// handleExportEvents exports all events from the central event bus
-func (wi *WebInterface) handleExportEvents(w http.ResponseWriter, r *http.Request) {
-	if r.Method != HTTPMethodGET {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
+// buildOperatorStructure validates a structure request and builds it in the world
+func (wi *WebInterface) buildOperatorStructure(req OperatorStructureRequest) (*OperatorStructure, error) {
+	cells := req.Cells
+	if req.From != nil && req.To != nil {
+		cells = append(cells, RasterizeLine(req.From.X, req.From.Y, req.To.X, req.To.Y)...)
+	}
+	if req.Kind == "" {
+		req.Kind = req.Type
 	}
+	return wi.world.BuildOperatorStructure(req.Type, req.Kind, cells, req.BaselineTicks)
+}
 
-	// Get query parameters for filtering
-	eventType := r.URL.Query().Get("type")
-	category := r.URL.Query().Get("category")
-	format := r.URL.Query().Get("format")
-	if format == "" {
-		format = "json"
+// handleOperatorStructures lists, builds, and removes operator barriers and corridors
+func (wi *WebInterface) handleOperatorStructures(w http.ResponseWriter, r *http.Request) {
+	if wi.world.OperatorStructures == nil {
+		http.Error(w, "Operator structures not available", http.StatusServiceUnavailable)
+		return
 	}
 
-	var events []CentralEvent
+	switch r.Method {
+	case HTTPMethodGET:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"structures":  wi.world.OperatorStructures.Structures,
+			"experiments": wi.world.OperatorStructures.Experiments,
+			"stats":       wi.world.OperatorStructures.GetStats(),
+		})
+
+	case http.MethodPost:
+		var req OperatorStructureRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid structure request: %v", err), http.StatusBadRequest)
+			return
+		}
+		structure, err := wi.buildOperatorStructure(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(structure)
 
-	if wi.world.CentralEventBus != nil {
-		if eventType != "" {
-			events = wi.world.CentralEventBus.GetEventsByType(eventType)
-		} else if category != "" {
-			events = wi.world.CentralEventBus.GetEventsByCategory(category)
-		} else {
-			events = wi.world.CentralEventBus.GetAllEvents()
+	case http.MethodDelete:
+		var id int
+		if _, err := fmt.Sscanf(r.URL.Query().Get("id"), "%d", &id); err != nil {
+			http.Error(w, "Missing or invalid structure id", http.StatusBadRequest)
+			return
 		}
-	}
+		if _, err := wi.world.RemoveOperatorStructure(id); err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
 
-	exportData := map[string]interface{}{
-		"events":      events,
-		"total_count": len(events),
-		"export_time": time.Now(),
-		"filters": map[string]string{
-			"type":     eventType,
-			"category": category,
-			"format":   format,
-		},
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
+}
 
-	if format == "csv" {
-		wi.exportEventsAsCSV(w, events)
-	} else {
+// InterventionRequest asks to undo or redo an operator intervention
+type InterventionRequest struct {
+	Action string `json:"action"` // "undo" or "redo"
+}
+
+// handleInterventions lists the intervention history and undoes or redoes interventions
+func (wi *WebInterface) handleInterventions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+		var undo, redo []Intervention
+		if err := wi.withOperatorWorld(r, func(world *World) error {
+			undo, redo = world.Interventions.History()
+			return nil
+		}); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
 		w.Header().Set("Content-Type", "application/json")
-		w.Header().Set("Content-Disposition", "attachment; filename=events_export.json")
-		_ = json.NewEncoder(w).Encode(exportData)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"undo": undo,
+			"redo": redo,
+		})
+
+	case http.MethodPost:
+		var req InterventionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid intervention request: %v", err), http.StatusBadRequest)
+			return
+		}
+		if req.Action != "undo" && req.Action != "redo" {
+			http.Error(w, fmt.Sprintf("Unknown intervention action %q", req.Action), http.StatusBadRequest)
+			return
+		}
+		var intervention *Intervention
+		err := wi.withOperatorWorld(r, func(world *World) (err error) {
+			if req.Action == "undo" {
+				intervention, err = world.Interventions.Undo(world)
+			} else {
+				intervention, err = world.Interventions.Redo(world)
+			}
+			return err
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(intervention)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
 }
 
-// handleExportAnalysis exports statistical analysis data
-func (wi *WebInterface) handleExportAnalysis(w http.ResponseWriter, r *http.Request) {
-	if r.Method != HTTPMethodGET {
+// TerraformRequest describes grid cells to change to another biome
+type TerraformRequest struct {
+	Biome string      `json:"biome"` // Biome name, e.g. "forest" or "deep_water"
+	Cells []GridPoint `json:"cells"`
+	From  *GridPoint  `json:"from,omitempty"` // Optional line start (grid coordinates)
+	To    *GridPoint  `json:"to,omitempty"`   // Optional line end (grid coordinates)
+}
+
+// handleTerraform changes the biome of grid cells
+func (wi *WebInterface) handleTerraform(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	format := r.URL.Query().Get("format")
-	if format == "" {
-		format = "json"
+	var req TerraformRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid terraform request: %v", err), http.StatusBadRequest)
+		return
 	}
-
-	var analysisData map[string]interface{}
-
-	if wi.world.StatisticalReporter != nil {
-		analysisData = map[string]interface{}{
-			"summary_statistics": wi.world.StatisticalReporter.GetSummaryStatistics(),
-			"recent_events":      wi.world.StatisticalReporter.Events,
-			"snapshots":          wi.world.StatisticalReporter.Snapshots,
-			"export_time":        time.Now(),
-		}
-	} else {
-		analysisData = map[string]interface{}{
-			"error":       "Statistical reporter not available",
-			"export_time": time.Now(),
-		}
+	biome, ok := parseBiomeName(req.Biome)
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unknown biome %q", req.Biome), http.StatusBadRequest)
+		return
+	}
+	cells := req.Cells
+	if req.From != nil && req.To != nil {
+		cells = append(cells, RasterizeLine(req.From.X, req.From.Y, req.To.X, req.To.Y)...)
 	}
 
-	if format == "csv" {
-		wi.exportAnalysisAsCSV(w, analysisData)
-	} else {
-		w.Header().Set("Content-Type", "application/json")
-		w.Header().Set("Content-Disposition", "attachment; filename=analysis_export.json")
-		_ = json.NewEncoder(w).Encode(analysisData)
+	var intervention *Intervention
+	err := wi.withOperatorWorld(r, func(world *World) (err error) {
+		intervention, err = world.TerraformCells(cells, biome)
+		return err
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(intervention)
 }
 
-// handleExportAnomalies exports anomaly detection data
-func (wi *WebInterface) handleExportAnomalies(w http.ResponseWriter, r *http.Request) {
-	if r.Method != HTTPMethodGET {
+// EnvironmentalEventRequest starts an environmental event centred on a grid cell
+type EnvironmentalEventRequest struct {
+	Type string `json:"type"`
+	X    int    `json:"x"`
+	Y    int    `json:"y"`
+}
+
+// EnvironmentalEventResponse describes an environmental event an operator started
+type EnvironmentalEventResponse struct {
+	ID          int     `json:"id"`
+	Type        string  `json:"type"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	StartTick   int     `json:"start_tick"`
+	Duration    int     `json:"duration"`
+	X           int     `json:"x"`
+	Y           int     `json:"y"`
+	MaxRadius   float64 `json:"max_radius"` // In grid cells
+}
+
+// handleOperatorEvents starts a wildfire, storm, flood or other environmental event on a
+// cell of the world (POST {type, x, y})
+func (wi *WebInterface) handleOperatorEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	format := r.URL.Query().Get("format")
-	if format == "" {
-		format = "json"
+	var req EnvironmentalEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid environmental event request: %v", err), http.StatusBadRequest)
+		return
 	}
 
-	var anomaliesData map[string]interface{}
+	var event *EnhancedEnvironmentalEvent
+	err := wi.withOperatorWorld(r, func(world *World) (err error) {
+		event, err = world.StartEnvironmentalEvent(req.Type, GridPoint{X: req.X, Y: req.Y})
+		return err
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(EnvironmentalEventResponse{
+		ID: event.ID, Type: event.Type, Name: event.Name, Description: event.Description,
+		StartTick: event.StartTick, Duration: event.Duration, X: req.X, Y: req.Y, MaxRadius: event.MaxRadius,
+	})
+}
 
-	if wi.world.StatisticalReporter != nil {
-		anomaliesData = map[string]interface{}{
-			"anomalies":     wi.world.StatisticalReporter.Anomalies,
-			"total_count":   len(wi.world.StatisticalReporter.Anomalies),
-			"anomaly_types": wi.world.StatisticalReporter.detectedAnomalies,
-			"export_time":   time.Now(),
-		}
-	} else {
-		anomaliesData = map[string]interface{}{
-			"error":       "Statistical reporter not available",
-			"export_time": time.Now(),
-		}
+type TraitEditRequest struct {
+	EntityID int     `json:"entity_id"`
+	Trait    string  `json:"trait"`
+	Value    float64 `json:"value"`
+}
+
+// handleTraitEdit changes a trait of a single entity
+func (wi *WebInterface) handleTraitEdit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
 	}
 
-	if format == "csv" {
-		wi.exportAnomaliesAsCSV(w, anomaliesData)
-	} else {
-		w.Header().Set("Content-Type", "application/json")
-		w.Header().Set("Content-Disposition", "attachment; filename=anomalies_export.json")
-		_ = json.NewEncoder(w).Encode(anomaliesData)
+	var req TraitEditRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid trait edit: %v", err), http.StatusBadRequest)
+		return
+	}
+	var intervention *Intervention
+	err := wi.withOperatorWorld(r, func(world *World) (err error) {
+		intervention, err = world.EditEntityTrait(req.EntityID, req.Trait, req.Value)
+		return err
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(intervention)
 }
 
 // exportEventsAsCSV exports events in CSV format
@@ -4254,30 +3236,35 @@ func (wi *WebInterface) handleWebSocketUpgrade(w http.ResponseWriter, r *http.Re
 		return
 	}
 	
-	// Handle the WebSocket connection
-	wi.handleWebSocket(conn)
+	// Handle the WebSocket connection; ?chunks=1 streams chunks from the start, so a large
+	// world's grid is never sent whole
+	wi.handleWebSocket(conn, r.URL.Query().Get("chunks") == "1")
 }
 
 // handleWebSocket handles WebSocket connections
-func (wi *WebInterface) handleWebSocket(conn *websocket.Conn) {
+func (wi *WebInterface) handleWebSocket(conn *websocket.Conn, chunked bool) {
 	defer conn.Close()
 
 	// Add client to the list
-	wi.clientsMutex.Lock()
-	wi.clients[conn] = true
-	wi.connMutexes[conn] = &sync.Mutex{} // Create mutex for this connection
-	wi.clientsMutex.Unlock()
+	client := wi.registerClient(conn)
+	wi.startHeartbeat(conn)
 
-	log.Printf("Client connected. Total clients: %d", len(wi.clients))
+	log.Printf("Client connected. Total clients: %d", wi.WebSocketStats().Clients)
 
 	// Send initial data
-	viewData := wi.viewManager.GetCurrentViewData()
+	var viewData *ViewData
+	if chunked {
+		client.subscribeChunks([]ChunkKey{})
+		viewData = wi.viewManager.GetViewFrame(0, 0, 1.0, wi.viewManager.BuildViewDetails(), false)
+		viewData.ChunkSize = gridChunkSize
+	} else {
+		viewData = wi.viewManager.GetCurrentViewData()
+	}
 	wi.sendToClient(conn, viewData)
 
 	// Listen for client messages
 	for {
-		var msg map[string]interface{}
-		err := conn.ReadJSON(&msg)
+		_, raw, err := conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("WebSocket error: %v", err)
@@ -4285,32 +3272,52 @@ func (wi *WebInterface) handleWebSocket(conn *websocket.Conn) {
 			break
 		}
 
-		// Handle client commands
-		if action, ok := msg["action"].(string); ok {
-			var data interface{}
-			if d, exists := msg["data"]; exists {
-				data = d
-			}
-			wi.handleClientAction(conn, action, data)
-		}
-		
-		// Handle isometric data requests
-		if msgType, ok := msg["type"].(string); ok && msgType == "get_isometric_data" {
-			wi.handleIsometricDataRequest(conn, msg)
-		}
+		// Anything the client sends shows it is still there
+		_ = conn.SetReadDeadline(time.Now().Add(wi.wsSettings.PongTimeout))
+		wi.handleClientMessage(conn, raw)
 	}
 
 	// Clean up client connection
-	wi.clientsMutex.Lock()
-	delete(wi.clients, conn)
-	delete(wi.connMutexes, conn) // Remove mutex for this connection
-	if playerID, exists := wi.clientPlayers[conn]; exists {
-		wi.playerManager.RemovePlayer(playerID)
-		delete(wi.clientPlayers, conn)
+	wi.unregisterClient(client)
+
+	log.Printf("Client disconnected. Total clients: %d", wi.WebSocketStats().Clients)
+}
+
+// handleClientMessage handles one raw client message. Malformed input gets an error
+// response instead of closing the connection, and a panic while handling a message is
+// reported to the client rather than taking down the server.
+func (wi *WebInterface) handleClientMessage(conn *websocket.Conn, raw []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Recovered from panic handling client message: %v", r)
+			wi.sendErrorToClient(conn, "Internal error while handling request")
+		}
+	}()
+
+	wi.dispatchClientMessage(conn, raw)
+}
+
+// dispatchClientMessage decodes a client message and routes it to the matching handler
+func (wi *WebInterface) dispatchClientMessage(conn *websocket.Conn, raw []byte) {
+	var msg map[string]interface{}
+	if err := json.Unmarshal(raw, &msg); err != nil || msg == nil {
+		wi.sendErrorToClient(conn, "Invalid message: expected a JSON object")
+		return
 	}
-	wi.clientsMutex.Unlock()
 
-	log.Printf("Client disconnected. Total clients: %d", len(wi.clients))
+	// Handle client commands
+	if action, ok := msg["action"].(string); ok {
+		var data interface{}
+		if d, exists := msg["data"]; exists {
+			data = d
+		}
+		wi.handleClientAction(conn, action, data)
+	}
+
+	// Handle isometric data requests
+	if msgType, ok := msg["type"].(string); ok && msgType == "get_isometric_data" {
+		wi.handleIsometricDataRequest(conn, msg)
+	}
 }
 
 // handleClientAction processes actions from web clients
@@ -4325,6 +3332,17 @@ func (wi *WebInterface) handleClientAction(conn *websocket.Conn, action string,
 	case "control_species":
 		wi.handleControlSpecies(conn, data)
 
+	case "select_group":
+		wi.handleSelectGroup(conn, data)
+
+	case "group_command":
+		wi.handleGroupCommand(conn, data)
+	case "set_species_policy":
+		wi.handleSetSpeciesPolicy(conn, data)
+	case "list_players", "propose_alliance", "respond_alliance", "leave_alliance",
+		"grant_co_control", "revoke_co_control", "gift_species":
+		wi.handleDiplomacy(conn, action, data)
+
 	case "toggle_pause":
 		wi.world.TogglePause()
 		log.Printf("Client requested pause toggle - now paused: %v", wi.world.IsPaused())
@@ -4355,11 +3373,13 @@ func (wi *WebInterface) handleClientAction(conn *websocket.Conn, action string,
 			err := stateManager.LoadFromData(stateData)
 			if err != nil {
 				log.Printf("Error loading state: %v", err)
+				wi.sendErrorToClient(conn, fmt.Sprintf("Failed to load state: %v", err))
 			} else {
 				log.Printf("State loaded successfully")
 			}
 		} else {
 			log.Printf("Invalid state data format")
+			wi.sendErrorToClient(conn, "Invalid state data format")
 		}
 
 	case "increase_speed":
@@ -4380,8 +3400,47 @@ func (wi *WebInterface) handleClientAction(conn *websocket.Conn, action string,
 			}
 		}
 
+	case "set_unlimited_speed":
+		if speedData, ok := data.(map[string]interface{}); ok {
+			if enabled, ok := speedData["enabled"].(bool); ok {
+				wi.governor.SetUnlimited(enabled)
+				log.Printf("Client set unlimited speed to %v", enabled)
+			}
+		}
+
+	case "set_max_cpu":
+		if cpuData, ok := data.(map[string]interface{}); ok {
+			if percent, ok := cpuData["percent"].(float64); ok {
+				wi.governor.SetMaxCPU(percent / 100)
+				log.Printf("Client capped simulation CPU at %.0f%%", wi.governor.MaxCPU()*100)
+			}
+		}
+
+	case "follow_entity":
+		if followData, ok := data.(map[string]interface{}); ok {
+			if id, ok := followData["entity_id"].(float64); ok {
+				wi.tickMutex.Lock()
+				err := wi.followEntity(int(id))
+				wi.tickMutex.Unlock()
+				if err != nil {
+					wi.sendErrorToClient(conn, err.Error())
+				} else {
+					log.Printf("Client followed entity %d", int(id))
+				}
+			}
+		}
+
+	case "unfollow_entity":
+		wi.tickMutex.Lock()
+		wi.unfollowEntity()
+		wi.tickMutex.Unlock()
+		log.Printf("Client stopped following")
+
 	case "pan":
 		if panData, ok := data.(map[string]interface{}); ok {
+			wi.tickMutex.Lock()
+			wi.unfollowEntity() // Panning away lets the camera go
+			wi.tickMutex.Unlock()
 			if deltaX, ok := panData["deltaX"].(float64); ok {
 				wi.viewportX += int(deltaX)
 			}
@@ -4412,8 +3471,48 @@ func (wi *WebInterface) handleClientAction(conn *websocket.Conn, action string,
 		log.Printf("Client zoomed out to %fx", wi.zoomLevel)
 
 	case "reset_viewport":
+		wi.tickMutex.Lock()
+		wi.unfollowEntity()
+		wi.tickMutex.Unlock()
 		wi.resetViewport()
 		log.Printf("Client reset viewport")
+
+	case "subscribe_chunks":
+		wi.handleSubscribeChunks(conn, data)
+
+	case "unsubscribe_chunks":
+		wi.handleUnsubscribeChunks(conn)
+
+	case "build_structure":
+		raw, err := json.Marshal(data)
+		if err != nil {
+			wi.sendErrorToClient(conn, "Invalid structure data")
+			return
+		}
+		var req OperatorStructureRequest
+		if err := json.Unmarshal(raw, &req); err != nil {
+			wi.sendErrorToClient(conn, "Invalid structure data")
+			return
+		}
+		if structure, err := wi.buildOperatorStructure(req); err != nil {
+			wi.sendErrorToClient(conn, err.Error())
+		} else {
+			log.Printf("Client built %s %s with %d cells", structure.Kind, structure.Type, len(structure.Cells))
+		}
+
+	case "undo_intervention":
+		if intervention, err := wi.world.Interventions.Undo(wi.world); err != nil {
+			wi.sendErrorToClient(conn, err.Error())
+		} else {
+			log.Printf("Client undid intervention %d: %s", intervention.ID, intervention.Description)
+		}
+
+	case "redo_intervention":
+		if intervention, err := wi.world.Interventions.Redo(wi.world); err != nil {
+			wi.sendErrorToClient(conn, err.Error())
+		} else {
+			log.Printf("Client redid intervention %d: %s", intervention.ID, intervention.Description)
+		}
 	}
 }
 
@@ -4452,39 +3551,39 @@ func (wi *WebInterface) handleIsometricDataRequest(conn *websocket.Conn, msg map
 		"type": "isometric",
 		"data": isometricData,
 	}
-	
-	wi.clientsMutex.RLock()
-	connMutex, exists := wi.connMutexes[conn]
-	wi.clientsMutex.RUnlock()
-	
-	if !exists {
-		log.Printf("Connection no longer exists, cannot send isometric data")
-		return
-	}
-	
-	connMutex.Lock()
-	err := conn.WriteJSON(response)
-	connMutex.Unlock()
-	
-	if err != nil {
-		log.Printf("Error sending isometric data: %v", err)
-	} else {
-		log.Printf("Successfully sent isometric data to client")
-	}
+
+	wi.sendJSONToClient(conn, response)
 }
 
-// serveStatic serves static files (for future CSS/JS files)
+// serveStatic serves the stylesheets and scripts the pages link to
 func (wi *WebInterface) serveStatic(w http.ResponseWriter, r *http.Request) {
-	// For now, just return 404 since we're embedding everything
-	http.NotFound(w, r)
+	webAssets.ServeStatic(w, r)
 }
 
-// simulationLoop runs the simulation and broadcasts updates
+// simulationLoop runs the simulation and broadcasts updates. View updates are sent every
+// updateInterval regardless of how many ticks ran in between.
 func (wi *WebInterface) simulationLoop() {
 	ticker := time.NewTicker(wi.updateInterval)
 	defer ticker.Stop()
 
 	for {
+		// In unlimited mode, keep ticking until a frame is due
+		wi.tickMutex.Lock()
+		paused := wi.world.IsPaused()
+		wi.tickMutex.Unlock()
+		if wi.governor.Unlimited() && !paused {
+			select {
+			case <-ticker.C:
+				wi.sendFrame()
+				wi.sendAudio()
+			case <-wi.stopChan:
+				return
+			default:
+				wi.runGovernedTicks(1)
+			}
+			continue
+		}
+
 		select {
 		case <-ticker.C:
 			// Run multiple simulation updates based on speed multiplier
@@ -4498,19 +3597,9 @@ func (wi *WebInterface) simulationLoop() {
 			wi.accumulatedUpdates -= float64(updatesToRun)
 
 			// Run the calculated number of updates
-			for i := 0; i < updatesToRun; i++ {
-				wi.world.Update()
-			}
-
-			// Get current view data with viewport
-			viewData := wi.viewManager.GetViewDataWithViewport(wi.viewportX, wi.viewportY, wi.zoomLevel)
-
-			// Send to broadcast channel (non-blocking)
-			select {
-			case wi.broadcastChan <- viewData:
-			default:
-				// Channel is full, skip this update
-			}
+			wi.runGovernedTicks(updatesToRun)
+			wi.sendFrame()
+			wi.sendAudio()
 
 		case <-wi.stopChan:
 			return
@@ -4518,6 +3607,66 @@ func (wi *WebInterface) simulationLoop() {
 	}
 }
 
+// runGovernedTicks runs simulation ticks, records them for the ticks-per-second
+// measurement and rests afterwards if the CPU cap requires it
+func (wi *WebInterface) runGovernedTicks(ticks int) {
+	start := time.Now()
+	wi.tickMutex.Lock()
+	startTick := wi.world.Tick
+	for i := 0; i < ticks; i++ {
+		wi.world.Update()
+	}
+	if ran := wi.world.Tick - startTick; ran > 0 {
+		wi.branches.Step(wi.world, ran)
+	}
+	if wi.world.IsPaused() {
+		ticks = 0
+	}
+	wi.tickMutex.Unlock()
+	now := time.Now()
+	wi.governor.RecordTicks(ticks, now)
+	if rest := wi.governor.Throttle(now.Sub(start)); rest > 0 {
+		time.Sleep(rest)
+	}
+}
+
+// sendFrame queues a view update for the broadcast loop
+func (wi *WebInterface) sendFrame() {
+	// Keep the rate measurement current while paused or between unlimited-mode frames
+	wi.governor.RecordTicks(0, time.Now())
+
+	// Get current view data with viewport and the analysis tabs the worker built last, building
+	// the viewport's grid only if a client takes it and the chunks clients stream
+	subscribed, allChunked := wi.chunkedClients()
+	follow := wi.followCamera()
+	viewData := wi.viewManager.GetViewFrame(wi.viewportX, wi.viewportY, wi.zoomLevel, wi.latestViewDetails(), !allChunked)
+	viewData.chunks = wi.buildSubscribedChunks(subscribed)
+	viewData.UnlimitedSpeed = wi.governor.Unlimited()
+	viewData.MaxCPU = wi.governor.MaxCPU()
+	viewData.TicksPerSecond = wi.governor.TicksPerSecond()
+	viewData.Follow = follow.Track
+
+	// Work out what each player can see while the world is not being updated
+	if wi.world.Config.FogOfWar {
+		viewData.fogOfWar = wi.playerVisibility()
+	}
+
+	// Send to broadcast channel (non-blocking)
+	select {
+	case wi.broadcastChan <- viewData:
+	default:
+		// Channel is full, skip this update
+	}
+}
+
+// sendAudio sends the clients the soundscape, which changes slowly, about once a second
+func (wi *WebInterface) sendAudio() {
+	if now := time.Now(); now.Sub(wi.lastAudio) >= audioPeriod {
+		wi.lastAudio = now
+		wi.notifyClients(wi.world.Audio.Mix(wi.world))
+	}
+}
+
 // broadcastLoop handles broadcasting updates to all connected clients
 func (wi *WebInterface) broadcastLoop() {
 	for {
@@ -4531,72 +3680,97 @@ func (wi *WebInterface) broadcastLoop() {
 	}
 }
 
-// broadcastToClients sends data to all connected WebSocket clients
+// broadcastToClients queues data for all connected WebSocket clients. The update is encoded
+// once and shared by every client that sees the whole map.
 func (wi *WebInterface) broadcastToClients(data *ViewData) {
 	wi.clientsMutex.RLock()
-	clients := make([]*websocket.Conn, 0, len(wi.clients))
-	for client := range wi.clients {
+	clients := make([]*wsClient, 0, len(wi.clients))
+	for _, client := range wi.clients {
 		clients = append(clients, client)
 	}
 	wi.clientsMutex.RUnlock()
 
-	// Send to each client
+	var shared []byte
 	for _, client := range clients {
-		wi.sendToClient(client, data)
+		if _, chunked := client.subscribedChunks(); chunked {
+			wi.queueChunkFrame(client, data)
+			continue
+		}
+		clientData := wi.viewDataForClient(client.conn, data)
+		if clientData == data && shared != nil {
+			wi.queueFrame(client, shared)
+			continue
+		}
+		message, err := json.Marshal(clientData)
+		if err != nil {
+			log.Printf("Error encoding view update: %v", err)
+			return
+		}
+		if clientData == data {
+			shared = message
+		}
+		wi.queueFrame(client, message)
 	}
 }
 
-// sendToClient sends data to a specific client
-func (wi *WebInterface) sendToClient(conn *websocket.Conn, data *ViewData) {
+// playerVisibility computes the fog of war of every connected player
+func (wi *WebInterface) playerVisibility() map[string]map[GridPoint]string {
 	wi.clientsMutex.RLock()
-	connMutex, exists := wi.connMutexes[conn]
-	wi.clientsMutex.RUnlock()
-	
-	if !exists {
-		return // Connection no longer exists
+	playerIDs := make(map[string]bool, len(wi.clientPlayers))
+	for _, playerID := range wi.clientPlayers {
+		playerIDs[playerID] = true
 	}
-	
-	connMutex.Lock()
-	defer connMutex.Unlock()
-	
-	err := conn.WriteJSON(data)
-	if err != nil {
-		log.Printf("Error sending data to client: %v", err)
-		// Client disconnected, remove from list
-		wi.clientsMutex.Lock()
-		delete(wi.clients, conn)
-		delete(wi.connMutexes, conn)
-		wi.clientsMutex.Unlock()
+	wi.clientsMutex.RUnlock()
+
+	visibility := make(map[string]map[GridPoint]string, len(playerIDs))
+	for _, playerID := range sortedKeys(playerIDs) {
+		visibility[playerID] = wi.world.FogOfWar.Visibility(wi.world, playerID, wi.playerManager.VisibleSpecies(playerID))
 	}
+	return visibility
 }
 
-// sendJSONToClient sends any JSON-serializable data to a specific client
-func (wi *WebInterface) sendJSONToClient(conn *websocket.Conn, data interface{}) {
-	wi.clientsMutex.RLock()
-	connMutex, exists := wi.connMutexes[conn]
-	wi.clientsMutex.RUnlock()
-	
-	if !exists {
-		return // Connection no longer exists
+// viewDataForClient limits the view to a player's fog of war when it is enabled, hiding
+// other players' groups and policies as well. Spectators see the whole map.
+func (wi *WebInterface) viewDataForClient(conn *websocket.Conn, data *ViewData) *ViewData {
+	if data.fogOfWar == nil {
+		return data
 	}
-	
-	connMutex.Lock()
-	defer connMutex.Unlock()
-	
-	err := conn.WriteJSON(data)
-	if err != nil {
-		log.Printf("Error sending JSON to client: %v", err)
-		// Client disconnected, remove from list
-		wi.clientsMutex.Lock()
-		delete(wi.clients, conn)
-		delete(wi.connMutexes, conn)
-		wi.clientsMutex.Unlock()
+	playerID, isPlayer := wi.playerForConn(conn)
+	if !isPlayer {
+		return data
+	}
+	fogged := applyFogOfWar(data, data.fogOfWar[playerID])
+	fogged.PlayerGroups = make([]PlayerGroupData, 0)
+	for _, group := range data.PlayerGroups {
+		if group.PlayerID == playerID {
+			fogged.PlayerGroups = append(fogged.PlayerGroups, group)
+		}
 	}
+	fogged.SpeciesPolicies = make([]SpeciesPolicy, 0)
+	for _, policy := range data.SpeciesPolicies {
+		if policy.PlayerID == playerID {
+			fogged.SpeciesPolicies = append(fogged.SpeciesPolicies, policy)
+		}
+	}
+	return fogged
+}
+
+// sendToClient queues data for a specific client
+func (wi *WebInterface) sendToClient(conn *websocket.Conn, data *ViewData) {
+	wi.queueMessage(conn, data)
+}
+
+// sendJSONToClient queues any JSON-serializable data for a specific client
+func (wi *WebInterface) sendJSONToClient(conn *websocket.Conn, data interface{}) {
+	wi.queueMessage(conn, data)
 }
 
-// Stop stops the web interface
+// Stop stops the web interface, returning once its loops have finished so the world is no
+// longer being updated
 func (wi *WebInterface) Stop() {
+	wi.running.Store(false)
 	close(wi.stopChan)
+	wi.loops.Wait()
 }
 
 // reinitializeWorld reinitializes the world with default populations after reset
@@ -4682,9 +3856,6 @@ func (wi *WebInterface) reinitializeWorld() {
 
 // handlePlayerJoin handles a player joining the game
 func (wi *WebInterface) handlePlayerJoin(conn *websocket.Conn, data interface{}) {
-	wi.clientsMutex.Lock()
-	defer wi.clientsMutex.Unlock()
-
 	// Parse player data
 	playerData, ok := data.(map[string]interface{})
 	if !ok {
@@ -4709,7 +3880,9 @@ func (wi *WebInterface) handlePlayerJoin(conn *websocket.Conn, data interface{})
 	}
 
 	// Map connection to player
+	wi.clientsMutex.Lock()
 	wi.clientPlayers[conn] = playerID
+	wi.clientsMutex.Unlock()
 
 	log.Printf("Player '%s' joined with ID %s", player.Name, playerID)
 
@@ -4723,13 +3896,19 @@ func (wi *WebInterface) handlePlayerJoin(conn *websocket.Conn, data interface{})
 	wi.sendJSONToClient(conn, response)
 }
 
+// playerForConn returns the player ID joined on a connection. The clients lock is
+// released before returning so callers can send responses without deadlocking.
+func (wi *WebInterface) playerForConn(conn *websocket.Conn) (string, bool) {
+	wi.clientsMutex.RLock()
+	defer wi.clientsMutex.RUnlock()
+	playerID, exists := wi.clientPlayers[conn]
+	return playerID, exists
+}
+
 // handleCreateSpecies handles a player creating a new species
 func (wi *WebInterface) handleCreateSpecies(conn *websocket.Conn, data interface{}) {
-	wi.clientsMutex.Lock()
-	defer wi.clientsMutex.Unlock()
-
 	// Get player ID for this connection
-	playerID, exists := wi.clientPlayers[conn]
+	playerID, exists := wi.playerForConn(conn)
 	if !exists {
 		wi.sendErrorToClient(conn, "You must join as a player first")
 		return
@@ -4748,17 +3927,54 @@ func (wi *WebInterface) handleCreateSpecies(conn *websocket.Conn, data interface
 		return
 	}
 
+	adjustments := make(map[string]float64)
+	if requested, ok := speciesData["traits"].(map[string]interface{}); ok {
+		for traitName, value := range requested {
+			if floatValue, ok := value.(float64); ok {
+				adjustments[traitName] = floatValue
+			}
+		}
+	}
+	createdName, baseTraits, err := wi.createSpecies(speciesName, adjustments)
+	if err != nil {
+		wi.sendErrorToClient(conn, err.Error())
+		return
+	}
+
+	// Add species to player
+	err = wi.playerManager.AddPlayerSpecies(playerID, createdName)
+	if err != nil {
+		wi.sendErrorToClient(conn, fmt.Sprintf("Failed to assign species to player: %v", err))
+		return
+	}
+
+	// Update player activity
+	wi.playerManager.UpdatePlayerActivity(playerID)
+
+	log.Printf("Player %s created species '%s'", playerID, createdName)
+
+	// Send success response
+	response := map[string]interface{}{
+		"type":         "species_created",
+		"species_name": createdName,
+		"message":      fmt.Sprintf("Successfully created species '%s'! You can now control its entities.", createdName),
+		"traits":       baseTraits,
+	}
+	wi.sendJSONToClient(conn, response)
+}
+
+// createSpecies adds a new species with the limited trait adjustments players are allowed,
+// returning the species name the world registered it under and its base traits
+func (wi *WebInterface) createSpecies(speciesName string, adjustments map[string]float64) (string, map[string]float64, error) {
 	// Validate and clean species name
 	cleanSpeciesName, err := ValidatePlayerName(speciesName)
 	if err != nil {
-		wi.sendErrorToClient(conn, fmt.Sprintf("Invalid species name: %v", err))
-		return
+		return "", nil, fmt.Errorf("invalid species name: %v", err)
 	}
 
 	// Check if species name already exists in the world
 	if _, exists := wi.world.Populations[cleanSpeciesName]; exists {
-		wi.sendErrorToClient(conn, "A species with this name already exists")
-		return
+		return "", nil, fmt.Errorf("a species with this name already exists")
 	}
 
 	// Create basic traits for the new species (limited control)
@@ -4779,25 +3995,21 @@ func (wi *WebInterface) handleCreateSpecies(conn *websocket.Conn, data interface
 	}
 
 	// Allow players to make minor adjustments to some traits
-	if adjustments, ok := speciesData["traits"].(map[string]interface{}); ok {
-		// Only allow adjustment of certain traits within limits
-		allowedTraits := []string{"speed", "aggression", "cooperation", "intelligence"}
-		for _, traitName := range allowedTraits {
-			if value, exists := adjustments[traitName]; exists {
-				if floatValue, ok := value.(float64); ok {
-					// Limit adjustments to ±0.3 range
-					adjustment := math.Max(-0.3, math.Min(0.3, floatValue))
-					baseTraits[traitName] += adjustment
-					// Ensure final values stay within reasonable bounds
-					baseTraits[traitName] = math.Max(-1.0, math.Min(1.0, baseTraits[traitName]))
-				}
-			}
+	// Only allow adjustment of certain traits within limits
+	allowedTraits := []string{"speed", "aggression", "cooperation", "intelligence"}
+	for _, traitName := range allowedTraits {
+		if value, exists := adjustments[traitName]; exists {
+			// Limit adjustments to ±0.3 range
+			adjustment := math.Max(-0.3, math.Min(0.3, value))
+			baseTraits[traitName] += adjustment
+			// Ensure final values stay within reasonable bounds
+			baseTraits[traitName] = math.Max(-1.0, math.Min(1.0, baseTraits[traitName]))
 		}
 	}
 
 	// Generate random starting position
-	startX := rand.Float64() * wi.world.Config.Width
-	startY := rand.Float64() * wi.world.Config.Height
+	startX := wi.world.Rand.Float64() * wi.world.Config.Width
+	startY := wi.world.Rand.Float64() * wi.world.Config.Height
 
 	// Create population config
 	popConfig := PopulationConfig{
@@ -4810,38 +4022,14 @@ func (wi *WebInterface) handleCreateSpecies(conn *websocket.Conn, data interface
 		BaseMutationRate: 0.08,     // Moderate mutation rate
 	}
 
-	// Add population to world
-	wi.world.AddPopulation(popConfig)
-
-	// Add species to player
-	err = wi.playerManager.AddPlayerSpecies(playerID, cleanSpeciesName)
-	if err != nil {
-		wi.sendErrorToClient(conn, fmt.Sprintf("Failed to assign species to player: %v", err))
-		return
-	}
-
-	// Update player activity
-	wi.playerManager.UpdatePlayerActivity(playerID)
-
-	log.Printf("Player %s created species '%s'", playerID, cleanSpeciesName)
-
-	// Send success response
-	response := map[string]interface{}{
-		"type":         "species_created",
-		"species_name": cleanSpeciesName,
-		"message":      fmt.Sprintf("Successfully created species '%s'! You can now control its entities.", cleanSpeciesName),
-		"traits":       baseTraits,
-	}
-	wi.sendJSONToClient(conn, response)
+	// Add population to world; the naming system gives it its species name
+	return wi.world.AddPopulation(popConfig), baseTraits, nil
 }
 
 // handleControlSpecies handles player commands to control their species
 func (wi *WebInterface) handleControlSpecies(conn *websocket.Conn, data interface{}) {
-	wi.clientsMutex.Lock()
-	defer wi.clientsMutex.Unlock()
-
 	// Get player ID for this connection
-	playerID, exists := wi.clientPlayers[conn]
+	playerID, exists := wi.playerForConn(conn)
 	if !exists {
 		wi.sendErrorToClient(conn, "You must join as a player first")
 		return
@@ -4896,6 +4084,315 @@ func (wi *WebInterface) handleControlSpecies(conn *websocket.Conn, data interfac
 	}
 }
 
+// gridCellWorldSize returns the world-space width and height of one grid cell
+func (wi *WebInterface) gridCellWorldSize() (float64, float64) {
+	return wi.world.Config.Width / float64(wi.world.Config.GridWidth), wi.world.Config.Height / float64(wi.world.Config.GridHeight)
+}
+
+// handleSelectGroup box-selects a player's creatures between two grid cells into a new group
+func (wi *WebInterface) handleSelectGroup(conn *websocket.Conn, data interface{}) {
+	playerID, exists := wi.playerForConn(conn)
+	if !exists {
+		wi.sendErrorToClient(conn, "You must join as a player first")
+		return
+	}
+	selectData, ok := data.(map[string]interface{})
+	if !ok {
+		wi.sendErrorToClient(conn, "Invalid selection data format")
+		return
+	}
+	x1, ok1 := selectData["x1"].(float64)
+	y1, ok2 := selectData["y1"].(float64)
+	x2, ok3 := selectData["x2"].(float64)
+	y2, ok4 := selectData["y2"].(float64)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		wi.sendErrorToClient(conn, "Selection corners (x1, y1, x2, y2) are required")
+		return
+	}
+
+	// Select from all of the species the player controls, or just one if given
+	species := wi.playerManager.ControllableSpecies(playerID)
+	if name, ok := selectData["species"].(string); ok && name != "" {
+		if !wi.playerManager.CanPlayerControlSpecies(playerID, name) {
+			wi.sendErrorToClient(conn, "You can only control your own species")
+			return
+		}
+		species = []string{name}
+	}
+	formation, _ := selectData["formation"].(string)
+
+	cellWidth, cellHeight := wi.gridCellWorldSize()
+	group, err := wi.world.PlayerGroups.SelectGroup(wi.world, playerID, species,
+		math.Min(x1, x2)*cellWidth, math.Min(y1, y2)*cellHeight,
+		(math.Max(x1, x2)+1)*cellWidth, (math.Max(y1, y2)+1)*cellHeight, formation)
+	if err != nil {
+		wi.sendErrorToClient(conn, err.Error())
+		return
+	}
+	wi.playerManager.UpdatePlayerActivity(playerID)
+
+	wi.sendJSONToClient(conn, map[string]interface{}{
+		"type":      "group_selected",
+		"group_id":  group.ID,
+		"size":      len(group.EntityIDs),
+		"formation": group.Formation,
+		"message":   fmt.Sprintf("Selected %d creatures as group %d", len(group.EntityIDs), group.ID),
+	})
+}
+
+// handleGroupCommand gives a player group a standing order (move, guard, gather, stop, disband).
+// Targets are grid cells; guard and gather areas are a radius in cells around the target.
+func (wi *WebInterface) handleGroupCommand(conn *websocket.Conn, data interface{}) {
+	playerID, exists := wi.playerForConn(conn)
+	if !exists {
+		wi.sendErrorToClient(conn, "You must join as a player first")
+		return
+	}
+	commandData, ok := data.(map[string]interface{})
+	if !ok {
+		wi.sendErrorToClient(conn, "Invalid group command format")
+		return
+	}
+	groupID, ok := commandData["group_id"].(float64)
+	if !ok {
+		wi.sendErrorToClient(conn, "Group ID is required")
+		return
+	}
+	command, _ := commandData["command"].(string)
+	formation, _ := commandData["formation"].(string)
+	wi.playerManager.UpdatePlayerActivity(playerID)
+
+	var order *GroupOrder
+	switch command {
+	case "disband":
+		if !wi.world.PlayerGroups.Disband(playerID, int(groupID)) {
+			wi.sendErrorToClient(conn, fmt.Sprintf("Group %d not found", int(groupID)))
+			return
+		}
+		wi.sendJSONToClient(conn, map[string]interface{}{
+			"type":    "command_executed",
+			"command": command,
+			"message": fmt.Sprintf("Disbanded group %d", int(groupID)),
+		})
+		return
+
+	case "stop":
+		// A nil order makes the group idle
+
+	case GroupOrderMove, GroupOrderGuard, GroupOrderGather:
+		x, xOk := commandData["x"].(float64)
+		y, yOk := commandData["y"].(float64)
+		if !xOk || !yOk {
+			wi.sendErrorToClient(conn, "Target cell (x, y) is required for group orders")
+			return
+		}
+		cellWidth, cellHeight := wi.gridCellWorldSize()
+		order = &GroupOrder{
+			Kind: command,
+			Target: Position{
+				X: math.Max(0, math.Min(wi.world.Config.Width-1, (x+0.5)*cellWidth)),
+				Y: math.Max(0, math.Min(wi.world.Config.Height-1, (y+0.5)*cellHeight)),
+			},
+			IssuedTick: wi.world.Tick,
+		}
+		if radius, ok := commandData["radius"].(float64); ok {
+			order.Radius = (radius + 0.5) * math.Max(cellWidth, cellHeight)
+		}
+
+	default:
+		wi.sendErrorToClient(conn, fmt.Sprintf("Unknown group command: %s", command))
+		return
+	}
+
+	if err := wi.world.PlayerGroups.IssueOrder(playerID, int(groupID), order, formation); err != nil {
+		wi.sendErrorToClient(conn, err.Error())
+		return
+	}
+	log.Printf("Player %s ordered group %d to %s", playerID, int(groupID), command)
+	wi.sendJSONToClient(conn, map[string]interface{}{
+		"type":     "command_executed",
+		"command":  command,
+		"group_id": int(groupID),
+		"message":  fmt.Sprintf("Group %d: %s", int(groupID), command),
+	})
+}
+
+// handleSetSpeciesPolicy sets the standing policy sliders of one of the player's species
+func (wi *WebInterface) handleSetSpeciesPolicy(conn *websocket.Conn, data interface{}) {
+	playerID, exists := wi.playerForConn(conn)
+	if !exists {
+		wi.sendErrorToClient(conn, "You must join as a player first")
+		return
+	}
+	policyData, ok := data.(map[string]interface{})
+	if !ok {
+		wi.sendErrorToClient(conn, "Invalid species policy format")
+		return
+	}
+	species, _ := policyData["species"].(string)
+	if !wi.playerManager.CanPlayerControlSpecies(playerID, species) {
+		wi.sendErrorToClient(conn, fmt.Sprintf("You cannot set the policy of species '%s'", species))
+		return
+	}
+	wi.playerManager.UpdatePlayerActivity(playerID)
+
+	var settings PolicySettings
+	settings.Aggression, _ = policyData["aggression"].(float64)
+	settings.Exploration, _ = policyData["exploration"].(float64)
+	settings.Reproduction, _ = policyData["reproduction"].(float64)
+	policy, err := wi.world.SpeciesPolicies.SetPolicy(species, playerID, settings, wi.world.Tick)
+	if err != nil {
+		wi.sendErrorToClient(conn, err.Error())
+		return
+	}
+	log.Printf("Player %s set policy of %s to %+v", playerID, species, settings)
+	wi.sendJSONToClient(conn, map[string]interface{}{
+		"type":    "species_policy_updated",
+		"species": species,
+		"policy":  policy,
+		"message": fmt.Sprintf("Policy for %s updated; it will take effect gradually", species),
+	})
+}
+
+// handleDiplomacy handles alliances between players, co-control of species by allies and
+// species gifts. Both players involved are sent their updated player list and species.
+func (wi *WebInterface) handleDiplomacy(conn *websocket.Conn, action string, data interface{}) {
+	playerID, exists := wi.playerForConn(conn)
+	if !exists {
+		wi.sendErrorToClient(conn, "You must join as a player first")
+		return
+	}
+	params, _ := data.(map[string]interface{})
+	otherID, _ := params["player_id"].(string)
+	species, _ := params["species"].(string)
+	wi.playerManager.UpdatePlayerActivity(playerID)
+
+	name := func(id string) string {
+		if player, exists := wi.playerManager.Players[id]; exists {
+			return player.Name
+		}
+		return id
+	}
+	var err error
+	var message, otherMessage string
+	switch action {
+	case "list_players":
+		// Only the update below
+
+	case "propose_alliance":
+		var formed bool
+		if formed, err = wi.playerManager.ProposeAlliance(playerID, otherID); formed {
+			message = fmt.Sprintf("You are now allied with %s", name(otherID))
+			otherMessage = fmt.Sprintf("You are now allied with %s", name(playerID))
+		} else {
+			message = fmt.Sprintf("Alliance proposed to %s", name(otherID))
+			otherMessage = fmt.Sprintf("%s proposes an alliance", name(playerID))
+		}
+
+	case "respond_alliance":
+		accept, _ := params["accept"].(bool)
+		err = wi.playerManager.RespondToAlliance(playerID, otherID, accept)
+		if accept {
+			message = fmt.Sprintf("You are now allied with %s", name(otherID))
+			otherMessage = fmt.Sprintf("%s accepted your alliance", name(playerID))
+		} else {
+			message = fmt.Sprintf("Declined the alliance with %s", name(otherID))
+			otherMessage = fmt.Sprintf("%s declined your alliance", name(playerID))
+		}
+
+	case "leave_alliance":
+		err = wi.playerManager.LeaveAlliance(playerID, otherID)
+		message = fmt.Sprintf("Left the alliance with %s", name(otherID))
+		otherMessage = fmt.Sprintf("%s left your alliance", name(playerID))
+
+	case "grant_co_control":
+		err = wi.playerManager.GrantCoControl(playerID, species, otherID)
+		message = fmt.Sprintf("%s can now control %s with you", name(otherID), species)
+		otherMessage = fmt.Sprintf("%s lets you co-control %s", name(playerID), species)
+
+	case "revoke_co_control":
+		err = wi.playerManager.RevokeCoControl(playerID, species, otherID)
+		message = fmt.Sprintf("%s can no longer control %s", name(otherID), species)
+		otherMessage = fmt.Sprintf("%s took back control of %s", name(playerID), species)
+
+	case "gift_species":
+		if err = wi.playerManager.GiftSpecies(playerID, species, otherID); err == nil {
+			wi.world.SpeciesPolicies.Reassign(species, otherID)
+		}
+		message = fmt.Sprintf("Gifted %s to %s", species, name(otherID))
+		otherMessage = fmt.Sprintf("%s gifted you %s", name(playerID), species)
+	}
+	if err != nil {
+		wi.sendErrorToClient(conn, err.Error())
+		return
+	}
+
+	if action != "list_players" {
+		log.Printf("Player %s: %s", playerID, message)
+	}
+	wi.sendDiplomacyUpdate(playerID, message)
+	if otherID != "" && otherID != playerID && otherMessage != "" {
+		wi.sendDiplomacyUpdate(otherID, otherMessage)
+	}
+}
+
+// sendDiplomacyUpdate sends a player the other players, their standing with them and the
+// species they can control, along with an optional message
+func (wi *WebInterface) sendDiplomacyUpdate(playerID, message string) {
+	players := make([]map[string]interface{}, 0)
+	for _, id := range sortedKeys(wi.playerManager.Players) {
+		if id == playerID {
+			continue
+		}
+		player := wi.playerManager.Players[id]
+		players = append(players, map[string]interface{}{
+			"id":        id,
+			"name":      player.Name,
+			"active":    player.IsActive,
+			"allied":    wi.playerManager.AreAllied(playerID, id),
+			"requested": wi.playerManager.AllianceRequests[id][playerID], // They asked this player
+			"pending":   wi.playerManager.AllianceRequests[playerID][id], // This player asked them
+		})
+	}
+	update := map[string]interface{}{
+		"type":    "diplomacy",
+		"message": message,
+		"players": players,
+		"species": wi.playerManager.ControllableSpecies(playerID),
+	}
+
+	wi.clientsMutex.RLock()
+	conns := make([]*websocket.Conn, 0, 1)
+	for conn, id := range wi.clientPlayers {
+		if id == playerID {
+			conns = append(conns, conn)
+		}
+	}
+	wi.clientsMutex.RUnlock()
+	for _, conn := range conns {
+		wi.sendJSONToClient(conn, update)
+	}
+}
+
+// publishOwnershipAudit puts every change in species control on the central event bus
+func (wi *WebInterface) publishOwnershipAudit(audit OwnershipAudit) {
+	if wi.world.CentralEventBus == nil {
+		return
+	}
+	description := fmt.Sprintf("Player %s %s %s to player %s", audit.FromPlayer, strings.ReplaceAll(audit.Action, "_", " "), audit.Species, audit.ToPlayer)
+	if audit.Reason != "" {
+		description += " (" + audit.Reason + ")"
+	}
+	wi.world.CentralEventBus.EmitSystemEvent(wi.world.Tick, "species_ownership_changed", audit.Action, "player_manager", description, nil,
+		map[string]interface{}{
+			"action":      audit.Action,
+			"species":     audit.Species,
+			"from_player": audit.FromPlayer,
+			"to_player":   audit.ToPlayer,
+			"reason":      audit.Reason,
+		})
+}
+
 // handleMoveCommand handles movement commands for player species
 func (wi *WebInterface) handleMoveCommand(conn *websocket.Conn, playerID string, population *Population, controlData map[string]interface{}) {
 	// Parse movement parameters
@@ -4915,9 +4412,8 @@ func (wi *WebInterface) handleMoveCommand(conn *websocket.Conn, playerID string,
 	moveCount := 0
 	for _, entity := range population.Entities {
 		if entity.IsAlive {
-			// Calculate direction to target
-			dx := targetX - entity.Position.X
-			dy := targetY - entity.Position.Y
+			// Calculate direction to target, the short way round a map that wraps
+			dx, dy := wi.world.Geometry().Direction(entity.Position, Position{X: targetX, Y: targetY})
 			distance := math.Sqrt(dx*dx + dy*dy)
 
 			if distance > 1.0 { // Only move if not already close
@@ -4929,8 +4425,7 @@ func (wi *WebInterface) handleMoveCommand(conn *websocket.Conn, playerID string,
 				entity.Position.Y += (dy / distance) * moveDistance
 
 				// Ensure entity stays within bounds
-				entity.Position.X = math.Max(0, math.Min(wi.world.Config.Width, entity.Position.X))
-				entity.Position.Y = math.Max(0, math.Min(wi.world.Config.Height, entity.Position.Y))
+				entity.Position = wi.world.KeepOnMap(entity.Position, 0)
 
 				moveCount++
 			}
@@ -4957,12 +4452,10 @@ func (wi *WebInterface) handleGatherCommand(conn *websocket.Conn, playerID strin
 	for _, entity := range population.Entities {
 		if entity.IsAlive && entity.Energy < 80 { // Only gather if not at high energy
 			// Find nearby plants to gather from
-			for _, plant := range wi.world.AllPlants {
+			for _, plant := range wi.world.spatialIndex().QueryPlantRadius(entity.Position, 5.0) {
 				if plant.IsAlive {
 					// Calculate distance to plant
-					dx := plant.Position.X - entity.Position.X
-					dy := plant.Position.Y - entity.Position.Y
-					distance := math.Sqrt(dx*dx + dy*dy)
+					distance := wi.world.Distance(entity.Position, plant.Position)
 
 					if distance <= 5.0 { // Within gathering range
 						// Entity gains energy, plant loses energy
@@ -4997,17 +4490,19 @@ func (wi *WebInterface) handleGatherCommand(conn *websocket.Conn, playerID strin
 // handleReproduceCommand handles reproduction commands for player species
 func (wi *WebInterface) handleReproduceCommand(conn *websocket.Conn, playerID string, population *Population, controlData map[string]interface{}) {
 	reproductionCount := 0
+	members := make(map[int]bool, len(population.Entities))
+	for _, entity := range population.Entities {
+		members[entity.ID] = true
+	}
 
 	// Find entities with sufficient energy for reproduction
 	for _, entity := range population.Entities {
 		if entity.IsAlive && entity.Energy > 70 { // High energy threshold for reproduction
 			// Find nearby entities of the same species for mating
-			for _, mate := range population.Entities {
-				if mate.IsAlive && mate.ID != entity.ID && mate.Energy > 70 {
+			for _, mate := range wi.world.spatialIndex().QueryRadius(entity.Position, 3.0) {
+				if members[mate.ID] && mate.IsAlive && mate.ID != entity.ID && mate.Energy > 70 {
 					// Calculate distance to potential mate
-					dx := mate.Position.X - entity.Position.X
-					dy := mate.Position.Y - entity.Position.Y
-					distance := math.Sqrt(dx*dx + dy*dy)
+					distance := wi.world.Distance(entity.Position, mate.Position)
 
 					if distance <= 3.0 { // Within mating range
 						// Create offspring through the existing reproduction system
@@ -5016,6 +4511,7 @@ func (wi *WebInterface) handleReproduceCommand(conn *websocket.Conn, playerID st
 							// Add offspring to population
 							population.Entities = append(population.Entities, offspring)
 							wi.world.AllEntities = append(wi.world.AllEntities, offspring)
+							members[offspring.ID] = true
 
 							// Reduce parent energy
 							entity.Energy -= 30
@@ -5106,6 +4602,8 @@ func (wi *WebInterface) handlePlayerEvent(eventType string, data map[string]inte
 				log.Printf("Error adding subspecies %s to player %s: %v", speciesName, playerID, err)
 				return
 			}
+			wi.world.SpeciesPolicies.Inherit(parentSpecies, speciesName)
+			wi.world.CompetitiveGame.AddSpecies(playerID, speciesName)
 
 			notification := map[string]interface{}{
 				"type":           "subspecies_formed",
@@ -5131,6 +4629,17 @@ func (wi *WebInterface) handlePlayerEvent(eventType string, data map[string]inte
 			"tick":         data["tick"],
 		}
 		wi.sendJSONToClient(playerWS, notification)
+
+	case "achievement_unlocked":
+		notification := map[string]interface{}{
+			"type":         "achievement_unlocked",
+			"species_name": speciesName,
+			"milestone":    data["milestone"],
+			"name":         data["name"],
+			"message":      fmt.Sprintf("Achievement unlocked: %s! %s", data["name"], data["detail"]),
+			"tick":         data["tick"],
+		}
+		wi.sendJSONToClient(playerWS, notification)
 	}
 }
 
@@ -5192,6 +4701,53 @@ func (wi *WebInterface) zoomOut() {
 	}
 }
 
+// followEntity locks the shared camera onto a living creature, letting go of the one it
+// followed before
+func (wi *WebInterface) followEntity(id int) error {
+	if id == wi.followedID {
+		return nil
+	}
+	if err := wi.world.Follow.Follow(wi.world, id); err != nil {
+		return err
+	}
+	wi.unfollowEntity()
+	wi.followedID = id
+	return nil
+}
+
+// unfollowEntity lets the shared camera move freely again
+func (wi *WebInterface) unfollowEntity() {
+	if wi.followedID != 0 {
+		wi.world.Follow.Unfollow(wi.followedID)
+		wi.followedID = 0
+	}
+}
+
+// followCamera centres the shared viewport on the followed creature while it lives, and
+// returns what the camera follows
+func (wi *WebInterface) followCamera() FollowState {
+	wi.tickMutex.Lock()
+	defer wi.tickMutex.Unlock()
+	state := FollowState{EntityID: wi.followedID}
+	if wi.followedID == 0 {
+		return state
+	}
+	state.Track = wi.world.Follow.Track(wi.followedID)
+	if state.Track == nil {
+		// The world was reset or reloaded under the camera
+		wi.followedID = 0
+		return FollowState{}
+	}
+	if frames := state.Track.Frames; state.Track.Alive && len(frames) > 0 {
+		position := frames[len(frames)-1].Position
+		x, y := wi.world.worldToGridCoords(position.X, position.Y)
+		wi.viewportX = x - int(float64(wi.world.Config.GridWidth)/wi.zoomLevel)/2
+		wi.viewportY = y - int(float64(wi.world.Config.GridHeight)/wi.zoomLevel)/2
+		wi.clampViewport()
+	}
+	return state
+}
+
 // resetViewport resets viewport to center of world at 1x zoom
 func (wi *WebInterface) resetViewport() {
 	wi.zoomLevel = 1.0
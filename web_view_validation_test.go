@@ -3,6 +3,8 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -639,3 +641,35 @@ func validateNeuralView(data *ViewData) []string {
 
 	return errors
 }
+
+// TestEntityDetailAPI tests the endpoint behind #entity/ID deep links
+func TestEntityDetailAPI(t *testing.T) {
+	world := NewWorld(WorldConfig{Width: 30, Height: 30, PopulationSize: 3, GridWidth: 6, GridHeight: 6})
+	world.AddPopulation(startingPopulations(false)[0])
+	routes := NewWebInterface(world).Routes()
+	entity := world.AllEntities[0]
+
+	rec := httptest.NewRecorder()
+	routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/entity/%d", entity.ID), nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Entity detail failed: %d %s", rec.Code, rec.Body.String())
+	}
+	var detail EntityDetailData
+	if err := json.Unmarshal(rec.Body.Bytes(), &detail); err != nil {
+		t.Fatalf("Invalid entity detail: %v", err)
+	}
+	if detail.ID != entity.ID || detail.Species != entity.Species || len(detail.Traits) != len(entity.Traits) {
+		t.Errorf("Expected details of entity %d (%s), got %+v", entity.ID, entity.Species, detail)
+	}
+
+	// The entity list describes each entity the same way
+	rec = httptest.NewRecorder()
+	routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/entities?limit=1", nil))
+	var page EntityListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil || len(page.Entities) != 1 {
+		t.Fatalf("Invalid entity list: %v %s", err, rec.Body.String())
+	}
+	if listed := page.Entities[0]; listed.ID != detail.ID || listed.Species != detail.Species || len(listed.Traits) != len(detail.Traits) {
+		t.Errorf("Expected the listed entity to match its detail, got %+v", listed)
+	}
+}
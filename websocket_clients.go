@@ -0,0 +1,282 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocketSettings bounds how long a client may stay silent and how far behind it may fall
+// before it is disconnected
+type WebSocketSettings struct {
+	PingInterval     time.Duration // How often the server pings each client
+	PongTimeout      time.Duration // How long a client may go without answering a ping or sending anything
+	WriteTimeout     time.Duration // How long a single write may block
+	QueueSize        int           // Messages waiting to be written to one client
+	FrameBacklog     int           // Queued messages beyond which view updates are skipped
+	MaxSkippedFrames int           // Consecutive view updates a client may miss before it is evicted
+}
+
+// DefaultWebSocketSettings pings every 25 seconds and evicts a client that has missed five
+// seconds of view updates at 10 FPS
+func DefaultWebSocketSettings() WebSocketSettings {
+	return WebSocketSettings{
+		PingInterval:     25 * time.Second,
+		PongTimeout:      60 * time.Second,
+		WriteTimeout:     10 * time.Second,
+		QueueSize:        64,
+		FrameBacklog:     8,
+		MaxSkippedFrames: 50,
+	}
+}
+
+// WebSocketStats summarises the connected clients for the status endpoint
+type WebSocketStats struct {
+	Clients       int   `json:"clients"`
+	Evicted       int64 `json:"evicted"`        // Clients disconnected for falling behind or going silent
+	SkippedFrames int64 `json:"skipped_frames"` // View updates not sent to clients with a backlog
+}
+
+// queueResult is what happened to a message offered to a client
+type queueResult int
+
+const (
+	messageQueued queueResult = iota
+	messageSkipped
+	clientTooSlow
+)
+
+// wsClient is one WebSocket connection with its own send queue. Only its write pump writes
+// to the connection, so a slow client holds up nobody but itself.
+type wsClient struct {
+	conn          *websocket.Conn
+	send          chan []byte
+	done          chan struct{}
+	closeOnce     sync.Once
+	skippedFrames int // Consecutive view updates skipped, guarded by frameMutex
+	frameMutex    sync.Mutex
+
+	// Chunk streaming, guarded by chunkMutex: the subscribed chunks, nil when the client takes
+	// the viewport's grid, and the hash of each chunk as last sent
+	chunkMutex  sync.Mutex
+	chunkKeys   []ChunkKey
+	chunkHashes map[ChunkKey]uint64
+}
+
+// newWSClient creates a client with room for queueSize pending messages
+func newWSClient(conn *websocket.Conn, queueSize int) *wsClient {
+	return &wsClient{
+		conn: conn,
+		send: make(chan []byte, queueSize),
+		done: make(chan struct{}),
+	}
+}
+
+// queue offers a message the client must receive, such as a response to its request. A client
+// whose queue is full cannot keep up.
+func (c *wsClient) queue(message []byte) queueResult {
+	select {
+	case c.send <- message:
+		return messageQueued
+	default:
+		return clientTooSlow
+	}
+}
+
+// queueFrame offers a view update. Updates supersede one another, so one is skipped rather than
+// queued behind a backlog; a client that misses too many in a row cannot keep up.
+func (c *wsClient) queueFrame(message []byte, settings WebSocketSettings) queueResult {
+	c.frameMutex.Lock()
+	defer c.frameMutex.Unlock()
+
+	if len(c.send) >= settings.FrameBacklog {
+		c.skippedFrames++
+		if c.skippedFrames > settings.MaxSkippedFrames {
+			return clientTooSlow
+		}
+		return messageSkipped
+	}
+	result := c.queue(message)
+	if result == messageQueued {
+		c.skippedFrames = 0
+	}
+	return result
+}
+
+// close stops the write pump; it is safe to call more than once
+func (c *wsClient) close() {
+	c.closeOnce.Do(func() { close(c.done) })
+}
+
+// writePump writes queued messages and heartbeat pings until the client is closed or a write
+// fails
+func (wi *WebInterface) writePump(client *wsClient) {
+	ticker := time.NewTicker(wi.wsSettings.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case message := <-client.send:
+			_ = client.conn.SetWriteDeadline(time.Now().Add(wi.wsSettings.WriteTimeout))
+			if err := client.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				log.Printf("Error sending data to client: %v", err)
+				wi.evictClient(client, "write failed")
+				return
+			}
+
+		case <-ticker.C:
+			_ = client.conn.SetWriteDeadline(time.Now().Add(wi.wsSettings.WriteTimeout))
+			if err := client.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				wi.evictClient(client, "ping failed")
+				return
+			}
+
+		case <-client.done:
+			return
+		}
+	}
+}
+
+// startHeartbeat expects a pong or a message from the client within the pong timeout. Reads
+// then fail for a client that has gone silent, which ends its connection.
+func (wi *WebInterface) startHeartbeat(conn *websocket.Conn) {
+	_ = conn.SetReadDeadline(time.Now().Add(wi.wsSettings.PongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wi.wsSettings.PongTimeout))
+	})
+}
+
+// registerClient adds a connection to the clients and starts writing to it
+func (wi *WebInterface) registerClient(conn *websocket.Conn) *wsClient {
+	client := newWSClient(conn, wi.wsSettings.QueueSize)
+	wi.clientsMutex.Lock()
+	wi.clients[conn] = client
+	wi.clientsMutex.Unlock()
+
+	go wi.writePump(client)
+	return client
+}
+
+// unregisterClient removes a connection and any player joined on it
+func (wi *WebInterface) unregisterClient(client *wsClient) {
+	client.close()
+	wi.clientsMutex.Lock()
+	delete(wi.clients, client.conn)
+	if playerID, exists := wi.clientPlayers[client.conn]; exists {
+		wi.playerManager.RemovePlayer(playerID)
+		delete(wi.clientPlayers, client.conn)
+	}
+	wi.clientsMutex.Unlock()
+}
+
+// evictClient disconnects a client that cannot keep up. Closing the connection ends its read
+// loop, which unregisters it.
+func (wi *WebInterface) evictClient(client *wsClient, reason string) {
+	evicted := false
+	client.closeOnce.Do(func() {
+		close(client.done)
+		evicted = true
+	})
+	if !evicted {
+		return
+	}
+	atomic.AddInt64(&wi.evictedClients, 1)
+	log.Printf("Evicting WebSocket client: %s", reason)
+
+	deadline := time.Now().Add(time.Second)
+	_ = client.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, reason), deadline)
+	_ = client.conn.Close()
+}
+
+// disconnectClients closes every connection, e.g. when the world is shut down. Each read
+// loop then ends and unregisters its client.
+func (wi *WebInterface) disconnectClients(reason string) {
+	wi.clientsMutex.RLock()
+	clients := make([]*wsClient, 0, len(wi.clients))
+	for _, client := range wi.clients {
+		clients = append(clients, client)
+	}
+	wi.clientsMutex.RUnlock()
+
+	deadline := time.Now().Add(time.Second)
+	for _, client := range clients {
+		client.close()
+		_ = client.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, reason), deadline)
+		_ = client.conn.Close()
+	}
+}
+
+// notifyClients queues a message for every connected client, such as an announcement
+func (wi *WebInterface) notifyClients(data interface{}) {
+	message, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Error encoding message for clients: %v", err)
+		return
+	}
+	wi.clientsMutex.RLock()
+	clients := make([]*wsClient, 0, len(wi.clients))
+	for _, client := range wi.clients {
+		clients = append(clients, client)
+	}
+	wi.clientsMutex.RUnlock()
+
+	for _, client := range clients {
+		if client.queue(message) == clientTooSlow {
+			wi.evictClient(client, "send queue full")
+		}
+	}
+}
+
+// clientForConn looks up the client of a connection
+func (wi *WebInterface) clientForConn(conn *websocket.Conn) (*wsClient, bool) {
+	wi.clientsMutex.RLock()
+	defer wi.clientsMutex.RUnlock()
+	client, exists := wi.clients[conn]
+	return client, exists
+}
+
+// queueMessage encodes a message for a client, evicting it if its queue is full
+func (wi *WebInterface) queueMessage(conn *websocket.Conn, data interface{}) {
+	client, exists := wi.clientForConn(conn)
+	if !exists {
+		return // Connection no longer exists
+	}
+	message, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Error encoding message for client: %v", err)
+		return
+	}
+	if client.queue(message) == clientTooSlow {
+		wi.evictClient(client, "send queue full")
+	}
+}
+
+// queueFrame offers an encoded view update to a client, skipping it or evicting the client
+// when it has fallen behind, and reports whether the update was queued
+func (wi *WebInterface) queueFrame(client *wsClient, message []byte) bool {
+	switch client.queueFrame(message, wi.wsSettings) {
+	case messageSkipped:
+		atomic.AddInt64(&wi.skippedFrames, 1)
+	case clientTooSlow:
+		wi.evictClient(client, "too far behind")
+	default:
+		return true
+	}
+	return false
+}
+
+// WebSocketStats counts the connected clients and how many have fallen behind
+func (wi *WebInterface) WebSocketStats() WebSocketStats {
+	wi.clientsMutex.RLock()
+	clients := len(wi.clients)
+	wi.clientsMutex.RUnlock()
+	return WebSocketStats{
+		Clients:       clients,
+		Evicted:       atomic.LoadInt64(&wi.evictedClients),
+		SkippedFrames: atomic.LoadInt64(&wi.skippedFrames),
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// dialTestWebSocket connects to a test server serving handler
+func dialTestWebSocket(t *testing.T, handler http.HandlerFunc) (*websocket.Conn, func()) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		server.Close()
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	return conn, func() {
+		conn.Close()
+		server.Close()
+	}
+}
+
+func TestWSClientQueueBackpressure(t *testing.T) {
+	settings := WebSocketSettings{QueueSize: 4, FrameBacklog: 2, MaxSkippedFrames: 3}
+	client := newWSClient(nil, settings.QueueSize)
+
+	var results []queueResult
+	for i := 0; i < 6; i++ {
+		results = append(results, client.queueFrame([]byte("{}"), settings))
+	}
+	want := []queueResult{messageQueued, messageQueued, messageSkipped, messageSkipped, messageSkipped, clientTooSlow}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Fatalf("Expected frames queued up to the backlog, then skipped, then the client too slow, got %v", results)
+		}
+	}
+
+	<-client.send
+	if client.queueFrame([]byte("{}"), settings) != messageQueued || client.skippedFrames != 0 {
+		t.Errorf("Expected a frame queued once the backlog drains, resetting the skipped count")
+	}
+	for client.queue([]byte("{}")) == messageQueued {
+	}
+	if len(client.send) != settings.QueueSize {
+		t.Errorf("Expected responses queued past the frame backlog up to the queue size, got %d", len(client.send))
+	}
+}
+
+func TestWebSocketHeartbeatDisconnectsSilentClients(t *testing.T) {
+	wi := NewWebInterface(newGraphQLTestWorld())
+	wi.wsSettings.PingInterval = 20 * time.Millisecond
+	wi.wsSettings.PongTimeout = 150 * time.Millisecond
+
+	live, closeLive := dialTestWebSocket(t, wi.handleWebSocketUpgrade)
+	defer closeLive()
+	var pings int32
+	live.SetPingHandler(func(data string) error {
+		atomic.AddInt32(&pings, 1)
+		return live.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	go func() {
+		for {
+			if _, _, err := live.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// A client that never reads never answers a ping
+	_, closeSilent := dialTestWebSocket(t, wi.handleWebSocketUpgrade)
+	defer closeSilent()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for wi.WebSocketStats().Clients != 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(3 * wi.wsSettings.PongTimeout)
+	if clients := wi.WebSocketStats().Clients; clients != 1 || atomic.LoadInt32(&pings) < 2 {
+		t.Errorf("Expected only the client answering pings to stay connected, got %d clients after %d pings", clients, atomic.LoadInt32(&pings))
+	}
+}
+
+func TestBroadcastEvictsClientsThatFallBehind(t *testing.T) {
+	wi := NewWebInterface(newGraphQLTestWorld())
+	wi.wsSettings.FrameBacklog = 2
+	wi.wsSettings.MaxSkippedFrames = 3
+
+	healthy, closeHealthy := dialTestWebSocket(t, wi.handleWebSocketUpgrade)
+	defer closeHealthy()
+	_ = healthy.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, _, err := healthy.ReadMessage(); err != nil {
+		t.Fatalf("Failed to read the initial view: %v", err)
+	}
+
+	// A client whose write pump has stalled, so nothing leaves its queue
+	stalled := make(chan *wsClient, 1)
+	browser, closeBrowser := dialTestWebSocket(t, func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		client := newWSClient(conn, wi.wsSettings.QueueSize)
+		wi.clientsMutex.Lock()
+		wi.clients[conn] = client
+		wi.clientsMutex.Unlock()
+		stalled <- client
+	})
+	defer closeBrowser()
+	client := <-stalled
+
+	for i := 0; i < 10; i++ {
+		wi.broadcastToClients(&ViewData{Tick: i})
+		var frame ViewData
+		if err := healthy.ReadJSON(&frame); err != nil || frame.Tick != i {
+			t.Fatalf("Expected the healthy client to receive frame %d, got %d (%v)", i, frame.Tick, err)
+		}
+	}
+
+	select {
+	case <-client.done:
+	default:
+		t.Fatalf("Expected the stalled client evicted")
+	}
+	if stats := wi.WebSocketStats(); stats.Evicted != 1 || stats.SkippedFrames != 3 {
+		t.Errorf("Expected one eviction after three skipped frames, got %+v", stats)
+	}
+	_ = browser.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, _, err := browser.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseTryAgainLater) {
+		t.Errorf("Expected the evicted client told to try again later, got %v", err)
+	}
+}
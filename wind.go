@@ -95,17 +95,19 @@ type WindSystem struct {
 
 	// Event tracking
 	EventBus *CentralEventBus
+
+	rng *rand.Rand // The wind's RNG stream, or the world's source
 }
 
 // NewWindSystem creates a new wind and pollen dispersal system
-func NewWindSystem(worldWidth, worldHeight int, eventBus *CentralEventBus) *WindSystem {
+func NewWindSystem(rng *rand.Rand, worldWidth, worldHeight int, eventBus *CentralEventBus) *WindSystem {
 	cellSize := 10.0 // Each wind cell covers 10x10 world units
 	mapWidth := int(math.Ceil(float64(worldWidth) / cellSize))
 	mapHeight := int(math.Ceil(float64(worldHeight) / cellSize))
 
 	ws := &WindSystem{
-		BaseWindDirection:  rand.Float64() * 2 * math.Pi, // Random initial direction
-		BaseWindStrength:   0.3 + rand.Float64()*0.4,     // 0.3-0.7 strength
+		BaseWindDirection:  rng.Float64() * 2 * math.Pi, // Random initial direction
+		BaseWindStrength:   0.3 + rng.Float64()*0.4,     // 0.3-0.7 strength
 		TurbulenceLevel:    0.2,
 		MapWidth:           mapWidth,
 		MapHeight:          mapHeight,
@@ -116,9 +118,10 @@ func NewWindSystem(worldWidth, worldHeight int, eventBus *CentralEventBus) *Wind
 		NextCloudID:        1,
 		SeasonalMultiplier: 1.0,
 		WeatherPattern:     0, // Start calm
-		WeatherDuration:    100 + rand.Intn(200),
+		WeatherDuration:    100 + rng.Intn(200),
 		RegionalStorms:     make([]RegionalStorm, 0),
 		EventBus:           eventBus,
+		rng:                rng,
 	}
 
 	// Initialize wind map
@@ -137,8 +140,8 @@ func (ws *WindSystem) Update(season Season, tick int) {
 	ws.updateSeasonalEffects(season)
 
 	// Add small random variations to base wind values every tick
-	if rand.Float64() < 0.1 { // 10% chance each tick for small variation
-		ws.BaseWindDirection += (rand.Float64() - 0.5) * 0.05 // Very small direction change
+	if ws.rng.Float64() < 0.1 { // 10% chance each tick for small variation
+		ws.BaseWindDirection += (ws.rng.Float64() - 0.5) * 0.05 // Very small direction change
 		if ws.BaseWindDirection < 0 {
 			ws.BaseWindDirection += 2 * math.Pi
 		}
@@ -147,7 +150,7 @@ func (ws *WindSystem) Update(season Season, tick int) {
 		}
 
 		// Very small strength variation
-		strengthChange := (rand.Float64() - 0.5) * 0.02 // ±0.01 change
+		strengthChange := (ws.rng.Float64() - 0.5) * 0.02 // ±0.01 change
 		ws.BaseWindStrength += strengthChange
 		if ws.BaseWindStrength < 0.1 {
 			ws.BaseWindStrength = 0.1
@@ -164,7 +167,7 @@ func (ws *WindSystem) Update(season Season, tick int) {
 	ws.updateRegionalStorms()
 
 	// Potentially spawn new regional storms
-	if rand.Float64() < 0.01 { // 1% chance per tick
+	if ws.rng.Float64() < 0.01 { // 1% chance per tick
 		ws.spawnRegionalStorm()
 	}
 
@@ -189,12 +192,12 @@ func (ws *WindSystem) generateWindPattern() {
 		for x := 0; x < ws.MapWidth; x++ {
 			// Base wind with some spatial variation
 			direction := ws.BaseWindDirection +
-				(rand.Float64()-0.5)*ws.TurbulenceLevel*math.Pi
+				(ws.rng.Float64()-0.5)*ws.TurbulenceLevel*math.Pi
 
 			strength := ws.BaseWindStrength * ws.SeasonalMultiplier
 
 			// Add terrain effects (simplified)
-			terrainEffect := 1.0 + (rand.Float64()-0.5)*0.3
+			terrainEffect := 1.0 + (ws.rng.Float64()-0.5)*0.3
 			strength *= terrainEffect
 
 			// Weather pattern effects
@@ -203,7 +206,7 @@ func (ws *WindSystem) generateWindPattern() {
 				strength *= 1.5
 			case 2: // Storm
 				strength *= 2.5
-				direction += (rand.Float64() - 0.5) * 0.5 // More turbulent
+				direction += (ws.rng.Float64() - 0.5) * 0.5 // More turbulent
 			case 3: // Tornado (regional, handled separately)
 				strength *= 1.2
 			case 4: // Hurricane (regional, handled separately)
@@ -252,8 +255,8 @@ func (ws *WindSystem) ReleasePollen(plant *Plant, amount int, tick int) {
 
 	for i := 0; i < amount; i++ {
 		// Create pollen grain with slight random offset from plant
-		offset := 0.5 + rand.Float64()*1.0
-		angle := rand.Float64() * 2 * math.Pi
+		offset := 0.5 + ws.rng.Float64()*1.0
+		angle := ws.rng.Float64() * 2 * math.Pi
 
 		pos := Position{
 			X: plant.Position.X + math.Cos(angle)*offset,
@@ -269,8 +272,8 @@ func (ws *WindSystem) ReleasePollen(plant *Plant, amount int, tick int) {
 			Genetics:  ws.copyGenetics(plant.Traits),
 			Viability: 1.0,
 			Age:       0,
-			MaxAge:    50 + rand.Intn(100), // 50-150 ticks lifespan
-			Size:      0.1 + rand.Float64()*0.1,
+			MaxAge:    50 + ws.rng.Intn(100), // 50-150 ticks lifespan
+			Size:      0.1 + ws.rng.Float64()*0.1,
 		}
 
 		ws.AllPollenGrains = append(ws.AllPollenGrains, pollen)
@@ -324,8 +327,8 @@ func (ws *WindSystem) updatePollenGrains() {
 
 		// Add some random turbulence
 		turbulence := Vector2D{
-			X: (rand.Float64() - 0.5) * wind.Turbulence,
-			Y: (rand.Float64() - 0.5) * wind.Turbulence,
+			X: (ws.rng.Float64() - 0.5) * wind.Turbulence,
+			Y: (ws.rng.Float64() - 0.5) * wind.Turbulence,
 		}
 		windForce = windForce.Add(turbulence)
 
@@ -365,7 +368,7 @@ func (ws *WindSystem) updatePollenClouds() {
 }
 
 // TryPollination attempts cross-pollination between pollen and plants
-func (ws *WindSystem) TryPollination(plants []*Plant, speciationSystem *SpeciationSystem, tick int) []*Plant {
+func (ws *WindSystem) TryPollination(plants []*Plant, speciationSystem *SpeciationSystem, tick int, geometry WorldGeometry) []*Plant {
 	newOffspring := make([]*Plant, 0)
 
 	for _, grain := range ws.AllPollenGrains {
@@ -379,10 +382,7 @@ func (ws *WindSystem) TryPollination(plants []*Plant, speciationSystem *Speciati
 				continue // Skip source plant and dead plants
 			}
 
-			distance := math.Sqrt(
-				math.Pow(plant.Position.X-grain.Position.X, 2) +
-					math.Pow(plant.Position.Y-grain.Position.Y, 2),
-			)
+			distance := geometry.Distance(plant.Position, grain.Position)
 
 			// Pollination range depends on plant size and pollen viability
 			pollinationRange := (plant.Size + 1.0) * grain.Viability
@@ -427,13 +427,13 @@ func (ws *WindSystem) crossPollinate(motherPlant *Plant, pollenGrain *PollenGrai
 	}
 
 	// Check if mother plant is ready for reproduction
-	if !motherPlant.CanReproduce() {
+	if !motherPlant.CanReproduce(ws.rng) {
 		return nil
 	}
 
 	// Create offspring location near mother plant
-	offset := 1.0 + rand.Float64()*2.0
-	angle := rand.Float64() * 2 * math.Pi
+	offset := 1.0 + ws.rng.Float64()*2.0
+	angle := ws.rng.Float64() * 2 * math.Pi
 
 	newPos := Position{
 		X: motherPlant.Position.X + math.Cos(angle)*offset,
@@ -442,13 +442,13 @@ func (ws *WindSystem) crossPollinate(motherPlant *Plant, pollenGrain *PollenGrai
 
 	// Determine offspring type (usually mother's type, sometimes hybrid)
 	var offspringType PlantType
-	if rand.Float64() < 0.9 {
+	if ws.rng.Float64() < 0.9 {
 		offspringType = motherPlant.Type
 	} else {
 		offspringType = pollenGrain.PlantType
 	}
 
-	offspring := NewPlant(ws.NextPollenID, offspringType, newPos)
+	offspring := NewPlant(ws.rng, ws.NextPollenID, offspringType, newPos)
 	ws.NextPollenID++
 	offspring.Generation = motherPlant.Generation + 1
 
@@ -456,7 +456,7 @@ func (ws *WindSystem) crossPollinate(motherPlant *Plant, pollenGrain *PollenGrai
 	motherTraits := motherPlant.Traits
 	fatherTraits := pollenGrain.Genetics
 
-	for traitName := range motherTraits {
+	for _, traitName := range sortedKeys(motherTraits) {
 		var newValue float64
 
 		motherValue := motherTraits[traitName].Value
@@ -467,14 +467,14 @@ func (ws *WindSystem) crossPollinate(motherPlant *Plant, pollenGrain *PollenGrai
 		}
 
 		// Genetic mixing with some randomization
-		if rand.Float64() < 0.5 {
+		if ws.rng.Float64() < 0.5 {
 			newValue = motherValue // Mother's trait
 		} else {
 			newValue = fatherValue // Father's trait
 		}
 
 		// Add some genetic variation
-		newValue += rand.NormFloat64() * 0.05
+		newValue += ws.rng.NormFloat64() * 0.05
 		newValue = math.Max(-1.0, math.Min(1.0, newValue))
 
 		offspring.SetTrait(traitName, newValue)
@@ -574,45 +574,45 @@ func (ws *WindSystem) updateWeatherPattern(tick int) {
 		// Weather transition probabilities
 		switch oldPattern {
 		case 0: // Calm -> Windy (60%) or Storm (10%) or Tornado (1%) or Hurricane (0.5%)
-			if rand.Float64() < 0.6 {
+			if ws.rng.Float64() < 0.6 {
 				ws.WeatherPattern = 1
-			} else if rand.Float64() < 0.1 {
+			} else if ws.rng.Float64() < 0.1 {
 				ws.WeatherPattern = 2
-			} else if rand.Float64() < 0.01 {
+			} else if ws.rng.Float64() < 0.01 {
 				ws.WeatherPattern = 3 // Tornado
-			} else if rand.Float64() < 0.005 {
+			} else if ws.rng.Float64() < 0.005 {
 				ws.WeatherPattern = 4 // Hurricane
 			}
 		case 1: // Windy -> Calm (40%) or Storm (20%) or Tornado (2%)
-			if rand.Float64() < 0.4 {
+			if ws.rng.Float64() < 0.4 {
 				ws.WeatherPattern = 0
-			} else if rand.Float64() < 0.2 {
+			} else if ws.rng.Float64() < 0.2 {
 				ws.WeatherPattern = 2
-			} else if rand.Float64() < 0.02 {
+			} else if ws.rng.Float64() < 0.02 {
 				ws.WeatherPattern = 3 // Tornado
 			}
 		case 2: // Storm -> Windy (50%) or Calm (30%) or Tornado (5%) or Hurricane (2%)
-			if rand.Float64() < 0.5 {
+			if ws.rng.Float64() < 0.5 {
 				ws.WeatherPattern = 1
-			} else if rand.Float64() < 0.3 {
+			} else if ws.rng.Float64() < 0.3 {
 				ws.WeatherPattern = 0
-			} else if rand.Float64() < 0.05 {
+			} else if ws.rng.Float64() < 0.05 {
 				ws.WeatherPattern = 3 // Tornado
-			} else if rand.Float64() < 0.02 {
+			} else if ws.rng.Float64() < 0.02 {
 				ws.WeatherPattern = 4 // Hurricane
 			}
 		case 3: // Tornado -> Windy (70%) or Storm (20%) or Calm (10%)
-			if rand.Float64() < 0.7 {
+			if ws.rng.Float64() < 0.7 {
 				ws.WeatherPattern = 1
-			} else if rand.Float64() < 0.2 {
+			} else if ws.rng.Float64() < 0.2 {
 				ws.WeatherPattern = 2
 			} else {
 				ws.WeatherPattern = 0
 			}
 		case 4: // Hurricane -> Storm (60%) or Windy (30%) or Calm (10%)
-			if rand.Float64() < 0.6 {
+			if ws.rng.Float64() < 0.6 {
 				ws.WeatherPattern = 2
-			} else if rand.Float64() < 0.3 {
+			} else if ws.rng.Float64() < 0.3 {
 				ws.WeatherPattern = 1
 			} else {
 				ws.WeatherPattern = 0
@@ -622,19 +622,19 @@ func (ws *WindSystem) updateWeatherPattern(tick int) {
 		// Set new weather duration
 		switch ws.WeatherPattern {
 		case 0: // Calm
-			ws.WeatherDuration = 150 + rand.Intn(200)
+			ws.WeatherDuration = 150 + ws.rng.Intn(200)
 		case 1: // Windy
-			ws.WeatherDuration = 100 + rand.Intn(150)
+			ws.WeatherDuration = 100 + ws.rng.Intn(150)
 		case 2: // Storm
-			ws.WeatherDuration = 30 + rand.Intn(70)
+			ws.WeatherDuration = 30 + ws.rng.Intn(70)
 		case 3: // Tornado
-			ws.WeatherDuration = 10 + rand.Intn(20) // Short duration
+			ws.WeatherDuration = 10 + ws.rng.Intn(20) // Short duration
 		case 4: // Hurricane
-			ws.WeatherDuration = 50 + rand.Intn(100) // Longer duration
+			ws.WeatherDuration = 50 + ws.rng.Intn(100) // Longer duration
 		}
 
 		// When weather changes, also slightly adjust base wind direction and strength
-		ws.BaseWindDirection += (rand.Float64() - 0.5) * 0.3 // Small direction change
+		ws.BaseWindDirection += (ws.rng.Float64() - 0.5) * 0.3 // Small direction change
 		if ws.BaseWindDirection < 0 {
 			ws.BaseWindDirection += 2 * math.Pi
 		}
@@ -643,7 +643,7 @@ func (ws *WindSystem) updateWeatherPattern(tick int) {
 		}
 
 		// Adjust base strength slightly
-		strengthChange := (rand.Float64() - 0.5) * 0.2 // ±0.1 change
+		strengthChange := (ws.rng.Float64() - 0.5) * 0.2 // ±0.1 change
 		ws.BaseWindStrength += strengthChange
 		if ws.BaseWindStrength < 0.1 {
 			ws.BaseWindStrength = 0.1
@@ -750,7 +750,7 @@ func (ws *WindSystem) getRegionalStormEffect(pos Position) RegionalStormEffect {
 			case StormThunderstorm:
 				effect.Strength *= 1.0 + stormInfluence*0.8
 				effect.TurbulenceMultiplier *= 1.0 + stormInfluence*2.0
-				effect.DirectionChange += (rand.Float64() - 0.5) * stormInfluence * 0.3
+				effect.DirectionChange += (ws.rng.Float64() - 0.5) * stormInfluence * 0.3
 
 			case StormTornado:
 				// Tornado creates circular wind pattern
@@ -770,12 +770,12 @@ func (ws *WindSystem) getRegionalStormEffect(pos Position) RegionalStormEffect {
 			case StormBlizzard:
 				effect.Strength *= 1.0 + stormInfluence*1.5
 				effect.TurbulenceMultiplier *= 1.0 + stormInfluence*2.5
-				effect.DirectionChange += (rand.Float64() - 0.5) * stormInfluence * 0.4
+				effect.DirectionChange += (ws.rng.Float64() - 0.5) * stormInfluence * 0.4
 
 			case StormDustStorm:
 				effect.Strength *= 1.0 + stormInfluence*1.2
 				effect.TurbulenceMultiplier *= 1.0 + stormInfluence*2.0
-				effect.DirectionChange += (rand.Float64() - 0.5) * stormInfluence * 0.2
+				effect.DirectionChange += (ws.rng.Float64() - 0.5) * stormInfluence * 0.2
 			}
 		}
 	}
@@ -824,38 +824,38 @@ func (ws *WindSystem) spawnRegionalStorm() {
 
 	storm := RegionalStorm{
 		ID:          len(ws.RegionalStorms) + 1,
-		Center:      Position{X: rand.Float64() * worldWidth, Y: rand.Float64() * worldHeight},
-		MovementDir: rand.Float64() * 2 * math.Pi,
-		Speed:       0.5 + rand.Float64()*1.5,
-		Intensity:   0.2 + rand.Float64()*0.3, // Start with moderate intensity
+		Center:      Position{X: ws.rng.Float64() * worldWidth, Y: ws.rng.Float64() * worldHeight},
+		MovementDir: ws.rng.Float64() * 2 * math.Pi,
+		Speed:       0.5 + ws.rng.Float64()*1.5,
+		Intensity:   0.2 + ws.rng.Float64()*0.3, // Start with moderate intensity
 	}
 
 	// Choose storm type based on season and probability
-	switch rand.Intn(5) {
+	switch ws.rng.Intn(5) {
 	case 0:
 		storm.Type = StormThunderstorm
-		storm.Radius = 15 + rand.Float64()*25
-		storm.Duration = 30 + rand.Intn(50)
+		storm.Radius = 15 + ws.rng.Float64()*25
+		storm.Duration = 30 + ws.rng.Intn(50)
 		storm.MaxDuration = storm.Duration
 	case 1:
 		storm.Type = StormTornado
-		storm.Radius = 5 + rand.Float64()*15
-		storm.Duration = 10 + rand.Intn(20)
+		storm.Radius = 5 + ws.rng.Float64()*15
+		storm.Duration = 10 + ws.rng.Intn(20)
 		storm.MaxDuration = storm.Duration
 	case 2:
 		storm.Type = StormHurricane
-		storm.Radius = 40 + rand.Float64()*60
-		storm.Duration = 80 + rand.Intn(120)
+		storm.Radius = 40 + ws.rng.Float64()*60
+		storm.Duration = 80 + ws.rng.Intn(120)
 		storm.MaxDuration = storm.Duration
 	case 3:
 		storm.Type = StormBlizzard
-		storm.Radius = 20 + rand.Float64()*40
-		storm.Duration = 60 + rand.Intn(80)
+		storm.Radius = 20 + ws.rng.Float64()*40
+		storm.Duration = 60 + ws.rng.Intn(80)
 		storm.MaxDuration = storm.Duration
 	case 4:
 		storm.Type = StormDustStorm
-		storm.Radius = 25 + rand.Float64()*35
-		storm.Duration = 40 + rand.Intn(60)
+		storm.Radius = 25 + ws.rng.Float64()*35
+		storm.Duration = 40 + ws.rng.Intn(60)
 		storm.MaxDuration = storm.Duration
 	}
 
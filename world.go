@@ -2,8 +2,10 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"math"
 	"math/rand"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +19,18 @@ type WorldConfig struct {
 	PopulationSize int
 	GridWidth      int // Grid cells for visualization
 	GridHeight     int
+	Breakpoints    []string                // Breakpoint specs armed when the world is created (see ParseBreakpoint)
+	FogOfWar       bool                    // Limit each player's map to what their species can perceive
+	Profile        string                  // Tuning profile setting the timescales (see FindTuningProfile); empty for the standard tuning
+	CustomTraits   []CustomTraitDefinition // Traits defined in configuration rather than code
+	Geometry       string                  `json:",omitempty"` // Which edges of the map join (see GeometryBox); empty for a box
+	GridShape      string                  `json:",omitempty"` // Shape of the grid cells (see GridShapeHex); empty for squares
+	Seed           int64                   `json:"-"`          // Seed of the world's random source; 0 picks one from the clock (saves record World.Seed)
+
+	// Set by run config files (see LoadRunConfig); left out of saves when unset
+	Simulation      *SimulationConfig     `json:",omitempty"` // Simulation settings used instead of the defaults and profile
+	EventTimetable  []ScheduledWorldEvent `json:",omitempty"` // World events started at given ticks
+	RandomEventsOff bool                  `json:",omitempty"` // Suppress random world events
 }
 
 // BiomeType represents different environmental zones
@@ -123,6 +137,7 @@ type PopulationConfig struct {
 	Spread           float64 // How spread out they start
 	Color            string  // For visualization
 	BaseMutationRate float64 // Base mutation rate for this species
+	Selection        string  // Selection strategy (see SelectionStrategyNames); tournament when empty
 }
 
 // World represents the environment containing multiple populations
@@ -142,19 +157,38 @@ type World struct {
 	Tick            int
 	Clock           time.Time
 	LastUpdate      time.Time
-	Paused          bool    // Whether the simulation is paused
-	SpeedMultiplier float64 // Speed multiplier for simulation (1.0 = normal, 2.0 = 2x speed, etc.)
+	Paused          bool              // Whether the simulation is paused
+	RandomEventsOff bool              // Suppress random world events (used by controlled-experiment worlds)
+	RespawnOff      bool              // Don't top up shrinking populations (used by controlled-experiment worlds)
+	Deterministic   bool              // Update entities sequentially so seeded runs are reproducible
+	Seed            int64             // Seed of the world's random source
+	Rand            *rand.Rand        // The world's random source, safe for concurrent use; every subsystem of the world draws from it
+	randSource      *lockedSource     // Source behind Rand
+	RNG             *RNGStreams       // Per-subsystem random streams; nil draws everything from Rand
+	Breakpoints     *BreakpointSystem // Conditions that pause the simulation
+	SpeedMultiplier float64           // Speed multiplier for simulation (1.0 = normal, 2.0 = 2x speed, etc.)
+	Scheduler       *SystemScheduler  // Decides which of the less frequent subsystems run each tick
+	Recorder        *ReplayRecorder   // Writes each tick to a replay file when recording
+	Autosaver       *Autosaver        // Saves the world every few ticks when autosaving
 	// Advanced feature systems
 	CommunicationSystem   *CommunicationSystem
 	GroupBehaviorSystem   *GroupBehaviorSystem
 	PhysicsSystem         *PhysicsSystem
 	CollisionSystem       *CollisionSystem
 	PhysicsComponents     map[int]*PhysicsComponent // Entity ID -> Physics
+	Components            *EntityComponents         // Entity components in packed arrays, for the systems that loop over them
+	Spatial               *SpatialIndex             // Creatures and plants bucketed by cell for proximity queries
+	geometry              WorldGeometry             // Shape of the map, built from Config.Geometry (see Geometry)
+	gridLayout            GridLayout                // Shape of the grid cells, built from Config.GridShape (see GridLayout)
 	AdvancedTimeSystem    *AdvancedTimeSystem
+	DayNight              *DayNightSystem  // Daylight on each row of the grid through the day-night cycle
+	Seasons               *SeasonalSystem  // The year's seasons
+	Migrations            *MigrationSystem // Herds on the move and the routes each species has learned
 	CivilizationSystem    *CivilizationSystem
 	ViewportSystem        *ViewportSystem
 	WindSystem            *WindSystem            // Wind and pollen dispersal system
 	SeedDispersalSystem   *SeedDispersalSystem   // Advanced seed dispersal and germination
+	SeedCachingSystem     *SeedCachingSystem     // Scatter-hoarding and forgotten-cache recruitment
 	ChemicalEcologySystem *ChemicalEcologySystem // Chemical communication and ecology
 	SpeciationSystem      *SpeciationSystem      // Species evolution and tracking
 	PlantNetworkSystem    *PlantNetworkSystem    // Underground plant networks and communication
@@ -182,6 +216,7 @@ type World struct {
 	// Statistical Analysis System
 	StatisticalReporter    *StatisticalReporter         // Comprehensive statistical analysis and reporting
 	EcosystemMonitor       *EcosystemMonitor            // Advanced ecosystem metrics and health monitoring
+	MetricHistory          *MetricHistory               // Population, fitness, energy and trait history for charts
 	EnvironmentalPressures *EnvironmentalPressureSystem // Long-term environmental pressures and stresses
 	SymbioticRelationships *SymbioticRelationshipSystem // Parasitic and symbiotic relationships between entities
 
@@ -198,6 +233,37 @@ type World struct {
 	// Advanced Biome Interaction System
 	BiomeBoundarySystem *BiomeBoundarySystem // Biome boundary effects and ecotone interactions
 
+	// Movement corridor and habitat fragmentation analysis
+	MovementCorridorSystem *MovementCorridorSystem    // Realized movement corridors, choke points, and connectivity
+	OperatorStructures     *OperatorStructureSystem   // Operator-drawn barriers, corridors, and fragmentation experiments
+	Interventions          *InterventionSystem        // Undo/redo history of operator interventions
+	PlayerGroups           *PlayerGroupSystem         // Box-selected player groups and their standing orders
+	SpeciesPolicies        *SpeciesPolicySystem       // Player policy sliders biasing their species' decisions
+	FogOfWar               *FogOfWarSystem            // Areas each player's species have explored
+	CompetitiveGame        *CompetitiveGameSystem     // Competitive multiplayer games and their victory conditions
+	Tutorials              *TutorialSystem            // Guided scenarios whose steps complete on simulation events
+	Gallery                *SnapshotGallery           // Rendered snapshots captured on notable events
+	Achievements           *AchievementSystem         // Emergent milestones earned by the world and its players
+	Audio                  *AmbientAudioSystem        // The soundscape web clients play
+	Predictions            *SpectatorPredictionSystem // Spectator predictions on species outcomes, scored in points
+	HashChain              *StateHashChain            // Per-epoch state hashes behind tamper-evident run certificates
+	PopulationCaps         *PopulationCapSystem       // Soft population caps enforced by emigration and an offstage dispersal pool
+	ResourceBudget         *ResourceBudgetSystem      // Sunlight, water, nutrients and prey biomass capping growth and reproduction
+	Resources              *ResourceMonitor           // Store footprints, leak alerts and pruning policies for long runs
+	TraitRegistry          *TraitRegistry             // Names, ranges and meanings of every trait
+	Mutations              *MutationSystem            // Mutation operators, their rates and which produced each change
+	Follow                 *FollowSystem              // What creatures followed by a camera did each tick
+	CustomTraits           *CustomTraitSystem         // Inheritance, costs and hooks of traits defined in configuration
+	RedQueen               *RedQueenSystem            // Coupled trait changes between predators and prey, parasites and hosts
+	BiomeStates            *BiomeStateMachine         // Environmental pressure driving biome transitions
+	Microclimates          *MicroclimateSystem        // Local conditions under tree cover and around structures
+	Pollution              *PollutionSystem           // Waste of advanced tribes contaminating soil and water
+	LightPollution         *LightPollutionSystem      // Night glow of settlements disturbing nocturnal life
+	NoisePollution         *NoisePollutionSystem      // Disturbance around busy colonies and worn paths
+	Zoonoses               *ZoonosisSystem            // Pathogens spilling over between wildlife and colonies
+	FoodStorage            *FoodStorageSystem         // Spoilage of stored food, preservation and famine
+	Demographics           *DemographicsSystem        // Colony censuses, population pyramids and demographic transitions
+
 	// Organism classification and lifespan system
 	OrganismClassifier *OrganismClassifier // Organism classification and aging system
 
@@ -215,17 +281,29 @@ type World struct {
 
 // NewWorld creates a new world with multiple populations
 func NewWorld(config WorldConfig) *World {
+	if config.Simulation != nil {
+		simConfig := *config.Simulation
+		return NewWorldWithConfig(config, &simConfig)
+	}
+
 	// Create default simulation config for backward compatibility
 	simConfig := DefaultSimulationConfig()
 	simConfig.World.Width = config.Width
 	simConfig.World.Height = config.Height
 	simConfig.World.GridWidth = config.GridWidth
 	simConfig.World.GridHeight = config.GridHeight
+	if config.Profile != "" {
+		if err := simConfig.ApplyTuningProfile(config.Profile); err != nil {
+			log.Printf("Ignoring tuning profile: %v", err)
+		}
+	}
 
 	return NewWorldWithConfig(config, simConfig)
 }
 
 func NewWorldWithConfig(config WorldConfig, simConfig *SimulationConfig) *World {
+	seed := seedForRun(config.Seed)
+	source := newLockedSource(seed)
 	world := &World{
 		Config:                   config,
 		SimConfig:                simConfig,
@@ -244,7 +322,12 @@ func NewWorldWithConfig(config WorldConfig, simConfig *SimulationConfig) *World
 		LastUpdate:               time.Now(),
 		SpeedMultiplier:          1.0, // Default normal speed
 		PreviousPopulationCounts: make(map[string]int),
+		RandomEventsOff:          config.RandomEventsOff,
+		Seed:                     seed,
+		Rand:                     rand.New(source),
+		randSource:               source,
 	}
+	world.setGeometry()
 
 	// Initialize grid
 	for y := 0; y < config.GridHeight; y++ {
@@ -256,11 +339,11 @@ func NewWorldWithConfig(config WorldConfig, simConfig *SimulationConfig) *World
 				Plants:   make([]*Plant, 0),
 				Event:    nil,
 				// Initialize soil system
-				SoilNutrients:  initializeSoilNutrients(),
-				WaterLevel:     initializeWaterLevel(world.generateBiome(x, y)),
-				SoilPH:         7.0 + (rand.Float64()-0.5)*2.0, // pH 6-8
-				SoilCompaction: rand.Float64() * 0.3,           // 0-30% compaction
-				OrganicMatter:  rand.Float64() * 0.2,           // 0-20% organic matter
+				SoilNutrients:  initializeSoilNutrients(world.Rand),
+				WaterLevel:     initializeWaterLevel(world.Rand, world.generateBiome(x, y)),
+				SoilPH:         7.0 + (world.Rand.Float64()-0.5)*2.0, // pH 6-8
+				SoilCompaction: world.Rand.Float64() * 0.3,           // 0-30% compaction
+				OrganicMatter:  world.Rand.Float64() * 0.2,           // 0-20% organic matter
 			}
 		}
 	} // Initialize advanced systems
@@ -269,11 +352,18 @@ func NewWorldWithConfig(config WorldConfig, simConfig *SimulationConfig) *World
 	world.PhysicsSystem = NewPhysicsSystem()
 	world.CollisionSystem = NewCollisionSystem()
 	world.PhysicsComponents = make(map[int]*PhysicsComponent)
+	world.Spatial = NewSpatialIndex()
 	world.AdvancedTimeSystem = NewAdvancedTimeSystem(&simConfig.Time) // Use configuration for time system
+	world.AdvancedTimeSystem.SeasonLength = scaledPeriod(simConfig.Time.DaysPerSeason, simConfig.Timescales.SeasonScale)
+	world.AdvancedTimeSystem.CycleLength = simConfig.DayNight.DayLength
+	world.DayNight = NewDayNightSystem()
+	world.Migrations = NewMigrationSystem(world.CentralEventBus)
+	world.Seasons = NewSeasonalSystem(world.CentralEventBus, world.Migrations)
 	world.CivilizationSystem = NewCivilizationSystem(world.CentralEventBus)
 	world.ViewportSystem = NewViewportSystem(config.Width, config.Height)
-	world.WindSystem = NewWindSystem(int(config.Width), int(config.Height), world.CentralEventBus)
+	world.WindSystem = NewWindSystem(world.Rand, int(config.Width), int(config.Height), world.CentralEventBus)
 	world.SeedDispersalSystem = NewSeedDispersalSystem()
+	world.SeedCachingSystem = NewSeedCachingSystem()
 	world.ChemicalEcologySystem = NewChemicalEcologySystem()
 	world.SpeciationSystem = NewSpeciationSystem()
 	world.PlantNetworkSystem = NewPlantNetworkSystem(world.CentralEventBus)
@@ -292,6 +382,8 @@ func NewWorldWithConfig(config WorldConfig, simConfig *SimulationConfig) *World
 
 	// Initialize reproduction and decay system
 	world.ReproductionSystem = NewReproductionSystem(world.CentralEventBus)
+	world.ReproductionSystem.GestationScale = simConfig.Timescales.GestationScale
+	world.ReproductionSystem.DecayScale = simConfig.Timescales.DecayScale
 	world.FungalNetwork = NewFungalNetwork()
 
 	// Initialize cultural knowledge system
@@ -302,6 +394,10 @@ func NewWorldWithConfig(config WorldConfig, simConfig *SimulationConfig) *World
 	world.EcosystemMonitor = NewEcosystemMonitor(100)                       // Keep 100 historical snapshots
 	world.EnvironmentalPressures = NewEnvironmentalPressureSystem()         // Environmental pressure monitoring
 	world.SymbioticRelationships = NewSymbioticRelationshipSystem()         // Parasitic and symbiotic relationships
+	world.MetricHistory = NewMetricHistory(defaultMetricHistoryCapacity)    // Charted metrics, 2k samples each
+
+	// Schedule the less frequent subsystems, snapshots and analysis included
+	world.scheduler()
 
 	// Connect StatisticalReporter to CentralEventBus
 	world.CentralEventBus.AddListener(func(event CentralEvent) {
@@ -325,7 +421,10 @@ func NewWorldWithConfig(config WorldConfig, simConfig *SimulationConfig) *World
 
 	// Connect EventLogger to CentralEventBus for legacy event types
 	world.CentralEventBus.AddListener(func(event CentralEvent) {
-		// Convert certain events to legacy LogEvent format
+		// Convert certain events to legacy LogEvent format, skipping ones mirrored from the logger itself
+		if event.Source == eventLoggerSource {
+			return
+		}
 		if event.Category == "system" || event.Type == "extinction" || event.Type == "birth" || event.Type == "evolution" {
 			logEvent := LogEvent{
 				Timestamp:   event.Timestamp,
@@ -344,6 +443,8 @@ func NewWorldWithConfig(config WorldConfig, simConfig *SimulationConfig) *World
 	world.InsectSystem = NewInsectSystem()
 	world.InsectPollinationSystem = NewInsectPollinationSystem()
 	world.ColonyWarfareSystem = NewColonyWarfareSystem()
+	world.ColonyWarfareSystem.EventBus = world.CentralEventBus
+	world.SymbioticRelationships.EventBus = world.CentralEventBus
 
 	// Initialize advanced AI and neural networks system
 	world.NeuralAISystem = NewNeuralAISystem()
@@ -351,8 +452,49 @@ func NewWorldWithConfig(config WorldConfig, simConfig *SimulationConfig) *World
 	// Initialize biome boundary interaction system
 	world.BiomeBoundarySystem = NewBiomeBoundarySystem()
 
+	// Initialize movement corridor analysis
+	world.MovementCorridorSystem = NewMovementCorridorSystem(config.GridWidth, config.GridHeight, world.CentralEventBus)
+	world.OperatorStructures = NewOperatorStructureSystem(world.CentralEventBus)
+	world.Interventions = NewInterventionSystem(world.CentralEventBus)
+	world.PlayerGroups = NewPlayerGroupSystem()
+	world.SpeciesPolicies = NewSpeciesPolicySystem()
+	world.FogOfWar = NewFogOfWarSystem()
+	world.CompetitiveGame = NewCompetitiveGameSystem(world.CentralEventBus)
+	world.Tutorials = NewTutorialSystem(world.CentralEventBus)
+	world.Gallery = NewSnapshotGallery(world.CentralEventBus)
+	world.Achievements = NewAchievementSystem(world.CentralEventBus)
+	world.Audio = NewAmbientAudioSystem()
+	world.Predictions = NewSpectatorPredictionSystem(world.CentralEventBus)
+	world.HashChain = NewStateHashChain()
+	world.PopulationCaps = NewPopulationCapSystem(world.CentralEventBus)
+	world.ResourceBudget = NewResourceBudgetSystem()
+	world.Resources = NewResourceMonitor(world.CentralEventBus)
+	world.Mutations = NewMutationSystem()
+	world.Follow = NewFollowSystem()
+	world.RedQueen = NewRedQueenSystem()
+	world.BiomeStates = NewBiomeStateMachine()
+	world.Microclimates = NewMicroclimateSystem()
+	world.Pollution = NewPollutionSystem()
+	world.LightPollution = NewLightPollutionSystem()
+	world.NoisePollution = NewNoisePollutionSystem()
+	world.Zoonoses = NewZoonosisSystem()
+	world.FoodStorage = NewFoodStorageSystem()
+	world.Demographics = NewDemographicsSystem()
+	if err := world.setCustomTraits(config.CustomTraits); err != nil {
+		log.Printf("Ignoring custom traits: %v", err)
+	}
+
+	// Arm breakpoints from the world configuration
+	world.Breakpoints = NewBreakpointSystem()
+	for _, spec := range config.Breakpoints {
+		if _, err := world.Breakpoints.Add(spec, nil); err != nil {
+			log.Printf("Ignoring breakpoint: %v", err)
+		}
+	}
+
 	// Initialize organism classification and lifespan system
 	world.OrganismClassifier = NewOrganismClassifier(world.AdvancedTimeSystem)
+	world.OrganismClassifier.ScaleLifespans(simConfig.Timescales.LifespanScale)
 
 	// Initialize metamorphosis system
 	world.MetamorphosisSystem = NewMetamorphosisSystem()
@@ -361,6 +503,8 @@ func NewWorldWithConfig(config WorldConfig, simConfig *SimulationConfig) *World
 	world.EnvironmentalEvents = make([]*EnhancedEnvironmentalEvent, 0)
 	world.NextEnvironmentalEventID = 1
 
+	world.shareRand()
+
 	// Generate initial world terrain
 	world.TopologySystem.GenerateInitialTerrain()
 
@@ -371,7 +515,7 @@ func NewWorldWithConfig(config WorldConfig, simConfig *SimulationConfig) *World
 
 	// Initialize fungal networks
 	if world.FungalNetwork != nil {
-		initialFungi := 20 + rand.Intn(30) // 20-50 initial fungi
+		initialFungi := 20 + world.Rand.Intn(30) // 20-50 initial fungi
 		world.FungalNetwork.SeedInitialFungi(world, initialFungi)
 	}
 
@@ -686,15 +830,14 @@ func (w *World) generateBiome(x, y int) BiomeType {
 	distFromCenter := math.Sqrt(dx*dx + dy*dy)
 	maxDist := math.Sqrt(centerX*centerX + centerY*centerY)
 
-	// Distance from edges (for polar caps)
-	distFromEdge := math.Min(math.Min(float64(x), float64(w.Config.GridWidth-x)),
-		math.Min(float64(y), float64(w.Config.GridHeight-y)))
+	// Distance from the poles (for polar caps)
+	distFromEdge := w.distanceFromPole(x, y)
 
 	// Enhanced noise generation for contiguous features
-	baseNoise := rand.Float64()
+	baseNoise := w.Rand.Float64()
 
 	// Create multiple noise layers for more realistic patterns
-	microNoise := (rand.Float64() - 0.5) * 0.1                   // Small local variations
+	microNoise := (w.Rand.Float64() - 0.5) * 0.1                 // Small local variations
 	regionalNoise := perlinNoise(float64(x)*0.1, float64(y)*0.1) // Larger regional patterns
 
 	combinedNoise := baseNoise + microNoise + regionalNoise*0.3
@@ -706,7 +849,7 @@ func (w *World) generateBiome(x, y int) BiomeType {
 	}
 
 	// Polar regions (extreme edges) - Ice and Tundra
-	if distFromEdge < 3 {
+	if distFromEdge < polarCapRows {
 		if combinedNoise < 0.7 {
 			return BiomeIce
 		} else {
@@ -797,26 +940,29 @@ func perlinNoise(x, y float64) float64 {
 	return (math.Sin(x*1.7+y*1.3) + math.Sin(x*2.3-y*0.7) + math.Sin(x*0.9+y*2.1)) / 3.0
 }
 
-// AddPopulation adds a new population to the world
-func (w *World) AddPopulation(config PopulationConfig) {
+// AddPopulation adds a new population to the world and returns the species name it was
+// registered under
+func (w *World) AddPopulation(config PopulationConfig) string {
 	// Generate a proper species name using the naming system
 	speciesName := w.SpeciesNaming.GenerateSpeciesName(config.Species, "", 0, w.Tick)
 
-	// Generate trait names based on base traits
-	traitNames := make([]string, 0, len(config.BaseTraits))
-	for name := range config.BaseTraits {
-		traitNames = append(traitNames, name)
-	}
+	// Generate trait names based on base traits, sorted so seeded runs draw random values in the same order
+	traitNames := sortedKeys(config.BaseTraits)
 
 	// Create population with species-specific mutation rate
-	pop := NewPopulation(w.Config.PopulationSize, traitNames, config.BaseMutationRate, 0.2)
+	pop := NewPopulation(w.Rand, w.Config.PopulationSize, traitNames, w.scaledMutationRate(config.BaseMutationRate), 0.2)
 	pop.Species = speciesName
+	if config.Selection != "" {
+		if strategy, err := NewSelectionStrategy(config.Selection); err == nil {
+			pop.Selection = strategy
+		}
+	}
 
 	// Initialize entities with base traits and positions
 	for _, entity := range pop.Entities {
 		// Set position around start position
-		angle := rand.Float64() * 2 * math.Pi
-		distance := rand.Float64() * config.Spread
+		angle := w.Rand.Float64() * 2 * math.Pi
+		distance := w.Rand.Float64() * config.Spread
 
 		entity.Position = Position{
 			X: config.StartPos.X + math.Cos(angle)*distance,
@@ -827,9 +973,9 @@ func (w *World) AddPopulation(config PopulationConfig) {
 		w.NextID++
 
 		// Apply base traits with some variation
-		for traitName, baseValue := range config.BaseTraits {
-			variation := (rand.Float64() - 0.5) * 0.4 // ±20% variation
-			value := baseValue + variation
+		for _, traitName := range traitNames {
+			variation := (w.Rand.Float64() - 0.5) * 0.4 // ±20% variation
+			value := config.BaseTraits[traitName] + variation
 			value = math.Max(-2.0, math.Min(2.0, value))
 			entity.SetTrait(traitName, value)
 		}
@@ -850,14 +996,15 @@ func (w *World) AddPopulation(config PopulationConfig) {
 		}
 
 		// Enhance entity with specialized systems
-		AddInsectTraitsToEntity(entity)
-		AddPollinatorTraitsToEntity(entity)
-		AddCasteStatusToEntity(entity)
+		AddInsectTraitsToEntity(w.Rand, entity)
+		AddPollinatorTraitsToEntity(w.Rand, entity)
+		AddCasteStatusToEntity(w.Rand, entity)
 
 		w.AllEntities = append(w.AllEntities, entity)
 	}
 
 	w.Populations[speciesName] = pop
+	return speciesName
 }
 
 // Update simulates one tick of the world
@@ -871,33 +1018,55 @@ func (w *World) Update() {
 	now := time.Now()
 	w.Clock = w.Clock.Add(24 * time.Hour) // Each tick = 1 day world time
 	w.LastUpdate = now
+	if w.Mutations != nil {
+		w.Mutations.tick = w.Tick
+	}
+	if w.RNG != nil {
+		w.drawFromStreams()
+	}
 	// 1. Update advanced time system (affects all other systems)
 	w.AdvancedTimeSystem.Update()
 	currentTimeState := w.AdvancedTimeSystem.GetTimeState()
+	w.dayNight()
+	if w.Seasons != nil {
+		w.Seasons.Update(w)
+	}
+	if w.Migrations != nil {
+		w.Migrations.Update(w)
+	}
 
-	// 2. Update wind system (affects pollen dispersal and plant reproduction)
-	w.WindSystem.Update(currentTimeState.Season, w.Tick)
+	// 2. Update wind system (affects pollen dispersal and plant reproduction). With RNG
+	// streams, topology updates alongside it from its own stream.
+	if w.RNG != nil {
+		w.updateStreamSubsystems(currentTimeState.Season)
+	} else {
+		w.WindSystem.Update(currentTimeState.Season, w.Tick)
+	}
 
 	// 2a. Update seed dispersal system (handles seed movement and germination)
 	w.SeedDispersalSystem.Update(w)
 
+	// 2a'. Update seed caching (scatter-hoarders bury seeds, forgotten caches sprout)
+	if w.SeedCachingSystem != nil {
+		w.SeedCachingSystem.Update(w)
+	}
+
 	// 2b. Update chemical ecology system (plant and entity chemical communication)
 	w.ChemicalEcologySystem.Update(w)
 
 	// 3. Update micro and macro evolution systems
 	w.CellularSystem.UpdateCellularOrganisms()
 	w.MacroEvolutionSystem.UpdateMacroEvolution(w)
-	w.TopologySystem.UpdateTopology(w.Tick)
+	if w.RNG == nil {
+		w.TopologySystem.UpdateTopology(w.Tick)
+	}
 
 	// Update biomes based on topology changes (less frequently to avoid constant map resets)
-	if w.Tick%10 == 0 { // Only update every 10 ticks instead of every tick
-		w.updateBiomesFromTopology()
-	}
+	scheduler := w.scheduler()
+	scheduler.Run("geology", w.Tick, w.updateBiomesFromTopology)
 
 	// Process biome transitions (hot spots melting ice, fires spreading, etc.)
-	if w.Tick%20 == 0 { // Process transitions every 20 ticks for stability
-		w.processBiomeTransitions()
-	}
+	scheduler.Run("biome_transitions", w.Tick, w.processBiomeTransitions)
 
 	// Clear grid entities and plants
 	w.clearGrid()
@@ -912,20 +1081,23 @@ func (w *World) Update() {
 	if currentTimeState.IsNight() {
 		eventChance *= 0.5 // Fewer events at night
 	}
-	if rand.Float64() < eventChance {
+	if !w.RandomEventsOff && w.Rand.Float64() < eventChance {
 		w.triggerRandomEvent()
 	}
+	if len(w.Config.EventTimetable) > 0 {
+		w.triggerScheduledEvents()
+	}
 
 	// Maybe trigger enhanced environmental events (lower chance)
 	enhancedEventChance := 0.005 // 0.5% chance per tick
-	if rand.Float64() < enhancedEventChance {
+	if !w.RandomEventsOff && w.Rand.Float64() < enhancedEventChance {
 		w.triggerEnhancedEnvironmentalEvent()
 	}
 	// Update all plants (affected by day/night cycle)
 	w.updatePlants()
 
 	// Update plant network system (underground networks and communication)
-	w.PlantNetworkSystem.Update(w.AllPlants, w.Tick)
+	w.PlantNetworkSystem.Update(w.AllPlants, w.Tick, w.Geometry())
 
 	// 2. Create physics components for new entities
 	for _, entity := range w.AllEntities {
@@ -940,8 +1112,12 @@ func (w *World) Update() {
 	// Update all entities with biome effects, time effects, and starvation checks
 	deltaTime := 0.1 // Physics time step
 
+	// Index the tick's creatures and plants before workers start querying them
+	w.spatialIndex()
+
 	// Use concurrent processing for entity updates if we have many entities
-	if len(w.AllEntities) > 50 {
+	// (workers share the world's random source, so concurrent order is not reproducible)
+	if len(w.AllEntities) > 50 && !w.Deterministic && w.RNG == nil {
 		w.updateEntitiesConcurrent(currentTimeState, deltaTime)
 		// Calculate inter-entity physics forces after concurrent updates
 		w.updateEntityPhysicsForces()
@@ -951,18 +1127,37 @@ func (w *World) Update() {
 
 	// 5. Reset collision counters and check collisions
 	w.PhysicsSystem.ResetCollisionCounters()
-	w.CollisionSystem.CheckCollisions(w.AllEntities, w.PhysicsComponents, w.PhysicsSystem, w)
+	w.CollisionSystem.CheckComponentCollisions(w.entityComponents(), w.PhysicsSystem, w)
 
-	// Update grid with current entity and plant positions
+	// Species policies adapt toward what their players set
+	if w.SpeciesPolicies != nil {
+		w.SpeciesPolicies.Update()
+	}
+
+	// Player groups follow their orders on top of their own movement
+	if w.PlayerGroups != nil {
+		w.PlayerGroups.Update(w)
+	}
+
+	// Enforce operator-built barriers and corridors after movement
+	if w.OperatorStructures != nil {
+		w.OperatorStructures.Update(w)
+	}
+
+	// Bring creatures that crossed a joined edge round to the other side
+	w.wrapMovers()
+
+	// Update grid with current entity and plant positions, and reindex them now movement has settled
 	w.updateGrid()
+	if w.Spatial != nil {
+		w.Spatial.Invalidate()
+	}
 
 	// 6. Update group behavior system
-	w.GroupBehaviorSystem.UpdateGroups(w.Tick)
+	w.GroupBehaviorSystem.UpdateGroups(w.Tick, w.Geometry())
 
 	// Try to form new groups based on proximity and compatibility
-	if w.Tick%10 == 0 {
-		w.attemptGroupFormation()
-	}
+	scheduler.Run("group_formation", w.Tick, w.attemptGroupFormation)
 
 	// Handle interactions between entities and with plants
 	w.handleInteractions()
@@ -986,30 +1181,34 @@ func (w *World) Update() {
 
 	// Update cultural knowledge system (multi-generational knowledge transfer)
 	if w.CulturalKnowledgeSystem != nil {
-		w.CulturalKnowledgeSystem.Update(w.AllEntities, w.Tick)
+		w.CulturalKnowledgeSystem.Update(w.AllEntities, w.Tick, w.Geometry())
 	}
 
 	// Remove dead entities and plants
 	w.removeDeadEntities()
 	w.removeDeadPlants()
 	// Plant reproduction
-	if w.Tick%10 == 0 {
-		w.reproducePlants()
-	}
+	scheduler.Run("plant_reproduction", w.Tick, w.reproducePlants)
 
 	// Update species evolution and tracking (after plant reproduction)
-	if w.Tick%20 == 0 {
-		w.SpeciationSystem.Update(w.AllPlants, w.Tick)
-	}
+	scheduler.Run("speciation", w.Tick, func() { w.SpeciationSystem.Update(w.AllPlants, w.Tick) })
 
 	// Population-level evolution (less frequent)
-	if w.Tick%50 == 0 {
-		w.evolvePopulations()
-	}
+	scheduler.Run("population_evolution", w.Tick, w.evolvePopulations)
 
 	// Spawn new entities occasionally (based on carrying capacity)
-	if w.Tick%20 == 0 {
-		w.spawnNewEntities()
+	if !w.RespawnOff {
+		scheduler.Run("respawn", w.Tick, w.spawnNewEntities)
+	}
+
+	// Hold populations under their soft caps by emigration rather than culling
+	if w.PopulationCaps != nil {
+		w.PopulationCaps.Update(w)
+	}
+
+	// Give newcomers their custom traits, charge trait costs and express hooks
+	if w.CustomTraits != nil {
+		w.CustomTraits.Update(w)
 	}
 
 	// Clean up physics components for dead entities
@@ -1026,7 +1225,8 @@ func (w *World) Update() {
 		}
 	}
 
-	// Update tool system
+	// Update tool system, carrying tools with their owners
+	w.carryTools(w.entityComponents())
 	w.ToolSystem.UpdateTools(w.Tick)
 
 	// Update environmental modification system
@@ -1040,6 +1240,8 @@ func (w *World) Update() {
 
 	// Update event logger with population changes
 	w.EventLogger.UpdatePopulationCounts(w.Tick, w.Populations)
+	w.emitExtinctions()
+	w.emitSpeciations()
 
 	// Check for player species extinction and splitting (if web interface is active)
 	if w.PlayerEventsCallback != nil {
@@ -1048,40 +1250,39 @@ func (w *World) Update() {
 
 	// Update statistical analysis system
 	if w.StatisticalReporter != nil {
-		// Take snapshot at regular intervals
-		if w.Tick%w.StatisticalReporter.SnapshotInterval == 0 {
-			w.StatisticalReporter.TakeSnapshot(w)
-		}
-
-		// Perform analysis at regular intervals
-		if w.Tick%w.StatisticalReporter.AnalysisInterval == 0 {
-			w.StatisticalReporter.PerformAnalysis(w)
-		}
+		// Take snapshots and perform analysis at regular intervals
+		scheduler.Run("statistics", w.Tick, func() { w.StatisticalReporter.TakeSnapshot(w) })
+		scheduler.Run("statistics_analysis", w.Tick, func() { w.StatisticalReporter.PerformAnalysis(w) })
 	}
 
 	// Update ecosystem monitoring and metrics (every 20 ticks to avoid overhead)
-	if w.EcosystemMonitor != nil && w.Tick%20 == 0 {
-		w.EcosystemMonitor.UpdateMetrics(w)
+	if w.EcosystemMonitor != nil {
+		scheduler.Run("ecosystem_metrics", w.Tick, func() { w.EcosystemMonitor.UpdateMetrics(w) })
+	}
+
+	// Record the metric history charted by the web interface (every 5 ticks)
+	if w.MetricHistory != nil {
+		scheduler.Run("metric_history", w.Tick, func() { w.MetricHistory.Record(w) })
 	}
 
 	// Update environmental pressures (every 10 ticks)
-	if w.EnvironmentalPressures != nil && w.Tick%10 == 0 {
-		w.EnvironmentalPressures.Update(w, w.Tick)
+	if w.EnvironmentalPressures != nil {
+		scheduler.Run("environmental_pressures", w.Tick, func() { w.EnvironmentalPressures.Update(w, w.Tick) })
 	}
 
 	// Update symbiotic relationships (every 5 ticks)
-	if w.SymbioticRelationships != nil && w.Tick%5 == 0 {
-		w.SymbioticRelationships.Update(w, w.Tick)
+	if w.SymbioticRelationships != nil {
+		scheduler.Run("symbiosis", w.Tick, func() { w.SymbioticRelationships.Update(w, w.Tick) })
 	}
 
 	// Update hive mind, caste, and insect systems
 	w.HiveMindSystem.Update()
 	w.CasteSystem.Update(w, w.Tick)
-	w.InsectSystem.Update(w.Tick)
+	w.InsectSystem.Update(w.Tick, w.Geometry())
 
 	// Update insect pollination system
 	currentSeason := w.AdvancedTimeSystem.GetTimeState().Season
-	w.InsectPollinationSystem.Update(w.AllEntities, w.AllPlants, currentSeason, w.Tick)
+	w.InsectPollinationSystem.Update(w.AllEntities, w.AllPlants, currentSeason, w.Tick, w.Geometry())
 
 	// Update colony warfare and diplomacy system
 	w.ColonyWarfareSystem.Update(w.CasteSystem.Colonies, w.Tick)
@@ -1095,24 +1296,115 @@ func (w *World) Update() {
 	// Update biome boundary system (ecotones, barriers, migration effects)
 	w.BiomeBoundarySystem.Update(w, w.Tick)
 
+	// Track movement corridors and habitat fragmentation
+	if w.MovementCorridorSystem != nil {
+		w.MovementCorridorSystem.Update(w)
+	}
+
 	// Try to form new collective intelligence systems
-	if w.Tick%100 == 0 { // Every 100 ticks
+	scheduler.Run("collective_formation", w.Tick, func() {
 		w.attemptHiveMindFormation()
 		w.attemptCasteColonyFormation()
 		w.attemptSwarmFormation()
+	})
+
+	// Score any competitive game and check its victory condition
+	if w.CompetitiveGame != nil {
+		w.CompetitiveGame.Update(w)
+	}
+
+	// Advance any tutorial whose current step the tick completed
+	if w.Tutorials != nil {
+		w.Tutorials.Update(w)
+	}
+
+	// Snapshot the world on the notable events of the tick
+	if w.Gallery != nil {
+		w.Gallery.Update(w)
+	}
+
+	// Award the milestones creatures reached this tick
+	if w.Achievements != nil {
+		w.Achievements.Update(w)
+	}
+
+	// Open and settle spectator predictions; they only watch the world
+	if w.Predictions != nil {
+		w.Predictions.Update(w)
+	}
+
+	// Follow arms races between interacting species
+	if w.RedQueen != nil {
+		w.RedQueen.Update(w)
+	}
+
+	// Map the shade and shelter of tree cover and structures
+	if w.Microclimates != nil {
+		w.Microclimates.Update(w)
+	}
+
+	// Spread the waste of advanced tribes downwind and downhill
+	if w.Pollution != nil {
+		w.Pollution.Update(w)
+	}
+
+	// Light the settlements of fire-using tribes
+	if w.LightPollution != nil {
+		w.LightPollution.Update(w)
+	}
+
+	// Stress and drive off wildlife near busy colonies and worn paths
+	if w.NoisePollution != nil {
+		w.NoisePollution.Update(w)
+	}
+
+	// Let pathogens spill over between wildlife and dense colonies
+	if w.Zoonoses != nil {
+		w.Zoonoses.Update(w)
+	}
+
+	// Spoil stored food and let famines and surpluses follow
+	if w.FoodStorage != nil {
+		w.FoodStorage.Update(w)
+	}
+
+	// Take a census of the colonies and watch for demographic transitions
+	if w.Demographics != nil {
+		w.Demographics.Update(w)
+	}
+
+	// Chain the state at the end of each epoch into the run certificate
+	if w.HashChain != nil {
+		w.HashChain.Update(w)
+	}
+
+	// Sample store footprints, pruning by policy and watching for leaks
+	if w.Resources != nil {
+		w.Resources.Update(w)
+	}
+
+	// Record what creatures followed by a camera did this tick
+	if w.Follow != nil {
+		w.Follow.Update(w)
+	}
+
+	// Pause if a breakpoint condition is met
+	w.checkBreakpoints()
+
+	// Write what changed this tick to the replay
+	if w.Recorder != nil {
+		w.Recorder.Record(w)
+	}
+
+	// Autosave once the interval has passed
+	if w.Autosaver != nil {
+		w.Autosaver.Update(w)
 	}
 }
 
 // getBiomeAtPosition returns the biome type at the given world position
 func (w *World) getBiomeAtPosition(x, y float64) BiomeType {
-	// Convert world coordinates to grid coordinates
-	gridX := int((x / w.Config.Width) * float64(w.Config.GridWidth))
-	gridY := int((y / w.Config.Height) * float64(w.Config.GridHeight))
-
-	// Clamp to grid bounds
-	gridX = int(math.Max(0, math.Min(float64(w.Config.GridWidth-1), float64(gridX))))
-	gridY = int(math.Max(0, math.Min(float64(w.Config.GridHeight-1), float64(gridY))))
-
+	gridX, gridY := w.worldToGridCoords(x, y)
 	return w.Grid[gridY][gridX].Biome
 }
 
@@ -1121,13 +1413,8 @@ func (w *World) getEntitiesNearPosition(pos Position, radius float64) []*Entity
 	nearby := make([]*Entity, 0)
 
 	for _, entity := range w.AllEntities {
-		if entity.IsAlive {
-			dx := entity.Position.X - pos.X
-			dy := entity.Position.Y - pos.Y
-			distance := math.Sqrt(dx*dx + dy*dy)
-			if distance <= radius {
-				nearby = append(nearby, entity)
-			}
+		if entity.IsAlive && w.Distance(entity.Position, pos) <= radius {
+			nearby = append(nearby, entity)
 		}
 	}
 
@@ -1136,75 +1423,57 @@ func (w *World) getEntitiesNearPosition(pos Position, radius float64) []*Entity
 
 // updateEntitiesSequential updates entities using single-threaded processing
 func (w *World) updateEntitiesSequential(currentTimeState TimeState, deltaTime float64) {
+	// With RNG streams the creatures update chunk by chunk
+	if w.RNG != nil {
+		w.updateEntitiesByChunk(currentTimeState)
+		w.updateEntityPhysicsByChunk(deltaTime)
+		return
+	}
+
 	for _, entity := range w.AllEntities {
 		if !entity.IsAlive {
 			continue
 		}
 
 		// Apply biome effects
-		w.updateEntityWithBiome(entity)
+		w.updateEntityWithBiome(w.Rand, w.Mutations, entity)
 
 		// Track environmental exposure for feedback loops
 		w.trackEntityEnvironmentalExposure(entity, currentTimeState)
 
 		// Apply time-based effects (circadian preferences)
-		w.applyTimeEffects(entity, currentTimeState)
+		w.applyTimeEffects(w.Rand, entity, currentTimeState)
 
 		// Check starvation-driven evolution
 		entity.CheckStarvation(w)
+		entity.CheckComplexity(w)
 
 		// Update basic entity properties using classification system and configuration
 		entity.UpdateWithClassificationAndConfig(w.OrganismClassifier, w.CellularSystem, w.SimConfig)
 
 		// Update metamorphosis and life stage development
-		if entity.MetamorphosisStatus == nil {
-			// Initialize metamorphosis status for new entities
-			entity.MetamorphosisStatus = NewMetamorphosisStatus(entity, w.MetamorphosisSystem)
-		}
-
-		// Get environmental factors for metamorphosis
-		gridX := int((entity.Position.X / w.Config.Width) * float64(w.Config.GridWidth))
-		gridY := int((entity.Position.Y / w.Config.Height) * float64(w.Config.GridHeight))
-		gridX = int(math.Max(0, math.Min(float64(w.Config.GridWidth-1), float64(gridX))))
-		gridY = int(math.Max(0, math.Min(float64(w.Config.GridHeight-1), float64(gridY))))
-
-		environment := w.calculateEnvironmentalFactors(entity, gridX, gridY)
-		stageChanged := w.MetamorphosisSystem.Update(entity, w.Tick, environment)
-
-		if stageChanged {
-			// Log metamorphosis events
-			w.CentralEventBus.EmitSystemEvent(w.Tick, "metamorphosis", "life_stage", "metamorphosis_system",
-				fmt.Sprintf("Entity %d advanced to %s stage", entity.ID, entity.MetamorphosisStatus.CurrentStage.String()),
-				&entity.Position, map[string]interface{}{
-					"entity_id":          entity.ID,
-					"new_stage":          entity.MetamorphosisStatus.CurrentStage.String(),
-					"metamorphosis_type": entity.MetamorphosisStatus.Type.String(),
-				})
-		}
+		w.updateMetamorphosis(entity)
 
 		// 4. Apply physics forces and movement
 		physics := w.PhysicsComponents[entity.ID]
 		if physics != nil {
 			// Get entity's current biome
-			gridX := int((entity.Position.X / w.Config.Width) * float64(w.Config.GridWidth))
-			gridY := int((entity.Position.Y / w.Config.Height) * float64(w.Config.GridHeight))
-			gridX = int(math.Max(0, math.Min(float64(w.Config.GridWidth-1), float64(gridX))))
-			gridY = int(math.Max(0, math.Min(float64(w.Config.GridHeight-1), float64(gridY))))
+			gridX, gridY := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
 			biome := w.Grid[gridY][gridX].Biome
 
 			// Calculate attraction/repulsion forces between entities
-			for _, other := range w.AllEntities {
+			for _, other := range w.spatialIndex().QueryRadius(entity.Position, w.PhysicsSystem.attractionReach()) {
 				if other.ID != entity.ID && other.IsAlive {
 					otherPhysics := w.PhysicsComponents[other.ID]
 					if otherPhysics != nil {
-						force := w.PhysicsSystem.CalculateAttraction(entity, other, physics, otherPhysics)
+						force := w.PhysicsSystem.CalculateAttraction(entity, other, physics, otherPhysics, w.Geometry())
 						w.PhysicsSystem.ApplyForce(physics, force)
 					}
 				}
 			}
 
 			// Apply fluid effects if in fluid regions
-			w.PhysicsSystem.ApplyFluidEffects(entity, physics, w.FluidRegions)
+			w.PhysicsSystem.ApplyFluidEffects(entity, physics, w.FluidRegions, w.Geometry())
 
 			// Update physics
 			w.PhysicsSystem.ApplyPhysics(entity, physics, biome, deltaTime)
@@ -1214,6 +1483,30 @@ func (w *World) updateEntitiesSequential(currentTimeState TimeState, deltaTime f
 	}
 }
 
+// updateMetamorphosis advances a creature's life stage and logs the change when it moves on
+// to the next
+func (w *World) updateMetamorphosis(entity *Entity) {
+	if entity.MetamorphosisStatus == nil {
+		// Initialize metamorphosis status for new entities
+		entity.MetamorphosisStatus = NewMetamorphosisStatus(entity, w.MetamorphosisSystem)
+	}
+
+	// Get environmental factors for metamorphosis
+	gridX, gridY := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
+
+	environment := w.calculateEnvironmentalFactors(entity, gridX, gridY)
+	if w.MetamorphosisSystem.Update(entity, w.Tick, environment) {
+		// Log metamorphosis events
+		w.CentralEventBus.EmitSystemEvent(w.Tick, "metamorphosis", "life_stage", "metamorphosis_system",
+			fmt.Sprintf("Entity %d advanced to %s stage", entity.ID, entity.MetamorphosisStatus.CurrentStage.String()),
+			&entity.Position, map[string]interface{}{
+				"entity_id":          entity.ID,
+				"new_stage":          entity.MetamorphosisStatus.CurrentStage.String(),
+				"metamorphosis_type": entity.MetamorphosisStatus.Type.String(),
+			})
+	}
+}
+
 // updateEntitiesConcurrent updates entities using multi-threaded processing
 func (w *World) updateEntitiesConcurrent(currentTimeState TimeState, deltaTime float64) {
 	// Worker pool for concurrent entity processing
@@ -1247,16 +1540,17 @@ func (w *World) updateEntitiesConcurrent(currentTimeState TimeState, deltaTime f
 // updateSingleEntity updates a single entity (thread-safe parts only)
 func (w *World) updateSingleEntity(entity *Entity, currentTimeState TimeState, deltaTime float64) {
 	// Apply biome effects
-	w.updateEntityWithBiome(entity)
+	w.updateEntityWithBiome(w.Rand, w.Mutations, entity)
 
 	// Track environmental exposure for feedback loops
 	w.trackEntityEnvironmentalExposure(entity, currentTimeState)
 
 	// Apply time-based effects (circadian preferences)
-	w.applyTimeEffects(entity, currentTimeState)
+	w.applyTimeEffects(w.Rand, entity, currentTimeState)
 
 	// Check starvation-driven evolution
 	entity.CheckStarvation(w)
+	entity.CheckComplexity(w)
 
 	// Update basic entity properties using classification system and configuration
 	entity.UpdateWithClassificationAndConfig(w.OrganismClassifier, w.CellularSystem, w.SimConfig)
@@ -1271,14 +1565,11 @@ func (w *World) updateSingleEntity(entity *Entity, currentTimeState TimeState, d
 	physics := w.PhysicsComponents[entity.ID]
 	if physics != nil {
 		// Get entity's current biome
-		gridX := int((entity.Position.X / w.Config.Width) * float64(w.Config.GridWidth))
-		gridY := int((entity.Position.Y / w.Config.Height) * float64(w.Config.GridHeight))
-		gridX = int(math.Max(0, math.Min(float64(w.Config.GridWidth-1), float64(gridX))))
-		gridY = int(math.Max(0, math.Min(float64(w.Config.GridHeight-1), float64(gridY))))
+		gridX, gridY := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
 		biome := w.Grid[gridY][gridX].Biome
 
 		// Apply fluid effects if in fluid regions
-		w.PhysicsSystem.ApplyFluidEffects(entity, physics, w.FluidRegions)
+		w.PhysicsSystem.ApplyFluidEffects(entity, physics, w.FluidRegions, w.Geometry())
 
 		// Update physics (without inter-entity forces for now)
 		w.PhysicsSystem.ApplyPhysics(entity, physics, biome, deltaTime)
@@ -1295,11 +1586,11 @@ func (w *World) updateEntityPhysicsForces() {
 		physics := w.PhysicsComponents[entity.ID]
 		if physics != nil {
 			// Calculate attraction/repulsion forces between entities
-			for _, other := range w.AllEntities {
+			for _, other := range w.spatialIndex().QueryRadius(entity.Position, w.PhysicsSystem.attractionReach()) {
 				if other.ID != entity.ID && other.IsAlive {
 					otherPhysics := w.PhysicsComponents[other.ID]
 					if otherPhysics != nil {
-						force := w.PhysicsSystem.CalculateAttraction(entity, other, physics, otherPhysics)
+						force := w.PhysicsSystem.CalculateAttraction(entity, other, physics, otherPhysics, w.Geometry())
 						w.PhysicsSystem.ApplyForce(physics, force)
 					}
 				}
@@ -1310,9 +1601,8 @@ func (w *World) updateEntityPhysicsForces() {
 
 // updatePlants handles plant growth, aging, and death with enhanced nutrient system
 func (w *World) updatePlants() {
-	// Get current season for plant nutrient calculations
-	currentTimeState := w.AdvancedTimeSystem.GetTimeState()
-	season := getSeasonName(currentTimeState.Season)
+	// The season decides how well plants thrive on their soil
+	growth := w.seasonEffects().PlantGrowth
 
 	// Process decay items and add nutrients to soil
 	if len(w.ReproductionSystem.DecayingItems) > 0 {
@@ -1322,32 +1612,39 @@ func (w *World) updatePlants() {
 	// Process rainfall effects on soil
 	w.processWeatherEffectsOnSoil()
 
+	// Share out the biomes' sunlight, water and nutrients before anything grows
+	if w.ResourceBudget != nil {
+		w.ResourceBudget.Update(w)
+	}
+
+	// With RNG streams the plants grow chunk by chunk
+	if w.RNG != nil {
+		w.updatePlantsByChunk(growth)
+		return
+	}
+
 	for _, plant := range w.AllPlants {
 		if !plant.IsAlive {
 			continue
 		}
 
 		// Get grid cell for plant's location
-		gridX := int((plant.Position.X / w.Config.Width) * float64(w.Config.GridWidth))
-		gridY := int((plant.Position.Y / w.Config.Height) * float64(w.Config.GridHeight))
-
-		// Clamp to grid bounds
-		gridX = int(math.Max(0, math.Min(float64(w.Config.GridWidth-1), float64(gridX))))
-		gridY = int(math.Max(0, math.Min(float64(w.Config.GridHeight-1), float64(gridY))))
+		gridX, gridY := w.worldToGridCoords(plant.Position.X, plant.Position.Y)
 
 		gridCell := &w.Grid[gridY][gridX]
 		biome := w.Biomes[gridCell.Biome]
 
-		// Update plant with enhanced nutrient system
-		nutritionalHealth := plant.updatePlantNutrients(gridCell, season)
+		// Update plant with enhanced nutrient system, within the resource budget
+		share := w.plantShare(gridX, gridY)
+		nutritionalHealth := plant.updatePlantNutrientsWithBudget(gridCell, growth, share)
 
 		// Traditional plant update with nutritional influence
-		plant.Update(biome)
+		plant.UpdateWithBudget(biome, share)
 
 		// Apply nutritional health effects
 		if nutritionalHealth < 0.5 {
 			// Severe malnutrition - chance of death
-			if rand.Float64() < (0.5-nutritionalHealth)*0.1 {
+			if w.Rand.Float64() < (0.5-nutritionalHealth)*0.1 {
 				plant.IsAlive = false
 				// Add plant matter to decay system
 				if w.ReproductionSystem != nil {
@@ -1368,12 +1665,13 @@ func (w *World) reproducePlants() {
 
 	// First, process asexual reproduction and pollen release
 	for _, plant := range w.AllPlants {
-		if !plant.IsAlive {
+		// Plants set no seed or pollen where the resource budget has no room for offspring
+		if !plant.IsAlive || !w.roomForPlants(plant.Position) {
 			continue
 		}
 
 		// Seed production and dispersal (replaces simple asexual reproduction)
-		if plant.CanReproduce() {
+		if plant.CanReproduce(w.Rand) {
 			// Create seeds instead of direct offspring
 			seedCount := 1 + int(plant.GetTrait("reproduction_rate")*3) // 1-4 seeds typically
 			for i := 0; i < seedCount; i++ {
@@ -1392,7 +1690,7 @@ func (w *World) reproducePlants() {
 		}
 
 		// Release pollen for sexual reproduction during flowering
-		if plant.CanReproduce() && (currentTimeState.Season == Spring || currentTimeState.Season == Summer) && rand.Float64() < 0.4 {
+		if plant.CanReproduce(w.Rand) && (currentTimeState.Season == Spring || currentTimeState.Season == Summer) && w.Rand.Float64() < 0.4 {
 			// Determine pollen amount based on plant traits and type
 			pollenAmount := int(5 + plant.Size*10 + plant.GetTrait("reproduction_rate")*15)
 
@@ -1416,7 +1714,12 @@ func (w *World) reproducePlants() {
 		}
 	}
 	// Process wind-based cross-pollination
-	crossPollinatedPlants := w.WindSystem.TryPollination(w.AllPlants, w.SpeciationSystem, w.Tick)
+	crossPollinatedPlants := w.WindSystem.TryPollination(w.AllPlants, w.SpeciationSystem, w.Tick, w.Geometry())
+
+	// Only the offspring the resource budget has room for take root
+	crossPollinatedPlants = slices.DeleteFunc(crossPollinatedPlants, func(offspring *Plant) bool {
+		return !w.admitPlant(offspring.Position)
+	})
 
 	// Assign IDs to cross-pollinated plants
 	for _, offspring := range crossPollinatedPlants {
@@ -1498,9 +1801,12 @@ func (w *World) updateGrid() {
 
 // worldToGridCoords converts world coordinates to grid coordinates with bounds clamping
 func (w *World) worldToGridCoords(worldX, worldY float64) (int, int) {
-	// Convert world coordinates to grid coordinates
-	gridX := int((worldX / w.Config.Width) * float64(w.Config.GridWidth))
-	gridY := int((worldY / w.Config.Height) * float64(w.Config.GridHeight))
+	// Convert world coordinates to grid coordinates, across a joined edge if need be
+	cell := w.GridLayout().CellAt(Position{X: worldX, Y: worldY})
+	gridX, gridY, onMap := w.wrapGridCell(cell.X, cell.Y)
+	if onMap {
+		return gridX, gridY
+	}
 
 	// Clamp to grid bounds
 	gridX = int(math.Max(0, math.Min(float64(w.Config.GridWidth-1), float64(gridX))))
@@ -1510,18 +1816,13 @@ func (w *World) worldToGridCoords(worldX, worldY float64) (int, int) {
 }
 
 // updateEntityWithBiome applies biome effects to an entity
-func (w *World) updateEntityWithBiome(entity *Entity) {
+func (w *World) updateEntityWithBiome(rng *rand.Rand, mutations *MutationSystem, entity *Entity) {
 	if !entity.IsAlive {
 		return
 	}
 
 	// Get entity's grid position
-	gridX := int((entity.Position.X / w.Config.Width) * float64(w.Config.GridWidth))
-	gridY := int((entity.Position.Y / w.Config.Height) * float64(w.Config.GridHeight))
-
-	// Clamp to grid bounds
-	gridX = int(math.Max(0, math.Min(float64(w.Config.GridWidth-1), float64(gridX))))
-	gridY = int(math.Max(0, math.Min(float64(w.Config.GridHeight-1), float64(gridY))))
+	gridX, gridY := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
 
 	cell := &w.Grid[gridY][gridX]
 	biome := w.Biomes[cell.Biome]
@@ -1530,40 +1831,39 @@ func (w *World) updateEntityWithBiome(entity *Entity) {
 	entity.Energy -= biome.EnergyDrain
 
 	// Apply biome mutation effects
-	if biome.MutationRate > 0 && rand.Float64() < biome.MutationRate {
-		entity.Mutate(biome.MutationRate, 0.1)
+	if biome.MutationRate > 0 && rng.Float64() < biome.MutationRate {
+		entity.MutateWith(rng, mutations, biome.MutationRate, 0.1)
 	}
 
 	// Apply event effects if present
 	if cell.Event != nil {
 		entity.Energy -= cell.Event.GlobalDamage
-		if cell.Event.GlobalMutation > 0 && rand.Float64() < cell.Event.GlobalMutation {
-			entity.Mutate(cell.Event.GlobalMutation, 0.2)
+		if cell.Event.GlobalMutation > 0 && rng.Float64() < cell.Event.GlobalMutation {
+			entity.MutateWith(rng, mutations, cell.Event.GlobalMutation, 0.2)
 		}
 	}
 
 	// Move entities randomly within their preferred biomes
-	w.moveEntityInBiome(entity, biome)
+	w.moveEntityInBiome(rng, entity, biome)
 }
 
 // moveEntityInBiome makes entities move based on biome preferences
-func (w *World) moveEntityInBiome(entity *Entity, biome Biome) {
+func (w *World) moveEntityInBiome(rng *rand.Rand, entity *Entity, biome Biome) {
 	// Movement based on entity traits and biome
 	speed := entity.GetTrait("speed")
 	intelligence := entity.GetTrait("intelligence")
 
 	// Intelligent entities seek better biomes
-	if intelligence > 0.5 && rand.Float64() < 0.3 {
+	if intelligence > 0.5 && rng.Float64() < 0.3 {
 		w.seekBetterBiome(entity)
 	} else {
 		// Random movement modified by speed and biome effects
 		maxMove := (0.5 + speed*0.5) * (w.Config.Width / float64(w.Config.GridWidth))
-		entity.MoveRandomly(maxMove)
+		entity.MoveRandomly(rng, maxMove)
 	}
 
 	// Keep entities within world bounds
-	entity.Position.X = math.Max(0, math.Min(w.Config.Width, entity.Position.X))
-	entity.Position.Y = math.Max(0, math.Min(w.Config.Height, entity.Position.Y))
+	entity.Position = w.KeepOnMap(entity.Position, 0)
 }
 
 // seekBetterBiome makes intelligent entities move toward favorable biomes
@@ -1571,26 +1871,17 @@ func (w *World) seekBetterBiome(entity *Entity) {
 	bestScore := -1000.0
 	bestX, bestY := entity.Position.X, entity.Position.Y
 
-	// Check nearby grid cells
-	currentGridX := int((entity.Position.X / w.Config.Width) * float64(w.Config.GridWidth))
-	currentGridY := int((entity.Position.Y / w.Config.Height) * float64(w.Config.GridHeight))
-
-	for dy := -2; dy <= 2; dy++ {
-		for dx := -2; dx <= 2; dx++ {
-			checkX := currentGridX + dx
-			checkY := currentGridY + dy
-
-			if checkX >= 0 && checkX < w.Config.GridWidth &&
-				checkY >= 0 && checkY < w.Config.GridHeight {
-
-				biome := w.Biomes[w.Grid[checkY][checkX].Biome]
-				score := w.evaluateBiomeForEntity(entity, biome)
-
-				if score > bestScore {
-					bestScore = score
-					bestX = (float64(checkX) + 0.5) * (w.Config.Width / float64(w.Config.GridWidth))
-					bestY = (float64(checkY) + 0.5) * (w.Config.Height / float64(w.Config.GridHeight))
-				}
+	// Check the grid cells within two steps
+	layout := w.GridLayout()
+	for _, check := range layout.Within(layout.CellAt(entity.Position), 2) {
+		if gridX, gridY, onMap := w.wrapGridCell(check.X, check.Y); onMap {
+			biome := w.Biomes[w.Grid[gridY][gridX].Biome]
+			score := w.evaluateBiomeForEntity(entity, biome)
+
+			if score > bestScore {
+				bestScore = score
+				center := layout.CellCenter(check)
+				bestX, bestY = center.X, center.Y
 			}
 		}
 	}
@@ -1598,7 +1889,7 @@ func (w *World) seekBetterBiome(entity *Entity) {
 	// Move toward best biome if found
 	if bestScore > -1000.0 {
 		speed := 0.3 + entity.GetTrait("speed")*0.2
-		entity.MoveTo(bestX, bestY, speed)
+		entity.MoveTo(bestX, bestY, speed, w.Geometry())
 	}
 }
 
@@ -1607,7 +1898,8 @@ func (w *World) evaluateBiomeForEntity(entity *Entity, biome Biome) float64 {
 	score := -biome.EnergyDrain * 10 // Avoid high energy drain
 
 	// Add points for beneficial trait modifiers
-	for trait, modifier := range biome.TraitModifiers {
+	for _, trait := range sortedKeys(biome.TraitModifiers) {
+		modifier := biome.TraitModifiers[trait]
 		entityValue := entity.GetTrait(trait)
 		if modifier > 0 && entityValue > 0 {
 			score += modifier * entityValue * 50
@@ -1633,6 +1925,9 @@ func (w *World) updateEvents() {
 		event.Duration--
 		if event.Duration > 0 {
 			newEvents = append(newEvents, event)
+		} else if w.CentralEventBus != nil {
+			w.CentralEventBus.EmitSystemEvent(w.Tick, "disaster_ended", "world_event", "world",
+				fmt.Sprintf("%s is over", event.Name), nil, map[string]interface{}{"name": event.Name})
 		}
 	}
 
@@ -1641,18 +1936,43 @@ func (w *World) updateEvents() {
 
 // triggerRandomEvent creates a new random world event
 func (w *World) triggerRandomEvent() {
-	events := []WorldEvent{
+	events := w.worldEventCatalogue()
+	w.startWorldEvent(events[w.Rand.Intn(len(events))])
+}
+
+// triggerScheduledEvents starts the events of the timetable due this tick
+func (w *World) triggerScheduledEvents() {
+	for _, scheduled := range w.Config.EventTimetable {
+		if scheduled.Tick != w.Tick {
+			continue
+		}
+		for _, event := range w.worldEventCatalogue() {
+			if event.Name == scheduled.Name {
+				if scheduled.Duration > 0 {
+					event.Duration = scheduled.Duration
+				}
+				w.startWorldEvent(event)
+				break
+			}
+		}
+	}
+}
+
+// worldEventCatalogue lists the world events that can happen, each with the biome changes it
+// would make now
+func (w *World) worldEventCatalogue() []WorldEvent {
+	return []WorldEvent{
 		{
 			Name:           "Solar Flare",
 			Description:    "Increased radiation across the world",
-			Duration:       30,
+			Duration:       worldEventDurations["Solar Flare"],
 			GlobalMutation: 0.2,
 			GlobalDamage:   2.0,
 		},
 		{
 			Name:           "Meteor Shower",
 			Description:    "Meteors create radiation zones",
-			Duration:       50,
+			Duration:       worldEventDurations["Meteor Shower"],
 			GlobalMutation: 0.05,
 			GlobalDamage:   1.0,
 			BiomeChanges:   w.generateMeteorCraters(),
@@ -1660,21 +1980,21 @@ func (w *World) triggerRandomEvent() {
 		{
 			Name:           "Ice Age",
 			Description:    "World cools, increasing energy drain",
-			Duration:       100,
+			Duration:       worldEventDurations["Ice Age"],
 			GlobalMutation: 0.0,
 			GlobalDamage:   1.5,
 		},
 		{
 			Name:           "Volcanic Winter",
 			Description:    "Ash clouds block sunlight",
-			Duration:       75,
+			Duration:       worldEventDurations["Volcanic Winter"],
 			GlobalMutation: 0.1,
 			GlobalDamage:   2.5,
 		},
 		{
 			Name:           "Volcanic Eruption",
 			Description:    "Massive lava flows create new biomes",
-			Duration:       40,
+			Duration:       worldEventDurations["Volcanic Eruption"],
 			GlobalMutation: 0.15,
 			GlobalDamage:   3.0,
 			BiomeChanges:   w.generateVolcanicFields(),
@@ -1682,14 +2002,14 @@ func (w *World) triggerRandomEvent() {
 		{
 			Name:           "Lightning Storm",
 			Description:    "Electrical discharges cause widespread mutations",
-			Duration:       20,
+			Duration:       worldEventDurations["Lightning Storm"],
 			GlobalMutation: 0.3,
 			GlobalDamage:   1.0,
 		},
 		{
 			Name:           "Wildfire",
 			Description:    "Fires spread across vegetation",
-			Duration:       35,
+			Duration:       worldEventDurations["Wildfire"],
 			GlobalMutation: 0.05,
 			GlobalDamage:   2.0,
 			BiomeChanges:   w.generateFireZones(),
@@ -1697,7 +2017,7 @@ func (w *World) triggerRandomEvent() {
 		{
 			Name:           "Great Flood",
 			Description:    "Rising waters reshape the landscape",
-			Duration:       60,
+			Duration:       worldEventDurations["Great Flood"],
 			GlobalMutation: 0.08,
 			GlobalDamage:   1.8,
 			BiomeChanges:   w.generateFloodZones(),
@@ -1705,21 +2025,21 @@ func (w *World) triggerRandomEvent() {
 		{
 			Name:           "Magnetic Storm",
 			Description:    "Electromagnetic chaos disrupts navigation",
-			Duration:       25,
+			Duration:       worldEventDurations["Magnetic Storm"],
 			GlobalMutation: 0.12,
 			GlobalDamage:   0.5,
 		},
 		{
 			Name:           "Ash Cloud",
 			Description:    "Dense ash blocks sunlight and poisons air",
-			Duration:       45,
+			Duration:       worldEventDurations["Ash Cloud"],
 			GlobalMutation: 0.08,
 			GlobalDamage:   2.2,
 		},
 		{
 			Name:           "Earthquake",
 			Description:    "Seismic activity creates new mountain ranges",
-			Duration:       15,
+			Duration:       worldEventDurations["Earthquake"],
 			GlobalMutation: 0.05,
 			GlobalDamage:   1.5,
 			BiomeChanges:   w.generateSeismicChanges(),
@@ -1727,24 +2047,33 @@ func (w *World) triggerRandomEvent() {
 		{
 			Name:           "Cosmic Radiation",
 			Description:    "Interstellar radiation penetrates atmosphere",
-			Duration:       80,
+			Duration:       worldEventDurations["Cosmic Radiation"],
 			GlobalMutation: 0.25,
 			GlobalDamage:   1.0,
 		},
 	}
+}
 
-	event := events[rand.Intn(len(events))]
+// startWorldEvent begins a world event, scaling its duration to the timescales
+func (w *World) startWorldEvent(event WorldEvent) {
+	event.Duration = w.scaledEventDuration(event.Duration)
 	w.Events = append(w.Events, &event)
+
+	if w.CentralEventBus != nil {
+		w.CentralEventBus.EmitSystemEvent(w.Tick, "disaster", "world_event", "world",
+			fmt.Sprintf("%s: %s", event.Name, event.Description), nil,
+			map[string]interface{}{"name": event.Name, "duration": event.Duration})
+	}
 }
 
 // generateMeteorCraters creates radiation zones from meteor impacts
 func (w *World) generateMeteorCraters() map[Position]BiomeType {
 	craters := make(map[Position]BiomeType)
-	numCraters := 3 + rand.Intn(5)
+	numCraters := 3 + w.Rand.Intn(5)
 
 	for i := 0; i < numCraters; i++ {
-		x := rand.Intn(w.Config.GridWidth)
-		y := rand.Intn(w.Config.GridHeight)
+		x := w.Rand.Intn(w.Config.GridWidth)
+		y := w.Rand.Intn(w.Config.GridHeight)
 		craters[Position{X: float64(x), Y: float64(y)}] = BiomeRadiation
 
 		// Add smaller radiation zones around impact
@@ -1752,7 +2081,7 @@ func (w *World) generateMeteorCraters() map[Position]BiomeType {
 			for dx := -1; dx <= 1; dx++ {
 				if x+dx >= 0 && x+dx < w.Config.GridWidth &&
 					y+dy >= 0 && y+dy < w.Config.GridHeight &&
-					rand.Float64() < 0.5 {
+					w.Rand.Float64() < 0.5 {
 					craters[Position{X: float64(x + dx), Y: float64(y + dy)}] = BiomeRadiation
 				}
 			}
@@ -1765,11 +2094,11 @@ func (w *World) generateMeteorCraters() map[Position]BiomeType {
 // generateVolcanicFields creates mountain and radiation zones from volcanic activity
 func (w *World) generateVolcanicFields() map[Position]BiomeType {
 	changes := make(map[Position]BiomeType)
-	numVolcanoes := 1 + rand.Intn(3)
+	numVolcanoes := 1 + w.Rand.Intn(3)
 
 	for i := 0; i < numVolcanoes; i++ {
-		centerX := rand.Intn(w.Config.GridWidth)
-		centerY := rand.Intn(w.Config.GridHeight)
+		centerX := w.Rand.Intn(w.Config.GridWidth)
+		centerY := w.Rand.Intn(w.Config.GridHeight)
 
 		// Create volcanic mountain at center
 		changes[Position{X: float64(centerX), Y: float64(centerY)}] = BiomeMountain
@@ -1782,7 +2111,7 @@ func (w *World) generateVolcanicFields() map[Position]BiomeType {
 				y := centerY + int(float64(radius)*math.Sin(radian))
 
 				if x >= 0 && x < w.Config.GridWidth && y >= 0 && y < w.Config.GridHeight &&
-					rand.Float64() < 0.6 {
+					w.Rand.Float64() < 0.6 {
 					changes[Position{X: float64(x), Y: float64(y)}] = BiomeRadiation
 				}
 			}
@@ -1803,7 +2132,7 @@ func (w *World) spreadAreaEffect(centerX, centerY, maxRadius int, baseChance, de
 				if x >= 0 && x < w.Config.GridWidth && y >= 0 && y < w.Config.GridHeight {
 					distance := math.Sqrt(float64(dx*dx + dy*dy))
 					chance := baseChance * math.Exp(-distance/decayFactor)
-					if rand.Float64() < chance {
+					if w.Rand.Float64() < chance {
 						changes[Position{X: float64(x), Y: float64(y)}] = biomeType
 					}
 				}
@@ -1815,11 +2144,11 @@ func (w *World) spreadAreaEffect(centerX, centerY, maxRadius int, baseChance, de
 // generateFireZones creates desert zones from wildfires
 func (w *World) generateFireZones() map[Position]BiomeType {
 	changes := make(map[Position]BiomeType)
-	numFires := 2 + rand.Intn(4)
+	numFires := 2 + w.Rand.Intn(4)
 
 	for i := 0; i < numFires; i++ {
-		centerX := rand.Intn(w.Config.GridWidth)
-		centerY := rand.Intn(w.Config.GridHeight)
+		centerX := w.Rand.Intn(w.Config.GridWidth)
+		centerY := w.Rand.Intn(w.Config.GridHeight)
 
 		// Fire spreads in irregular patterns
 		w.spreadAreaEffect(centerX, centerY, 4, 0.8, 2.0, BiomeDesert, changes)
@@ -1831,25 +2160,25 @@ func (w *World) generateFireZones() map[Position]BiomeType {
 // generateFloodZones creates water zones from flooding
 func (w *World) generateFloodZones() map[Position]BiomeType {
 	changes := make(map[Position]BiomeType)
-	numFloodSources := 1 + rand.Intn(2)
+	numFloodSources := 1 + w.Rand.Intn(2)
 
 	for i := 0; i < numFloodSources; i++ {
 		// Start flood from edge of map (representing river overflow)
 		var centerX, centerY int
-		edge := rand.Intn(4)
+		edge := w.Rand.Intn(4)
 		switch edge {
 		case 0: // top edge
-			centerX = rand.Intn(w.Config.GridWidth)
+			centerX = w.Rand.Intn(w.Config.GridWidth)
 			centerY = 0
 		case 1: // right edge
 			centerX = w.Config.GridWidth - 1
-			centerY = rand.Intn(w.Config.GridHeight)
+			centerY = w.Rand.Intn(w.Config.GridHeight)
 		case 2: // bottom edge
-			centerX = rand.Intn(w.Config.GridWidth)
+			centerX = w.Rand.Intn(w.Config.GridWidth)
 			centerY = w.Config.GridHeight - 1
 		case 3: // left edge
 			centerX = 0
-			centerY = rand.Intn(w.Config.GridHeight)
+			centerY = w.Rand.Intn(w.Config.GridHeight)
 		}
 
 		// Flood spreads inward
@@ -1864,16 +2193,16 @@ func (w *World) generateSeismicChanges() map[Position]BiomeType {
 	changes := make(map[Position]BiomeType)
 
 	// Create fault lines that generate mountain ranges
-	numFaults := 1 + rand.Intn(2)
+	numFaults := 1 + w.Rand.Intn(2)
 
 	for i := 0; i < numFaults; i++ {
 		// Random fault line across the map
-		startX := rand.Intn(w.Config.GridWidth)
-		startY := rand.Intn(w.Config.GridHeight)
+		startX := w.Rand.Intn(w.Config.GridWidth)
+		startY := w.Rand.Intn(w.Config.GridHeight)
 
 		// Fault direction
-		angle := rand.Float64() * 2 * math.Pi
-		length := 8 + rand.Intn(12)
+		angle := w.Rand.Float64() * 2 * math.Pi
+		length := 8 + w.Rand.Intn(12)
 
 		for step := 0; step < length; step++ {
 			x := startX + int(float64(step)*math.Cos(angle))
@@ -1887,7 +2216,7 @@ func (w *World) generateSeismicChanges() map[Position]BiomeType {
 					for dx := -1; dx <= 1; dx++ {
 						nx, ny := x+dx, y+dy
 						if nx >= 0 && nx < w.Config.GridWidth && ny >= 0 && ny < w.Config.GridHeight &&
-							rand.Float64() < 0.4 {
+							w.Rand.Float64() < 0.4 {
 							changes[Position{X: float64(nx), Y: float64(ny)}] = BiomeMountain
 						}
 					}
@@ -1899,129 +2228,53 @@ func (w *World) generateSeismicChanges() map[Position]BiomeType {
 	return changes
 }
 
-// BiomeTransition represents a transition between biome states
-type BiomeTransition struct {
-	From        BiomeType
-	To          BiomeType
-	Trigger     string  // "heat", "cold", "fire", "flood", etc.
-	Probability float64 // Base probability of transition
-}
-
-// processBiomeTransitions handles realistic biome state changes
+// processBiomeTransitions handles realistic biome state changes, letting sustained
+// environmental pressure move cells between biomes
 func (w *World) processBiomeTransitions() {
-	transitions := make(map[Position]BiomeType)
-
-	// Define transition rules
-	transitionRules := []BiomeTransition{
-		// Hot spots melt ice - very rare natural transitions
-		{BiomeIce, BiomeWater, "heat", 0.02},
-		{BiomeIce, BiomePlains, "heat", 0.01}, // Ice melts to plains in some cases
-		// Hot springs create effects - more likely near hot springs
-		{BiomeIce, BiomeWater, "hotspring", 0.15},        // Near hot springs, ice melts more rapidly
-		{BiomeWater, BiomeRainforest, "hotspring", 0.05}, // Hot springs create humid environments
-		// Fire effects - moderate probability
-		{BiomeForest, BiomeDesert, "fire", 0.1},
-		{BiomeRainforest, BiomeDesert, "fire", 0.08},
-		{BiomePlains, BiomeDesert, "fire", 0.06},
-		// Fire extinguishing - slow recovery
-		{BiomeDesert, BiomePlains, "water", 0.03}, // Desert recovering after water
-		// Water accumulation effects - gradual
-		{BiomePlains, BiomeSwamp, "flood", 0.08},
-		{BiomeDesert, BiomePlains, "flood", 0.1},
-		// Cold effects - slow freezing
-		{BiomeWater, BiomeIce, "cold", 0.03},
-		{BiomeSwamp, BiomeIce, "cold", 0.04},
-		// Volcanic effects - significant but rare
-		{BiomePlains, BiomeMountain, "volcanic", 0.12},
-		{BiomeForest, BiomeRadiation, "volcanic", 0.08}, // Lava burns forest to radiation zones
-	}
-
-	// Check each grid cell for potential transitions
-	for y := 0; y < w.Config.GridHeight; y++ {
-		for x := 0; x < w.Config.GridWidth; x++ {
-			currentBiome := w.Grid[y][x].Biome
-
-			// Check for transition triggers in nearby cells
-			triggers := w.detectTransitionTriggers(x, y)
-
-			for trigger, intensity := range triggers {
-				for _, rule := range transitionRules {
-					if rule.From == currentBiome && rule.Trigger == trigger {
-						// Probability modified by trigger intensity
-						actualProbability := rule.Probability * intensity
-						if rand.Float64() < actualProbability {
-							transitions[Position{X: float64(x), Y: float64(y)}] = rule.To
-
-							// Log the transition for events
-							if w.EventLogger != nil {
-								event := LogEvent{
-									Timestamp: time.Now(),
-									Tick:      w.Tick,
-									Type: fmt.Sprintf("biome_transition_%s_to_%s",
-										w.getBiomeName(rule.From), w.getBiomeName(rule.To)),
-									Description: fmt.Sprintf("Biome transition from %s to %s triggered by %s",
-										w.getBiomeName(rule.From), w.getBiomeName(rule.To), trigger),
-									Data: map[string]interface{}{
-										"trigger":    trigger,
-										"intensity":  intensity,
-										"from_biome": w.getBiomeName(rule.From),
-										"to_biome":   w.getBiomeName(rule.To),
-										"position_x": float64(x),
-										"position_y": float64(y),
-									},
-								}
-								w.EventLogger.addEvent(event)
-							}
-							break // Only one transition per cell per tick
-						}
-					}
-				}
-			}
-		}
-	}
-
-	// Apply transitions
-	for pos, newBiome := range transitions {
-		gridX, gridY := int(pos.X), int(pos.Y)
-		if gridX >= 0 && gridX < w.Config.GridWidth && gridY >= 0 && gridY < w.Config.GridHeight {
-			w.Grid[gridY][gridX].Biome = newBiome
-		}
+	if w.BiomeStates == nil {
+		w.BiomeStates = NewBiomeStateMachine()
 	}
+	w.BiomeStates.Process(w)
 }
 
 // detectTransitionTriggers identifies environmental conditions that can cause biome transitions
 func (w *World) detectTransitionTriggers(x, y int) map[string]float64 {
 	triggers := make(map[string]float64)
-
-	// Check in a 3x3 neighborhood around the cell
-	for dy := -1; dy <= 1; dy++ {
-		for dx := -1; dx <= 1; dx++ {
-			nx, ny := x+dx, y+dy
-			if nx >= 0 && nx < w.Config.GridWidth && ny >= 0 && ny < w.Config.GridHeight {
-				neighborBiome := w.Grid[ny][nx].Biome
-				distance := math.Sqrt(float64(dx*dx + dy*dy))
-				intensity := 1.0 / (1.0 + distance) // Closer = stronger effect
-
-				switch neighborBiome {
-				case BiomeHotSpring:
-					triggers["heat"] = math.Max(triggers["heat"], intensity*0.8)
-					triggers["hotspring"] = math.Max(triggers["hotspring"], intensity)
-				case BiomeRadiation:
-					triggers["heat"] = math.Max(triggers["heat"], intensity*0.6)
-					triggers["volcanic"] = math.Max(triggers["volcanic"], intensity*0.7)
-				case BiomeWater, BiomeDeepWater, BiomeSwamp:
-					triggers["water"] = math.Max(triggers["water"], intensity*0.5)
-					triggers["flood"] = math.Max(triggers["flood"], intensity*0.3)
-				case BiomeIce, BiomeTundra:
-					triggers["cold"] = math.Max(triggers["cold"], intensity*0.4)
-				}
+	wetNeighbor := false
+
+	// Check the cell and the cells touching it
+	cell := GridPoint{X: x, Y: y}
+	for _, n := range append([]GridPoint{cell}, w.adjacentCells(x, y)...) {
+		neighborBiome := w.Grid[n.Y][n.X].Biome
+		distance := w.GridLayout().CenterDistance(cell, n)
+		intensity := 1.0 / (1.0 + distance) // Closer = stronger effect
+
+		switch neighborBiome {
+		case BiomeHotSpring:
+			triggers["heat"] = math.Max(triggers["heat"], intensity*0.8)
+			triggers["hotspring"] = math.Max(triggers["hotspring"], intensity)
+		case BiomeRadiation:
+			triggers["heat"] = math.Max(triggers["heat"], intensity*0.6)
+			triggers["volcanic"] = math.Max(triggers["volcanic"], intensity*0.7)
+		case BiomeWater, BiomeDeepWater, BiomeSwamp:
+			triggers["water"] = math.Max(triggers["water"], intensity*0.5)
+			triggers["flood"] = math.Max(triggers["flood"], intensity*0.3)
+			if n != cell {
+				wetNeighbor = true
 			}
+		case BiomeIce, BiomeTundra:
+			triggers["cold"] = math.Max(triggers["cold"], intensity*0.4)
 		}
 	}
 
+	// A dried-out cell with no open water beside it is in drought, harder the drier it is
+	if level := w.Grid[y][x].WaterLevel; level < biomeDroughtLevel && !wetNeighbor {
+		triggers["drought"] = 1.0 - level/biomeDroughtLevel
+	}
+
 	// Check for fire events - simplified fire detection
 	// In a real implementation, this would check for active fire events
-	if rand.Float64() < 0.01 { // 1% chance of fire starting in flammable biomes
+	if w.Rand.Float64() < biomeFireStartChance { // Rare chance of fire starting in flammable biomes
 		currentBiome := w.Grid[y][x].Biome
 		if currentBiome == BiomeForest || currentBiome == BiomeRainforest || currentBiome == BiomePlains {
 			triggers["fire"] = 0.8
@@ -2033,6 +2286,11 @@ func (w *World) detectTransitionTriggers(x, y int) map[string]float64 {
 
 // getBiomeName returns human-readable biome name
 func (w *World) getBiomeName(biome BiomeType) string {
+	return biomeName(biome)
+}
+
+// biomeName returns the human-readable name of a biome type
+func biomeName(biome BiomeType) string {
 	biomeNames := map[BiomeType]string{
 		BiomePlains:       "plains",
 		BiomeForest:       "forest",
@@ -2067,12 +2325,13 @@ func (w *World) handleInteractions() {
 			continue
 		}
 
-		for j, entity2 := range w.AllEntities {
+		for _, j := range w.spatialIndex().QueryRadiusSlots(entity1.Position, interactionDistance) {
+			entity2 := w.AllEntities[j]
 			if i >= j || !entity2.IsAlive {
 				continue
 			}
 
-			distance := entity1.DistanceTo(entity2)
+			distance := w.Distance(entity1.Position, entity2.Position)
 			if distance <= interactionDistance {
 				w.processEntityInteraction(entity1, entity2)
 			}
@@ -2091,12 +2350,7 @@ func (w *World) handleEntityPlantInteractions() {
 		}
 
 		// Get entity's grid position
-		gridX := int((entity.Position.X / w.Config.Width) * float64(w.Config.GridWidth))
-		gridY := int((entity.Position.Y / w.Config.Height) * float64(w.Config.GridHeight))
-
-		// Clamp to grid bounds
-		gridX = int(math.Max(0, math.Min(float64(w.Config.GridWidth-1), float64(gridX))))
-		gridY = int(math.Max(0, math.Min(float64(w.Config.GridHeight-1), float64(gridY))))
+		gridX, gridY := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
 
 		cell := &w.Grid[gridY][gridX]
 
@@ -2107,10 +2361,12 @@ func (w *World) handleEntityPlantInteractions() {
 			}
 
 			// Check if entity can and wants to eat this plant
-			if entity.CanEatPlant(plant) && rand.Float64() < 0.4 {
+			// The season decides how much there is to find
+			if entity.CanEatPlant(plant) && w.Rand.Float64() < 0.4*w.seasonEffects().Food {
 				if entity.EatPlant(plant, w.Tick) {
+					w.capEnergy(entity)
 					// Log successful plant consumption
-					if rand.Float64() < 0.1 { // Log 10% of plant eating events
+					if w.Rand.Float64() < 0.1 { // Log 10% of plant eating events
 						w.EventLogger.LogEcosystemShift(w.Tick,
 							fmt.Sprintf("%s ate %s for nutrition", entity.Species, GetPlantConfigs()[plant.Type].Name),
 							map[string]interface{}{
@@ -2125,10 +2381,10 @@ func (w *World) handleEntityPlantInteractions() {
 		}
 
 		// Attempt to drink water if thirsty
-		if rand.Float64() < 0.6 { // 60% chance to attempt drinking each tick
+		if w.Rand.Float64() < 0.6 { // 60% chance to attempt drinking each tick
 			if entity.DrinkWater(w, w.Tick) {
 				// Log successful drinking (less frequently than eating)
-				if rand.Float64() < 0.05 { // Log 5% of drinking events
+				if w.Rand.Float64() < 0.05 { // Log 5% of drinking events
 					w.EventLogger.LogEcosystemShift(w.Tick,
 						fmt.Sprintf("%s drank water", entity.Species),
 						map[string]interface{}{
@@ -2147,8 +2403,8 @@ func (w *World) processEntityInteraction(entity1, entity2 *Entity) {
 	// Same species interactions
 	if entity1.Species == entity2.Species {
 		// Chance to merge if conditions are met
-		if rand.Float64() < 0.01 && entity1.CanMerge(entity2) {
-			merged := entity1.Merge(entity2, w.NextID)
+		if w.Rand.Float64() < 0.01 && entity1.CanMerge(entity2) {
+			merged := entity1.Merge(w.Rand, entity2, w.NextID)
 			if merged != nil {
 				w.NextID++
 				w.AllEntities = append(w.AllEntities, merged)
@@ -2159,18 +2415,31 @@ func (w *World) processEntityInteraction(entity1, entity2 *Entity) {
 
 	// Different species interactions
 	// Try to kill/eat
-	if entity1.CanKill(entity2) && rand.Float64() < 0.1 {
-		entity1.Kill(entity2)
-	} else if entity2.CanKill(entity1) && rand.Float64() < 0.1 {
-		entity2.Kill(entity1)
+	if entity1.CanKill(w.Rand, entity2) && w.Rand.Float64() < w.killChance(entity1) {
+		if entity1.Kill(w.Rand, entity2) {
+			w.recordPredation(entity1, entity2)
+		}
+	} else if entity2.CanKill(w.Rand, entity1) && w.Rand.Float64() < w.killChance(entity2) {
+		if entity2.Kill(w.Rand, entity1) {
+			w.recordPredation(entity2, entity1)
+		}
 	}
 
 	// Try to eat dead entities
-	if !entity2.IsAlive && entity1.CanEat(entity2) && rand.Float64() < 0.3 {
+	if !entity2.IsAlive && entity1.CanEat(entity2) && w.Rand.Float64() < 0.3 {
 		entity1.Eat(entity2, w.Tick)
-	} else if !entity1.IsAlive && entity2.CanEat(entity1) && rand.Float64() < 0.3 {
+	} else if !entity1.IsAlive && entity2.CanEat(entity1) && w.Rand.Float64() < 0.3 {
 		entity2.Eat(entity1, w.Tick)
 	}
+	w.capEnergy(entity1)
+	w.capEnergy(entity2)
+}
+
+// capEnergy holds a creature's energy to the configured maximum after a gain
+func (w *World) capEnergy(entity *Entity) {
+	if entity.Energy > w.SimConfig.Energy.MaxEnergyLevel {
+		entity.Energy = w.SimConfig.Energy.MaxEnergyLevel
+	}
 }
 
 // removeDeadEntities removes dead entities from the world
@@ -2199,7 +2468,8 @@ func (w *World) removeDeadEntities() {
 
 // evolvePopulations runs evolution on each population
 func (w *World) evolvePopulations() {
-	for _, pop := range w.Populations {
+	for _, popName := range sortedKeys(w.Populations) {
+		pop := w.Populations[popName]
 		if len(pop.Entities) < 5 {
 			continue // Skip evolution if population too small
 		}
@@ -2221,7 +2491,7 @@ func (w *World) evolvePopulations() {
 
 		// Only evolve if we have enough entities
 		if len(pop.Entities) >= 10 {
-			pop.Evolve()
+			pop.EvolveWith(w.Mutations)
 
 			// Update world entity list with new entities
 			for _, entity := range pop.Entities {
@@ -2242,28 +2512,29 @@ func (w *World) evolvePopulations() {
 
 // spawnNewEntities creates new random entities to maintain population
 func (w *World) spawnNewEntities() {
-	for _, pop := range w.Populations {
+	for _, popName := range sortedKeys(w.Populations) {
+		pop := w.Populations[popName]
 		if len(pop.Entities) < w.Config.PopulationSize/2 {
 			// Spawn new entity near existing ones
 			if len(pop.Entities) > 0 {
-				parent := pop.Entities[rand.Intn(len(pop.Entities))]
+				parent := pop.Entities[w.Rand.Intn(len(pop.Entities))]
 
 				// Create new entity near parent
 				newPos := Position{
-					X: parent.Position.X + (rand.Float64()-0.5)*10,
-					Y: parent.Position.Y + (rand.Float64()-0.5)*10,
+					X: parent.Position.X + (w.Rand.Float64()-0.5)*10,
+					Y: parent.Position.Y + (w.Rand.Float64()-0.5)*10,
 				}
 
 				// Ensure position is within world bounds
-				newPos.X = math.Max(0, math.Min(w.Config.Width, newPos.X))
-				newPos.Y = math.Max(0, math.Min(w.Config.Height, newPos.Y))
+				newPos = w.KeepOnMap(newPos, 0)
 
-				newEntity := NewEntity(w.NextID, pop.TraitNames, pop.Species, newPos)
+				newEntity := NewEntity(w.Rand, w.NextID, pop.TraitNames, pop.Species, newPos)
 				w.NextID++
 
 				// Copy some traits from parent with mutation
-				for name, trait := range parent.Traits {
-					value := trait.Value + (rand.Float64()-0.5)*0.5
+				for _, name := range sortedKeys(parent.Traits) {
+					trait := parent.Traits[name]
+					value := trait.Value + (w.Rand.Float64()-0.5)*0.5
 					value = math.Max(-2.0, math.Min(2.0, value))
 					newEntity.SetTrait(name, value)
 				}
@@ -2284,9 +2555,9 @@ func (w *World) spawnNewEntities() {
 				}
 
 				// Enhance entity with specialized systems
-				AddInsectTraitsToEntity(newEntity)
-				AddPollinatorTraitsToEntity(newEntity)
-				AddCasteStatusToEntity(newEntity)
+				AddInsectTraitsToEntity(w.Rand, newEntity)
+				AddPollinatorTraitsToEntity(w.Rand, newEntity)
+				AddCasteStatusToEntity(w.Rand, newEntity)
 
 				pop.Entities = append(pop.Entities, newEntity)
 				w.AllEntities = append(w.AllEntities, newEntity)
@@ -2304,8 +2575,8 @@ func (w *World) initializePlants() {
 
 	for i := 0; i < totalPlants; i++ {
 		// Random position
-		x := rand.Intn(w.Config.GridWidth)
-		y := rand.Intn(w.Config.GridHeight)
+		x := w.Rand.Intn(w.Config.GridWidth)
+		y := w.Rand.Intn(w.Config.GridHeight)
 
 		cell := &w.Grid[y][x]
 		biome := w.Biomes[cell.Biome]
@@ -2314,13 +2585,13 @@ func (w *World) initializePlants() {
 		var plantType PlantType
 		switch biome.Type {
 		case BiomePlains:
-			if rand.Float64() < 0.6 {
+			if w.Rand.Float64() < 0.6 {
 				plantType = PlantGrass
 			} else {
 				plantType = PlantBush
 			}
 		case BiomeForest:
-			switch rand.Float64() {
+			switch w.Rand.Float64() {
 			case 0.0:
 				plantType = PlantTree
 			case 0.1:
@@ -2331,13 +2602,13 @@ func (w *World) initializePlants() {
 				plantType = PlantGrass
 			}
 		case BiomeDesert:
-			if rand.Float64() < 0.7 {
+			if w.Rand.Float64() < 0.7 {
 				plantType = PlantCactus
 			} else {
 				plantType = PlantBush
 			}
 		case BiomeMountain:
-			if rand.Float64() < 0.8 {
+			if w.Rand.Float64() < 0.8 {
 				plantType = PlantBush
 			} else {
 				plantType = PlantGrass
@@ -2345,7 +2616,7 @@ func (w *World) initializePlants() {
 		case BiomeWater:
 			plantType = PlantAlgae
 		case BiomeRadiation:
-			if rand.Float64() < 0.6 {
+			if w.Rand.Float64() < 0.6 {
 				plantType = PlantMushroom
 			} else {
 				plantType = PlantBush
@@ -2355,10 +2626,10 @@ func (w *World) initializePlants() {
 		}
 
 		// Create plant at world coordinates
-		worldX := (float64(x) + rand.Float64()) * (w.Config.Width / float64(w.Config.GridWidth))
-		worldY := (float64(y) + rand.Float64()) * (w.Config.Height / float64(w.Config.GridHeight))
+		worldX := (float64(x) + w.Rand.Float64()) * (w.Config.Width / float64(w.Config.GridWidth))
+		worldY := (float64(y) + w.Rand.Float64()) * (w.Config.Height / float64(w.Config.GridHeight))
 
-		plant := NewPlant(w.NextPlantID, plantType, Position{X: worldX, Y: worldY})
+		plant := NewPlant(w.Rand, w.NextPlantID, plantType, Position{X: worldX, Y: worldY})
 		w.NextPlantID++
 
 		// Add to world and grid
@@ -2376,7 +2647,8 @@ func (w *World) GetStats() map[string]interface{} {
 
 	// Population stats
 	populationStats := make(map[string]map[string]interface{})
-	for species, pop := range w.Populations {
+	for _, species := range sortedKeys(w.Populations) {
+		pop := w.Populations[species]
 		popStats := make(map[string]interface{})
 		popStats["count"] = len(pop.Entities)
 
@@ -2405,14 +2677,17 @@ func (w *World) String() string {
 }
 
 // applyTimeEffects applies time-of-day and seasonal effects to entities
-func (w *World) applyTimeEffects(entity *Entity, timeState TimeState) {
+func (w *World) applyTimeEffects(rng *rand.Rand, entity *Entity, timeState TimeState) {
 	// Initialize biorhythm if not present (for compatibility with existing entities)
 	if entity.BioRhythm == nil {
-		entity.BioRhythm = NewBioRhythm(entity.ID, entity)
+		entity.BioRhythm = NewBioRhythm(rng, entity.ID, entity)
 	}
 
 	// Update biorhythm system
-	entity.BioRhythm.Update(w.Tick, entity, timeState)
+	entity.BioRhythm.Update(rng, w.Tick, entity, timeState)
+	if policy, exists := w.speciesPolicy(entity.Species); exists {
+		policy.biasActivityNeeds(entity.BioRhythm)
+	}
 
 	// Apply activity-based energy effects
 	activityModifier := entity.BioRhythm.GetActivityModifier(entity, timeState)
@@ -2520,19 +2795,19 @@ func (w *World) handleEntityCommunication(entity *Entity) {
 	cooperation := entity.GetTrait("cooperation")
 	if intelligence > 0.4 && cooperation > 0.3 {
 		// Send signals based on entity state
-		if entity.Energy < 30 && rand.Float64() < 0.1 {
+		if entity.Energy < 30 && w.Rand.Float64() < 0.1 {
 			// Distress signal
 			w.CommunicationSystem.SendSignal(entity, SignalDanger, map[string]interface{}{
 				"energy":  entity.Energy,
 				"species": entity.Species,
 			}, w.Tick)
-		} else if entity.Energy > 80 && rand.Float64() < 0.05 {
+		} else if entity.Energy > 80 && w.Rand.Float64() < 0.05 {
 			// Food found signal
 			w.CommunicationSystem.SendSignal(entity, SignalFood, map[string]interface{}{
 				"position": entity.Position,
 				"energy":   entity.Energy,
 			}, w.Tick)
-		} else if cooperation > 0.6 && rand.Float64() < 0.03 {
+		} else if cooperation > 0.6 && w.Rand.Float64() < 0.03 {
 			// Cooperation signal
 			w.CommunicationSystem.SendSignal(entity, SignalHelp, map[string]interface{}{
 				"species":     entity.Species,
@@ -2542,7 +2817,7 @@ func (w *World) handleEntityCommunication(entity *Entity) {
 	}
 
 	// Receive and respond to signals
-	receivedSignals := w.CommunicationSystem.ReceiveSignals(entity, w.Tick)
+	receivedSignals := w.CommunicationSystem.ReceiveSignals(entity, w.Tick, w.Geometry())
 	for _, signal := range receivedSignals {
 		w.respondToSignal(entity, signal)
 	}
@@ -2555,19 +2830,16 @@ func (w *World) respondToSignal(entity *Entity, signal Signal) {
 		// Cooperative entities might help
 		if entity.GetTrait("cooperation") > 0.5 && entity.Energy > 50 {
 			// Move toward distress signal
-			dx := entity.Position.X - signal.Position.X
-			dy := entity.Position.Y - signal.Position.Y
-			distance := math.Sqrt(dx*dx + dy*dy)
-			if distance > 1 {
+			if w.Distance(entity.Position, signal.Position) > 1 {
 				speed := entity.GetTrait("speed") * 0.5
-				entity.MoveTo(signal.Position.X, signal.Position.Y, speed)
+				entity.MoveTo(signal.Position.X, signal.Position.Y, speed, w.Geometry())
 			}
 		}
 	case SignalFood:
 		// Move toward food if hungry
 		if entity.Energy < 60 {
 			speed := entity.GetTrait("speed") * 0.3
-			entity.MoveTo(signal.Position.X, signal.Position.Y, speed)
+			entity.MoveTo(signal.Position.X, signal.Position.Y, speed, w.Geometry())
 		}
 	case SignalHelp:
 		// Increase cooperation temporarily
@@ -2595,7 +2867,8 @@ func (w *World) attemptGroupFormation() {
 	}
 
 	// Try to form groups within each species
-	for species, candidates := range groupCandidates {
+	for _, species := range sortedKeys(groupCandidates) {
+		candidates := groupCandidates[species]
 		if len(candidates) < 2 {
 			continue
 		}
@@ -2609,7 +2882,7 @@ func (w *World) attemptGroupFormation() {
 					continue
 				}
 
-				distance := entity1.DistanceTo(entity2)
+				distance := w.Distance(entity1.Position, entity2.Position)
 				if distance <= 15.0 { // Group formation distance
 					nearbyEntities = append(nearbyEntities, entity2)
 				}
@@ -2675,8 +2948,7 @@ func (w *World) processCivilizationActivities() {
 				// Check if entity is near tribe territory
 				inTerritory := false
 				for _, territory := range tribe.Territory {
-					distance := math.Sqrt(math.Pow(entity.Position.X-territory.X, 2) + math.Pow(entity.Position.Y-territory.Y, 2))
-					if distance <= 20.0 {
+					if w.Distance(entity.Position, territory) <= 20.0 {
 						inTerritory = true
 						break
 					}
@@ -2684,7 +2956,7 @@ func (w *World) processCivilizationActivities() {
 
 				if inTerritory && entity.GetTrait("cooperation") > 0.5 && entity.GetTrait("intelligence") > 0.4 {
 					// Try to recruit entity
-					if rand.Float64() < 0.05 { // 5% chance
+					if w.Rand.Float64() < 0.05 { // 5% chance
 						tribe.Members = append(tribe.Members, entity)
 						entity.TribeID = tribe.ID
 					}
@@ -2695,12 +2967,12 @@ func (w *World) processCivilizationActivities() {
 		// Tribe activities based on size and resources
 		if len(tribe.Members) >= 3 {
 			// Larger tribes can build structures
-			if rand.Float64() < 0.02 && len(tribe.Structures) < 5 {
+			if w.Rand.Float64() < 0.02 && len(tribe.Structures) < 5 {
 				w.buildTribeStructure(tribe)
 			}
 
 			// Resource gathering and trading
-			if rand.Float64() < 0.1 {
+			if w.Rand.Float64() < 0.1 {
 				w.processTribeResourceGathering(tribe)
 			}
 		}
@@ -2723,17 +2995,17 @@ func (w *World) buildTribeStructure(tribe *Tribe) {
 	centerY /= float64(len(tribe.Members))
 
 	// Random offset for structure location
-	structX := centerX + (rand.Float64()-0.5)*20
-	structY := centerY + (rand.Float64()-0.5)*20
+	structX := centerX + (w.Rand.Float64()-0.5)*20
+	structY := centerY + (w.Rand.Float64()-0.5)*20
 
 	// Ensure within world bounds
 	structX = math.Max(0, math.Min(w.Config.Width, structX))
 	structY = math.Max(0, math.Min(w.Config.Height, structY))
 	// Determine structure type based on tribe needs
 	var structType StructureType = StructureNest // Default to basic shelter
-	if len(tribe.Structures) > 0 && rand.Float64() < 0.3 {
+	if len(tribe.Structures) > 0 && w.Rand.Float64() < 0.3 {
 		structType = StructureCache // Storage
-	} else if len(tribe.Structures) > 1 && rand.Float64() < 0.2 {
+	} else if len(tribe.Structures) > 1 && w.Rand.Float64() < 0.2 {
 		if tribe.TechLevel >= 3 {
 			structType = StructureFarm // Workshop equivalent
 		} else {
@@ -2761,14 +3033,11 @@ func (w *World) processTribeResourceGathering(tribe *Tribe) {
 		}
 
 		// Check for plants to harvest
-		gridX := int((member.Position.X / w.Config.Width) * float64(w.Config.GridWidth))
-		gridY := int((member.Position.Y / w.Config.Height) * float64(w.Config.GridHeight))
-		gridX = int(math.Max(0, math.Min(float64(w.Config.GridWidth-1), float64(gridX))))
-		gridY = int(math.Max(0, math.Min(float64(w.Config.GridHeight-1), float64(gridY))))
+		gridX, gridY := w.worldToGridCoords(member.Position.X, member.Position.Y)
 
 		cell := &w.Grid[gridY][gridX]
 		for _, plant := range cell.Plants {
-			if plant.IsAlive && rand.Float64() < 0.1 {
+			if plant.IsAlive && w.Rand.Float64() < 0.1 {
 				// Harvest resource from plant
 				harvestedAmount := 1.0 + member.GetTrait("intelligence")*0.5
 				tribe.Resources["food"] += harvestedAmount
@@ -2778,7 +3047,7 @@ func (w *World) processTribeResourceGathering(tribe *Tribe) {
 		}
 
 		// Intelligent members can gather building materials
-		if member.GetTrait("intelligence") > 0.6 && rand.Float64() < 0.05 {
+		if member.GetTrait("intelligence") > 0.6 && w.Rand.Float64() < 0.05 {
 			tribe.Resources["materials"] += 0.5 + member.GetTrait("strength")*0.3
 		}
 	}
@@ -2801,10 +3070,7 @@ func (w *World) trackEntityEnvironmentalExposure(entity *Entity, timeState TimeS
 	}
 
 	// Get entity's current biome
-	gridX := int((entity.Position.X / w.Config.Width) * float64(w.Config.GridWidth))
-	gridY := int((entity.Position.Y / w.Config.Height) * float64(w.Config.GridHeight))
-	gridX = int(math.Max(0, math.Min(float64(w.Config.GridWidth-1), float64(gridX))))
-	gridY = int(math.Max(0, math.Min(float64(w.Config.GridHeight-1), float64(gridY))))
+	gridX, gridY := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
 
 	cell := &w.Grid[gridY][gridX]
 	biome := cell.Biome
@@ -2839,7 +3105,7 @@ func seasonToString(season Season) string {
 func (w *World) updateReproductionSystem() {
 	// Update mating seasons based on current time
 	currentTimeState := w.AdvancedTimeSystem.GetTimeState()
-	w.ReproductionSystem.UpdateMatingSeasons(w.AllEntities, seasonToString(currentTimeState.Season))
+	w.ReproductionSystem.UpdateMatingSeasons(w.AllEntities, seasonToString(currentTimeState.Season), w.seasonEffects().Mating)
 
 	// Enhanced seasonal mating behaviors
 	w.ReproductionSystem.UpdateSeasonalMatingBehaviors(w.AllEntities, currentTimeState.Season, w.Tick)
@@ -2900,8 +3166,7 @@ func (w *World) processMatingMigration() {
 		}
 
 		// Skip if already at preferred location (within tolerance)
-		dx := entity.Position.X - entity.ReproductionStatus.PreferredMatingLocation.X
-		dy := entity.Position.Y - entity.ReproductionStatus.PreferredMatingLocation.Y
+		dx, dy := w.Geometry().Direction(entity.ReproductionStatus.PreferredMatingLocation, entity.Position)
 		distance := math.Sqrt(dx*dx + dy*dy)
 
 		if distance <= 5.0 { // Close enough to preferred location
@@ -2918,6 +3183,9 @@ func (w *World) processMatingMigration() {
 		directionX := dx / distance
 		directionY := dy / distance
 
+		// Settlement lights draw night migrants off course
+		directionX, directionY = w.misdirectMigrant(entity, directionX, directionY)
+
 		// Move towards the target
 		entity.Position.X += directionX * moveSpeed
 		entity.Position.Y += directionY * moveSpeed
@@ -2964,6 +3232,15 @@ func (w *World) processMatingAttempts() {
 
 		// Don't reproduce if entity has low energy (adjusted for classification)
 		energyThreshold := 30.0
+		reproductionChance := 0.1
+		if policy, exists := w.speciesPolicy(entity1.Species); exists {
+			energyThreshold = policy.reproductionEnergyThreshold(energyThreshold)
+			reproductionChance = policy.reproductionChance(reproductionChance)
+		}
+		if w.ResourceBudget != nil {
+			// Crowded biomes leave less food for young
+			reproductionChance *= w.ResourceBudget.ReproductionFactor(w.getBiomeAtPosition(entity1.Position.X, entity1.Position.Y))
+		}
 		maintenanceCost := w.OrganismClassifier.CalculateEnergyMaintenance(entity1, entity1.Classification)
 		if entity1.Energy < energyThreshold+maintenanceCost*5 { // Need 5x maintenance cost as buffer
 			continue
@@ -2975,14 +3252,14 @@ func (w *World) processMatingAttempts() {
 		}
 
 		// Low probability of reproduction to avoid test interference
-		if rand.Float64() > 0.1 { // Only 10% chance per tick per entity
+		if w.Rand.Float64() > reproductionChance { // Only 10% chance per tick per entity unless a policy changes it
 			continue
 		}
 
 		// Find nearby potential mates
-		for j := i + 1; j < len(w.AllEntities); j++ {
+		for _, j := range w.spatialIndex().QueryRadiusSlots(entity1.Position, 5.0) {
 			entity2 := w.AllEntities[j]
-			if !entity2.IsAlive || entity2.ReproductionStatus == nil {
+			if j <= i || !entity2.IsAlive || entity2.ReproductionStatus == nil {
 				continue
 			}
 
@@ -3000,14 +3277,14 @@ func (w *World) processMatingAttempts() {
 			}
 
 			// Check distance (entities need to be close to mate)
-			distance := entity1.DistanceTo(entity2)
+			distance := w.Distance(entity1.Position, entity2.Position)
 			if distance > 5.0 { // Mating range
 				continue
 			}
 
 			// Check for competition - see if there are other potential mates nearby
 			competition := w.checkMatingCompetition(entity1, entity2)
-			if competition && rand.Float64() < 0.7 { // 70% chance competition prevents mating
+			if competition && w.Rand.Float64() < 0.7 { // 70% chance competition prevents mating
 				continue
 			}
 
@@ -3020,8 +3297,8 @@ func (w *World) processMatingAttempts() {
 				switch entity1.ReproductionStatus.Mode {
 				case DirectCoupling:
 					// Create immediate offspring using existing crossover
-					offspring := Crossover(entity1, entity2, w.NextID, entity1.Species)
-					offspring.Mutate(0.1, 0.2) // Some mutation
+					offspring := Crossover(w.Rand, entity1, entity2, w.NextID, entity1.Species)
+					offspring.MutateWith(w.Rand, w.Mutations, w.scaledMutationRate(0.1), 0.2) // Some mutation
 					w.NextID++
 					w.AllEntities = append(w.AllEntities, offspring)
 					w.EventLogger.LogWorldEvent(w.Tick, "birth", fmt.Sprintf("Direct coupling produced entity %d", offspring.ID))
@@ -3029,11 +3306,11 @@ func (w *World) processMatingAttempts() {
 				case Budding:
 					// Asexual reproduction - create clone with mutation
 					if entity1.Energy >= 50.0 {
-						clone := entity1.Clone()
+						clone := entity1.Clone(w.Rand)
 						clone.ID = w.NextID
-						clone.Mutate(0.15, 0.3) // Higher mutation for asexual reproduction
-						clone.Position.X += (rand.Float64() - 0.5) * 4.0
-						clone.Position.Y += (rand.Float64() - 0.5) * 4.0
+						clone.MutateWith(w.Rand, w.Mutations, w.scaledMutationRate(0.15), 0.3) // Higher mutation for asexual reproduction
+						clone.Position.X += (w.Rand.Float64() - 0.5) * 4.0
+						clone.Position.Y += (w.Rand.Float64() - 0.5) * 4.0
 						w.NextID++
 						w.AllEntities = append(w.AllEntities, clone)
 						w.EventLogger.LogWorldEvent(w.Tick, "budding", fmt.Sprintf("Entity %d reproduced by budding, created entity %d", entity1.ID, clone.ID))
@@ -3042,14 +3319,14 @@ func (w *World) processMatingAttempts() {
 				case Fission:
 					// Split into multiple offspring
 					if entity1.Energy >= 80.0 {
-						numOffspring := 2 + rand.Intn(2) // 2-3 offspring
+						numOffspring := 2 + w.Rand.Intn(2) // 2-3 offspring
 						for i := 0; i < numOffspring; i++ {
-							clone := entity1.Clone()
+							clone := entity1.Clone(w.Rand)
 							clone.ID = w.NextID
-							clone.Energy = entity1.Energy / float64(numOffspring+1) // Distribute energy
-							clone.Mutate(0.2, 0.4)                                  // Higher mutation for fission
-							clone.Position.X += (rand.Float64() - 0.5) * 6.0
-							clone.Position.Y += (rand.Float64() - 0.5) * 6.0
+							clone.Energy = entity1.Energy / float64(numOffspring+1)               // Distribute energy
+							clone.MutateWith(w.Rand, w.Mutations, w.scaledMutationRate(0.2), 0.4) // Higher mutation for fission
+							clone.Position.X += (w.Rand.Float64() - 0.5) * 6.0
+							clone.Position.Y += (w.Rand.Float64() - 0.5) * 6.0
 							w.NextID++
 							w.AllEntities = append(w.AllEntities, clone)
 						}
@@ -3180,10 +3457,8 @@ func (w *World) generateTerritories() map[int]*Territory {
 // applyDecayFertilizer enhances plants near decaying organic matter
 func (w *World) applyDecayFertilizer(fertilizer *DecayableItem) {
 	// Find plants within fertilizer range
-	for _, plant := range w.AllPlants {
-		dx := plant.Position.X - fertilizer.Position.X
-		dy := plant.Position.Y - fertilizer.Position.Y
-		distance := math.Sqrt(dx*dx + dy*dy)
+	for _, plant := range w.spatialIndex().QueryPlantRadius(fertilizer.Position, 10.0) {
+		distance := w.Distance(plant.Position, fertilizer.Position)
 
 		if distance <= 10.0 { // Fertilizer effect range
 			// Boost plant energy and growth
@@ -3205,7 +3480,10 @@ func (w *World) checkMatingCompetition(entity1, entity2 *Entity) bool {
 	// Look for other entities nearby that could compete
 	competitorCount := 0
 
-	for _, potential := range w.AllEntities {
+	// Competitors must be within range of either mate, so the ones near the midpoint cover both
+	midpoint := Position{X: (entity1.Position.X + entity2.Position.X) / 2, Y: (entity1.Position.Y + entity2.Position.Y) / 2}
+	reach := 8.0 + w.Distance(entity1.Position, entity2.Position)/2
+	for _, potential := range w.spatialIndex().QueryRadius(midpoint, reach) {
 		if !potential.IsAlive || potential.ReproductionStatus == nil {
 			continue
 		}
@@ -3226,8 +3504,8 @@ func (w *World) checkMatingCompetition(entity1, entity2 *Entity) bool {
 		}
 
 		// Check if competitor is close enough to interfere
-		distance1 := entity1.DistanceTo(potential)
-		distance2 := entity2.DistanceTo(potential)
+		distance1 := w.Distance(entity1.Position, potential.Position)
+		distance2 := w.Distance(entity2.Position, potential.Position)
 
 		if distance1 <= 8.0 || distance2 <= 8.0 { // Competition range larger than mating range
 			// Check if competitor is stronger/more attractive
@@ -3275,13 +3553,14 @@ func (w *World) applyBiomeEffects() {
 
 		// Apply biome-specific effects
 		w.applyBiomeSpecificEffects(entity, biome)
+		w.capEnergy(entity)
 	}
 }
 
 // applyEnvironmentalPressure applies environmental stress based on biome conditions
 func (w *World) applyEnvironmentalPressure(entity *Entity, biome Biome) {
 	// Calculate environmental stress factors
-	temperatureStress := math.Abs(biome.Temperature) * 0.1
+	temperatureStress := math.Abs(w.localTemperature(entity.Position, biome.Temperature)) * 0.1
 	pressureStress := math.Abs(biome.Pressure-1.0) * 0.15
 	oxygenStress := (1.0 - biome.OxygenLevel) * 0.2
 
@@ -3320,8 +3599,8 @@ func (w *World) applyBiomeSpecificEffects(entity *Entity, biome Biome) {
 		if entity.GetTrait("aquatic_adaptation") < 0.7 {
 			entity.Energy -= 0.05 // Reduced from 0.5 for daily time scale
 			// Increase mutation rate due to pressure stress
-			if rand.Float64() < 0.02 {
-				entity.Mutate(0.1, 0.1)
+			if w.Rand.Float64() < 0.02 {
+				entity.MutateWith(w.Rand, w.Mutations, 0.1, 0.1)
 			}
 		}
 
@@ -3340,7 +3619,7 @@ func (w *World) applyBiomeSpecificEffects(entity *Entity, biome Biome) {
 		if entity.GetTrait("endurance") < 0.6 {
 			entity.Energy -= 1.0
 			// Risk of severe energy loss
-			if entity.Energy < 10 && rand.Float64() < 0.01 {
+			if entity.Energy < 10 && w.Rand.Float64() < 0.01 {
 				entity.Energy -= 5 // Severe cold damage
 				if entity.Energy <= 0 {
 					entity.IsAlive = false // Death by freezing
@@ -3368,8 +3647,8 @@ func (w *World) applyBiomeSpecificEffects(entity *Entity, biome Biome) {
 	case BiomeRadiation:
 		// Radiation causes mutations and energy loss
 		entity.Energy -= 1.0
-		if rand.Float64() < 0.1 {
-			entity.Mutate(0.2, 0.15)
+		if w.Rand.Float64() < 0.1 {
+			entity.MutateWith(w.Rand, w.Mutations, 0.2, 0.15)
 		}
 
 	case BiomeCanyon:
@@ -3382,7 +3661,7 @@ func (w *World) applyBiomeSpecificEffects(entity *Entity, biome Biome) {
 
 	case BiomeSwamp:
 		// Disease risk in wetlands
-		if entity.GetTrait("defense") < 0.5 && rand.Float64() < 0.005 {
+		if entity.GetTrait("defense") < 0.5 && w.Rand.Float64() < 0.005 {
 			entity.Energy -= 2.0 // Disease-like energy loss
 		}
 	}
@@ -3405,6 +3684,86 @@ func (w *World) Reset() {
 	// Clear events
 	w.Events = make([]*WorldEvent, 0)
 
+	// Interventions refer to the old world, so they can no longer be undone
+	if w.Interventions != nil {
+		w.Interventions.Clear()
+	}
+	if w.SpeciesPolicies != nil {
+		w.SpeciesPolicies.Clear()
+	}
+	if w.FogOfWar != nil {
+		w.FogOfWar.Clear()
+	}
+	if w.CompetitiveGame != nil {
+		w.CompetitiveGame.Abort(w)
+	}
+	if w.Tutorials != nil {
+		w.Tutorials.Stop(w)
+	}
+	if w.Gallery != nil {
+		w.Gallery.Restart()
+	}
+	if w.Achievements != nil {
+		w.Achievements.Restart()
+	}
+	if w.Seasons != nil {
+		w.Seasons.Restart()
+	}
+	if w.Migrations != nil {
+		w.Migrations.Restart()
+	}
+	if w.Audio != nil {
+		w.Audio.Restart()
+	}
+	if w.Predictions != nil {
+		w.Predictions.CancelOpen(w.Tick)
+	}
+	if w.HashChain != nil {
+		w.HashChain.Restart(0)
+	}
+	if w.PopulationCaps != nil {
+		w.PopulationCaps.Clear()
+	}
+	if w.ResourceBudget != nil {
+		w.ResourceBudget.Clear()
+	}
+	if w.Resources != nil {
+		w.Resources.Clear()
+	}
+	if w.Mutations != nil {
+		w.Mutations.Clear()
+	}
+	if w.Follow != nil {
+		w.Follow.Clear() // The creatures followed are gone
+	}
+	if w.BiomeStates != nil {
+		w.BiomeStates.Clear()
+	}
+	if w.Microclimates != nil {
+		w.Microclimates.Clear()
+	}
+	if w.Pollution != nil {
+		w.Pollution.Clear()
+	}
+	if w.LightPollution != nil {
+		w.LightPollution.Clear()
+	}
+	if w.NoisePollution != nil {
+		w.NoisePollution.Clear()
+	}
+	if w.Zoonoses != nil {
+		w.Zoonoses.Clear()
+	}
+	if w.FoodStorage != nil {
+		w.FoodStorage.Clear()
+	}
+	if w.Demographics != nil {
+		w.Demographics.Clear()
+	}
+	if w.RedQueen != nil {
+		w.RedQueen.Clear()
+	}
+
 	// Clear grid
 	w.clearGrid()
 
@@ -3543,8 +3902,8 @@ func (w *World) recalculateBiomesFromTopology() {
 	cellsToUpdate := totalCells / 50 // Update 2% of cells (was 10%)
 
 	for i := 0; i < cellsToUpdate; i++ {
-		x := rand.Intn(w.Config.GridWidth)
-		y := rand.Intn(w.Config.GridHeight)
+		x := w.Rand.Intn(w.Config.GridWidth)
+		y := w.Rand.Intn(w.Config.GridHeight)
 
 		// Get topology information
 		topoX := int((float64(x) / float64(w.Config.GridWidth)) * float64(w.TopologySystem.Width))
@@ -3639,7 +3998,7 @@ func (w *World) attemptHiveMindFormation() {
 				continue
 			}
 
-			distance := entity.DistanceTo(other)
+			distance := w.Distance(entity.Position, other.Position)
 			if distance < 15.0 {
 				otherIntelligence := other.GetTrait("intelligence")
 				otherCooperation := other.GetTrait("cooperation")
@@ -3716,7 +4075,7 @@ func (w *World) attemptCasteColonyFormation() {
 					continue
 				}
 
-				distance := entity.DistanceTo(other)
+				distance := w.Distance(entity.Position, other.Position)
 				if distance < 20.0 {
 					otherCooperation := other.GetTrait("cooperation")
 					otherIntelligence := other.GetTrait("intelligence")
@@ -3801,7 +4160,8 @@ func (w *World) attemptSwarmFormation() {
 	}
 
 	// Try to form swarms from each group
-	for _, group := range proximityGroups {
+	for _, groupKey := range sortedKeys(proximityGroups) {
+		group := proximityGroups[groupKey]
 		if len(group) >= 5 {
 			// Determine swarm purpose based on group characteristics
 			avgAggression := 0.0
@@ -3874,13 +4234,13 @@ func (w *World) CreateOffspring(parent1, parent2 *Entity) *Entity {
 	}
 
 	// Inherit traits from both parents with some variation
-	for traitName := range parent1.Traits {
+	for _, traitName := range sortedKeys(parent1.Traits) {
 		parent1Value := parent1.Traits[traitName].Value
 		parent2Value := parent2.Traits[traitName].Value
 
 		// Average parent values with some random variation
 		avgValue := (parent1Value + parent2Value) / 2.0
-		variation := (rand.Float64() - 0.5) * 0.2 // Small random variation
+		variation := (w.Rand.Float64() - 0.5) * 0.2 // Small random variation
 		finalValue := avgValue + variation
 
 		// Clamp to reasonable bounds
@@ -3894,25 +4254,76 @@ func (w *World) CreateOffspring(parent1, parent2 *Entity) *Entity {
 
 	// Initialize molecular systems for offspring
 	offspring.MolecularNeeds = NewMolecularNeeds(offspring)
-	offspring.MolecularMetabolism = NewMolecularMetabolism(offspring)
+	offspring.MolecularMetabolism = NewMolecularMetabolism(w.Rand, offspring)
 	offspring.MolecularProfile = CreateEntityMolecularProfile(offspring)
 
 	// Initialize other systems
 	offspring.DietaryMemory = NewDietaryMemory()
 	offspring.EnvironmentalMemory = NewEnvironmentalMemory()
 
-	offspring.ReproductionStatus = NewReproductionStatus()
+	offspring.ReproductionStatus = NewReproductionStatus(w.Rand)
 
 	// Add enhanced systems
-	AddCasteStatusToEntity(offspring)
+	AddCasteStatusToEntity(w.Rand, offspring)
 	if IsEntityInsectLike(offspring) {
-		AddInsectTraitsToEntity(offspring)
-		AddPollinatorTraitsToEntity(offspring)
+		AddInsectTraitsToEntity(w.Rand, offspring)
+		AddPollinatorTraitsToEntity(w.Rand, offspring)
 	}
 
 	return offspring
 }
 
+// eventLoggerSource marks central events mirrored from the EventLogger, which already holds them
+const eventLoggerSource = "event_logger"
+
+// emitExtinctions raises this tick's species extinctions, detected by the event logger, on the central event bus
+func (w *World) emitExtinctions() {
+	if w.CentralEventBus == nil {
+		return
+	}
+	for _, event := range w.EventLogger.GetEventsSince(w.Tick) {
+		if event.Type == EventSpeciesExtinction {
+			w.CentralEventBus.EmitSystemEvent(w.Tick, "extinction", "species", eventLoggerSource, event.Description, nil, event.Data)
+			w.scheduler().Trigger("ecosystem_metrics")
+		}
+	}
+}
+
+// emitSpeciations raises a speciation on the central event bus for each species that
+// creatures evolved into this tick while it had no other living members
+func (w *World) emitSpeciations() {
+	if w.CentralEventBus == nil {
+		return
+	}
+	evolved := make(map[string][]LogEvent)
+	order := make([]string, 0)
+	for _, event := range w.EventLogger.GetEventsSince(w.Tick) {
+		if event.Type != EventSpeciesEvolution {
+			continue
+		}
+		species, _ := event.Data["species"].(string)
+		if _, seen := evolved[species]; !seen {
+			order = append(order, species)
+		}
+		evolved[species] = append(evolved[species], event)
+	}
+	if len(order) == 0 {
+		return
+	}
+
+	living := livingSpeciesCounts(w)
+	for _, species := range order {
+		events := evolved[species]
+		if living[species] > len(events) {
+			continue // Creatures joined a species that already existed
+		}
+		first := events[0]
+		w.CentralEventBus.EmitSystemEvent(w.Tick, EventTypeSpeciation, "species", eventLoggerSource,
+			fmt.Sprintf("New species %s evolved from %v", species, first.Data["from_species"]), nil,
+			map[string]interface{}{"species": species, "from_species": first.Data["from_species"], "founders": len(events)})
+	}
+}
+
 // checkPlayerSpeciesEvents checks for species extinction and splitting events for player notifications
 func (w *World) checkPlayerSpeciesEvents() {
 	if w.PlayerEventsCallback == nil {
@@ -4149,7 +4560,7 @@ func (w *World) updateFireSpread(fire *EnhancedEnvironmentalEvent) {
 						}
 
 						spreadProbability := 0.6 + windBonus - (distance/currentRadius)*0.3
-						if rand.Float64() < spreadProbability {
+						if w.Rand.Float64() < spreadProbability {
 							newAffectedCells[cellPos] = BiomeDesert // Fire turns vegetation to desert
 						}
 					} else if w.isFireExtinguishingBiome(currentBiome) {
@@ -4177,7 +4588,7 @@ func (w *World) updateStormMovement(storm *EnhancedEnvironmentalEvent) {
 	baseWind := w.WindSystem.BaseWindDirection
 
 	// Add some randomness to storm movement
-	stormDirection := baseWind + (rand.Float64()-0.5)*0.5 // ±0.25 radians variation
+	stormDirection := baseWind + (w.Rand.Float64()-0.5)*0.5 // ±0.25 radians variation
 
 	storm.Direction = stormDirection
 	storm.Speed = 1.0 + w.WindSystem.BaseWindStrength*0.5
@@ -4205,12 +4616,12 @@ func (w *World) updateStormMovement(storm *EnhancedEnvironmentalEvent) {
 					// Storm effects depend on type and current biome
 					if storm.Type == "hurricane" || storm.Type == "tornado" {
 						// Destructive storms can create wasteland
-						if rand.Float64() < 0.3 {
+						if w.Rand.Float64() < 0.3 {
 							newAffectedCells[cellPos] = BiomeDesert
 						}
 					} else if storm.Type == "storm" {
 						// Regular storms might create temporary flooding
-						if currentBiome == BiomePlains && rand.Float64() < 0.2 {
+						if currentBiome == BiomePlains && w.Rand.Float64() < 0.2 {
 							newAffectedCells[cellPos] = BiomeSwamp
 						}
 					}
@@ -4244,7 +4655,7 @@ func (w *World) updateVolcanicSpread(volcano *EnhancedEnvironmentalEvent) {
 					// Close to center becomes mountain, further out becomes radiation (lava)
 					if distance < currentRadius*0.3 {
 						newAffectedCells[cellPos] = BiomeMountain
-					} else if rand.Float64() < 0.7 {
+					} else if w.Rand.Float64() < 0.7 {
 						newAffectedCells[cellPos] = BiomeRadiation
 					}
 				}
@@ -4281,7 +4692,7 @@ func (w *World) updateFloodSpread(flood *EnhancedEnvironmentalEvent) {
 
 					// Floods turn plains/desert to swamp/water
 					if currentBiome == BiomePlains || currentBiome == BiomeDesert {
-						if rand.Float64() < 0.6 {
+						if w.Rand.Float64() < 0.6 {
 							newAffectedCells[cellPos] = BiomeSwamp
 						}
 					}
@@ -4309,8 +4720,7 @@ func (w *World) applyEventEffects(event *EnhancedEnvironmentalEvent) {
 			continue
 		}
 
-		distance := math.Sqrt(math.Pow(entity.Position.X-event.Position.X, 2) +
-			math.Pow(entity.Position.Y-event.Position.Y, 2))
+		distance := w.Distance(entity.Position, event.Position)
 
 		if distance <= event.Radius {
 			// Apply event-specific effects
@@ -4318,7 +4728,7 @@ func (w *World) applyEventEffects(event *EnhancedEnvironmentalEvent) {
 
 			if mutationEffect, exists := event.Effects["mutation"]; exists {
 				// Apply mutation by directly calling the Mutate method
-				entity.Mutate(mutationEffect*intensity, 0.1)
+				entity.MutateWith(w.Rand, w.Mutations, mutationEffect*intensity, 0.1)
 			}
 
 			if damageEffect, exists := event.Effects["damage"]; exists {
@@ -4336,8 +4746,7 @@ func (w *World) applyEventEffects(event *EnhancedEnvironmentalEvent) {
 			continue
 		}
 
-		distance := math.Sqrt(math.Pow(plant.Position.X-event.Position.X, 2) +
-			math.Pow(plant.Position.Y-event.Position.Y, 2))
+		distance := w.Distance(plant.Position, event.Position)
 
 		if distance <= event.Radius {
 			intensity := (event.Radius - distance) / event.Radius
@@ -4354,17 +4763,35 @@ func (w *World) applyEventEffects(event *EnhancedEnvironmentalEvent) {
 	}
 }
 
+// environmentalEventTypes are the kinds of enhanced environmental event
+var environmentalEventTypes = []string{"wildfire", "storm", "volcanic_eruption", "flood", "hurricane", "tornado"}
+
 // triggerEnhancedEnvironmentalEvent creates a new enhanced environmental event
 func (w *World) triggerEnhancedEnvironmentalEvent() {
-	eventTypes := []string{"wildfire", "storm", "volcanic_eruption", "flood", "hurricane", "tornado"}
-	eventType := eventTypes[rand.Intn(len(eventTypes))]
+	eventType := environmentalEventTypes[w.Rand.Intn(len(environmentalEventTypes))]
 
 	// Random position for event
 	pos := Position{
-		X: rand.Float64() * float64(w.Config.GridWidth),
-		Y: rand.Float64() * float64(w.Config.GridHeight),
+		X: w.Rand.Float64() * float64(w.Config.GridWidth),
+		Y: w.Rand.Float64() * float64(w.Config.GridHeight),
 	}
+	w.startEnvironmentalEvent(eventType, pos)
+}
 
+// StartEnvironmentalEvent starts an enhanced environmental event of a kind in the middle of
+// a grid cell, as an operator or a tutorial asks
+func (w *World) StartEnvironmentalEvent(eventType string, cell GridPoint) (*EnhancedEnvironmentalEvent, error) {
+	if !slices.Contains(environmentalEventTypes, eventType) {
+		return nil, fmt.Errorf("unknown environmental event %q (use %s)", eventType, strings.Join(environmentalEventTypes, ", "))
+	}
+	if cell.X < 0 || cell.X >= w.Config.GridWidth || cell.Y < 0 || cell.Y >= w.Config.GridHeight {
+		return nil, fmt.Errorf("cell %d,%d is outside the %dx%d grid", cell.X, cell.Y, w.Config.GridWidth, w.Config.GridHeight)
+	}
+	return w.startEnvironmentalEvent(eventType, Position{X: float64(cell.X) + 0.5, Y: float64(cell.Y) + 0.5}), nil
+}
+
+// startEnvironmentalEvent starts an enhanced environmental event at a grid position
+func (w *World) startEnvironmentalEvent(eventType string, pos Position) *EnhancedEnvironmentalEvent {
 	event := &EnhancedEnvironmentalEvent{
 		ID:            w.NextEnvironmentalEventID,
 		Type:          eventType,
@@ -4380,7 +4807,7 @@ func (w *World) triggerEnhancedEnvironmentalEvent() {
 	case "wildfire":
 		event.Name = "Wildfire"
 		event.Description = "Spreading fire burns vegetation"
-		event.Duration = 20 + rand.Intn(20)
+		event.Duration = environmentalEventDurations["wildfire"].roll(w.Rand)
 		event.Radius = 2.0
 		event.MaxRadius = 8.0
 		event.Intensity = 0.8
@@ -4393,7 +4820,7 @@ func (w *World) triggerEnhancedEnvironmentalEvent() {
 	case "storm":
 		event.Name = "Storm"
 		event.Description = "Heavy rainfall and wind"
-		event.Duration = 15 + rand.Intn(15)
+		event.Duration = environmentalEventDurations["storm"].roll(w.Rand)
 		event.Radius = 5.0
 		event.MaxRadius = 12.0
 		event.Intensity = 0.6
@@ -4405,7 +4832,7 @@ func (w *World) triggerEnhancedEnvironmentalEvent() {
 	case "volcanic_eruption":
 		event.Name = "Volcanic Eruption"
 		event.Description = "Lava flows reshape the landscape"
-		event.Duration = 30 + rand.Intn(25)
+		event.Duration = environmentalEventDurations["volcanic_eruption"].roll(w.Rand)
 		event.Radius = 1.0
 		event.MaxRadius = 6.0
 		event.Intensity = 1.0
@@ -4417,7 +4844,7 @@ func (w *World) triggerEnhancedEnvironmentalEvent() {
 	case "flood":
 		event.Name = "Great Flood"
 		event.Description = "Rising waters flood the land"
-		event.Duration = 25 + rand.Intn(20)
+		event.Duration = environmentalEventDurations["flood"].roll(w.Rand)
 		event.Radius = 3.0
 		event.MaxRadius = 10.0
 		event.Intensity = 0.7
@@ -4429,7 +4856,7 @@ func (w *World) triggerEnhancedEnvironmentalEvent() {
 	case "hurricane":
 		event.Name = "Hurricane"
 		event.Description = "Massive rotating storm system"
-		event.Duration = 18 + rand.Intn(12)
+		event.Duration = environmentalEventDurations["hurricane"].roll(w.Rand)
 		event.Radius = 6.0
 		event.MaxRadius = 15.0
 		event.Intensity = 0.9
@@ -4441,7 +4868,7 @@ func (w *World) triggerEnhancedEnvironmentalEvent() {
 	case "tornado":
 		event.Name = "Tornado"
 		event.Description = "Destructive rotating windstorm"
-		event.Duration = 8 + rand.Intn(8)
+		event.Duration = environmentalEventDurations["tornado"].roll(w.Rand)
 		event.Radius = 1.5
 		event.MaxRadius = 3.0
 		event.Intensity = 1.0
@@ -4451,26 +4878,24 @@ func (w *World) triggerEnhancedEnvironmentalEvent() {
 		event.Effects["damage"] = 5.0
 		event.Effects["mutation"] = 0.1
 	}
+	event.Duration = w.scaledEventDuration(event.Duration)
 
 	w.EnvironmentalEvents = append(w.EnvironmentalEvents, event)
 
-	// Log event start
-	if w.EventLogger != nil {
-		startEvent := LogEvent{
-			Timestamp:   time.Now(),
-			Tick:        w.Tick,
-			Type:        "environmental_event_start",
-			Description: fmt.Sprintf("%s started at position (%.1f, %.1f)", event.Name, event.Position.X, event.Position.Y),
-			Data: map[string]interface{}{
+	// Log event start; the central event bus forwards it to the event logger
+	if w.CentralEventBus != nil {
+		position := event.Position
+		w.CentralEventBus.EmitSystemEvent(w.Tick, "environmental_event_start", event.Type, "environment",
+			fmt.Sprintf("%s started at position (%.1f, %.1f)", event.Name, event.Position.X, event.Position.Y), &position,
+			map[string]interface{}{
 				"event_type": event.Type,
 				"position_x": event.Position.X,
 				"position_y": event.Position.Y,
 				"intensity":  event.Intensity,
 				"duration":   event.Duration,
-			},
-		}
-		w.EventLogger.addEvent(startEvent)
+			})
 	}
+	return event
 }
 
 // Helper functions
@@ -4535,19 +4960,14 @@ func (w *World) processDecayNutrientsToSoil() {
 		}
 
 		// Get grid cell for decay item location
-		gridX := int((decayItem.Position.X / w.Config.Width) * float64(w.Config.GridWidth))
-		gridY := int((decayItem.Position.Y / w.Config.Height) * float64(w.Config.GridHeight))
-
-		// Clamp to grid bounds
-		gridX = int(math.Max(0, math.Min(float64(w.Config.GridWidth-1), float64(gridX))))
-		gridY = int(math.Max(0, math.Min(float64(w.Config.GridHeight-1), float64(gridY))))
+		gridX, gridY := w.worldToGridCoords(decayItem.Position.X, decayItem.Position.Y)
 
 		gridCell := &w.Grid[gridY][gridX]
 
 		// Add nutrients gradually as item decays
 		decayProgress := float64(w.Tick-decayItem.CreationTick) / float64(decayItem.DecayPeriod)
 		if decayProgress > 0.1 { // Start releasing nutrients after 10% decay
-			addDecayNutrientsToSoil(gridCell, decayItem)
+			addDecayNutrientsToSoil(w.Rand, gridCell, decayItem)
 		}
 	}
 }
@@ -4565,7 +4985,7 @@ func (w *World) processWeatherEffectsOnSoil() {
 
 					if distance <= event.Radius {
 						intensity := (event.Radius - distance) / event.Radius * event.Intensity
-						processRainfall(&w.Grid[y][x], intensity)
+						processRainfall(w.Rand, &w.Grid[y][x], intensity)
 					}
 				}
 			}
@@ -4573,46 +4993,33 @@ func (w *World) processWeatherEffectsOnSoil() {
 	}
 
 	// Seasonal rainfall patterns
-	currentTimeState := w.AdvancedTimeSystem.GetTimeState()
-	seasonalRainfall := 0.0
-
-	switch currentTimeState.Season {
-	case Spring:
-		seasonalRainfall = 0.02 // Moderate spring rains
-	case Summer:
-		seasonalRainfall = 0.01 // Lighter summer rains
-	case Autumn:
-		seasonalRainfall = 0.03 // Heavier autumn rains
-	case Winter:
-		seasonalRainfall = 0.015 // Light winter precipitation
-	}
+	rainfall := seasonalRainfall(w.AdvancedTimeSystem.GetTimeState().Season)
 
 	// Apply seasonal rainfall randomly across the world
-	if rand.Float64() < 0.3 { // 30% chance of rain each tick
+	if w.Rand.Float64() < 0.3 { // 30% chance of rain each tick
 		for y := 0; y < w.Config.GridHeight; y++ {
 			for x := 0; x < w.Config.GridWidth; x++ {
-				if rand.Float64() < 0.1 { // 10% of cells get rain
-					processRainfall(&w.Grid[y][x], seasonalRainfall)
+				if w.Rand.Float64() < 0.1 { // 10% of cells get rain
+					processRainfall(w.Rand, &w.Grid[y][x], rainfall)
 				}
 			}
 		}
 	}
 }
 
-// getSeasonName converts Season enum to string
-func getSeasonName(season Season) string {
+// seasonalRainfall is the water a shower brings a cell in a season
+func seasonalRainfall(season Season) float64 {
 	switch season {
 	case Spring:
-		return "spring"
+		return 0.02 // Moderate spring rains
 	case Summer:
-		return "summer"
+		return 0.01 // Lighter summer rains
 	case Autumn:
-		return "autumn"
+		return 0.03 // Heavier autumn rains
 	case Winter:
-		return "winter"
-	default:
-		return "spring"
+		return 0.015 // Light winter precipitation
 	}
+	return 0
 }
 
 // calculateMolecularHealth calculates entity's overall molecular health status
@@ -4653,9 +5060,9 @@ func (w *World) processMassDieOffImpacts(deathsBySpecies map[string]int, totalDe
 		eventDetails["species_affected"] = deathsBySpecies
 		eventDetails["population_before"] = populationSize + totalDeaths
 
-		if w.EventLogger != nil {
-			w.EventLogger.LogWorldEvent(w.Tick, severity,
-				fmt.Sprintf("%s event: %.1f%% population loss (%d deaths)", severity, deathRate*100, totalDeaths))
+		if w.CentralEventBus != nil {
+			w.CentralEventBus.EmitSystemEvent(w.Tick, severity, "population", "world",
+				fmt.Sprintf("%s event: %.1f%% population loss (%d deaths)", severity, deathRate*100, totalDeaths), nil, eventDetails)
 		}
 
 		if w.StatisticalReporter != nil {
@@ -4689,8 +5096,8 @@ func (w *World) enhanceNutrientCycling(intensity float64, totalDeaths int) {
 	nutrientBoostCount := int(float64(totalDeaths) * intensity * 0.5)
 
 	for i := 0; i < nutrientBoostCount; i++ {
-		x := rand.Intn(w.Config.GridWidth)
-		y := rand.Intn(w.Config.GridHeight)
+		x := w.Rand.Intn(w.Config.GridWidth)
+		y := w.Rand.Intn(w.Config.GridHeight)
 
 		// Enhance soil nutrients
 		cell := &w.Grid[y][x]
@@ -4716,8 +5123,8 @@ func (w *World) applyContaminationEffects(intensity float64) {
 	contaminationCount := int(intensity * 10) // More contamination with higher death rates
 
 	for i := 0; i < contaminationCount; i++ {
-		x := rand.Intn(w.Config.GridWidth)
-		y := rand.Intn(w.Config.GridHeight)
+		x := w.Rand.Intn(w.Config.GridWidth)
+		y := w.Rand.Intn(w.Config.GridHeight)
 
 		cell := &w.Grid[y][x]
 
@@ -4726,7 +5133,7 @@ func (w *World) applyContaminationEffects(intensity float64) {
 			cell.Event = &WorldEvent{
 				Name:           "contamination",
 				Description:    "Decomposition contamination",
-				Duration:       20 + rand.Intn(20), // 20-40 ticks
+				Duration:       contaminationRange.roll(w.Rand),
 				GlobalDamage:   0.5 * intensity,
 				GlobalMutation: 0.02 * intensity,
 			}
@@ -4787,7 +5194,7 @@ func (w *World) attemptBasicToolsAndModifications() {
 	}
 
 	// Very low chance to create tools/modifications to make the systems visible
-	if rand.Float64() > 0.01 { // 1% chance per tick
+	if w.Rand.Float64() > 0.01 { // 1% chance per tick
 		return
 	}
 
@@ -4803,13 +5210,13 @@ func (w *World) attemptBasicToolsAndModifications() {
 		return
 	}
 
-	entity := eligibleEntities[rand.Intn(len(eligibleEntities))]
+	entity := eligibleEntities[w.Rand.Intn(len(eligibleEntities))]
 
 	// 50% chance for tool, 50% for environmental modification
-	if rand.Float64() < 0.5 {
+	if w.Rand.Float64() < 0.5 {
 		// Create a basic tool
 		toolTypes := []ToolType{ToolStone, ToolStick, ToolSpear, ToolHammer}
-		toolType := toolTypes[rand.Intn(len(toolTypes))]
+		toolType := toolTypes[w.Rand.Intn(len(toolTypes))]
 		tool := w.ToolSystem.CreateTool(entity, toolType, entity.Position)
 		if tool != nil && w.EventLogger != nil {
 			w.EventLogger.LogWorldEvent(w.Tick, "tool_creation",
@@ -4817,7 +5224,7 @@ func (w *World) attemptBasicToolsAndModifications() {
 		}
 	} else {
 		// Create an environmental modification (use specific methods)
-		switch rand.Intn(4) {
+		switch w.Rand.Intn(4) {
 		case 0:
 			mod := w.EnvironmentalModSystem.CreateBurrow(entity, entity.Position)
 			if mod != nil && w.EventLogger != nil {
@@ -4832,8 +5239,8 @@ func (w *World) attemptBasicToolsAndModifications() {
 			}
 		case 2:
 			// Create tunnel
-			direction := rand.Float64() * 2 * math.Pi
-			length := 3.0 + rand.Float64()*5.0
+			direction := w.Rand.Float64() * 2 * math.Pi
+			length := 3.0 + w.Rand.Float64()*5.0
 			mod := w.EnvironmentalModSystem.CreateTunnel(entity, entity.Position, direction, length)
 			if mod != nil && w.EventLogger != nil {
 				w.EventLogger.LogWorldEvent(w.Tick, "environment_modification",
@@ -4857,13 +5264,13 @@ func (w *World) calculateEnvironmentalFactors(entity *Entity, gridX, gridY int)
 	cell := w.Grid[gridY][gridX]
 
 	// Temperature based on biome and season
-	timeState := w.AdvancedTimeSystem.GetTimeState()
 	baseTemp := w.getBiomeTemperature(cell.Biome)
-	seasonalMod := w.getSeasonalTemperatureModifier(w.seasonToString(timeState.Season))
-	environment["temperature"] = baseTemp * seasonalMod
+	seasonalMod := w.seasonEffects().Temperature
+	micro := w.Microclimates.At(gridX, gridY)
+	environment["temperature"] = baseTemp * seasonalMod * (1 - micro.Cooling)
 
-	// Humidity based on biome
-	environment["humidity"] = w.getBiomeHumidity(cell.Biome)
+	// Humidity based on biome, held in by cover
+	environment["humidity"] = micro.humidity(w.getBiomeHumidity(cell.Biome))
 
 	// Food availability based on nearby plants and resources
 	foodCount := 0
@@ -4899,12 +5306,12 @@ func (w *World) calculateEnvironmentalFactors(entity *Entity, gridX, gridY int)
 	threatLevel := 0.0
 	entityCount := 0
 
-	for _, other := range w.AllEntities {
+	for _, other := range w.spatialIndex().QueryRadius(entity.Position, 10.0) {
 		if other == nil || !other.IsAlive || other.ID == entity.ID {
 			continue
 		}
 
-		distance := entity.DistanceTo(other)
+		distance := w.Distance(entity.Position, other.Position)
 		if distance < 10.0 { // Within threat detection range
 			entityCount++
 			aggression := other.GetTrait("aggression")
@@ -4984,35 +5391,22 @@ func (w *World) getBiomeHumidity(biome BiomeType) float64 {
 	}
 }
 
-// getSeasonalTemperatureModifier returns seasonal temperature adjustment
-func (w *World) getSeasonalTemperatureModifier(season string) float64 {
-	switch season {
-	case "spring":
-		return 0.8
-	case "summer":
-		return 1.2
-	case "autumn":
-		return 0.9
-	case "winter":
-		return 0.6
-	default:
-		return 1.0
-	}
-}
-
 // getTemperatureAt returns temperature at a specific position
 func (w *World) getTemperatureAt(pos Position) float64 {
 	biome := w.getBiomeAtPosition(pos.X, pos.Y)
 	baseTemp := w.getBiomeTemperature(biome)
 
 	// Apply seasonal modifier
-	season := w.getCurrentSeason()
-	seasonMod := w.getSeasonalTemperatureModifier(season)
+	seasonMod := w.seasonEffects().Temperature
+
+	// Tree cover and structures shade the cell
+	gridX, gridY := w.worldToGridCoords(pos.X, pos.Y)
+	cooling := w.Microclimates.At(gridX, gridY).Cooling
 
 	// Add some randomness for micro-climates
-	randomVariation := (rand.Float64()*2 - 1) * 3.0 // ±3 degrees
+	randomVariation := (w.Rand.Float64()*2 - 1) * 3.0 // ±3 degrees
 
-	return baseTemp*seasonMod + randomVariation
+	return baseTemp*seasonMod*(1-cooling) + randomVariation
 }
 
 // getMoistureAt returns moisture level at a specific position
@@ -5033,28 +5427,21 @@ func (w *World) getMoistureAt(pos Position) float64 {
 		baseMoisture *= 0.9 // Winter conditions
 	}
 
+	// Tree cover holds moisture in
+	gridX, gridY := w.worldToGridCoords(pos.X, pos.Y)
+	baseMoisture = w.Microclimates.At(gridX, gridY).humidity(math.Min(1.0, baseMoisture))
+
 	// Add randomness for local weather
-	randomVariation := (rand.Float64()*2 - 1) * 0.2 // ±20%
+	randomVariation := (w.Rand.Float64()*2 - 1) * 0.2 // ±20%
 
 	return math.Max(0.0, math.Min(1.0, baseMoisture+randomVariation))
 }
 
 // getSunlightAt returns sunlight level at a specific position
 func (w *World) getSunlightAt(pos Position) float64 {
-	// Base sunlight depends on day/night cycle
-	dayTime := float64(w.Tick%100) / 100.0 // 100 ticks per day
-
-	var baseSunlight float64
-	if dayTime >= 0.25 && dayTime <= 0.75 { // Day time
-		// Peak at noon (0.5), fade at dawn/dusk
-		if dayTime <= 0.5 {
-			baseSunlight = (dayTime - 0.25) * 4.0 // 0.25 to 0.5 -> 0 to 1
-		} else {
-			baseSunlight = (0.75 - dayTime) * 4.0 // 0.5 to 0.75 -> 1 to 0
-		}
-	} else {
-		baseSunlight = 0.0 // Night time
-	}
+	// Base sunlight is the daylight the world clock casts on the cell's latitude
+	_, gridY := w.gridCell(pos)
+	baseSunlight := w.daylightAt(gridY)
 
 	// Biome effects on sunlight
 	biome := w.getBiomeAtPosition(pos.X, pos.Y)
@@ -5074,8 +5461,7 @@ func (w *World) getSunlightAt(pos Position) float64 {
 		for _, event := range w.EnvironmentalEvents {
 			if event.Type == "storm" || event.Type == "ash_cloud" {
 				// Check if position is affected by the event
-				distance := math.Sqrt(math.Pow(pos.X-event.Position.X, 2) + math.Pow(pos.Y-event.Position.Y, 2))
-				if distance <= event.Radius {
+				if w.Distance(pos, event.Position) <= event.Radius {
 					baseSunlight *= 0.3 // Storms block sunlight
 				}
 			}
@@ -5114,22 +5500,6 @@ func (w *World) getCurrentSeason() string {
 	}
 }
 
-// seasonToString converts Season enum to string
-func (w *World) seasonToString(season Season) string {
-	switch season {
-	case Spring:
-		return "spring"
-	case Summer:
-		return "summer"
-	case Autumn:
-		return "autumn"
-	case Winter:
-		return "winter"
-	default:
-		return "unknown"
-	}
-}
-
 // getElevationAt returns the elevation at a given position
 func (w *World) getElevationAt(position Position) float64 {
 	if w.TopologySystem != nil {
@@ -5145,23 +5515,63 @@ func (w *World) isValidPosition(position Position) bool {
 	return x >= 0 && x < w.Config.GridWidth && y >= 0 && y < w.Config.GridHeight
 }
 
+// killChance is the chance per interaction that a creature presses an attack it could win
+func (w *World) killChance(entity *Entity) float64 {
+	chance := 0.1
+	if policy, exists := w.speciesPolicy(entity.Species); exists {
+		chance = policy.killChance(chance)
+	}
+	return chance * w.litHuntModifier(entity) * w.darkHuntModifier(entity)
+}
+
+// thinkingSlots lists the slots of the living entities intelligent enough to be steered by
+// a neural network that has been built for them, in slot order
+func thinkingSlots(components *EntityComponents) []int {
+	intelligence := components.Trait("intelligence")
+	slots := make([]int, 0)
+	if intelligence == nil {
+		return slots
+	}
+	for slot := 0; slot < components.Len(); slot++ {
+		if components.Health[slot].Alive && intelligence[slot] > 0.3 && components.Neural[slot] != nil {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
 // processNeuralDecisions handles neural network decision making for intelligent entities
 func (w *World) processNeuralDecisions() {
-	for _, entity := range w.AllEntities {
-		if !entity.IsAlive || entity.GetTrait("intelligence") <= 0.3 {
-			continue // Skip dead or non-intelligent entities
-		}
+	components := w.entityComponents()
 
-		// Check if entity has a neural network
-		if w.NeuralAISystem.EntityNetworks[entity.ID] == nil {
-			continue // Network will be created on next update
-		}
+	// With RNG streams every network's inputs are sensed up front, chunk by chunk
+	var sensed map[int][]float64
+	if w.RNG != nil {
+		sensed = w.environmentalInputsByChunk(components)
+	}
+
+	for _, slot := range thinkingSlots(components) {
+		entity := components.Entities[slot]
 
 		// Create environmental inputs for the neural network
-		environmentInputs := w.createEnvironmentalInputs(entity)
+		environmentInputs, exists := sensed[entity.ID]
+		if !exists {
+			environmentInputs = w.createEnvironmentalInputs(entity)
+		}
+		policy, hasPolicy := w.speciesPolicy(entity.Species)
+		if hasPolicy {
+			policy.biasNeuralInputs(environmentInputs)
+		}
 
 		// Get neural network decision
 		outputs := w.NeuralAISystem.ProcessNeuralDecision(entity, environmentInputs, w.Tick)
+		if hasPolicy {
+			policy.biasNeuralOutputs(outputs)
+		}
+
+		if w.Follow != nil {
+			w.Follow.recordDecision(entity.ID, w.NeuralAISystem.EntityNetworks[entity.ID], environmentInputs, outputs)
+		}
 
 		// Apply neural decision to entity behavior
 		w.applyNeuralDecision(entity, outputs, environmentInputs)
@@ -5175,10 +5585,7 @@ func (w *World) createEnvironmentalInputs(entity *Entity) []float64 {
 	// Input 0: Vision/Environmental awareness (0-1)
 	// Based on entity's vision trait and nearby environment
 	vision := entity.GetTrait("vision")
-	gridX := int((entity.Position.X / w.Config.Width) * float64(w.Config.GridWidth))
-	gridY := int((entity.Position.Y / w.Config.Height) * float64(w.Config.GridHeight))
-	gridX = int(math.Max(0, math.Min(float64(w.Config.GridWidth-1), float64(gridX))))
-	gridY = int(math.Max(0, math.Min(float64(w.Config.GridHeight-1), float64(gridY))))
+	gridX, gridY := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
 
 	// Environmental awareness based on biome favorability
 	biome := w.Grid[gridY][gridX].Biome
@@ -5193,9 +5600,9 @@ func (w *World) createEnvironmentalInputs(entity *Entity) []float64 {
 	threatLevel := 0.0
 
 	// Check for nearby aggressive entities
-	for _, other := range w.AllEntities {
+	for _, other := range w.spatialIndex().QueryRadius(entity.Position, 10.0) {
 		if other.ID != entity.ID && other.IsAlive {
-			distance := entity.DistanceTo(other)
+			distance := w.Distance(entity.Position, other.Position)
 			if distance < 10.0 { // Within threat detection range
 				aggression := other.GetTrait("aggression")
 				threat := aggression * (10.0 - distance) / 10.0
@@ -5216,10 +5623,9 @@ func (w *World) createEnvironmentalInputs(entity *Entity) []float64 {
 	foodLevel := 0.0
 
 	// Check for nearby plants
-	for _, plant := range w.AllPlants {
+	for _, plant := range w.spatialIndex().QueryPlantRadius(entity.Position, 15.0) {
 		if plant.IsAlive {
-			distance := math.Sqrt(math.Pow(entity.Position.X-plant.Position.X, 2) +
-				math.Pow(entity.Position.Y-plant.Position.Y, 2))
+			distance := w.Distance(entity.Position, plant.Position)
 			if distance < 15.0 { // Within foraging range
 				plantValue := plant.Energy / 100.0
 				foodLevel += plantValue * (15.0 - distance) / 15.0
@@ -5229,9 +5635,9 @@ func (w *World) createEnvironmentalInputs(entity *Entity) []float64 {
 
 	// Check for nearby prey entities (if carnivorous)
 	if entity.GetTrait("aggression") > 0.3 {
-		for _, other := range w.AllEntities {
+		for _, other := range w.spatialIndex().QueryRadius(entity.Position, 10.0) {
 			if other.ID != entity.ID && other.IsAlive {
-				distance := entity.DistanceTo(other)
+				distance := w.Distance(entity.Position, other.Position)
 				if distance < 10.0 && other.Energy < entity.Energy { // Potential prey
 					preyValue := other.Energy / 100.0
 					foodLevel += preyValue * (10.0 - distance) / 10.0
@@ -5249,9 +5655,9 @@ func (w *World) createEnvironmentalInputs(entity *Entity) []float64 {
 
 	if cooperation > 0.3 {
 		nearbyEntities := 0
-		for _, other := range w.AllEntities {
+		for _, other := range w.spatialIndex().QueryRadius(entity.Position, 8.0) {
 			if other.ID != entity.ID && other.IsAlive {
-				distance := entity.DistanceTo(other)
+				distance := w.Distance(entity.Position, other.Position)
 				if distance < 8.0 { // Social interaction range
 					if other.Species == entity.Species || other.GetTrait("cooperation") > 0.3 {
 						socialLevel += cooperation * (8.0 - distance) / 8.0
@@ -5294,13 +5700,10 @@ func (w *World) applyNeuralDecision(entity *Entity, outputs []float64, inputs []
 		targetY := entity.Position.Y + moveY*speed
 
 		// Apply environment-aware movement
-		gridX := int((entity.Position.X / w.Config.Width) * float64(w.Config.GridWidth))
-		gridY := int((entity.Position.Y / w.Config.Height) * float64(w.Config.GridHeight))
-		gridX = int(math.Max(0, math.Min(float64(w.Config.GridWidth-1), float64(gridX))))
-		gridY = int(math.Max(0, math.Min(float64(w.Config.GridHeight-1), float64(gridY))))
+		gridX, gridY := w.worldToGridCoords(entity.Position.X, entity.Position.Y)
 		biome := w.Grid[gridY][gridX].Biome
 
-		entity.MoveToWithEnvironment(targetX, targetY, speed, biome)
+		entity.MoveToWithEnvironment(targetX, targetY, speed, biome, w.Geometry())
 
 		// Provide learning feedback based on movement outcome
 		w.provideNeuralFeedback(entity, inputs, outputs, actionIntensity)
@@ -5369,8 +5772,14 @@ func (w *World) SetSpeedMultiplier(multiplier float64) {
 	// Update the simulation configuration to reflect the new speed
 	// Always apply to the default config to avoid cumulative effects
 	baseConfig := DefaultSimulationConfig()
+	if w.Config.Simulation != nil {
+		configured := *w.Config.Simulation
+		baseConfig = &configured
+	}
 	// Preserve world-specific settings
 	baseConfig.World = w.SimConfig.World
+	baseConfig.Population = w.SimConfig.Population
+	baseConfig.Timescales = w.SimConfig.Timescales
 	w.SimConfig = baseConfig.ApplySpeedMultiplier(multiplier)
 }
 
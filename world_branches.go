@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// World branch limits
+const (
+	maxWorldBranches     = 4   // Branches running alongside the live world at once
+	branchSampleInterval = 10  // Ticks between comparison samples
+	maxBranchSamples     = 200 // Samples kept per timeline
+)
+
+// BranchSample is one point of a timeline's history for the comparison dashboard
+type BranchSample struct {
+	Tick       int     `json:"tick"`
+	Population int     `json:"population"`
+	Plants     int     `json:"plants"`
+	Species    int     `json:"species"`
+	MeanEnergy float64 `json:"mean_energy"`
+}
+
+// WorldBranch is an alternate timeline forked from the live world. It runs alongside the
+// live world, tick for tick, unless paused.
+type WorldBranch struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	ForkTick int    `json:"fork_tick"`
+	Paused   bool   `json:"paused"`
+	World    *World `json:"-"`
+
+	history      []BranchSample
+	trunkHistory []BranchSample // The live world over the same ticks
+}
+
+// BranchComparison sets a branch against the live world
+type BranchComparison struct {
+	ID           int            `json:"id"`
+	Name         string         `json:"name"`
+	ForkTick     int            `json:"fork_tick"`
+	Paused       bool           `json:"paused"`
+	Branch       BranchSample   `json:"branch"`
+	Trunk        BranchSample   `json:"trunk"`
+	BranchCounts map[string]int `json:"branch_species"`
+	TrunkCounts  map[string]int `json:"trunk_species"`
+	History      []BranchSample `json:"history"`
+	TrunkHistory []BranchSample `json:"trunk_history"`
+}
+
+// WorldBranchSystem forks the live world into alternate timelines so an intervention can be
+// tried in a branch while the original carries on
+type WorldBranchSystem struct {
+	mu       sync.Mutex
+	branches map[int]*WorldBranch
+	nextID   int
+}
+
+// NewWorldBranchSystem creates a branch system with no branches
+func NewWorldBranchSystem() *WorldBranchSystem {
+	return &WorldBranchSystem{branches: make(map[int]*WorldBranch), nextID: 1}
+}
+
+// Fork snapshots the live world into a new branch. The snapshot carries what a save file
+// does: creatures, plants, terrain, world events, time and wind.
+func (bs *WorldBranchSystem) Fork(trunk *World, name string) (BranchComparison, error) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	if len(bs.branches) >= maxWorldBranches {
+		return BranchComparison{}, fmt.Errorf("at most %d branches can run at once; remove one first", maxWorldBranches)
+	}
+
+	state, err := NewStateManager(trunk).CurrentState()
+	if err != nil {
+		return BranchComparison{}, fmt.Errorf("failed to snapshot the world: %v", err)
+	}
+	// Each branch draws from a seed of its own, so branches forked together still diverge
+	config := trunk.Config
+	config.Seed = trunk.Seed + int64(bs.nextID)
+	world := NewWorld(config)
+	if err := NewStateManager(world).restoreState(state); err != nil {
+		return BranchComparison{}, fmt.Errorf("failed to build the branch: %v", err)
+	}
+	world.SimConfig = trunk.SimConfig
+	world.RandomEventsOff = trunk.RandomEventsOff
+	world.RespawnOff = trunk.RespawnOff
+	world.Deterministic = trunk.Deterministic
+	world.RNG = trunk.RNG
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = fmt.Sprintf("Branch %d", bs.nextID)
+	}
+	branch := &WorldBranch{ID: bs.nextID, Name: name, ForkTick: trunk.Tick, World: world}
+	bs.nextID++
+	bs.branches[branch.ID] = branch
+	branch.sample(trunk)
+
+	if trunk.CentralEventBus != nil {
+		trunk.CentralEventBus.EmitSystemEvent(trunk.Tick, "world_forked", "branch", "world_branches",
+			fmt.Sprintf("World forked into %q at tick %d", name, trunk.Tick), nil,
+			map[string]interface{}{"branch_id": branch.ID, "name": name})
+	}
+	return branch.compare(trunk), nil
+}
+
+// Step advances every running branch by the ticks the live world just ran, keeping the
+// timelines in step for comparison
+func (bs *WorldBranchSystem) Step(trunk *World, ticks int) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	for _, id := range sortedKeys(bs.branches) {
+		branch := bs.branches[id]
+		if branch.Paused {
+			continue
+		}
+		for i := 0; i < ticks; i++ {
+			branch.World.Update()
+			if branch.World.Tick%branchSampleInterval == 0 {
+				branch.sample(trunk)
+			}
+		}
+	}
+}
+
+// With runs fn on a branch's world while holding the lock, e.g. to apply an intervention
+func (bs *WorldBranchSystem) With(id int, fn func(branch *WorldBranch)) error {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	branch, exists := bs.branches[id]
+	if !exists {
+		return fmt.Errorf("branch %d not found", id)
+	}
+	fn(branch)
+	return nil
+}
+
+// Remove discards a branch
+func (bs *WorldBranchSystem) Remove(id int) bool {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	_, exists := bs.branches[id]
+	delete(bs.branches, id)
+	return exists
+}
+
+// Compare sets every branch against the live world, in ID order
+func (bs *WorldBranchSystem) Compare(trunk *World) []BranchComparison {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	comparisons := make([]BranchComparison, 0, len(bs.branches))
+	for _, id := range sortedKeys(bs.branches) {
+		comparisons = append(comparisons, bs.branches[id].compare(trunk))
+	}
+	return comparisons
+}
+
+// Diff compares a branch's state with the live world's in the detail of a save diff
+func (bs *WorldBranchSystem) Diff(trunk *World, id int) (*SaveDiff, error) {
+	trunkState, err := NewStateManager(trunk).CurrentState()
+	if err != nil {
+		return nil, err
+	}
+	var branchState *SimulationState
+	if err := bs.With(id, func(branch *WorldBranch) {
+		branchState, err = NewStateManager(branch.World).CurrentState()
+	}); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return DiffStates(trunkState, branchState), nil
+}
+
+// sample records where both timelines stand; the caller holds the lock
+func (branch *WorldBranch) sample(trunk *World) {
+	branch.history = appendBranchSample(branch.history, sampleWorld(branch.World))
+	branch.trunkHistory = appendBranchSample(branch.trunkHistory, sampleWorld(trunk))
+}
+
+// compare builds the branch's comparison with the live world; the caller holds the lock
+func (branch *WorldBranch) compare(trunk *World) BranchComparison {
+	return BranchComparison{
+		ID:           branch.ID,
+		Name:         branch.Name,
+		ForkTick:     branch.ForkTick,
+		Paused:       branch.Paused,
+		Branch:       sampleWorld(branch.World),
+		Trunk:        sampleWorld(trunk),
+		BranchCounts: livingSpeciesCounts(branch.World),
+		TrunkCounts:  livingSpeciesCounts(trunk),
+		History:      append([]BranchSample(nil), branch.history...),
+		TrunkHistory: append([]BranchSample(nil), branch.trunkHistory...),
+	}
+}
+
+// sampleWorld measures a world for the comparison dashboard
+func sampleWorld(w *World) BranchSample {
+	sample := BranchSample{Tick: w.Tick, Species: len(livingSpeciesCounts(w))}
+	totalEnergy := 0.0
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive {
+			sample.Population++
+			totalEnergy += entity.Energy
+		}
+	}
+	if sample.Population > 0 {
+		sample.MeanEnergy = totalEnergy / float64(sample.Population)
+	}
+	for _, plant := range w.AllPlants {
+		if plant.IsAlive {
+			sample.Plants++
+		}
+	}
+	return sample
+}
+
+// appendBranchSample adds a sample, dropping the oldest beyond maxBranchSamples
+func appendBranchSample(samples []BranchSample, sample BranchSample) []BranchSample {
+	samples = append(samples, sample)
+	if len(samples) > maxBranchSamples {
+		samples = samples[len(samples)-maxBranchSamples:]
+	}
+	return samples
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestForkRunsAlongsideTheLiveWorld(t *testing.T) {
+	trunk := newSteppingTestWorld(11)
+	for i := 0; i < 5; i++ {
+		trunk.Update()
+	}
+	branches := NewWorldBranchSystem()
+
+	forked, err := branches.Fork(trunk, "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forked.Name != "Branch 1" || forked.ForkTick != 5 || forked.Branch != forked.Trunk {
+		t.Fatalf("Expected an identical default-named branch at tick 5, got %+v", forked)
+	}
+
+	// An intervention in the branch leaves the live world alone
+	var victim *Entity
+	for _, entity := range trunk.AllEntities {
+		if entity.IsAlive {
+			victim = entity
+			break
+		}
+	}
+	var editErr error
+	if err := branches.With(forked.ID, func(branch *WorldBranch) {
+		_, editErr = branch.World.EditEntityTrait(victim.ID, "speed", 0.95)
+	}); err != nil || editErr != nil {
+		t.Fatal(err, editErr)
+	}
+	if victim.GetTrait("speed") == 0.95 {
+		t.Fatal("Expected the branch to hold its own copy of each creature")
+	}
+
+	for i := 0; i < branchSampleInterval; i++ {
+		trunk.Update()
+		branches.Step(trunk, 1)
+	}
+	comparison := branches.Compare(trunk)[0]
+	if comparison.Branch.Tick != trunk.Tick || len(comparison.History) != 2 || len(comparison.TrunkHistory) != 2 {
+		t.Errorf("Expected the branch to keep pace and be sampled, got %+v", comparison)
+	}
+
+	// A paused branch stays where it is
+	if err := branches.With(forked.ID, func(branch *WorldBranch) { branch.Paused = true }); err != nil {
+		t.Fatal(err)
+	}
+	trunk.Update()
+	branches.Step(trunk, 1)
+	if comparison = branches.Compare(trunk)[0]; comparison.Branch.Tick != trunk.Tick-1 {
+		t.Errorf("Expected a paused branch to stop at tick %d, got %d", trunk.Tick-1, comparison.Branch.Tick)
+	}
+
+	diff, err := branches.Diff(trunk, forked.ID)
+	if err != nil || diff == nil {
+		t.Fatalf("Expected a diff between the timelines, got %v", err)
+	}
+	if _, err := branches.Diff(trunk, 99); err == nil {
+		t.Error("Expected diffing an unknown branch to fail")
+	}
+
+	for i := len(branches.Compare(trunk)); i < maxWorldBranches; i++ {
+		if _, err := branches.Fork(trunk, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := branches.Fork(trunk, ""); err == nil {
+		t.Error("Expected forks beyond the limit to be rejected")
+	}
+	if !branches.Remove(forked.ID) || branches.Remove(forked.ID) {
+		t.Error("Expected a branch to be removed exactly once")
+	}
+}
+
+func TestBranchesAPI(t *testing.T) {
+	wi := NewWebInterface(newSteppingTestWorld(13))
+
+	rec := httptest.NewRecorder()
+	wi.handleBranches(rec, httptest.NewRequest(http.MethodPost, "/api/branches", bytes.NewReader([]byte(`{"name": "No predators"}`))))
+	var branch BranchComparison
+	if rec.Code != http.StatusCreated || json.Unmarshal(rec.Body.Bytes(), &branch) != nil || branch.Name != "No predators" {
+		t.Fatalf("Forking failed: %d %s", rec.Code, rec.Body.String())
+	}
+
+	// Stepping the live world steps its branches too
+	rec = httptest.NewRecorder()
+	wi.handleStep(rec, httptest.NewRequest(http.MethodPost, "/api/step", bytes.NewReader([]byte(`{"ticks": 4}`))))
+	rec = httptest.NewRecorder()
+	wi.handleBranches(rec, httptest.NewRequest(http.MethodGet, "/api/branches", nil))
+	var comparisons []BranchComparison
+	if err := json.Unmarshal(rec.Body.Bytes(), &comparisons); err != nil || len(comparisons) != 1 || comparisons[0].Branch.Tick != 4 {
+		t.Fatalf("Expected the branch to follow the step, got %s", rec.Body.String())
+	}
+
+	// Operator interventions can target the branch
+	rec = httptest.NewRecorder()
+	wi.handleInterventions(rec, httptest.NewRequest(http.MethodPost, "/api/operator/interventions?branch=99", bytes.NewReader([]byte(`{"action": "undo"}`))))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected an unknown branch to be refused, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	wi.handleTerraform(rec, httptest.NewRequest(http.MethodPost, "/api/operator/terraform?branch=1", bytes.NewReader([]byte(`{"biome": "desert", "cells": [{"x": 0, "y": 0}]}`))))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Terraforming the branch failed: %d %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	wi.handleInterventions(rec, httptest.NewRequest(http.MethodGet, "/api/operator/interventions?branch=1", nil))
+	var history struct {
+		Undo []Intervention `json:"undo"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &history); err != nil || len(history.Undo) != 1 {
+		t.Errorf("Expected the branch's intervention history, got %s", rec.Body.String())
+	}
+	if undo, _ := wi.world.Interventions.History(); len(undo) != 0 {
+		t.Error("Expected the live world's history to be untouched")
+	}
+
+	rec = httptest.NewRecorder()
+	wi.handleBranch(rec, httptest.NewRequest(http.MethodGet, "/api/branches/branch?id=1", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected a diff of the branch, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	wi.handleBranch(rec, httptest.NewRequest(http.MethodDelete, "/api/branches/branch?id=1", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Expected the branch to be removed, got %d", rec.Code)
+	}
+}
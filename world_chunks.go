@@ -0,0 +1,232 @@
+package main
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// worldChunkCells is the side of a world chunk in grid cells
+const worldChunkCells = 8
+
+// Prefixes of the streams of each chunk's updates
+const (
+	rngStreamPlants   = "plants:"
+	rngStreamEntities = "entities:"
+)
+
+// worldChunks partitions the grid into square chunks of cells. Plant growth, creature updates
+// and creature physics are split by chunk and each chunk touches only state it owns, so the chunks can
+// update on separate workers; whatever they would share is buffered per chunk and merged in
+// chunk order afterwards. A run gives the same world whether its chunks update one after
+// another or concurrently.
+type worldChunks struct {
+	columns, rows int
+}
+
+func (w *World) chunks() worldChunks {
+	return worldChunks{
+		columns: (w.Config.GridWidth + worldChunkCells - 1) / worldChunkCells,
+		rows:    (w.Config.GridHeight + worldChunkCells - 1) / worldChunkCells,
+	}
+}
+
+// count is the number of chunks
+func (c worldChunks) count() int {
+	return c.columns * c.rows
+}
+
+// gridCell returns the grid cell a position falls in, clamped to the grid
+func (w *World) gridCell(pos Position) (int, int) {
+	return w.worldToGridCoords(pos.X, pos.Y)
+}
+
+// chunkOf returns the chunk owning a grid cell
+func (c worldChunks) chunkOf(gridX, gridY int) int {
+	return (gridY/worldChunkCells)*c.columns + gridX/worldChunkCells
+}
+
+// forEachChunk runs update for every chunk index, spread over one worker per CPU when the
+// world's RNG streams are parallel and one after another otherwise
+func (w *World) forEachChunk(count int, update func(chunk int)) {
+	workers := 1
+	if w.RNG != nil && w.RNG.Parallel {
+		workers = min(runtime.GOMAXPROCS(0), count)
+	}
+	if workers <= 1 {
+		for chunk := 0; chunk < count; chunk++ {
+			update(chunk)
+		}
+		return
+	}
+
+	next := make(chan int, count)
+	for chunk := 0; chunk < count; chunk++ {
+		next <- chunk
+	}
+	close(next)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range next {
+				update(chunk)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// updatePlantsByChunk grows the plants chunk by chunk. A chunk's plants feed only on the soil
+// of its own cells and roll for malnutrition from the chunk's own stream; the decaying matter
+// of plants that die is added once every chunk is done, in chunk order.
+func (w *World) updatePlantsByChunk(growth float64) {
+	chunks := w.chunks()
+	plants := make([][]*Plant, chunks.count())
+	for _, plant := range w.AllPlants {
+		if plant.IsAlive {
+			chunk := chunks.chunkOf(w.gridCell(plant.Position))
+			plants[chunk] = append(plants[chunk], plant)
+		}
+	}
+
+	died := make([][]*Plant, len(plants))
+	w.forEachChunk(len(plants), func(chunk int) {
+		if len(plants[chunk]) == 0 {
+			return
+		}
+		rng := w.RNG.Stream(rngStreamPlants+strconv.Itoa(chunk), w.Tick)
+		for _, plant := range plants[chunk] {
+			gridX, gridY := w.gridCell(plant.Position)
+			gridCell := &w.Grid[gridY][gridX]
+
+			share := w.plantShare(gridX, gridY)
+			nutritionalHealth := plant.updatePlantNutrientsWithBudget(gridCell, growth, share)
+			plant.UpdateWithBudget(w.Biomes[gridCell.Biome], share)
+
+			// Severe malnutrition - chance of death
+			if nutritionalHealth < 0.5 && rng.Float64() < (0.5-nutritionalHealth)*0.1 {
+				plant.IsAlive = false
+				died[chunk] = append(died[chunk], plant)
+			}
+		}
+	})
+
+	if w.ReproductionSystem == nil {
+		return
+	}
+	for _, dead := range died {
+		for _, plant := range dead {
+			w.ReproductionSystem.AddDecayingItem("plant_matter", plant.Position, plant.NutritionVal*plant.Size, "plant", plant.Size, w.Tick)
+		}
+	}
+}
+
+// updateEntitiesByChunk updates the creatures chunk by chunk in two passes. The first runs
+// what touches nothing but the creature itself (biome drain and mutation, wandering,
+// biorhythm and aging) with the chunk's own stream and mutation log. The second resolves
+// what creatures share, one chunk after another: it merges the mutation logs in chunk order,
+// then runs metamorphosis, starvation-driven evolution and signalling, which look at the
+// creatures around and change the world's records.
+func (w *World) updateEntitiesByChunk(timeState TimeState) {
+	chunks := w.chunks()
+	members := make([][]*Entity, chunks.count())
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive {
+			chunk := chunks.chunkOf(w.gridCell(entity.Position))
+			members[chunk] = append(members[chunk], entity)
+		}
+	}
+
+	mutations := make([]*MutationSystem, len(members))
+	w.forEachChunk(len(members), func(chunk int) {
+		if len(members[chunk]) == 0 {
+			return
+		}
+		rng := w.RNG.Stream(rngStreamEntities+strconv.Itoa(chunk), w.Tick)
+		classifier := w.OrganismClassifier.withRand(rng)
+		mutations[chunk] = w.Mutations.fork()
+		for _, entity := range members[chunk] {
+			w.updateEntityWithBiome(rng, mutations[chunk], entity)
+			w.trackEntityEnvironmentalExposure(entity, timeState)
+			w.applyTimeEffects(rng, entity, timeState)
+			entity.UpdateWithClassificationAndConfig(classifier, w.CellularSystem, w.SimConfig)
+		}
+	})
+
+	for chunk := range members {
+		w.Mutations.merge(mutations[chunk])
+		for _, entity := range members[chunk] {
+			entity.CheckStarvation(w)
+			entity.CheckComplexity(w)
+			w.updateMetamorphosis(entity)
+			w.handleEntityCommunication(entity)
+		}
+	}
+}
+
+// updateEntityPhysicsByChunk moves the creatures chunk by chunk in two passes. The first
+// sums the attraction between every pair of creatures within range of each other from where
+// they all stand at the start of the pass; the second applies each creature's forces, fluids and drag to it alone. The
+// collision check that follows settles creatures the moves brought together.
+func (w *World) updateEntityPhysicsByChunk(deltaTime float64) {
+	chunks := w.chunks()
+	members := make([][]*Entity, chunks.count())
+	for _, entity := range w.AllEntities {
+		if entity.IsAlive && w.PhysicsComponents[entity.ID] != nil {
+			chunk := chunks.chunkOf(w.gridCell(entity.Position))
+			members[chunk] = append(members[chunk], entity)
+		}
+	}
+
+	index, reach := w.spatialIndex(), w.PhysicsSystem.attractionReach()
+	w.forEachChunk(len(members), func(chunk int) {
+		for _, entity := range members[chunk] {
+			physics := w.PhysicsComponents[entity.ID]
+			for _, other := range index.QueryRadius(entity.Position, reach) {
+				if other.ID != entity.ID && other.IsAlive && w.PhysicsComponents[other.ID] != nil {
+					force := w.PhysicsSystem.CalculateAttraction(entity, other, physics, w.PhysicsComponents[other.ID], w.Geometry())
+					w.PhysicsSystem.ApplyForce(physics, force)
+				}
+			}
+		}
+	})
+
+	w.forEachChunk(len(members), func(chunk int) {
+		for _, entity := range members[chunk] {
+			physics := w.PhysicsComponents[entity.ID]
+			gridX, gridY := w.gridCell(entity.Position)
+			w.PhysicsSystem.ApplyFluidEffects(entity, physics, w.FluidRegions, w.Geometry())
+			w.PhysicsSystem.ApplyPhysics(entity, physics, w.Grid[gridY][gridX].Biome, deltaTime)
+		}
+	})
+}
+
+// environmentalInputsByChunk senses the neural network inputs of every thinking creature
+// chunk by chunk. Sensing only reads the world, so the creatures all see it as it stands
+// before any of them acts on their decision.
+func (w *World) environmentalInputsByChunk(components *EntityComponents) map[int][]float64 {
+	chunks := w.chunks()
+	members := make([][]*Entity, chunks.count())
+	for _, slot := range thinkingSlots(components) {
+		chunk := chunks.chunkOf(w.gridCell(components.Positions[slot]))
+		members[chunk] = append(members[chunk], components.Entities[slot])
+	}
+
+	inputs := make([][][]float64, len(members))
+	w.forEachChunk(len(members), func(chunk int) {
+		inputs[chunk] = make([][]float64, len(members[chunk]))
+		for i, entity := range members[chunk] {
+			inputs[chunk][i] = w.createEnvironmentalInputs(entity)
+		}
+	})
+
+	sensed := make(map[int][]float64)
+	for chunk := range members {
+		for i, entity := range members[chunk] {
+			sensed[entity.ID] = inputs[chunk][i]
+		}
+	}
+	return sensed
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestWorldChunksPartitionTheGrid(t *testing.T) {
+	world := NewWorld(WorldConfig{Width: 100, Height: 100, GridWidth: 20, GridHeight: 10})
+	chunks := world.chunks()
+	if chunks.count() != 6 {
+		t.Fatalf("Expected a 3x2 partition of a 20x10 grid, got %+v", chunks)
+	}
+	cells := make([]int, chunks.count())
+	for y := 0; y < world.Config.GridHeight; y++ {
+		for x := 0; x < world.Config.GridWidth; x++ {
+			cells[chunks.chunkOf(x, y)]++
+		}
+	}
+	if cells[0] != worldChunkCells*worldChunkCells || cells[5] != 4*2 {
+		t.Errorf("Expected every cell in exactly one chunk, got %v", cells)
+	}
+	if x, y := world.gridCell(Position{X: 150, Y: -3}); x != 19 || y != 0 {
+		t.Errorf("Expected positions off the grid clamped to its edge, got %d,%d", x, y)
+	}
+}
+
+func TestChunkedUpdatesMatchAcrossWorkers(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	config := DefaultDeterminismConfig()
+	config.World.PopulationSize = 40
+	config.Ticks = 40
+	config.Parallel = true
+	report := VerifyDeterminism(config)
+	if report.Diverged || report.TicksCompared != config.Ticks {
+		t.Fatalf("Expected chunks updated on 4 workers to match one after another, got: %s", report.Summary())
+	}
+}
+
+// benchmarkTicks times the ticks of a large seeded world, its creatures, plants and physics
+// updated chunk by chunk on every CPU or one after another as a whole
+func benchmarkTicks(b *testing.B, chunked bool) {
+	config := DefaultDeterminismConfig()
+	config.World.Width, config.World.Height = 400, 400
+	config.World.GridWidth, config.World.GridHeight = 160, 100
+	config.World.PopulationSize = 300
+	config.World.Seed = 146
+	world := NewWorld(config.World)
+	world.Deterministic = true
+	if chunked {
+		world.RNG = NewRNGStreams(config.World.Seed, true)
+	}
+	for _, population := range startingPopulations(false) {
+		world.AddPopulation(population)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		world.Update()
+	}
+	b.ReportMetric(float64(len(world.AllEntities)), "entities")
+}
+
+func BenchmarkTickSerial(b *testing.B)  { benchmarkTicks(b, false) }
+func BenchmarkTickChunked(b *testing.B) { benchmarkTicks(b, true) }
@@ -0,0 +1,53 @@
+package main
+
+// fixtureWorldSeed seeds the worlds made by newTestWorld, so the systems run against them
+// draw the same numbers every run
+const fixtureWorldSeed = 7
+
+// newTestWorld makes a seeded square world of a size, on a grid of a number of cells a side,
+// with neither plants nor creatures
+func newTestWorld(size float64, cells int) *World {
+	world := NewWorld(WorldConfig{Width: size, Height: size, GridWidth: cells, GridHeight: cells, Seed: fixtureWorldSeed})
+	world.AllPlants = nil
+	world.AllEntities = nil
+	return world
+}
+
+// newEmptyTestWorld makes a test world of a size in cells of ten units
+func newEmptyTestWorld(size float64) *World {
+	return newTestWorld(size, int(size/10))
+}
+
+// fillTestBiome turns every cell of a world into one biome
+func fillTestBiome(world *World, biome BiomeType) {
+	for y := range world.Grid {
+		for x := range world.Grid[y] {
+			world.Grid[y][x].Biome = biome
+		}
+	}
+}
+
+// steppingTestConfig is the small seeded world determinism runs use, with five creatures
+// to each starting population
+func steppingTestConfig(seed int64) WorldConfig {
+	config := DefaultDeterminismConfig().World
+	config.PopulationSize = 5
+	config.Seed = seed
+	return config
+}
+
+// newPopulatedTestWorld builds a world the way determinism runs do: it updates its creatures
+// in order and holds the starting populations
+func newPopulatedTestWorld(config WorldConfig, primitive bool) *World {
+	world := NewWorld(config)
+	world.Deterministic = true
+	for _, population := range startingPopulations(primitive) {
+		world.AddPopulation(population)
+	}
+	return world
+}
+
+// newSteppingTestWorld builds a small seeded world the way determinism runs do
+func newSteppingTestWorld(seed int64) *World {
+	return newPopulatedTestWorld(steppingTestConfig(seed), false)
+}
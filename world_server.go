@@ -0,0 +1,547 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// defaultAutosaveInterval is the ticks between autosaves of a hosted world that doesn't set
+// its own interval
+const defaultAutosaveInterval = 1000
+
+// Files kept for each hosted world in its directory below the server's data directory
+const (
+	hostedWorldSettingsFile = "world.json"
+	hostedWorldStateFile    = "state.json"
+	hostedWorldGalleryDir   = "gallery"
+)
+
+// worldNamePattern keeps world names usable as directory names and URL path segments
+var worldNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,39}$`)
+
+// HostedWorldSettings is how a hosted world was created, kept beside its autosaves so the
+// server can bring it back after a restart
+type HostedWorldSettings struct {
+	Name             string    `json:"name"`
+	Config           RunConfig `json:"config"`
+	AutosaveInterval int       `json:"autosave_interval"` // Ticks between autosaves, 0 to only save on shutdown
+	Created          time.Time `json:"created"`
+}
+
+// CreateWorldRequest is the body of POST /api/worlds
+type CreateWorldRequest struct {
+	Name             string     `json:"name"`
+	Config           *RunConfig `json:"config,omitempty"`            // A run config, as given to --config; empty for the defaults
+	AutosaveInterval *int       `json:"autosave_interval,omitempty"` // Ticks between autosaves, 0 to only save on shutdown
+}
+
+// HostedWorldSummary describes a hosted world in the lobby
+type HostedWorldSummary struct {
+	Name             string     `json:"name"`
+	URL              string     `json:"url"`
+	Tick             int        `json:"tick"`
+	Entities         int        `json:"entities"`
+	Plants           int        `json:"plants"`
+	Populations      int        `json:"populations"`
+	Players          int        `json:"players"`
+	Clients          int        `json:"clients"`
+	Paused           bool       `json:"paused"`
+	AutosaveInterval int        `json:"autosave_interval"`
+	LastSavedTick    int        `json:"last_saved_tick"` // -1 before the first save
+	LastSaved        *time.Time `json:"last_saved,omitempty"`
+	Created          time.Time  `json:"created"`
+}
+
+// HostedWorld is a world the server runs with its own web interface, players and autosaves
+type HostedWorld struct {
+	Settings      HostedWorldSettings
+	wi            *WebInterface
+	routes        http.Handler
+	dir           string
+	saveMutex     sync.Mutex // Held while the world is written to disk
+	lastSavedTick int
+	lastSaved     time.Time
+}
+
+// WorldServer hosts any number of named worlds in one process. Each world keeps a directory
+// below the data directory with its settings and latest save, is served below
+// /worlds/<name>/ and is listed, created and deleted from the lobby.
+type WorldServer struct {
+	dataDir string
+	worlds  map[string]*HostedWorld
+	mutex   sync.RWMutex
+	// The class whose students each have a world, nil without one
+	classroom      *Classroom
+	classroomMutex sync.Mutex
+	// Readiness: running from Start, closing once Close begins saving
+	running atomic.Bool
+	closing atomic.Bool
+}
+
+// NewWorldServer creates a server for the worlds in a data directory, bringing back every
+// world saved there
+func NewWorldServer(dataDir string) (*WorldServer, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create the data directory: %v", err)
+	}
+	server := &WorldServer{dataDir: dataDir, worlds: make(map[string]*HostedWorld)}
+
+	entries, err := os.ReadDir(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the data directory: %v", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !worldNamePattern.MatchString(entry.Name()) {
+			continue
+		}
+		dir := filepath.Join(dataDir, entry.Name())
+		data, err := os.ReadFile(filepath.Join(dir, hostedWorldSettingsFile))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("world %s: %v", entry.Name(), err)
+		}
+		var settings HostedWorldSettings
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return nil, fmt.Errorf("world %s: invalid settings: %v", entry.Name(), err)
+		}
+		settings.Name = entry.Name()
+		hosted, err := newHostedWorld(settings, dir)
+		if err != nil {
+			return nil, fmt.Errorf("world %s: %v", entry.Name(), err)
+		}
+		server.worlds[settings.Name] = hosted
+	}
+
+	if server.classroom, err = loadClassroom(dataDir); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// newHostedWorld builds a world from its settings, restoring its latest save if there is one
+func newHostedWorld(settings HostedWorldSettings, dir string) (*HostedWorld, error) {
+	if err := settings.Config.Validate(); err != nil {
+		return nil, err
+	}
+	worldConfig, err := settings.Config.WorldConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	world := NewWorld(worldConfig)
+	if settings.Config.Speed.Multiplier > 0 {
+		world.SetSpeedMultiplier(settings.Config.Speed.Multiplier)
+	}
+
+	hosted := &HostedWorld{Settings: settings, dir: dir, lastSavedTick: -1}
+	if err := world.Gallery.SetDir(filepath.Join(dir, hostedWorldGalleryDir)); err != nil {
+		return nil, err
+	}
+	statePath := filepath.Join(dir, hostedWorldStateFile)
+	if _, err := os.Stat(statePath); err == nil {
+		if err := NewStateManager(world).LoadFromFile(statePath); err != nil {
+			return nil, err
+		}
+		hosted.lastSavedTick = world.Tick
+	} else {
+		populations := startingPopulations(settings.Config.Primitive)
+		if len(settings.Config.Populations) > 0 {
+			populations = settings.Config.StartingPopulations(worldConfig.Width, worldConfig.Height)
+		}
+		for _, population := range populations {
+			world.AddPopulation(population)
+		}
+	}
+
+	hosted.wi = NewWebInterface(world)
+	hosted.wi.governor.SetUnlimited(settings.Config.Speed.Unlimited)
+	if settings.Config.Speed.MaxCPU > 0 {
+		hosted.wi.governor.SetMaxCPU(settings.Config.Speed.MaxCPU / 100)
+	}
+	hosted.routes = http.StripPrefix("/worlds/"+settings.Name, hosted.wi.Routes())
+	return hosted, nil
+}
+
+// Summary describes the world for the lobby
+func (h *HostedWorld) Summary() HostedWorldSummary {
+	h.wi.tickMutex.Lock()
+	world := h.wi.world
+	summary := HostedWorldSummary{
+		Name:             h.Settings.Name,
+		URL:              "/worlds/" + h.Settings.Name + "/",
+		Tick:             world.Tick,
+		Entities:         len(world.AllEntities),
+		Plants:           len(world.AllPlants),
+		Populations:      len(world.Populations),
+		Paused:           world.IsPaused(),
+		AutosaveInterval: h.Settings.AutosaveInterval,
+		Created:          h.Settings.Created,
+	}
+	h.wi.tickMutex.Unlock()
+
+	summary.Players = len(h.wi.playerManager.GetActivePlayers())
+	summary.Clients = h.wi.WebSocketStats().Clients
+	h.saveMutex.Lock()
+	summary.LastSavedTick = h.lastSavedTick
+	if !h.lastSaved.IsZero() {
+		lastSaved := h.lastSaved
+		summary.LastSaved = &lastSaved
+	}
+	h.saveMutex.Unlock()
+	return summary
+}
+
+// Save writes the world's state to its directory between ticks
+func (h *HostedWorld) Save() error {
+	h.saveMutex.Lock()
+	defer h.saveMutex.Unlock()
+
+	// Write beside the last save and swap, so a crash mid-write never loses it
+	path := filepath.Join(h.dir, hostedWorldStateFile)
+	h.wi.tickMutex.Lock()
+	tick := h.wi.world.Tick
+	err := NewStateManager(h.wi.world).WriteStateFile(path + ".tmp")
+	h.wi.tickMutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to save world %s: %v", h.Settings.Name, err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to save world %s: %v", h.Settings.Name, err)
+	}
+	h.lastSavedTick = tick
+	h.lastSaved = time.Now()
+	return nil
+}
+
+// dueForAutosave reports whether the world has run its autosave interval since the last save
+func (h *HostedWorld) dueForAutosave() bool {
+	if h.Settings.AutosaveInterval <= 0 {
+		return false
+	}
+	h.wi.tickMutex.Lock()
+	tick := h.wi.world.Tick
+	h.wi.tickMutex.Unlock()
+	h.saveMutex.Lock()
+	defer h.saveMutex.Unlock()
+	return tick-max(h.lastSavedTick, 0) >= h.Settings.AutosaveInterval
+}
+
+// CreateWorld starts a new world and records its settings
+func (s *WorldServer) CreateWorld(request CreateWorldRequest) (*HostedWorld, error) {
+	if !worldNamePattern.MatchString(request.Name) {
+		return nil, fmt.Errorf("world names are 1-40 lowercase letters, digits, - and _, starting with a letter or digit")
+	}
+	settings := HostedWorldSettings{Name: request.Name, AutosaveInterval: defaultAutosaveInterval, Created: time.Now()}
+	if request.Config != nil {
+		settings.Config = *request.Config
+	}
+	if request.AutosaveInterval != nil {
+		if *request.AutosaveInterval < 0 {
+			return nil, fmt.Errorf("autosave interval must not be negative")
+		}
+		settings.AutosaveInterval = *request.AutosaveInterval
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, exists := s.worlds[request.Name]; exists {
+		return nil, fmt.Errorf("world %q already exists", request.Name)
+	}
+
+	dir := filepath.Join(s.dataDir, request.Name)
+	hosted, err := newHostedWorld(settings, dir)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the world settings: %v", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create the world directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, hostedWorldSettingsFile), data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write the world settings: %v", err)
+	}
+
+	s.worlds[request.Name] = hosted
+	hosted.wi.Start()
+	log.Printf("Created world %s", request.Name)
+	return hosted, nil
+}
+
+// DeleteWorld stops a world, disconnects its clients and removes its files
+func (s *WorldServer) DeleteWorld(name string) error {
+	s.mutex.Lock()
+	hosted, exists := s.worlds[name]
+	delete(s.worlds, name)
+	s.mutex.Unlock()
+	if !exists {
+		return fmt.Errorf("no world named %q", name)
+	}
+
+	hosted.wi.Stop()
+	hosted.wi.disconnectClients("world deleted")
+	hosted.saveMutex.Lock()
+	defer hosted.saveMutex.Unlock()
+	if err := os.RemoveAll(hosted.dir); err != nil {
+		return fmt.Errorf("failed to remove world %s: %v", name, err)
+	}
+	log.Printf("Deleted world %s", name)
+	return nil
+}
+
+// World returns a hosted world by name
+func (s *WorldServer) World(name string) (*HostedWorld, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	hosted, exists := s.worlds[name]
+	return hosted, exists
+}
+
+// hostedWorlds lists the worlds by name
+func (s *WorldServer) hostedWorlds() []*HostedWorld {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	worlds := make([]*HostedWorld, 0, len(s.worlds))
+	for _, name := range sortedKeys(s.worlds) {
+		worlds = append(worlds, s.worlds[name])
+	}
+	return worlds
+}
+
+// Worlds describes every hosted world by name
+func (s *WorldServer) Worlds() []HostedWorldSummary {
+	summaries := make([]HostedWorldSummary, 0)
+	for _, hosted := range s.hostedWorlds() {
+		summaries = append(summaries, hosted.Summary())
+	}
+	return summaries
+}
+
+// Start runs every hosted world
+func (s *WorldServer) Start() {
+	s.running.Store(true)
+	for _, hosted := range s.hostedWorlds() {
+		hosted.wi.Start()
+	}
+}
+
+// Autosave saves the worlds that have run their autosave interval since their last save
+func (s *WorldServer) Autosave() {
+	for _, hosted := range s.hostedWorlds() {
+		if !hosted.dueForAutosave() {
+			continue
+		}
+		if err := hosted.Save(); err != nil {
+			log.Printf("Autosave failed: %v", err)
+		}
+	}
+}
+
+// autosaveLoop checks for worlds due an autosave every second until ctx is cancelled
+func (s *WorldServer) autosaveLoop(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.Autosave()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Close stops every world and saves it
+func (s *WorldServer) Close() error {
+	s.closing.Store(true)
+	var failed []string
+	for _, hosted := range s.hostedWorlds() {
+		hosted.wi.Stop()
+		hosted.wi.disconnectClients("server shutting down")
+		if err := hosted.Save(); err != nil {
+			log.Printf("%v", err)
+			failed = append(failed, hosted.Settings.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to save %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// Handler routes the lobby, its API, the classroom dashboard and every world's interface
+// below /worlds/<name>/
+func (s *WorldServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.serveLobby)
+	mux.HandleFunc("/api/worlds", s.handleWorlds)
+	mux.HandleFunc("/worlds/", s.serveWorld)
+	mux.HandleFunc("/classroom", s.serveClassroom)
+	mux.HandleFunc("/api/classroom", s.handleClassroom)
+	mux.HandleFunc("/api/classroom/students", s.handleClassroomStudents)
+	mux.HandleFunc("/api/classroom/pause", s.handleClassroomPause)
+	mux.HandleFunc("/api/classroom/broadcast", s.handleClassroomBroadcast)
+	mux.HandleFunc("/api/classroom/results", s.handleClassroomResults)
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/readyz", s.handleReadyz)
+	mux.HandleFunc("/static/", func(w http.ResponseWriter, r *http.Request) {
+		webAssets.ServeStatic(w, r)
+	})
+	return mux
+}
+
+// serveLobby serves the lobby page
+func (s *WorldServer) serveLobby(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	webAssets.ServePage(w, "lobby")
+}
+
+// handleHealthz answers liveness probes for the whole server
+func (s *WorldServer) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeHealthStatus(w, r, http.StatusOK, HealthStatus{Status: "ok"})
+}
+
+// handleReadyz answers readiness probes: ready once the saved worlds are restored and
+// running, unavailable while they are being saved on shutdown
+func (s *WorldServer) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.closing.Load() {
+		writeHealthStatus(w, r, http.StatusServiceUnavailable, HealthStatus{Status: "stopping"})
+		return
+	}
+	if !s.running.Load() {
+		writeHealthStatus(w, r, http.StatusServiceUnavailable, HealthStatus{Status: "starting"})
+		return
+	}
+	writeHealthStatus(w, r, http.StatusOK, HealthStatus{Status: "ready"})
+}
+
+// serveWorld hands a request below /worlds/<name>/ to that world's interface
+func (s *WorldServer) serveWorld(w http.ResponseWriter, r *http.Request) {
+	name, _, inside := strings.Cut(strings.TrimPrefix(r.URL.Path, "/worlds/"), "/")
+	hosted, exists := s.World(name)
+	if !exists {
+		http.NotFound(w, r)
+		return
+	}
+	if !inside {
+		// Pages address the world's API relative to themselves, so they need the slash
+		http.Redirect(w, r, "/worlds/"+name+"/", http.StatusMovedPermanently)
+		return
+	}
+	hosted.routes.ServeHTTP(w, r)
+}
+
+// handleWorlds lists (GET), creates (POST) and deletes (DELETE ?name=) hosted worlds
+func (s *WorldServer) handleWorlds(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case HTTPMethodGET:
+	case http.MethodPost:
+		var request CreateWorldRequest
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+		hosted, err := s.CreateWorld(request)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(hosted.Summary())
+		return
+	case http.MethodDelete:
+		if err := s.DeleteWorld(r.URL.Query().Get("name")); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.Worlds())
+}
+
+// runServeCommand runs the world server until interrupted, saving every world on the way out
+func runServeCommand(args []string) error {
+	fs := newCommandFlags("serve")
+	port := fs.Int("port", 8080, "Port for the lobby and the worlds")
+	dataDir := fs.String("data", "worlds", "Directory keeping each world's settings and autosaves")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := applyEnvironment(fs, commandEnvPrefix(fs.Name())); err != nil {
+		return err
+	}
+
+	server, err := NewWorldServer(*dataDir)
+	if err != nil {
+		return err
+	}
+	return serveWorlds(server, *port, *dataDir)
+}
+
+// serveWorlds runs a world server on a port until interrupted, saving every world on the way out
+func serveWorlds(server *WorldServer, port int, dataDir string) error {
+	server.Start()
+	names := make([]string, 0)
+	for _, summary := range server.Worlds() {
+		names = append(names, fmt.Sprintf("%s (tick %d)", summary.Name, summary.Tick))
+	}
+	if len(names) > 0 {
+		log.Printf("Restored %d world(s): %s", len(names), strings.Join(names, ", "))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go server.autosaveLoop(ctx)
+
+	httpServer := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: server.Handler()}
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- httpServer.ListenAndServe() }()
+	log.Printf("World server lobby on http://localhost:%d (data in %s)", port, dataDir)
+	log.Printf("Press Ctrl+C to save every world and stop")
+
+	select {
+	case err := <-serveErr:
+		server.Close()
+		return err
+	case <-ctx.Done():
+	}
+	shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = httpServer.Shutdown(shutdown)
+	return server.Close()
+}
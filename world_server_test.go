@@ -0,0 +1,121 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testHostedWorld = `{"name": "islands", "autosave_interval": 5, "config": {"world": {"width": 60, "height": 60, "grid_width": 20, "grid_height": 20, "population_size": 5}, "speed": {"multiplier": 0.1}}}`
+
+func TestWorldServerHostsSeparateWorlds(t *testing.T) {
+	server, err := NewWorldServer(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create the server: %v", err)
+	}
+	defer server.Close()
+	handler := server.Handler().ServeHTTP
+	if code := callControlAPI(t, handler, http.MethodGet, "/readyz", "", nil); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected the server not ready before it starts, got %d", code)
+	}
+	server.Start()
+
+	var created HostedWorldSummary
+	if code := callControlAPI(t, handler, http.MethodPost, "/api/worlds", testHostedWorld, &created); code != http.StatusCreated {
+		t.Fatalf("Expected the world created, got %d", code)
+	}
+	if created.URL != "/worlds/islands/" || created.AutosaveInterval != 5 {
+		t.Errorf("Expected the world's address and autosave interval, got %+v", created)
+	}
+	callControlAPI(t, handler, http.MethodPost, "/api/worlds", `{"name": "plains"}`, nil)
+	for _, body := range []string{testHostedWorld, `{"name": "Bad Name"}`, `{"name": "x", "config": {"world": {"profile": "glacial"}}}`} {
+		if code := callControlAPI(t, handler, http.MethodPost, "/api/worlds", body, nil); code != http.StatusBadRequest {
+			t.Errorf("Expected %s refused, got %d", body, code)
+		}
+	}
+
+	var worlds []HostedWorldSummary
+	callControlAPI(t, handler, http.MethodGet, "/api/worlds", "", &worlds)
+	if len(worlds) != 2 || worlds[0].Name != "islands" || worlds[1].AutosaveInterval != defaultAutosaveInterval {
+		t.Fatalf("Expected both worlds listed by name, got %+v", worlds)
+	}
+
+	// Each world's own interface answers below its name
+	var islands, plains struct {
+		Entities int `json:"entities"`
+	}
+	callControlAPI(t, handler, http.MethodGet, "/worlds/islands/api/status", "", &islands)
+	callControlAPI(t, handler, http.MethodGet, "/worlds/plains/api/status", "", &plains)
+	if islands.Entities == 0 || islands.Entities == plains.Entities {
+		t.Errorf("Expected each world's own creatures, got %d and %d", islands.Entities, plains.Entities)
+	}
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/worlds/islands", nil))
+	if rec.Code != http.StatusMovedPermanently || rec.Header().Get("Location") != "/worlds/islands/" {
+		t.Errorf("Expected a redirect to the world's page, got %d", rec.Code)
+	}
+	if code := callControlAPI(t, handler, http.MethodGet, "/worlds/nowhere/api/status", "", nil); code != http.StatusNotFound {
+		t.Errorf("Expected an unknown world not found, got %d", code)
+	}
+
+	// Probes answer for the whole server and for each world
+	var health HealthStatus
+	for _, path := range []string{"/healthz", "/readyz", "/worlds/islands/readyz"} {
+		if code := callControlAPI(t, handler, http.MethodGet, path, "", &health); code != http.StatusOK {
+			t.Errorf("Expected %s to pass, got %d %+v", path, code, health)
+		}
+	}
+
+	callControlAPI(t, handler, http.MethodDelete, "/api/worlds?name=plains", "", &worlds)
+	if len(worlds) != 1 {
+		t.Errorf("Expected the deleted world gone, got %+v", worlds)
+	}
+	if _, err := os.Stat(filepath.Join(server.dataDir, "plains")); !os.IsNotExist(err) {
+		t.Errorf("Expected the deleted world's files removed")
+	}
+}
+
+func TestWorldServerAutosavesAndRestores(t *testing.T) {
+	dataDir := t.TempDir()
+	server, err := NewWorldServer(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	callControlAPI(t, server.Handler().ServeHTTP, http.MethodPost, "/api/worlds", testHostedWorld, nil)
+	hosted, _ := server.World("islands")
+
+	hosted.wi.tickMutex.Lock()
+	hosted.wi.world.SetPaused(true)
+	for hosted.wi.world.Tick < 5 {
+		hosted.wi.world.SetPaused(false)
+		hosted.wi.world.Update()
+		hosted.wi.world.SetPaused(true)
+	}
+	hosted.wi.tickMutex.Unlock()
+	server.Autosave()
+	if summary := hosted.Summary(); summary.LastSavedTick < 5 || summary.LastSaved == nil {
+		t.Fatalf("Expected the world autosaved after 5 ticks, got %+v", summary)
+	}
+
+	if err := server.Close(); err != nil {
+		t.Fatalf("Failed to save on shutdown: %v", err)
+	}
+	savedTick := hosted.Summary().Tick
+
+	restarted, err := NewWorldServer(dataDir)
+	if err != nil {
+		t.Fatalf("Failed to restart the server: %v", err)
+	}
+	restored, exists := restarted.World("islands")
+	if !exists {
+		t.Fatalf("Expected the world back after a restart")
+	}
+	if summary := restored.Summary(); summary.Tick != savedTick || summary.AutosaveInterval != 5 || summary.Entities == 0 {
+		t.Errorf("Expected the world restored at tick %d with its settings, got %+v", savedTick, summary)
+	}
+	if restored.wi.world.Config.GridWidth != 20 {
+		t.Errorf("Expected the world's run config kept")
+	}
+}
@@ -117,6 +117,7 @@ func TestWorldUpdate(t *testing.T) {
 		PopulationSize: 3,
 		GridWidth:      20,
 		GridHeight:     15,
+		Seed:           150,
 	}
 
 	// Use custom simulation config that ensures energy decreases
@@ -265,8 +266,8 @@ func TestEntityInteractions(t *testing.T) {
 	pos1 := Position{X: 0, Y: 0}
 	pos2 := Position{X: 3, Y: 4}
 
-	entity1 := NewEntity(1, []string{"strength"}, "herbivore", pos1)
-	entity2 := NewEntity(2, []string{"strength"}, "herbivore", pos2)
+	entity1 := NewEntity(sharedRand, 1, []string{"strength"}, "herbivore", pos1)
+	entity2 := NewEntity(sharedRand, 2, []string{"strength"}, "herbivore", pos2)
 
 	distance := entity1.DistanceTo(entity2)
 	expectedDistance := 5.0 // 3-4-5 triangle
@@ -278,12 +279,12 @@ func TestEntityInteractions(t *testing.T) {
 
 func TestEntityMovement(t *testing.T) {
 	pos := Position{X: 0, Y: 0}
-	entity := NewEntity(1, []string{"strength"}, "herbivore", pos)
+	entity := NewEntity(sharedRand, 1, []string{"strength"}, "herbivore", pos)
 
 	initialEnergy := entity.Energy
 
 	// Move to position (3, 4)
-	entity.MoveTo(3, 4, 1.0)
+	entity.MoveTo(3, 4, 1.0, BoxGeometry{})
 
 	// Check that entity moved
 	if entity.Position.X == 0 && entity.Position.Y == 0 {
@@ -302,25 +303,25 @@ func TestEntityCombat(t *testing.T) {
 	pos2 := Position{X: 1, Y: 1}
 
 	// Create a strong predator
-	predator := NewEntity(1, []string{"aggression", "strength", "size"}, "predator", pos1)
+	predator := NewEntity(sharedRand, 1, []string{"aggression", "strength", "size"}, "predator", pos1)
 	predator.SetTrait("aggression", 0.9)
 	predator.SetTrait("strength", 0.8)
 	predator.SetTrait("size", 0.7)
 
 	// Create a weak prey
-	prey := NewEntity(2, []string{"defense", "strength", "size"}, "herbivore", pos2)
+	prey := NewEntity(sharedRand, 2, []string{"defense", "strength", "size"}, "herbivore", pos2)
 	prey.SetTrait("defense", 0.1)
 	prey.SetTrait("strength", 0.2)
 	prey.SetTrait("size", -0.5)
 
 	// Predator should be able to kill prey
-	canKill := predator.CanKill(prey)
+	canKill := predator.CanKill(sharedRand, prey)
 	if !canKill {
 		t.Errorf("Expected predator to be able to kill prey")
 	}
 
 	// Perform the kill
-	success := predator.Kill(prey)
+	success := predator.Kill(sharedRand, prey)
 	if !success {
 		t.Errorf("Expected kill to succeed")
 	}
@@ -342,12 +343,12 @@ func TestEntityMerging(t *testing.T) {
 	pos2 := Position{X: 1, Y: 1}
 
 	// Create two compatible entities of same species
-	entity1 := NewEntity(1, []string{"intelligence", "cooperation"}, "herbivore", pos1)
+	entity1 := NewEntity(sharedRand, 1, []string{"intelligence", "cooperation"}, "herbivore", pos1)
 	entity1.SetTrait("intelligence", 0.6)
 	entity1.SetTrait("cooperation", 0.5)
 	entity1.Energy = 60
 
-	entity2 := NewEntity(2, []string{"intelligence", "cooperation"}, "herbivore", pos2)
+	entity2 := NewEntity(sharedRand, 2, []string{"intelligence", "cooperation"}, "herbivore", pos2)
 	entity2.SetTrait("intelligence", 0.7)
 	entity2.SetTrait("cooperation", 0.4)
 	entity2.Energy = 60
@@ -359,7 +360,7 @@ func TestEntityMerging(t *testing.T) {
 	}
 
 	// Perform the merge
-	merged := entity1.Merge(entity2, 999)
+	merged := entity1.Merge(sharedRand, entity2, 999)
 	if merged == nil {
 		t.Errorf("Expected merge to produce a new entity")
 		return
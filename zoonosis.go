@@ -0,0 +1,284 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Zoonosis parameters
+const (
+	zoonosisInterval   = 10   // Ticks between spillover checks
+	denseColonySize    = 5    // Members a tribe needs before it is crowded enough for pathogens to take hold
+	contactRadius      = 10.0 // Distance at which colonists and wildlife share hunting grounds
+	livestockRadius    = 8.0  // Distance from a farm at which wildlife grazes as the tribe's livestock
+	livestockContact   = 3.0  // Contact weight of an animal kept at a farm
+	huntContact        = 5.0  // Contact weight of butchering a kill
+	spilloverChance    = 0.02 // Chance per contact that a fully transmissible pathogen crosses over
+	maxDensityFactor   = 2.0  // Most that crowding multiplies the spillover chance by
+	maxSpilloverRecord = 50   // Spillover events kept for reports
+)
+
+// Spillover directions
+const (
+	SpilloverToColony   = "wildlife_to_colony"
+	SpilloverToWildlife = "colony_to_wildlife"
+)
+
+// SpilloverEvent is a pathogen crossing between wildlife and a colony
+type SpilloverEvent struct {
+	Tick        int     `json:"tick"`
+	Direction   string  `json:"direction"`
+	TribeID     int     `json:"tribe_id"`
+	TribeName   string  `json:"tribe_name"`
+	Route       string  `json:"route"` // "contact", "livestock" or "hunting"
+	FromID      int     `json:"from_id"`
+	FromSpecies string  `json:"from_species"`
+	ToID        int     `json:"to_id"`
+	ToSpecies   string  `json:"to_species"`
+	Contacts    float64 `json:"contacts"`
+	Probability float64 `json:"probability"`
+}
+
+// ColonyContact is how much one tribe mixes with wildlife and what has crossed between them
+type ColonyContact struct {
+	TribeID       int     `json:"tribe_id"`
+	Name          string  `json:"name"`
+	Members       int     `json:"members"`
+	Contacts      float64 `json:"contacts"`       // Weighted contacts with infected wildlife at the last check
+	HuntContacts  int     `json:"hunt_contacts"`  // Infected kills butchered
+	SpilloversIn  int     `json:"spillovers_in"`  // Pathogens caught from wildlife
+	SpilloversOut int     `json:"spillovers_out"` // Pathogens passed to wildlife
+}
+
+// ZoonosisReport lists every colony's contact with wildlife and the recent spillovers
+type ZoonosisReport struct {
+	Tick           int              `json:"tick"`
+	InfectedWild   int              `json:"infected_wild"`
+	InfectedColony int              `json:"infected_colony"`
+	Colonies       []ColonyContact  `json:"colonies"`
+	Spillovers     []SpilloverEvent `json:"spillovers"`
+}
+
+// zoonoticExposure is one contact that could carry a pathogen across
+type zoonoticExposure struct {
+	source       *Entity
+	target       *Entity
+	relationship *SymbioticRelationship
+	route        string
+	weight       float64
+}
+
+// ZoonosisSystem lets the pathogens of parasitic relationships spill over between wildlife
+// and dense colonies. The more colonists hunt, keep animals and share ground with infected
+// wildlife, the likelier a crossing.
+type ZoonosisSystem struct {
+	colonies   map[int]*ColonyContact
+	hunts      map[int][]zoonoticExposure // Infected kills butchered since the last check, by tribe
+	Spillovers []SpilloverEvent
+}
+
+// NewZoonosisSystem creates a system with no spillovers yet
+func NewZoonosisSystem() *ZoonosisSystem {
+	return &ZoonosisSystem{
+		colonies:   make(map[int]*ColonyContact),
+		hunts:      make(map[int][]zoonoticExposure),
+		Spillovers: make([]SpilloverEvent, 0),
+	}
+}
+
+// infections maps each infected host to its pathogen
+func (w *World) infections() map[int]*SymbioticRelationship {
+	infected := make(map[int]*SymbioticRelationship)
+	if w.SymbioticRelationships == nil {
+		return infected
+	}
+	for _, relationship := range w.SymbioticRelationships.Relationships {
+		if relationship.IsActive && relationship.Type == RelationshipParasitic && relationship.Transmission > 0 {
+			infected[relationship.HostID] = relationship
+		}
+	}
+	return infected
+}
+
+// RecordHunt notes a colonist butchering an infected wild kill, the closest contact of all
+func (zs *ZoonosisSystem) RecordHunt(w *World, hunter, prey *Entity) {
+	if hunter.TribeID == 0 || prey.TribeID != 0 {
+		return
+	}
+	if relationship := w.infections()[prey.ID]; relationship != nil {
+		zs.hunts[hunter.TribeID] = append(zs.hunts[hunter.TribeID], zoonoticExposure{
+			source: prey, target: hunter, relationship: relationship, route: "hunting", weight: huntContact,
+		})
+	}
+}
+
+// Update weighs every dense colony's contacts with wildlife every zoonosisInterval ticks and
+// lets pathogens cross in either direction
+func (zs *ZoonosisSystem) Update(w *World) {
+	if w.Tick%zoonosisInterval != 0 || w.CivilizationSystem == nil || w.SymbioticRelationships == nil {
+		return
+	}
+	hunts := zs.hunts
+	zs.hunts = make(map[int][]zoonoticExposure)
+	infected := w.infections()
+
+	for _, tribe := range w.CivilizationSystem.Tribes {
+		if len(tribe.Members) < denseColonySize {
+			continue
+		}
+		record := zs.colonies[tribe.ID]
+		if record == nil {
+			record = &ColonyContact{TribeID: tribe.ID}
+			zs.colonies[tribe.ID] = record
+		}
+		record.Name, record.Members = tribe.Name, len(tribe.Members)
+		density := math.Min(maxDensityFactor, float64(len(tribe.Members))/denseColonySize)
+
+		inward, outward := w.colonyExposures(tribe, infected)
+		inward = append(inward, hunts[tribe.ID]...)
+		record.HuntContacts += len(hunts[tribe.ID])
+		record.Contacts = 0
+		for _, exposure := range inward {
+			record.Contacts += exposure.weight
+		}
+
+		if zs.spill(w, tribe, SpilloverToColony, inward, density, infected) {
+			record.SpilloversIn++
+		}
+		if zs.spill(w, tribe, SpilloverToWildlife, outward, density, infected) {
+			record.SpilloversOut++
+		}
+	}
+}
+
+// colonyExposures gathers the contacts through which a pathogen could reach a tribe from
+// wildlife, and through which the tribe's own infections could reach wildlife
+func (w *World) colonyExposures(tribe *Tribe, infected map[int]*SymbioticRelationship) (inward, outward []zoonoticExposure) {
+	farms := make([]Position, 0)
+	for _, structure := range tribe.Structures {
+		if structure.Type == StructureFarm && structure.Health > 0 {
+			farms = append(farms, structure.Position)
+		}
+	}
+	for _, animal := range w.AllEntities {
+		if !animal.IsAlive || animal.TribeID != 0 {
+			continue
+		}
+		// Animals grazing at the tribe's farms are handled daily, like livestock
+		route, weight := "contact", 1.0
+		for _, farm := range farms {
+			if w.Distance(animal.Position, farm) <= livestockRadius {
+				route, weight = "livestock", livestockContact
+				break
+			}
+		}
+		for _, member := range tribe.Members {
+			if !member.IsAlive || (route == "contact" &&
+				w.Distance(animal.Position, member.Position) > contactRadius) {
+				continue
+			}
+			if relationship := infected[animal.ID]; relationship != nil && infected[member.ID] == nil {
+				inward = append(inward, zoonoticExposure{animal, member, relationship, route, weight})
+			}
+			if relationship := infected[member.ID]; relationship != nil && infected[animal.ID] == nil {
+				outward = append(outward, zoonoticExposure{member, animal, relationship, route, weight})
+			}
+		}
+	}
+	return inward, outward
+}
+
+// spill decides whether a pathogen crosses through a set of contacts and, if one does,
+// infects the target of the heaviest contact. The chance of crossing grows with every
+// contact, weighted by how close it is and how transmissible the pathogen, and with crowding.
+func (zs *ZoonosisSystem) spill(w *World, tribe *Tribe, direction string, exposures []zoonoticExposure, density float64, infected map[int]*SymbioticRelationship) bool {
+	escape := 1.0
+	var heaviest *zoonoticExposure
+	contacts := 0.0
+	for i := range exposures {
+		exposure := &exposures[i]
+		if !exposure.target.IsAlive || infected[exposure.target.ID] != nil {
+			continue
+		}
+		perContact := math.Min(1, spilloverChance*exposure.relationship.Transmission*density)
+		escape *= math.Pow(1-perContact, exposure.weight)
+		contacts += exposure.weight
+		if heaviest == nil || exposure.weight > heaviest.weight {
+			heaviest = exposure
+		}
+	}
+	if heaviest == nil {
+		return false
+	}
+	probability := 1 - escape
+	if w.Rand.Float64() >= probability {
+		return false
+	}
+
+	w.SymbioticRelationships.createTransmittedRelationship(heaviest.target, heaviest.relationship, w.Tick)
+	infected[heaviest.target.ID] = heaviest.relationship
+	event := SpilloverEvent{
+		Tick: w.Tick, Direction: direction, TribeID: tribe.ID, TribeName: tribe.Name, Route: heaviest.route,
+		FromID: heaviest.source.ID, FromSpecies: heaviest.source.Species,
+		ToID: heaviest.target.ID, ToSpecies: heaviest.target.Species,
+		Contacts: contacts, Probability: probability,
+	}
+	zs.Spillovers = append(zs.Spillovers, event)
+	if len(zs.Spillovers) > maxSpilloverRecord {
+		zs.Spillovers = zs.Spillovers[1:]
+	}
+	w.logSpillover(event)
+	return true
+}
+
+// logSpillover reports a spillover on the event bus as a major event
+func (w *World) logSpillover(event SpilloverEvent) {
+	if w.CentralEventBus == nil {
+		return
+	}
+	var description string
+	if event.Direction == SpilloverToColony {
+		description = fmt.Sprintf("A pathogen has spilled over from %s into tribe %s through %s", event.FromSpecies, event.TribeName, event.Route)
+	} else {
+		description = fmt.Sprintf("A pathogen has spilled over from tribe %s into wild %s through %s", event.TribeName, event.ToSpecies, event.Route)
+	}
+	w.CentralEventBus.EmitSystemEvent(w.Tick, "zoonotic_spillover", event.Direction, "zoonosis", description, nil, map[string]interface{}{
+		"tribe_id":     event.TribeID,
+		"route":        event.Route,
+		"from_species": event.FromSpecies,
+		"to_species":   event.ToSpecies,
+		"probability":  event.Probability,
+	})
+}
+
+// Report lists the dense colonies' contact with wildlife, how many wild and colony hosts
+// are infected, and the spillovers so far
+func (zs *ZoonosisSystem) Report(w *World) ZoonosisReport {
+	report := ZoonosisReport{
+		Tick:       w.Tick,
+		Colonies:   make([]ColonyContact, 0),
+		Spillovers: append([]SpilloverEvent{}, zs.Spillovers...),
+	}
+	infected := w.infections()
+	for _, entity := range w.AllEntities {
+		if !entity.IsAlive || infected[entity.ID] == nil {
+			continue
+		}
+		if entity.TribeID != 0 {
+			report.InfectedColony++
+		} else {
+			report.InfectedWild++
+		}
+	}
+	for _, id := range sortedKeys(zs.colonies) {
+		report.Colonies = append(report.Colonies, *zs.colonies[id])
+	}
+	return report
+}
+
+// Clear forgets every colony's contacts and past spillovers, as when a new world is loaded
+func (zs *ZoonosisSystem) Clear() {
+	zs.colonies = make(map[int]*ColonyContact)
+	zs.hunts = make(map[int][]zoonoticExposure)
+	zs.Spillovers = make([]SpilloverEvent, 0)
+}
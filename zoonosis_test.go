@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newZoonosisTestWorld makes an empty 100x100 world with a tribe of the given size camped
+// at (50, 50)
+func newZoonosisTestWorld(members int) (*World, *Tribe) {
+	world := newEmptyTestWorld(100)
+	return world, addTestTribe(world, "Marrowfen", members, Position{X: 50, Y: 50})
+}
+
+// infect gives a host a pathogen of the given transmissibility
+func infect(world *World, host *Entity, transmission float64) {
+	srs := world.SymbioticRelationships
+	srs.Relationships = append(srs.Relationships, &SymbioticRelationship{
+		ID: srs.NextRelationshipID, HostID: host.ID, SymbiontID: 999, Type: RelationshipParasitic,
+		Strength: 0.5, Virulence: 0.3, Transmission: transmission, IsActive: true,
+	})
+	srs.NextRelationshipID++
+}
+
+// runZoonoses advances the zoonosis system by a number of checks
+func runZoonoses(world *World, checks int) {
+	runSystem(world, zoonosisInterval, checks, world.Zoonoses.Update)
+}
+
+func TestPathogensSpillIntoDenseColonies(t *testing.T) {
+	world, _ := newZoonosisTestWorld(denseColonySize + 1)
+	deer := NewEntity(sharedRand, 100, []string{"speed"}, "Deer", Position{X: 55, Y: 50})
+	world.AllEntities = append(world.AllEntities, deer)
+	infect(world, deer, 1)
+	runZoonoses(world, 100)
+
+	report := world.Zoonoses.Report(world)
+	if len(report.Colonies) != 1 || report.Colonies[0].SpilloversIn == 0 || report.InfectedColony == 0 {
+		t.Fatalf("Expected a pathogen to spill from the deer into the colony, got %+v", report)
+	}
+	spillover := report.Spillovers[0]
+	if spillover.Direction != SpilloverToColony || spillover.FromSpecies != "Deer" || spillover.ToSpecies != "Folk" || spillover.Route != "contact" {
+		t.Errorf("Expected a contact spillover from deer to the tribe, got %+v", spillover)
+	}
+	events := world.CentralEventBus.GetEventsByType("zoonotic_spillover")
+	if len(events) == 0 || events[0].Severity != SeverityHigh {
+		t.Errorf("Expected the spillover logged as a major event, got %+v", events)
+	}
+
+	sparse, _ := newZoonosisTestWorld(denseColonySize - 1)
+	stray := NewEntity(sharedRand, 100, []string{"speed"}, "Deer", Position{X: 55, Y: 50})
+	sparse.AllEntities = append(sparse.AllEntities, stray)
+	infect(sparse, stray, 1)
+	runZoonoses(sparse, 100)
+	if report := sparse.Zoonoses.Report(sparse); report.InfectedColony != 0 || len(report.Spillovers) != 0 {
+		t.Errorf("Expected a small band to escape spillover, got %+v", report)
+	}
+}
+
+func TestHuntingAndLivestockRaiseContact(t *testing.T) {
+	world, tribe := newZoonosisTestWorld(denseColonySize)
+	tribe.Structures = append(tribe.Structures, NewStructure(1, StructureFarm, Position{X: 80, Y: 80}, tribe.Leader))
+	grazing := NewEntity(sharedRand, 100, []string{"speed"}, "Goat", Position{X: 82, Y: 80})
+	distant := NewEntity(sharedRand, 101, []string{"speed"}, "Goat", Position{X: 10, Y: 10})
+	world.AllEntities = append(world.AllEntities, grazing, distant)
+	infect(world, grazing, 1e-9)
+	infect(world, distant, 1e-9)
+
+	world.Zoonoses.RecordHunt(world, tribe.Leader, distant)
+	world.Zoonoses.RecordHunt(world, tribe.Leader, NewEntity(sharedRand, 102, []string{"speed"}, "Goat", Position{}))
+	runZoonoses(world, 1)
+
+	colony := world.Zoonoses.Report(world).Colonies[0]
+	if colony.HuntContacts != 1 {
+		t.Errorf("Expected only the infected kill counted as a hunting contact, got %d", colony.HuntContacts)
+	}
+	want := livestockContact*denseColonySize + huntContact
+	if colony.Contacts != want {
+		t.Errorf("Expected contacts from the farm's livestock and the hunt, got %.1f against %.1f", colony.Contacts, want)
+	}
+}
+
+func TestPathogensSpillFromColoniesIntoWildlife(t *testing.T) {
+	world, tribe := newZoonosisTestWorld(denseColonySize + 1)
+	deer := NewEntity(sharedRand, 100, []string{"speed"}, "Deer", Position{X: 55, Y: 50})
+	world.AllEntities = append(world.AllEntities, deer)
+	infect(world, tribe.Leader, 1)
+	runZoonoses(world, 100)
+
+	if world.infections()[deer.ID] == nil || world.Zoonoses.Report(world).Colonies[0].SpilloversOut == 0 {
+		t.Fatalf("Expected the colony's pathogen to spill into the deer")
+	}
+
+	wi := NewWebInterface(world)
+	rec := httptest.NewRecorder()
+	wi.handleZoonoses(rec, httptest.NewRequest(http.MethodGet, "/api/zoonoses", nil))
+	var report ZoonosisReport
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &report) != nil || len(report.Spillovers) == 0 {
+		t.Fatalf("Expected a zoonosis report with the spillover, got %d %s", rec.Code, rec.Body.String())
+	}
+	if report.Spillovers[0].Direction != SpilloverToWildlife || report.InfectedWild != 1 {
+		t.Errorf("Expected a spillover into wildlife reported, got %+v", report)
+	}
+}